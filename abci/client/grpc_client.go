@@ -1,9 +1,11 @@
 package abcicli
 
 import (
+	"container/list"
+	"errors"
 	"fmt"
 	"net"
-	"sync"
+	"reflect"
 	"time"
 
 	"golang.org/x/net/context"
@@ -17,33 +19,85 @@ import (
 
 var _ Client = (*grpcClient)(nil)
 
-// A stripped copy of the remoteClient that makes
-// synchronous calls using grpc
+// GRPCConn is a gRPC connection to an ABCI server, shared by the gRPC clients
+// created from it: each client opens a stream of its own over the connection,
+// multiplexed by HTTP/2. The connection is dialed when the first client starts,
+// and closed when the last one stops.
+type GRPCConn struct {
+	addr string
+
+	mtx  cmtsync.Mutex
+	conn *grpc.ClientConn
+	refs int
+}
+
+// NewGRPCConn returns a connection to the gRPC ABCI server at addr.
+func NewGRPCConn(addr string) *GRPCConn {
+	return &GRPCConn{addr: addr}
+}
+
+func (c *GRPCConn) acquire() (*grpc.ClientConn, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.conn == nil {
+		//nolint:staticcheck // SA1019 Existing use of deprecated but supported dial option.
+		conn, err := grpc.Dial(c.addr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+		if err != nil {
+			return nil, err
+		}
+		c.conn = conn
+	}
+	c.refs++
+	return c.conn, nil
+}
+
+func (c *GRPCConn) release() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.refs--
+	if c.refs == 0 {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// grpcClient sends the requests on a gRPC stream, pipelined as the socket
+// client does: the requests are queued, sent in order, and matched with the
+// responses received in the same order. The bounded queue, and the HTTP/2 flow
+// control of the stream, push back on the callers when the application falls
+// behind.
 type grpcClient struct {
 	service.BaseService
 	mustConnect bool
 
-	client   types.ABCIApplicationClient
-	conn     *grpc.ClientConn
-	chReqRes chan *ReqRes // dispatches "async" responses to callbacks *in order*, needed by mempool
+	conn     *GRPCConn
+	stream   types.ABCIApplication_StreamClient
+	cancel   context.CancelFunc
+	reqQueue chan *ReqRes
 
-	mtx   cmtsync.Mutex
-	addr  string
-	err   error
-	resCb func(*types.Request, *types.Response) // listens to all callbacks
+	mtx     cmtsync.Mutex
+	err     error
+	reqSent *list.List                            // list of requests sent, waiting for response
+	resCb   func(*types.Request, *types.Response) // listens to all callbacks
 }
 
+// NewGRPCClient creates a new gRPC client, with a connection of its own to the
+// given address. If mustConnect is true, the client will return an error upon
+// start if it fails to connect.
 func NewGRPCClient(addr string, mustConnect bool) Client {
+	return NewGRPCClientFromConn(NewGRPCConn(addr), mustConnect)
+}
+
+// NewGRPCClientFromConn creates a new gRPC client, which streams its requests
+// over conn, possibly shared with other clients.
+func NewGRPCClientFromConn(conn *GRPCConn, mustConnect bool) Client {
 	cli := &grpcClient{
-		addr:        addr,
+		conn:        conn,
 		mustConnect: mustConnect,
-		// Buffering the channel is needed to make calls appear asynchronous,
-		// which is required when the caller makes multiple async calls before
-		// processing callbacks (e.g. due to holding locks). 64 means that a
-		// caller can make up to 64 async calls before a callback must be
-		// processed (otherwise it deadlocks). It also means that we can make 64
-		// gRPC calls while processing a slow callback at the channel head.
-		chReqRes: make(chan *ReqRes, 64),
+		reqQueue:    make(chan *ReqRes, reqQueueSize),
+		reqSent:     list.New(),
 	}
 	cli.BaseService = *service.NewBaseService(nil, "grpcClient", cli)
 	return cli
@@ -58,49 +112,19 @@ func (cli *grpcClient) OnStart() error {
 		return err
 	}
 
-	// This processes asynchronous request/response messages and dispatches
-	// them to callbacks.
-	go func() {
-		// Use a separate function to use defer for mutex unlocks (this handles panics)
-		callCb := func(reqres *ReqRes) {
-			cli.mtx.Lock()
-			defer cli.mtx.Unlock()
-
-			reqres.Done()
-
-			// Notify client listener if set
-			if cli.resCb != nil {
-				cli.resCb(reqres.Request, reqres.Response)
-			}
-
-			// Notify reqRes listener if set
-			reqres.InvokeCallback()
-		}
-		for reqres := range cli.chReqRes {
-			if reqres != nil {
-				callCb(reqres)
-			} else {
-				cli.Logger.Error("Received nil reqres")
-			}
-		}
-	}()
-
-RETRY_LOOP:
 	for {
-		//nolint:staticcheck // SA1019 Existing use of deprecated but supported dial option.
-		conn, err := grpc.Dial(cli.addr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+		conn, err := cli.conn.acquire()
 		if err != nil {
 			if cli.mustConnect {
 				return err
 			}
-			cli.Logger.Error(fmt.Sprintf("abci.grpcClient failed to connect to %v.  Retrying...\n", cli.addr), "err", err)
+			cli.Logger.Error(fmt.Sprintf("abci.grpcClient failed to connect to %v.  Retrying...\n", cli.conn.addr), "err", err)
 			time.Sleep(time.Second * dialRetryIntervalSeconds)
-			continue RETRY_LOOP
+			continue
 		}
 
-		cli.Logger.Info("Dialed server. Waiting for echo.", "addr", cli.addr)
+		cli.Logger.Info("Dialed server. Waiting for echo.", "addr", cli.conn.addr)
 		client := types.NewABCIApplicationClient(conn)
-		cli.conn = conn
 
 	ENSURE_CONNECTED:
 		for {
@@ -112,18 +136,32 @@ RETRY_LOOP:
 			time.Sleep(time.Second * echoRetryIntervalSeconds)
 		}
 
-		cli.client = client
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := client.Stream(ctx, grpc.WaitForReady(true))
+		if err != nil {
+			cancel()
+			cli.conn.release()
+			return fmt.Errorf("opening the ABCI stream: %w", err)
+		}
+		cli.stream, cli.cancel = stream, cancel
+
+		go cli.sendRequestsRoutine()
+		go cli.recvResponseRoutine()
+
 		return nil
 	}
 }
 
+// OnStop implements Service by closing the stream, releasing the connection
+// and flushing all queues.
 func (cli *grpcClient) OnStop() {
 	cli.BaseService.OnStop()
 
-	if cli.conn != nil {
-		cli.conn.Close()
+	if cli.cancel != nil {
+		cli.cancel()
+		cli.conn.release()
 	}
-	close(cli.chReqRes)
+	cli.flushQueue()
 }
 
 func (cli *grpcClient) StopForError(err error) {
@@ -158,193 +196,184 @@ func (cli *grpcClient) SetResponseCallback(resCb Callback) {
 }
 
 //----------------------------------------
-// GRPC calls are synchronous, but some callbacks expect to be called asynchronously
-// (eg. the mempool expects to be able to lock to remove bad txs from cache).
-// To accommodate, we finish each call in its own go-routine,
-// which is expensive, but easy - if you want something better, use the socket protocol!
-// maybe one day, if people really want it, we use grpc streams,
-// but hopefully not :D
 
-func (cli *grpcClient) EchoAsync(msg string) *ReqRes {
-	req := types.ToRequestEcho(msg)
-	res, err := cli.client.Echo(context.Background(), req.GetEcho(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
+func (cli *grpcClient) sendRequestsRoutine() {
+	for {
+		select {
+		case reqres := <-cli.reqQueue:
+			cli.willSendReq(reqres)
+			if err := cli.stream.Send(reqres.Request); err != nil {
+				cli.StopForError(fmt.Errorf("send request: %w", err))
+				return
+			}
+		case <-cli.Quit():
+			return
+		}
 	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_Echo{Echo: res}})
 }
 
-func (cli *grpcClient) FlushAsync() *ReqRes {
-	req := types.ToRequestFlush()
-	res, err := cli.client.Flush(context.Background(), req.GetFlush(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
+func (cli *grpcClient) recvResponseRoutine() {
+	for {
+		res, err := cli.stream.Recv()
+		if err != nil {
+			cli.StopForError(fmt.Errorf("receive response: %w", err))
+			return
+		}
+
+		switch r := res.Value.(type) {
+		case *types.Response_Exception: // app responded with error
+			cli.StopForError(errors.New(r.Exception.Error))
+			return
+		default:
+			if err := cli.didRecvResponse(res); err != nil {
+				cli.StopForError(err)
+				return
+			}
+		}
 	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_Flush{Flush: res}})
 }
 
-func (cli *grpcClient) InfoAsync(params types.RequestInfo) *ReqRes {
-	req := types.ToRequestInfo(params)
-	res, err := cli.client.Info(context.Background(), req.GetInfo(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
+func (cli *grpcClient) willSendReq(reqres *ReqRes) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	cli.reqSent.PushBack(reqres)
+}
+
+func (cli *grpcClient) didRecvResponse(res *types.Response) error {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+
+	// Get the first ReqRes.
+	next := cli.reqSent.Front()
+	if next == nil {
+		return fmt.Errorf("unexpected %v when nothing expected", reflect.TypeOf(res.Value))
+	}
+
+	reqres := next.Value.(*ReqRes)
+	if !resMatchesReq(reqres.Request, res) {
+		return fmt.Errorf("unexpected %v when response to %v expected",
+			reflect.TypeOf(res.Value), reflect.TypeOf(reqres.Request.Value))
+	}
+
+	reqres.Response = res
+	reqres.Done()            // release waiters
+	cli.reqSent.Remove(next) // pop first item from linked list
+
+	// Notify client listener if set (global callback).
+	if cli.resCb != nil {
+		cli.resCb(reqres.Request, res)
 	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_Info{Info: res}})
+
+	// Notify reqRes listener if set (request specific callback).
+	reqres.InvokeCallback()
+
+	return nil
 }
 
-func (cli *grpcClient) DeliverTxAsync(params types.RequestDeliverTx) *ReqRes {
-	req := types.ToRequestDeliverTx(params)
-	res, err := cli.client.DeliverTx(context.Background(), req.GetDeliverTx(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
+func (cli *grpcClient) queueRequest(req *types.Request) *ReqRes {
+	reqres := NewReqRes(req)
+	cli.reqQueue <- reqres
+	return reqres
+}
+
+func (cli *grpcClient) flushQueue() {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+
+	// mark all in-flight messages as resolved (they will get cli.Error())
+	for req := cli.reqSent.Front(); req != nil; req = req.Next() {
+		reqres := req.Value.(*ReqRes)
+		reqres.Done()
+	}
+	cli.reqSent.Init()
+
+	// mark all queued messages as resolved
+LOOP:
+	for {
+		select {
+		case reqres := <-cli.reqQueue:
+			reqres.Done()
+		default:
+			break LOOP
+		}
 	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_DeliverTx{DeliverTx: res}})
+}
+
+// finishSyncCall waits for the response to a queued request, or for the client
+// to stop.
+func (cli *grpcClient) finishSyncCall(reqres *ReqRes) *types.Response {
+	reqres.Wait()
+	return reqres.Response
+}
+
+//----------------------------------------
+
+func (cli *grpcClient) EchoAsync(msg string) *ReqRes {
+	return cli.queueRequest(types.ToRequestEcho(msg))
+}
+
+func (cli *grpcClient) FlushAsync() *ReqRes {
+	return cli.queueRequest(types.ToRequestFlush())
+}
+
+func (cli *grpcClient) InfoAsync(params types.RequestInfo) *ReqRes {
+	return cli.queueRequest(types.ToRequestInfo(params))
+}
+
+func (cli *grpcClient) DeliverTxAsync(params types.RequestDeliverTx) *ReqRes {
+	return cli.queueRequest(types.ToRequestDeliverTx(params))
 }
 
 func (cli *grpcClient) CheckTxAsync(params types.RequestCheckTx) *ReqRes {
-	req := types.ToRequestCheckTx(params)
-	res, err := cli.client.CheckTx(context.Background(), req.GetCheckTx(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_CheckTx{CheckTx: res}})
+	return cli.queueRequest(types.ToRequestCheckTx(params))
 }
 
 func (cli *grpcClient) QueryAsync(params types.RequestQuery) *ReqRes {
-	req := types.ToRequestQuery(params)
-	res, err := cli.client.Query(context.Background(), req.GetQuery(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_Query{Query: res}})
+	return cli.queueRequest(types.ToRequestQuery(params))
 }
 
 func (cli *grpcClient) CommitAsync() *ReqRes {
-	req := types.ToRequestCommit()
-	res, err := cli.client.Commit(context.Background(), req.GetCommit(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_Commit{Commit: res}})
+	return cli.queueRequest(types.ToRequestCommit())
 }
 
 func (cli *grpcClient) InitChainAsync(params types.RequestInitChain) *ReqRes {
-	req := types.ToRequestInitChain(params)
-	res, err := cli.client.InitChain(context.Background(), req.GetInitChain(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_InitChain{InitChain: res}})
+	return cli.queueRequest(types.ToRequestInitChain(params))
 }
 
 func (cli *grpcClient) BeginBlockAsync(params types.RequestBeginBlock) *ReqRes {
-	req := types.ToRequestBeginBlock(params)
-	res, err := cli.client.BeginBlock(context.Background(), req.GetBeginBlock(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_BeginBlock{BeginBlock: res}})
+	return cli.queueRequest(types.ToRequestBeginBlock(params))
 }
 
 func (cli *grpcClient) EndBlockAsync(params types.RequestEndBlock) *ReqRes {
-	req := types.ToRequestEndBlock(params)
-	res, err := cli.client.EndBlock(context.Background(), req.GetEndBlock(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_EndBlock{EndBlock: res}})
+	return cli.queueRequest(types.ToRequestEndBlock(params))
 }
 
 func (cli *grpcClient) ListSnapshotsAsync(params types.RequestListSnapshots) *ReqRes {
-	req := types.ToRequestListSnapshots(params)
-	res, err := cli.client.ListSnapshots(context.Background(), req.GetListSnapshots(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_ListSnapshots{ListSnapshots: res}})
+	return cli.queueRequest(types.ToRequestListSnapshots(params))
 }
 
 func (cli *grpcClient) OfferSnapshotAsync(params types.RequestOfferSnapshot) *ReqRes {
-	req := types.ToRequestOfferSnapshot(params)
-	res, err := cli.client.OfferSnapshot(context.Background(), req.GetOfferSnapshot(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_OfferSnapshot{OfferSnapshot: res}})
+	return cli.queueRequest(types.ToRequestOfferSnapshot(params))
 }
 
 func (cli *grpcClient) LoadSnapshotChunkAsync(params types.RequestLoadSnapshotChunk) *ReqRes {
-	req := types.ToRequestLoadSnapshotChunk(params)
-	res, err := cli.client.LoadSnapshotChunk(context.Background(), req.GetLoadSnapshotChunk(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_LoadSnapshotChunk{LoadSnapshotChunk: res}})
+	return cli.queueRequest(types.ToRequestLoadSnapshotChunk(params))
 }
 
 func (cli *grpcClient) ApplySnapshotChunkAsync(params types.RequestApplySnapshotChunk) *ReqRes {
-	req := types.ToRequestApplySnapshotChunk(params)
-	res, err := cli.client.ApplySnapshotChunk(context.Background(), req.GetApplySnapshotChunk(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_ApplySnapshotChunk{ApplySnapshotChunk: res}})
+	return cli.queueRequest(types.ToRequestApplySnapshotChunk(params))
 }
 
 func (cli *grpcClient) PrepareProposalAsync(params types.RequestPrepareProposal) *ReqRes {
-	req := types.ToRequestPrepareProposal(params)
-	res, err := cli.client.PrepareProposal(context.Background(), req.GetPrepareProposal(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_PrepareProposal{PrepareProposal: res}})
+	return cli.queueRequest(types.ToRequestPrepareProposal(params))
 }
 
 func (cli *grpcClient) ProcessProposalAsync(params types.RequestProcessProposal) *ReqRes {
-	req := types.ToRequestProcessProposal(params)
-	res, err := cli.client.ProcessProposal(context.Background(), req.GetProcessProposal(), grpc.WaitForReady(true))
-	if err != nil {
-		cli.StopForError(err)
-	}
-
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_ProcessProposal{ProcessProposal: res}})
+	return cli.queueRequest(types.ToRequestProcessProposal(params))
 }
 
-// finishAsyncCall creates a ReqRes for an async call, and immediately populates it
-// with the response. We don't complete it until it's been ordered via the channel.
-func (cli *grpcClient) finishAsyncCall(req *types.Request, res *types.Response) *ReqRes {
-	reqres := NewReqRes(req)
-	reqres.Response = res
-	cli.chReqRes <- reqres // use channel for async responses, since they must be ordered
-	return reqres
-}
-
-// finishSyncCall waits for an async call to complete. It is necessary to call all
-// sync calls asynchronously as well, to maintain call and response ordering via
-// the channel, and this method will wait until the async call completes.
-func (cli *grpcClient) finishSyncCall(reqres *ReqRes) *types.Response {
-	// It's possible that the callback is called twice, since the callback can
-	// be called immediately on SetCallback() in addition to after it has been
-	// set. This is because completing the ReqRes happens in a separate critical
-	// section from the one where the callback is called: there is a race where
-	// SetCallback() is called between completing the ReqRes and dispatching the
-	// callback.
-	//
-	// We also buffer the channel with 1 response, since SetCallback() will be
-	// called synchronously if the reqres is already completed, in which case
-	// it will block on sending to the channel since it hasn't gotten around to
-	// receiving from it yet.
-	//
-	// ReqRes should really handle callback dispatch internally, to guarantee
-	// that it's only called once and avoid the above race conditions.
-	var once sync.Once
-	ch := make(chan *types.Response, 1)
-	reqres.SetCallback(func(res *types.Response) {
-		once.Do(func() {
-			ch <- res
-		})
-	})
-	return <-ch
+func (cli *grpcClient) FinalizeBlockAsync(params types.RequestFinalizeBlock) *ReqRes {
+	return cli.queueRequest(types.ToRequestFinalizeBlock(params))
 }
 
 //----------------------------------------
@@ -433,3 +462,8 @@ func (cli *grpcClient) ProcessProposalSync(params types.RequestProcessProposal)
 	reqres := cli.ProcessProposalAsync(params)
 	return cli.finishSyncCall(reqres).GetProcessProposal(), cli.Error()
 }
+
+func (cli *grpcClient) FinalizeBlockSync(params types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
+	reqres := cli.FinalizeBlockAsync(params)
+	return cli.finishSyncCall(reqres).GetFinalizeBlock(), cli.Error()
+}
@@ -64,7 +64,7 @@ func (app *localClient) InfoAsync(req types.RequestInfo) *ReqRes {
 	app.mtx.Lock()
 	defer app.mtx.Unlock()
 
-	res := app.Application.Info(req)
+	res := types.Info(app.Application, req)
 	return app.callback(
 		types.ToRequestInfo(req),
 		types.ToResponseInfo(res),
@@ -214,6 +214,17 @@ func (app *localClient) ProcessProposalAsync(req types.RequestProcessProposal) *
 	)
 }
 
+func (app *localClient) FinalizeBlockAsync(req types.RequestFinalizeBlock) *ReqRes {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := types.FinalizeBlock(app.Application, req)
+	return app.callback(
+		types.ToRequestFinalizeBlock(req),
+		types.ToResponseFinalizeBlock(res),
+	)
+}
+
 //-------------------------------------------------------
 
 func (app *localClient) FlushSync() error {
@@ -228,7 +239,7 @@ func (app *localClient) InfoSync(req types.RequestInfo) (*types.ResponseInfo, er
 	app.mtx.Lock()
 	defer app.mtx.Unlock()
 
-	res := app.Application.Info(req)
+	res := types.Info(app.Application, req)
 	return &res, nil
 }
 
@@ -340,6 +351,14 @@ func (app *localClient) ProcessProposalSync(req types.RequestProcessProposal) (*
 	return &res, nil
 }
 
+func (app *localClient) FinalizeBlockSync(req types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := types.FinalizeBlock(app.Application, req)
+	return &res, nil
+}
+
 //-------------------------------------------------------
 
 func (app *localClient) callback(req *types.Request, res *types.Response) *ReqRes {
@@ -283,6 +283,10 @@ func (cli *socketClient) ProcessProposalAsync(req types.RequestProcessProposal)
 	return cli.queueRequest(types.ToRequestProcessProposal(req))
 }
 
+func (cli *socketClient) FinalizeBlockAsync(req types.RequestFinalizeBlock) *ReqRes {
+	return cli.queueRequest(types.ToRequestFinalizeBlock(req))
+}
+
 //----------------------------------------
 
 func (cli *socketClient) FlushSync() error {
@@ -430,6 +434,15 @@ func (cli *socketClient) ProcessProposalSync(req types.RequestProcessProposal) (
 	return reqres.Response.GetProcessProposal(), cli.Error()
 }
 
+func (cli *socketClient) FinalizeBlockSync(req types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
+	reqres := cli.queueRequest(types.ToRequestFinalizeBlock(req))
+	if err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+
+	return reqres.Response.GetFinalizeBlock(), cli.Error()
+}
+
 //----------------------------------------
 
 func (cli *socketClient) queueRequest(req *types.Request) *ReqRes {
@@ -507,6 +520,8 @@ func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
 		_, ok = res.Value.(*types.Response_PrepareProposal)
 	case *types.Request_ProcessProposal:
 		_, ok = res.Value.(*types.Response_ProcessProposal)
+	case *types.Request_FinalizeBlock:
+		_, ok = res.Value.(*types.Response_FinalizeBlock)
 	}
 	return ok
 }
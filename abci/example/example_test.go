@@ -46,6 +46,11 @@ func TestGRPC(t *testing.T) {
 	testGRPCSync(t, types.NewGRPCApplication(types.NewBaseApplication()))
 }
 
+func TestGRPCStream(t *testing.T) {
+	fmt.Println("### Testing GRPC stream")
+	testGRPCStream(t, types.NewGRPCApplication(kvstore.NewApplication()))
+}
+
 func testStream(t *testing.T, app types.Application) {
 	numDeliverTxs := 20000
 	socketFile := fmt.Sprintf("test-%08x.sock", grand.Int31n(1<<30))
@@ -187,3 +192,62 @@ func testGRPCSync(t *testing.T, app types.ABCIApplicationServer) {
 
 	}
 }
+
+func testGRPCStream(t *testing.T, app types.ABCIApplicationServer) {
+	numDeliverTxs := 20000
+	socketFile := fmt.Sprintf("/tmp/test-%08x.sock", grand.Int31n(1<<30))
+	defer os.Remove(socketFile)
+	socket := fmt.Sprintf("unix://%v", socketFile)
+
+	// Start the listener
+	server := abciserver.NewGRPCServer(socket, app)
+	server.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	if err := server.Start(); err != nil {
+		t.Fatalf("Error starting GRPC server: %v", err.Error())
+	}
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// Connect two clients, each streaming over the same connection
+	conn := abcicli.NewGRPCConn(socket)
+	clients := make([]abcicli.Client, 2)
+	for i := range clients {
+		client := abcicli.NewGRPCClientFromConn(conn, true)
+		client.SetLogger(log.TestingLogger().With("module", "abci-client"))
+		if err := client.Start(); err != nil {
+			t.Fatalf("Error starting GRPC client: %v", err.Error())
+		}
+		t.Cleanup(func() {
+			if err := client.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		clients[i] = client
+	}
+	consensus, query := clients[0], clients[1]
+
+	// Pipeline the requests, and check that the responses arrive in order
+	counter := 0
+	consensus.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if r, ok := res.Value.(*types.Response_DeliverTx); ok {
+			require.Equal(t, code.CodeTypeOK, r.DeliverTx.Code)
+			require.Equal(t, fmt.Sprintf("key%d", counter), r.DeliverTx.Events[0].Attributes[1].Value)
+			counter++
+		}
+	})
+	for i := 0; i < numDeliverTxs; i++ {
+		tx := []byte(fmt.Sprintf("key%d=value%d", i, i))
+		consensus.DeliverTxAsync(types.RequestDeliverTx{Tx: tx})
+	}
+	require.NoError(t, consensus.FlushSync())
+	require.Equal(t, numDeliverTxs, counter)
+
+	_, err := consensus.CommitSync()
+	require.NoError(t, err)
+	res, err := query.QuerySync(types.RequestQuery{Data: []byte("key42")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value42"), res.Value)
+}
@@ -1,6 +1,7 @@
 package kvstore
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
 	"sort"
@@ -15,6 +16,8 @@ import (
 	"github.com/cometbft/cometbft/abci/example/code"
 	abciserver "github.com/cometbft/cometbft/abci/server"
 	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 )
 
@@ -202,6 +205,40 @@ func TestValUpdates(t *testing.T) {
 
 }
 
+func TestBN254ValUpdates(t *testing.T) {
+	kvstore := NewPersistentKVStoreApplication(t.TempDir())
+
+	privKey1, privKey2 := bn254.GenPrivKey(), bn254.GenPrivKey()
+	v1 := types.UpdateValidator(privKey1.PubKey().Bytes(), 10, bn254.KeyType)
+	v2 := types.UpdateValidator(privKey2.PubKey().Bytes(), 20, bn254.KeyType)
+
+	// add the validators, proving the possession of their keys
+	makeApplyBlock(t, kvstore, 1, []types.ValidatorUpdate{v1, v2},
+		MakeBN254ValSetChangeTx(privKey1, v1.Power),
+		MakeBN254ValSetChangeTx(privKey2, v2.Power))
+	valsEqual(t, []types.ValidatorUpdate{v1, v2}, kvstore.Validators())
+
+	// the keys not proven to be possessed are rejected
+	unproven := bn254.GenPrivKey()
+	pk, err := cryptoenc.PubKeyToProto(unproven.PubKey())
+	require.NoError(t, err)
+	res := kvstore.DeliverTx(types.RequestDeliverTx{Tx: MakeValSetChangeTx(pk, 10)})
+	require.Equal(t, code.CodeTypeUnauthorized, res.Code, res.Log)
+
+	proof, err := privKey1.ProvePossession()
+	require.NoError(t, err)
+	tx := fmt.Sprintf("val:bn254:%s!10!%s",
+		base64.StdEncoding.EncodeToString(unproven.PubKey().Bytes()),
+		base64.StdEncoding.EncodeToString(proof))
+	res = kvstore.DeliverTx(types.RequestDeliverTx{Tx: []byte(tx)})
+	require.Equal(t, code.CodeTypeUnauthorized, res.Code, res.Log)
+
+	// removing a validator needs no proof
+	v1.Power = 0
+	makeApplyBlock(t, kvstore, 2, []types.ValidatorUpdate{v1}, MakeValSetChangeTx(v1.PubKey, 0))
+	valsEqual(t, []types.ValidatorUpdate{v2}, kvstore.Validators())
+}
+
 func makeApplyBlock(
 	t *testing.T,
 	kvstore types.Application,
@@ -11,7 +11,10 @@ import (
 
 	"github.com/cometbft/cometbft/abci/example/code"
 	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
 	"github.com/cometbft/cometbft/libs/log"
 	pc "github.com/cometbft/cometbft/proto/tendermint/crypto"
 )
@@ -218,33 +221,59 @@ func (app *PersistentKVStoreApplication) Validators() (validators []types.Valida
 	return
 }
 
+// MakeValSetChangeTx returns the tx updating the power of pubkey. The bn254
+// validators must be added with MakeBN254ValSetChangeTx, proving the possession
+// of their key.
 func MakeValSetChangeTx(pubkey pc.PublicKey, power int64) []byte {
 	pk, err := cryptoenc.PubKeyFromProto(pubkey)
 	if err != nil {
 		panic(err)
 	}
 	pubStr := base64.StdEncoding.EncodeToString(pk.Bytes())
-	return []byte(fmt.Sprintf("val:%s!%d", pubStr, power))
+	if pk.Type() == ed25519.KeyType {
+		return []byte(fmt.Sprintf("val:%s!%d", pubStr, power))
+	}
+	return []byte(fmt.Sprintf("val:%s:%s!%d", pk.Type(), pubStr, power))
+}
+
+// MakeBN254ValSetChangeTx returns the tx updating the power of the bn254
+// validator of privKey, along with the proof of possession of the key.
+func MakeBN254ValSetChangeTx(privKey bn254.PrivKey, power int64) []byte {
+	proof, err := privKey.ProvePossession()
+	if err != nil {
+		panic(err)
+	}
+	return []byte(fmt.Sprintf("val:%s:%s!%d!%s", bn254.KeyType,
+		base64.StdEncoding.EncodeToString(privKey.PubKey().Bytes()), power,
+		base64.StdEncoding.EncodeToString(proof)))
 }
 
 func isValidatorTx(tx []byte) bool {
 	return strings.HasPrefix(string(tx), ValidatorSetChangePrefix)
 }
 
-// format is "val:pubkey!power"
-// pubkey is a base64-encoded 32-byte ed25519 key
+// format is "val:[type:]pubkey!power[!proof]"
+// pubkey is a base64-encoded key of the given type, ed25519 if omitted. The
+// bn254 validators added or updated must prove the possession of their key:
+// proof is the base64-encoded proof of possession.
 func (app *PersistentKVStoreApplication) execValidatorTx(tx []byte) types.ResponseDeliverTx {
 	tx = tx[len(ValidatorSetChangePrefix):]
 
-	//  get the pubkey and power
-	pubKeyAndPower := strings.Split(string(tx), "!")
-	if len(pubKeyAndPower) != 2 {
+	// get the key type
+	keyType := ed25519.KeyType
+	if typ, rest, ok := strings.Cut(string(tx), ":"); ok {
+		keyType, tx = typ, []byte(rest)
+	}
+
+	//  get the pubkey, power and proof
+	parts := strings.Split(string(tx), "!")
+	if len(parts) != 2 && len(parts) != 3 {
 		return types.ResponseDeliverTx{
 			Code: code.CodeTypeEncodingError,
-			Log:  fmt.Sprintf("Expected 'pubkey!power'. Got %v", pubKeyAndPower),
+			Log:  fmt.Sprintf("Expected 'pubkey!power[!proof]'. Got %v", parts),
 		}
 	}
-	pubkeyS, powerS := pubKeyAndPower[0], pubKeyAndPower[1]
+	pubkeyS, powerS := parts[0], parts[1]
 
 	// decode the pubkey
 	pubkey, err := base64.StdEncoding.DecodeString(pubkeyS)
@@ -264,8 +293,54 @@ func (app *PersistentKVStoreApplication) execValidatorTx(tx []byte) types.Respon
 		}
 	}
 
+	switch keyType {
+	case ed25519.KeyType, secp256k1.KeyType:
+	case bn254.KeyType:
+		var pk bn254.PubKey
+		if err := pk.SetBytes(pubkey); err != nil {
+			return types.ResponseDeliverTx{
+				Code: code.CodeTypeEncodingError,
+				Log:  fmt.Sprintf("Pubkey (%s) is invalid: %v", pubkeyS, err),
+			}
+		}
+		if power > 0 {
+			if res := verifyPossession(pk, parts[2:]); !res.IsOK() {
+				return res
+			}
+		}
+	default:
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeEncodingError,
+			Log:  fmt.Sprintf("Key type %s is not supported", keyType),
+		}
+	}
+
 	// update
-	return app.updateValidator(types.UpdateValidator(pubkey, power, ""))
+	return app.updateValidator(types.UpdateValidator(pubkey, power, keyType))
+}
+
+// verifyPossession verifies the proof of possession of pk, if any.
+func verifyPossession(pk bn254.PubKey, proof []string) types.ResponseDeliverTx {
+	if len(proof) == 0 {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeUnauthorized,
+			Log:  fmt.Sprintf("Missing the proof of possession of %s", pk),
+		}
+	}
+	bz, err := base64.StdEncoding.DecodeString(proof[0])
+	if err != nil {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeEncodingError,
+			Log:  fmt.Sprintf("Proof (%s) is invalid base64", proof[0]),
+		}
+	}
+	if !pk.VerifyPossession(bz) {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeUnauthorized,
+			Log:  fmt.Sprintf("Invalid proof of possession of %s", pk),
+		}
+	}
+	return types.ResponseDeliverTx{Code: code.CodeTypeOK}
 }
 
 // add, update, or remove a validator
@@ -1,9 +1,17 @@
 package types
 
 import (
+	"io"
+
 	context "golang.org/x/net/context"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 )
 
+// streamResponsesSize is the number of responses a stream buffers while they
+// are being sent, before it stops handling the requests.
+const streamResponsesSize = 1000
+
 //go:generate ../../scripts/mockery_generate.sh Application
 
 // Application is an interface that enables any finite, deterministic state machine
@@ -34,6 +42,52 @@ type Application interface {
 	ApplySnapshotChunk(RequestApplySnapshotChunk) ResponseApplySnapshotChunk // Apply a shapshot chunk
 }
 
+// BlockFinalizer is implemented by the applications executing a decided block
+// in a single FinalizeBlock call, rather than in BeginBlock, DeliverTx and
+// EndBlock calls. The applications not implementing it keep being driven
+// through these legacy methods, see FinalizeBlock.
+type BlockFinalizer interface {
+	FinalizeBlock(RequestFinalizeBlock) ResponseFinalizeBlock // Execute a block, returns the results of its txs and the changes to the validator set
+}
+
+// FinalizeBlock executes a decided block on app, through app.FinalizeBlock if
+// app implements BlockFinalizer, else by calling BeginBlock, DeliverTx for each
+// tx and EndBlock, and combining their responses.
+func FinalizeBlock(app Application, req RequestFinalizeBlock) ResponseFinalizeBlock {
+	if f, ok := app.(BlockFinalizer); ok {
+		return f.FinalizeBlock(req)
+	}
+
+	resBegin := app.BeginBlock(RequestBeginBlock{
+		Hash:                req.Hash,
+		Header:              req.Header,
+		LastCommitInfo:      req.LastCommitInfo,
+		ByzantineValidators: req.ByzantineValidators,
+	})
+	txResults := make([]*ResponseDeliverTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		res := app.DeliverTx(RequestDeliverTx{Tx: tx})
+		txResults[i] = &res
+	}
+	resEnd := app.EndBlock(RequestEndBlock{Height: req.Header.Height})
+
+	return ResponseFinalizeBlock{
+		BeginBlockEvents:      resBegin.Events,
+		TxResults:             txResults,
+		EndBlockEvents:        resEnd.Events,
+		ValidatorUpdates:      resEnd.ValidatorUpdates,
+		ConsensusParamUpdates: resEnd.ConsensusParamUpdates,
+	}
+}
+
+// Info returns the information about app, advertising that FinalizeBlock is
+// handled, as it is for all the applications, see FinalizeBlock.
+func Info(app Application, req RequestInfo) ResponseInfo {
+	res := app.Info(req)
+	res.FinalizeBlock = true
+	return res
+}
+
 //-------------------------------------------------------
 // BaseApplication is a base form of Application
 
@@ -117,10 +171,14 @@ func (BaseApplication) ProcessProposal(req RequestProcessProposal) ResponseProce
 // GRPCApplication is a GRPC wrapper for Application
 type GRPCApplication struct {
 	app Application
+
+	// serializes the requests handled on the streams, as the socket server
+	// does for its connections
+	mtx cmtsync.Mutex
 }
 
 func NewGRPCApplication(app Application) *GRPCApplication {
-	return &GRPCApplication{app}
+	return &GRPCApplication{app: app}
 }
 
 func (app *GRPCApplication) Echo(ctx context.Context, req *RequestEcho) (*ResponseEcho, error) {
@@ -132,7 +190,7 @@ func (app *GRPCApplication) Flush(ctx context.Context, req *RequestFlush) (*Resp
 }
 
 func (app *GRPCApplication) Info(ctx context.Context, req *RequestInfo) (*ResponseInfo, error) {
-	res := app.app.Info(*req)
+	res := Info(app.app, *req)
 	return &res, nil
 }
 
@@ -206,3 +264,93 @@ func (app *GRPCApplication) ProcessProposal(
 	res := app.app.ProcessProposal(*req)
 	return &res, nil
 }
+
+func (app *GRPCApplication) FinalizeBlock(
+	ctx context.Context, req *RequestFinalizeBlock) (*ResponseFinalizeBlock, error) {
+	res := FinalizeBlock(app.app, *req)
+	return &res, nil
+}
+
+// Stream handles the requests received on stream in order, and sends their
+// responses back in the same order. The responses are sent while the next
+// requests are handled, so that a client can pipeline its requests.
+func (app *GRPCApplication) Stream(stream ABCIApplication_StreamServer) error {
+	var (
+		responses = make(chan *Response, streamResponsesSize)
+		sent      = make(chan struct{})
+		sendErr   error
+	)
+	go func() {
+		defer close(sent)
+		for res := range responses {
+			if sendErr == nil {
+				sendErr = stream.Send(res)
+			}
+		}
+	}()
+
+	var err error
+	for {
+		var req *Request
+		req, err = stream.Recv()
+		if err != nil {
+			break
+		}
+		app.mtx.Lock()
+		res := HandleRequest(app.app, req)
+		app.mtx.Unlock()
+		responses <- res
+	}
+	close(responses)
+	<-sent
+
+	if sendErr != nil {
+		return sendErr
+	}
+	if err == io.EOF { // the client closed the stream
+		return nil
+	}
+	return err
+}
+
+// HandleRequest passes req to app, and returns its response.
+func HandleRequest(app Application, req *Request) *Response {
+	switch r := req.Value.(type) {
+	case *Request_Echo:
+		return ToResponseEcho(r.Echo.Message)
+	case *Request_Flush:
+		return ToResponseFlush()
+	case *Request_Info:
+		return ToResponseInfo(Info(app, *r.Info))
+	case *Request_DeliverTx:
+		return ToResponseDeliverTx(app.DeliverTx(*r.DeliverTx))
+	case *Request_CheckTx:
+		return ToResponseCheckTx(app.CheckTx(*r.CheckTx))
+	case *Request_Commit:
+		return ToResponseCommit(app.Commit())
+	case *Request_Query:
+		return ToResponseQuery(app.Query(*r.Query))
+	case *Request_InitChain:
+		return ToResponseInitChain(app.InitChain(*r.InitChain))
+	case *Request_BeginBlock:
+		return ToResponseBeginBlock(app.BeginBlock(*r.BeginBlock))
+	case *Request_EndBlock:
+		return ToResponseEndBlock(app.EndBlock(*r.EndBlock))
+	case *Request_FinalizeBlock:
+		return ToResponseFinalizeBlock(FinalizeBlock(app, *r.FinalizeBlock))
+	case *Request_ListSnapshots:
+		return ToResponseListSnapshots(app.ListSnapshots(*r.ListSnapshots))
+	case *Request_OfferSnapshot:
+		return ToResponseOfferSnapshot(app.OfferSnapshot(*r.OfferSnapshot))
+	case *Request_PrepareProposal:
+		return ToResponsePrepareProposal(app.PrepareProposal(*r.PrepareProposal))
+	case *Request_ProcessProposal:
+		return ToResponseProcessProposal(app.ProcessProposal(*r.ProcessProposal))
+	case *Request_LoadSnapshotChunk:
+		return ToResponseLoadSnapshotChunk(app.LoadSnapshotChunk(*r.LoadSnapshotChunk))
+	case *Request_ApplySnapshotChunk:
+		return ToResponseApplySnapshotChunk(app.ApplySnapshotChunk(*r.ApplySnapshotChunk))
+	default:
+		return ToResponseException("Unknown request")
+	}
+}
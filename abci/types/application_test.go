@@ -0,0 +1,69 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+// legacyApp executes the blocks with BeginBlock, DeliverTx and EndBlock.
+type legacyApp struct {
+	BaseApplication
+
+	calls []string
+}
+
+func (app *legacyApp) BeginBlock(req RequestBeginBlock) ResponseBeginBlock {
+	app.calls = append(app.calls, "begin_block")
+	return ResponseBeginBlock{Events: []Event{{Type: "begin"}}}
+}
+
+func (app *legacyApp) DeliverTx(req RequestDeliverTx) ResponseDeliverTx {
+	app.calls = append(app.calls, "deliver_tx")
+	return ResponseDeliverTx{Code: CodeTypeOK, Data: req.Tx}
+}
+
+func (app *legacyApp) EndBlock(req RequestEndBlock) ResponseEndBlock {
+	app.calls = append(app.calls, "end_block")
+	return ResponseEndBlock{
+		ValidatorUpdates: []ValidatorUpdate{{Power: req.Height}},
+		Events:           []Event{{Type: "end"}},
+	}
+}
+
+// finalizerApp executes the blocks with FinalizeBlock.
+type finalizerApp struct {
+	legacyApp
+}
+
+func (app *finalizerApp) FinalizeBlock(req RequestFinalizeBlock) ResponseFinalizeBlock {
+	app.calls = append(app.calls, "finalize_block")
+	return ResponseFinalizeBlock{TxResults: []*ResponseDeliverTx{{Code: 1}}}
+}
+
+func TestFinalizeBlock(t *testing.T) {
+	req := RequestFinalizeBlock{
+		Header: cmtproto.Header{Height: 5},
+		Txs:    [][]byte{[]byte("a"), []byte("b")},
+	}
+
+	legacy := &legacyApp{}
+	res := FinalizeBlock(legacy, req)
+	assert.Equal(t, []string{"begin_block", "deliver_tx", "deliver_tx", "end_block"}, legacy.calls)
+	assert.Equal(t, ResponseFinalizeBlock{
+		BeginBlockEvents: []Event{{Type: "begin"}},
+		TxResults: []*ResponseDeliverTx{
+			{Code: CodeTypeOK, Data: []byte("a")},
+			{Code: CodeTypeOK, Data: []byte("b")},
+		},
+		EndBlockEvents:   []Event{{Type: "end"}},
+		ValidatorUpdates: []ValidatorUpdate{{Power: 5}},
+	}, res)
+
+	finalizer := &finalizerApp{}
+	res = FinalizeBlock(finalizer, req)
+	assert.Equal(t, []string{"finalize_block"}, finalizer.calls)
+	assert.Equal(t, ResponseFinalizeBlock{TxResults: []*ResponseDeliverTx{{Code: 1}}}, res)
+}
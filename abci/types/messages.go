@@ -127,6 +127,12 @@ func ToRequestProcessProposal(req RequestProcessProposal) *Request {
 	}
 }
 
+func ToRequestFinalizeBlock(req RequestFinalizeBlock) *Request {
+	return &Request{
+		Value: &Request_FinalizeBlock{&req},
+	}
+}
+
 //----------------------------------------
 
 func ToResponseException(errStr string) *Response {
@@ -230,3 +236,9 @@ func ToResponseProcessProposal(res ResponseProcessProposal) *Response {
 		Value: &Response_ProcessProposal{&res},
 	}
 }
+
+func ToResponseFinalizeBlock(res ResponseFinalizeBlock) *Response {
+	return &Response{
+		Value: &Response_FinalizeBlock{&res},
+	}
+}
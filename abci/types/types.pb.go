@@ -121,7 +121,7 @@ func (x ResponseOfferSnapshot_Result) String() string {
 }
 
 func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{31, 0}
 }
 
 type ResponseApplySnapshotChunk_Result int32
@@ -158,7 +158,7 @@ func (x ResponseApplySnapshotChunk_Result) String() string {
 }
 
 func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{33, 0}
 }
 
 type ResponseProcessProposal_ProposalStatus int32
@@ -186,7 +186,7 @@ func (x ResponseProcessProposal_ProposalStatus) String() string {
 }
 
 func (ResponseProcessProposal_ProposalStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{35, 0}
 }
 
 type Request struct {
@@ -207,6 +207,7 @@ type Request struct {
 	//	*Request_ApplySnapshotChunk
 	//	*Request_PrepareProposal
 	//	*Request_ProcessProposal
+	//	*Request_FinalizeBlock
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -297,6 +298,9 @@ type Request_PrepareProposal struct {
 type Request_ProcessProposal struct {
 	ProcessProposal *RequestProcessProposal `protobuf:"bytes,17,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
 }
+type Request_FinalizeBlock struct {
+	FinalizeBlock *RequestFinalizeBlock `protobuf:"bytes,18,opt,name=finalize_block,json=finalizeBlock,proto3,oneof" json:"finalize_block,omitempty"`
+}
 
 func (*Request_Echo) isRequest_Value()               {}
 func (*Request_Flush) isRequest_Value()              {}
@@ -314,6 +318,7 @@ func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
 func (*Request_ApplySnapshotChunk) isRequest_Value() {}
 func (*Request_PrepareProposal) isRequest_Value()    {}
 func (*Request_ProcessProposal) isRequest_Value()    {}
+func (*Request_FinalizeBlock) isRequest_Value()      {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -434,6 +439,13 @@ func (m *Request) GetProcessProposal() *RequestProcessProposal {
 	return nil
 }
 
+func (m *Request) GetFinalizeBlock() *RequestFinalizeBlock {
+	if x, ok := m.GetValue().(*Request_FinalizeBlock); ok {
+		return x.FinalizeBlock
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -453,6 +465,7 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_ApplySnapshotChunk)(nil),
 		(*Request_PrepareProposal)(nil),
 		(*Request_ProcessProposal)(nil),
+		(*Request_FinalizeBlock)(nil),
 	}
 }
 
@@ -1418,6 +1431,84 @@ func (m *RequestProcessProposal) GetProposerAddress() []byte {
 	return nil
 }
 
+// executes a decided block, replacing the BeginBlock, DeliverTx and EndBlock
+// calls with a single one.
+type RequestFinalizeBlock struct {
+	Hash                []byte        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Header              types1.Header `protobuf:"bytes,2,opt,name=header,proto3" json:"header"`
+	LastCommitInfo      CommitInfo    `protobuf:"bytes,3,opt,name=last_commit_info,json=lastCommitInfo,proto3" json:"last_commit_info"`
+	ByzantineValidators []Misbehavior `protobuf:"bytes,4,rep,name=byzantine_validators,json=byzantineValidators,proto3" json:"byzantine_validators"`
+	Txs                 [][]byte      `protobuf:"bytes,5,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *RequestFinalizeBlock) Reset()         { *m = RequestFinalizeBlock{} }
+func (m *RequestFinalizeBlock) String() string { return proto.CompactTextString(m) }
+func (*RequestFinalizeBlock) ProtoMessage()    {}
+func (*RequestFinalizeBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{17}
+}
+func (m *RequestFinalizeBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestFinalizeBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestFinalizeBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestFinalizeBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestFinalizeBlock.Merge(m, src)
+}
+func (m *RequestFinalizeBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestFinalizeBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestFinalizeBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestFinalizeBlock proto.InternalMessageInfo
+
+func (m *RequestFinalizeBlock) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *RequestFinalizeBlock) GetHeader() types1.Header {
+	if m != nil {
+		return m.Header
+	}
+	return types1.Header{}
+}
+
+func (m *RequestFinalizeBlock) GetLastCommitInfo() CommitInfo {
+	if m != nil {
+		return m.LastCommitInfo
+	}
+	return CommitInfo{}
+}
+
+func (m *RequestFinalizeBlock) GetByzantineValidators() []Misbehavior {
+	if m != nil {
+		return m.ByzantineValidators
+	}
+	return nil
+}
+
+func (m *RequestFinalizeBlock) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//
@@ -1438,6 +1529,7 @@ type Response struct {
 	//	*Response_ApplySnapshotChunk
 	//	*Response_PrepareProposal
 	//	*Response_ProcessProposal
+	//	*Response_FinalizeBlock
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -1445,7 +1537,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{17}
+	return fileDescriptor_252557cfdd89a31a, []int{18}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1531,6 +1623,9 @@ type Response_PrepareProposal struct {
 type Response_ProcessProposal struct {
 	ProcessProposal *ResponseProcessProposal `protobuf:"bytes,18,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
 }
+type Response_FinalizeBlock struct {
+	FinalizeBlock *ResponseFinalizeBlock `protobuf:"bytes,19,opt,name=finalize_block,json=finalizeBlock,proto3,oneof" json:"finalize_block,omitempty"`
+}
 
 func (*Response_Exception) isResponse_Value()          {}
 func (*Response_Echo) isResponse_Value()               {}
@@ -1549,6 +1644,7 @@ func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
 func (*Response_ApplySnapshotChunk) isResponse_Value() {}
 func (*Response_PrepareProposal) isResponse_Value()    {}
 func (*Response_ProcessProposal) isResponse_Value()    {}
+func (*Response_FinalizeBlock) isResponse_Value()      {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -1676,6 +1772,13 @@ func (m *Response) GetProcessProposal() *ResponseProcessProposal {
 	return nil
 }
 
+func (m *Response) GetFinalizeBlock() *ResponseFinalizeBlock {
+	if x, ok := m.GetValue().(*Response_FinalizeBlock); ok {
+		return x.FinalizeBlock
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1696,6 +1799,7 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_ApplySnapshotChunk)(nil),
 		(*Response_PrepareProposal)(nil),
 		(*Response_ProcessProposal)(nil),
+		(*Response_FinalizeBlock)(nil),
 	}
 }
 
@@ -1708,7 +1812,7 @@ func (m *ResponseException) Reset()         { *m = ResponseException{} }
 func (m *ResponseException) String() string { return proto.CompactTextString(m) }
 func (*ResponseException) ProtoMessage()    {}
 func (*ResponseException) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{18}
+	return fileDescriptor_252557cfdd89a31a, []int{19}
 }
 func (m *ResponseException) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1752,7 +1856,7 @@ func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
 func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
 func (*ResponseEcho) ProtoMessage()    {}
 func (*ResponseEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{19}
+	return fileDescriptor_252557cfdd89a31a, []int{20}
 }
 func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1795,7 +1899,7 @@ func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
 func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
 func (*ResponseFlush) ProtoMessage()    {}
 func (*ResponseFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{20}
+	return fileDescriptor_252557cfdd89a31a, []int{21}
 }
 func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1830,13 +1934,17 @@ type ResponseInfo struct {
 	AppVersion       uint64 `protobuf:"varint,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
 	LastBlockHeight  int64  `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
 	LastBlockAppHash []byte `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+	// Whether the ABCI server handles FinalizeBlock. The servers of CometBFT set
+	// it, for all the applications: the nodes execute the blocks through
+	// BeginBlock, DeliverTx and EndBlock with the other servers.
+	FinalizeBlock bool `protobuf:"varint,6,opt,name=finalize_block,json=finalizeBlock,proto3" json:"finalize_block,omitempty"`
 }
 
 func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
 func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
 func (*ResponseInfo) ProtoMessage()    {}
 func (*ResponseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{21}
+	return fileDescriptor_252557cfdd89a31a, []int{22}
 }
 func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1900,6 +2008,13 @@ func (m *ResponseInfo) GetLastBlockAppHash() []byte {
 	return nil
 }
 
+func (m *ResponseInfo) GetFinalizeBlock() bool {
+	if m != nil {
+		return m.FinalizeBlock
+	}
+	return false
+}
+
 type ResponseInitChain struct {
 	ConsensusParams *types1.ConsensusParams `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params,omitempty"`
 	Validators      []ValidatorUpdate       `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators"`
@@ -1910,7 +2025,7 @@ func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()    {}
 func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{22}
+	return fileDescriptor_252557cfdd89a31a, []int{23}
 }
 func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1977,7 +2092,7 @@ func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()    {}
 func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{23}
+	return fileDescriptor_252557cfdd89a31a, []int{24}
 }
 func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2077,7 +2192,7 @@ func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()    {}
 func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{24}
+	return fileDescriptor_252557cfdd89a31a, []int{25}
 }
 func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2128,7 +2243,7 @@ func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{25}
+	return fileDescriptor_252557cfdd89a31a, []int{26}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2228,7 +2343,7 @@ func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()    {}
 func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{26}
+	return fileDescriptor_252557cfdd89a31a, []int{27}
 }
 func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2323,7 +2438,7 @@ func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
 func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndBlock) ProtoMessage()    {}
 func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{27}
+	return fileDescriptor_252557cfdd89a31a, []int{28}
 }
 func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2383,7 +2498,7 @@ func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
 func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
 func (*ResponseCommit) ProtoMessage()    {}
 func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28}
+	return fileDescriptor_252557cfdd89a31a, []int{29}
 }
 func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2434,7 +2549,7 @@ func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
 func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*ResponseListSnapshots) ProtoMessage()    {}
 func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29}
+	return fileDescriptor_252557cfdd89a31a, []int{30}
 }
 func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2478,7 +2593,7 @@ func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
 func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*ResponseOfferSnapshot) ProtoMessage()    {}
 func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30}
+	return fileDescriptor_252557cfdd89a31a, []int{31}
 }
 func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2522,7 +2637,7 @@ func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotC
 func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
 func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31}
+	return fileDescriptor_252557cfdd89a31a, []int{32}
 }
 func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2568,7 +2683,7 @@ func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapsho
 func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
 func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32}
+	return fileDescriptor_252557cfdd89a31a, []int{33}
 }
 func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2626,7 +2741,7 @@ func (m *ResponsePrepareProposal) Reset()         { *m = ResponsePrepareProposal
 func (m *ResponsePrepareProposal) String() string { return proto.CompactTextString(m) }
 func (*ResponsePrepareProposal) ProtoMessage()    {}
 func (*ResponsePrepareProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33}
+	return fileDescriptor_252557cfdd89a31a, []int{34}
 }
 func (m *ResponsePrepareProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2670,7 +2785,7 @@ func (m *ResponseProcessProposal) Reset()         { *m = ResponseProcessProposal
 func (m *ResponseProcessProposal) String() string { return proto.CompactTextString(m) }
 func (*ResponseProcessProposal) ProtoMessage()    {}
 func (*ResponseProcessProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+	return fileDescriptor_252557cfdd89a31a, []int{35}
 }
 func (m *ResponseProcessProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2706,6 +2821,85 @@ func (m *ResponseProcessProposal) GetStatus() ResponseProcessProposal_ProposalSt
 	return ResponseProcessProposal_UNKNOWN
 }
 
+type ResponseFinalizeBlock struct {
+	// events emitted before executing the txs, as by BeginBlock.
+	BeginBlockEvents []Event `protobuf:"bytes,1,rep,name=begin_block_events,json=beginBlockEvents,proto3" json:"begin_block_events,omitempty"`
+	// the results of the txs, in the order of the block.
+	TxResults []*ResponseDeliverTx `protobuf:"bytes,2,rep,name=tx_results,json=txResults,proto3" json:"tx_results,omitempty"`
+	// events emitted after executing the txs, as by EndBlock.
+	EndBlockEvents        []Event                 `protobuf:"bytes,3,rep,name=end_block_events,json=endBlockEvents,proto3" json:"end_block_events,omitempty"`
+	ValidatorUpdates      []ValidatorUpdate       `protobuf:"bytes,4,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
+	ConsensusParamUpdates *types1.ConsensusParams `protobuf:"bytes,5,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
+}
+
+func (m *ResponseFinalizeBlock) Reset()         { *m = ResponseFinalizeBlock{} }
+func (m *ResponseFinalizeBlock) String() string { return proto.CompactTextString(m) }
+func (*ResponseFinalizeBlock) ProtoMessage()    {}
+func (*ResponseFinalizeBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{36}
+}
+func (m *ResponseFinalizeBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseFinalizeBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseFinalizeBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseFinalizeBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseFinalizeBlock.Merge(m, src)
+}
+func (m *ResponseFinalizeBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseFinalizeBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseFinalizeBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseFinalizeBlock proto.InternalMessageInfo
+
+func (m *ResponseFinalizeBlock) GetBeginBlockEvents() []Event {
+	if m != nil {
+		return m.BeginBlockEvents
+	}
+	return nil
+}
+
+func (m *ResponseFinalizeBlock) GetTxResults() []*ResponseDeliverTx {
+	if m != nil {
+		return m.TxResults
+	}
+	return nil
+}
+
+func (m *ResponseFinalizeBlock) GetEndBlockEvents() []Event {
+	if m != nil {
+		return m.EndBlockEvents
+	}
+	return nil
+}
+
+func (m *ResponseFinalizeBlock) GetValidatorUpdates() []ValidatorUpdate {
+	if m != nil {
+		return m.ValidatorUpdates
+	}
+	return nil
+}
+
+func (m *ResponseFinalizeBlock) GetConsensusParamUpdates() *types1.ConsensusParams {
+	if m != nil {
+		return m.ConsensusParamUpdates
+	}
+	return nil
+}
+
 type CommitInfo struct {
 	Round int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
 	Votes []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
@@ -2715,7 +2909,7 @@ func (m *CommitInfo) Reset()         { *m = CommitInfo{} }
 func (m *CommitInfo) String() string { return proto.CompactTextString(m) }
 func (*CommitInfo) ProtoMessage()    {}
 func (*CommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+	return fileDescriptor_252557cfdd89a31a, []int{37}
 }
 func (m *CommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2770,7 +2964,7 @@ func (m *ExtendedCommitInfo) Reset()         { *m = ExtendedCommitInfo{} }
 func (m *ExtendedCommitInfo) String() string { return proto.CompactTextString(m) }
 func (*ExtendedCommitInfo) ProtoMessage()    {}
 func (*ExtendedCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{38}
 }
 func (m *ExtendedCommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2825,7 +3019,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{39}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2879,7 +3073,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{40}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2943,7 +3137,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{41}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3011,7 +3205,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{40}
+	return fileDescriptor_252557cfdd89a31a, []int{42}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3064,7 +3258,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{41}
+	return fileDescriptor_252557cfdd89a31a, []int{43}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3117,7 +3311,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{42}
+	return fileDescriptor_252557cfdd89a31a, []int{44}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3170,7 +3364,7 @@ func (m *ExtendedVoteInfo) Reset()         { *m = ExtendedVoteInfo{} }
 func (m *ExtendedVoteInfo) String() string { return proto.CompactTextString(m) }
 func (*ExtendedVoteInfo) ProtoMessage()    {}
 func (*ExtendedVoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{43}
+	return fileDescriptor_252557cfdd89a31a, []int{45}
 }
 func (m *ExtendedVoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3238,7 +3432,7 @@ func (m *Misbehavior) Reset()         { *m = Misbehavior{} }
 func (m *Misbehavior) String() string { return proto.CompactTextString(m) }
 func (*Misbehavior) ProtoMessage()    {}
 func (*Misbehavior) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{44}
+	return fileDescriptor_252557cfdd89a31a, []int{46}
 }
 func (m *Misbehavior) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3314,7 +3508,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{45}
+	return fileDescriptor_252557cfdd89a31a, []int{47}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3401,6 +3595,7 @@ func init() {
 	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
 	proto.RegisterType((*RequestPrepareProposal)(nil), "tendermint.abci.RequestPrepareProposal")
 	proto.RegisterType((*RequestProcessProposal)(nil), "tendermint.abci.RequestProcessProposal")
+	proto.RegisterType((*RequestFinalizeBlock)(nil), "tendermint.abci.RequestFinalizeBlock")
 	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
 	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
 	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
@@ -3419,6 +3614,7 @@ func init() {
 	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
 	proto.RegisterType((*ResponsePrepareProposal)(nil), "tendermint.abci.ResponsePrepareProposal")
 	proto.RegisterType((*ResponseProcessProposal)(nil), "tendermint.abci.ResponseProcessProposal")
+	proto.RegisterType((*ResponseFinalizeBlock)(nil), "tendermint.abci.ResponseFinalizeBlock")
 	proto.RegisterType((*CommitInfo)(nil), "tendermint.abci.CommitInfo")
 	proto.RegisterType((*ExtendedCommitInfo)(nil), "tendermint.abci.ExtendedCommitInfo")
 	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
@@ -3435,195 +3631,205 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2995 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x3b, 0x73, 0x23, 0xc7,
-	0xf1, 0xc7, 0xfb, 0xd1, 0x78, 0x2d, 0xe7, 0x28, 0x0a, 0x07, 0x9d, 0x48, 0x6a, 0x55, 0x92, 0xee,
-	0x4e, 0x12, 0xa9, 0x3f, 0xf5, 0x3f, 0x3d, 0x4a, 0x96, 0x2d, 0x00, 0x87, 0x33, 0x78, 0xa4, 0x48,
-	0x7a, 0x09, 0x9e, 0x4a, 0x7e, 0xdc, 0x6a, 0x01, 0x0c, 0x89, 0xd5, 0x01, 0xbb, 0xab, 0xdd, 0x01,
-	0x05, 0x2a, 0xb4, 0xca, 0x55, 0x2e, 0x95, 0x03, 0x85, 0x4a, 0x14, 0x38, 0x70, 0xe2, 0x4f, 0xe0,
-	0xc8, 0x91, 0x03, 0x05, 0x0e, 0x14, 0x38, 0x70, 0x24, 0xbb, 0xa4, 0xcc, 0x5f, 0xc0, 0x81, 0x03,
-	0xbb, 0xe6, 0xb1, 0x2f, 0x00, 0x4b, 0x80, 0x92, 0xcb, 0x55, 0x2e, 0x67, 0x33, 0xbd, 0xdd, 0x3d,
-	0x33, 0x3d, 0x33, 0xdd, 0xfd, 0xeb, 0x1d, 0x78, 0x82, 0x60, 0xa3, 0x8f, 0xed, 0x91, 0x6e, 0x90,
-	0x6d, 0xad, 0xdb, 0xd3, 0xb7, 0xc9, 0x85, 0x85, 0x9d, 0x2d, 0xcb, 0x36, 0x89, 0x89, 0x2a, 0xfe,
-	0xc7, 0x2d, 0xfa, 0xb1, 0xf6, 0x64, 0x80, 0xbb, 0x67, 0x5f, 0x58, 0xc4, 0xdc, 0xb6, 0x6c, 0xd3,
-	0x3c, 0xe5, 0xfc, 0xb5, 0x1b, 0x81, 0xcf, 0x4c, 0x4f, 0x50, 0x5b, 0xe8, 0xab, 0x10, 0x7e, 0x84,
-	0x2f, 0xdc, 0xaf, 0x4f, 0xce, 0xc8, 0x5a, 0x9a, 0xad, 0x8d, 0xdc, 0xcf, 0x1b, 0x67, 0xa6, 0x79,
-	0x36, 0xc4, 0xdb, 0xac, 0xd7, 0x1d, 0x9f, 0x6e, 0x13, 0x7d, 0x84, 0x1d, 0xa2, 0x8d, 0x2c, 0xc1,
-	0xb0, 0x7a, 0x66, 0x9e, 0x99, 0xac, 0xb9, 0x4d, 0x5b, 0x9c, 0x2a, 0xff, 0x33, 0x07, 0x59, 0x05,
-	0x7f, 0x30, 0xc6, 0x0e, 0x41, 0x3b, 0x90, 0xc2, 0xbd, 0x81, 0x59, 0x8d, 0x6f, 0xc6, 0x6f, 0x16,
-	0x76, 0x6e, 0x6c, 0x4d, 0x2d, 0x6e, 0x4b, 0xf0, 0xb5, 0x7a, 0x03, 0xb3, 0x1d, 0x53, 0x18, 0x2f,
-	0xba, 0x03, 0xe9, 0xd3, 0xe1, 0xd8, 0x19, 0x54, 0x13, 0x4c, 0xe8, 0xc9, 0x28, 0xa1, 0x7b, 0x94,
-	0xa9, 0x1d, 0x53, 0x38, 0x37, 0x1d, 0x4a, 0x37, 0x4e, 0xcd, 0x6a, 0xf2, 0xf2, 0xa1, 0x76, 0x8d,
-	0x53, 0x36, 0x14, 0xe5, 0x45, 0x0d, 0x00, 0xdd, 0xd0, 0x89, 0xda, 0x1b, 0x68, 0xba, 0x51, 0x4d,
-	0x33, 0xc9, 0xa7, 0xa2, 0x25, 0x75, 0xd2, 0xa4, 0x8c, 0xed, 0x98, 0x92, 0xd7, 0xdd, 0x0e, 0x9d,
-	0xee, 0x07, 0x63, 0x6c, 0x5f, 0x54, 0x33, 0x97, 0x4f, 0xf7, 0x47, 0x94, 0x89, 0x4e, 0x97, 0x71,
-	0xa3, 0x16, 0x14, 0xba, 0xf8, 0x4c, 0x37, 0xd4, 0xee, 0xd0, 0xec, 0x3d, 0xaa, 0x66, 0x99, 0xb0,
-	0x1c, 0x25, 0xdc, 0xa0, 0xac, 0x0d, 0xca, 0xd9, 0x8e, 0x29, 0xd0, 0xf5, 0x7a, 0xe8, 0x7b, 0x90,
-	0xeb, 0x0d, 0x70, 0xef, 0x91, 0x4a, 0x26, 0xd5, 0x1c, 0xd3, 0xb1, 0x11, 0xa5, 0xa3, 0x49, 0xf9,
-	0x3a, 0x93, 0x76, 0x4c, 0xc9, 0xf6, 0x78, 0x93, 0xae, 0xbf, 0x8f, 0x87, 0xfa, 0x39, 0xb6, 0xa9,
-	0x7c, 0xfe, 0xf2, 0xf5, 0xdf, 0xe5, 0x9c, 0x4c, 0x43, 0xbe, 0xef, 0x76, 0xd0, 0x0f, 0x20, 0x8f,
-	0x8d, 0xbe, 0x58, 0x06, 0x30, 0x15, 0x9b, 0x91, 0xfb, 0x6c, 0xf4, 0xdd, 0x45, 0xe4, 0xb0, 0x68,
-	0xa3, 0xd7, 0x20, 0xd3, 0x33, 0x47, 0x23, 0x9d, 0x54, 0x0b, 0x4c, 0x7a, 0x3d, 0x72, 0x01, 0x8c,
-	0xab, 0x1d, 0x53, 0x04, 0x3f, 0x3a, 0x80, 0xf2, 0x50, 0x77, 0x88, 0xea, 0x18, 0x9a, 0xe5, 0x0c,
-	0x4c, 0xe2, 0x54, 0x8b, 0x4c, 0xc3, 0x33, 0x51, 0x1a, 0xf6, 0x75, 0x87, 0x1c, 0xbb, 0xcc, 0xed,
-	0x98, 0x52, 0x1a, 0x06, 0x09, 0x54, 0x9f, 0x79, 0x7a, 0x8a, 0x6d, 0x4f, 0x61, 0xb5, 0x74, 0xb9,
-	0xbe, 0x43, 0xca, 0xed, 0xca, 0x53, 0x7d, 0x66, 0x90, 0x80, 0x7e, 0x02, 0xd7, 0x86, 0xa6, 0xd6,
-	0xf7, 0xd4, 0xa9, 0xbd, 0xc1, 0xd8, 0x78, 0x54, 0x2d, 0x33, 0xa5, 0xb7, 0x22, 0x27, 0x69, 0x6a,
-	0x7d, 0x57, 0x45, 0x93, 0x0a, 0xb4, 0x63, 0xca, 0xca, 0x70, 0x9a, 0x88, 0x1e, 0xc2, 0xaa, 0x66,
-	0x59, 0xc3, 0x8b, 0x69, 0xed, 0x15, 0xa6, 0xfd, 0x76, 0x94, 0xf6, 0x3a, 0x95, 0x99, 0x56, 0x8f,
-	0xb4, 0x19, 0x2a, 0xea, 0x80, 0x64, 0xd9, 0xd8, 0xd2, 0x6c, 0xac, 0x5a, 0xb6, 0x69, 0x99, 0x8e,
-	0x36, 0xac, 0x4a, 0x4c, 0xf7, 0x73, 0x51, 0xba, 0x8f, 0x38, 0xff, 0x91, 0x60, 0x6f, 0xc7, 0x94,
-	0x8a, 0x15, 0x26, 0x71, 0xad, 0x66, 0x0f, 0x3b, 0x8e, 0xaf, 0x75, 0x65, 0x91, 0x56, 0xc6, 0x1f,
-	0xd6, 0x1a, 0x22, 0x35, 0xb2, 0x90, 0x3e, 0xd7, 0x86, 0x63, 0x7c, 0x3f, 0x95, 0x4b, 0x49, 0x69,
-	0xf9, 0x39, 0x28, 0x04, 0x1c, 0x0b, 0xaa, 0x42, 0x76, 0x84, 0x1d, 0x47, 0x3b, 0xc3, 0xcc, 0x0f,
-	0xe5, 0x15, 0xb7, 0x2b, 0x97, 0xa1, 0x18, 0x74, 0x26, 0xf2, 0xa7, 0x71, 0x4f, 0x92, 0xfa, 0x09,
-	0x2a, 0x79, 0x8e, 0x6d, 0x47, 0x37, 0x0d, 0x57, 0x52, 0x74, 0xd1, 0xd3, 0x50, 0x62, 0x27, 0x5e,
-	0x75, 0xbf, 0x53, 0x67, 0x95, 0x52, 0x8a, 0x8c, 0xf8, 0x40, 0x30, 0x6d, 0x40, 0xc1, 0xda, 0xb1,
-	0x3c, 0x96, 0x24, 0x63, 0x01, 0x6b, 0xc7, 0x72, 0x19, 0x9e, 0x82, 0x22, 0x5d, 0xa9, 0xc7, 0x91,
-	0x62, 0x83, 0x14, 0x28, 0x4d, 0xb0, 0xc8, 0x7f, 0x4c, 0x80, 0x34, 0xed, 0x80, 0xd0, 0x6b, 0x90,
-	0xa2, 0xbe, 0x58, 0xb8, 0xd5, 0xda, 0x16, 0x77, 0xd4, 0x5b, 0xae, 0xa3, 0xde, 0xea, 0xb8, 0x8e,
-	0xba, 0x91, 0xfb, 0xe2, 0xab, 0x8d, 0xd8, 0xa7, 0x7f, 0xd9, 0x88, 0x2b, 0x4c, 0x02, 0x5d, 0xa7,
-	0xfe, 0x42, 0xd3, 0x0d, 0x55, 0xef, 0xb3, 0x29, 0xe7, 0xa9, 0x33, 0xd0, 0x74, 0x63, 0xb7, 0x8f,
-	0xf6, 0x41, 0xea, 0x99, 0x86, 0x83, 0x0d, 0x67, 0xec, 0xa8, 0x3c, 0x10, 0x08, 0x67, 0x1a, 0x72,
-	0x09, 0x3c, 0xbc, 0x34, 0x5d, 0xce, 0x23, 0xc6, 0xa8, 0x54, 0x7a, 0x61, 0x02, 0xba, 0x07, 0x70,
-	0xae, 0x0d, 0xf5, 0xbe, 0x46, 0x4c, 0xdb, 0xa9, 0xa6, 0x36, 0x93, 0x73, 0xfd, 0xc2, 0x03, 0x97,
-	0xe5, 0xc4, 0xea, 0x6b, 0x04, 0x37, 0x52, 0x74, 0xba, 0x4a, 0x40, 0x12, 0x3d, 0x0b, 0x15, 0xcd,
-	0xb2, 0x54, 0x87, 0x68, 0x04, 0xab, 0xdd, 0x0b, 0x82, 0x1d, 0xe6, 0xa7, 0x8b, 0x4a, 0x49, 0xb3,
-	0xac, 0x63, 0x4a, 0x6d, 0x50, 0x22, 0x7a, 0x06, 0xca, 0xd4, 0x27, 0xeb, 0xda, 0x50, 0x1d, 0x60,
-	0xfd, 0x6c, 0x40, 0x98, 0x3f, 0x4e, 0x2a, 0x25, 0x41, 0x6d, 0x33, 0xa2, 0xdc, 0xf7, 0x76, 0x9c,
-	0xf9, 0x63, 0x84, 0x20, 0xd5, 0xd7, 0x88, 0xc6, 0x2c, 0x59, 0x54, 0x58, 0x9b, 0xd2, 0x2c, 0x8d,
-	0x0c, 0x84, 0x7d, 0x58, 0x1b, 0xad, 0x41, 0x46, 0xa8, 0x4d, 0x32, 0xb5, 0xa2, 0x87, 0x56, 0x21,
-	0x6d, 0xd9, 0xe6, 0x39, 0x66, 0x5b, 0x97, 0x53, 0x78, 0x47, 0xfe, 0x38, 0x01, 0x2b, 0x33, 0x9e,
-	0x9b, 0xea, 0x1d, 0x68, 0xce, 0xc0, 0x1d, 0x8b, 0xb6, 0xd1, 0x2b, 0x54, 0xaf, 0xd6, 0xc7, 0xb6,
-	0x88, 0x76, 0xd5, 0x59, 0x53, 0xb7, 0xd9, 0x77, 0x61, 0x1a, 0xc1, 0x8d, 0xf6, 0x40, 0x1a, 0x6a,
-	0x0e, 0x51, 0xb9, 0x27, 0x54, 0x03, 0x91, 0xef, 0x89, 0x19, 0x23, 0x73, 0xbf, 0x49, 0x0f, 0xb4,
-	0x50, 0x52, 0xa6, 0xa2, 0x3e, 0x15, 0x9d, 0xc0, 0x6a, 0xf7, 0xe2, 0x23, 0xcd, 0x20, 0xba, 0x81,
-	0xd5, 0x99, 0x5d, 0x9b, 0x0d, 0xa5, 0x6f, 0xeb, 0x4e, 0x17, 0x0f, 0xb4, 0x73, 0xdd, 0x74, 0xa7,
-	0x75, 0xcd, 0x93, 0xf7, 0x76, 0xd4, 0x91, 0x15, 0x28, 0x87, 0x43, 0x0f, 0x2a, 0x43, 0x82, 0x4c,
-	0xc4, 0xfa, 0x13, 0x64, 0x82, 0x5e, 0x82, 0x14, 0x5d, 0x23, 0x5b, 0x7b, 0x79, 0xce, 0x40, 0x42,
-	0xae, 0x73, 0x61, 0x61, 0x85, 0x71, 0xca, 0xb2, 0x77, 0x1b, 0xbc, 0x70, 0x34, 0xad, 0x55, 0xbe,
-	0x05, 0x95, 0xa9, 0x78, 0x13, 0xd8, 0xbe, 0x78, 0x70, 0xfb, 0xe4, 0x0a, 0x94, 0x42, 0xc1, 0x45,
-	0x5e, 0x83, 0xd5, 0x79, 0xb1, 0x42, 0x1e, 0x78, 0xf4, 0x90, 0xcf, 0x47, 0x77, 0x20, 0xe7, 0x05,
-	0x0b, 0x7e, 0x1b, 0xaf, 0xcf, 0xac, 0xc2, 0x65, 0x56, 0x3c, 0x56, 0x7a, 0x0d, 0xe9, 0xa9, 0x66,
-	0xc7, 0x21, 0xc1, 0x26, 0x9e, 0xd5, 0x2c, 0xab, 0xad, 0x39, 0x03, 0xf9, 0x3d, 0xa8, 0x46, 0x05,
-	0x82, 0xa9, 0x65, 0xa4, 0xbc, 0x53, 0xb8, 0x06, 0x99, 0x53, 0xd3, 0x1e, 0x69, 0x84, 0x29, 0x2b,
-	0x29, 0xa2, 0x47, 0x4f, 0x27, 0x0f, 0x0a, 0x49, 0x46, 0xe6, 0x1d, 0x59, 0x85, 0xeb, 0x91, 0xc1,
-	0x80, 0x8a, 0xe8, 0x46, 0x1f, 0x73, 0x7b, 0x96, 0x14, 0xde, 0xf1, 0x15, 0xf1, 0xc9, 0xf2, 0x0e,
-	0x1d, 0xd6, 0x61, 0x6b, 0x65, 0xfa, 0xf3, 0x8a, 0xe8, 0xc9, 0x9f, 0x25, 0x61, 0x6d, 0x7e, 0x48,
-	0x40, 0x9b, 0x50, 0x1c, 0x69, 0x13, 0x95, 0x4c, 0xc4, 0x5d, 0xe6, 0xdb, 0x01, 0x23, 0x6d, 0xd2,
-	0x99, 0xf0, 0x8b, 0x2c, 0x41, 0x92, 0x4c, 0x9c, 0x6a, 0x62, 0x33, 0x79, 0xb3, 0xa8, 0xd0, 0x26,
-	0x3a, 0x81, 0x95, 0xa1, 0xd9, 0xd3, 0x86, 0x6a, 0xe0, 0xc4, 0x8b, 0xc3, 0xfe, 0xf4, 0x8c, 0xb1,
-	0x5b, 0x13, 0x46, 0xe9, 0xcf, 0x1c, 0xfa, 0x0a, 0xd3, 0xb1, 0xef, 0x9d, 0x7c, 0x74, 0x17, 0x0a,
-	0x23, 0xff, 0x20, 0x5f, 0xe1, 0xb0, 0x07, 0xc5, 0x02, 0x5b, 0x92, 0x0e, 0x39, 0x06, 0xd7, 0x45,
-	0x67, 0xae, 0xec, 0xa2, 0x5f, 0x82, 0x55, 0x03, 0x4f, 0x48, 0xe0, 0x22, 0xf2, 0x73, 0x92, 0x65,
-	0xa6, 0x47, 0xf4, 0x9b, 0x7f, 0xc9, 0xe8, 0x91, 0x41, 0xb7, 0x58, 0x50, 0xb5, 0x4c, 0x07, 0xdb,
-	0xaa, 0xd6, 0xef, 0xdb, 0xd8, 0x71, 0x58, 0x32, 0x58, 0x64, 0x91, 0x92, 0xd1, 0xeb, 0x9c, 0x2c,
-	0xff, 0x32, 0xb8, 0x35, 0xa1, 0x20, 0xea, 0x1a, 0x3e, 0xee, 0x1b, 0xfe, 0x18, 0x56, 0x85, 0x7c,
-	0x3f, 0x64, 0xfb, 0xc4, 0xb2, 0x8e, 0x06, 0xb9, 0xe2, 0xd1, 0x66, 0x4f, 0x7e, 0x3b, 0xb3, 0xbb,
-	0xbe, 0x34, 0x15, 0xf0, 0xa5, 0xff, 0x65, 0x5b, 0xf1, 0xa7, 0x3c, 0xe4, 0x14, 0xec, 0x58, 0x34,
-	0x70, 0xa2, 0x06, 0xe4, 0xf1, 0xa4, 0x87, 0x2d, 0xe2, 0xe6, 0x1a, 0xf3, 0xc1, 0x00, 0xe7, 0x6e,
-	0xb9, 0x9c, 0x34, 0x13, 0xf7, 0xc4, 0xd0, 0xcb, 0x02, 0x6c, 0x45, 0xe3, 0x26, 0x21, 0x1e, 0x44,
-	0x5b, 0xaf, 0xb8, 0x68, 0x2b, 0x19, 0x99, 0x7c, 0x73, 0xa9, 0x29, 0xb8, 0xf5, 0xb2, 0x80, 0x5b,
-	0xa9, 0x05, 0x83, 0x85, 0xf0, 0x56, 0x33, 0x84, 0xb7, 0x32, 0x0b, 0x96, 0x19, 0x01, 0xb8, 0x5e,
-	0x71, 0x01, 0x57, 0x76, 0xc1, 0x8c, 0xa7, 0x10, 0xd7, 0xbd, 0x30, 0xe2, 0xca, 0x45, 0x38, 0x10,
-	0x57, 0x3a, 0x12, 0x72, 0xbd, 0x19, 0x80, 0x5c, 0xf9, 0x48, 0xbc, 0xc3, 0x95, 0xcc, 0xc1, 0x5c,
-	0xcd, 0x10, 0xe6, 0x82, 0x05, 0x36, 0x88, 0x00, 0x5d, 0x6f, 0x05, 0x41, 0x57, 0x21, 0x12, 0xb7,
-	0x89, 0xfd, 0x9e, 0x87, 0xba, 0x5e, 0xf7, 0x50, 0x57, 0x31, 0x12, 0x36, 0x8a, 0x35, 0x4c, 0xc3,
-	0xae, 0xc3, 0x19, 0xd8, 0xc5, 0x61, 0xd2, 0xb3, 0x91, 0x2a, 0x16, 0xe0, 0xae, 0xc3, 0x19, 0xdc,
-	0x55, 0x5e, 0xa0, 0x70, 0x01, 0xf0, 0xfa, 0xe9, 0x7c, 0xe0, 0x15, 0x0d, 0x8d, 0xc4, 0x34, 0x97,
-	0x43, 0x5e, 0x6a, 0x04, 0xf2, 0xe2, 0xe8, 0xe8, 0xf9, 0x48, 0xf5, 0x4b, 0x43, 0xaf, 0x93, 0x39,
-	0xd0, 0x8b, 0x83, 0xa4, 0x9b, 0x91, 0xca, 0x97, 0xc0, 0x5e, 0x27, 0x73, 0xb0, 0x17, 0x5a, 0xa8,
-	0xf6, 0x2a, 0xe0, 0x2b, 0x2d, 0x65, 0xe4, 0x5b, 0x34, 0xf5, 0x9d, 0xf2, 0x53, 0x34, 0x7f, 0xc0,
-	0xb6, 0x6d, 0xda, 0x02, 0x46, 0xf1, 0x8e, 0x7c, 0x93, 0x26, 0xe3, 0xbe, 0x4f, 0xba, 0x04, 0xa8,
-	0xb1, 0x3c, 0x2d, 0xe0, 0x87, 0xe4, 0xdf, 0xc5, 0x7d, 0x59, 0x96, 0xc3, 0x06, 0x13, 0xf9, 0xbc,
-	0x48, 0xe4, 0x03, 0xf0, 0x2d, 0x11, 0x86, 0x6f, 0x1b, 0x50, 0xa0, 0xf9, 0xd7, 0x14, 0x32, 0xd3,
-	0x2c, 0x0f, 0x99, 0xdd, 0x86, 0x15, 0x16, 0xf1, 0x38, 0xc8, 0x13, 0x61, 0x25, 0xc5, 0xc2, 0x4a,
-	0x85, 0x7e, 0xe0, 0x17, 0x8a, 0xc7, 0x97, 0x17, 0xe1, 0x5a, 0x80, 0xd7, 0xcb, 0xeb, 0x38, 0x4c,
-	0x91, 0x3c, 0xee, 0xba, 0x48, 0xf0, 0xfe, 0x10, 0xf7, 0x2d, 0xe4, 0x43, 0xba, 0x79, 0xe8, 0x2b,
-	0xfe, 0x6f, 0x42, 0x5f, 0x89, 0x6f, 0x8d, 0xbe, 0x82, 0x79, 0x6a, 0x32, 0x9c, 0xa7, 0xfe, 0x3d,
-	0xee, 0xef, 0x89, 0x87, 0xa5, 0x7a, 0x66, 0x1f, 0x8b, 0xcc, 0x91, 0xb5, 0x69, 0x52, 0x31, 0x34,
-	0xcf, 0x44, 0x7e, 0x48, 0x9b, 0x94, 0xcb, 0x0b, 0x1c, 0x79, 0x11, 0x17, 0xbc, 0xa4, 0x93, 0x07,
-	0x6e, 0x91, 0x74, 0x4a, 0x90, 0x7c, 0x84, 0x79, 0x5d, 0xad, 0xa8, 0xd0, 0x26, 0xe5, 0x63, 0x47,
-	0x4d, 0x04, 0x60, 0xde, 0x41, 0xaf, 0x41, 0x9e, 0x55, 0x44, 0x55, 0xd3, 0x72, 0x84, 0x5b, 0x0f,
-	0xe5, 0x26, 0xbc, 0xf0, 0xb9, 0x75, 0x44, 0x79, 0x0e, 0x2d, 0x47, 0xc9, 0x59, 0xa2, 0x15, 0xc8,
-	0x18, 0xf2, 0xa1, 0x8c, 0xe1, 0x06, 0xe4, 0xe9, 0xec, 0x1d, 0x4b, 0xeb, 0x61, 0xe6, 0xa2, 0xf3,
-	0x8a, 0x4f, 0x90, 0x1f, 0x02, 0x9a, 0x0d, 0x12, 0xa8, 0x0d, 0x19, 0x7c, 0x8e, 0x0d, 0xc2, 0x33,
-	0xa8, 0xc2, 0xce, 0xda, 0x6c, 0x6a, 0x4a, 0x3f, 0x37, 0xaa, 0xd4, 0xc8, 0x7f, 0xfb, 0x6a, 0x43,
-	0xe2, 0xdc, 0x2f, 0x98, 0x23, 0x9d, 0xe0, 0x91, 0x45, 0x2e, 0x14, 0x21, 0x2f, 0xff, 0x36, 0x41,
-	0x01, 0x4c, 0x28, 0x80, 0xcc, 0xb5, 0xad, 0x7b, 0xe4, 0x13, 0x01, 0xec, 0xba, 0x9c, 0xbd, 0xd7,
-	0x01, 0xce, 0x34, 0x47, 0xfd, 0x50, 0x33, 0x08, 0xee, 0x0b, 0xa3, 0x07, 0x28, 0xa8, 0x06, 0x39,
-	0xda, 0x1b, 0x3b, 0xb8, 0x2f, 0x60, 0xb4, 0xd7, 0x0f, 0xac, 0x33, 0xfb, 0xdd, 0xd6, 0x19, 0xb6,
-	0x72, 0x6e, 0xca, 0xca, 0xf7, 0x53, 0xb9, 0xbc, 0x54, 0x74, 0x21, 0x05, 0xdd, 0x33, 0xdd, 0xb4,
-	0x75, 0x72, 0xa1, 0x94, 0x46, 0x78, 0x64, 0x99, 0xe6, 0x50, 0xe5, 0x3e, 0xe4, 0x17, 0x09, 0xff,
-	0x36, 0xf9, 0x90, 0xf0, 0x7f, 0xce, 0x5c, 0xf2, 0xaf, 0x58, 0x9d, 0x28, 0x1c, 0xf0, 0xd1, 0x31,
-	0xac, 0x78, 0x97, 0x59, 0x1d, 0xb3, 0x4b, 0xee, 0x1e, 0xcf, 0x65, 0xbd, 0x81, 0x74, 0x1e, 0x26,
-	0x3b, 0xe8, 0x5d, 0x78, 0x7c, 0xca, 0x53, 0x79, 0xaa, 0x13, 0xcb, 0x3a, 0xac, 0xc7, 0xc2, 0x0e,
-	0xcb, 0x55, 0xed, 0x1b, 0x2b, 0xf9, 0x1d, 0xef, 0xd0, 0x2e, 0x94, 0xc3, 0xf9, 0xcb, 0xdc, 0xed,
-	0x7f, 0x1a, 0x4a, 0x36, 0x26, 0x9a, 0x6e, 0xa8, 0xa1, 0xe2, 0x4e, 0x91, 0x13, 0x45, 0xc9, 0xe8,
-	0x08, 0x1e, 0x9b, 0x9b, 0xc7, 0xa0, 0x57, 0x21, 0xef, 0xa7, 0x40, 0xdc, 0xaa, 0x97, 0x80, 0x7f,
-	0x9f, 0x57, 0xfe, 0x7d, 0xdc, 0x57, 0x19, 0x2e, 0x27, 0xb4, 0x20, 0x63, 0x63, 0x67, 0x3c, 0xe4,
-	0x00, 0xbf, 0xbc, 0xf3, 0xe2, 0x72, 0x19, 0x10, 0xa5, 0x8e, 0x87, 0x44, 0x11, 0xc2, 0xf2, 0x43,
-	0xc8, 0x70, 0x0a, 0x2a, 0x40, 0xf6, 0xe4, 0x60, 0xef, 0xe0, 0xf0, 0x9d, 0x03, 0x29, 0x86, 0x00,
-	0x32, 0xf5, 0x66, 0xb3, 0x75, 0xd4, 0x91, 0xe2, 0x28, 0x0f, 0xe9, 0x7a, 0xe3, 0x50, 0xe9, 0x48,
-	0x09, 0x4a, 0x56, 0x5a, 0xf7, 0x5b, 0xcd, 0x8e, 0x94, 0x44, 0x2b, 0x50, 0xe2, 0x6d, 0xf5, 0xde,
-	0xa1, 0xf2, 0x76, 0xbd, 0x23, 0xa5, 0x02, 0xa4, 0xe3, 0xd6, 0xc1, 0xdd, 0x96, 0x22, 0xa5, 0xe5,
-	0xff, 0x83, 0xeb, 0x91, 0x39, 0x93, 0x5f, 0x2b, 0x88, 0x07, 0x6a, 0x05, 0xf2, 0x67, 0x09, 0xa8,
-	0x45, 0x27, 0x42, 0xe8, 0xfe, 0xd4, 0xc2, 0x77, 0xae, 0x90, 0x45, 0x4d, 0xad, 0x1e, 0x3d, 0x03,
-	0x65, 0x1b, 0x9f, 0x62, 0xd2, 0x1b, 0xf0, 0xc4, 0x8c, 0x07, 0xc0, 0x92, 0x52, 0x12, 0x54, 0x26,
-	0xe4, 0x70, 0xb6, 0xf7, 0x71, 0x8f, 0xa8, 0xdc, 0xc7, 0xf0, 0x43, 0x97, 0xa7, 0x6c, 0x94, 0x7a,
-	0xcc, 0x89, 0xf2, 0x7b, 0x57, 0xb2, 0x65, 0x1e, 0xd2, 0x4a, 0xab, 0xa3, 0xbc, 0x2b, 0x25, 0x11,
-	0x82, 0x32, 0x6b, 0xaa, 0xc7, 0x07, 0xf5, 0xa3, 0xe3, 0xf6, 0x21, 0xb5, 0xe5, 0x35, 0xa8, 0xb8,
-	0xb6, 0x74, 0x89, 0x69, 0xf9, 0x79, 0x78, 0x3c, 0x22, 0x8b, 0x9b, 0xc5, 0xe4, 0xf2, 0xaf, 0xe3,
-	0x41, 0xee, 0x30, 0x82, 0x3f, 0x84, 0x8c, 0x43, 0x34, 0x32, 0x76, 0x84, 0x11, 0x5f, 0x5d, 0x36,
-	0xad, 0xdb, 0x72, 0x1b, 0xc7, 0x4c, 0x5c, 0x11, 0x6a, 0xe4, 0x3b, 0x50, 0x0e, 0x7f, 0x89, 0xb6,
-	0x81, 0x7f, 0x88, 0x12, 0xf2, 0xbb, 0x00, 0x81, 0xea, 0xe2, 0x2a, 0xa4, 0x6d, 0x73, 0x6c, 0xf4,
-	0xd9, 0xa4, 0xd2, 0x0a, 0xef, 0xa0, 0x3b, 0x90, 0x3e, 0x37, 0xb9, 0xcf, 0x98, 0x7f, 0x71, 0x1e,
-	0x98, 0x04, 0x07, 0x4a, 0x09, 0x9c, 0x5b, 0xd6, 0x01, 0xcd, 0x56, 0x78, 0x22, 0x86, 0x78, 0x33,
-	0x3c, 0xc4, 0x53, 0x91, 0xb5, 0xa2, 0xf9, 0x43, 0x7d, 0x04, 0x69, 0xe6, 0x6d, 0xa8, 0xe7, 0x60,
-	0x55, 0x4a, 0x91, 0x5a, 0xd2, 0x36, 0xfa, 0x19, 0x80, 0x46, 0x88, 0xad, 0x77, 0xc7, 0xfe, 0x00,
-	0x1b, 0xf3, 0xbd, 0x55, 0xdd, 0xe5, 0x6b, 0xdc, 0x10, 0x6e, 0x6b, 0xd5, 0x17, 0x0d, 0xb8, 0xae,
-	0x80, 0x42, 0xf9, 0x00, 0xca, 0x61, 0x59, 0x37, 0x19, 0xe2, 0x73, 0x08, 0x27, 0x43, 0x3c, 0xb7,
-	0x15, 0xc9, 0x90, 0x97, 0x4a, 0x25, 0x79, 0x41, 0x9a, 0x75, 0xe4, 0x4f, 0xe2, 0x90, 0xeb, 0x4c,
-	0xc4, 0x39, 0x8e, 0x28, 0x86, 0xfa, 0xa2, 0x89, 0x60, 0xe9, 0x8f, 0x57, 0x57, 0x93, 0x5e, 0xcd,
-	0xf6, 0x2d, 0xef, 0xa6, 0xa6, 0x96, 0xc5, 0xae, 0x6e, 0xed, 0x5a, 0x78, 0xa7, 0x37, 0x20, 0xef,
-	0xc5, 0x1a, 0x9a, 0xa3, 0xbb, 0x75, 0x92, 0xb8, 0x48, 0x30, 0x79, 0x97, 0x95, 0xd6, 0xcd, 0x0f,
-	0x45, 0x71, 0x31, 0xa9, 0xf0, 0x8e, 0xdc, 0x87, 0xca, 0x54, 0xa0, 0x42, 0x6f, 0x40, 0xd6, 0x1a,
-	0x77, 0x55, 0xd7, 0x3c, 0x53, 0xd5, 0x24, 0x37, 0xfb, 0x1b, 0x77, 0x87, 0x7a, 0x6f, 0x0f, 0x5f,
-	0xb8, 0x93, 0xb1, 0xc6, 0xdd, 0x3d, 0x6e, 0x45, 0x3e, 0x4a, 0x22, 0x38, 0xca, 0x39, 0xe4, 0xdc,
-	0x43, 0x81, 0xbe, 0x0f, 0x79, 0x2f, 0x06, 0x7a, 0x7f, 0x5c, 0x22, 0x83, 0xa7, 0x50, 0xef, 0x8b,
-	0x50, 0x28, 0xe1, 0xe8, 0x67, 0x86, 0x5b, 0x43, 0xe3, 0x98, 0x3d, 0xc1, 0x76, 0xa7, 0xc2, 0x3f,
-	0xec, 0xbb, 0x10, 0x41, 0xfe, 0x4d, 0x1c, 0xa4, 0xe9, 0x53, 0xf9, 0x9f, 0x9c, 0x00, 0x75, 0x8a,
-	0xf4, 0xf4, 0xab, 0x98, 0x4e, 0xc2, 0xc3, 0x46, 0x45, 0xa5, 0x44, 0xa9, 0x2d, 0x97, 0x28, 0x7f,
-	0x9c, 0x80, 0x42, 0xa0, 0x42, 0x87, 0xfe, 0x3f, 0x70, 0x45, 0xca, 0x73, 0x72, 0x8b, 0x00, 0xaf,
-	0x5f, 0xcc, 0x0f, 0x2f, 0x2c, 0x71, 0xf5, 0x85, 0x45, 0xfd, 0x94, 0x71, 0x0b, 0x7e, 0xa9, 0x2b,
-	0x17, 0xfc, 0x5e, 0x00, 0x44, 0x4c, 0xa2, 0x0d, 0xd5, 0x73, 0x93, 0xe8, 0xc6, 0x99, 0xca, 0x8f,
-	0x06, 0xcf, 0xf8, 0x24, 0xf6, 0xe5, 0x01, 0xfb, 0x70, 0xc4, 0x4e, 0xc9, 0xcf, 0xe3, 0x90, 0xf3,
-	0x42, 0xf7, 0x55, 0x6b, 0xf3, 0x6b, 0x90, 0x11, 0xd1, 0x89, 0x17, 0xe7, 0x45, 0x6f, 0x6e, 0x65,
-	0xb3, 0x06, 0xb9, 0x11, 0x26, 0x1a, 0xcb, 0x5f, 0x38, 0xac, 0xf4, 0xfa, 0xb7, 0x5f, 0x87, 0x42,
-	0xe0, 0x37, 0x09, 0xf5, 0x13, 0x07, 0xad, 0x77, 0xa4, 0x58, 0x2d, 0xfb, 0xc9, 0xe7, 0x9b, 0xc9,
-	0x03, 0xfc, 0x21, 0xbd, 0x61, 0x4a, 0xab, 0xd9, 0x6e, 0x35, 0xf7, 0xa4, 0x78, 0xad, 0xf0, 0xc9,
-	0xe7, 0x9b, 0x59, 0x05, 0xb3, 0x62, 0xd4, 0xed, 0x3d, 0xa8, 0x4c, 0x6d, 0x4c, 0xd8, 0xbf, 0x23,
-	0x28, 0xdf, 0x3d, 0x39, 0xda, 0xdf, 0x6d, 0xd6, 0x3b, 0x2d, 0xf5, 0xc1, 0x61, 0xa7, 0x25, 0xc5,
-	0xd1, 0xe3, 0x70, 0x6d, 0x7f, 0xf7, 0x87, 0xed, 0x8e, 0xda, 0xdc, 0xdf, 0x6d, 0x1d, 0x74, 0xd4,
-	0x7a, 0xa7, 0x53, 0x6f, 0xee, 0x49, 0x89, 0x9d, 0x7f, 0x00, 0x54, 0xea, 0x8d, 0xe6, 0x2e, 0x8d,
-	0xcf, 0x7a, 0x4f, 0x63, 0xb0, 0xbf, 0x09, 0x29, 0x06, 0xec, 0x2f, 0x7d, 0xf8, 0x51, 0xbb, 0xbc,
-	0x52, 0x89, 0xee, 0x41, 0x9a, 0x61, 0x7e, 0x74, 0xf9, 0x4b, 0x90, 0xda, 0x82, 0xd2, 0x25, 0x9d,
-	0x0c, 0xbb, 0x4e, 0x97, 0x3e, 0x0d, 0xa9, 0x5d, 0x5e, 0xc9, 0x44, 0x0a, 0xe4, 0x7d, 0x94, 0xb1,
-	0xf8, 0xa9, 0x44, 0x6d, 0x09, 0xef, 0x88, 0xf6, 0x21, 0xeb, 0xc2, 0xbc, 0x45, 0x8f, 0x37, 0x6a,
-	0x0b, 0x4b, 0x8d, 0xd4, 0x5c, 0x1c, 0x8e, 0x5f, 0xfe, 0x12, 0xa5, 0xb6, 0xa0, 0x6e, 0x8a, 0x76,
-	0x21, 0x23, 0x32, 0xe7, 0x05, 0x0f, 0x32, 0x6a, 0x8b, 0x4a, 0x87, 0xd4, 0x68, 0x7e, 0xa1, 0x63,
-	0xf1, 0xfb, 0x9a, 0xda, 0x12, 0x25, 0x61, 0x74, 0x02, 0x10, 0x00, 0xdf, 0x4b, 0x3c, 0x9c, 0xa9,
-	0x2d, 0x53, 0xea, 0x45, 0x87, 0x90, 0xf3, 0xd0, 0xd3, 0xc2, 0x67, 0x2c, 0xb5, 0xc5, 0x35, 0x57,
-	0xf4, 0x10, 0x4a, 0x61, 0xd4, 0xb0, 0xdc, 0xe3, 0x94, 0xda, 0x92, 0xc5, 0x54, 0xaa, 0x3f, 0x0c,
-	0x21, 0x96, 0x7b, 0xac, 0x52, 0x5b, 0xb2, 0xb6, 0x8a, 0xde, 0x87, 0x95, 0xd9, 0x14, 0x7f, 0xf9,
-	0xb7, 0x2b, 0xb5, 0x2b, 0x54, 0x5b, 0xd1, 0x08, 0xd0, 0x1c, 0x68, 0x70, 0x85, 0xa7, 0x2c, 0xb5,
-	0xab, 0x14, 0x5f, 0x51, 0x1f, 0x2a, 0xd3, 0xf9, 0xf6, 0xb2, 0x4f, 0x5b, 0x6a, 0x4b, 0x17, 0x62,
-	0xf9, 0x28, 0xe1, 0x3c, 0x7d, 0xd9, 0xa7, 0x2e, 0xb5, 0xa5, 0xeb, 0xb2, 0x8d, 0xfa, 0x17, 0x5f,
-	0xaf, 0xc7, 0xbf, 0xfc, 0x7a, 0x3d, 0xfe, 0xd7, 0xaf, 0xd7, 0xe3, 0x9f, 0x7e, 0xb3, 0x1e, 0xfb,
-	0xf2, 0x9b, 0xf5, 0xd8, 0x9f, 0xbf, 0x59, 0x8f, 0xfd, 0xf8, 0xb9, 0x33, 0x9d, 0x0c, 0xc6, 0xdd,
-	0xad, 0x9e, 0x39, 0xda, 0xee, 0x99, 0x23, 0x4c, 0xba, 0xa7, 0xc4, 0x6f, 0xf8, 0xef, 0x0f, 0xbb,
-	0x19, 0x16, 0x1f, 0x5f, 0xfe, 0x57, 0x00, 0x00, 0x00, 0xff, 0xff, 0xad, 0xbb, 0x1b, 0xa2, 0x9f,
-	0x28, 0x00, 0x00,
+	// 3161 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcd, 0x73, 0x23, 0xc5,
+	0x15, 0xd7, 0xb7, 0xa5, 0xa7, 0xaf, 0x71, 0xaf, 0x77, 0xd1, 0x0e, 0x8b, 0xbd, 0x0c, 0x01, 0x76,
+	0x17, 0xf0, 0x12, 0x13, 0xbe, 0x8a, 0x90, 0x20, 0x6b, 0xb5, 0x91, 0xd7, 0xc6, 0x76, 0xc6, 0xf2,
+	0x52, 0xe4, 0x63, 0x87, 0x91, 0xd4, 0xb6, 0x86, 0x95, 0x34, 0xc3, 0x4c, 0xcb, 0xd8, 0x1c, 0x93,
+	0x4a, 0x55, 0x8a, 0xca, 0x81, 0x23, 0x17, 0x52, 0x95, 0x03, 0x97, 0xfc, 0x11, 0x39, 0xe5, 0xc0,
+	0x21, 0x55, 0xe1, 0x98, 0x43, 0x0a, 0x52, 0x70, 0xcb, 0x3f, 0x90, 0x6b, 0xaa, 0xbf, 0x46, 0x33,
+	0x92, 0xc6, 0x1a, 0x43, 0x92, 0xaa, 0x14, 0xb7, 0xee, 0x37, 0xef, 0xbd, 0xe9, 0x7e, 0xdd, 0xfd,
+	0xde, 0xfb, 0xbd, 0x6e, 0x78, 0x94, 0xe0, 0x51, 0x0f, 0xbb, 0x43, 0x6b, 0x44, 0x6e, 0x9b, 0x9d,
+	0xae, 0x75, 0x9b, 0x9c, 0x39, 0xd8, 0x5b, 0x77, 0x5c, 0x9b, 0xd8, 0xa8, 0x3a, 0xf9, 0xb8, 0x4e,
+	0x3f, 0xaa, 0x8f, 0x05, 0xb8, 0xbb, 0xee, 0x99, 0x43, 0xec, 0xdb, 0x8e, 0x6b, 0xdb, 0x47, 0x9c,
+	0x5f, 0xbd, 0x16, 0xf8, 0xcc, 0xf4, 0x04, 0xb5, 0xa9, 0xd7, 0x66, 0x85, 0x1f, 0xe2, 0x33, 0xf9,
+	0xf5, 0xb1, 0x19, 0x59, 0xc7, 0x74, 0xcd, 0xa1, 0xfc, 0xbc, 0x76, 0x6c, 0xdb, 0xc7, 0x03, 0x7c,
+	0x9b, 0xf5, 0x3a, 0xe3, 0xa3, 0xdb, 0xc4, 0x1a, 0x62, 0x8f, 0x98, 0x43, 0x47, 0x30, 0xac, 0x1c,
+	0xdb, 0xc7, 0x36, 0x6b, 0xde, 0xa6, 0x2d, 0x4e, 0xd5, 0xfe, 0x5a, 0x80, 0x25, 0x1d, 0xbf, 0x37,
+	0xc6, 0x1e, 0x41, 0x1b, 0x90, 0xc1, 0xdd, 0xbe, 0x5d, 0x4b, 0x5e, 0x4f, 0xde, 0x28, 0x6e, 0x5c,
+	0x5b, 0x9f, 0x9a, 0xdc, 0xba, 0xe0, 0x6b, 0x76, 0xfb, 0x76, 0x2b, 0xa1, 0x33, 0x5e, 0xf4, 0x22,
+	0x64, 0x8f, 0x06, 0x63, 0xaf, 0x5f, 0x4b, 0x31, 0xa1, 0xc7, 0xa2, 0x84, 0xee, 0x52, 0xa6, 0x56,
+	0x42, 0xe7, 0xdc, 0xf4, 0x57, 0xd6, 0xe8, 0xc8, 0xae, 0xa5, 0xcf, 0xff, 0xd5, 0xd6, 0xe8, 0x88,
+	0xfd, 0x8a, 0xf2, 0xa2, 0x4d, 0x00, 0x6b, 0x64, 0x11, 0xa3, 0xdb, 0x37, 0xad, 0x51, 0x2d, 0xcb,
+	0x24, 0x1f, 0x8f, 0x96, 0xb4, 0x48, 0x83, 0x32, 0xb6, 0x12, 0x7a, 0xc1, 0x92, 0x1d, 0x3a, 0xdc,
+	0xf7, 0xc6, 0xd8, 0x3d, 0xab, 0xe5, 0xce, 0x1f, 0xee, 0x4f, 0x29, 0x13, 0x1d, 0x2e, 0xe3, 0x46,
+	0x4d, 0x28, 0x76, 0xf0, 0xb1, 0x35, 0x32, 0x3a, 0x03, 0xbb, 0xfb, 0xb0, 0xb6, 0xc4, 0x84, 0xb5,
+	0x28, 0xe1, 0x4d, 0xca, 0xba, 0x49, 0x39, 0x5b, 0x09, 0x1d, 0x3a, 0x7e, 0x0f, 0xfd, 0x10, 0xf2,
+	0xdd, 0x3e, 0xee, 0x3e, 0x34, 0xc8, 0x69, 0x2d, 0xcf, 0x74, 0xac, 0x45, 0xe9, 0x68, 0x50, 0xbe,
+	0xf6, 0x69, 0x2b, 0xa1, 0x2f, 0x75, 0x79, 0x93, 0xce, 0xbf, 0x87, 0x07, 0xd6, 0x09, 0x76, 0xa9,
+	0x7c, 0xe1, 0xfc, 0xf9, 0xdf, 0xe1, 0x9c, 0x4c, 0x43, 0xa1, 0x27, 0x3b, 0xe8, 0xc7, 0x50, 0xc0,
+	0xa3, 0x9e, 0x98, 0x06, 0x30, 0x15, 0xd7, 0x23, 0xd7, 0x79, 0xd4, 0x93, 0x93, 0xc8, 0x63, 0xd1,
+	0x46, 0xaf, 0x40, 0xae, 0x6b, 0x0f, 0x87, 0x16, 0xa9, 0x15, 0x99, 0xf4, 0x6a, 0xe4, 0x04, 0x18,
+	0x57, 0x2b, 0xa1, 0x0b, 0x7e, 0xb4, 0x0b, 0x95, 0x81, 0xe5, 0x11, 0xc3, 0x1b, 0x99, 0x8e, 0xd7,
+	0xb7, 0x89, 0x57, 0x2b, 0x31, 0x0d, 0x4f, 0x46, 0x69, 0xd8, 0xb1, 0x3c, 0x72, 0x20, 0x99, 0x5b,
+	0x09, 0xbd, 0x3c, 0x08, 0x12, 0xa8, 0x3e, 0xfb, 0xe8, 0x08, 0xbb, 0xbe, 0xc2, 0x5a, 0xf9, 0x7c,
+	0x7d, 0x7b, 0x94, 0x5b, 0xca, 0x53, 0x7d, 0x76, 0x90, 0x80, 0x7e, 0x0e, 0x97, 0x06, 0xb6, 0xd9,
+	0xf3, 0xd5, 0x19, 0xdd, 0xfe, 0x78, 0xf4, 0xb0, 0x56, 0x61, 0x4a, 0x6f, 0x46, 0x0e, 0xd2, 0x36,
+	0x7b, 0x52, 0x45, 0x83, 0x0a, 0xb4, 0x12, 0xfa, 0xf2, 0x60, 0x9a, 0x88, 0x1e, 0xc0, 0x8a, 0xe9,
+	0x38, 0x83, 0xb3, 0x69, 0xed, 0x55, 0xa6, 0xfd, 0x56, 0x94, 0xf6, 0x3a, 0x95, 0x99, 0x56, 0x8f,
+	0xcc, 0x19, 0x2a, 0x6a, 0x83, 0xe2, 0xb8, 0xd8, 0x31, 0x5d, 0x6c, 0x38, 0xae, 0xed, 0xd8, 0x9e,
+	0x39, 0xa8, 0x29, 0x4c, 0xf7, 0xd3, 0x51, 0xba, 0xf7, 0x39, 0xff, 0xbe, 0x60, 0x6f, 0x25, 0xf4,
+	0xaa, 0x13, 0x26, 0x71, 0xad, 0x76, 0x17, 0x7b, 0xde, 0x44, 0xeb, 0xf2, 0x22, 0xad, 0x8c, 0x3f,
+	0xac, 0x35, 0x44, 0xa2, 0x0b, 0x77, 0x64, 0x8d, 0xcc, 0x81, 0xf5, 0x01, 0x16, 0x1b, 0x11, 0x9d,
+	0xbf, 0x70, 0x77, 0x05, 0xb7, 0xdc, 0x8d, 0xe5, 0xa3, 0x20, 0x61, 0x73, 0x09, 0xb2, 0x27, 0xe6,
+	0x60, 0x8c, 0xef, 0x65, 0xf2, 0x19, 0x25, 0xab, 0x3d, 0x0d, 0xc5, 0x80, 0xa3, 0x42, 0x35, 0x58,
+	0x1a, 0x62, 0xcf, 0x33, 0x8f, 0x31, 0xf3, 0x6b, 0x05, 0x5d, 0x76, 0xb5, 0x0a, 0x94, 0x82, 0xce,
+	0x49, 0xfb, 0x28, 0x09, 0xc5, 0x80, 0xdf, 0xa1, 0x92, 0x27, 0xd8, 0xf5, 0x2c, 0x7b, 0x24, 0x25,
+	0x45, 0x17, 0x3d, 0x01, 0x65, 0x36, 0x70, 0x43, 0x7e, 0xa7, 0xce, 0x2f, 0xa3, 0x97, 0x18, 0xf1,
+	0xbe, 0x60, 0x5a, 0x83, 0xa2, 0xb3, 0xe1, 0xf8, 0x2c, 0x69, 0xc6, 0x02, 0xce, 0x86, 0x23, 0x19,
+	0x1e, 0x87, 0x12, 0x9d, 0xa5, 0xcf, 0x91, 0x61, 0x3f, 0x29, 0x52, 0x9a, 0x60, 0xd1, 0xfe, 0x92,
+	0x02, 0x65, 0xda, 0xa1, 0xa1, 0x57, 0x20, 0x43, 0x7d, 0xbb, 0x70, 0xd3, 0xea, 0x3a, 0x77, 0xfc,
+	0xeb, 0xd2, 0xf1, 0xaf, 0xb7, 0xa5, 0xe3, 0xdf, 0xcc, 0x7f, 0xf6, 0xc5, 0x5a, 0xe2, 0xa3, 0x2f,
+	0xd7, 0x92, 0x3a, 0x93, 0x40, 0x57, 0xa9, 0xff, 0x31, 0xad, 0x91, 0x61, 0xf5, 0xd8, 0x90, 0x0b,
+	0xd4, 0xb9, 0x98, 0xd6, 0x68, 0xab, 0x87, 0x76, 0x40, 0xe9, 0xda, 0x23, 0x0f, 0x8f, 0xbc, 0xb1,
+	0x67, 0xf0, 0xc0, 0x52, 0x4b, 0xcf, 0xba, 0x18, 0x1e, 0xae, 0x1a, 0x92, 0x73, 0x9f, 0x31, 0xea,
+	0xd5, 0x6e, 0x98, 0x80, 0xee, 0x02, 0x9c, 0x98, 0x03, 0xab, 0x67, 0x12, 0xdb, 0xf5, 0x6a, 0x99,
+	0xeb, 0xe9, 0xb9, 0x7e, 0xe6, 0xbe, 0x64, 0x39, 0x74, 0x7a, 0x26, 0xc1, 0x9b, 0x19, 0x3a, 0x5c,
+	0x3d, 0x20, 0x89, 0x9e, 0x82, 0xaa, 0xe9, 0x38, 0x86, 0x47, 0x4c, 0x82, 0x8d, 0xce, 0x19, 0xc1,
+	0x1e, 0xf3, 0xfb, 0x25, 0xbd, 0x6c, 0x3a, 0xce, 0x01, 0xa5, 0x6e, 0x52, 0x22, 0x7a, 0x12, 0x2a,
+	0xd4, 0xc7, 0x5b, 0xe6, 0xc0, 0xe8, 0x63, 0xeb, 0xb8, 0x4f, 0x98, 0x7f, 0x4f, 0xeb, 0x65, 0x41,
+	0x6d, 0x31, 0xa2, 0xd6, 0x83, 0x52, 0xd0, 0xbf, 0x23, 0x04, 0x99, 0x9e, 0x49, 0x4c, 0x66, 0xc9,
+	0x92, 0xce, 0xda, 0x94, 0xe6, 0x98, 0xa4, 0x2f, 0xec, 0xc3, 0xda, 0xe8, 0x0a, 0xe4, 0x84, 0xda,
+	0x34, 0x53, 0x2b, 0x7a, 0x68, 0x05, 0xb2, 0x8e, 0x6b, 0x9f, 0x60, 0xb6, 0x74, 0x79, 0x9d, 0x77,
+	0xb4, 0x5f, 0xa7, 0x60, 0x79, 0x26, 0x12, 0x50, 0xbd, 0x7d, 0xd3, 0xeb, 0xcb, 0x7f, 0xd1, 0x36,
+	0x7a, 0x89, 0xea, 0x35, 0x7b, 0xd8, 0x15, 0xd1, 0xb3, 0x36, 0x6b, 0xea, 0x16, 0xfb, 0x2e, 0x4c,
+	0x23, 0xb8, 0xd1, 0x36, 0x28, 0x03, 0xd3, 0x23, 0x06, 0xf7, 0xac, 0x46, 0x20, 0x92, 0x3e, 0x3a,
+	0x63, 0x64, 0xee, 0x87, 0xe9, 0x86, 0x16, 0x4a, 0x2a, 0x54, 0x74, 0x42, 0x45, 0x87, 0xb0, 0xd2,
+	0x39, 0xfb, 0xc0, 0x1c, 0x11, 0x6b, 0x84, 0x8d, 0x99, 0x55, 0x9b, 0x0d, 0xcd, 0x6f, 0x5a, 0x5e,
+	0x07, 0xf7, 0xcd, 0x13, 0xcb, 0x96, 0xc3, 0xba, 0xe4, 0xcb, 0xfb, 0x2b, 0xea, 0x69, 0x3a, 0x54,
+	0xc2, 0xa1, 0x0c, 0x55, 0x20, 0x45, 0x4e, 0xc5, 0xfc, 0x53, 0xe4, 0x14, 0x3d, 0x0f, 0x19, 0x3a,
+	0x47, 0x36, 0xf7, 0xca, 0x9c, 0x1f, 0x09, 0xb9, 0xf6, 0x99, 0x83, 0x75, 0xc6, 0xa9, 0x69, 0xa0,
+	0x4c, 0x87, 0xb7, 0x69, 0xad, 0xda, 0x4d, 0xa8, 0x4e, 0xc5, 0xaf, 0xc0, 0xf2, 0x25, 0x83, 0xcb,
+	0xa7, 0x55, 0xa1, 0x1c, 0x0a, 0x56, 0xda, 0x15, 0x58, 0x99, 0x17, 0x7b, 0xb4, 0x3e, 0xac, 0xcc,
+	0x8b, 0x21, 0xe8, 0x45, 0xc8, 0xfb, 0xc1, 0x87, 0x9f, 0xc6, 0xab, 0x33, 0xb3, 0x90, 0xcc, 0xba,
+	0xcf, 0x4a, 0x8f, 0x21, 0xdd, 0xd5, 0x6c, 0x3b, 0xa4, 0xd8, 0xc0, 0x97, 0x4c, 0xc7, 0x69, 0x99,
+	0x5e, 0x5f, 0x7b, 0x07, 0x6a, 0x51, 0x81, 0x65, 0x6a, 0x1a, 0x19, 0x7f, 0x17, 0x5e, 0x81, 0xdc,
+	0x91, 0xed, 0x0e, 0x4d, 0xc2, 0x94, 0x95, 0x75, 0xd1, 0xa3, 0xbb, 0x93, 0x07, 0x99, 0x34, 0x23,
+	0xf3, 0x8e, 0x66, 0xc0, 0xd5, 0xc8, 0xe0, 0x42, 0x45, 0xac, 0x51, 0x0f, 0x73, 0x7b, 0x96, 0x75,
+	0xde, 0x99, 0x28, 0xe2, 0x83, 0xe5, 0x1d, 0xfa, 0x5b, 0x8f, 0xcd, 0x95, 0xe9, 0x2f, 0xe8, 0xa2,
+	0xa7, 0x7d, 0x9c, 0x86, 0x2b, 0xf3, 0x43, 0x0c, 0xba, 0x0e, 0xa5, 0xa1, 0x79, 0x6a, 0x90, 0x53,
+	0x71, 0x96, 0xf9, 0x72, 0xc0, 0xd0, 0x3c, 0x6d, 0x9f, 0xf2, 0x83, 0xac, 0x40, 0x9a, 0x9c, 0x7a,
+	0xb5, 0xd4, 0xf5, 0xf4, 0x8d, 0x92, 0x4e, 0x9b, 0xe8, 0x10, 0x96, 0x07, 0x76, 0xd7, 0x1c, 0x18,
+	0x81, 0x1d, 0x2f, 0x36, 0xfb, 0x13, 0x33, 0xc6, 0x6e, 0x9e, 0x32, 0x4a, 0x6f, 0x66, 0xd3, 0x57,
+	0x99, 0x8e, 0x1d, 0x7f, 0xe7, 0xa3, 0x3b, 0x50, 0x1c, 0x4e, 0x36, 0xf2, 0x05, 0x36, 0x7b, 0x50,
+	0x2c, 0xb0, 0x24, 0xd9, 0x90, 0x63, 0x90, 0x2e, 0x3a, 0x77, 0x61, 0x17, 0xfd, 0x3c, 0xac, 0x8c,
+	0xf0, 0x29, 0x09, 0x1c, 0x44, 0xbe, 0x4f, 0x96, 0x98, 0xe9, 0x11, 0xfd, 0x36, 0x39, 0x64, 0x74,
+	0xcb, 0xa0, 0x9b, 0x2c, 0x48, 0x3b, 0xb6, 0x87, 0x5d, 0xc3, 0xec, 0xf5, 0x5c, 0xec, 0x79, 0x2c,
+	0xb9, 0x2c, 0xe9, 0x55, 0x49, 0xaf, 0x73, 0xb2, 0xf6, 0xdb, 0xe0, 0xd2, 0x84, 0x83, 0xb2, 0x30,
+	0x7c, 0x72, 0x62, 0xf8, 0x03, 0x58, 0x11, 0xf2, 0xbd, 0x90, 0xed, 0x53, 0x71, 0x1d, 0x0d, 0x92,
+	0xe2, 0xd1, 0x66, 0x4f, 0x7f, 0x33, 0xb3, 0x4b, 0x5f, 0x9a, 0x09, 0xf8, 0xd2, 0xff, 0xb3, 0xa5,
+	0xf8, 0x38, 0x05, 0x2b, 0xf3, 0xd2, 0x9b, 0xef, 0x5a, 0x9c, 0x90, 0x1b, 0x2f, 0xeb, 0x6f, 0x3c,
+	0xed, 0x53, 0x80, 0xbc, 0x8e, 0x3d, 0xc7, 0x1e, 0x79, 0x18, 0x6d, 0x42, 0x01, 0x9f, 0x76, 0xb1,
+	0x43, 0x64, 0x1a, 0x36, 0x1f, 0x77, 0x71, 0xee, 0xa6, 0xe4, 0xa4, 0xa0, 0xc7, 0x17, 0x43, 0x2f,
+	0x08, 0x5c, 0x1b, 0x0d, 0x51, 0x85, 0x78, 0x10, 0xd8, 0xbe, 0x24, 0x81, 0x6d, 0x3a, 0x12, 0xe7,
+	0x70, 0xa9, 0x29, 0x64, 0xfb, 0x82, 0x40, 0xb6, 0x99, 0x05, 0x3f, 0x0b, 0x41, 0xdb, 0x46, 0x08,
+	0xda, 0xe6, 0x16, 0x4c, 0x33, 0x02, 0xdb, 0xbe, 0x24, 0xb1, 0xed, 0xd2, 0x82, 0x11, 0x4f, 0x81,
+	0xdb, 0xbb, 0x61, 0x70, 0x9b, 0x8f, 0xf0, 0xad, 0x52, 0x3a, 0x12, 0xdd, 0xbe, 0x1e, 0x40, 0xb7,
+	0x85, 0x48, 0x68, 0xc9, 0x95, 0xcc, 0x81, 0xb7, 0x8d, 0x10, 0xbc, 0x85, 0x05, 0x36, 0x88, 0xc0,
+	0xb7, 0x6f, 0x04, 0xf1, 0x6d, 0x31, 0x12, 0x22, 0x8b, 0xf5, 0x9e, 0x07, 0x70, 0x5f, 0xf5, 0x01,
+	0x6e, 0x29, 0x12, 0xa1, 0x8b, 0x39, 0x4c, 0x23, 0xdc, 0xbd, 0x19, 0x84, 0xcb, 0x11, 0xe9, 0x53,
+	0x91, 0x2a, 0x16, 0x40, 0xdc, 0xbd, 0x19, 0x88, 0x5b, 0x59, 0xa0, 0x70, 0x01, 0xc6, 0xfd, 0xc5,
+	0x7c, 0x8c, 0x1b, 0x8d, 0x42, 0xc5, 0x30, 0xe3, 0x81, 0x5c, 0x23, 0x02, 0xe4, 0x72, 0x20, 0xfa,
+	0x4c, 0xa4, 0xfa, 0xd8, 0x28, 0xf7, 0x70, 0x0e, 0xca, 0xe5, 0x78, 0xf4, 0x46, 0xa4, 0xf2, 0x18,
+	0x30, 0xf7, 0x70, 0x0e, 0xcc, 0x45, 0x0b, 0xd5, 0x2e, 0xc4, 0xb9, 0x7b, 0x33, 0x38, 0xf7, 0xd2,
+	0x82, 0xd5, 0x8b, 0x0f, 0x74, 0xb3, 0x4a, 0x4e, 0xbb, 0x09, 0xcb, 0x52, 0xd0, 0x77, 0x7c, 0x34,
+	0x57, 0xc3, 0xae, 0x6b, 0xbb, 0x02, 0xb2, 0xf2, 0x8e, 0x76, 0x03, 0x4a, 0x3e, 0xeb, 0xf9, 0xa0,
+	0x98, 0xe5, 0xc4, 0x01, 0xc7, 0xa6, 0x7d, 0x99, 0x84, 0x52, 0xd0, 0x67, 0x85, 0x40, 0x53, 0x41,
+	0x80, 0xa6, 0x00, 0x54, 0x4e, 0x85, 0xa1, 0xf2, 0x1a, 0x14, 0x69, 0xae, 0x3b, 0x85, 0x82, 0x4d,
+	0xc7, 0x47, 0xc1, 0xb7, 0x60, 0x99, 0xc5, 0x28, 0x0e, 0xa8, 0x45, 0x08, 0xcf, 0xb0, 0x10, 0x5e,
+	0xa5, 0x1f, 0xb8, 0x2d, 0x18, 0x19, 0x3d, 0x07, 0x97, 0x02, 0xbc, 0x7e, 0x0e, 0xcd, 0x21, 0xa1,
+	0xe2, 0x73, 0xd7, 0x79, 0x32, 0x4d, 0x51, 0xe1, 0xd4, 0x02, 0xe4, 0x18, 0x4e, 0x0b, 0x9b, 0x55,
+	0xfb, 0x73, 0x12, 0x96, 0x67, 0x5c, 0xeb, 0x5c, 0x40, 0x9c, 0xfc, 0x0f, 0x01, 0xe2, 0xd4, 0x37,
+	0x06, 0xc4, 0x41, 0xe8, 0x90, 0x0e, 0x43, 0x87, 0x7f, 0x25, 0xa1, 0x1c, 0xf2, 0xf0, 0x74, 0xa5,
+	0xba, 0x76, 0x0f, 0x8b, 0x64, 0x9e, 0xb5, 0x69, 0xb8, 0x1d, 0xd8, 0xc7, 0x22, 0x65, 0xa7, 0x4d,
+	0xca, 0xe5, 0x07, 0xac, 0x82, 0x88, 0x47, 0x3e, 0x0e, 0xe0, 0xb9, 0x14, 0xef, 0x50, 0xd9, 0x87,
+	0x98, 0x97, 0x4e, 0x4b, 0x3a, 0x6d, 0xa2, 0x15, 0xb1, 0x23, 0x45, 0x4e, 0xc4, 0x3b, 0xe8, 0x15,
+	0x28, 0xb0, 0xa2, 0xb7, 0x61, 0x3b, 0x5e, 0x2d, 0x3f, 0x9b, 0x6f, 0xf0, 0xda, 0xf6, 0xfa, 0x3e,
+	0xe5, 0xd9, 0x73, 0x3c, 0x3d, 0xef, 0x88, 0x56, 0x20, 0x89, 0x2b, 0x84, 0x92, 0xb8, 0x6b, 0x50,
+	0xa0, 0xa3, 0xf7, 0x1c, 0xb3, 0x8b, 0x59, 0x68, 0x28, 0xe8, 0x13, 0x82, 0xf6, 0x00, 0xd0, 0x6c,
+	0x70, 0x42, 0x2d, 0xc8, 0xe1, 0x13, 0x3c, 0x22, 0x3c, 0xa9, 0x2d, 0x6e, 0x5c, 0x99, 0x45, 0x0b,
+	0xf4, 0xf3, 0x66, 0x8d, 0x1a, 0xf9, 0x9f, 0x5f, 0xac, 0x29, 0x9c, 0xfb, 0x59, 0x7b, 0x68, 0x11,
+	0x3c, 0x74, 0xc8, 0x99, 0x2e, 0xe4, 0xb5, 0x3f, 0xa6, 0xa0, 0x2a, 0x7f, 0x20, 0xc1, 0xec, 0x3c,
+	0xdb, 0xca, 0x93, 0x91, 0x0a, 0x94, 0x13, 0xe2, 0xd9, 0x7b, 0x15, 0xe0, 0xd8, 0xf4, 0x8c, 0xf7,
+	0xcd, 0x11, 0xc1, 0x3d, 0x61, 0xf4, 0x00, 0x05, 0xa9, 0x90, 0xa7, 0xbd, 0xb1, 0x87, 0x7b, 0xa2,
+	0xb2, 0xe1, 0xf7, 0x03, 0xf3, 0x5c, 0xfa, 0x76, 0xf3, 0x0c, 0x5b, 0x39, 0x3f, 0x65, 0xe5, 0x7b,
+	0x99, 0x7c, 0x41, 0x29, 0x49, 0x94, 0x47, 0xd7, 0xcc, 0xb2, 0x5d, 0x8b, 0x9c, 0xe9, 0xe5, 0x21,
+	0x1e, 0x3a, 0xb6, 0x3d, 0x30, 0xb8, 0xab, 0xf9, 0x4d, 0x0a, 0x96, 0x67, 0x82, 0xf4, 0x77, 0xcf,
+	0x5c, 0xda, 0xef, 0x58, 0xe9, 0x2e, 0x9c, 0x68, 0xa0, 0x03, 0x58, 0xf6, 0x0f, 0xb3, 0x31, 0x66,
+	0x87, 0x5c, 0x6e, 0xcf, 0xb8, 0xde, 0x40, 0x39, 0x09, 0x93, 0x3d, 0xf4, 0x36, 0x3c, 0x32, 0xe5,
+	0xa9, 0x7c, 0xd5, 0xa9, 0xb8, 0x0e, 0xeb, 0x72, 0xd8, 0x61, 0x49, 0xd5, 0x13, 0x63, 0xa5, 0xbf,
+	0xe5, 0x19, 0xda, 0x82, 0x8a, 0xb4, 0x86, 0x80, 0x82, 0xf3, 0x96, 0xff, 0x09, 0x28, 0xbb, 0x98,
+	0xd0, 0x0a, 0x65, 0xa8, 0xde, 0x56, 0xe2, 0x44, 0x51, 0xc5, 0xdb, 0x87, 0xcb, 0x73, 0xf3, 0x27,
+	0xf4, 0x32, 0x14, 0x26, 0xa9, 0x17, 0xb7, 0xea, 0x39, 0xf5, 0x98, 0x09, 0xaf, 0xf6, 0xa7, 0x24,
+	0x5c, 0x9e, 0x9b, 0x41, 0xa1, 0x26, 0xe4, 0x5c, 0xec, 0x8d, 0x07, 0xbc, 0xe6, 0x52, 0xd9, 0x78,
+	0x2e, 0x5e, 0xe6, 0x45, 0xa9, 0xe3, 0x01, 0xd1, 0x85, 0xb0, 0xf6, 0x00, 0x72, 0x9c, 0x82, 0x8a,
+	0xb0, 0x74, 0xb8, 0xbb, 0xbd, 0xbb, 0xf7, 0xd6, 0xae, 0x92, 0x40, 0x00, 0xb9, 0x7a, 0xa3, 0xd1,
+	0xdc, 0x6f, 0x2b, 0x49, 0x54, 0x80, 0x6c, 0x7d, 0x73, 0x4f, 0x6f, 0x2b, 0x29, 0x4a, 0xd6, 0x9b,
+	0xf7, 0x9a, 0x8d, 0xb6, 0x92, 0x46, 0xcb, 0x50, 0xe6, 0x6d, 0xe3, 0xee, 0x9e, 0xfe, 0x66, 0xbd,
+	0xad, 0x64, 0x02, 0xa4, 0x83, 0xe6, 0xee, 0x9d, 0xa6, 0xae, 0x64, 0xb5, 0xef, 0xc3, 0x55, 0x39,
+	0x8e, 0xd9, 0xba, 0x91, 0x5f, 0xbe, 0x49, 0x06, 0xca, 0x37, 0x14, 0x80, 0xaa, 0xd1, 0x09, 0x18,
+	0xba, 0x37, 0x35, 0xf1, 0x8d, 0x0b, 0x64, 0x6f, 0x53, 0xb3, 0xa7, 0x71, 0xd8, 0xc5, 0x47, 0x98,
+	0x74, 0xfb, 0x3c, 0x21, 0xe4, 0x01, 0xb0, 0xac, 0x97, 0x05, 0x95, 0x09, 0x79, 0x9c, 0xed, 0x5d,
+	0xdc, 0x25, 0x06, 0xf7, 0x31, 0x7c, 0xd3, 0x15, 0xf4, 0x32, 0xa7, 0x1e, 0x70, 0xa2, 0xf6, 0xce,
+	0x85, 0x6c, 0x59, 0x80, 0xac, 0xde, 0x6c, 0xeb, 0x6f, 0x2b, 0x69, 0x84, 0xa0, 0xc2, 0x9a, 0xc6,
+	0xc1, 0x6e, 0x7d, 0xff, 0xa0, 0xb5, 0x47, 0x6d, 0x79, 0x09, 0xaa, 0xd2, 0x96, 0x92, 0x98, 0xd5,
+	0x9e, 0x81, 0x47, 0x22, 0xb2, 0xc7, 0xd9, 0x32, 0x89, 0xf6, 0x87, 0x64, 0x90, 0x7b, 0x3a, 0x03,
+	0xcc, 0x79, 0xc4, 0x24, 0x63, 0x4f, 0x18, 0xf1, 0xe5, 0xb8, 0xe9, 0xe4, 0xba, 0x6c, 0x1c, 0x30,
+	0x71, 0x5d, 0xa8, 0xd1, 0x5e, 0x84, 0x4a, 0xf8, 0x4b, 0xb4, 0x0d, 0x26, 0x9b, 0x28, 0xa5, 0xfd,
+	0x3d, 0x0d, 0x97, 0xe7, 0xe6, 0x98, 0xe8, 0x5d, 0x40, 0x01, 0xec, 0x67, 0xc4, 0x0a, 0x98, 0xdf,
+	0x13, 0x87, 0xfd, 0xda, 0xac, 0x64, 0xe0, 0xe0, 0x2b, 0x13, 0x64, 0xc8, 0xc4, 0x3c, 0x54, 0x07,
+	0x20, 0xa7, 0x06, 0xdf, 0x13, 0x32, 0x07, 0x8a, 0x01, 0xf0, 0xf4, 0x02, 0x39, 0xe5, 0x0b, 0xee,
+	0xa1, 0x1e, 0x28, 0x3e, 0xbc, 0x33, 0x62, 0x79, 0x26, 0x4d, 0x0c, 0x56, 0x9d, 0x96, 0x0b, 0x0c,
+	0xb5, 0x22, 0xc1, 0x9f, 0x18, 0xe8, 0x5c, 0x2f, 0x9d, 0xf9, 0xef, 0x79, 0xe9, 0xec, 0xb7, 0xf3,
+	0xd2, 0xda, 0xdb, 0x00, 0x81, 0x3a, 0xcd, 0x0a, 0x64, 0x5d, 0x7b, 0x3c, 0xea, 0xb1, 0x3d, 0x97,
+	0xd5, 0x79, 0x87, 0x5e, 0x7c, 0x9f, 0xd8, 0x3c, 0x24, 0xcc, 0xf7, 0x8b, 0xf7, 0x6d, 0x82, 0x03,
+	0xd5, 0x1f, 0xce, 0xad, 0x59, 0x80, 0x66, 0x6b, 0xaa, 0x11, 0xbf, 0x78, 0x3d, 0xfc, 0x8b, 0xc7,
+	0x23, 0xab, 0xb3, 0xf3, 0x7f, 0xf5, 0x01, 0x64, 0x99, 0xfd, 0x69, 0x60, 0x60, 0xf7, 0x02, 0x02,
+	0x60, 0xd0, 0x36, 0xfa, 0x25, 0x80, 0x49, 0x88, 0x6b, 0x75, 0xc6, 0x93, 0x1f, 0xac, 0xcd, 0x5f,
+	0xf2, 0xba, 0xe4, 0xdb, 0xbc, 0x26, 0xd6, 0x7e, 0x65, 0x22, 0x1a, 0x58, 0xf5, 0x80, 0x42, 0x6d,
+	0x17, 0x2a, 0x61, 0x59, 0x99, 0xeb, 0xf2, 0x31, 0x84, 0x73, 0x5d, 0x8e, 0x70, 0x78, 0x67, 0x92,
+	0x29, 0xa7, 0xf9, 0x15, 0x10, 0xeb, 0x68, 0x1f, 0x26, 0x21, 0xdf, 0x16, 0xbb, 0x36, 0xea, 0xfa,
+	0x61, 0x22, 0x9a, 0x0a, 0x16, 0xdb, 0xf9, 0x7d, 0x46, 0xda, 0xbf, 0x25, 0x79, 0xc3, 0x77, 0xc4,
+	0x99, 0xb8, 0x25, 0x11, 0x59, 0x05, 0x14, 0xc1, 0xe7, 0x35, 0x28, 0xf8, 0x9b, 0x94, 0x22, 0x35,
+	0x59, 0x99, 0x4c, 0x0a, 0xfc, 0xc0, 0xbb, 0x74, 0x38, 0x8e, 0xfd, 0xbe, 0x28, 0xe7, 0xa7, 0x75,
+	0xde, 0xd1, 0x7a, 0x50, 0x9d, 0xda, 0xe1, 0xe8, 0x35, 0x58, 0x72, 0xc6, 0x1d, 0x43, 0x9a, 0x67,
+	0xaa, 0xf6, 0x27, 0x93, 0xfb, 0x71, 0x67, 0x60, 0x75, 0xb7, 0xf1, 0x99, 0x1c, 0x8c, 0x33, 0xee,
+	0x6c, 0x73, 0x2b, 0xf2, 0xbf, 0xa4, 0x82, 0x7f, 0x39, 0x81, 0xbc, 0xdc, 0x14, 0xe8, 0x47, 0x50,
+	0xf0, 0x0f, 0x8f, 0x7f, 0xc7, 0x19, 0x79, 0xea, 0x84, 0xfa, 0x89, 0x08, 0x05, 0x94, 0x9e, 0x75,
+	0x3c, 0x92, 0x55, 0x6b, 0x0e, 0xfc, 0x52, 0x6c, 0x75, 0xaa, 0xfc, 0xc3, 0x8e, 0x04, 0x8a, 0xda,
+	0xa7, 0x49, 0x50, 0xa6, 0x77, 0xe5, 0xff, 0x72, 0x00, 0x34, 0xe6, 0xd1, 0xdd, 0x6f, 0x60, 0x3a,
+	0x08, 0x1f, 0x21, 0x97, 0xf4, 0x32, 0xa5, 0x36, 0x25, 0x91, 0x5e, 0x29, 0x16, 0x03, 0xf5, 0x54,
+	0xf4, 0x83, 0xc0, 0x11, 0xa9, 0xcc, 0x71, 0x4a, 0x01, 0xde, 0xc9, 0xf5, 0x59, 0x78, 0x62, 0xa9,
+	0x8b, 0x4f, 0x2c, 0xea, 0x1a, 0x54, 0x96, 0xd8, 0x33, 0x17, 0x2e, 0xb1, 0x3f, 0x0b, 0x88, 0xd8,
+	0xc4, 0x1c, 0x18, 0x27, 0x36, 0xb1, 0x46, 0xc7, 0x06, 0xdf, 0x1a, 0x3c, 0xa1, 0x57, 0xd8, 0x97,
+	0xfb, 0xec, 0xc3, 0x3e, 0xdb, 0x25, 0xbf, 0x4a, 0x42, 0xde, 0xcf, 0xcc, 0x2e, 0x7a, 0x1b, 0x76,
+	0x05, 0x72, 0x22, 0xf9, 0xe0, 0xd7, 0x61, 0xa2, 0x37, 0xf7, 0x2e, 0x41, 0x85, 0xfc, 0x10, 0x13,
+	0x93, 0xa5, 0xa7, 0xbc, 0xb8, 0xe0, 0xf7, 0x6f, 0xbd, 0x0a, 0xc5, 0xc0, 0xc5, 0x24, 0xf5, 0x13,
+	0xbb, 0xcd, 0xb7, 0x94, 0x84, 0xba, 0xf4, 0xe1, 0x27, 0xd7, 0xd3, 0xbb, 0xf8, 0x7d, 0x7a, 0xc2,
+	0xf4, 0x66, 0xa3, 0xd5, 0x6c, 0x6c, 0x2b, 0x49, 0xb5, 0xf8, 0xe1, 0x27, 0xd7, 0x97, 0x74, 0xcc,
+	0x6a, 0x9c, 0xb7, 0xb6, 0xa1, 0x3a, 0xb5, 0x30, 0xe1, 0xf0, 0x8d, 0xa0, 0x72, 0xe7, 0x70, 0x7f,
+	0x67, 0xab, 0x51, 0x6f, 0x37, 0x8d, 0xfb, 0x7b, 0xed, 0xa6, 0x92, 0x44, 0x8f, 0xc0, 0xa5, 0x9d,
+	0xad, 0x9f, 0xb4, 0xda, 0x46, 0x63, 0x67, 0xab, 0xb9, 0xdb, 0x36, 0xea, 0xed, 0x76, 0xbd, 0xb1,
+	0xad, 0xa4, 0x36, 0x7e, 0x5f, 0x82, 0x6a, 0x7d, 0xb3, 0xb1, 0x45, 0xd3, 0x2f, 0xab, 0x6b, 0xb2,
+	0xe2, 0x4f, 0x03, 0x32, 0xac, 0xbc, 0x73, 0xee, 0xd3, 0x2d, 0xf5, 0xfc, 0x02, 0x38, 0xba, 0x0b,
+	0x59, 0x56, 0xf9, 0x41, 0xe7, 0xbf, 0xe5, 0x52, 0x17, 0x54, 0xc4, 0xe9, 0x60, 0xd8, 0x71, 0x3a,
+	0xf7, 0x71, 0x97, 0x7a, 0x7e, 0x81, 0x1c, 0xe9, 0x50, 0x98, 0x80, 0xc8, 0xc5, 0x8f, 0x9d, 0xd4,
+	0x18, 0xde, 0x11, 0xed, 0xc0, 0x92, 0x44, 0xf1, 0x8b, 0x9e, 0x5f, 0xa9, 0x0b, 0x2b, 0xd8, 0xd4,
+	0x5c, 0xbc, 0xda, 0x72, 0xfe, 0x5b, 0x32, 0x75, 0x41, 0x39, 0x1e, 0x6d, 0x41, 0x4e, 0x00, 0xa3,
+	0x05, 0x4f, 0xaa, 0xd4, 0x45, 0x15, 0x69, 0x6a, 0xb4, 0x49, 0x1d, 0x6b, 0xf1, 0x0b, 0x39, 0x35,
+	0xc6, 0x4d, 0x03, 0x3a, 0x04, 0x08, 0xd4, 0x56, 0x62, 0x3c, 0x7d, 0x53, 0xe3, 0xdc, 0x20, 0xa0,
+	0x3d, 0xc8, 0xfb, 0xe0, 0x78, 0xe1, 0x43, 0x34, 0x75, 0x71, 0x29, 0x1f, 0x3d, 0x80, 0x72, 0x18,
+	0x14, 0xc6, 0x7b, 0x5e, 0xa6, 0xc6, 0xac, 0xd1, 0x53, 0xfd, 0x61, 0x84, 0x18, 0xef, 0xb9, 0x99,
+	0x1a, 0xb3, 0x64, 0x8f, 0xde, 0x85, 0xe5, 0x59, 0x04, 0x17, 0xff, 0xf5, 0x99, 0x7a, 0x81, 0x22,
+	0x3e, 0x1a, 0x02, 0x9a, 0x83, 0xfc, 0x2e, 0xf0, 0x18, 0x4d, 0xbd, 0x48, 0x4d, 0x1f, 0xf5, 0xa0,
+	0x3a, 0x0d, 0xa7, 0xe2, 0x3e, 0x4e, 0x53, 0x63, 0xd7, 0xf7, 0xf9, 0x5f, 0xc2, 0x30, 0x2c, 0xee,
+	0x63, 0x35, 0x35, 0x76, 0xb9, 0x9f, 0x6e, 0x83, 0x30, 0x90, 0x8a, 0xf7, 0x78, 0x4d, 0x8d, 0x59,
+	0xfb, 0x47, 0x75, 0xc8, 0x1d, 0x10, 0x17, 0x9b, 0x43, 0x54, 0x8b, 0x52, 0xac, 0x5e, 0x8d, 0xd4,
+	0x75, 0x23, 0xf9, 0x7c, 0x72, 0xb3, 0xfe, 0xd9, 0x57, 0xab, 0xc9, 0xcf, 0xbf, 0x5a, 0x4d, 0xfe,
+	0xe3, 0xab, 0xd5, 0xe4, 0x47, 0x5f, 0xaf, 0x26, 0x3e, 0xff, 0x7a, 0x35, 0xf1, 0xb7, 0xaf, 0x57,
+	0x13, 0x3f, 0x7b, 0xfa, 0xd8, 0x22, 0xfd, 0x71, 0x67, 0xbd, 0x6b, 0x0f, 0x6f, 0x77, 0xed, 0x21,
+	0x26, 0x9d, 0x23, 0x32, 0x69, 0x4c, 0x1e, 0x39, 0x77, 0x72, 0x2c, 0x84, 0xbf, 0xf0, 0xef, 0x01,
+	0x00, 0x74, 0x7e, 0xdf, 0x77, 0x04, 0x2d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3654,6 +3860,9 @@ type ABCIApplicationClient interface {
 	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
 	PrepareProposal(ctx context.Context, in *RequestPrepareProposal, opts ...grpc.CallOption) (*ResponsePrepareProposal, error)
 	ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error)
+	FinalizeBlock(ctx context.Context, in *RequestFinalizeBlock, opts ...grpc.CallOption) (*ResponseFinalizeBlock, error)
+	// Stream pipelines the requests of a connection, answered in order.
+	Stream(ctx context.Context, opts ...grpc.CallOption) (ABCIApplication_StreamClient, error)
 }
 
 type aBCIApplicationClient struct {
@@ -3808,6 +4017,46 @@ func (c *aBCIApplicationClient) ProcessProposal(ctx context.Context, in *Request
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) FinalizeBlock(ctx context.Context, in *RequestFinalizeBlock, opts ...grpc.CallOption) (*ResponseFinalizeBlock, error) {
+	out := new(ResponseFinalizeBlock)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/FinalizeBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) Stream(ctx context.Context, opts ...grpc.CallOption) (ABCIApplication_StreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ABCIApplication_serviceDesc.Streams[0], "/tendermint.abci.ABCIApplication/Stream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aBCIApplicationStreamClient{stream}
+	return x, nil
+}
+
+type ABCIApplication_StreamClient interface {
+	Send(*Request) error
+	Recv() (*Response, error)
+	grpc.ClientStream
+}
+
+type aBCIApplicationStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aBCIApplicationStreamClient) Send(m *Request) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aBCIApplicationStreamClient) Recv() (*Response, error) {
+	m := new(Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ABCIApplicationServer is the server API for ABCIApplication service.
 type ABCIApplicationServer interface {
 	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
@@ -3826,6 +4075,9 @@ type ABCIApplicationServer interface {
 	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
 	PrepareProposal(context.Context, *RequestPrepareProposal) (*ResponsePrepareProposal, error)
 	ProcessProposal(context.Context, *RequestProcessProposal) (*ResponseProcessProposal, error)
+	FinalizeBlock(context.Context, *RequestFinalizeBlock) (*ResponseFinalizeBlock, error)
+	// Stream pipelines the requests of a connection, answered in order.
+	Stream(ABCIApplication_StreamServer) error
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -3880,6 +4132,12 @@ func (*UnimplementedABCIApplicationServer) PrepareProposal(ctx context.Context,
 func (*UnimplementedABCIApplicationServer) ProcessProposal(ctx context.Context, req *RequestProcessProposal) (*ResponseProcessProposal, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessProposal not implemented")
 }
+func (*UnimplementedABCIApplicationServer) FinalizeBlock(ctx context.Context, req *RequestFinalizeBlock) (*ResponseFinalizeBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeBlock not implemented")
+}
+func (*UnimplementedABCIApplicationServer) Stream(srv ABCIApplication_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
 
 func RegisterABCIApplicationServer(s grpc1.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -4173,6 +4431,50 @@ func _ABCIApplication_ProcessProposal_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ABCIApplication_FinalizeBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestFinalizeBlock)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).FinalizeBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/FinalizeBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).FinalizeBlock(ctx, req.(*RequestFinalizeBlock))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ABCIApplicationServer).Stream(&aBCIApplicationStreamServer{stream})
+}
+
+type ABCIApplication_StreamServer interface {
+	Send(*Response) error
+	Recv() (*Request, error)
+	grpc.ServerStream
+}
+
+type aBCIApplicationStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aBCIApplicationStreamServer) Send(m *Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aBCIApplicationStreamServer) Recv() (*Request, error) {
+	m := new(Request)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.abci.ABCIApplication",
 	HandlerType: (*ABCIApplicationServer)(nil),
@@ -4241,8 +4543,19 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ProcessProposal",
 			Handler:    _ABCIApplication_ProcessProposal_Handler,
 		},
+		{
+			MethodName: "FinalizeBlock",
+			Handler:    _ABCIApplication_FinalizeBlock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _ABCIApplication_Stream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/abci/types.proto",
 }
 
@@ -4618,6 +4931,29 @@ func (m *Request_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Request_FinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_FinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.FinalizeBlock != nil {
+		{
+			size, err := m.FinalizeBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
 func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4783,12 +5119,12 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n18, err18 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
-	if err18 != nil {
-		return 0, err18
+	n19, err19 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
+	if err19 != nil {
+		return 0, err19
 	}
-	i -= n18
-	i = encodeVarintTypes(dAtA, i, uint64(n18))
+	i -= n19
+	i = encodeVarintTypes(dAtA, i, uint64(n19))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -5205,12 +5541,12 @@ func (m *RequestPrepareProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i--
 		dAtA[i] = 0x3a
 	}
-	n22, err22 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
-	if err22 != nil {
-		return 0, err22
+	n23, err23 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
+	if err23 != nil {
+		return 0, err23
 	}
-	i -= n22
-	i = encodeVarintTypes(dAtA, i, uint64(n22))
+	i -= n23
+	i = encodeVarintTypes(dAtA, i, uint64(n23))
 	i--
 	dAtA[i] = 0x32
 	if m.Height != 0 {
@@ -5293,12 +5629,12 @@ func (m *RequestProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i--
 		dAtA[i] = 0x3a
 	}
-	n24, err24 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
-	if err24 != nil {
-		return 0, err24
+	n25, err25 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
+	if err25 != nil {
+		return 0, err25
 	}
-	i -= n24
-	i = encodeVarintTypes(dAtA, i, uint64(n24))
+	i -= n25
+	i = encodeVarintTypes(dAtA, i, uint64(n25))
 	i--
 	dAtA[i] = 0x32
 	if m.Height != 0 {
@@ -5349,7 +5685,7 @@ func (m *RequestProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
+func (m *RequestFinalizeBlock) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5359,21 +5695,94 @@ func (m *Response) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+func (m *RequestFinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RequestFinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Value != nil {
-		{
-			size := m.Value.Size()
-			i -= size
-			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.ByzantineValidators) > 0 {
+		for iNdEx := len(m.ByzantineValidators) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ByzantineValidators[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	{
+		size, err := m.LastCommitInfo.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Response) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
 				return 0, err
 			}
 		}
@@ -5744,6 +6153,29 @@ func (m *Response_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_FinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_FinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.FinalizeBlock != nil {
+		{
+			size, err := m.FinalizeBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseException) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5847,6 +6279,16 @@ func (m *ResponseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.FinalizeBlock {
+		i--
+		if m.FinalizeBlock {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.LastBlockAppHash) > 0 {
 		i -= len(m.LastBlockAppHash)
 		copy(dAtA[i:], m.LastBlockAppHash)
@@ -6442,20 +6884,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA47 := make([]byte, len(m.RefetchChunks)*10)
-		var j46 int
+		dAtA51 := make([]byte, len(m.RefetchChunks)*10)
+		var j50 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA47[j46] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA51[j50] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j46++
+				j50++
 			}
-			dAtA47[j46] = uint8(num)
-			j46++
+			dAtA51[j50] = uint8(num)
+			j50++
 		}
-		i -= j46
-		copy(dAtA[i:], dAtA47[:j46])
-		i = encodeVarintTypes(dAtA, i, uint64(j46))
+		i -= j50
+		copy(dAtA[i:], dAtA51[:j50])
+		i = encodeVarintTypes(dAtA, i, uint64(j50))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -6527,6 +6969,97 @@ func (m *ResponseProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *ResponseFinalizeBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseFinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseFinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ConsensusParamUpdates != nil {
+		{
+			size, err := m.ConsensusParamUpdates.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.ValidatorUpdates) > 0 {
+		for iNdEx := len(m.ValidatorUpdates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ValidatorUpdates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.EndBlockEvents) > 0 {
+		for iNdEx := len(m.EndBlockEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.EndBlockEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.TxResults) > 0 {
+		for iNdEx := len(m.TxResults) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TxResults[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.BeginBlockEvents) > 0 {
+		for iNdEx := len(m.BeginBlockEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BeginBlockEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *CommitInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -6943,12 +7476,12 @@ func (m *Misbehavior) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n52, err52 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
-	if err52 != nil {
-		return 0, err52
+	n57, err57 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
+	if err57 != nil {
+		return 0, err57
 	}
-	i -= n52
-	i = encodeVarintTypes(dAtA, i, uint64(n52))
+	i -= n57
+	i = encodeVarintTypes(dAtA, i, uint64(n57))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -7241,6 +7774,18 @@ func (m *Request_ProcessProposal) Size() (n int) {
 	}
 	return n
 }
+func (m *Request_FinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FinalizeBlock != nil {
+		l = m.FinalizeBlock.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *RequestEcho) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7557,6 +8102,35 @@ func (m *RequestProcessProposal) Size() (n int) {
 	return n
 }
 
+func (m *RequestFinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.Header.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.LastCommitInfo.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.ByzantineValidators) > 0 {
+		for _, e := range m.ByzantineValidators {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *Response) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7773,6 +8347,18 @@ func (m *Response_ProcessProposal) Size() (n int) {
 	}
 	return n
 }
+func (m *Response_FinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FinalizeBlock != nil {
+		l = m.FinalizeBlock.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *ResponseException) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7832,6 +8418,9 @@ func (m *ResponseInfo) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.FinalizeBlock {
+		n += 2
+	}
 	return n
 }
 
@@ -8128,25 +8717,44 @@ func (m *ResponseProcessProposal) Size() (n int) {
 	return n
 }
 
-func (m *CommitInfo) Size() (n int) {
+func (m *ResponseFinalizeBlock) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Round != 0 {
-		n += 1 + sovTypes(uint64(m.Round))
+	if len(m.BeginBlockEvents) > 0 {
+		for _, e := range m.BeginBlockEvents {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
 	}
-	if len(m.Votes) > 0 {
-		for _, e := range m.Votes {
+	if len(m.TxResults) > 0 {
+		for _, e := range m.TxResults {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.EndBlockEvents) > 0 {
+		for _, e := range m.EndBlockEvents {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.ValidatorUpdates) > 0 {
+		for _, e := range m.ValidatorUpdates {
 			l = e.Size()
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if m.ConsensusParamUpdates != nil {
+		l = m.ConsensusParamUpdates.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
-func (m *ExtendedCommitInfo) Size() (n int) {
+func (m *CommitInfo) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -8164,7 +8772,25 @@ func (m *ExtendedCommitInfo) Size() (n int) {
 	return n
 }
 
-func (m *Event) Size() (n int) {
+func (m *ExtendedCommitInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Event) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -8929,6 +9555,41 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_ProcessProposal{v}
 			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestFinalizeBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_FinalizeBlock{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11120,7 +11781,7 @@ func (m *RequestProcessProposal) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *RequestFinalizeBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11143,17 +11804,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestFinalizeBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestFinalizeBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11163,30 +11824,29 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseException{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
-			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11213,15 +11873,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseEcho{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Echo{v}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommitInfo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11248,15 +11906,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseFlush{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LastCommitInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Flush{v}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ByzantineValidators", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11283,17 +11939,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseInfo{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ByzantineValidators = append(m.ByzantineValidators, Misbehavior{})
+			if err := m.ByzantineValidators[len(m.ByzantineValidators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Info{v}
 			iNdEx = postIndex
-		case 6:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11303,30 +11958,77 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseInitChain{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Value = &Response_InitChain{v}
-			iNdEx = postIndex
-		case 7:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11353,15 +12055,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseQuery{}
+			v := &ResponseException{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Query{v}
+			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11388,15 +12090,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseBeginBlock{}
+			v := &ResponseEcho{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_BeginBlock{v}
+			m.Value = &Response_Echo{v}
 			iNdEx = postIndex
-		case 9:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11423,15 +12125,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseCheckTx{}
+			v := &ResponseFlush{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_CheckTx{v}
+			m.Value = &Response_Flush{v}
 			iNdEx = postIndex
-		case 10:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11458,15 +12160,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseDeliverTx{}
+			v := &ResponseInfo{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_DeliverTx{v}
+			m.Value = &Response_Info{v}
 			iNdEx = postIndex
-		case 11:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11493,15 +12195,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseEndBlock{}
+			v := &ResponseInitChain{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_EndBlock{v}
+			m.Value = &Response_InitChain{v}
 			iNdEx = postIndex
-		case 12:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11528,15 +12230,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseCommit{}
+			v := &ResponseQuery{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Commit{v}
+			m.Value = &Response_Query{v}
 			iNdEx = postIndex
-		case 13:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11563,15 +12265,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseListSnapshots{}
+			v := &ResponseBeginBlock{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_ListSnapshots{v}
+			m.Value = &Response_BeginBlock{v}
 			iNdEx = postIndex
-		case 14:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11598,15 +12300,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseOfferSnapshot{}
+			v := &ResponseCheckTx{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_OfferSnapshot{v}
+			m.Value = &Response_CheckTx{v}
 			iNdEx = postIndex
-		case 15:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11633,15 +12335,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseLoadSnapshotChunk{}
+			v := &ResponseDeliverTx{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_LoadSnapshotChunk{v}
+			m.Value = &Response_DeliverTx{v}
 			iNdEx = postIndex
-		case 16:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11668,15 +12370,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseApplySnapshotChunk{}
+			v := &ResponseEndBlock{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_ApplySnapshotChunk{v}
+			m.Value = &Response_EndBlock{v}
 			iNdEx = postIndex
-		case 17:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PrepareProposal", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11703,15 +12405,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponsePrepareProposal{}
+			v := &ResponseCommit{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_PrepareProposal{v}
+			m.Value = &Response_Commit{v}
 			iNdEx = postIndex
-		case 18:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11738,16 +12440,226 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseProcessProposal{}
+			v := &ResponseListSnapshots{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_ProcessProposal{v}
+			m.Value = &Response_ListSnapshots{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseOfferSnapshot{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_OfferSnapshot{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseLoadSnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_LoadSnapshotChunk{v}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseApplySnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ApplySnapshotChunk{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrepareProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponsePrepareProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_PrepareProposal{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseProcessProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ProcessProposal{v}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseFinalizeBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_FinalizeBlock{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
 			if (skippy < 0) || (iNdEx+skippy) < 0 {
@@ -12144,6 +13056,26 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				m.LastBlockAppHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlock", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FinalizeBlock = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14074,6 +15006,228 @@ func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ResponseFinalizeBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseFinalizeBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseFinalizeBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlockEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BeginBlockEvents = append(m.BeginBlockEvents, Event{})
+			if err := m.BeginBlockEvents[len(m.BeginBlockEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxResults", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxResults = append(m.TxResults, &ResponseDeliverTx{})
+			if err := m.TxResults[len(m.TxResults)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlockEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndBlockEvents = append(m.EndBlockEvents, Event{})
+			if err := m.EndBlockEvents[len(m.EndBlockEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorUpdates = append(m.ValidatorUpdates, ValidatorUpdate{})
+			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParamUpdates == nil {
+				m.ConsensusParamUpdates = &types1.ConsensusParams{}
+			}
+			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
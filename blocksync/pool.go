@@ -34,6 +34,21 @@ const (
 	maxPendingRequestsPerPeer = 20
 	requestRetrySeconds       = 30
 
+	// The number of requests a peer can have pending, its window, starts at
+	// initialPendingRequestsPerPeer. It grows by one for each block the peer
+	// sends, up to maxPendingRequestsPerPeer, and is halved each time a
+	// request to the peer stalls.
+	initialPendingRequestsPerPeer = 5
+
+	// A request stalls when the block isn't received after stallLatencyFactor
+	// times the average latency of the peer (bounded by minRequestRetry and
+	// requestRetrySeconds), in which case the block is requested from another
+	// peer.
+	stallLatencyFactor = 4
+	minRequestRetry    = 2 * time.Second
+	// weight of the latest latency in the average latency of a peer
+	latencyEWMAWeight = 0.2
+
 	// Minimum recv rate to ensure we're receiving blocks from a peer fast
 	// enough. If a peer is not sending us data at at least that rate, we
 	// consider them to have timedout and we disconnect.
@@ -267,12 +282,17 @@ func (pool *BlockPool) AddBlock(peerID p2p.ID, block *types.Block, blockSize int
 		return
 	}
 
-	if requester.setBlock(block, peerID) {
+	if requestedAt, ok := requester.setBlock(block, peerID); ok {
 		atomic.AddInt32(&pool.numPending, -1)
 		peer := pool.peers[peerID]
 		if peer != nil {
 			peer.decrPending(blockSize)
+			peer.onBlock(time.Since(requestedAt))
 		}
+	} else if requester.didStall(peerID) {
+		// the block was requested from another peer since, as this one was too
+		// slow to send it
+		pool.Logger.Debug("Ignoring block from a peer which stalled", "peer", peerID, "blockHeight", block.Height)
 	} else {
 		pool.Logger.Info("invalid peer", "peer", peerID, "blockHeight", block.Height)
 		pool.sendError(errors.New("invalid peer"), peerID)
@@ -349,27 +369,56 @@ func (pool *BlockPool) updateMaxPeerHeight() {
 	pool.maxPeerHeight = max
 }
 
-// Pick an available peer with the given height available.
-// If no peers are available, returns nil.
-func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
+// Pick an available peer with the given height available, which is expected
+// to send the block the soonest, preferring the peers which didn't stall on
+// this request already. Returns the peer, and the delay after which the
+// request to it stalls. If no peers are available, returns nil.
+func (pool *BlockPool) pickIncrAvailablePeer(height int64, stalled map[p2p.ID]bool) (*bpPeer, time.Duration) {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	var best, bestStalled *bpPeer
 	for _, peer := range pool.peers {
 		if peer.didTimeout {
 			pool.removePeer(peer.id)
 			continue
 		}
-		if peer.numPending >= maxPendingRequestsPerPeer {
+		if peer.numPending >= peer.window {
 			continue
 		}
 		if height < peer.base || height > peer.height {
 			continue
 		}
-		peer.incrPending()
-		return peer
+		if stalled[peer.id] {
+			if bestStalled == nil || peer.score() < bestStalled.score() {
+				bestStalled = peer
+			}
+		} else if best == nil || peer.score() < best.score() {
+			best = peer
+		}
+	}
+	if best == nil {
+		best = bestStalled
+	}
+	if best == nil {
+		return nil, 0
+	}
+	best.incrPending()
+	return best, best.stallTimeout()
+}
+
+// onRequestStalled penalizes the peer to which the request for the block at
+// the given height stalled, and frees the slot of the request, which will be
+// sent to another peer.
+func (pool *BlockPool) onRequestStalled(height int64, peerID p2p.ID) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if peer := pool.peers[peerID]; peer != nil {
+		peer.onStall()
+		pool.Logger.Debug("Block request stalled, requesting it from another peer",
+			"height", height, "peer", peerID, "window", peer.window, "latency", peer.latency)
 	}
-	return nil
 }
 
 func (pool *BlockPool) makeNextRequester() {
@@ -435,6 +484,8 @@ func (pool *BlockPool) debug() string {
 type bpPeer struct {
 	didTimeout  bool
 	numPending  int32
+	window      int32         // maximum number of pending requests
+	latency     time.Duration // average latency of the requests, 0 until measured
 	height      int64
 	base        int64
 	pool        *BlockPool
@@ -453,11 +504,62 @@ func newBPPeer(pool *BlockPool, peerID p2p.ID, base int64, height int64) *bpPeer
 		base:       base,
 		height:     height,
 		numPending: 0,
+		window:     initialPendingRequestsPerPeer,
 		logger:     log.NewNopLogger(),
 	}
 	return peer
 }
 
+// score estimates how long it would take the peer to send a block requested
+// now, behind its pending requests. The peers whose latency isn't measured yet
+// come first.
+func (peer *bpPeer) score() time.Duration {
+	return time.Duration(peer.numPending+1) * peer.latency
+}
+
+// stallTimeout returns the delay after which a request to the peer stalls.
+func (peer *bpPeer) stallTimeout() time.Duration {
+	if peer.latency == 0 {
+		return requestRetrySeconds * time.Second
+	}
+	timeout := stallLatencyFactor * peer.latency
+	switch {
+	case timeout < minRequestRetry:
+		return minRequestRetry
+	case timeout > requestRetrySeconds*time.Second:
+		return requestRetrySeconds * time.Second
+	default:
+		return timeout
+	}
+}
+
+// onBlock updates the average latency of the peer with the latency of the
+// last block it sent, and widens its window.
+func (peer *bpPeer) onBlock(latency time.Duration) {
+	if peer.latency == 0 {
+		peer.latency = latency
+	} else {
+		peer.latency += time.Duration(latencyEWMAWeight * float64(latency-peer.latency))
+	}
+	if peer.window < maxPendingRequestsPerPeer {
+		peer.window++
+	}
+}
+
+// onStall halves the window of the peer, and frees the slot of the stalled
+// request.
+func (peer *bpPeer) onStall() {
+	if peer.window /= 2; peer.window < 1 {
+		peer.window = 1
+	}
+	if peer.numPending > 0 {
+		peer.numPending--
+		if peer.numPending == 0 && peer.timeout != nil {
+			peer.timeout.Stop()
+		}
+	}
+}
+
 func (peer *bpPeer) setLogger(l log.Logger) {
 	peer.logger = l
 }
@@ -513,9 +615,12 @@ type bpRequester struct {
 	gotBlockCh chan struct{}
 	redoCh     chan p2p.ID // redo may send multitime, add peerId to identify repeat
 
-	mtx    cmtsync.Mutex
-	peerID p2p.ID
-	block  *types.Block
+	mtx         cmtsync.Mutex
+	peerID      p2p.ID
+	requestedAt time.Time
+	block       *types.Block
+	// peers to which the request stalled
+	stalledPeers map[p2p.ID]bool
 }
 
 func newBPRequester(pool *BlockPool, height int64) *bpRequester {
@@ -525,8 +630,9 @@ func newBPRequester(pool *BlockPool, height int64) *bpRequester {
 		gotBlockCh: make(chan struct{}, 1),
 		redoCh:     make(chan p2p.ID, 1),
 
-		peerID: "",
-		block:  nil,
+		peerID:       "",
+		block:        nil,
+		stalledPeers: make(map[p2p.ID]bool),
 	}
 	bpr.BaseService = *service.NewBaseService(nil, "bpRequester", bpr)
 	return bpr
@@ -537,21 +643,30 @@ func (bpr *bpRequester) OnStart() error {
 	return nil
 }
 
-// Returns true if the peer matches and block doesn't already exist.
-func (bpr *bpRequester) setBlock(block *types.Block, peerID p2p.ID) bool {
+// Returns true if the peer matches and block doesn't already exist, along
+// with the time at which the block was requested.
+func (bpr *bpRequester) setBlock(block *types.Block, peerID p2p.ID) (time.Time, bool) {
 	bpr.mtx.Lock()
 	if bpr.block != nil || bpr.peerID != peerID {
 		bpr.mtx.Unlock()
-		return false
+		return time.Time{}, false
 	}
 	bpr.block = block
+	requestedAt := bpr.requestedAt
 	bpr.mtx.Unlock()
 
 	select {
 	case bpr.gotBlockCh <- struct{}{}:
 	default:
 	}
-	return true
+	return requestedAt, true
+}
+
+// didStall returns true if the request to the given peer stalled.
+func (bpr *bpRequester) didStall(peerID p2p.ID) bool {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+	return bpr.stalledPeers[peerID]
 }
 
 func (bpr *bpRequester) getBlock() *types.Block {
@@ -595,13 +710,19 @@ func (bpr *bpRequester) requestRoutine() {
 OUTER_LOOP:
 	for {
 		// Pick a peer to send request to.
-		var peer *bpPeer
+		var (
+			peer         *bpPeer
+			stallTimeout time.Duration
+		)
 	PICK_PEER_LOOP:
 		for {
 			if !bpr.IsRunning() || !bpr.pool.IsRunning() {
 				return
 			}
-			peer = bpr.pool.pickIncrAvailablePeer(bpr.height)
+			bpr.mtx.Lock()
+			stalled := bpr.stalledPeers
+			bpr.mtx.Unlock()
+			peer, stallTimeout = bpr.pool.pickIncrAvailablePeer(bpr.height, stalled)
 			if peer == nil {
 				bpr.Logger.Debug("No peers currently available; will retry shortly", "height", bpr.height)
 				time.Sleep(requestIntervalMS * time.Millisecond)
@@ -611,9 +732,10 @@ OUTER_LOOP:
 		}
 		bpr.mtx.Lock()
 		bpr.peerID = peer.id
+		bpr.requestedAt = time.Now()
 		bpr.mtx.Unlock()
 
-		to := time.NewTimer(requestRetrySeconds * time.Second)
+		to := time.NewTimer(stallTimeout)
 		// Send request and wait.
 		bpr.pool.sendRequest(bpr.height, peer.id)
 	WAIT_LOOP:
@@ -627,12 +749,21 @@ OUTER_LOOP:
 			case <-bpr.Quit():
 				return
 			case <-to.C:
-				bpr.Logger.Debug("Retrying block request after timeout", "height", bpr.height, "peer", bpr.peerID)
+				if bpr.getBlock() != nil {
+					// the block was received in the meantime
+					continue WAIT_LOOP
+				}
+				bpr.Logger.Debug("Retrying block request after timeout", "height", bpr.height, "peer", peer.id)
+				bpr.pool.onRequestStalled(bpr.height, peer.id)
+				bpr.mtx.Lock()
+				bpr.stalledPeers[peer.id] = true
+				bpr.mtx.Unlock()
 				// Simulate a redo
 				bpr.reset()
 				continue OUTER_LOOP
 			case peerID := <-bpr.redoCh:
 				if peerID == bpr.peerID {
+					to.Stop()
 					bpr.reset()
 					continue OUTER_LOOP
 				} else {
@@ -640,7 +771,9 @@ OUTER_LOOP:
 				}
 			case <-bpr.gotBlockCh:
 				// We got a block!
-				// Continue the for-loop and wait til Quit.
+				// Continue the for-loop and wait til Quit, or a redo if the block
+				// turns out to be invalid.
+				to.Stop()
 				continue WAIT_LOOP
 			}
 		}
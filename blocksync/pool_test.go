@@ -240,3 +240,76 @@ func TestBlockPoolRemovePeer(t *testing.T) {
 
 	assert.EqualValues(t, 0, pool.MaxPeerHeight())
 }
+
+func TestBlockPoolPicksFastestPeer(t *testing.T) {
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	pool.SetLogger(log.TestingLogger())
+	pool.SetPeerRange("slow", 1, 100)
+	pool.SetPeerRange("fast", 1, 100)
+	pool.SetPeerRange("short", 1, 5)
+	pool.peers["slow"].latency = time.Second
+	pool.peers["fast"].latency = 10 * time.Millisecond
+
+	// the fast peer is picked until its window is full
+	for i := 0; i < initialPendingRequestsPerPeer; i++ {
+		peer, timeout := pool.pickIncrAvailablePeer(10, nil)
+		require.NotNil(t, peer)
+		assert.EqualValues(t, "fast", peer.id)
+		assert.Equal(t, minRequestRetry, timeout)
+	}
+	peer, timeout := pool.pickIncrAvailablePeer(10, nil)
+	require.NotNil(t, peer)
+	assert.EqualValues(t, "slow", peer.id)
+	assert.Equal(t, 4*time.Second, timeout)
+
+	// peers which stalled are only picked if there are no others
+	peer, _ = pool.pickIncrAvailablePeer(10, map[p2p.ID]bool{"slow": true})
+	require.NotNil(t, peer)
+	assert.EqualValues(t, "slow", peer.id)
+
+	// peers whose latency isn't known yet are picked first
+	pool.SetPeerRange("new", 1, 100)
+	peer, timeout = pool.pickIncrAvailablePeer(10, nil)
+	require.NotNil(t, peer)
+	assert.EqualValues(t, "new", peer.id)
+	assert.Equal(t, requestRetrySeconds*time.Second, timeout)
+
+	// peers which don't have the block aren't picked
+	peer, _ = pool.pickIncrAvailablePeer(101, nil)
+	assert.Nil(t, peer)
+}
+
+func TestBPPeerWindow(t *testing.T) {
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	peer := newBPPeer(pool, "peer", 1, 100)
+	assert.EqualValues(t, initialPendingRequestsPerPeer, peer.window)
+
+	// the window widens with each block, up to the maximum
+	for i := 0; i < 2*maxPendingRequestsPerPeer; i++ {
+		peer.onBlock(time.Second)
+	}
+	assert.EqualValues(t, maxPendingRequestsPerPeer, peer.window)
+	assert.Equal(t, time.Second, peer.latency)
+	assert.Equal(t, 4*time.Second, peer.stallTimeout())
+
+	peer.onBlock(2 * time.Second)
+	assert.Equal(t, 1200*time.Millisecond, peer.latency)
+	assert.Equal(t, 4800*time.Millisecond, peer.stallTimeout())
+
+	// the stall timeout is bounded
+	peer.latency = 10 * time.Millisecond
+	assert.Equal(t, minRequestRetry, peer.stallTimeout())
+	peer.latency = time.Minute
+	assert.Equal(t, requestRetrySeconds*time.Second, peer.stallTimeout())
+
+	// and is halved on each stall, down to 1 request
+	peer.incrPending()
+	peer.onStall()
+	assert.EqualValues(t, maxPendingRequestsPerPeer/2, peer.window)
+	assert.EqualValues(t, 0, peer.numPending)
+	for i := 0; i < 10; i++ {
+		peer.onStall()
+	}
+	assert.EqualValues(t, 1, peer.window)
+	assert.EqualValues(t, 0, peer.numPending)
+}
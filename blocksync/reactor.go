@@ -66,7 +66,13 @@ type Reactor struct {
 func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
 	blockSync bool, metrics *Metrics) *Reactor {
 
-	if state.LastBlockHeight != store.Height() {
+	storeHeight := store.Height()
+	if storeHeight == 0 && state.LastBlockHeight > 0 && store.LoadSeenCommit(state.LastBlockHeight) != nil {
+		// the node was bootstrapped by a state sync, and restarted before it synced any block:
+		// resume from the height of the snapshot
+		storeHeight = state.LastBlockHeight
+	}
+	if state.LastBlockHeight != storeHeight {
 		panic(fmt.Sprintf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
 			store.Height()))
 	}
@@ -76,7 +82,7 @@ func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockS
 	const capacity = 1000                      // must be bigger than peers count
 	errorsCh := make(chan peerError, capacity) // so we don't block in #Receive#pool.AddBlock
 
-	startHeight := store.Height() + 1
+	startHeight := storeHeight + 1
 	if startHeight == 1 {
 		startHeight = state.InitialHeight
 	}
@@ -352,13 +358,14 @@ FOR_LOOP:
 				break FOR_LOOP
 			}
 			firstPartSetHeader := firstParts.Header()
-			firstID := types.BlockID{Hash: first.Hash(), PartSetHeader: firstPartSetHeader}
+			encoding := state.ConsensusParams.Encoding.Canonical
+			firstID := types.BlockID{Hash: first.Hash(encoding), PartSetHeader: firstPartSetHeader}
 			// Finally, verify the first block using the second's commit
 			// NOTE: we can probably make this more efficient, but note that calling
 			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 			// currently necessary.
 			err = state.Validators.VerifyCommitLight(
-				chainID, firstID, first.Height, second.LastCommit)
+				chainID, encoding, firstID, first.Height, second.LastCommit)
 
 			if err == nil {
 				// validate the block before we persist it
@@ -387,14 +394,14 @@ FOR_LOOP:
 			bcR.pool.PopRequest()
 
 			// TODO: batch saves so we dont persist to disk every block
-			bcR.store.SaveBlock(first, firstParts, second.LastCommit)
+			bcR.store.SaveBlock(first, firstParts, second.LastCommit, encoding)
 
 			// TODO: same thing for app - but we would need a way to
 			// get the hash without persisting the state
 			state, err = bcR.blockExec.ApplyBlock(state, firstID, first)
 			if err != nil {
 				// TODO This is bad, are we zombie?
-				panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
+				panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, firstID.Hash, err))
 			}
 			blocksSynced++
 
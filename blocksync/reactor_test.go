@@ -74,9 +74,7 @@ func newReactor(
 
 	blockDB := dbm.NewMemDB()
 	stateDB := dbm.NewMemDB()
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	blockStore := store.NewBlockStore(blockDB)
 
 	state, err := stateStore.LoadFromDBOrGenesisDoc(genDoc)
@@ -101,9 +99,7 @@ func newReactor(
 	// pool.height is determined from the store.
 	fastSync := true
 	db := dbm.NewMemDB()
-	stateStore = sm.NewStore(db, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore = sm.NewStore(db, sm.StoreOptions{})
 	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
 		mp, sm.EmptyEvidencePool{}, blockStore)
 	if err = stateStore.Save(state); err != nil {
@@ -136,14 +132,14 @@ func newReactor(
 
 		thisParts, err := thisBlock.MakePartSet(types.BlockPartSizeBytes)
 		require.NoError(t, err)
-		blockID := types.BlockID{Hash: thisBlock.Hash(), PartSetHeader: thisParts.Header()}
+		blockID := types.BlockID{Hash: thisBlock.Hash(types.CanonicalEncodingProto), PartSetHeader: thisParts.Header()}
 
 		state, err = blockExec.ApplyBlock(state, blockID, thisBlock)
 		if err != nil {
 			panic(fmt.Errorf("error apply block: %w", err))
 		}
 
-		blockStore.SaveBlock(thisBlock, thisParts, lastCommit)
+		blockStore.SaveBlock(thisBlock, thisParts, lastCommit, types.CanonicalEncodingProto)
 	}
 
 	bcReactor := NewReactor(state.Copy(), blockExec, blockStore, fastSync, NopMetrics())
@@ -329,3 +325,24 @@ func (app *testApp) Commit() abci.ResponseCommit {
 func (app *testApp) Query(reqQuery abci.RequestQuery) (resQuery abci.ResponseQuery) {
 	return
 }
+
+func TestNewReactorAfterStateSync(t *testing.T) {
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	// the block store has no blocks before the first one is synced
+	state.LastBlockHeight = 10
+	assert.Panics(t, func() {
+		NewReactor(state, nil, blockStore, true, NopMetrics())
+	})
+
+	// but holds the commit of the snapshot height, after a state sync
+	commit, err := test.MakeCommit(test.MakeBlockID(), 10, 0, state.Validators, privVals,
+		genDoc.ChainID, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, blockStore.SaveSeenCommit(10, commit))
+	bcReactor := NewReactor(state, nil, blockStore, true, NopMetrics())
+	assert.EqualValues(t, 11, bcReactor.pool.height)
+}
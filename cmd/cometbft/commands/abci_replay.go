@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/proxy"
+)
+
+var abciReplayStop bool
+
+func init() {
+	ABCIReplayCmd.Flags().BoolVar(&abciReplayStop, "stop", false,
+		"stop at the first response differing from the recorded one")
+}
+
+// ABCIReplayCmd replays the ABCI calls recorded by a node against the
+// application.
+var ABCIReplayCmd = &cobra.Command{
+	Use:   "abci-replay [file]",
+	Short: "Replay the ABCI calls recorded by a node against the application",
+	Long: `
+Replay the ABCI calls recorded by a node, with abci_record_file set, against
+the application, and report the responses differing from the recorded ones.
+The calls are replayed in the order of their recorded responses, on a single
+connection, so the application should be started from the state it had when
+the recording started, usually from scratch.
+
+The application must be running, and listening on the proxy_app address.
+`,
+	Example: `
+	cometbft abci-replay data/abci.rec
+	cometbft abci-replay --stop data/abci.rec
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		client, err := proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()).NewABCIClient()
+		if err != nil {
+			return err
+		}
+		client.SetLogger(logger.With("module", "abci-client"))
+		if err := client.Start(); err != nil {
+			return fmt.Errorf("error starting the ABCI client: %w", err)
+		}
+		defer func() { _ = client.Stop() }()
+
+		r := bufio.NewReader(f)
+		var calls, mismatches int
+		for ; ; calls++ {
+			call, err := proxy.ReadRecordedCall(r)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("reading call %d: %w", calls, err)
+			}
+			res, err := proxy.Replay(client, call)
+			if err != nil {
+				return fmt.Errorf("replaying call %d: %w", calls, err)
+			}
+
+			replayed, err := res.Marshal()
+			if err != nil {
+				return err
+			}
+			recorded, err := call.Response.Marshal()
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(replayed, recorded) {
+				continue
+			}
+			mismatches++
+			logger.Error("Response differs from the recorded one",
+				"call", calls, "connection", call.Connection, "request", fmt.Sprintf("%T", call.Request.Value),
+				"time", call.Time, "recorded", call.Response.String(), "replayed", res.String())
+			if abciReplayStop {
+				calls++
+				break
+			}
+		}
+
+		logger.Info("Replayed the ABCI calls", "calls", calls, "mismatches", mismatches)
+		if mismatches > 0 {
+			return fmt.Errorf("%d of the %d replayed responses differ from the recorded ones", mismatches, calls)
+		}
+		return nil
+	},
+}
@@ -14,8 +14,9 @@ import (
 )
 
 var CompactGoLevelDBCmd = &cobra.Command{
-	Use:   "experimental-compact-goleveldb",
-	Short: "force compacts the CometBFT storage engine (only GoLevelDB supported)",
+	Use:        "experimental-compact-goleveldb",
+	Short:      "force compacts the CometBFT storage engine (only GoLevelDB supported)",
+	Deprecated: "use compact-db instead",
 	Long: `
 This is a temporary utility command that performs a force compaction on the state 
 and blockstores to reduce disk space for a pruning node. This should only be run 
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+)
+
+// compactDBNames are the names of the databases of the node, see DBContext.
+var compactDBNames = []string{"blockstore", "state", "evidence", "tx_index"}
+
+var compactDBs []string
+
+func init() {
+	CompactDBCmd.Flags().StringSliceVar(&compactDBs, "dbs", compactDBNames,
+		"databases to compact")
+}
+
+// CompactDBCmd compacts the databases of the node.
+var CompactDBCmd = &cobra.Command{
+	Use:   "compact-db",
+	Short: "compact the databases of the node, reclaiming the disk space of the pruned data",
+	Long: `
+Compact the block store, state, evidence and indexer databases, reclaiming the
+disk space of the pruned data, which some backends (e.g. goleveldb) never do on
+their own. Only the goleveldb and pebbledb backends support compaction.
+
+This should only be run once the node has stopped. To compact the databases of
+a running node, set compaction_interval in the [storage] section of the
+configuration instead.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, name := range compactDBs {
+			if err := compactDB(config, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func compactDB(config *cfg.Config, name string) error {
+	if !cmtos.FileExists(filepath.Join(config.DBDir(), name+".db")) {
+		logger.Info("No database found, skipping", "db", name, "dir", config.DBDir())
+		return nil
+	}
+	db, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: name, Config: config})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	logger.Info("Compacting database", "db", name)
+	start := time.Now()
+	if err := cfg.CompactDB(db); err != nil {
+		return fmt.Errorf("failed to compact the %s database: %w", name, err)
+	}
+	logger.Info("Compacted database", "db", name, "took", time.Since(start))
+	return nil
+}
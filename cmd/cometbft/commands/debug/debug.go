@@ -9,13 +9,21 @@ import (
 )
 
 var (
-	nodeRPCAddr string
-	profAddr    string
-	frequency   uint
+	nodeRPCAddr        string
+	profAddr           string
+	profAPIKey         string
+	metricsAddr        string
+	frequency          uint
+	walChunks          int
+	numConsensusStates int
 
-	flagNodeRPCAddr = "rpc-laddr"
-	flagProfAddr    = "pprof-laddr"
-	flagFrequency   = "frequency"
+	flagNodeRPCAddr     = "rpc-laddr"
+	flagProfAddr        = "pprof-laddr"
+	flagProfAPIKey      = "pprof-api-key"
+	flagMetricsAddr     = "metrics-laddr"
+	flagFrequency       = "frequency"
+	flagWALChunks       = "wal-chunks"
+	flagConsensusStates = "consensus-states"
 
 	logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
 )
@@ -35,6 +43,36 @@ func init() {
 		"tcp://localhost:26657",
 		"the CometBFT node's RPC address (<host>:<port>)",
 	)
+	DebugCmd.PersistentFlags().StringVar(
+		&profAddr,
+		flagProfAddr,
+		"",
+		"the profiling server address (<host>:<port>), to dump the goroutine and heap profiles",
+	)
+	DebugCmd.PersistentFlags().StringVar(
+		&profAPIKey,
+		flagProfAPIKey,
+		"",
+		"the API key presented to the profiling server, if it requires authentication (rpc.pprof_auth)",
+	)
+	DebugCmd.PersistentFlags().StringVar(
+		&metricsAddr,
+		flagMetricsAddr,
+		"",
+		"the Prometheus server address (http://<host>:<port>), to dump a snapshot of the metrics",
+	)
+	DebugCmd.PersistentFlags().IntVar(
+		&walChunks,
+		flagWALChunks,
+		1,
+		"the number of rotated WAL files to include, along with the head of the WAL",
+	)
+	DebugCmd.PersistentFlags().IntVar(
+		&numConsensusStates,
+		flagConsensusStates,
+		5,
+		"the number of the last /consensus_state responses to include",
+	)
 
 	DebugCmd.AddCommand(killCmd)
 	DebugCmd.AddCommand(dumpCmd)
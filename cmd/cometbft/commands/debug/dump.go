@@ -21,7 +21,8 @@ var dumpCmd = &cobra.Command{
 	Long: `Continuously poll a CometBFT process and dump debugging data into a single
 location at a specified frequency. At each frequency interval, an archived and compressed
 file will contain node debugging information including the goroutine and heap profiles
-if enabled.`,
+and a snapshot of the metrics if enabled, the tail of the WAL, the address book and
+the last /consensus_state responses, one per interval.`,
 	Args: cobra.ExactArgs(1),
 	RunE: dumpCmdHandler,
 }
@@ -33,13 +34,6 @@ func init() {
 		30,
 		"the frequency (seconds) in which to poll, aggregate and dump CometBFT debug data",
 	)
-
-	dumpCmd.Flags().StringVar(
-		&profAddr,
-		flagProfAddr,
-		"",
-		"the profiling server address (<host>:<port>)",
-	)
 }
 
 func dumpCmdHandler(_ *cobra.Command, args []string) error {
@@ -68,17 +62,18 @@ func dumpCmdHandler(_ *cobra.Command, args []string) error {
 	conf = conf.SetRoot(home)
 	cfg.EnsureRoot(conf.RootDir)
 
-	dumpDebugData(outDir, conf, rpc)
+	states := &consensusStates{max: numConsensusStates}
+	dumpDebugData(outDir, conf, rpc, states)
 
 	ticker := time.NewTicker(time.Duration(frequency) * time.Second)
 	for range ticker.C {
-		dumpDebugData(outDir, conf, rpc)
+		dumpDebugData(outDir, conf, rpc, states)
 	}
 
 	return nil
 }
 
-func dumpDebugData(outDir string, conf *cfg.Config, rpc *rpchttp.HTTP) {
+func dumpDebugData(outDir string, conf *cfg.Config, rpc *rpchttp.HTTP, states *consensusStates) {
 	start := time.Now().UTC()
 
 	tmpDir, err := os.MkdirTemp(outDir, "cometbft_debug_tmp")
@@ -106,12 +101,24 @@ func dumpDebugData(outDir string, conf *cfg.Config, rpc *rpchttp.HTTP) {
 		return
 	}
 
+	logger.Info("getting node last consensus states...")
+	if err := dumpConsensusStates(rpc, states, tmpDir); err != nil {
+		logger.Error("failed to dump node consensus states", "error", err)
+		return
+	}
+
 	logger.Info("copying node WAL...")
-	if err := copyWAL(conf, tmpDir); err != nil {
+	if err := copyWAL(conf, tmpDir, walChunks); err != nil {
 		logger.Error("failed to copy node WAL", "error", err)
 		return
 	}
 
+	logger.Info("copying node address book...")
+	if err := copyAddrBook(conf, tmpDir); err != nil {
+		logger.Error("failed to copy node address book", "error", err)
+		return
+	}
+
 	if profAddr != "" {
 		logger.Info("getting node goroutine profile...")
 		if err := dumpProfile(tmpDir, profAddr, "goroutine", 2); err != nil {
@@ -126,6 +133,14 @@ func dumpDebugData(outDir string, conf *cfg.Config, rpc *rpchttp.HTTP) {
 		}
 	}
 
+	if metricsAddr != "" {
+		logger.Info("getting node metrics...")
+		if err := dumpMetrics(tmpDir, metricsAddr); err != nil {
+			logger.Error("failed to dump metrics", "error", err)
+			return
+		}
+	}
+
 	outFile := filepath.Join(outDir, fmt.Sprintf("%s.zip", start.Format(time.RFC3339)))
 	if err := zipDir(tmpDir, outFile); err != nil {
 		logger.Error("failed to create and compress archive", "file", outFile, "error", err)
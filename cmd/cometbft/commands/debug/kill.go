@@ -1,6 +1,7 @@
 package debug
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -24,7 +25,9 @@ var killCmd = &cobra.Command{
 	Long: `Kill a CometBFT process while also aggregating CometBFT process data
 such as the latest node state, including consensus and networking state,
 go-routine state, and the node's WAL and config information. This aggregated data
-is packaged into a compressed archive.
+is packaged into a compressed archive, along with the address book, the last
+/consensus_state responses, one per second, and the goroutine and heap profiles
+and a snapshot of the metrics if enabled.
 
 Example:
 $ cometbft debug 34255 /path/to/cmt-debug.zip`,
@@ -76,8 +79,18 @@ func killCmdHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	logger.Info("getting node last consensus states...")
+	if err := sampleConsensusStates(rpc, tmpDir); err != nil {
+		return err
+	}
+
 	logger.Info("copying node WAL...")
-	if err := copyWAL(conf, tmpDir); err != nil {
+	if err := copyWAL(conf, tmpDir, walChunks); err != nil {
+		return err
+	}
+
+	logger.Info("copying node address book...")
+	if err := copyAddrBook(conf, tmpDir); err != nil {
 		return err
 	}
 
@@ -86,6 +99,25 @@ func killCmdHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if profAddr != "" {
+		logger.Info("getting node goroutine profile...")
+		if err := dumpProfile(tmpDir, profAddr, "goroutine", 2); err != nil {
+			return err
+		}
+
+		logger.Info("getting node heap profile...")
+		if err := dumpProfile(tmpDir, profAddr, "heap", 2); err != nil {
+			return err
+		}
+	}
+
+	if metricsAddr != "" {
+		logger.Info("getting node metrics...")
+		if err := dumpMetrics(tmpDir, metricsAddr); err != nil {
+			return err
+		}
+	}
+
 	logger.Info("killing CometBFT process")
 	if err := killProc(pid, tmpDir); err != nil {
 		return err
@@ -95,6 +127,29 @@ func killCmdHandler(cmd *cobra.Command, args []string) error {
 	return zipDir(tmpDir, outFile)
 }
 
+// consensusStateInterval is the interval between the consensus states sampled
+// before killing the process.
+const consensusStateInterval = time.Second
+
+// sampleConsensusStates gets the last consensus states from the CometBFT RPC,
+// one per consensusStateInterval, and writes them to files under dir. It
+// returns an error upon failure.
+func sampleConsensusStates(rpc *rpchttp.HTTP, dir string) error {
+	if numConsensusStates <= 0 {
+		return nil
+	}
+	states := &consensusStates{max: numConsensusStates}
+	for i := 1; i < numConsensusStates; i++ {
+		state, err := rpc.ConsensusState(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get node consensus state: %w", err)
+		}
+		states.add(time.Now().UTC(), state)
+		time.Sleep(consensusStateInterval)
+	}
+	return dumpConsensusStates(rpc, states, dir)
+}
+
 // killProc attempts to kill the CometBFT process with a given PID with an
 // ABORT signal which should result in a goroutine stacktrace. The PID's STDERR
 // is tailed and piped to a file under the directory dir. An error is returned
@@ -8,9 +8,12 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"time"
 
 	cfg "github.com/cometbft/cometbft/config"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 )
 
 // dumpStatus gets node status state dump from the CometBFT RPC and writes it
@@ -46,13 +49,113 @@ func dumpConsensusState(rpc *rpchttp.HTTP, dir, filename string) error {
 	return writeStateJSONToFile(consDump, dir, filename)
 }
 
-// copyWAL copies the CometBFT node's WAL file. It returns an error if the
-// WAL file cannot be read or copied.
-func copyWAL(conf *cfg.Config, dir string) error {
+// dumpConsensusStates gets the consensus state from the CometBFT RPC and adds
+// it to states, then writes the states to files under dir/consensus_states. It
+// returns an error upon failure.
+func dumpConsensusStates(rpc *rpchttp.HTTP, states *consensusStates, dir string) error {
+	if states.max <= 0 {
+		return nil
+	}
+	state, err := rpc.ConsensusState(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get node consensus state: %w", err)
+	}
+	states.add(time.Now().UTC(), state)
+
+	statesDir := filepath.Join(dir, "consensus_states")
+	if err := os.Mkdir(statesDir, os.ModePerm); err != nil {
+		return err
+	}
+	for _, s := range states.states {
+		if err := writeStateJSONToFile(s.state, statesDir, s.time.Format(time.RFC3339Nano)+".json"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// consensusStates are the last max consensus states, from the oldest to the
+// newest.
+type consensusStates struct {
+	max    int
+	states []timedConsensusState
+}
+
+type timedConsensusState struct {
+	time  time.Time
+	state *ctypes.ResultConsensusState
+}
+
+func (cs *consensusStates) add(t time.Time, state *ctypes.ResultConsensusState) {
+	cs.states = append(cs.states, timedConsensusState{time: t, state: state})
+	if len(cs.states) > cs.max {
+		cs.states = cs.states[len(cs.states)-cs.max:]
+	}
+}
+
+// copyWAL copies the tail of the CometBFT node's WAL: its head file and the
+// last chunks rotated files. It returns an error if the WAL files cannot be
+// read or copied.
+func copyWAL(conf *cfg.Config, dir string, chunks int) error {
 	walPath := conf.Consensus.WalFile()
 	walFile := filepath.Base(walPath)
 
-	return copyFile(walPath, filepath.Join(dir, walFile))
+	if err := copyFile(walPath, filepath.Join(dir, walFile)); err != nil {
+		return err
+	}
+	if chunks <= 0 {
+		return nil
+	}
+
+	// the rotated files are named after the head, with a zero-padded index
+	rotated, err := filepath.Glob(walPath + ".[0-9][0-9][0-9]*")
+	if err != nil {
+		return err
+	}
+	sort.Slice(rotated, func(i, j int) bool {
+		if len(rotated[i]) != len(rotated[j]) {
+			return len(rotated[i]) < len(rotated[j])
+		}
+		return rotated[i] < rotated[j]
+	})
+	if len(rotated) > chunks {
+		rotated = rotated[len(rotated)-chunks:]
+	}
+	for _, chunk := range rotated {
+		if err := copyFile(chunk, filepath.Join(dir, filepath.Base(chunk))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyAddrBook copies the CometBFT node's address book, if any. It returns an
+// error if the address book cannot be read or copied.
+func copyAddrBook(conf *cfg.Config, dir string) error {
+	addrBookPath := conf.P2P.AddrBookFile()
+	if _, err := os.Stat(addrBookPath); os.IsNotExist(err) {
+		return nil
+	}
+
+	return copyFile(addrBookPath, filepath.Join(dir, filepath.Base(addrBookPath)))
+}
+
+// dumpMetrics gets a snapshot of the metrics of the Prometheus server at addr,
+// and writes it to the file metrics.txt. It returns an error upon failure.
+func dumpMetrics(dir, addr string) error {
+	//nolint:gosec,nolintlint
+	resp, err := http.Get(addr + "/metrics")
+	if err != nil {
+		return fmt.Errorf("failed to query for metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read metrics response body: %w", err)
+	}
+
+	return os.WriteFile(path.Join(dir, "metrics.txt"), body, os.ModePerm)
 }
 
 // copyConfig copies the CometBFT node's config file. It returns an error if
@@ -67,12 +170,22 @@ func copyConfig(home, dir string) error {
 func dumpProfile(dir, addr, profile string, debug int) error {
 	endpoint := fmt.Sprintf("%s/debug/pprof/%s?debug=%d", addr, profile, debug)
 
-	//nolint:gosec,nolintlint
-	resp, err := http.Get(endpoint)
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to query for %s profile: %w", profile, err)
+	}
+	if profAPIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+profAPIKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to query for %s profile: %w", profile, err)
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to query for %s profile: %s", profile, resp.Status)
+	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/light/evm"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cometbft/cometbft/types"
+)
+
+var (
+	evmCalldataNode   string
+	evmCalldataHeight int64
+	evmCalldataVector bool
+)
+
+func init() {
+	EVMCalldataCmd.Flags().StringVar(&evmCalldataNode, "node", "tcp://127.0.0.1:26657",
+		"RPC address of the node")
+	EVMCalldataCmd.Flags().Int64Var(&evmCalldataHeight, "height", 0,
+		"height of the block (default: the latest block)")
+	EVMCalldataCmd.Flags().BoolVar(&evmCalldataVector, "vector", false,
+		"print the test vector, as JSON, instead of the calldata")
+}
+
+// EVMCalldataCmd prints the calldata of the EVM light client contracts
+// verifying a block.
+var EVMCalldataCmd = &cobra.Command{
+	Use:   "evm-calldata",
+	Short: "Print the calldata verifying a block in an EVM light client contract",
+	Long: `
+Fetch a block, its aggregated commit and its validator sets from a node, and
+print, as hex, the calldata of the contracts verifying it:
+
+	` + evm.FunctionSignature + `
+
+With --vector, print instead the test vector of the block, as JSON: the
+arguments of the call, along with the votes of the signers, the messages they
+are hashed to, and the MiMC commitments to the validator sets and the public
+inputs of the circuits the contracts are expected to compute.
+
+The chain must use bn254 keys and the fixed-width canonical encoding.
+`,
+	Example: `
+	cometbft evm-calldata --node tcp://127.0.0.1:26657 --height 100
+	cometbft evm-calldata --height 100 --vector > testdata/block-100.json
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := rpchttp.New(evmCalldataNode, "/websocket")
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+
+		var height *int64
+		if evmCalldataHeight > 0 {
+			height = &evmCalldataHeight
+		}
+		params, err := client.ConsensusParams(ctx, height)
+		if err != nil {
+			return fmt.Errorf("fetching the consensus params: %w", err)
+		}
+		if encoding := params.ConsensusParams.Encoding.Canonical; encoding != types.CanonicalEncodingFixedWidth {
+			return fmt.Errorf("the chain uses the %s canonical encoding, not %s",
+				encoding, types.CanonicalEncodingFixedWidth)
+		}
+
+		calldata, err := evm.Fetch(ctx, client, evmCalldataHeight)
+		if err != nil {
+			return err
+		}
+		if evmCalldataVector {
+			bz, err := json.MarshalIndent(calldata, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		}
+		bz, err := calldata.ABIEncode()
+		if err != nil {
+			return err
+		}
+		fmt.Println("0x" + hex.EncodeToString(bz))
+		return nil
+	},
+}
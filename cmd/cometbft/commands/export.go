@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/log"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/statesync"
+	"github.com/cometbft/cometbft/types"
+)
+
+var (
+	exportHeight   int64
+	exportChainID  string
+	exportAppState string
+	exportSnapshot string
+	exportProofs   string
+)
+
+func init() {
+	ExportCmd.Flags().Int64Var(&exportHeight, "height", 0,
+		"height to export the state at (default: the height of the state)")
+	ExportCmd.Flags().StringVar(&exportChainID, "chain-id", "",
+		"chain ID of the new network (default: the chain ID of the genesis)")
+	ExportCmd.Flags().StringVar(&exportAppState, "app-state", "",
+		"JSON file holding the state of the application at the height, exported by the application")
+	ExportCmd.Flags().StringVar(&exportSnapshot, "snapshot", "",
+		"file to export the snapshot of the application at the height to, as \"snapshot export\" does")
+	ExportCmd.Flags().StringVar(&exportProofs, "proofs-of-possession", "",
+		"JSON file mapping the addresses of the bn254 validators which joined after the genesis to the hex "+
+			"proofs of possession of their keys")
+}
+
+// ExportCmd writes the genesis document of a network continuing the chain
+// after a given height.
+var ExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export the state at a given height as the genesis of a new network",
+	Long: `
+Export the state at --height as a genesis document, written to a file or to the
+standard output if the file is "-", to launch a network continuing the chain,
+e.g. after a halt. The initial height of the new network is --height + 1, and
+its validators, consensus params and app hash are the ones the chain had after
+committing --height.
+
+The validators keep the names and the proofs of possession of their bn254 keys
+of the current genesis. The proofs of possession of the validators which joined
+later aren't stored by CometBFT, so they must be given with
+--proofs-of-possession, in a JSON object mapping the hex address of each of
+these validators to the hex proof of possession of its key. The export fails if
+one of them is missing.
+
+The state of the application isn't stored by CometBFT either: with --app-state,
+the state exported by the application at --height, in JSON, is included in the
+genesis, to be passed to InitChain. With --snapshot, the snapshot the
+application advertises through ABCI at --height is exported to a file as well,
+for applications which restore their state from it, in which case the
+application must be running, and listening on the proxy_app address.
+
+The node must not be running.
+`,
+	Example: `
+	cometbft export --height 1000 genesis.json
+	cometbft export --height 1000 --chain-id new-chain --app-state app.json -
+	cometbft export --height 1000 --snapshot snapshot.tar genesis.json
+	cometbft export --height 1000 --proofs-of-possession proofs.json genesis.json
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := logger
+		if args[0] == "-" {
+			// keep the standard output for the genesis
+			logger = log.NewTMLogger(log.NewSyncWriter(os.Stderr))
+		}
+
+		genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+		if err != nil {
+			return err
+		}
+		blockStore, stateStore, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = blockStore.Close()
+			_ = stateStore.Close()
+		}()
+
+		height := exportHeight
+		if height == 0 {
+			state, err := stateStore.Load()
+			if err != nil {
+				return err
+			}
+			height = state.LastBlockHeight
+		}
+		proofs, err := loadProofsOfPossession(exportProofs)
+		if err != nil {
+			return err
+		}
+		newGenDoc, err := sm.ExportGenesis(blockStore, stateStore, genDoc, height, proofs)
+		if err != nil {
+			return fmt.Errorf("failed to export height %d: %w", height, err)
+		}
+		if exportChainID != "" {
+			newGenDoc.ChainID = exportChainID
+		}
+		if exportAppState != "" {
+			bz, err := os.ReadFile(exportAppState)
+			if err != nil {
+				return err
+			}
+			if !json.Valid(bz) {
+				return fmt.Errorf("app state %s is not valid JSON", exportAppState)
+			}
+			newGenDoc.AppState = bz
+		}
+		if err := newGenDoc.ValidateAndComplete(); err != nil {
+			return fmt.Errorf("invalid exported genesis: %w", err)
+		}
+
+		if exportSnapshot != "" {
+			if err := exportAppSnapshot(exportSnapshot, height, logger); err != nil {
+				return err
+			}
+		}
+
+		if args[0] != "-" {
+			if err := newGenDoc.SaveAs(args[0]); err != nil {
+				return err
+			}
+			logger.Info("Exported genesis", "height", height, "initialHeight", newGenDoc.InitialHeight,
+				"appHash", newGenDoc.AppHash, "file", args[0])
+			return nil
+		}
+		bz, err := cmtjson.MarshalIndent(newGenDoc, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(bz))
+		return err
+	},
+}
+
+// loadProofsOfPossession reads the proofs of possession of the bn254 keys of
+// the validators from file, if any, by the address of the validator.
+func loadProofsOfPossession(file string) (map[string][]byte, error) {
+	if file == "" {
+		return nil, nil
+	}
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var hexProofs map[string]cmtbytes.HexBytes
+	if err := json.Unmarshal(bz, &hexProofs); err != nil {
+		return nil, fmt.Errorf("invalid proofs of possession in %s: %w", file, err)
+	}
+	proofs := make(map[string][]byte, len(hexProofs))
+	for address, proof := range hexProofs {
+		proofs[strings.ToUpper(address)] = proof
+	}
+	return proofs, nil
+}
+
+// exportAppSnapshot writes the snapshot of the application at height to file.
+func exportAppSnapshot(file string, height int64, logger log.Logger) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	proxyApp, err := startProxyApp(config, logger)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = proxyApp.Stop() }()
+
+	snapshot, err := statesync.ExportArchive(proxyApp.Snapshot(), uint64(height), 0, f)
+	if err != nil {
+		return fmt.Errorf("failed to export snapshot: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	logger.Info("Exported snapshot", "height", snapshot.Height, "format", snapshot.Format,
+		"chunks", snapshot.Chunks, "hash", fmt.Sprintf("%X", snapshot.Hash))
+	return nil
+}
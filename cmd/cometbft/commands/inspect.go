@@ -8,12 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/inspect"
-	"github.com/cometbft/cometbft/state"
-	"github.com/cometbft/cometbft/state/indexer/block"
-	"github.com/cometbft/cometbft/store"
-	"github.com/cometbft/cometbft/types"
 )
 
 // InspectCmd is the command for starting an inspect server.
@@ -28,6 +23,11 @@ var InspectCmd = &cobra.Command{
 	CometBFT process. CometBFT will not start up while in this inconsistent state.
 	The inspect command can be used to query the block and state store using CometBFT
 	RPC calls to debug issues of inconsistent state.
+
+	Neither the p2p layer nor the consensus are started, and the validator key is
+	not loaded, so a crashed validator can be inspected without risking to double
+	sign. The databases are opened read-only, so that the data is left as is for
+	the forensics.
 	`,
 
 	RunE: runInspect,
@@ -39,7 +39,7 @@ func init() {
 			config.RPC.ListenAddress, "RPC listenener address. Port required")
 	InspectCmd.Flags().
 		String("db-backend",
-			config.DBBackend, "database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb")
+			config.DBBackend, "database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebbledb")
 	InspectCmd.Flags().
 		String("db-dir", config.DBPath, "database directory")
 }
@@ -55,29 +55,10 @@ func runInspect(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
-	blockStoreDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "blockstore", Config: config})
-	if err != nil {
-		return err
-	}
-	blockStore := store.NewBlockStore(blockStoreDB)
-	defer blockStore.Close()
-
-	stateDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "state", Config: config})
-	if err != nil {
-		return err
-	}
-	stateStore := state.NewStore(stateDB, state.StoreOptions{DiscardABCIResponses: false})
-	defer stateStore.Close()
-
-	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
-	if err != nil {
-		return err
-	}
-	txIndexer, blockIndexer, err := block.IndexerFromConfig(config, cfg.DefaultDBProvider, genDoc.ChainID)
+	ins, err := inspect.NewFromConfigWithLogger(config, logger)
 	if err != nil {
 		return err
 	}
-	ins := inspect.New(config.RPC, blockStore, stateStore, txIndexer, blockIndexer, logger)
 
 	logger.Info("starting inspect server")
 	if err := ins.Run(ctx); err != nil {
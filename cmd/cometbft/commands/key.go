@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/privval"
+)
+
+var (
+	keyPassphraseFile string
+	keyNode           bool
+	keyOutput         string
+	keyOverwrite      bool
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{KeyEncryptCmd, KeyDecryptCmd} {
+		cmd.Flags().StringVar(&keyPassphraseFile, "passphrase-file", "",
+			"file containing the passphrase (default: priv_validator_key_passphrase_file, "+
+				"else the "+privval.PassphraseEnv+" environment variable, else prompted for)")
+	}
+
+	for _, cmd := range []*cobra.Command{KeyExportCmd, KeyImportCmd} {
+		cmd.Flags().StringVar(&keyPassphraseFile, "passphrase-file", "",
+			"file containing the passphrase of the armored key (default: prompted for)")
+		cmd.Flags().BoolVar(&keyNode, "node", false, "the node key rather than the private validator key")
+	}
+	KeyExportCmd.Flags().StringVarP(&keyOutput, "output", "o", "", "file to write the armored key to (default: stdout)")
+	KeyImportCmd.Flags().BoolVar(&keyOverwrite, "overwrite", false, "overwrite the existing key file")
+
+	KeyCmd.AddCommand(KeyEncryptCmd)
+	KeyCmd.AddCommand(KeyDecryptCmd)
+	KeyCmd.AddCommand(KeyExportCmd)
+	KeyCmd.AddCommand(KeyImportCmd)
+}
+
+// KeyCmd contains the commands managing the private validator and node keys.
+var KeyCmd = &cobra.Command{
+	Use:   "key",
+	Short: "Encrypt, decrypt, export or import the private validator and node keys",
+}
+
+// KeyEncryptCmd encrypts the plaintext validator key in place.
+var KeyEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt the private validator key with a passphrase",
+	Long: `
+Encrypt the private key in priv_validator_key_file in place, with AES-256-GCM
+under a key derived from the passphrase with argon2id. The node then reads the
+passphrase at startup, from priv_validator_key_passphrase_file, the
+CMT_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable, or else the terminal.
+
+The address and the public key are left in the clear.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passphrase, err := privval.ReadPassphrase(passphraseFile(), true)
+		if err != nil {
+			return err
+		}
+		if err := privval.EncryptFilePVKey(config.PrivValidatorKeyFile(), passphrase); err != nil {
+			return fmt.Errorf("encrypting %s: %w", config.PrivValidatorKeyFile(), err)
+		}
+		logger.Info("Encrypted the private validator key", "path", config.PrivValidatorKeyFile())
+		return nil
+	},
+}
+
+// KeyDecryptCmd decrypts the encrypted validator key in place.
+var KeyDecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Decrypt the private validator key, encrypted with a passphrase",
+	Long: `
+Decrypt the private key in priv_validator_key_file in place, as encrypted by
+"key encrypt", e.g. to change its passphrase.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passphrase, err := privval.ReadPassphrase(passphraseFile(), false)
+		if err != nil {
+			return err
+		}
+		if err := privval.DecryptFilePVKey(config.PrivValidatorKeyFile(), passphrase); err != nil {
+			return fmt.Errorf("decrypting %s: %w", config.PrivValidatorKeyFile(), err)
+		}
+		logger.Info("Decrypted the private validator key", "path", config.PrivValidatorKeyFile())
+		return nil
+	},
+}
+
+// KeyExportCmd exports the validator key or the node key, armored.
+var KeyExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the private validator key, or the node key, armored with a passphrase",
+	Long: `
+Export the private key in priv_validator_key_file, or in node_key_file with
+--node, encrypted with a passphrase as by "key encrypt", in ASCII armor, e.g. to
+back it up or to move it to another machine with "key import". All the key
+types are supported.
+
+If the validator key is encrypted, its passphrase is read as by the node, from
+priv_validator_key_passphrase_file, the CMT_PRIV_VALIDATOR_KEY_PASSPHRASE
+environment variable, or else the terminal.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var (
+			armored string
+			err     error
+		)
+		if keyNode {
+			armored, err = exportNodeKey(config.NodeKeyFile())
+		} else {
+			armored, err = exportPrivValidatorKey(config.PrivValidatorKeyFile())
+		}
+		if err != nil {
+			return err
+		}
+
+		if keyOutput == "" {
+			fmt.Fprint(cmd.OutOrStdout(), armored)
+			return nil
+		}
+		if err := os.WriteFile(keyOutput, []byte(armored), 0o600); err != nil {
+			return err
+		}
+		logger.Info("Exported the key", "path", keyOutput)
+		return nil
+	},
+}
+
+func exportNodeKey(keyFile string) (string, error) {
+	nodeKey, err := p2p.LoadNodeKey(keyFile)
+	if err != nil {
+		return "", err
+	}
+	passphrase, err := privval.ReadArmorPassphrase(keyPassphraseFile, true)
+	if err != nil {
+		return "", err
+	}
+	return privval.ArmorPrivKey(nodeKey.PrivKey, passphrase)
+}
+
+func exportPrivValidatorKey(keyFile string) (string, error) {
+	var keyPassphrase []byte
+	encrypted, err := privval.IsEncryptedFilePVKey(keyFile)
+	if err != nil {
+		return "", err
+	}
+	if encrypted {
+		keyPassphrase, err = privval.ReadPassphrase(config.PrivValidatorKeyPassphrase(), false)
+		if err != nil {
+			return "", err
+		}
+	}
+	passphrase, err := privval.ReadArmorPassphrase(keyPassphraseFile, true)
+	if err != nil {
+		return "", err
+	}
+	return privval.ExportFilePVKey(keyFile, keyPassphrase, passphrase)
+}
+
+// KeyImportCmd imports the validator key or the node key, exported by
+// KeyExportCmd.
+var KeyImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import the private validator key, or the node key, exported by key export",
+	Long: `
+Import the armored private key exported by "key export", read from the given
+file or else stdin, into priv_validator_key_file, or into node_key_file with
+--node. The key file must not exist, unless --overwrite is given.
+
+The validator key is written in plaintext, see "key encrypt". The existing
+priv_validator_state_file is kept: when moving a validator to another machine,
+move its state file along, so that it can't double sign.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var (
+			armored []byte
+			err     error
+		)
+		if len(args) > 0 {
+			armored, err = os.ReadFile(args[0])
+		} else {
+			armored, err = io.ReadAll(cmd.InOrStdin())
+		}
+		if err != nil {
+			return fmt.Errorf("reading the armored key: %w", err)
+		}
+		passphrase, err := privval.ReadArmorPassphrase(keyPassphraseFile, false)
+		if err != nil {
+			return err
+		}
+
+		if keyNode {
+			keyFile := config.NodeKeyFile()
+			if err := importNodeKey(string(armored), passphrase, keyFile); err != nil {
+				return err
+			}
+			logger.Info("Imported the node key", "path", keyFile)
+			return nil
+		}
+		keyFile := config.PrivValidatorKeyFile()
+		if err := privval.ImportFilePVKey(string(armored), passphrase, keyFile,
+			config.PrivValidatorStateFile(), keyOverwrite); err != nil {
+			return err
+		}
+		logger.Info("Imported the private validator key", "path", keyFile)
+		return nil
+	},
+}
+
+func importNodeKey(armored string, passphrase []byte, keyFile string) error {
+	if !keyOverwrite && cmtos.FileExists(keyFile) {
+		return fmt.Errorf("the node key file %s already exists", keyFile)
+	}
+	privKey, err := privval.UnarmorPrivKey(armored, passphrase)
+	if err != nil {
+		return err
+	}
+	nodeKey := &p2p.NodeKey{PrivKey: privKey}
+	return nodeKey.SaveAs(keyFile)
+}
+
+func passphraseFile() string {
+	if keyPassphraseFile != "" {
+		return keyPassphraseFile
+	}
+	return config.PrivValidatorKeyPassphrase()
+}
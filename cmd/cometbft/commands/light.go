@@ -15,14 +15,19 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	cmtcfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmtos "github.com/cometbft/cometbft/libs/os"
 	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lighthttp "github.com/cometbft/cometbft/light/provider/http"
 	lproxy "github.com/cometbft/cometbft/light/proxy"
 	lrpc "github.com/cometbft/cometbft/light/rpc"
 	dbs "github.com/cometbft/cometbft/light/store/db"
+	"github.com/cometbft/cometbft/light/store/s3"
 	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	"github.com/cometbft/cometbft/types"
 )
 
 // LightCmd represents the base command when called without any subcommands
@@ -57,11 +62,21 @@ var (
 	listenAddr         string
 	primaryAddr        string
 	witnessAddrsJoined string
+	witnessPoolJoined  string
+	divergenceWebhook  string
 	chainID            string
+	canonicalEncoding  string
 	home               string
+	dbBackend          string
 	maxOpenConnections int
 
+	s3Endpoint     string
+	s3Bucket       string
+	s3Region       string
+	s3WriteThrough bool
+
 	sequential     bool
+	bisection      string
 	trustingPeriod time.Duration
 	trustedHeight  int64
 	trustedHash    []byte
@@ -80,8 +95,23 @@ func init() {
 		"connect to a CometBFT node at this address")
 	LightCmd.Flags().StringVarP(&witnessAddrsJoined, "witnesses", "w", "",
 		"CometBFT nodes to cross-check the primary node, comma-separated")
+	LightCmd.Flags().StringVar(&witnessPoolJoined, "witness-pool", "",
+		"CometBFT nodes replacing the witnesses which are removed or don't respond, comma-separated")
+	LightCmd.Flags().StringVar(&divergenceWebhook, "divergence-webhook", "",
+		"URL the reports of the witnesses diverging from the primary, and of the trusted header expiring, are POSTed to")
+	LightCmd.Flags().StringVar(&canonicalEncoding, "canonical-encoding", string(types.CanonicalEncodingProto),
+		"canonical encoding of the votes, headers and validator sets of the chain: proto | fixed-width")
 	LightCmd.Flags().StringVar(&home, "home-dir", os.ExpandEnv(filepath.Join("$HOME", ".cometbft-light")),
 		"specify the home directory")
+	LightCmd.Flags().StringVar(&dbBackend, "db-backend", string(dbm.GoLevelDBBackend),
+		"database backend of the trusted store: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebbledb | memdb")
+	LightCmd.Flags().StringVar(&s3Endpoint, "s3-endpoint", "",
+		"S3-compatible endpoint of a bucket to read trusted headers through (credentials are read from "+
+			"AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY)")
+	LightCmd.Flags().StringVar(&s3Bucket, "s3-bucket", "", "bucket to read trusted headers through")
+	LightCmd.Flags().StringVar(&s3Region, "s3-region", "", "region of the bucket")
+	LightCmd.Flags().BoolVar(&s3WriteThrough, "s3-write-through", false,
+		"also write the trusted headers to the bucket")
 	LightCmd.Flags().IntVar(
 		&maxOpenConnections,
 		"max-open-connections",
@@ -98,6 +128,9 @@ func init() {
 	LightCmd.Flags().BoolVar(&sequential, "sequential", false,
 		"sequential verification. Verify all headers sequentially as opposed to using skipping verification",
 	)
+	LightCmd.Flags().StringVar(&bisection, "bisection", "default",
+		"strategy picking the intermediate headers of skipping verification: default, binary, sequential or batching",
+	)
 }
 
 func runProxy(cmd *cobra.Command, args []string) error {
@@ -112,14 +145,18 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	logger = log.NewFilter(logger, option)
 
 	chainID = args[0]
-	logger.Info("Creating client...", "chainID", chainID)
+	encoding := types.CanonicalEncoding(canonicalEncoding)
+	if err := encoding.ValidateBasic(); err != nil {
+		return err
+	}
+	logger.Info("Creating client...", "chainID", chainID, "encoding", encoding)
 
 	witnessesAddrs := []string{}
 	if witnessAddrsJoined != "" {
 		witnessesAddrs = strings.Split(witnessAddrsJoined, ",")
 	}
 
-	db, err := dbm.NewGoLevelDB("light-client-db", home)
+	db, err := cmtcfg.NewDB("light-client-db", dbm.BackendType(dbBackend), home)
 	if err != nil {
 		return fmt.Errorf("can't create a db: %w", err)
 	}
@@ -166,10 +203,46 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		}),
 	}
 
+	if witnessPoolJoined != "" {
+		pool := make([]provider.Provider, 0)
+		for _, addr := range strings.Split(witnessPoolJoined, ",") {
+			p, err := lighthttp.New(chainID, encoding, addr)
+			if err != nil {
+				return fmt.Errorf("can't create a provider for %s: %w", addr, err)
+			}
+			pool = append(pool, p)
+		}
+		options = append(options, light.WitnessPool(pool))
+	}
+	if divergenceWebhook != "" {
+		options = append(options,
+			light.OnDivergence(light.WebhookDivergenceHandler(divergenceWebhook, 10*time.Second, logger)))
+	}
+
 	if sequential {
 		options = append(options, light.SequentialVerification())
 	} else {
-		options = append(options, light.SkippingVerification(trustLevel))
+		strategy, err := parseBisectionStrategy(bisection)
+		if err != nil {
+			return err
+		}
+		options = append(options, light.SkippingVerification(trustLevel), light.Bisection(strategy))
+	}
+
+	trustedStore := dbs.New(db, chainID)
+	if s3Endpoint != "" {
+		trustedStore, err = s3.New(trustedStore, s3.Config{
+			Endpoint:        s3Endpoint,
+			Bucket:          s3Bucket,
+			Region:          s3Region,
+			Prefix:          chainID,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			WriteThrough:    s3WriteThrough,
+		})
+		if err != nil {
+			return fmt.Errorf("can't create the S3 store: %w", err)
+		}
 	}
 
 	var c *light.Client
@@ -177,6 +250,7 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		c, err = light.NewHTTPClient(
 			context.Background(),
 			chainID,
+			encoding,
 			light.TrustOptions{
 				Period: trustingPeriod,
 				Height: trustedHeight,
@@ -184,16 +258,17 @@ func runProxy(cmd *cobra.Command, args []string) error {
 			},
 			primaryAddr,
 			witnessesAddrs,
-			dbs.New(db, chainID),
+			trustedStore,
 			options...,
 		)
 	} else { // continue from latest state
 		c, err = light.NewHTTPClientFromTrustedStore(
 			chainID,
+			encoding,
 			trustingPeriod,
 			primaryAddr,
 			witnessesAddrs,
-			dbs.New(db, chainID),
+			trustedStore,
 			options...,
 		)
 	}
@@ -231,6 +306,25 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// defaultBisectionBatchSize is the batch size of the "batching" bisection
+// strategy.
+const defaultBisectionBatchSize = 1000
+
+func parseBisectionStrategy(name string) (light.BisectionStrategy, error) {
+	switch name {
+	case "default":
+		return light.DefaultBisection(), nil
+	case "binary":
+		return light.BinaryBisection(), nil
+	case "sequential":
+		return light.SequentialBisection(), nil
+	case "batching":
+		return light.TrustPeriodBatching(defaultBisectionBatchSize), nil
+	default:
+		return nil, fmt.Errorf("unknown bisection strategy %q", name)
+	}
+}
+
 func checkForExistingProviders(db dbm.DB) (string, []string, error) {
 	primaryBytes, err := db.Get(primaryKey)
 	if err != nil {
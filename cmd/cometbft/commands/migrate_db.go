@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/libs/progressbar"
+	"github.com/cometbft/cometbft/store/migrate"
+)
+
+var migrateDBDryRun bool
+
+func init() {
+	MigrateDBCmd.Flags().BoolVar(&migrateDBDryRun, "dry-run", false,
+		"only list the pending migrations, without applying them")
+}
+
+// MigrateDBCmd migrates the block and state stores to the latest version of their schemas.
+var MigrateDBCmd = &cobra.Command{
+	Use:   "experimental-migrate-db",
+	Short: "migrate the block and state stores to the latest version of their schemas",
+	Long: `
+Migrate the block and state stores to the latest version of their on-disk
+schemas, applying the pending migrations in order. The node refuses to start on
+databases requiring such a migration. Interrupted migrations resume where they
+stopped when the command is run again.
+
+This should only be run once the node has stopped, and after backing up the
+data directory. Use --dry-run to list the pending migrations.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, schema := range []migrate.Schema{migrate.BlockStoreSchema, migrate.StateStoreSchema} {
+			if err := migrateDB(config, schema, migrateDBDryRun); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func migrateDB(config *cfg.Config, schema migrate.Schema, dryRun bool) error {
+	if !cmtos.FileExists(filepath.Join(config.DBDir(), schema.Name+".db")) {
+		fmt.Printf("%s: no database found in %v\n", schema.Name, config.DBDir())
+		return nil
+	}
+	db, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: schema.Name, Config: config})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var (
+		bar     progressbar.Bar
+		current uint64
+	)
+	from, to, err := schema.Migrate(db, logger, migrate.Options{
+		DryRun: dryRun,
+		Progress: func(m migrate.Migration, done, total int64) {
+			if total <= 0 {
+				return
+			}
+			if m.Version != current {
+				if current != 0 {
+					bar.Finish()
+				}
+				current = m.Version
+				bar.NewOption(0, total)
+			}
+			bar.Play(done)
+		},
+	})
+	if current != 0 {
+		bar.Finish()
+	}
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("%s: %d migrations pending, from schema version %d to %d\n", schema.Name, to-from, from, to)
+	} else {
+		fmt.Printf("%s: migrated from schema version %d to %d\n", schema.Name, from, to)
+	}
+	return nil
+}
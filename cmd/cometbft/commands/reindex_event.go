@@ -9,16 +9,15 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 
-	abcitypes "github.com/cometbft/cometbft/abci/types"
 	cmtcfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/progressbar"
 	"github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/state/indexer"
 	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
 	"github.com/cometbft/cometbft/state/indexer/sink/psql"
+	"github.com/cometbft/cometbft/state/indexer/sink/stream"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/state/txindex/kv"
-	"github.com/cometbft/cometbft/types"
 )
 
 const (
@@ -32,26 +31,41 @@ var (
 
 // ReIndexEventCmd constructs a command to re-index events in a block height interval.
 var ReIndexEventCmd = &cobra.Command{
-	Use:   "reindex-event",
-	Short: "reindex events to the event store backends",
+	Use:     "reindex",
+	Aliases: []string{"reindex-event"},
+	Short:   "reindex events to the event store backends",
 	Long: `
-reindex-event is an offline tooling to re-index block and tx events to the eventsinks,
-you can run this command when the event store backend dropped/disconnected or you want to 
-replace the backend. The default start-height is 0, meaning the tooling will start 
-reindex from the base block height(inclusive); and the default end-height is 0, meaning 
-the tooling will reindex until the latest block height(inclusive). User can omit
-either or both arguments.
-
-Note: This operation requires ABCI Responses. Do not set DiscardABCIResponses to true if you
-want to use this command.
+reindex is an offline tooling to re-index block and tx events to the eventsinks,
+replaying the stored blocks and ABCI responses through the indexer. You can run
+this command when the event store backend dropped/disconnected, or to switch to
+another backend without resyncing the chain: pass --indexer (and --psql-conn),
+then update the tx_index section of the config.toml accordingly.
+
+The default --from is 0, meaning the tooling will start reindex from the base
+block height(inclusive); and the default --to is 0, meaning the tooling will
+reindex until the latest block height(inclusive). User can omit either or both
+arguments.
+
+Note: This operation requires ABCI Responses. Set retain_abci_responses to 0 (or to
+cover the heights to reindex) if you want to use this command.
 	`,
 	Example: `
-	cometbft reindex-event
-	cometbft reindex-event --start-height 2
-	cometbft reindex-event --end-height 10
-	cometbft reindex-event --start-height 2 --end-height 10
+	cometbft reindex
+	cometbft reindex --from 2
+	cometbft reindex --to 10
+	cometbft reindex --from 2 --to 10 --indexer psql --psql-conn postgresql://...
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if reindexIndexer != "" {
+			config.TxIndex.Indexer = reindexIndexer
+		}
+		if reindexPsqlConn != "" {
+			config.TxIndex.PsqlConn = reindexPsqlConn
+		}
+		if reindexStreamURL != "" {
+			config.TxIndex.StreamURL = reindexStreamURL
+		}
+
 		bs, ss, err := loadStateAndBlockStore(config)
 		if err != nil {
 			fmt.Println(reindexFailed, err)
@@ -92,13 +106,27 @@ want to use this command.
 }
 
 var (
-	startHeight int64
-	endHeight   int64
+	startHeight      int64
+	endHeight        int64
+	reindexIndexer   string
+	reindexPsqlConn  string
+	reindexStreamURL string
 )
 
 func init() {
-	ReIndexEventCmd.Flags().Int64Var(&startHeight, "start-height", 0, "the block height would like to start for re-index")
-	ReIndexEventCmd.Flags().Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for re-index")
+	flags := ReIndexEventCmd.Flags()
+	flags.Int64Var(&startHeight, "from", 0, "the block height would like to start for re-index")
+	flags.Int64Var(&endHeight, "to", 0, "the block height would like to finish for re-index")
+	flags.Int64Var(&startHeight, "start-height", 0, "the block height would like to start for re-index")
+	flags.Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for re-index")
+	_ = flags.MarkDeprecated("start-height", "use --from instead")
+	_ = flags.MarkDeprecated("end-height", "use --to instead")
+	flags.StringVar(&reindexIndexer, "indexer", "",
+		"the indexer to re-index to, \"kv\", \"psql\" or \"kafka\" (defaults to the indexer of the config)")
+	flags.StringVar(&reindexPsqlConn, "psql-conn", "",
+		"the PostgreSQL connection string of the psql indexer (defaults to the psql-conn of the config)")
+	flags.StringVar(&reindexStreamURL, "stream-url", "",
+		"the URL of the kafka indexer (defaults to the stream-url of the config)")
 }
 
 func loadEventSinks(cfg *cmtcfg.Config, chainID string) (indexer.BlockIndexer, txindex.TxIndexer, error) {
@@ -114,9 +142,19 @@ func loadEventSinks(cfg *cmtcfg.Config, chainID string) (indexer.BlockIndexer, t
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := es.Migrate(); err != nil {
+			return nil, nil, err
+		}
+		return es.BlockIndexer(), es.TxIndexer(), nil
+	case "kafka":
+		es, err := stream.NewEventSinkFromURL(strings.ToLower(cfg.TxIndex.Indexer), cfg.TxIndex.StreamURL, chainID,
+			cfg.TxIndex.StreamBlockTopic, cfg.TxIndex.StreamTxTopic)
+		if err != nil {
+			return nil, nil, err
+		}
 		return es.BlockIndexer(), es.TxIndexer(), nil
 	case "kv":
-		store, err := dbm.NewDB("tx_index", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+		store, err := cmtcfg.NewDB("tx_index", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
 		if err != nil {
 			return nil, nil, err
 		}
@@ -149,47 +187,9 @@ func eventReIndex(cmd *cobra.Command, args eventReIndexArgs) error {
 		case <-cmd.Context().Done():
 			return fmt.Errorf("event re-index terminated at height %d: %w", i, cmd.Context().Err())
 		default:
-			b := args.blockStore.LoadBlock(i)
-			if b == nil {
-				return fmt.Errorf("not able to load block at height %d from the blockstore", i)
-			}
-
-			r, err := args.stateStore.LoadABCIResponses(i)
-			if err != nil {
-				return fmt.Errorf("not able to load ABCI Response at height %d from the statestore", i)
-			}
-
-			e := types.EventDataNewBlockHeader{
-				Header:           b.Header,
-				NumTxs:           int64(len(b.Txs)),
-				ResultBeginBlock: *r.BeginBlock,
-				ResultEndBlock:   *r.EndBlock,
-			}
-
-			var batch *txindex.Batch
-			if e.NumTxs > 0 {
-				batch = txindex.NewBatch(e.NumTxs)
-
-				for i := range b.Data.Txs {
-					tr := abcitypes.TxResult{
-						Height: b.Height,
-						Index:  uint32(i),
-						Tx:     b.Data.Txs[i],
-						Result: *(r.DeliverTxs[i]),
-					}
-
-					if err = batch.Add(&tr); err != nil {
-						return fmt.Errorf("adding tx to batch: %w", err)
-					}
-				}
-
-				if err := args.txIndexer.AddBatch(batch); err != nil {
-					return fmt.Errorf("tx event re-index at height %d failed: %w", i, err)
-				}
-			}
-
-			if err := args.blockIndexer.Index(e); err != nil {
-				return fmt.Errorf("block event re-index at height %d failed: %w", i, err)
+			if err := txindex.IndexHeight(i, args.blockStore, args.stateStore,
+				args.blockIndexer, args.txIndexer); err != nil {
+				return err
 			}
 		}
 
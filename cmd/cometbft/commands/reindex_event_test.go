@@ -90,7 +90,8 @@ func TestLoadEventSink(t *testing.T) {
 		{"", "", true},
 		{"NULL", "", true},
 		{"KV", "", false},
-		{"PSQL", "", true}, // true because empty connect url
+		{"PSQL", "", true},  // true because empty connect url
+		{"KAFKA", "", true}, // true because empty stream url
 		// skip to test PSQL connect with correct url
 		{"UnsupportedSinkType", "wrongUrl", true},
 	}
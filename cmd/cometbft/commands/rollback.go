@@ -11,18 +11,26 @@ import (
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/os"
 	"github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/indexer"
+	"github.com/cometbft/cometbft/state/indexer/block"
+	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/store"
 )
 
-var removeBlock = false
+var (
+	removeBlock    = false
+	rollbackHeight int64
+)
 
 func init() {
 	RollbackStateCmd.Flags().BoolVar(&removeBlock, "hard", false, "remove last block as well as state")
+	RollbackStateCmd.Flags().Int64Var(&rollbackHeight, "height", 0,
+		"the height to roll back to, several heights below the state (defaults to one height below)")
 }
 
 var RollbackStateCmd = &cobra.Command{
 	Use:   "rollback",
-	Short: "rollback CometBFT state by one height",
+	Short: "rollback CometBFT state by one or several heights",
 	Long: `
 A state rollback is performed to recover from an incorrect application state transition,
 when CometBFT has persisted an incorrect app hash and is thus unable to make
@@ -31,9 +39,24 @@ The application should also roll back to height n - 1. If the --hard flag is not
 no blocks will be removed so upon restarting CometBFT the transactions in block n will be 
 re-executed against the application. Using --hard will also remove block n. This can
 be done multiple times.
+
+With --height h, the state is rolled back to height h in one go, e.g. when an app
+hash divergence is discovered several blocks late. The application should also
+roll back to height h. The blocks above h + 1 are removed, along with their ABCI
+responses and what the indexer indexed at these heights, and so is block h + 1
+if --hard is used.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		height, hash, err := RollbackState(config, removeBlock)
+		var (
+			height int64
+			hash   []byte
+			err    error
+		)
+		if rollbackHeight > 0 {
+			height, hash, err = RollbackStateTo(config, rollbackHeight, removeBlock)
+		} else {
+			height, hash, err = RollbackState(config, removeBlock)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to rollback state: %w", err)
 		}
@@ -66,15 +89,96 @@ func RollbackState(config *cfg.Config, removeBlock bool) (int64, []byte, error)
 	return state.Rollback(blockStore, stateStore, removeBlock)
 }
 
+// RollbackStateTo rolls the state back to height, which must be below the
+// state height, and the blockstore to height + 1, or height if removeBlock is
+// true. The ABCI responses and what the indexer indexed above height are
+// deleted. Returns the latest state height and app hash alongside an error if
+// there was one.
+func RollbackStateTo(config *cfg.Config, height int64, removeBlock bool) (int64, []byte, error) {
+	blockStore, stateDB, err := loadBlockStoreAndStateDB(config)
+	if err != nil {
+		return -1, nil, err
+	}
+	stateStore := state.NewStore(stateDB, state.StoreOptions{
+		ABCIResponsesRetention: config.Storage.RetainABCIResponses,
+	})
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	s, err := stateStore.Load()
+	if err != nil {
+		return -1, nil, err
+	}
+	if height < s.LastBlockHeight {
+		// the heights rolled back include the pending block, if any
+		top := blockStore.Height()
+		if top < s.LastBlockHeight {
+			top = s.LastBlockHeight
+		}
+		if err := rollbackIndexer(config, stateDB, s.ChainID, height, top); err != nil {
+			return -1, nil, fmt.Errorf("failed to roll back the indexer: %w", err)
+		}
+	}
+
+	return state.RollbackTo(blockStore, stateStore, height, removeBlock)
+}
+
+// rollbackIndexer deletes what the indexer of the config indexed from height + 1
+// to top.
+func rollbackIndexer(config *cfg.Config, stateDB dbm.DB, chainID string, height, top int64) error {
+	switch config.TxIndex.Indexer {
+	case "kv", "psql":
+	case "", "null":
+		return nil
+	default:
+		fmt.Printf("The %s indexer can't delete the rolled back heights\n", config.TxIndex.Indexer)
+		return nil
+	}
+
+	txIndexer, blockIndexer, err := block.IndexerFromConfig(config, cfg.DefaultDBProvider, chainID)
+	if err != nil {
+		return err
+	}
+	for _, idx := range []interface{}{txIndexer, blockIndexer} {
+		deleter, ok := idx.(indexer.HeightDeleter)
+		if !ok {
+			return fmt.Errorf("the %s indexer can't delete heights", config.TxIndex.Indexer)
+		}
+		for h := height + 1; h <= top; h++ {
+			if err := deleter.DeleteHeight(h); err != nil {
+				return err
+			}
+		}
+	}
+
+	progress, err := txindex.NewProgress(stateDB, config.TxIndex.Indexer)
+	if err != nil {
+		return err
+	}
+	return progress.Truncate(height)
+}
+
 func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store, error) {
-	dbType := dbm.BackendType(config.DBBackend)
+	blockStore, stateDB, err := loadBlockStoreAndStateDB(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	stateStore := state.NewStore(stateDB, state.StoreOptions{
+		ABCIResponsesRetention: config.Storage.RetainABCIResponses,
+	})
 
+	return blockStore, stateStore, nil
+}
+
+func loadBlockStoreAndStateDB(config *cfg.Config) (*store.BlockStore, dbm.DB, error) {
 	if !os.FileExists(filepath.Join(config.DBDir(), "blockstore.db")) {
 		return nil, nil, fmt.Errorf("no blockstore found in %v", config.DBDir())
 	}
 
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, config.DBDir())
+	blockStoreDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "blockstore", Config: config})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -85,13 +189,10 @@ func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store,
 	}
 
 	// Get StateStore
-	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
+	stateDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "state", Config: config})
 	if err != nil {
 		return nil, nil, err
 	}
-	stateStore := state.NewStore(stateDB, state.StoreOptions{
-		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
-	})
 
-	return blockStore, stateStore, nil
+	return blockStore, stateDB, nil
 }
@@ -4,19 +4,22 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/cli"
-	cmtflags "github.com/cometbft/cometbft/libs/cli/flags"
 	"github.com/cometbft/cometbft/libs/log"
 )
 
 var (
 	config = cfg.DefaultConfig()
 	logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	// the log levels of the modules, reloaded by the node on SIGHUP (see
+	// reloadConfig)
+	logLevels *log.Levels
 )
 
 func init() {
@@ -80,13 +83,17 @@ var RootCmd = &cobra.Command{
 		}
 
 		if config.LogFormat == cfg.LogFormatJSON {
-			logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
+			logger = log.NewJSONLogger(log.NewSyncWriter(os.Stdout))
+		}
+		if config.LogSamplingFirst > 0 {
+			logger = log.NewSamplingLogger(logger, config.LogSamplingFirst, config.LogSamplingThereafter, time.Second)
 		}
 
-		logger, err = cmtflags.ParseLogLevel(config.LogLevel, logger, cfg.DefaultLogLevel)
+		logLevels, err = log.ParseLevels(config.LogLevel, cfg.DefaultLogLevel)
 		if err != nil {
 			return err
 		}
+		logger = log.NewModuleFilter(logger, logLevels)
 
 		if viper.GetBool(cli.TraceFlag) {
 			logger = log.NewTracingLogger(logger)
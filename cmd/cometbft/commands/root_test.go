@@ -172,6 +172,34 @@ func TestRootConfig(t *testing.T) {
 	}
 }
 
+func TestRootConfigDiscardABCIResponses(t *testing.T) {
+	for _, tc := range []struct {
+		storage string
+		expErr  bool
+	}{
+		{"discard_abci_responses = true", true},
+		{"discard_abci_responses = true\nretain_abci_responses = 1", false},
+		{"discard_abci_responses = false", false},
+	} {
+		clearConfig(defaultRoot)
+		configFilePath := filepath.Join(defaultRoot, "config")
+		require.NoError(t, cmtos.EnsureDir(configFilePath, 0700))
+		// the config file of a previous version
+		data := "[storage]\n" + tc.storage + "\n"
+		require.NoError(t, os.WriteFile(filepath.Join(configFilePath, "config.toml"), []byte(data), 0600))
+
+		rootCmd := testRootCmd()
+		cmd := cli.PrepareBaseCmd(rootCmd, "CMT", defaultRoot)
+		cmd.Exit = func(int) {}
+		err := cli.RunWithArgs(cmd, []string{rootCmd.Use}, nil)
+		if tc.expErr {
+			assert.Error(t, err, tc.storage)
+		} else {
+			assert.NoError(t, err, tc.storage)
+		}
+	}
+}
+
 // WriteConfigVals writes a toml file with the given values.
 // It returns an error if writing was impossible.
 func WriteConfigVals(dir string, vals map[string]string) error {
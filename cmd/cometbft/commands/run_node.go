@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	cfg "github.com/cometbft/cometbft/config"
 	cmtos "github.com/cometbft/cometbft/libs/os"
@@ -23,6 +26,7 @@ var (
 func AddNodeFlags(cmd *cobra.Command) {
 	// bind flags
 	cmd.Flags().String("moniker", config.Moniker, "node name")
+	cmd.Flags().String("mode", config.Mode, "node mode: validator | full | seed")
 
 	// priv val flags
 	cmd.Flags().String(
@@ -71,6 +75,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "enable/disable UPNP port forwarding")
 	cmd.Flags().Bool("p2p.pex", config.P2P.PexReactor, "enable/disable Peer-Exchange")
 	cmd.Flags().Bool("p2p.seed_mode", config.P2P.SeedMode, "enable/disable seed mode")
+	_ = cmd.Flags().MarkDeprecated("p2p.seed_mode", "use --mode=seed instead")
 	cmd.Flags().String("p2p.private_peer_ids", config.P2P.PrivatePeerIDs, "comma-delimited private peer IDs")
 
 	// consensus flags
@@ -87,7 +92,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String(
 		"db_backend",
 		config.DBBackend,
-		"database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb")
+		"database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebbledb")
 	cmd.Flags().String(
 		"db_dir",
 		config.DBPath,
@@ -110,6 +115,8 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to create node: %w", err)
 			}
+			nm.LogLevels(logLevels)(n)
+			nm.WithConfigLoader(loadConfig)(n)
 
 			if err := n.Start(); err != nil {
 				return fmt.Errorf("failed to start node: %w", err)
@@ -117,6 +124,9 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 
 			logger.Info("Started node", "nodeInfo", n.Switch().NodeInfo())
 
+			// Reload the configuration upon receiving SIGHUP.
+			go reloadConfig(n)
+
 			// Stop upon receiving SIGTERM or CTRL-C.
 			cmtos.TrapSignal(logger, func() {
 				if n.IsRunning() {
@@ -135,6 +145,37 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 	return cmd
 }
 
+// reloadConfig reloads the configuration every time the node receives
+// SIGHUP, applying the fields which can be at runtime (see
+// node.ReloadConfig).
+func reloadConfig(n *nm.Node) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		reload, err := n.ReloadConfigFile()
+		if err != nil {
+			logger.Error("failed to reload the configuration", "err", err)
+			continue
+		}
+		logger.Info("Reloaded the configuration",
+			"applied", reload.Applied, "require_restart", reload.RequireRestart)
+	}
+}
+
+// loadConfig reads the configuration file again. The flags take precedence
+// over the file.
+func loadConfig() (*cfg.Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	conf := cfg.DefaultConfig()
+	if err := viper.Unmarshal(conf); err != nil {
+		return nil, err
+	}
+	conf.SetRoot(config.RootDir)
+	return conf, nil
+}
+
 func checkGenesisHash(config *cfg.Config) error {
 	if len(genesisHash) == 0 || config.Genesis == "" {
 		return nil
@@ -25,9 +25,8 @@ func showValidator(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
 	}
 
-	pv := privval.LoadFilePV(keyFilePath, config.PrivValidatorStateFile())
-
-	pubKey, err := pv.GetPubKey()
+	// the public key is in the clear, even when the key is encrypted
+	pubKey, err := privval.LoadFilePVKeyPubKey(keyFilePath)
 	if err != nil {
 		return fmt.Errorf("can't get pubkey: %w", err)
 	}
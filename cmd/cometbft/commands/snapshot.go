@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/proxy"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/statesync"
+	"github.com/cometbft/cometbft/store"
+)
+
+var (
+	snapshotHeight uint64
+	snapshotFormat uint32
+)
+
+func init() {
+	SnapshotExportCmd.Flags().Uint64Var(&snapshotHeight, "height", 0,
+		"height of the snapshot to export (default: the latest snapshot)")
+	SnapshotExportCmd.Flags().Uint32Var(&snapshotFormat, "format", 0,
+		"format of the snapshot to export (default: the highest format)")
+
+	SnapshotCmd.AddCommand(SnapshotExportCmd)
+	SnapshotCmd.AddCommand(SnapshotImportCmd)
+}
+
+// SnapshotCmd contains the commands to export and import the snapshots of the application.
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Export or import a snapshot of the application, as an archive file",
+}
+
+// SnapshotExportCmd writes a snapshot of the application to an archive file.
+var SnapshotExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export a snapshot of the application to an archive file",
+	Long: `
+Export one of the snapshots advertised by the application through ABCI to an
+archive file, or to the standard output if the file is "-". The archive can be
+imported with "snapshot import" to bootstrap another node.
+
+The application must be running, and listening on the proxy_app address.
+`,
+	Example: `
+	cometbft snapshot export snapshot.tar
+	cometbft snapshot export --height 1000 - | gzip > snapshot.tar.gz
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w, logger := io.Writer(os.Stdout), logger
+		if args[0] == "-" {
+			// keep the standard output for the archive
+			logger = log.NewTMLogger(log.NewSyncWriter(os.Stderr))
+		} else {
+			f, err := os.OpenFile(args[0], os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		proxyApp, err := startProxyApp(config, logger)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = proxyApp.Stop() }()
+
+		snapshot, err := statesync.ExportArchive(proxyApp.Snapshot(), snapshotHeight, snapshotFormat, w)
+		if err != nil {
+			return fmt.Errorf("failed to export snapshot: %w", err)
+		}
+		if f, ok := w.(*os.File); ok && f != os.Stdout {
+			if err := f.Sync(); err != nil {
+				return err
+			}
+		}
+		logger.Info("Exported snapshot", "height", snapshot.Height, "format", snapshot.Format,
+			"chunks", snapshot.Chunks, "hash", fmt.Sprintf("%X", snapshot.Hash))
+		return nil
+	},
+}
+
+// SnapshotImportCmd restores a snapshot of the application from an archive file, and bootstraps
+// the node with the state at its height.
+var SnapshotImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Restore a snapshot of the application from an archive file",
+	Long: `
+Restore the snapshot from an archive file written by "snapshot export", or from
+the standard input if the file is "-", into the application, and bootstrap the
+node with the state at the height of the snapshot. The node can then be started
+and will block sync from there, as after a state sync.
+
+Like state sync, the snapshot is verified with a light client, so the
+rpc_servers and trust_period parameters of the [statesync] section of the
+configuration are required, as well as trust_height and trust_hash, unless they
+are left empty to trust the latest block of the RPC servers. The node must not have
+any state yet, and the application must be running, and listening on the
+proxy_app address.
+`,
+	Example: `
+	cometbft snapshot import snapshot.tar
+	gunzip -c snapshot.tar.gz | cometbft snapshot import -
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r := io.Reader(os.Stdin)
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		return importSnapshot(config, r)
+	},
+}
+
+func importSnapshot(config *cfg.Config, r io.Reader) error {
+	ssConfig := *config.StateSync
+	ssConfig.Enable = true
+	if err := ssConfig.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [statesync] config: %w", err)
+	}
+
+	blockStoreDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return err
+	}
+	blockStore := store.NewBlockStore(blockStoreDB)
+	defer blockStore.Close()
+	stateDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "state", Config: config})
+	if err != nil {
+		return err
+	}
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		ABCIResponsesRetention: config.Storage.RetainABCIResponses,
+	})
+	defer stateStore.Close()
+
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+	if state.LastBlockHeight > 0 || blockStore.Height() > 0 {
+		return errors.New("the node already has a state, run \"unsafe-reset-all\" first")
+	}
+
+	proxyApp, err := startProxyApp(config, logger)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = proxyApp.Stop() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stateProvider, err := statesync.NewLightClientStateProvider(
+		ctx,
+		state.ChainID, state.ConsensusParams.Encoding.Canonical, state.Version, state.InitialHeight,
+		ssConfig.RPCServers, light.TrustOptions{
+			Period: ssConfig.TrustPeriod,
+			Height: ssConfig.TrustHeight,
+			Hash:   ssConfig.TrustHashBytes(),
+		}, logger.With("module", "light"))
+	if err != nil {
+		return fmt.Errorf("failed to set up light client state provider: %w", err)
+	}
+
+	state, commit, err := statesync.RestoreArchive(ssConfig, logger.With("module", "statesync"),
+		proxyApp.Snapshot(), proxyApp.Query(), stateProvider, ssConfig.TempDir, r)
+	if err != nil {
+		return fmt.Errorf("failed to import snapshot: %w", err)
+	}
+	if err := stateStore.Bootstrap(state); err != nil {
+		return fmt.Errorf("failed to bootstrap node with new state: %w", err)
+	}
+	if err := blockStore.SaveSeenCommit(state.LastBlockHeight, commit); err != nil {
+		return fmt.Errorf("failed to store last seen commit: %w", err)
+	}
+
+	logger.Info("Imported snapshot", "height", state.LastBlockHeight,
+		"appHash", fmt.Sprintf("%X", state.AppHash))
+	return nil
+}
+
+// startProxyApp connects to the application, on the proxy_app address.
+func startProxyApp(config *cfg.Config, logger log.Logger) (proxy.AppConns, error) {
+	clientCreator := proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir())
+	proxyApp := proxy.NewAppConns(clientCreator, proxy.NopMetrics())
+	proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := proxyApp.Start(); err != nil {
+		return nil, fmt.Errorf("error starting proxy app connections: %w", err)
+	}
+	return proxyApp, nil
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+var (
+	storeExportFrom int64
+	storeExportTo   int64
+)
+
+func init() {
+	StoreExportCmd.Flags().Int64Var(&storeExportFrom, "from", 0,
+		"first height to export (default: the base of the block store)")
+	StoreExportCmd.Flags().Int64Var(&storeExportTo, "to", 0,
+		"last height to export (default: the height of the block store)")
+
+	StoreCmd.AddCommand(StoreExportCmd)
+	StoreCmd.AddCommand(StoreImportCmd)
+}
+
+// StoreCmd contains the commands to export and import ranges of blocks, as archive files.
+var StoreCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Export or import a range of blocks, as an archive file",
+}
+
+// StoreExportCmd writes a range of blocks to an archive file.
+var StoreExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export a range of blocks to an archive file",
+	Long: `
+Export the blocks from --from to --to (inclusive), along with their commits,
+validator sets and ABCI responses, to an archive file, or to the standard output
+if the file is "-". The archive can be imported with "store import", e.g. to
+move old blocks to cold storage and serve them from another node later on.
+
+The node must not be running.
+`,
+	Example: `
+	cometbft store export --from 1 --to 100000 blocks-1-100000.tar
+	cometbft store export --from 1 --to 100000 - | gzip > blocks-1-100000.tar.gz
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w, logger := io.Writer(os.Stdout), logger
+		if args[0] == "-" {
+			// keep the standard output for the archive
+			logger = log.NewTMLogger(log.NewSyncWriter(os.Stderr))
+		} else {
+			f, err := os.OpenFile(args[0], os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		blockStore, stateStore, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = blockStore.Close()
+			_ = stateStore.Close()
+		}()
+
+		from, to := storeExportFrom, storeExportTo
+		if from == 0 {
+			from = blockStore.Base()
+		}
+		if to == 0 {
+			to = blockStore.Height()
+		}
+		manifest, err := store.ExportArchive(blockStore, stateStore, from, to, w)
+		if err != nil {
+			return fmt.Errorf("failed to export blocks: %w", err)
+		}
+		if f, ok := w.(*os.File); ok && f != os.Stdout {
+			if err := f.Sync(); err != nil {
+				return err
+			}
+		}
+		logger.Info("Exported blocks", "from", manifest.From, "to", manifest.To)
+		return nil
+	},
+}
+
+// StoreImportCmd reads a range of blocks from an archive file into the stores of the node.
+var StoreImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import a range of blocks from an archive file",
+	Long: `
+Import the blocks of an archive file written by "store export", or read from the
+standard input if the file is "-", along with their commits, validator sets and
+ABCI responses. The blocks must follow the last block of the node, if any, so
+that consecutive archives can be imported one after the other.
+
+The imported blocks can be served with "cometbft inspect". As the state of the
+node is left as is, the node must not have any state: the archive must be
+imported into the data directory of a node which is not used otherwise, and
+which must not be running. The commits of the archive are not verified, so it
+must come from a trusted source.
+`,
+	Example: `
+	cometbft store import blocks-1-100000.tar
+	gunzip -c blocks-1-100000.tar.gz | cometbft store import -
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r := io.Reader(os.Stdin)
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		return importBlocks(config, r)
+	},
+}
+
+func importBlocks(config *cfg.Config, r io.Reader) error {
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+
+	blockStoreDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return err
+	}
+	blockStore := store.NewBlockStore(blockStoreDB)
+	defer blockStore.Close()
+	stateDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "state", Config: config})
+	if err != nil {
+		return err
+	}
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		ABCIResponsesRetention: config.Storage.RetainABCIResponses,
+	})
+	defer stateStore.Close()
+
+	state, err := stateStore.Load()
+	if err != nil {
+		return err
+	}
+	if !state.IsEmpty() {
+		return errors.New("the node already has a state, blocks can only be imported into an empty data directory")
+	}
+
+	manifest, err := store.ImportArchive(blockStore, stateStore, genDoc.ChainID,
+		genDoc.ConsensusParams.Encoding.Canonical, r)
+	if err != nil {
+		return fmt.Errorf("failed to import blocks: %w", err)
+	}
+	logger.Info("Imported blocks", "from", manifest.From, "to", manifest.To)
+	return nil
+}
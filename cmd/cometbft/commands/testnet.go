@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -11,7 +12,9 @@ import (
 	"github.com/spf13/viper"
 
 	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/libs/bytes"
+	cmtos "github.com/cometbft/cometbft/libs/os"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/privval"
@@ -34,10 +37,19 @@ var (
 	hostnames               []string
 	p2pPort                 int
 	randomMonikers          bool
+
+	keyTypes          []string
+	powers            []int64
+	powerDistribution string
+	nSentries         int
 )
 
 const (
 	nodeDirPerm = 0755
+
+	powerDistributionUniform   = "uniform"
+	powerDistributionLinear    = "linear"
+	powerDistributionGeometric = "geometric"
 )
 
 func init() {
@@ -74,6 +86,15 @@ func init() {
 		"P2P Port")
 	TestnetFilesCmd.Flags().BoolVar(&randomMonikers, "random-monikers", false,
 		"randomize the moniker for each generated node")
+	TestnetFilesCmd.Flags().StringSliceVar(&keyTypes, "key-type", []string{types.ABCIPubKeyTypeEd25519},
+		"key type of the validators, or of each validator (\"ed25519,bn254,...\"): ed25519, secp256k1 or bn254")
+	TestnetFilesCmd.Flags().Int64SliceVar(&powers, "power", nil,
+		"voting power of each validator (\"10,20,...\"), overriding power-distribution")
+	TestnetFilesCmd.Flags().StringVar(&powerDistribution, "power-distribution", powerDistributionUniform,
+		"voting power distribution of the validators: uniform (1, 1, 1, ...), linear (1, 2, 3, ...) "+
+			"or geometric (1, 2, 4, ...)")
+	TestnetFilesCmd.Flags().IntVar(&nSentries, "sentries", 0,
+		"number of sentry nodes of each validator, which then only peers with its sentries")
 }
 
 // TestnetFilesCmd allows initialisation of files for a CometBFT testnet.
@@ -87,20 +108,41 @@ Note, strict routability for addresses is turned off in the config file.
 
 Optionally, it will fill in persistent_peers list in config file using either hostnames or IPs.
 
+The validators can each have their own key type and voting power. The bn254
+keys come with their proofs of possession in the genesis file. With "sentries",
+each validator is put behind its own sentry nodes, which are numbered after the
+validators and before the non-validators: the validator only peers with its
+sentries, which keep its ID private.
+
 Example:
 
 	cometbft testnet --v 4 --o ./output --populate-persistent-peers --starting-ip-address 192.168.10.2
+	cometbft testnet --v 4 --key-type bn254 --power-distribution linear --sentries 2
+	cometbft testnet --v 3 --key-type ed25519,bn254,bn254 --power 10,20,30
 	`,
 	RunE: testnetFiles,
 }
 
 func testnetFiles(cmd *cobra.Command, args []string) error {
-	if len(hostnames) > 0 && len(hostnames) != (nValidators+nNonValidators) {
+	nNodes := nValidators*(1+nSentries) + nNonValidators
+	if len(hostnames) > 0 && len(hostnames) != nNodes {
 		return fmt.Errorf(
-			"testnet needs precisely %d hostnames (number of validators plus non-validators) if --hostname parameter is used",
-			nValidators+nNonValidators,
+			"testnet needs precisely %d hostnames (number of validators, sentries and non-validators) "+
+				"if --hostname parameter is used",
+			nNodes,
 		)
 	}
+	if nSentries < 0 {
+		return fmt.Errorf("the number of sentries must not be negative, got %d", nSentries)
+	}
+	valKeyTypes, err := testnetKeyTypes()
+	if err != nil {
+		return err
+	}
+	valPowers, err := testnetPowers()
+	if err != nil {
+		return err
+	}
 
 	config := cfg.DefaultConfig()
 
@@ -119,29 +161,27 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 	}
 
 	genVals := make([]types.GenesisValidator, nValidators)
+	consensusParams := types.DefaultConsensusParams()
+	consensusParams.Validator.PubKeyTypes = nil
 
 	for i := 0; i < nValidators; i++ {
 		nodeDirName := fmt.Sprintf("%s%d", nodeDirPrefix, i)
 		nodeDir := filepath.Join(outputDir, nodeDirName)
 		config.SetRoot(nodeDir)
 
-		err := os.MkdirAll(filepath.Join(nodeDir, "config"), nodeDirPerm)
-		if err != nil {
-			_ = os.RemoveAll(outputDir)
-			return err
-		}
-		err = os.MkdirAll(filepath.Join(nodeDir, "data"), nodeDirPerm)
-		if err != nil {
+		if err := makeNodeDir(nodeDir); err != nil {
 			_ = os.RemoveAll(outputDir)
 			return err
 		}
 
+		pvKeyFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorKey)
+		pvStateFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorState)
+		if !cmtos.FileExists(pvKeyFile) {
+			privval.GenFilePVCustom(pvKeyFile, pvStateFile, valKeyTypes[i]).Save()
+		}
 		if err := initFilesWithConfig(config); err != nil {
 			return err
 		}
-
-		pvKeyFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorKey)
-		pvStateFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorState)
 		pv := privval.LoadFilePV(pvKeyFile, pvStateFile)
 
 		pubKey, err := pv.GetPubKey()
@@ -151,23 +191,25 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 		genVals[i] = types.GenesisValidator{
 			Address: pubKey.Address(),
 			PubKey:  pubKey,
-			Power:   1,
+			Power:   valPowers[i],
 			Name:    nodeDirName,
 		}
+		if privKey, ok := pv.Key.PrivKey.(bn254.PrivKey); ok {
+			genVals[i].ProofOfPossession, err = privKey.ProvePossession()
+			if err != nil {
+				return fmt.Errorf("can't prove possession of the bn254 key: %w", err)
+			}
+		}
+		if !types.IsValidPubkeyType(consensusParams.Validator, valKeyTypes[i]) {
+			consensusParams.Validator.PubKeyTypes = append(consensusParams.Validator.PubKeyTypes, valKeyTypes[i])
+		}
 	}
 
-	for i := 0; i < nNonValidators; i++ {
-		nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i+nValidators))
+	for i := nValidators; i < nNodes; i++ {
+		nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i))
 		config.SetRoot(nodeDir)
 
-		err := os.MkdirAll(filepath.Join(nodeDir, "config"), nodeDirPerm)
-		if err != nil {
-			_ = os.RemoveAll(outputDir)
-			return err
-		}
-
-		err = os.MkdirAll(filepath.Join(nodeDir, "data"), nodeDirPerm)
-		if err != nil {
+		if err := makeNodeDir(nodeDir); err != nil {
 			_ = os.RemoveAll(outputDir)
 			return err
 		}
@@ -180,14 +222,14 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 	// Generate genesis doc from generated validators
 	genDoc := &types.GenesisDoc{
 		ChainID:         "chain-" + cmtrand.Str(6),
-		ConsensusParams: types.DefaultConsensusParams(),
+		ConsensusParams: consensusParams,
 		GenesisTime:     cmttime.Now(),
 		InitialHeight:   initialHeight,
 		Validators:      genVals,
 	}
 
 	// Write genesis file.
-	for i := 0; i < nValidators+nNonValidators; i++ {
+	for i := 0; i < nNodes; i++ {
 		nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i))
 		if err := genDoc.SaveAs(filepath.Join(nodeDir, config.BaseConfig.Genesis)); err != nil {
 			_ = os.RemoveAll(outputDir)
@@ -195,37 +237,110 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Gather persistent peer addresses.
-	var (
-		persistentPeers string
-		err             error
-	)
-	if populatePersistentPeers {
-		persistentPeers, err = persistentPeersString(config)
-		if err != nil {
-			_ = os.RemoveAll(outputDir)
-			return err
-		}
+	// Gather the node IDs, for the persistent peers and the sentries.
+	nodeIDs, err := testnetNodeIDs(config, nNodes)
+	if err != nil {
+		_ = os.RemoveAll(outputDir)
+		return err
 	}
 
 	// Overwrite default config.
-	for i := 0; i < nValidators+nNonValidators; i++ {
+	for i := 0; i < nNodes; i++ {
 		nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i))
 		config.SetRoot(nodeDir)
 		config.P2P.AddrBookStrict = false
 		config.P2P.AllowDuplicateIP = true
 		if populatePersistentPeers {
-			config.P2P.PersistentPeers = persistentPeers
+			config.P2P.PersistentPeers = persistentPeersString(i, nodeIDs)
+		}
+		if nSentries > 0 {
+			config.P2P.PexReactor = i >= nValidators
+			if val, ok := sentryOf(i); ok {
+				config.P2P.PrivatePeerIDs = string(nodeIDs[val])
+				config.P2P.UnconditionalPeerIDs = string(nodeIDs[val])
+			} else {
+				config.P2P.PrivatePeerIDs = ""
+				config.P2P.UnconditionalPeerIDs = ""
+			}
 		}
 		config.Moniker = moniker(i)
 
 		cfg.WriteConfigFile(filepath.Join(nodeDir, "config", "config.toml"), config)
 	}
 
-	fmt.Printf("Successfully initialized %v node directories\n", nValidators+nNonValidators)
+	fmt.Printf("Successfully initialized %v node directories\n", nNodes)
 	return nil
 }
 
+func makeNodeDir(nodeDir string) error {
+	if err := os.MkdirAll(filepath.Join(nodeDir, "config"), nodeDirPerm); err != nil {
+		return err
+	}
+	return os.MkdirAll(filepath.Join(nodeDir, "data"), nodeDirPerm)
+}
+
+// testnetKeyTypes returns the key type of each validator.
+func testnetKeyTypes() ([]string, error) {
+	if len(keyTypes) != 1 && len(keyTypes) != nValidators {
+		return nil, fmt.Errorf("testnet needs one key type, or precisely %d (number of validators)", nValidators)
+	}
+	valKeyTypes := make([]string, nValidators)
+	for i := range valKeyTypes {
+		if len(keyTypes) == 1 {
+			valKeyTypes[i] = keyTypes[0]
+		} else {
+			valKeyTypes[i] = keyTypes[i]
+		}
+		if _, ok := types.ABCIPubKeyTypesToNames[valKeyTypes[i]]; !ok {
+			return nil, fmt.Errorf("unsupported key type %q", valKeyTypes[i])
+		}
+	}
+	return valKeyTypes, nil
+}
+
+// testnetPowers returns the voting power of each validator.
+func testnetPowers() ([]int64, error) {
+	if len(powers) > 0 && len(powers) != nValidators {
+		return nil, fmt.Errorf("testnet needs precisely %d powers (number of validators) if --power is used",
+			nValidators)
+	}
+	valPowers := make([]int64, nValidators)
+	var total int64
+	for i := range valPowers {
+		switch {
+		case len(powers) > 0:
+			valPowers[i] = powers[i]
+		case powerDistribution == powerDistributionUniform:
+			valPowers[i] = 1
+		case powerDistribution == powerDistributionLinear:
+			valPowers[i] = int64(i) + 1
+		case powerDistribution == powerDistributionGeometric:
+			if i >= 62 {
+				return nil, errors.New("too many validators for a geometric power distribution")
+			}
+			valPowers[i] = 1 << i
+		default:
+			return nil, fmt.Errorf("unknown power distribution %q", powerDistribution)
+		}
+		if valPowers[i] <= 0 {
+			return nil, fmt.Errorf("the voting power must be positive, got %d", valPowers[i])
+		}
+		if total > types.MaxTotalVotingPower-valPowers[i] {
+			return nil, fmt.Errorf("the total voting power exceeds %d", types.MaxTotalVotingPower)
+		}
+		total += valPowers[i]
+	}
+	return valPowers, nil
+}
+
+// sentryOf returns the validator of node i, if it is a sentry.
+func sentryOf(i int) (int, bool) {
+	if nSentries == 0 || i < nValidators || i >= nValidators*(1+nSentries) {
+		return 0, false
+	}
+	return (i - nValidators) / nSentries, true
+}
+
 func hostnameOrIP(i int) string {
 	if len(hostnames) > 0 && i < len(hostnames) {
 		return hostnames[i]
@@ -246,18 +361,40 @@ func hostnameOrIP(i int) string {
 	return ip.String()
 }
 
-func persistentPeersString(config *cfg.Config) (string, error) {
-	persistentPeers := make([]string, nValidators+nNonValidators)
-	for i := 0; i < nValidators+nNonValidators; i++ {
+func testnetNodeIDs(config *cfg.Config, nNodes int) ([]p2p.ID, error) {
+	nodeIDs := make([]p2p.ID, nNodes)
+	for i := range nodeIDs {
 		nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i))
 		config.SetRoot(nodeDir)
 		nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
 		if err != nil {
-			return "", err
+			return nil, err
+		}
+		nodeIDs[i] = nodeKey.ID()
+	}
+	return nodeIDs, nil
+}
+
+// persistentPeersString returns the persistent peers of node i: all the nodes,
+// unless there are sentries. Then, the validators only peer with their
+// sentries, and the other nodes with each other, and the sentries with their
+// validator.
+func persistentPeersString(i int, nodeIDs []p2p.ID) string {
+	persistentPeers := make([]string, 0, len(nodeIDs))
+	for j, id := range nodeIDs {
+		if nSentries > 0 {
+			if i < nValidators {
+				if val, ok := sentryOf(j); !ok || val != i {
+					continue
+				}
+			} else if val, ok := sentryOf(i); j < nValidators && (!ok || val != j) {
+				continue
+			}
 		}
-		persistentPeers[i] = p2p.IDAddressString(nodeKey.ID(), fmt.Sprintf("%s:%d", hostnameOrIP(i), p2pPort))
+		persistentPeers = append(persistentPeers,
+			p2p.IDAddressString(id, fmt.Sprintf("%s:%d", hostnameOrIP(j), p2pPort)))
 	}
-	return strings.Join(persistentPeers, ","), nil
+	return strings.Join(persistentPeers, ",")
 }
 
 func moniker(i int) string {
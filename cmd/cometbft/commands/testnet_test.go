@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTestnetFiles(t *testing.T) {
+	outputDir = t.TempDir()
+	nValidators, nNonValidators, nSentries = 3, 1, 2
+	keyTypes = []string{types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeBn254, types.ABCIPubKeyTypeBn254}
+	powers, powerDistribution = nil, powerDistributionLinear
+	t.Cleanup(func() {
+		nValidators, nNonValidators, nSentries = 4, 0, 0
+		keyTypes = []string{types.ABCIPubKeyTypeEd25519}
+		powerDistribution = powerDistributionUniform
+	})
+	require.NoError(t, testnetFiles(nil, nil))
+
+	nodeDir := func(i int) string { return filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i)) }
+	genFile := filepath.Join(nodeDir(0), "config", "genesis.json")
+	result := validateGenesisFile(genFile)
+	require.True(t, result.Valid, result.Errors)
+	genDoc, err := types.GenesisDocFromFile(genFile)
+	require.NoError(t, err)
+	require.Len(t, genDoc.Validators, 3)
+	for i, val := range genDoc.Validators {
+		assert.Equal(t, keyTypes[i], val.PubKey.Type())
+		assert.EqualValues(t, i+1, val.Power)
+	}
+	assert.Nil(t, genDoc.Validators[0].ProofOfPossession)
+	assert.NotNil(t, genDoc.Validators[1].ProofOfPossession)
+	assert.Equal(t, []string{types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeBn254},
+		genDoc.ConsensusParams.Validator.PubKeyTypes)
+
+	readConfig := func(i int) *cfg.Config {
+		v := viper.New()
+		v.SetConfigFile(filepath.Join(nodeDir(i), "config", "config.toml"))
+		require.NoError(t, v.ReadInConfig())
+		config := cfg.DefaultConfig()
+		require.NoError(t, v.Unmarshal(config))
+		return config
+	}
+	nodeIDs, err := testnetNodeIDs(cfg.DefaultConfig(), 10)
+	require.NoError(t, err)
+
+	// validator 1 only peers with its sentries, nodes 5 and 6
+	config := readConfig(1)
+	assert.False(t, config.P2P.PexReactor)
+	peers := strings.Split(config.P2P.PersistentPeers, ",")
+	require.Len(t, peers, 2)
+	assert.True(t, strings.HasPrefix(peers[0], string(nodeIDs[5])+"@"))
+	assert.True(t, strings.HasPrefix(peers[1], string(nodeIDs[6])+"@"))
+
+	// its sentries keep it private
+	config = readConfig(5)
+	assert.True(t, config.P2P.PexReactor)
+	assert.Equal(t, string(nodeIDs[1]), config.P2P.PrivatePeerIDs)
+	assert.Contains(t, config.P2P.PersistentPeers, string(nodeIDs[1]))
+	assert.NotContains(t, config.P2P.PersistentPeers, string(nodeIDs[0]))
+
+	// the non-validator peers with the sentries only
+	config = readConfig(9)
+	assert.Empty(t, config.P2P.PrivatePeerIDs)
+	for i := 0; i < 3; i++ {
+		assert.NotContains(t, config.P2P.PersistentPeers, string(nodeIDs[i]))
+	}
+	assert.Contains(t, config.P2P.PersistentPeers, string(nodeIDs[3]))
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/types"
+)
+
+var validateGenesisJSON bool
+
+func init() {
+	ValidateGenesisCmd.Flags().BoolVar(&validateGenesisJSON, "json", false,
+		"print the result as JSON, for CI pipelines")
+}
+
+// ValidateGenesisCmd validates a genesis file.
+var ValidateGenesisCmd = &cobra.Command{
+	Use:   "validate-genesis [file]",
+	Short: "validate a genesis file",
+	Long: `
+Validate the genesis file of the node, or the given file, beyond the checks made
+when starting the node: the keys of the validators must be of the types allowed
+by the consensus params, the bn254 keys must come with a valid proof of
+possession, the validators must be unique and their total voting power must not
+overflow, and app_state must be a JSON object.
+
+All the errors found are reported, each with the field it is about. With --json,
+the result is printed as a JSON object, {"valid": bool, "errors": [{"field",
+"error"}]}. The command fails if the file is invalid.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := config.GenesisFile()
+		if len(args) > 0 {
+			file = args[0]
+		}
+		result := validateGenesisFile(file)
+
+		if validateGenesisJSON {
+			bz, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+		} else {
+			for _, e := range result.Errors {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", e.Field, e.Error)
+			}
+			if result.Valid {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s is valid\n", file)
+			}
+		}
+		if !result.Valid {
+			return fmt.Errorf("%s is invalid", file)
+		}
+		return nil
+	},
+}
+
+// genesisValidation is the result of the validation of a genesis file.
+type genesisValidation struct {
+	Valid  bool           `json:"valid"`
+	Errors []genesisError `json:"errors"`
+}
+
+// genesisError is an error of a field of a genesis file.
+type genesisError struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+func (v *genesisValidation) addError(field string, err error) {
+	v.Errors = append(v.Errors, genesisError{Field: field, Error: err.Error()})
+}
+
+// validateGenesisFile validates the genesis file, collecting all the errors.
+func validateGenesisFile(file string) (result genesisValidation) {
+	result.Errors = []genesisError{}
+	defer func() { result.Valid = len(result.Errors) == 0 }()
+
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		result.addError("", err)
+		return result
+	}
+	var genDoc types.GenesisDoc
+	if err := cmtjson.Unmarshal(bz, &genDoc); err != nil {
+		result.addError("", err)
+		return result
+	}
+
+	if genDoc.ChainID == "" {
+		result.addError("chain_id", errors.New("must not be empty"))
+	} else if len(genDoc.ChainID) > types.MaxChainIDLen {
+		result.addError("chain_id", fmt.Errorf("is too long (max: %d)", types.MaxChainIDLen))
+	}
+	if genDoc.InitialHeight < 0 {
+		result.addError("initial_height", fmt.Errorf("must not be negative, got %d", genDoc.InitialHeight))
+	}
+
+	params := genDoc.ConsensusParams
+	if params == nil {
+		params = types.DefaultConsensusParams()
+	} else if params.Encoding.Canonical == "" {
+		params.Encoding = types.DefaultEncodingParams()
+	}
+	if err := params.ValidateBasic(); err != nil {
+		result.addError("consensus_params", err)
+	}
+
+	validateGenesisValidators(&result, genDoc.Validators, params)
+
+	if len(genDoc.AppState) > 0 {
+		var appState interface{}
+		if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+			result.addError("app_state", err)
+		} else if _, ok := appState.(map[string]interface{}); !ok && appState != nil {
+			result.addError("app_state", errors.New("must be a JSON object"))
+		}
+	}
+
+	return result
+}
+
+func validateGenesisValidators(result *genesisValidation, vals []types.GenesisValidator,
+	params *types.ConsensusParams) {
+	var (
+		totalPower int64
+		seen       = make(map[string]int, len(vals))
+	)
+	for i, v := range vals {
+		field := fmt.Sprintf("validators[%d]", i)
+		if v.PubKey == nil {
+			result.addError(field+".pub_key", errors.New("is missing"))
+			continue
+		}
+
+		keyType := v.PubKey.Type()
+		if !types.IsValidPubkeyType(params.Validator, keyType) {
+			result.addError(field+".pub_key", fmt.Errorf("%s keys are not in consensus_params.validator.pub_key_types",
+				keyType))
+		}
+		if pk, ok := v.PubKey.(bn254.PubKey); ok {
+			if len(v.ProofOfPossession) == 0 {
+				result.addError(field+".proof_of_possession", errors.New("is missing for a bn254 key"))
+			} else if !pk.VerifyPossession(v.ProofOfPossession) {
+				result.addError(field+".proof_of_possession", errors.New("is invalid"))
+			}
+		} else if len(v.ProofOfPossession) > 0 {
+			result.addError(field+".proof_of_possession", fmt.Errorf("is set for a %s key", keyType))
+		}
+
+		address := v.PubKey.Address()
+		if len(v.Address) > 0 && !bytes.Equal(v.Address, address) {
+			result.addError(field+".address", fmt.Errorf("is not the address of the key, %v", address))
+		}
+		if j, ok := seen[string(address)]; ok {
+			result.addError(field, fmt.Errorf("is a duplicate of validators[%d]", j))
+		}
+		seen[string(address)] = i
+
+		if v.Power <= 0 {
+			result.addError(field+".power", fmt.Errorf("must be positive, got %d", v.Power))
+			continue
+		}
+		if totalPower > types.MaxTotalVotingPower-v.Power {
+			result.addError(field+".power", fmt.Errorf("overflows the max total voting power (%d)",
+				types.MaxTotalVotingPower))
+			continue
+		}
+		totalPower += v.Power
+	}
+}
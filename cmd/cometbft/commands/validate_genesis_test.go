@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestValidateGenesisFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "genesis.json")
+
+	bn254Key := bn254.GenPrivKey()
+	proof, err := bn254Key.ProvePossession()
+	require.NoError(t, err)
+	ed25519Key := ed25519.GenPrivKey().PubKey()
+	params := types.DefaultConsensusParams()
+	params.Validator.PubKeyTypes = []string{types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeBn254}
+	genDoc := &types.GenesisDoc{
+		ChainID:         "test-chain",
+		ConsensusParams: params,
+		Validators: []types.GenesisValidator{
+			{PubKey: bn254Key.PubKey(), Power: 10, ProofOfPossession: proof},
+			{PubKey: ed25519Key, Power: 10},
+		},
+		AppState: []byte(`{"accounts": []}`),
+	}
+	require.NoError(t, genDoc.SaveAs(file))
+	result := validateGenesisFile(file)
+	require.True(t, result.Valid, result.Errors)
+	require.Empty(t, result.Errors)
+
+	// all the errors are reported
+	genDoc.ConsensusParams.Validator.PubKeyTypes = []string{types.ABCIPubKeyTypeEd25519}
+	genDoc.Validators[0].ProofOfPossession = nil
+	genDoc.Validators = append(genDoc.Validators,
+		types.GenesisValidator{PubKey: ed25519Key, Power: types.MaxTotalVotingPower})
+	genDoc.AppState = []byte(`"state"`)
+	require.NoError(t, genDoc.SaveAs(file))
+	result = validateGenesisFile(file)
+	require.False(t, result.Valid)
+	require.Equal(t, []string{
+		"validators[0].pub_key",
+		"validators[0].proof_of_possession",
+		"validators[2]",
+		"validators[2].power",
+		"app_state",
+	}, genesisErrorFields(result))
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"chain_id": 1}`), 0o600))
+	result = validateGenesisFile(file)
+	require.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+}
+
+func genesisErrorFields(result genesisValidation) []string {
+	fields := make([]string, len(result.Errors))
+	for i, e := range result.Errors {
+		fields[i] = e.Field
+	}
+	return fields
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/consensus"
+)
+
+var walRepairDryRun bool
+
+func init() {
+	WALRepairCmd.Flags().BoolVar(&walRepairDryRun, "dry-run", false,
+		"only check the WAL, without truncating it")
+
+	WALCmd.AddCommand(WALRepairCmd)
+}
+
+// WALCmd contains the commands managing the consensus WAL.
+var WALCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "Check or repair the consensus write-ahead log",
+}
+
+// WALRepairCmd truncates the files of the consensus WAL at their last valid
+// record.
+var WALRepairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Truncate the consensus WAL at its last valid record",
+	Long: `
+Scan the files of the consensus WAL, in wal_file, for records truncated or
+corrupted e.g. by an unclean shutdown, and truncate each corrupted file at its
+last valid record, once copied to <file>.CORRUPTED. The records past the
+corruption are lost.
+
+The height, round and step from which consensus recovers are reported, along
+with the last height ended in the WAL. The node must be stopped.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		walFile := config.Consensus.WalFile()
+		var (
+			check *consensus.WALCheck
+			err   error
+		)
+		if walRepairDryRun {
+			check, err = consensus.CheckWAL(walFile)
+		} else {
+			check, err = consensus.RepairWAL(walFile)
+		}
+		if err != nil {
+			return err
+		}
+		printWALCheck(cmd.OutOrStdout(), check, !walRepairDryRun)
+		return nil
+	},
+}
+
+func printWALCheck(w io.Writer, check *consensus.WALCheck, repaired bool) {
+	for _, fc := range check.Files {
+		if !fc.Corrupted() {
+			fmt.Fprintf(w, "%s: %d records, %d bytes, valid\n", fc.Path, fc.Records, fc.Size)
+			continue
+		}
+		fmt.Fprintf(w, "%s: %d valid records, corrupted at byte %d of %d: %v\n",
+			fc.Path, fc.Records, fc.ValidSize, fc.Size, fc.Err)
+		if repaired {
+			fmt.Fprintf(w, "%s: truncated to %d bytes, backed up to %s.CORRUPTED\n",
+				fc.Path, fc.ValidSize, fc.Path)
+		}
+	}
+	fmt.Fprintf(w, "last ended height: %d\n", check.LastEndHeight)
+	if check.Height > 0 {
+		fmt.Fprintf(w, "recoverable height/round/step: %d/%d/%s\n", check.Height, check.Round, check.Step)
+	}
+}
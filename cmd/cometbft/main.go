@@ -15,11 +15,13 @@ func main() {
 	rootCmd := cmd.RootCmd
 	rootCmd.AddCommand(
 		cmd.GenValidatorCmd,
+		cmd.KeyCmd,
 		cmd.InitFilesCmd,
 		cmd.ProbeUpnpCmd,
 		cmd.LightCmd,
 		cmd.ReplayCmd,
 		cmd.ReplayConsoleCmd,
+		cmd.ABCIReplayCmd,
 		cmd.ResetAllCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ResetStateCmd,
@@ -29,8 +31,17 @@ func main() {
 		cmd.GenNodeKeyCmd,
 		cmd.VersionCmd,
 		cmd.RollbackStateCmd,
+		cmd.ValidateGenesisCmd,
+		cmd.WALCmd,
 		cmd.CompactGoLevelDBCmd,
+		cmd.CompactDBCmd,
+		cmd.MigrateDBCmd,
 		cmd.InspectCmd,
+		cmd.SnapshotCmd,
+		cmd.StoreCmd,
+		cmd.ExportCmd,
+		cmd.ReIndexEventCmd,
+		cmd.EVMCalldataCmd,
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
 	)
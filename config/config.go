@@ -8,8 +8,10 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/cometbft/cometbft/internal/encdb"
 	"github.com/cometbft/cometbft/version"
 )
 
@@ -24,6 +26,39 @@ const (
 	// LogFormatJSON is a format for json output
 	LogFormatJSON = "json"
 
+	// ABCIUnresponsiveHalt stops the node when the application is unresponsive
+	// on the consensus connection.
+	ABCIUnresponsiveHalt = "halt"
+	// ABCIUnresponsiveDegrade keeps waiting for the application, while the
+	// calls on the other connections fail fast.
+	ABCIUnresponsiveDegrade = "degrade"
+
+	// PrivValidatorOnDeadlineSkip gives up on the sign requests exceeding
+	// their deadline, the validator missing the vote or the proposal.
+	PrivValidatorOnDeadlineSkip = "skip"
+	// PrivValidatorOnDeadlineBlock keeps retrying the sign requests exceeding
+	// their deadline, blocking consensus until the signer answers.
+	PrivValidatorOnDeadlineBlock = "block"
+
+	// ModeValidator runs a node which signs blocks and votes with its private
+	// validator, when in the validator set.
+	ModeValidator = "validator"
+	// ModeFull runs a node which follows the chain, but never signs.
+	ModeFull = "full"
+	// ModeSeed runs a node which only crawls the network for peers, and shares
+	// their addresses.
+	ModeSeed = "seed"
+
+	// ReconnectBackoffHybrid redials a persistent peer at a fixed interval for
+	// a few minutes, then with an exponentially increasing pause (3s, 9s, 27s,
+	// ...).
+	ReconnectBackoffHybrid = "hybrid"
+	// ReconnectBackoffConstant redials a persistent peer at a fixed interval.
+	ReconnectBackoffConstant = "constant"
+	// ReconnectBackoffExponential redials a persistent peer with a pause
+	// doubling from the interval at each attempt.
+	ReconnectBackoffExponential = "exponential"
+
 	// DefaultLogLevel defines a default log level as INFO.
 	DefaultLogLevel = "info"
 
@@ -39,6 +74,17 @@ const (
 
 	DefaultNodeKeyName  = "node_key.json"
 	DefaultAddrBookName = "addrbook.json"
+
+	// SubscriptionOverflowPolicyClose cancels a subscription whose buffer is
+	// full.
+	SubscriptionOverflowPolicyClose = "close"
+	// SubscriptionOverflowPolicyDrop drops the events which don't fit in the
+	// buffer of a subscription, which stays open.
+	SubscriptionOverflowPolicyDrop = "drop"
+	// SubscriptionOverflowPolicyDropOldest drops the oldest events in the
+	// buffer of a subscription to make room for the new ones, keeping the
+	// subscription open.
+	SubscriptionOverflowPolicyDropOldest = "drop_oldest"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -122,6 +168,11 @@ func (cfg *Config) SetRoot(root string) *Config {
 	return cfg
 }
 
+// ProfileDir returns the full path to the directory of the profiles.
+func (cfg *Config) ProfileDir() string {
+	return rootify(cfg.Instrumentation.ProfileDir, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *Config) ValidateBasic() error {
@@ -146,15 +197,38 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.Storage.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [storage] section: %w", err)
+	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx_index] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
+	if cfg.P2P.SeedMode && cfg.Mode != ModeSeed {
+		return errors.New("p2p.seed_mode is deprecated, set mode = \"seed\" instead")
+	}
+	if cfg.Mode == ModeSeed {
+		if !cfg.P2P.PexReactor {
+			return errors.New("a seed node needs the peer-exchange reactor (p2p.pex)")
+		}
+		if cfg.StateSync.Enable {
+			return errors.New("a seed node can't state sync (statesync.enable)")
+		}
+	}
 	return nil
 }
 
 // CheckDeprecated returns any deprecation warnings. These are printed to the operator on startup
 func (cfg *Config) CheckDeprecated() []string {
 	var warnings []string
+	if cfg.P2P.SeedMode {
+		warnings = append(warnings, "p2p.seed_mode is deprecated, use mode = \"seed\" instead")
+	}
+	if cfg.Storage.DiscardABCIResponses {
+		warnings = append(warnings, "storage.discard_abci_responses is deprecated, use retain_abci_responses = 1 instead")
+	}
 	return warnings
 }
 
@@ -179,6 +253,16 @@ type BaseConfig struct { //nolint: maligned
 	// A custom human readable name for this node
 	Moniker string `mapstructure:"moniker"`
 
+	// Mode of the node: validator | full | seed
+	// * validator - signs blocks and votes with the private validator, which
+	//   must have a public key, when in the validator set
+	// * full - follows the chain, but never signs, even if its key is in the
+	//   validator set; a remote signer can't be set
+	// * seed - only crawls the network for peers and shares their addresses,
+	//   without the mempool, consensus, block sync, evidence and state sync
+	//   reactors; the peer-exchange reactor must be enabled
+	Mode string `mapstructure:"mode"`
+
 	// Database backend: goleveldb | cleveldb | boltdb | rocksdb
 	// * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 	//   - pure go
@@ -198,17 +282,36 @@ type BaseConfig struct { //nolint: maligned
 	// * badgerdb (uses github.com/dgraph-io/badger)
 	//   - EXPERIMENTAL
 	//   - use badgerdb build tag (go build -tags badgerdb)
+	// * pebbledb (uses github.com/cockroachdb/pebble)
+	//   - EXPERIMENTAL
+	//   - pure go
+	//   - use pebbledb build tag (go build -tags pebbledb)
 	DBBackend string `mapstructure:"db_backend"`
 
 	// Database directory
 	DBPath string `mapstructure:"db_dir"`
 
+	// Source of the key encrypting the values of the blockstore, state and
+	// evidence databases at rest, with AES-256-GCM. Empty disables encryption.
+	// The key is 32 bytes long, hex-encoded, and read from:
+	// * file:<path> - a file
+	// * env:<name> - an environment variable
+	// * cmd:<command> - the output of a command, e.g. decrypting the key with
+	//   a key management service
+	DBEncryptionKey string `mapstructure:"db_encryption_key"`
+
 	// Output level for logging
 	LogLevel string `mapstructure:"log_level"`
 
 	// Output format: 'plain' (colored text) or 'json'
 	LogFormat string `mapstructure:"log_format"`
 
+	// Sampling of the debug and info log events: of the log events with the
+	// same level and message, every second, the first LogSamplingFirst ones are
+	// logged, then every LogSamplingThereafter-th one. 0 disables sampling.
+	LogSamplingFirst      int `mapstructure:"log_sampling_first"`
+	LogSamplingThereafter int `mapstructure:"log_sampling_thereafter"`
+
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis_file"`
 
@@ -218,37 +321,116 @@ type BaseConfig struct { //nolint: maligned
 	// Path to the JSON file containing the last sign state of a validator
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
+	// Path to the file containing the passphrase of the private key, when it
+	// is encrypted. If not set, the passphrase is read from the
+	// CMT_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable, or prompted for
+	PrivValidatorKeyPassphraseFile string `mapstructure:"priv_validator_key_passphrase_file"`
+
+	// Number of heights the last sign state of a validator can be behind the
+	// latest block before the node refuses to start, as it may have been
+	// restored from an old backup; 0 disables the check
+	PrivValidatorMaxWatermarkGap int64 `mapstructure:"priv_validator_max_watermark_gap"`
+
 	// TCP or UNIX socket address for CometBFT to listen on for
 	// connections from an external PrivValidator process
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// Time within which a request to the external PrivValidator process
+	// connected to priv_validator_laddr must succeed, the failed attempts
+	// being retried with an exponential backoff
+	PrivValidatorSignDeadline time.Duration `mapstructure:"priv_validator_sign_deadline"`
+
+	// What the node does when a request to the external PrivValidator process
+	// exceeds priv_validator_sign_deadline: skip | block. "skip" gives up on
+	// the request, "block" keeps retrying it
+	PrivValidatorOnDeadline string `mapstructure:"priv_validator_on_deadline"`
+
+	// Address of an external PrivValidator process serving the gRPC remote
+	// signer protocol, which CometBFT dials. Can't be set along with
+	// priv_validator_laddr
+	PrivValidatorGRPCAddr string `mapstructure:"priv_validator_grpc_addr"`
+
+	// Certificate, matching private key, and CA used for mutual TLS with the
+	// gRPC remote signer. All or none must be set; without them, the connection
+	// is insecure, e.g. when secured by a service mesh
+	PrivValidatorGRPCTLSCertFile string `mapstructure:"priv_validator_grpc_tls_cert_file"`
+	PrivValidatorGRPCTLSKeyFile  string `mapstructure:"priv_validator_grpc_tls_key_file"`
+	PrivValidatorGRPCTLSCAFile   string `mapstructure:"priv_validator_grpc_tls_ca_file"`
+
+	// Deadline of the requests to the gRPC remote signer; 0 waits indefinitely
+	PrivValidatorGRPCTimeout time.Duration `mapstructure:"priv_validator_grpc_timeout"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// Deadline of the calls to the ABCI application on the mempool, query and
+	// snapshot connections; 0 waits indefinitely
+	ABCITimeout time.Duration `mapstructure:"abci_timeout"`
+
+	// Time after which a call on the consensus connection is reported as
+	// unresponsive; 0 never reports it
+	ABCIConsensusTimeout time.Duration `mapstructure:"abci_consensus_timeout"`
+
+	// Number of times the Info and Query calls timing out are retried
+	ABCIQueryRetries int `mapstructure:"abci_query_retries"`
+
+	// Number of consecutive timeouts on a connection after which its calls are
+	// refused for abci_breaker_cooldown, before one probes the application
+	// again; 0 disables the circuit breaker
+	ABCIBreakerThreshold int           `mapstructure:"abci_breaker_threshold"`
+	ABCIBreakerCooldown  time.Duration `mapstructure:"abci_breaker_cooldown"`
+
+	// What the node does when the application is unresponsive on the consensus
+	// connection: halt | degrade. "halt" stops the node, "degrade" keeps
+	// waiting for the application while the other connections fail fast
+	ABCIOnUnresponsive string `mapstructure:"abci_on_unresponsive"`
+
+	// If set, the calls to the ABCI application and their responses are
+	// recorded to this file, to be replayed with `cometbft abci-replay`
+	ABCIRecordFile string `mapstructure:"abci_record_file"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
+
+	// Time the node has to drain on SIGTERM, before it exits: the RPC server
+	// stops accepting requests and waits for the ones in flight, the
+	// subscribers are sent a final Shutdown event, the pending CheckTx calls
+	// are flushed, then the services are stopped, the consensus finishing its
+	// step and flushing its WAL. 0 stops the node without draining
+	ShutdownDrainTimeout time.Duration `mapstructure:"shutdown_drain_timeout"`
 }
 
 // DefaultBaseConfig returns a default base configuration for a CometBFT node
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		Version:            version.TMCoreSemVer,
-		Genesis:            defaultGenesisJSONPath,
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               "socket",
-		LogLevel:           DefaultLogLevel,
-		LogFormat:          LogFormatPlain,
-		FilterPeers:        false,
-		DBBackend:          "goleveldb",
-		DBPath:             DefaultDataDir,
+		Version:                   version.TMCoreSemVer,
+		Genesis:                   defaultGenesisJSONPath,
+		PrivValidatorKey:          defaultPrivValKeyPath,
+		PrivValidatorState:        defaultPrivValStatePath,
+		NodeKey:                   defaultNodeKeyPath,
+		Moniker:                   defaultMoniker,
+		Mode:                      ModeValidator,
+		ProxyApp:                  "tcp://127.0.0.1:26658",
+		ABCI:                      "socket",
+		PrivValidatorSignDeadline: 5 * time.Second,
+		PrivValidatorOnDeadline:   PrivValidatorOnDeadlineSkip,
+		PrivValidatorGRPCTimeout:  3 * time.Second,
+		ABCITimeout:               30 * time.Second,
+		ABCIConsensusTimeout:      60 * time.Second,
+		ABCIQueryRetries:          2,
+		ABCIBreakerThreshold:      3,
+		ABCIBreakerCooldown:       10 * time.Second,
+		ABCIOnUnresponsive:        ABCIUnresponsiveDegrade,
+		LogLevel:                  DefaultLogLevel,
+		LogFormat:                 LogFormatPlain,
+		FilterPeers:               false,
+		ShutdownDrainTimeout:      10 * time.Second,
+		DBBackend:                 "goleveldb",
+		DBPath:                    DefaultDataDir,
 	}
 }
 
@@ -280,6 +462,48 @@ func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// ABCIRecordPath returns the full path to the file the ABCI calls are
+// recorded to, or "" if they are not recorded
+func (cfg BaseConfig) ABCIRecordPath() string {
+	if cfg.ABCIRecordFile == "" {
+		return ""
+	}
+	return rootify(cfg.ABCIRecordFile, cfg.RootDir)
+}
+
+// PrivValidatorKeyPassphrase returns the full path to the file containing the
+// passphrase of the encrypted priv_validator_key.json, or "" if not set.
+func (cfg BaseConfig) PrivValidatorKeyPassphrase() string {
+	if cfg.PrivValidatorKeyPassphraseFile == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorKeyPassphraseFile, cfg.RootDir)
+}
+
+// PrivValidatorGRPCTLSCert returns the full path to the certificate used with
+// the gRPC remote signer
+func (cfg BaseConfig) PrivValidatorGRPCTLSCert() string {
+	return rootify(cfg.PrivValidatorGRPCTLSCertFile, cfg.RootDir)
+}
+
+// PrivValidatorGRPCTLSKey returns the full path to the private key used with
+// the gRPC remote signer
+func (cfg BaseConfig) PrivValidatorGRPCTLSKey() string {
+	return rootify(cfg.PrivValidatorGRPCTLSKeyFile, cfg.RootDir)
+}
+
+// PrivValidatorGRPCTLSCA returns the full path to the CA used with the gRPC
+// remote signer
+func (cfg BaseConfig) PrivValidatorGRPCTLSCA() string {
+	return rootify(cfg.PrivValidatorGRPCTLSCAFile, cfg.RootDir)
+}
+
+// IsPrivValidatorGRPCTLSEnabled returns true if the connection to the gRPC
+// remote signer uses mutual TLS
+func (cfg BaseConfig) IsPrivValidatorGRPCTLSEnabled() bool {
+	return cfg.PrivValidatorGRPCTLSCertFile != ""
+}
+
 // DBDir returns the full path to the database directory
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -294,11 +518,83 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return fmt.Errorf("invalid version string: %s", cfg.Version)
 	}
 
+	switch cfg.Mode {
+	case ModeValidator:
+	case ModeFull, ModeSeed:
+		if cfg.PrivValidatorListenAddr != "" || cfg.PrivValidatorGRPCAddr != "" {
+			return fmt.Errorf("a %s node doesn't sign, priv_validator_laddr and priv_validator_grpc_addr can't be set",
+				cfg.Mode)
+		}
+	default:
+		return errors.New("unknown mode (must be 'validator', 'full' or 'seed')")
+	}
+
 	switch cfg.LogFormat {
 	case LogFormatPlain, LogFormatJSON:
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	if cfg.LogSamplingFirst < 0 {
+		return errors.New("log_sampling_first can't be negative")
+	}
+	if cfg.LogSamplingThereafter < 0 {
+		return errors.New("log_sampling_thereafter can't be negative")
+	}
+	if cfg.DBEncryptionKey != "" && !encdb.ValidKeySource(cfg.DBEncryptionKey) {
+		return errors.New("invalid db_encryption_key (must start with 'file:', 'env:' or 'cmd:')")
+	}
+	if cfg.ABCITimeout < 0 {
+		return errors.New("abci_timeout can't be negative")
+	}
+	if cfg.ABCIConsensusTimeout < 0 {
+		return errors.New("abci_consensus_timeout can't be negative")
+	}
+	if cfg.ABCIQueryRetries < 0 {
+		return errors.New("abci_query_retries can't be negative")
+	}
+	if cfg.ABCIBreakerThreshold < 0 {
+		return errors.New("abci_breaker_threshold can't be negative")
+	}
+	if cfg.ABCIBreakerCooldown < 0 {
+		return errors.New("abci_breaker_cooldown can't be negative")
+	}
+	switch cfg.ABCIOnUnresponsive {
+	case ABCIUnresponsiveHalt, ABCIUnresponsiveDegrade:
+	default:
+		return errors.New("unknown abci_on_unresponsive (must be 'halt' or 'degrade')")
+	}
+	if cfg.PrivValidatorGRPCAddr != "" && cfg.PrivValidatorListenAddr != "" {
+		return errors.New("priv_validator_grpc_addr and priv_validator_laddr can't both be set")
+	}
+	tlsFiles := 0
+	for _, f := range []string{
+		cfg.PrivValidatorGRPCTLSCertFile, cfg.PrivValidatorGRPCTLSKeyFile, cfg.PrivValidatorGRPCTLSCAFile,
+	} {
+		if f != "" {
+			tlsFiles++
+		}
+	}
+	if tlsFiles != 0 && tlsFiles != 3 {
+		return errors.New("priv_validator_grpc_tls_cert_file, priv_validator_grpc_tls_key_file and " +
+			"priv_validator_grpc_tls_ca_file must all be set, or none")
+	}
+	if cfg.PrivValidatorMaxWatermarkGap < 0 {
+		return errors.New("priv_validator_max_watermark_gap can't be negative")
+	}
+	if cfg.PrivValidatorGRPCTimeout < 0 {
+		return errors.New("priv_validator_grpc_timeout can't be negative")
+	}
+	if cfg.PrivValidatorSignDeadline <= 0 {
+		return errors.New("priv_validator_sign_deadline must be positive")
+	}
+	if cfg.ShutdownDrainTimeout < 0 {
+		return errors.New("shutdown_drain_timeout can't be negative")
+	}
+	switch cfg.PrivValidatorOnDeadline {
+	case PrivValidatorOnDeadlineSkip, PrivValidatorOnDeadlineBlock:
+	default:
+		return errors.New("unknown priv_validator_on_deadline (must be 'skip' or 'block')")
+	}
 	return nil
 }
 
@@ -324,10 +620,19 @@ type RPCConfig struct {
 	// A list of non simple headers the client is allowed to use with cross-domain requests.
 	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
 
+	// How long the results of a preflight request can be cached by the
+	// client. 0 - the Access-Control-Max-Age header is not sent.
+	CORSMaxAge time.Duration `mapstructure:"cors_max_age"`
+
 	// TCP or UNIX socket address for the gRPC server to listen on
-	// NOTE: This server only supports /broadcast_tx_commit
 	GRPCListenAddress string `mapstructure:"grpc_laddr"`
 
+	// Certificate and matching private key used to serve gRPC over TLS.
+	// Might be either absolute paths or paths related to CometBFT's config
+	// directory. Both must be set for TLS to be enabled.
+	GRPCTLSCertFile string `mapstructure:"grpc_tls_cert_file"`
+	GRPCTLSKeyFile  string `mapstructure:"grpc_tls_key_file"`
+
 	// Maximum number of simultaneous connections.
 	// Does not include RPC (HTTP&WebSocket) connections. See max_open_connections
 	// If you want to accept a larger number than the default, make sure
@@ -347,12 +652,18 @@ type RPCConfig struct {
 	// 1024 - 40 - 10 - 50 = 924 = ~900
 	MaxOpenConnections int `mapstructure:"max_open_connections"`
 
+	// Maximum number of simultaneous WebSocket connections from a single IP
+	// address.
+	// 0 - unlimited.
+	MaxWebSocketConnectionsPerIP int `mapstructure:"max_websocket_connections_per_ip"`
+
 	// Maximum number of unique clientIDs that can /subscribe
 	// If you're using /broadcast_tx_commit, set to the estimated maximum number
 	// of broadcast_tx_commit calls per block.
 	MaxSubscriptionClients int `mapstructure:"max_subscription_clients"`
 
-	// Maximum number of unique queries a given client can /subscribe to
+	// Maximum number of unique queries a given client can /subscribe to. Each
+	// WebSocket connection is a distinct client.
 	// If you're using GRPC (or Local RPC client) and /broadcast_tx_commit, set
 	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max_subscriptions_per_client"`
@@ -361,6 +672,12 @@ type RPCConfig struct {
 	// returning `ErrOutOfCapacity`.
 	SubscriptionBufferSize int `mapstructure:"experimental_subscription_buffer_size"`
 
+	// What to do when the buffer of a subscription is full: "close" cancels
+	// the subscription with `ErrOutOfCapacity`, "drop" drops the new events
+	// and "drop_oldest" the oldest buffered ones, keeping the subscription
+	// open.
+	SubscriptionOverflowPolicy string `mapstructure:"subscription_overflow_policy"`
+
 	// The maximum number of responses that can be buffered per WebSocket
 	// client. If clients cannot read from the WebSocket endpoint fast enough,
 	// they will be disconnected, so increasing this parameter may reduce the
@@ -392,6 +709,36 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
 
+	// Maximum number of requests in a JSON-RPC batch. 0 - unlimited.
+	MaxRequestBatchSize int `mapstructure:"max_request_batch_size"`
+
+	// Maximum number of requests of a JSON-RPC batch executed concurrently.
+	// Only the read-only methods are executed concurrently, the others are
+	// executed in the order of the requests.
+	RequestBatchConcurrency int `mapstructure:"request_batch_concurrency"`
+
+	// Compress the responses with zstd or gzip, as negotiated with the client
+	// through the Accept-Encoding header.
+	ResponseCompression bool `mapstructure:"response_compression"`
+
+	// Size, in bytes, below which responses are sent uncompressed.
+	ResponseCompressionMinSize int `mapstructure:"response_compression_min_size"`
+
+	// Maximum size, in bytes, of the in-memory LRU cache of the responses for
+	// immutable data: blocks, canonical commits and block results. The size
+	// of the responses is approximated by the size of their protobuf
+	// encoding. 0 - disabled.
+	ResponseCacheSize int64 `mapstructure:"response_cache_size"`
+
+	// Thresholds past which /health/detailed reports a subsystem as
+	// unhealthy: the age of the last block, the number of peers, the latency
+	// of a read from the block store and the fraction of the mempool capacity
+	// in use. 0 disables the check.
+	HealthMaxLastBlockAge      time.Duration `mapstructure:"health_max_last_block_age"`
+	HealthMinPeers             int           `mapstructure:"health_min_peers"`
+	HealthMaxDBLatency         time.Duration `mapstructure:"health_max_db_latency"`
+	HealthMaxMempoolSaturation float64       `mapstructure:"health_max_mempool_saturation"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to CometBFT's config directory.
 	//
@@ -410,9 +757,41 @@ type RPCConfig struct {
 	// Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls_key_file"`
 
+	// API keys accepted by the RPC server. Clients present them in the
+	// Authorization header ("Authorization: Bearer <key>").
+	//
+	// NOTE: if neither auth_api_keys nor auth_jwt_secret_file is set,
+	// authentication is disabled.
+	AuthAPIKeys []string `mapstructure:"auth_api_keys"`
+
+	// The path to a file containing the secret used to verify the JWTs (signed
+	// with HS256) presented by clients in the Authorization header. The subject
+	// of the token identifies the client.
+	// Might be either absolute path or path related to CometBFT's config directory.
+	AuthJWTSecretFile string `mapstructure:"auth_jwt_secret_file"`
+
+	// Maximum rate, in calls per second, at which each client can call each
+	// RPC method, and the size of the burst allowed above it. Clients are
+	// identified by their credentials if authentication is enabled, and by
+	// their IP address otherwise. 0 - unlimited.
+	RateLimit      float64 `mapstructure:"rate_limit"`
+	RateLimitBurst int     `mapstructure:"rate_limit_burst"`
+
+	// Same as rate_limit and rate_limit_burst, for the methods listed in
+	// expensive_methods. 0 - unlimited.
+	ExpensiveRateLimit      float64 `mapstructure:"expensive_rate_limit"`
+	ExpensiveRateLimitBurst int     `mapstructure:"expensive_rate_limit_burst"`
+
+	// RPC methods subject to expensive_rate_limit instead of rate_limit.
+	ExpensiveMethods []string `mapstructure:"expensive_methods"`
+
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	// FIXME: This should be moved under the instrumentation section
 	PprofListenAddress string `mapstructure:"pprof_laddr"`
+
+	// When true, the pprof endpoints require the credentials of the RPC
+	// server (auth_api_keys or auth_jwt_secret_file).
+	PprofAuth bool `mapstructure:"pprof_auth"`
 }
 
 // DefaultRPCConfig returns a default configuration for the RPC server
@@ -422,23 +801,47 @@ func DefaultRPCConfig() *RPCConfig {
 		CORSAllowedOrigins:     []string{},
 		CORSAllowedMethods:     []string{http.MethodHead, http.MethodGet, http.MethodPost},
 		CORSAllowedHeaders:     []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time"},
+		CORSMaxAge:             0,
 		GRPCListenAddress:      "",
 		GRPCMaxOpenConnections: 900,
 
-		Unsafe:             false,
-		MaxOpenConnections: 900,
+		Unsafe:                       false,
+		MaxOpenConnections:           900,
+		MaxWebSocketConnectionsPerIP: 0,
 
-		MaxSubscriptionClients:    100,
-		MaxSubscriptionsPerClient: 5,
-		SubscriptionBufferSize:    defaultSubscriptionBufferSize,
-		TimeoutBroadcastTxCommit:  10 * time.Second,
-		WebSocketWriteBufferSize:  defaultSubscriptionBufferSize,
+		MaxSubscriptionClients:     100,
+		MaxSubscriptionsPerClient:  5,
+		SubscriptionBufferSize:     defaultSubscriptionBufferSize,
+		SubscriptionOverflowPolicy: SubscriptionOverflowPolicyClose,
+		TimeoutBroadcastTxCommit:   10 * time.Second,
+		WebSocketWriteBufferSize:   defaultSubscriptionBufferSize,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		MaxRequestBatchSize:     0,
+		RequestBatchConcurrency: 4,
+
+		ResponseCompression:        false,
+		ResponseCompressionMinSize: 1024,
+		ResponseCacheSize:          0,
+
+		HealthMaxLastBlockAge:      time.Minute,
+		HealthMinPeers:             1,
+		HealthMaxDBLatency:         100 * time.Millisecond,
+		HealthMaxMempoolSaturation: 0.9,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
+
+		AuthAPIKeys:       []string{},
+		AuthJWTSecretFile: "",
+
+		RateLimit:               0,
+		RateLimitBurst:          0,
+		ExpensiveRateLimit:      0,
+		ExpensiveRateLimitBurst: 0,
+		ExpensiveMethods:        []string{"tx_search", "block_search"},
 	}
 }
 
@@ -460,6 +863,9 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max_open_connections can't be negative")
 	}
+	if cfg.MaxWebSocketConnectionsPerIP < 0 {
+		return errors.New("max_websocket_connections_per_ip can't be negative")
+	}
 	if cfg.MaxSubscriptionClients < 0 {
 		return errors.New("max_subscription_clients can't be negative")
 	}
@@ -472,6 +878,13 @@ func (cfg *RPCConfig) ValidateBasic() error {
 			minSubscriptionBufferSize,
 		)
 	}
+	switch cfg.SubscriptionOverflowPolicy {
+	case SubscriptionOverflowPolicyClose, SubscriptionOverflowPolicyDrop, SubscriptionOverflowPolicyDropOldest:
+	default:
+		return fmt.Errorf("unknown subscription_overflow_policy %q, must be %q, %q or %q",
+			cfg.SubscriptionOverflowPolicy, SubscriptionOverflowPolicyClose, SubscriptionOverflowPolicyDrop,
+			SubscriptionOverflowPolicyDropOldest)
+	}
 	if cfg.WebSocketWriteBufferSize < cfg.SubscriptionBufferSize {
 		return fmt.Errorf(
 			"experimental_websocket_write_buffer_size must be >= experimental_subscription_buffer_size (%d)",
@@ -487,6 +900,53 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.MaxRequestBatchSize < 0 {
+		return errors.New("max_request_batch_size can't be negative")
+	}
+	if cfg.RequestBatchConcurrency <= 0 {
+		return errors.New("request_batch_concurrency must be positive")
+	}
+	if cfg.CORSMaxAge < 0 {
+		return errors.New("cors_max_age can't be negative")
+	}
+	if cfg.ResponseCompressionMinSize < 0 {
+		return errors.New("response_compression_min_size can't be negative")
+	}
+	if cfg.ResponseCacheSize < 0 {
+		return errors.New("response_cache_size can't be negative")
+	}
+	if cfg.HealthMaxLastBlockAge < 0 {
+		return errors.New("health_max_last_block_age can't be negative")
+	}
+	if cfg.HealthMinPeers < 0 {
+		return errors.New("health_min_peers can't be negative")
+	}
+	if cfg.HealthMaxDBLatency < 0 {
+		return errors.New("health_max_db_latency can't be negative")
+	}
+	if cfg.HealthMaxMempoolSaturation < 0 || cfg.HealthMaxMempoolSaturation > 1 {
+		return errors.New("health_max_mempool_saturation must be between 0 and 1")
+	}
+	if cfg.PprofAuth && !cfg.IsAuthEnabled() {
+		return errors.New("pprof_auth requires auth_api_keys or auth_jwt_secret_file")
+	}
+	for _, key := range cfg.AuthAPIKeys {
+		if key == "" {
+			return errors.New("auth_api_keys can't contain empty keys")
+		}
+	}
+	if cfg.RateLimit < 0 {
+		return errors.New("rate_limit can't be negative")
+	}
+	if cfg.RateLimitBurst < 0 {
+		return errors.New("rate_limit_burst can't be negative")
+	}
+	if cfg.ExpensiveRateLimit < 0 {
+		return errors.New("expensive_rate_limit can't be negative")
+	}
+	if cfg.ExpensiveRateLimitBurst < 0 {
+		return errors.New("expensive_rate_limit_burst can't be negative")
+	}
 	return nil
 }
 
@@ -519,6 +979,45 @@ func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
 
+func (cfg RPCConfig) JWTSecretFile() string {
+	path := cfg.AuthJWTSecretFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
+// IsAuthEnabled returns true if clients must authenticate with an API key or
+// a JWT.
+func (cfg RPCConfig) IsAuthEnabled() bool {
+	return len(cfg.AuthAPIKeys) != 0 || cfg.AuthJWTSecretFile != ""
+}
+
+// IsRateLimitEnabled returns true if any rate limit is set.
+func (cfg RPCConfig) IsRateLimitEnabled() bool {
+	return cfg.RateLimit > 0 || cfg.ExpensiveRateLimit > 0
+}
+
+func (cfg RPCConfig) GRPCKeyFile() string {
+	path := cfg.GRPCTLSKeyFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
+func (cfg RPCConfig) GRPCCertFile() string {
+	path := cfg.GRPCTLSCertFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
+func (cfg RPCConfig) IsGRPCTLSEnabled() bool {
+	return cfg.GRPCTLSCertFile != "" && cfg.GRPCTLSKeyFile != ""
+}
+
 //-----------------------------------------------------------------------------
 // P2PConfig
 
@@ -536,6 +1035,11 @@ type P2PConfig struct { //nolint: maligned
 	// We only use these if we can’t connect to peers in the addrbook
 	Seeds string `mapstructure:"seeds"`
 
+	// Comma separated list of DNS seeds. The TXT records of each host name
+	// hold comma separated lists of seed nodes (id@host:port), which are used
+	// in addition to Seeds.
+	DNSSeeds string `mapstructure:"dns_seeds"`
+
 	// Comma separated list of peers to be added to the peer store
 	// on startup. Either BootstrapPeers or PersistentPeers are
 	// needed for peer discovery
@@ -544,7 +1048,8 @@ type P2PConfig struct { //nolint: maligned
 	// Comma separated list of nodes to keep persistent connections to
 	PersistentPeers string `mapstructure:"persistent_peers"`
 
-	// UPNP port forwarding
+	// UPNP port forwarding. If ExternalAddress is empty, the p2p port is mapped
+	// on the gateway (via UPnP or NAT-PMP) and the external address advertised.
 	UPNP bool `mapstructure:"upnp"`
 
 	// Path to address book
@@ -566,6 +1071,22 @@ type P2PConfig struct { //nolint: maligned
 	// Maximum pause when redialing a persistent peer (if zero, exponential backoff is used)
 	PersistentPeersMaxDialPeriod time.Duration `mapstructure:"persistent_peers_max_dial_period"`
 
+	// Maximum number of attempts to reconnect to a persistent peer, before
+	// leaving it to the PEX reactor (0 - keep trying forever)
+	PersistentPeersReconnectMaxAttempts int `mapstructure:"persistent_peers_reconnect_max_attempts"`
+
+	// How the pause between the attempts to reconnect to a persistent peer
+	// grows: "hybrid", "constant" or "exponential"
+	PersistentPeersReconnectBackoff string `mapstructure:"persistent_peers_reconnect_backoff"`
+
+	// Base pause between the attempts to reconnect to a persistent peer
+	PersistentPeersReconnectInterval time.Duration `mapstructure:"persistent_peers_reconnect_interval"`
+
+	// Comma separated list of reconnect policies overriding the above for some
+	// peers, as <ID>:<field>=<value>;..., e.g.
+	// "<ID>:max_attempts=0;backoff=constant;interval=1s;unconditional=true"
+	PersistentPeersReconnectPolicies string `mapstructure:"persistent_peers_reconnect_policies"`
+
 	// Time to wait before flushing messages out on the connection
 	FlushThrottleTimeout time.Duration `mapstructure:"flush_throttle_timeout"`
 
@@ -584,7 +1105,8 @@ type P2PConfig struct { //nolint: maligned
 	// Seed mode, in which node constantly crawls the network and looks for
 	// peers. If another node asks it for addresses, it responds and disconnects.
 	//
-	// Does not work if the peer-exchange reactor is disabled.
+	// Deprecated: set Mode to ModeSeed instead. It can only be set along with
+	// it.
 	SeedMode bool `mapstructure:"seed_mode"`
 
 	// Comma separated list of peer IDs to keep private (will not be gossiped to
@@ -594,6 +1116,25 @@ type P2PConfig struct { //nolint: maligned
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
+	// Require mutually-authenticated TLS, on top of the secret connection, for
+	// connections with the peers listed in PrivatePeerIDs. Their certificates
+	// must be signed by PrivatePeerTLSCAFile and carry the peer's node ID as a
+	// DNS subject alternative name.
+	PrivatePeerTLS bool `mapstructure:"private_peer_tls"`
+
+	// Certificate and matching private key used by this node for mutual TLS
+	// with private peers. Paths are either absolute or relative to CometBFT's
+	// config directory.
+	PrivatePeerTLSCertFile string `mapstructure:"private_peer_tls_cert_file"`
+	PrivatePeerTLSKeyFile  string `mapstructure:"private_peer_tls_key_file"`
+
+	// CA certificate(s) used to verify private peers' certificates.
+	PrivatePeerTLSCAFile string `mapstructure:"private_peer_tls_ca_file"`
+
+	// Comma separated list of reactors whose messages are compressed when the
+	// peer supports it. Possible values: "blocksync", "statesync", "mempool".
+	CompressedReactors string `mapstructure:"compressed_reactors"`
+
 	// Peer connection configuration.
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
@@ -609,26 +1150,29 @@ type P2PConfig struct { //nolint: maligned
 // DefaultP2PConfig returns a default configuration for the peer-to-peer layer
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
-		ListenAddress:                "tcp://0.0.0.0:26656",
-		ExternalAddress:              "",
-		UPNP:                         false,
-		AddrBook:                     defaultAddrBookPath,
-		AddrBookStrict:               true,
-		MaxNumInboundPeers:           40,
-		MaxNumOutboundPeers:          10,
-		PersistentPeersMaxDialPeriod: 0 * time.Second,
-		FlushThrottleTimeout:         100 * time.Millisecond,
-		MaxPacketMsgPayloadSize:      1024,    // 1 kB
-		SendRate:                     5120000, // 5 mB/s
-		RecvRate:                     5120000, // 5 mB/s
-		PexReactor:                   true,
-		SeedMode:                     false,
-		AllowDuplicateIP:             false,
-		HandshakeTimeout:             20 * time.Second,
-		DialTimeout:                  3 * time.Second,
-		TestDialFail:                 false,
-		TestFuzz:                     false,
-		TestFuzzConfig:               DefaultFuzzConnConfig(),
+		ListenAddress:                       "tcp://0.0.0.0:26656",
+		ExternalAddress:                     "",
+		UPNP:                                false,
+		AddrBook:                            defaultAddrBookPath,
+		AddrBookStrict:                      true,
+		MaxNumInboundPeers:                  40,
+		MaxNumOutboundPeers:                 10,
+		PersistentPeersMaxDialPeriod:        0 * time.Second,
+		PersistentPeersReconnectMaxAttempts: 30,
+		PersistentPeersReconnectBackoff:     ReconnectBackoffHybrid,
+		PersistentPeersReconnectInterval:    5 * time.Second,
+		FlushThrottleTimeout:                100 * time.Millisecond,
+		MaxPacketMsgPayloadSize:             1024,    // 1 kB
+		SendRate:                            5120000, // 5 mB/s
+		RecvRate:                            5120000, // 5 mB/s
+		PexReactor:                          true,
+		SeedMode:                            false,
+		AllowDuplicateIP:                    false,
+		HandshakeTimeout:                    20 * time.Second,
+		DialTimeout:                         3 * time.Second,
+		TestDialFail:                        false,
+		TestFuzz:                            false,
+		TestFuzzConfig:                      DefaultFuzzConnConfig(),
 	}
 }
 
@@ -646,6 +1190,28 @@ func (cfg *P2PConfig) AddrBookFile() string {
 	return rootify(cfg.AddrBook, cfg.RootDir)
 }
 
+// PrivatePeerTLSCert returns the full path to the private peer TLS certificate.
+func (cfg *P2PConfig) PrivatePeerTLSCert() string {
+	return cfg.configFile(cfg.PrivatePeerTLSCertFile)
+}
+
+// PrivatePeerTLSKey returns the full path to the private peer TLS key.
+func (cfg *P2PConfig) PrivatePeerTLSKey() string {
+	return cfg.configFile(cfg.PrivatePeerTLSKeyFile)
+}
+
+// PrivatePeerTLSCA returns the full path to the private peer TLS CA file.
+func (cfg *P2PConfig) PrivatePeerTLSCA() string {
+	return cfg.configFile(cfg.PrivatePeerTLSCAFile)
+}
+
+func (cfg *P2PConfig) configFile(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
@@ -661,6 +1227,17 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.PersistentPeersMaxDialPeriod < 0 {
 		return errors.New("persistent_peers_max_dial_period can't be negative")
 	}
+	if cfg.PersistentPeersReconnectMaxAttempts < 0 {
+		return errors.New("persistent_peers_reconnect_max_attempts can't be negative")
+	}
+	switch cfg.PersistentPeersReconnectBackoff {
+	case ReconnectBackoffHybrid, ReconnectBackoffConstant, ReconnectBackoffExponential:
+	default:
+		return fmt.Errorf("unknown persistent_peers_reconnect_backoff %q", cfg.PersistentPeersReconnectBackoff)
+	}
+	if cfg.PersistentPeersReconnectInterval < 0 {
+		return errors.New("persistent_peers_reconnect_interval can't be negative")
+	}
 	if cfg.MaxPacketMsgPayloadSize < 0 {
 		return errors.New("max_packet_msg_payload_size can't be negative")
 	}
@@ -670,9 +1247,36 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv_rate can't be negative")
 	}
+	if cfg.PrivatePeerTLS {
+		if cfg.PrivatePeerTLSCertFile == "" || cfg.PrivatePeerTLSKeyFile == "" || cfg.PrivatePeerTLSCAFile == "" {
+			return errors.New("private_peer_tls requires private_peer_tls_cert_file, " +
+				"private_peer_tls_key_file and private_peer_tls_ca_file to be set")
+		}
+		if cfg.PrivatePeerIDs == "" {
+			return errors.New("private_peer_tls requires private_peer_ids to be set")
+		}
+	}
+	for _, r := range cfg.CompressedReactorList() {
+		switch r {
+		case "blocksync", "statesync", "mempool":
+		default:
+			return fmt.Errorf("unknown reactor %q in compressed_reactors", r)
+		}
+	}
 	return nil
 }
 
+// CompressedReactorList returns the reactors listed in CompressedReactors.
+func (cfg *P2PConfig) CompressedReactorList() []string {
+	var reactors []string
+	for _, r := range strings.Split(cfg.CompressedReactors, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			reactors = append(reactors, r)
+		}
+	}
+	return reactors
+}
+
 // FuzzConnConfig is a FuzzedConnection configuration.
 type FuzzConnConfig struct {
 	Mode         int
@@ -743,6 +1347,22 @@ type MempoolConfig struct {
 	// Including space needed by encoding (one varint per transaction).
 	// XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 	MaxBatchBytes int `mapstructure:"max_batch_bytes"`
+	// Maximum rate, in txs per second, at which each peer can send txs, in
+	// bursts of up to PeerTxBurst. The txs past the limit are dropped,
+	// unchecked. 0 disables the limit.
+	PeerTxRate  float64 `mapstructure:"peer_tx_rate"`
+	PeerTxBurst int     `mapstructure:"peer_tx_burst"`
+	// Same as PeerTxRate and PeerTxBurst, for all the peers together.
+	PeersTxRate  float64 `mapstructure:"peers_tx_rate"`
+	PeersTxBurst int     `mapstructure:"peers_tx_burst"`
+	// AdaptiveGossip (default: false) delays the broadcast of the txs to the
+	// peers which usually already have them, i.e. which mostly send us txs we
+	// already have, by up to AdaptiveGossipMaxDelay, in proportion to how often
+	// they do. The txs such a peer sends us in the meantime aren't sent to it,
+	// which cuts the duplicate traffic on dense topologies, while the peers
+	// which need the txs still get them right away.
+	AdaptiveGossip         bool          `mapstructure:"adaptive_gossip"`
+	AdaptiveGossipMaxDelay time.Duration `mapstructure:"adaptive_gossip_max_delay"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the CometBFT mempool
@@ -757,6 +1377,8 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxsBytes: 1024 * 1024 * 1024, // 1GB
 		CacheSize:   10000,
 		MaxTxBytes:  1024 * 1024, // 1MB
+
+		AdaptiveGossipMaxDelay: 200 * time.Millisecond,
 	}
 }
 
@@ -792,6 +1414,21 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max_tx_bytes can't be negative")
 	}
+	if cfg.PeerTxRate < 0 {
+		return errors.New("peer_tx_rate can't be negative")
+	}
+	if cfg.PeerTxBurst < 0 {
+		return errors.New("peer_tx_burst can't be negative")
+	}
+	if cfg.PeersTxRate < 0 {
+		return errors.New("peers_tx_rate can't be negative")
+	}
+	if cfg.PeersTxBurst < 0 {
+		return errors.New("peers_tx_burst can't be negative")
+	}
+	if cfg.AdaptiveGossipMaxDelay < 0 {
+		return errors.New("adaptive_gossip_max_delay can't be negative")
+	}
 	return nil
 }
 
@@ -860,12 +1497,17 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 			return errors.New("trusted_period is required")
 		}
 
-		if cfg.TrustHeight <= 0 {
-			return errors.New("trusted_height is required")
+		// both are left empty to trust the latest block which all the RPC servers agree on
+		if cfg.TrustHeight < 0 {
+			return errors.New("trusted_height can't be negative")
 		}
 
-		if len(cfg.TrustHash) == 0 {
-			return errors.New("trusted_hash is required")
+		if cfg.TrustHeight == 0 && len(cfg.TrustHash) > 0 {
+			return errors.New("trusted_height is required with trusted_hash")
+		}
+
+		if cfg.TrustHeight > 0 && len(cfg.TrustHash) == 0 {
+			return errors.New("trusted_hash is required with trusted_height")
 		}
 
 		_, err := hex.DecodeString(cfg.TrustHash)
@@ -957,6 +1599,16 @@ type ConsensusConfig struct {
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer_query_maj23_sleep_duration"`
 
 	DoubleSignCheckHeight int64 `mapstructure:"double_sign_check_height"`
+
+	// Watch the votes gossiped by the peers, and the blocks of the witnesses,
+	// for blocks conflicting with the ones committed
+	ForkMonitor bool `mapstructure:"fork_monitor"`
+	// Comma separated list of the RPC servers of the witnesses
+	ForkMonitorWitnesses string `mapstructure:"fork_monitor_witnesses"`
+	// Interval at which the last block is checked against the witnesses
+	ForkMonitorInterval time.Duration `mapstructure:"fork_monitor_interval"`
+	// Stop the node once a fork is found
+	ForkMonitorHalt bool `mapstructure:"fork_monitor_halt"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
@@ -976,6 +1628,9 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
+		ForkMonitor:                 false,
+		ForkMonitorInterval:         10 * time.Second,
+		ForkMonitorHalt:             false,
 	}
 }
 
@@ -1078,26 +1733,86 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double_sign_check_height can't be negative")
 	}
+	if cfg.ForkMonitorInterval < 0 {
+		return errors.New("fork_monitor_interval can't be negative")
+	}
+	if cfg.ForkMonitor && cfg.ForkMonitorWitnesses != "" && cfg.ForkMonitorInterval == 0 {
+		return errors.New("fork_monitor_interval must be positive with fork_monitor_witnesses")
+	}
 	return nil
 }
 
+// ForkMonitorWitnessList returns the RPC servers of the witnesses of the fork
+// monitor.
+func (cfg *ConsensusConfig) ForkMonitorWitnessList() []string {
+	var witnesses []string
+	for _, w := range strings.Split(cfg.ForkMonitorWitnesses, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			witnesses = append(witnesses, w)
+		}
+	}
+	return witnesses
+}
+
 //-----------------------------------------------------------------------------
 // StorageConfig
 
 // StorageConfig allows more fine-grained control over certain storage-related
 // behavior.
 type StorageConfig struct {
-	// Set to false to ensure ABCI responses are persisted. ABCI responses are
-	// required for `/block_results` RPC queries, and to reindex events in the
-	// command-line tool.
+	// Number of the latest heights of which the ABCI responses are retained.
+	// ABCI responses are required for `/block_results` RPC queries, and to
+	// reindex events in the command-line tool. The responses falling out of
+	// this window are deleted, including, in the background on startup, the
+	// ones saved before the window was set or reduced. 0 retains them all.
+	RetainABCIResponses int64 `mapstructure:"retain_abci_responses"`
+
+	// Discard the ABCI responses, except the latest ones, required to recover
+	// from a crash.
+	//
+	// Deprecated: set RetainABCIResponses to 1 instead. It can only be set
+	// along with it.
 	DiscardABCIResponses bool `mapstructure:"discard_abci_responses"`
+
+	// Interval at which the node prunes the blocks, commits and ABCI responses
+	// in the background. 0 disables it, in which case the blocks are pruned
+	// on commit up to the retain height returned by the application.
+	PruningInterval time.Duration `mapstructure:"pruning_interval"`
+
+	// Number of the latest blocks retained by the background pruning. 0 means
+	// no retention by number of blocks.
+	RetainBlocks int64 `mapstructure:"retain_blocks"`
+
+	// Age of the oldest block retained by the background pruning. 0 means no
+	// retention by age.
+	RetainDuration time.Duration `mapstructure:"retain_duration"`
+
+	// Don't prune the blocks above the oldest snapshot of the application, so
+	// that other nodes can state sync from it.
+	PruningRetainSnapshots bool `mapstructure:"pruning_retain_snapshots"`
+
+	// Interval at which the node compacts its databases, reclaiming the disk
+	// space of the pruned data. 0 disables it.
+	CompactionInterval time.Duration `mapstructure:"compaction_interval"`
+
+	// Interval at which the node checks the invariants between its stores
+	// (block store, state, consensus WAL and indexer), reporting the
+	// violations in the logs, the metrics and the /invariants RPC endpoint.
+	// 0 disables it.
+	InvariantCheckInterval time.Duration `mapstructure:"invariant_check_interval"`
 }
 
 // DefaultStorageConfig returns the default configuration options relating to
 // CometBFT storage optimization.
 func DefaultStorageConfig() *StorageConfig {
 	return &StorageConfig{
-		DiscardABCIResponses: false,
+		RetainABCIResponses:    0,
+		PruningInterval:        0,
+		RetainBlocks:           0,
+		RetainDuration:         0,
+		PruningRetainSnapshots: true,
+		CompactionInterval:     0,
+		InvariantCheckInterval: 0,
 	}
 }
 
@@ -1105,10 +1820,42 @@ func DefaultStorageConfig() *StorageConfig {
 // testing.
 func TestStorageConfig() *StorageConfig {
 	return &StorageConfig{
-		DiscardABCIResponses: false,
+		RetainABCIResponses:    0,
+		PruningInterval:        0,
+		RetainBlocks:           0,
+		RetainDuration:         0,
+		PruningRetainSnapshots: true,
+		CompactionInterval:     0,
+		InvariantCheckInterval: 0,
 	}
 }
 
+// ValidateBasic performs basic validation.
+func (cfg *StorageConfig) ValidateBasic() error {
+	if cfg.RetainABCIResponses < 0 {
+		return errors.New("retain_abci_responses can't be negative")
+	}
+	if cfg.DiscardABCIResponses && cfg.RetainABCIResponses != 1 {
+		return errors.New("discard_abci_responses is deprecated, set retain_abci_responses = 1 instead")
+	}
+	if cfg.PruningInterval < 0 {
+		return errors.New("pruning_interval can't be negative")
+	}
+	if cfg.RetainBlocks < 0 {
+		return errors.New("retain_blocks can't be negative")
+	}
+	if cfg.RetainDuration < 0 {
+		return errors.New("retain_duration can't be negative")
+	}
+	if cfg.CompactionInterval < 0 {
+		return errors.New("compaction_interval can't be negative")
+	}
+	if cfg.InvariantCheckInterval < 0 {
+		return errors.New("invariant_check_interval can't be negative")
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // TxIndexConfig
 // Remember that Event has the following structure:
@@ -1130,20 +1877,48 @@ type TxIndexConfig struct {
 	//   2) "kv" (default) - the simplest possible indexer,
 	//      backed by key-value storage (defaults to levelDB; see DBBackend).
 	//   3) "psql" - the indexer services backed by PostgreSQL.
+	//   4) "kafka" - the events are published to Kafka, through a Kafka REST proxy.
+	//      Requires Backfill.
 	Indexer string `mapstructure:"indexer"`
 
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// The URL of the Kafka REST proxy, e.g. https://<user>:<password>@<host>:8082,
+	// for the "kafka" indexer.
+	StreamURL string `mapstructure:"stream-url"`
+
+	// The Kafka topics the block and tx events are published to.
+	StreamBlockTopic string `mapstructure:"stream-block-topic"`
+	StreamTxTopic    string `mapstructure:"stream-tx-topic"`
+
+	// When true, the heights missing from the index, e.g. after the node was
+	// restarted with indexing temporarily disabled, are indexed in the
+	// background from the block store. The ABCI responses of the heights must
+	// be retained (see storage.retain_abci_responses).
+	Backfill bool `mapstructure:"backfill"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
 func DefaultTxIndexConfig() *TxIndexConfig {
 	return &TxIndexConfig{
-		Indexer: "kv",
+		Indexer:          "kv",
+		StreamBlockTopic: "cometbft.blocks",
+		StreamTxTopic:    "cometbft.txs",
+		Backfill:         true,
 	}
 }
 
+// ValidateBasic performs basic validation.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	// the heights which fail to be published are left to the backfill
+	if cfg.Indexer == "kafka" && !cfg.Backfill {
+		return errors.New("the kafka indexer requires backfill")
+	}
+	return nil
+}
+
 // TestTxIndexConfig returns a default configuration for the transaction indexer.
 func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
@@ -1170,16 +1945,61 @@ type InstrumentationConfig struct {
 
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
+
+	// Base URL of an OTLP/HTTP receiver, e.g. an OpenTelemetry collector at
+	// "http://localhost:4318", to which the traces (RPC calls, ABCI calls, p2p
+	// sends, consensus steps) and metrics of the node are exported. Empty
+	// disables the export.
+	OTLPEndpoint string `mapstructure:"otlp_endpoint"`
+
+	// Headers of the export requests, as "key=value", e.g. to authenticate
+	// with the receiver.
+	OTLPHeaders []string `mapstructure:"otlp_headers"`
+
+	// How often the traces and metrics are exported.
+	OTLPExportInterval time.Duration `mapstructure:"otlp_export_interval"`
+
+	// Number of recent blocks over which the blocks missed by each validator
+	// of the active set are counted. 0 disables the per-validator metrics.
+	ValidatorLivenessWindow int `mapstructure:"validator_liveness_window"`
+
+	// How often the CPU and heap profiles of the node are captured, so that
+	// performance regressions can be diagnosed after the fact. 0 disables the
+	// capture.
+	ProfileInterval time.Duration `mapstructure:"profile_interval"`
+
+	// How long the CPU is profiled for, at each capture.
+	ProfileCPUDuration time.Duration `mapstructure:"profile_cpu_duration"`
+
+	// Directory where the profiles are kept, relative to the home directory
+	// unless absolute. Empty disables keeping them locally.
+	ProfileDir string `mapstructure:"profile_dir"`
+
+	// Number of profiles of each type kept in profile_dir, the older ones
+	// being removed.
+	ProfileRetain int `mapstructure:"profile_retain"`
+
+	// Base URL of a Pyroscope-compatible server to which the profiles are
+	// pushed, e.g. "http://localhost:4040". Empty disables the push.
+	ProfilePushURL string `mapstructure:"profile_push_url"`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
 // reporting.
 func DefaultInstrumentationConfig() *InstrumentationConfig {
 	return &InstrumentationConfig{
-		Prometheus:           false,
-		PrometheusListenAddr: ":26660",
-		MaxOpenConnections:   3,
-		Namespace:            "cometbft",
+		Prometheus:              false,
+		PrometheusListenAddr:    ":26660",
+		MaxOpenConnections:      3,
+		Namespace:               "cometbft",
+		OTLPHeaders:             []string{},
+		OTLPExportInterval:      10 * time.Second,
+		ValidatorLivenessWindow: 100,
+		ProfileInterval:         0,
+		ProfileCPUDuration:      10 * time.Second,
+		ProfileDir:              "data/profiles",
+		ProfileRetain:           24,
+		ProfilePushURL:          "",
 	}
 }
 
@@ -1195,6 +2015,28 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max_open_connections can't be negative")
 	}
+	if cfg.OTLPExportInterval <= 0 {
+		return errors.New("otlp_export_interval must be positive")
+	}
+	if cfg.ValidatorLivenessWindow < 0 {
+		return errors.New("validator_liveness_window can't be negative")
+	}
+	if cfg.ProfileInterval < 0 {
+		return errors.New("profile_interval can't be negative")
+	}
+	if cfg.IsProfilingEnabled() {
+		if cfg.ProfileCPUDuration <= 0 || cfg.ProfileCPUDuration >= cfg.ProfileInterval {
+			return errors.New("profile_cpu_duration must be positive and less than profile_interval")
+		}
+		if cfg.ProfileDir != "" && cfg.ProfileRetain <= 0 {
+			return errors.New("profile_retain must be positive")
+		}
+	}
+	for _, header := range cfg.OTLPHeaders {
+		if k, _, ok := strings.Cut(header, "="); !ok || k == "" {
+			return fmt.Errorf("otlp_headers: %q is not key=value", header)
+		}
+	}
 	return nil
 }
 
@@ -1202,6 +2044,27 @@ func (cfg *InstrumentationConfig) IsPrometheusEnabled() bool {
 	return cfg.Prometheus && cfg.PrometheusListenAddr != ""
 }
 
+// IsOTLPEnabled returns whether the traces and metrics are exported over OTLP.
+func (cfg *InstrumentationConfig) IsOTLPEnabled() bool {
+	return cfg.OTLPEndpoint != ""
+}
+
+// IsProfilingEnabled returns whether the profiles of the node are captured
+// periodically.
+func (cfg *InstrumentationConfig) IsProfilingEnabled() bool {
+	return cfg.ProfileInterval > 0 && (cfg.ProfileDir != "" || cfg.ProfilePushURL != "")
+}
+
+// OTLPHeadersMap returns the headers of the OTLP export requests.
+func (cfg *InstrumentationConfig) OTLPHeadersMap() map[string]string {
+	headers := make(map[string]string, len(cfg.OTLPHeaders))
+	for _, header := range cfg.OTLPHeaders {
+		k, v, _ := strings.Cut(header, "=")
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return headers
+}
+
 //-----------------------------------------------------------------------------
 // Utils
 
@@ -41,6 +41,38 @@ func TestConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestConfigValidateMode(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Mode = "observer"
+	assert.Error(t, cfg.ValidateBasic())
+
+	// only a validator can have a remote signer
+	cfg = config.DefaultConfig()
+	cfg.PrivValidatorListenAddr = "tcp://0.0.0.0:26659"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Mode = config.ModeFull
+	assert.Error(t, cfg.ValidateBasic())
+
+	// a seed needs PEX, and can't state sync
+	cfg = config.DefaultConfig()
+	cfg.Mode = config.ModeSeed
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.P2P.PexReactor = false
+	assert.Error(t, cfg.ValidateBasic())
+	cfg = config.DefaultConfig()
+	cfg.Mode = config.ModeSeed
+	cfg.StateSync.Enable = true
+	assert.Error(t, cfg.ValidateBasic())
+
+	// the deprecated seed_mode only goes along with the seed mode
+	cfg = config.DefaultConfig()
+	cfg.P2P.SeedMode = true
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Mode = config.ModeSeed
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Len(t, cfg.CheckDeprecated(), 1)
+}
+
 func TestTLSConfiguration(t *testing.T) {
 	assert := assert.New(t)
 	cfg := config.DefaultConfig()
@@ -64,6 +96,41 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.DBEncryptionKey = "env:DB_KEY"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.DBEncryptionKey = "/path/to/key"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.ABCIOnUnresponsive = config.ABCIUnresponsiveHalt
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ABCIOnUnresponsive = "panic"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.ABCITimeout = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.ShutdownDrainTimeout = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ShutdownDrainTimeout = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.PrivValidatorGRPCAddr = "signer:26659"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PrivValidatorListenAddr = "tcp://0.0.0.0:26659"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.PrivValidatorGRPCTLSCertFile = "config/signer.pem"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorGRPCTLSKeyFile = "config/signer-key.pem"
+	cfg.PrivValidatorGRPCTLSCAFile = "config/ca.pem"
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -73,11 +140,17 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 	fieldsToTest := []string{
 		"GRPCMaxOpenConnections",
 		"MaxOpenConnections",
+		"MaxWebSocketConnectionsPerIP",
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"MaxRequestBatchSize",
+		"RequestBatchConcurrency",
+		"CORSMaxAge",
+		"ResponseCompressionMinSize",
+		"ResponseCacheSize",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -85,6 +158,22 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg = config.TestRPCConfig()
+	cfg.SubscriptionOverflowPolicy = "block"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SubscriptionOverflowPolicy = config.SubscriptionOverflowPolicyDrop
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.SubscriptionOverflowPolicy = config.SubscriptionOverflowPolicyDropOldest
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// the pprof endpoints can only require the credentials of the RPC server
+	// if there are any
+	cfg = config.TestRPCConfig()
+	cfg.PprofAuth = true
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.AuthAPIKeys = []string{"key"}
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -98,6 +187,8 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"PersistentPeersReconnectMaxAttempts",
+		"PersistentPeersReconnectInterval",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -105,6 +196,9 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.PersistentPeersReconnectBackoff = "linear"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -116,6 +210,7 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"AdaptiveGossipMaxDelay",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -128,6 +223,21 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := config.TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.Enable = true
+	cfg.RPCServers = []string{"127.0.0.1:26657", "127.0.0.2:26657"}
+	cfg.TrustPeriod = time.Hour
+	require.NoError(t, cfg.ValidateBasic())
+
+	// the trusted height and hash are either both set, or both discovered
+	cfg.TrustHeight = 1
+	require.Error(t, cfg.ValidateBasic())
+	cfg.TrustHash = "0A0B"
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.TrustHeight = 0
+	require.Error(t, cfg.ValidateBasic())
+	cfg.TrustHeight = -1
+	require.Error(t, cfg.ValidateBasic())
 }
 
 func TestBlockSyncConfigValidateBasic(t *testing.T) {
@@ -167,6 +277,10 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *config.ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *config.ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *config.ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"ForkMonitorInterval negative":         {func(c *config.ConsensusConfig) { c.ForkMonitorInterval = -1 }, true},
+		"ForkMonitorInterval zero with witnesses": {func(c *config.ConsensusConfig) {
+			c.ForkMonitor, c.ForkMonitorWitnesses, c.ForkMonitorInterval = true, "http://127.0.0.1:26657", 0
+		}, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
@@ -184,6 +298,43 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestStorageConfigValidateBasic(t *testing.T) {
+	cfg := config.TestStorageConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	fieldsToTest := []string{
+		"RetainABCIResponses",
+		"PruningInterval",
+		"RetainBlocks",
+		"RetainDuration",
+		"CompactionInterval",
+		"InvariantCheckInterval",
+	}
+
+	for _, fieldName := range fieldsToTest {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(-1)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
+	}
+
+	// the deprecated discard_abci_responses only goes along with a retention
+	// of 1
+	cfg.DiscardABCIResponses = true
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RetainABCIResponses = 1
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Indexer = "kafka"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Backfill = false
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg := config.TestInstrumentationConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -191,4 +342,11 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	// tamper with maximum open connections
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
+
+	// the CPU is profiled for less than the interval between the captures
+	cfg = config.TestInstrumentationConfig()
+	cfg.ProfileInterval = time.Minute
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ProfileCPUDuration = time.Minute
+	assert.Error(t, cfg.ValidateBasic())
 }
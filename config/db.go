@@ -2,9 +2,15 @@ package config
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	"github.com/cometbft/cometbft/internal/encdb"
+	"github.com/cometbft/cometbft/internal/pebbledb"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/libs/service"
 )
@@ -21,10 +27,71 @@ type DBContext struct {
 // DBProvider takes a DBContext and returns an instantiated DB.
 type DBProvider func(*DBContext) (dbm.DB, error)
 
+// PebbleDBBackend represents pebble (uses github.com/cockroachdb/pebble).
+// It is not part of cometbft-db, and requires the pebbledb build tag.
+const PebbleDBBackend dbm.BackendType = "pebbledb"
+
+// encryptedDBs are the databases encrypted with DBEncryptionKey.
+var encryptedDBs = map[string]bool{"blockstore": true, "state": true, "evidence": true}
+
 // DefaultDBProvider returns a database using the DBBackend and DBDir
-// specified in the Config.
+// specified in the Config. The blockstore, state and evidence databases are
+// encrypted with the DBEncryptionKey, if any.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 	dbType := dbm.BackendType(ctx.Config.DBBackend)
 
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	db, err := NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	if err != nil || ctx.Config.DBEncryptionKey == "" || !encryptedDBs[ctx.ID] {
+		return db, err
+	}
+	key, err := encdb.LoadKey(ctx.Config.DBEncryptionKey)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	encDB, err := encdb.New(db, key)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := encDB.CheckKey(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("opening the %s database: %w", ctx.ID, err)
+	}
+	return encDB, nil
+}
+
+// NewDB creates a new database of type backend with the given name, like
+// dbm.NewDB, but also supports PebbleDBBackend.
+func NewDB(name string, backend dbm.BackendType, dir string) (dbm.DB, error) {
+	if backend != PebbleDBBackend {
+		return dbm.NewDB(name, backend, dir)
+	}
+	db, err := pebbledb.New(name, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+	return db, nil
+}
+
+// ErrCompactionNotSupported is returned by CompactDB for the backends which
+// don't support compaction.
+var ErrCompactionNotSupported = errors.New("compaction is not supported by the database backend")
+
+// CompactDB compacts the database, reclaiming the disk space of the deleted
+// data. It can be called while the database is in use, but is I/O intensive.
+// Only goleveldb and pebbledb support it, in-memory databases are left as is.
+func CompactDB(db dbm.DB) error {
+	switch db := db.(type) {
+	case *dbm.GoLevelDB:
+		return db.DB().CompactRange(util.Range{})
+	case interface{ Compact() error }:
+		return db.Compact()
+	case *dbm.MemDB:
+		return nil
+	case interface{ Unwrap() dbm.DB }:
+		return CompactDB(db.Unwrap())
+	default:
+		return ErrCompactionNotSupported
+	}
 }
@@ -0,0 +1,73 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/encdb"
+)
+
+func TestCompactDB(t *testing.T) {
+	db, err := config.NewDB("test", dbm.GoLevelDBBackend, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, db.Set([]byte{byte(i >> 8), byte(i)}, make([]byte, 100)))
+	}
+	for i := 0; i < 900; i++ {
+		require.NoError(t, db.Delete([]byte{byte(i >> 8), byte(i)}))
+	}
+	require.NoError(t, config.CompactDB(db))
+	ok, err := db.Has([]byte{0x03, 0xe7})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	assert.NoError(t, config.CompactDB(dbm.NewMemDB()))
+	assert.ErrorIs(t, config.CompactDB(dbm.NewPrefixDB(dbm.NewMemDB(), []byte("p"))),
+		config.ErrCompactionNotSupported)
+}
+
+func TestDefaultDBProviderEncryption(t *testing.T) {
+	cfg := config.TestConfig().SetRoot(t.TempDir())
+	t.Setenv("DB_ENCRYPTION_TEST_KEY", strings.Repeat("ab", encdb.KeySize))
+	cfg.DBEncryptionKey = "env:DB_ENCRYPTION_TEST_KEY"
+
+	for id, encrypted := range map[string]bool{"blockstore": true, "tx_index": false} {
+		db, err := config.DefaultDBProvider(&config.DBContext{ID: id, Config: cfg})
+		require.NoError(t, err)
+		_, ok := db.(*encdb.DB)
+		assert.Equal(t, encrypted, ok, id)
+		require.NoError(t, db.Close())
+	}
+
+	cfg.DBEncryptionKey = "env:DB_ENCRYPTION_TEST_MISSING_KEY"
+	_, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: cfg})
+	assert.Error(t, err)
+
+	// a database written without encryption is refused on opening
+	cfg.DBBackend = string(dbm.GoLevelDBBackend)
+	cfg.DBEncryptionKey = ""
+	db, err := config.DefaultDBProvider(&config.DBContext{ID: "evidence", Config: cfg})
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("key"), []byte("plain")))
+	require.NoError(t, db.Close())
+	cfg.DBEncryptionKey = "env:DB_ENCRYPTION_TEST_KEY"
+	_, err = config.DefaultDBProvider(&config.DBContext{ID: "evidence", Config: cfg})
+	assert.ErrorIs(t, err, encdb.ErrDecryption)
+
+	// as is one encrypted with another key
+	db, err = config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: cfg})
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("key"), []byte("value")))
+	require.NoError(t, db.Close())
+	t.Setenv("DB_ENCRYPTION_TEST_KEY", strings.Repeat("cd", encdb.KeySize))
+	_, err = config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: cfg})
+	assert.ErrorIs(t, err, encdb.ErrDecryption)
+}
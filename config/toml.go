@@ -89,6 +89,16 @@ proxy_app = "{{ .BaseConfig.ProxyApp }}"
 # A custom human readable name for this node
 moniker = "{{ .BaseConfig.Moniker }}"
 
+# Mode of the node: validator | full | seed
+# * validator - signs blocks and votes with the private validator, which must
+#   have a public key, when in the validator set
+# * full - follows the chain, but never signs, even if its key is in the
+#   validator set; a remote signer can't be set
+# * seed - only crawls the network for peers and shares their addresses,
+#   without the mempool, consensus, block sync, evidence and state sync
+#   reactors; the peer-exchange reactor must be enabled
+mode = "{{ .BaseConfig.Mode }}"
+
 # Database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb
 # * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 #   - pure go
@@ -108,17 +118,45 @@ moniker = "{{ .BaseConfig.Moniker }}"
 # * badgerdb (uses github.com/dgraph-io/badger)
 #   - EXPERIMENTAL
 #   - use badgerdb build tag (go build -tags badgerdb)
+# * pebbledb (uses github.com/cockroachdb/pebble)
+#   - EXPERIMENTAL
+#   - pure go
+#   - use pebbledb build tag (go build -tags pebbledb)
 db_backend = "{{ .BaseConfig.DBBackend }}"
 
 # Database directory
 db_dir = "{{ js .BaseConfig.DBPath }}"
 
-# Output level for logging, including package level options
+# Source of the key encrypting the values of the blockstore, state and evidence
+# databases at rest, with AES-256-GCM. Empty disables encryption. Encryption
+# can only be enabled on a new node (or after "unsafe-reset-all"), and the key
+# can't be changed afterwards.
+# The key is 32 bytes long, hex-encoded, and read from:
+# * file:<path> - a file
+# * env:<name> - an environment variable
+# * cmd:<command> - the output of a command run by the shell, e.g. decrypting
+#   the key with a key management service
+db_encryption_key = "{{ js .BaseConfig.DBEncryptionKey }}"
+
+# Output level for logging, including package level options, e.g.
+# "consensus:debug,p2p:error,*:info". It is reloaded from this file when the
+# node receives SIGHUP, along with the other fields which can be applied at
+# runtime, unless it is set by the --log_level flag.
 log_level = "{{ .BaseConfig.LogLevel }}"
 
-# Output format: 'plain' (colored text) or 'json'
+# Output format: 'plain' (colored text) or 'json'. The JSON log events have
+# the level, time and message in the "level", "ts" and "msg" fields, followed
+# by the "module" field and the other fields of the event.
 log_format = "{{ .BaseConfig.LogFormat }}"
 
+# Sampling of the debug and info log events, to bound the cost of the most
+# frequent ones: of the log events with the same level and message, every
+# second, the first log_sampling_first ones are logged, then every
+# log_sampling_thereafter-th one (none if 0). Error log events are never
+# sampled. 0 disables sampling.
+log_sampling_first = {{ .BaseConfig.LogSamplingFirst }}
+log_sampling_thereafter = {{ .BaseConfig.LogSamplingThereafter }}
+
 ##### additional base config options #####
 
 # Path to the JSON file containing the initial validator set and other meta data
@@ -130,20 +168,98 @@ priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 # Path to the JSON file containing the last sign state of a validator
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
+# Path to the file containing the passphrase of the private key, when it is
+# encrypted with "cometbft key encrypt". If not set, the passphrase is read
+# from the CMT_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable, or else
+# prompted for at startup.
+priv_validator_key_passphrase_file = "{{ js .BaseConfig.PrivValidatorKeyPassphraseFile }}"
+
+# Number of heights the last sign state of a validator can be behind the latest
+# block before the node refuses to start, as it may have been restored from an
+# old backup and sign conflicting votes. 0 disables the check.
+priv_validator_max_watermark_gap = {{ .BaseConfig.PrivValidatorMaxWatermarkGap }}
+
 # TCP or UNIX socket address for CometBFT to listen on for
 # connections from an external PrivValidator process
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# Time within which a request to the external PrivValidator process connected
+# to priv_validator_laddr must succeed, the failed attempts being retried with
+# an exponential backoff.
+priv_validator_sign_deadline = "{{ .BaseConfig.PrivValidatorSignDeadline }}"
+
+# What the node does when a request to the external PrivValidator process
+# exceeds priv_validator_sign_deadline: skip | block
+#   1) "skip" gives up on the request: the validator misses the vote or the
+#      proposal it was signing, and catches up in the next round.
+#   2) "block" keeps retrying the request, blocking consensus until the signer
+#      answers, which may be preferable with a single, restarting, signer.
+priv_validator_on_deadline = "{{ .BaseConfig.PrivValidatorOnDeadline }}"
+
+# Address of an external PrivValidator process serving the gRPC remote signer
+# protocol, which CometBFT dials, e.g. "signer.example.com:26659". Can't be set
+# along with priv_validator_laddr.
+priv_validator_grpc_addr = "{{ .BaseConfig.PrivValidatorGRPCAddr }}"
+
+# Certificate, matching private key, and CA used for mutual TLS with the gRPC
+# remote signer. Paths are relative to the home directory. All or none must be
+# set; without them, the connection is insecure, e.g. when a service mesh
+# secures it.
+priv_validator_grpc_tls_cert_file = "{{ js .BaseConfig.PrivValidatorGRPCTLSCertFile }}"
+priv_validator_grpc_tls_key_file = "{{ js .BaseConfig.PrivValidatorGRPCTLSKeyFile }}"
+priv_validator_grpc_tls_ca_file = "{{ js .BaseConfig.PrivValidatorGRPCTLSCAFile }}"
+
+# Deadline of the requests to the gRPC remote signer, which drops them once it
+# passed. 0 waits indefinitely.
+priv_validator_grpc_timeout = "{{ .BaseConfig.PrivValidatorGRPCTimeout }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# Deadline of the calls to the ABCI application on the mempool, query and
+# snapshot connections. 0 waits indefinitely.
+abci_timeout = "{{ .BaseConfig.ABCITimeout }}"
+
+# Time after which a call on the consensus connection is reported as
+# unresponsive. 0 never reports it.
+abci_consensus_timeout = "{{ .BaseConfig.ABCIConsensusTimeout }}"
+
+# Number of times the Info and Query calls timing out are retried.
+abci_query_retries = {{ .BaseConfig.ABCIQueryRetries }}
+
+# Number of consecutive timeouts on a connection after which its calls are
+# refused for abci_breaker_cooldown, before one probes the application again.
+# 0 disables the circuit breaker.
+abci_breaker_threshold = {{ .BaseConfig.ABCIBreakerThreshold }}
+abci_breaker_cooldown = "{{ .BaseConfig.ABCIBreakerCooldown }}"
+
+# What the node does when the application is unresponsive on the consensus
+# connection: halt | degrade
+#   1) "halt" stops the node, as when the application crashes.
+#   2) "degrade" keeps waiting for the application, the calls on the mempool,
+#      query and snapshot connections failing fast meanwhile.
+abci_on_unresponsive = "{{ .BaseConfig.ABCIOnUnresponsive }}"
+
+# If set, the calls to the ABCI application and their responses are recorded
+# to this file, e.g. to reproduce the bugs of a non-deterministic application
+# by replaying them with "cometbft abci-replay". The file grows with every
+# call: only record them while debugging.
+abci_record_file = "{{ .BaseConfig.ABCIRecordFile }}"
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter_peers = {{ .BaseConfig.FilterPeers }}
 
+# Time the node has to drain on SIGTERM, before it exits: the RPC server stops
+# accepting requests and waits for the ones in flight, the subscribers are sent
+# a final Shutdown event, the pending CheckTx calls are flushed, then the
+# services are stopped, the consensus finishing its step and flushing its WAL.
+# 0 stops the node without draining.
+shutdown_drain_timeout = "{{ .BaseConfig.ShutdownDrainTimeout }}"
+
 
 #######################################################################
 ###                 Advanced Configuration Options                  ###
@@ -168,10 +284,19 @@ cors_allowed_methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 # A list of non simple headers the client is allowed to use with cross-domain requests
 cors_allowed_headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
+# How long the results of a preflight request can be cached by the client.
+# 0 - the Access-Control-Max-Age header is not sent.
+cors_max_age = "{{ .RPC.CORSMaxAge }}"
+
 # TCP or UNIX socket address for the gRPC server to listen on
-# NOTE: This server only supports /broadcast_tx_commit
 grpc_laddr = "{{ .RPC.GRPCListenAddress }}"
 
+# The paths to a certificate and matching private key used to serve gRPC
+# over TLS. Might be either absolute paths or paths related to CometBFT's
+# config directory. Both must be set for TLS to be enabled.
+grpc_tls_cert_file = "{{ .RPC.GRPCTLSCertFile }}"
+grpc_tls_key_file = "{{ .RPC.GRPCTLSKeyFile }}"
+
 # Maximum number of simultaneous connections.
 # Does not include RPC (HTTP&WebSocket) connections. See max_open_connections
 # If you want to accept a larger number than the default, make sure
@@ -193,12 +318,18 @@ unsafe = {{ .RPC.Unsafe }}
 # 1024 - 40 - 10 - 50 = 924 = ~900
 max_open_connections = {{ .RPC.MaxOpenConnections }}
 
+# Maximum number of simultaneous WebSocket connections from a single IP
+# address, so that a single client can't exhaust max_open_connections.
+# 0 - unlimited.
+max_websocket_connections_per_ip = {{ .RPC.MaxWebSocketConnectionsPerIP }}
+
 # Maximum number of unique clientIDs that can /subscribe
 # If you're using /broadcast_tx_commit, set to the estimated maximum number
 # of broadcast_tx_commit calls per block.
 max_subscription_clients = {{ .RPC.MaxSubscriptionClients }}
 
-# Maximum number of unique queries a given client can /subscribe to
+# Maximum number of unique queries a given client can /subscribe to. Each
+# WebSocket connection is a distinct client.
 # If you're using GRPC (or Local RPC client) and /broadcast_tx_commit, set to
 # the estimated # maximum number of broadcast_tx_commit calls per block.
 max_subscriptions_per_client = {{ .RPC.MaxSubscriptionsPerClient }}
@@ -209,6 +340,15 @@ max_subscriptions_per_client = {{ .RPC.MaxSubscriptionsPerClient }}
 # higher event throughput rates (and will use more memory).
 experimental_subscription_buffer_size = {{ .RPC.SubscriptionBufferSize }}
 
+# What to do when the buffer of a subscription is full:
+#   1) "close" (default) - cancel the subscription with an error
+#   2) "drop" - drop the new events, keeping the subscription open
+#   3) "drop_oldest" - drop the oldest buffered events to make room for the new
+#      ones, keeping the subscription open
+# The node never waits for a slow subscriber. The dropped events are counted by
+# the rpc_subscription_events_dropped and pubsub_messages_dropped metrics.
+subscription_overflow_policy = "{{ .RPC.SubscriptionOverflowPolicy }}"
+
 # Experimental parameter to specify the maximum number of RPC responses that
 # can be buffered per WebSocket client. If clients cannot read from the
 # WebSocket endpoint fast enough, they will be disconnected, so increasing this
@@ -242,6 +382,36 @@ max_body_bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max_header_bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Maximum number of requests in a JSON-RPC batch. 0 - unlimited.
+max_request_batch_size = {{ .RPC.MaxRequestBatchSize }}
+
+# Maximum number of requests of a JSON-RPC batch executed concurrently.
+# Only the read-only methods are executed concurrently, the others (e.g.
+# broadcast_tx_*) are executed in the order of the requests.
+request_batch_concurrency = {{ .RPC.RequestBatchConcurrency }}
+
+# Compress the responses with zstd or gzip, as negotiated with the client
+# through the Accept-Encoding header.
+response_compression = {{ .RPC.ResponseCompression }}
+
+# Size, in bytes, below which responses are sent uncompressed.
+response_compression_min_size = {{ .RPC.ResponseCompressionMinSize }}
+
+# Maximum size, in bytes, of the in-memory LRU cache of the responses for
+# immutable data: blocks, canonical commits and block results. The size of the
+# responses is approximated by the size of their protobuf encoding.
+# 0 - disabled.
+response_cache_size = {{ .RPC.ResponseCacheSize }}
+
+# Thresholds past which /health/detailed reports a subsystem as unhealthy: the
+# age of the last block, the number of peers, the latency of a read from the
+# block store and the fraction of the mempool capacity in use.
+# 0 - disables the check.
+health_max_last_block_age = "{{ .RPC.HealthMaxLastBlockAge }}"
+health_min_peers = {{ .RPC.HealthMinPeers }}
+health_max_db_latency = "{{ .RPC.HealthMaxDBLatency }}"
+health_max_mempool_saturation = {{ .RPC.HealthMaxMempoolSaturation }}
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to CometBFT's config directory.
 # If the certificate is signed by a certificate authority,
@@ -257,9 +427,38 @@ tls_cert_file = "{{ .RPC.TLSCertFile }}"
 # Otherwise, HTTP server is run.
 tls_key_file = "{{ .RPC.TLSKeyFile }}"
 
+# API keys accepted by the RPC server (HTTP, JSON-RPC and websocket).
+# Clients present them in the Authorization header ("Authorization: Bearer <key>").
+# NOTE: if neither auth_api_keys nor auth_jwt_secret_file is set, authentication is disabled.
+auth_api_keys = [{{ range .RPC.AuthAPIKeys }}{{ printf "%q, " . }}{{end}}]
+
+# The path to a file containing the secret used to verify the JWTs (signed with HS256)
+# presented by clients in the Authorization header. The subject of the token identifies the client.
+# Might be either absolute path or path related to CometBFT's config directory.
+auth_jwt_secret_file = "{{ .RPC.AuthJWTSecretFile }}"
+
+# Maximum rate, in calls per second, at which each client can call each RPC method,
+# and the size of the burst allowed above it. Clients are identified by their
+# credentials if authentication is enabled, and by their IP address otherwise.
+# 0 - unlimited.
+rate_limit = {{ .RPC.RateLimit }}
+rate_limit_burst = {{ .RPC.RateLimitBurst }}
+
+# Same as rate_limit and rate_limit_burst, for the methods listed in expensive_methods.
+# 0 - unlimited.
+expensive_rate_limit = {{ .RPC.ExpensiveRateLimit }}
+expensive_rate_limit_burst = {{ .RPC.ExpensiveRateLimitBurst }}
+
+# RPC methods subject to expensive_rate_limit instead of rate_limit.
+expensive_methods = [{{ range .RPC.ExpensiveMethods }}{{ printf "%q, " . }}{{end}}]
+
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 
+# When true, the pprof endpoints require the credentials of the RPC server
+# (auth_api_keys or auth_jwt_secret_file).
+pprof_auth = {{ .RPC.PprofAuth }}
+
 #######################################################
 ###           P2P Configuration Options             ###
 #######################################################
@@ -278,6 +477,11 @@ external_address = "{{ .P2P.ExternalAddress }}"
 # Comma separated list of seed nodes to connect to
 seeds = "{{ .P2P.Seeds }}"
 
+# Comma separated list of DNS seeds. The TXT records of each host name
+# hold comma separated lists of seed nodes (id@host:port), re-resolved
+# every time the node falls back to seeds. Used in addition to seeds.
+dns_seeds = "{{ .P2P.DNSSeeds }}"
+
 # Comma separated list of peers to be added to the peer store
 # on startup. Either BootstrapPeers or PersistentPeers are
 # needed for peer discovery
@@ -287,6 +491,10 @@ bootstrap_peers = "{{ .P2P.BootstrapPeers }}"
 persistent_peers = "{{ .P2P.PersistentPeers }}"
 
 # UPNP port forwarding
+# If enabled and external_address is empty, the p2p port is mapped on the
+# gateway using UPnP (or NAT-PMP as a fallback), the lease is refreshed in the
+# background and the discovered external address is advertised to peers once
+# known. The node does not wait for the gateway to start.
 upnp = {{ .P2P.UPNP }}
 
 # Path to address book
@@ -308,6 +516,25 @@ unconditional_peer_ids = "{{ .P2P.UnconditionalPeerIDs }}"
 # Maximum pause when redialing a persistent peer (if zero, exponential backoff is used)
 persistent_peers_max_dial_period = "{{ .P2P.PersistentPeersMaxDialPeriod }}"
 
+# Maximum number of attempts to reconnect to a persistent peer, before leaving
+# it to the PEX reactor (0 - keep trying forever)
+persistent_peers_reconnect_max_attempts = {{ .P2P.PersistentPeersReconnectMaxAttempts }}
+
+# How the pause between the attempts to reconnect to a persistent peer grows:
+#   1) "hybrid" (default) - the interval for 20 attempts, then 3s, 9s, 27s, ...
+#   2) "constant" - the interval
+#   3) "exponential" - doubling from the interval
+persistent_peers_reconnect_backoff = "{{ .P2P.PersistentPeersReconnectBackoff }}"
+
+# Base pause between the attempts to reconnect to a persistent peer
+persistent_peers_reconnect_interval = "{{ .P2P.PersistentPeersReconnectInterval }}"
+
+# Comma separated list of reconnect policies overriding the above for some
+# peers, as <ID>:<field>=<value>;... with the fields max_attempts, backoff,
+# interval and unconditional (to connect ignoring the peer limits), e.g.
+# "<ID>:max_attempts=0;backoff=constant;interval=1s;unconditional=true"
+persistent_peers_reconnect_policies = "{{ .P2P.PersistentPeersReconnectPolicies }}"
+
 # Time to wait before flushing messages out on the connection
 flush_throttle_timeout = "{{ .P2P.FlushThrottleTimeout }}"
 
@@ -326,7 +553,7 @@ pex = {{ .P2P.PexReactor }}
 # Seed mode, in which node constantly crawls the network and looks for
 # peers. If another node asks it for addresses, it responds and disconnects.
 #
-# Does not work if the peer-exchange reactor is disabled.
+# Deprecated: set mode = "seed" instead. It can only be set along with it.
 seed_mode = {{ .P2P.SeedMode }}
 
 # Comma separated list of peer IDs to keep private (will not be gossiped to other peers)
@@ -335,6 +562,22 @@ private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
+# Require mutually-authenticated TLS, on top of the secret connection, for
+# connections with the peers listed in private_peer_ids (e.g. between a
+# validator and its sentries). Peer certificates must be signed by
+# private_peer_tls_ca_file and carry the peer's node ID as a DNS subject
+# alternative name. Paths are absolute or relative to the config directory.
+private_peer_tls = {{ .P2P.PrivatePeerTLS }}
+private_peer_tls_cert_file = "{{ .P2P.PrivatePeerTLSCertFile }}"
+private_peer_tls_key_file = "{{ .P2P.PrivatePeerTLSKeyFile }}"
+private_peer_tls_ca_file = "{{ .P2P.PrivatePeerTLSCAFile }}"
+
+# Comma separated list of reactors whose messages are compressed (zstd or
+# snappy, negotiated during the handshake) with peers which also enabled
+# compression for them. Useful for archive and snapshot serving nodes.
+# Possible values: "blocksync", "statesync", "mempool".
+compressed_reactors = "{{ .P2P.CompressedReactors }}"
+
 # Peer connection configuration.
 handshake_timeout = "{{ .P2P.HandshakeTimeout }}"
 dial_timeout = "{{ .P2P.DialTimeout }}"
@@ -389,6 +632,24 @@ max_tx_bytes = {{ .Mempool.MaxTxBytes }}
 # XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 max_batch_bytes = {{ .Mempool.MaxBatchBytes }}
 
+# Maximum rate, in txs per second, at which each peer can send txs, in bursts
+# of up to peer_tx_burst. The txs past the limit are dropped, unchecked.
+# 0 disables the limit.
+peer_tx_rate = {{ .Mempool.PeerTxRate }}
+peer_tx_burst = {{ .Mempool.PeerTxBurst }}
+
+# Same as peer_tx_rate and peer_tx_burst, for all the peers together.
+peers_tx_rate = {{ .Mempool.PeersTxRate }}
+peers_tx_burst = {{ .Mempool.PeersTxBurst }}
+
+# Delay the broadcast of the txs to the peers which usually already have them,
+# i.e. which mostly send us txs we already have, by up to
+# adaptive_gossip_max_delay, in proportion to how often they do. The txs such a
+# peer sends us in the meantime aren't sent to it, which cuts the duplicate
+# traffic on dense topologies.
+adaptive_gossip = {{ .Mempool.AdaptiveGossip }}
+adaptive_gossip_max_delay = "{{ .Mempool.AdaptiveGossipMaxDelay }}"
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -403,6 +664,8 @@ enable = {{ .StateSync.Enable }}
 # RPC servers (comma-separated) for light client verification of the synced state machine and
 # retrieval of state data for node bootstrapping. Also needs a trusted height and corresponding
 # header hash obtained from a trusted source, and a period during which validators can be trusted.
+# If trust_height and trust_hash are left empty, the latest block which all the RPC servers have is
+# trusted, provided that they agree on its hash: the RPC servers must then be trusted not to collude.
 #
 # For Cosmos SDK-based chains, trust_period should usually be about 2/3 of the unbonding time (~2
 # weeks) during which they can be financially punished (slashed) for misbehavior.
@@ -415,7 +678,9 @@ trust_period = "{{ .StateSync.TrustPeriod }}"
 discovery_time = "{{ .StateSync.DiscoveryTime }}"
 
 # Temporary directory for state sync snapshot chunks, defaults to the OS tempdir (typically /tmp).
-# Will create a new, randomly named directory within, and remove it when done.
+# Will create a directory within for each snapshot, and remove it when done. If
+# the node is restarted in the middle of a state sync, the restoration of the
+# snapshot is resumed, reusing the chunks found in its directory.
 temp_dir = "{{ .StateSync.TempDir }}"
 
 # The timeout duration before re-requesting a chunk, possibly from a different
@@ -479,16 +744,74 @@ create_empty_blocks_interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 peer_gossip_sleep_duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Fork monitor: watches the votes gossiped by the peers, and the blocks of the
+# witnesses below, for blocks conflicting with the ones committed. A fork, i.e.
+# a conflicting block signed by more than 1/3 of the voting power, is logged,
+# counted by the fork_monitor_conflicts metric, and its evidence is added to
+# the evidence pool.
+fork_monitor = {{ .Consensus.ForkMonitor }}
+
+# Comma separated list of the RPC servers of the witnesses, e.g.
+# "http://10.0.0.1:26657,http://10.0.0.2:26657", ideally run by other
+# operators
+fork_monitor_witnesses = "{{ .Consensus.ForkMonitorWitnesses }}"
+
+# Interval at which the last block is checked against the witnesses
+fork_monitor_interval = "{{ .Consensus.ForkMonitorInterval }}"
+
+# Stop the node once a fork is found, for the operators to investigate
+fork_monitor_halt = {{ .Consensus.ForkMonitorHalt }}
+
 #######################################################
 ###         Storage Configuration Options           ###
 #######################################################
 [storage]
 
-# Set to true to discard ABCI responses from the state store, which can save a
-# considerable amount of disk space. Set to false to ensure ABCI responses are
-# persisted. ABCI responses are required for /block_results RPC queries, and to
-# reindex events in the command-line tool.
-discard_abci_responses = {{ .Storage.DiscardABCIResponses}}
+# Number of the latest heights of which the ABCI responses are retained in the
+# state store, which can save a considerable amount of disk space. ABCI
+# responses are required for /block_results RPC queries, and to reindex events
+# in the command-line tool. The responses falling out of this window are
+# deleted, including, in the background on startup, the ones saved before the
+# window was set or reduced. 0 retains them all.
+retain_abci_responses = {{ .Storage.RetainABCIResponses }}
+
+# Interval at which the node prunes the blocks, commits and ABCI responses in
+# the background, spreading the pruning over time. 0 disables it, in which case
+# the blocks are pruned on commit, up to the retain height returned by the
+# application. Otherwise, the blocks below the retain height of the
+# application are pruned unless they are retained by retain_blocks or
+# retain_duration. The blocks above the retain height of the application are
+# always retained, and so are the headers and commits needed to prove
+# evidence.
+pruning_interval = "{{ .Storage.PruningInterval }}"
+
+# Number of the latest blocks retained by the background pruning. 0 means no
+# retention by number of blocks.
+retain_blocks = {{ .Storage.RetainBlocks }}
+
+# Age of the oldest block retained by the background pruning. 0 means no
+# retention by age.
+retain_duration = "{{ .Storage.RetainDuration }}"
+
+# If true, the background pruning retains the blocks above the oldest snapshot
+# of the application, so that other nodes can state sync from it.
+pruning_retain_snapshots = {{ .Storage.PruningRetainSnapshots }}
+
+# Interval at which the node compacts its databases (block store, state,
+# evidence and indexer), reclaiming the disk space of the pruned data, which
+# some backends (e.g. goleveldb) never do on their own. Compaction runs online
+# but is I/O intensive, so the interval should be long (e.g. "24h"). 0
+# disables it. Only goleveldb and pebbledb support compaction.
+compaction_interval = "{{ .Storage.CompactionInterval }}"
+
+# Interval at which the node checks the invariants between its stores: the
+# block store against the state, the validator sets against the header of the
+# last block, the end of the consensus WAL against the state and the
+# continuity of the indexed heights. Violations, e.g. due to silent disk
+# corruption or an inconsistent rollback, are logged as errors and reported by
+# the invariant_violated metric and the /invariants RPC endpoint, before they
+# cause the node to halt. 0 disables it.
+invariant_check_interval = "{{ .Storage.InvariantCheckInterval }}"
 
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
@@ -505,6 +828,9 @@ discard_abci_responses = {{ .Storage.DiscardABCIResponses}}
 #   2) "kv" (default) - the simplest possible indexer, backed by key-value storage (defaults to levelDB; see DBBackend).
 # 		- When "kv" is chosen "tx.height" and "tx.hash" will always be indexed.
 #   3) "psql" - the indexer services backed by PostgreSQL.
+#   4) "kafka" - the events are published to Kafka, through a Kafka REST proxy.
+# 		- The heights which fail to be published are published again by the backfill,
+# 		  which must be enabled.
 # When "kv" or "psql" is chosen "tx.height" and "tx.hash" will always be indexed.
 indexer = "{{ .TxIndex.Indexer }}"
 
@@ -512,6 +838,20 @@ indexer = "{{ .TxIndex.Indexer }}"
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# The URL of the Kafka REST proxy, for the "kafka" indexer, e.g.:
+#   https://<user>:<password>@<host>:8082
+stream-url = "{{ .TxIndex.StreamURL }}"
+
+# The Kafka topics the block and tx events are published to.
+stream-block-topic = "{{ .TxIndex.StreamBlockTopic }}"
+stream-tx-topic = "{{ .TxIndex.StreamTxTopic }}"
+
+# When true, the heights missing from the index, e.g. after the node was
+# restarted with indexing temporarily disabled, are indexed in the background
+# from the block store. The ABCI responses of the heights must be retained (see
+# retain_abci_responses). The missing heights are reported by /indexer_status.
+backfill = {{ .TxIndex.Backfill }}
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -533,4 +873,39 @@ max_open_connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
+
+# Base URL of an OTLP/HTTP receiver, e.g. an OpenTelemetry collector at
+# "http://localhost:4318", to which the traces (RPC calls, ABCI calls, p2p
+# sends, consensus steps) and metrics of the node are exported, with the
+# chain_id, node_id and moniker resource attributes. Empty disables the export.
+otlp_endpoint = "{{ .Instrumentation.OTLPEndpoint }}"
+
+# Headers of the export requests, as "key=value", e.g. to authenticate with the
+# receiver.
+otlp_headers = [{{ range .Instrumentation.OTLPHeaders }}{{ printf "%q, " . }}{{end}}]
+
+# How often the traces and metrics are exported.
+otlp_export_interval = "{{ .Instrumentation.OTLPExportInterval }}"
+
+# Number of recent blocks over which the blocks missed by each validator of the
+# active set are counted. 0 disables the per-validator metrics.
+validator_liveness_window = {{ .Instrumentation.ValidatorLivenessWindow }}
+
+# How often the CPU and heap profiles of the node are captured, so that
+# performance regressions can be diagnosed after the fact. 0 disables the capture.
+profile_interval = "{{ .Instrumentation.ProfileInterval }}"
+
+# How long the CPU is profiled for, at each capture.
+profile_cpu_duration = "{{ .Instrumentation.ProfileCPUDuration }}"
+
+# Directory where the profiles are kept, relative to the home directory unless
+# absolute. Empty disables keeping them locally.
+profile_dir = "{{ .Instrumentation.ProfileDir }}"
+
+# Number of profiles of each type kept in profile_dir, the older ones being removed.
+profile_retain = {{ .Instrumentation.ProfileRetain }}
+
+# Base URL of a Pyroscope-compatible server to which the profiles are pushed,
+# e.g. "http://localhost:4040". Empty disables the push.
+profile_push_url = "{{ .Instrumentation.ProfilePushURL }}"
 `
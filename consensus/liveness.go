@@ -0,0 +1,79 @@
+package consensus
+
+import (
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/types"
+)
+
+// validatorLiveness counts the blocks missed by each validator of the active
+// set over a sliding window of the last heights, for the per-validator
+// metrics.
+type validatorLiveness struct {
+	window  int
+	windows map[string]*livenessWindow
+}
+
+// livenessWindow is a ring buffer of whether a validator missed each of the
+// last blocks.
+type livenessWindow struct {
+	missed []bool
+	next   int
+	count  int
+}
+
+func newValidatorLiveness(window int) *validatorLiveness {
+	return &validatorLiveness{
+		window:  window,
+		windows: make(map[string]*livenessWindow),
+	}
+}
+
+// record records whether the validator missed the last block, and returns
+// the number of blocks it missed over the window.
+func (w *livenessWindow) record(missed bool) int {
+	if w.missed[w.next] {
+		w.count--
+	}
+	w.missed[w.next] = missed
+	if missed {
+		w.count++
+	}
+	w.next = (w.next + 1) % len(w.missed)
+	return w.count
+}
+
+// record records the signatures of commit, made by vals, and updates the
+// metrics of each validator. The validators which left the set are
+// forgotten.
+func (l *validatorLiveness) record(m *Metrics, vals *types.ValidatorSet, commit *types.Commit) {
+	// only the signatures of a commit whose signatures for the block are all
+	// bn254 signatures can be aggregated
+	aggregatable := true
+	for i, val := range vals.Validators {
+		if commit.Signatures[i].ForBlock() && val.PubKey.Type() != bn254.KeyType {
+			aggregatable = false
+			break
+		}
+	}
+
+	windows := make(map[string]*livenessWindow, len(vals.Validators))
+	for i, val := range vals.Validators {
+		addr := val.Address.String()
+		w, ok := l.windows[addr]
+		if !ok {
+			w = &livenessWindow{missed: make([]bool, l.window)}
+		}
+		windows[addr] = w
+
+		commitSig := commit.Signatures[i]
+		label := []string{"validator_address", addr}
+		if commitSig.ForBlock() {
+			m.ValidatorsLastSignedHeight.With(label...).Set(float64(commit.Height))
+			if aggregatable {
+				m.ValidatorsAggregatedCommits.With(label...).Add(1)
+			}
+		}
+		m.ValidatorsMissedBlocksWindow.With(label...).Set(float64(w.record(!commitSig.ForBlock())))
+	}
+	l.windows = windows
+}
@@ -0,0 +1,47 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestValidatorLiveness(t *testing.T) {
+	vals, _ := test.ValidatorSet(context.Background(), t, 3, 10)
+	m := NopMetrics()
+
+	commit := func(height int64, absent int) *types.Commit {
+		c := &types.Commit{Height: height, Signatures: make([]types.CommitSig, vals.Size())}
+		for i, val := range vals.Validators {
+			if i == absent {
+				c.Signatures[i] = types.NewCommitSigAbsent()
+				continue
+			}
+			c.Signatures[i] = types.CommitSig{BlockIDFlag: types.BlockIDFlagCommit, ValidatorAddress: val.Address}
+		}
+		return c
+	}
+
+	// the last validator misses 3 blocks in a row, then signs
+	l := newValidatorLiveness(2)
+	for h := int64(1); h <= 3; h++ {
+		l.record(m, vals, commit(h, 2))
+	}
+	w := l.windows[vals.Validators[2].Address.String()]
+	assert.Equal(t, 2, w.count)
+	assert.Equal(t, 0, l.windows[vals.Validators[0].Address.String()].count)
+	l.record(m, vals, commit(4, -1))
+	assert.Equal(t, 1, w.count)
+	l.record(m, vals, commit(5, -1))
+	assert.Equal(t, 0, w.count)
+	assert.Len(t, l.windows, 3)
+
+	// the validators which left the set are forgotten
+	vals = types.NewValidatorSet(vals.Validators[:1])
+	l.record(m, vals, commit(6, -1))
+	assert.Len(t, l.windows, 1)
+}
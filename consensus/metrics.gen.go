@@ -64,6 +64,24 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "validator_missed_blocks",
 			Help:      "Amount of blocks missed per validator.",
 		}, append(labels, "validator_address")).With(labelsAndValues...),
+		ValidatorsLastSignedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validators_last_signed_height",
+			Help:      "Last height signed by each validator of the active set.",
+		}, append(labels, "validator_address")).With(labelsAndValues...),
+		ValidatorsMissedBlocksWindow: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validators_missed_blocks_window",
+			Help:      "Number of blocks missed by each validator of the active set among the last blocks of the liveness window.",
+		}, append(labels, "validator_address")).With(labelsAndValues...),
+		ValidatorsAggregatedCommits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validators_aggregated_commits",
+			Help:      "Number of commits whose aggregated signature includes the signature of each validator of the active set.",
+		}, append(labels, "validator_address")).With(labelsAndValues...),
 		MissingValidators: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -179,31 +197,34 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Height:                    discard.NewGauge(),
-		ValidatorLastSignedHeight: discard.NewGauge(),
-		Rounds:                    discard.NewGauge(),
-		RoundDurationSeconds:      discard.NewHistogram(),
-		Validators:                discard.NewGauge(),
-		ValidatorsPower:           discard.NewGauge(),
-		ValidatorPower:            discard.NewGauge(),
-		ValidatorMissedBlocks:     discard.NewGauge(),
-		MissingValidators:         discard.NewGauge(),
-		MissingValidatorsPower:    discard.NewGauge(),
-		ByzantineValidators:       discard.NewGauge(),
-		ByzantineValidatorsPower:  discard.NewGauge(),
-		BlockIntervalSeconds:      discard.NewHistogram(),
-		NumTxs:                    discard.NewGauge(),
-		BlockSizeBytes:            discard.NewGauge(),
-		TotalTxs:                  discard.NewGauge(),
-		CommittedHeight:           discard.NewGauge(),
-		BlockParts:                discard.NewCounter(),
-		StepDurationSeconds:       discard.NewHistogram(),
-		BlockGossipPartsReceived:  discard.NewCounter(),
-		QuorumPrevoteDelay:        discard.NewGauge(),
-		FullPrevoteDelay:          discard.NewGauge(),
-		ProposalReceiveCount:      discard.NewCounter(),
-		ProposalCreateCount:       discard.NewCounter(),
-		RoundVotingPowerPercent:   discard.NewGauge(),
-		LateVotes:                 discard.NewCounter(),
+		Height:                       discard.NewGauge(),
+		ValidatorLastSignedHeight:    discard.NewGauge(),
+		Rounds:                       discard.NewGauge(),
+		RoundDurationSeconds:         discard.NewHistogram(),
+		Validators:                   discard.NewGauge(),
+		ValidatorsPower:              discard.NewGauge(),
+		ValidatorPower:               discard.NewGauge(),
+		ValidatorMissedBlocks:        discard.NewGauge(),
+		ValidatorsLastSignedHeight:   discard.NewGauge(),
+		ValidatorsMissedBlocksWindow: discard.NewGauge(),
+		ValidatorsAggregatedCommits:  discard.NewCounter(),
+		MissingValidators:            discard.NewGauge(),
+		MissingValidatorsPower:       discard.NewGauge(),
+		ByzantineValidators:          discard.NewGauge(),
+		ByzantineValidatorsPower:     discard.NewGauge(),
+		BlockIntervalSeconds:         discard.NewHistogram(),
+		NumTxs:                       discard.NewGauge(),
+		BlockSizeBytes:               discard.NewGauge(),
+		TotalTxs:                     discard.NewGauge(),
+		CommittedHeight:              discard.NewGauge(),
+		BlockParts:                   discard.NewCounter(),
+		StepDurationSeconds:          discard.NewHistogram(),
+		BlockGossipPartsReceived:     discard.NewCounter(),
+		QuorumPrevoteDelay:           discard.NewGauge(),
+		FullPrevoteDelay:             discard.NewGauge(),
+		ProposalReceiveCount:         discard.NewCounter(),
+		ProposalCreateCount:          discard.NewCounter(),
+		RoundVotingPowerPercent:      discard.NewGauge(),
+		LateVotes:                    discard.NewCounter(),
 	}
 }
@@ -41,6 +41,14 @@ type Metrics struct {
 	ValidatorPower metrics.Gauge `metrics_labels:"validator_address"`
 	// Amount of blocks missed per validator.
 	ValidatorMissedBlocks metrics.Gauge `metrics_labels:"validator_address"`
+	// Last height signed by each validator of the active set.
+	ValidatorsLastSignedHeight metrics.Gauge `metrics_labels:"validator_address"`
+	// Number of blocks missed by each validator of the active set among the
+	// last blocks of the liveness window.
+	ValidatorsMissedBlocksWindow metrics.Gauge `metrics_labels:"validator_address"`
+	// Number of commits whose aggregated signature includes the signature of
+	// each validator of the active set.
+	ValidatorsAggregatedCommits metrics.Counter `metrics_labels:"validator_address"`
 	// Number of validators who did not sign.
 	MissingValidators metrics.Gauge
 	// Total power of the missing validators.
@@ -344,6 +344,9 @@ func (h *Handshaker) ReplayBlocks(
 			}
 
 			if res.ConsensusParams != nil {
+				if err := state.ConsensusParams.ValidateUpdate(res.ConsensusParams); err != nil {
+					return nil, fmt.Errorf("error updating consensus params: %w", err)
+				}
 				state.ConsensusParams = state.ConsensusParams.Update(res.ConsensusParams)
 				state.Version.Consensus.App = state.ConsensusParams.Version.App
 			}
@@ -468,7 +471,8 @@ func (h *Handshaker) replayBlocks(
 			assertAppHashEqualsOneFromBlock(appHash, block)
 		}
 
-		appHash, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, h.logger, h.stateStore, h.genDoc.InitialHeight)
+		appHash, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, h.logger, h.stateStore, h.genDoc.InitialHeight,
+			state.ConsensusParams.Encoding.Canonical)
 		if err != nil {
 			return nil, err
 		}
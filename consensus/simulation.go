@@ -0,0 +1,502 @@
+package consensus
+
+import (
+	"container/heap"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"math/rand"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/proxy"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+const simulationChainID = "simulation"
+
+// simulationGenesisTime is the genesis time of the simulations, at which
+// their clock starts.
+var simulationGenesisTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SimulationOption sets an optional parameter on the Simulation.
+type SimulationOption func(*Simulation)
+
+// SimulationLatency sets the bounds of the latency of the messages between
+// the validators, drawn uniformly. It defaults to 1ms to 50ms.
+func SimulationLatency(min, max time.Duration) SimulationOption {
+	return func(s *Simulation) {
+		s.minLatency = min
+		s.maxLatency = max
+	}
+}
+
+// SimulationLogger sets the logger of the simulation and of its validators,
+// which logs every simulated event at the debug level.
+func SimulationLogger(logger log.Logger) SimulationOption {
+	return func(s *Simulation) { s.logger = logger }
+}
+
+// Simulation is a deterministic discrete-event simulation of a network of
+// validators. Their consensus states run in a single goroutine, with their
+// timeouts and the messages between them scheduled on a virtual clock, and
+// all the randomness drawn from a seed: the keys of the validators, the
+// latencies of the messages and the order of simultaneous events. A run is
+// thus reproduced by its seed, and different seeds explore different
+// orderings of the messages and interleavings with the timeouts.
+//
+// The messages don't go through the reactors: the proposals, block parts and
+// votes of each validator are broadcast to the others, in order, and the
+// messages of later heights and rounds are held back until the validator
+// reaches them, as gossip would.
+type Simulation struct {
+	rng        *rand.Rand
+	now        time.Time
+	nodes      []*simNode
+	events     simEvents
+	seq        int64
+	digest     hash.Hash
+	minLatency time.Duration
+	maxLatency time.Duration
+	logger     log.Logger
+}
+
+// simNode is a validator of a simulation.
+type simNode struct {
+	id      p2p.ID
+	cs      *State
+	ticker  *simTicker
+	stop    func()
+	pending []msgInfo // messages of later heights or rounds
+	// the last delivery time of the messages sent to each node, to keep them
+	// in order
+	sent map[p2p.ID]time.Time
+}
+
+// NewSimulation returns a simulation of nValidators validators of equal
+// power, running the applications returned by newApp with the given
+// consensus config, which must create empty blocks. The simulation starts at
+// genesis.
+func NewSimulation(
+	config *cfg.ConsensusConfig,
+	nValidators int,
+	seed int64,
+	newApp func() abci.Application,
+	options ...SimulationOption,
+) (*Simulation, error) {
+	if !config.CreateEmptyBlocks {
+		return nil, errors.New("simulations need create_empty_blocks")
+	}
+	s := &Simulation{
+		rng:        rand.New(rand.NewSource(seed)), //nolint:gosec
+		now:        simulationGenesisTime,
+		digest:     sha256.New(),
+		minLatency: time.Millisecond,
+		maxLatency: 50 * time.Millisecond,
+		logger:     log.NewNopLogger(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	if s.minLatency < 0 || s.maxLatency < s.minLatency {
+		return nil, fmt.Errorf("invalid latency bounds %v to %v", s.minLatency, s.maxLatency)
+	}
+
+	genDoc := &types.GenesisDoc{
+		ChainID:       simulationChainID,
+		GenesisTime:   simulationGenesisTime,
+		InitialHeight: 1,
+	}
+	privVals := make([]types.PrivValidator, nValidators)
+	for i := range privVals {
+		privKey := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("simulation/%d/%d", seed, i)))
+		privVals[i] = types.NewMockPVWithParams(privKey, false, false)
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+			PubKey: privKey.PubKey(),
+			Power:  10,
+		})
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+
+	for i, privVal := range privVals {
+		n, err := s.newNode(p2p.ID(fmt.Sprintf("validator%d", i)), config, genDoc, privVal, newApp())
+		if err != nil {
+			s.Stop()
+			return nil, err
+		}
+		s.nodes = append(s.nodes, n)
+	}
+	for _, n := range s.nodes {
+		// the start time was set on the wall clock
+		n.cs.StartTime = n.cs.config.Commit(s.now)
+		n.cs.scheduleRound0(&n.cs.RoundState)
+	}
+	return s, nil
+}
+
+func (s *Simulation) newNode(
+	id p2p.ID,
+	config *cfg.ConsensusConfig,
+	genDoc *types.GenesisDoc,
+	privVal types.PrivValidator,
+	app abci.Application,
+) (*simNode, error) {
+	logger := s.logger.With("validator", id)
+	n := &simNode{id: id, sent: make(map[p2p.ID]time.Time)}
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	state, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app), proxy.NopMetrics())
+	proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := proxyApp.Start(); err != nil {
+		return nil, fmt.Errorf("starting the app connections: %w", err)
+	}
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(logger.With("module", "events"))
+	if err := eventBus.Start(); err != nil {
+		_ = proxyApp.Stop()
+		return nil, fmt.Errorf("starting the event bus: %w", err)
+	}
+	n.stop = func() {
+		_ = eventBus.Stop()
+		_ = proxyApp.Stop()
+	}
+
+	handshaker := NewHandshaker(stateStore, state, blockStore, genDoc)
+	handshaker.SetLogger(logger.With("module", "consensus"))
+	handshaker.SetEventBus(eventBus)
+	if err := handshaker.Handshake(proxyApp); err != nil {
+		n.stop()
+		return nil, fmt.Errorf("handshake: %w", err)
+	}
+	if state, err = stateStore.Load(); err != nil {
+		n.stop()
+		return nil, err
+	}
+
+	mempool, evpool := emptyMempool{}, sm.EmptyEvidencePool{}
+	blockExec := sm.NewBlockExecutor(stateStore, logger.With("module", "state"), proxyApp.Consensus(),
+		mempool, evpool, blockStore)
+	n.cs = NewState(config, state, blockExec, blockStore, mempool, evpool)
+	n.cs.now = s.Now
+	n.ticker = &simTicker{sim: s, node: n}
+	n.cs.SetTimeoutTicker(n.ticker)
+	n.cs.SetLogger(logger.With("module", "consensus"))
+	n.cs.SetPrivValidator(privVal)
+	n.cs.SetEventBus(eventBus)
+	return n, nil
+}
+
+// Now returns the time of the simulation.
+func (s *Simulation) Now() time.Time {
+	return s.now
+}
+
+// States returns the consensus states of the validators, which must only be
+// used between the steps of the simulation.
+func (s *Simulation) States() []*State {
+	states := make([]*State, len(s.nodes))
+	for i, n := range s.nodes {
+		states[i] = n.cs
+	}
+	return states
+}
+
+// Height returns the lowest height committed by the validators.
+func (s *Simulation) Height() int64 {
+	var height int64
+	for i, n := range s.nodes {
+		if h := n.cs.blockStore.Height(); i == 0 || h < height {
+			height = h
+		}
+	}
+	return height
+}
+
+// Digest returns the hash of the events simulated so far, which is the same
+// for the runs with the same seed.
+func (s *Simulation) Digest() []byte {
+	return s.digest.Sum(nil)
+}
+
+// Step simulates the next event, and returns false if there is none left.
+func (s *Simulation) Step() bool {
+	if s.events.Len() == 0 {
+		return false
+	}
+	ev := heap.Pop(&s.events).(*simEvent)
+	s.now = ev.at
+	n := ev.node
+
+	if ev.timeout != nil {
+		if ev.gen != n.ticker.gen {
+			// replaced by a later timeout
+			return true
+		}
+		s.record(n, ev.timeout.String())
+		n.cs.handleTimeout(*ev.timeout, n.cs.RoundState)
+	} else {
+		s.record(n, fmt.Sprintf("%v from %q", ev.msg.Msg, ev.msg.PeerID))
+		if ev.msg.PeerID == "" || n.accept(ev.msg) {
+			n.cs.handleMsg(ev.msg)
+		}
+	}
+
+	// broadcast the messages of the node, and pass it the ones it was
+	// waiting for, until it has neither
+	for {
+		s.broadcast(n)
+		mi, ok := n.nextPending()
+		if !ok {
+			return true
+		}
+		n.cs.handleMsg(mi)
+	}
+}
+
+// RunToHeight runs the simulation until all the validators committed the
+// given height, within maxDuration of the simulated time, and checks they
+// agree on the blocks.
+func (s *Simulation) RunToHeight(height int64, maxDuration time.Duration) error {
+	deadline := s.now.Add(maxDuration)
+	for s.Height() < height {
+		if !s.Step() {
+			return errors.New("no events left to simulate")
+		}
+		if s.now.After(deadline) {
+			return fmt.Errorf("height %d not committed within %v, at height %d", height, maxDuration, s.Height())
+		}
+	}
+	return s.CheckAgreement()
+}
+
+// CheckAgreement checks that the validators committed the same blocks.
+func (s *Simulation) CheckAgreement() error {
+	for height := int64(1); ; height++ {
+		var first *types.BlockMeta
+		for _, n := range s.nodes {
+			meta := n.cs.blockStore.LoadBlockMeta(height)
+			if meta == nil {
+				continue
+			}
+			if first == nil {
+				first = meta
+			} else if !meta.BlockID.Equals(first.BlockID) {
+				return fmt.Errorf("validators committed blocks %v and %v at height %d",
+					first.BlockID, meta.BlockID, height)
+			}
+		}
+		if first == nil {
+			return nil
+		}
+	}
+}
+
+// Stop stops the event buses and the applications of the validators.
+func (s *Simulation) Stop() {
+	for _, n := range s.nodes {
+		n.stop()
+	}
+}
+
+// record logs an event and adds it to the digest.
+func (s *Simulation) record(n *simNode, event string) {
+	elapsed := s.now.Sub(simulationGenesisTime)
+	s.logger.Debug("Simulated event", "time", elapsed, "validator", n.id, "event", event)
+	fmt.Fprintf(s.digest, "%d %s %s\n", elapsed, n.id, event)
+}
+
+// broadcast schedules the delivery of the messages of a node to itself, at
+// once, and to the other nodes, after their latency, and drains its stats.
+func (s *Simulation) broadcast(n *simNode) {
+	for {
+		select {
+		case <-n.cs.statsMsgQueue:
+			continue
+		case mi := <-n.cs.internalMsgQueue:
+			s.send(n, n, mi, 0)
+			for _, peer := range s.nodes {
+				if peer != n {
+					latency := s.minLatency + time.Duration(s.rng.Int63n(int64(s.maxLatency-s.minLatency)+1))
+					s.send(n, peer, msgInfo{Msg: mi.Msg, PeerID: n.id}, latency)
+				}
+			}
+			continue
+		default:
+		}
+		return
+	}
+}
+
+// send schedules the delivery of a message after the given latency, but
+// after the previous messages from the same node.
+func (s *Simulation) send(from, to *simNode, mi msgInfo, latency time.Duration) {
+	at := s.now.Add(latency)
+	if last, ok := from.sent[to.id]; ok && !at.After(last) {
+		at = last.Add(time.Nanosecond)
+	}
+	from.sent[to.id] = at
+	s.push(&simEvent{at: at, node: to, msg: mi})
+}
+
+func (s *Simulation) push(ev *simEvent) {
+	s.seq++
+	ev.order = s.rng.Int63()
+	ev.seq = s.seq
+	heap.Push(&s.events, ev)
+}
+
+// accept returns whether a message from a peer can be passed to the node,
+// and otherwise holds it back if it is for a later height or round.
+func (n *simNode) accept(mi msgInfo) bool {
+	switch n.classify(mi.Msg) {
+	case simDeliver:
+		return true
+	case simWait:
+		n.pending = append(n.pending, mi)
+	}
+	return false
+}
+
+// nextPending returns the first message held back which can now be passed
+// to the node, and drops the ones it is past.
+func (n *simNode) nextPending() (msgInfo, bool) {
+	for i := 0; i < len(n.pending); i++ {
+		mi := n.pending[i]
+		switch n.classify(mi.Msg) {
+		case simWait:
+			continue
+		case simDeliver:
+			n.pending = append(n.pending[:i], n.pending[i+1:]...)
+			return mi, true
+		case simDrop:
+			n.pending = append(n.pending[:i], n.pending[i+1:]...)
+			i--
+		}
+	}
+	return msgInfo{}, false
+}
+
+const (
+	simDeliver = iota
+	simWait
+	simDrop
+)
+
+// classify returns whether a message can be passed to the node, must wait
+// for it to reach a later height or round, or is of a height it is past. As
+// the reactor, it passes the votes of the next round, to skip to it.
+func (n *simNode) classify(msg Message) int {
+	var (
+		height int64
+		round  int32
+		vote   *types.Vote
+	)
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		height, round = msg.Proposal.Height, msg.Proposal.Round
+	case *BlockPartMessage:
+		height, round = msg.Height, msg.Round
+	case *VoteMessage:
+		vote = msg.Vote
+		height, round = vote.Height, vote.Round
+	default:
+		return simDeliver
+	}
+
+	rs := &n.cs.RoundState
+	switch {
+	case height < rs.Height:
+		if vote != nil && vote.Type == cmtproto.PrecommitType && height == rs.Height-1 {
+			return simDeliver
+		}
+		return simDrop
+	case height > rs.Height:
+		return simWait
+	case vote != nil && round > rs.Round+1, vote == nil && round > rs.Round:
+		return simWait
+	}
+	return simDeliver
+}
+
+// simTicker is the TimeoutTicker of a simulated node, which schedules the
+// timeouts on the clock of the simulation.
+type simTicker struct {
+	sim  *Simulation
+	node *simNode
+	ti   timeoutInfo
+	gen  int64 // of the last timeout scheduled
+}
+
+var _ TimeoutTicker = (*simTicker)(nil)
+
+func (t *simTicker) Start() error             { return nil }
+func (t *simTicker) Stop() error              { return nil }
+func (t *simTicker) Chan() <-chan timeoutInfo { return nil }
+func (t *simTicker) SetLogger(log.Logger)     {}
+func (t *simTicker) ScheduleTimeout(ti timeoutInfo) {
+	if !supersedes(ti, t.ti) {
+		return
+	}
+	t.ti = ti
+	t.gen++
+	duration := ti.Duration
+	if duration < 0 {
+		duration = 0
+	}
+	t.sim.push(&simEvent{at: t.sim.now.Add(duration), node: t.node, timeout: &ti, gen: t.gen})
+}
+
+// simEvent is the delivery of a message or a timeout to a node.
+type simEvent struct {
+	at      time.Time
+	order   int64 // random, for the events at the same time
+	seq     int64
+	node    *simNode
+	msg     msgInfo
+	timeout *timeoutInfo
+	gen     int64
+}
+
+// simEvents is a priority queue of events, by time and random order.
+type simEvents []*simEvent
+
+func (e simEvents) Len() int { return len(e) }
+
+func (e simEvents) Less(i, j int) bool {
+	if !e[i].at.Equal(e[j].at) {
+		return e[i].at.Before(e[j].at)
+	}
+	if e[i].order != e[j].order {
+		return e[i].order < e[j].order
+	}
+	return e[i].seq < e[j].seq
+}
+
+func (e simEvents) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+
+func (e *simEvents) Push(x interface{}) { *e = append(*e, x.(*simEvent)) }
+
+func (e *simEvents) Pop() interface{} {
+	old := *e
+	ev := old[len(old)-1]
+	*e = old[:len(old)-1]
+	return ev
+}
@@ -0,0 +1,65 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+)
+
+func newTestSimulation(t *testing.T, seed int64, options ...SimulationOption) *Simulation {
+	t.Helper()
+	sim, err := NewSimulation(cfg.TestConsensusConfig(), 4, seed,
+		func() abci.Application { return kvstore.NewApplication() }, options...)
+	require.NoError(t, err)
+	t.Cleanup(sim.Stop)
+	return sim
+}
+
+func TestSimulationDeterministic(t *testing.T) {
+	run := func(seed int64) ([]byte, time.Time) {
+		sim := newTestSimulation(t, seed)
+		require.NoError(t, sim.RunToHeight(5, time.Minute))
+		return sim.Digest(), sim.Now()
+	}
+
+	digest, now := run(1)
+	digest2, now2 := run(1)
+	assert.Equal(t, digest, digest2)
+	assert.Equal(t, now, now2)
+
+	digest3, _ := run(2)
+	assert.NotEqual(t, digest, digest3)
+}
+
+func TestSimulationRoundChanges(t *testing.T) {
+	// latencies around the timeouts of the test config make the validators
+	// skip rounds, and time out waiting for the messages of each other
+	sim := newTestSimulation(t, 3, SimulationLatency(0, 60*time.Millisecond))
+	require.NoError(t, sim.RunToHeight(10, time.Minute))
+
+	rounds := 0
+	for h := int64(1); h <= 10; h++ {
+		commit := sim.States()[0].blockStore.LoadBlockCommit(h)
+		if commit == nil {
+			commit = sim.States()[0].blockStore.LoadSeenCommit(h)
+		}
+		require.NotNil(t, commit)
+		if commit.Round > 0 {
+			rounds++
+		}
+	}
+	assert.Positive(t, rounds)
+}
+
+func TestSimulationLatency(t *testing.T) {
+	_, err := NewSimulation(cfg.TestConsensusConfig(), 4, 1,
+		func() abci.Application { return kvstore.NewApplication() },
+		SimulationLatency(time.Second, time.Millisecond))
+	assert.Error(t, err)
+}
@@ -84,7 +84,7 @@ func TestStateProposerSelection0(t *testing.T) {
 	ensureNewProposal(proposalCh, height, round)
 
 	rs := cs1.GetRoundState()
-	signAddVotes(cs1, cmtproto.PrecommitType, rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header(), vss[1:]...)
+	signAddVotes(cs1, cmtproto.PrecommitType, rs.ProposalBlock.Hash(types.CanonicalEncodingProto), rs.ProposalBlockParts.Header(), vss[1:]...)
 
 	// Wait for new round so next validator is set.
 	ensureNewRound(newRoundCh, height+1, 0)
@@ -211,7 +211,7 @@ func TestStateBadProposal(t *testing.T) {
 	propBlock.AppHash = stateHash
 	propBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
-	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	blockID := types.BlockID{Hash: propBlock.Hash(types.CanonicalEncodingProto), PartSetHeader: propBlockParts.Header()}
 	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
 	p := proposal.ToProto()
 	if err := vs2.SignProposal(cs1.state.ChainID, p); err != nil {
@@ -239,7 +239,7 @@ func TestStateBadProposal(t *testing.T) {
 	bps, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
-	signAddVotes(cs1, cmtproto.PrevoteType, propBlock.Hash(), bps.Header(), vs2)
+	signAddVotes(cs1, cmtproto.PrevoteType, propBlock.Hash(types.CanonicalEncodingProto), bps.Header(), vs2)
 	ensurePrevote(voteCh, height, round)
 
 	// wait for precommit
@@ -248,7 +248,7 @@ func TestStateBadProposal(t *testing.T) {
 
 	bps2, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
-	signAddVotes(cs1, cmtproto.PrecommitType, propBlock.Hash(), bps2.Header(), vs2)
+	signAddVotes(cs1, cmtproto.PrecommitType, propBlock.Hash(types.CanonicalEncodingProto), bps2.Header(), vs2)
 }
 
 func TestStateOversizedBlock(t *testing.T) {
@@ -273,7 +273,7 @@ func TestStateOversizedBlock(t *testing.T) {
 
 	propBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
-	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	blockID := types.BlockID{Hash: propBlock.Hash(types.CanonicalEncodingProto), PartSetHeader: propBlockParts.Header()}
 	proposal := types.NewProposal(height, round, -1, blockID)
 	p := proposal.ToProto()
 	if err := vs2.SignProposal(cs1.state.ChainID, p); err != nil {
@@ -308,14 +308,14 @@ func TestStateOversizedBlock(t *testing.T) {
 	bps, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
-	signAddVotes(cs1, cmtproto.PrevoteType, propBlock.Hash(), bps.Header(), vs2)
+	signAddVotes(cs1, cmtproto.PrevoteType, propBlock.Hash(types.CanonicalEncodingProto), bps.Header(), vs2)
 	ensurePrevote(voteCh, height, round)
 	ensurePrecommit(voteCh, height, round)
 	validatePrecommit(t, cs1, round, -1, vss[0], nil, nil)
 
 	bps2, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
-	signAddVotes(cs1, cmtproto.PrecommitType, propBlock.Hash(), bps2.Header(), vs2)
+	signAddVotes(cs1, cmtproto.PrecommitType, propBlock.Hash(types.CanonicalEncodingProto), bps2.Header(), vs2)
 }
 
 //----------------------------------------------------------------------------------------------------
@@ -348,7 +348,7 @@ func TestStateFullRound1(t *testing.T) {
 	ensureNewRound(newRoundCh, height, round)
 
 	ensureNewProposal(propCh, height, round)
-	propBlockHash := cs.GetRoundState().ProposalBlock.Hash()
+	propBlockHash := cs.GetRoundState().ProposalBlock.Hash(types.CanonicalEncodingProto)
 
 	ensurePrevote(voteCh, height, round) // wait for prevote
 	validatePrevote(t, cs, round, vss[0], propBlockHash)
@@ -395,7 +395,7 @@ func TestStateFullRound2(t *testing.T) {
 
 	// we should be stuck in limbo waiting for more prevotes
 	rs := cs1.GetRoundState()
-	propBlockHash, propPartSetHeader := rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header()
+	propBlockHash, propPartSetHeader := rs.ProposalBlock.Hash(types.CanonicalEncodingProto), rs.ProposalBlockParts.Header()
 
 	// prevote arrives from vs2:
 	signAddVotes(cs1, cmtproto.PrevoteType, propBlockHash, propPartSetHeader, vs2)
@@ -445,7 +445,7 @@ func TestStateLockNoPOL(t *testing.T) {
 
 	ensureNewProposal(proposalCh, height, round)
 	roundState := cs1.GetRoundState()
-	theBlockHash := roundState.ProposalBlock.Hash()
+	theBlockHash := roundState.ProposalBlock.Hash(types.CanonicalEncodingProto)
 	thePartSetHeader := roundState.ProposalBlockParts.Header()
 
 	ensurePrevote(voteCh, height, round) // prevote
@@ -492,7 +492,7 @@ func TestStateLockNoPOL(t *testing.T) {
 	// wait to finish prevote
 	ensurePrevote(voteCh, height, round)
 	// we should have prevoted our locked block
-	validatePrevote(t, cs1, round, vss[0], rs.LockedBlock.Hash())
+	validatePrevote(t, cs1, round, vss[0], rs.LockedBlock.Hash(types.CanonicalEncodingProto))
 
 	// add a conflicting prevote from the other validator
 	bps, err := rs.LockedBlock.MakePartSet(partSize)
@@ -533,7 +533,7 @@ func TestStateLockNoPOL(t *testing.T) {
 	rs = cs1.GetRoundState()
 
 	// now we're on a new round and are the proposer
-	if !bytes.Equal(rs.ProposalBlock.Hash(), rs.LockedBlock.Hash()) {
+	if !bytes.Equal(rs.ProposalBlock.Hash(types.CanonicalEncodingProto), rs.LockedBlock.Hash(types.CanonicalEncodingProto)) {
 		panic(fmt.Sprintf(
 			"Expected proposal block to be locked block. Got %v, Expected %v",
 			rs.ProposalBlock,
@@ -541,7 +541,7 @@ func TestStateLockNoPOL(t *testing.T) {
 	}
 
 	ensurePrevote(voteCh, height, round) // prevote
-	validatePrevote(t, cs1, round, vss[0], rs.LockedBlock.Hash())
+	validatePrevote(t, cs1, round, vss[0], rs.LockedBlock.Hash(types.CanonicalEncodingProto))
 
 	bps0, err := rs.ProposalBlock.MakePartSet(partSize)
 	require.NoError(t, err)
@@ -592,13 +592,13 @@ func TestStateLockNoPOL(t *testing.T) {
 	ensureNewProposal(proposalCh, height, round)
 	ensurePrevote(voteCh, height, round) // prevote
 	// prevote for locked block (not proposal)
-	validatePrevote(t, cs1, 3, vss[0], cs1.LockedBlock.Hash())
+	validatePrevote(t, cs1, 3, vss[0], cs1.LockedBlock.Hash(types.CanonicalEncodingProto))
 
 	// prevote for proposed block
 	bps4, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
-	signAddVotes(cs1, cmtproto.PrevoteType, propBlock.Hash(), bps4.Header(), vs2)
+	signAddVotes(cs1, cmtproto.PrevoteType, propBlock.Hash(types.CanonicalEncodingProto), bps4.Header(), vs2)
 	ensurePrevote(voteCh, height, round)
 
 	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Prevote(round).Nanoseconds())
@@ -610,7 +610,7 @@ func TestStateLockNoPOL(t *testing.T) {
 	signAddVotes(
 		cs1,
 		cmtproto.PrecommitType,
-		propBlock.Hash(),
+		propBlock.Hash(types.CanonicalEncodingProto),
 		bps5.Header(),
 		vs2) // NOTE: conflicting precommits at same height
 	ensurePrecommit(voteCh, height, round)
@@ -650,7 +650,7 @@ func TestStateLockPOLRelock(t *testing.T) {
 	ensureNewRound(newRoundCh, height, round)
 	ensureNewProposal(proposalCh, height, round)
 	rs := cs1.GetRoundState()
-	theBlockHash := rs.ProposalBlock.Hash()
+	theBlockHash := rs.ProposalBlock.Hash(types.CanonicalEncodingProto)
 	theBlockParts := rs.ProposalBlockParts.Header()
 
 	ensurePrevote(voteCh, height, round) // prevote
@@ -673,7 +673,7 @@ func TestStateLockPOLRelock(t *testing.T) {
 	propBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
-	propBlockHash := propBlock.Hash()
+	propBlockHash := propBlock.Hash(types.CanonicalEncodingProto)
 	require.NotEqual(t, propBlockHash, theBlockHash)
 
 	incrementRound(vs2, vs3, vs4)
@@ -748,7 +748,7 @@ func TestStateLockPOLUnlock(t *testing.T) {
 
 	ensureNewProposal(proposalCh, height, round)
 	rs := cs1.GetRoundState()
-	theBlockHash := rs.ProposalBlock.Hash()
+	theBlockHash := rs.ProposalBlock.Hash(types.CanonicalEncodingProto)
 	theBlockParts := rs.ProposalBlockParts.Header()
 
 	ensurePrevote(voteCh, height, round)
@@ -772,7 +772,7 @@ func TestStateLockPOLUnlock(t *testing.T) {
 	// timeout to new round
 	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
 	rs = cs1.GetRoundState()
-	lockedBlockHash := rs.LockedBlock.Hash()
+	lockedBlockHash := rs.LockedBlock.Hash(types.CanonicalEncodingProto)
 
 	incrementRound(vs2, vs3, vs4)
 	round++ // moving to the next round
@@ -838,7 +838,7 @@ func TestStateLockPOLUnlockOnUnknownBlock(t *testing.T) {
 	ensureNewRound(newRoundCh, height, round)
 	ensureNewProposal(proposalCh, height, round)
 	rs := cs1.GetRoundState()
-	firstBlockHash := rs.ProposalBlock.Hash()
+	firstBlockHash := rs.ProposalBlock.Hash(types.CanonicalEncodingProto)
 	firstBlockParts := rs.ProposalBlockParts.Header()
 
 	ensurePrevote(voteCh, height, round) // prevote
@@ -861,7 +861,7 @@ func TestStateLockPOLUnlockOnUnknownBlock(t *testing.T) {
 	secondBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
-	secondBlockHash := propBlock.Hash()
+	secondBlockHash := propBlock.Hash(types.CanonicalEncodingProto)
 	require.NotEqual(t, secondBlockHash, firstBlockHash)
 
 	incrementRound(vs2, vs3, vs4)
@@ -906,7 +906,7 @@ func TestStateLockPOLUnlockOnUnknownBlock(t *testing.T) {
 	}
 	thirdPropBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
-	thirdPropBlockHash := propBlock.Hash()
+	thirdPropBlockHash := propBlock.Hash(types.CanonicalEncodingProto)
 	require.NotEqual(t, secondBlockHash, thirdPropBlockHash)
 
 	incrementRound(vs2, vs3, vs4)
@@ -966,15 +966,15 @@ func TestStateLockPOLSafety1(t *testing.T) {
 	propBlock := rs.ProposalBlock
 
 	ensurePrevote(voteCh, height, round)
-	validatePrevote(t, cs1, round, vss[0], propBlock.Hash())
+	validatePrevote(t, cs1, round, vss[0], propBlock.Hash(types.CanonicalEncodingProto))
 
 	// the others sign a polka but we don't see it
 	bps, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
-	prevotes := signVotes(cmtproto.PrevoteType, propBlock.Hash(), bps.Header(), vs2, vs3, vs4)
+	prevotes := signVotes(cmtproto.PrevoteType, propBlock.Hash(types.CanonicalEncodingProto), bps.Header(), vs2, vs3, vs4)
 
-	t.Logf("old prop hash %v", fmt.Sprintf("%X", propBlock.Hash()))
+	t.Logf("old prop hash %v", fmt.Sprintf("%X", propBlock.Hash(types.CanonicalEncodingProto)))
 
 	// we do see them precommit nil
 	signAddVotes(cs1, cmtproto.PrecommitType, nil, types.PartSetHeader{}, vs2, vs3, vs4)
@@ -986,7 +986,7 @@ func TestStateLockPOLSafety1(t *testing.T) {
 	t.Log("### ONTO ROUND 1")
 
 	prop, propBlock := decideProposal(t, cs1, vs2, vs2.Height, vs2.Round+1)
-	propBlockHash := propBlock.Hash()
+	propBlockHash := propBlock.Hash(types.CanonicalEncodingProto)
 	propBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
@@ -1083,7 +1083,7 @@ func TestStateLockPOLSafety2(t *testing.T) {
 	// the block for R0: gets polkad but we miss it
 	// (even though we signed it, shhh)
 	_, propBlock0 := decideProposal(t, cs1, vss[0], height, round)
-	propBlockHash0 := propBlock0.Hash()
+	propBlockHash0 := propBlock0.Hash(types.CanonicalEncodingProto)
 	propBlockParts0, err := propBlock0.MakePartSet(partSize)
 	require.NoError(t, err)
 	propBlockID0 := types.BlockID{Hash: propBlockHash0, PartSetHeader: propBlockParts0.Header()}
@@ -1093,7 +1093,7 @@ func TestStateLockPOLSafety2(t *testing.T) {
 
 	// the block for round 1
 	prop1, propBlock1 := decideProposal(t, cs1, vs2, vs2.Height, vs2.Round+1)
-	propBlockHash1 := propBlock1.Hash()
+	propBlockHash1 := propBlock1.Hash(types.CanonicalEncodingProto)
 	propBlockParts1, err := propBlock1.MakePartSet(partSize)
 	require.NoError(t, err)
 
@@ -1187,7 +1187,7 @@ func TestProposeValidBlock(t *testing.T) {
 	ensureNewProposal(proposalCh, height, round)
 	rs := cs1.GetRoundState()
 	propBlock := rs.ProposalBlock
-	propBlockHash := propBlock.Hash()
+	propBlockHash := propBlock.Hash(types.CanonicalEncodingProto)
 
 	ensurePrevote(voteCh, height, round)
 	validatePrevote(t, cs1, round, vss[0], propBlockHash)
@@ -1247,10 +1247,10 @@ func TestProposeValidBlock(t *testing.T) {
 	ensureNewProposal(proposalCh, height, round)
 
 	rs = cs1.GetRoundState()
-	assert.True(t, bytes.Equal(rs.ProposalBlock.Hash(), propBlockHash))
-	assert.True(t, bytes.Equal(rs.ProposalBlock.Hash(), rs.ValidBlock.Hash()))
+	assert.True(t, bytes.Equal(rs.ProposalBlock.Hash(types.CanonicalEncodingProto), propBlockHash))
+	assert.True(t, bytes.Equal(rs.ProposalBlock.Hash(types.CanonicalEncodingProto), rs.ValidBlock.Hash(types.CanonicalEncodingProto)))
 	assert.True(t, rs.Proposal.POLRound == rs.ValidRound)
-	assert.True(t, bytes.Equal(rs.Proposal.BlockID.Hash, rs.ValidBlock.Hash()))
+	assert.True(t, bytes.Equal(rs.Proposal.BlockID.Hash, rs.ValidBlock.Hash(types.CanonicalEncodingProto)))
 }
 
 // What we want:
@@ -1278,7 +1278,7 @@ func TestSetValidBlockOnDelayedPrevote(t *testing.T) {
 	ensureNewProposal(proposalCh, height, round)
 	rs := cs1.GetRoundState()
 	propBlock := rs.ProposalBlock
-	propBlockHash := propBlock.Hash()
+	propBlockHash := propBlock.Hash(types.CanonicalEncodingProto)
 	propBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
@@ -1310,7 +1310,7 @@ func TestSetValidBlockOnDelayedPrevote(t *testing.T) {
 
 	rs = cs1.GetRoundState()
 
-	assert.True(t, bytes.Equal(rs.ValidBlock.Hash(), propBlockHash))
+	assert.True(t, bytes.Equal(rs.ValidBlock.Hash(types.CanonicalEncodingProto), propBlockHash))
 	assert.True(t, rs.ValidBlockParts.Header().Equals(propBlockParts.Header()))
 	assert.True(t, rs.ValidRound == round)
 }
@@ -1347,7 +1347,7 @@ func TestSetValidBlockOnDelayedProposal(t *testing.T) {
 	validatePrevote(t, cs1, round, vss[0], nil)
 
 	prop, propBlock := decideProposal(t, cs1, vs2, vs2.Height, vs2.Round+1)
-	propBlockHash := propBlock.Hash()
+	propBlockHash := propBlock.Hash(types.CanonicalEncodingProto)
 	propBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
@@ -1367,7 +1367,7 @@ func TestSetValidBlockOnDelayedProposal(t *testing.T) {
 	ensureNewProposal(proposalCh, height, round)
 	rs := cs1.GetRoundState()
 
-	assert.True(t, bytes.Equal(rs.ValidBlock.Hash(), propBlockHash))
+	assert.True(t, bytes.Equal(rs.ValidBlock.Hash(types.CanonicalEncodingProto), propBlockHash))
 	assert.True(t, rs.ValidBlockParts.Header().Equals(propBlockParts.Header()))
 	assert.True(t, rs.ValidRound == round)
 }
@@ -1414,7 +1414,7 @@ func TestProcessProposalAccept(t *testing.T) {
 			rs := cs1.GetRoundState()
 			var prevoteHash cmtbytes.HexBytes
 			if !testCase.expectedNilPrevote {
-				prevoteHash = rs.ProposalBlock.Hash()
+				prevoteHash = rs.ProposalBlock.Hash(types.CanonicalEncodingProto)
 			}
 			ensurePrevoteMatch(t, voteCh, height, round, prevoteHash)
 		})
@@ -1557,7 +1557,7 @@ func TestEmitNewValidBlockEventOnCommitWithoutBlock(t *testing.T) {
 	validBlockCh := subscribe(cs1.eventBus, types.EventQueryValidBlock)
 
 	_, propBlock := decideProposal(t, cs1, vs2, vs2.Height, vs2.Round)
-	propBlockHash := propBlock.Hash()
+	propBlockHash := propBlock.Hash(types.CanonicalEncodingProto)
 	propBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
@@ -1591,7 +1591,7 @@ func TestCommitFromPreviousRound(t *testing.T) {
 	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
 
 	prop, propBlock := decideProposal(t, cs1, vs2, vs2.Height, vs2.Round)
-	propBlockHash := propBlock.Hash()
+	propBlockHash := propBlock.Hash(types.CanonicalEncodingProto)
 	propBlockParts, err := propBlock.MakePartSet(partSize)
 	require.NoError(t, err)
 
@@ -1658,7 +1658,7 @@ func TestStartNextHeightCorrectlyAfterTimeout(t *testing.T) {
 
 	ensureNewProposal(proposalCh, height, round)
 	rs := cs1.GetRoundState()
-	theBlockHash := rs.ProposalBlock.Hash()
+	theBlockHash := rs.ProposalBlock.Hash(types.CanonicalEncodingProto)
 	theBlockParts := rs.ProposalBlockParts.Header()
 
 	ensurePrevote(voteCh, height, round)
@@ -1718,7 +1718,7 @@ func TestResetTimeoutPrecommitUponNewHeight(t *testing.T) {
 
 	ensureNewProposal(proposalCh, height, round)
 	rs := cs1.GetRoundState()
-	theBlockHash := rs.ProposalBlock.Hash()
+	theBlockHash := rs.ProposalBlock.Hash(types.CanonicalEncodingProto)
 	theBlockParts := rs.ProposalBlockParts.Header()
 
 	ensurePrevote(voteCh, height, round)
@@ -1864,17 +1864,17 @@ func TestStateHalt1(t *testing.T) {
 
 	ensurePrevote(voteCh, height, round)
 
-	signAddVotes(cs1, cmtproto.PrevoteType, propBlock.Hash(), propBlockParts.Header(), vs2, vs3, vs4)
+	signAddVotes(cs1, cmtproto.PrevoteType, propBlock.Hash(types.CanonicalEncodingProto), propBlockParts.Header(), vs2, vs3, vs4)
 
 	ensurePrecommit(voteCh, height, round)
 	// the proposed block should now be locked and our precommit added
-	validatePrecommit(t, cs1, round, round, vss[0], propBlock.Hash(), propBlock.Hash())
+	validatePrecommit(t, cs1, round, round, vss[0], propBlock.Hash(types.CanonicalEncodingProto), propBlock.Hash(types.CanonicalEncodingProto))
 
 	// add precommits from the rest
 	signAddVotes(cs1, cmtproto.PrecommitType, nil, types.PartSetHeader{}, vs2) // didnt receive proposal
-	signAddVotes(cs1, cmtproto.PrecommitType, propBlock.Hash(), propBlockParts.Header(), vs3)
+	signAddVotes(cs1, cmtproto.PrecommitType, propBlock.Hash(types.CanonicalEncodingProto), propBlockParts.Header(), vs3)
 	// we receive this later, but vs3 might receive it earlier and with ours will go to commit!
-	precommit4 := signVote(vs4, cmtproto.PrecommitType, propBlock.Hash(), propBlockParts.Header())
+	precommit4 := signVote(vs4, cmtproto.PrecommitType, propBlock.Hash(types.CanonicalEncodingProto), propBlockParts.Header())
 
 	incrementRound(vs2, vs3, vs4)
 
@@ -1894,7 +1894,7 @@ func TestStateHalt1(t *testing.T) {
 
 	// go to prevote, prevote for locked block
 	ensurePrevote(voteCh, height, round)
-	validatePrevote(t, cs1, round, vss[0], rs.LockedBlock.Hash())
+	validatePrevote(t, cs1, round, vss[0], rs.LockedBlock.Hash(types.CanonicalEncodingProto))
 
 	// now we receive the precommit from the previous round
 	addVotes(cs1, precommit4)
@@ -26,7 +26,7 @@ func TestMain(m *testing.M) {
 func TestPeerCatchupRounds(t *testing.T) {
 	valSet, privVals := types.RandValidatorSet(10, 1)
 
-	hvs := NewHeightVoteSet(test.DefaultTestChainID, 1, valSet)
+	hvs := NewHeightVoteSet(test.DefaultTestChainID, types.CanonicalEncodingProto, 1, valSet)
 
 	vote999_0 := makeVoteHR(t, 1, 0, 999, privVals)
 	added, err := hvs.AddVote(vote999_0, "peer1")
@@ -76,7 +76,7 @@ func makeVoteHR(t *testing.T, height int64, valIndex, round int32, privVals []ty
 	}
 
 	v := vote.ToProto()
-	err = privVal.SignVote(test.DefaultTestChainID, v)
+	err = privVal.SignVote(test.DefaultTestChainID, types.CanonicalEncodingProto, v)
 	if err != nil {
 		panic(fmt.Sprintf("Error signing vote: %v", err))
 	}
@@ -113,8 +113,9 @@ type RoundStateSimple struct {
 	Proposer          types.ValidatorInfo `json:"proposer"`
 }
 
-// Compress the RoundState to RoundStateSimple
-func (rs *RoundState) RoundStateSimple() RoundStateSimple {
+// Compress the RoundState to RoundStateSimple, the blocks being hashed with
+// the given encoding.
+func (rs *RoundState) RoundStateSimple(encoding types.CanonicalEncoding) RoundStateSimple {
 	votesJSON, err := rs.Votes.MarshalJSON()
 	if err != nil {
 		panic(err)
@@ -126,9 +127,9 @@ func (rs *RoundState) RoundStateSimple() RoundStateSimple {
 	return RoundStateSimple{
 		HeightRoundStep:   fmt.Sprintf("%d/%d/%d", rs.Height, rs.Round, rs.Step),
 		StartTime:         rs.StartTime,
-		ProposalBlockHash: rs.ProposalBlock.Hash(),
-		LockedBlockHash:   rs.LockedBlock.Hash(),
-		ValidBlockHash:    rs.ValidBlock.Hash(),
+		ProposalBlockHash: rs.ProposalBlock.Hash(encoding),
+		LockedBlockHash:   rs.LockedBlock.Hash(encoding),
+		ValidBlockHash:    rs.ValidBlock.Hash(encoding),
 		Votes:             votesJSON,
 		Proposer: types.ValidatorInfo{
 			Address: addr,
@@ -153,12 +154,13 @@ func (rs *RoundState) NewRoundEvent() types.EventDataNewRound {
 	}
 }
 
-// CompleteProposalEvent returns information about a proposed block as an event.
-func (rs *RoundState) CompleteProposalEvent() types.EventDataCompleteProposal {
+// CompleteProposalEvent returns information about a proposed block, hashed
+// with the given encoding, as an event.
+func (rs *RoundState) CompleteProposalEvent(encoding types.CanonicalEncoding) types.EventDataCompleteProposal {
 	// We must construct BlockID from ProposalBlock and ProposalBlockParts
 	// cs.Proposal is not guaranteed to be set when this function is called
 	blockID := types.BlockID{
-		Hash:          rs.ProposalBlock.Hash(),
+		Hash:          rs.ProposalBlock.Hash(encoding),
 		PartSetHeader: rs.ProposalBlockParts.Header(),
 	}
 
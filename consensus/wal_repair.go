@@ -0,0 +1,180 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/types"
+)
+
+// WALFileCheck is the result of the check of a file of the WAL.
+type WALFileCheck struct {
+	Path    string
+	Size    int64
+	Records int
+	// ValidSize is the size of the prefix of valid records, at which the file
+	// is truncated by RepairWAL.
+	ValidSize int64
+	// Err is the first corruption found, if any.
+	Err error
+}
+
+// Corrupted reports whether the file has a truncated or corrupted record.
+func (fc WALFileCheck) Corrupted() bool {
+	return fc.Err != nil
+}
+
+// WALCheck is the result of the check of the WAL, by CheckWAL or RepairWAL.
+type WALCheck struct {
+	// Files are the files of the WAL, the rotated ones first and the head
+	// last.
+	Files []WALFileCheck
+
+	// LastEndHeight is the height of the last EndHeightMessage, -1 if none.
+	LastEndHeight int64
+	// Height, Round and Step are the last ones recorded by the valid records,
+	// from which consensus recovers; Height is 0 if none.
+	Height int64
+	Round  int32
+	Step   string
+}
+
+// Corrupted reports whether a file of the WAL is corrupted.
+func (c *WALCheck) Corrupted() bool {
+	for _, fc := range c.Files {
+		if fc.Corrupted() {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckWAL scans the files of the WAL with the head at walFile, in order, for
+// truncated or corrupted records.
+func CheckWAL(walFile string) (*WALCheck, error) {
+	paths, err := walFiles(walFile)
+	if err != nil {
+		return nil, err
+	}
+	check := &WALCheck{LastEndHeight: -1}
+	for _, path := range paths {
+		fc, err := check.scanFile(path)
+		if err != nil {
+			return nil, err
+		}
+		check.Files = append(check.Files, fc)
+	}
+	return check, nil
+}
+
+// RepairWAL checks the WAL with the head at walFile, and truncates each
+// corrupted file at its last valid record, once copied to <file>.CORRUPTED.
+// The records past the first corruption of a file are lost.
+func RepairWAL(walFile string) (*WALCheck, error) {
+	check, err := CheckWAL(walFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, fc := range check.Files {
+		if !fc.Corrupted() {
+			continue
+		}
+		if err := cmtos.CopyFile(fc.Path, fc.Path+".CORRUPTED"); err != nil {
+			return nil, fmt.Errorf("backing up %s: %w", fc.Path, err)
+		}
+		if err := os.Truncate(fc.Path, fc.ValidSize); err != nil {
+			return nil, fmt.Errorf("truncating %s: %w", fc.Path, err)
+		}
+	}
+	return check, nil
+}
+
+// scanFile decodes the records of the file at path up to the first error,
+// keeping track of the last height, round and step.
+func (c *WALCheck) scanFile(path string) (WALFileCheck, error) {
+	fc := WALFileCheck{Path: path}
+	f, err := os.Open(path)
+	if err != nil {
+		return fc, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return fc, err
+	}
+	fc.Size = fi.Size()
+
+	cr := &countingReader{rd: f}
+	dec := NewWALDecoder(cr)
+	for {
+		msg, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return fc, nil
+		}
+		if err != nil {
+			fc.Err = err
+			return fc, nil
+		}
+		fc.Records++
+		fc.ValidSize = cr.n
+
+		switch m := msg.Msg.(type) {
+		case EndHeightMessage:
+			c.LastEndHeight = m.Height
+		case types.EventDataRoundState:
+			c.Height, c.Round, c.Step = m.Height, m.Round, m.Step
+		case timeoutInfo:
+			c.Height, c.Round, c.Step = m.Height, m.Round, m.Step.String()
+		}
+	}
+}
+
+// walFiles returns the paths of the files of the WAL with the head at walFile:
+// the rotated ones, <walFile>.NNN, by index, then the head.
+func walFiles(walFile string) ([]string, error) {
+	matches, err := filepath.Glob(walFile + ".*")
+	if err != nil {
+		return nil, err
+	}
+	type indexedPath struct {
+		index int
+		path  string
+	}
+	var rotated []indexedPath
+	for _, path := range matches {
+		index, err := strconv.Atoi(strings.TrimPrefix(path, walFile+"."))
+		if err != nil {
+			continue
+		}
+		rotated = append(rotated, indexedPath{index, path})
+	}
+	sort.Slice(rotated, func(i, j int) bool { return rotated[i].index < rotated[j].index })
+
+	paths := make([]string, 0, len(rotated)+1)
+	for _, r := range rotated {
+		paths = append(paths, r.path)
+	}
+	if !cmtos.FileExists(walFile) {
+		return nil, fmt.Errorf("no WAL at %s", walFile)
+	}
+	return append(paths, walFile), nil
+}
+
+// countingReader counts the bytes read from rd.
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.rd.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
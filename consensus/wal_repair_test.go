@@ -0,0 +1,76 @@
+package consensus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/consensus/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+func writeWALFile(t *testing.T, path string, msgs ...WALMessage) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	enc := NewWALEncoder(f)
+	for _, msg := range msgs {
+		require.NoError(t, enc.Encode(&TimedWALMessage{Time: cmttime.Now(), Msg: msg}))
+	}
+}
+
+func TestRepairWAL(t *testing.T) {
+	walFile := filepath.Join(t.TempDir(), "wal")
+	writeWALFile(t, walFile+".000",
+		EndHeightMessage{0},
+		cmttypes.EventDataRoundState{Height: 1, Round: 0, Step: types.RoundStepNewHeight.String()},
+	)
+	writeWALFile(t, walFile,
+		EndHeightMessage{1},
+		timeoutInfo{Duration: time.Second, Height: 2, Round: 1, Step: types.RoundStepPropose},
+	)
+	fi, err := os.Stat(walFile)
+	require.NoError(t, err)
+	validSize := fi.Size()
+
+	check, err := CheckWAL(walFile)
+	require.NoError(t, err)
+	require.Len(t, check.Files, 2)
+	assert.Equal(t, walFile+".000", check.Files[0].Path)
+	assert.False(t, check.Corrupted())
+	assert.EqualValues(t, 1, check.LastEndHeight)
+	assert.EqualValues(t, 2, check.Height)
+	assert.EqualValues(t, 1, check.Round)
+	assert.Equal(t, types.RoundStepPropose.String(), check.Step)
+
+	// a record cut short by an unclean shutdown
+	f, err := os.OpenFile(walFile, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0x01, 0x02, 0x03, 0x04, 0x00, 0x00, 0x00, 0x10, 0xff})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	check, err = CheckWAL(walFile)
+	require.NoError(t, err)
+	assert.True(t, check.Corrupted())
+	assert.Equal(t, 2, check.Files[1].Records)
+	assert.Equal(t, validSize, check.Files[1].ValidSize)
+	assert.Equal(t, validSize+9, check.Files[1].Size)
+
+	check, err = RepairWAL(walFile)
+	require.NoError(t, err)
+	assert.True(t, check.Corrupted())
+	assert.FileExists(t, walFile+".CORRUPTED")
+
+	check, err = CheckWAL(walFile)
+	require.NoError(t, err)
+	require.Len(t, check.Files, 2)
+	assert.False(t, check.Corrupted())
+	assert.Equal(t, validSize, check.Files[1].Size)
+	assert.EqualValues(t, 2, check.Height)
+}
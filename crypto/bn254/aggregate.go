@@ -0,0 +1,161 @@
+package bn254
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// AggregateSignatures sums the given (uncompressed G2) signatures into a
+// single signature of the same size.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+
+	var agg bn254.G2Jac
+	for i, sig := range sigs {
+		// SetBytes would also take a compressed point, e.g. any 64 bytes
+		if len(sig) != SignatureSize {
+			return nil, fmt.Errorf("invalid signature #%d: %d bytes, expected %d", i, len(sig), SignatureSize)
+		}
+		var p bn254.G2Affine
+		if _, err := p.SetBytes(sig); err != nil {
+			return nil, fmt.Errorf("invalid signature #%d: %w", i, err)
+		}
+		var pj bn254.G2Jac
+		pj.FromAffine(&p)
+		agg.AddAssign(&pj)
+	}
+
+	var res bn254.G2Affine
+	res.FromJacobian(&agg)
+	return res.Marshal(), nil
+}
+
+// VerifyAggregateSignature verifies that sig aggregates the signatures of
+// msgs[i] by pubKeys[i]. The messages need not be distinct: the public keys
+// which signed the same message are summed, so that only one pairing is
+// computed per distinct message.
+func VerifyAggregateSignature(pubKeys []PubKey, msgs [][]byte, sig []byte) bool {
+	decoded := make([]*DecodedPubKey, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		decoded[i] = &DecodedPubKey{PubKey: pubKey}
+		if _, err := decoded[i].point.SetBytes(pubKey[:]); err != nil {
+			return false
+		}
+	}
+	return VerifyAggregateSignatureDecoded(decoded, msgs, sig)
+}
+
+// VerifyAggregateSignatureDecoded is VerifyAggregateSignature, with public
+// keys which are already decoded.
+func VerifyAggregateSignatureDecoded(pubKeys []*DecodedPubKey, msgs [][]byte, sig []byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) {
+		return false
+	}
+
+	var signature bn254.G2Affine
+	if _, err := signature.SetBytes(sig); err != nil {
+		return false
+	}
+
+	var (
+		index = make(map[string]int, len(msgs))
+		keys  []bn254.G1Jac
+		hms   []bn254.G2Affine
+	)
+	for i, msg := range msgs {
+		j, ok := index[string(msg)]
+		if !ok {
+			j = len(keys)
+			index[string(msg)] = j
+			hm, _ := hashedMessage(msg)
+			keys = append(keys, bn254.G1Jac{})
+			hms = append(hms, hm)
+		}
+		var pj bn254.G1Jac
+		pj.FromAffine(&pubKeys[i].point)
+		keys[j].AddAssign(&pj)
+	}
+
+	var G1BaseNeg bn254.G1Affine
+	G1BaseNeg.Neg(&G1Base)
+
+	g1s := make([]bn254.G1Affine, 0, len(keys)+1)
+	g2s := make([]bn254.G2Affine, 0, len(hms)+1)
+	g1s = append(g1s, G1BaseNeg)
+	g2s = append(g2s, signature)
+	for j := range keys {
+		var pk bn254.G1Affine
+		pk.FromJacobian(&keys[j])
+		g1s = append(g1s, pk)
+		g2s = append(g2s, hms[j])
+	}
+
+	valid, err := bn254.PairingCheck(g1s, g2s)
+	if err != nil {
+		return false
+	}
+	return valid
+}
+
+// BatchVerify verifies the signatures sigs[i] of msgs[i] by pubKey with a
+// single pairing check, rather than one per signature. The signatures are
+// combined with random coefficients, so that invalid signatures can't cancel
+// each other out.
+func BatchVerify(pubKey PubKey, msgs [][]byte, sigs [][]byte) bool {
+	decoded := &DecodedPubKey{PubKey: pubKey}
+	if _, err := decoded.point.SetBytes(pubKey[:]); err != nil {
+		return false
+	}
+	return decoded.BatchVerify(msgs, sigs)
+}
+
+// BatchVerify is BatchVerify, with the public key already decoded.
+func (pubKey *DecodedPubKey) BatchVerify(msgs [][]byte, sigs [][]byte) bool {
+	if len(msgs) == 0 || len(msgs) != len(sigs) {
+		return false
+	}
+
+	var sigSum, hmSum bn254.G2Jac
+	for i, msg := range msgs {
+		var sig bn254.G2Affine
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			return false
+		}
+		hm, _ := hashedMessage(msg)
+
+		// the first coefficient is 1, the others are random 128-bit scalars
+		r := big.NewInt(1)
+		if i > 0 {
+			b := make([]byte, 16)
+			if _, err := rand.Read(b); err != nil {
+				return false
+			}
+			r.SetBytes(b)
+		}
+		var sj, hj bn254.G2Jac
+		sj.FromAffine(&sig)
+		sj.ScalarMultiplication(&sj, r)
+		sigSum.AddAssign(&sj)
+		hj.FromAffine(&hm)
+		hj.ScalarMultiplication(&hj, r)
+		hmSum.AddAssign(&hj)
+	}
+
+	var G1BaseNeg bn254.G1Affine
+	G1BaseNeg.Neg(&G1Base)
+	var signature, hashed bn254.G2Affine
+	signature.FromJacobian(&sigSum)
+	hashed.FromJacobian(&hmSum)
+
+	valid, err := bn254.PairingCheck([]bn254.G1Affine{G1BaseNeg, pubKey.point}, []bn254.G2Affine{signature, hashed})
+	if err != nil {
+		return false
+	}
+	return valid
+}
@@ -0,0 +1,119 @@
+package bn254
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateSignatures(t *testing.T) {
+	var (
+		pubKeys []PubKey
+		msgs    [][]byte
+		sigs    [][]byte
+	)
+	for i, msg := range []string{"a", "b", "a"} {
+		privKey := GenPrivKey()
+		sig, err := privKey.Sign([]byte(msg))
+		require.NoError(t, err)
+		require.True(t, privKey.PubKey().VerifySignature([]byte(msg), sig), i)
+
+		pubKeys = append(pubKeys, privKey.PubKey().(PubKey))
+		msgs = append(msgs, []byte(msg))
+		sigs = append(sigs, sig)
+	}
+
+	agg, err := AggregateSignatures(sigs)
+	require.NoError(t, err)
+	assert.Len(t, agg, len(sigs[0]))
+	assert.True(t, VerifyAggregateSignature(pubKeys, msgs, agg))
+
+	// a single signature aggregates to itself
+	single, err := AggregateSignatures(sigs[:1])
+	require.NoError(t, err)
+	assert.Equal(t, sigs[0], single)
+
+	// wrong message
+	assert.False(t, VerifyAggregateSignature(pubKeys, [][]byte{msgs[0], msgs[0], msgs[2]}, agg))
+	// missing signer
+	assert.False(t, VerifyAggregateSignature(pubKeys[:2], msgs[:2], agg))
+	// mismatched lengths
+	assert.False(t, VerifyAggregateSignature(pubKeys, msgs[:2], agg))
+
+	_, err = AggregateSignatures(nil)
+	assert.Error(t, err)
+	_, err = AggregateSignatures([][]byte{{0x01}})
+	assert.Error(t, err)
+	// only uncompressed signatures are aggregated
+	var compressed bn254.G2Affine
+	_, err = compressed.SetBytes(sigs[0])
+	require.NoError(t, err)
+	c := compressed.Bytes()
+	_, err = AggregateSignatures([][]byte{c[:]})
+	assert.Error(t, err)
+}
+
+func TestBatchVerify(t *testing.T) {
+	privKey := GenPrivKey()
+	pubKey := privKey.PubKey().(PubKey)
+	msgs := [][]byte{[]byte("a"), []byte("b")}
+	var sigs [][]byte
+	for _, msg := range msgs {
+		sig, err := privKey.Sign(msg)
+		require.NoError(t, err)
+		sigs = append(sigs, sig)
+	}
+	assert.True(t, BatchVerify(pubKey, msgs, sigs))
+
+	// swapped signatures
+	assert.False(t, BatchVerify(pubKey, msgs, [][]byte{sigs[1], sigs[0]}))
+	// another signer
+	assert.False(t, BatchVerify(GenPrivKey().PubKey().(PubKey), msgs, sigs))
+	// signatures offsetting each other, whose sum is valid
+	var a, b, d bn254.G2Affine
+	_, err := a.SetBytes(sigs[0])
+	require.NoError(t, err)
+	_, err = b.SetBytes(sigs[1])
+	require.NoError(t, err)
+	d.Add(&G2Base, &G2Base)
+	a.Add(&a, &d)
+	b.Sub(&b, &d)
+	assert.False(t, BatchVerify(pubKey, msgs, [][]byte{a.Marshal(), b.Marshal()}))
+	// mismatched lengths
+	assert.False(t, BatchVerify(pubKey, msgs, sigs[:1]))
+}
+
+func TestDecodedPubKey(t *testing.T) {
+	privKey := GenPrivKey()
+	pubKey := privKey.PubKey().(PubKey)
+	msg := []byte("msg")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+
+	decoded, err := pubKey.Decode()
+	require.NoError(t, err)
+	assert.True(t, decoded.VerifySignature(msg, sig))
+	assert.False(t, decoded.VerifySignature([]byte("other"), sig))
+	assert.Equal(t, pubKey.Address(), decoded.Address())
+	assert.True(t, decoded.Equals(pubKey))
+	assert.True(t, decoded.Equals(decoded))
+	assert.False(t, decoded.Equals(GenPrivKey().PubKey()))
+
+	d, ok := Decoded(pubKey)
+	require.True(t, ok)
+	assert.True(t, d.BatchVerify([][]byte{msg}, [][]byte{sig}))
+	assert.True(t, VerifyAggregateSignatureDecoded([]*DecodedPubKey{d}, [][]byte{msg}, sig))
+	d2, ok := Decoded(decoded)
+	require.True(t, ok)
+	assert.Same(t, decoded, d2)
+
+	// not a point of the curve
+	var invalid PubKey
+	invalid[0] = 0xff
+	_, err = invalid.Decode()
+	assert.Error(t, err)
+	_, ok = Decoded(invalid)
+	assert.False(t, ok)
+}
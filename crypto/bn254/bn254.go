@@ -25,6 +25,8 @@ const (
 	KeyType     = "bn254"
 	PubKeySize  = bn254.SizeOfG1AffineCompressed
 	PrivKeySize = sizePrivateKey
+	// SignatureSize is the size of a signature, an uncompressed G2 point.
+	SignatureSize  = bn254.SizeOfG2AffineUncompressed
 	sizeFr         = fr.Bytes
 	sizeFp         = fp.Bytes
 	sizePublicKey  = sizeFp
@@ -81,13 +83,11 @@ func (pubKey PubKey) Address() crypto.Address {
 	return crypto.AddressHash(pubKey[:])
 }
 
-func (pubKey PubKey) SetBytes(buf []byte) error {
+func (pubKey *PubKey) SetBytes(buf []byte) error {
 	if len(buf) != PubKeySize {
 		return fmt.Errorf("Unexpected public key size")
 	}
-	for i := 0; i < PubKeySize; i++ {
-		pubKey[i] = buf[i]
-	}
+	copy(pubKey[:], buf)
 	return nil
 }
 // Bytes returns the PubKey byte format.
@@ -96,15 +96,18 @@ func (pubKey PubKey) Bytes() []byte {
 }
 
 func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
-	hashedMessage, _ := hashedMessage(msg)
 	var public bn254.G1Affine
 	_, err := public.SetBytes(pubKey[:])
 	if err != nil {
 		return false
 	}
+	return verifySignature(&public, msg, sig)
+}
 
+func verifySignature(public *bn254.G1Affine, msg []byte, sig []byte) bool {
+	hashedMessage, _ := hashedMessage(msg)
 	var signature bn254.G2Affine
-	_, err = signature.SetBytes(sig)
+	_, err := signature.SetBytes(sig)
 	if err != nil {
 		return false
 	}
@@ -112,13 +115,22 @@ func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
 	var G1BaseNeg bn254.G1Affine
 	G1BaseNeg.Neg(&G1Base)
 
-	valid, err := bn254.PairingCheck([]bn254.G1Affine{G1BaseNeg, public}, []bn254.G2Affine{signature, hashedMessage})
+	valid, err := bn254.PairingCheck([]bn254.G1Affine{G1BaseNeg, *public}, []bn254.G2Affine{signature, hashedMessage})
 	if err != nil {
 		return false
 	}
 	return valid
 }
 
+// Coordinates returns the big-endian affine coordinates of the public key.
+func (pubKey PubKey) Coordinates() (x, y [sizeFp]byte, err error) {
+	var public bn254.G1Affine
+	if _, err = public.SetBytes(pubKey[:]); err != nil {
+		return x, y, err
+	}
+	return public.X.Bytes(), public.Y.Bytes(), nil
+}
+
 func (pubKey PubKey) String() string {
 	return fmt.Sprintf("PubKeyBn254{%X}", []byte(pubKey[:]))
 }
@@ -134,6 +146,52 @@ func (pubKey PubKey) Equals(other crypto.PubKey) bool {
 	return false
 }
 
+// Decode implements crypto.PubKeyDecoder, returning a *DecodedPubKey.
+func (pubKey PubKey) Decode() (crypto.PubKey, error) {
+	decoded := &DecodedPubKey{PubKey: pubKey}
+	if _, err := decoded.point.SetBytes(pubKey[:]); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+var _ crypto.PubKey = (*DecodedPubKey)(nil)
+
+// DecodedPubKey is a PubKey whose point is decompressed, and checked to be on
+// the curve and in the subgroup, once for all the signatures it verifies.
+type DecodedPubKey struct {
+	PubKey
+	point bn254.G1Affine
+}
+
+// Decoded returns pubKey decoded, if it is a valid bn254 public key, decoded
+// or not.
+func Decoded(pubKey crypto.PubKey) (*DecodedPubKey, bool) {
+	switch pubKey := pubKey.(type) {
+	case *DecodedPubKey:
+		return pubKey, true
+	case PubKey:
+		decoded, err := pubKey.Decode()
+		if err != nil {
+			return nil, false
+		}
+		return decoded.(*DecodedPubKey), true
+	default:
+		return nil, false
+	}
+}
+
+func (pubKey *DecodedPubKey) VerifySignature(msg []byte, sig []byte) bool {
+	return verifySignature(&pubKey.point, msg, sig)
+}
+
+func (pubKey *DecodedPubKey) Equals(other crypto.PubKey) bool {
+	if decoded, ok := other.(*DecodedPubKey); ok {
+		other = decoded.PubKey
+	}
+	return pubKey.PubKey.Equals(other)
+}
+
 func GenPrivKey() PrivKey {
 	secret, err := bls254.GenerateKey(rand.Reader)
 	if err != nil {
@@ -142,6 +200,19 @@ func GenPrivKey() PrivKey {
 	return PrivKey(secret.Bytes())
 }
 
+// GenPrivKeyFromSecret deterministically derives a private key from secret.
+// NOTE: secret should be the output of a KDF like bcrypt, if it's derived from
+// user input.
+func GenPrivKeyFromSecret(secret []byte) PrivKey {
+	h := sha3.NewShake256()
+	h.Write(secret)
+	key, err := bls254.GenerateKey(h)
+	if err != nil {
+		panic(err)
+	}
+	return PrivKey(key.Bytes())
+}
+
 var G1Base bn254.G1Affine
 var G2Base bn254.G2Affine
 
@@ -150,17 +221,48 @@ var Hash = sha3.NewLegacyKeccak256
 func init() {
 	cmtjson.RegisterType(PubKey{}, PubKeyName)
 	cmtjson.RegisterType(PrivKey{}, PrivKeyName)
+	cmtjson.RegisterFastPath(PubKey{},
+		func(w *cmtjson.Writer, v interface{}) { w.Bytes(v.(*PubKey)[:]) },
+		func(bz []byte, v interface{}) error {
+			pubKey, err := cmtjson.DecodeBytes(bz)
+			if err != nil {
+				return err
+			}
+			if len(pubKey) != PubKeySize {
+				return fmt.Errorf("got %v bytes, expected %v", len(pubKey), PubKeySize)
+			}
+			copy(v.(*PubKey)[:], pubKey)
+			return nil
+		})
 
 	_, _, G1Base, G2Base = bn254.Generators()
 }
 
+// HashToCurveDST is the domain separation tag of the hashing of the messages
+// to G2, the try-and-increment over keccak256 of hashedMessage. Peers hashing
+// to the curve otherwise can't verify each other's signatures.
+const HashToCurveDST = "CometBLS_BN254G2_KECCAK256_TAI_"
+
+// HashToCurveNonce returns the nonce with which msg is hashed to G2.
+func HashToCurveNonce(msg []byte) uint32 {
+	_, nonce := hashedMessage(msg)
+	return nonce
+}
+
+// HashToCurve returns the G2 point to which msg is hashed before it is
+// signed, and the nonce with which it is.
+func HashToCurve(msg []byte) (bn254.G2Affine, uint32) {
+	return hashedMessage(msg)
+}
+
 /* Loop until we find a valid G2 point derived from:
-   X0=uint256(keccak256(msg || i)))
-   X1=uint256(keccak256(i || msg)))
+   X0=uint256(keccak256(i || msg))) mod p
+   X1=uint256(keccak256(msg || i))) mod p
 
-   Y0,Y1=Decompress(X0, X1)
+   Y=sqrt(X^3 + b') with X=(X0, X1), then the cofactor of (X, Y) is cleared so
+   that the point lands in the r-torsion subgroup.
 
-   Point is then recoverable from the tuple (msg, i, Y0, Y1)
+   Point is then recoverable from the tuple (msg, i)
 
 TODO: performance
 */
@@ -169,20 +271,30 @@ func hashedMessage(msg []byte) (bn254.G2Affine, uint32) {
 	var i = uint32(0)
 	b := make([]byte, 4)
 	h := Hash()
-	for {
+	for ; ; i++ {
 		binary.BigEndian.PutUint32(b, i)
 		h.Reset()
 		h.Write(b)
 		h.Write(msg)
-		X0 := h.Sum(nil)
+		point.X.A0.SetBytes(h.Sum(nil))
 		h.Reset()
 		h.Write(msg)
 		h.Write(b)
-		X1 := h.Sum(nil)
-		_, err := point.SetBytes(append(X0, X1...))
-		if err != nil || !point.IsOnCurve() {
-			fmt.Printf("Invalid point at %d\n", i)
-			i++
+		point.X.A1.SetBytes(h.Sum(nil))
+
+		// y² = x³ + b'
+		rhs := point.X
+		rhs.Square(&point.X).Mul(&rhs, &point.X)
+		twistB := point.X
+		twistB.SetOne().MulBybTwistCurveCoeff(&twistB)
+		rhs.Add(&rhs, &twistB)
+		if rhs.Legendre() != 1 {
+			continue
+		}
+		point.Y.Sqrt(&rhs)
+
+		point.ClearCofactor(&point)
+		if point.IsInfinity() {
 			continue
 		}
 		break
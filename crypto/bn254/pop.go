@@ -0,0 +1,23 @@
+package bn254
+
+// popDomain prefixes the message of a proof of possession, so that it can't be
+// mistaken for a signature of a consensus message.
+var popDomain = []byte("BN254_POP_")
+
+func popMessage(pubKey PubKey) []byte {
+	return append(append([]byte(nil), popDomain...), pubKey[:]...)
+}
+
+// ProvePossession returns a proof that privKey is held by the owner of its
+// public key: its signature of the public key. The aggregated signatures of
+// keys registered without such a proof are open to rogue key attacks, where a
+// key derived from the others forges the signature of the whole set.
+func (privKey PrivKey) ProvePossession() ([]byte, error) {
+	return privKey.Sign(popMessage(privKey.PubKey().(PubKey)))
+}
+
+// VerifyPossession verifies proof is a proof of possession of the private key
+// of pubKey.
+func (pubKey PubKey) VerifyPossession(proof []byte) bool {
+	return pubKey.VerifySignature(popMessage(pubKey), proof)
+}
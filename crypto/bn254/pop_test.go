@@ -0,0 +1,36 @@
+package bn254
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofOfPossession(t *testing.T) {
+	privKey := GenPrivKey()
+	pubKey := privKey.PubKey().(PubKey)
+
+	proof, err := privKey.ProvePossession()
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifyPossession(proof))
+
+	// the proof is bound to the key
+	other := GenPrivKey().PubKey().(PubKey)
+	assert.False(t, other.VerifyPossession(proof))
+
+	// and is not a signature of the public key alone
+	sig, err := privKey.Sign(pubKey[:])
+	require.NoError(t, err)
+	assert.False(t, pubKey.VerifyPossession(sig))
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	privKey := GenPrivKeyFromSecret([]byte("secret"))
+	assert.Equal(t, privKey, GenPrivKeyFromSecret([]byte("secret")))
+	assert.NotEqual(t, privKey, GenPrivKeyFromSecret([]byte("other secret")))
+
+	sig, err := privKey.Sign([]byte("msg"))
+	require.NoError(t, err)
+	assert.True(t, privKey.PubKey().VerifySignature([]byte("msg"), sig))
+}
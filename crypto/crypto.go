@@ -27,6 +27,15 @@ type PubKey interface {
 	Type() string
 }
 
+// PubKeyDecoder is implemented by the public keys which can be decoded ahead
+// of the verification of their signatures, e.g. whose curve point can be
+// decompressed once for all, rather than for each signature.
+type PubKeyDecoder interface {
+	// Decode returns the decoded public key, which verifies the same
+	// signatures faster. It returns an error if the key is invalid.
+	Decode() (PubKey, error)
+}
+
 type PrivKey interface {
 	Bytes() []byte
 	Sign(msg []byte) ([]byte, error)
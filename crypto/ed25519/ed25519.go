@@ -59,6 +59,13 @@ const (
 func init() {
 	cmtjson.RegisterType(PubKey{}, PubKeyName)
 	cmtjson.RegisterType(PrivKey{}, PrivKeyName)
+	cmtjson.RegisterFastPath(PubKey{},
+		func(w *cmtjson.Writer, v interface{}) { w.Bytes(*v.(*PubKey)) },
+		func(bz []byte, v interface{}) error {
+			pubKey, err := cmtjson.DecodeBytes(bz)
+			*v.(*PubKey) = pubKey
+			return err
+		})
 }
 
 // PrivKey implements crypto.PrivKey.
@@ -190,6 +197,42 @@ func (pubKey PubKey) Equals(other crypto.PubKey) bool {
 	return false
 }
 
+// Decode implements crypto.PubKeyDecoder, returning a *DecodedPubKey.
+func (pubKey PubKey) Decode() (crypto.PubKey, error) {
+	if len(pubKey) != PubKeySize {
+		return nil, fmt.Errorf("pubkey size is incorrect; expected: %d, got %d", PubKeySize, len(pubKey))
+	}
+	expanded, err := ed25519.NewExpandedPublicKey(ed25519.PublicKey(pubKey))
+	if err != nil {
+		return nil, err
+	}
+	return &DecodedPubKey{PubKey: pubKey, expanded: expanded}, nil
+}
+
+var _ crypto.PubKey = (*DecodedPubKey)(nil)
+
+// DecodedPubKey is a PubKey whose point is decompressed and expanded, once for
+// all the signatures it verifies, without going through the cache of the
+// expanded keys.
+type DecodedPubKey struct {
+	PubKey
+	expanded *ed25519.ExpandedPublicKey
+}
+
+func (pubKey *DecodedPubKey) VerifySignature(msg []byte, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+	return ed25519.VerifyExpandedWithOptions(pubKey.expanded, msg, sig, verifyOptions)
+}
+
+func (pubKey *DecodedPubKey) Equals(other crypto.PubKey) bool {
+	if decoded, ok := other.(*DecodedPubKey); ok {
+		other = decoded.PubKey
+	}
+	return pubKey.PubKey.Equals(other)
+}
+
 //-------------------------------------
 
 // BatchVerifier implements batch verification for ed25519.
@@ -202,6 +245,14 @@ func NewBatchVerifier() crypto.BatchVerifier {
 }
 
 func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	if decoded, ok := key.(*DecodedPubKey); ok {
+		if len(signature) != SignatureSize {
+			return errors.New("invalid signature")
+		}
+		b.AddExpandedWithOptions(decoded.expanded, msg, signature, verifyOptions)
+		return nil
+	}
+
 	pkEd, ok := key.(PubKey)
 	if !ok {
 		return fmt.Errorf("pubkey is not Ed25519")
@@ -52,3 +52,29 @@ func TestBatchSafe(t *testing.T) {
 	ok, _ := v.Verify()
 	require.True(t, ok)
 }
+
+func TestDecodedPubKey(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	pubKey := privKey.PubKey().(ed25519.PubKey)
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+
+	decoded, err := pubKey.Decode()
+	require.NoError(t, err)
+	assert.True(t, decoded.VerifySignature(msg, sig))
+	assert.True(t, decoded.Equals(pubKey))
+	assert.Equal(t, pubKey.Address(), decoded.Address())
+
+	v := ed25519.NewBatchVerifier()
+	require.NoError(t, v.Add(decoded, msg, sig))
+	require.NoError(t, v.Add(pubKey, msg, sig))
+	ok, _ := v.Verify()
+	assert.True(t, ok)
+
+	sig[7] ^= byte(0x01)
+	assert.False(t, decoded.VerifySignature(msg, sig))
+
+	_, err = ed25519.PubKey([]byte("short")).Decode()
+	assert.Error(t, err)
+}
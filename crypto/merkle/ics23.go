@@ -0,0 +1,137 @@
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+)
+
+// ICS23ProofSpec returns the ICS-23 spec of the proofs returned by
+// ICS23ExistenceProof, i.e. of the RFC 6962 trees built by HashFromByteSlices.
+//
+// ICS-23 leaves are made of a non-empty key and value, which are hashed
+// together, while the leaves of these trees are made of a single item. The
+// first byte of the item is thus the key of the leaf, and the rest its value,
+// so that the leaf hash is SHA-256(0x00 || key || value).
+func ICS23ProofSpec() *cmtcrypto.ProofSpec {
+	return &cmtcrypto.ProofSpec{
+		LeafSpec: &cmtcrypto.LeafOp{
+			Hash:         cmtcrypto.HashOp_SHA256,
+			PrehashKey:   cmtcrypto.HashOp_NO_HASH,
+			PrehashValue: cmtcrypto.HashOp_NO_HASH,
+			Length:       cmtcrypto.LengthOp_NO_PREFIX,
+			Prefix:       append([]byte(nil), leafPrefix...),
+		},
+		InnerSpec: &cmtcrypto.InnerSpec{
+			ChildOrder:      []int32{0, 1},
+			ChildSize:       tmhash.Size,
+			MinPrefixLength: int32(len(innerPrefix)),
+			MaxPrefixLength: int32(len(innerPrefix)),
+			Hash:            cmtcrypto.HashOp_SHA256,
+		},
+		MaxDepth: MaxAunts,
+	}
+}
+
+// ICS23ExistenceProof returns the proof that leaf is in the tree, in the ICS-23
+// format described by ICS23ProofSpec. The leaf must be at least 2 bytes long.
+func (sp *Proof) ICS23ExistenceProof(leaf []byte) (*cmtcrypto.ExistenceProof, error) {
+	if len(leaf) < 2 {
+		return nil, fmt.Errorf("expected a leaf of at least 2 bytes, got %d", len(leaf))
+	}
+	if !bytes.Equal(sp.LeafHash, leafHash(leaf)) {
+		return nil, errors.New("leaf does not match the leaf hash of the proof")
+	}
+	path, err := sp.ICS23InnerOps()
+	if err != nil {
+		return nil, err
+	}
+	spec := ICS23ProofSpec()
+	return &cmtcrypto.ExistenceProof{
+		Key:   leaf[:1],
+		Value: leaf[1:],
+		Leaf:  spec.LeafSpec,
+		Path:  path,
+	}, nil
+}
+
+// ICS23InnerOps returns the path from the leaf to the root as ICS-23 inner
+// ops, i.e. the aunts prefixed with 0x01 if they are left children, or as
+// suffixes if they are right ones.
+func (sp *Proof) ICS23InnerOps() ([]*cmtcrypto.InnerOp, error) {
+	if err := sp.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if sp.Index >= sp.Total {
+		return nil, fmt.Errorf("index %d out of range for a total of %d", sp.Index, sp.Total)
+	}
+
+	// the aunts go from the leaf to the root, while the tree is split from the
+	// root to the leaf
+	path := make([]*cmtcrypto.InnerOp, len(sp.Aunts))
+	aunts := sp.Aunts
+	index, total := sp.Index, sp.Total
+	for total > 1 {
+		if len(aunts) == 0 {
+			return nil, fmt.Errorf("expected more than %d aunts for a total of %d", len(sp.Aunts), sp.Total)
+		}
+		aunt := aunts[len(aunts)-1]
+		aunts = aunts[:len(aunts)-1]
+
+		op := &cmtcrypto.InnerOp{Hash: cmtcrypto.HashOp_SHA256}
+		numLeft := getSplitPoint(total)
+		if index < numLeft {
+			op.Prefix = append([]byte(nil), innerPrefix...)
+			op.Suffix = aunt
+			total = numLeft
+		} else {
+			op.Prefix = append(append(make([]byte, 0, len(innerPrefix)+len(aunt)), innerPrefix...), aunt...)
+			index -= numLeft
+			total -= numLeft
+		}
+		path[len(aunts)] = op
+	}
+	if len(aunts) != 0 {
+		return nil, fmt.Errorf("expected %d aunts for a total of %d, got %d",
+			len(sp.Aunts)-len(aunts), sp.Total, len(sp.Aunts))
+	}
+	return path, nil
+}
+
+// ICS23Root returns the root hash proven by an existence proof in the format
+// described by ICS23ProofSpec. It returns an error if the proof is in another
+// format.
+func ICS23Root(proof *cmtcrypto.ExistenceProof) ([]byte, error) {
+	leaf, spec := proof.Leaf, ICS23ProofSpec().LeafSpec
+	if leaf == nil || leaf.Hash != spec.Hash || leaf.PrehashKey != spec.PrehashKey ||
+		leaf.PrehashValue != spec.PrehashValue || leaf.Length != spec.Length || !bytes.Equal(leaf.Prefix, spec.Prefix) {
+		return nil, errors.New("unexpected leaf op")
+	}
+	if len(proof.Key) == 0 || len(proof.Value) == 0 {
+		return nil, errors.New("empty key or value")
+	}
+	if len(proof.Path) > MaxAunts {
+		return nil, fmt.Errorf("expected no more than %d inner ops, got %d", MaxAunts, len(proof.Path))
+	}
+
+	hash := leafHash(append(append([]byte(nil), proof.Key...), proof.Value...))
+	for i, op := range proof.Path {
+		switch {
+		case op.Hash != cmtcrypto.HashOp_SHA256:
+			return nil, fmt.Errorf("inner op #%d: unexpected hash op %v", i, op.Hash)
+		case len(op.Prefix) == len(innerPrefix) && len(op.Suffix) == tmhash.Size:
+			hash = innerHash(hash, op.Suffix)
+		case len(op.Prefix) == len(innerPrefix)+tmhash.Size && len(op.Suffix) == 0:
+			hash = innerHash(op.Prefix[len(innerPrefix):], hash)
+		default:
+			return nil, fmt.Errorf("inner op #%d: unexpected prefix and suffix lengths", i)
+		}
+		if !bytes.Equal(op.Prefix[:len(innerPrefix)], innerPrefix) {
+			return nil, fmt.Errorf("inner op #%d: unexpected prefix", i)
+		}
+	}
+	return hash, nil
+}
@@ -0,0 +1,118 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+)
+
+// ics23Root computes the root of an ICS-23 existence proof, as an ICS-23
+// verifier would, checking it against the spec.
+func ics23Root(t *testing.T, spec *cmtcrypto.ProofSpec, proof *cmtcrypto.ExistenceProof) []byte {
+	require.Equal(t, spec.LeafSpec, proof.Leaf)
+	require.NotEmpty(t, proof.Key)
+	require.NotEmpty(t, proof.Value)
+	require.LessOrEqual(t, len(proof.Path), int(spec.MaxDepth))
+
+	h := sha256.New()
+	h.Write(proof.Leaf.Prefix)
+	h.Write(proof.Key)
+	h.Write(proof.Value)
+	hash := h.Sum(nil)
+	for _, op := range proof.Path {
+		require.Equal(t, spec.InnerSpec.Hash, op.Hash)
+		require.GreaterOrEqual(t, len(op.Prefix), int(spec.InnerSpec.MinPrefixLength))
+		require.LessOrEqual(t, len(op.Prefix), int(spec.InnerSpec.MaxPrefixLength+spec.InnerSpec.ChildSize))
+		require.Zero(t, len(op.Suffix)%int(spec.InnerSpec.ChildSize))
+		require.NotEqual(t, spec.LeafSpec.Prefix, op.Prefix[:len(spec.LeafSpec.Prefix)])
+
+		h.Reset()
+		h.Write(op.Prefix)
+		h.Write(hash)
+		h.Write(op.Suffix)
+		hash = h.Sum(nil)
+	}
+	return hash
+}
+
+func TestProofICS23(t *testing.T) {
+	spec := ICS23ProofSpec()
+	for total := 1; total <= 20; total++ {
+		t.Run(fmt.Sprintf("total=%d", total), func(t *testing.T) {
+			items := make([][]byte, total)
+			for i := range items {
+				items[i] = cmtrand.Bytes(32)
+			}
+			rootHash, proofs := ProofsFromByteSlices(items)
+			for i, proof := range proofs {
+				ep, err := proof.ICS23ExistenceProof(items[i])
+				require.NoError(t, err)
+				assert.Len(t, ep.Path, len(proof.Aunts))
+				assert.Equal(t, rootHash, ics23Root(t, spec, ep), "item %d", i)
+				root, err := ICS23Root(ep)
+				require.NoError(t, err)
+				assert.Equal(t, rootHash, root, "item %d", i)
+			}
+		})
+	}
+}
+
+func TestProofICS23Invalid(t *testing.T) {
+	items := [][]byte{{1, 2}, {3, 4}, {5, 6}}
+	_, proofs := ProofsFromByteSlices(items)
+
+	_, err := proofs[0].ICS23ExistenceProof(items[1])
+	assert.Error(t, err)
+	_, err = proofs[0].ICS23ExistenceProof([]byte{1})
+	assert.Error(t, err)
+
+	proof := *proofs[0]
+	proof.Aunts = proof.Aunts[1:]
+	_, err = proof.ICS23InnerOps()
+	assert.Error(t, err)
+
+	proof = *proofs[2]
+	proof.Aunts = append(proof.Aunts, proof.Aunts[0])
+	_, err = proof.ICS23InnerOps()
+	assert.Error(t, err)
+
+	proof = *proofs[2]
+	proof.Index = 3
+	_, err = proof.ICS23InnerOps()
+	assert.Error(t, err)
+}
+
+func TestICS23RootInvalid(t *testing.T) {
+	items := [][]byte{{1, 2}, {3, 4}, {5, 6}}
+	_, proofs := ProofsFromByteSlices(items)
+	valid, err := proofs[2].ICS23ExistenceProof(items[2])
+	require.NoError(t, err)
+
+	testCases := map[string]func(ep *cmtcrypto.ExistenceProof){
+		"no leaf op":         func(ep *cmtcrypto.ExistenceProof) { ep.Leaf = nil },
+		"prehashed value":    func(ep *cmtcrypto.ExistenceProof) { ep.Leaf.PrehashValue = cmtcrypto.HashOp_SHA256 },
+		"empty key":          func(ep *cmtcrypto.ExistenceProof) { ep.Key = nil },
+		"keccak inner op":    func(ep *cmtcrypto.ExistenceProof) { ep.Path[0].Hash = cmtcrypto.HashOp_KECCAK256 },
+		"wrong inner prefix": func(ep *cmtcrypto.ExistenceProof) { ep.Path[0].Prefix[0] = 0 },
+		"both siblings": func(ep *cmtcrypto.ExistenceProof) {
+			ep.Path[0].Suffix = ep.Path[0].Prefix[1:]
+		},
+	}
+	for name, malleate := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ep := &cmtcrypto.ExistenceProof{}
+			bz, err := valid.Marshal()
+			require.NoError(t, err)
+			require.NoError(t, ep.Unmarshal(bz))
+			malleate(ep)
+			_, err = ICS23Root(ep)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -28,6 +28,13 @@ const (
 func init() {
 	cmtjson.RegisterType(PubKey{}, PubKeyName)
 	cmtjson.RegisterType(PrivKey{}, PrivKeyName)
+	cmtjson.RegisterFastPath(PubKey{},
+		func(w *cmtjson.Writer, v interface{}) { w.Bytes(*v.(*PubKey)) },
+		func(bz []byte, v interface{}) error {
+			pubKey, err := cmtjson.DecodeBytes(bz)
+			*v.(*PubKey) = pubKey
+			return err
+		})
 }
 
 var _ crypto.PrivKey = PrivKey{}
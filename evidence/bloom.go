@@ -0,0 +1,93 @@
+package evidence
+
+import (
+	"encoding/binary"
+	"math"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// bloomFilter is a set of evidence hashes, with false positives but no false
+// negatives, of a bounded size. Once capacity hashes were added, a new
+// generation of the filter is started and the oldest one dropped, so that the
+// last capacity hashes at least are always found.
+type bloomFilter struct {
+	mtx cmtsync.Mutex
+
+	numBits   uint64
+	numHashes int
+	capacity  int
+
+	current, previous []uint64
+	count             int
+}
+
+// newBloomFilter returns a filter of capacity hashes per generation, with a
+// false positive rate of fpRate.
+func newBloomFilter(capacity int, fpRate float64) *bloomFilter {
+	// m = -n ln(p) / (ln 2)^2 and k = m/n ln 2 minimize the false positives.
+	numBits := uint64(math.Ceil(-float64(capacity) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	numBits = (numBits + 63) / 64 * 64
+	numHashes := int(math.Round(float64(numBits) / float64(capacity) * math.Ln2))
+	if numHashes < 1 {
+		numHashes = 1
+	}
+	return &bloomFilter{
+		numBits:   numBits,
+		numHashes: numHashes,
+		capacity:  capacity,
+		current:   make([]uint64, numBits/64),
+		previous:  make([]uint64, numBits/64),
+	}
+}
+
+// Add adds hash, a cryptographic hash, to the filter.
+func (bf *bloomFilter) Add(hash []byte) {
+	bf.mtx.Lock()
+	defer bf.mtx.Unlock()
+
+	if bf.count >= bf.capacity {
+		bf.previous, bf.current = bf.current, bf.previous
+		for i := range bf.current {
+			bf.current[i] = 0
+		}
+		bf.count = 0
+	}
+	bf.forEachBit(hash, func(idx uint64) bool {
+		bf.current[idx/64] |= 1 << (idx % 64)
+		return true
+	})
+	bf.count++
+}
+
+// Has reports whether hash may have been added to the filter.
+func (bf *bloomFilter) Has(hash []byte) bool {
+	bf.mtx.Lock()
+	defer bf.mtx.Unlock()
+
+	return bf.has(bf.current, hash) || bf.has(bf.previous, hash)
+}
+
+func (bf *bloomFilter) has(bits []uint64, hash []byte) bool {
+	found := true
+	bf.forEachBit(hash, func(idx uint64) bool {
+		found = bits[idx/64]&(1<<(idx%64)) != 0
+		return found
+	})
+	return found
+}
+
+// forEachBit calls f with the bits of hash, until it returns false. The bits
+// are derived from two 64-bit words of hash, which is uniformly distributed
+// already, by double hashing.
+func (bf *bloomFilter) forEachBit(hash []byte, f func(idx uint64) bool) {
+	var buf [16]byte
+	copy(buf[:], hash)
+	h1 := binary.BigEndian.Uint64(buf[:8])
+	h2 := binary.BigEndian.Uint64(buf[8:]) | 1
+	for i := 0; i < bf.numHashes; i++ {
+		if !f((h1 + uint64(i)*h2) % bf.numBits) {
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package evidence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/p2p/mock"
+)
+
+func TestBloomFilter(t *testing.T) {
+	bf := newBloomFilter(100, 1e-6)
+
+	hash := func(i int) []byte { return tmhash.Sum([]byte{byte(i), byte(i >> 8)}) }
+	for i := 0; i < 100; i++ {
+		bf.Add(hash(i))
+	}
+	for i := 0; i < 100; i++ {
+		assert.True(t, bf.Has(hash(i)), i)
+	}
+	for i := 100; i < 1000; i++ {
+		assert.False(t, bf.Has(hash(i)), i)
+	}
+
+	// a new generation keeps the last hashes
+	for i := 100; i < 150; i++ {
+		bf.Add(hash(i))
+	}
+	for i := 0; i < 150; i++ {
+		assert.True(t, bf.Has(hash(i)), i)
+	}
+
+	// and the oldest ones are dropped after another
+	for i := 150; i < 250; i++ {
+		bf.Add(hash(i))
+	}
+	assert.False(t, bf.Has(hash(0)))
+	assert.True(t, bf.Has(hash(100)))
+	assert.True(t, bf.Has(hash(249)))
+}
+
+func TestPeerRateLimiter(t *testing.T) {
+	now := time.Now()
+	evR := NewReactor(nil, ReactorPeerRateLimit(1, 2))
+	peerA, peerB := mock.NewPeer(nil), mock.NewPeer(nil)
+	a, b := string(peerA.ID()), string(peerB.ID())
+
+	assert.True(t, evR.peerLimiter.AllowN(now, a, 1))
+	assert.True(t, evR.peerLimiter.AllowN(now, a, 1))
+	assert.False(t, evR.peerLimiter.AllowN(now, a, 1))
+	assert.True(t, evR.peerLimiter.AllowN(now, b, 1))
+
+	now = now.Add(time.Second)
+	assert.True(t, evR.peerLimiter.AllowN(now, a, 1))
+	assert.False(t, evR.peerLimiter.AllowN(now, a, 1))
+
+	// the state of a removed peer is dropped
+	evR.RemovePeer(peerA, nil)
+	assert.Equal(t, 1, evR.peerLimiter.Len())
+	assert.True(t, evR.peerLimiter.AllowN(now, a, 1))
+	assert.True(t, evR.peerLimiter.AllowN(now, a, 1))
+
+	unlimited := NewReactor(nil, ReactorPeerRateLimit(0, 0))
+	for i := 0; i < 100; i++ {
+		assert.True(t, unlimited.peerLimiter.AllowN(now, a, 1))
+	}
+}
@@ -0,0 +1,37 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package forkmonitor
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Conflicts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "conflicts",
+			Help:      "Number of blocks found conflicting with a committed block, by source (gossip or the address of the witness) and outcome: fork or bad_witness.",
+		}, append(labels, "source", "outcome")).With(labelsAndValues...),
+		WitnessErrors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "witness_errors",
+			Help:      "Number of the checks of a witness which failed, e.g. as the witness was offline, by witness.",
+		}, append(labels, "witness")).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Conflicts:     discard.NewCounter(),
+		WitnessErrors: discard.NewCounter(),
+	}
+}
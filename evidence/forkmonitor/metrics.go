@@ -0,0 +1,24 @@
+package forkmonitor
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "fork_monitor"
+)
+
+//go:generate go run ../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of blocks found conflicting with a committed block, by source
+	// (gossip or the address of the witness) and outcome: fork or
+	// bad_witness.
+	Conflicts metrics.Counter `metrics_labels:"source, outcome"`
+	// Number of the checks of a witness which failed, e.g. as the witness was
+	// offline, by witness.
+	WitnessErrors metrics.Counter `metrics_labels:"witness"`
+}
@@ -0,0 +1,406 @@
+// Package forkmonitor watches for blocks conflicting with the blocks committed
+// by the node, in the votes gossiped by the peers and in the blocks of
+// witnesses, so that operators are warned early of a fork of the chain.
+package forkmonitor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// SourceGossip is the source of the conflicts found in the votes gossiped
+	// by the peers. The source of the other conflicts is the address of the
+	// witness.
+	SourceGossip = "gossip"
+
+	// OutcomeFork means more than 1/3 of the voting power signed a block
+	// conflicting with the one committed, which the safety of the chain
+	// doesn't tolerate.
+	OutcomeFork = "fork"
+	// OutcomeBadWitness means a witness returned a conflicting block which
+	// could not be verified.
+	OutcomeBadWitness = "bad_witness"
+
+	witnessTimeout = 10 * time.Second
+
+	// number of conflicts kept, see Monitor.Conflicts
+	maxConflicts = 100
+)
+
+// EvidencePool is the pool the evidence of the forks is added to.
+type EvidencePool interface {
+	AddEvidence(ev types.Evidence) error
+	ReportConflictingVotes(voteA, voteB *types.Vote)
+}
+
+// Conflict is a block found conflicting with a block committed by the node.
+type Conflict struct {
+	Time    time.Time
+	Source  string
+	Outcome string
+	Height  int64
+	// BlockID is the ID of the block committed by the node.
+	BlockID            types.BlockID
+	ConflictingBlockID types.BlockID
+	// Voting power, at the height, of the validators which signed both
+	// blocks, out of the total voting power.
+	ConflictingPower int64
+	TotalPower       int64
+	// Evidence formed against the validators which signed the conflicting
+	// block of a witness, if verified.
+	Evidence *types.LightClientAttackEvidence
+	// Reason why the conflicting block could not be verified, if so.
+	Reason string
+}
+
+// Monitor watches for conflicting blocks. As it implements EvidencePool, the
+// conflicting votes reported by consensus must go through it; the witnesses
+// are checked at a regular interval.
+type Monitor struct {
+	service.BaseService
+
+	chainID    string
+	encoding   types.CanonicalEncoding
+	blockStore sm.BlockStore
+	stateStore sm.Store
+	evpool     EvidencePool
+	witnesses  []provider.Provider
+	interval   time.Duration
+	halt       func()
+	metrics    *Metrics
+
+	mtx cmtsync.Mutex
+	// the precommits conflicting with the committed blocks, by height and
+	// conflicting block
+	votes     map[int64]map[string]*conflictingVotes
+	conflicts []Conflict
+}
+
+type conflictingVotes struct {
+	blockID  types.BlockID
+	powers   map[string]int64 // by validator address
+	reported bool
+}
+
+// Option sets an optional parameter on the Monitor.
+type Option func(*Monitor)
+
+// WithWitnesses checks the last block of the node against the witnesses at
+// each interval.
+func WithWitnesses(interval time.Duration, witnesses ...provider.Provider) Option {
+	return func(m *Monitor) {
+		m.interval = interval
+		m.witnesses = witnesses
+	}
+}
+
+// WithHalt calls halt once a fork is found.
+func WithHalt(halt func()) Option {
+	return func(m *Monitor) { m.halt = halt }
+}
+
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) Option {
+	return func(m *Monitor) { m.metrics = metrics }
+}
+
+// NewMonitor returns a monitor of the blocks of blockStore, which adds the
+// evidence of the forks to evpool. The encoding is the canonical encoding of
+// the chain.
+func NewMonitor(
+	chainID string,
+	encoding types.CanonicalEncoding,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	evpool EvidencePool,
+	options ...Option,
+) *Monitor {
+	m := &Monitor{
+		chainID:    chainID,
+		encoding:   encoding,
+		blockStore: blockStore,
+		stateStore: stateStore,
+		evpool:     evpool,
+		metrics:    NopMetrics(),
+		votes:      make(map[int64]map[string]*conflictingVotes),
+	}
+	m.BaseService = *service.NewBaseService(nil, "ForkMonitor", m)
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// OnStart implements service.Service by starting the routine checking the
+// witnesses, if any.
+func (m *Monitor) OnStart() error {
+	if len(m.witnesses) > 0 {
+		go m.checkRoutine()
+	}
+	return nil
+}
+
+// Conflicts returns the last conflicts found, the oldest first.
+func (m *Monitor) Conflicts() []Conflict {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	conflicts := make([]Conflict, len(m.conflicts))
+	copy(conflicts, m.conflicts)
+	return conflicts
+}
+
+// AddEvidence implements EvidencePool.
+func (m *Monitor) AddEvidence(ev types.Evidence) error {
+	return m.evpool.AddEvidence(ev)
+}
+
+// ReportConflictingVotes implements EvidencePool. The conflicting votes are
+// passed on to the evidence pool, and the precommits for a block conflicting
+// with a committed one are added up: a fork is reported once the validators
+// which signed both hold more than 1/3 of the voting power.
+func (m *Monitor) ReportConflictingVotes(voteA, voteB *types.Vote) {
+	m.evpool.ReportConflictingVotes(voteA, voteB)
+
+	height := voteA.Height
+	if voteA.Type != cmtproto.PrecommitType || height > m.blockStore.Height() {
+		return
+	}
+	meta := m.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return
+	}
+	var conflicting *types.Vote
+	switch {
+	case voteA.BlockID.Equals(meta.BlockID):
+		conflicting = voteB
+	case voteB.BlockID.Equals(meta.BlockID):
+		conflicting = voteA
+	default:
+		return
+	}
+	if conflicting.BlockID.IsZero() {
+		return
+	}
+	vals, err := m.stateStore.LoadValidators(height)
+	if err != nil {
+		m.Logger.Error("Failed to load the validators", "height", height, "err", err)
+		return
+	}
+	_, val := vals.GetByAddress(conflicting.ValidatorAddress)
+	if val == nil {
+		return
+	}
+
+	m.mtx.Lock()
+	for h := range m.votes {
+		// consensus only takes the votes of the last height committed
+		if h < height-1 {
+			delete(m.votes, h)
+		}
+	}
+	if m.votes[height] == nil {
+		m.votes[height] = make(map[string]*conflictingVotes)
+	}
+	key := conflicting.BlockID.Key()
+	votes, ok := m.votes[height][key]
+	if !ok {
+		votes = &conflictingVotes{blockID: conflicting.BlockID, powers: make(map[string]int64)}
+		m.votes[height][key] = votes
+	}
+	votes.powers[val.Address.String()] = val.VotingPower
+	var power int64
+	for _, p := range votes.powers {
+		power += p
+	}
+	total := vals.TotalVotingPower()
+	fork := !votes.reported && power*3 > total
+	if fork {
+		votes.reported = true
+	}
+	m.mtx.Unlock()
+
+	if fork {
+		m.report(Conflict{
+			Source:             SourceGossip,
+			Outcome:            OutcomeFork,
+			Height:             height,
+			BlockID:            meta.BlockID,
+			ConflictingBlockID: conflicting.BlockID,
+			ConflictingPower:   power,
+			TotalPower:         total,
+		})
+	}
+}
+
+func (m *Monitor) checkRoutine() {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, witness := range m.witnesses {
+				m.checkWitness(witness)
+			}
+		case <-m.Quit():
+			return
+		}
+	}
+}
+
+// checkWitness compares the last block committed by the node with the block
+// of the witness at the same height.
+func (m *Monitor) checkWitness(witness provider.Provider) {
+	height := m.blockStore.Height()
+	if height == 0 {
+		return
+	}
+	meta := m.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), witnessTimeout)
+	defer cancel()
+	lb, err := witness.LightBlock(ctx, height)
+	switch {
+	case errors.Is(err, provider.ErrHeightTooHigh) || errors.Is(err, provider.ErrLightBlockNotFound):
+		m.Logger.Debug("Witness doesn't have the block", "witness", witness, "height", height)
+		return
+	case err != nil:
+		m.metrics.WitnessErrors.With("witness", fmt.Sprint(witness)).Add(1)
+		m.Logger.Info("Failed to get the block of the witness", "witness", witness, "height", height, "err", err)
+		return
+	case bytes.Equal(lb.Hash(m.encoding), meta.BlockID.Hash):
+		return
+	}
+
+	conflict := Conflict{
+		Source:  fmt.Sprint(witness),
+		Outcome: OutcomeFork,
+		Height:  height,
+		BlockID: meta.BlockID,
+	}
+	if lb.Commit != nil {
+		conflict.ConflictingBlockID = lb.Commit.BlockID
+	}
+	ev, err := m.newEvidence(lb)
+	if err != nil {
+		conflict.Outcome = OutcomeBadWitness
+		conflict.Reason = err.Error()
+	} else {
+		conflict.Evidence = ev
+		conflict.TotalPower = ev.TotalVotingPower
+		for _, val := range ev.ByzantineValidators {
+			conflict.ConflictingPower += val.VotingPower
+		}
+	}
+	m.report(conflict)
+}
+
+// newEvidence verifies the conflicting block of a witness, and forms the
+// evidence against the validators which signed it.
+func (m *Monitor) newEvidence(conflicting *types.LightBlock) (*types.LightClientAttackEvidence, error) {
+	if err := conflicting.ValidateBasic(m.chainID, m.encoding); err != nil {
+		return nil, fmt.Errorf("invalid block: %w", err)
+	}
+	height := conflicting.Height
+	if err := conflicting.ValidatorSet.VerifyCommitLight(m.chainID, m.encoding, conflicting.Commit.BlockID,
+		height, conflicting.Commit); err != nil {
+		return nil, fmt.Errorf("invalid commit: %w", err)
+	}
+	trusted, err := m.lightBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	// with the same validators, the validators signed both blocks, else the
+	// validators of the previous block must have signed the conflicting one
+	common := trusted
+	if !bytes.Equal(conflicting.ValidatorsHash, trusted.ValidatorsHash) {
+		if common, err = m.lightBlock(height - 1); err != nil {
+			return nil, err
+		}
+		err := common.ValidatorSet.VerifyCommitLightTrusting(m.chainID, m.encoding, conflicting.Commit,
+			light.DefaultTrustLevel)
+		if err != nil {
+			return nil, fmt.Errorf("commit not signed by the validators: %w", err)
+		}
+	}
+	ev := light.NewLightClientAttackEvidence(conflicting, trusted, common)
+	var power int64
+	for _, val := range ev.ByzantineValidators {
+		power += val.VotingPower
+	}
+	if power*3 <= ev.TotalVotingPower {
+		return nil, fmt.Errorf("conflicting block signed by %d of %d voting power", power, ev.TotalVotingPower)
+	}
+	return ev, nil
+}
+
+// lightBlock returns the light block of the node at height.
+func (m *Monitor) lightBlock(height int64) (*types.LightBlock, error) {
+	meta := m.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, fmt.Errorf("no block at height %d", height)
+	}
+	commit := m.blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		commit = m.blockStore.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		return nil, fmt.Errorf("no commit at height %d", height)
+	}
+	vals, err := m.stateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &meta.Header, Commit: commit},
+		ValidatorSet: vals,
+	}, nil
+}
+
+// report logs, counts and records the conflict, adding its evidence to the
+// evidence pool, and halts on a fork if set to.
+func (m *Monitor) report(conflict Conflict) {
+	conflict.Time = time.Now()
+	m.mtx.Lock()
+	m.conflicts = append(m.conflicts, conflict)
+	if len(m.conflicts) > maxConflicts {
+		m.conflicts = m.conflicts[1:]
+	}
+	m.mtx.Unlock()
+
+	m.Logger.Error("Conflicting block detected",
+		"source", conflict.Source,
+		"outcome", conflict.Outcome,
+		"height", conflict.Height,
+		"blockID", conflict.BlockID,
+		"conflictingBlockID", conflict.ConflictingBlockID,
+		"conflictingPower", conflict.ConflictingPower,
+		"totalPower", conflict.TotalPower,
+		"reason", conflict.Reason)
+	m.metrics.Conflicts.With("source", conflict.Source, "outcome", conflict.Outcome).Add(1)
+
+	if conflict.Evidence != nil {
+		if err := m.evpool.AddEvidence(conflict.Evidence); err != nil {
+			m.Logger.Error("Failed to add the evidence of the fork", "err", err)
+		}
+	}
+	if conflict.Outcome == OutcomeFork && m.halt != nil {
+		m.Logger.Error("Halting on the fork", "height", conflict.Height)
+		m.halt()
+	}
+}
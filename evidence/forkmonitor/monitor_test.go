@@ -0,0 +1,195 @@
+package forkmonitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+const chainID = "fork-monitor-test"
+
+type evidencePool struct {
+	mtx      cmtsync.Mutex
+	evidence []types.Evidence
+	reported int
+}
+
+func (p *evidencePool) AddEvidence(ev types.Evidence) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.evidence = append(p.evidence, ev)
+	return nil
+}
+
+func (p *evidencePool) ReportConflictingVotes(voteA, voteB *types.Vote) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.reported++
+}
+
+type witness struct {
+	lb *types.LightBlock
+}
+
+func (w witness) ChainID() string { return chainID }
+
+func (w witness) String() string { return "witness" }
+
+func (w witness) LightBlock(_ context.Context, height int64) (*types.LightBlock, error) {
+	return w.lb, nil
+}
+
+func (w witness) ReportEvidence(context.Context, types.Evidence) error { return nil }
+
+// makeLightBlock returns a light block at height, signed by all the
+// validators.
+func makeLightBlock(t *testing.T, height int64, appHash string,
+	vals *types.ValidatorSet, privVals []types.PrivValidator,
+) *types.LightBlock {
+	t.Helper()
+	header := &types.Header{
+		Version:            cmtversion.Consensus{Block: version.BlockProtocol},
+		ChainID:            chainID,
+		Height:             height,
+		Time:               time.Unix(1700000000, 0).UTC(),
+		LastBlockID:        types.BlockID{Hash: tmhash.Sum([]byte("last")), PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}},
+		LastCommitHash:     tmhash.Sum([]byte("last_commit")),
+		DataHash:           tmhash.Sum([]byte("data")),
+		ValidatorsHash:     vals.Hash(types.CanonicalEncodingProto),
+		NextValidatorsHash: vals.Hash(types.CanonicalEncodingProto),
+		ConsensusHash:      tmhash.Sum([]byte("consensus")),
+		AppHash:            tmhash.Sum([]byte(appHash)),
+		LastResultsHash:    tmhash.Sum([]byte("results")),
+		EvidenceHash:       tmhash.Sum([]byte{}),
+		ProposerAddress:    vals.Proposer.Address,
+	}
+	blockID := types.BlockID{
+		Hash:          header.Hash(types.CanonicalEncodingProto),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte(appHash))},
+	}
+	voteSet := types.NewVoteSet(chainID, types.CanonicalEncodingProto, height, 0, cmtproto.PrecommitType, vals)
+	commit, err := types.MakeCommit(blockID, height, 0, voteSet, privVals, header.Time)
+	require.NoError(t, err)
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: vals,
+	}
+}
+
+func newTestMonitor(t *testing.T, ours *types.LightBlock, options ...Option) (*Monitor, *evidencePool) {
+	t.Helper()
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(ours.Height)
+	blockStore.On("LoadBlockMeta", ours.Height).Return(&types.BlockMeta{
+		BlockID: ours.Commit.BlockID,
+		Header:  *ours.Header,
+	})
+	blockStore.On("LoadBlockCommit", ours.Height).Return(nil)
+	blockStore.On("LoadSeenCommit", ours.Height).Return(ours.Commit)
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadValidators", ours.Height).Return(ours.ValidatorSet, nil)
+
+	evpool := &evidencePool{}
+	m := NewMonitor(chainID, types.CanonicalEncodingProto, blockStore, stateStore, evpool, options...)
+	m.SetLogger(log.TestingLogger())
+	return m, evpool
+}
+
+func TestMonitorGossip(t *testing.T) {
+	vals, privVals := types.RandValidatorSet(3, 10)
+	ours := makeLightBlock(t, 2, "ours", vals, privVals)
+	conflicting := makeLightBlock(t, 2, "conflicting", vals, privVals)
+
+	halted := 0
+	m, evpool := newTestMonitor(t, ours, WithHalt(func() { halted++ }))
+
+	precommit := func(i int, blockID types.BlockID) *types.Vote {
+		return &types.Vote{
+			Type:             cmtproto.PrecommitType,
+			Height:           2,
+			BlockID:          blockID,
+			ValidatorAddress: vals.Validators[i].Address,
+			ValidatorIndex:   int32(i),
+		}
+	}
+
+	// a single validator signing both blocks is not a fork
+	m.ReportConflictingVotes(precommit(0, ours.Commit.BlockID), precommit(0, conflicting.Commit.BlockID))
+	assert.Empty(t, m.Conflicts())
+	// nor are precommits for nil
+	m.ReportConflictingVotes(precommit(1, types.BlockID{}), precommit(1, ours.Commit.BlockID))
+	assert.Empty(t, m.Conflicts())
+
+	// more than 1/3 of the voting power signing both blocks is
+	m.ReportConflictingVotes(precommit(2, conflicting.Commit.BlockID), precommit(2, ours.Commit.BlockID))
+	conflicts := m.Conflicts()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, SourceGossip, conflicts[0].Source)
+	assert.Equal(t, OutcomeFork, conflicts[0].Outcome)
+	assert.Equal(t, int64(2), conflicts[0].Height)
+	assert.Equal(t, ours.Commit.BlockID, conflicts[0].BlockID)
+	assert.Equal(t, conflicting.Commit.BlockID, conflicts[0].ConflictingBlockID)
+	assert.Equal(t, int64(20), conflicts[0].ConflictingPower)
+	assert.Equal(t, int64(30), conflicts[0].TotalPower)
+	assert.Equal(t, 1, halted)
+
+	// the fork is reported once
+	m.ReportConflictingVotes(precommit(1, conflicting.Commit.BlockID), precommit(1, ours.Commit.BlockID))
+	assert.Len(t, m.Conflicts(), 1)
+	assert.Equal(t, 1, halted)
+
+	// all the conflicting votes are passed on to the evidence pool
+	assert.Equal(t, 4, evpool.reported)
+}
+
+func TestMonitorWitness(t *testing.T) {
+	vals, privVals := types.RandValidatorSet(3, 10)
+	ours := makeLightBlock(t, 2, "ours", vals, privVals)
+
+	halted := 0
+	m, evpool := newTestMonitor(t, ours, WithHalt(func() { halted++ }))
+
+	// same block
+	m.checkWitness(witness{ours})
+	assert.Empty(t, m.Conflicts())
+
+	// conflicting block not committed by the validators
+	bad := makeLightBlock(t, 2, "bad", vals, privVals)
+	bad.Commit.Signatures[0] = types.NewCommitSigAbsent()
+	bad.Commit.Signatures[1] = types.NewCommitSigAbsent()
+	m.checkWitness(witness{bad})
+	conflicts := m.Conflicts()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "witness", conflicts[0].Source)
+	assert.Equal(t, OutcomeBadWitness, conflicts[0].Outcome)
+	assert.NotEmpty(t, conflicts[0].Reason)
+	assert.Nil(t, conflicts[0].Evidence)
+	assert.Zero(t, halted)
+
+	// conflicting block committed by the validators
+	conflicting := makeLightBlock(t, 2, "conflicting", vals, privVals)
+	m.checkWitness(witness{conflicting})
+	conflicts = m.Conflicts()
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, OutcomeFork, conflicts[1].Outcome)
+	assert.Equal(t, conflicting.Commit.BlockID, conflicts[1].ConflictingBlockID)
+	assert.Equal(t, int64(30), conflicts[1].ConflictingPower)
+	require.NotNil(t, conflicts[1].Evidence)
+	assert.Equal(t, int64(2), conflicts[1].Evidence.CommonHeight)
+	assert.Len(t, conflicts[1].Evidence.ByzantineValidators, 3)
+	require.Len(t, evpool.evidence, 1)
+	assert.Equal(t, conflicts[1].Evidence, evpool.evidence[0])
+	assert.Equal(t, 1, halted)
+}
@@ -13,6 +13,7 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	"github.com/cometbft/cometbft/crypto/bn254"
 	clist "github.com/cometbft/cometbft/libs/clist"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -71,7 +72,7 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 	// if pending evidence already in db, in event of prior failure, then check for expiration,
 	// update the size and load it back to the evidenceList
 	pool.pruningHeight, pool.pruningTime = pool.removeExpiredPendingEvidence()
-	evList, _, err := pool.listEvidence(baseKeyPending, -1)
+	evList, _, err := pool.listEvidence(baseKeyPending, -1, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -83,12 +84,15 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 	return pool, nil
 }
 
-// PendingEvidence is used primarily as part of block proposal and returns up to maxNum of uncommitted evidence.
+// PendingEvidence is used primarily as part of block proposal and returns up to maxBytes of uncommitted
+// evidence, of the types enabled by the consensus params. The evidence which doesn't fit is skipped, so
+// that smaller evidence can still be proposed.
 func (evpool *Pool) PendingEvidence(maxBytes int64) ([]types.Evidence, int64) {
 	if evpool.Size() == 0 {
 		return []types.Evidence{}, 0
 	}
-	evidence, size, err := evpool.listEvidence(baseKeyPending, maxBytes)
+	params := evpool.State().ConsensusParams.Evidence
+	evidence, size, err := evpool.listEvidence(baseKeyPending, maxBytes, params.IsEnabled)
 	if err != nil {
 		evpool.logger.Error("Unable to retrieve pending evidence", "err", err)
 	}
@@ -98,7 +102,7 @@ func (evpool *Pool) PendingEvidence(maxBytes int64) ([]types.Evidence, int64) {
 // Update takes both the new state and the evidence committed at that height and performs
 // the following operations:
 //  1. Take any conflicting votes from consensus and use the state's LastBlockTime to form
-//     DuplicateVoteEvidence and add it to the pool.
+//     DuplicateVoteEvidence, or Bn254DuplicateVoteEvidence, and add it to the pool.
 //  2. Update the pool's state which contains evidence params relating to expiry.
 //  3. Moves pending evidence that has now been committed into the committed pool.
 //  4. Removes any expired evidence based on both height and time.
@@ -355,9 +359,11 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 	}
 }
 
-// listEvidence retrieves lists evidence from oldest to newest within maxBytes.
+// listEvidence retrieves lists evidence from oldest to newest within maxBytes, skipping the evidence
+// which doesn't fit or, if include is not nil, isn't included by it.
 // If maxBytes is -1, there's no cap on the size of returned evidence.
-func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Evidence, int64, error) {
+func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64,
+	include func(types.Evidence) bool) ([]types.Evidence, int64, error) {
 	var (
 		evSize    int64
 		totalSize int64
@@ -376,19 +382,20 @@ func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Eviden
 		if err != nil {
 			return evidence, totalSize, err
 		}
-		evList.Evidence = append(evList.Evidence, evpb)
-		evSize = int64(evList.Size())
-		if maxBytes != -1 && evSize > maxBytes {
-			if err := iter.Error(); err != nil {
-				return evidence, totalSize, err
-			}
-			return evidence, totalSize, nil
-		}
-
 		ev, err := types.EvidenceFromProto(&evpb)
 		if err != nil {
 			return nil, totalSize, err
 		}
+		if include != nil && !include(ev) {
+			continue
+		}
+
+		evList.Evidence = append(evList.Evidence, evpb)
+		evSize = int64(evList.Size())
+		if maxBytes != -1 && evSize > maxBytes {
+			evList.Evidence = evList.Evidence[:len(evList.Evidence)-1]
+			continue
+		}
 
 		totalSize = evSize
 		evidence = append(evidence, ev)
@@ -453,7 +460,7 @@ func (evpool *Pool) updateState(state sm.State) {
 }
 
 // processConsensusBuffer converts all the duplicate votes witnessed from consensus
-// into DuplicateVoteEvidence. It sets the evidence timestamp to the block height
+// into DuplicateVoteEvidence, or Bn254DuplicateVoteEvidence. It sets the evidence timestamp to the block height
 // from the most recently committed block.
 // Evidence is then added to the pool so as to be ready to be broadcasted and proposed.
 func (evpool *Pool) processConsensusBuffer(state sm.State) {
@@ -464,12 +471,12 @@ func (evpool *Pool) processConsensusBuffer(state sm.State) {
 		// Check the height of the conflicting votes and fetch the corresponding time and validator set
 		// to produce the valid evidence
 		var (
-			dve *types.DuplicateVoteEvidence
+			dve types.Evidence
 			err error
 		)
 		switch {
 		case voteSet.VoteA.Height == state.LastBlockHeight:
-			dve, err = types.NewDuplicateVoteEvidence(
+			dve, err = newDuplicateVoteEvidence(
 				voteSet.VoteA,
 				voteSet.VoteB,
 				state.LastBlockTime,
@@ -490,7 +497,7 @@ func (evpool *Pool) processConsensusBuffer(state sm.State) {
 				evpool.logger.Error("failed to load block time for conflicting votes", "height", voteSet.VoteA.Height)
 				continue
 			}
-			dve, err = types.NewDuplicateVoteEvidence(
+			dve, err = newDuplicateVoteEvidence(
 				voteSet.VoteA,
 				voteSet.VoteB,
 				blockMeta.Header.Time,
@@ -541,6 +548,17 @@ type duplicateVoteSet struct {
 	VoteB *types.Vote
 }
 
+// newDuplicateVoteEvidence returns the evidence of the conflicting votes, as
+// Bn254DuplicateVoteEvidence for the bn254 validators, whose individual votes
+// are lost once their commits are aggregated.
+func newDuplicateVoteEvidence(voteA, voteB *types.Vote, blockTime time.Time, valSet *types.ValidatorSet,
+) (types.Evidence, error) {
+	if _, val := valSet.GetByAddress(voteA.ValidatorAddress); val != nil && val.PubKey.Type() == bn254.KeyType {
+		return types.NewBn254DuplicateVoteEvidence(voteA, voteB, blockTime, valSet)
+	}
+	return types.NewDuplicateVoteEvidence(voteA, voteB, blockTime, valSet)
+}
+
 func bytesToEv(evBytes []byte) (types.Evidence, error) {
 	var evpb cmtproto.Evidence
 	err := evpb.Unmarshal(evBytes)
@@ -11,6 +11,7 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/evidence"
 	"github.com/cometbft/cometbft/evidence/mocks"
 	"github.com/cometbft/cometbft/internal/test"
@@ -93,6 +94,38 @@ func TestEvidencePoolBasic(t *testing.T) {
 	assert.Equal(t, 1, len(evs))
 }
 
+func TestPendingEvidenceDisabledTypes(t *testing.T) {
+	var (
+		height     = int64(1)
+		stateStore = &smmocks.Store{}
+		blockStore = &mocks.BlockStore{}
+	)
+
+	valSet, privVals := types.RandValidatorSet(1, 10)
+	state := createState(height+1, valSet)
+	state.ConsensusParams.Evidence.DisabledTypes = []string{types.EvidenceTypeDuplicateVote}
+
+	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
+		&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}},
+	)
+	stateStore.On("LoadValidators", mock.AnythingOfType("int64")).Return(valSet, nil)
+	stateStore.On("Load").Return(state, nil)
+
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	ev, err := types.NewMockDuplicateVoteEvidenceWithValidator(height, defaultEvidenceTime, privVals[0], evidenceChainID)
+	require.NoError(t, err)
+	require.NoError(t, pool.AddEvidence(ev))
+	assert.EqualValues(t, 1, pool.Size())
+
+	// the evidence is kept, but not proposed
+	evs, size := pool.PendingEvidence(defaultEvidenceMaxBytes)
+	assert.Empty(t, evs)
+	assert.Zero(t, size)
+}
+
 // Tests inbound evidence for the right time and height
 func TestAddExpiredEvidence(t *testing.T) {
 	var (
@@ -182,6 +215,41 @@ func TestReportConflictingVotes(t *testing.T) {
 	require.NotNil(t, next)
 }
 
+func TestReportConflictingBn254Votes(t *testing.T) {
+	var height int64 = 10
+
+	pv := types.NewMockPVWithParams(bn254.GenPrivKey(), false, false)
+	stateStore := initializeValidatorState(pv, height)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockStore, err := initializeBlockStore(dbm.NewMemDB(), state, pv.PrivKey.PubKey().Address())
+	require.NoError(t, err)
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	dve, err := types.NewMockDuplicateVoteEvidenceWithValidator(height, defaultEvidenceTime, pv, evidenceChainID)
+	require.NoError(t, err)
+	pool.ReportConflictingVotes(dve.VoteA, dve.VoteB)
+
+	state.LastBlockHeight++
+	state.LastBlockTime = defaultEvidenceTime.Add(time.Minute)
+	pool.Update(state, []types.Evidence{})
+
+	// the votes of a bn254 validator make Bn254DuplicateVoteEvidence
+	evList, _ := pool.PendingEvidence(defaultEvidenceMaxBytes)
+	require.Len(t, evList, 1)
+	ev, ok := evList[0].(*types.Bn254DuplicateVoteEvidence)
+	require.True(t, ok, "got %T", evList[0])
+	assert.Equal(t, dve.VoteA, ev.VoteA)
+	assert.Equal(t, dve.VoteB, ev.VoteB)
+
+	// which the other nodes verify
+	pool2, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	require.NoError(t, pool2.CheckEvidence(evList))
+}
+
 func TestEvidencePoolUpdate(t *testing.T) {
 	height := int64(21)
 	pool, val := defaultTestPool(t, height)
@@ -357,9 +425,7 @@ func TestRecoverPendingEvidence(t *testing.T) {
 
 func initializeStateFromValidatorSet(valSet *types.ValidatorSet, height int64) sm.Store {
 	stateDB := dbm.NewMemDB()
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	state := sm.State{
 		ChainID:                     evidenceChainID,
 		InitialHeight:               1,
@@ -423,7 +489,7 @@ func initializeBlockStore(db dbm.DB, state sm.State, valAddr []byte) (*store.Blo
 		}
 
 		seenCommit := makeCommit(i, valAddr)
-		blockStore.SaveBlock(block, partSet, seenCommit)
+		blockStore.SaveBlock(block, partSet, seenCommit, types.CanonicalEncodingProto)
 	}
 
 	return blockStore, nil
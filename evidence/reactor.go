@@ -8,6 +8,7 @@ import (
 
 	clist "github.com/cometbft/cometbft/libs/clist"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/ratelimit"
 	"github.com/cometbft/cometbft/p2p"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/types"
@@ -25,6 +26,18 @@ const (
 	broadcastEvidenceIntervalS = 10
 	// If a message fails wait this much before sending it again
 	peerRetryMessageIntervalMS = 100
+
+	// By default, each peer can send up to this much new evidence per second,
+	// in bursts of up to defaultPeerEvidenceBurst. The evidence past that is
+	// dropped, unverified.
+	defaultPeerEvidenceRate  = 10
+	defaultPeerEvidenceBurst = 100
+
+	// The hashes of the evidence already committed or pending are kept in a
+	// bloom filter, with this capacity and false positive rate, so that peers
+	// re-broadcasting it don't make it verified again.
+	seenEvidenceCapacity = 10000
+	seenEvidenceFPRate   = 1e-6
 )
 
 // Reactor handles evpool evidence broadcasting amongst peers.
@@ -32,14 +45,41 @@ type Reactor struct {
 	p2p.BaseReactor
 	evpool   *Pool
 	eventBus *types.EventBus
+
+	peerLimit        ratelimit.Limit
+	peerLimiter      *ratelimit.Limiter
+	rateLimitMetrics *ratelimit.Metrics
+	seen             *bloomFilter
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// ReactorPeerRateLimit limits the new evidence each peer can send to rate
+// pieces per second, in bursts of up to burst. A zero rate disables the limit.
+func ReactorPeerRateLimit(rate float64, burst int) ReactorOption {
+	return func(evR *Reactor) { evR.peerLimit = ratelimit.Limit{Rate: rate, Burst: burst} }
+}
+
+// ReactorRateLimitMetrics counts the evidence allowed and dropped by the rate
+// limit of the peers, under the limiter name "evidence".
+func ReactorRateLimitMetrics(metrics *ratelimit.Metrics) ReactorOption {
+	return func(evR *Reactor) { evR.rateLimitMetrics = metrics }
 }
 
 // NewReactor returns a new Reactor with the given config and evpool.
-func NewReactor(evpool *Pool) *Reactor {
+func NewReactor(evpool *Pool, options ...ReactorOption) *Reactor {
 	evR := &Reactor{
-		evpool: evpool,
+		evpool:           evpool,
+		peerLimit:        ratelimit.Limit{Rate: defaultPeerEvidenceRate, Burst: defaultPeerEvidenceBurst},
+		rateLimitMetrics: ratelimit.NopMetrics(),
+		seen:             newBloomFilter(seenEvidenceCapacity, seenEvidenceFPRate),
 	}
 	evR.BaseReactor = *p2p.NewBaseReactor("Evidence", evR)
+	for _, option := range options {
+		option(evR)
+	}
+	evR.peerLimiter = ratelimit.NewLimiter(evR.peerLimit, ratelimit.WithMetrics(evR.rateLimitMetrics, "evidence"))
 	return evR
 }
 
@@ -67,8 +107,14 @@ func (evR *Reactor) AddPeer(peer p2p.Peer) {
 	go evR.broadcastEvidenceRoutine(peer)
 }
 
+// RemovePeer implements Reactor.
+func (evR *Reactor) RemovePeer(peer p2p.Peer, _ interface{}) {
+	evR.peerLimiter.Remove(string(peer.ID()))
+}
+
 // Receive implements Reactor.
-// It adds any received evidence to the evpool.
+// It adds any received evidence to the evpool, but the evidence already
+// committed or pending, and the evidence past the rate limit of the peer.
 func (evR *Reactor) Receive(e p2p.Envelope) {
 	evis, err := evidenceListFromProto(e.Message)
 	if err != nil {
@@ -78,6 +124,16 @@ func (evR *Reactor) Receive(e p2p.Envelope) {
 	}
 
 	for _, ev := range evis {
+		hash := ev.Hash()
+		if evR.seen.Has(hash) {
+			// peers re-broadcast the evidence until they see it committed
+			continue
+		}
+		if !evR.peerLimiter.Allow(string(e.Src.ID())) {
+			evR.Logger.Debug("Peer exceeded its evidence rate limit, dropping evidence", "src", e.Src, "ev", ev)
+			continue
+		}
+
 		err := evR.evpool.AddEvidence(ev)
 		switch err.(type) {
 		case *types.ErrInvalidEvidence:
@@ -86,6 +142,8 @@ func (evR *Reactor) Receive(e p2p.Envelope) {
 			evR.Switch.StopPeerForError(e.Src, err)
 			return
 		case nil:
+			// the evidence is now pending, or was already committed
+			evR.seen.Add(hash)
 		default:
 			// continue to the next piece of evidence
 			evR.Logger.Error("Evidence has not been added", "evidence", evis, "err", err)
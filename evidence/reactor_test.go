@@ -226,6 +226,54 @@ func TestReactorBroadcastEvidenceMemoryLeak(t *testing.T) {
 	_ = sendEvidence(t, pool, val, 2)
 }
 
+func TestReactorReceiveRateLimit(t *testing.T) {
+	height := int64(10)
+	pool, val := defaultTestPool(t, height)
+
+	p := &p2pmocks.Peer{}
+	p.On("ID").Return(p2p.ID("ABC"))
+	p.On("String").Return("mock")
+
+	r := evidence.NewReactor(pool, evidence.ReactorPeerRateLimit(0.001, 2))
+	r.SetLogger(log.TestingLogger())
+
+	evs := make([]types.Evidence, 3)
+	for i := range evs {
+		ev, err := types.NewMockDuplicateVoteEvidenceWithValidator(height,
+			defaultEvidenceTime.Add(time.Duration(height)*time.Minute), val, evidenceChainID)
+		require.NoError(t, err)
+		evs[i] = ev
+	}
+	receive := func(ev types.Evidence) {
+		evp, err := types.EvidenceToProto(ev)
+		require.NoError(t, err)
+		r.Receive(p2p.Envelope{
+			Src:       p,
+			ChannelID: evidence.EvidenceChannel,
+			Message:   &cmtproto.EvidenceList{Evidence: []cmtproto.Evidence{*evp}},
+		})
+	}
+
+	receive(evs[0])
+	// re-broadcast evidence is not verified again, nor rate limited
+	receive(evs[0])
+	receive(evs[0])
+	receive(evs[1])
+	// the peer exceeded its rate limit
+	receive(evs[2])
+
+	pending, _ := pool.PendingEvidence(-1)
+	assert.ElementsMatch(t, evs[:2], pending)
+
+	// the limit is per peer
+	p2 := &p2pmocks.Peer{}
+	p2.On("ID").Return(p2p.ID("DEF"))
+	p = p2
+	receive(evs[2])
+	pending, _ = pool.PendingEvidence(-1)
+	assert.ElementsMatch(t, evs, pending)
+}
+
 // evidenceLogger is a TestingLogger which uses a different
 // color for each validator ("validator" key must exist).
 func evidenceLogger() log.Logger {
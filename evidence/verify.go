@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/light"
 	"github.com/cometbft/cometbft/types"
 )
@@ -52,7 +54,14 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		if err != nil {
 			return err
 		}
-		return VerifyDuplicateVote(ev, state.ChainID, valSet)
+		return VerifyDuplicateVote(ev, state.ChainID, state.ConsensusParams.Encoding.Canonical, valSet)
+
+	case *types.Bn254DuplicateVoteEvidence:
+		valSet, err := evpool.stateDB.LoadValidators(evidence.Height())
+		if err != nil {
+			return err
+		}
+		return VerifyBn254DuplicateVote(ev, state.ChainID, state.ConsensusParams.Encoding.Canonical, valSet)
 
 	case *types.LightClientAttackEvidence:
 		commonHeader, err := getSignedHeader(evpool.blockStore, evidence.Height())
@@ -87,7 +96,7 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		}
 
 		err = VerifyLightClientAttack(ev, commonHeader, trustedHeader, commonVals, state.LastBlockTime,
-			state.ConsensusParams.Evidence.MaxAgeDuration)
+			state.ConsensusParams.Evidence.MaxAgeDuration, state.ConsensusParams.Encoding.Canonical)
 		if err != nil {
 			return err
 		}
@@ -102,18 +111,33 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 // the following checks:
 //   - the common header from the full node has at least 1/3 voting power which is also present in
 //     the conflicting header's commit
-//   - 2/3+ of the conflicting validator set correctly signed the conflicting block
+//   - 2/3+ of the conflicting validator set correctly signed the conflicting block, whose commit
+//     may be aggregated
 //   - the nodes trusted header at the same height as the conflicting header has a different hash
 //
+// The headers and validator sets are hashed, and the votes verified, with the
+// given canonical encoding of the chain.
+//
 // CONTRACT: must run ValidateBasic() on the evidence before verifying
 //
 //	must check that the evidence has not expired (i.e. is outside the maximum age threshold)
 func VerifyLightClientAttack(e *types.LightClientAttackEvidence, commonHeader, trustedHeader *types.SignedHeader,
-	commonVals *types.ValidatorSet, now time.Time, trustPeriod time.Duration) error {
+	commonVals *types.ValidatorSet, now time.Time, trustPeriod time.Duration, encoding types.CanonicalEncoding) error {
+	if err := e.ValidateHashes(encoding); err != nil {
+		return err
+	}
+
 	// In the case of lunatic attack there will be a different commonHeader height. Therefore the node perform a single
 	// verification jump between the common header and the conflicting one
 	if commonHeader.Height != e.ConflictingBlock.Height {
-		err := commonVals.VerifyCommitLightTrusting(trustedHeader.ChainID, e.ConflictingBlock.Commit, light.DefaultTrustLevel)
+		var err error
+		if ac := e.ConflictingAggregatedCommit; ac != nil {
+			// the aggregated signature is verified below
+			err = commonVals.VerifyAggregatedCommitLightTrusting(e.ConflictingBlock.ValidatorSet, ac, light.DefaultTrustLevel)
+		} else {
+			err = commonVals.VerifyCommitLightTrusting(trustedHeader.ChainID, encoding, e.ConflictingBlock.Commit,
+				light.DefaultTrustLevel)
+		}
 		if err != nil {
 			return fmt.Errorf("skipping verification of conflicting block failed: %w", err)
 		}
@@ -125,8 +149,13 @@ func VerifyLightClientAttack(e *types.LightClientAttackEvidence, commonHeader, t
 	}
 
 	// Verify that the 2/3+ commits from the conflicting validator set were for the conflicting header
-	if err := e.ConflictingBlock.ValidatorSet.VerifyCommitLight(trustedHeader.ChainID, e.ConflictingBlock.Commit.BlockID,
-		e.ConflictingBlock.Height, e.ConflictingBlock.Commit); err != nil {
+	if ac := e.ConflictingAggregatedCommit; ac != nil {
+		if err := e.ConflictingBlock.ValidatorSet.VerifyAggregatedCommit(trustedHeader.ChainID, encoding, ac.BlockID,
+			e.ConflictingBlock.Height, ac); err != nil {
+			return fmt.Errorf("invalid aggregated commit from conflicting block: %w", err)
+		}
+	} else if err := e.ConflictingBlock.ValidatorSet.VerifyCommitLight(trustedHeader.ChainID, encoding,
+		e.ConflictingBlock.Commit.BlockID, e.ConflictingBlock.Height, e.ConflictingBlock.Commit); err != nil {
 		return fmt.Errorf("invalid commit from conflicting block: %w", err)
 	}
 
@@ -143,9 +172,8 @@ func VerifyLightClientAttack(e *types.LightClientAttackEvidence, commonHeader, t
 		)
 
 		// In all other cases check that the hashes of the conflicting header and the trusted header are different
-	} else if bytes.Equal(trustedHeader.Hash(), e.ConflictingBlock.Hash()) {
-		return fmt.Errorf("trusted header hash matches the evidence's conflicting header hash: %X",
-			trustedHeader.Hash())
+	} else if hash := trustedHeader.Hash(encoding); bytes.Equal(hash, e.ConflictingBlock.Hash(encoding)) {
+		return fmt.Errorf("trusted header hash matches the evidence's conflicting header hash: %X", hash)
 	}
 
 	return validateABCIEvidence(e, commonVals, trustedHeader)
@@ -156,26 +184,73 @@ func VerifyLightClientAttack(e *types.LightClientAttackEvidence, commonHeader, t
 //   - the validator is in the validator set at the height of the evidence
 //   - the height, round, type and validator address of the votes must be the same
 //   - the block ID's must be different
-//   - The signatures must both be valid
-func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet *types.ValidatorSet) error {
+//   - The signatures must both be valid, with the canonical encoding of the chain
+func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, encoding types.CanonicalEncoding,
+	valSet *types.ValidatorSet) error {
+	pubKey, err := verifyDuplicateVoteConsistency(e, valSet)
+	if err != nil {
+		return err
+	}
+
+	va := e.VoteA.ToProto()
+	vb := e.VoteB.ToProto()
+	// Signatures must be valid
+	if !pubKey.VerifySignature(types.VoteSignBytes(chainID, encoding, va), e.VoteA.Signature) {
+		return fmt.Errorf("verifying VoteA: %w", types.ErrVoteInvalidSignature)
+	}
+	if !pubKey.VerifySignature(types.VoteSignBytes(chainID, encoding, vb), e.VoteB.Signature) {
+		return fmt.Errorf("verifying VoteB: %w", types.ErrVoteInvalidSignature)
+	}
+
+	return nil
+}
+
+// VerifyBn254DuplicateVote verifies Bn254DuplicateVoteEvidence as
+// VerifyDuplicateVote does, the validator having to be a bn254 validator. Both
+// signatures are verified with a single pairing check.
+func VerifyBn254DuplicateVote(e *types.Bn254DuplicateVoteEvidence, chainID string, encoding types.CanonicalEncoding,
+	valSet *types.ValidatorSet) error {
+	pubKey, err := verifyDuplicateVoteConsistency(&e.DuplicateVoteEvidence, valSet)
+	if err != nil {
+		return err
+	}
+	bn254PubKey, ok := bn254.Decoded(pubKey)
+	if !ok {
+		return fmt.Errorf("validator %X is not a bn254 validator", e.VoteA.ValidatorAddress)
+	}
+
+	msgs := [][]byte{
+		types.VoteSignBytes(chainID, encoding, e.VoteA.ToProto()),
+		types.VoteSignBytes(chainID, encoding, e.VoteB.ToProto()),
+	}
+	if !bn254PubKey.BatchVerify(msgs, [][]byte{e.VoteA.Signature, e.VoteB.Signature}) {
+		return fmt.Errorf("verifying the votes: %w", types.ErrVoteInvalidSignature)
+	}
+	return nil
+}
+
+// verifyDuplicateVoteConsistency checks the duplicate votes of e against
+// valSet, but not their signatures, and returns the public key of their
+// validator, decoded.
+func verifyDuplicateVoteConsistency(e *types.DuplicateVoteEvidence, valSet *types.ValidatorSet) (crypto.PubKey, error) {
 	_, val := valSet.GetByAddress(e.VoteA.ValidatorAddress)
 	if val == nil {
-		return fmt.Errorf("address %X was not a validator at height %d", e.VoteA.ValidatorAddress, e.Height())
+		return nil, fmt.Errorf("address %X was not a validator at height %d", e.VoteA.ValidatorAddress, e.Height())
 	}
-	pubKey := val.PubKey
+	pubKey := val.VerifyingPubKey()
 
 	// H/R/S must be the same
 	if e.VoteA.Height != e.VoteB.Height ||
 		e.VoteA.Round != e.VoteB.Round ||
 		e.VoteA.Type != e.VoteB.Type {
-		return fmt.Errorf("h/r/s does not match: %d/%d/%v vs %d/%d/%v",
+		return nil, fmt.Errorf("h/r/s does not match: %d/%d/%v vs %d/%d/%v",
 			e.VoteA.Height, e.VoteA.Round, e.VoteA.Type,
 			e.VoteB.Height, e.VoteB.Round, e.VoteB.Type)
 	}
 
 	// Address must be the same
 	if !bytes.Equal(e.VoteA.ValidatorAddress, e.VoteB.ValidatorAddress) {
-		return fmt.Errorf("validator addresses do not match: %X vs %X",
+		return nil, fmt.Errorf("validator addresses do not match: %X vs %X",
 			e.VoteA.ValidatorAddress,
 			e.VoteB.ValidatorAddress,
 		)
@@ -183,7 +258,7 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 
 	// BlockIDs must be different
 	if e.VoteA.BlockID.Equals(e.VoteB.BlockID) {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"block IDs are the same (%v) - not a real duplicate vote",
 			e.VoteA.BlockID,
 		)
@@ -192,31 +267,21 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 	// pubkey must match address (this should already be true, sanity check)
 	addr := e.VoteA.ValidatorAddress
 	if !bytes.Equal(pubKey.Address(), addr) {
-		return fmt.Errorf("address (%X) doesn't match pubkey (%v - %X)",
+		return nil, fmt.Errorf("address (%X) doesn't match pubkey (%v - %X)",
 			addr, pubKey, pubKey.Address())
 	}
 
 	// validator voting power and total voting power must match
 	if val.VotingPower != e.ValidatorPower {
-		return fmt.Errorf("validator power from evidence and our validator set does not match (%d != %d)",
+		return nil, fmt.Errorf("validator power from evidence and our validator set does not match (%d != %d)",
 			e.ValidatorPower, val.VotingPower)
 	}
 	if valSet.TotalVotingPower() != e.TotalVotingPower {
-		return fmt.Errorf("total voting power from the evidence and our validator set does not match (%d != %d)",
+		return nil, fmt.Errorf("total voting power from the evidence and our validator set does not match (%d != %d)",
 			e.TotalVotingPower, valSet.TotalVotingPower())
 	}
 
-	va := e.VoteA.ToProto()
-	vb := e.VoteB.ToProto()
-	// Signatures must be valid
-	if !pubKey.VerifySignature(types.VoteSignBytes(chainID, va), e.VoteA.Signature) {
-		return fmt.Errorf("verifying VoteA: %w", types.ErrVoteInvalidSignature)
-	}
-	if !pubKey.VerifySignature(types.VoteSignBytes(chainID, vb), e.VoteB.Signature) {
-		return fmt.Errorf("verifying VoteB: %w", types.ErrVoteInvalidSignature)
-	}
-
-	return nil
+	return pubKey, nil
 }
 
 // validateABCIEvidence validates the ABCI component of the light client attack
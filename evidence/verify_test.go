@@ -11,10 +11,12 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/evidence"
 	"github.com/cometbft/cometbft/evidence/mocks"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
 	sm "github.com/cometbft/cometbft/state"
@@ -42,25 +44,25 @@ func TestVerifyLightClientAttack_Lunatic(t *testing.T) {
 
 	// good pass -> no error
 	err := evidence.VerifyLightClientAttack(ev, common.SignedHeader, trusted.SignedHeader, common.ValidatorSet,
-		defaultEvidenceTime.Add(2*time.Hour), 3*time.Hour)
+		defaultEvidenceTime.Add(2*time.Hour), 3*time.Hour, types.CanonicalEncodingProto)
 	assert.NoError(t, err)
 
 	// trusted and conflicting hashes are the same -> an error should be returned
 	err = evidence.VerifyLightClientAttack(ev, common.SignedHeader, ev.ConflictingBlock.SignedHeader, common.ValidatorSet,
-		defaultEvidenceTime.Add(2*time.Hour), 3*time.Hour)
+		defaultEvidenceTime.Add(2*time.Hour), 3*time.Hour, types.CanonicalEncodingProto)
 	assert.Error(t, err)
 
 	// evidence with different total validator power should fail
 	ev.TotalVotingPower = 1 * defaultVotingPower
 	err = evidence.VerifyLightClientAttack(ev, common.SignedHeader, trusted.SignedHeader, common.ValidatorSet,
-		defaultEvidenceTime.Add(2*time.Hour), 3*time.Hour)
+		defaultEvidenceTime.Add(2*time.Hour), 3*time.Hour, types.CanonicalEncodingProto)
 	assert.Error(t, err)
 
 	// evidence without enough malicious votes should fail
 	ev, trusted, common = makeLunaticEvidence(
 		t, height, commonHeight, totalVals, byzVals-1, totalVals-byzVals, defaultEvidenceTime, attackTime)
 	err = evidence.VerifyLightClientAttack(ev, common.SignedHeader, trusted.SignedHeader, common.ValidatorSet,
-		defaultEvidenceTime.Add(2*time.Hour), 3*time.Hour)
+		defaultEvidenceTime.Add(2*time.Hour), 3*time.Hour, types.CanonicalEncodingProto)
 	assert.Error(t, err)
 }
 
@@ -195,7 +197,7 @@ func TestVerifyLightClientAttack_Equivocation(t *testing.T) {
 	trustedHeader := makeHeaderRandom(10)
 
 	conflictingHeader := makeHeaderRandom(10)
-	conflictingHeader.ValidatorsHash = conflictingVals.Hash()
+	conflictingHeader.ValidatorsHash = conflictingVals.Hash(types.CanonicalEncodingProto)
 
 	trustedHeader.ValidatorsHash = conflictingHeader.ValidatorsHash
 	trustedHeader.NextValidatorsHash = conflictingHeader.NextValidatorsHash
@@ -205,8 +207,8 @@ func TestVerifyLightClientAttack_Equivocation(t *testing.T) {
 
 	// we are simulating a duplicate vote attack where all the validators in the conflictingVals set
 	// except the last validator vote twice
-	blockID := makeBlockID(conflictingHeader.Hash(), 1000, []byte("partshash"))
-	voteSet := types.NewVoteSet(evidenceChainID, 10, 1, cmtproto.SignedMsgType(2), conflictingVals)
+	blockID := makeBlockID(conflictingHeader.Hash(types.CanonicalEncodingProto), 1000, []byte("partshash"))
+	voteSet := types.NewVoteSet(evidenceChainID, types.CanonicalEncodingProto, 10, 1, cmtproto.SignedMsgType(2), conflictingVals)
 	commit, err := types.MakeCommit(blockID, 10, 1, voteSet, conflictingPrivVals[:4], defaultEvidenceTime)
 	require.NoError(t, err)
 	ev := &types.LightClientAttackEvidence{
@@ -223,8 +225,8 @@ func TestVerifyLightClientAttack_Equivocation(t *testing.T) {
 		Timestamp:           defaultEvidenceTime,
 	}
 
-	trustedBlockID := makeBlockID(trustedHeader.Hash(), 1000, []byte("partshash"))
-	trustedVoteSet := types.NewVoteSet(evidenceChainID, 10, 1, cmtproto.SignedMsgType(2), conflictingVals)
+	trustedBlockID := makeBlockID(trustedHeader.Hash(types.CanonicalEncodingProto), 1000, []byte("partshash"))
+	trustedVoteSet := types.NewVoteSet(evidenceChainID, types.CanonicalEncodingProto, 10, 1, cmtproto.SignedMsgType(2), conflictingVals)
 	trustedCommit, err := types.MakeCommit(trustedBlockID, 10, 1, trustedVoteSet, conflictingPrivVals, defaultEvidenceTime)
 	require.NoError(t, err)
 	trustedSignedHeader := &types.SignedHeader{
@@ -234,19 +236,19 @@ func TestVerifyLightClientAttack_Equivocation(t *testing.T) {
 
 	// good pass -> no error
 	err = evidence.VerifyLightClientAttack(ev, trustedSignedHeader, trustedSignedHeader, conflictingVals,
-		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour)
+		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour, types.CanonicalEncodingProto)
 	assert.NoError(t, err)
 
 	// trusted and conflicting hashes are the same -> an error should be returned
 	err = evidence.VerifyLightClientAttack(ev, trustedSignedHeader, ev.ConflictingBlock.SignedHeader, conflictingVals,
-		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour)
+		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour, types.CanonicalEncodingProto)
 	assert.Error(t, err)
 
 	// conflicting header has different next validators hash which should have been correctly derived from
 	// the previous round
 	ev.ConflictingBlock.Header.NextValidatorsHash = crypto.CRandBytes(tmhash.Size)
 	err = evidence.VerifyLightClientAttack(ev, trustedSignedHeader, trustedSignedHeader, nil,
-		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour)
+		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour, types.CanonicalEncodingProto)
 	assert.Error(t, err)
 	// revert next validators hash
 	ev.ConflictingBlock.Header.NextValidatorsHash = trustedHeader.NextValidatorsHash
@@ -275,11 +277,87 @@ func TestVerifyLightClientAttack_Equivocation(t *testing.T) {
 	assert.Equal(t, 1, len(pendingEvs))
 }
 
+func TestVerifyLightClientAttack_AggregatedEquivocation(t *testing.T) {
+	conflictingVals, conflictingPrivVals := types.RandBn254ValidatorSet(5, defaultVotingPower)
+	trustedHeader := makeHeaderRandom(10)
+
+	conflictingHeader := makeHeaderRandom(10)
+	conflictingHeader.ValidatorsHash = conflictingVals.Hash(types.CanonicalEncodingProto)
+
+	trustedHeader.ValidatorsHash = conflictingHeader.ValidatorsHash
+	trustedHeader.NextValidatorsHash = conflictingHeader.NextValidatorsHash
+	trustedHeader.ConsensusHash = conflictingHeader.ConsensusHash
+	trustedHeader.AppHash = conflictingHeader.AppHash
+	trustedHeader.LastResultsHash = conflictingHeader.LastResultsHash
+
+	// all the validators but the last vote twice, the conflicting commit
+	// being aggregated, as given to the light clients of aggregated commits
+	blockID := makeBlockID(conflictingHeader.Hash(types.CanonicalEncodingProto), 1000, []byte("partshash"))
+	voteSet := types.NewVoteSet(evidenceChainID, types.CanonicalEncodingProto, 10, 1, cmtproto.SignedMsgType(2), conflictingVals)
+	commit, err := types.MakeCommit(blockID, 10, 1, voteSet, conflictingPrivVals[:4], defaultEvidenceTime)
+	require.NoError(t, err)
+	aggregatedCommit, err := commit.Aggregate()
+	require.NoError(t, err)
+
+	trustedBlockID := makeBlockID(trustedHeader.Hash(types.CanonicalEncodingProto), 1000, []byte("partshash"))
+	trustedVoteSet := types.NewVoteSet(evidenceChainID, types.CanonicalEncodingProto, 10, 1, cmtproto.SignedMsgType(2), conflictingVals)
+	trustedCommit, err := types.MakeCommit(trustedBlockID, 10, 1, trustedVoteSet, conflictingPrivVals, defaultEvidenceTime)
+	require.NoError(t, err)
+	trustedBlock := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: trustedHeader,
+			Commit: trustedCommit,
+		},
+		ValidatorSet: conflictingVals,
+	}
+
+	ev := light.NewAggregatedLightClientAttackEvidence(
+		&types.AggregatedSignedHeader{Header: conflictingHeader, Commit: aggregatedCommit},
+		conflictingVals, trustedBlock, trustedBlock)
+	require.NoError(t, ev.ValidateBasic())
+	assert.ElementsMatch(t, conflictingVals.Validators[:4], ev.ByzantineValidators)
+
+	// good pass -> no error
+	err = evidence.VerifyLightClientAttack(ev, trustedBlock.SignedHeader, trustedBlock.SignedHeader, conflictingVals,
+		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour, types.CanonicalEncodingProto)
+	assert.NoError(t, err)
+
+	// a signer which didn't sign -> the aggregated signature doesn't verify
+	forged := *ev
+	forgedCommit := *aggregatedCommit
+	forgedCommit.Signers = []byte{0x1f}
+	forgedCommit.Timestamps = append(forgedCommit.Timestamps, defaultEvidenceTime)
+	forged.ConflictingAggregatedCommit = &forgedCommit
+	forged.ByzantineValidators = conflictingVals.Validators
+	err = evidence.VerifyLightClientAttack(&forged, trustedBlock.SignedHeader, trustedBlock.SignedHeader,
+		conflictingVals, defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour, types.CanonicalEncodingProto)
+	assert.Error(t, err)
+
+	state := sm.State{
+		LastBlockTime:   defaultEvidenceTime.Add(1 * time.Minute),
+		LastBlockHeight: 11,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", int64(10)).Return(conflictingVals, nil)
+	stateStore.On("Load").Return(state, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: *trustedHeader})
+	blockStore.On("LoadBlockCommit", int64(10)).Return(trustedCommit)
+
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	err = pool.CheckEvidence(types.EvidenceList{ev})
+	assert.NoError(t, err)
+}
+
 func TestVerifyLightClientAttack_Amnesia(t *testing.T) {
 	conflictingVals, conflictingPrivVals := types.RandValidatorSet(5, 10)
 
 	conflictingHeader := makeHeaderRandom(10)
-	conflictingHeader.ValidatorsHash = conflictingVals.Hash()
+	conflictingHeader.ValidatorsHash = conflictingVals.Hash(types.CanonicalEncodingProto)
 	trustedHeader := makeHeaderRandom(10)
 	trustedHeader.ValidatorsHash = conflictingHeader.ValidatorsHash
 	trustedHeader.NextValidatorsHash = conflictingHeader.NextValidatorsHash
@@ -289,8 +367,8 @@ func TestVerifyLightClientAttack_Amnesia(t *testing.T) {
 
 	// we are simulating an amnesia attack where all the validators in the conflictingVals set
 	// except the last validator vote twice. However this time the commits are of different rounds.
-	blockID := makeBlockID(conflictingHeader.Hash(), 1000, []byte("partshash"))
-	voteSet := types.NewVoteSet(evidenceChainID, 10, 0, cmtproto.SignedMsgType(2), conflictingVals)
+	blockID := makeBlockID(conflictingHeader.Hash(types.CanonicalEncodingProto), 1000, []byte("partshash"))
+	voteSet := types.NewVoteSet(evidenceChainID, types.CanonicalEncodingProto, 10, 0, cmtproto.SignedMsgType(2), conflictingVals)
 	commit, err := types.MakeCommit(blockID, 10, 0, voteSet, conflictingPrivVals, defaultEvidenceTime)
 	require.NoError(t, err)
 	ev := &types.LightClientAttackEvidence{
@@ -307,8 +385,8 @@ func TestVerifyLightClientAttack_Amnesia(t *testing.T) {
 		Timestamp:           defaultEvidenceTime,
 	}
 
-	trustedBlockID := makeBlockID(trustedHeader.Hash(), 1000, []byte("partshash"))
-	trustedVoteSet := types.NewVoteSet(evidenceChainID, 10, 1, cmtproto.SignedMsgType(2), conflictingVals)
+	trustedBlockID := makeBlockID(trustedHeader.Hash(types.CanonicalEncodingProto), 1000, []byte("partshash"))
+	trustedVoteSet := types.NewVoteSet(evidenceChainID, types.CanonicalEncodingProto, 10, 1, cmtproto.SignedMsgType(2), conflictingVals)
 	trustedCommit, err := types.MakeCommit(trustedBlockID, 10, 1, trustedVoteSet, conflictingPrivVals, defaultEvidenceTime)
 	require.NoError(t, err)
 	trustedSignedHeader := &types.SignedHeader{
@@ -318,12 +396,12 @@ func TestVerifyLightClientAttack_Amnesia(t *testing.T) {
 
 	// good pass -> no error
 	err = evidence.VerifyLightClientAttack(ev, trustedSignedHeader, trustedSignedHeader, conflictingVals,
-		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour)
+		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour, types.CanonicalEncodingProto)
 	assert.NoError(t, err)
 
 	// trusted and conflicting hashes are the same -> an error should be returned
 	err = evidence.VerifyLightClientAttack(ev, trustedSignedHeader, ev.ConflictingBlock.SignedHeader, conflictingVals,
-		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour)
+		defaultEvidenceTime.Add(1*time.Minute), 2*time.Hour, types.CanonicalEncodingProto)
 	assert.Error(t, err)
 
 	state := sm.State{
@@ -370,11 +448,11 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 
 	vote1 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultEvidenceTime)
 	v1 := vote1.ToProto()
-	err := val.SignVote(chainID, v1)
+	err := val.SignVote(chainID, types.CanonicalEncodingProto, v1)
 	require.NoError(t, err)
 	badVote := makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultEvidenceTime)
 	bv := badVote.ToProto()
-	err = val2.SignVote(chainID, bv)
+	err = val2.SignVote(chainID, types.CanonicalEncodingProto, bv)
 	require.NoError(t, err)
 
 	vote1.Signature = v1.Signature
@@ -405,9 +483,9 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 			Timestamp:        defaultEvidenceTime,
 		}
 		if c.valid {
-			assert.Nil(t, evidence.VerifyDuplicateVote(ev, chainID, valSet), "evidence should be valid")
+			assert.Nil(t, evidence.VerifyDuplicateVote(ev, chainID, types.CanonicalEncodingProto, valSet), "evidence should be valid")
 		} else {
-			assert.NotNil(t, evidence.VerifyDuplicateVote(ev, chainID, valSet), "evidence should be invalid")
+			assert.NotNil(t, evidence.VerifyDuplicateVote(ev, chainID, types.CanonicalEncodingProto, valSet), "evidence should be invalid")
 		}
 	}
 
@@ -452,6 +530,58 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVerifyBn254DuplicateVoteEvidence(t *testing.T) {
+	val := types.NewMockPVWithParams(bn254.GenPrivKey(), false, false)
+	val2 := types.NewMockPVWithParams(bn254.GenPrivKey(), false, false)
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(1)})
+
+	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+
+	const chainID = "mychain"
+
+	vote1 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultEvidenceTime)
+	badVote := makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime)
+	bv := badVote.ToProto()
+	require.NoError(t, val2.SignVote(chainID, types.CanonicalEncodingProto, bv))
+	badVote.Signature = bv.Signature
+
+	cases := []voteData{
+		{vote1, makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime), true},
+		{vote1, makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultEvidenceTime), false},     // wrong block id
+		{vote1, makeVote(t, val, "mychain2", 0, 10, 2, 1, blockID2, defaultEvidenceTime), false}, // wrong chain id
+		{vote1, makeVote(t, val2, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime), false},   // wrong validator
+		{vote1, badVote, false}, // signed by wrong key
+	}
+	for _, c := range cases {
+		ev := &types.Bn254DuplicateVoteEvidence{DuplicateVoteEvidence: types.DuplicateVoteEvidence{
+			VoteA:            c.vote1,
+			VoteB:            c.vote2,
+			ValidatorPower:   1,
+			TotalVotingPower: 1,
+			Timestamp:        defaultEvidenceTime,
+		}}
+		if c.valid {
+			assert.NoError(t, evidence.VerifyBn254DuplicateVote(ev, chainID, types.CanonicalEncodingProto, valSet), "evidence should be valid")
+		} else {
+			assert.Error(t, evidence.VerifyBn254DuplicateVote(ev, chainID, types.CanonicalEncodingProto, valSet), "evidence should be invalid")
+		}
+	}
+
+	// the evidence of an ed25519 validator is not bn254 evidence
+	edVal := types.NewMockPV()
+	edValSet := types.NewValidatorSet([]*types.Validator{edVal.ExtractIntoValidator(1)})
+	ev := &types.Bn254DuplicateVoteEvidence{DuplicateVoteEvidence: types.DuplicateVoteEvidence{
+		VoteA:            makeVote(t, edVal, chainID, 0, 10, 2, 1, blockID, defaultEvidenceTime),
+		VoteB:            makeVote(t, edVal, chainID, 0, 10, 2, 1, blockID2, defaultEvidenceTime),
+		ValidatorPower:   1,
+		TotalVotingPower: 1,
+		Timestamp:        defaultEvidenceTime,
+	}}
+	assert.NoError(t, evidence.VerifyDuplicateVote(&ev.DuplicateVoteEvidence, chainID, types.CanonicalEncodingProto, edValSet))
+	assert.Error(t, evidence.VerifyBn254DuplicateVote(ev, chainID, types.CanonicalEncodingProto, edValSet))
+}
+
 func makeLunaticEvidence(
 	t *testing.T,
 	height, commonHeight int64,
@@ -479,10 +609,10 @@ func makeLunaticEvidence(
 
 	conflictingHeader := makeHeaderRandom(height)
 	conflictingHeader.Time = attackTime
-	conflictingHeader.ValidatorsHash = conflictingVals.Hash()
+	conflictingHeader.ValidatorsHash = conflictingVals.Hash(types.CanonicalEncodingProto)
 
-	blockID := makeBlockID(conflictingHeader.Hash(), 1000, []byte("partshash"))
-	voteSet := types.NewVoteSet(evidenceChainID, height, 1, cmtproto.SignedMsgType(2), conflictingVals)
+	blockID := makeBlockID(conflictingHeader.Hash(types.CanonicalEncodingProto), 1000, []byte("partshash"))
+	voteSet := types.NewVoteSet(evidenceChainID, types.CanonicalEncodingProto, height, 1, cmtproto.SignedMsgType(2), conflictingVals)
 	commit, err := types.MakeCommit(blockID, height, 1, voteSet, conflictingPrivVals, defaultEvidenceTime)
 	require.NoError(t, err)
 	ev = &types.LightClientAttackEvidence{
@@ -507,9 +637,9 @@ func makeLunaticEvidence(
 		},
 		ValidatorSet: commonValSet,
 	}
-	trustedBlockID := makeBlockID(trustedHeader.Hash(), 1000, []byte("partshash"))
+	trustedBlockID := makeBlockID(trustedHeader.Hash(types.CanonicalEncodingProto), 1000, []byte("partshash"))
 	trustedVals, privVals := types.RandValidatorSet(totalVals, defaultVotingPower)
-	trustedVoteSet := types.NewVoteSet(evidenceChainID, height, 1, cmtproto.SignedMsgType(2), trustedVals)
+	trustedVoteSet := types.NewVoteSet(evidenceChainID, types.CanonicalEncodingProto, height, 1, cmtproto.SignedMsgType(2), trustedVals)
 	trustedCommit, err := types.MakeCommit(trustedBlockID, height, 1, trustedVoteSet, privVals, defaultEvidenceTime)
 	require.NoError(t, err)
 	trusted = &types.LightBlock{
@@ -547,7 +677,7 @@ func makeVote(
 	}
 
 	vpb := v.ToProto()
-	err = val.SignVote(chainID, vpb)
+	err = val.SignVote(chainID, types.CanonicalEncodingProto, vpb)
 	if err != nil {
 		panic(err)
 	}
@@ -51,26 +51,31 @@ type Inspector struct {
 //
 //nolint:lll
 func New(cfg *config.RPCConfig, bs state.BlockStore, ss state.Store, txidx txindex.TxIndexer, blkidx indexer.BlockIndexer, lg log.Logger) *Inspector {
-	routes := rpc.Routes(*cfg, ss, bs, txidx, blkidx, logger)
-	eb := types.NewEventBus()
-	eb.SetLogger(logger.With("module", "events"))
+	routes := rpc.Routes(*cfg, ss, bs, txidx, blkidx, lg)
 	return &Inspector{
 		routes: routes,
 		config: cfg,
-		logger: logger,
+		logger: lg,
 		ss:     ss,
 		bs:     bs,
 	}
 }
 
 // NewFromConfig constructs an Inspector using the values defined in the passed in config.
+// The databases are opened read-only: the writes fail with ErrReadOnly.
 func NewFromConfig(cfg *config.Config) (*Inspector, error) {
-	bsDB, err := config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: cfg})
+	return NewFromConfigWithLogger(cfg, logger.With("module", "inspect"))
+}
+
+// NewFromConfigWithLogger is NewFromConfig with the given logger.
+func NewFromConfigWithLogger(cfg *config.Config, lg log.Logger) (*Inspector, error) {
+	dbProvider := readOnlyDBProvider(config.DefaultDBProvider)
+	bsDB, err := dbProvider(&config.DBContext{ID: "blockstore", Config: cfg})
 	if err != nil {
 		return nil, err
 	}
 	bs := store.NewBlockStore(bsDB)
-	sDB, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: cfg})
+	sDB, err := dbProvider(&config.DBContext{ID: "state", Config: cfg})
 	if err != nil {
 		return nil, err
 	}
@@ -78,11 +83,10 @@ func NewFromConfig(cfg *config.Config) (*Inspector, error) {
 	if err != nil {
 		return nil, err
 	}
-	txidx, blkidx, err := block.IndexerFromConfig(cfg, config.DefaultDBProvider, genDoc.ChainID)
+	txidx, blkidx, err := block.IndexerFromConfig(cfg, dbProvider, genDoc.ChainID)
 	if err != nil {
 		return nil, err
 	}
-	lg := logger.With("module", "inspect")
 	ss := state.NewStore(sDB, state.StoreOptions{})
 	return New(cfg.RPC, bs, ss, txidx, blkidx, lg), nil
 }
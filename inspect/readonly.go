@@ -0,0 +1,57 @@
+package inspect
+
+import (
+	"errors"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+// ErrReadOnly is returned when writing to the databases of the Inspector,
+// which are opened read-only.
+var ErrReadOnly = errors.New("the database is opened read-only")
+
+// readOnlyDB rejects the writes to the underlying database, so that the data
+// of the node under inspection can't be altered by mistake.
+type readOnlyDB struct {
+	dbm.DB
+}
+
+var _ dbm.DB = readOnlyDB{}
+
+// readOnlyDBProvider returns a DBProvider opening the databases of provider
+// read-only.
+func readOnlyDBProvider(provider config.DBProvider) config.DBProvider {
+	return func(ctx *config.DBContext) (dbm.DB, error) {
+		db, err := provider(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return readOnlyDB{DB: db}, nil
+	}
+}
+
+// Unwrap returns the underlying database.
+func (db readOnlyDB) Unwrap() dbm.DB {
+	return db.DB
+}
+
+func (readOnlyDB) Set([]byte, []byte) error     { return ErrReadOnly }
+func (readOnlyDB) SetSync([]byte, []byte) error { return ErrReadOnly }
+func (readOnlyDB) Delete([]byte) error          { return ErrReadOnly }
+func (readOnlyDB) DeleteSync([]byte) error      { return ErrReadOnly }
+
+func (db readOnlyDB) NewBatch() dbm.Batch {
+	return readOnlyBatch{Batch: db.DB.NewBatch()}
+}
+
+// readOnlyBatch rejects the writes of a batch.
+type readOnlyBatch struct {
+	dbm.Batch
+}
+
+func (readOnlyBatch) Set([]byte, []byte) error { return ErrReadOnly }
+func (readOnlyBatch) Delete([]byte) error      { return ErrReadOnly }
+func (readOnlyBatch) Write() error             { return ErrReadOnly }
+func (readOnlyBatch) WriteSync() error         { return ErrReadOnly }
@@ -0,0 +1,35 @@
+package inspect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+func TestReadOnlyDB(t *testing.T) {
+	memDB := dbm.NewMemDB()
+	require.NoError(t, memDB.Set([]byte("key"), []byte("value")))
+	db, err := readOnlyDBProvider(func(*config.DBContext) (dbm.DB, error) {
+		return memDB, nil
+	})(&config.DBContext{ID: "state"})
+	require.NoError(t, err)
+
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	require.ErrorIs(t, db.Set([]byte("key"), []byte("other")), ErrReadOnly)
+	require.ErrorIs(t, db.DeleteSync([]byte("key")), ErrReadOnly)
+	batch := db.NewBatch()
+	require.ErrorIs(t, batch.Delete([]byte("key")), ErrReadOnly)
+	require.ErrorIs(t, batch.WriteSync(), ErrReadOnly)
+	require.NoError(t, batch.Close())
+
+	value, err = db.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+}
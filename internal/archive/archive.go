@@ -0,0 +1,31 @@
+// Package archive implements the tar files shared by the snapshot and block
+// archives of the node.
+package archive
+
+import (
+	"archive/tar"
+	"fmt"
+)
+
+// ChecksumRecord is the PAX record holding the hex encoded SHA-256 checksum of
+// the body of a file.
+const ChecksumRecord = "COMETBFT.sha256"
+
+// WriteFile writes a regular file with the given body and PAX records to tw.
+func WriteFile(tw *tar.Writer, name string, body []byte, records map[string]string) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag:   tar.TypeReg,
+		Name:       name,
+		Mode:       0o600,
+		Size:       int64(len(body)),
+		Format:     tar.FormatPAX,
+		PAXRecords: records,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %v: %w", name, err)
+	}
+	if _, err := tw.Write(body); err != nil {
+		return fmt.Errorf("failed to write %v: %w", name, err)
+	}
+	return nil
+}
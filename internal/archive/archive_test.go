@@ -0,0 +1,35 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, WriteFile(tw, "a", []byte("hello"), map[string]string{ChecksumRecord: "1"}))
+	require.NoError(t, WriteFile(tw, "b", nil, nil))
+	require.NoError(t, tw.Close())
+
+	tr := tar.NewReader(&buf)
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "a", hdr.Name)
+	require.Equal(t, "1", hdr.PAXRecords[ChecksumRecord])
+	body, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), body)
+
+	hdr, err = tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "b", hdr.Name)
+	require.Zero(t, hdr.Size)
+
+	_, err = tr.Next()
+	require.Equal(t, io.EOF, err)
+}
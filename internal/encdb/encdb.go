@@ -0,0 +1,228 @@
+// Package encdb implements the encryption at rest of the values of a
+// database, with AES-256-GCM.
+//
+// Only the values are encrypted: the keys are left as is, so that they remain
+// ordered for the iterators. The keys of the databases of the node hold
+// heights, hashes and the like, but no data of the chain on their own.
+//
+// Every value is encrypted with a random nonce, and authenticated along with
+// its key, so that values can't be swapped between keys.
+package encdb
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// KeySize is the size of the encryption keys.
+const KeySize = 32
+
+// ErrDecryption is returned when a value can't be decrypted: either the
+// database is not encrypted, or it is encrypted with another key, or the value
+// is corrupted.
+var ErrDecryption = errors.New("failed to decrypt value, the database may not be encrypted with this key")
+
+// DB encrypts the values of the underlying database.
+type DB struct {
+	db   dbm.DB
+	aead cipher.AEAD
+}
+
+var _ dbm.DB = (*DB)(nil)
+
+// New returns a DB encrypting the values of db with key, which must be
+// KeySize bytes long.
+func New(db dbm.DB, key []byte) (*DB, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes long, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &DB{db: db, aead: aead}, nil
+}
+
+// CheckKey checks that the database is encrypted with the key of db, by
+// decrypting its first value, if any. It returns ErrDecryption if the value
+// can't be decrypted, e.g. if the database was written without encryption or
+// with another key, so that this is found out when opening the database rather
+// than when reading from it.
+func (db *DB) CheckKey() error {
+	itr, err := db.db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	if !itr.Valid() {
+		return itr.Error()
+	}
+	if _, err := db.decrypt(itr.Key(), itr.Value()); err != nil {
+		return fmt.Errorf("key %X: %w", itr.Key(), err)
+	}
+	return nil
+}
+
+// Unwrap returns the underlying database.
+func (db *DB) Unwrap() dbm.DB {
+	return db.db
+}
+
+func (db *DB) encrypt(key, value []byte) ([]byte, error) {
+	nonce := make([]byte, db.aead.NonceSize(), db.aead.NonceSize()+len(value)+db.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return db.aead.Seal(nonce, nonce, value, key), nil
+}
+
+func (db *DB) decrypt(key, value []byte) ([]byte, error) {
+	if len(value) < db.aead.NonceSize() {
+		return nil, ErrDecryption
+	}
+	nonce, ciphertext := value[:db.aead.NonceSize()], value[db.aead.NonceSize():]
+	plaintext, err := db.aead.Open(nil, nonce, ciphertext, key)
+	if err != nil {
+		return nil, ErrDecryption
+	}
+	if plaintext == nil {
+		// empty values are distinct from missing ones
+		plaintext = []byte{}
+	}
+	return plaintext, nil
+}
+
+// Get implements DB.
+func (db *DB) Get(key []byte) ([]byte, error) {
+	value, err := db.db.Get(key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return db.decrypt(key, value)
+}
+
+// Has implements DB.
+func (db *DB) Has(key []byte) (bool, error) {
+	return db.db.Has(key)
+}
+
+// Set implements DB.
+func (db *DB) Set(key, value []byte) error {
+	ciphertext, err := db.encryptValue(key, value)
+	if err != nil {
+		return err
+	}
+	return db.db.Set(key, ciphertext)
+}
+
+// SetSync implements DB.
+func (db *DB) SetSync(key, value []byte) error {
+	ciphertext, err := db.encryptValue(key, value)
+	if err != nil {
+		return err
+	}
+	return db.db.SetSync(key, ciphertext)
+}
+
+// encryptValue encrypts a value to set, leaving the validation of the
+// arguments to the underlying database.
+func (db *DB) encryptValue(key, value []byte) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return db.encrypt(key, value)
+}
+
+// Delete implements DB.
+func (db *DB) Delete(key []byte) error {
+	return db.db.Delete(key)
+}
+
+// DeleteSync implements DB.
+func (db *DB) DeleteSync(key []byte) error {
+	return db.db.DeleteSync(key)
+}
+
+// Iterator implements DB.
+func (db *DB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	itr, err := db.db.Iterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &iterator{Iterator: itr, db: db}, nil
+}
+
+// ReverseIterator implements DB.
+func (db *DB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	itr, err := db.db.ReverseIterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &iterator{Iterator: itr, db: db}, nil
+}
+
+// Close implements DB.
+func (db *DB) Close() error {
+	return db.db.Close()
+}
+
+// NewBatch implements DB.
+func (db *DB) NewBatch() dbm.Batch {
+	return &batch{Batch: db.db.NewBatch(), db: db}
+}
+
+// Print implements DB.
+func (db *DB) Print() error {
+	itr, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", itr.Key(), itr.Value())
+	}
+	return itr.Error()
+}
+
+// Stats implements DB.
+func (db *DB) Stats() map[string]string {
+	return db.db.Stats()
+}
+
+type iterator struct {
+	dbm.Iterator
+	db *DB
+}
+
+// Value implements Iterator. It panics if the value can't be decrypted, as
+// the backends do on corrupted data.
+func (itr *iterator) Value() []byte {
+	value, err := itr.db.decrypt(itr.Key(), itr.Iterator.Value())
+	if err != nil {
+		panic(fmt.Errorf("key %X: %w", itr.Key(), err))
+	}
+	return value
+}
+
+type batch struct {
+	dbm.Batch
+	db *DB
+}
+
+// Set implements Batch.
+func (b *batch) Set(key, value []byte) error {
+	ciphertext, err := b.db.encryptValue(key, value)
+	if err != nil {
+		return err
+	}
+	return b.Batch.Set(key, ciphertext)
+}
@@ -0,0 +1,141 @@
+package encdb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func TestDB(t *testing.T) {
+	raw := dbm.NewMemDB()
+	db, err := New(raw, testKey(1))
+	require.NoError(t, err)
+
+	require.NoError(t, db.Set([]byte("a"), []byte("value a")))
+	require.NoError(t, db.SetSync([]byte("b"), []byte{}))
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("c"), []byte("value c")))
+	require.NoError(t, batch.Set([]byte("d"), []byte("value d")))
+	require.NoError(t, batch.Delete([]byte("d")))
+	require.NoError(t, batch.WriteSync())
+	require.NoError(t, batch.Close())
+
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value a"), value)
+	value, err = db.Get([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{}, value)
+	value, err = db.Get([]byte("d"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// the values are encrypted, not the keys
+	value, err = raw.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(value), "value a")
+
+	itr, err := db.ReverseIterator(nil, nil)
+	require.NoError(t, err)
+	var keys, values []string
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, string(itr.Key()))
+		values = append(values, string(itr.Value()))
+	}
+	require.NoError(t, itr.Close())
+	assert.Equal(t, []string{"c", "b", "a"}, keys)
+	assert.Equal(t, []string{"value c", "", "value a"}, values)
+
+	require.NoError(t, db.Delete([]byte("a")))
+	ok, err := db.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.Error(t, db.Set([]byte("a"), nil))
+	require.Error(t, db.Set(nil, []byte("value")))
+}
+
+func TestDBDecryptionFailures(t *testing.T) {
+	raw := dbm.NewMemDB()
+	db, err := New(raw, testKey(1))
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("a"), []byte("value a")))
+	require.NoError(t, db.Set([]byte("b"), []byte("value b")))
+
+	// another key
+	other, err := New(raw, testKey(2))
+	require.NoError(t, err)
+	_, err = other.Get([]byte("a"))
+	assert.ErrorIs(t, err, ErrDecryption)
+
+	// the key is checked against the first value
+	require.NoError(t, db.CheckKey())
+	assert.ErrorIs(t, other.CheckKey(), ErrDecryption)
+	empty, err := New(dbm.NewMemDB(), testKey(2))
+	require.NoError(t, err)
+	require.NoError(t, empty.CheckKey())
+
+	// values moved to another key
+	bz, err := raw.Get([]byte("b"))
+	require.NoError(t, err)
+	require.NoError(t, raw.Set([]byte("a"), bz))
+	_, err = db.Get([]byte("a"))
+	assert.ErrorIs(t, err, ErrDecryption)
+
+	// values which are not encrypted
+	require.NoError(t, raw.Set([]byte("c"), []byte("plain")))
+	_, err = db.Get([]byte("c"))
+	assert.ErrorIs(t, err, ErrDecryption)
+	itr, err := db.Iterator([]byte("c"), nil)
+	require.NoError(t, err)
+	defer itr.Close()
+	assert.Panics(t, func() { itr.Value() })
+
+	_, err = New(raw, testKey(1)[:16])
+	require.Error(t, err)
+}
+
+func TestLoadKey(t *testing.T) {
+	key := testKey(3)
+	encoded := hex.EncodeToString(key)
+
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte(encoded+"\n"), 0o600))
+	t.Setenv("ENCDB_TEST_KEY", encoded)
+
+	for _, source := range []string{
+		KeySourceFile + path,
+		KeySourceEnv + "ENCDB_TEST_KEY",
+		KeySourceCmd + "echo " + encoded,
+	} {
+		assert.True(t, ValidKeySource(source), source)
+		loaded, err := LoadKey(source)
+		require.NoError(t, err, source)
+		assert.Equal(t, key, loaded, source)
+	}
+
+	for _, source := range []string{
+		"",
+		path,
+		KeySourceFile,
+		KeySourceFile + filepath.Join(t.TempDir(), "missing"),
+		KeySourceEnv + "ENCDB_TEST_MISSING_KEY",
+		KeySourceCmd + "echo " + encoded[:32],
+		KeySourceCmd + "echo not hex",
+		KeySourceCmd + "exit 1",
+	} {
+		_, err := LoadKey(source)
+		assert.Error(t, err, source)
+	}
+}
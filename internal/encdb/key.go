@@ -0,0 +1,75 @@
+package encdb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Key sources, see LoadKey.
+const (
+	KeySourceFile = "file:"
+	KeySourceEnv  = "env:"
+	KeySourceCmd  = "cmd:"
+)
+
+// LoadKey loads a hex-encoded encryption key from the given source, which is
+// one of:
+//
+//   - "file:<path>": the content of the file;
+//   - "env:<name>": the value of the environment variable;
+//   - "cmd:<command>": the output of the command, run by the shell, e.g. to
+//     decrypt the key with a key management service.
+func LoadKey(source string) ([]byte, error) {
+	var (
+		encoded []byte
+		err     error
+	)
+	switch {
+	case strings.HasPrefix(source, KeySourceFile):
+		encoded, err = os.ReadFile(strings.TrimPrefix(source, KeySourceFile))
+	case strings.HasPrefix(source, KeySourceEnv):
+		name := strings.TrimPrefix(source, KeySourceEnv)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", name)
+		}
+		encoded = []byte(value)
+	case strings.HasPrefix(source, KeySourceCmd):
+		var stderr bytes.Buffer
+		cmd := exec.Command("sh", "-c", strings.TrimPrefix(source, KeySourceCmd)) //nolint:gosec
+		cmd.Stderr = &stderr
+		encoded, err = cmd.Output()
+		if err != nil {
+			err = fmt.Errorf("%w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+	default:
+		return nil, fmt.Errorf("invalid encryption key source %q, expected %s, %s or %s",
+			source, KeySourceFile, KeySourceEnv, KeySourceCmd)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the encryption key: %w", err)
+	}
+
+	key, err := hex.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be hex-encoded: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes long, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// ValidKeySource returns whether source is of a known kind, see LoadKey.
+func ValidKeySource(source string) bool {
+	for _, prefix := range []string{KeySourceFile, KeySourceEnv, KeySourceCmd} {
+		if strings.HasPrefix(source, prefix) && len(source) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,327 @@
+//go:build pebbledb
+// +build pebbledb
+
+// Package pebbledb implements the cometbft-db DB interface on top of pebble
+// (github.com/cockroachdb/pebble), which doesn't suffer from the write stalls
+// of goleveldb under sustained write load.
+package pebbledb
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// Enabled reports whether the pebbledb backend is compiled in.
+const Enabled = true
+
+var (
+	errBatchClosed = errors.New("batch has been written or closed")
+	errKeyEmpty    = errors.New("key cannot be empty")
+	errValueNil    = errors.New("value cannot be nil")
+)
+
+// PebbleDB is a DB backed by pebble.
+type PebbleDB struct {
+	db *pebble.DB
+}
+
+var _ dbm.DB = (*PebbleDB)(nil)
+
+// New opens (or creates) the database name in dir with the default options.
+func New(name, dir string) (*PebbleDB, error) {
+	return NewWithOptions(name, dir, &pebble.Options{})
+}
+
+// NewWithOptions opens (or creates) the database name in dir with the given
+// options.
+func NewWithOptions(name, dir string, opts *pebble.Options) (*PebbleDB, error) {
+	db, err := pebble.Open(filepath.Join(dir, name+".db"), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &PebbleDB{db: db}, nil
+}
+
+// DB returns the underlying pebble database.
+func (db *PebbleDB) DB() *pebble.DB {
+	return db.db
+}
+
+// Get implements DB.
+func (db *PebbleDB) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errKeyEmpty
+	}
+	value, closer, err := db.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer closer.Close()
+	return cp(value), nil
+}
+
+// Has implements DB.
+func (db *PebbleDB) Has(key []byte) (bool, error) {
+	bz, err := db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return bz != nil, nil
+}
+
+// Set implements DB.
+func (db *PebbleDB) Set(key []byte, value []byte) error {
+	return db.set(key, value, pebble.NoSync)
+}
+
+// SetSync implements DB.
+func (db *PebbleDB) SetSync(key []byte, value []byte) error {
+	return db.set(key, value, pebble.Sync)
+}
+
+func (db *PebbleDB) set(key []byte, value []byte, opts *pebble.WriteOptions) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	return db.db.Set(key, value, opts)
+}
+
+// Delete implements DB.
+func (db *PebbleDB) Delete(key []byte) error {
+	return db.delete(key, pebble.NoSync)
+}
+
+// DeleteSync implements DB.
+func (db *PebbleDB) DeleteSync(key []byte) error {
+	return db.delete(key, pebble.Sync)
+}
+
+func (db *PebbleDB) delete(key []byte, opts *pebble.WriteOptions) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	return db.db.Delete(key, opts)
+}
+
+// Compact compacts the whole key space, reclaiming the disk space of the
+// deleted keys.
+func (db *PebbleDB) Compact() error {
+	itr, err := db.db.NewIter(nil)
+	if err != nil {
+		return err
+	}
+	var start, end []byte
+	if itr.First() {
+		start = cp(itr.Key())
+	}
+	if itr.Last() {
+		// the end of the range is exclusive
+		end = append(cp(itr.Key()), 0x00)
+	}
+	if err := itr.Close(); err != nil {
+		return err
+	}
+	if start == nil {
+		return nil
+	}
+	return db.db.Compact(start, end, true)
+}
+
+// Close implements DB.
+func (db *PebbleDB) Close() error {
+	return db.db.Close()
+}
+
+// Print implements DB.
+func (db *PebbleDB) Print() error {
+	itr, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", itr.Key(), itr.Value())
+	}
+	return itr.Error()
+}
+
+// Stats implements DB.
+func (db *PebbleDB) Stats() map[string]string {
+	m := db.db.Metrics()
+	return map[string]string{
+		"pebble.metrics":            m.String(),
+		"pebble.disk_space_usage":   fmt.Sprint(m.DiskSpaceUsage()),
+		"pebble.compactions":        fmt.Sprint(m.Compact.Count),
+		"pebble.memtable_size":      fmt.Sprint(m.MemTable.Size),
+		"pebble.read_amplification": fmt.Sprint(m.ReadAmp()),
+	}
+}
+
+// NewBatch implements DB.
+func (db *PebbleDB) NewBatch() dbm.Batch {
+	return &pebbleBatch{batch: db.db.NewBatch()}
+}
+
+// Iterator implements DB.
+func (db *PebbleDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	return db.newIterator(start, end, false)
+}
+
+// ReverseIterator implements DB.
+func (db *PebbleDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	return db.newIterator(start, end, true)
+}
+
+func (db *PebbleDB) newIterator(start, end []byte, isReverse bool) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	source, err := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	if err != nil {
+		return nil, err
+	}
+	if isReverse {
+		source.Last()
+	} else {
+		source.First()
+	}
+	return &pebbleIterator{source: source, start: start, end: end, isReverse: isReverse}, nil
+}
+
+type pebbleBatch struct {
+	batch *pebble.Batch
+}
+
+var _ dbm.Batch = (*pebbleBatch)(nil)
+
+// Set implements Batch.
+func (b *pebbleBatch) Set(key, value []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	if b.batch == nil {
+		return errBatchClosed
+	}
+	return b.batch.Set(key, value, nil)
+}
+
+// Delete implements Batch.
+func (b *pebbleBatch) Delete(key []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if b.batch == nil {
+		return errBatchClosed
+	}
+	return b.batch.Delete(key, nil)
+}
+
+// Write implements Batch.
+func (b *pebbleBatch) Write() error {
+	return b.write(pebble.NoSync)
+}
+
+// WriteSync implements Batch.
+func (b *pebbleBatch) WriteSync() error {
+	return b.write(pebble.Sync)
+}
+
+func (b *pebbleBatch) write(opts *pebble.WriteOptions) error {
+	if b.batch == nil {
+		return errBatchClosed
+	}
+	if err := b.batch.Commit(opts); err != nil {
+		return err
+	}
+	// Make sure batch cannot be used afterwards. Callers should still call Close(), for errors.
+	return b.Close()
+}
+
+// Close implements Batch.
+func (b *pebbleBatch) Close() error {
+	if b.batch != nil {
+		err := b.batch.Close()
+		b.batch = nil
+		return err
+	}
+	return nil
+}
+
+type pebbleIterator struct {
+	source    *pebble.Iterator
+	start     []byte
+	end       []byte
+	isReverse bool
+}
+
+var _ dbm.Iterator = (*pebbleIterator)(nil)
+
+// Domain implements Iterator.
+func (itr *pebbleIterator) Domain() ([]byte, []byte) {
+	return itr.start, itr.end
+}
+
+// Valid implements Iterator. The bounds are enforced by pebble.
+func (itr *pebbleIterator) Valid() bool {
+	return itr.source.Valid()
+}
+
+// Next implements Iterator.
+func (itr *pebbleIterator) Next() {
+	itr.assertIsValid()
+	if itr.isReverse {
+		itr.source.Prev()
+	} else {
+		itr.source.Next()
+	}
+}
+
+// Key implements Iterator.
+func (itr *pebbleIterator) Key() []byte {
+	itr.assertIsValid()
+	return cp(itr.source.Key())
+}
+
+// Value implements Iterator.
+func (itr *pebbleIterator) Value() []byte {
+	itr.assertIsValid()
+	return cp(itr.source.Value())
+}
+
+// Error implements Iterator.
+func (itr *pebbleIterator) Error() error {
+	return itr.source.Error()
+}
+
+// Close implements Iterator.
+func (itr *pebbleIterator) Close() error {
+	return itr.source.Close()
+}
+
+func (itr *pebbleIterator) assertIsValid() {
+	if !itr.Valid() {
+		panic("iterator is invalid")
+	}
+}
+
+// cp copies the buffers returned by pebble, which are only valid until the
+// next operation.
+func cp(bz []byte) []byte {
+	ret := make([]byte, len(bz))
+	copy(ret, bz)
+	return ret
+}
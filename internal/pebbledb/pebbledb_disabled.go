@@ -0,0 +1,18 @@
+//go:build !pebbledb
+// +build !pebbledb
+
+package pebbledb
+
+import (
+	"errors"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// Enabled reports whether the pebbledb backend is compiled in.
+const Enabled = false
+
+// New returns an error: the pebbledb backend requires the pebbledb build tag.
+func New(name, dir string) (dbm.DB, error) {
+	return nil, errors.New("the pebbledb backend is not compiled in, build with -tags pebbledb")
+}
@@ -0,0 +1,212 @@
+//go:build pebbledb
+// +build pebbledb
+
+package pebbledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+func newTestDB(t *testing.T) *PebbleDB {
+	db, err := New("test", t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	return db
+}
+
+func TestGetSetDelete(t *testing.T) {
+	db := newTestDB(t)
+
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	ok, err := db.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, db.Set([]byte("a"), []byte{0x01}))
+	require.NoError(t, db.SetSync([]byte("b"), []byte{}))
+
+	value, err = db.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01}, value)
+	// empty values are distinct from missing ones
+	value, err = db.Get([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{}, value)
+	ok, err = db.Has([]byte("b"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, db.Delete([]byte("a")))
+	require.NoError(t, db.DeleteSync([]byte("b")))
+	// deleting a missing key is not an error
+	require.NoError(t, db.Delete([]byte("c")))
+	ok, err = db.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Equal(t, errKeyEmpty, db.Set(nil, []byte{0x01}))
+	assert.Equal(t, errKeyEmpty, db.Delete([]byte{}))
+	assert.Equal(t, errValueNil, db.Set([]byte("a"), nil))
+	_, err = db.Get(nil)
+	assert.Equal(t, errKeyEmpty, err)
+}
+
+func TestIterator(t *testing.T) {
+	db := newTestDB(t)
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		require.NoError(t, db.Set([]byte(k), []byte(k+k)))
+	}
+
+	testCases := []struct {
+		name     string
+		start    []byte
+		end      []byte
+		reverse  bool
+		expected []string
+	}{
+		{"all", nil, nil, false, []string{"a", "b", "c", "d", "e"}},
+		{"all reverse", nil, nil, true, []string{"e", "d", "c", "b", "a"}},
+		{"start inclusive", []byte("b"), nil, false, []string{"b", "c", "d", "e"}},
+		{"end exclusive", nil, []byte("d"), false, []string{"a", "b", "c"}},
+		{"range", []byte("b"), []byte("d"), false, []string{"b", "c"}},
+		{"range reverse", []byte("b"), []byte("d"), true, []string{"c", "b"}},
+		{"missing bounds", []byte("bb"), []byte("dd"), false, []string{"c", "d"}},
+		{"missing bounds reverse", []byte("bb"), []byte("dd"), true, []string{"d", "c"}},
+		{"empty", []byte("c"), []byte("c"), false, nil},
+		{"out of range", []byte("f"), nil, true, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				itr dbm.Iterator
+				err error
+			)
+			if tc.reverse {
+				itr, err = db.ReverseIterator(tc.start, tc.end)
+			} else {
+				itr, err = db.Iterator(tc.start, tc.end)
+			}
+			require.NoError(t, err)
+			defer itr.Close()
+
+			start, end := itr.Domain()
+			assert.Equal(t, tc.start, start)
+			assert.Equal(t, tc.end, end)
+
+			var keys []string
+			for ; itr.Valid(); itr.Next() {
+				keys = append(keys, string(itr.Key()))
+				assert.Equal(t, string(itr.Key())+string(itr.Key()), string(itr.Value()))
+			}
+			require.NoError(t, itr.Error())
+			assert.Equal(t, tc.expected, keys)
+			assert.Panics(t, func() { itr.Next() })
+			assert.Panics(t, func() { itr.Key() })
+		})
+	}
+
+	_, err := db.Iterator([]byte{}, nil)
+	assert.Equal(t, errKeyEmpty, err)
+	_, err = db.ReverseIterator(nil, []byte{})
+	assert.Equal(t, errKeyEmpty, err)
+}
+
+func TestIteratorKeysAreCopied(t *testing.T) {
+	db := newTestDB(t)
+	require.NoError(t, db.Set([]byte("a"), []byte{0x01}))
+	require.NoError(t, db.Set([]byte("b"), []byte{0x02}))
+
+	itr, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer itr.Close()
+
+	key, value := itr.Key(), itr.Value()
+	itr.Next()
+	assert.Equal(t, []byte("a"), key)
+	assert.Equal(t, []byte{0x01}, value)
+}
+
+func TestBatch(t *testing.T) {
+	db := newTestDB(t)
+	require.NoError(t, db.Set([]byte("a"), []byte{0x01}))
+
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("b"), []byte{0x02}))
+	require.NoError(t, batch.Set([]byte("c"), []byte{0x03}))
+	require.NoError(t, batch.Delete([]byte("a")))
+	assert.Equal(t, errKeyEmpty, batch.Set(nil, []byte{0x01}))
+	assert.Equal(t, errValueNil, batch.Set([]byte("d"), nil))
+	assert.Equal(t, errKeyEmpty, batch.Delete(nil))
+
+	// nothing is written until the batch is
+	ok, err := db.Has([]byte("b"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, batch.Write())
+	assertKeys(t, db, "b", "c")
+
+	// the batch can't be used once written, but can be closed
+	assert.Equal(t, errBatchClosed, batch.Set([]byte("d"), []byte{0x04}))
+	assert.Equal(t, errBatchClosed, batch.Delete([]byte("b")))
+	assert.Equal(t, errBatchClosed, batch.Write())
+	assert.Equal(t, errBatchClosed, batch.WriteSync())
+	require.NoError(t, batch.Close())
+
+	// nor once closed, and closing discards it
+	batch = db.NewBatch()
+	require.NoError(t, batch.Set([]byte("d"), []byte{0x04}))
+	require.NoError(t, batch.Close())
+	require.NoError(t, batch.Close())
+	assert.Equal(t, errBatchClosed, batch.WriteSync())
+	assertKeys(t, db, "b", "c")
+
+	batch = db.NewBatch()
+	require.NoError(t, batch.Delete([]byte("b")))
+	require.NoError(t, batch.Set([]byte("e"), []byte{0x05}))
+	require.NoError(t, batch.WriteSync())
+	require.NoError(t, batch.Close())
+	assertKeys(t, db, "c", "e")
+}
+
+func assertKeys(t *testing.T, db *PebbleDB, expected ...string) {
+	t.Helper()
+	itr, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer itr.Close()
+	var keys []string
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+	require.NoError(t, itr.Error())
+	assert.Equal(t, expected, keys)
+}
+
+func TestCompact(t *testing.T) {
+	db := newTestDB(t)
+	require.NoError(t, db.Compact())
+
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, db.Set([]byte{byte(i >> 8), byte(i)}, make([]byte, 100)))
+	}
+	for i := 0; i < 900; i++ {
+		require.NoError(t, db.Delete([]byte{byte(i >> 8), byte(i)}))
+	}
+	require.NoError(t, db.Compact())
+
+	itr, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer itr.Close()
+	n := 0
+	for ; itr.Valid(); itr.Next() {
+		n++
+	}
+	assert.Equal(t, 100, n)
+}
@@ -27,7 +27,7 @@ func MakeCommitFromVoteSet(blockID types.BlockID, voteSet *types.VoteSet, valida
 
 		v := vote.ToProto()
 
-		if err := validators[i].SignVote(voteSet.ChainID(), v); err != nil {
+		if err := validators[i].SignVote(voteSet.ChainID(), voteSet.Encoding(), v); err != nil {
 			return nil, err
 		}
 		vote.Signature = v.Signature
@@ -39,6 +39,8 @@ func MakeCommitFromVoteSet(blockID types.BlockID, voteSet *types.VoteSet, valida
 	return voteSet.MakeCommit(), nil
 }
 
+// MakeCommit returns a commit of the block, signed by privVals with the
+// (default) proto canonical encoding.
 func MakeCommit(blockID types.BlockID, height int64, round int32, valSet *types.ValidatorSet, privVals []types.PrivValidator, chainID string, now time.Time) (*types.Commit, error) {
 	sigs := make([]types.CommitSig, len(valSet.Validators))
 	for i := 0; i < len(valSet.Validators); i++ {
@@ -69,7 +71,7 @@ func MakeCommit(blockID types.BlockID, height int64, round int32, valSet *types.
 
 		v := vote.ToProto()
 
-		if err := privVal.SignVote(chainID, v); err != nil {
+		if err := privVal.SignVote(chainID, types.CanonicalEncodingProto, v); err != nil {
 			return nil, err
 		}
 
@@ -7,6 +7,8 @@ import (
 	"github.com/cometbft/cometbft/types"
 )
 
+// MakeVote returns a vote signed by val with the (default) proto canonical
+// encoding.
 func MakeVote(
 	val types.PrivValidator,
 	chainID string,
@@ -33,7 +35,7 @@ func MakeVote(
 	}
 
 	vpb := v.ToProto()
-	if err := val.SignVote(chainID, vpb); err != nil {
+	if err := val.SignVote(chainID, types.CanonicalEncodingProto, vpb); err != nil {
 		return nil, err
 	}
 
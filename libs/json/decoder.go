@@ -55,6 +55,11 @@ func decodeReflect(bz []byte, rv reflect.Value) error {
 		rv = rv.Elem()
 	}
 
+	// If the type has a decoding fast path, use it.
+	if fp := typeRegistry.fastPath(rv.Type()); fp != nil && fp.decode != nil {
+		return fp.decode(bz, rv.Addr().Interface())
+	}
+
 	// Times must be UTC and end with Z
 	if rv.Type() == timeType {
 		switch {
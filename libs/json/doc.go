@@ -95,4 +95,9 @@
 //
 //	Struct{Car: &Car{Wheels: 4}, Vehicle: &Car{Wheels: 4}}
 //	// Output: {"Car": {"Wheels: 4"}, "Vehicle": {"type":"vehicle/car","value":{"Wheels":4}}}
+//
+// Values are encoded and decoded by reflection, unless their type has fast paths registered with
+// RegisterFastPath: hand-written functions, equivalent to reflection, for the types encoded the
+// most, e.g. blocks, headers, votes and validator sets on RPC requests. They write with a Writer
+// and read with an Object, handling the encoding of integers, bytes and times as above.
 package json
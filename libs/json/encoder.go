@@ -78,6 +78,19 @@ func encodeReflect(w io.Writer, rv reflect.Value) error {
 		rv = reflect.ValueOf(rv.Interface().(time.Time).Round(0).UTC())
 	}
 
+	// If the type has an encoding fast path, use it, giving it a pointer to the value.
+	if fp := typeRegistry.fastPath(rv.Type()); fp != nil && fp.encode != nil {
+		ptr := reflect.New(rv.Type())
+		if rv.CanAddr() {
+			ptr = rv.Addr()
+		} else {
+			ptr.Elem().Set(rv)
+		}
+		fw := newWriter(w)
+		fp.encode(fw, ptr.Interface())
+		return fw.Err()
+	}
+
 	// If the value implements json.Marshaler, defer to stdlib directly. Since we've already
 	// dereferenced, we try implementations with both value receiver and pointer receiver. We must
 	// do this after the time normalization above, and thus after dereferencing.
@@ -0,0 +1,445 @@
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+)
+
+// EncodeFunc is the JSON encoding fast path of a type, writing the value v
+// points to to w. See RegisterFastPath.
+type EncodeFunc func(w *Writer, v interface{})
+
+// DecodeFunc is the JSON decoding fast path of a type, decoding bz into the
+// value v points to. See RegisterFastPath.
+type DecodeFunc func(bz []byte, v interface{}) error
+
+// fastPath contains the JSON encoding and decoding fast paths of a type.
+type fastPath struct {
+	encode EncodeFunc
+	decode DecodeFunc
+}
+
+// RegisterFastPath registers hand-written JSON encoding and decoding functions
+// for the type of _type, used instead of reflection, e.g. for the types
+// encoded on every RPC request. Either can be nil, to only register the other.
+// They must be equivalent to the reflection-based encoding and decoding of the
+// type, whose values they are given pointers to; the values of other types are
+// still encoded and decoded by reflection.
+//
+// Should only be called in init() functions, as it panics on error.
+func RegisterFastPath(_type interface{}, encode EncodeFunc, decode DecodeFunc) {
+	if _type == nil {
+		panic("cannot register fast paths for nil type")
+	}
+	err := typeRegistry.registerFastPath(reflect.ValueOf(_type).Type(), &fastPath{
+		encode: encode,
+		decode: decode,
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Writer writes JSON values for encoding fast paths, in the same encoding as
+// reflection: e.g. 64-bit integers as strings. It keeps the first error.
+type Writer struct {
+	w   io.Writer
+	err error
+	// first is, for each open object or array, whether no element was written
+	first []bool
+}
+
+func newWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Err returns the first error encountered.
+func (w *Writer) Err() error {
+	return w.err
+}
+
+func (w *Writer) write(bz []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(bz)
+	}
+}
+
+func (w *Writer) writeString(s string) {
+	if w.err == nil {
+		_, w.err = io.WriteString(w.w, s)
+	}
+}
+
+// BeginObject starts an object, to end with EndObject.
+func (w *Writer) BeginObject() {
+	w.writeString("{")
+	w.first = append(w.first, true)
+}
+
+// EndObject ends the current object.
+func (w *Writer) EndObject() {
+	w.first = w.first[:len(w.first)-1]
+	w.writeString("}")
+}
+
+// BeginArray starts an array, to end with EndArray.
+func (w *Writer) BeginArray() {
+	w.writeString("[")
+	w.first = append(w.first, true)
+}
+
+// EndArray ends the current array.
+func (w *Writer) EndArray() {
+	w.first = w.first[:len(w.first)-1]
+	w.writeString("]")
+}
+
+func (w *Writer) separate() {
+	if w.first[len(w.first)-1] {
+		w.first[len(w.first)-1] = false
+		return
+	}
+	w.writeString(",")
+}
+
+// Field writes the name of the next field of the current object, to be
+// followed by its value.
+func (w *Writer) Field(name string) {
+	w.separate()
+	w.String(name)
+	w.writeString(":")
+}
+
+// Elem precedes the next element of the current array.
+func (w *Writer) Elem() {
+	w.separate()
+}
+
+// Null writes null.
+func (w *Writer) Null() {
+	w.writeString("null")
+}
+
+// Int64 writes a 64-bit integer, as a string.
+func (w *Writer) Int64(v int64) {
+	w.writeString(`"` + strconv.FormatInt(v, 10) + `"`)
+}
+
+// Uint64 writes an unsigned 64-bit integer, as a string.
+func (w *Writer) Uint64(v uint64) {
+	w.writeString(`"` + strconv.FormatUint(v, 10) + `"`)
+}
+
+// Int32 writes a 32-bit (or smaller) integer, as a number.
+func (w *Writer) Int32(v int32) {
+	w.writeString(strconv.FormatInt(int64(v), 10))
+}
+
+// Uint32 writes an unsigned 32-bit (or smaller) integer, as a number.
+func (w *Writer) Uint32(v uint32) {
+	w.writeString(strconv.FormatUint(uint64(v), 10))
+}
+
+// String writes a string.
+func (w *Writer) String(s string) {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c >= 0x7f || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			// leave the escaping to the stdlib
+			w.stdlib(s)
+			return
+		}
+	}
+	w.writeString(`"` + s + `"`)
+}
+
+// Bytes writes a byte slice, in base64, or null if nil.
+func (w *Writer) Bytes(bz []byte) {
+	if bz == nil {
+		w.Null()
+		return
+	}
+	buf := make([]byte, base64.StdEncoding.EncodedLen(len(bz))+2)
+	buf[0], buf[len(buf)-1] = '"', '"'
+	base64.StdEncoding.Encode(buf[1:], bz)
+	w.write(buf)
+}
+
+// HexBytes writes bytes, in upper case hex.
+func (w *Writer) HexBytes(bz cmtbytes.HexBytes) {
+	buf := make([]byte, hex.EncodedLen(len(bz))+2)
+	buf[0], buf[len(buf)-1] = '"', '"'
+	hex.Encode(buf[1:], bz)
+	w.write(bytes.ToUpper(buf))
+}
+
+// Time writes a time, in UTC.
+func (w *Writer) Time(t time.Time) {
+	if w.err != nil {
+		return
+	}
+	bz, err := t.Round(0).UTC().MarshalJSON()
+	if err != nil {
+		w.err = err
+		return
+	}
+	w.write(bz)
+}
+
+// Value writes any value by reflection, or its fast path. Interface values,
+// e.g. of fields, are written with their type wrapper when given by pointer.
+func (w *Writer) Value(v interface{}) {
+	if w.err != nil {
+		return
+	}
+	if v == nil {
+		w.Null()
+		return
+	}
+	w.err = encodeReflect(w.w, reflect.ValueOf(v))
+}
+
+func (w *Writer) stdlib(v interface{}) {
+	if w.err == nil {
+		w.err = encodeStdlib(w.w, v)
+	}
+}
+
+// Object is a JSON object being decoded by a decoding fast path, in the same
+// encoding as reflection. A missing or null field decodes to the zero value.
+// It keeps the first error.
+type Object struct {
+	fields map[string]json.RawMessage
+	err    error
+}
+
+// DecodeObject returns the object bz.
+func DecodeObject(bz []byte) *Object {
+	o := &Object{}
+	o.err = json.Unmarshal(bz, &o.fields)
+	return o
+}
+
+// Err returns the first error encountered.
+func (o *Object) Err() error {
+	return o.err
+}
+
+// field returns the value of the field name, or nil if it is missing or null,
+// or if an error was encountered.
+func (o *Object) field(name string) []byte {
+	if o.err != nil {
+		return nil
+	}
+	bz := o.fields[name]
+	if len(bz) == 0 || bytes.Equal(bz, []byte("null")) {
+		return nil
+	}
+	return bz
+}
+
+func (o *Object) fail(name string, err error) {
+	if o.err == nil && err != nil {
+		o.err = fmt.Errorf("field %q: %w", name, err)
+	}
+}
+
+// Int64 decodes the 64-bit integer name, encoded as a string.
+func (o *Object) Int64(name string, v *int64) {
+	bz := o.field(name)
+	if bz == nil {
+		*v = 0
+		return
+	}
+	s, err := unquoteInt(bz)
+	if err == nil {
+		*v, err = strconv.ParseInt(s, 10, 64)
+	}
+	o.fail(name, err)
+}
+
+// Uint64 decodes the unsigned 64-bit integer name, encoded as a string.
+func (o *Object) Uint64(name string, v *uint64) {
+	bz := o.field(name)
+	if bz == nil {
+		*v = 0
+		return
+	}
+	s, err := unquoteInt(bz)
+	if err == nil {
+		*v, err = strconv.ParseUint(s, 10, 64)
+	}
+	o.fail(name, err)
+}
+
+// Int32 decodes the 32-bit integer name, encoded as a number.
+func (o *Object) Int32(name string, v *int32) {
+	bz := o.field(name)
+	if bz == nil {
+		*v = 0
+		return
+	}
+	i, err := strconv.ParseInt(string(bz), 10, 32)
+	*v = int32(i)
+	o.fail(name, err)
+}
+
+// Uint32 decodes the unsigned 32-bit integer name, encoded as a number.
+func (o *Object) Uint32(name string, v *uint32) {
+	bz := o.field(name)
+	if bz == nil {
+		*v = 0
+		return
+	}
+	i, err := strconv.ParseUint(string(bz), 10, 32)
+	*v = uint32(i)
+	o.fail(name, err)
+}
+
+// Uint8 decodes the unsigned 8-bit integer name, encoded as a number.
+func (o *Object) Uint8(name string, v *uint8) {
+	bz := o.field(name)
+	if bz == nil {
+		*v = 0
+		return
+	}
+	i, err := strconv.ParseUint(string(bz), 10, 8)
+	*v = uint8(i)
+	o.fail(name, err)
+}
+
+// String decodes the string name.
+func (o *Object) String(name string, v *string) {
+	bz := o.field(name)
+	if bz == nil {
+		*v = ""
+		return
+	}
+	if len(bz) >= 2 && bz[0] == '"' && bz[len(bz)-1] == '"' && bytes.IndexByte(bz, '\\') < 0 && isASCII(bz) {
+		*v = string(bz[1 : len(bz)-1])
+		return
+	}
+	o.fail(name, json.Unmarshal(bz, v))
+}
+
+// Bytes decodes the byte slice name, as DecodeBytes.
+func (o *Object) Bytes(name string, v *[]byte) {
+	bz := o.field(name)
+	if bz == nil {
+		*v = nil
+		return
+	}
+	var err error
+	*v, err = DecodeBytes(bz)
+	o.fail(name, err)
+}
+
+// DecodeBytes decodes a byte slice, encoded in base64; empty or null slices
+// decode to nil.
+func DecodeBytes(bz []byte) ([]byte, error) {
+	var (
+		v   []byte
+		err error
+	)
+	switch {
+	case bytes.Equal(bz, []byte("null")):
+	case len(bz) >= 2 && bz[0] == '"' && bz[len(bz)-1] == '"' && bytes.IndexByte(bz, '\\') < 0:
+		v = make([]byte, base64.StdEncoding.DecodedLen(len(bz)-2))
+		var n int
+		n, err = base64.StdEncoding.Decode(v, bz[1:len(bz)-1])
+		v = v[:n]
+	default:
+		err = json.Unmarshal(bz, &v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// HexBytes decodes the bytes name, encoded in hex.
+func (o *Object) HexBytes(name string, v *cmtbytes.HexBytes) {
+	bz := o.field(name)
+	if bz == nil {
+		*v = nil
+		return
+	}
+	o.fail(name, v.UnmarshalJSON(bz))
+}
+
+// Time decodes the time name, which must be in UTC.
+func (o *Object) Time(name string, v *time.Time) {
+	bz := o.field(name)
+	if bz == nil {
+		*v = time.Time{}
+		return
+	}
+	o.fail(name, decodeReflect(bz, reflect.ValueOf(v).Elem()))
+}
+
+// Value decodes the field name into the value v points to, by reflection or
+// its fast path.
+func (o *Object) Value(name string, v interface{}) {
+	if o.err != nil {
+		return
+	}
+	bz := o.fields[name]
+	if len(bz) == 0 {
+		bz = []byte("null")
+	}
+	o.fail(name, DecodeValue(bz, v))
+}
+
+// DecodeValue decodes bz into the value v points to as a field or an element,
+// by reflection or its fast path: unlike Unmarshal, the values of registered
+// types are not expected with their type wrapper.
+func DecodeValue(bz []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("must decode into a pointer")
+	}
+	return decodeReflect(bz, rv.Elem())
+}
+
+// Array returns the elements of the array name, to decode e.g. with DecodeValue;
+// nil if it is missing, null or empty.
+func (o *Object) Array(name string) []json.RawMessage {
+	bz := o.field(name)
+	if bz == nil {
+		return nil
+	}
+	var elems []json.RawMessage
+	o.fail(name, json.Unmarshal(bz, &elems))
+	if len(elems) == 0 {
+		return nil
+	}
+	return elems
+}
+
+func unquoteInt(bz []byte) (string, error) {
+	if len(bz) < 2 || bz[0] != '"' || bz[len(bz)-1] != '"' {
+		return "", fmt.Errorf("invalid 64-bit integer encoding %q, expected string", string(bz))
+	}
+	return string(bz[1 : len(bz)-1]), nil
+}
+
+func isASCII(bz []byte) bool {
+	for _, c := range bz {
+		if c < 0x20 || c >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
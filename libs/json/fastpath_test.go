@@ -0,0 +1,128 @@
+package json_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/libs/json"
+)
+
+// Fast has JSON fast paths, equivalent to the reflection-based encoding of
+// SlowFast.
+type Fast struct {
+	Int64   int64          `json:"int64"`
+	Int32   int32          `json:"int32"`
+	String  string         `json:"string"`
+	Bytes   []byte         `json:"bytes"`
+	Hex     bytes.HexBytes `json:"hex"`
+	Time    time.Time      `json:"time"`
+	Vehicle Vehicle        `json:"vehicle"`
+	Boats   []Boat         `json:"boats"`
+}
+
+type SlowFast Fast
+
+func init() {
+	json.RegisterFastPath(Fast{},
+		func(w *json.Writer, v interface{}) {
+			f := v.(*Fast)
+			w.BeginObject()
+			w.Field("int64")
+			w.Int64(f.Int64)
+			w.Field("int32")
+			w.Int32(f.Int32)
+			w.Field("string")
+			w.String(f.String)
+			w.Field("bytes")
+			w.Bytes(f.Bytes)
+			w.Field("hex")
+			w.HexBytes(f.Hex)
+			w.Field("time")
+			w.Time(f.Time)
+			w.Field("vehicle")
+			w.Value(&f.Vehicle)
+			w.Field("boats")
+			if f.Boats == nil {
+				w.Null()
+			} else {
+				w.BeginArray()
+				for _, boat := range f.Boats {
+					w.Elem()
+					w.Value(boat)
+				}
+				w.EndArray()
+			}
+			w.EndObject()
+		},
+		func(bz []byte, v interface{}) error {
+			f := v.(*Fast)
+			o := json.DecodeObject(bz)
+			o.Int64("int64", &f.Int64)
+			o.Int32("int32", &f.Int32)
+			o.String("string", &f.String)
+			o.Bytes("bytes", &f.Bytes)
+			o.HexBytes("hex", &f.Hex)
+			o.Time("time", &f.Time)
+			o.Value("vehicle", &f.Vehicle)
+			f.Boats = nil
+			if elems := o.Array("boats"); elems != nil {
+				f.Boats = make([]Boat, len(elems))
+				for i, elem := range elems {
+					if err := json.DecodeValue(elem, &f.Boats[i]); err != nil {
+						return err
+					}
+				}
+			}
+			return o.Err()
+		})
+}
+
+func TestFastPath(t *testing.T) {
+	now := time.Date(2020, 6, 8, 16, 21, 28, 123, time.FixedZone("UTC+2", 2*60*60))
+	testcases := map[string]Fast{
+		"empty": {},
+		"full": {
+			Int64:   -64,
+			Int32:   32,
+			String:  "foo",
+			Bytes:   []byte{1, 2, 3},
+			Hex:     bytes.HexBytes{0xab, 0xcd},
+			Time:    now,
+			Vehicle: &Car{Wheels: 4},
+			Boats:   []Boat{{Sail: true}, {}},
+		},
+		"escaped": {String: "<\"foo\"\n é>", Bytes: []byte{}, Boats: []Boat{}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			bz, err := json.Marshal(tc)
+			require.NoError(t, err)
+			slow, err := json.Marshal(SlowFast(tc))
+			require.NoError(t, err)
+			assert.Equal(t, string(slow), string(bz))
+
+			// nested in a struct, as pointer
+			bz, err = json.Marshal(struct{ F *Fast }{&tc})
+			require.NoError(t, err)
+			slow, err = json.Marshal(struct{ F *SlowFast }{(*SlowFast)(&tc)})
+			require.NoError(t, err)
+			assert.Equal(t, string(slow), string(bz))
+
+			var fast struct{ F *Fast }
+			require.NoError(t, json.Unmarshal(bz, &fast))
+			var slowDecoded struct{ F *SlowFast }
+			require.NoError(t, json.Unmarshal(bz, &slowDecoded))
+			assert.Equal(t, *slowDecoded.F, SlowFast(*fast.F))
+		})
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`{"int64":64}`), &Fast{}))
+	assert.Error(t, json.Unmarshal([]byte(`{"time":"2020-06-08T16:21:28+02:00"}`), &Fast{}))
+	assert.Error(t, json.Unmarshal([]byte(`[]`), &Fast{}))
+	assert.Panics(t, func() { json.RegisterFastPath(&Fast{}, nil, nil) })
+}
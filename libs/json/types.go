@@ -40,15 +40,17 @@ type typeInfo struct {
 // types is a type registry. It is safe for concurrent use.
 type types struct {
 	cmtsync.RWMutex
-	byType map[reflect.Type]*typeInfo
-	byName map[string]*typeInfo
+	byType    map[reflect.Type]*typeInfo
+	byName    map[string]*typeInfo
+	fastPaths map[reflect.Type]*fastPath
 }
 
 // newTypes creates a new type registry.
 func newTypes() types {
 	return types{
-		byType: map[reflect.Type]*typeInfo{},
-		byName: map[string]*typeInfo{},
+		byType:    map[reflect.Type]*typeInfo{},
+		byName:    map[string]*typeInfo{},
+		fastPaths: map[reflect.Type]*fastPath{},
 	}
 }
 
@@ -107,3 +109,25 @@ func (t *types) name(rt reflect.Type) string {
 	}
 	return tInfo.name
 }
+
+// registers the fast paths of the given type, which must not be registered already. Unwraps
+// pointers as necessary.
+func (t *types) registerFastPath(rt reflect.Type, fp *fastPath) error {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	t.Lock()
+	defer t.Unlock()
+	if _, ok := t.fastPaths[rt]; ok {
+		return fmt.Errorf("the fast paths of the type %v are already registered", rt)
+	}
+	t.fastPaths[rt] = fp
+	return nil
+}
+
+// fastPath looks up the fast paths of a type, or nil if not registered.
+func (t *types) fastPath(rt reflect.Type) *fastPath {
+	t.RLock()
+	defer t.RUnlock()
+	return t.fastPaths[rt]
+}
@@ -0,0 +1,247 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// The names of the fields of the log events of NewJSONLogger, which log
+// pipelines can rely on.
+const (
+	TimeKey    = "ts"
+	LevelKey   = "level"
+	MessageKey = "msg"
+	ModuleKey  = moduleKey
+)
+
+const jsonTimeFormat = "2006-01-02T15:04:05.000000000Z07:00"
+
+var jsonBufPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 512)
+		return &buf
+	},
+}
+
+type jsonLogger struct {
+	w      io.Writer
+	noTS   bool
+	fields []byte // keyvals given to With, encoded
+}
+
+// Interface assertions
+var _ Logger = (*jsonLogger)(nil)
+
+// NewJSONLogger returns a Logger that encodes the log events to the Writer as
+// single JSON objects, one per line, with the level, time and message first:
+//
+//	{"level":"info","ts":"2023-01-02T15:04:05.000000000Z","msg":"Started node","module":"main"}
+//
+// Unlike NewTMJSONLogger, it encodes the keyvals without reflection for the
+// common types of values and the keyvals given to With only once. []byte
+// values are encoded as hexadecimal strings (uppercased), as by NewTMLogger.
+// The passed Writer must be safe for concurrent use by multiple goroutines if
+// the returned Logger will be used concurrently.
+//
+// It doesn't wrap a structured logging library, e.g. zap or zerolog: the
+// keyvals of Logger are untyped, so their typed fields would still be picked
+// by a type switch as below, and []byte values would need a custom encoder
+// to be hexadecimal rather than base64.
+func NewJSONLogger(w io.Writer) Logger {
+	return &jsonLogger{w: w}
+}
+
+// NewJSONLoggerNoTS is the same as NewJSONLogger, but without the timestamp.
+func NewJSONLoggerNoTS(w io.Writer) Logger {
+	return &jsonLogger{w: w, noTS: true}
+}
+
+// Debug logs a message at level Debug.
+func (l *jsonLogger) Debug(msg string, keyvals ...interface{}) {
+	l.log("debug", msg, keyvals)
+}
+
+// Info logs a message at level Info.
+func (l *jsonLogger) Info(msg string, keyvals ...interface{}) {
+	l.log("info", msg, keyvals)
+}
+
+// Error logs a message at level Error.
+func (l *jsonLogger) Error(msg string, keyvals ...interface{}) {
+	l.log("error", msg, keyvals)
+}
+
+// With returns a new contextual logger with keyvals prepended to those passed
+// to calls to Info, Debug or Error.
+func (l *jsonLogger) With(keyvals ...interface{}) Logger {
+	fields := make([]byte, len(l.fields), len(l.fields)+64)
+	copy(fields, l.fields)
+	return &jsonLogger{
+		w:      l.w,
+		noTS:   l.noTS,
+		fields: appendJSONKeyvals(fields, keyvals),
+	}
+}
+
+func (l *jsonLogger) log(lvl, msg string, keyvals []interface{}) {
+	bufp := jsonBufPool.Get().(*[]byte)
+	buf := (*bufp)[:0]
+
+	buf = append(buf, `{"`+LevelKey+`":"`...)
+	buf = append(buf, lvl...)
+	buf = append(buf, '"')
+	if !l.noTS {
+		buf = append(buf, `,"`+TimeKey+`":"`...)
+		buf = time.Now().UTC().AppendFormat(buf, jsonTimeFormat)
+		buf = append(buf, '"')
+	}
+	buf = append(buf, `,"`+MessageKey+`":`...)
+	buf = appendJSONString(buf, msg)
+	buf = append(buf, l.fields...)
+	buf = appendJSONKeyvals(buf, keyvals)
+	buf = append(buf, '}', '\n')
+
+	l.w.Write(buf) //nolint:errcheck // there is nowhere left to log the error
+
+	*bufp = buf
+	jsonBufPool.Put(bufp)
+}
+
+func appendJSONKeyvals(buf []byte, keyvals []interface{}) []byte {
+	for i := 0; i < len(keyvals); i += 2 {
+		buf = append(buf, ',')
+		if key, ok := keyvals[i].(string); ok {
+			buf = appendJSONString(buf, key)
+		} else {
+			buf = appendJSONString(buf, fmt.Sprint(keyvals[i]))
+		}
+		buf = append(buf, ':')
+		if i+1 < len(keyvals) {
+			buf = appendJSONValue(buf, keyvals[i+1])
+		} else {
+			buf = append(buf, `"(MISSING)"`...)
+		}
+	}
+	return buf
+}
+
+func appendJSONValue(buf []byte, value interface{}) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, "null"...)
+	case string:
+		return appendJSONString(buf, v)
+	case bool:
+		return strconv.AppendBool(buf, v)
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(buf, v, 10)
+	case uint:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(buf, v, 10)
+	case float32:
+		return appendJSONFloat(buf, float64(v), 32)
+	case float64:
+		return appendJSONFloat(buf, v, 64)
+	case []byte:
+		buf = append(buf, '"')
+		for _, b := range v {
+			buf = append(buf, upperHex[b>>4], upperHex[b&0x0f])
+		}
+		return append(buf, '"')
+	case time.Time:
+		buf = append(buf, '"')
+		buf = v.AppendFormat(buf, time.RFC3339Nano)
+		return append(buf, '"')
+	case error:
+		return appendJSONString(buf, safeString(v, v.Error))
+	case fmt.Stringer:
+		return appendJSONString(buf, safeString(v, v.String))
+	default:
+		bz, err := json.Marshal(v)
+		if err != nil {
+			return appendJSONString(buf, fmt.Sprintf("%+v", v))
+		}
+		return append(buf, bz...)
+	}
+}
+
+const upperHex = "0123456789ABCDEF"
+
+// Floats which are not numbers in JSON are encoded as strings.
+func appendJSONFloat(buf []byte, f float64, bitSize int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		buf = append(buf, '"')
+		buf = strconv.AppendFloat(buf, f, 'g', -1, bitSize)
+		return append(buf, '"')
+	}
+	return strconv.AppendFloat(buf, f, 'g', -1, bitSize)
+}
+
+func appendJSONString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				buf = append(buf, '\\', c)
+			case c == '\n':
+				buf = append(buf, '\\', 'n')
+			case c == '\r':
+				buf = append(buf, '\\', 'r')
+			case c == '\t':
+				buf = append(buf, '\\', 't')
+			case c < 0x20:
+				buf = append(buf, '\\', 'u', '0', '0', upperHex[c>>4], upperHex[c&0x0f])
+			default:
+				buf = append(buf, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, "\ufffd"...)
+		} else {
+			buf = append(buf, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(buf, '"')
+}
+
+// safeString calls the method, a nil pointer receiver of which can panic.
+func safeString(v interface{}, method func() string) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+				s = "NULL"
+				return
+			}
+			panic(r)
+		}
+	}()
+	return method()
+}
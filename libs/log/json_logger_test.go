@@ -0,0 +1,71 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewJSONLoggerNoTS(&buf).With("module", "consensus")
+
+	logger.Info("Added \"vote\"\n", "height", int64(12), "round", int32(0), "hash", []byte{0xab, 0x01},
+		"err", errors.New("bad"), "took", 2*time.Second, "ratio", math.Inf(1), "odd")
+	logger.With("peer", "abc").Debug("here", "ok", true, "kvs", map[string]int{"a": 1})
+	logger.Error("\x01é\xff")
+
+	assert.Equal(t, strings.Join([]string{
+		`{"level":"info","msg":"Added \"vote\"\n","module":"consensus","height":12,"round":0,"hash":"AB01",` +
+			`"err":"bad","took":"2s","ratio":"+Inf","odd":"(MISSING)"}`,
+		`{"level":"debug","msg":"here","module":"consensus","peer":"abc","ok":true,"kvs":{"a":1}}`,
+		`{"level":"error","msg":"\u0001é` + "\ufffd" + `","module":"consensus"}`,
+		``,
+	}, "\n"), buf.String())
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		assert.True(t, json.Valid([]byte(line)), line)
+	}
+}
+
+func TestJSONLoggerTime(t *testing.T) {
+	var buf bytes.Buffer
+	log.NewJSONLogger(&buf).Info("here")
+
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &event))
+	ts, err := time.Parse(time.RFC3339Nano, event[log.TimeKey].(string))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), ts, time.Minute)
+	assert.Equal(t, "info", event[log.LevelKey])
+	assert.Equal(t, "here", event[log.MessageKey])
+}
+
+func TestJSONLoggerNilError(t *testing.T) {
+	var buf bytes.Buffer
+	var err *nilError
+	log.NewJSONLoggerNoTS(&buf).Info("here", "err", err)
+	assert.Equal(t, `{"level":"info","msg":"here","err":"NULL"}`+"\n", buf.String())
+}
+
+type nilError struct{ msg string }
+
+func (e *nilError) Error() string { return e.msg }
+
+func BenchmarkJSONLogger(b *testing.B) {
+	benchmarkRunner(b, log.NewJSONLogger(io.Discard).With("module", "consensus"), baseInfoMessage)
+}
+
+func BenchmarkTMJSONLogger(b *testing.B) {
+	benchmarkRunner(b, log.NewTMJSONLogger(io.Discard).With("module", "consensus"), baseInfoMessage)
+}
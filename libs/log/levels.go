@@ -0,0 +1,187 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+const defaultModule = "*"
+
+// Levels are the log levels of the modules, parsed from a comma-separated
+// list of module:level pairs with an optional *:level pair (* means all other
+// modules). They can be changed at runtime with Set, and the change applies to
+// all the loggers filtered by them, including the ones already created.
+type Levels struct {
+	defaultLevel string
+	rules        atomic.Value // *levelRules
+}
+
+type levelRules struct {
+	spec    string
+	allowed level            // levels allowed for the modules without a rule
+	modules map[string]level // levels allowed for the modules with a rule
+}
+
+// ParseLevels parses the log levels, e.g. "consensus:debug,mempool:debug,*:error".
+// A single level, e.g. "info", applies to all the modules. defaultLevel is the
+// level of the modules without a rule when there is no *:level pair.
+func ParseLevels(spec, defaultLevel string) (*Levels, error) {
+	ls := &Levels{defaultLevel: defaultLevel}
+	if err := ls.Set(spec); err != nil {
+		return nil, err
+	}
+	return ls, nil
+}
+
+// Set replaces the log levels.
+func (ls *Levels) Set(spec string) error {
+	rules, err := parseLevelRules(spec, ls.defaultLevel)
+	if err != nil {
+		return err
+	}
+	ls.rules.Store(rules)
+	return nil
+}
+
+// Validate returns the error Set would return for spec, without setting it.
+func (ls *Levels) Validate(spec string) error {
+	_, err := parseLevelRules(spec, ls.defaultLevel)
+	return err
+}
+
+// String returns the log levels as they were last set.
+func (ls *Levels) String() string {
+	return ls.load().spec
+}
+
+// Modules returns the modules with a level of their own, sorted.
+func (ls *Levels) Modules() []string {
+	rules := ls.load()
+	modules := make([]string, 0, len(rules.modules))
+	for module := range rules.modules {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+func (ls *Levels) load() *levelRules {
+	return ls.rules.Load().(*levelRules)
+}
+
+func (ls *Levels) allowed(module string) level {
+	rules := ls.load()
+	if allowed, ok := rules.modules[module]; ok {
+		return allowed
+	}
+	return rules.allowed
+}
+
+func parseLevelRules(spec, defaultLevel string) (*levelRules, error) {
+	if spec == "" {
+		return nil, errors.New("empty log level")
+	}
+
+	l := spec
+	// prefix simple one word levels (e.g. "info") with "*"
+	if !strings.Contains(l, ":") {
+		l = defaultModule + ":" + l
+	}
+
+	rules := &levelRules{spec: spec, modules: make(map[string]level)}
+	isDefaultLevelSet := false
+	for _, item := range strings.Split(l, ",") {
+		moduleAndLevel := strings.Split(item, ":")
+		if len(moduleAndLevel) != 2 {
+			return nil, fmt.Errorf("expected list in a form of \"module:level\" pairs, given pair %s, list %s", item, spec)
+		}
+		module, lvl := moduleAndLevel[0], moduleAndLevel[1]
+
+		allowed, err := parseLevel(lvl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse log level (pair %s, list %s): %w", item, spec, err)
+		}
+		if module == defaultModule {
+			rules.allowed = allowed
+			isDefaultLevelSet = true
+		} else {
+			rules.modules[module] = allowed
+		}
+	}
+
+	// if "*" is not provided, set default global level
+	if !isDefaultLevelSet {
+		allowed, err := parseLevel(defaultLevel)
+		if err != nil {
+			return nil, err
+		}
+		rules.allowed = allowed
+	}
+	return rules, nil
+}
+
+func parseLevel(lvl string) (level, error) {
+	switch lvl {
+	case "debug":
+		return levelError | levelInfo | levelDebug, nil
+	case "info":
+		return levelError | levelInfo, nil
+	case "error":
+		return levelError, nil
+	case "none":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("expected either \"info\", \"debug\", \"error\" or \"none\" level, given %s", lvl)
+	}
+}
+
+//--------------------------------------------------------------------------------
+
+type moduleFilter struct {
+	next   Logger
+	levels *Levels
+	module string
+}
+
+// NewModuleFilter wraps next and filters its log events by the level of their
+// module, the last value of the "module" key given to With. Unlike NewFilter,
+// the levels are looked up on every log event, so that a change of levels
+// applies to the loggers already created.
+func NewModuleFilter(next Logger, levels *Levels) Logger {
+	return &moduleFilter{next: next, levels: levels}
+}
+
+func (l *moduleFilter) Debug(msg string, keyvals ...interface{}) {
+	if l.levels.allowed(l.module)&levelDebug != 0 {
+		l.next.Debug(msg, keyvals...)
+	}
+}
+
+func (l *moduleFilter) Info(msg string, keyvals ...interface{}) {
+	if l.levels.allowed(l.module)&levelInfo != 0 {
+		l.next.Info(msg, keyvals...)
+	}
+}
+
+func (l *moduleFilter) Error(msg string, keyvals ...interface{}) {
+	if l.levels.allowed(l.module)&levelError != 0 {
+		l.next.Error(msg, keyvals...)
+	}
+}
+
+func (l *moduleFilter) With(keyvals ...interface{}) Logger {
+	module := l.module
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == moduleKey {
+			module = fmt.Sprint(keyvals[i+1])
+		}
+	}
+	return &moduleFilter{
+		next:   l.next.With(keyvals...),
+		levels: l.levels,
+		module: module,
+	}
+}
@@ -0,0 +1,53 @@
+package log_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestModuleFilter(t *testing.T) {
+	var buf bytes.Buffer
+	levels, err := log.ParseLevels("consensus:debug,p2p:none", "info")
+	require.NoError(t, err)
+	logger := log.NewModuleFilter(log.NewJSONLoggerNoTS(&buf), levels)
+	consensus := logger.With("module", "consensus")
+	p2p := logger.With("module", "p2p")
+	mempool := logger.With("module", "mempool")
+
+	consensus.Debug("a")
+	p2p.Error("b")
+	mempool.Debug("c")
+	mempool.Info("d")
+	assert.Equal(t, `{"level":"debug","msg":"a","module":"consensus"}`+"\n"+
+		`{"level":"info","msg":"d","module":"mempool"}`+"\n", buf.String())
+	assert.Equal(t, []string{"consensus", "p2p"}, levels.Modules())
+
+	// the new levels apply to the loggers already created
+	require.NoError(t, levels.Set("p2p:error,*:error"))
+	assert.Equal(t, "p2p:error,*:error", levels.String())
+	buf.Reset()
+	consensus.Debug("a")
+	p2p.Error("b")
+	mempool.Info("d")
+	assert.Equal(t, `{"level":"error","msg":"b","module":"p2p"}`+"\n", buf.String())
+
+	// the levels are kept on error
+	require.Error(t, levels.Validate("p2p:loud"))
+	require.NoError(t, levels.Validate("p2p:info"))
+	require.Error(t, levels.Set("p2p:loud"))
+	assert.Equal(t, "p2p:error,*:error", levels.String())
+}
+
+func TestParseLevels(t *testing.T) {
+	for _, spec := range []string{"", "some", "mempool:some", "*:some,mempool:error", "mempool:info:debug"} {
+		_, err := log.ParseLevels(spec, "info")
+		require.Error(t, err, spec)
+	}
+	_, err := log.ParseLevels("mempool:error", "some")
+	require.Error(t, err)
+}
@@ -0,0 +1,108 @@
+package log
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const samplerCounters = 4096
+
+type samplingLogger struct {
+	next    Logger
+	sampler *sampler
+}
+
+type sampler struct {
+	first      uint64
+	thereafter uint64
+	tick       time.Duration
+	debug      [samplerCounters]samplerCounter
+	info       [samplerCounters]samplerCounter
+}
+
+type samplerCounter struct {
+	resetAt atomic.Int64
+	n       atomic.Uint64
+}
+
+// Interface assertions
+var _ Logger = (*samplingLogger)(nil)
+
+// NewSamplingLogger wraps next and samples its debug and info log events,
+// bounding the cost of the hot log sites: of the log events with the same
+// level and message, within every tick, the first ones are logged, then every
+// thereafter-th one (none if thereafter is 0). Error log events are never
+// sampled.
+//
+// The log events are counted in a fixed number of counters, so that a few
+// messages can share a counter and be sampled together.
+func NewSamplingLogger(next Logger, first, thereafter int, tick time.Duration) Logger {
+	if first < 0 {
+		first = 0
+	}
+	if thereafter < 0 {
+		thereafter = 0
+	}
+	return &samplingLogger{
+		next: next,
+		sampler: &sampler{
+			first:      uint64(first),
+			thereafter: uint64(thereafter),
+			tick:       tick,
+		},
+	}
+}
+
+func (l *samplingLogger) Debug(msg string, keyvals ...interface{}) {
+	if l.sampler.sample(&l.sampler.debug, msg) {
+		l.next.Debug(msg, keyvals...)
+	}
+}
+
+func (l *samplingLogger) Info(msg string, keyvals ...interface{}) {
+	if l.sampler.sample(&l.sampler.info, msg) {
+		l.next.Info(msg, keyvals...)
+	}
+}
+
+func (l *samplingLogger) Error(msg string, keyvals ...interface{}) {
+	l.next.Error(msg, keyvals...)
+}
+
+// With returns a logger sampling its log events with the same counters.
+func (l *samplingLogger) With(keyvals ...interface{}) Logger {
+	return &samplingLogger{
+		next:    l.next.With(keyvals...),
+		sampler: l.sampler,
+	}
+}
+
+// sample returns whether the log event with the message is to be logged.
+func (s *sampler) sample(counters *[samplerCounters]samplerCounter, msg string) bool {
+	// FNV-1a, without allocating
+	h := uint32(2166136261)
+	for i := 0; i < len(msg); i++ {
+		h ^= uint32(msg[i])
+		h *= 16777619
+	}
+	n := counters[h%samplerCounters].inc(time.Now(), s.tick)
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
+
+// inc counts a log event, resetting the count every tick.
+func (c *samplerCounter) inc(now time.Time, tick time.Duration) uint64 {
+	tn := now.UnixNano()
+	resetAt := c.resetAt.Load()
+	if resetAt > tn {
+		return c.n.Add(1)
+	}
+	c.n.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, tn+tick.Nanoseconds()) {
+		// another log event reset the count first
+		return c.n.Add(1)
+	}
+	return 1
+}
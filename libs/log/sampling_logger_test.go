@@ -0,0 +1,56 @@
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestSamplingLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewSamplingLogger(log.NewJSONLoggerNoTS(&buf), 2, 3, time.Hour)
+
+	for i := 0; i < 10; i++ {
+		logger.With("i", i).Info("hot")
+		logger.Error("hot")
+	}
+	logger.Debug("hot")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var infos []string
+	errs := 0
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, `{"level":"info"`):
+			infos = append(infos, line)
+		case strings.HasPrefix(line, `{"level":"error"`):
+			errs++
+		}
+	}
+	// the first 2, then every 3rd
+	assert.Equal(t, []string{
+		`{"level":"info","msg":"hot","i":0}`,
+		`{"level":"info","msg":"hot","i":1}`,
+		`{"level":"info","msg":"hot","i":4}`,
+		`{"level":"info","msg":"hot","i":7}`,
+	}, infos)
+	assert.Equal(t, 10, errs)
+	// the debug log events are counted apart
+	assert.Equal(t, `{"level":"debug","msg":"hot"}`, lines[len(lines)-1])
+}
+
+func TestSamplingLoggerTick(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewSamplingLogger(log.NewJSONLoggerNoTS(&buf), 1, 0, 10*time.Millisecond)
+
+	logger.Info("hot")
+	logger.Info("hot")
+	time.Sleep(20 * time.Millisecond)
+	logger.Info("hot")
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+}
@@ -0,0 +1,262 @@
+// Package profiler captures the CPU and heap profiles of the node
+// periodically, and keeps them locally or pushes them to a Pyroscope-compatible
+// server, so that the performance regressions of a node can be diagnosed after
+// the fact.
+package profiler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+const (
+	// DefaultCPUDuration is how long the CPU is profiled for, at each capture.
+	DefaultCPUDuration = 10 * time.Second
+
+	// DefaultRetain is the number of profiles of each type kept locally.
+	DefaultRetain = 24
+
+	pushTimeout = 30 * time.Second
+	pushAppName = "cometbft"
+	fileSuffix  = ".pb.gz"
+)
+
+// Profile types.
+const (
+	ProfileCPU  = "cpu"
+	ProfileHeap = "heap"
+)
+
+// Profiler captures a CPU and a heap profile at each interval.
+type Profiler struct {
+	service.BaseService
+
+	interval    time.Duration
+	cpuDuration time.Duration
+	dir         string
+	retain      int
+	pushURL     string
+	tags        map[string]string
+	client      *http.Client
+}
+
+// Option sets an optional parameter on the Profiler.
+type Option func(*Profiler)
+
+// WithCPUDuration sets how long the CPU is profiled for, at each capture. It
+// must be less than the interval.
+func WithCPUDuration(d time.Duration) Option {
+	return func(p *Profiler) { p.cpuDuration = d }
+}
+
+// WithDir keeps the profiles in dir, as <type>-<unix time>.pb.gz, the last
+// retain of each type only.
+func WithDir(dir string, retain int) Option {
+	return func(p *Profiler) {
+		p.dir = dir
+		p.retain = retain
+	}
+}
+
+// WithPushURL pushes the profiles to the ingestion endpoint of the
+// Pyroscope-compatible server at url, e.g. "http://localhost:4040", with the
+// given tags (e.g. the chain and node IDs).
+func WithPushURL(url string, tags map[string]string) Option {
+	return func(p *Profiler) {
+		p.pushURL = strings.TrimSuffix(url, "/")
+		p.tags = tags
+	}
+}
+
+// NewProfiler returns a profiler capturing the profiles every interval.
+func NewProfiler(interval time.Duration, options ...Option) *Profiler {
+	p := &Profiler{
+		interval:    interval,
+		cpuDuration: DefaultCPUDuration,
+		retain:      DefaultRetain,
+		client:      &http.Client{Timeout: pushTimeout},
+	}
+	p.BaseService = *service.NewBaseService(nil, "Profiler", p)
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// OnStart implements service.Service by starting the capture routine.
+func (p *Profiler) OnStart() error {
+	if p.dir != "" {
+		if err := os.MkdirAll(p.dir, 0o700); err != nil {
+			return fmt.Errorf("creating the profile directory: %w", err)
+		}
+	}
+	go p.captureRoutine()
+	return nil
+}
+
+func (p *Profiler) captureRoutine() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.capture()
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// capture captures a CPU profile, over cpuDuration, then a heap profile.
+func (p *Profiler) capture() {
+	var buf bytes.Buffer
+	start := time.Now()
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		// e.g. a CPU profile is being captured through the pprof endpoints
+		p.Logger.Error("Failed to start the CPU profile", "err", err)
+	} else {
+		timer := time.NewTimer(p.cpuDuration)
+		select {
+		case <-timer.C:
+		case <-p.Quit():
+			timer.Stop()
+		}
+		pprof.StopCPUProfile()
+		p.handle(ProfileCPU, start, time.Now(), buf.Bytes())
+	}
+
+	buf.Reset()
+	now := time.Now()
+	if err := pprof.Lookup("heap").WriteTo(&buf, 0); err != nil {
+		p.Logger.Error("Failed to write the heap profile", "err", err)
+		return
+	}
+	p.handle(ProfileHeap, now, now, buf.Bytes())
+}
+
+func (p *Profiler) handle(typ string, start, end time.Time, profile []byte) {
+	if p.dir != "" {
+		if err := p.save(typ, start, profile); err != nil {
+			p.Logger.Error("Failed to save the profile", "type", typ, "err", err)
+		}
+	}
+	if p.pushURL != "" {
+		if err := p.push(typ, start, end, profile); err != nil {
+			p.Logger.Error("Failed to push the profile", "type", typ, "err", err)
+		}
+	}
+}
+
+// save writes the profile to the directory, and removes the oldest profiles
+// of its type past the retained ones.
+func (p *Profiler) save(typ string, start time.Time, profile []byte) error {
+	name := filepath.Join(p.dir, typ+"-"+strconv.FormatInt(start.Unix(), 10)+fileSuffix)
+	if err := os.WriteFile(name, profile, 0o600); err != nil {
+		return err
+	}
+
+	files, err := p.Profiles(typ)
+	if err != nil {
+		return err
+	}
+	for len(files) > p.retain {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// Profiles returns the paths of the profiles of the given type kept in the
+// directory, the oldest first.
+func (p *Profiler) Profiles(typ string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(p.dir, typ+"-*"+fileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := func(file string) int64 {
+		ts := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), typ+"-"), fileSuffix)
+		n, _ := strconv.ParseInt(ts, 10, 64)
+		return n
+	}
+	sort.Slice(files, func(i, j int) bool { return timestamp(files[i]) < timestamp(files[j]) })
+	return files, nil
+}
+
+// push posts the profile to the /ingest endpoint of the server, the
+// application being named after the profile type, e.g. "cometbft.cpu".
+func (p *Profiler) push(typ string, start, end time.Time, profile []byte) error {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("profile", "profile.pprof")
+	if err != nil {
+		return err
+	}
+	if _, err := fw.Write(profile); err != nil {
+		return err
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	q := url.Values{}
+	q.Set("name", p.appName(typ))
+	q.Set("from", strconv.FormatInt(start.Unix(), 10))
+	q.Set("until", strconv.FormatInt(end.Unix(), 10))
+	q.Set("format", "pprof")
+	q.Set("spyName", "gospy")
+
+	ctx, cancel := context.WithTimeout(context.Background(), pushTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.pushURL+"/ingest?"+q.Encode(), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// appName returns the application name of the profile type, with the tags,
+// e.g. "cometbft.cpu{chain_id=test}".
+func (p *Profiler) appName(typ string) string {
+	name := pushAppName + "." + typ
+	if len(p.tags) == 0 {
+		return name
+	}
+	keys := make([]string, 0, len(p.tags))
+	for k := range p.tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	tags := make([]string, len(keys))
+	for i, k := range keys {
+		tags[i] = k + "=" + p.tags[k]
+	}
+	return name + "{" + strings.Join(tags, ",") + "}"
+}
@@ -0,0 +1,72 @@
+package profiler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestProfiler(t *testing.T) {
+	pushed := make(chan string, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ingest", r.URL.Path)
+		assert.Equal(t, "pprof", r.URL.Query().Get("format"))
+		f, _, err := r.FormFile("profile")
+		if assert.NoError(t, err) {
+			f.Close()
+		}
+		pushed <- r.URL.Query().Get("name")
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	p := NewProfiler(50*time.Millisecond,
+		WithCPUDuration(10*time.Millisecond),
+		WithDir(dir, 2),
+		WithPushURL(srv.URL, map[string]string{"node_id": "abcd", "chain_id": "test"}),
+	)
+	p.SetLogger(log.TestingLogger())
+	require.NoError(t, p.Start())
+	t.Cleanup(func() { _ = p.Stop() })
+
+	names := map[string]bool{}
+	for len(names) < 2 {
+		select {
+		case name := <-pushed:
+			names[name] = true
+		case <-time.After(5 * time.Second):
+			t.Fatal("no profile pushed")
+		}
+	}
+	assert.True(t, names["cometbft.cpu{chain_id=test,node_id=abcd}"])
+	assert.True(t, names["cometbft.heap{chain_id=test,node_id=abcd}"])
+
+	cpu, err := p.Profiles(ProfileCPU)
+	require.NoError(t, err)
+	assert.NotEmpty(t, cpu)
+	heap, err := p.Profiles(ProfileHeap)
+	require.NoError(t, err)
+	assert.NotEmpty(t, heap)
+}
+
+func TestProfilerRetain(t *testing.T) {
+	dir := t.TempDir()
+	p := NewProfiler(time.Minute, WithDir(dir, 2))
+	for _, ts := range []int64{30, 10, 20} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "heap-"+strconv.FormatInt(ts, 10)+fileSuffix), nil, 0o600))
+	}
+	require.NoError(t, p.save(ProfileHeap, time.Unix(40, 0), []byte("profile")))
+
+	files, err := p.Profiles(ProfileHeap)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "heap-30.pb.gz"), filepath.Join(dir, "heap-40.pb.gz")}, files)
+}
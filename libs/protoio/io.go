@@ -75,22 +75,29 @@ func getSize(v interface{}) (int, bool) {
 // byteReader wraps an io.Reader and implements io.ByteReader, required by
 // binary.ReadUvarint(). Reading one byte at a time is extremely slow, but this
 // is what Amino did previously anyway, and the caller can wrap the underlying
-// reader in a bufio.Reader if appropriate.
+// reader in a bufio.Reader if appropriate, whose ReadByte is then used.
 type byteReader struct {
-	reader    io.Reader
-	buf       []byte
-	bytesRead int // keeps track of bytes read via ReadByte()
+	reader     io.Reader
+	byteReader io.ByteReader // reader, if it is an io.ByteReader
+	buf        [1]byte
+	bytesRead  int // keeps track of bytes read via ReadByte()
 }
 
-func newByteReader(r io.Reader) *byteReader {
-	return &byteReader{
-		reader: r,
-		buf:    make([]byte, 1),
-	}
+func newByteReader(r io.Reader) byteReader {
+	br, _ := r.(io.ByteReader)
+	return byteReader{reader: r, byteReader: br}
 }
 
 func (r *byteReader) ReadByte() (byte, error) {
-	n, err := r.reader.Read(r.buf)
+	if r.byteReader != nil {
+		b, err := r.byteReader.ReadByte()
+		if err != nil {
+			return 0x00, err
+		}
+		r.bytesRead++
+		return b, nil
+	}
+	n, err := r.reader.Read(r.buf[:])
 	r.bytesRead += n
 	if err != nil {
 		return 0x00, err
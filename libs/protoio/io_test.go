@@ -42,6 +42,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/libs/protoio"
+	tmp2p "github.com/cometbft/cometbft/proto/tendermint/p2p"
 )
 
 func iotest(writer protoio.WriteCloser, reader protoio.ReadCloser) error {
@@ -182,3 +183,33 @@ func TestShort(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, varintLen+len(bz), n)
 }
+
+func TestMarshalDelimited(t *testing.T) {
+	msg := &test.NinOptNative{Field15: []byte{0x01, 0x02, 0x03}}
+	bz, err := protoio.MarshalDelimited(msg)
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	_, err = protoio.NewDelimitedWriter(buf).WriteMsg(msg)
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), bz)
+
+	var got test.NinOptNative
+	require.NoError(t, protoio.UnmarshalDelimited(bz, &got))
+	require.NoError(t, got.VerboseEqual(msg))
+
+	require.Equal(t, io.EOF, protoio.UnmarshalDelimited(nil, &got))
+	require.Equal(t, io.ErrUnexpectedEOF, protoio.UnmarshalDelimited(bz[:len(bz)-1], &got))
+	require.Equal(t, io.ErrUnexpectedEOF, protoio.UnmarshalDelimited([]byte{0xff}, &got))
+}
+
+func BenchmarkWriteMsg(b *testing.B) {
+	msg := &tmp2p.PacketMsg{ChannelID: 0x20, EOF: true, Data: make([]byte, 1024)}
+	w := protoio.NewDelimitedWriter(io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := w.WriteMsg(msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
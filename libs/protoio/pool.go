@@ -0,0 +1,36 @@
+package protoio
+
+import "sync"
+
+// maxPooledBufferSize is the capacity of the largest buffers returned to
+// bufferPool. The larger ones, e.g. of whole blocks, are rare enough to be
+// left to the garbage collector, rather than pinned in the pool.
+const maxPooledBufferSize = 64 * 1024
+
+// bufferPool holds the buffers the messages are marshaled into before being
+// written, shared by all the writers.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 1024)
+		return &buf
+	},
+}
+
+// getBuffer returns a buffer of length size from the pool. It must be given
+// back with putBuffer once written.
+func getBuffer(size int) *[]byte {
+	buf := bufferPool.Get().(*[]byte)
+	if cap(*buf) < size {
+		*buf = make([]byte, size)
+	}
+	*buf = (*buf)[:size]
+	return buf
+}
+
+// putBuffer gives buf back to the pool, unless it is too large.
+func putBuffer(buf *[]byte) {
+	if cap(*buf) > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
@@ -31,7 +31,6 @@
 package protoio
 
 import (
-	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -49,11 +48,12 @@ func NewDelimitedReader(r io.Reader, maxSize int) ReadCloser {
 	if c, ok := r.(io.Closer); ok {
 		closer = c
 	}
-	return &varintReader{r, nil, maxSize, closer}
+	return &varintReader{r: r, br: newByteReader(r), maxSize: maxSize, closer: closer}
 }
 
 type varintReader struct {
 	r       io.Reader
+	br      byteReader
 	buf     []byte
 	maxSize int
 	closer  io.Closer
@@ -64,9 +64,9 @@ func (r *varintReader) ReadMsg(msg proto.Message) (int, error) {
 	// number of bytes read, so we use our own byteReader. This can't be
 	// buffered, so the caller should pass a buffered io.Reader to avoid poor
 	// performance.
-	byteReader := newByteReader(r.r)
-	l, err := binary.ReadUvarint(byteReader)
-	n := byteReader.bytesRead
+	r.br.bytesRead = 0
+	l, err := binary.ReadUvarint(&r.br)
+	n := r.br.bytesRead
 	if err != nil {
 		return n, err
 	}
@@ -100,7 +100,20 @@ func (r *varintReader) Close() error {
 	return nil
 }
 
+// UnmarshalDelimited unmarshals the first varint-delimited message of data into
+// msg, straight from data.
 func UnmarshalDelimited(data []byte, msg proto.Message) error {
-	_, err := NewDelimitedReader(bytes.NewReader(data), len(data)).ReadMsg(msg)
-	return err
+	l, n := binary.Uvarint(data)
+	switch {
+	case n == 0 && len(data) == 0:
+		return io.EOF
+	case n == 0:
+		return io.ErrUnexpectedEOF
+	case n < 0:
+		return fmt.Errorf("invalid out-of-range message length")
+	}
+	if l > uint64(len(data)-n) {
+		return io.ErrUnexpectedEOF
+	}
+	return proto.Unmarshal(data[n:n+int(l)], msg)
 }
@@ -40,30 +40,30 @@ import (
 
 // NewDelimitedWriter writes a varint-delimited Protobuf message to a writer. It is
 // equivalent to the gogoproto NewDelimitedWriter, except WriteMsg() also returns the
-// number of bytes written, which is necessary in the p2p package.
+// number of bytes written, which is necessary in the p2p package. The messages are
+// marshaled into pooled buffers, so writers are cheap to create for one message.
 func NewDelimitedWriter(w io.Writer) WriteCloser {
-	return &varintWriter{w, make([]byte, binary.MaxVarintLen64), nil}
+	return &varintWriter{w: w}
 }
 
 type varintWriter struct {
 	w      io.Writer
-	lenBuf []byte
-	buffer []byte
+	lenBuf [binary.MaxVarintLen64]byte
 }
 
 func (w *varintWriter) WriteMsg(msg proto.Message) (int, error) {
 	if m, ok := msg.(marshaler); ok {
 		n, ok := getSize(m)
 		if ok {
-			if n+binary.MaxVarintLen64 >= len(w.buffer) {
-				w.buffer = make([]byte, n+binary.MaxVarintLen64)
-			}
-			lenOff := binary.PutUvarint(w.buffer, uint64(n))
-			_, err := m.MarshalTo(w.buffer[lenOff:])
+			buf := getBuffer(n + binary.MaxVarintLen64)
+			defer putBuffer(buf)
+
+			lenOff := binary.PutUvarint(*buf, uint64(n))
+			_, err := m.MarshalTo((*buf)[lenOff:])
 			if err != nil {
 				return 0, err
 			}
-			_, err = w.w.Write(w.buffer[:lenOff+n])
+			_, err = w.w.Write((*buf)[:lenOff+n])
 			return lenOff + n, err
 		}
 	}
@@ -74,7 +74,7 @@ func (w *varintWriter) WriteMsg(msg proto.Message) (int, error) {
 		return 0, err
 	}
 	length := uint64(len(data))
-	n := binary.PutUvarint(w.lenBuf, length)
+	n := binary.PutUvarint(w.lenBuf[:], length)
 	_, err = w.w.Write(w.lenBuf[:n])
 	if err != nil {
 		return 0, err
@@ -90,7 +90,22 @@ func (w *varintWriter) Close() error {
 	return nil
 }
 
+// MarshalDelimited returns the varint-delimited encoding of msg, allocated once
+// with its exact size when msg knows it.
 func MarshalDelimited(msg proto.Message) ([]byte, error) {
+	if m, ok := msg.(marshaler); ok {
+		if n, ok := getSize(m); ok {
+			var lenBuf [binary.MaxVarintLen64]byte
+			lenOff := binary.PutUvarint(lenBuf[:], uint64(n))
+			bz := make([]byte, lenOff+n)
+			copy(bz, lenBuf[:lenOff])
+			if _, err := m.MarshalTo(bz[lenOff:]); err != nil {
+				return nil, err
+			}
+			return bz, nil
+		}
+	}
+
 	var buf bytes.Buffer
 	_, err := NewDelimitedWriter(&buf).WriteMsg(msg)
 	if err != nil {
@@ -0,0 +1,44 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package pubsub
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Subscriptions: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "subscriptions",
+			Help:      "Number of open subscriptions.",
+		}, labels).With(labelsAndValues...),
+		MessagesDropped: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "messages_dropped",
+			Help:      "Number of messages dropped because the buffer of a subscription was full, by overflow policy (drop_newest or drop_oldest).",
+		}, append(labels, "policy")).With(labelsAndValues...),
+		SubscriptionsClosed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "subscriptions_closed",
+			Help:      "Number of subscriptions closed because their buffer was full.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Subscriptions:       discard.NewGauge(),
+		MessagesDropped:     discard.NewCounter(),
+		SubscriptionsClosed: discard.NewCounter(),
+	}
+}
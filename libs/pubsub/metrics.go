@@ -0,0 +1,24 @@
+package pubsub
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "pubsub"
+)
+
+//go:generate go run ../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of open subscriptions.
+	Subscriptions metrics.Gauge
+	// Number of messages dropped because the buffer of a subscription was
+	// full, by overflow policy (drop_newest or drop_oldest).
+	MessagesDropped metrics.Counter `metrics_labels:"policy"`
+	// Number of subscriptions closed because their buffer was full.
+	SubscriptionsClosed metrics.Counter
+}
@@ -6,9 +6,10 @@
 // that channel (fan-in).
 //
 // Clients subscribe for messages, which could be of any type, using a query.
-// When some message is published, we match it with all queries. If there is a
-// match, this message will be pushed to all clients, subscribed to that query.
-// See query subpackage for our implementation.
+// When some message is published, we match it with the queries which may match
+// its events (see IndexedQuery). Each query is evaluated once, and if there is
+// a match, the message is pushed to all clients subscribed to that query. See
+// query subpackage for our implementation.
 //
 // Example:
 //
@@ -37,6 +38,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/cometbft/cometbft/libs/service"
 	cmtsync "github.com/cometbft/cometbft/libs/sync"
@@ -72,6 +74,16 @@ type Query interface {
 	String() string
 }
 
+// IndexedQuery is a Query which can only match events having a given key and,
+// if hasValue is true, a given value for that key. The key may also be an
+// event type (e.g. "{eventType}"). The server uses it to skip the query for
+// the events it can't match. Queries returning an empty key are matched
+// against all events.
+type IndexedQuery interface {
+	Query
+	IndexKey() (key, value string, hasValue bool)
+}
+
 type cmd struct {
 	op operation
 
@@ -92,6 +104,7 @@ type Server struct {
 
 	cmds    chan cmd
 	cmdsCap int
+	metrics *Metrics
 
 	// check if we have subscription before
 	// subscribing or unsubscribing
@@ -108,6 +121,7 @@ type Option func(*Server)
 func NewServer(options ...Option) *Server {
 	s := &Server{
 		subscriptions: make(map[string]map[string]struct{}),
+		metrics:       NopMetrics(),
 	}
 	s.BaseService = *service.NewBaseService(nil, "PubSub", s)
 
@@ -133,6 +147,11 @@ func BufferCapacity(cap int) Option {
 	}
 }
 
+// WithMetrics sets the metrics of the server.
+func WithMetrics(metrics *Metrics) Option {
+	return func(s *Server) { s.metrics = metrics }
+}
+
 // BufferCapacity returns capacity of the internal server's queue.
 func (s *Server) BufferCapacity() int {
 	return s.cmdsCap
@@ -159,17 +178,54 @@ func (s *Server) Subscribe(
 		outCap = outCapacity[0]
 	}
 
-	return s.subscribe(ctx, clientID, query, outCap)
+	return s.subscribe(ctx, clientID, query, NewSubscription(outCap))
+}
+
+// SubscribeWithPolicy does the same as Subscribe, except that policy decides
+// what happens when the client is not pulling the messages fast enough and
+// the buffer of the subscription, of outCapacity messages, is full. The
+// server never blocks on such a subscription. If not nil, onDrop is called
+// for each dropped message; it is called by the server's goroutine, so it
+// must not block.
+func (s *Server) SubscribeWithPolicy(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	outCapacity int,
+	policy OverflowPolicy,
+	onDrop func(),
+) (*Subscription, error) {
+	if outCapacity <= 0 {
+		panic("Negative or zero capacity. Use SubscribeUnbuffered if you want an unbuffered channel")
+	}
+
+	subscription := NewSubscription(outCapacity)
+	subscription.policy = policy
+	subscription.onDrop = onDrop
+	return s.subscribe(ctx, clientID, query, subscription)
+}
+
+// SubscribeDropping is SubscribeWithPolicy with OverflowDropNewest: the
+// messages are dropped, instead of the subscription being terminated with
+// ErrOutOfCapacity, when the client is not pulling them fast enough.
+func (s *Server) SubscribeDropping(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	outCapacity int,
+	onDrop func(),
+) (*Subscription, error) {
+	return s.SubscribeWithPolicy(ctx, clientID, query, outCapacity, OverflowDropNewest, onDrop)
 }
 
 // SubscribeUnbuffered does the same as Subscribe, except it returns a
 // subscription with unbuffered channel. Use with caution as it can freeze the
 // server.
 func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query Query) (*Subscription, error) {
-	return s.subscribe(ctx, clientID, query, 0)
+	return s.subscribe(ctx, clientID, query, NewSubscription(0))
 }
 
-func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
+func (s *Server) subscribe(ctx context.Context, clientID string, query Query, subscription *Subscription) (*Subscription, error) {
 	s.mtx.RLock()
 	clientSubscriptions, ok := s.subscriptions[clientID]
 	if ok {
@@ -180,7 +236,6 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 		return nil, ErrAlreadySubscribed
 	}
 
-	subscription := NewSubscription(outCapacity)
 	select {
 	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
 		s.mtx.Lock()
@@ -291,10 +346,34 @@ func (s *Server) OnStop() {
 
 // NOTE: not goroutine safe
 type state struct {
+	metrics *Metrics
+
 	// query string -> client -> subscription
 	subscriptions map[string]map[string]*Subscription
 	// query string -> queryPlusRefCount
 	queries map[string]*queryPlusRefCount
+	// index key -> query string -> empty struct
+	index map[indexKey]map[string]struct{}
+	// query string -> empty struct, for the queries which can't be indexed
+	unindexed map[string]struct{}
+}
+
+// indexKey is the key under which an IndexedQuery is indexed.
+type indexKey struct {
+	key, value string
+	hasValue   bool
+}
+
+func queryIndexKey(q Query) (indexKey, bool) {
+	iq, ok := q.(IndexedQuery)
+	if !ok {
+		return indexKey{}, false
+	}
+	key, value, hasValue := iq.IndexKey()
+	if key == "" {
+		return indexKey{}, false
+	}
+	return indexKey{key: key, value: value, hasValue: hasValue}, true
 }
 
 // queryPlusRefCount holds a pointer to a query and reference counter. When
@@ -307,8 +386,11 @@ type queryPlusRefCount struct {
 // OnStart implements Service.OnStart by starting the server.
 func (s *Server) OnStart() error {
 	go s.loop(state{
+		metrics:       s.metrics,
 		subscriptions: make(map[string]map[string]*Subscription),
 		queries:       make(map[string]*queryPlusRefCount),
+		index:         make(map[indexKey]map[string]struct{}),
+		unindexed:     make(map[string]struct{}),
 	})
 	return nil
 }
@@ -350,10 +432,19 @@ func (state *state) add(clientID string, q Query, subscription *Subscription) {
 	}
 	// create subscription
 	state.subscriptions[qStr][clientID] = subscription
+	state.metrics.Subscriptions.Add(1)
 
 	// initialize query if needed
 	if _, ok := state.queries[qStr]; !ok {
 		state.queries[qStr] = &queryPlusRefCount{q: q, refCount: 0}
+		if ik, ok := queryIndexKey(q); ok {
+			if _, ok := state.index[ik]; !ok {
+				state.index[ik] = make(map[string]struct{})
+			}
+			state.index[ik][qStr] = struct{}{}
+		} else {
+			state.unindexed[qStr] = struct{}{}
+		}
 	}
 	// increment reference counter
 	state.queries[qStr].refCount++
@@ -371,6 +462,7 @@ func (state *state) remove(clientID string, qStr string, reason error) {
 	}
 
 	subscription.cancel(reason)
+	state.metrics.Subscriptions.Add(-1)
 
 	// remove client from query map.
 	// if query has no other clients subscribed, remove it.
@@ -383,6 +475,14 @@ func (state *state) remove(clientID string, qStr string, reason error) {
 	state.queries[qStr].refCount--
 	// remove the query if nobody else is using it
 	if state.queries[qStr].refCount == 0 {
+		if ik, ok := queryIndexKey(state.queries[qStr].q); ok {
+			delete(state.index[ik], qStr)
+			if len(state.index[ik]) == 0 {
+				delete(state.index, ik)
+			}
+		} else {
+			delete(state.unindexed, qStr)
+		}
 		delete(state.queries, qStr)
 	}
 }
@@ -403,8 +503,41 @@ func (state *state) removeAll(reason error) {
 	}
 }
 
+// candidates returns the queries which may match the given events: the ones
+// indexed under one of the event keys, event types or key/value pairs, and the
+// ones which can't be indexed.
+func (state *state) candidates(events map[string][]string) map[string]struct{} {
+	candidates := make(map[string]struct{}, len(state.unindexed))
+	for qStr := range state.unindexed {
+		candidates[qStr] = struct{}{}
+	}
+	if len(state.index) == 0 {
+		return candidates
+	}
+
+	lookup := func(ik indexKey) {
+		for qStr := range state.index[ik] {
+			candidates[qStr] = struct{}{}
+		}
+	}
+	for k, vs := range events {
+		lookup(indexKey{key: k})
+		if i := strings.LastIndexByte(k, '.'); i >= 0 {
+			lookup(indexKey{key: k[:i]})
+		}
+		for _, v := range vs {
+			lookup(indexKey{key: k, value: v, hasValue: true})
+		}
+	}
+	return candidates
+}
+
 func (state *state) send(msg interface{}, events map[string][]string) error {
-	for qStr, clientSubscriptions := range state.subscriptions {
+	for qStr := range state.candidates(events) {
+		clientSubscriptions, ok := state.subscriptions[qStr]
+		if !ok {
+			continue
+		}
 		q := state.queries[qStr].q
 
 		match, err := q.Matches(events)
@@ -417,13 +550,13 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 				if cap(subscription.out) == 0 {
 					// block on unbuffered channel
 					subscription.out <- NewMessage(msg, events)
-				} else {
-					// don't block on buffered channels
-					select {
-					case subscription.out <- NewMessage(msg, events):
-					default:
-						state.remove(clientID, qStr, ErrOutOfCapacity)
-					}
+					continue
+				}
+				// don't block on buffered channels
+				select {
+				case subscription.out <- NewMessage(msg, events):
+				default:
+					state.overflow(clientID, qStr, subscription, NewMessage(msg, events))
 				}
 			}
 		}
@@ -431,3 +564,31 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 
 	return nil
 }
+
+// overflow applies the policy of a subscription whose buffer is full to msg.
+func (state *state) overflow(clientID, qStr string, subscription *Subscription, msg Message) {
+	switch subscription.policy {
+	case OverflowDropNewest:
+	case OverflowDropOldest:
+		// the client may pull the oldest message first, leaving room for msg
+		select {
+		case <-subscription.out:
+		default:
+		}
+		// the server is the only sender, so there is room for msg now
+		select {
+		case subscription.out <- msg:
+		default:
+		}
+	default:
+		state.metrics.SubscriptionsClosed.Add(1)
+		state.remove(clientID, qStr, ErrOutOfCapacity)
+		return
+	}
+
+	subscription.dropped.Add(1)
+	state.metrics.MessagesDropped.With("policy", subscription.policy.String()).Add(1)
+	if subscription.onDrop != nil {
+		subscription.onDrop()
+	}
+}
@@ -149,6 +149,96 @@ func TestSlowClientIsRemovedWithErrOutOfCapacity(t *testing.T) {
 	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
 }
 
+func TestSlowClientMessagesAreDropped(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	assert.Panics(t, func() {
+		_, err = s.SubscribeDropping(ctx, clientID, query.All, 0, nil)
+		require.NoError(t, err)
+	})
+
+	dropped := make(chan struct{}, 10)
+	subscription, err := s.SubscribeDropping(ctx, clientID, query.All, 1, func() { dropped <- struct{}{} })
+	require.NoError(t, err)
+	for _, msg := range []string{"Fat Cobra", "Viper", "Black Mamba"} {
+		err = s.Publish(ctx, msg)
+		require.NoError(t, err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-dropped:
+		case <-time.After(3 * time.Second):
+			t.Fatal("Expected the messages to be dropped")
+		}
+	}
+
+	assertReceive(t, "Fat Cobra", subscription.Out())
+	err = s.Publish(ctx, "Quicksilver")
+	require.NoError(t, err)
+	assertReceive(t, "Quicksilver", subscription.Out())
+	assert.NoError(t, subscription.Err())
+}
+
+func TestSubscribeDropOldest(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	dropped := make(chan struct{}, 10)
+	subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.All, 2, pubsub.OverflowDropOldest,
+		func() { dropped <- struct{}{} })
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.OverflowDropOldest, subscription.Policy())
+	for _, msg := range []string{"Fat Cobra", "Viper", "Black Mamba", "Quicksilver"} {
+		err = s.Publish(ctx, msg)
+		require.NoError(t, err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-dropped:
+		case <-time.After(3 * time.Second):
+			t.Fatal("Expected the messages to be dropped")
+		}
+	}
+
+	// the newest messages are kept
+	assertReceive(t, "Black Mamba", subscription.Out())
+	assertReceive(t, "Quicksilver", subscription.Out())
+	assert.EqualValues(t, 2, subscription.Dropped())
+	assert.NoError(t, subscription.Err())
+}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	for _, policy := range []pubsub.OverflowPolicy{
+		pubsub.OverflowClose, pubsub.OverflowDropNewest, pubsub.OverflowDropOldest,
+	} {
+		parsed, err := pubsub.ParseOverflowPolicy(policy.String())
+		require.NoError(t, err)
+		assert.Equal(t, policy, parsed)
+	}
+	parsed, err := pubsub.ParseOverflowPolicy("drop")
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.OverflowDropNewest, parsed)
+	_, err = pubsub.ParseOverflowPolicy("block")
+	assert.Error(t, err)
+}
+
 func TestDifferentClients(t *testing.T) {
 	s := pubsub.NewServer()
 	s.SetLogger(log.TestingLogger())
@@ -193,6 +283,57 @@ func TestDifferentClients(t *testing.T) {
 	assert.Zero(t, len(subscription3.Out()))
 }
 
+func TestIndexedQueries(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	subscribe := func(clientID, q string) *pubsub.Subscription {
+		subscription, err := s.Subscribe(ctx, clientID, query.MustCompile(q), 10)
+		require.NoError(t, err)
+		return subscription
+	}
+	byValue := subscribe("client-1", "tm.event = 'Tx' AND transfer.sender = 'Igor'")
+	byKey := subscribe("client-2", "transfer.amount > 10")
+	byType := subscribe("client-3", "transfer EXISTS")
+	byList := subscribe("client-4", "transfer.sender IN ('Ivan', 'Igor')")
+	all, err := s.Subscribe(ctx, "client-5", query.All, 10)
+	require.NoError(t, err)
+
+	publish := func(msg string, events map[string][]string) {
+		require.NoError(t, s.PublishWithEvents(ctx, msg, events))
+	}
+	publish("Ivan", map[string][]string{"tm.event": {"Tx"}, "transfer.sender": {"Ivan"}, "transfer.amount": {"5"}})
+	publish("Igor", map[string][]string{"tm.event": {"Tx"}, "transfer.sender": {"Ivan", "Igor"}, "transfer.amount": {"20"}})
+	publish("NewBlock", map[string][]string{"tm.event": {"NewBlock"}})
+
+	assertReceive(t, "Igor", byValue.Out())
+	assertReceive(t, "Igor", byKey.Out())
+	assertReceive(t, "Ivan", byType.Out())
+	assertReceive(t, "Igor", byType.Out())
+	assertReceive(t, "Ivan", byList.Out())
+	assertReceive(t, "Igor", byList.Out())
+	assertReceive(t, "Ivan", all.Out())
+	assertReceive(t, "Igor", all.Out())
+	assertReceive(t, "NewBlock", all.Out())
+	for _, subscription := range []*pubsub.Subscription{byValue, byKey, byType, byList} {
+		assert.Zero(t, len(subscription.Out()))
+	}
+
+	// once unsubscribed, the query is removed from the index
+	require.NoError(t, s.Unsubscribe(ctx, "client-1", query.MustCompile("tm.event = 'Tx' AND transfer.sender = 'Igor'")))
+	assertCancelled(t, byValue, pubsub.ErrUnsubscribed)
+	byValue = subscribe("client-6", "tm.event = 'Tx' AND transfer.sender = 'Igor'")
+	publish("Igor", map[string][]string{"tm.event": {"Tx"}, "transfer.sender": {"Igor"}})
+	assertReceive(t, "Igor", byValue.Out())
+}
+
 func TestSubscribeDuplicateKeys(t *testing.T) {
 	ctx := context.Background()
 	s := pubsub.NewServer()
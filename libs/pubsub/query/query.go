@@ -9,6 +9,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -92,7 +93,26 @@ func (q *Query) Matches(events map[string][]string) (bool, error) {
 	if q == nil {
 		return true, nil
 	}
-	return q.matchesEvents(ExpandEvents(events)), nil
+	return q.matchesEvents(events), nil
+}
+
+// IndexKey satisfies part of the pubsub.IndexedQuery interface. It returns the
+// tag of one of the conditions of q, along with its value if the condition is
+// a string equality. Events which don't have that tag (or value) can't match
+// q. A nil *Query can't be indexed.
+func (q *Query) IndexKey() (key, value string, hasValue bool) {
+	if q == nil || len(q.conds) == 0 {
+		return "", "", false
+	}
+	// Prefer the last string equality: conditions on the event type usually
+	// come first, and are the least selective.
+	for i := len(q.ast) - 1; i >= 0; i-- {
+		c := q.ast[i]
+		if c.Op == syntax.TEq && c.Arg.Type == syntax.TString && c.Arg.Value() != "" {
+			return c.Tag, c.Arg.Value(), true
+		}
+	}
+	return q.conds[0].tag, "", false
 }
 
 // String matches part of the pubsub.Query interface.
@@ -112,7 +132,7 @@ func (q *Query) Syntax() syntax.Query {
 }
 
 // matchesEvents reports whether all the conditions match the given events.
-func (q *Query) matchesEvents(events []types.Event) bool {
+func (q *Query) matchesEvents(events map[string][]string) bool {
 	for _, cond := range q.conds {
 		if !cond.matchesAny(events) {
 			return false
@@ -127,54 +147,28 @@ func (q *Query) matchesEvents(events []types.Event) bool {
 type condition struct {
 	tag   string // e.g., "tx.hash"
 	match func(s string) bool
-}
 
-// findAttr returns a slice of attribute values from event matching the
-// condition tag, and reports whether the event type strictly equals the
-// condition tag.
-func (c condition) findAttr(event types.Event) ([]string, bool) {
-	if !strings.HasPrefix(c.tag, event.Type) {
-		return nil, false // type does not match tag
-	} else if len(c.tag) == len(event.Type) {
-		return nil, true // type == tag
-	}
-	var vals []string
-	for _, attr := range event.Attributes {
-		fullName := event.Type + "." + attr.Key
-		if fullName == c.tag {
-			vals = append(vals, attr.Value)
-		}
-	}
-	return vals, false
+	// matchesEmpty caches match(""), see matchesAny.
+	matchesEmpty bool
 }
 
-// matchesAny reports whether c matches at least one of the given events.
-func (c condition) matchesAny(events []types.Event) bool {
-	for _, event := range events {
-		if c.matchesEvent(event) {
+// matchesAny reports whether c matches at least one of the given events. The
+// events are in their flattened form, keyed by "{eventType}.{eventAttrKey}".
+func (c condition) matchesAny(events map[string][]string) bool {
+	for _, v := range events[c.tag] {
+		if c.match(v) {
 			return true
 		}
 	}
-	return false
-}
-
-// matchesEvent reports whether c matches the given event.
-func (c condition) matchesEvent(event types.Event) bool {
-	vs, tagEqualsType := c.findAttr(event)
-	if len(vs) == 0 {
-		// As a special case, a condition tag that exactly matches the event type
-		// is matched against an empty string. This allows existence checks to
-		// work for type-only queries.
-		if tagEqualsType {
-			return c.match("")
-		}
-		return false
-	}
 
-	// At this point, we have candidate values.
-	for _, v := range vs {
-		if c.match(v) {
-			return true
+	// As a special case, a condition tag that exactly matches the event type
+	// is matched against an empty string. This allows existence checks to
+	// work for type-only queries.
+	if c.matchesEmpty {
+		for k := range events {
+			if i := strings.LastIndexByte(k, '.'); i >= 0 && k[:i] == c.tag {
+				return true
+			}
 		}
 	}
 	return false
@@ -187,6 +181,17 @@ func compileCondition(cond syntax.Condition) (condition, error) {
 	// comparisons that take arguments.
 	if cond.Op == syntax.TExists {
 		out.match = func(string) bool { return true }
+		out.matchesEmpty = true
+		return out, nil
+	}
+
+	if cond.Op == syntax.TIn {
+		match, err := compileIn(cond.Args)
+		if err != nil {
+			return condition{}, err
+		}
+		out.match = match
+		out.matchesEmpty = match("")
 		return out, nil
 	}
 
@@ -215,9 +220,49 @@ func compileCondition(cond syntax.Condition) (condition, error) {
 		return condition{}, fmt.Errorf("invalid op/arg combination (%v, %v)", cond.Op, argType)
 	}
 	out.match = mcons(argValue)
+	out.matchesEmpty = out.match("")
 	return out, nil
 }
 
+// compileIn compiles the arguments of an IN condition into a function which
+// reports whether a value equals any of them. String arguments are looked up
+// in a set, the others are compared in turn.
+func compileIn(args []*syntax.Arg) (func(string) bool, error) {
+	if len(args) == 0 {
+		return nil, errors.New("missing arguments for IN")
+	}
+
+	strs := make(map[string]struct{})
+	var others []func(string) bool
+	for _, arg := range args {
+		var argValue interface{}
+		switch arg.Type {
+		case syntax.TString:
+			strs[arg.Value()] = struct{}{}
+			continue
+		case syntax.TNumber:
+			argValue = arg.Number()
+		case syntax.TTime, syntax.TDate:
+			argValue = arg.Time()
+		default:
+			return nil, fmt.Errorf("unknown argument type %v", arg.Type)
+		}
+		others = append(others, opTypeMap[syntax.TEq][arg.Type](argValue))
+	}
+
+	return func(s string) bool {
+		if _, ok := strs[s]; ok {
+			return true
+		}
+		for _, match := range others {
+			if match(s) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
 // TODO(creachadair): The existing implementation allows anything number shaped
 // to be treated as a number. This preserves the parts of that behavior we had
 // tests for, but we should probably get rid of that.
@@ -241,6 +286,13 @@ var opTypeMap = map[syntax.Token]map[syntax.Token]func(interface{}) func(string)
 			}
 		},
 	},
+	syntax.TStartsWith: {
+		syntax.TString: func(v interface{}) func(string) bool {
+			return func(s string) bool {
+				return strings.HasPrefix(s, v.(string))
+			}
+		},
+	},
 	syntax.TEq: {
 		syntax.TString: func(v interface{}) func(string) bool {
 			return func(s string) bool { return s == v.(string) }
@@ -245,6 +245,30 @@ func TestCompiledMatches(t *testing.T) {
 		{`abci.owner.name CONTAINS 'Igor'`,
 			newTestEvents(`abci|owner.name=Pavel|owner.name=Ivan`),
 			false},
+		{`abci.owner.name STARTS_WITH 'Ig'`,
+			newTestEvents(`abci|owner.name=Igor|owner.name=Ivan`),
+			true},
+		{`abci.owner.name STARTS_WITH 'gor'`,
+			newTestEvents(`abci|owner.name=Igor|owner.name=Ivan`),
+			false},
+		{`abci.owner.name IN ('Pavel', 'Ivan')`,
+			newTestEvents(`abci|owner.name=Igor|owner.name=Ivan`),
+			true},
+		{`abci.owner.name IN ('Pavel', 'John')`,
+			newTestEvents(`abci|owner.name=Igor|owner.name=Ivan`),
+			false},
+		{`tx.gas IN (7, 8)`,
+			newTestEvents(`tx|gas=8`),
+			true},
+		{`tx.gas IN (7, 9)`,
+			newTestEvents(`tx|gas=8`),
+			false},
+		{`tx.date IN (DATE 2018-01-01, DATE 2017-01-01)`,
+			newTestEvents(`tx|date=` + txDate),
+			true},
+		{`abci.owner IN ('')`,
+			newTestEvents(`abci|owner.name=Igor`),
+			true},
 		{`abci.owner.name = 'Igor'`,
 			newTestEvents(`abci|owner.name=Igor|owner.name=Ivan`),
 			true},
@@ -8,10 +8,13 @@
 //	query      = conditions EOF
 //	conditions = condition {"AND" condition}
 //	condition  = tag comparison
-//	comparison = equal / order / contains / "EXISTS"
-//	equal      = "=" (date / number / time / value)
+//	comparison = equal / order / contains / prefix / in / "EXISTS"
+//	equal      = "=" operand
 //	order      = cmp (date / number / time)
 //	contains   = "CONTAINS" value
+//	prefix     = "STARTS_WITH" value
+//	in         = "IN" "(" operand {"," operand} ")"
+//	operand    = date / number / time / value
 //	cmp        = "<" / "<=" / ">" / ">="
 //
 // The lexical terms are defined here using RE2 regular expression notation:
@@ -29,17 +29,26 @@ func (q Query) String() string {
 
 // A Condition is a single conditional expression, consisting of a tag, a
 // comparison operator, and an optional argument. The type of the argument
-// depends on the operator.
+// depends on the operator. The IN operator takes a list of arguments, stored
+// in Args, instead.
 type Condition struct {
-	Tag string
-	Op  Token
-	Arg *Arg
+	Tag  string
+	Op   Token
+	Arg  *Arg
+	Args []*Arg
 
 	opText string
 }
 
 func (c Condition) String() string {
 	s := c.Tag + " " + c.opText
+	if c.Op == TIn {
+		ss := make([]string, len(c.Args))
+		for i, arg := range c.Args {
+			ss[i] = arg.String()
+		}
+		return s + " (" + strings.Join(ss, ", ") + ")"
+	}
 	if c.Arg != nil {
 		return s + " " + c.Arg.String()
 	}
@@ -147,7 +156,7 @@ func (p *Parser) parseCond() (Condition, error) {
 		return cond, err
 	}
 	cond.Tag = p.scanner.Text()
-	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TExists); err != nil {
+	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TStartsWith, TIn, TExists); err != nil {
 		return cond, err
 	}
 	cond.Op = p.scanner.Token()
@@ -159,8 +168,11 @@ func (p *Parser) parseCond() (Condition, error) {
 		err = p.require(TNumber, TTime, TDate)
 	case TEq:
 		err = p.require(TNumber, TTime, TDate, TString)
-	case TContains:
+	case TContains, TStartsWith:
 		err = p.require(TString)
+	case TIn:
+		cond.Args, err = p.parseList()
+		return cond, err
 	case TExists:
 		// no argument
 		return cond, nil
@@ -174,6 +186,27 @@ func (p *Parser) parseCond() (Condition, error) {
 	return cond, nil
 }
 
+// parseList parses the non-empty, parenthesized list of arguments of the IN
+// operator.
+func (p *Parser) parseList() ([]*Arg, error) {
+	if err := p.require(TLParen); err != nil {
+		return nil, err
+	}
+	var args []*Arg
+	for {
+		if err := p.require(TNumber, TTime, TDate, TString); err != nil {
+			return nil, err
+		}
+		args = append(args, &Arg{Type: p.scanner.Token(), text: p.scanner.Text()})
+		if err := p.require(TComma, TRParen); err != nil {
+			return nil, err
+		}
+		if p.scanner.Token() == TRParen {
+			return args, nil
+		}
+	}
+}
+
 // require advances the scanner and requires that the resulting token is one of
 // the specified token types.
 func (p *Parser) require(tokens ...Token) error {
@@ -30,6 +30,12 @@ const (
 	TGeq             // operator: >=
 
 	// Do not reorder these values without updating the scanner code.
+
+	TIn         // operator: IN
+	TStartsWith // operator: STARTS_WITH
+	TLParen     // list start: (
+	TRParen     // list end: )
+	TComma      // list separator: ,
 )
 
 var tString = [...]string{
@@ -47,11 +53,17 @@ var tString = [...]string{
 	TLeq:      "<= operator",
 	TGt:       "> operator",
 	TGeq:      ">= operator",
+
+	TIn:         "IN operator",
+	TStartsWith: "STARTS_WITH operator",
+	TLParen:     "(",
+	TRParen:     ")",
+	TComma:      ",",
 }
 
 func (t Token) String() string {
 	v := int(t)
-	if v > len(tString) {
+	if v >= len(tString) {
 		return "unknown token type"
 	}
 	return tString[v]
@@ -107,6 +119,8 @@ func (s *Scanner) Next() error {
 			return s.scanString(ch)
 		case '<', '>', '=':
 			return s.scanCompare(ch)
+		case '(', ')', ',':
+			return s.scanPunct(ch)
 		default:
 			return s.invalid(ch)
 		}
@@ -193,6 +207,21 @@ func (s *Scanner) scanCompare(first rune) error {
 	return nil
 }
 
+func (s *Scanner) scanPunct(ch rune) error {
+	s.buf.WriteRune(ch)
+	switch ch {
+	case '(':
+		s.tok = TLParen
+	case ')':
+		s.tok = TRParen
+	case ',':
+		s.tok = TComma
+	default:
+		return s.invalid(ch)
+	}
+	return nil
+}
+
 func (s *Scanner) scanTagLike(first rune) error {
 	s.buf.WriteRune(first)
 	var hasSpace bool
@@ -228,6 +257,10 @@ func (s *Scanner) scanTagLike(first rune) error {
 		s.tok = TExists
 	case "CONTAINS":
 		s.tok = TContains
+	case "IN":
+		s.tok = TIn
+	case "STARTS_WITH":
+		s.tok = TStartsWith
 	default:
 		s.tok = TTag
 	}
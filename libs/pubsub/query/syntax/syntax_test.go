@@ -39,6 +39,10 @@ func TestScanner(t *testing.T) {
 		{`x AND y`, []syntax.Token{syntax.TTag, syntax.TAnd, syntax.TTag}},
 		{`x.y CONTAINS 'z'`, []syntax.Token{syntax.TTag, syntax.TContains, syntax.TString}},
 		{`foo EXISTS`, []syntax.Token{syntax.TTag, syntax.TExists}},
+		{`x STARTS_WITH 'y'`, []syntax.Token{syntax.TTag, syntax.TStartsWith, syntax.TString}},
+		{`x IN ('y', 1)`, []syntax.Token{
+			syntax.TTag, syntax.TIn, syntax.TLParen, syntax.TString, syntax.TComma, syntax.TNumber, syntax.TRParen,
+		}},
 		{`and AND`, []syntax.Token{syntax.TTag, syntax.TAnd}},
 
 		// Timestamp
@@ -129,6 +133,20 @@ func TestParseValid(t *testing.T) {
 
 		{"abci.account.name CONTAINS 'Igor'", true},
 
+		{"abci.account.name STARTS_WITH 'Ig'", true},
+		{"abci.account.name STARTS_WITH 5", false},
+		{"abci.account.name STARTS_WITH", false},
+
+		{"abci.account.name IN ('Igor')", true},
+		{"abci.account.name IN ('Igor', 'Ivan')", true},
+		{"account.balance IN (1, 2.5, 'x', DATE 2013-05-03, TIME 2013-05-03T14:45:00Z)", true},
+		{"abci.account.name IN ('Igor', 'Ivan') AND account.balance=100", true},
+		{"abci.account.name IN ()", false},
+		{"abci.account.name IN 'Igor'", false},
+		{"abci.account.name IN ('Igor',)", false},
+		{"abci.account.name IN ('Igor' 'Ivan')", false},
+		{"abci.account.name IN ('Igor'", false},
+
 		{"tx.date > DATE 2013-05-03", true},
 		{"tx.date < DATE 2013-05-03", true},
 		{"tx.date <= DATE 2013-05-03", true},
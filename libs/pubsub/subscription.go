@@ -2,6 +2,8 @@ package pubsub
 
 import (
 	"errors"
+	"fmt"
+	"sync/atomic"
 
 	cmtsync "github.com/cometbft/cometbft/libs/sync"
 )
@@ -15,6 +17,49 @@ var (
 	ErrOutOfCapacity = errors.New("internal subscription event buffer is out of capacity")
 )
 
+// OverflowPolicy is what the server does with a message when the buffer of a
+// subscription is full.
+type OverflowPolicy int
+
+const (
+	// OverflowClose cancels the subscription with ErrOutOfCapacity.
+	OverflowClose OverflowPolicy = iota
+	// OverflowDropNewest drops the message, keeping the subscription open.
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest message in the buffer to make room
+	// for the new one, keeping the subscription open.
+	OverflowDropOldest
+)
+
+// String returns the name of the policy, as accepted by ParseOverflowPolicy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowClose:
+		return "close"
+	case OverflowDropNewest:
+		return "drop_newest"
+	case OverflowDropOldest:
+		return "drop_oldest"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// ParseOverflowPolicy returns the policy named s: "close", "drop_newest" (or
+// "drop") or "drop_oldest".
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch s {
+	case "close":
+		return OverflowClose, nil
+	case "drop", "drop_newest":
+		return OverflowDropNewest, nil
+	case "drop_oldest":
+		return OverflowDropOldest, nil
+	default:
+		return 0, fmt.Errorf("unknown overflow policy %q", s)
+	}
+}
+
 // A Subscription represents a client subscription for a particular query and
 // consists of three things:
 // 1) channel onto which messages and events are published
@@ -23,6 +68,11 @@ var (
 type Subscription struct {
 	out chan Message
 
+	// what to do when out is full
+	policy  OverflowPolicy
+	onDrop  func()
+	dropped atomic.Uint64
+
 	canceled chan struct{}
 	mtx      cmtsync.RWMutex
 	err      error
@@ -43,6 +93,18 @@ func (s *Subscription) Out() <-chan Message {
 	return s.out
 }
 
+// Policy returns what the server does when the buffer of the subscription is
+// full.
+func (s *Subscription) Policy() OverflowPolicy {
+	return s.policy
+}
+
+// Dropped returns the number of messages dropped because the buffer of the
+// subscription was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
 // Canceled returns a channel that's closed when the subscription is
 // terminated and supposed to be used in a select statement.
 func (s *Subscription) Canceled() <-chan struct{} {
@@ -0,0 +1,107 @@
+// Package ratelimit provides token buckets, to limit the rate of requests
+// (RPC calls, messages from peers...), alone or per key with Limiter. Buckets
+// can be nested, so that a token is only taken from a bucket if its parent,
+// the budget it shares with others, has one too.
+package ratelimit
+
+import (
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// Limit is the rate, in tokens per second, and the burst of a token bucket.
+// A zero rate disables the limit. The burst is at least 1.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited returns whether the limit is disabled.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+// Bucket is a token bucket, which starts full. It is safe for concurrent use.
+type Bucket struct {
+	limit  Limit
+	burst  float64
+	parent *Bucket
+
+	mtx    cmtsync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewBucket returns a full bucket. If parent is not nil, the bucket only
+// gives tokens which its parent gives too.
+func NewBucket(limit Limit, parent *Bucket) *Bucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &Bucket{limit: limit, burst: burst, parent: parent, tokens: burst}
+}
+
+// Limit returns the limit of the bucket.
+func (b *Bucket) Limit() Limit {
+	return b.limit
+}
+
+// Allow is shorthand for AllowN(time.Now(), 1).
+func (b *Bucket) Allow() bool {
+	return b.AllowN(time.Now(), 1)
+}
+
+// AllowN takes n tokens from the bucket and its ancestors at time now, and
+// returns true, if they all have n tokens; otherwise it takes none, and
+// returns false.
+func (b *Bucket) AllowN(now time.Time, n int) bool {
+	// lock the buckets from the child to the root, always in this order
+	var chain []*Bucket
+	for c := b; c != nil; c = c.parent {
+		c.mtx.Lock()
+		chain = append(chain, c)
+	}
+	defer func() {
+		for i := len(chain) - 1; i >= 0; i-- {
+			chain[i].mtx.Unlock()
+		}
+	}()
+
+	for _, c := range chain {
+		if !c.limit.Unlimited() && c.refill(now) < float64(n) {
+			return false
+		}
+	}
+	for _, c := range chain {
+		if !c.limit.Unlimited() {
+			c.tokens -= float64(n)
+		}
+	}
+	return true
+}
+
+// Full returns whether the bucket is full at time now, i.e. whether it has
+// been idle long enough to forget it.
+func (b *Bucket) Full(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.limit.Unlimited() || b.refill(now) >= b.burst
+}
+
+// refill adds the tokens accumulated since the last call and returns the
+// number of available tokens. b.mtx must be held.
+func (b *Bucket) refill(now time.Time) float64 {
+	if b.last.IsZero() {
+		b.last = now
+	}
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.limit.Rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	return b.tokens
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// SweepInterval is how often a Limiter drops the buckets of the idle keys.
+const SweepInterval = time.Minute
+
+// Limiter limits the rate of requests per key (e.g. a peer or a client), with
+// one bucket per key, created on the first request. The buckets of the keys
+// which went idle are eventually dropped, so that the memory used doesn't grow
+// with the number of keys ever seen.
+type Limiter struct {
+	limit  Limit
+	parent *Bucket
+
+	allowed metrics.Counter
+	limited metrics.Counter
+	keys    metrics.Gauge
+
+	mtx       cmtsync.Mutex
+	buckets   map[string]*Bucket
+	lastSweep time.Time
+}
+
+// Option sets an optional parameter of a Limiter.
+type Option func(*Limiter)
+
+// WithParent makes the requests of all the keys share the budget of parent,
+// e.g. a limit of the rate of the requests of all the peers together.
+func WithParent(parent *Bucket) Option {
+	return func(l *Limiter) { l.parent = parent }
+}
+
+// WithMetrics counts the requests of the limiter, under the given name.
+func WithMetrics(m *Metrics, name string) Option {
+	return func(l *Limiter) {
+		l.allowed = m.Allowed.With("limiter", name)
+		l.limited = m.Limited.With("limiter", name)
+		l.keys = m.Keys.With("limiter", name)
+	}
+}
+
+// NewLimiter returns a limiter applying limit to every key. A zero rate, with
+// no parent, disables the limiter.
+func NewLimiter(limit Limit, options ...Option) *Limiter {
+	l := &Limiter{
+		limit:   limit,
+		allowed: discard.NewCounter(),
+		limited: discard.NewCounter(),
+		keys:    discard.NewGauge(),
+		buckets: make(map[string]*Bucket),
+	}
+	for _, option := range options {
+		option(l)
+	}
+	return l
+}
+
+// Limit returns the limit of every key.
+func (l *Limiter) Limit() Limit {
+	return l.limit
+}
+
+// Allow is shorthand for AllowN(time.Now(), key, 1).
+func (l *Limiter) Allow(key string) bool {
+	return l.AllowN(time.Now(), key, 1)
+}
+
+// AllowN takes n tokens from the bucket of key, and from the parent bucket if
+// any, at time now, and returns false if there were not enough.
+func (l *Limiter) AllowN(now time.Time, key string, n int) bool {
+	if l.limit.Unlimited() && l.parent == nil {
+		l.allowed.Add(float64(n))
+		return true
+	}
+
+	var b *Bucket
+	if l.limit.Unlimited() {
+		b = l.parent
+	} else {
+		b = l.bucket(now, key)
+	}
+	if !b.AllowN(now, n) {
+		l.limited.Add(float64(n))
+		return false
+	}
+	l.allowed.Add(float64(n))
+	return true
+}
+
+// bucket returns the bucket of key, created if need be.
+func (l *Limiter) bucket(now time.Time, key string) *Bucket {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.lastSweep.IsZero() {
+		l.lastSweep = now
+	} else if now.Sub(l.lastSweep) >= SweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = NewBucket(l.limit, l.parent)
+		l.buckets[key] = b
+		l.keys.Set(float64(len(l.buckets)))
+	}
+	return b
+}
+
+// sweep drops the buckets which are full again. l.mtx must be held.
+func (l *Limiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if b.Full(now) {
+			delete(l.buckets, key)
+		}
+	}
+	l.keys.Set(float64(len(l.buckets)))
+	l.lastSweep = now
+}
+
+// Remove drops the bucket of key, e.g. of a peer which disconnected.
+func (l *Limiter) Remove(key string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	delete(l.buckets, key)
+	l.keys.Set(float64(len(l.buckets)))
+}
+
+// Len returns the number of keys tracked.
+func (l *Limiter) Len() int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return len(l.buckets)
+}
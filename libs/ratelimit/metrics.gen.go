@@ -0,0 +1,44 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package ratelimit
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Allowed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "allowed",
+			Help:      "Number of tokens given by a rate limiter.",
+		}, append(labels, "limiter")).With(labelsAndValues...),
+		Limited: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "limited",
+			Help:      "Number of tokens refused by a rate limiter, for exceeding its limit.",
+		}, append(labels, "limiter")).With(labelsAndValues...),
+		Keys: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "keys",
+			Help:      "Number of keys (peers, clients...) tracked by a rate limiter.",
+		}, append(labels, "limiter")).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Allowed: discard.NewCounter(),
+		Limited: discard.NewCounter(),
+		Keys:    discard.NewGauge(),
+	}
+}
@@ -0,0 +1,23 @@
+package ratelimit
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "ratelimit"
+)
+
+//go:generate go run ../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of tokens given by a rate limiter.
+	Allowed metrics.Counter `metrics_labels:"limiter"`
+	// Number of tokens refused by a rate limiter, for exceeding its limit.
+	Limited metrics.Counter `metrics_labels:"limiter"`
+	// Number of keys (peers, clients...) tracked by a rate limiter.
+	Keys metrics.Gauge `metrics_labels:"limiter"`
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewBucket(Limit{Rate: 1, Burst: 2}, nil)
+
+	// the burst is available right away, then one token per second
+	assert.True(t, b.AllowN(now, 1))
+	assert.True(t, b.AllowN(now, 1))
+	assert.False(t, b.AllowN(now, 1))
+	assert.False(t, b.Full(now))
+
+	now = now.Add(time.Second)
+	assert.True(t, b.AllowN(now, 1))
+	assert.False(t, b.AllowN(now, 1))
+
+	now = now.Add(time.Hour)
+	assert.True(t, b.Full(now))
+	assert.False(t, b.AllowN(now, 3), "more than the burst")
+	assert.True(t, b.AllowN(now, 2))
+
+	unlimited := NewBucket(Limit{}, nil)
+	for i := 0; i < 100; i++ {
+		assert.True(t, unlimited.AllowN(now, 10))
+	}
+}
+
+func TestBucketParent(t *testing.T) {
+	now := time.Unix(0, 0)
+	total := NewBucket(Limit{Rate: 1, Burst: 3}, nil)
+	a := NewBucket(Limit{Rate: 1, Burst: 2}, total)
+	b := NewBucket(Limit{Rate: 1, Burst: 2}, total)
+
+	assert.True(t, a.AllowN(now, 1))
+	assert.True(t, a.AllowN(now, 1))
+	assert.False(t, a.AllowN(now, 1), "a is out of tokens")
+	assert.True(t, b.AllowN(now, 1))
+	assert.False(t, b.AllowN(now, 1), "the total is out of tokens")
+
+	// no token is taken from b when the total has none
+	now = now.Add(time.Second)
+	assert.True(t, b.AllowN(now, 1))
+	assert.False(t, a.AllowN(now, 1))
+	now = now.Add(time.Second)
+	assert.True(t, a.AllowN(now, 1))
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewLimiter(Limit{Rate: 1, Burst: 2}, WithMetrics(NopMetrics(), "test"))
+
+	require.True(t, l.AllowN(now, "alice", 1))
+	require.True(t, l.AllowN(now, "alice", 1))
+	require.False(t, l.AllowN(now, "alice", 1))
+	// buckets are per key
+	require.True(t, l.AllowN(now, "bob", 1))
+	assert.Equal(t, 2, l.Len())
+
+	l.Remove("alice")
+	require.True(t, l.AllowN(now, "alice", 1))
+
+	// idle keys are eventually forgotten
+	now = now.Add(SweepInterval)
+	require.True(t, l.AllowN(now, "carol", 1))
+	assert.Equal(t, 1, l.Len())
+}
+
+func TestLimiterParent(t *testing.T) {
+	now := time.Unix(0, 0)
+	total := NewBucket(Limit{Rate: 1, Burst: 2}, nil)
+	l := NewLimiter(Limit{Rate: 1, Burst: 2}, WithParent(total))
+	require.True(t, l.AllowN(now, "alice", 1))
+	require.True(t, l.AllowN(now, "bob", 1))
+	require.False(t, l.AllowN(now, "carol", 1))
+
+	// without a limit per key, only the parent limits the requests
+	total = NewBucket(Limit{Rate: 1, Burst: 1}, nil)
+	l = NewLimiter(Limit{}, WithParent(total))
+	require.True(t, l.AllowN(now, "alice", 1))
+	require.False(t, l.AllowN(now, "bob", 1))
+	assert.Zero(t, l.Len())
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	l := NewLimiter(Limit{})
+	for i := 0; i < 100; i++ {
+		require.True(t, l.Allow("alice"))
+	}
+	assert.Zero(t, l.Len())
+}
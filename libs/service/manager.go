@@ -0,0 +1,266 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+const (
+	// DefaultStartTimeout is the time a service of a Manager has to start,
+	// unless set with StartTimeout.
+	DefaultStartTimeout = time.Minute
+	// DefaultStopTimeout is the time a service of a Manager has to stop,
+	// unless set with StopTimeout.
+	DefaultStopTimeout = 10 * time.Second
+)
+
+// Readier is implemented by the services which can be running without being
+// ready yet, e.g. while catching up. The other services are ready as soon as
+// they are running.
+type Readier interface {
+	Ready() bool
+}
+
+// Manager starts services in the order of their dependencies, and stops them
+// in the reverse order: a service is started after the services it depends
+// on, and stopped before them.
+//
+// The services are added with Add before the manager is started. A service
+// already running when the manager starts, e.g. one which had to be started
+// early to set up the others, is left as it is, and stopped by the manager in
+// the order of its dependencies.
+type Manager struct {
+	logger log.Logger
+
+	mtx      cmtsync.Mutex
+	services map[string]*managedService
+	added    []string // names of the services, in the order they were added
+	started  []string // names of the services started, in order
+}
+
+type managedService struct {
+	name         string
+	service      Service
+	dependencies []string
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+}
+
+// ManagedOption sets an optional parameter of a service of a Manager.
+type ManagedOption func(*managedService)
+
+// DependsOn declares the services which have to be started before the
+// service, and stopped after it.
+func DependsOn(names ...string) ManagedOption {
+	return func(ms *managedService) { ms.dependencies = append(ms.dependencies, names...) }
+}
+
+// StartTimeout sets the time the service has to start. 0 means no timeout.
+func StartTimeout(timeout time.Duration) ManagedOption {
+	return func(ms *managedService) { ms.startTimeout = timeout }
+}
+
+// StopTimeout sets the time the service has to stop, after which the manager
+// stops the next services without waiting for it. 0 means no timeout.
+func StopTimeout(timeout time.Duration) ManagedOption {
+	return func(ms *managedService) { ms.stopTimeout = timeout }
+}
+
+// NewManager returns a manager of no services.
+func NewManager(logger log.Logger) *Manager {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	return &Manager{
+		logger:   logger,
+		services: make(map[string]*managedService),
+	}
+}
+
+// Add adds a service under the given name. It returns an error if the name is
+// already taken.
+func (m *Manager) Add(name string, s Service, options ...ManagedOption) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.services[name]; ok {
+		return fmt.Errorf("service %q already added", name)
+	}
+	ms := &managedService{
+		name:         name,
+		service:      s,
+		startTimeout: DefaultStartTimeout,
+		stopTimeout:  DefaultStopTimeout,
+	}
+	for _, option := range options {
+		option(ms)
+	}
+	m.services[name] = ms
+	m.added = append(m.added, name)
+	return nil
+}
+
+// Order returns the names of the services in the order they are started:
+// every service after its dependencies, and otherwise in the order they were
+// added. It returns an error if a dependency is unknown or circular.
+func (m *Manager) Order() ([]string, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.order()
+}
+
+func (m *Manager) order() ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(m.services))
+	order := make([]string, 0, len(m.services))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		ms, ok := m.services[name]
+		if !ok {
+			return fmt.Errorf("service %q depends on unknown service %q", path[len(path)-1], name)
+		}
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular dependency: %s", strings.Join(append(path, name), " -> "))
+		}
+		state[name] = visiting
+		for _, dep := range ms.dependencies {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	for _, name := range m.added {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// Start starts the services in the order of their dependencies. If a service
+// fails to start, or doesn't start in time, the services started so far are
+// stopped, and the error is returned.
+func (m *Manager) Start() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	order, err := m.order()
+	if err != nil {
+		return err
+	}
+	for _, name := range order {
+		ms := m.services[name]
+		if !ms.service.IsRunning() {
+			m.logger.Debug("Starting service", "service", name)
+			if err := runWithTimeout(ms.service.Start, ms.startTimeout); err != nil {
+				m.stop()
+				return fmt.Errorf("failed to start %s: %w", name, err)
+			}
+		}
+		m.started = append(m.started, name)
+	}
+	return nil
+}
+
+// Stop stops the services started, in the reverse order of their
+// dependencies. A service which doesn't stop in time is logged, and left
+// behind. Stop returns an error listing the services which failed to stop.
+func (m *Manager) Stop() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.stop()
+}
+
+func (m *Manager) stop() error {
+	var failed []string
+	for i := len(m.started) - 1; i >= 0; i-- {
+		ms := m.services[m.started[i]]
+		m.logger.Debug("Stopping service", "service", ms.name)
+		err := runWithTimeout(ms.service.Stop, ms.stopTimeout)
+		if err != nil && !errors.Is(err, ErrAlreadyStopped) {
+			m.logger.Error("Failed to stop service", "service", ms.name, "err", err)
+			failed = append(failed, ms.name)
+		}
+	}
+	m.started = nil
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to stop %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// ServiceStatus is the status of a service of a Manager.
+type ServiceStatus struct {
+	Name    string
+	Running bool
+	Ready   bool
+}
+
+// Statuses returns the statuses of the services, in the order they were
+// added.
+func (m *Manager) Statuses() []ServiceStatus {
+	m.mtx.Lock()
+	services := make([]*managedService, 0, len(m.added))
+	for _, name := range m.added {
+		services = append(services, m.services[name])
+	}
+	m.mtx.Unlock()
+
+	statuses := make([]ServiceStatus, 0, len(services))
+	for _, ms := range services {
+		status := ServiceStatus{Name: ms.name, Running: ms.service.IsRunning()}
+		status.Ready = status.Running
+		if r, ok := ms.service.(Readier); ok && status.Running {
+			status.Ready = r.Ready()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// NotReady returns the names of the services which are not ready, in the
+// order they were added.
+func (m *Manager) NotReady() []string {
+	var names []string
+	for _, status := range m.Statuses() {
+		if !status.Ready {
+			names = append(names, status.Name)
+		}
+	}
+	return names
+}
+
+// runWithTimeout runs fn, returning an error if it doesn't return in time.
+// fn keeps running in the background after the timeout.
+func runWithTimeout(fn func() error, timeout time.Duration) error {
+	if timeout <= 0 {
+		return fn()
+	}
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+}
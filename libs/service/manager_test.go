@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// eventLog records the starts and stops of the services, some of which may
+// still be stopping in the background.
+type eventLog struct {
+	mtx    cmtsync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(event string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) get() []string {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return append([]string(nil), l.events...)
+}
+
+type managedTestService struct {
+	BaseService
+	events   *eventLog
+	startErr error
+	delay    time.Duration
+	ready    bool
+}
+
+func newManagedTestService(name string, events *eventLog) *managedTestService {
+	s := &managedTestService{events: events, ready: true}
+	s.BaseService = *NewBaseService(nil, name, s)
+	return s
+}
+
+func (s *managedTestService) OnStart() error {
+	time.Sleep(s.delay)
+	if s.startErr != nil {
+		return s.startErr
+	}
+	s.events.add("start " + s.String())
+	return nil
+}
+
+func (s *managedTestService) OnStop() {
+	time.Sleep(s.delay)
+	s.events.add("stop " + s.String())
+}
+
+func (s *managedTestService) Ready() bool { return s.ready }
+
+func TestManagerOrder(t *testing.T) {
+	events := &eventLog{}
+	m := NewManager(nil)
+	require.NoError(t, m.Add("switch", newManagedTestService("switch", events), DependsOn("indexer", "app")))
+	require.NoError(t, m.Add("indexer", newManagedTestService("indexer", events), DependsOn("events")))
+	require.NoError(t, m.Add("events", newManagedTestService("events", events)))
+	require.NoError(t, m.Add("app", newManagedTestService("app", events)))
+	require.Error(t, m.Add("app", newManagedTestService("app", events)))
+
+	order, err := m.Order()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"events", "indexer", "app", "switch"}, order)
+
+	require.NoError(t, m.Start())
+	require.NoError(t, m.Stop())
+	assert.Equal(t, []string{
+		"start events", "start indexer", "start app", "start switch",
+		"stop switch", "stop app", "stop indexer", "stop events",
+	}, events.get())
+}
+
+func TestManagerDependencyErrors(t *testing.T) {
+	events := &eventLog{}
+	m := NewManager(nil)
+	require.NoError(t, m.Add("a", newManagedTestService("a", events), DependsOn("b")))
+	require.NoError(t, m.Add("b", newManagedTestService("b", events), DependsOn("c")))
+	require.NoError(t, m.Add("c", newManagedTestService("c", events), DependsOn("a")))
+	_, err := m.Order()
+	require.EqualError(t, err, "circular dependency: a -> b -> c -> a")
+
+	m = NewManager(nil)
+	require.NoError(t, m.Add("a", newManagedTestService("a", events), DependsOn("b")))
+	require.EqualError(t, m.Start(), `service "a" depends on unknown service "b"`)
+	assert.Empty(t, events.get())
+}
+
+func TestManagerStartFailure(t *testing.T) {
+	events := &eventLog{}
+	m := NewManager(nil)
+	failing := newManagedTestService("b", events)
+	failing.startErr = errors.New("boom")
+	slow := newManagedTestService("c", events)
+	slow.delay = time.Second
+	require.NoError(t, m.Add("a", newManagedTestService("a", events)))
+	require.NoError(t, m.Add("b", failing, DependsOn("a")))
+	require.EqualError(t, m.Start(), "failed to start b: boom")
+	assert.Equal(t, []string{"start a", "stop a"}, events.get())
+
+	events = &eventLog{}
+	m = NewManager(nil)
+	require.NoError(t, m.Add("a", newManagedTestService("a", events)))
+	require.NoError(t, m.Add("c", slow, StartTimeout(10*time.Millisecond)))
+	require.EqualError(t, m.Start(), "failed to start c: timed out after 10ms")
+	assert.Equal(t, []string{"start a", "stop a"}, events.get())
+}
+
+func TestManagerStopTimeout(t *testing.T) {
+	events := &eventLog{}
+	m := NewManager(nil)
+	slow := newManagedTestService("b", events)
+	require.NoError(t, m.Add("a", newManagedTestService("a", events)))
+	require.NoError(t, m.Add("b", slow, DependsOn("a"), StopTimeout(10*time.Millisecond)))
+	require.NoError(t, m.Start())
+
+	slow.delay = time.Second
+	require.EqualError(t, m.Stop(), "failed to stop b")
+	assert.Equal(t, []string{"start a", "start b", "stop a"}, events.get())
+}
+
+func TestManagerAlreadyRunning(t *testing.T) {
+	events := &eventLog{}
+	m := NewManager(nil)
+	running := newManagedTestService("a", events)
+	require.NoError(t, running.Start())
+	require.NoError(t, m.Add("a", running))
+	require.NoError(t, m.Add("b", newManagedTestService("b", events), DependsOn("a")))
+
+	require.NoError(t, m.Start())
+	require.NoError(t, m.Stop())
+	assert.Equal(t, []string{"start a", "start b", "stop b", "stop a"}, events.get())
+}
+
+func TestManagerStatuses(t *testing.T) {
+	events := &eventLog{}
+	m := NewManager(nil)
+	syncing := newManagedTestService("b", events)
+	syncing.ready = false
+	require.NoError(t, m.Add("a", newManagedTestService("a", events)))
+	require.NoError(t, m.Add("b", syncing))
+	assert.Equal(t, []string{"a", "b"}, m.NotReady())
+
+	require.NoError(t, m.Start())
+	assert.Equal(t, []ServiceStatus{
+		{Name: "a", Running: true, Ready: true},
+		{Name: "b", Running: true, Ready: false},
+	}, m.Statuses())
+	assert.Equal(t, []string{"b"}, m.NotReady())
+	require.NoError(t, m.Stop())
+}
@@ -0,0 +1,240 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/version"
+)
+
+const (
+	// DefaultExportInterval is how often the spans and metrics are exported.
+	DefaultExportInterval = 10 * time.Second
+
+	// maxQueuedSpans is the number of spans queued for export, past which the
+	// new spans are dropped rather than slowing the node down.
+	maxQueuedSpans = 8192
+	// maxBatchSpans is the maximum number of spans per export request.
+	maxBatchSpans = 512
+
+	exportTimeout = 10 * time.Second
+	scopeName     = "github.com/cometbft/cometbft"
+)
+
+// Resource identifies the node in the exported spans and metrics, so that
+// they can be correlated with the ones of the other services.
+type Resource struct {
+	ChainID string
+	NodeID  string
+	Moniker string
+}
+
+func (r Resource) attributes() []otlpKeyValue {
+	return attributes([]interface{}{
+		"service.name", "cometbft",
+		"service.version", version.TMCoreSemVer,
+		"service.instance.id", r.NodeID,
+		"chain_id", r.ChainID,
+		"node_id", r.NodeID,
+		"moniker", r.Moniker,
+	})
+}
+
+// Exporter periodically sends the spans and the Prometheus metrics of the
+// node to an OTLP/HTTP endpoint, e.g. an OpenTelemetry collector.
+type Exporter struct {
+	service.BaseService
+
+	endpoint string
+	resource Resource
+	interval time.Duration
+	headers  http.Header
+	gatherer prometheus.Gatherer
+	client   *http.Client
+
+	spans   chan *Span
+	dropped atomic.Uint64
+	start   time.Time
+}
+
+// ExporterOption sets an optional parameter on the Exporter.
+type ExporterOption func(*Exporter)
+
+// WithExportInterval sets how often the spans and metrics are exported.
+func WithExportInterval(interval time.Duration) ExporterOption {
+	return func(e *Exporter) { e.interval = interval }
+}
+
+// WithHeaders sets the headers of the export requests, e.g. to
+// authenticate with the endpoint.
+func WithHeaders(headers map[string]string) ExporterOption {
+	return func(e *Exporter) {
+		for k, v := range headers {
+			e.headers.Set(k, v)
+		}
+	}
+}
+
+// WithGatherer sets the source of the exported metrics, instead of the
+// default Prometheus registry; nil disables the export of the metrics.
+func WithGatherer(gatherer prometheus.Gatherer) ExporterOption {
+	return func(e *Exporter) { e.gatherer = gatherer }
+}
+
+// NewExporter returns an exporter to endpoint, the base URL of an OTLP/HTTP
+// receiver (e.g. "http://localhost:4318"), to which it posts /v1/traces and
+// /v1/metrics.
+func NewExporter(endpoint string, resource Resource, options ...ExporterOption) *Exporter {
+	e := &Exporter{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		resource: resource,
+		interval: DefaultExportInterval,
+		headers:  make(http.Header),
+		gatherer: prometheus.DefaultGatherer,
+		client:   &http.Client{Timeout: exportTimeout},
+		spans:    make(chan *Span, maxQueuedSpans),
+	}
+	e.BaseService = *service.NewBaseService(nil, "TelemetryExporter", e)
+	for _, option := range options {
+		option(e)
+	}
+	return e
+}
+
+// OnStart implements service.Service by installing the exporter and starting
+// the export routine.
+func (e *Exporter) OnStart() error {
+	e.start = time.Now()
+	SetExporter(e)
+	go e.exportRoutine()
+	return nil
+}
+
+// OnStop implements service.Service by uninstalling the exporter. The queued
+// spans are exported one last time.
+func (e *Exporter) OnStop() {
+	SetExporter(nil)
+}
+
+// Dropped returns the number of spans dropped because the queue was full.
+func (e *Exporter) Dropped() uint64 {
+	return e.dropped.Load()
+}
+
+// export queues s for export, unless the queue is full.
+func (e *Exporter) export(s *Span) {
+	select {
+	case e.spans <- s:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+func (e *Exporter) exportRoutine() {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, maxBatchSpans)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.exportSpans(batch); err != nil {
+			e.Logger.Error("Failed to export spans", "n", len(batch), "err", err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case s := <-e.spans:
+			batch = append(batch, s)
+			if len(batch) == maxBatchSpans {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+			if err := e.exportMetrics(); err != nil {
+				e.Logger.Error("Failed to export metrics", "err", err)
+			}
+		case <-e.Quit():
+			// export the spans queued before the stop, one last time
+			for n := len(e.spans); n > 0; n-- {
+				batch = append(batch, <-e.spans)
+				if len(batch) == maxBatchSpans {
+					flush()
+				}
+			}
+			flush()
+			return
+		}
+	}
+}
+
+func (e *Exporter) exportSpans(batch []*Span) error {
+	spans := make([]otlpSpan, len(batch))
+	for i, s := range batch {
+		spans[i] = toOTLPSpan(s)
+	}
+	return e.post("/v1/traces", otlpTracesRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: e.resource.attributes()},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scopeName, Version: version.TMCoreSemVer}, Spans: spans}},
+	}}})
+}
+
+func (e *Exporter) exportMetrics() error {
+	if e.gatherer == nil {
+		return nil
+	}
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("gathering metrics: %w", err)
+	}
+	metrics := toOTLPMetrics(families, e.start, time.Now())
+	if len(metrics) == 0 {
+		return nil
+	}
+	return e.post("/v1/metrics", otlpMetricsRequest{ResourceMetrics: []otlpResourceMetrics{{
+		Resource:     otlpResource{Attributes: e.resource.attributes()},
+		ScopeMetrics: []otlpScopeMetrics{{Scope: otlpScope{Name: scopeName, Version: version.TMCoreSemVer}, Metrics: metrics}},
+	}}})
+}
+
+func (e *Exporter) post(path string, request interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, vs := range e.headers {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s: %s", path, resp.Status, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
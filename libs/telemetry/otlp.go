@@ -0,0 +1,320 @@
+package telemetry
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// The OTLP/HTTP JSON encoding of the trace and metrics export requests. See
+// https://github.com/open-telemetry/opentelemetry-proto. The 64-bit integers
+// are encoded as strings, and the ids as hex strings.
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    string   `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpTracesRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus    `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Message string `json:"message,omitempty"`
+	Code    int    `json:"code"`
+}
+
+const otlpStatusCodeError = 2
+
+type otlpMetricsRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Gauge       *otlpGauge     `json:"gauge,omitempty"`
+	Sum         *otlpSum       `json:"sum,omitempty"`
+	Histogram   *otlpHistogram `json:"histogram,omitempty"`
+	Summary     *otlpSummary   `json:"summary,omitempty"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+const otlpAggregationTemporalityCumulative = 2
+
+type otlpSum struct {
+	DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+	IsMonotonic            bool                  `json:"isMonotonic"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	AsDouble          float64        `json:"asDouble"`
+}
+
+type otlpHistogram struct {
+	DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                      `json:"aggregationTemporality"`
+}
+
+type otlpHistogramDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	Count             string         `json:"count"`
+	Sum               float64        `json:"sum"`
+	BucketCounts      []string       `json:"bucketCounts"`
+	ExplicitBounds    []float64      `json:"explicitBounds"`
+}
+
+type otlpSummary struct {
+	DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+}
+
+type otlpSummaryDataPoint struct {
+	Attributes        []otlpKeyValue      `json:"attributes,omitempty"`
+	StartTimeUnixNano string              `json:"startTimeUnixNano"`
+	TimeUnixNano      string              `json:"timeUnixNano"`
+	Count             string              `json:"count"`
+	Sum               float64             `json:"sum"`
+	QuantileValues    []otlpQuantileValue `json:"quantileValues"`
+}
+
+type otlpQuantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// attributes converts key/value pairs to OTLP attributes. The values which
+// are not strings, booleans, integers or floats are formatted as strings; a
+// trailing key without value is dropped.
+func attributes(keyvals []interface{}) []otlpKeyValue {
+	if len(keyvals) < 2 {
+		return nil
+	}
+	kvs := make([]otlpKeyValue, 0, len(keyvals)/2)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		kvs = append(kvs, otlpKeyValue{Key: fmt.Sprint(keyvals[i]), Value: anyValue(keyvals[i+1])})
+	}
+	return kvs
+}
+
+func anyValue(v interface{}) otlpAnyValue {
+	switch v := v.(type) {
+	case string:
+		return otlpAnyValue{StringValue: &v}
+	case bool:
+		return otlpAnyValue{BoolValue: &v}
+	case int:
+		return otlpAnyValue{IntValue: strconv.FormatInt(int64(v), 10)}
+	case int32:
+		return otlpAnyValue{IntValue: strconv.FormatInt(int64(v), 10)}
+	case int64:
+		return otlpAnyValue{IntValue: strconv.FormatInt(v, 10)}
+	case uint8:
+		return otlpAnyValue{IntValue: strconv.FormatUint(uint64(v), 10)}
+	case uint32:
+		return otlpAnyValue{IntValue: strconv.FormatUint(uint64(v), 10)}
+	case float64:
+		return otlpAnyValue{DoubleValue: &v}
+	case fmt.Stringer:
+		s := v.String()
+		return otlpAnyValue{StringValue: &s}
+	default:
+		s := fmt.Sprint(v)
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+func toOTLPSpan(s *Span) otlpSpan {
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.traceID[:]),
+		SpanID:            hex.EncodeToString(s.spanID[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: unixNano(s.start),
+		EndTimeUnixNano:   unixNano(s.end),
+		Attributes:        attributes(s.keyvals),
+	}
+	if s.parentID != (spanID{}) {
+		span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	if s.err != nil {
+		span.Status = &otlpStatus{Code: otlpStatusCodeError, Message: s.err.Error()}
+	}
+	return span
+}
+
+// toOTLPMetrics converts the Prometheus metric families to OTLP metrics, the
+// counters, histograms and summaries being cumulative since start.
+func toOTLPMetrics(families []*dto.MetricFamily, start, now time.Time) []otlpMetric {
+	startNano, nowNano := unixNano(start), unixNano(now)
+	metrics := make([]otlpMetric, 0, len(families))
+	for _, mf := range families {
+		m := otlpMetric{Name: mf.GetName(), Description: mf.GetHelp()}
+		switch mf.GetType() {
+		case dto.MetricType_COUNTER:
+			m.Sum = &otlpSum{AggregationTemporality: otlpAggregationTemporalityCumulative, IsMonotonic: true}
+			for _, pm := range mf.Metric {
+				if !finite(pm.GetCounter().GetValue()) {
+					continue
+				}
+				m.Sum.DataPoints = append(m.Sum.DataPoints, otlpNumberDataPoint{
+					Attributes:        labels(pm),
+					StartTimeUnixNano: startNano,
+					TimeUnixNano:      nowNano,
+					AsDouble:          pm.GetCounter().GetValue(),
+				})
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			m.Gauge = &otlpGauge{}
+			for _, pm := range mf.Metric {
+				value := pm.GetGauge().GetValue()
+				if pm.Untyped != nil {
+					value = pm.GetUntyped().GetValue()
+				}
+				if !finite(value) {
+					continue
+				}
+				m.Gauge.DataPoints = append(m.Gauge.DataPoints, otlpNumberDataPoint{
+					Attributes:   labels(pm),
+					TimeUnixNano: nowNano,
+					AsDouble:     value,
+				})
+			}
+		case dto.MetricType_HISTOGRAM:
+			m.Histogram = &otlpHistogram{AggregationTemporality: otlpAggregationTemporalityCumulative}
+			for _, pm := range mf.Metric {
+				m.Histogram.DataPoints = append(m.Histogram.DataPoints,
+					histogramDataPoint(pm, startNano, nowNano))
+			}
+		case dto.MetricType_SUMMARY:
+			m.Summary = &otlpSummary{}
+			for _, pm := range mf.Metric {
+				s := pm.GetSummary()
+				dp := otlpSummaryDataPoint{
+					Attributes:        labels(pm),
+					StartTimeUnixNano: startNano,
+					TimeUnixNano:      nowNano,
+					Count:             strconv.FormatUint(s.GetSampleCount(), 10),
+					Sum:               s.GetSampleSum(),
+				}
+				for _, q := range s.Quantile {
+					if !finite(q.GetValue()) {
+						continue
+					}
+					dp.QuantileValues = append(dp.QuantileValues,
+						otlpQuantileValue{Quantile: q.GetQuantile(), Value: q.GetValue()})
+				}
+				m.Summary.DataPoints = append(m.Summary.DataPoints, dp)
+			}
+		default:
+			continue
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+// histogramDataPoint converts the cumulative buckets of a Prometheus
+// histogram to the counts per bucket of OTLP, the last bucket being +Inf.
+func histogramDataPoint(pm *dto.Metric, startNano, nowNano string) otlpHistogramDataPoint {
+	h := pm.GetHistogram()
+	dp := otlpHistogramDataPoint{
+		Attributes:        labels(pm),
+		StartTimeUnixNano: startNano,
+		TimeUnixNano:      nowNano,
+		Count:             strconv.FormatUint(h.GetSampleCount(), 10),
+		Sum:               h.GetSampleSum(),
+	}
+	var cumulative uint64
+	for _, b := range h.Bucket {
+		if math.IsInf(b.GetUpperBound(), 1) {
+			continue
+		}
+		dp.ExplicitBounds = append(dp.ExplicitBounds, b.GetUpperBound())
+		dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(b.GetCumulativeCount()-cumulative, 10))
+		cumulative = b.GetCumulativeCount()
+	}
+	dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(h.GetSampleCount()-cumulative, 10))
+	return dp
+}
+
+// finite returns whether v can be encoded in JSON, unlike NaN and infinities.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+func labels(pm *dto.Metric) []otlpKeyValue {
+	if len(pm.Label) == 0 {
+		return nil
+	}
+	kvs := make([]otlpKeyValue, len(pm.Label))
+	for i, l := range pm.Label {
+		value := l.GetValue()
+		kvs[i] = otlpKeyValue{Key: l.GetName(), Value: otlpAnyValue{StringValue: &value}}
+	}
+	return kvs
+}
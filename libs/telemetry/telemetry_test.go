@@ -0,0 +1,133 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestSpansDisabled(t *testing.T) {
+	SetExporter(nil)
+	ctx, span := StartSpan(context.Background(), "noop", SpanKindInternal)
+	assert.Nil(t, span)
+	assert.Empty(t, TraceParent(ctx))
+	// a nil span is usable
+	span.SetAttributes("k", "v")
+	span.SetError(errors.New("error"))
+	span.End()
+}
+
+func TestTraceParent(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := ContextWithTraceParent(context.Background(), traceparent)
+	assert.Equal(t, traceparent, TraceParent(ctx))
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736aa-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47zz-00f067aa0ba902b7-01",
+	} {
+		assert.Empty(t, TraceParent(ContextWithTraceParent(context.Background(), invalid)), invalid)
+	}
+}
+
+func TestExporter(t *testing.T) {
+	requests := make(chan map[string]interface{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &req))
+		req["path"] = r.URL.Path
+		requests <- req
+	}))
+	defer srv.Close()
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_total", Help: "A test counter."})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_seconds", Buckets: []float64{1, 2}})
+	registry.MustRegister(counter, histogram)
+	counter.Add(3)
+	histogram.Observe(0.5)
+	histogram.Observe(1.5)
+	histogram.Observe(5)
+
+	e := NewExporter(srv.URL+"/", Resource{ChainID: "test-chain", NodeID: "abcd", Moniker: "node0"},
+		WithExportInterval(50*time.Millisecond),
+		WithHeaders(map[string]string{"Authorization": "secret"}),
+		WithGatherer(registry),
+	)
+	e.SetLogger(log.TestingLogger())
+	require.NoError(t, e.Start())
+	t.Cleanup(func() { _ = e.Stop() })
+	require.True(t, Enabled())
+
+	ctx := ContextWithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, parent := StartSpan(ctx, "parent", SpanKindServer, "method", "status")
+	_, child := StartSpan(ctx, "child", SpanKindClient, "height", int64(10))
+	child.SetError(errors.New("failed"))
+	child.End()
+	parent.End()
+
+	var traces, metrics map[string]interface{}
+	for traces == nil || metrics == nil {
+		select {
+		case req := <-requests:
+			if req["path"] == "/v1/traces" {
+				traces = req
+			} else {
+				metrics = req
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no export")
+		}
+	}
+
+	resource := traces["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	assert.Contains(t, resource["resource"].(map[string]interface{})["attributes"],
+		map[string]interface{}{"key": "chain_id", "value": map[string]interface{}{"stringValue": "test-chain"}})
+	spans := resource["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	require.Len(t, spans, 2)
+	childSpan, parentSpan := spans[0].(map[string]interface{}), spans[1].(map[string]interface{})
+	assert.Equal(t, "child", childSpan["name"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", parentSpan["traceId"])
+	assert.Equal(t, "00f067aa0ba902b7", parentSpan["parentSpanId"])
+	assert.Equal(t, parentSpan["traceId"], childSpan["traceId"])
+	assert.Equal(t, parentSpan["spanId"], childSpan["parentSpanId"])
+	assert.Equal(t, map[string]interface{}{"code": float64(2), "message": "failed"}, childSpan["status"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"key": "height", "value": map[string]interface{}{"intValue": "10"}}},
+		childSpan["attributes"])
+
+	ms := metrics["resourceMetrics"].([]interface{})[0].(map[string]interface{})["scopeMetrics"].([]interface{})[0].(map[string]interface{})["metrics"].([]interface{})
+	require.Len(t, ms, 2)
+	byName := make(map[string]map[string]interface{})
+	for _, m := range ms {
+		byName[m.(map[string]interface{})["name"].(string)] = m.(map[string]interface{})
+	}
+	sum := byName["test_total"]["sum"].(map[string]interface{})
+	assert.Equal(t, true, sum["isMonotonic"])
+	assert.Equal(t, float64(3), sum["dataPoints"].([]interface{})[0].(map[string]interface{})["asDouble"])
+	dp := byName["test_seconds"]["histogram"].(map[string]interface{})["dataPoints"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "3", dp["count"])
+	assert.Equal(t, []interface{}{"1", "1", "1"}, dp["bucketCounts"])
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, dp["explicitBounds"])
+
+	require.NoError(t, e.Stop())
+	assert.False(t, Enabled())
+}
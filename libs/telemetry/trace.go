@@ -0,0 +1,204 @@
+// Package telemetry exports the traces and metrics of the node to an
+// OpenTelemetry collector, over OTLP/HTTP with the JSON encoding.
+//
+// The spans are recorded with StartSpan and RecordSpan, which do nothing
+// until an Exporter is installed with SetExporter, so that the instrumented
+// code paths cost next to nothing when the export is disabled. The metrics are
+// the ones registered with Prometheus, gathered and exported periodically.
+package telemetry
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// SpanKind is the kind of a span, as defined by OpenTelemetry.
+type SpanKind int
+
+const (
+	// SpanKindInternal is an operation internal to the node, e.g. a
+	// consensus step.
+	SpanKindInternal SpanKind = 1
+	// SpanKindServer is the handling of a request, e.g. an RPC call.
+	SpanKindServer SpanKind = 2
+	// SpanKindClient is a request to another service, e.g. an ABCI call.
+	SpanKindClient SpanKind = 3
+	// SpanKindProducer is a message sent without waiting for a reply, e.g. a
+	// p2p message.
+	SpanKindProducer SpanKind = 4
+)
+
+type (
+	traceID [16]byte
+	spanID  [8]byte
+)
+
+// Span is a timed operation. A nil *Span, as returned while no exporter is
+// installed, is valid and records nothing.
+type Span struct {
+	exporter *Exporter
+
+	name     string
+	kind     SpanKind
+	traceID  traceID
+	spanID   spanID
+	parentID spanID
+	start    time.Time
+	end      time.Time
+	keyvals  []interface{}
+	err      error
+}
+
+// exporter is the installed *Exporter, or nil.
+var exporter atomic.Value
+
+// SetExporter installs e as the destination of the spans; nil disables them.
+func SetExporter(e *Exporter) {
+	exporter.Store(&e)
+}
+
+func currentExporter() *Exporter {
+	e, _ := exporter.Load().(**Exporter)
+	if e == nil {
+		return nil
+	}
+	return *e
+}
+
+// Enabled returns whether the spans are exported.
+func Enabled() bool {
+	return currentExporter() != nil
+}
+
+type spanContextKey struct{}
+
+// spanContext identifies a span, local or remote, for its children.
+type spanContext struct {
+	traceID traceID
+	spanID  spanID
+}
+
+// StartSpan starts a span named name, child of the span of ctx if any, with
+// the given attributes as key/value pairs, and returns a context carrying it.
+// The span must be ended with End.
+func StartSpan(ctx context.Context, name string, kind SpanKind, keyvals ...interface{}) (context.Context, *Span) {
+	e := currentExporter()
+	if e == nil {
+		return ctx, nil
+	}
+
+	s := &Span{
+		exporter: e,
+		name:     name,
+		kind:     kind,
+		start:    time.Now(),
+		keyvals:  keyvals,
+	}
+	if parent, ok := ctx.Value(spanContextKey{}).(spanContext); ok {
+		s.traceID = parent.traceID
+		s.parentID = parent.spanID
+	} else {
+		s.traceID = newTraceID()
+	}
+	s.spanID = newSpanID()
+	return context.WithValue(ctx, spanContextKey{}, spanContext{traceID: s.traceID, spanID: s.spanID}), s
+}
+
+// RecordSpan records a span which took place between start and end, e.g. a
+// consensus step whose end is only known once the next one starts.
+func RecordSpan(name string, kind SpanKind, start, end time.Time, keyvals ...interface{}) {
+	e := currentExporter()
+	if e == nil {
+		return
+	}
+	e.export(&Span{
+		exporter: e,
+		name:     name,
+		kind:     kind,
+		traceID:  newTraceID(),
+		spanID:   newSpanID(),
+		start:    start,
+		end:      end,
+		keyvals:  keyvals,
+	})
+}
+
+// SetError marks the span as failed with err, if not nil.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.err = err
+}
+
+// SetAttributes adds the given attributes, as key/value pairs, to the span.
+func (s *Span) SetAttributes(keyvals ...interface{}) {
+	if s == nil {
+		return
+	}
+	s.keyvals = append(s.keyvals, keyvals...)
+}
+
+// End ends the span and queues it for export. It must be called once.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+	s.exporter.export(s)
+}
+
+// ContextWithTraceParent returns a context carrying the remote span of the
+// W3C traceparent header value, e.g. of an RPC request, so that the spans
+// started from it belong to the trace of the caller. Invalid values are
+// ignored.
+func ContextWithTraceParent(ctx context.Context, traceparent string) context.Context {
+	// version "-" trace-id "-" parent-id "-" trace-flags
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 2*len(traceID{}) || len(parts[2]) != 2*len(spanID{}) {
+		return ctx
+	}
+	var sc spanContext
+	if _, err := hex.Decode(sc.traceID[:], []byte(parts[1])); err != nil {
+		return ctx
+	}
+	if _, err := hex.Decode(sc.spanID[:], []byte(parts[2])); err != nil {
+		return ctx
+	}
+	if sc.traceID == (traceID{}) || sc.spanID == (spanID{}) {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// TraceParent returns the W3C traceparent header value of the span of ctx,
+// to propagate it to another service, or "" if ctx has no span.
+func TraceParent(ctx context.Context) string {
+	sc, ok := ctx.Value(spanContextKey{}).(spanContext)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("00-%x-%x-01", sc.traceID[:], sc.spanID[:])
+}
+
+func newTraceID() (id traceID) {
+	for id == (traceID{}) {
+		binary.BigEndian.PutUint64(id[:8], rand.Uint64()) //nolint:gosec // ids need not be unpredictable
+		binary.BigEndian.PutUint64(id[8:], rand.Uint64()) //nolint:gosec
+	}
+	return id
+}
+
+func newSpanID() (id spanID) {
+	for id == (spanID{}) {
+		binary.BigEndian.PutUint64(id[:], rand.Uint64()) //nolint:gosec
+	}
+	return id
+}
@@ -0,0 +1,117 @@
+package light
+
+import (
+	"time"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+// BisectionStrategy picks the intermediate heights that the skipping
+// verification fetches when a light block can't be verified directly from the
+// latest verified one (i.e. when less than the trust level of the trusted
+// validator set signed it).
+//
+// Relayers and other light client users have very different latency/trust
+// tradeoffs, hence the strategy can be supplied with the Bisection option.
+type BisectionStrategy interface {
+	// Pivot returns the height of the next light block to fetch, given the
+	// latest verified light block and the light block which could not be
+	// verified from it. trustingPeriod is the trusting period of the client.
+	//
+	// The returned height must be in (verified.Height, untrusted.Height).
+	// Heights outside of this range are clamped to it.
+	Pivot(verified, untrusted *types.LightBlock, trustingPeriod time.Duration, now time.Time) int64
+}
+
+// DefaultBisection returns the strategy used by default. As light blocks from
+// the previous iterations are cached, and so are always above the middle of
+// the range, it pivots at 9/16 of the range to find something in between.
+func DefaultBisection() BisectionStrategy {
+	return fractionBisection{
+		numerator:   verifySkippingNumerator,
+		denominator: verifySkippingDenominator,
+	}
+}
+
+// BinaryBisection returns a strategy pivoting at the middle of the range, as
+// described in the specification.
+func BinaryBisection() BisectionStrategy {
+	return fractionBisection{numerator: 1, denominator: 2}
+}
+
+type fractionBisection struct {
+	numerator   int64
+	denominator int64
+}
+
+func (b fractionBisection) Pivot(verified, untrusted *types.LightBlock, _ time.Duration, _ time.Time) int64 {
+	return verified.Height + (untrusted.Height-verified.Height)*b.numerator/b.denominator
+}
+
+// SequentialBisection returns a strategy fetching the light block right after
+// the latest verified one. Unlike SequentialVerification, the target light
+// block is still tried first, and so are the light blocks fetched earlier, so
+// the client only falls back to adjacent verification where the validator set
+// changed too much. It minimizes the number of signature checks of each step
+// at the cost of more round trips.
+func SequentialBisection() BisectionStrategy {
+	return sequentialBisection{}
+}
+
+type sequentialBisection struct{}
+
+func (sequentialBisection) Pivot(verified, _ *types.LightBlock, _ time.Duration, _ time.Time) int64 {
+	return verified.Height + 1
+}
+
+// TrustPeriodBatching returns a strategy fetching at most batchSize blocks
+// ahead of the latest verified light block. The step shrinks as the verified
+// light block ages: while it is fresh, the client takes large (cheap) steps;
+// as it gets close to the end of its trusting period, the client takes small
+// steps, which are more likely to be verifiable, so it doesn't lose trust
+// while retrying.
+//
+// batchSize must be positive.
+func TrustPeriodBatching(batchSize int64) BisectionStrategy {
+	if batchSize <= 0 {
+		panic("batchSize must be positive")
+	}
+	return trustPeriodBatching{batchSize: batchSize}
+}
+
+type trustPeriodBatching struct {
+	batchSize int64
+}
+
+func (b trustPeriodBatching) Pivot(
+	verified, untrusted *types.LightBlock,
+	trustingPeriod time.Duration,
+	now time.Time,
+) int64 {
+	step := b.batchSize
+	if trustingPeriod > 0 {
+		remaining := trustingPeriod - now.Sub(verified.Time)
+		if remaining < 0 {
+			remaining = 0
+		}
+		// computed in floating point to avoid overflows with large periods
+		step = int64(float64(b.batchSize) * (float64(remaining) / float64(trustingPeriod)))
+	}
+	if half := (untrusted.Height - verified.Height) / 2; step > half {
+		step = half
+	}
+	return verified.Height + step
+}
+
+// pivotHeight asks the strategy for the next height to fetch and clamps it to
+// (verified.Height, untrusted.Height).
+func (c *Client) pivotHeight(verified, untrusted *types.LightBlock, now time.Time) int64 {
+	height := c.bisection.Pivot(verified, untrusted, c.trustingPeriod, now)
+	switch {
+	case height <= verified.Height:
+		height = verified.Height + 1
+	case height >= untrusted.Height:
+		height = untrusted.Height - 1
+	}
+	return height
+}
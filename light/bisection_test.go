@@ -0,0 +1,49 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestBisectionStrategies(t *testing.T) {
+	lightBlock := func(height int64, time time.Time) *types.LightBlock {
+		return &types.LightBlock{SignedHeader: &types.SignedHeader{
+			Header: &types.Header{Height: height, Time: time},
+		}}
+	}
+	var (
+		period = 10 * time.Hour
+		fresh  = lightBlock(100, bTime)
+		target = lightBlock(1100, bTime.Add(time.Hour))
+	)
+
+	testCases := []struct {
+		name     string
+		strategy light.BisectionStrategy
+		verified *types.LightBlock
+		now      time.Time
+		pivot    int64
+	}{
+		{"default", light.DefaultBisection(), fresh, bTime, 662},
+		{"binary", light.BinaryBisection(), fresh, bTime, 600},
+		{"sequential", light.SequentialBisection(), fresh, bTime, 101},
+		{"batching, fresh trust", light.TrustPeriodBatching(200), fresh, bTime, 300},
+		{"batching, aged trust", light.TrustPeriodBatching(200), fresh, bTime.Add(9 * time.Hour), 120},
+		{"batching, expired trust", light.TrustPeriodBatching(200), fresh, bTime.Add(11 * time.Hour), 100},
+		{"batching, capped at half the range", light.TrustPeriodBatching(2000), fresh, bTime, 600},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.pivot, tc.strategy.Pivot(tc.verified, target, period, tc.now))
+		})
+	}
+
+	assert.Panics(t, func() { light.TrustPeriodBatching(0) })
+}
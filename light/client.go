@@ -111,6 +111,40 @@ func MaxClockDrift(d time.Duration) Option {
 	}
 }
 
+// Bisection option sets the strategy used by the skipping verification to
+// pick the intermediate light blocks to fetch. Default: DefaultBisection().
+// It has no effect with SequentialVerification.
+func Bisection(s BisectionStrategy) Option {
+	return func(c *Client) {
+		c.bisection = s
+	}
+}
+
+// WitnessPool option sets the providers replacing the witnesses which are
+// removed (because they sent a conflicting or invalid header) or which don't
+// respond. Witnesses which don't respond are put back into the pool.
+func WitnessPool(pool []provider.Provider) Option {
+	return func(c *Client) {
+		c.witnessPool = pool
+	}
+}
+
+// OnDivergence option adds a handler of the reports of the witnesses
+// returning a header which conflicts with the primary's, and of the trusted
+// header expiring. Reports are always logged. See WebhookDivergenceHandler.
+func OnDivergence(h DivergenceHandler) Option {
+	return func(c *Client) {
+		c.divergenceHandlers = append(c.divergenceHandlers, h)
+	}
+}
+
+// ClientMetrics option sets the metrics of the light client.
+func ClientMetrics(metrics *Metrics) Option {
+	return func(c *Client) {
+		c.metrics = metrics
+	}
+}
+
 // MaxBlockLag represents the maximum time difference between the realtime
 // that a block is received and the timestamp of that block.
 // One can approximate it to the maximum block production time
@@ -132,10 +166,12 @@ func MaxBlockLag(d time.Duration) Option {
 // Default verification: SkippingVerification(DefaultTrustLevel)
 type Client struct {
 	chainID          string
+	encoding         types.CanonicalEncoding
 	trustingPeriod   time.Duration // see TrustOptions.Period
 	verificationMode mode
 	trustLevel       cmtmath.Fraction
-	maxRetryAttempts uint16 // see MaxRetryAttempts option
+	bisection        BisectionStrategy // see Bisection option
+	maxRetryAttempts uint16            // see MaxRetryAttempts option
 	maxClockDrift    time.Duration
 	maxBlockLag      time.Duration
 
@@ -145,6 +181,8 @@ type Client struct {
 	primary provider.Provider
 	// Providers used to "witness" new headers.
 	witnesses []provider.Provider
+	// Providers replacing the witnesses, see WitnessPool option.
+	witnessPool []provider.Provider
 
 	// Where trusted light blocks are stored.
 	trustedStore store.Store
@@ -155,6 +193,13 @@ type Client struct {
 	pruningSize uint16
 	// See ConfirmationFunction option
 	confirmationFn func(action string) bool
+	// See OnDivergence option
+	divergenceHandlers []DivergenceHandler
+	// Height of the last expired trusted light block reported, see
+	// reportTrustExpired.
+	trustExpiredHeight int64
+
+	metrics *Metrics
 
 	quit chan struct{}
 
@@ -165,6 +210,10 @@ type Client struct {
 // obtain the light block from the primary or they are invalid (e.g. trust
 // hash does not match with the one from the headers).
 //
+// The encoding is the canonical encoding of the chain (see the Encoding
+// consensus params), with which the headers and validator sets are hashed and
+// the votes are signed.
+//
 // Witnesses are providers, which will be used for cross-checking the primary
 // provider. At least one witness must be given when skipping verification is
 // used (default). A witness can become a primary iff the current primary is
@@ -174,6 +223,7 @@ type Client struct {
 func NewClient(
 	ctx context.Context,
 	chainID string,
+	encoding types.CanonicalEncoding,
 	trustOptions TrustOptions,
 	primary provider.Provider,
 	witnesses []provider.Provider,
@@ -184,7 +234,8 @@ func NewClient(
 		return nil, fmt.Errorf("invalid TrustOptions: %w", err)
 	}
 
-	c, err := NewClientFromTrustedStore(chainID, trustOptions.Period, primary, witnesses, trustedStore, options...)
+	c, err := NewClientFromTrustedStore(chainID, encoding, trustOptions.Period, primary, witnesses, trustedStore,
+		options...)
 	if err != nil {
 		return nil, err
 	}
@@ -211,6 +262,7 @@ func NewClient(
 // See NewClient
 func NewClientFromTrustedStore(
 	chainID string,
+	encoding types.CanonicalEncoding,
 	trustingPeriod time.Duration,
 	primary provider.Provider,
 	witnesses []provider.Provider,
@@ -219,9 +271,11 @@ func NewClientFromTrustedStore(
 
 	c := &Client{
 		chainID:          chainID,
+		encoding:         encoding,
 		trustingPeriod:   trustingPeriod,
 		verificationMode: skipping,
 		trustLevel:       DefaultTrustLevel,
+		bisection:        DefaultBisection(),
 		maxRetryAttempts: defaultMaxRetryAttempts,
 		maxClockDrift:    defaultMaxClockDrift,
 		maxBlockLag:      defaultMaxBlockLag,
@@ -231,6 +285,7 @@ func NewClientFromTrustedStore(
 		pruningSize:      defaultPruningSize,
 		confirmationFn:   func(action string) bool { return true },
 		quit:             make(chan struct{}),
+		metrics:          NopMetrics(),
 		logger:           log.NewNopLogger(),
 	}
 
@@ -250,12 +305,27 @@ func NewClientFromTrustedStore(
 				i, w, w.ChainID(), chainID)
 		}
 	}
+	for i, w := range c.witnessPool {
+		if w.ChainID() != chainID {
+			return nil, fmt.Errorf("pooled witness #%d: %v is on another chain %s, expected %s",
+				i, w, w.ChainID(), chainID)
+		}
+	}
+	c.metrics.Witnesses.Set(float64(len(c.witnesses)))
+
+	if err := c.encoding.ValidateBasic(); err != nil {
+		return nil, err
+	}
 
 	// Validate trust level.
 	if err := ValidateTrustLevel(c.trustLevel); err != nil {
 		return nil, err
 	}
 
+	if c.bisection == nil {
+		return nil, errors.New("nil bisection strategy")
+	}
+
 	if err := c.restoreTrustedLightBlock(); err != nil {
 		return nil, err
 	}
@@ -308,19 +378,19 @@ func (c *Client) checkTrustedHeaderUsingOptions(ctx context.Context, options Tru
 		if err != nil {
 			return err
 		}
-		primaryHash = h.Hash()
+		primaryHash = h.Hash(c.encoding)
 	case options.Height == c.latestTrustedBlock.Height:
 		primaryHash = options.Hash
 	case options.Height < c.latestTrustedBlock.Height:
 		c.logger.Info("Client initialized with old header (trusted is more recent)",
 			"old", options.Height,
 			"trustedHeight", c.latestTrustedBlock.Height,
-			"trustedHash", c.latestTrustedBlock.Hash())
+			"trustedHash", c.latestTrustedBlock.Hash(c.encoding))
 
 		action := fmt.Sprintf(
 			"Rollback to %d (%X)? Note this will remove newer light blocks up to %d (%X)",
 			options.Height, options.Hash,
-			c.latestTrustedBlock.Height, c.latestTrustedBlock.Hash())
+			c.latestTrustedBlock.Height, c.latestTrustedBlock.Hash(c.encoding))
 		if c.confirmationFn(action) {
 			// remove all the headers (options.Height, trustedHeader.Height]
 			err := c.cleanupAfter(options.Height)
@@ -337,13 +407,13 @@ func (c *Client) checkTrustedHeaderUsingOptions(ctx context.Context, options Tru
 		primaryHash = options.Hash
 	}
 
-	if !bytes.Equal(primaryHash, c.latestTrustedBlock.Hash()) {
+	if !bytes.Equal(primaryHash, c.latestTrustedBlock.Hash(c.encoding)) {
 		c.logger.Info("Prev. trusted header's hash (h1) doesn't match hash from primary provider (h2)",
-			"h1", c.latestTrustedBlock.Hash(), "h2", primaryHash)
+			"h1", c.latestTrustedBlock.Hash(c.encoding), "h2", primaryHash)
 
 		action := fmt.Sprintf(
 			"Prev. trusted header's hash %X doesn't match hash %X from primary provider. Remove all the stored light blocks?",
-			c.latestTrustedBlock.Hash(), primaryHash)
+			c.latestTrustedBlock.Hash(c.encoding), primaryHash)
 		if c.confirmationFn(action) {
 			err := c.Cleanup()
 			if err != nil {
@@ -369,16 +439,16 @@ func (c *Client) initializeWithTrustOptions(ctx context.Context, options TrustOp
 	// NOTE: - Verify func will check if it's expired or not.
 	//       - h.Time is not being checked against time.Now() because we don't
 	//         want to add yet another argument to NewClient* functions.
-	if err := l.ValidateBasic(c.chainID); err != nil {
+	if err := l.ValidateBasic(c.chainID, c.encoding); err != nil {
 		return err
 	}
 
-	if !bytes.Equal(l.Hash(), options.Hash) {
-		return fmt.Errorf("expected header's hash %X, but got %X", options.Hash, l.Hash())
+	if !bytes.Equal(l.Hash(c.encoding), options.Hash) {
+		return fmt.Errorf("expected header's hash %X, but got %X", options.Hash, l.Hash(c.encoding))
 	}
 
 	// 2) Ensure that +2/3 of validators signed correctly.
-	err = l.ValidatorSet.VerifyCommitLight(c.chainID, l.Commit.BlockID, l.Height, l.Commit)
+	err = l.ValidatorSet.VerifyCommitLight(c.chainID, c.encoding, l.Commit.BlockID, l.Height, l.Commit)
 	if err != nil {
 		return fmt.Errorf("invalid commit: %w", err)
 	}
@@ -454,7 +524,7 @@ func (c *Client) Update(ctx context.Context, now time.Time) (*types.LightBlock,
 		if err != nil {
 			return nil, err
 		}
-		c.logger.Info("Advanced to new state", "height", latestBlock.Height, "hash", latestBlock.Hash())
+		c.logger.Info("Advanced to new state", "height", latestBlock.Height, "hash", latestBlock.Hash(c.encoding))
 		return latestBlock, nil
 	}
 
@@ -479,7 +549,7 @@ func (c *Client) VerifyLightBlockAtHeight(ctx context.Context, height int64, now
 	// Check if the light block already verified.
 	h, err := c.TrustedLightBlock(height)
 	if err == nil {
-		c.logger.Info("Header has already been verified", "height", height, "hash", h.Hash())
+		c.logger.Info("Header has already been verified", "height", height, "hash", h.Hash(c.encoding))
 		// Return already trusted light block
 		return h, nil
 	}
@@ -534,11 +604,12 @@ func (c *Client) VerifyHeader(ctx context.Context, newHeader *types.Header, now
 	l, err := c.TrustedLightBlock(newHeader.Height)
 	if err == nil {
 		// Make sure it's the same header.
-		if !bytes.Equal(l.Hash(), newHeader.Hash()) {
-			return fmt.Errorf("existing trusted header %X does not match newHeader %X", l.Hash(), newHeader.Hash())
+		if !bytes.Equal(l.Hash(c.encoding), newHeader.Hash(c.encoding)) {
+			return fmt.Errorf("existing trusted header %X does not match newHeader %X",
+				l.Hash(c.encoding), newHeader.Hash(c.encoding))
 		}
 		c.logger.Info("Header has already been verified",
-			"height", newHeader.Height, "hash", newHeader.Hash())
+			"height", newHeader.Height, "hash", newHeader.Hash(c.encoding))
 		return nil
 	}
 
@@ -548,19 +619,21 @@ func (c *Client) VerifyHeader(ctx context.Context, newHeader *types.Header, now
 		return fmt.Errorf("failed to retrieve light block from primary to verify against: %w", err)
 	}
 
-	if !bytes.Equal(l.Hash(), newHeader.Hash()) {
-		return fmt.Errorf("light block header %X does not match newHeader %X", l.Hash(), newHeader.Hash())
+	if !bytes.Equal(l.Hash(c.encoding), newHeader.Hash(c.encoding)) {
+		return fmt.Errorf("light block header %X does not match newHeader %X",
+			l.Hash(c.encoding), newHeader.Hash(c.encoding))
 	}
 
 	return c.verifyLightBlock(ctx, l, now)
 }
 
 func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.LightBlock, now time.Time) error {
-	c.logger.Info("VerifyHeader", "height", newLightBlock.Height, "hash", newLightBlock.Hash())
+	c.logger.Info("VerifyHeader", "height", newLightBlock.Height, "hash", newLightBlock.Hash(c.encoding))
 
 	var (
-		verifyFunc func(ctx context.Context, trusted *types.LightBlock, new *types.LightBlock, now time.Time) error
-		err        error
+		verifyFunc   func(ctx context.Context, trusted *types.LightBlock, new *types.LightBlock, now time.Time) error
+		trustedBlock *types.LightBlock
+		err          error
 	)
 
 	switch c.verificationMode {
@@ -580,7 +653,8 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 	switch {
 	// Verifying forwards
 	case newLightBlock.Height >= c.latestTrustedBlock.Height:
-		err = verifyFunc(ctx, c.latestTrustedBlock, newLightBlock, now)
+		trustedBlock = c.latestTrustedBlock
+		err = verifyFunc(ctx, trustedBlock, newLightBlock, now)
 
 	// Verifying backwards
 	case newLightBlock.Height < firstBlockHeight:
@@ -598,10 +672,15 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 		if err != nil {
 			return fmt.Errorf("can't get signed header before height %d: %w", newLightBlock.Height, err)
 		}
-		err = verifyFunc(ctx, closestBlock, newLightBlock, now)
+		trustedBlock = closestBlock
+		err = verifyFunc(ctx, trustedBlock, newLightBlock, now)
 	}
 	if err != nil {
 		c.logger.Error("Can't verify", "err", err)
+		var expiredErr ErrOldHeaderExpired
+		if trustedBlock != nil && errors.As(err, &expiredErr) {
+			c.reportTrustExpired(trustedBlock, newLightBlock, err)
+		}
 		return err
 	}
 
@@ -637,12 +716,12 @@ func (c *Client) verifySequential(
 		// 2) Verify them
 		c.logger.Debug("Verify adjacent newLightBlock against verifiedBlock",
 			"trustedHeight", verifiedBlock.Height,
-			"trustedHash", verifiedBlock.Hash(),
+			"trustedHash", verifiedBlock.Hash(c.encoding),
 			"newHeight", interimBlock.Height,
-			"newHash", interimBlock.Hash())
+			"newHash", interimBlock.Hash(c.encoding))
 
 		err = VerifyAdjacent(verifiedBlock.SignedHeader, interimBlock.SignedHeader, interimBlock.ValidatorSet,
-			c.trustingPeriod, now, c.maxClockDrift)
+			c.trustingPeriod, now, c.maxClockDrift, c.encoding)
 		if err != nil {
 			err := ErrVerificationFailed{From: verifiedBlock.Height, To: interimBlock.Height, Reason: err}
 
@@ -664,10 +743,10 @@ func (c *Client) verifySequential(
 					return err
 				}
 
-				if !bytes.Equal(replacementBlock.Hash(), newLightBlock.Hash()) {
+				if !bytes.Equal(replacementBlock.Hash(c.encoding), newLightBlock.Hash(c.encoding)) {
 					c.logger.Error("Replacement provider has a different light block",
-						"newHash", newLightBlock.Hash(),
-						"replHash", replacementBlock.Hash())
+						"newHash", newLightBlock.Hash(c.encoding),
+						"replHash", replacementBlock.Hash(c.encoding))
 					// return original error
 					return err
 				}
@@ -698,8 +777,8 @@ func (c *Client) verifySequential(
 
 // see VerifyHeader
 //
-// verifySkipping finds the middle light block between a trusted and new light block,
-// reiterating the action until it verifies a light block. A cache of light blocks
+// verifySkipping finds an intermediate light block between a trusted and new light
+// block (see BisectionStrategy), reiterating the action until it verifies a light block. A cache of light blocks
 // requested from source is kept such that when a verification is made, and the
 // light client tries again to verify the new light block in the middle, the light
 // client does not need to ask for all the same light blocks again.
@@ -721,12 +800,13 @@ func (c *Client) verifySkipping(
 	for {
 		c.logger.Debug("Verify non-adjacent newHeader against verifiedBlock",
 			"trustedHeight", verifiedBlock.Height,
-			"trustedHash", verifiedBlock.Hash(),
+			"trustedHash", verifiedBlock.Hash(c.encoding),
 			"newHeight", blockCache[depth].Height,
-			"newHash", blockCache[depth].Hash())
+			"newHash", blockCache[depth].Hash(c.encoding))
 
 		err := Verify(verifiedBlock.SignedHeader, verifiedBlock.ValidatorSet, blockCache[depth].SignedHeader,
-			blockCache[depth].ValidatorSet, c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+			blockCache[depth].ValidatorSet, c.trustingPeriod, now, c.maxClockDrift, c.trustLevel,
+			c.encoding)
 		switch err.(type) {
 		case nil:
 			// Have we verified the last header
@@ -746,8 +826,7 @@ func (c *Client) verifySkipping(
 		case ErrNewValSetCantBeTrusted:
 			// do add another header to the end of the cache
 			if depth == len(blockCache)-1 {
-				pivotHeight := verifiedBlock.Height + (blockCache[depth].Height-verifiedBlock.
-					Height)*verifySkippingNumerator/verifySkippingDenominator
+				pivotHeight := c.pivotHeight(verifiedBlock, blockCache[depth], now)
 				interimBlock, providerErr := source.LightBlock(ctx, pivotHeight)
 				switch providerErr {
 				case nil:
@@ -801,10 +880,10 @@ func (c *Client) verifySkippingAgainstPrimary(
 			return err
 		}
 
-		if !bytes.Equal(replacementBlock.Hash(), newLightBlock.Hash()) {
+		if !bytes.Equal(replacementBlock.Hash(c.encoding), newLightBlock.Hash(c.encoding)) {
 			c.logger.Error("Replacement provider has a different light block",
-				"newHash", newLightBlock.Hash(),
-				"replHash", replacementBlock.Hash())
+				"newHash", newLightBlock.Hash(c.encoding),
+				"replHash", replacementBlock.Hash(c.encoding))
 			// return original error
 			return err
 		}
@@ -850,6 +929,14 @@ func (c *Client) ChainID() string {
 	return c.chainID
 }
 
+// Encoding returns the canonical encoding the light client was configured
+// with.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) Encoding() types.CanonicalEncoding {
+	return c.encoding
+}
+
 // Primary returns the primary provider.
 //
 // NOTE: provider may be not safe for concurrent access.
@@ -948,10 +1035,10 @@ func (c *Client) backwards(
 		interimHeader = interimBlock.Header
 		c.logger.Debug("Verify newHeader against verifiedHeader",
 			"trustedHeight", verifiedHeader.Height,
-			"trustedHash", verifiedHeader.Hash(),
+			"trustedHash", verifiedHeader.Hash(c.encoding),
 			"newHeight", interimHeader.Height,
-			"newHash", interimHeader.Hash())
-		if err := VerifyBackwards(interimHeader, verifiedHeader); err != nil {
+			"newHash", interimHeader.Hash(c.encoding))
+		if err := VerifyBackwards(interimHeader, verifiedHeader, c.encoding); err != nil {
 			// verification has failed
 			c.logger.Error("backwards verification failed, replacing primary...", "err", err, "primary", c.primary)
 
@@ -963,7 +1050,7 @@ func (c *Client) backwards(
 			}
 
 			// before continuing we must check that they have the same target header to validate
-			if !bytes.Equal(newPrimarysBlock.Hash(), newHeader.Hash()) {
+			if !bytes.Equal(newPrimarysBlock.Hash(c.encoding), newHeader.Hash(c.encoding)) {
 				c.logger.Debug("replaced primary but new primary has a different block to the initial one")
 				// return the original error
 				return err
@@ -1015,10 +1102,13 @@ func (c *Client) lightBlockFromPrimary(ctx context.Context, height int64) (*type
 	}
 }
 
+// removeWitnesses removes the witnesses at the given indexes, replacing them
+// by providers of the witness pool, if any.
+//
 // NOTE: requires a providerMutex lock
 func (c *Client) removeWitnesses(indexes []int) error {
 	// check that we will still have witnesses remaining
-	if len(c.witnesses) <= len(indexes) {
+	if len(c.witnesses)+len(c.witnessPool) <= len(indexes) {
 		return ErrNoWitnesses
 	}
 
@@ -1030,9 +1120,33 @@ func (c *Client) removeWitnesses(indexes []int) error {
 		c.witnesses = c.witnesses[:len(c.witnesses)-1]
 	}
 
+	for i := 0; i < len(indexes) && len(c.witnessPool) > 0; i++ {
+		c.logger.Info("Replacing removed witness", "witness", c.witnessPool[0])
+		c.witnesses = append(c.witnesses, c.witnessPool[0])
+		c.witnessPool = c.witnessPool[1:]
+		c.metrics.WitnessesRotated.With("reason", "removed").Add(1)
+	}
+	c.metrics.Witnesses.Set(float64(len(c.witnesses)))
+
 	return nil
 }
 
+// rotateWitnesses replaces the witnesses at the given indexes, which don't
+// respond, by providers of the witness pool, putting them back into the pool.
+//
+// NOTE: requires a providerMutex lock
+func (c *Client) rotateWitnesses(indexes []int) {
+	poolSize := len(c.witnessPool)
+	for i := 0; i < len(indexes) && i < poolSize; i++ {
+		offline := c.witnesses[indexes[i]]
+		c.logger.Info("Rotating witness which doesn't respond", "witness", offline,
+			"replacement", c.witnessPool[0])
+		c.witnesses[indexes[i]] = c.witnessPool[0]
+		c.witnessPool = append(c.witnessPool[1:], offline)
+		c.metrics.WitnessesRotated.With("reason", "offline").Add(1)
+	}
+}
+
 type witnessResponse struct {
 	lb           *types.LightBlock
 	witnessIndex int
@@ -1156,6 +1270,14 @@ func (c *Client) compareFirstHeaderWithWitnesses(ctx context.Context, h *types.S
 		case errConflictingHeaders:
 			c.logger.Error(fmt.Sprintf(`Witness #%d has a different header. Please check primary is correct
 and remove witness. Otherwise, use the different primary`, e.WitnessIndex), "witness", c.witnesses[e.WitnessIndex])
+			c.reportDivergence(DivergenceReport{
+				Outcome:      DivergenceUnverified,
+				Height:       h.Height,
+				Primary:      fmt.Sprint(c.primary),
+				Witness:      fmt.Sprint(c.witnesses[e.WitnessIndex]),
+				PrimaryBlock: &types.LightBlock{SignedHeader: h},
+				WitnessBlock: e.Block,
+			})
 			return err
 		case errBadWitness:
 			// If witness sent us an invalid header, then remove it
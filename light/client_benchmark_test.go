@@ -12,6 +12,7 @@ import (
 	"github.com/cometbft/cometbft/light/provider"
 	mockp "github.com/cometbft/cometbft/light/provider/mock"
 	dbs "github.com/cometbft/cometbft/light/store/db"
+	"github.com/cometbft/cometbft/types"
 )
 
 // NOTE: block is produced every minute. Make sure the verification time
@@ -30,10 +31,11 @@ func BenchmarkSequence(b *testing.B) {
 	c, err := light.NewClient(
 		context.Background(),
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 24 * time.Hour,
 			Height: 1,
-			Hash:   genesisBlock.Hash(),
+			Hash:   genesisBlock.Hash(types.CanonicalEncodingProto),
 		},
 		benchmarkFullNode,
 		[]provider.Provider{benchmarkFullNode},
@@ -58,10 +60,11 @@ func BenchmarkBisection(b *testing.B) {
 	c, err := light.NewClient(
 		context.Background(),
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 24 * time.Hour,
 			Height: 1,
-			Hash:   genesisBlock.Hash(),
+			Hash:   genesisBlock.Hash(types.CanonicalEncodingProto),
 		},
 		benchmarkFullNode,
 		[]provider.Provider{benchmarkFullNode},
@@ -86,10 +89,11 @@ func BenchmarkBackwards(b *testing.B) {
 	c, err := light.NewClient(
 		context.Background(),
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 24 * time.Hour,
 			Height: trustedBlock.Height,
-			Hash:   trustedBlock.Hash(),
+			Hash:   trustedBlock.Hash(types.CanonicalEncodingProto),
 		},
 		benchmarkFullNode,
 		[]provider.Provider{benchmarkFullNode},
@@ -34,15 +34,15 @@ var (
 		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
 	// 3/3 signed
 	h2 = keys.GenSignedHeaderLastBlockID(chainID, 2, bTime.Add(30*time.Minute), nil, vals, vals,
-		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys), types.BlockID{Hash: h1.Hash()})
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys), types.BlockID{Hash: h1.Hash(types.CanonicalEncodingProto)})
 	// 3/3 signed
 	h3 = keys.GenSignedHeaderLastBlockID(chainID, 3, bTime.Add(1*time.Hour), nil, vals, vals,
-		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys), types.BlockID{Hash: h2.Hash()})
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys), types.BlockID{Hash: h2.Hash(types.CanonicalEncodingProto)})
 	trustPeriod  = 4 * time.Hour
 	trustOptions = light.TrustOptions{
 		Period: 4 * time.Hour,
 		Height: 1,
-		Hash:   h1.Hash(),
+		Hash:   h1.Hash(types.CanonicalEncodingProto),
 	}
 	valSet = map[int64]*types.ValidatorSet{
 		1: vals,
@@ -61,6 +61,7 @@ var (
 	l2       = &types.LightBlock{SignedHeader: h2, ValidatorSet: vals}
 	fullNode = mockp.New(
 		chainID,
+		types.CanonicalEncodingProto,
 		headerSet,
 		valSet,
 	)
@@ -82,7 +83,7 @@ func TestValidateTrustOptions(t *testing.T) {
 			light.TrustOptions{
 				Period: -1 * time.Hour,
 				Height: 1,
-				Hash:   h1.Hash(),
+				Hash:   h1.Hash(types.CanonicalEncodingProto),
 			},
 		},
 		{
@@ -90,7 +91,7 @@ func TestValidateTrustOptions(t *testing.T) {
 			light.TrustOptions{
 				Period: 1 * time.Hour,
 				Height: 0,
-				Hash:   h1.Hash(),
+				Hash:   h1.Hash(types.CanonicalEncodingProto),
 			},
 		},
 		{
@@ -221,14 +222,17 @@ func TestClient_SequentialVerification(t *testing.T) {
 			c, err := light.NewClient(
 				ctx,
 				chainID,
+				types.CanonicalEncodingProto,
 				trustOptions,
 				mockp.New(
 					chainID,
+					types.CanonicalEncodingProto,
 					tc.otherHeaders,
 					tc.vals,
 				),
 				[]provider.Provider{mockp.New(
 					chainID,
+					types.CanonicalEncodingProto,
 					tc.otherHeaders,
 					tc.vals,
 				)},
@@ -346,14 +350,17 @@ func TestClient_SkippingVerification(t *testing.T) {
 			c, err := light.NewClient(
 				ctx,
 				chainID,
+				types.CanonicalEncodingProto,
 				trustOptions,
 				mockp.New(
 					chainID,
+					types.CanonicalEncodingProto,
 					tc.otherHeaders,
 					tc.vals,
 				),
 				[]provider.Provider{mockp.New(
 					chainID,
+					types.CanonicalEncodingProto,
 					tc.otherHeaders,
 					tc.vals,
 				)},
@@ -387,10 +394,11 @@ func TestClientLargeBisectionVerification(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 4 * time.Hour,
 			Height: trustedLightBlock.Height,
-			Hash:   trustedLightBlock.Hash(),
+			Hash:   trustedLightBlock.Hash(types.CanonicalEncodingProto),
 		},
 		veryLargeFullNode,
 		[]provider.Provider{veryLargeFullNode},
@@ -409,10 +417,11 @@ func TestClientBisectionBetweenTrustedHeaders(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 4 * time.Hour,
 			Height: 1,
-			Hash:   h1.Hash(),
+			Hash:   h1.Hash(types.CanonicalEncodingProto),
 		},
 		fullNode,
 		[]provider.Provider{fullNode},
@@ -433,10 +442,57 @@ func TestClientBisectionBetweenTrustedHeaders(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClientBisectionStrategies(t *testing.T) {
+	// the validator set changes at every height, so the client has to fetch
+	// intermediate light blocks
+	node := mockp.New(genMockNode(chainID, 50, 10, 1, bTime))
+	trustedLightBlock, err := node.LightBlock(ctx, 1)
+	require.NoError(t, err)
+	target, err := node.LightBlock(ctx, 50)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		strategy light.BisectionStrategy
+	}{
+		{"default", light.DefaultBisection()},
+		{"binary", light.BinaryBisection()},
+		{"sequential", light.SequentialBisection()},
+		{"trust period batching", light.TrustPeriodBatching(8)},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := light.NewClient(
+				ctx,
+				chainID,
+				types.CanonicalEncodingProto,
+				light.TrustOptions{
+					Period: 4 * time.Hour,
+					Height: trustedLightBlock.Height,
+					Hash:   trustedLightBlock.Hash(types.CanonicalEncodingProto),
+				},
+				node,
+				[]provider.Provider{node},
+				dbs.New(dbm.NewMemDB(), chainID),
+				light.SkippingVerification(light.DefaultTrustLevel),
+				light.Bisection(tc.strategy),
+			)
+			require.NoError(t, err)
+
+			l, err := c.VerifyLightBlockAtHeight(ctx, 50, bTime.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, target, l)
+		})
+	}
+}
+
 func TestClient_Cleanup(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		trustOptions,
 		fullNode,
 		[]provider.Provider{fullNode},
@@ -467,6 +523,7 @@ func TestClientRestoresTrustedHeaderAfterStartup1(t *testing.T) {
 		c, err := light.NewClient(
 			ctx,
 			chainID,
+			types.CanonicalEncodingProto,
 			trustOptions,
 			fullNode,
 			[]provider.Provider{fullNode},
@@ -478,8 +535,8 @@ func TestClientRestoresTrustedHeaderAfterStartup1(t *testing.T) {
 		l, err := c.TrustedLightBlock(1)
 		assert.NoError(t, err)
 		assert.NotNil(t, l)
-		assert.Equal(t, l.Hash(), h1.Hash())
-		assert.Equal(t, l.ValidatorSet.Hash(), h1.ValidatorsHash.Bytes())
+		assert.Equal(t, l.Hash(types.CanonicalEncodingProto), h1.Hash(types.CanonicalEncodingProto))
+		assert.Equal(t, l.ValidatorSet.Hash(types.CanonicalEncodingProto), h1.ValidatorsHash.Bytes())
 	}
 
 	// 2. options.Hash != trustedHeader.Hash
@@ -494,6 +551,7 @@ func TestClientRestoresTrustedHeaderAfterStartup1(t *testing.T) {
 
 		primary := mockp.New(
 			chainID,
+			types.CanonicalEncodingProto,
 			map[int64]*types.SignedHeader{
 				// trusted header
 				1: header1,
@@ -504,10 +562,11 @@ func TestClientRestoresTrustedHeaderAfterStartup1(t *testing.T) {
 		c, err := light.NewClient(
 			ctx,
 			chainID,
+			types.CanonicalEncodingProto,
 			light.TrustOptions{
 				Period: 4 * time.Hour,
 				Height: 1,
-				Hash:   header1.Hash(),
+				Hash:   header1.Hash(types.CanonicalEncodingProto),
 			},
 			primary,
 			[]provider.Provider{primary},
@@ -519,8 +578,8 @@ func TestClientRestoresTrustedHeaderAfterStartup1(t *testing.T) {
 		l, err := c.TrustedLightBlock(1)
 		assert.NoError(t, err)
 		if assert.NotNil(t, l) {
-			assert.Equal(t, l.Hash(), header1.Hash())
-			assert.NoError(t, l.ValidateBasic(chainID))
+			assert.Equal(t, l.Hash(types.CanonicalEncodingProto), header1.Hash(types.CanonicalEncodingProto))
+			assert.NoError(t, l.ValidateBasic(chainID, types.CanonicalEncodingProto))
 		}
 	}
 }
@@ -536,10 +595,11 @@ func TestClientRestoresTrustedHeaderAfterStartup2(t *testing.T) {
 		c, err := light.NewClient(
 			ctx,
 			chainID,
+			types.CanonicalEncodingProto,
 			light.TrustOptions{
 				Period: 4 * time.Hour,
 				Height: 2,
-				Hash:   h2.Hash(),
+				Hash:   h2.Hash(types.CanonicalEncodingProto),
 			},
 			fullNode,
 			[]provider.Provider{fullNode},
@@ -552,8 +612,8 @@ func TestClientRestoresTrustedHeaderAfterStartup2(t *testing.T) {
 		l, err := c.TrustedLightBlock(1)
 		assert.NoError(t, err)
 		assert.NotNil(t, l)
-		assert.Equal(t, l.Hash(), h1.Hash())
-		assert.NoError(t, l.ValidateBasic(chainID))
+		assert.Equal(t, l.Hash(types.CanonicalEncodingProto), h1.Hash(types.CanonicalEncodingProto))
+		assert.NoError(t, l.ValidateBasic(chainID, types.CanonicalEncodingProto))
 	}
 
 	// 2. options.Hash != trustedHeader.Hash
@@ -572,6 +632,7 @@ func TestClientRestoresTrustedHeaderAfterStartup2(t *testing.T) {
 
 		primary := mockp.New(
 			chainID,
+			types.CanonicalEncodingProto,
 			map[int64]*types.SignedHeader{
 				1: diffHeader1,
 				2: diffHeader2,
@@ -582,10 +643,11 @@ func TestClientRestoresTrustedHeaderAfterStartup2(t *testing.T) {
 		c, err := light.NewClient(
 			ctx,
 			chainID,
+			types.CanonicalEncodingProto,
 			light.TrustOptions{
 				Period: 4 * time.Hour,
 				Height: 2,
-				Hash:   diffHeader2.Hash(),
+				Hash:   diffHeader2.Hash(types.CanonicalEncodingProto),
 			},
 			primary,
 			[]provider.Provider{primary},
@@ -616,6 +678,7 @@ func TestClientRestoresTrustedHeaderAfterStartup3(t *testing.T) {
 		c, err := light.NewClient(
 			ctx,
 			chainID,
+			types.CanonicalEncodingProto,
 			trustOptions,
 			fullNode,
 			[]provider.Provider{fullNode},
@@ -628,8 +691,8 @@ func TestClientRestoresTrustedHeaderAfterStartup3(t *testing.T) {
 		l, err := c.TrustedLightBlock(1)
 		assert.NoError(t, err)
 		assert.NotNil(t, l)
-		assert.Equal(t, l.Hash(), h1.Hash())
-		assert.NoError(t, l.ValidateBasic(chainID))
+		assert.Equal(t, l.Hash(types.CanonicalEncodingProto), h1.Hash(types.CanonicalEncodingProto))
+		assert.NoError(t, l.ValidateBasic(chainID, types.CanonicalEncodingProto))
 
 		// Check we no longer have 2nd light block.
 		l, err = c.TrustedLightBlock(2)
@@ -662,6 +725,7 @@ func TestClientRestoresTrustedHeaderAfterStartup3(t *testing.T) {
 
 		primary := mockp.New(
 			chainID,
+			types.CanonicalEncodingProto,
 			map[int64]*types.SignedHeader{
 				1: header1,
 			},
@@ -671,10 +735,11 @@ func TestClientRestoresTrustedHeaderAfterStartup3(t *testing.T) {
 		c, err := light.NewClient(
 			ctx,
 			chainID,
+			types.CanonicalEncodingProto,
 			light.TrustOptions{
 				Period: 4 * time.Hour,
 				Height: 1,
-				Hash:   header1.Hash(),
+				Hash:   header1.Hash(types.CanonicalEncodingProto),
 			},
 			primary,
 			[]provider.Provider{primary},
@@ -687,8 +752,8 @@ func TestClientRestoresTrustedHeaderAfterStartup3(t *testing.T) {
 		l, err := c.TrustedLightBlock(1)
 		assert.NoError(t, err)
 		assert.NotNil(t, l)
-		assert.Equal(t, l.Hash(), header1.Hash())
-		assert.NoError(t, l.ValidateBasic(chainID))
+		assert.Equal(t, l.Hash(types.CanonicalEncodingProto), header1.Hash(types.CanonicalEncodingProto))
+		assert.NoError(t, l.ValidateBasic(chainID, types.CanonicalEncodingProto))
 
 		// Check we no longer have invalid 2nd light block (+lightblock2+).
 		l, err = c.TrustedLightBlock(2)
@@ -701,6 +766,7 @@ func TestClient_Update(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		trustOptions,
 		fullNode,
 		[]provider.Provider{fullNode},
@@ -714,7 +780,7 @@ func TestClient_Update(t *testing.T) {
 	assert.NoError(t, err)
 	if assert.NotNil(t, l) {
 		assert.EqualValues(t, 3, l.Height)
-		assert.NoError(t, l.ValidateBasic(chainID))
+		assert.NoError(t, l.ValidateBasic(chainID, types.CanonicalEncodingProto))
 	}
 }
 
@@ -722,6 +788,7 @@ func TestClient_Concurrency(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		trustOptions,
 		fullNode,
 		[]provider.Provider{fullNode},
@@ -763,6 +830,7 @@ func TestClientReplacesPrimaryWithWitnessIfPrimaryIsUnavailable(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		trustOptions,
 		deadNode,
 		[]provider.Provider{fullNode, fullNode},
@@ -785,10 +853,11 @@ func TestClient_BackwardsVerification(t *testing.T) {
 		c, err := light.NewClient(
 			ctx,
 			chainID,
+			types.CanonicalEncodingProto,
 			light.TrustOptions{
 				Period: 4 * time.Minute,
 				Height: trustHeader.Height,
-				Hash:   trustHeader.Hash(),
+				Hash:   trustHeader.Hash(types.CanonicalEncodingProto),
 			},
 			largeFullNode,
 			[]provider.Provider{largeFullNode},
@@ -839,6 +908,7 @@ func TestClient_BackwardsVerification(t *testing.T) {
 				// 7) provides incorrect height
 				mockp.New(
 					chainID,
+					types.CanonicalEncodingProto,
 					map[int64]*types.SignedHeader{
 						1: h1,
 						2: keys.GenSignedHeader(chainID, 1, bTime.Add(30*time.Minute), nil, vals, vals,
@@ -852,6 +922,7 @@ func TestClient_BackwardsVerification(t *testing.T) {
 				// 8) provides incorrect hash
 				mockp.New(
 					chainID,
+					types.CanonicalEncodingProto,
 					map[int64]*types.SignedHeader{
 						1: h1,
 						2: keys.GenSignedHeader(chainID, 2, bTime.Add(30*time.Minute), nil, vals, vals,
@@ -867,10 +938,11 @@ func TestClient_BackwardsVerification(t *testing.T) {
 			c, err := light.NewClient(
 				ctx,
 				chainID,
+				types.CanonicalEncodingProto,
 				light.TrustOptions{
 					Period: 1 * time.Hour,
 					Height: 3,
-					Hash:   h3.Hash(),
+					Hash:   h3.Hash(types.CanonicalEncodingProto),
 				},
 				tc.provider,
 				[]provider.Provider{tc.provider},
@@ -893,6 +965,7 @@ func TestClient_NewClientFromTrustedStore(t *testing.T) {
 
 	c, err := light.NewClientFromTrustedStore(
 		chainID,
+		types.CanonicalEncodingProto,
 		trustPeriod,
 		deadNode,
 		[]provider.Provider{deadNode},
@@ -911,11 +984,12 @@ func TestClientRemovesWitnessIfItSendsUsIncorrectHeader(t *testing.T) {
 	// different headers hash then primary plus less than 1/3 signed (no fork)
 	badProvider1 := mockp.New(
 		chainID,
+		types.CanonicalEncodingProto,
 		map[int64]*types.SignedHeader{
 			1: h1,
 			2: keys.GenSignedHeaderLastBlockID(chainID, 2, bTime.Add(30*time.Minute), nil, vals, vals,
 				hash("app_hash2"), hash("cons_hash"), hash("results_hash"),
-				len(keys), len(keys), types.BlockID{Hash: h1.Hash()}),
+				len(keys), len(keys), types.BlockID{Hash: h1.Hash(types.CanonicalEncodingProto)}),
 		},
 		map[int64]*types.ValidatorSet{
 			1: vals,
@@ -925,6 +999,7 @@ func TestClientRemovesWitnessIfItSendsUsIncorrectHeader(t *testing.T) {
 	// header is empty
 	badProvider2 := mockp.New(
 		chainID,
+		types.CanonicalEncodingProto,
 		map[int64]*types.SignedHeader{
 			1: h1,
 			2: h2,
@@ -936,11 +1011,12 @@ func TestClientRemovesWitnessIfItSendsUsIncorrectHeader(t *testing.T) {
 	)
 
 	lb1, _ := badProvider1.LightBlock(ctx, 2)
-	require.NotEqual(t, lb1.Hash(), l1.Hash())
+	require.NotEqual(t, lb1.Hash(types.CanonicalEncodingProto), l1.Hash(types.CanonicalEncodingProto))
 
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		trustOptions,
 		fullNode,
 		[]provider.Provider{badProvider1, badProvider2},
@@ -972,13 +1048,14 @@ func TestClient_TrustedValidatorSet(t *testing.T) {
 	differentVals, _ := types.RandValidatorSet(10, 100)
 	badValSetNode := mockp.New(
 		chainID,
+		types.CanonicalEncodingProto,
 		map[int64]*types.SignedHeader{
 			1: h1,
 			// 3/3 signed, but validator set at height 2 below is invalid -> witness
 			// should be removed.
 			2: keys.GenSignedHeaderLastBlockID(chainID, 2, bTime.Add(30*time.Minute), nil, vals, vals,
 				hash("app_hash2"), hash("cons_hash"), hash("results_hash"),
-				0, len(keys), types.BlockID{Hash: h1.Hash()}),
+				0, len(keys), types.BlockID{Hash: h1.Hash(types.CanonicalEncodingProto)}),
 			3: h3,
 		},
 		map[int64]*types.ValidatorSet{
@@ -991,6 +1068,7 @@ func TestClient_TrustedValidatorSet(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		trustOptions,
 		fullNode,
 		[]provider.Provider{badValSetNode, fullNode},
@@ -1009,6 +1087,7 @@ func TestClientPrunesHeadersAndValidatorSets(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		trustOptions,
 		fullNode,
 		[]provider.Provider{fullNode},
@@ -1076,12 +1155,14 @@ func TestClientEnsureValidHeadersAndValSets(t *testing.T) {
 	for _, tc := range testCases {
 		badNode := mockp.New(
 			chainID,
+			types.CanonicalEncodingProto,
 			tc.headers,
 			tc.vals,
 		)
 		c, err := light.NewClient(
 			ctx,
 			chainID,
+			types.CanonicalEncodingProto,
 			trustOptions,
 			badNode,
 			[]provider.Provider{badNode, badNode},
@@ -1110,10 +1191,11 @@ func TestClientHandlesContexts(t *testing.T) {
 	_, err = light.NewClient(
 		ctxTimeOut,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 24 * time.Hour,
 			Height: 1,
-			Hash:   genBlock.Hash(),
+			Hash:   genBlock.Hash(types.CanonicalEncodingProto),
 		},
 		p,
 		[]provider.Provider{p, p},
@@ -1127,10 +1209,11 @@ func TestClientHandlesContexts(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 24 * time.Hour,
 			Height: 1,
-			Hash:   genBlock.Hash(),
+			Hash:   genBlock.Hash(types.CanonicalEncodingProto),
 		},
 		p,
 		[]provider.Provider{p, p},
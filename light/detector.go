@@ -33,6 +33,7 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 		headerMatched      bool
 		lastVerifiedHeader = primaryTrace[len(primaryTrace)-1].SignedHeader
 		witnessesToRemove  = make([]int, 0)
+		witnessesToRotate  = make([]int, 0)
 	)
 	c.logger.Debug("Running detector against trace", "endBlockHeight", lastVerifiedHeader.Height,
 		"endBlockHash", lastVerifiedHeader.Hash, "length", len(primaryTrace))
@@ -86,9 +87,16 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 				return e
 			}
 			c.logger.Info("error in light block request to witness", "err", err)
+			var noResponse errNoResponse
+			if errors.As(e, &noResponse) {
+				witnessesToRotate = append(witnessesToRotate, noResponse.WitnessIndex)
+			}
 		}
 	}
 
+	// replace witnesses that don't respond. This doesn't change the indexes.
+	c.rotateWitnesses(witnessesToRotate)
+
 	// remove witnesses that have misbehaved
 	if err := c.removeWitnesses(witnessesToRemove); err != nil {
 		return err
@@ -124,7 +132,10 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 
 	// the witness hasn't been helpful in comparing headers, we mark the response and continue
 	// comparing with the rest of the witnesses
-	case provider.ErrNoResponse, provider.ErrLightBlockNotFound, context.DeadlineExceeded, context.Canceled:
+	case provider.ErrNoResponse:
+		errc <- errNoResponse{WitnessIndex: witnessIndex}
+		return
+	case provider.ErrLightBlockNotFound, context.DeadlineExceeded, context.Canceled:
 		errc <- err
 		return
 
@@ -196,8 +207,9 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 		return
 	}
 
-	if !bytes.Equal(h.Hash(), lightBlock.Hash()) {
+	if !bytes.Equal(h.Hash(c.encoding), lightBlock.Hash(c.encoding)) {
 		errc <- errConflictingHeaders{Block: lightBlock, WitnessIndex: witnessIndex}
+		return
 	}
 
 	c.logger.Debug("Matching header received by witness", "height", h.Height, "witness", witnessIndex)
@@ -229,15 +241,26 @@ func (c *Client) handleConflictingHeaders(
 		supportingWitness,
 		now,
 	)
+	report := DivergenceReport{
+		Height:       challengingBlock.Height,
+		Primary:      fmt.Sprint(c.primary),
+		Witness:      fmt.Sprint(supportingWitness),
+		PrimaryBlock: primaryTrace[len(primaryTrace)-1],
+		WitnessBlock: challengingBlock,
+	}
 	if err != nil {
 		c.logger.Info("error validating witness's divergent header", "witness", supportingWitness, "err", err)
+		report.Outcome = DivergenceBadWitness
+		report.Reason = err.Error()
+		c.reportDivergence(report)
 		return nil
 	}
+	report.Outcome = DivergenceAttack
 
 	// We are suspecting that the primary is faulty, hence we hold the witness as the source of truth
 	// and generate evidence against the primary that we can send to the witness
 	commonBlock, trustedBlock := witnessTrace[0], witnessTrace[len(witnessTrace)-1]
-	evidenceAgainstPrimary := newLightClientAttackEvidence(primaryBlock, trustedBlock, commonBlock)
+	evidenceAgainstPrimary := NewLightClientAttackEvidence(primaryBlock, trustedBlock, commonBlock)
 	c.logger.Error("ATTEMPTED ATTACK DETECTED. Sending evidence againt primary by witness", "ev", evidenceAgainstPrimary,
 		"primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstPrimary, supportingWitness)
@@ -260,15 +283,21 @@ func (c *Client) handleConflictingHeaders(
 	)
 	if err != nil {
 		c.logger.Info("Error validating primary's divergent header", "primary", c.primary, "err", err)
+		report.EvidenceAgainstPrimary = evidenceAgainstPrimary
+		report.Reason = err.Error()
+		c.reportDivergence(report)
 		return ErrLightClientAttack
 	}
 
 	// We now use the primary trace to create evidence against the witness and send it to the primary
 	commonBlock, trustedBlock = primaryTrace[0], primaryTrace[len(primaryTrace)-1]
-	evidenceAgainstWitness := newLightClientAttackEvidence(witnessBlock, trustedBlock, commonBlock)
+	evidenceAgainstWitness := NewLightClientAttackEvidence(witnessBlock, trustedBlock, commonBlock)
 	c.logger.Error("Sending evidence against witness by primary", "ev", evidenceAgainstWitness,
 		"primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstWitness, c.primary)
+	report.EvidenceAgainstPrimary = evidenceAgainstPrimary
+	report.EvidenceAgainstWitness = evidenceAgainstWitness
+	c.reportDivergence(report)
 	// We return the error and don't process anymore witnesses
 	return ErrLightClientAttack
 }
@@ -342,7 +371,7 @@ func (c *Client) examineConflictingHeaderAgainstTrace(
 		// The first block in the trace MUST be the same to the light block that the source produces
 		// else we cannot continue with verification.
 		if idx == 0 {
-			if shash, thash := sourceBlock.Hash(), traceBlock.Hash(); !bytes.Equal(shash, thash) {
+			if shash, thash := sourceBlock.Hash(c.encoding), traceBlock.Hash(c.encoding); !bytes.Equal(shash, thash) {
 				return nil, nil, fmt.Errorf("trusted block is different to the source's first block (%X = %X)",
 					thash, shash)
 			}
@@ -357,7 +386,7 @@ func (c *Client) examineConflictingHeaderAgainstTrace(
 			return nil, nil, fmt.Errorf("verifySkipping of conflicting header failed: %w", err)
 		}
 		// check if the headers verified by the source has diverged from the trace
-		if shash, thash := sourceBlock.Hash(), traceBlock.Hash(); !bytes.Equal(shash, thash) {
+		if shash, thash := sourceBlock.Hash(c.encoding), traceBlock.Hash(c.encoding); !bytes.Equal(shash, thash) {
 			// Bifurcation point found!
 			return sourceTrace, traceBlock, nil
 		}
@@ -403,10 +432,35 @@ func (c *Client) getTargetBlockOrLatest(
 	return false, lightBlock, nil
 }
 
-// newLightClientAttackEvidence determines the type of attack and then forms the evidence filling out
-// all the fields such that it is ready to be sent to a full node.
-func newLightClientAttackEvidence(conflicted, trusted, common *types.LightBlock) *types.LightClientAttackEvidence {
-	ev := &types.LightClientAttackEvidence{ConflictingBlock: conflicted}
+// NewLightClientAttackEvidence determines the type of attack and then forms the evidence filling out
+// all the fields such that it is ready to be sent to a full node. trusted is the block at the same
+// height as conflicted on the chain, and common the last block both agree on.
+func NewLightClientAttackEvidence(conflicted, trusted, common *types.LightBlock) *types.LightClientAttackEvidence {
+	return fillLightClientAttackEvidence(&types.LightClientAttackEvidence{ConflictingBlock: conflicted}, trusted, common)
+}
+
+// NewAggregatedLightClientAttackEvidence returns the evidence against the
+// validators of conflictingVals, which signed the conflicting header with an
+// aggregated commit, as verified by the light clients of aggregated commits.
+// trusted is the block at the same height on the chain, and common the last
+// block both agree on, as for the light client attacks detected by Client.
+func NewAggregatedLightClientAttackEvidence(
+	conflicting *types.AggregatedSignedHeader,
+	conflictingVals *types.ValidatorSet,
+	trusted, common *types.LightBlock,
+) *types.LightClientAttackEvidence {
+	ev := &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: &types.SignedHeader{Header: conflicting.Header},
+			ValidatorSet: conflictingVals,
+		},
+		ConflictingAggregatedCommit: conflicting.Commit,
+	}
+	return fillLightClientAttackEvidence(ev, trusted, common)
+}
+
+func fillLightClientAttackEvidence(ev *types.LightClientAttackEvidence,
+	trusted, common *types.LightBlock) *types.LightClientAttackEvidence {
 	// if this is an equivocation or amnesia attack, i.e. the validator sets are the same, then we
 	// return the height of the conflicting block else if it is a lunatic attack and the validator sets
 	// are not the same then we send the height of the common header.
@@ -28,7 +28,7 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 	)
 
 	witnessHeaders, witnessValidators, chainKeys := genMockNodeWithKeys(chainID, latestHeight, valSize, 2, bTime)
-	witness := mockp.New(chainID, witnessHeaders, witnessValidators)
+	witness := mockp.New(chainID, types.CanonicalEncodingProto, witnessHeaders, witnessValidators)
 	forgedKeys := chainKeys[divergenceHeight-1].ChangeKeys(3) // we change 3 out of the 5 validators (still 2/5 remain)
 	forgedVals := forgedKeys.ToValidators(2, 0)
 
@@ -42,21 +42,24 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 			nil, forgedVals, forgedVals, hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(forgedKeys))
 		primaryValidators[height] = forgedVals
 	}
-	primary := mockp.New(chainID, primaryHeaders, primaryValidators)
+	primary := mockp.New(chainID, types.CanonicalEncodingProto, primaryHeaders, primaryValidators)
 
+	var reports []light.DivergenceReport
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 4 * time.Hour,
 			Height: 1,
-			Hash:   primaryHeaders[1].Hash(),
+			Hash:   primaryHeaders[1].Hash(types.CanonicalEncodingProto),
 		},
 		primary,
 		[]provider.Provider{witness},
 		dbs.New(dbm.NewMemDB(), chainID),
 		light.Logger(log.TestingLogger()),
 		light.MaxRetryAttempts(1),
+		light.OnDivergence(func(r light.DivergenceReport) { reports = append(reports, r) }),
 	)
 	require.NoError(t, err)
 
@@ -66,6 +69,16 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		assert.Equal(t, light.ErrLightClientAttack, err)
 	}
 
+	// Check the divergence was reported.
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, light.DivergenceAttack, reports[0].Outcome)
+		assert.EqualValues(t, 10, reports[0].Height)
+		assert.Equal(t, primaryHeaders[10].Hash(types.CanonicalEncodingProto), reports[0].PrimaryBlock.Hash(types.CanonicalEncodingProto))
+		assert.Equal(t, witnessHeaders[10].Hash(types.CanonicalEncodingProto), reports[0].WitnessBlock.Hash(types.CanonicalEncodingProto))
+		assert.NotNil(t, reports[0].EvidenceAgainstPrimary)
+		assert.NotNil(t, reports[0].EvidenceAgainstWitness)
+	}
+
 	// Check evidence was sent to both full nodes.
 	evAgainstPrimary := &types.LightClientAttackEvidence{
 		// after the divergence height the valset doesn't change so we expect the evidence to be for height 10
@@ -108,7 +121,7 @@ func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
 		)
 		// validators don't change in this network (however we still use a map just for convenience)
 		witnessHeaders, witnessValidators, chainKeys := genMockNodeWithKeys(chainID, latestHeight+2, valSize, 2, bTime)
-		witness := mockp.New(chainID, witnessHeaders, witnessValidators)
+		witness := mockp.New(chainID, types.CanonicalEncodingProto, witnessHeaders, witnessValidators)
 
 		for height := int64(1); height <= latestHeight; height++ {
 			if height < divergenceHeight {
@@ -124,15 +137,16 @@ func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
 				hash("cons_hash"), hash("results_hash"), 0, len(chainKeys[height])-1)
 			primaryValidators[height] = witnessValidators[height]
 		}
-		primary := mockp.New(chainID, primaryHeaders, primaryValidators)
+		primary := mockp.New(chainID, types.CanonicalEncodingProto, primaryHeaders, primaryValidators)
 
 		c, err := light.NewClient(
 			ctx,
 			chainID,
+			types.CanonicalEncodingProto,
 			light.TrustOptions{
 				Period: 4 * time.Hour,
 				Height: 1,
-				Hash:   primaryHeaders[1].Hash(),
+				Hash:   primaryHeaders[1].Hash(types.CanonicalEncodingProto),
 			},
 			primary,
 			[]provider.Provider{witness},
@@ -207,8 +221,8 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 		0, len(forgedKeys),
 	)
 
-	witness := mockp.New(chainID, witnessHeaders, witnessValidators)
-	primary := mockp.New(chainID, primaryHeaders, primaryValidators)
+	witness := mockp.New(chainID, types.CanonicalEncodingProto, witnessHeaders, witnessValidators)
+	primary := mockp.New(chainID, types.CanonicalEncodingProto, primaryHeaders, primaryValidators)
 
 	laggingWitness := witness.Copy(chainID)
 
@@ -219,10 +233,11 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 4 * time.Hour,
 			Height: 1,
-			Hash:   primaryHeaders[1].Hash(),
+			Hash:   primaryHeaders[1].Hash(types.CanonicalEncodingProto),
 		},
 		primary,
 		[]provider.Provider{witness, accomplice},
@@ -286,10 +301,11 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 	c, err = light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 4 * time.Hour,
 			Height: 1,
-			Hash:   primaryHeaders[1].Hash(),
+			Hash:   primaryHeaders[1].Hash(types.CanonicalEncodingProto),
 		},
 		primary,
 		[]provider.Provider{laggingWitness, accomplice},
@@ -316,9 +332,10 @@ func TestClientDivergentTraces1(t *testing.T) {
 	_, err = light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Height: 1,
-			Hash:   firstBlock.Hash(),
+			Hash:   firstBlock.Hash(types.CanonicalEncodingProto),
 			Period: 4 * time.Hour,
 		},
 		primary,
@@ -340,9 +357,10 @@ func TestClientDivergentTraces2(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Height: 1,
-			Hash:   firstBlock.Hash(),
+			Hash:   firstBlock.Hash(types.CanonicalEncodingProto),
 			Period: 4 * time.Hour,
 		},
 		primary,
@@ -361,23 +379,24 @@ func TestClientDivergentTraces2(t *testing.T) {
 // 3. witness has the same first header, but different second header
 // => creation should succeed, but the verification should fail
 func TestClientDivergentTraces3(t *testing.T) {
-	_, primaryHeaders, primaryVals := genMockNode(chainID, 10, 5, 2, bTime)
-	primary := mockp.New(chainID, primaryHeaders, primaryVals)
+	_, _, primaryHeaders, primaryVals := genMockNode(chainID, 10, 5, 2, bTime)
+	primary := mockp.New(chainID, types.CanonicalEncodingProto, primaryHeaders, primaryVals)
 
 	firstBlock, err := primary.LightBlock(ctx, 1)
 	require.NoError(t, err)
 
-	_, mockHeaders, mockVals := genMockNode(chainID, 10, 5, 2, bTime)
+	_, _, mockHeaders, mockVals := genMockNode(chainID, 10, 5, 2, bTime)
 	mockHeaders[1] = primaryHeaders[1]
 	mockVals[1] = primaryVals[1]
-	witness := mockp.New(chainID, mockHeaders, mockVals)
+	witness := mockp.New(chainID, types.CanonicalEncodingProto, mockHeaders, mockVals)
 
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Height: 1,
-			Hash:   firstBlock.Hash(),
+			Hash:   firstBlock.Hash(types.CanonicalEncodingProto),
 			Period: 4 * time.Hour,
 		},
 		primary,
@@ -396,13 +415,13 @@ func TestClientDivergentTraces3(t *testing.T) {
 // 4. Witness has a divergent header but can not produce a valid trace to back it up.
 // It should be ignored
 func TestClientDivergentTraces4(t *testing.T) {
-	_, primaryHeaders, primaryVals := genMockNode(chainID, 10, 5, 2, bTime)
-	primary := mockp.New(chainID, primaryHeaders, primaryVals)
+	_, _, primaryHeaders, primaryVals := genMockNode(chainID, 10, 5, 2, bTime)
+	primary := mockp.New(chainID, types.CanonicalEncodingProto, primaryHeaders, primaryVals)
 
 	firstBlock, err := primary.LightBlock(ctx, 1)
 	require.NoError(t, err)
 
-	_, mockHeaders, mockVals := genMockNode(chainID, 10, 5, 2, bTime)
+	_, _, mockHeaders, mockVals := genMockNode(chainID, 10, 5, 2, bTime)
 	witness := primary.Copy(chainID)
 	witness.AddLightBlock(&types.LightBlock{
 		SignedHeader: mockHeaders[10],
@@ -412,9 +431,10 @@ func TestClientDivergentTraces4(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Height: 1,
-			Hash:   firstBlock.Hash(),
+			Hash:   firstBlock.Hash(types.CanonicalEncodingProto),
 			Period: 4 * time.Hour,
 		},
 		primary,
@@ -428,3 +448,56 @@ func TestClientDivergentTraces4(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, 1, len(c.Witnesses()))
 }
+
+// Witnesses which don't respond are rotated with the witness pool, and the
+// ones sending conflicting headers are replaced from it.
+func TestClientWitnessPool(t *testing.T) {
+	_, _, primaryHeaders, primaryVals := genMockNode(chainID, 10, 5, 2, bTime)
+	primary := mockp.New(chainID, types.CanonicalEncodingProto, primaryHeaders, primaryVals)
+	firstBlock, err := primary.LightBlock(ctx, 1)
+	require.NoError(t, err)
+	_, _, headers, vals := genMockNode(chainID, 10, 5, 2, bTime)
+	faulty := mockp.New(chainID, types.CanonicalEncodingProto, headers, vals)
+	// same blocks as the first one, except for the last one
+	for height := int64(1); height < 10; height++ {
+		lb, err := primary.LightBlock(ctx, height)
+		require.NoError(t, err)
+		headers[height], vals[height] = lb.SignedHeader, lb.ValidatorSet
+	}
+	spare1 := mockp.New(chainID, types.CanonicalEncodingProto, nil, nil)
+	spare2 := mockp.New(chainID, types.CanonicalEncodingProto, primaryHeaders, primaryVals)
+
+	var reports []light.DivergenceReport
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		types.CanonicalEncodingProto,
+		light.TrustOptions{
+			Height: 1,
+			Hash:   firstBlock.Hash(types.CanonicalEncodingProto),
+			Period: 4 * time.Hour,
+		},
+		primary,
+		[]provider.Provider{deadNode, faulty, primary},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+		light.MaxRetryAttempts(1),
+		light.WitnessPool([]provider.Provider{spare1, spare2}),
+		light.OnDivergence(func(r light.DivergenceReport) { reports = append(reports, r) }),
+	)
+	require.NoError(t, err)
+
+	_, err = c.VerifyLightBlockAtHeight(ctx, 10, bTime.Add(1*time.Hour))
+	require.NoError(t, err)
+
+	// the dead node is rotated with the first spare, and the faulty witness is
+	// replaced by the second one.
+	witnesses := c.Witnesses()
+	assert.ElementsMatch(t, []provider.Provider{spare1, primary, spare2}, witnesses)
+
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, light.DivergenceBadWitness, reports[0].Outcome)
+		assert.EqualValues(t, 10, reports[0].Height)
+		assert.NotEmpty(t, reports[0].Reason)
+	}
+}
@@ -0,0 +1,138 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Outcomes of a divergence, see DivergenceReport.
+const (
+	// DivergenceAttack means the conflicting header of the witness could be
+	// verified: either the primary or the witness is attacking the light
+	// client, which halts.
+	DivergenceAttack = "attack"
+	// DivergenceBadWitness means the conflicting header of the witness could
+	// not be verified. The witness is removed.
+	DivergenceBadWitness = "bad_witness"
+	// DivergenceUnverified means a witness disagrees with the trusted header
+	// the light client was initialized with, which can't be examined further.
+	DivergenceUnverified = "unverified"
+	// DivergenceTrustExpired means the trusted header a new header was
+	// verified against is past the trusting period: the light client can't
+	// verify new headers until it is reset with a new trusted header. The
+	// report has no witness, and its height is the one of the trusted header.
+	DivergenceTrustExpired = "trust_expired"
+)
+
+// DivergenceReport describes a witness returning a header which conflicts
+// with the header of the primary, or the trusted header expiring, for manual
+// or automated handling.
+type DivergenceReport struct {
+	Time    time.Time `json:"time"`
+	Outcome string    `json:"outcome"`
+	Height  int64     `json:"height"`
+	Primary string    `json:"primary"`
+	Witness string    `json:"witness"`
+	// PrimaryBlock is the light block of the primary.
+	PrimaryBlock *types.LightBlock `json:"primary_block"`
+	// WitnessBlock is the conflicting light block of the witness.
+	WitnessBlock *types.LightBlock `json:"witness_block"`
+	// Evidence formed against the primary and the witness, if any.
+	EvidenceAgainstPrimary *types.LightClientAttackEvidence `json:"evidence_against_primary,omitempty"`
+	EvidenceAgainstWitness *types.LightClientAttackEvidence `json:"evidence_against_witness,omitempty"`
+	// TrustedBlock is the expired trusted light block, if the trusting period
+	// expired.
+	TrustedBlock *types.LightBlock `json:"trusted_block,omitempty"`
+	// Reason why the header of the witness or of the primary could not be
+	// verified, if so.
+	Reason string `json:"reason,omitempty"`
+}
+
+// DivergenceHandler handles the divergence reports. It is called
+// synchronously by the light client, so it must not block.
+type DivergenceHandler func(DivergenceReport)
+
+// WebhookDivergenceHandler returns a DivergenceHandler POSTing the reports,
+// encoded in JSON, to url. Requests are sent in the background and time out
+// after timeout. Failures are logged.
+func WebhookDivergenceHandler(url string, timeout time.Duration, logger log.Logger) DivergenceHandler {
+	client := &http.Client{Timeout: timeout}
+	return func(report DivergenceReport) {
+		go func() {
+			if err := postDivergenceReport(client, url, report); err != nil {
+				logger.Error("Failed to send divergence report", "url", url, "err", err)
+			}
+		}()
+	}
+}
+
+func postDivergenceReport(client *http.Client, url string, report DivergenceReport) error {
+	bz, err := cmtjson.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// reportDivergence logs, counts and hands the report to the handlers.
+func (c *Client) reportDivergence(report DivergenceReport) {
+	report.Time = time.Now()
+	keyvals := []interface{}{
+		"outcome", report.Outcome,
+		"height", report.Height,
+		"primary", report.Primary,
+	}
+	if report.PrimaryBlock != nil {
+		keyvals = append(keyvals, "primaryHash", report.PrimaryBlock.Hash(c.encoding))
+	}
+	if report.WitnessBlock != nil {
+		keyvals = append(keyvals, "witness", report.Witness, "witnessHash", report.WitnessBlock.Hash(c.encoding))
+	}
+	keyvals = append(keyvals, "reason", report.Reason)
+	if report.Outcome == DivergenceTrustExpired {
+		c.logger.Error("Trusted header expired", keyvals...)
+	} else {
+		c.logger.Error("Divergence detected", keyvals...)
+	}
+	c.metrics.Divergences.With("outcome", report.Outcome).Add(1)
+	for _, h := range c.divergenceHandlers {
+		h(report)
+	}
+}
+
+// reportTrustExpired reports the trusted light block the new light block could
+// not be verified against because it expired, once per trusted light block.
+func (c *Client) reportTrustExpired(trustedBlock, newLightBlock *types.LightBlock, err error) {
+	if atomic.SwapInt64(&c.trustExpiredHeight, trustedBlock.Height) == trustedBlock.Height {
+		return
+	}
+	c.reportDivergence(DivergenceReport{
+		Outcome:      DivergenceTrustExpired,
+		Height:       trustedBlock.Height,
+		Primary:      fmt.Sprint(c.primary),
+		PrimaryBlock: newLightBlock,
+		TrustedBlock: trustedBlock,
+		Reason:       err.Error(),
+	})
+}
@@ -0,0 +1,91 @@
+package light_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	dbs "github.com/cometbft/cometbft/light/store/db"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestWebhookDivergenceHandler(t *testing.T) {
+	received := make(chan light.DivergenceReport, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		bz, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var report light.DivergenceReport
+		require.NoError(t, cmtjson.Unmarshal(bz, &report))
+		received <- report
+	}))
+	defer srv.Close()
+
+	handler := light.WebhookDivergenceHandler(srv.URL, time.Second, log.TestingLogger())
+	handler(light.DivergenceReport{
+		Outcome:      light.DivergenceAttack,
+		Height:       2,
+		PrimaryBlock: l1,
+		WitnessBlock: l2,
+	})
+
+	select {
+	case report := <-received:
+		assert.Equal(t, light.DivergenceAttack, report.Outcome)
+		assert.EqualValues(t, 2, report.Height)
+		assert.Equal(t, l1.Hash(types.CanonicalEncodingProto), report.PrimaryBlock.Hash(types.CanonicalEncodingProto))
+		assert.Equal(t, l2.Hash(types.CanonicalEncodingProto), report.WitnessBlock.Hash(types.CanonicalEncodingProto))
+	case <-time.After(5 * time.Second):
+		t.Fatal("report not received")
+	}
+}
+
+func TestClientReportsTrustExpired(t *testing.T) {
+	var reports []light.DivergenceReport
+	c, err := light.NewClient(
+		context.Background(),
+		chainID,
+		types.CanonicalEncodingProto,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{fullNode},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+		light.OnDivergence(func(r light.DivergenceReport) { reports = append(reports, r) }),
+	)
+	require.NoError(t, err)
+
+	// the trusted header at height 1 expired
+	now := bTime.Add(trustPeriod + time.Hour)
+	_, err = c.VerifyLightBlockAtHeight(context.Background(), 3, now)
+	var expiredErr light.ErrOldHeaderExpired
+	require.True(t, errors.As(err, &expiredErr), err)
+
+	require.Len(t, reports, 1)
+	report := reports[0]
+	assert.Equal(t, light.DivergenceTrustExpired, report.Outcome)
+	assert.EqualValues(t, 1, report.Height)
+	assert.Equal(t, h1.Hash(types.CanonicalEncodingProto), report.TrustedBlock.Hash(types.CanonicalEncodingProto))
+	assert.Equal(t, h3.Hash(types.CanonicalEncodingProto), report.PrimaryBlock.Hash(types.CanonicalEncodingProto))
+	assert.Nil(t, report.WitnessBlock)
+	assert.NotEmpty(t, report.Reason)
+
+	// the same expired header is only reported once
+	_, err = c.VerifyLightBlockAtHeight(context.Background(), 2, now)
+	require.Error(t, err)
+	assert.Len(t, reports, 1)
+}
@@ -70,10 +70,11 @@ Example usage:
 
 	c, err := NewHTTPClient(
 		chainID,
+		types.CanonicalEncodingProto, // see the Encoding consensus params
 		TrustOptions{
 			Period: 504 * time.Hour, // 21 days
 			Height: 100,
-			Hash:   header.Hash(),
+			Hash:   header.Hash(types.CanonicalEncodingProto),
 		},
 		"http://localhost:26657",
 		[]string{"http://witness1:26657"},
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cometbft/cometbft/light/provider"
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -86,7 +87,7 @@ type errConflictingHeaders struct {
 func (e errConflictingHeaders) Error() string {
 	return fmt.Sprintf(
 		"header hash (%X) from witness (%d) does not match primary",
-		e.Block.Hash(), e.WitnessIndex)
+		e.Block.Commit.BlockID.Hash, e.WitnessIndex)
 }
 
 // errBadWitness is returned when the witness either does not respond or
@@ -100,6 +101,19 @@ func (e errBadWitness) Error() string {
 	return fmt.Sprintf("Witness %d returned error: %s", e.WitnessIndex, e.Reason.Error())
 }
 
+// errNoResponse is returned when a witness doesn't respond.
+type errNoResponse struct {
+	WitnessIndex int
+}
+
+func (e errNoResponse) Error() string {
+	return fmt.Sprintf("Witness %d: %s", e.WitnessIndex, provider.ErrNoResponse)
+}
+
+func (e errNoResponse) Unwrap() error {
+	return provider.ErrNoResponse
+}
+
 var errNoDivergence = errors.New(
 	"sanity check failed: no divergence between the original trace and the provider's new trace",
 )
@@ -0,0 +1,260 @@
+// Package evm generates the calldata of the light client contracts verifying
+// the blocks of a chain on an EVM chain, e.g. the bridges, along with test
+// vectors, so that the tests of the contracts stay byte-compatible with the
+// node.
+//
+// The contracts verify the aggregated bn254 commits (see
+// types.AggregatedCommit) of chains using the fixed-width canonical encoding
+// (see types.CanonicalEncodingFixedWidth), either directly, with the pairing
+// precompile, or through a zk proof whose public inputs are PublicInputs.
+package evm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/types"
+)
+
+// FunctionSignature is the signature of the function of the contracts
+// verifying a commit, whose calldata is returned by Calldata.ABIEncode:
+//
+//	verifyCommit(
+//	    bytes header,             // Calldata.Header
+//	    bytes signers,            // Calldata.Signers
+//	    bytes timestamps,         // Calldata.Timestamps
+//	    uint256[4] signature,     // Calldata.Signature
+//	    uint256[] publicInputs    // Calldata.PublicInputs
+//	)
+const FunctionSignature = "verifyCommit(bytes,bytes,bytes,uint256[4],uint256[])"
+
+// The public inputs of the circuits verifying a commit, in order, each of
+// them a big-endian element of the scalar field of bn254.
+const (
+	// The MiMC commitment to the validator set which signed the block, see
+	// types.ValidatorSet.Commitment.
+	PublicInputValidatorsCommitment = iota
+	// The MiMC commitment to the validator set of the next block.
+	PublicInputNextValidatorsCommitment
+	// The high and low 128 bits of the hash of the block.
+	PublicInputBlockHashHi
+	PublicInputBlockHashLo
+	// The height of the block.
+	PublicInputHeight
+	// The voting power of the signers.
+	PublicInputSignedVotingPower
+
+	numPublicInputs
+)
+
+// wordSize is the size of the words of the ABI encoding.
+const wordSize = 32
+
+// Calldata is what a light client contract is given to verify a block, along
+// with what it is expected to compute from it, as a test vector.
+type Calldata struct {
+	ChainID   string            `json:"chain_id"`
+	Height    int64             `json:"height"`
+	BlockHash cmtbytes.HexBytes `json:"block_hash"`
+	// Header is the fixed-width encoding of the header, whose SHA-256 hash is
+	// the hash of the block.
+	Header cmtbytes.HexBytes `json:"header"`
+	// Signers is the bitmap of the signers, in the order of the validator
+	// set: validator i signed iff bit i%8 (least significant first) of byte
+	// i/8 is set.
+	Signers cmtbytes.HexBytes `json:"signers"`
+	// Timestamps are the fixed-width timestamps of the votes of the signers
+	// (int64 seconds, uint32 nanoseconds), concatenated in the same order,
+	// i.e. the suffixes of their sign bytes.
+	Timestamps cmtbytes.HexBytes `json:"timestamps"`
+	// Signature is the uncompressed aggregated signature, i.e. the
+	// coordinates X.A1, X.A0, Y.A1, Y.A0 of the G2 point, which is the order
+	// of the pairing precompile.
+	Signature cmtbytes.HexBytes `json:"signature"`
+	// Votes are the votes of the signers, in the order of the validator set.
+	Votes []Vote `json:"votes"`
+
+	SignedVotingPower int64 `json:"signed_voting_power"`
+	TotalVotingPower  int64 `json:"total_voting_power"`
+	// ValidatorsCommitment and NextValidatorsCommitment are the MiMC
+	// commitments to the validator sets of the block and of the next one.
+	ValidatorsCommitment     cmtbytes.HexBytes `json:"validators_commitment"`
+	NextValidatorsCommitment cmtbytes.HexBytes `json:"next_validators_commitment"`
+	// PublicInputs are the public inputs of the circuits verifying the commit,
+	// indexed by the PublicInput* constants.
+	PublicInputs []cmtbytes.HexBytes `json:"public_inputs"`
+}
+
+// Vote is the vote of a signer, which the contracts don't get but compute,
+// for their tests.
+type Vote struct {
+	ValidatorIndex int   `json:"validator_index"`
+	VotingPower    int64 `json:"voting_power"`
+	// PubKey is the uncompressed public key of the signer, i.e. the
+	// coordinates X, Y of the G1 point.
+	PubKey    cmtbytes.HexBytes `json:"pub_key"`
+	Timestamp time.Time         `json:"timestamp"`
+	// SignBytes is the message signed, and HashedMessage the uncompressed G2
+	// point it is hashed to, with Nonce the nonce of the hashing (see
+	// bn254.HashToCurve).
+	SignBytes     cmtbytes.HexBytes `json:"sign_bytes"`
+	HashedMessage cmtbytes.HexBytes `json:"hashed_message"`
+	Nonce         uint32            `json:"nonce"`
+}
+
+// NewCalldata returns the calldata verifying the block of the given header,
+// signed by vals, whose next validator set is nextVals. The aggregated commit
+// is verified first, so that the calldata is valid.
+//
+// The chain must use the fixed-width canonical encoding, the only one the
+// contracts support: the header, validator sets and votes are hashed and
+// verified with it.
+func NewCalldata(chainID string, header *types.Header, ac *types.AggregatedCommit,
+	vals, nextVals *types.ValidatorSet) (*Calldata, error) {
+	const encoding = types.CanonicalEncodingFixedWidth
+	if vals == nil || nextVals == nil {
+		return nil, errors.New("nil validator set")
+	}
+	ash := types.AggregatedSignedHeader{Header: header, Commit: ac}
+	if err := ash.ValidateBasic(chainID, encoding); err != nil {
+		return nil, err
+	}
+	if hash := vals.Hash(encoding); !bytes.Equal(header.ValidatorsHash, hash) {
+		return nil, fmt.Errorf("expected validators hash %X, got %X", header.ValidatorsHash, hash)
+	}
+	if hash := nextVals.Hash(encoding); !bytes.Equal(header.NextValidatorsHash, hash) {
+		return nil, fmt.Errorf("expected next validators hash %X, got %X", header.NextValidatorsHash, hash)
+	}
+	if err := types.VerifyAggregatedCommit(chainID, encoding, vals, ac.BlockID, header.Height, ac); err != nil {
+		return nil, fmt.Errorf("invalid aggregated commit: %w", err)
+	}
+
+	headerBytes, err := header.FixedWidthBytes()
+	if err != nil {
+		return nil, err
+	}
+	valsCommitment, err := vals.Commitment()
+	if err != nil {
+		return nil, err
+	}
+	nextValsCommitment, err := nextVals.Commitment()
+	if err != nil {
+		return nil, err
+	}
+
+	cd := &Calldata{
+		ChainID:                  chainID,
+		Height:                   header.Height,
+		BlockHash:                ac.BlockID.Hash,
+		Header:                   headerBytes,
+		Signers:                  ac.Signers,
+		Signature:                ac.Signature,
+		TotalVotingPower:         vals.TotalVotingPower(),
+		ValidatorsCommitment:     valsCommitment,
+		NextValidatorsCommitment: nextValsCommitment,
+	}
+	for idx, val := range vals.Validators {
+		if !ac.HasSigner(idx) {
+			continue
+		}
+		// the keys were checked by VerifyAggregatedCommit
+		x, y, err := val.PubKey.(bn254.PubKey).Coordinates()
+		if err != nil {
+			return nil, fmt.Errorf("validator #%d has an invalid key: %w", idx, err)
+		}
+		timestamp := ac.Timestamps[len(cd.Votes)]
+		signBytes := ac.VoteSignBytes(chainID, encoding, timestamp)
+		hashed, nonce := bn254.HashToCurve(signBytes)
+		hashedBytes := hashed.RawBytes()
+
+		cd.Timestamps = binary.BigEndian.AppendUint64(cd.Timestamps, uint64(timestamp.Unix()))
+		cd.Timestamps = binary.BigEndian.AppendUint32(cd.Timestamps, uint32(timestamp.Nanosecond()))
+		cd.SignedVotingPower += val.VotingPower
+		cd.Votes = append(cd.Votes, Vote{
+			ValidatorIndex: idx,
+			VotingPower:    val.VotingPower,
+			PubKey:         append(x[:], y[:]...),
+			Timestamp:      timestamp,
+			SignBytes:      signBytes,
+			HashedMessage:  hashedBytes[:],
+			Nonce:          nonce,
+		})
+	}
+
+	cd.PublicInputs = make([]cmtbytes.HexBytes, numPublicInputs)
+	cd.PublicInputs[PublicInputValidatorsCommitment] = valsCommitment
+	cd.PublicInputs[PublicInputNextValidatorsCommitment] = nextValsCommitment
+	cd.PublicInputs[PublicInputBlockHashHi] = leftPad(cd.BlockHash[:len(cd.BlockHash)/2])
+	cd.PublicInputs[PublicInputBlockHashLo] = leftPad(cd.BlockHash[len(cd.BlockHash)/2:])
+	cd.PublicInputs[PublicInputHeight] = uint64Word(uint64(cd.Height))
+	cd.PublicInputs[PublicInputSignedVotingPower] = uint64Word(uint64(cd.SignedVotingPower))
+	for i, input := range cd.PublicInputs {
+		var elem fr.Element
+		if err := elem.SetBytesCanonical(input); err != nil {
+			return nil, fmt.Errorf("public input #%d is not a field element: %w", i, err)
+		}
+	}
+	return cd, nil
+}
+
+// ABIEncode returns the calldata of the call to FunctionSignature, i.e. its
+// selector followed by the ABI encoding of its arguments.
+func (cd *Calldata) ABIEncode() ([]byte, error) {
+	if len(cd.Signature) != 4*wordSize {
+		return nil, fmt.Errorf("expected a %d bytes signature, got %d", 4*wordSize, len(cd.Signature))
+	}
+
+	// the head holds the offsets of the dynamic arguments, and the static
+	// ones, the tail the dynamic arguments
+	var head, tail []byte
+	const headSize = (3 + 4 + 1) * wordSize
+	appendBytes := func(bz []byte) {
+		head = append(head, uint64Word(uint64(headSize+len(tail)))...)
+		tail = append(tail, uint64Word(uint64(len(bz)))...)
+		tail = append(tail, bz...)
+		if rem := len(bz) % wordSize; rem != 0 {
+			tail = append(tail, make([]byte, wordSize-rem)...)
+		}
+	}
+	appendBytes(cd.Header)
+	appendBytes(cd.Signers)
+	appendBytes(cd.Timestamps)
+	head = append(head, cd.Signature...)
+	head = append(head, uint64Word(uint64(headSize+len(tail)))...)
+	tail = append(tail, uint64Word(uint64(len(cd.PublicInputs)))...)
+	for i, input := range cd.PublicInputs {
+		if len(input) != wordSize {
+			return nil, fmt.Errorf("expected a %d bytes public input #%d, got %d", wordSize, i, len(input))
+		}
+		tail = append(tail, input...)
+	}
+
+	return append(append(Selector(), head...), tail...), nil
+}
+
+// Selector returns the selector of FunctionSignature, i.e. the first 4 bytes
+// of its Keccak-256 hash.
+func Selector() []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(FunctionSignature))
+	return h.Sum(nil)[:4]
+}
+
+// leftPad returns bz as a (big-endian) word.
+func leftPad(bz []byte) []byte {
+	word := make([]byte, wordSize)
+	copy(word[wordSize-len(bz):], bz)
+	return word
+}
+
+func uint64Word(v uint64) []byte {
+	return leftPad(binary.BigEndian.AppendUint64(nil, v))
+}
@@ -0,0 +1,200 @@
+package evm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	gnark "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	"github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+const chainID = "evm-chain"
+
+// the chains the calldata is made for use the fixed-width encoding
+const encoding = types.CanonicalEncodingFixedWidth
+
+// makeBlock returns the header of a block, hashed and signed by vals with the
+// given encoding, except for the validators in absent, whose next validator
+// set is nextVals.
+func makeBlock(t *testing.T, encoding types.CanonicalEncoding, vals, nextVals *types.ValidatorSet,
+	privVals map[string]types.PrivValidator, absent map[int]bool) (*types.Header, *types.AggregatedCommit) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	header := &types.Header{
+		Version:            cmtversion.Consensus{Block: version.BlockProtocol, App: 1},
+		ChainID:            chainID,
+		Height:             100,
+		Time:               now,
+		LastBlockID:        types.BlockID{Hash: tmhash.Sum([]byte("last")), PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}},
+		LastCommitHash:     tmhash.Sum([]byte("last_commit")),
+		DataHash:           tmhash.Sum([]byte("data")),
+		ValidatorsHash:     vals.Hash(encoding),
+		NextValidatorsHash: nextVals.Hash(encoding),
+		ConsensusHash:      tmhash.Sum([]byte("consensus")),
+		AppHash:            []byte("app"),
+		LastResultsHash:    tmhash.Sum([]byte("last_results")),
+		EvidenceHash:       tmhash.Sum([]byte("evidence")),
+		ProposerAddress:    vals.Validators[0].Address,
+	}
+	blockID := types.BlockID{Hash: header.Hash(encoding), PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("block_parts"))}}
+
+	sigs := make([]types.CommitSig, vals.Size())
+	for i, val := range vals.Validators {
+		if absent[i] {
+			sigs[i] = types.NewCommitSigAbsent()
+			continue
+		}
+		vote := &types.Vote{
+			ValidatorAddress: val.Address,
+			ValidatorIndex:   int32(i),
+			Height:           header.Height,
+			Type:             cmtproto.PrecommitType,
+			BlockID:          blockID,
+			Timestamp:        now.Add(time.Duration(i) * time.Millisecond),
+		}
+		v := vote.ToProto()
+		require.NoError(t, privVals[val.Address.String()].SignVote(chainID, encoding, v))
+		vote.Signature = v.Signature
+		sigs[i] = vote.CommitSig()
+	}
+	ac, err := types.NewCommit(header.Height, 0, blockID, sigs).Aggregate()
+	require.NoError(t, err)
+	return header, ac
+}
+
+func makeValidators(t *testing.T, n int) (*types.ValidatorSet, map[string]types.PrivValidator) {
+	vals := make([]*types.Validator, n)
+	privVals := make(map[string]types.PrivValidator, n)
+	for i := range vals {
+		privKey := bn254.GenPrivKeyFromSecret([]byte(fmt.Sprintf("evm/%d", i)))
+		vals[i] = types.NewValidator(privKey.PubKey(), int64(10+i))
+		privVals[vals[i].Address.String()] = types.NewMockPVWithParams(privKey, false, false)
+	}
+	return types.NewValidatorSet(vals), privVals
+}
+
+func TestNewCalldata(t *testing.T) {
+	vals, privVals := makeValidators(t, 10)
+	nextVals, _ := makeValidators(t, 9)
+	header, ac := makeBlock(t, encoding, vals, nextVals, privVals, map[int]bool{1: true, 8: true})
+
+	cd, err := NewCalldata(chainID, header, ac, vals, nextVals)
+	require.NoError(t, err)
+
+	headerBytes, err := header.FixedWidthBytes()
+	require.NoError(t, err)
+	assert.EqualValues(t, headerBytes, cd.Header)
+	assert.EqualValues(t, header.Hash(encoding), cd.BlockHash)
+	assert.EqualValues(t, ac.Signers, cd.Signers)
+	require.Len(t, cd.Votes, 8)
+	assert.Len(t, cd.Timestamps, 8*12)
+
+	valsCommitment, err := vals.Commitment()
+	require.NoError(t, err)
+	nextValsCommitment, err := nextVals.Commitment()
+	require.NoError(t, err)
+	assert.EqualValues(t, valsCommitment, cd.ValidatorsCommitment)
+	assert.EqualValues(t, nextValsCommitment, cd.NextValidatorsCommitment)
+	assert.Equal(t, vals.TotalVotingPower(), cd.TotalVotingPower)
+	assert.Equal(t, vals.TotalVotingPower()-vals.Validators[1].VotingPower-vals.Validators[8].VotingPower,
+		cd.SignedVotingPower)
+
+	require.Len(t, cd.PublicInputs, numPublicInputs)
+	assert.EqualValues(t, valsCommitment, cd.PublicInputs[PublicInputValidatorsCommitment])
+	assert.EqualValues(t, nextValsCommitment, cd.PublicInputs[PublicInputNextValidatorsCommitment])
+	assert.EqualValues(t, append(make([]byte, 16), cd.BlockHash[:16]...), cd.PublicInputs[PublicInputBlockHashHi])
+	assert.EqualValues(t, append(make([]byte, 16), cd.BlockHash[16:]...), cd.PublicInputs[PublicInputBlockHashLo])
+	assert.EqualValues(t, 100, binary.BigEndian.Uint64(cd.PublicInputs[PublicInputHeight][24:]))
+	assert.EqualValues(t, cd.SignedVotingPower,
+		binary.BigEndian.Uint64(cd.PublicInputs[PublicInputSignedVotingPower][24:]))
+
+	// the contracts check the signature as the pairing precompile would:
+	// e(-G1, signature) * e(pk_0, H(m_0)) * ... * e(pk_n, H(m_n)) == 1
+	_, _, g1, _ := gnark.Generators()
+	var sig gnark.G2Affine
+	_, err = sig.SetBytes(cd.Signature)
+	require.NoError(t, err)
+	ps := []gnark.G1Affine{*new(gnark.G1Affine).Neg(&g1)}
+	qs := []gnark.G2Affine{sig}
+	for i, vote := range cd.Votes {
+		assert.Equal(t, cd.Timestamps[i*12:(i+1)*12], vote.SignBytes[len(vote.SignBytes)-12:])
+		var pk gnark.G1Affine
+		_, err := pk.SetBytes(vote.PubKey)
+		require.NoError(t, err)
+		var hashed gnark.G2Affine
+		_, err = hashed.SetBytes(vote.HashedMessage)
+		require.NoError(t, err)
+		ps = append(ps, pk)
+		qs = append(qs, hashed)
+	}
+	ok, err := gnark.PairingCheck(ps, qs)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestNewCalldataInvalid(t *testing.T) {
+	vals, privVals := makeValidators(t, 4)
+	header, ac := makeBlock(t, types.CanonicalEncodingProto, vals, vals, privVals, nil)
+
+	// proto encoding
+	_, err := NewCalldata(chainID, header, ac, vals, vals)
+	assert.Error(t, err)
+
+	header, ac = makeBlock(t, encoding, vals, vals, privVals, nil)
+	_, err = NewCalldata(chainID, header, ac, vals, vals)
+	require.NoError(t, err)
+
+	otherVals, _ := makeValidators(t, 3)
+	_, err = NewCalldata(chainID, header, ac, vals, otherVals)
+	assert.Error(t, err)
+	_, err = NewCalldata("other-chain", header, ac, vals, vals)
+	assert.Error(t, err)
+
+	// not enough voting power
+	header, ac = makeBlock(t, encoding, vals, vals, privVals, map[int]bool{0: true, 1: true})
+	_, err = NewCalldata(chainID, header, ac, vals, vals)
+	assert.Error(t, err)
+}
+
+func TestABIEncode(t *testing.T) {
+	vals, privVals := makeValidators(t, 4)
+	header, ac := makeBlock(t, encoding, vals, vals, privVals, nil)
+	cd, err := NewCalldata(chainID, header, ac, vals, vals)
+	require.NoError(t, err)
+
+	bz, err := cd.ABIEncode()
+	require.NoError(t, err)
+
+	// keccak256("verifyCommit(bytes,bytes,bytes,uint256[4],uint256[])")[:4]
+	assert.Equal(t, Selector(), bz[:4])
+	args := bz[4:]
+	word := func(i int) []byte { return args[i*32 : (i+1)*32] }
+	uint64At := func(offset int) int { return int(binary.BigEndian.Uint64(args[offset+24 : offset+32])) }
+	bytesAt := func(i int) []byte {
+		offset := uint64At(i * 32)
+		return args[offset+32 : offset+32+uint64At(offset)]
+	}
+
+	assert.EqualValues(t, cd.Header, bytesAt(0))
+	assert.EqualValues(t, cd.Signers, bytesAt(1))
+	assert.EqualValues(t, cd.Timestamps, bytesAt(2))
+	for i := 0; i < 4; i++ {
+		assert.EqualValues(t, cd.Signature[i*32:(i+1)*32], word(3+i))
+	}
+	offset := uint64At(7 * 32)
+	require.Equal(t, numPublicInputs, uint64At(offset))
+	for i, input := range cd.PublicInputs {
+		assert.EqualValues(t, input, args[offset+32*(i+1):offset+32*(i+2)])
+	}
+	assert.Len(t, args, offset+32*(numPublicInputs+1))
+	assert.Zero(t, len(args)%32)
+}
@@ -0,0 +1,67 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	"github.com/cometbft/cometbft/types"
+)
+
+// validatorsPerPage is the number of validators fetched per request.
+const validatorsPerPage = 100
+
+// Fetch fetches the block at the given height (the latest one if zero), its
+// aggregated commit and the validator sets from a node, and returns the
+// calldata verifying it. See NewCalldata.
+func Fetch(ctx context.Context, client rpcclient.SignClient, height int64) (*Calldata, error) {
+	var heightPtr *int64
+	if height > 0 {
+		heightPtr = &height
+	}
+	res, err := client.CommitAggregated(ctx, heightPtr)
+	if err != nil {
+		return nil, fmt.Errorf("fetching the aggregated commit: %w", err)
+	}
+	if res.Header == nil {
+		return nil, fmt.Errorf("no header at height %d", height)
+	}
+	vals, err := types.ValidatorSetFromExistingValidators(res.Validators)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validators of height %d: %w", res.Header.Height, err)
+	}
+	nextVals, err := fetchValidators(ctx, client, res.Header.Height+1)
+	if err != nil {
+		return nil, err
+	}
+	return NewCalldata(res.Header.ChainID, res.Header, res.Commit, vals, nextVals)
+}
+
+// fetchValidators fetches all the pages of the validator set at the given
+// height.
+func fetchValidators(ctx context.Context, client rpcclient.SignClient, height int64) (*types.ValidatorSet, error) {
+	var (
+		vals    []*types.Validator
+		page    = 1
+		perPage = validatorsPerPage
+	)
+	for {
+		res, err := client.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf("fetching the validators of height %d: %w", height, err)
+		}
+		if len(res.Validators) == 0 {
+			return nil, fmt.Errorf("page %d of the validators of height %d is empty", page, height)
+		}
+		vals = append(vals, res.Validators...)
+		if len(vals) >= res.Total {
+			break
+		}
+		page++
+	}
+	valSet, err := types.ValidatorSetFromExistingValidators(vals)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validators of height %d: %w", height, err)
+	}
+	return valSet, nil
+}
@@ -17,6 +17,7 @@ import (
 	httpp "github.com/cometbft/cometbft/light/provider/http"
 	dbs "github.com/cometbft/cometbft/light/store/db"
 	rpctest "github.com/cometbft/cometbft/rpc/test"
+	"github.com/cometbft/cometbft/types"
 )
 
 // Automatically getting new headers and verifying them.
@@ -32,7 +33,7 @@ func ExampleClient_Update() {
 
 	config := rpctest.GetConfig()
 
-	primary, err := httpp.New(chainID, config.RPC.ListenAddress)
+	primary, err := httpp.New(chainID, types.CanonicalEncodingProto, config.RPC.ListenAddress)
 	if err != nil {
 		stdlog.Fatal(err)
 	}
@@ -50,10 +51,11 @@ func ExampleClient_Update() {
 	c, err := light.NewClient(
 		context.Background(),
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 504 * time.Hour, // 21 days
 			Height: 2,
-			Hash:   block.Hash(),
+			Hash:   block.Hash(types.CanonicalEncodingProto),
 		},
 		primary,
 		[]provider.Provider{primary}, // NOTE: primary should not be used here
@@ -97,7 +99,7 @@ func ExampleClient_VerifyLightBlockAtHeight() {
 
 	config := rpctest.GetConfig()
 
-	primary, err := httpp.New(chainID, config.RPC.ListenAddress)
+	primary, err := httpp.New(chainID, types.CanonicalEncodingProto, config.RPC.ListenAddress)
 	if err != nil {
 		stdlog.Fatal(err)
 	}
@@ -115,10 +117,11 @@ func ExampleClient_VerifyLightBlockAtHeight() {
 	c, err := light.NewClient(
 		context.Background(),
 		chainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: 504 * time.Hour, // 21 days
 			Height: 2,
-			Hash:   block.Hash(),
+			Hash:   block.Hash(types.CanonicalEncodingProto),
 		},
 		primary,
 		[]provider.Provider{primary}, // NOTE: primary should not be used here
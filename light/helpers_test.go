@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -32,6 +33,16 @@ func genPrivKeys(n int) privKeys {
 }
 
 // // Change replaces the key at index i.
+// genBn254PrivKeys produces an array of bn254 private keys, whose commits can
+// be aggregated.
+func genBn254PrivKeys(n int) privKeys {
+	res := make(privKeys, n)
+	for i := range res {
+		res[i] = bn254.GenPrivKey()
+	}
+	return res
+}
+
 // func (pkz privKeys) Change(i int) privKeys {
 // 	res := make(privKeys, len(pkz))
 // 	copy(res, pkz)
@@ -80,7 +91,7 @@ func (pkz privKeys) signHeader(header *types.Header, valSet *types.ValidatorSet,
 	}
 
 	blockID := types.BlockID{
-		Hash:          header.Hash(),
+		Hash:          header.Hash(types.CanonicalEncodingProto),
 		PartSetHeader: types.PartSetHeader{Total: 1, Hash: crypto.CRandBytes(32)},
 	}
 
@@ -110,7 +121,7 @@ func makeVote(header *types.Header, valset *types.ValidatorSet,
 
 	v := vote.ToProto()
 	// Sign it
-	signBytes := types.VoteSignBytes(header.ChainID, v)
+	signBytes := types.VoteSignBytes(header.ChainID, types.CanonicalEncodingProto, v)
 	sig, err := key.Sign(signBytes)
 	if err != nil {
 		panic(err)
@@ -131,8 +142,8 @@ func genHeader(chainID string, height int64, bTime time.Time, txs types.Txs,
 		Time:    bTime,
 		// LastBlockID
 		// LastCommitHash
-		ValidatorsHash:     valset.Hash(),
-		NextValidatorsHash: nextValset.Hash(),
+		ValidatorsHash:     valset.Hash(types.CanonicalEncodingProto),
+		NextValidatorsHash: nextValset.Hash(types.CanonicalEncodingProto),
 		DataHash:           txs.Hash(),
 		AppHash:            appHash,
 		ConsensusHash:      consHash,
@@ -216,7 +227,7 @@ func genMockNodeWithKeys(
 		currentHeader = keys.GenSignedHeaderLastBlockID(chainID, height, bTime.Add(time.Duration(height)*time.Minute),
 			nil,
 			keys.ToValidators(2, 0), newKeys.ToValidators(2, 0), hash("app_hash"), hash("cons_hash"),
-			hash("results_hash"), 0, len(keys), types.BlockID{Hash: lastHeader.Hash()})
+			hash("results_hash"), 0, len(keys), types.BlockID{Hash: lastHeader.Hash(types.CanonicalEncodingProto)})
 		headers[height] = currentHeader
 		valset[height] = keys.ToValidators(2, 0)
 		lastHeader = currentHeader
@@ -234,10 +245,11 @@ func genMockNode(
 	valVariation float32,
 	bTime time.Time) (
 	string,
+	types.CanonicalEncoding,
 	map[int64]*types.SignedHeader,
 	map[int64]*types.ValidatorSet) {
 	headers, valset, _ := genMockNodeWithKeys(chainID, blockSize, valSize, valVariation, bTime)
-	return chainID, headers, valset
+	return chainID, types.CanonicalEncodingProto, headers, valset
 }
 
 func hash(s string) []byte {
@@ -0,0 +1,44 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package light
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Witnesses: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "witnesses",
+			Help:      "Number of witnesses the primary is cross-checked with.",
+		}, labels).With(labelsAndValues...),
+		WitnessesRotated: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "witnesses_rotated",
+			Help:      "Number of witnesses replaced by a provider of the witness pool, by reason: offline or removed.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+		Divergences: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "divergences",
+			Help:      "Number of conflicting headers returned by witnesses, by outcome: attack, bad_witness or unverified, and of expired trusted headers, with the trust_expired outcome.",
+		}, append(labels, "outcome")).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Witnesses:        discard.NewGauge(),
+		WitnessesRotated: discard.NewCounter(),
+		Divergences:      discard.NewCounter(),
+	}
+}
@@ -0,0 +1,26 @@
+package light
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "light"
+)
+
+//go:generate go run ../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of witnesses the primary is cross-checked with.
+	Witnesses metrics.Gauge
+	// Number of witnesses replaced by a provider of the witness pool, by
+	// reason: offline or removed.
+	WitnessesRotated metrics.Counter `metrics_labels:"reason"`
+	// Number of conflicting headers returned by witnesses, by outcome:
+	// attack, bad_witness or unverified, and of expired trusted headers, with
+	// the trust_expired outcome.
+	Divergences metrics.Counter `metrics_labels:"outcome"`
+}
@@ -26,14 +26,16 @@ var (
 
 // http provider uses an RPC client to obtain the necessary information.
 type http struct {
-	chainID string
-	client  rpcclient.RemoteClient
+	chainID  string
+	encoding types.CanonicalEncoding
+	client   rpcclient.RemoteClient
 }
 
 // New creates a HTTP provider, which is using the rpchttp.HTTP client under
 // the hood. If no scheme is provided in the remote URL, http will be used by
-// default. The 5s timeout is used for all requests.
-func New(chainID, remote string) (provider.Provider, error) {
+// default. The 5s timeout is used for all requests. The light blocks are
+// checked to hash with the canonical encoding of the chain.
+func New(chainID string, encoding types.CanonicalEncoding, remote string) (provider.Provider, error) {
 	// Ensure URL scheme is set (default HTTP) when not provided.
 	if !strings.Contains(remote, "://") {
 		remote = "http://" + remote
@@ -44,14 +46,15 @@ func New(chainID, remote string) (provider.Provider, error) {
 		return nil, err
 	}
 
-	return NewWithClient(chainID, httpClient), nil
+	return NewWithClient(chainID, encoding, httpClient), nil
 }
 
 // NewWithClient allows you to provide a custom client.
-func NewWithClient(chainID string, client rpcclient.RemoteClient) provider.Provider {
+func NewWithClient(chainID string, encoding types.CanonicalEncoding, client rpcclient.RemoteClient) provider.Provider {
 	return &http{
-		client:  client,
-		chainID: chainID,
+		client:   client,
+		chainID:  chainID,
+		encoding: encoding,
 	}
 }
 
@@ -65,7 +68,7 @@ func (p *http) String() string {
 }
 
 // LightBlock fetches a LightBlock at the given height and checks the
-// chainID matches.
+// chainID matches and the header hashes to the block ID of the commit.
 func (p *http) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
 	h, err := validateHeight(height)
 	if err != nil {
@@ -93,7 +96,7 @@ func (p *http) LightBlock(ctx context.Context, height int64) (*types.LightBlock,
 		ValidatorSet: vs,
 	}
 
-	err = lb.ValidateBasic(p.chainID)
+	err = lb.ValidateBasic(p.chainID, p.encoding)
 	if err != nil {
 		return nil, provider.ErrBadLightBlock{Reason: err}
 	}
@@ -20,15 +20,15 @@ import (
 )
 
 func TestNewProvider(t *testing.T) {
-	c, err := lighthttp.New("chain-test", "192.168.0.1:26657")
+	c, err := lighthttp.New("chain-test", types.CanonicalEncodingProto, "192.168.0.1:26657")
 	require.NoError(t, err)
 	require.Equal(t, fmt.Sprintf("%s", c), "http{http://192.168.0.1:26657}")
 
-	c, err = lighthttp.New("chain-test", "http://153.200.0.1:26657")
+	c, err = lighthttp.New("chain-test", types.CanonicalEncodingProto, "http://153.200.0.1:26657")
 	require.NoError(t, err)
 	require.Equal(t, fmt.Sprintf("%s", c), "http{http://153.200.0.1:26657}")
 
-	c, err = lighthttp.New("chain-test", "153.200.0.1")
+	c, err = lighthttp.New("chain-test", types.CanonicalEncodingProto, "153.200.0.1")
 	require.NoError(t, err)
 	require.Equal(t, fmt.Sprintf("%s", c), "http{http://153.200.0.1}")
 }
@@ -48,7 +48,7 @@ func TestProvider(t *testing.T) {
 	c, err := rpchttp.New(rpcAddr, "/websocket")
 	require.Nil(t, err)
 
-	p := lighthttp.NewWithClient(chainID, c)
+	p := lighthttp.NewWithClient(chainID, types.CanonicalEncodingProto, c)
 	require.NoError(t, err)
 	require.NotNil(t, p)
 
@@ -63,7 +63,7 @@ func TestProvider(t *testing.T) {
 	assert.True(t, lb.Height < 1000)
 
 	// let's check this is valid somehow
-	assert.Nil(t, lb.ValidateBasic(chainID))
+	assert.Nil(t, lb.ValidateBasic(chainID, types.CanonicalEncodingProto))
 
 	// historical queries now work :)
 	lower := lb.Height - 3
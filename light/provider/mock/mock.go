@@ -13,7 +13,8 @@ import (
 )
 
 type Mock struct {
-	chainID string
+	chainID  string
+	encoding types.CanonicalEncoding
 
 	mtx              sync.Mutex
 	headers          map[int64]*types.SignedHeader
@@ -25,8 +26,13 @@ type Mock struct {
 var _ provider.Provider = (*Mock)(nil)
 
 // New creates a mock provider with the given set of headers and validator
-// sets.
-func New(chainID string, headers map[int64]*types.SignedHeader, vals map[int64]*types.ValidatorSet) *Mock {
+// sets, hashed with the given encoding.
+func New(
+	chainID string,
+	encoding types.CanonicalEncoding,
+	headers map[int64]*types.SignedHeader,
+	vals map[int64]*types.ValidatorSet,
+) *Mock {
 	height := int64(0)
 	for h := range headers {
 		if h > height {
@@ -35,6 +41,7 @@ func New(chainID string, headers map[int64]*types.SignedHeader, vals map[int64]*
 	}
 	return &Mock{
 		chainID:          chainID,
+		encoding:         encoding,
 		headers:          headers,
 		vals:             vals,
 		evidenceToReport: make(map[string]types.Evidence),
@@ -50,12 +57,12 @@ func (p *Mock) ChainID() string {
 func (p *Mock) String() string {
 	var headers strings.Builder
 	for _, h := range p.headers {
-		fmt.Fprintf(&headers, " %d:%X", h.Height, h.Hash())
+		fmt.Fprintf(&headers, " %d:%X", h.Height, h.Hash(p.encoding))
 	}
 
 	var vals strings.Builder
 	for _, v := range p.vals {
-		fmt.Fprintf(&vals, " %X", v.Hash())
+		fmt.Fprintf(&vals, " %X", v.Hash(p.encoding))
 	}
 
 	return fmt.Sprintf("Mock{headers: %s, vals: %v}", headers.String(), vals.String())
@@ -96,7 +103,7 @@ func (p *Mock) LightBlock(ctx context.Context, height int64) (*types.LightBlock,
 	if lb.SignedHeader == nil || lb.ValidatorSet == nil {
 		return nil, provider.ErrBadLightBlock{Reason: errors.New("nil header or vals")}
 	}
-	if err := lb.ValidateBasic(lb.ChainID); err != nil {
+	if err := lb.ValidateBasic(lb.ChainID, p.encoding); err != nil {
 		return nil, provider.ErrBadLightBlock{Reason: err}
 	}
 	return lb, nil
@@ -116,7 +123,7 @@ func (p *Mock) AddLightBlock(lb *types.LightBlock) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
-	if err := lb.ValidateBasic(lb.ChainID); err != nil {
+	if err := lb.ValidateBasic(lb.ChainID, p.encoding); err != nil {
 		panic(fmt.Sprintf("unable to add light block, err: %v", err))
 	}
 	p.headers[lb.Height] = lb.SignedHeader
@@ -127,5 +134,5 @@ func (p *Mock) AddLightBlock(lb *types.LightBlock) {
 }
 
 func (p *Mock) Copy(id string) *Mock {
-	return New(id, p.headers, p.vals)
+	return New(id, p.encoding, p.headers, p.vals)
 }
@@ -18,27 +18,30 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"unsubscribe_all": rpcserver.NewWSRPCFunc(c.UnsubscribeAllWS, ""),
 
 		// info API
-		"health":               rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
-		"status":               rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
-		"net_info":             rpcserver.NewRPCFunc(makeNetInfoFunc(c), ""),
-		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", rpcserver.Cacheable()),
-		"genesis":              rpcserver.NewRPCFunc(makeGenesisFunc(c), "", rpcserver.Cacheable()),
-		"genesis_chunked":      rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", rpcserver.Cacheable()),
-		"block":                rpcserver.NewRPCFunc(makeBlockFunc(c), "height", rpcserver.Cacheable("height")),
-		"header":               rpcserver.NewRPCFunc(makeHeaderFunc(c), "height", rpcserver.Cacheable("height")),
-		"header_by_hash":       rpcserver.NewRPCFunc(makeHeaderByHashFunc(c), "hash", rpcserver.Cacheable()),
-		"block_by_hash":        rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", rpcserver.Cacheable()),
-		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", rpcserver.Cacheable("height")),
-		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height", rpcserver.Cacheable("height")),
-		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", rpcserver.Cacheable()),
-		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
-		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by"),
-		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", rpcserver.Cacheable("height")),
-		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
-		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
-		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", rpcserver.Cacheable("height")),
-		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
-		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
+		"health":                rpcserver.NewRPCFunc(makeHealthFunc(c), "", rpcserver.ConcurrencySafe()),
+		"status":                rpcserver.NewRPCFunc(makeStatusFunc(c), "", rpcserver.ConcurrencySafe()),
+		"net_info":              rpcserver.NewRPCFunc(makeNetInfoFunc(c), "", rpcserver.ConcurrencySafe()),
+		"blockchain":            rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", rpcserver.Cacheable(), rpcserver.ConcurrencySafe()),
+		"genesis":               rpcserver.NewRPCFunc(makeGenesisFunc(c), "", rpcserver.Cacheable(), rpcserver.ConcurrencySafe()),
+		"genesis_chunked":       rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", rpcserver.Cacheable(), rpcserver.ConcurrencySafe()),
+		"block":                 rpcserver.NewRPCFunc(makeBlockFunc(c), "height", rpcserver.Cacheable("height"), rpcserver.ConcurrencySafe()),
+		"header":                rpcserver.NewRPCFunc(makeHeaderFunc(c), "height,with_commit", rpcserver.Cacheable("height"), rpcserver.ConcurrencySafe()),
+		"header_by_hash":        rpcserver.NewRPCFunc(makeHeaderByHashFunc(c), "hash,with_commit", rpcserver.Cacheable(), rpcserver.ConcurrencySafe()),
+		"block_by_hash":         rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", rpcserver.Cacheable(), rpcserver.ConcurrencySafe()),
+		"block_results":         rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", rpcserver.Cacheable("height"), rpcserver.ConcurrencySafe()),
+		"block_events":          rpcserver.NewRPCFunc(makeBlockEventsFunc(c), "height", rpcserver.Cacheable("height"), rpcserver.ConcurrencySafe()),
+		"commit":                rpcserver.NewRPCFunc(makeCommitFunc(c), "height", rpcserver.Cacheable("height"), rpcserver.ConcurrencySafe()),
+		"commit_aggregated":     rpcserver.NewRPCFunc(makeCommitAggregatedFunc(c), "height", rpcserver.Cacheable("height"), rpcserver.ConcurrencySafe()),
+		"tx":                    rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", rpcserver.Cacheable(), rpcserver.ConcurrencySafe()),
+		"tx_search":             rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by", rpcserver.ConcurrencySafe()),
+		"block_search":          rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by", rpcserver.ConcurrencySafe()),
+		"validators":            rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", rpcserver.Cacheable("height"), rpcserver.ConcurrencySafe()),
+		"validators_commitment": rpcserver.NewRPCFunc(makeValidatorsCommitmentFunc(c), "height", rpcserver.Cacheable("height"), rpcserver.ConcurrencySafe()),
+		"dump_consensus_state":  rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), "", rpcserver.ConcurrencySafe()),
+		"consensus_state":       rpcserver.NewRPCFunc(makeConsensusStateFunc(c), "", rpcserver.ConcurrencySafe()),
+		"consensus_params":      rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", rpcserver.Cacheable("height"), rpcserver.ConcurrencySafe()),
+		"unconfirmed_txs":       rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit", rpcserver.ConcurrencySafe()),
+		"num_unconfirmed_txs":   rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), "", rpcserver.ConcurrencySafe()),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx"),
@@ -46,8 +49,8 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"broadcast_tx_async":  rpcserver.NewRPCFunc(makeBroadcastTxAsyncFunc(c), "tx"),
 
 		// abci API
-		"abci_query": rpcserver.NewRPCFunc(makeABCIQueryFunc(c), "path,data,height,prove"),
-		"abci_info":  rpcserver.NewRPCFunc(makeABCIInfoFunc(c), "", rpcserver.Cacheable()),
+		"abci_query": rpcserver.NewRPCFunc(makeABCIQueryFunc(c), "path,data,height,prove", rpcserver.ConcurrencySafe()),
+		"abci_info":  rpcserver.NewRPCFunc(makeABCIInfoFunc(c), "", rpcserver.Cacheable(), rpcserver.ConcurrencySafe()),
 
 		// evidence API
 		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence"),
@@ -110,22 +113,41 @@ func makeBlockFunc(c *lrpc.Client) rpcBlockFunc {
 	}
 }
 
-type rpcHeaderFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultHeader, error)
+type rpcHeaderFunc func(ctx *rpctypes.Context, height *int64, withCommit bool) (*ctypes.ResultHeader, error)
 
 func makeHeaderFunc(c *lrpc.Client) rpcHeaderFunc {
-	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultHeader, error) {
-		return c.Header(ctx.Context(), height)
+	return func(ctx *rpctypes.Context, height *int64, withCommit bool) (*ctypes.ResultHeader, error) {
+		res, err := c.Header(ctx.Context(), height)
+		if err != nil || !withCommit {
+			return res, err
+		}
+		return withVerifiedCommit(ctx, c, res)
 	}
 }
 
-type rpcHeaderByHashFunc func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultHeader, error)
+type rpcHeaderByHashFunc func(ctx *rpctypes.Context, hash []byte, withCommit bool) (*ctypes.ResultHeader, error)
 
 func makeHeaderByHashFunc(c *lrpc.Client) rpcHeaderByHashFunc {
-	return func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultHeader, error) {
-		return c.HeaderByHash(ctx.Context(), hash)
+	return func(ctx *rpctypes.Context, hash []byte, withCommit bool) (*ctypes.ResultHeader, error) {
+		res, err := c.HeaderByHash(ctx.Context(), hash)
+		if err != nil || !withCommit || res.Header == nil {
+			return res, err
+		}
+		return withVerifiedCommit(ctx, c, res)
 	}
 }
 
+// withVerifiedCommit adds the commit of the trusted header to res.
+func withVerifiedCommit(ctx *rpctypes.Context, c *lrpc.Client, res *ctypes.ResultHeader) (*ctypes.ResultHeader, error) {
+	commit, err := c.Commit(ctx.Context(), &res.Header.Height)
+	if err != nil {
+		return nil, err
+	}
+	res.Commit = commit.Commit
+	res.CanonicalCommit = commit.CanonicalCommit
+	return res, nil
+}
+
 type rpcBlockByHashFunc func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultBlock, error)
 
 func makeBlockByHashFunc(c *lrpc.Client) rpcBlockByHashFunc {
@@ -142,6 +164,14 @@ func makeBlockResultsFunc(c *lrpc.Client) rpcBlockResultsFunc {
 	}
 }
 
+type rpcBlockEventsFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlockEvents, error)
+
+func makeBlockEventsFunc(c *lrpc.Client) rpcBlockEventsFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlockEvents, error) {
+		return c.BlockEvents(ctx.Context(), height)
+	}
+}
+
 type rpcCommitFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommit, error)
 
 func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
@@ -150,6 +180,14 @@ func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
 	}
 }
 
+type rpcCommitAggregatedFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommitAggregated, error)
+
+func makeCommitAggregatedFunc(c *lrpc.Client) rpcCommitAggregatedFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommitAggregated, error) {
+		return c.CommitAggregated(ctx.Context(), height)
+	}
+}
+
 type rpcTxFunc func(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
 func makeTxFunc(c *lrpc.Client) rpcTxFunc {
@@ -207,6 +245,14 @@ func makeValidatorsFunc(c *lrpc.Client) rpcValidatorsFunc {
 	}
 }
 
+type rpcValidatorsCommitmentFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultValidatorsCommitment, error)
+
+func makeValidatorsCommitmentFunc(c *lrpc.Client) rpcValidatorsCommitmentFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultValidatorsCommitment, error) {
+		return c.ValidatorsCommitment(ctx.Context(), height)
+	}
+}
+
 type rpcDumpConsensusStateFunc func(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState, error)
 
 func makeDumpConsensusStateFunc(c *lrpc.Client) rpcDumpConsensusStateFunc {
@@ -15,6 +15,7 @@ import (
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	service "github.com/cometbft/cometbft/libs/service"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
@@ -31,6 +32,7 @@ type KeyPathFunc func(path string, key []byte) (merkle.KeyPath, error)
 //go:generate ../../scripts/mockery_generate.sh LightClient
 type LightClient interface {
 	ChainID() string
+	Encoding() types.CanonicalEncoding
 	Update(ctx context.Context, now time.Time) (*types.LightBlock, error)
 	VerifyLightBlockAtHeight(ctx context.Context, height int64, now time.Time) (*types.LightBlock, error)
 	TrustedLightBlock(height int64) (*types.LightBlock, error)
@@ -260,6 +262,10 @@ func (c *Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	return c.next.Health(ctx)
 }
 
+func (c *Client) HealthDetailed(ctx context.Context) (*ctypes.ResultHealthDetailed, error) {
+	return c.next.HealthDetailed(ctx)
+}
+
 // BlockchainInfo calls rpcclient#BlockchainInfo and then verifies every header
 // returned.
 func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
@@ -273,7 +279,7 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 		if meta == nil {
 			return nil, fmt.Errorf("nil block meta %d", i)
 		}
-		if err := meta.ValidateBasic(); err != nil {
+		if err := meta.ValidateBasic(c.lc.Encoding()); err != nil {
 			return nil, fmt.Errorf("invalid block meta %d: %w", i, err)
 		}
 	}
@@ -292,7 +298,7 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 		if err != nil {
 			return nil, fmt.Errorf("trusted header %d: %w", meta.Header.Height, err)
 		}
-		if bmH, tH := meta.Header.Hash(), h.Hash(); !bytes.Equal(bmH, tH) {
+		if bmH, tH := meta.Header.Hash(c.lc.Encoding()), h.Hash(c.lc.Encoding()); !bytes.Equal(bmH, tH) {
 			return nil, fmt.Errorf("block meta header %X does not match with trusted header %X",
 				bmH, tH)
 		}
@@ -315,66 +321,80 @@ func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock,
 	if err != nil {
 		return nil, err
 	}
-
-	// Validate res.
-	if err := res.BlockID.ValidateBasic(); err != nil {
+	if err := c.verifyBlock(ctx, res); err != nil {
 		return nil, err
 	}
-	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
-	}
-	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
-			bmH, bH)
-	}
+	return res, nil
+}
 
-	// Update the light client if we're behind.
-	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
+// BlockByHash calls rpcclient#BlockByHash and then verifies the result.
+func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error) {
+	res, err := c.next.BlockByHash(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
-
-	// Verify block.
-	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
-			bH, tH)
+	if err := c.verifyBlock(ctx, res); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(res.BlockID.Hash, hash) {
+		return nil, fmt.Errorf("block %X does not match with requested hash %X", res.BlockID.Hash, hash)
 	}
-
 	return res, nil
 }
 
-// BlockByHash calls rpcclient#BlockByHash and then verifies the result.
-func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error) {
-	res, err := c.next.BlockByHash(ctx, hash)
+// BlockByTime calls rpcclient#BlockByTime and then verifies the result, and that
+// the previous block is before t, so that the block is the first one at or
+// after t.
+func (c *Client) BlockByTime(ctx context.Context, t time.Time) (*ctypes.ResultBlock, error) {
+	res, err := c.next.BlockByTime(ctx, t)
 	if err != nil {
 		return nil, err
 	}
+	if err := c.verifyBlock(ctx, res); err != nil {
+		return nil, err
+	}
+	if res.Block.Time.Before(t) {
+		return nil, fmt.Errorf("block time %v is before requested time %v", res.Block.Time, t)
+	}
+	if res.Block.Height > 1 {
+		prevHeight := res.Block.Height - 1
+		prev, err := c.updateLightClientIfNeededTo(ctx, &prevHeight)
+		if err != nil {
+			return nil, err
+		}
+		if !prev.Time.Before(t) {
+			return nil, fmt.Errorf("block %d at %v is not the first one at or after %v", res.Block.Height, res.Block.Time, t)
+		}
+	}
+	return res, nil
+}
 
+// verifyBlock verifies the block against the trusted header at its height.
+func (c *Client) verifyBlock(ctx context.Context, res *ctypes.ResultBlock) error {
 	// Validate res.
 	if err := res.BlockID.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
 	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
-	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
+	if bmH, bH := res.BlockID.Hash, res.Block.Hash(c.lc.Encoding()); !bytes.Equal(bmH, bH) {
+		return fmt.Errorf("blockID %X does not match with block %X",
 			bmH, bH)
 	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Verify block.
-	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
+	if bH, tH := res.Block.Hash(c.lc.Encoding()), l.Hash(c.lc.Encoding()); !bytes.Equal(bH, tH) {
+		return fmt.Errorf("block header %X does not match with trusted header %X",
 			bH, tH)
 	}
-
-	return res, nil
+	return nil
 }
 
 // BlockResults returns the block results for the given height. If no height is
@@ -402,6 +422,9 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 	if res.Height <= 0 {
 		return nil, errNegOrZeroHeight
 	}
+	if res.Height != h {
+		return nil, fmt.Errorf("block results at height %d do not match with requested height %d", res.Height, h)
+	}
 
 	// Update the light client if we're behind.
 	nextHeight := h + 1
@@ -467,9 +490,8 @@ func (c *Client) HeaderByHash(ctx context.Context, hash cmtbytes.HexBytes) (*cty
 		return nil, err
 	}
 
-	if !bytes.Equal(lb.Header.Hash(), res.Header.Hash()) {
-		return nil, fmt.Errorf("primary header hash does not match trusted header hash. (%X != %X)",
-			lb.Header.Hash(), res.Header.Hash())
+	if lH, rH := lb.Header.Hash(c.lc.Encoding()), res.Header.Hash(c.lc.Encoding()); !bytes.Equal(lH, rH) {
+		return nil, fmt.Errorf("primary header hash does not match trusted header hash. (%X != %X)", lH, rH)
 	}
 
 	return res, nil
@@ -489,18 +511,97 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommi
 	}, nil
 }
 
-// Tx calls rpcclient#Tx method and then verifies the proof if such was
-// requested.
+// CommitAggregated calls rpcclient#CommitAggregated and then verifies the
+// aggregated commit against the trusted header and validator set at its height.
+func (c *Client) CommitAggregated(ctx context.Context, height *int64) (*ctypes.ResultCommitAggregated, error) {
+	res, err := c.next.CommitAggregated(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Header == nil {
+		return nil, errors.New("nil header")
+	}
+	if err := res.Header.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Header.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	if lH, rH := l.Header.Hash(c.lc.Encoding()), res.Header.Hash(c.lc.Encoding()); !bytes.Equal(lH, rH) {
+		return nil, fmt.Errorf("primary header hash does not match trusted header hash. (%X != %X)", lH, rH)
+	}
+	if err := l.ValidatorSet.VerifyAggregatedCommit(l.ChainID, c.lc.Encoding(), l.Commit.BlockID, l.Height,
+		res.Commit); err != nil {
+		return nil, fmt.Errorf("invalid aggregated commit: %w", err)
+	}
+
+	// Return the trusted validators, in case the primary lied about them.
+	res.Validators = l.ValidatorSet.Validators
+	res.SignedVotingPower = 0
+	for idx, val := range res.Validators {
+		if res.Commit.HasSigner(idx) {
+			res.SignedVotingPower += val.VotingPower
+		}
+	}
+	res.TotalVotingPower = l.ValidatorSet.TotalVotingPower()
+	return res, nil
+}
+
+// Tx calls rpcclient#Tx method, always with a proof, and then verifies the tx
+// and its result. The proof is only returned if it was requested.
 func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	res, err := c.next.Tx(ctx, hash, prove)
-	if err != nil || !prove {
-		return res, err
+	res, err := c.next.Tx(ctx, hash, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(res.Hash, hash) {
+		return nil, fmt.Errorf("tx hash %X does not match with requested hash %X", res.Hash, hash)
+	}
+	if err := c.verifyTx(ctx, res, make(map[int64]*ctypes.ResultBlockResults)); err != nil {
+		return nil, err
+	}
+	if !prove {
+		res.Proof = types.TxProof{}
+	}
+	return res, nil
+}
+
+// TxProof calls rpcclient#TxProof and then verifies that the proof proves the
+// tx hash against the data hash of the verified header.
+func (c *Client) TxProof(ctx context.Context, hash []byte) (*ctypes.ResultTxProof, error) {
+	res, err := c.next.TxProof(ctx, hash)
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate res.
+	if !bytes.Equal(res.Hash, hash) {
+		return nil, fmt.Errorf("tx hash %X does not match with requested hash %X", res.Hash, hash)
+	}
 	if res.Height <= 0 {
 		return nil, errNegOrZeroHeight
 	}
+	var proof cmtcrypto.CommitmentProof
+	if err := proof.Unmarshal(res.Proof); err != nil {
+		return nil, fmt.Errorf("failed to decode proof: %w", err)
+	}
+	if proof.Exist == nil {
+		return nil, errors.New("expected an existence proof")
+	}
+	if !bytes.Equal(append(append([]byte(nil), proof.Exist.Key...), proof.Exist.Value...), hash) {
+		return nil, errors.New("proof does not match with tx hash")
+	}
+	rootHash, err := merkle.ICS23Root(proof.Exist)
+	if err != nil {
+		return nil, err
+	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
@@ -508,10 +609,21 @@ func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.Resul
 		return nil, err
 	}
 
-	// Validate the proof.
-	return res, res.Proof.Validate(l.DataHash)
+	// Verify the proof against the header.
+	if !bytes.Equal(rootHash, l.DataHash) {
+		return nil, fmt.Errorf("proof root %X does not match with data hash %X", rootHash, l.DataHash)
+	}
+	if !bytes.Equal(res.RootHash, l.DataHash) {
+		return nil, fmt.Errorf("root hash %X does not match with data hash %X", res.RootHash, l.DataHash)
+	}
+	return res, nil
 }
 
+// TxSearch calls rpcclient#TxSearch, always with proofs, and then verifies
+// every tx returned, and its result. The proofs are only returned if they were
+// requested.
+//
+// Note the search itself can't be verified: the primary could omit some txs.
 func (c *Client) TxSearch(
 	ctx context.Context,
 	query string,
@@ -519,16 +631,111 @@ func (c *Client) TxSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
-	return c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	res, err := c.next.TxSearch(ctx, query, true, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// the txs of a block share its results
+	blockResults := make(map[int64]*ctypes.ResultBlockResults)
+	for i, tx := range res.Txs {
+		if tx == nil {
+			return nil, fmt.Errorf("nil tx %d", i)
+		}
+		if err := c.verifyTx(ctx, tx, blockResults); err != nil {
+			return nil, fmt.Errorf("tx %d: %w", i, err)
+		}
+		if !prove {
+			tx.Proof = types.TxProof{}
+		}
+	}
+	return res, nil
+}
+
+// verifyTx verifies the tx against the data hash of the trusted header at its
+// height, and its result against the verified results of the block, which are
+// fetched unless they are in blockResults already.
+func (c *Client) verifyTx(ctx context.Context, res *ctypes.ResultTx,
+	blockResults map[int64]*ctypes.ResultBlockResults) error {
+	// Validate res.
+	if res.Height <= 0 {
+		return errNegOrZeroHeight
+	}
+	if !bytes.Equal(res.Hash, res.Tx.Hash()) {
+		return fmt.Errorf("tx hash %X does not match with tx %X", res.Hash, res.Tx.Hash())
+	}
+	if !bytes.Equal(res.Proof.Data, res.Tx) || res.Proof.Proof.Index != int64(res.Index) {
+		return errors.New("proof does not match with tx")
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return err
+	}
+
+	// Validate the proof.
+	if err := res.Proof.Validate(l.DataHash); err != nil {
+		return err
+	}
+
+	// Verify the result, against the results of the block.
+	results, ok := blockResults[res.Height]
+	if !ok {
+		if results, err = c.BlockResults(ctx, &res.Height); err != nil {
+			return fmt.Errorf("failed to verify result: %w", err)
+		}
+		blockResults[res.Height] = results
+	}
+	if int(res.Index) >= len(results.TxsResults) {
+		return fmt.Errorf("block %d has %d results, got tx index %d", res.Height, len(results.TxsResults), res.Index)
+	}
+	trusted := types.NewResults(results.TxsResults[res.Index : res.Index+1])
+	if !proto.Equal(trusted[0], types.NewResults([]*abci.ResponseDeliverTx{&res.TxResult})[0]) {
+		return fmt.Errorf("tx result does not match with trusted result of block %d", res.Height)
+	}
+	return nil
 }
 
+// BlockEvents returns the BeginBlock and EndBlock events of the verified block
+// results at the given height, see BlockResults. The events indexed by the
+// full node can't be verified on their own.
+func (c *Client) BlockEvents(ctx context.Context, height *int64) (*ctypes.ResultBlockEvents, error) {
+	res, err := c.BlockResults(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlockEvents{
+		Height:           res.Height,
+		BeginBlockEvents: res.BeginBlockEvents,
+		EndBlockEvents:   res.EndBlockEvents,
+	}, nil
+}
+
+// BlockSearch calls rpcclient#BlockSearch and then verifies every block
+// returned.
+//
+// Note the search itself can't be verified: the primary could omit some blocks.
 func (c *Client) BlockSearch(
 	ctx context.Context,
 	query string,
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultBlockSearch, error) {
-	return c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	res, err := c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, block := range res.Blocks {
+		if block == nil {
+			return nil, fmt.Errorf("nil block %d", i)
+		}
+		if err := c.verifyBlock(ctx, block); err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+	}
+	return res, nil
 }
 
 // Validators fetches and verifies validators.
@@ -562,6 +769,66 @@ func (c *Client) Validators(
 		Total:       totalCount}, nil
 }
 
+// ValidatorsCommitment computes the commitment to the verified validators.
+func (c *Client) ValidatorsCommitment(ctx context.Context, height *int64) (*ctypes.ResultValidatorsCommitment, error) {
+	// Update the light client if we're behind and retrieve the light block at the
+	// requested height or at the latest height if no height is provided.
+	l, err := c.updateLightClientIfNeededTo(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := l.ValidatorSet.Commitment()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultValidatorsCommitment{
+		BlockHeight:      l.Height,
+		Scheme:           types.ValidatorSetCommitmentScheme,
+		Commitment:       commitment,
+		Count:            l.ValidatorSet.Size(),
+		TotalVotingPower: l.ValidatorSet.TotalVotingPower(),
+	}, nil
+}
+
+// ValidatorsDiff computes the difference between the verified validator sets
+// at the from and to heights.
+func (c *Client) ValidatorsDiff(ctx context.Context, from, to *int64) (*ctypes.ResultValidatorsDiff, error) {
+	lTo, err := c.updateLightClientIfNeededTo(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil {
+		h := cmtmath.MaxInt64(lTo.Height-1, 1)
+		from = &h
+	}
+	if *from > lTo.Height {
+		return nil, fmt.Errorf("from height %d must be less than or equal to to height %d", *from, lTo.Height)
+	}
+	lFrom, err := c.updateLightClientIfNeededTo(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := types.NewValidatorSetDiff(lFrom.ValidatorSet, lTo.ValidatorSet)
+	result := &ctypes.ResultValidatorsDiff{
+		FromHeight: lFrom.Height,
+		ToHeight:   lTo.Height,
+		Updates:    diff.Updates,
+		Removals:   diff.Removals,
+		FromHash:   lFrom.ValidatorSet.Hash(c.lc.Encoding()),
+		ToHash:     lTo.ValidatorSet.Hash(c.lc.Encoding()),
+	}
+	if commitment, err := lFrom.ValidatorSet.Commitment(); err == nil {
+		result.FromCommitment = commitment
+	}
+	if commitment, err := lTo.ValidatorSet.Commitment(); err == nil {
+		result.ToCommitment = commitment
+	}
+	return result, nil
+}
+
 func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return c.next.BroadcastEvidence(ctx, ev)
 }
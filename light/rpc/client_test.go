@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	lcmock "github.com/cometbft/cometbft/light/rpc/mocks"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// txFixture sets up a primary serving the txs of the block at height 1, and a
+// light client trusting the headers at heights 1 and 2.
+func txFixture(t *testing.T) (*Client, *mocks.Client, types.Txs, []*abci.ResponseDeliverTx) {
+	txs := types.Txs{[]byte("tx0"), []byte("tx1")}
+	results := []*abci.ResponseDeliverTx{{Code: 0, Data: []byte("ok")}, {Code: 1, Log: "failed"}}
+
+	bbeBytes, err := proto.Marshal(&abci.ResponseBeginBlock{})
+	require.NoError(t, err)
+	ebeBytes, err := proto.Marshal(&abci.ResponseEndBlock{})
+	require.NoError(t, err)
+	resultsHash := merkle.HashFromByteSlices([][]byte{bbeBytes, types.NewResults(results).Hash(), ebeBytes})
+
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(1), mock.Anything).Return(&types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &types.Header{Height: 1, DataHash: txs.Hash()}},
+	}, nil)
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(2), mock.Anything).Return(&types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &types.Header{Height: 2, LastResultsHash: resultsHash}},
+	}, nil)
+
+	next := &mocks.Client{}
+	next.On("BlockResults", mock.Anything, mock.Anything).Return(&ctypes.ResultBlockResults{
+		Height:     1,
+		TxsResults: results,
+	}, nil)
+
+	return NewClient(next, lc), next, txs, results
+}
+
+func resultTx(txs types.Txs, results []*abci.ResponseDeliverTx, i int) *ctypes.ResultTx {
+	return &ctypes.ResultTx{
+		Hash:     txs[i].Hash(),
+		Height:   1,
+		Index:    uint32(i),
+		TxResult: *results[i],
+		Tx:       txs[i],
+		Proof:    txs.Proof(i),
+	}
+}
+
+func TestTx(t *testing.T) {
+	c, next, txs, results := txFixture(t)
+	next.On("Tx", mock.Anything, []byte(txs[0].Hash()), true).Return(resultTx(txs, results, 0), nil)
+	next.On("Tx", mock.Anything, []byte(txs[1].Hash()), true).Return(resultTx(txs, results, 1), nil)
+
+	res, err := c.Tx(context.Background(), txs[0].Hash(), true)
+	require.NoError(t, err)
+	assert.Equal(t, txs.Proof(0), res.Proof)
+
+	// the proof is always verified, but only returned if requested
+	res, err = c.Tx(context.Background(), txs[1].Hash(), false)
+	require.NoError(t, err)
+	assert.Equal(t, types.TxProof{}, res.Proof)
+	assert.Equal(t, *results[1], res.TxResult)
+}
+
+func TestTx_Invalid(t *testing.T) {
+	testCases := map[string]func(res *ctypes.ResultTx){
+		"tampered tx":     func(res *ctypes.ResultTx) { res.Tx = []byte("tx2") },
+		"tampered result": func(res *ctypes.ResultTx) { res.TxResult.Code = 1 },
+		"wrong index":     func(res *ctypes.ResultTx) { res.Index = 1 },
+		"missing proof":   func(res *ctypes.ResultTx) { res.Proof = types.TxProof{} },
+		"other tx proof": func(res *ctypes.ResultTx) {
+			res.Proof = types.Txs{[]byte("tx2")}.Proof(0)
+			res.Tx = []byte("tx2")
+			res.Hash = res.Tx.Hash()
+		},
+	}
+	for name, tamper := range testCases {
+		tamper := tamper
+		t.Run(name, func(t *testing.T) {
+			c, next, txs, results := txFixture(t)
+			res := resultTx(txs, results, 0)
+			tamper(res)
+			next.On("Tx", mock.Anything, mock.Anything, true).Return(res, nil)
+
+			_, err := c.Tx(context.Background(), res.Hash, false)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestTxProof(t *testing.T) {
+	c, next, txs, _ := txFixture(t)
+	resultTxProof := func(txs types.Txs, i int) *ctypes.ResultTxProof {
+		proof, err := txs.Proof(i).ICS23()
+		require.NoError(t, err)
+		bz, err := proof.Marshal()
+		require.NoError(t, err)
+		return &ctypes.ResultTxProof{Hash: txs[i].Hash(), Height: 1, Index: uint32(i), RootHash: txs.Hash(), Proof: bz}
+	}
+	next.On("TxProof", mock.Anything, []byte(txs[1].Hash())).Return(resultTxProof(txs, 1), nil).Once()
+
+	res, err := c.TxProof(context.Background(), txs[1].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, resultTxProof(txs, 1), res)
+
+	// proof of another tx
+	next.On("TxProof", mock.Anything, []byte(txs[1].Hash())).Return(resultTxProof(txs, 0), nil).Once()
+	_, err = c.TxProof(context.Background(), txs[1].Hash())
+	assert.Error(t, err)
+
+	// proof against another root
+	otherTxs := types.Txs{txs[0], txs[1], []byte("tx2")}
+	next.On("TxProof", mock.Anything, []byte(txs[1].Hash())).Return(resultTxProof(otherTxs, 1), nil).Once()
+	_, err = c.TxProof(context.Background(), txs[1].Hash())
+	assert.Error(t, err)
+}
+
+func TestTxSearch(t *testing.T) {
+	c, next, txs, results := txFixture(t)
+	next.On("TxSearch", mock.Anything, "tx.height=1", true, mock.Anything, mock.Anything, "").
+		Return(&ctypes.ResultTxSearch{
+			Txs:        []*ctypes.ResultTx{resultTx(txs, results, 0), resultTx(txs, results, 1)},
+			TotalCount: 2,
+		}, nil)
+
+	res, err := c.TxSearch(context.Background(), "tx.height=1", false, nil, nil, "")
+	require.NoError(t, err)
+	require.Len(t, res.Txs, 2)
+	for _, tx := range res.Txs {
+		assert.Equal(t, types.TxProof{}, tx.Proof)
+	}
+	// the results of the block are only fetched once
+	next.AssertNumberOfCalls(t, "BlockResults", 1)
+
+	tampered := resultTx(txs, results, 1)
+	tampered.TxResult.Data = []byte("ok")
+	next.On("TxSearch", mock.Anything, "tx.height>0", true, mock.Anything, mock.Anything, "").
+		Return(&ctypes.ResultTxSearch{
+			Txs:        []*ctypes.ResultTx{resultTx(txs, results, 0), tampered},
+			TotalCount: 2,
+		}, nil)
+	_, err = c.TxSearch(context.Background(), "tx.height>0", true, nil, nil, "")
+	require.Error(t, err)
+}
@@ -31,6 +31,20 @@ func (_m *LightClient) ChainID() string {
 	return r0
 }
 
+// Encoding provides a mock function with given fields:
+func (_m *LightClient) Encoding() types.CanonicalEncoding {
+	ret := _m.Called()
+
+	var r0 types.CanonicalEncoding
+	if rf, ok := ret.Get(0).(func() types.CanonicalEncoding); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(types.CanonicalEncoding)
+	}
+
+	return r0
+}
+
 // TrustedLightBlock provides a mock function with given fields: height
 func (_m *LightClient) TrustedLightBlock(height int64) (*types.LightBlock, error) {
 	ret := _m.Called(height)
@@ -7,6 +7,7 @@ import (
 	"github.com/cometbft/cometbft/light/provider"
 	"github.com/cometbft/cometbft/light/provider/http"
 	"github.com/cometbft/cometbft/light/store"
+	"github.com/cometbft/cometbft/types"
 )
 
 // NewHTTPClient initiates an instance of a light client using HTTP addresses
@@ -18,13 +19,14 @@ import (
 func NewHTTPClient(
 	ctx context.Context,
 	chainID string,
+	encoding types.CanonicalEncoding,
 	trustOptions TrustOptions,
 	primaryAddress string,
 	witnessesAddresses []string,
 	trustedStore store.Store,
 	options ...Option) (*Client, error) {
 
-	providers, err := providersFromAddresses(append(witnessesAddresses, primaryAddress), chainID)
+	providers, err := providersFromAddresses(append(witnessesAddresses, primaryAddress), chainID, encoding)
 	if err != nil {
 		return nil, err
 	}
@@ -32,6 +34,7 @@ func NewHTTPClient(
 	return NewClient(
 		ctx,
 		chainID,
+		encoding,
 		trustOptions,
 		providers[len(providers)-1],
 		providers[:len(providers)-1],
@@ -47,19 +50,21 @@ func NewHTTPClient(
 // See NewClientFromTrustedStore.
 func NewHTTPClientFromTrustedStore(
 	chainID string,
+	encoding types.CanonicalEncoding,
 	trustingPeriod time.Duration,
 	primaryAddress string,
 	witnessesAddresses []string,
 	trustedStore store.Store,
 	options ...Option) (*Client, error) {
 
-	providers, err := providersFromAddresses(append(witnessesAddresses, primaryAddress), chainID)
+	providers, err := providersFromAddresses(append(witnessesAddresses, primaryAddress), chainID, encoding)
 	if err != nil {
 		return nil, err
 	}
 
 	return NewClientFromTrustedStore(
 		chainID,
+		encoding,
 		trustingPeriod,
 		providers[len(providers)-1],
 		providers[:len(providers)-1],
@@ -67,10 +72,14 @@ func NewHTTPClientFromTrustedStore(
 		options...)
 }
 
-func providersFromAddresses(addrs []string, chainID string) ([]provider.Provider, error) {
+func providersFromAddresses(
+	addrs []string,
+	chainID string,
+	encoding types.CanonicalEncoding,
+) ([]provider.Provider, error) {
 	providers := make([]provider.Provider, len(addrs))
 	for idx, address := range addrs {
-		p, err := http.New(chainID, address)
+		p, err := http.New(chainID, encoding, address)
 		if err != nil {
 			return nil, err
 		}
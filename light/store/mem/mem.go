@@ -0,0 +1,167 @@
+// Package mem implements an in-memory trusted store for the light client.
+//
+// Nothing is persisted, so the light client has to be initialized with
+// TrustOptions on every start. It suits embedded and short-lived light
+// clients, which don't need (or can't have) a database.
+package mem
+
+import (
+	"sort"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/light/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+type mem struct {
+	mtx cmtsync.RWMutex
+	// heights are kept in ascending order.
+	heights     []int64
+	lightBlocks map[int64]*types.LightBlock
+}
+
+// New returns an empty in-memory Store.
+func New() store.Store {
+	return &mem{lightBlocks: make(map[int64]*types.LightBlock)}
+}
+
+// SaveLightBlock stores the LightBlock, replacing the one at the same height
+// if any.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *mem) SaveLightBlock(lb *types.LightBlock) error {
+	if lb.Height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.lightBlocks[lb.Height]; !ok {
+		i := s.search(lb.Height)
+		s.heights = append(s.heights, 0)
+		copy(s.heights[i+1:], s.heights[i:])
+		s.heights[i] = lb.Height
+	}
+	s.lightBlocks[lb.Height] = lb
+
+	return nil
+}
+
+// DeleteLightBlock deletes the LightBlock at the given height.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *mem) DeleteLightBlock(height int64) error {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.lightBlocks[height]; !ok {
+		return nil
+	}
+	delete(s.lightBlocks, height)
+	i := s.search(height)
+	s.heights = append(s.heights[:i], s.heights[i+1:]...)
+
+	return nil
+}
+
+// LightBlock returns the LightBlock at the given height.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *mem) LightBlock(height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	lb, ok := s.lightBlocks[height]
+	if !ok {
+		return nil, store.ErrLightBlockNotFound
+	}
+	return lb, nil
+}
+
+// LastLightBlockHeight returns the last LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *mem) LastLightBlockHeight() (int64, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if len(s.heights) == 0 {
+		return -1, nil
+	}
+	return s.heights[len(s.heights)-1], nil
+}
+
+// FirstLightBlockHeight returns the first LightBlock height stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *mem) FirstLightBlockHeight() (int64, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if len(s.heights) == 0 {
+		return -1, nil
+	}
+	return s.heights[0], nil
+}
+
+// LightBlockBefore returns the LightBlock right before the given height. It
+// returns ErrLightBlockNotFound if no such block exists.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *mem) LightBlockBefore(height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	i := s.search(height)
+	if i == 0 {
+		return nil, store.ErrLightBlockNotFound
+	}
+	return s.lightBlocks[s.heights[i-1]], nil
+}
+
+// Prune removes the oldest light blocks until there are only size of them
+// left.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *mem) Prune(size uint16) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if len(s.heights) <= int(size) {
+		return nil
+	}
+	numToPrune := len(s.heights) - int(size)
+	for _, height := range s.heights[:numToPrune] {
+		delete(s.lightBlocks, height)
+	}
+	s.heights = append([]int64(nil), s.heights[numToPrune:]...)
+
+	return nil
+}
+
+// Size returns the number of light blocks stored.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *mem) Size() uint16 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return uint16(len(s.heights))
+}
+
+// search returns the index of the first height >= height.
+func (s *mem) search(height int64) int {
+	return sort.Search(len(s.heights), func(i int) bool { return s.heights[i] >= height })
+}
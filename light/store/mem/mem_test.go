@@ -0,0 +1,68 @@
+package mem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/light/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestStore(t *testing.T) {
+	s := New()
+
+	height, err := s.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, height)
+	height, err = s.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, height)
+
+	for _, h := range []int64{5, 2, 9, 7} {
+		require.NoError(t, s.SaveLightBlock(lightBlock(h)))
+	}
+	// saving the same height twice doesn't grow the store
+	require.NoError(t, s.SaveLightBlock(lightBlock(7)))
+	assert.EqualValues(t, 4, s.Size())
+
+	height, err = s.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, height)
+	height, err = s.LastLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, height)
+
+	lb, err := s.LightBlockBefore(7)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, lb.Height)
+	lb, err = s.LightBlockBefore(100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, lb.Height)
+	_, err = s.LightBlockBefore(2)
+	assert.Equal(t, store.ErrLightBlockNotFound, err)
+
+	require.NoError(t, s.DeleteLightBlock(5))
+	_, err = s.LightBlock(5)
+	assert.Equal(t, store.ErrLightBlockNotFound, err)
+	lb, err = s.LightBlockBefore(7)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, lb.Height)
+
+	require.NoError(t, s.Prune(1))
+	assert.EqualValues(t, 1, s.Size())
+	lb, err = s.LightBlock(9)
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, lb.Height)
+	_, err = s.LightBlock(2)
+	assert.Equal(t, store.ErrLightBlockNotFound, err)
+
+	assert.Panics(t, func() { _, _ = s.LightBlock(0) })
+}
+
+func lightBlock(height int64) *types.LightBlock {
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &types.Header{Height: height}},
+	}
+}
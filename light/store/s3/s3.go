@@ -0,0 +1,238 @@
+// Package s3 implements a read-through trusted store for the light client,
+// backed by an S3-compatible object storage.
+//
+// Light blocks which are not found in the local store are fetched from the
+// bucket and cached locally. Optionally, light blocks saved locally are also
+// written to the bucket, so that a single light client can populate a bucket
+// shared by many others (e.g. short-lived or embedded light clients).
+//
+// NOTE: the light client does NOT verify the light blocks of its trusted
+// store. The bucket must only be writable by trusted light clients.
+package s3
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/light/store"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	// maxObjectSize bounds the size of the light blocks read from the bucket.
+	maxObjectSize = 16 << 20
+)
+
+// Config is the configuration of the bucket.
+type Config struct {
+	// Endpoint of the S3-compatible service, e.g.
+	// "https://s3.eu-west-1.amazonaws.com". Path-style requests are used.
+	Endpoint string
+	Bucket   string
+	Region   string
+	// Prefix of the object keys, usually the chain ID.
+	Prefix string
+
+	// Credentials used to sign the requests. If AccessKeyID is empty, the
+	// requests are anonymous (e.g. a public bucket).
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// WriteThrough also writes the light blocks saved locally to the bucket.
+	WriteThrough bool
+
+	// HTTPClient defaults to a client with a 10s timeout.
+	HTTPClient *http.Client
+}
+
+type s3 struct {
+	store.Store
+
+	cfg      Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// New returns a Store reading through local to the bucket described by cfg.
+// Deletions and pruning only apply to local: the retention of the bucket is
+// expected to be managed by its lifecycle rules.
+func New(local store.Store, cfg Config) (store.Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("empty bucket")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("invalid endpoint scheme %q", endpoint.Scheme)
+	}
+	if cfg.AccessKeyID != "" && cfg.Region == "" {
+		return nil, errors.New("a region is required to sign the requests")
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	return &s3{Store: local, cfg: cfg, endpoint: endpoint, client: client}, nil
+}
+
+// SaveLightBlock saves the LightBlock locally and, with WriteThrough, to the
+// bucket.
+func (s *s3) SaveLightBlock(lb *types.LightBlock) error {
+	if err := s.Store.SaveLightBlock(lb); err != nil {
+		return err
+	}
+	if !s.cfg.WriteThrough {
+		return nil
+	}
+
+	lbpb, err := lb.ToProto()
+	if err != nil {
+		return fmt.Errorf("unable to convert light block to protobuf: %w", err)
+	}
+	bz, err := lbpb.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshaling LightBlock: %w", err)
+	}
+	if _, err := s.do(http.MethodPut, lb.Height, bz); err != nil {
+		return fmt.Errorf("writing light block %d to the bucket: %w", lb.Height, err)
+	}
+	return nil
+}
+
+// LightBlock returns the LightBlock from the local store or, if it's not
+// there, from the bucket, caching it locally.
+func (s *s3) LightBlock(height int64) (*types.LightBlock, error) {
+	lb, err := s.Store.LightBlock(height)
+	if !errors.Is(err, store.ErrLightBlockNotFound) {
+		return lb, err
+	}
+
+	bz, err := s.do(http.MethodGet, height, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading light block %d from the bucket: %w", height, err)
+	}
+	if bz == nil {
+		return nil, store.ErrLightBlockNotFound
+	}
+
+	var lbpb cmtproto.LightBlock
+	if err := lbpb.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
+	}
+	lb, err = types.LightBlockFromProto(&lbpb)
+	if err != nil {
+		return nil, fmt.Errorf("proto conversion error: %w", err)
+	}
+	if lb.Height != height {
+		return nil, fmt.Errorf("object of height %d holds light block %d", height, lb.Height)
+	}
+
+	if err := s.Store.SaveLightBlock(lb); err != nil {
+		return nil, fmt.Errorf("caching light block %d: %w", height, err)
+	}
+	return lb, nil
+}
+
+// do sends a request for the object of the light block at height. For GET
+// requests, it returns the object, or nil if there is none.
+func (s *s3) do(method string, height int64, body []byte) ([]byte, error) {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.cfg.Bucket + "/" + s.objectKey(height)
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if s.cfg.AccessKeyID != "" {
+		s.sign(req, body, time.Now().UTC())
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bz, err := io.ReadAll(io.LimitReader(resp.Body, maxObjectSize))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case method == http.MethodGet && resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode/100 != 2:
+		return nil, fmt.Errorf("%s: %s", resp.Status, bz)
+	}
+	return bz, nil
+}
+
+func (s *s3) objectKey(height int64) string {
+	if s.cfg.Prefix == "" {
+		return fmt.Sprintf("%020d", height)
+	}
+	return fmt.Sprintf("%s/%020d", s.cfg.Prefix, height)
+}
+
+// sign signs the request with AWS Signature Version 4.
+func (s *s3) sign(req *http.Request, body []byte, now time.Time) {
+	var (
+		amzDate     = now.Format("20060102T150405Z")
+		date        = now.Format("20060102")
+		scope       = date + "/" + s.cfg.Region + "/s3/aws4_request"
+		payloadHash = sha256Hex(body)
+	)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(bz []byte) string {
+	h := sha256.Sum256(bz)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
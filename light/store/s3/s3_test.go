@@ -0,0 +1,136 @@
+package s3
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/light/store"
+	"github.com/cometbft/cometbft/light/store/mem"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	"github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+// bucket is a minimal S3-compatible bucket.
+type bucket struct {
+	mtx     sync.Mutex
+	objects map[string][]byte
+	auth    []string
+}
+
+func (b *bucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.auth = append(b.auth, r.Header.Get("Authorization"))
+	switch r.Method {
+	case http.MethodGet:
+		bz, ok := b.objects[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(bz)
+	case http.MethodPut:
+		bz, _ := io.ReadAll(r.Body)
+		b.objects[r.URL.Path] = bz
+	}
+}
+
+func TestReadThrough(t *testing.T) {
+	b := &bucket{objects: make(map[string][]byte)}
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	cfg := Config{
+		Endpoint:        srv.URL,
+		Bucket:          "light",
+		Region:          "eu-west-1",
+		Prefix:          "test-chain",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		WriteThrough:    true,
+	}
+	writer, err := New(mem.New(), cfg)
+	require.NoError(t, err)
+
+	lb := randLightBlock(t, 3)
+	require.NoError(t, writer.SaveLightBlock(lb))
+	assert.Contains(t, b.objects, "/light/test-chain/00000000000000000003")
+
+	cfg.WriteThrough = false
+	local := mem.New()
+	reader, err := New(local, cfg)
+	require.NoError(t, err)
+
+	// read from the bucket, then cached locally
+	got, err := reader.LightBlock(3)
+	require.NoError(t, err)
+	assert.Equal(t, lb.Hash(types.CanonicalEncodingProto), got.Hash(types.CanonicalEncodingProto))
+	cached, err := local.LightBlock(3)
+	require.NoError(t, err)
+	assert.Equal(t, lb.Hash(types.CanonicalEncodingProto), cached.Hash(types.CanonicalEncodingProto))
+	assert.Len(t, b.auth, 2)
+	got, err = reader.LightBlock(3)
+	require.NoError(t, err)
+	assert.Equal(t, lb.Hash(types.CanonicalEncodingProto), got.Hash(types.CanonicalEncodingProto))
+	assert.Len(t, b.auth, 2)
+
+	_, err = reader.LightBlock(4)
+	assert.Equal(t, store.ErrLightBlockNotFound, err)
+
+	for _, auth := range b.auth {
+		assert.True(t, strings.HasPrefix(auth,
+			"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), auth)
+		assert.Contains(t, auth, "/eu-west-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date")
+	}
+}
+
+func TestBucketErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "access denied", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	s, err := New(mem.New(), Config{Endpoint: srv.URL, Bucket: "light", WriteThrough: true})
+	require.NoError(t, err)
+
+	_, err = s.LightBlock(1)
+	assert.ErrorContains(t, err, "403")
+	assert.ErrorContains(t, s.SaveLightBlock(randLightBlock(t, 1)), "403")
+
+	_, err = New(mem.New(), Config{Endpoint: "ftp://example.com", Bucket: "light"})
+	assert.Error(t, err)
+	_, err = New(mem.New(), Config{Endpoint: srv.URL})
+	assert.Error(t, err)
+	_, err = New(mem.New(), Config{Endpoint: srv.URL, Bucket: "light", AccessKeyID: "AKIDEXAMPLE"})
+	assert.Error(t, err)
+}
+
+func randLightBlock(t *testing.T, height int64) *types.LightBlock {
+	t.Helper()
+	vals, _ := types.RandValidatorSet(2, 1)
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &types.Header{
+				Version:            cmtversion.Consensus{Block: version.BlockProtocol},
+				ChainID:            "test-chain",
+				Height:             height,
+				Time:               time.Now().UTC(),
+				ValidatorsHash:     vals.Hash(types.CanonicalEncodingProto),
+				NextValidatorsHash: vals.Hash(types.CanonicalEncodingProto),
+				ProposerAddress:    vals.Proposer.Address,
+			},
+			Commit: &types.Commit{},
+		},
+		ValidatorSet: vals,
+	}
+}
@@ -28,7 +28,8 @@ var (
 //	 e) headers are non-adjacent.
 //
 // maxClockDrift defines how much untrustedHeader.Time can drift into the
-// future.
+// future. The headers and validator sets are hashed with the canonical
+// encoding of the chain.
 func VerifyNonAdjacent(
 	trustedHeader *types.SignedHeader, // height=X
 	trustedVals *types.ValidatorSet, // height=X or height=X+1
@@ -37,7 +38,8 @@ func VerifyNonAdjacent(
 	trustingPeriod time.Duration,
 	now time.Time,
 	maxClockDrift time.Duration,
-	trustLevel cmtmath.Fraction) error {
+	trustLevel cmtmath.Fraction,
+	encoding types.CanonicalEncoding) error {
 
 	if untrustedHeader.Height == trustedHeader.Height+1 {
 		return errors.New("headers must be non adjacent in height")
@@ -50,12 +52,12 @@ func VerifyNonAdjacent(
 	if err := verifyNewHeaderAndVals(
 		untrustedHeader, untrustedVals,
 		trustedHeader,
-		now, maxClockDrift); err != nil {
+		now, maxClockDrift, encoding); err != nil {
 		return ErrInvalidHeader{err}
 	}
 
 	// Ensure that +`trustLevel` (default 1/3) or more of last trusted validators signed correctly.
-	err := trustedVals.VerifyCommitLightTrusting(trustedHeader.ChainID, untrustedHeader.Commit, trustLevel)
+	err := trustedVals.VerifyCommitLightTrusting(trustedHeader.ChainID, encoding, untrustedHeader.Commit, trustLevel)
 	if err != nil {
 		switch e := err.(type) {
 		case types.ErrNotEnoughVotingPowerSigned:
@@ -70,7 +72,7 @@ func VerifyNonAdjacent(
 	// NOTE: this should always be the last check because untrustedVals can be
 	// intentionally made very large to DOS the light client. not the case for
 	// VerifyAdjacent, where validator set is known in advance.
-	if err := untrustedVals.VerifyCommitLight(trustedHeader.ChainID, untrustedHeader.Commit.BlockID,
+	if err := untrustedVals.VerifyCommitLight(trustedHeader.ChainID, encoding, untrustedHeader.Commit.BlockID,
 		untrustedHeader.Height, untrustedHeader.Commit); err != nil {
 		return ErrInvalidHeader{err}
 	}
@@ -89,14 +91,16 @@ func VerifyNonAdjacent(
 //	e) headers are adjacent.
 //
 // maxClockDrift defines how much untrustedHeader.Time can drift into the
-// future.
+// future. The headers and validator sets are hashed with the canonical
+// encoding of the chain.
 func VerifyAdjacent(
 	trustedHeader *types.SignedHeader, // height=X
 	untrustedHeader *types.SignedHeader, // height=X+1
 	untrustedVals *types.ValidatorSet, // height=X+1
 	trustingPeriod time.Duration,
 	now time.Time,
-	maxClockDrift time.Duration) error {
+	maxClockDrift time.Duration,
+	encoding types.CanonicalEncoding) error {
 
 	if untrustedHeader.Height != trustedHeader.Height+1 {
 		return errors.New("headers must be adjacent in height")
@@ -109,7 +113,7 @@ func VerifyAdjacent(
 	if err := verifyNewHeaderAndVals(
 		untrustedHeader, untrustedVals,
 		trustedHeader,
-		now, maxClockDrift); err != nil {
+		now, maxClockDrift, encoding); err != nil {
 		return ErrInvalidHeader{err}
 	}
 
@@ -123,7 +127,7 @@ func VerifyAdjacent(
 	}
 
 	// Ensure that +2/3 of new validators signed correctly.
-	if err := untrustedVals.VerifyCommitLight(trustedHeader.ChainID, untrustedHeader.Commit.BlockID,
+	if err := untrustedVals.VerifyCommitLight(trustedHeader.ChainID, encoding, untrustedHeader.Commit.BlockID,
 		untrustedHeader.Height, untrustedHeader.Commit); err != nil {
 		return ErrInvalidHeader{err}
 	}
@@ -140,14 +144,137 @@ func Verify(
 	trustingPeriod time.Duration,
 	now time.Time,
 	maxClockDrift time.Duration,
-	trustLevel cmtmath.Fraction) error {
+	trustLevel cmtmath.Fraction,
+	encoding types.CanonicalEncoding) error {
 
 	if untrustedHeader.Height != trustedHeader.Height+1 {
 		return VerifyNonAdjacent(trustedHeader, trustedVals, untrustedHeader, untrustedVals,
-			trustingPeriod, now, maxClockDrift, trustLevel)
+			trustingPeriod, now, maxClockDrift, trustLevel, encoding)
+	}
+
+	return VerifyAdjacent(trustedHeader, untrustedHeader, untrustedVals, trustingPeriod, now, maxClockDrift, encoding)
+}
+
+// VerifyNonAdjacentAggregated is VerifyNonAdjacent for an untrustedHeader
+// with an aggregated commit, which can only be signed by bn254 validators.
+// Only the header of the trusted block is needed, e.g. for light clients
+// which were given aggregated commits only.
+//
+// The signers of the aggregated commit which are part of trustedVals must
+// have signed for trustLevel of its voting power, and more than 2/3 of
+// untrustedVals for the whole aggregated signature to be valid.
+func VerifyNonAdjacentAggregated(
+	trustedHeader *types.Header, // height=X
+	trustedVals *types.ValidatorSet, // height=X or height=X+1
+	untrustedHeader *types.AggregatedSignedHeader, // height=Y
+	untrustedVals *types.ValidatorSet, // height=Y
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+	trustLevel cmtmath.Fraction,
+	encoding types.CanonicalEncoding) error {
+
+	if untrustedHeader.Height == trustedHeader.Height+1 {
+		return errors.New("headers must be non adjacent in height")
+	}
+
+	if headerExpired(trustedHeader, trustingPeriod, now) {
+		return ErrOldHeaderExpired{trustedHeader.Time.Add(trustingPeriod), now}
+	}
+
+	if err := verifyNewAggregatedHeaderAndVals(
+		untrustedHeader, untrustedVals,
+		trustedHeader,
+		now, maxClockDrift, encoding); err != nil {
+		return ErrInvalidHeader{err}
+	}
+
+	// Ensure that +`trustLevel` (default 1/3) or more of last trusted validators signed. The
+	// signature itself is verified below, since it aggregates those of all the signers.
+	err := trustedVals.VerifyAggregatedCommitLightTrusting(untrustedVals, untrustedHeader.Commit, trustLevel)
+	if err != nil {
+		switch e := err.(type) {
+		case types.ErrNotEnoughVotingPowerSigned:
+			return ErrNewValSetCantBeTrusted{e}
+		default:
+			return e
+		}
+	}
+
+	// Ensure that +2/3 of new validators signed correctly.
+	if err := untrustedVals.VerifyAggregatedCommit(trustedHeader.ChainID, encoding, untrustedHeader.Commit.BlockID,
+		untrustedHeader.Height, untrustedHeader.Commit); err != nil {
+		return ErrInvalidHeader{err}
+	}
+
+	return nil
+}
+
+// VerifyAdjacentAggregated is VerifyAdjacent for an untrustedHeader with an
+// aggregated commit, which can only be signed by bn254 validators. Only the
+// header of the trusted block is needed.
+func VerifyAdjacentAggregated(
+	trustedHeader *types.Header, // height=X
+	untrustedHeader *types.AggregatedSignedHeader, // height=X+1
+	untrustedVals *types.ValidatorSet, // height=X+1
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+	encoding types.CanonicalEncoding) error {
+
+	if untrustedHeader.Height != trustedHeader.Height+1 {
+		return errors.New("headers must be adjacent in height")
+	}
+
+	if headerExpired(trustedHeader, trustingPeriod, now) {
+		return ErrOldHeaderExpired{trustedHeader.Time.Add(trustingPeriod), now}
+	}
+
+	if err := verifyNewAggregatedHeaderAndVals(
+		untrustedHeader, untrustedVals,
+		trustedHeader,
+		now, maxClockDrift, encoding); err != nil {
+		return ErrInvalidHeader{err}
+	}
+
+	// Check the validator hashes are the same
+	if !bytes.Equal(untrustedHeader.ValidatorsHash, trustedHeader.NextValidatorsHash) {
+		err := fmt.Errorf("expected old header next validators (%X) to match those from new header (%X)",
+			trustedHeader.NextValidatorsHash,
+			untrustedHeader.ValidatorsHash,
+		)
+		return err
+	}
+
+	// Ensure that +2/3 of new validators signed correctly.
+	if err := untrustedVals.VerifyAggregatedCommit(trustedHeader.ChainID, encoding, untrustedHeader.Commit.BlockID,
+		untrustedHeader.Height, untrustedHeader.Commit); err != nil {
+		return ErrInvalidHeader{err}
+	}
+
+	return nil
+}
+
+// VerifyAggregated combines both VerifyAdjacentAggregated and
+// VerifyNonAdjacentAggregated functions.
+func VerifyAggregated(
+	trustedHeader *types.Header, // height=X
+	trustedVals *types.ValidatorSet, // height=X or height=X+1
+	untrustedHeader *types.AggregatedSignedHeader, // height=Y
+	untrustedVals *types.ValidatorSet, // height=Y
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+	trustLevel cmtmath.Fraction,
+	encoding types.CanonicalEncoding) error {
+
+	if untrustedHeader.Height != trustedHeader.Height+1 {
+		return VerifyNonAdjacentAggregated(trustedHeader, trustedVals, untrustedHeader, untrustedVals,
+			trustingPeriod, now, maxClockDrift, trustLevel, encoding)
 	}
 
-	return VerifyAdjacent(trustedHeader, untrustedHeader, untrustedVals, trustingPeriod, now, maxClockDrift)
+	return VerifyAdjacentAggregated(trustedHeader, untrustedHeader, untrustedVals, trustingPeriod, now,
+		maxClockDrift, encoding)
 }
 
 func verifyNewHeaderAndVals(
@@ -155,12 +282,40 @@ func verifyNewHeaderAndVals(
 	untrustedVals *types.ValidatorSet,
 	trustedHeader *types.SignedHeader,
 	now time.Time,
-	maxClockDrift time.Duration) error {
+	maxClockDrift time.Duration,
+	encoding types.CanonicalEncoding) error {
 
-	if err := untrustedHeader.ValidateBasic(trustedHeader.ChainID); err != nil {
+	if err := untrustedHeader.ValidateBasic(trustedHeader.ChainID, encoding); err != nil {
 		return fmt.Errorf("untrustedHeader.ValidateBasic failed: %w", err)
 	}
 
+	return verifyNewHeader(untrustedHeader.Header, untrustedVals, trustedHeader.Header, now, maxClockDrift, encoding)
+}
+
+func verifyNewAggregatedHeaderAndVals(
+	untrustedHeader *types.AggregatedSignedHeader,
+	untrustedVals *types.ValidatorSet,
+	trustedHeader *types.Header,
+	now time.Time,
+	maxClockDrift time.Duration,
+	encoding types.CanonicalEncoding) error {
+
+	if err := untrustedHeader.ValidateBasic(trustedHeader.ChainID, encoding); err != nil {
+		return fmt.Errorf("untrustedHeader.ValidateBasic failed: %w", err)
+	}
+
+	return verifyNewHeader(untrustedHeader.Header, untrustedVals, trustedHeader, now, maxClockDrift, encoding)
+}
+
+// verifyNewHeader checks that the untrusted header, which is valid, can follow the trusted header.
+func verifyNewHeader(
+	untrustedHeader *types.Header,
+	untrustedVals *types.ValidatorSet,
+	trustedHeader *types.Header,
+	now time.Time,
+	maxClockDrift time.Duration,
+	encoding types.CanonicalEncoding) error {
+
 	if untrustedHeader.Height <= trustedHeader.Height {
 		return fmt.Errorf("expected new header height %d to be greater than one of old header %d",
 			untrustedHeader.Height,
@@ -180,10 +335,10 @@ func verifyNewHeaderAndVals(
 			maxClockDrift)
 	}
 
-	if !bytes.Equal(untrustedHeader.ValidatorsHash, untrustedVals.Hash()) {
+	if valsHash := untrustedVals.Hash(encoding); !bytes.Equal(untrustedHeader.ValidatorsHash, valsHash) {
 		return fmt.Errorf("expected new header validators (%X) to match those that were supplied (%X) at height %d",
 			untrustedHeader.ValidatorsHash,
-			untrustedVals.Hash(),
+			valsHash,
 			untrustedHeader.Height,
 		)
 	}
@@ -205,6 +360,10 @@ func ValidateTrustLevel(lvl cmtmath.Fraction) error {
 
 // HeaderExpired return true if the given header expired.
 func HeaderExpired(h *types.SignedHeader, trustingPeriod time.Duration, now time.Time) bool {
+	return headerExpired(h.Header, trustingPeriod, now)
+}
+
+func headerExpired(h *types.Header, trustingPeriod time.Duration, now time.Time) bool {
 	expirationTime := h.Time.Add(trustingPeriod)
 	return !expirationTime.After(now)
 }
@@ -218,7 +377,7 @@ func HeaderExpired(h *types.SignedHeader, trustingPeriod time.Duration, now time
 //	 of the trusted header
 //
 //	 For any of these cases ErrInvalidHeader is returned.
-func VerifyBackwards(untrustedHeader, trustedHeader *types.Header) error {
+func VerifyBackwards(untrustedHeader, trustedHeader *types.Header, encoding types.CanonicalEncoding) error {
 	if err := untrustedHeader.ValidateBasic(); err != nil {
 		return ErrInvalidHeader{err}
 	}
@@ -234,10 +393,10 @@ func VerifyBackwards(untrustedHeader, trustedHeader *types.Header) error {
 				trustedHeader.Time)}
 	}
 
-	if !bytes.Equal(untrustedHeader.Hash(), trustedHeader.LastBlockID.Hash) {
+	if hash := untrustedHeader.Hash(encoding); !bytes.Equal(hash, trustedHeader.LastBlockID.Hash) {
 		return ErrInvalidHeader{
 			fmt.Errorf("older header hash %X does not match trusted header's last block %X",
-				untrustedHeader.Hash(),
+				hash,
 				trustedHeader.LastBlockID.Hash)}
 	}
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	"github.com/cometbft/cometbft/light"
@@ -155,7 +156,7 @@ func TestVerifyAdjacentHeaders(t *testing.T) {
 	for i, tc := range testCases {
 		tc := tc
 		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
-			err := light.VerifyAdjacent(header, tc.newHeader, tc.newVals, tc.trustingPeriod, tc.now, maxClockDrift)
+			err := light.VerifyAdjacent(header, tc.newHeader, tc.newVals, tc.trustingPeriod, tc.now, maxClockDrift, types.CanonicalEncodingProto)
 			switch {
 			case tc.expErr != nil && assert.Error(t, err):
 				assert.Equal(t, tc.expErr, err)
@@ -271,7 +272,7 @@ func TestVerifyNonAdjacentHeaders(t *testing.T) {
 		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
 			err := light.VerifyNonAdjacent(header, vals, tc.newHeader, tc.newVals, tc.trustingPeriod,
 				tc.now, maxClockDrift,
-				light.DefaultTrustLevel)
+				light.DefaultTrustLevel, types.CanonicalEncodingProto)
 
 			switch {
 			case tc.expErr != nil && assert.Error(t, err):
@@ -301,7 +302,7 @@ func TestVerifyReturnsErrorIfTrustLevelIsInvalid(t *testing.T) {
 	)
 
 	err := light.Verify(header, vals, header, vals, 2*time.Hour, time.Now(), maxClockDrift,
-		cmtmath.Fraction{Numerator: 2, Denominator: 1})
+		cmtmath.Fraction{Numerator: 2, Denominator: 1}, types.CanonicalEncodingProto)
 	assert.Error(t, err)
 }
 
@@ -333,3 +334,114 @@ func TestValidateTrustLevel(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyAggregated(t *testing.T) {
+	const chainID = "TestVerifyAggregated"
+
+	var (
+		keys = genBn254PrivKeys(4)
+		// 20, 30, 40, 50 - the first 3 don't have 2/3, the last 3 do!
+		vals     = keys.ToValidators(20, 10)
+		bTime, _ = time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+		header   = keys.GenSignedHeader(chainID, 1, bTime, nil, vals, vals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+		// 1/3+ of the voting power of vals remains in the new set, but not 2/3
+		newKeys = append(privKeys{keys[3], keys[2]}, genBn254PrivKeys(2)...)
+		newVals = newKeys.ToValidators(50, 0)
+		// no trusted validators remain in the other set
+		otherKeys = genBn254PrivKeys(4)
+		otherVals = otherKeys.ToValidators(10, 0)
+
+		trustingPeriod = 3 * time.Hour
+		now            = bTime.Add(2 * time.Hour)
+	)
+
+	aggregate := func(sh *types.SignedHeader) *types.AggregatedSignedHeader {
+		ac, err := sh.Commit.Aggregate()
+		require.NoError(t, err)
+		return &types.AggregatedSignedHeader{Header: sh.Header, Commit: ac}
+	}
+	forged := aggregate(keys.GenSignedHeader(chainID, 2, bTime.Add(time.Hour), nil, vals, vals,
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 1, len(keys)))
+	forged.Commit.Signers = []byte{0x0f}
+	forged.Commit.Timestamps = append(forged.Commit.Timestamps, forged.Commit.Timestamps[0])
+
+	testCases := map[string]struct {
+		newHeader  *types.AggregatedSignedHeader
+		newVals    *types.ValidatorSet
+		expErr     error
+		expErrText string
+	}{
+		"adjacent, all signed": {
+			aggregate(keys.GenSignedHeader(chainID, 2, bTime.Add(time.Hour), nil, vals, vals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))),
+			vals, nil, "",
+		},
+		"adjacent, 2/3+ signed": {
+			aggregate(keys.GenSignedHeader(chainID, 2, bTime.Add(time.Hour), nil, vals, vals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 1, len(keys))),
+			vals, nil, "",
+		},
+		"adjacent, less than 2/3 signed": {
+			aggregate(keys.GenSignedHeader(chainID, 2, bTime.Add(time.Hour), nil, vals, vals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys)-1)),
+			vals, light.ErrInvalidHeader{Reason: types.ErrNotEnoughVotingPowerSigned{Got: 90, Needed: 93}}, "",
+		},
+		"adjacent, forged signers": {
+			forged, vals, nil, "wrong aggregated signature",
+		},
+		"adjacent, different validators": {
+			aggregate(newKeys.GenSignedHeader(chainID, 2, bTime.Add(time.Hour), nil, newVals, newVals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(newKeys))),
+			newVals, nil, "to match those from new header",
+		},
+		"non-adjacent, same validators": {
+			aggregate(keys.GenSignedHeader(chainID, 3, bTime.Add(time.Hour), nil, vals, vals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))),
+			vals, nil, "",
+		},
+		"non-adjacent, 1/3+ of the trusted validators signed": {
+			aggregate(newKeys.GenSignedHeader(chainID, 3, bTime.Add(time.Hour), nil, newVals, newVals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(newKeys))),
+			newVals, nil, "",
+		},
+		"non-adjacent, less than 1/3 of the trusted validators signed": {
+			aggregate(newKeys.GenSignedHeader(chainID, 3, bTime.Add(time.Hour), nil, newVals, newVals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 1, len(newKeys))),
+			newVals, light.ErrNewValSetCantBeTrusted{Reason: types.ErrNotEnoughVotingPowerSigned{Got: 40, Needed: 46}}, "",
+		},
+		"non-adjacent, none of the trusted validators signed": {
+			aggregate(otherKeys.GenSignedHeader(chainID, 3, bTime.Add(time.Hour), nil, otherVals, otherVals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(otherKeys))),
+			otherVals, light.ErrNewValSetCantBeTrusted{Reason: types.ErrNotEnoughVotingPowerSigned{Got: 0, Needed: 46}}, "",
+		},
+		"non-adjacent, wrong validators": {
+			aggregate(newKeys.GenSignedHeader(chainID, 3, bTime.Add(time.Hour), nil, newVals, newVals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(newKeys))),
+			vals, nil, "to match those that were supplied",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := light.VerifyAggregated(header.Header, vals, tc.newHeader, tc.newVals, trustingPeriod, now,
+				maxClockDrift, light.DefaultTrustLevel, types.CanonicalEncodingProto)
+			switch {
+			case tc.expErr != nil:
+				assert.Equal(t, tc.expErr, err)
+			case tc.expErrText != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expErrText)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// the signed headers of bn254 validators are verified as well
+	newHeader := keys.GenSignedHeader(chainID, 3, bTime.Add(time.Hour), nil, vals, vals,
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+	require.NoError(t, light.Verify(header, vals, newHeader, vals, trustingPeriod, now, maxClockDrift,
+		light.DefaultTrustLevel, types.CanonicalEncodingProto))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/config"
@@ -27,6 +28,10 @@ type CListMempool struct {
 	height   int64 // the last block Update()'d to
 	txsBytes int64 // total size of mempool, in bytes
 
+	// limits of the mempool, initially the ones of the config (see SetLimits)
+	maxTxs      int64
+	maxTxsBytes int64
+
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
 	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
@@ -79,6 +84,8 @@ func NewCListMempool(
 		proxyAppConn:  proxyAppConn,
 		txs:           clist.New(),
 		height:        height,
+		maxTxs:        int64(cfg.Size),
+		maxTxsBytes:   cfg.MaxTxsBytes,
 		recheckCursor: nil,
 		recheckEnd:    nil,
 		logger:        log.NewNopLogger(),
@@ -100,6 +107,14 @@ func NewCListMempool(
 	return mp
 }
 
+// SetLimits changes the maximum number of transactions and total size of the
+// mempool at runtime. Lowering them doesn't evict any transaction: the new
+// transactions are rejected until the mempool is back under the limits.
+func (mem *CListMempool) SetLimits(maxTxs int, maxTxsBytes int64) {
+	atomic.StoreInt64(&mem.maxTxs, int64(maxTxs))
+	atomic.StoreInt64(&mem.maxTxsBytes, maxTxsBytes)
+}
+
 // NOTE: not thread safe - should only be called once, on startup
 func (mem *CListMempool) EnableTxsAvailable() {
 	mem.txsAvailable = make(chan struct{}, 1)
@@ -348,16 +363,18 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 
 func (mem *CListMempool) isFull(txSize int) error {
 	var (
-		memSize  = mem.Size()
-		txsBytes = mem.SizeBytes()
+		memSize     = mem.Size()
+		txsBytes    = mem.SizeBytes()
+		maxTxs      = int(atomic.LoadInt64(&mem.maxTxs))
+		maxTxsBytes = atomic.LoadInt64(&mem.maxTxsBytes)
 	)
 
-	if memSize >= mem.config.Size || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
+	if memSize >= maxTxs || int64(txSize)+txsBytes > maxTxsBytes {
 		return ErrMempoolIsFull{
 			NumTxs:      memSize,
-			MaxTxs:      mem.config.Size,
+			MaxTxs:      maxTxs,
 			TxsBytes:    txsBytes,
-			MaxTxsBytes: mem.config.MaxTxsBytes,
+			MaxTxsBytes: maxTxsBytes,
 		}
 	}
 
@@ -394,6 +411,7 @@ func (mem *CListMempool) resCbFirstTime(
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
 				tx:        tx,
+				timestamp: time.Now(),
 			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
@@ -662,9 +680,10 @@ func (mem *CListMempool) recheckTxs() {
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64     // height that this tx had been validated in
+	gasWanted int64     // amount of gas this tx states it will require
+	tx        types.Tx  //
+	timestamp time.Time // time this tx entered the mempool
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -0,0 +1,60 @@
+package mempool
+
+import (
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+// gossipRedundancyWeight is the weight of each tx received from a peer in the
+// moving average of its redundancy, so that it follows the last ~50 txs.
+const gossipRedundancyWeight = 0.02
+
+// gossipStats tracks the redundancy of the peers, i.e. the fraction of the txs
+// they send us which we already have, as an exponential moving average. A peer
+// which mostly sends us txs we already have is connected to the same sources
+// as we are, so it likely already has the txs we would send to it. See
+// MempoolConfig.AdaptiveGossip.
+type gossipStats struct {
+	mtx        cmtsync.RWMutex
+	redundancy map[p2p.ID]float64
+}
+
+func newGossipStats() *gossipStats {
+	return &gossipStats{redundancy: make(map[p2p.ID]float64)}
+}
+
+// RecordTx records a tx received from the peer, which we already had if
+// duplicate is true.
+func (s *gossipStats) RecordTx(peerID p2p.ID, duplicate bool) {
+	var sample float64
+	if duplicate {
+		sample = 1
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.redundancy[peerID] += gossipRedundancyWeight * (sample - s.redundancy[peerID])
+}
+
+// Redundancy returns the redundancy of the peer, between 0 (it never sent us a
+// tx we already had, or no tx at all) and 1 (it only sends us txs we already
+// have).
+func (s *gossipStats) Redundancy(peerID p2p.ID) float64 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.redundancy[peerID]
+}
+
+// Delay returns how long after entering the mempool the txs are sent to the
+// peer: maxDelay scaled by its redundancy.
+func (s *gossipStats) Delay(peerID p2p.ID, maxDelay time.Duration) time.Duration {
+	return time.Duration(s.Redundancy(peerID) * float64(maxDelay))
+}
+
+// Remove forgets the peer.
+func (s *gossipStats) Remove(peerID p2p.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.redundancy, peerID)
+}
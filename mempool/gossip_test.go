@@ -0,0 +1,69 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestGossipStats(t *testing.T) {
+	stats := newGossipStats()
+	const peerID = p2p.ID("peer")
+	assert.Zero(t, stats.Redundancy(peerID))
+	assert.Zero(t, stats.Delay(peerID, time.Second))
+
+	for i := 0; i < 500; i++ {
+		stats.RecordTx(peerID, true)
+	}
+	assert.InDelta(t, 1, stats.Redundancy(peerID), 0.01)
+	assert.InDelta(t, time.Second, stats.Delay(peerID, time.Second), float64(10*time.Millisecond))
+
+	// half of the txs are new
+	for i := 0; i < 500; i++ {
+		stats.RecordTx(peerID, i%2 == 0)
+	}
+	assert.InDelta(t, 0.5, stats.Redundancy(peerID), 0.05)
+
+	stats.Remove(peerID)
+	assert.Zero(t, stats.Redundancy(peerID))
+}
+
+func TestReactorAdaptiveGossipDelaysRedundantPeers(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.AdaptiveGossip = true
+	config.Mempool.AdaptiveGossipMaxDelay = 500 * time.Millisecond
+	const N = 2
+	reactors := makeAndConnectReactors(config, N)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1})
+		}
+	}
+
+	// the peer always sent the txs the first reactor already had
+	peers := reactors[0].Switch.Peers().List()
+	require.Len(t, peers, 1)
+	for i := 0; i < 500; i++ {
+		reactors[0].gossipStats.RecordTx(peers[0].ID(), true)
+	}
+
+	start := time.Now()
+	txs := checkTxs(t, reactors[0].mempool, 10, UnknownPeerID)
+	ensureNoTxs(t, reactors[1], 200*time.Millisecond)
+	// the txs are still sent, once the delay is over
+	waitForTxsOnReactor(t, txs, reactors[1], 1)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
@@ -8,6 +8,7 @@ import (
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/clist"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/ratelimit"
 	"github.com/cometbft/cometbft/p2p"
 	protomem "github.com/cometbft/cometbft/proto/tendermint/mempool"
 	"github.com/cometbft/cometbft/types"
@@ -21,16 +22,43 @@ type Reactor struct {
 	config  *cfg.MempoolConfig
 	mempool *CListMempool
 	ids     *mempoolIDs
+
+	txLimiter        *ratelimit.Limiter // limits the rate of the txs of the peers
+	rateLimitMetrics *ratelimit.Metrics
+
+	gossipStats *gossipStats // see MempoolConfig.AdaptiveGossip
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// ReactorRateLimitMetrics counts the txs allowed and dropped by the rate
+// limits of the peers, under the limiter name "mempool".
+func ReactorRateLimitMetrics(metrics *ratelimit.Metrics) ReactorOption {
+	return func(memR *Reactor) { memR.rateLimitMetrics = metrics }
 }
 
 // NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
+func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool, options ...ReactorOption) *Reactor {
 	memR := &Reactor{
-		config:  config,
-		mempool: mempool,
-		ids:     newMempoolIDs(),
+		config:           config,
+		mempool:          mempool,
+		ids:              newMempoolIDs(),
+		rateLimitMetrics: ratelimit.NopMetrics(),
+		gossipStats:      newGossipStats(),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
+	for _, option := range options {
+		option(memR)
+	}
+
+	limiterOptions := []ratelimit.Option{ratelimit.WithMetrics(memR.rateLimitMetrics, "mempool")}
+	if config.PeersTxRate > 0 {
+		limiterOptions = append(limiterOptions, ratelimit.WithParent(ratelimit.NewBucket(
+			ratelimit.Limit{Rate: config.PeersTxRate, Burst: config.PeersTxBurst}, nil)))
+	}
+	memR.txLimiter = ratelimit.NewLimiter(
+		ratelimit.Limit{Rate: config.PeerTxRate, Burst: config.PeerTxBurst}, limiterOptions...)
 	return memR
 }
 
@@ -50,6 +78,8 @@ func (memR *Reactor) SetLogger(l log.Logger) {
 func (memR *Reactor) OnStart() error {
 	if !memR.config.Broadcast {
 		memR.Logger.Info("Tx broadcasting is disabled")
+	} else if memR.config.AdaptiveGossip {
+		memR.Logger.Info("Adaptive tx gossip is enabled", "maxDelay", memR.config.AdaptiveGossipMaxDelay)
 	}
 	return nil
 }
@@ -85,6 +115,8 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 // RemovePeer implements Reactor.
 func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	memR.ids.Reclaim(peer)
+	memR.txLimiter.Remove(string(peer.ID()))
+	memR.gossipStats.Remove(peer.ID())
 	// broadcast routine checks if peer is gone and returns
 }
 
@@ -107,7 +139,14 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 		var err error
 		for _, tx := range protoTxs {
 			ntx := types.Tx(tx)
+			if !memR.txLimiter.Allow(string(txInfo.SenderP2PID)) {
+				memR.Logger.Debug("Peer exceeded its tx rate limit, dropping tx", "src", e.Src, "tx", ntx.String())
+				continue
+			}
 			err = memR.mempool.CheckTx(ntx, nil, txInfo)
+			if memR.config.AdaptiveGossip && e.Src != nil && (err == nil || errors.Is(err, ErrTxInCache)) {
+				memR.gossipStats.RecordTx(e.Src.ID(), err != nil)
+			}
 			if errors.Is(err, ErrTxInCache) {
 				memR.Logger.Debug("Tx already exists in cache", "tx", ntx.String())
 			} else if err != nil {
@@ -173,6 +212,21 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 			continue
 		}
 
+		// Give the peer time to get the tx from another peer if it usually
+		// does, see MempoolConfig.AdaptiveGossip.
+		if memR.config.AdaptiveGossip {
+			delay := memR.gossipStats.Delay(peer.ID(), memR.config.AdaptiveGossipMaxDelay)
+			if wait := time.Until(memTx.timestamp.Add(delay)); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-peer.Quit():
+					return
+				case <-memR.Quit():
+					return
+				}
+			}
+		}
+
 		// NOTE: Transaction batching was disabled due to
 		// https://github.com/tendermint/tendermint/issues/5796
 
@@ -0,0 +1,96 @@
+package node
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+// dbCompactor compacts the databases of the node at a regular interval, as
+// pruned databases don't necessarily reclaim disk space on their own.
+type dbCompactor struct {
+	service.BaseService
+
+	interval time.Duration
+
+	mtx sync.Mutex
+	dbs map[string]dbm.DB
+}
+
+func newDBCompactor(interval time.Duration, logger log.Logger) *dbCompactor {
+	c := &dbCompactor{
+		interval: interval,
+		dbs:      make(map[string]dbm.DB),
+	}
+	c.BaseService = *service.NewBaseService(logger, "DBCompactor", c)
+	return c
+}
+
+// track returns a DBProvider recording the databases returned by provider, to
+// compact them.
+func (c *dbCompactor) track(provider cfg.DBProvider) cfg.DBProvider {
+	return func(ctx *cfg.DBContext) (dbm.DB, error) {
+		db, err := provider(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.mtx.Lock()
+		c.dbs[ctx.ID] = db
+		c.mtx.Unlock()
+		return db, nil
+	}
+}
+
+// OnStart implements service.Service.
+func (c *dbCompactor) OnStart() error {
+	go c.compactRoutine()
+	return nil
+}
+
+func (c *dbCompactor) compactRoutine() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Quit():
+			return
+		case <-ticker.C:
+			c.compact()
+		}
+	}
+}
+
+// compact compacts the databases one after the other.
+func (c *dbCompactor) compact() {
+	c.mtx.Lock()
+	dbs := make(map[string]dbm.DB, len(c.dbs))
+	for id, db := range c.dbs {
+		dbs[id] = db
+	}
+	c.mtx.Unlock()
+
+	for id, db := range dbs {
+		select {
+		case <-c.Quit():
+			return
+		default:
+		}
+		start := time.Now()
+		err := cfg.CompactDB(db)
+		switch {
+		case errors.Is(err, cfg.ErrCompactionNotSupported):
+			c.Logger.Debug("Database backend doesn't support compaction", "db", id)
+		case err != nil:
+			c.Logger.Error("Failed to compact database", "db", id, "err", err)
+		default:
+			c.Logger.Info("Compacted database", "db", id, "took", time.Since(start))
+		}
+	}
+}
@@ -1,33 +1,46 @@
 package node
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/pprof"
+	"os"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	bc "github.com/cometbft/cometbft/blocksync"
 	cfg "github.com/cometbft/cometbft/config"
 	cs "github.com/cometbft/cometbft/consensus"
 	"github.com/cometbft/cometbft/evidence"
+	"github.com/cometbft/cometbft/evidence/forkmonitor"
 
 	"github.com/cometbft/cometbft/libs/log"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/libs/profiler"
 	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	"github.com/cometbft/cometbft/libs/ratelimit"
 	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/libs/telemetry"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/pex"
+	"github.com/cometbft/cometbft/p2p/upnp"
 	"github.com/cometbft/cometbft/proxy"
 	rpccore "github.com/cometbft/cometbft/rpc/core"
 	grpccore "github.com/cometbft/cometbft/rpc/grpc"
 	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/state/indexer"
+	"github.com/cometbft/cometbft/state/invariant"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/state/txindex/null"
 	"github.com/cometbft/cometbft/statesync"
@@ -35,8 +48,6 @@ import (
 	"github.com/cometbft/cometbft/types"
 	cmttime "github.com/cometbft/cometbft/types/time"
 	"github.com/cometbft/cometbft/version"
-
-	_ "net/http/pprof" //nolint: gosec
 )
 
 // Node is the highest level interface to a full CometBFT node.
@@ -53,14 +64,18 @@ type Node struct {
 	transport   *p2p.MultiplexTransport
 	sw          *p2p.Switch  // p2p connections
 	addrBook    pex.AddrBook // known peers
-	nodeInfo    p2p.NodeInfo
 	nodeKey     *p2p.NodeKey // our node privkey
 	isListening bool
+	portMapper  *upnp.PortMapper // keeps the p2p port mapped on the gateway (optional)
 
 	// services
-	eventBus          *types.EventBus // pub/sub for services
+	eventBus          *types.EventBus     // pub/sub for services
+	telemetryExporter *telemetry.Exporter // exports the traces and metrics over OTLP (optional)
+	profiler          *profiler.Profiler  // captures the CPU and heap profiles periodically (optional)
 	stateStore        sm.Store
 	blockStore        *store.BlockStore // store the blockchain to disk
+	pruner            *sm.Pruner        // prunes the stores in the background (optional)
+	dbCompactor       *dbCompactor      // compacts the databases in the background (optional)
 	bcReactor         p2p.Reactor       // for block-syncing
 	mempool           mempl.Mempool
 	stateSync         bool                    // whether the node should state sync on startup
@@ -70,13 +85,27 @@ type Node struct {
 	consensusState    *cs.State               // latest consensus state
 	consensusReactor  *cs.Reactor             // for participating in the consensus
 	evidencePool      *evidence.Pool          // tracking evidence
+	forkMonitor       *forkmonitor.Monitor    // watches for conflicting blocks (optional)
+	invariantChecker  *invariant.Checker      // checks the invariants between the stores (optional)
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
+	rpcMetrics        *rpccore.Metrics
+	rateLimitMetrics  *ratelimit.Metrics
+	rpcRateLimiter    *rpcserver.RateLimiter // nil if the RPC is not rate limited
+	rpcDrainer        *rpcserver.Drainer     // waits for the RPC requests in flight on shutdown
 	txIndexer         txindex.TxIndexer
 	blockIndexer      indexer.BlockIndexer
 	indexerService    *txindex.IndexerService
+	indexerProgress   *txindex.Progress   // nil if indexing is disabled
+	backfiller        *txindex.Backfiller // backfills the index in the background (optional)
 	prometheusSrv     *http.Server
 	pprofSrv          *http.Server
+
+	// starts and stops the services in the order of their dependencies
+	services *service.Manager
+
+	// reloads the safe subset of the configuration at runtime (see reload.go)
+	reloader configReloader
 }
 
 // Option sets a parameter for the node.
@@ -107,14 +136,10 @@ func CustomReactors(reactors map[string]p2p.Reactor) Option {
 			// NOTE: This is a bit messy now with the type casting but is
 			// cleaned up in the following version when NodeInfo is changed from
 			// and interface to a concrete type
-			if ni, ok := n.nodeInfo.(p2p.DefaultNodeInfo); ok {
+			if _, ok := n.transport.NodeInfo().(p2p.DefaultNodeInfo); ok {
 				for _, chDesc := range reactor.GetChannels() {
-					if !ni.HasChannel(chDesc.ID) {
-						ni.Channels = append(ni.Channels, chDesc.ID)
-						n.transport.AddChannel(chDesc.ID)
-					}
+					n.transport.AddChannel(chDesc.ID)
 				}
-				n.nodeInfo = ni
 			} else {
 				n.Logger.Error("Node info is not of type DefaultNodeInfo. Custom reactor channels can not be added.")
 			}
@@ -144,13 +169,19 @@ func NewNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option,
 ) (*Node, error) {
+	var compactor *dbCompactor
+	if config.Storage.CompactionInterval > 0 {
+		compactor = newDBCompactor(config.Storage.CompactionInterval, logger.With("module", "compactor"))
+		dbProvider = compactor.track(dbProvider)
+	}
+
 	blockStore, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
 	}
 
 	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+		ABCIResponsesRetention: config.Storage.RetainABCIResponses,
 	})
 
 	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genesisDocProvider)
@@ -158,10 +189,16 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics, abciMetrics, bsMetrics, ssMetrics := metricsProvider(genDoc.ChainID)
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, abciMetrics, bsMetrics, ssMetrics, rpcMetrics, privvalMetrics, rateLimitMetrics, pubsubMetrics, forkMonitorMetrics, invariantMetrics := metricsProvider(genDoc.ChainID)
+
+	// Export the traces from the start, to cover the handshake with the app.
+	telemetryExporter, err := createAndStartTelemetryExporter(config, genDoc.ChainID, nodeKey, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger, abciMetrics)
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, config, logger, abciMetrics)
 	if err != nil {
 		return nil, err
 	}
@@ -170,13 +207,13 @@ func NewNode(config *cfg.Config,
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
 	// but before it indexed the txs, or, endblocker panicked)
-	eventBus, err := createAndStartEventBus(logger)
+	eventBus, err := createAndStartEventBus(pubsubMetrics, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config,
-		genDoc.ChainID, dbProvider, eventBus, logger)
+	indexerService, txIndexer, blockIndexer, indexerProgress, err := createAndStartIndexerService(config,
+		genDoc.ChainID, dbProvider, stateDB, eventBus, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -185,19 +222,41 @@ func NewNode(config *cfg.Config,
 	// external signing process.
 	if config.PrivValidatorListenAddr != "" {
 		// FIXME: we should start services inside OnStart
-		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
+		privValidator, err = createAndStartPrivValidatorSocketClient(config, genDoc.ChainID, privvalMetrics, logger)
 		if err != nil {
 			return nil, fmt.Errorf("error with private validator socket client: %w", err)
 		}
 	}
+	// If an address is provided, dial the external signing process over gRPC.
+	if config.PrivValidatorGRPCAddr != "" {
+		privValidator, err = createAndStartPrivValidatorGRPCClient(config, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator gRPC client: %w", err)
+		}
+	}
 
 	pubKey, err := privValidator.GetPubKey()
 	if err != nil {
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
 	}
+	// only a validator node signs, with a private validator which must be
+	// usable
+	var signer types.PrivValidator
+	if config.Mode == cfg.ModeValidator {
+		if pubKey == nil || len(pubKey.Bytes()) == 0 {
+			return nil, errors.New("refusing to start a validator node whose private validator has no public key")
+		}
+		if err := checkPrivValidatorWatermark(config, state, privValidator, pubKey); err != nil {
+			return nil, err
+		}
+		signer = privValidator
+	}
+	signsAlone := func(state sm.State) bool {
+		return signer != nil && onlyValidatorIsUs(state, pubKey)
+	}
 
 	// Determine whether we should attempt state sync.
-	stateSync := config.StateSync.Enable && !onlyValidatorIsUs(state, pubKey)
+	stateSync := config.StateSync.Enable && !signsAlone(state)
 	if stateSync && state.LastBlockHeight > 0 {
 		logger.Info("Found local state with non-zero height, skipping state sync")
 		stateSync = false
@@ -222,19 +281,33 @@ func NewNode(config *cfg.Config,
 
 	// Determine whether we should do block sync. This must happen after the handshake, since the
 	// app may modify the validator set, specifying ourself as the only validator.
-	blockSync := !onlyValidatorIsUs(state, pubKey)
+	// A seed node doesn't sync.
+	blockSync := config.Mode != cfg.ModeSeed && !signsAlone(state)
 
-	logNodeStartupInfo(state, pubKey, logger, consensusLogger)
+	logNodeStartupInfo(state, pubKey, config.Mode, logger, consensusLogger)
 
 	// Make MempoolReactor
-	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger)
+	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, rateLimitMetrics, logger)
 
 	// Make Evidence Reactor
-	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateStore, blockStore, logger)
+	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateStore, blockStore, rateLimitMetrics, logger)
 	if err != nil {
 		return nil, err
 	}
 
+	forkMonitor, err := createForkMonitor(config, genDoc.ChainID, state.ConsensusParams.Encoding.Canonical,
+		stateStore, blockStore, evidencePool, forkMonitorMetrics, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	pruner := createPruner(config, stateStore, blockStore, proxyApp, smMetrics, logger)
+	backfiller := createBackfiller(config, indexerProgress, stateStore, blockStore, txIndexer, blockIndexer, logger)
+	blockExecOptions := []sm.BlockExecutorOption{sm.BlockExecutorWithMetrics(smMetrics)}
+	if pruner != nil {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithPruner(pruner))
+	}
+
 	// make block executor for consensus and blocksync reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
@@ -243,7 +316,7 @@ func NewNode(config *cfg.Config,
 		mempool,
 		evidencePool,
 		blockStore,
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
 	)
 
 	// Make BlocksyncReactor. Don't start block sync if we're doing a state sync first.
@@ -252,12 +325,20 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("could not create blocksync reactor: %w", err)
 	}
 
-	// Make ConsensusReactor
+	// Make ConsensusReactor, reporting the conflicting votes to the fork
+	// monitor if enabled
+	var csEvidencePool forkmonitor.EvidencePool = evidencePool
+	if forkMonitor != nil {
+		csEvidencePool = forkMonitor
+	}
 	consensusReactor, consensusState := createConsensusReactor(
-		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, stateSync || blockSync, eventBus, consensusLogger,
+		config, state, blockExec, blockStore, mempool, csEvidencePool,
+		signer, csMetrics, stateSync || blockSync, eventBus, consensusLogger,
 	)
 
+	invariantChecker := createInvariantChecker(config, stateStore, blockStore, consensusState,
+		indexerProgress, invariantMetrics, logger)
+
 	// Set up state sync reactor, and schedule a sync if requested.
 	// FIXME The way we do phased startups (e.g. replay -> block sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
@@ -277,7 +358,10 @@ func NewNode(config *cfg.Config,
 	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
@@ -291,6 +375,15 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("could not add peers from persistent_peers field: %w", err)
 	}
 
+	reconnectPolicy := p2p.DefaultReconnectPolicy(config.P2P)
+	reconnectPolicies, err := p2p.ParseReconnectPolicies(config.P2P.PersistentPeersReconnectPolicies, reconnectPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse persistent_peers_reconnect_policies field: %w", err)
+	}
+	if err := sw.SetReconnectPolicies(reconnectPolicy, reconnectPolicies); err != nil {
+		return nil, err
+	}
+
 	err = sw.AddUnconditionalPeerIDs(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
 	if err != nil {
 		return nil, fmt.Errorf("could not add peer ids from unconditional_peer_ids field: %w", err)
@@ -301,6 +394,24 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("could not create addrbook: %w", err)
 	}
 
+	// If no external address is configured, try to map the p2p port on the
+	// gateway and advertise the resulting address once it is known.
+	var portMapper *upnp.PortMapper
+	if config.P2P.UPNP && config.P2P.ExternalAddress == "" {
+		portMapper = createPortMapper(config, nodeKey, logger)
+		if portMapper != nil {
+			portMapper.SetOnMapped(func(externalAddr string) {
+				addr, err := p2p.NewNetAddressString(p2p.IDAddressString(nodeKey.ID(), externalAddr))
+				if err != nil {
+					logger.Error("Invalid external address from gateway", "addr", externalAddr, "err", err)
+					return
+				}
+				transport.SetListenAddr(externalAddr)
+				addrBook.AddOurAddress(addr)
+			})
+		}
+	}
+
 	for _, addr := range splitAndTrimEmpty(config.P2P.BootstrapPeers, ",", " ") {
 		netAddrs, err := p2p.NewNetAddressString(addr)
 		if err != nil {
@@ -325,7 +436,7 @@ func NewNode(config *cfg.Config,
 	// If PEX is on, it should handle dialing the seeds. Otherwise the switch does it.
 	// Note we currently use the addrBook regardless at least for AddOurAddress
 	if config.P2P.PexReactor {
-		createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+		createPEXReactorAndAddToSwitch(addrBook, config, sw, rateLimitMetrics, logger)
 	}
 
 	// Add private IDs to addrbook to block those peers being added
@@ -336,27 +447,37 @@ func NewNode(config *cfg.Config,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
 
-		transport: transport,
-		sw:        sw,
-		addrBook:  addrBook,
-		nodeInfo:  nodeInfo,
-		nodeKey:   nodeKey,
-
-		stateStore:       stateStore,
-		blockStore:       blockStore,
-		bcReactor:        bcReactor,
-		mempool:          mempool,
-		consensusState:   consensusState,
-		consensusReactor: consensusReactor,
-		stateSyncReactor: stateSyncReactor,
-		stateSync:        stateSync,
-		stateSyncGenesis: state, // Shouldn't be necessary, but need a way to pass the genesis state
-		evidencePool:     evidencePool,
-		proxyApp:         proxyApp,
-		txIndexer:        txIndexer,
-		indexerService:   indexerService,
-		blockIndexer:     blockIndexer,
-		eventBus:         eventBus,
+		transport:  transport,
+		sw:         sw,
+		addrBook:   addrBook,
+		nodeKey:    nodeKey,
+		portMapper: portMapper,
+
+		stateStore:        stateStore,
+		blockStore:        blockStore,
+		pruner:            pruner,
+		dbCompactor:       compactor,
+		bcReactor:         bcReactor,
+		mempool:           mempool,
+		consensusState:    consensusState,
+		consensusReactor:  consensusReactor,
+		stateSyncReactor:  stateSyncReactor,
+		stateSync:         stateSync,
+		stateSyncGenesis:  state, // Shouldn't be necessary, but need a way to pass the genesis state
+		evidencePool:      evidencePool,
+		forkMonitor:       forkMonitor,
+		proxyApp:          proxyApp,
+		txIndexer:         txIndexer,
+		indexerService:    indexerService,
+		indexerProgress:   indexerProgress,
+		invariantChecker:  invariantChecker,
+		backfiller:        backfiller,
+		blockIndexer:      blockIndexer,
+		eventBus:          eventBus,
+		telemetryExporter: telemetryExporter,
+		profiler:          createProfiler(config, genDoc.ChainID, nodeKey, logger),
+		rpcMetrics:        rpcMetrics,
+		rateLimitMetrics:  rateLimitMetrics,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -364,9 +485,80 @@ func NewNode(config *cfg.Config,
 		option(node)
 	}
 
+	services, err := node.createServiceManager()
+	if err != nil {
+		return nil, err
+	}
+	node.services = services
+
 	return node, nil
 }
 
+// createServiceManager adds the services of the node to a manager, with their
+// dependencies. The proxy app, event bus, indexer and remote signer are
+// already running, as they are needed to set up the node, and are only
+// stopped by the manager.
+func (n *Node) createServiceManager() (*service.Manager, error) {
+	type managed struct {
+		name    string
+		service service.Service
+		options []service.ManagedOption
+	}
+	var services []managed
+	if n.telemetryExporter != nil {
+		services = append(services, managed{"telemetry", n.telemetryExporter, nil})
+	}
+	if n.profiler != nil {
+		services = append(services, managed{"profiler", n.profiler, nil})
+	}
+	services = append(services, []managed{
+		{"proxyApp", n.proxyApp, nil},
+		{"eventBus", n.eventBus, nil},
+		{"indexer", n.indexerService, []service.ManagedOption{service.DependsOn("eventBus")}},
+	}...)
+	// the switch starts the reactors, consensus included
+	switchDeps := []string{"proxyApp", "eventBus", "indexer"}
+	if pvsc, ok := n.privValidator.(service.Service); ok {
+		services = append(services, managed{"privValidator", pvsc, nil})
+		switchDeps = append(switchDeps, "privValidator")
+	}
+	if n.portMapper != nil {
+		services = append(services, managed{"portMapper", n.portMapper, nil})
+	}
+	services = append(services, managed{"switch", n.sw, []service.ManagedOption{
+		service.DependsOn(switchDeps...),
+		// consensus replays the WAL on start, which has no bound
+		service.StartTimeout(0),
+	}})
+	if n.pruner != nil {
+		services = append(services, managed{"pruner", n.pruner, []service.ManagedOption{service.DependsOn("proxyApp")}})
+	}
+	if n.dbCompactor != nil {
+		services = append(services, managed{"dbCompactor", n.dbCompactor, nil})
+	}
+	if n.forkMonitor != nil {
+		services = append(services, managed{"forkMonitor", n.forkMonitor, nil})
+	}
+	if n.backfiller != nil {
+		services = append(services, managed{"backfiller", n.backfiller, nil})
+	}
+	if n.invariantChecker != nil {
+		// the consensus WAL is opened when the switch starts
+		services = append(services, managed{"invariantChecker", n.invariantChecker, []service.ManagedOption{service.DependsOn("switch")}})
+	}
+
+	m := service.NewManager(n.Logger.With("module", "services"))
+	for _, s := range services {
+		if err := m.Add(s.name, s.service, s.options...); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := m.Order(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // OnStart starts the Node. It implements service.Service.
 func (n *Node) OnStart() error {
 	now := cmttime.Now()
@@ -378,7 +570,11 @@ func (n *Node) OnStart() error {
 
 	// run pprof server if it is enabled
 	if n.config.RPC.IsPprofEnabled() {
-		n.pprofSrv = n.startPprofServer()
+		pprofSrv, err := n.startPprofServer()
+		if err != nil {
+			return err
+		}
+		n.pprofSrv = pprofSrv
 	}
 
 	// begin prometheus metrics gathering if it is enabled
@@ -407,9 +603,8 @@ func (n *Node) OnStart() error {
 
 	n.isListening = true
 
-	// Start the switch (the P2P server).
-	err = n.sw.Start()
-	if err != nil {
+	// Start the services, the switch (the P2P server) included.
+	if err := n.services.Start(); err != nil {
 		return err
 	}
 
@@ -419,6 +614,10 @@ func (n *Node) OnStart() error {
 		return fmt.Errorf("could not dial peers from persistent_peers field: %w", err)
 	}
 
+	if n.config.Storage.RetainABCIResponses > 0 {
+		go n.pruneABCIResponses(n.config.Storage.RetainABCIResponses)
+	}
+
 	// Run state sync
 	if n.stateSync {
 		bcR, ok := n.bcReactor.(blockSyncReactor)
@@ -435,27 +634,48 @@ func (n *Node) OnStart() error {
 	return nil
 }
 
+// pruneABCIResponses deletes the ABCI responses out of the retention window
+// which the state store didn't delete on its own, i.e. the ones saved before
+// the window was set or reduced.
+func (n *Node) pruneABCIResponses(retention int64) {
+	state, err := n.stateStore.Load()
+	if err != nil {
+		n.Logger.Error("Failed to load state to prune ABCI responses", "err", err)
+		return
+	}
+	retainHeight := state.LastBlockHeight - retention + 1
+	if retainHeight <= 1 {
+		return
+	}
+	pruned, err := n.stateStore.PruneABCIResponses(retainHeight)
+	if err != nil {
+		n.Logger.Error("Failed to prune ABCI responses", "err", err)
+		return
+	}
+	if pruned > 0 {
+		n.Logger.Info("Pruned ABCI responses out of the retention window", "pruned", pruned,
+			"retainHeight", retainHeight)
+	}
+}
+
 // OnStop stops the Node. It implements service.Service.
 func (n *Node) OnStop() {
 	n.BaseService.OnStop()
 
 	n.Logger.Info("Stopping Node")
 
-	// first stop the non-reactor services
-	if err := n.eventBus.Stop(); err != nil {
-		n.Logger.Error("Error closing eventBus", "err", err)
-	}
-	if err := n.indexerService.Stop(); err != nil {
-		n.Logger.Error("Error closing indexerService", "err", err)
-	}
-
-	// now stop the reactors
-	if err := n.sw.Stop(); err != nil {
-		n.Logger.Error("Error closing switch", "err", err)
-	}
-
-	if err := n.transport.Close(); err != nil {
-		n.Logger.Error("Error closing transport", "err", err)
+	// first stop the services, in the reverse order of their dependencies,
+	// draining the node beforehand if enabled
+	stopped := true
+	if timeout := n.config.ShutdownDrainTimeout; timeout > 0 {
+		stopped = n.drainAndStop(timeout)
+	} else {
+		if err := n.services.Stop(); err != nil {
+			n.Logger.Error("Error stopping services", "err", err)
+		}
+		if err := n.transport.Close(); err != nil {
+			n.Logger.Error("Error closing transport", "err", err)
+		}
 	}
 
 	n.isListening = false
@@ -468,12 +688,6 @@ func (n *Node) OnStop() {
 		}
 	}
 
-	if pvsc, ok := n.privValidator.(service.Service); ok {
-		if err := pvsc.Stop(); err != nil {
-			n.Logger.Error("Error closing private validator", "err", err)
-		}
-	}
-
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
 			// Error from closing listeners, or context timeout:
@@ -485,6 +699,10 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Pprof HTTP server Shutdown", "err", err)
 		}
 	}
+	if !stopped {
+		// the services may still be writing to the stores
+		return
+	}
 	if n.blockStore != nil {
 		if err := n.blockStore.Close(); err != nil {
 			n.Logger.Error("problem closing blockstore", "err", err)
@@ -497,6 +715,56 @@ func (n *Node) OnStop() {
 	}
 }
 
+// drainAndStop drains the node, then stops the services, within timeout:
+//   - the RPC server rejects the new requests, and the ones in flight are
+//     waited for;
+//   - the transport stops accepting peers;
+//   - the subscribers are sent a final Shutdown event;
+//   - the pending CheckTx calls are flushed to the application;
+//   - the services are stopped: the switch stops the peers, so that no more
+//     messages are received, then the reactors, the consensus finishing the
+//     step in flight and flushing its WAL, then the event bus, which cancels
+//     the subscriptions.
+//
+// It returns false if the timeout elapsed before the services were stopped.
+func (n *Node) drainAndStop(timeout time.Duration) bool {
+	n.Logger.Info("Draining the node", "timeout", timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if n.rpcDrainer != nil {
+			if err := n.rpcDrainer.Drain(ctx); err != nil {
+				n.Logger.Error("Failed to drain the RPC requests", "err", err)
+			}
+		}
+		if err := n.transport.Close(); err != nil {
+			n.Logger.Error("Error closing transport", "err", err)
+		}
+		if err := n.eventBus.PublishEventShutdown(types.EventDataShutdown{Reason: "node shutting down"}); err != nil {
+			n.Logger.Error("Failed to publish the Shutdown event", "err", err)
+		}
+		n.mempool.Lock()
+		if err := n.mempool.FlushAppConn(); err != nil {
+			n.Logger.Error("Failed to flush the mempool connection", "err", err)
+		}
+		n.mempool.Unlock()
+		if err := n.services.Stop(); err != nil {
+			n.Logger.Error("Error stopping services", "err", err)
+		}
+	}()
+
+	select {
+	case <-stopped:
+		return true
+	case <-ctx.Done():
+		n.Logger.Error("Timed out draining the node, stopping anyway", "timeout", timeout)
+		return false
+	}
+}
+
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
 func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 	pubKey, err := n.privValidator.GetPubKey()
@@ -518,17 +786,38 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		GenDoc:           n.genesisDoc,
 		TxIndexer:        n.txIndexer,
 		BlockIndexer:     n.blockIndexer,
+		IndexerProgress:  n.indexerProgress,
+		InvariantChecker: n.invariantChecker,
 		ConsensusReactor: n.consensusReactor,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
+		MempoolConfig:    n.config.Mempool,
 
-		Logger: n.Logger.With("module", "rpc"),
+		Logger:  n.Logger.With("module", "rpc"),
+		Metrics: n.rpcMetrics,
 
 		Config: *n.config.RPC,
+
+		Services: n.services,
+	}
+	if pv, ok := n.privValidator.(interface{ IsConnected() bool }); ok {
+		rpcCoreEnv.PrivValidator = pv
+	}
+	rpcCoreEnv.ReloadConfig = func() ([]string, []string, error) {
+		reload, err := n.ReloadConfigFile()
+		if err != nil {
+			return nil, nil, err
+		}
+		return reload.Applied, reload.RequireRestart, nil
+	}
+	if genesisFile := n.config.GenesisFile(); cmtos.FileExists(genesisFile) {
+		// serve the genesis chunks from the file, rather than from memory
+		rpcCoreEnv.GenesisFile = genesisFile
 	}
 	if err := rpcCoreEnv.InitGenesisChunks(); err != nil {
 		return nil, err
 	}
+	rpcCoreEnv.InitResponseCache()
 	return &rpcCoreEnv, nil
 }
 
@@ -556,6 +845,25 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	var auth *rpcserver.Authenticator
+	if n.config.RPC.IsAuthEnabled() {
+		if auth, err = n.rpcAuthenticator(); err != nil {
+			return nil, err
+		}
+	}
+	var limiter *rpcserver.RateLimiter
+	if n.config.RPC.IsRateLimitEnabled() {
+		limiter = rpcserver.NewRateLimiter(
+			rpcserver.RateLimit{Rate: n.config.RPC.RateLimit, Burst: n.config.RPC.RateLimitBurst},
+			rpcserver.RateLimit{Rate: n.config.RPC.ExpensiveRateLimit, Burst: n.config.RPC.ExpensiveRateLimitBurst},
+			n.config.RPC.ExpensiveMethods,
+			rpcserver.RateLimiterWithMetrics(n.rateLimitMetrics),
+		)
+		n.rpcRateLimiter = limiter
+	}
+
+	n.rpcDrainer = rpcserver.NewDrainer()
+
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
@@ -573,8 +881,12 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 			rpcserver.WriteChanCapacity(n.config.RPC.WebSocketWriteBufferSize),
 		)
 		wm.SetLogger(wmLogger)
+		wm.SetMaxConnectionsPerIP(n.config.RPC.MaxWebSocketConnectionsPerIP)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
-		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger,
+			rpcserver.MaxBatchSize(n.config.RPC.MaxRequestBatchSize),
+			rpcserver.BatchConcurrency(n.config.RPC.RequestBatchConcurrency),
+		)
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config.MaxOpenConnections,
@@ -584,14 +896,25 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		}
 
 		var rootHandler http.Handler = mux
+		if limiter != nil {
+			rootHandler = rpcserver.RateLimitHandler(rootHandler, limiter)
+		}
+		if auth != nil {
+			rootHandler = rpcserver.AuthHandler(rootHandler, auth, rpcLogger)
+		}
+		if n.config.RPC.ResponseCompression {
+			rootHandler = rpcserver.CompressionHandler(rootHandler, n.config.RPC.ResponseCompressionMinSize)
+		}
 		if n.config.RPC.IsCorsEnabled() {
 			corsMiddleware := cors.New(cors.Options{
 				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
 				AllowedMethods: n.config.RPC.CORSAllowedMethods,
 				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
+				MaxAge:         int(n.config.RPC.CORSMaxAge.Seconds()),
 			})
-			rootHandler = corsMiddleware.Handler(mux)
+			rootHandler = corsMiddleware.Handler(rootHandler)
 		}
+		rootHandler = n.rpcDrainer.Handler(rootHandler)
 		if n.config.RPC.IsTLSEnabled() {
 			go func() {
 				if err := rpcserver.ServeTLS(
@@ -635,12 +958,20 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		if config.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
 			config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 		}
+		var opts []grpc.ServerOption
+		if n.config.RPC.IsGRPCTLSEnabled() {
+			creds, err := credentials.NewServerTLSFromFile(n.config.RPC.GRPCCertFile(), n.config.RPC.GRPCKeyFile())
+			if err != nil {
+				return nil, fmt.Errorf("failed to load gRPC TLS certificate: %w", err)
+			}
+			opts = append(opts, grpc.Creds(creds))
+		}
 		listener, err := rpcserver.Listen(grpcListenAddr, config.MaxOpenConnections)
 		if err != nil {
 			return nil, err
 		}
 		go func() {
-			if err := grpccore.StartGRPCServer(env, listener); err != nil {
+			if err := grpccore.StartGRPCServer(env, listener, opts...); err != nil {
 				n.Logger.Error("Error starting gRPC server", "err", err)
 			}
 		}()
@@ -673,20 +1004,60 @@ func (n *Node) startPrometheusServer() *http.Server {
 	return srv
 }
 
-// starts a ppro
-func (n *Node) startPprofServer() *http.Server {
+// rpcAuthenticator returns the authenticator of the RPC clients, whose
+// credentials are also required by the pprof endpoints if pprof_auth is set.
+func (n *Node) rpcAuthenticator() (*rpcserver.Authenticator, error) {
+	var jwtSecret []byte
+	if n.config.RPC.AuthJWTSecretFile != "" {
+		bz, err := os.ReadFile(n.config.RPC.JWTSecretFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT secret: %w", err)
+		}
+		jwtSecret = bytes.TrimSpace(bz)
+		if len(jwtSecret) == 0 {
+			return nil, errors.New("JWT secret file is empty")
+		}
+	}
+	return rpcserver.NewAuthenticator(n.config.RPC.AuthAPIKeys, jwtSecret), nil
+}
+
+// starts a pprof server, serving the pprof endpoints only, behind the
+// authentication of the RPC clients if pprof_auth is set
+func (n *Node) startPprofServer() (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	var handler http.Handler = mux
+	if n.config.RPC.PprofAuth {
+		auth, err := n.rpcAuthenticator()
+		if err != nil {
+			return nil, err
+		}
+		handler = rpcserver.AuthHandler(mux, auth, n.Logger.With("module", "pprof"))
+	}
+
 	srv := &http.Server{
 		Addr:              n.config.RPC.PprofListenAddress,
-		Handler:           nil,
+		Handler:           handler,
 		ReadHeaderTimeout: readHeaderTimeout,
 	}
+	// listen right away, so that the node fails to start if the address is
+	// taken
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("pprof server: %w", err)
+	}
 	go func() {
-		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-			// Error starting or closing listener:
-			n.Logger.Error("pprof HTTP server ListenAndServe", "err", err)
+		if err := srv.Serve(ln); err != http.ErrServerClosed {
+			// Error closing listener:
+			n.Logger.Error("pprof HTTP server Serve", "err", err)
 		}
 	}()
-	return srv
+	return srv, nil
 }
 
 // Switch returns the Node's Switch.
@@ -732,9 +1103,9 @@ func (n *Node) IsListening() bool {
 	return n.isListening
 }
 
-// NodeInfo returns the Node's Info from the Switch.
+// NodeInfo returns the Node's Info, as sent to peers in the handshake.
 func (n *Node) NodeInfo() p2p.NodeInfo {
-	return n.nodeInfo
+	return n.transport.NodeInfo()
 }
 
 func makeNodeInfo(
@@ -758,24 +1129,43 @@ func makeNodeInfo(
 		DefaultNodeID: nodeKey.ID(),
 		Network:       genDoc.ChainID,
 		Version:       version.TMCoreSemVer,
-		Channels: []byte{
-			bc.BlocksyncChannel,
-			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
-			mempl.MempoolChannel,
-			evidence.EvidenceChannel,
-			statesync.SnapshotChannel, statesync.ChunkChannel,
-		},
-		Moniker: config.Moniker,
+		Moniker:       config.Moniker,
 		Other: p2p.DefaultNodeInfoOther{
 			TxIndex:    txIndexerStatus,
 			RPCAddress: config.RPC.ListenAddress,
 		},
 	}
 
+	// a seed node only runs the peer-exchange reactor
+	if config.Mode != cfg.ModeSeed {
+		nodeInfo.Channels = []byte{
+			bc.BlocksyncChannel,
+			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
+			mempl.MempoolChannel,
+			evidence.EvidenceChannel,
+			statesync.SnapshotChannel, statesync.ChunkChannel,
+		}
+	}
 	if config.P2P.PexReactor {
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
+	if reactors := config.P2P.CompressedReactorList(); len(reactors) > 0 {
+		nodeInfo.Features |= p2p.FeatureCompression
+		nodeInfo.Compression.Algorithms = p2p.SupportedCompressionAlgorithms
+		for _, r := range reactors {
+			switch r {
+			case "blocksync":
+				nodeInfo.Compression.Channels = append(nodeInfo.Compression.Channels, bc.BlocksyncChannel)
+			case "statesync":
+				nodeInfo.Compression.Channels = append(nodeInfo.Compression.Channels,
+					statesync.SnapshotChannel, statesync.ChunkChannel)
+			case "mempool":
+				nodeInfo.Compression.Channels = append(nodeInfo.Compression.Channels, mempl.MempoolChannel)
+			}
+		}
+	}
+
 	lAddr := config.P2P.ExternalAddress
 
 	if lAddr == "" {
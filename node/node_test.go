@@ -17,6 +17,7 @@ import (
 
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/evidence"
 	"github.com/cometbft/cometbft/internal/test"
@@ -26,7 +27,9 @@ import (
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/conn"
 	p2pmock "github.com/cometbft/cometbft/p2p/mock"
+	"github.com/cometbft/cometbft/p2p/pex"
 	"github.com/cometbft/cometbft/privval"
+	privvalgrpc "github.com/cometbft/cometbft/privval/grpc"
 	"github.com/cometbft/cometbft/proxy"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/store"
@@ -77,6 +80,28 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNodeServices(t *testing.T) {
+	config := test.ResetTestRoot("node_services_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+
+	order, err := n.services.Order()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"proxyApp", "eventBus", "indexer", "switch", "backfiller"}, order)
+	// the services needed to set up the node are already running
+	assert.Equal(t, []string{"switch", "backfiller"}, n.services.NotReady())
+
+	require.NoError(t, n.Start())
+	assert.Empty(t, n.services.NotReady())
+
+	require.NoError(t, n.Stop())
+	for _, status := range n.services.Statuses() {
+		assert.False(t, status.Running, status.Name)
+	}
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
@@ -131,7 +156,7 @@ func TestNodeSetAppVersion(t *testing.T) {
 	assert.Equal(t, state.Version.Consensus.App, appVersion)
 
 	// check version is set in node info
-	assert.Equal(t, n.nodeInfo.(p2p.DefaultNodeInfo).ProtocolVersion.App, appVersion)
+	assert.Equal(t, n.NodeInfo().(p2p.DefaultNodeInfo).ProtocolVersion.App, appVersion)
 }
 
 func TestPprofServer(t *testing.T) {
@@ -157,6 +182,128 @@ func TestPprofServer(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+func TestPprofServerAuth(t *testing.T) {
+	config := test.ResetTestRoot("node_pprof_auth_test")
+	defer os.RemoveAll(config.RootDir)
+	config.RPC.PprofListenAddress = testFreeAddr(t)
+	config.RPC.PprofAuth = true
+	config.RPC.AuthAPIKeys = []string{"key"}
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer func() {
+		require.NoError(t, n.Stop())
+	}()
+
+	url := "http://" + config.RPC.PprofListenAddress + "/debug/pprof/"
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer key")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNodeDrainOnStop(t *testing.T) {
+	config := test.ResetTestRoot("node_drain_test")
+	defer os.RemoveAll(config.RootDir)
+	config.ShutdownDrainTimeout = 5 * time.Second
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+
+	sub, err := n.EventBus().Subscribe(context.Background(), "node_test", types.EventQueryShutdown)
+	require.NoError(t, err)
+	require.NoError(t, n.Stop())
+
+	// the subscribers get the Shutdown event, then the subscriptions are
+	// canceled
+	select {
+	case msg := <-sub.Out():
+		assert.Equal(t, types.EventDataShutdown{Reason: "node shutting down"}, msg.Data())
+	case <-time.After(time.Second):
+		t.Fatal("no Shutdown event")
+	}
+	<-sub.Canceled()
+}
+
+func TestNodeReloadConfig(t *testing.T) {
+	config := test.ResetTestRoot("node_reload_config_test")
+	defer os.RemoveAll(config.RootDir)
+
+	levels, err := log.ParseLevels(config.LogLevel, cfg.DefaultLogLevel)
+	require.NoError(t, err)
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	LogLevels(levels)(n)
+
+	reloaded := func() *cfg.Config {
+		c := *config
+		mempool, rpc, p2pConfig := *config.Mempool, *config.RPC, *config.P2P
+		c.Mempool, c.RPC, c.P2P = &mempool, &rpc, &p2pConfig
+		return &c
+	}
+
+	c := reloaded()
+	c.LogLevel = "consensus:debug,*:error"
+	c.Mempool.Size = 10
+	c.RPC.RateLimit = 5 // the RPC server is not running
+	c.P2P.ListenAddress = "tcp://127.0.0.1:0"
+	reload, err := n.ReloadConfig(c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log_level", "mempool.size"}, reload.Applied)
+	assert.Equal(t, []string{"p2p.laddr", "rpc.rate_limit"}, reload.RequireRestart)
+	assert.Equal(t, "consensus:debug,*:error", levels.String())
+	assert.Equal(t, config.Mempool.Size, n.config.Mempool.Size)
+
+	// only the changes since the last reload are applied again
+	reload, err = n.ReloadConfig(c)
+	require.NoError(t, err)
+	assert.Empty(t, reload.Applied)
+
+	// nothing is applied if the configuration is invalid
+	c = reloaded()
+	c.LogLevel = "consensus:verbose"
+	_, err = n.ReloadConfig(c)
+	require.Error(t, err)
+	c = reloaded()
+	c.Mempool.Size = -1
+	_, err = n.ReloadConfig(c)
+	require.Error(t, err)
+	c = reloaded()
+	c.P2P.PersistentPeersReconnectPolicies = "d51fb70907db1c6c2d5237e78379b25cf1a37ab4:backoff=linear"
+	_, err = n.ReloadConfig(c)
+	require.Error(t, err)
+	c = reloaded()
+	c.LogLevel = "p2p:debug,*:error"
+	c.P2P.PersistentPeers = "invalid@127.0.0.1:26656"
+	_, err = n.ReloadConfig(c)
+	require.Error(t, err)
+	assert.Equal(t, "consensus:debug,*:error", levels.String())
+
+	// the reconnect policies are replaced
+	c = reloaded()
+	c.P2P.PersistentPeersReconnectInterval = time.Second
+	c.P2P.PersistentPeersReconnectPolicies = "d51fb70907db1c6c2d5237e78379b25cf1a37ab4:max_attempts=0"
+	reload, err = n.ReloadConfig(c)
+	require.NoError(t, err)
+	assert.Subset(t, reload.Applied, []string{
+		"p2p.persistent_peers_reconnect_interval", "p2p.persistent_peers_reconnect_policies",
+	})
+	assert.Equal(t, time.Second, n.sw.DefaultReconnectPolicy().Interval)
+	policy := n.sw.ReconnectPolicyOf("d51fb70907db1c6c2d5237e78379b25cf1a37ab4")
+	assert.Equal(t, 0, policy.MaxAttempts)
+	assert.Equal(t, time.Second, policy.Interval)
+}
+
 func TestNodeSetPrivValTCP(t *testing.T) {
 	addr := "tcp://" + testFreeAddr(t)
 
@@ -190,6 +337,57 @@ func TestNodeSetPrivValTCP(t *testing.T) {
 	assert.IsType(t, &privval.RetrySignerClient{}, n.PrivValidator())
 }
 
+func TestNodeSetPrivValGRPC(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	config := test.ResetTestRoot("node_priv_val_grpc_test")
+	defer os.RemoveAll(config.RootDir)
+	config.BaseConfig.PrivValidatorGRPCAddr = ln.Addr().String()
+
+	privVal := types.NewMockPV()
+	server := privvalgrpc.NewServer(
+		privvalgrpc.NewSignerServer(test.DefaultTestChainID, privVal, log.TestingLogger()),
+		nil,
+	)
+	go server.Serve(ln) //nolint:errcheck // ignore for tests
+	defer server.Stop()
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.IsType(t, &privvalgrpc.SignerClient{}, n.PrivValidator())
+}
+
+func TestCheckPrivValidatorWatermark(t *testing.T) {
+	config := test.ResetTestRoot("node_priv_val_watermark_test")
+	defer os.RemoveAll(config.RootDir)
+	config.BaseConfig.PrivValidatorMaxWatermarkGap = 100
+
+	pv := privval.GenFilePV("", "")
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+	state := sm.State{
+		LastBlockHeight: 1000,
+		Validators:      types.NewValidatorSet([]*types.Validator{types.NewValidator(pubKey, 10)}),
+	}
+
+	pv.LastSignState.Height = 950
+	assert.NoError(t, checkPrivValidatorWatermark(config, state, pv, pubKey))
+
+	// e.g. restored from an old backup
+	pv.LastSignState.Height = 800
+	assert.Error(t, checkPrivValidatorWatermark(config, state, pv, pubKey))
+
+	// a validator which isn't in the validator set isn't checked
+	other := privval.GenFilePV("", "")
+	otherPubKey, err := other.GetPubKey()
+	require.NoError(t, err)
+	assert.NoError(t, checkPrivValidatorWatermark(config, state, other, otherPubKey))
+
+	config.BaseConfig.PrivValidatorMaxWatermarkGap = 0
+	assert.NoError(t, checkPrivValidatorWatermark(config, state, pv, pubKey))
+}
+
 // address without a protocol must result in error
 func TestPrivValidatorListenAddrNoProtocol(t *testing.T) {
 	addrNoPrefix := testFreeAddr(t)
@@ -258,9 +456,7 @@ func TestCreateProposalBlock(t *testing.T) {
 
 	var height int64 = 1
 	state, stateDB, privVals := state(1, height)
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	maxBytes := 16384
 	var partSize uint32 = 256
 	maxEvidenceBytes := int64(maxBytes / 2)
@@ -356,9 +552,7 @@ func TestMaxProposalBlockSize(t *testing.T) {
 
 	var height int64 = 1
 	state, stateDB, _ := state(1, height)
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	var maxBytes int64 = 16384
 	var partSize uint32 = 256
 	state.ConsensusParams.Block.MaxBytes = maxBytes
@@ -454,6 +648,63 @@ func TestNodeNewNodeCustomReactors(t *testing.T) {
 	assert.Contains(t, channels, cr.Channels[0].ID)
 }
 
+func TestNodeModes(t *testing.T) {
+	newNode := func(config *cfg.Config, pv types.PrivValidator) (*Node, error) {
+		nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+		require.NoError(t, err)
+		return NewNode(config,
+			pv,
+			nodeKey,
+			proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+			DefaultGenesisDocProviderFunc(config),
+			cfg.DefaultDBProvider,
+			DefaultMetricsProvider(config.Instrumentation),
+			log.TestingLogger(),
+		)
+	}
+
+	t.Run("validator without a public key", func(t *testing.T) {
+		config := test.ResetTestRoot("node_mode_validator_test")
+		defer os.RemoveAll(config.RootDir)
+
+		_, err := newNode(config, noPubKeyPV{types.NewMockPV()})
+		require.ErrorContains(t, err, "no public key")
+	})
+
+	t.Run("full", func(t *testing.T) {
+		config := test.ResetTestRoot("node_mode_full_test")
+		defer os.RemoveAll(config.RootDir)
+		config.Mode = cfg.ModeFull
+
+		// the only validator doesn't sign, but syncs
+		n, err := newNode(config, privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()))
+		require.NoError(t, err)
+		assert.True(t, n.consensusReactor.WaitSync())
+		assert.NotNil(t, n.Switch().Reactor("CONSENSUS"))
+	})
+
+	t.Run("seed", func(t *testing.T) {
+		config := test.ResetTestRoot("node_mode_seed_test")
+		defer os.RemoveAll(config.RootDir)
+		config.Mode = cfg.ModeSeed
+
+		n, err := newNode(config, privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()))
+		require.NoError(t, err)
+		assert.Len(t, n.Switch().Reactors(), 1)
+		assert.NotNil(t, n.Switch().Reactor("PEX"))
+		assert.Equal(t, []byte{pex.PexChannel}, []byte(n.NodeInfo().(p2p.DefaultNodeInfo).Channels))
+	})
+}
+
+// noPubKeyPV is a private validator with an empty public key.
+type noPubKeyPV struct {
+	types.MockPV
+}
+
+func (noPubKeyPV) GetPubKey() (crypto.PubKey, error) {
+	return ed25519.PubKey{}, nil
+}
+
 func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	privVals := make([]types.PrivValidator, nVals)
 	vals := make([]types.GenesisValidator, nVals)
@@ -475,9 +726,7 @@ func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 
 	// save validators to db for 2 heights
 	stateDB := dbm.NewMemDB()
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	if err := stateStore.Save(s); err != nil {
 		panic(err)
 	}
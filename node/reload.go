@@ -0,0 +1,309 @@
+package node
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/p2p"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	sm "github.com/cometbft/cometbft/state"
+)
+
+// ConfigLoader loads the configuration to reload, e.g. from the configuration
+// file along with the command line flags.
+type ConfigLoader func() (*cfg.Config, error)
+
+// LogLevels makes the log levels of the modules, by which the loggers of the
+// node are filtered, part of the configuration reloaded at runtime.
+func LogLevels(levels *log.Levels) Option {
+	return func(n *Node) { n.reloader.logLevels = levels }
+}
+
+// WithConfigLoader sets how the configuration is loaded by ReloadConfigFile.
+// By default, only the configuration file of the home directory is read.
+func WithConfigLoader(loader ConfigLoader) Option {
+	return func(n *Node) { n.reloader.loader = loader }
+}
+
+// ConfigReload is the outcome of a reload of the configuration.
+type ConfigReload struct {
+	// The fields, by their key in the configuration file (e.g.
+	// "rpc.rate_limit"), applied at runtime.
+	Applied []string
+	// The fields which differ from the running configuration, but only apply
+	// after a restart.
+	RequireRestart []string
+}
+
+type configReloader struct {
+	logLevels *log.Levels
+	loader    ConfigLoader
+
+	mtx cmtsync.Mutex
+	// the configuration last reloaded, against which the changes to apply
+	// are found, or nil if none was
+	last *cfg.Config
+}
+
+// reloadableFields are the fields of the configuration which can be applied
+// at runtime, if the corresponding subsystem is enabled.
+var reloadableFields = map[string]bool{
+	"log_level":                                   true,
+	"rpc.rate_limit":                              true,
+	"rpc.rate_limit_burst":                        true,
+	"rpc.expensive_rate_limit":                    true,
+	"rpc.expensive_rate_limit_burst":              true,
+	"rpc.expensive_methods":                       true,
+	"mempool.size":                                true,
+	"mempool.max_txs_bytes":                       true,
+	"p2p.persistent_peers":                        true,
+	"p2p.persistent_peers_reconnect_max_attempts": true,
+	"p2p.persistent_peers_reconnect_backoff":      true,
+	"p2p.persistent_peers_reconnect_interval":     true,
+	"p2p.persistent_peers_reconnect_policies":     true,
+	"storage.retain_blocks":                       true,
+	"storage.retain_duration":                     true,
+}
+
+// ReloadConfigFile loads the configuration with the loader of the node (see
+// WithConfigLoader) and reloads it.
+func (n *Node) ReloadConfigFile() (*ConfigReload, error) {
+	loader := n.reloader.loader
+	if loader == nil {
+		loader = func() (*cfg.Config, error) { return loadConfigFile(n.config.RootDir) }
+	}
+	config, err := loader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the configuration: %w", err)
+	}
+	return n.ReloadConfig(config)
+}
+
+// ReloadConfig applies the safe subset of config at runtime: the log level
+// (see LogLevels), the RPC rate limits, the mempool size, the persistent
+// peers and their reconnect policies (replacing those set through the RPC)
+// and the retention of the pruner. The other changes
+// are reported as requiring a restart.
+//
+// All the changes are checked before any is applied, so that nothing is
+// applied if one of them is invalid. Applying a checked change can still fail
+// if the node is in an unexpected state, in which case the changes applied
+// before it are kept: they are logged, and listed in the returned error.
+func (n *Node) ReloadConfig(config *cfg.Config) (*ConfigReload, error) {
+	if err := config.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	r := &n.reloader
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	last := r.last
+	if last == nil {
+		last = n.config
+	}
+
+	running, lastFields, newFields := configFields(n.config), configFields(last), configFields(config)
+	changed := func(key string) bool { return !fieldsEqual(lastFields[key], newFields[key]) }
+	report := &ConfigReload{}
+	for key, value := range newFields {
+		if !reloadableFields[key] && !fieldsEqual(running[key], value) {
+			report.RequireRestart = append(report.RequireRestart, key)
+		}
+	}
+
+	// check the changes before applying any
+	var (
+		levelsChanged            bool
+		addedPeers, removedPeers []string
+		reconnectPolicy          p2p.ReconnectPolicy
+		reconnectPolicies        map[p2p.ID]p2p.ReconnectPolicy
+		reconnectPoliciesChanged bool
+		err                      error
+	)
+	if changed("log_level") && r.logLevels != nil {
+		if err := r.logLevels.Validate(config.LogLevel); err != nil {
+			return nil, fmt.Errorf("invalid log_level: %w", err)
+		}
+		levelsChanged = true
+	}
+	if changed("p2p.persistent_peers") {
+		peers := splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " ")
+		if _, errs := p2p.NewNetAddressStrings(peers); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid p2p.persistent_peers: %w", errs[0])
+		}
+		lastPeers := make(map[string]bool)
+		for _, peer := range splitAndTrimEmpty(last.P2P.PersistentPeers, ",", " ") {
+			lastPeers[peer] = true
+		}
+		for _, peer := range peers {
+			if !lastPeers[peer] {
+				addedPeers = append(addedPeers, peer)
+			}
+			delete(lastPeers, peer)
+		}
+		for peer := range lastPeers {
+			removedPeers = append(removedPeers, peer)
+		}
+	}
+	for _, key := range []string{
+		"p2p.persistent_peers_reconnect_max_attempts", "p2p.persistent_peers_reconnect_backoff",
+		"p2p.persistent_peers_reconnect_interval", "p2p.persistent_peers_reconnect_policies",
+	} {
+		reconnectPoliciesChanged = reconnectPoliciesChanged || changed(key)
+	}
+	if reconnectPoliciesChanged {
+		reconnectPolicy = p2p.DefaultReconnectPolicy(config.P2P)
+		if err := reconnectPolicy.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid default reconnect policy: %w", err)
+		}
+		reconnectPolicies, err = p2p.ParseReconnectPolicies(config.P2P.PersistentPeersReconnectPolicies, reconnectPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid p2p.persistent_peers_reconnect_policies: %w", err)
+		}
+	}
+
+	apply := func(enabled bool, keys ...string) {
+		for _, key := range keys {
+			if !changed(key) {
+				continue
+			}
+			if enabled {
+				report.Applied = append(report.Applied, key)
+			} else {
+				report.RequireRestart = append(report.RequireRestart, key)
+			}
+		}
+	}
+
+	// the changes are valid: apply them
+	partial := func(err error) (*ConfigReload, error) {
+		sort.Strings(report.Applied)
+		n.Logger.Error("Failed to reload the configuration, partially applied", "applied", report.Applied, "err", err)
+		return nil, fmt.Errorf("configuration partially applied (%s): %w", strings.Join(report.Applied, ", "), err)
+	}
+
+	if levelsChanged {
+		if err := r.logLevels.Set(config.LogLevel); err != nil {
+			return partial(err)
+		}
+	}
+	apply(r.logLevels != nil, "log_level")
+
+	if n.rpcRateLimiter != nil {
+		n.rpcRateLimiter.SetLimits(
+			rpcserver.RateLimit{Rate: config.RPC.RateLimit, Burst: config.RPC.RateLimitBurst},
+			rpcserver.RateLimit{Rate: config.RPC.ExpensiveRateLimit, Burst: config.RPC.ExpensiveRateLimitBurst},
+			config.RPC.ExpensiveMethods,
+		)
+	}
+	apply(n.rpcRateLimiter != nil, "rpc.rate_limit", "rpc.rate_limit_burst",
+		"rpc.expensive_rate_limit", "rpc.expensive_rate_limit_burst", "rpc.expensive_methods")
+
+	mempool, ok := n.mempool.(interface{ SetLimits(int, int64) })
+	if ok {
+		mempool.SetLimits(config.Mempool.Size, config.Mempool.MaxTxsBytes)
+	}
+	apply(ok, "mempool.size", "mempool.max_txs_bytes")
+
+	if reconnectPoliciesChanged {
+		if err := n.sw.SetReconnectPolicies(reconnectPolicy, reconnectPolicies); err != nil {
+			return partial(fmt.Errorf("failed to set the reconnect policies: %w", err))
+		}
+	}
+	apply(true, "p2p.persistent_peers_reconnect_max_attempts", "p2p.persistent_peers_reconnect_backoff",
+		"p2p.persistent_peers_reconnect_interval", "p2p.persistent_peers_reconnect_policies")
+
+	if len(removedPeers) > 0 {
+		if err := n.sw.RemovePersistentPeers(removedPeers); err != nil {
+			return partial(fmt.Errorf("failed to remove the persistent peers: %w", err))
+		}
+	}
+	if len(addedPeers) > 0 {
+		if err := n.sw.AddPersistentPeers(addedPeers); err != nil {
+			return partial(fmt.Errorf("failed to add the persistent peers: %w", err))
+		}
+		if err := n.sw.DialPeersAsync(addedPeers); err != nil {
+			return partial(fmt.Errorf("failed to dial the persistent peers: %w", err))
+		}
+	}
+	apply(true, "p2p.persistent_peers")
+
+	if n.pruner != nil {
+		n.pruner.SetRetention(sm.PrunerRetention{
+			Blocks:   config.Storage.RetainBlocks,
+			Duration: config.Storage.RetainDuration,
+		})
+	}
+	apply(n.pruner != nil, "storage.retain_blocks", "storage.retain_duration")
+
+	r.last = config
+	sort.Strings(report.Applied)
+	sort.Strings(report.RequireRestart)
+	return report, nil
+}
+
+// loadConfigFile reads the configuration file of the home directory.
+func loadConfigFile(home string) (*cfg.Config, error) {
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(home, cfg.DefaultConfigDir, cfg.DefaultConfigFileName))
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	config := cfg.DefaultConfig()
+	if err := v.Unmarshal(config); err != nil {
+		return nil, err
+	}
+	config.SetRoot(home)
+	return config, nil
+}
+
+// configFields returns the values of the fields of config, by their key in
+// the configuration file, e.g. "rpc.rate_limit".
+func configFields(config *cfg.Config) map[string]interface{} {
+	fields := make(map[string]interface{})
+	addConfigFields(fields, "", reflect.ValueOf(config).Elem())
+	return fields
+}
+
+func addConfigFields(fields map[string]interface{}, prefix string, v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f, value := v.Type().Field(i), v.Field(i)
+		tag := f.Tag.Get("mapstructure")
+		if !f.IsExported() || tag == "" || tag == "home" {
+			continue
+		}
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.Struct {
+			if strings.HasSuffix(tag, ",squash") {
+				addConfigFields(fields, prefix, value)
+			} else {
+				addConfigFields(fields, prefix+tag+".", value)
+			}
+			continue
+		}
+		fields[prefix+tag] = value.Interface()
+	}
+}
+
+// fieldsEqual returns whether the values of a field are equal, an empty list
+// being equal to none.
+func fieldsEqual(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == reflect.Slice && vb.Kind() == reflect.Slice && va.Len() == 0 && vb.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
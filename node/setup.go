@@ -9,9 +9,8 @@ import (
 	"strings"
 	"time"
 
-	_ "net/http/pprof" //nolint: gosec // securely exposed on separate, optional port
-
 	dbm "github.com/cometbft/cometbft-db"
+	"google.golang.org/grpc"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/blocksync"
@@ -19,21 +18,35 @@ import (
 	cs "github.com/cometbft/cometbft/consensus"
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/evidence"
+	"github.com/cometbft/cometbft/evidence/forkmonitor"
 	"github.com/cometbft/cometbft/statesync"
 
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/libs/profiler"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	"github.com/cometbft/cometbft/libs/ratelimit"
+	"github.com/cometbft/cometbft/libs/telemetry"
 	"github.com/cometbft/cometbft/light"
+	lightprovider "github.com/cometbft/cometbft/light/provider"
+	lighthttp "github.com/cometbft/cometbft/light/provider/http"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/pex"
+	"github.com/cometbft/cometbft/p2p/upnp"
 	"github.com/cometbft/cometbft/privval"
+	privvalgrpc "github.com/cometbft/cometbft/privval/grpc"
 	"github.com/cometbft/cometbft/proxy"
+	rpccore "github.com/cometbft/cometbft/rpc/core"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/state/indexer/block"
+	"github.com/cometbft/cometbft/state/invariant"
 	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/state/txindex/null"
 	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/store/migrate"
 	"github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
 
@@ -67,8 +80,13 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
 	}
 
+	pv, err := loadOrGenFilePV(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return NewNode(config,
-		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		pv,
 		nodeKey,
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
@@ -79,12 +97,12 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 }
 
 // MetricsProvider returns a consensus, p2p and mempool Metrics.
-type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics)
+type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics, *rpccore.Metrics, *privval.Metrics, *ratelimit.Metrics, *cmtpubsub.Metrics, *forkmonitor.Metrics, *invariant.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
-	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics) {
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics, *rpccore.Metrics, *privval.Metrics, *ratelimit.Metrics, *cmtpubsub.Metrics, *forkmonitor.Metrics, *invariant.Metrics) {
 		if config.Prometheus {
 			return cs.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
@@ -92,9 +110,15 @@ func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
 				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				proxy.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				blocksync.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				statesync.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+				statesync.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				rpccore.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				privval.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				ratelimit.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				cmtpubsub.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				forkmonitor.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				invariant.PrometheusMetrics(config.Namespace, "chain_id", chainID)
 		}
-		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), proxy.NopMetrics(), blocksync.NopMetrics(), statesync.NopMetrics()
+		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), proxy.NopMetrics(), blocksync.NopMetrics(), statesync.NopMetrics(), rpccore.NopMetrics(), privval.NopMetrics(), ratelimit.NopMetrics(), cmtpubsub.NopMetrics(), forkmonitor.NopMetrics(), invariant.NopMetrics()
 	}
 }
 
@@ -110,18 +134,45 @@ func initDBs(config *cfg.Config, dbProvider cfg.DBProvider) (blockStore *store.B
 	if err != nil {
 		return
 	}
+	if err = migrate.BlockStoreSchema.Check(blockStoreDB); err != nil {
+		return
+	}
 	blockStore = store.NewBlockStore(blockStoreDB)
 
 	stateDB, err = dbProvider(&cfg.DBContext{ID: "state", Config: config})
 	if err != nil {
 		return
 	}
+	if err = migrate.StateStoreSchema.Check(stateDB); err != nil {
+		return
+	}
 
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger, metrics *proxy.Metrics) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator, metrics)
+func createAndStartProxyAppConns(
+	clientCreator proxy.ClientCreator,
+	config *cfg.Config,
+	logger log.Logger,
+	metrics *proxy.Metrics,
+) (proxy.AppConns, error) {
+	options := []proxy.AppConnsOption{proxy.WithGuard(proxy.GuardConfig{
+		Timeout:          config.ABCITimeout,
+		ConsensusTimeout: config.ABCIConsensusTimeout,
+		QueryRetries:     config.ABCIQueryRetries,
+		BreakerThreshold: config.ABCIBreakerThreshold,
+		BreakerCooldown:  config.ABCIBreakerCooldown,
+		OnUnresponsive:   config.ABCIOnUnresponsive,
+	})}
+	if path := config.ABCIRecordPath(); path != "" {
+		rec, err := proxy.NewRecorder(path)
+		if err != nil {
+			return nil, fmt.Errorf("error opening the ABCI record file: %w", err)
+		}
+		logger.Info("Recording the ABCI calls", "file", path)
+		options = append(options, proxy.WithRecorder(rec))
+	}
+	proxyApp := proxy.NewAppConns(clientCreator, metrics, options...)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -129,8 +180,50 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
-func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
-	eventBus := types.NewEventBus()
+// createAndStartTelemetryExporter returns nil if the OTLP export is disabled.
+func createAndStartTelemetryExporter(
+	config *cfg.Config,
+	chainID string,
+	nodeKey *p2p.NodeKey,
+	logger log.Logger,
+) (*telemetry.Exporter, error) {
+	if !config.Instrumentation.IsOTLPEnabled() {
+		return nil, nil
+	}
+	exporter := telemetry.NewExporter(
+		config.Instrumentation.OTLPEndpoint,
+		telemetry.Resource{ChainID: chainID, NodeID: string(nodeKey.ID()), Moniker: config.Moniker},
+		telemetry.WithExportInterval(config.Instrumentation.OTLPExportInterval),
+		telemetry.WithHeaders(config.Instrumentation.OTLPHeadersMap()),
+	)
+	exporter.SetLogger(logger.With("module", "telemetry"))
+	if err := exporter.Start(); err != nil {
+		return nil, err
+	}
+	return exporter, nil
+}
+
+// createProfiler returns nil if the periodic capture of the profiles is
+// disabled.
+func createProfiler(config *cfg.Config, chainID string, nodeKey *p2p.NodeKey, logger log.Logger) *profiler.Profiler {
+	if !config.Instrumentation.IsProfilingEnabled() {
+		return nil
+	}
+	options := []profiler.Option{profiler.WithCPUDuration(config.Instrumentation.ProfileCPUDuration)}
+	if config.Instrumentation.ProfileDir != "" {
+		options = append(options, profiler.WithDir(config.ProfileDir(), config.Instrumentation.ProfileRetain))
+	}
+	if config.Instrumentation.ProfilePushURL != "" {
+		options = append(options, profiler.WithPushURL(config.Instrumentation.ProfilePushURL,
+			map[string]string{"chain_id": chainID, "node_id": string(nodeKey.ID())}))
+	}
+	p := profiler.NewProfiler(config.Instrumentation.ProfileInterval, options...)
+	p.SetLogger(logger.With("module", "profiler"))
+	return p
+}
+
+func createAndStartEventBus(metrics *cmtpubsub.Metrics, logger log.Logger) (*types.EventBus, error) {
+	eventBus := types.NewEventBusWithOptions(cmtpubsub.WithMetrics(metrics))
 	eventBus.SetLogger(logger.With("module", "events"))
 	if err := eventBus.Start(); err != nil {
 		return nil, err
@@ -138,30 +231,59 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
+// createAndStartIndexerService also returns the progress of the indexers,
+// recorded in stateDB, or nil if indexing is disabled.
 func createAndStartIndexerService(
 	config *cfg.Config,
 	chainID string,
 	dbProvider cfg.DBProvider,
+	stateDB dbm.DB,
 	eventBus *types.EventBus,
 	logger log.Logger,
-) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
+) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, *txindex.Progress, error) {
 	var (
 		txIndexer    txindex.TxIndexer
 		blockIndexer indexer.BlockIndexer
+		progress     *txindex.Progress
 	)
 	txIndexer, blockIndexer, err := block.IndexerFromConfig(config, dbProvider, chainID)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
-	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	var options []txindex.IndexerServiceOption
+	if _, ok := txIndexer.(*null.TxIndex); !ok {
+		progress, err = txindex.NewProgress(stateDB, config.TxIndex.Indexer)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		options = append(options, txindex.IndexerServiceWithProgress(progress))
+	}
+
+	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false, options...)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 
 	if err := indexerService.Start(); err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
-	return indexerService, txIndexer, blockIndexer, nil
+	return indexerService, txIndexer, blockIndexer, progress, nil
+}
+
+func createBackfiller(
+	config *cfg.Config,
+	progress *txindex.Progress,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	txIndexer txindex.TxIndexer,
+	blockIndexer indexer.BlockIndexer,
+	logger log.Logger,
+) *txindex.Backfiller {
+	if !config.TxIndex.Backfill || progress == nil {
+		return nil
+	}
+	return txindex.NewBackfiller(progress, blockStore, stateStore, blockIndexer, txIndexer,
+		logger.With("module", "txindex"))
 }
 
 func doHandshake(
@@ -182,7 +304,7 @@ func doHandshake(
 	return nil
 }
 
-func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusLogger log.Logger) {
+func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, mode string, logger, consensusLogger log.Logger) {
 	// Log the version info.
 	logger.Info("Version info",
 		"tendermint_version", version.TMCoreSemVer,
@@ -202,9 +324,15 @@ func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusL
 
 	addr := pubKey.Address()
 	// Log whether this node is a validator or an observer
-	if state.Validators.HasAddress(addr) {
+	switch {
+	case mode != cfg.ModeValidator && state.Validators.HasAddress(addr):
+		consensusLogger.Error("The key of this node is in the validator set, but it doesn't sign",
+			"mode", mode, "addr", addr, "pubKey", pubKey)
+	case mode != cfg.ModeValidator:
+		consensusLogger.Info("This node doesn't sign", "mode", mode)
+	case state.Validators.HasAddress(addr):
 		consensusLogger.Info("This node is a validator", "addr", addr, "pubKey", pubKey)
-	} else {
+	default:
 		consensusLogger.Info("This node is not a validator", "addr", addr, "pubKey", pubKey)
 	}
 }
@@ -222,6 +350,7 @@ func createMempoolAndMempoolReactor(
 	proxyApp proxy.AppConns,
 	state sm.State,
 	memplMetrics *mempl.Metrics,
+	rateLimitMetrics *ratelimit.Metrics,
 	logger log.Logger,
 ) (mempl.Mempool, p2p.Reactor) {
 	logger = logger.With("module", "mempool")
@@ -239,6 +368,7 @@ func createMempoolAndMempoolReactor(
 	reactor := mempl.NewReactor(
 		config.Mempool,
 		mp,
+		mempl.ReactorRateLimitMetrics(rateLimitMetrics),
 	)
 	if config.Consensus.WaitForTxs() {
 		mp.EnableTxsAvailable()
@@ -248,8 +378,74 @@ func createMempoolAndMempoolReactor(
 	return mp, reactor
 }
 
+// createInvariantChecker returns the checker of the invariants between the
+// stores, if enabled.
+func createInvariantChecker(
+	config *cfg.Config,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	consensusState *cs.State,
+	indexerProgress *txindex.Progress,
+	metrics *invariant.Metrics,
+	logger log.Logger,
+) *invariant.Checker {
+	if config.Storage.InvariantCheckInterval == 0 {
+		return nil
+	}
+	options := []invariant.Option{
+		invariant.WithWAL(consensusState.GetWAL),
+		invariant.WithMetrics(metrics),
+	}
+	if indexerProgress != nil {
+		options = append(options, invariant.WithIndexerProgress(indexerProgress))
+	}
+	checker := invariant.NewChecker(stateStore, blockStore, config.Storage.InvariantCheckInterval, options...)
+	checker.SetLogger(logger.With("module", "invariant"))
+	return checker
+}
+
+// createForkMonitor returns the fork monitor, if enabled, which must be passed
+// the conflicting votes reported by consensus in place of evidencePool.
+func createForkMonitor(
+	config *cfg.Config,
+	chainID string,
+	encoding types.CanonicalEncoding,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	evidencePool *evidence.Pool,
+	metrics *forkmonitor.Metrics,
+	logger log.Logger,
+) (*forkmonitor.Monitor, error) {
+	if !config.Consensus.ForkMonitor {
+		return nil, nil
+	}
+	logger = logger.With("module", "forkmonitor")
+	options := []forkmonitor.Option{forkmonitor.WithMetrics(metrics)}
+	if witnesses := config.Consensus.ForkMonitorWitnessList(); len(witnesses) > 0 {
+		providers := make([]lightprovider.Provider, len(witnesses))
+		for i, witness := range witnesses {
+			p, err := lighthttp.New(chainID, encoding, witness)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fork monitor witness %q: %w", witness, err)
+			}
+			providers[i] = p
+		}
+		options = append(options, forkmonitor.WithWitnesses(config.Consensus.ForkMonitorInterval, providers...))
+	}
+	if config.Consensus.ForkMonitorHalt {
+		options = append(options, forkmonitor.WithHalt(func() {
+			if err := cmtos.Kill(); err != nil {
+				logger.Error("Failed to kill this process - please do so manually", "err", err)
+			}
+		}))
+	}
+	monitor := forkmonitor.NewMonitor(chainID, encoding, blockStore, stateStore, evidencePool, options...)
+	monitor.SetLogger(logger)
+	return monitor, nil
+}
+
 func createEvidenceReactor(config *cfg.Config, dbProvider cfg.DBProvider,
-	stateStore sm.Store, blockStore *store.BlockStore, logger log.Logger,
+	stateStore sm.Store, blockStore *store.BlockStore, rateLimitMetrics *ratelimit.Metrics, logger log.Logger,
 ) (*evidence.Reactor, *evidence.Pool, error) {
 	evidenceDB, err := dbProvider(&cfg.DBContext{ID: "evidence", Config: config})
 	if err != nil {
@@ -260,7 +456,7 @@ func createEvidenceReactor(config *cfg.Config, dbProvider cfg.DBProvider,
 	if err != nil {
 		return nil, nil, err
 	}
-	evidenceReactor := evidence.NewReactor(evidencePool)
+	evidenceReactor := evidence.NewReactor(evidencePool, evidence.ReactorRateLimitMetrics(rateLimitMetrics))
 	evidenceReactor.SetLogger(evidenceLogger)
 	return evidenceReactor, evidencePool, nil
 }
@@ -291,7 +487,7 @@ func createConsensusReactor(config *cfg.Config,
 	blockExec *sm.BlockExecutor,
 	blockStore sm.BlockStore,
 	mempool mempl.Mempool,
-	evidencePool *evidence.Pool,
+	evidencePool forkmonitor.EvidencePool,
 	privValidator types.PrivValidator,
 	csMetrics *cs.Metrics,
 	waitSync bool,
@@ -306,6 +502,7 @@ func createConsensusReactor(config *cfg.Config,
 		mempool,
 		evidencePool,
 		cs.StateMetrics(csMetrics),
+		cs.StateValidatorLivenessWindow(config.Instrumentation.ValidatorLivenessWindow),
 	)
 	consensusState.SetLogger(consensusLogger)
 	if privValidator != nil {
@@ -327,6 +524,7 @@ func createTransport(
 ) (
 	*p2p.MultiplexTransport,
 	[]p2p.PeerFilterFunc,
+	error,
 ) {
 	var (
 		mConnConfig = p2p.MConnConfig(config.P2P)
@@ -385,7 +583,23 @@ func createTransport(
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
 
-	return transport, peerFilters
+	if config.P2P.PrivatePeerTLS {
+		tlsConfig, err := p2p.LoadPrivatePeerTLSConfig(
+			config.P2P.PrivatePeerTLSCert(),
+			config.P2P.PrivatePeerTLSKey(),
+			config.P2P.PrivatePeerTLSCA(),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set up private peer TLS: %w", err)
+		}
+		ids := make([]p2p.ID, 0)
+		for _, id := range splitAndTrimEmpty(config.P2P.PrivatePeerIDs, ",", " ") {
+			ids = append(ids, p2p.ID(id))
+		}
+		p2p.MultiplexTransportPrivatePeerTLS(tlsConfig, ids)(transport)
+	}
+
+	return transport, peerFilters, nil
 }
 
 func createSwitch(config *cfg.Config,
@@ -408,11 +622,14 @@ func createSwitch(config *cfg.Config,
 		p2p.SwitchPeerFilters(peerFilters...),
 	)
 	sw.SetLogger(p2pLogger)
-	sw.AddReactor("MEMPOOL", mempoolReactor)
-	sw.AddReactor("BLOCKSYNC", bcReactor)
-	sw.AddReactor("CONSENSUS", consensusReactor)
-	sw.AddReactor("EVIDENCE", evidenceReactor)
-	sw.AddReactor("STATESYNC", stateSyncReactor)
+	// a seed node only runs the peer-exchange reactor, added later
+	if config.Mode != cfg.ModeSeed {
+		sw.AddReactor("MEMPOOL", mempoolReactor)
+		sw.AddReactor("BLOCKSYNC", bcReactor)
+		sw.AddReactor("CONSENSUS", consensusReactor)
+		sw.AddReactor("EVIDENCE", evidenceReactor)
+		sw.AddReactor("STATESYNC", stateSyncReactor)
+	}
 
 	sw.SetNodeInfo(nodeInfo)
 	sw.SetNodeKey(nodeKey)
@@ -448,14 +665,69 @@ func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 	return addrBook, nil
 }
 
+// createPortMapper returns the service mapping the p2p listen port on the
+// local gateway. The port is mapped in the background once the service is
+// started, so a missing gateway neither delays nor fails the node's startup.
+func createPortMapper(config *cfg.Config, nodeKey *p2p.NodeKey, logger log.Logger) *upnp.PortMapper {
+	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(nodeKey.ID(), config.P2P.ListenAddress))
+	if err != nil {
+		logger.Error("Can't map p2p port: invalid p2p.laddr", "err", err)
+		return nil
+	}
+	return upnp.NewPortMapper(int(addr.Port), upnp.DefaultLeaseDuration, logger.With("module", "upnp"))
+}
+
+// createPruner returns the background pruner of the block and state stores,
+// or nil if it's disabled.
+func createPruner(
+	config *cfg.Config,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	proxyApp proxy.AppConns,
+	metrics *sm.Metrics,
+	logger log.Logger,
+) *sm.Pruner {
+	if config.Storage.PruningInterval == 0 {
+		return nil
+	}
+	options := []sm.PrunerOption{sm.PrunerWithMetrics(metrics)}
+	if config.Storage.PruningRetainSnapshots {
+		options = append(options, sm.PrunerWithSnapshotHeight(func() (int64, error) {
+			res, err := proxyApp.Snapshot().ListSnapshotsSync(abci.RequestListSnapshots{})
+			if err != nil {
+				return 0, err
+			}
+			height := int64(0)
+			for _, snapshot := range res.Snapshots {
+				if height == 0 || int64(snapshot.Height) < height {
+					height = int64(snapshot.Height)
+				}
+			}
+			return height, nil
+		}))
+	}
+	return sm.NewPruner(
+		stateStore,
+		blockStore,
+		config.Storage.PruningInterval,
+		sm.PrunerRetention{
+			Blocks:   config.Storage.RetainBlocks,
+			Duration: config.Storage.RetainDuration,
+		},
+		logger.With("module", "pruner"),
+		options...,
+	)
+}
+
 func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
-	sw *p2p.Switch, logger log.Logger,
+	sw *p2p.Switch, rateLimitMetrics *ratelimit.Metrics, logger log.Logger,
 ) {
 	// TODO persistent peers ? so we can have their DNS addrs saved
 	pexReactor := pex.NewReactor(addrBook,
 		&pex.ReactorConfig{
 			Seeds:    splitAndTrimEmpty(config.P2P.Seeds, ",", " "),
-			SeedMode: config.P2P.SeedMode,
+			DNSSeeds: splitAndTrimEmpty(config.P2P.DNSSeeds, ",", " "),
+			SeedMode: config.Mode == cfg.ModeSeed,
 			// See consensus/reactor.go: blocksToContributeToBecomeGoodPeer 10000
 			// blocks assuming 10s blocks ~ 28 hours.
 			// TODO (melekes): make it dynamic based on the actual block latencies
@@ -463,6 +735,7 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 			// https://github.com/tendermint/tendermint/issues/3523
 			SeedDisconnectWaitPeriod:     28 * time.Hour,
 			PersistentPeersMaxDialPeriod: config.P2P.PersistentPeersMaxDialPeriod,
+			RateLimitMetrics:             rateLimitMetrics,
 		})
 	pexReactor.SetLogger(logger.With("module", "pex"))
 	sw.AddReactor("PEX", pexReactor)
@@ -481,7 +754,7 @@ func startStateSync(ssR *statesync.Reactor, bcR blockSyncReactor, conR *cs.React
 		defer cancel()
 		stateProvider, err = statesync.NewLightClientStateProvider(
 			ctx,
-			state.ChainID, state.Version, state.InitialHeight,
+			state.ChainID, state.ConsensusParams.Encoding.Canonical, state.Version, state.InitialHeight,
 			config.RPCServers, light.TrustOptions{
 				Period: config.TrustPeriod,
 				Height: config.TrustHeight,
@@ -547,9 +820,7 @@ func LoadStateFromDBOrGenesisDocProvider(
 			return sm.State{}, nil, err
 		}
 	}
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	state, err := stateStore.LoadFromDBOrGenesisDoc(genDoc)
 	if err != nil {
 		return sm.State{}, nil, err
@@ -587,17 +858,36 @@ func saveGenesisDoc(db dbm.DB, genDoc *types.GenesisDoc) error {
 	return nil
 }
 
+// loadOrGenFilePV loads the FilePV of the node, or generates it. If its key is
+// encrypted, the passphrase is read as set by the config.
+func loadOrGenFilePV(config *cfg.Config) (*privval.FilePV, error) {
+	encrypted, err := privval.IsEncryptedFilePVKey(config.PrivValidatorKeyFile())
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()), nil
+	}
+
+	passphrase, err := privval.ReadPassphrase(config.PrivValidatorKeyPassphrase(), false)
+	if err != nil {
+		return nil, err
+	}
+	return privval.LoadFilePVWithPassphrase(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), passphrase)
+}
+
 func createAndStartPrivValidatorSocketClient(
-	listenAddr,
+	config *cfg.Config,
 	chainID string,
+	metrics *privval.Metrics,
 	logger log.Logger,
 ) (types.PrivValidator, error) {
-	pve, err := privval.NewSignerListener(listenAddr, logger)
+	pve, err := privval.NewSignerListener(config.PrivValidatorListenAddr, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
 
-	pvsc, err := privval.NewSignerClient(pve, chainID)
+	pvsc, err := privval.NewSignerClient(pve, chainID, privval.SignerClientMetrics(metrics))
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
@@ -608,15 +898,73 @@ func createAndStartPrivValidatorSocketClient(
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
 	}
 
-	const (
-		retries = 50 // 50 * 100ms = 5s total
-		timeout = 100 * time.Millisecond
+	// the requests are retried until their deadline
+	const backoff = 100 * time.Millisecond
+	pvscWithRetries := privval.NewRetrySignerClient(pvsc, 0, backoff,
+		privval.RetrySignerClientDeadline(config.PrivValidatorSignDeadline, config.PrivValidatorOnDeadline),
+		privval.RetrySignerClientMetrics(metrics),
 	)
-	pvscWithRetries := privval.NewRetrySignerClient(pvsc, retries, timeout)
 
 	return pvscWithRetries, nil
 }
 
+func createAndStartPrivValidatorGRPCClient(
+	config *cfg.Config,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	var opts []grpc.DialOption
+	if config.IsPrivValidatorGRPCTLSEnabled() {
+		tlsConfig, err := privvalgrpc.LoadTLSConfig(
+			config.PrivValidatorGRPCTLSCert(),
+			config.PrivValidatorGRPCTLSKey(),
+			config.PrivValidatorGRPCTLSCA(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the TLS configuration: %w", err)
+		}
+		opts = append(opts, privvalgrpc.WithTLS(tlsConfig))
+	} else {
+		logger.Info("Connecting to the gRPC remote signer without TLS", "addr", config.PrivValidatorGRPCAddr)
+	}
+
+	pvsc := privvalgrpc.NewSignerClient(config.PrivValidatorGRPCAddr, chainID, config.PrivValidatorGRPCTimeout,
+		logger.With("module", "privval"), opts...)
+	if err := pvsc.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	// try to get a pubkey from private validate first time
+	if _, err := pvsc.GetPubKey(); err != nil {
+		_ = pvsc.Stop()
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+	return pvsc, nil
+}
+
+// checkPrivValidatorWatermark refuses to start a validator whose last sign
+// state is more than priv_validator_max_watermark_gap heights behind the latest
+// block, as it may have been restored from an old backup and sign votes
+// conflicting with the ones it already signed. Only the FilePV last sign state
+// is checked, the remote signers keeping theirs.
+func checkPrivValidatorWatermark(
+	config *cfg.Config,
+	state sm.State,
+	privValidator types.PrivValidator,
+	pubKey crypto.PubKey,
+) error {
+	pv, ok := privValidator.(*privval.FilePV)
+	if !ok || config.PrivValidatorMaxWatermarkGap == 0 || !state.Validators.HasAddress(pubKey.Address()) {
+		return nil
+	}
+	if gap := state.LastBlockHeight - pv.LastSignState.Height; gap > config.PrivValidatorMaxWatermarkGap {
+		return fmt.Errorf("the last sign state of the validator, at height %d, is %d heights behind the "+
+			"latest block, more than priv_validator_max_watermark_gap: was it restored from a backup?",
+			pv.LastSignState.Height, gap)
+	}
+	return nil
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
@@ -0,0 +1,161 @@
+package p2p
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression algorithms which can be negotiated with a peer.
+const (
+	CompressionZstd   = "zstd"
+	CompressionSnappy = "snappy"
+)
+
+// SupportedCompressionAlgorithms lists the compression algorithms supported by
+// this node, in order of preference.
+var SupportedCompressionAlgorithms = []string{CompressionZstd, CompressionSnappy}
+
+// Every message on a compressed channel is prefixed with a byte telling how
+// the rest of it is encoded.
+const (
+	compressionFrameRaw byte = iota
+	compressionFrameZstd
+	compressionFrameSnappy
+)
+
+// compressionThreshold is the size below which messages are sent
+// uncompressed, as compressing them isn't worth the CPU.
+const compressionThreshold = 1024
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecodeAllCapLimit(true))
+)
+
+// channelCompression is the result of the compression negotiation with a
+// peer: the channels which are compressed and the algorithm used to compress
+// messages sent on them.
+type channelCompression struct {
+	frame    byte
+	channels map[byte]struct{}
+}
+
+// negotiateCompression returns the compression to use with a peer, or nil if
+// no channel is to be compressed. Channels are compressed if both nodes
+// enabled compression for them, using our most preferred algorithm which the
+// peer supports.
+func negotiateCompression(ours, theirs DefaultNodeInfoCompression) *channelCompression {
+	var frame byte
+OUTER_LOOP:
+	for _, a1 := range ours.Algorithms {
+		for _, a2 := range theirs.Algorithms {
+			if a1 != a2 {
+				continue
+			}
+			switch a1 {
+			case CompressionZstd:
+				frame = compressionFrameZstd
+			case CompressionSnappy:
+				frame = compressionFrameSnappy
+			default:
+				continue
+			}
+			break OUTER_LOOP
+		}
+	}
+	if frame == compressionFrameRaw {
+		return nil
+	}
+
+	channels := make(map[byte]struct{})
+	for _, ch := range ours.Channels {
+		if bytes.IndexByte(theirs.Channels, ch) >= 0 {
+			channels[ch] = struct{}{}
+		}
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+	return &channelCompression{frame: frame, channels: channels}
+}
+
+// enabled returns true if messages on chID are compressed.
+func (c *channelCompression) enabled(chID byte) bool {
+	if c == nil {
+		return false
+	}
+	_, ok := c.channels[chID]
+	return ok
+}
+
+// compress encodes msg for a compressed channel.
+func (c *channelCompression) compress(msg []byte) []byte {
+	if len(msg) < compressionThreshold {
+		return append([]byte{compressionFrameRaw}, msg...)
+	}
+
+	var out []byte
+	switch c.frame {
+	case compressionFrameZstd:
+		out = zstdEncoder.EncodeAll(msg, []byte{compressionFrameZstd})
+	case compressionFrameSnappy:
+		out = make([]byte, 1+snappy.MaxEncodedLen(len(msg)))
+		out[0] = compressionFrameSnappy
+		out = out[:1+len(snappy.Encode(out[1:], msg))]
+	}
+	// poorly compressible data is sent as is
+	if len(out) > len(msg) {
+		return append([]byte{compressionFrameRaw}, msg...)
+	}
+	return out
+}
+
+// decompress decodes a message received on a compressed channel. It fails if
+// the decompressed message would be larger than maxSize.
+func decompress(frame []byte, maxSize int) ([]byte, error) {
+	if len(frame) == 0 {
+		return nil, errors.New("empty compressed message")
+	}
+	data := frame[1:]
+	switch frame[0] {
+	case compressionFrameRaw:
+		return data, nil
+	case compressionFrameZstd:
+		// we always write the content size, so the output buffer can be
+		// allocated (and bounded) before decompressing.
+		var h zstd.Header
+		if err := h.Decode(data); err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		if !h.HasFCS {
+			return nil, errors.New("zstd: missing frame content size")
+		}
+		if h.FrameContentSize > uint64(maxSize) {
+			return nil, fmt.Errorf("zstd: decompressed message too large (%d > %d)", h.FrameContentSize, maxSize)
+		}
+		msg, err := zstdDecoder.DecodeAll(data, make([]byte, 0, h.FrameContentSize))
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		return msg, nil
+	case compressionFrameSnappy:
+		n, err := snappy.DecodedLen(data)
+		if err != nil {
+			return nil, fmt.Errorf("snappy: %w", err)
+		}
+		if n > maxSize {
+			return nil, fmt.Errorf("snappy: decompressed message too large (%d > %d)", n, maxSize)
+		}
+		msg, err := snappy.Decode(nil, data)
+		if err != nil {
+			return nil, fmt.Errorf("snappy: %w", err)
+		}
+		return msg, nil
+	default:
+		return nil, fmt.Errorf("unknown compression frame type %d", frame[0])
+	}
+}
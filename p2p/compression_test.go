@@ -0,0 +1,146 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+)
+
+func TestNegotiateCompression(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ours, theirs  DefaultNodeInfoCompression
+		expectFrame   byte
+		expectEnabled []byte
+	}{
+		{
+			"peer without compression",
+			DefaultNodeInfoCompression{Channels: []byte{0x40}, Algorithms: SupportedCompressionAlgorithms},
+			DefaultNodeInfoCompression{},
+			compressionFrameRaw, nil,
+		},
+		{
+			"our preference wins",
+			DefaultNodeInfoCompression{Channels: []byte{0x40, 0x61}, Algorithms: []string{"zstd", "snappy"}},
+			DefaultNodeInfoCompression{Channels: []byte{0x40}, Algorithms: []string{"snappy", "zstd"}},
+			compressionFrameZstd, []byte{0x40},
+		},
+		{
+			"common algorithm",
+			DefaultNodeInfoCompression{Channels: []byte{0x40}, Algorithms: []string{"zstd", "snappy"}},
+			DefaultNodeInfoCompression{Channels: []byte{0x40}, Algorithms: []string{"lz4", "snappy"}},
+			compressionFrameSnappy, []byte{0x40},
+		},
+		{
+			"unknown algorithm only",
+			DefaultNodeInfoCompression{Channels: []byte{0x40}, Algorithms: []string{"lz4"}},
+			DefaultNodeInfoCompression{Channels: []byte{0x40}, Algorithms: []string{"lz4"}},
+			compressionFrameRaw, nil,
+		},
+		{
+			"no common channel",
+			DefaultNodeInfoCompression{Channels: []byte{0x40}, Algorithms: SupportedCompressionAlgorithms},
+			DefaultNodeInfoCompression{Channels: []byte{0x61}, Algorithms: SupportedCompressionAlgorithms},
+			compressionFrameRaw, nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := negotiateCompression(tc.ours, tc.theirs)
+			if tc.expectFrame == compressionFrameRaw {
+				assert.Nil(t, c)
+				assert.False(t, c.enabled(0x40))
+				return
+			}
+			require.NotNil(t, c)
+			assert.Equal(t, tc.expectFrame, c.frame)
+			assert.Len(t, c.channels, len(tc.expectEnabled))
+			for _, ch := range tc.expectEnabled {
+				assert.True(t, c.enabled(ch))
+			}
+		})
+	}
+}
+
+func TestCompressionRoundTrip(t *testing.T) {
+	small := []byte("hello")
+	large := bytes.Repeat([]byte("compressible "), 1000)
+	random := cmtrand.Bytes(4096)
+
+	for _, frame := range []byte{compressionFrameZstd, compressionFrameSnappy} {
+		c := &channelCompression{frame: frame}
+		for _, msg := range [][]byte{small, large, random} {
+			enc := c.compress(msg)
+			switch {
+			case len(msg) < compressionThreshold, bytes.Equal(msg, random):
+				assert.Equal(t, compressionFrameRaw, enc[0])
+			default:
+				assert.Equal(t, frame, enc[0])
+				assert.Less(t, len(enc), len(msg))
+			}
+
+			dec, err := decompress(enc, len(msg))
+			require.NoError(t, err)
+			assert.Equal(t, msg, dec)
+		}
+	}
+}
+
+func TestDecompressLimits(t *testing.T) {
+	large := bytes.Repeat([]byte("compressible "), 1000)
+
+	for _, frame := range []byte{compressionFrameZstd, compressionFrameSnappy} {
+		c := &channelCompression{frame: frame}
+		_, err := decompress(c.compress(large), len(large)-1)
+		assert.Error(t, err)
+	}
+
+	_, err := decompress(nil, 100)
+	assert.Error(t, err)
+	_, err = decompress([]byte{0xff, 0x01}, 100)
+	assert.Error(t, err)
+	_, err = decompress([]byte{compressionFrameZstd, 0x01, 0x02}, 100)
+	assert.Error(t, err)
+}
+
+func TestTransportNegotiatesCompression(t *testing.T) {
+	listener, listenerID := newTestTLSTransport("listener")
+	dialer, _ := newTestTLSTransport("dialer")
+	for _, mt := range []*MultiplexTransport{listener, dialer} {
+		ni := mt.nodeInfo.(DefaultNodeInfo)
+		ni.Features = FeatureCompression
+		ni.Compression = DefaultNodeInfoCompression{
+			Channels:   []byte{testCh},
+			Algorithms: SupportedCompressionAlgorithms,
+		}
+		mt.nodeInfo = ni
+	}
+
+	addr, err := NewNetAddressString(IDAddressString(listenerID, "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, listener.Listen(*addr))
+	defer listener.Close()
+
+	laddr := NewNetAddress(listenerID, listener.listener.Addr())
+	peerc := make(chan Peer, 1)
+	go func() {
+		p, err := dialer.Dial(*laddr, peerConfig{})
+		require.NoError(t, err)
+		peerc <- p
+	}()
+
+	p, err := listener.Accept(peerConfig{})
+	require.NoError(t, err)
+	for _, p := range []Peer{p, <-peerc} {
+		assert.Equal(t, FeatureCompression, p.Features())
+		c := p.(*peer).compression
+		assert.True(t, c.enabled(testCh))
+		assert.Equal(t, compressionFrameZstd, c.frame)
+	}
+}
@@ -81,6 +81,7 @@ type MConnection struct {
 	conn          net.Conn
 	bufConnReader *bufio.Reader
 	bufConnWriter *bufio.Writer
+	protoWriter   protoio.Writer // writes the packets to bufConnWriter
 	sendMonitor   *flow.Monitor
 	recvMonitor   *flow.Monitor
 	send          chan struct{}
@@ -111,6 +112,10 @@ type MConnection struct {
 	pongTimer     *time.Timer
 	pongTimeoutCh chan bool // true - timeout, false - peer sent pong
 
+	pingSentAt int64 // atomic; time of the ping awaiting a pong in ns, 0 if none
+	rtt        int64 // atomic; smoothed round-trip time of the pings in ns
+	lastRTT    int64 // atomic; round-trip time of the last ping in ns
+
 	chStatsTimer *time.Ticker // update channel stats periodically
 
 	created time.Time // time of creation
@@ -188,6 +193,7 @@ func NewMConnectionWithConfig(
 		config:        config,
 		created:       time.Now(),
 	}
+	mconn.protoWriter = protoio.NewDelimitedWriter(mconn.bufConnWriter)
 
 	// Create channels
 	var channelsIdx = map[byte]*Channel{}
@@ -422,7 +428,7 @@ func (c *MConnection) CanSend(chID byte) bool {
 func (c *MConnection) sendRoutine() {
 	defer c._recover()
 
-	protoWriter := protoio.NewDelimitedWriter(c.bufConnWriter)
+	protoWriter := c.protoWriter
 
 FOR_LOOP:
 	for {
@@ -446,6 +452,7 @@ FOR_LOOP:
 				break SELECTION
 			}
 			c.sendMonitor.Update(_n)
+			atomic.StoreInt64(&c.pingSentAt, time.Now().UnixNano())
 			c.Logger.Debug("Starting pong timer", "dur", c.config.PongTimeout)
 			c.pongTimer = time.AfterFunc(c.config.PongTimeout, func() {
 				select {
@@ -542,7 +549,7 @@ func (c *MConnection) sendPacketMsg() bool {
 	// c.Logger.Info("Found a msgPacket to send")
 
 	// Make & send a PacketMsg from this channel
-	_n, err := leastChannel.writePacketMsgTo(c.bufConnWriter)
+	_n, err := leastChannel.writePacketMsgTo(c.protoWriter)
 	if err != nil {
 		c.Logger.Error("Failed to write PacketMsg", "err", err)
 		c.stopForError(err)
@@ -619,6 +626,9 @@ FOR_LOOP:
 			}
 		case *tmp2p.Packet_PacketPong:
 			c.Logger.Debug("Receive Pong")
+			if sentAt := atomic.SwapInt64(&c.pingSentAt, 0); sentAt != 0 {
+				c.updateRTT(time.Duration(time.Now().UnixNano() - sentAt))
+			}
 			select {
 			case c.pongTimeoutCh <- false:
 			default:
@@ -634,6 +644,7 @@ FOR_LOOP:
 				break FOR_LOOP
 			}
 
+			channel.recvMonitor.Update(_n)
 			msgBytes, err := channel.recvPacketMsg(*pkt.PacketMsg)
 			if err != nil {
 				if c.IsRunning() {
@@ -670,6 +681,23 @@ func (c *MConnection) stopPongTimer() {
 	}
 }
 
+// updateRTT records the round-trip time of a ping. The smoothed round-trip
+// time is an exponential moving average with a weight of 1/8, as for TCP.
+// Only called by recvRoutine.
+func (c *MConnection) updateRTT(rtt time.Duration) {
+	if rtt < 0 {
+		rtt = 0
+	}
+	atomic.StoreInt64(&c.lastRTT, int64(rtt))
+	srtt := atomic.LoadInt64(&c.rtt)
+	if srtt == 0 {
+		srtt = int64(rtt)
+	} else {
+		srtt += (int64(rtt) - srtt) / 8
+	}
+	atomic.StoreInt64(&c.rtt, srtt)
+}
+
 // maxPacketMsgSize returns a maximum size of PacketMsg
 func (c *MConnection) maxPacketMsgSize() int {
 	bz, err := proto.Marshal(mustWrapPacket(&tmp2p.PacketMsg{
@@ -687,7 +715,11 @@ type ConnectionStatus struct {
 	Duration    time.Duration
 	SendMonitor flow.Status
 	RecvMonitor flow.Status
-	Channels    []ChannelStatus
+	// Smoothed and last round-trip times of the pings, 0 until the first pong
+	// is received.
+	RTT      time.Duration
+	LastRTT  time.Duration
+	Channels []ChannelStatus
 }
 
 type ChannelStatus struct {
@@ -696,6 +728,9 @@ type ChannelStatus struct {
 	SendQueueSize     int
 	Priority          int
 	RecentlySent      int64
+	// Current send and receive rates of the channel, in bytes per second.
+	SendRate int64
+	RecvRate int64
 }
 
 func (c *MConnection) Status() ConnectionStatus {
@@ -703,6 +738,8 @@ func (c *MConnection) Status() ConnectionStatus {
 	status.Duration = time.Since(c.created)
 	status.SendMonitor = c.sendMonitor.Status()
 	status.RecvMonitor = c.recvMonitor.Status()
+	status.RTT = time.Duration(atomic.LoadInt64(&c.rtt))
+	status.LastRTT = time.Duration(atomic.LoadInt64(&c.lastRTT))
 	status.Channels = make([]ChannelStatus, len(c.channels))
 	for i, channel := range c.channels {
 		status.Channels[i] = ChannelStatus{
@@ -711,6 +748,8 @@ func (c *MConnection) Status() ConnectionStatus {
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
+			SendRate:          channel.sendMonitor.Status().CurRate,
+			RecvRate:          channel.recvMonitor.Status().CurRate,
 		}
 	}
 	return status
@@ -751,6 +790,8 @@ type Channel struct {
 	recving       []byte
 	sending       []byte
 	recentlySent  int64 // exponential moving average
+	sendMonitor   *flow.Monitor
+	recvMonitor   *flow.Monitor
 
 	maxPacketMsgPayloadSize int
 
@@ -767,6 +808,8 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 		desc:                    desc,
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
+		sendMonitor:             flow.New(0, 0),
+		recvMonitor:             flow.New(0, 0),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 	}
 }
@@ -844,10 +887,11 @@ func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
 
 // Writes next PacketMsg to w and updates c.recentlySent.
 // Not goroutine-safe
-func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, err error) {
+func (ch *Channel) writePacketMsgTo(w protoio.Writer) (n int, err error) {
 	packet := ch.nextPacketMsg()
-	n, err = protoio.NewDelimitedWriter(w).WriteMsg(mustWrapPacket(&packet))
+	n, err = w.WriteMsg(mustWrapPacket(&packet))
 	atomic.AddInt64(&ch.recentlySent, int64(n))
+	ch.sendMonitor.Update(n)
 	return
 }
 
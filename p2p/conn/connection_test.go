@@ -163,6 +163,67 @@ func TestMConnectionStatus(t *testing.T) {
 	assert.Zero(t, status.Channels[0].SendQueueSize)
 }
 
+func TestMConnectionStatusRTT(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	mconn := createTestMConnection(client)
+	err := mconn.Start()
+	require.Nil(t, err)
+	defer mconn.Stop() //nolint:errcheck // ignore for tests
+
+	assert.Zero(t, mconn.Status().RTT)
+
+	// answer the pings after a delay
+	delay := 20 * time.Millisecond
+	go func() {
+		protoReader := protoio.NewDelimitedReader(server, maxPingPongPacketSize)
+		protoWriter := protoio.NewDelimitedWriter(server)
+		for {
+			var pkt tmp2p.Packet
+			if _, err := protoReader.ReadMsg(&pkt); err != nil {
+				return
+			}
+			time.Sleep(delay)
+			if _, err := protoWriter.WriteMsg(mustWrapPacket(&tmp2p.PacketPong{})); err != nil {
+				return
+			}
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		return mconn.Status().LastRTT > 0
+	}, time.Second, 10*time.Millisecond)
+	status := mconn.Status()
+	assert.GreaterOrEqual(t, status.LastRTT, delay)
+	assert.GreaterOrEqual(t, status.RTT, delay)
+	assert.True(t, mconn.IsRunning())
+}
+
+func TestMConnectionStatusChannelRates(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	mconn1 := createTestMConnection(client)
+	err := mconn1.Start()
+	require.Nil(t, err)
+	defer mconn1.Stop() //nolint:errcheck // ignore for tests
+
+	mconn2 := createTestMConnection(server)
+	err = mconn2.Start()
+	require.Nil(t, err)
+	defer mconn2.Stop() //nolint:errcheck // ignore for tests
+
+	assert.True(t, mconn2.Send(0x01, []byte("Quicksilver")))
+
+	require.Eventually(t, func() bool {
+		return mconn2.Status().Channels[0].SendRate > 0 && mconn1.Status().Channels[0].RecvRate > 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Zero(t, mconn2.Status().Channels[0].RecvRate)
+}
+
 func TestMConnectionPongTimeoutResultsInError(t *testing.T) {
 	server, client := net.Pipe()
 	defer server.Close()
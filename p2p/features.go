@@ -0,0 +1,64 @@
+package p2p
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Features is a bitmap of optional protocol features, advertised in
+// DefaultNodeInfo during the handshake. A feature is enabled on a connection
+// only if both nodes advertise it, which lets new protocol features roll out
+// without breaking compatibility with older nodes.
+//
+// Bits are never reused: a retired feature keeps its bit reserved.
+type Features uint64
+
+const (
+	// FeatureVoteAggregation indicates support for aggregated votes.
+	FeatureVoteAggregation Features = 1 << iota
+	// FeatureCompactBlocks indicates support for compact block propagation.
+	FeatureCompactBlocks
+	// FeatureCompression indicates support for per-channel message
+	// compression. See DefaultNodeInfoCompression.
+	FeatureCompression
+	// FeatureQUIC indicates support for upgrading the connection to QUIC.
+	FeatureQUIC
+)
+
+var featureNames = []struct {
+	feature Features
+	name    string
+}{
+	{FeatureVoteAggregation, "vote_aggregation"},
+	{FeatureCompactBlocks, "compact_blocks"},
+	{FeatureCompression, "compression"},
+	{FeatureQUIC, "quic"},
+}
+
+// Has returns true if all the features in f2 are set in f.
+func (f Features) Has(f2 Features) bool {
+	return f&f2 == f2
+}
+
+// Intersect returns the features supported by both f and f2.
+func (f Features) Intersect(f2 Features) Features {
+	return f & f2
+}
+
+// String returns the names of the features, separated by "|".
+func (f Features) String() string {
+	if f == 0 {
+		return "none"
+	}
+	var names []string
+	for _, fn := range featureNames {
+		if f.Has(fn.feature) {
+			names = append(names, fn.name)
+			f &^= fn.feature
+		}
+	}
+	if f != 0 {
+		names = append(names, fmt.Sprintf("%#x", uint64(f)))
+	}
+	return strings.Join(names, "|")
+}
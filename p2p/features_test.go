@@ -0,0 +1,33 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+func TestFeatures(t *testing.T) {
+	ours := FeatureVoteAggregation | FeatureCompression
+	theirs := FeatureCompression | FeatureQUIC | Features(1<<40)
+
+	negotiated := ours.Intersect(theirs)
+	assert.Equal(t, FeatureCompression, negotiated)
+	assert.True(t, negotiated.Has(FeatureCompression))
+	assert.False(t, negotiated.Has(FeatureVoteAggregation))
+	assert.False(t, ours.Has(FeatureVoteAggregation|FeatureQUIC))
+
+	assert.Equal(t, "none", Features(0).String())
+	assert.Equal(t, "vote_aggregation|compression", ours.String())
+	assert.Equal(t, "compression|quic|0x10000000000", theirs.String())
+}
+
+func TestNodeInfoFeaturesRoundTrip(t *testing.T) {
+	ni := testNodeInfo(PubKeyToID(ed25519.GenPrivKey().PubKey()), "node").(DefaultNodeInfo)
+	ni.Features = FeatureCompactBlocks | FeatureQUIC
+
+	ni2, err := DefaultNodeInfoFromToProto(ni.ToProto())
+	assert.NoError(t, err)
+	assert.Equal(t, ni.Features, ni2.Features)
+}
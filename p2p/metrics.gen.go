@@ -32,12 +32,60 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "peer_send_bytes_total",
 			Help:      "Number of bytes sent to a given peer.",
 		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerReceiveMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_receive_messages_total",
+			Help:      "Number of messages received from a given peer.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerSendMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_messages_total",
+			Help:      "Number of messages sent to a given peer.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerSendFailuresTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_failures_total",
+			Help:      "Number of messages which could not be queued for sending to a given peer, because the channel's send queue was full.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
 		PeerPendingSendBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "peer_pending_send_bytes",
 			Help:      "Pending bytes to be sent to a given peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerChannelSendQueueSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_send_queue_size",
+			Help:      "Number of messages queued for sending to a given peer, per channel.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerChannelSendQueueSaturation: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_send_queue_saturation",
+			Help:      "Fraction of the channel's send queue capacity in use, between 0 and 1. A channel close to 1 is congesting the connection.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerRTTSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_rtt_seconds",
+			Help:      "Smoothed round-trip time of the pings sent to a given peer, in seconds.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerChannelSendRate: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_send_rate",
+			Help:      "Current rate at which bytes are sent to a given peer, per channel, in bytes per second.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerChannelReceiveRate: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_receive_rate",
+			Help:      "Current rate at which bytes are received from a given peer, per channel, in bytes per second.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
 		NumTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -61,12 +109,20 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                    discard.NewGauge(),
-		PeerReceiveBytesTotal:    discard.NewCounter(),
-		PeerSendBytesTotal:       discard.NewCounter(),
-		PeerPendingSendBytes:     discard.NewGauge(),
-		NumTxs:                   discard.NewGauge(),
-		MessageReceiveBytesTotal: discard.NewCounter(),
-		MessageSendBytesTotal:    discard.NewCounter(),
+		Peers:                          discard.NewGauge(),
+		PeerReceiveBytesTotal:          discard.NewCounter(),
+		PeerSendBytesTotal:             discard.NewCounter(),
+		PeerReceiveMessagesTotal:       discard.NewCounter(),
+		PeerSendMessagesTotal:          discard.NewCounter(),
+		PeerSendFailuresTotal:          discard.NewCounter(),
+		PeerPendingSendBytes:           discard.NewGauge(),
+		PeerChannelSendQueueSize:       discard.NewGauge(),
+		PeerChannelSendQueueSaturation: discard.NewGauge(),
+		PeerRTTSeconds:                 discard.NewGauge(),
+		PeerChannelSendRate:            discard.NewGauge(),
+		PeerChannelReceiveRate:         discard.NewGauge(),
+		NumTxs:                         discard.NewGauge(),
+		MessageReceiveBytesTotal:       discard.NewCounter(),
+		MessageSendBytesTotal:          discard.NewCounter(),
 	}
 }
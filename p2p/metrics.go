@@ -32,8 +32,28 @@ type Metrics struct {
 	PeerReceiveBytesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
 	// Number of bytes sent to a given peer.
 	PeerSendBytesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
+	// Number of messages received from a given peer.
+	PeerReceiveMessagesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
+	// Number of messages sent to a given peer.
+	PeerSendMessagesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
+	// Number of messages which could not be queued for sending to a given
+	// peer, because the channel's send queue was full.
+	PeerSendFailuresTotal metrics.Counter `metrics_labels:"peer_id,chID"`
 	// Pending bytes to be sent to a given peer.
 	PeerPendingSendBytes metrics.Gauge `metrics_labels:"peer_id"`
+	// Number of messages queued for sending to a given peer, per channel.
+	PeerChannelSendQueueSize metrics.Gauge `metrics_labels:"peer_id,chID"`
+	// Fraction of the channel's send queue capacity in use, between 0 and 1.
+	// A channel close to 1 is congesting the connection.
+	PeerChannelSendQueueSaturation metrics.Gauge `metrics_labels:"peer_id,chID"`
+	// Smoothed round-trip time of the pings sent to a given peer, in seconds.
+	PeerRTTSeconds metrics.Gauge `metrics_name:"peer_rtt_seconds" metrics_labels:"peer_id"`
+	// Current rate at which bytes are sent to a given peer, per channel, in
+	// bytes per second.
+	PeerChannelSendRate metrics.Gauge `metrics_labels:"peer_id,chID"`
+	// Current rate at which bytes are received from a given peer, per channel,
+	// in bytes per second.
+	PeerChannelReceiveRate metrics.Gauge `metrics_labels:"peer_id,chID"`
 	// Number of transactions submitted by each peer.
 	NumTxs metrics.Gauge `metrics_labels:"peer_id"`
 	// Number of bytes of each message type received.
@@ -51,6 +51,7 @@ func (mp *Peer) NodeInfo() p2p.NodeInfo {
 		ListenAddr:    mp.addr.DialString(),
 	}
 }
+func (mp *Peer) Features() p2p.Features        { return 0 }
 func (mp *Peer) Status() conn.ConnectionStatus { return conn.ConnectionStatus{} }
 func (mp *Peer) ID() p2p.ID                    { return mp.id }
 func (mp *Peer) IsOutbound() bool              { return mp.Outbound }
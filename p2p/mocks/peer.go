@@ -32,6 +32,20 @@ func (_m *Peer) CloseConn() error {
 	return r0
 }
 
+// Features provides a mock function with given fields:
+func (_m *Peer) Features() p2p.Features {
+	ret := _m.Called()
+
+	var r0 p2p.Features
+	if rf, ok := ret.Get(0).(func() p2p.Features); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(p2p.Features)
+	}
+
+	return r0
+}
+
 // FlushStop provides a mock function with given fields:
 func (_m *Peer) FlushStop() {
 	_m.Called()
@@ -15,6 +15,8 @@ import (
 const (
 	maxNodeInfoSize = 10240 // 10KB
 	maxNumChannels  = 16    // plenty of room for upgrades, for now
+
+	maxNumCompressionAlgorithms = 8
 )
 
 // Max size of the NodeInfo struct
@@ -93,6 +95,12 @@ type DefaultNodeInfo struct {
 	// ASCIIText fields
 	Moniker string               `json:"moniker"` // arbitrary moniker
 	Other   DefaultNodeInfoOther `json:"other"`   // other application specific data
+
+	// Compression capabilities, used to negotiate per-channel compression.
+	Compression DefaultNodeInfoCompression `json:"compression"`
+
+	// Optional protocol features supported by the node.
+	Features Features `json:"features"`
 }
 
 // DefaultNodeInfoOther is the misc. applcation specific data
@@ -101,6 +109,13 @@ type DefaultNodeInfoOther struct {
 	RPCAddress string `json:"rpc_address"`
 }
 
+// DefaultNodeInfoCompression lists the channels a node is willing to compress
+// and the compression algorithms it supports, in order of preference.
+type DefaultNodeInfoCompression struct {
+	Channels   cmtbytes.HexBytes `json:"channels"`
+	Algorithms []string          `json:"algorithms"`
+}
+
 // ID returns the node's peer ID.
 func (info DefaultNodeInfo) ID() ID {
 	return info.DefaultNodeID
@@ -170,6 +185,23 @@ func (info DefaultNodeInfo) Validate() error {
 		return fmt.Errorf("info.Other.RPCAddress=%v must be valid ASCII text without tabs", rpcAddr)
 	}
 
+	// Validate Compression. Unknown algorithms are allowed so that new ones
+	// can be introduced, they're simply never negotiated.
+	compression := info.Compression
+	if len(compression.Channels) > maxNumChannels {
+		return fmt.Errorf("info.Compression.Channels is too long (%v). Max is %v",
+			len(compression.Channels), maxNumChannels)
+	}
+	if len(compression.Algorithms) > maxNumCompressionAlgorithms {
+		return fmt.Errorf("info.Compression.Algorithms is too long (%v). Max is %v",
+			len(compression.Algorithms), maxNumCompressionAlgorithms)
+	}
+	for _, algo := range compression.Algorithms {
+		if !cmtstrings.IsASCIIText(algo) {
+			return fmt.Errorf("info.Compression.Algorithms contains invalid algorithm %q", algo)
+		}
+	}
+
 	return nil
 }
 
@@ -246,6 +278,11 @@ func (info DefaultNodeInfo) ToProto() *tmp2p.DefaultNodeInfo {
 		TxIndex:    info.Other.TxIndex,
 		RPCAddress: info.Other.RPCAddress,
 	}
+	dni.Compression = tmp2p.DefaultNodeInfoCompression{
+		Channels:   info.Compression.Channels,
+		Algorithms: info.Compression.Algorithms,
+	}
+	dni.Features = uint64(info.Features)
 
 	return dni
 }
@@ -270,6 +307,11 @@ func DefaultNodeInfoFromToProto(pb *tmp2p.DefaultNodeInfo) (DefaultNodeInfo, err
 			TxIndex:    pb.Other.TxIndex,
 			RPCAddress: pb.Other.RPCAddress,
 		},
+		Compression: DefaultNodeInfoCompression{
+			Channels:   pb.Compression.Channels,
+			Algorithms: pb.Compression.Algorithms,
+		},
+		Features: Features(pb.Features),
 	}
 
 	return dni, nil
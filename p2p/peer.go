@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"reflect"
@@ -11,6 +12,7 @@ import (
 	"github.com/cometbft/cometbft/libs/cmap"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/libs/telemetry"
 
 	cmtconn "github.com/cometbft/cometbft/p2p/conn"
 )
@@ -34,6 +36,7 @@ type Peer interface {
 	CloseConn() error // close original connection
 
 	NodeInfo() NodeInfo // peer's info
+	Features() Features // features supported by both the peer and us
 	Status() cmtconn.ConnectionStatus
 	SocketAddr() *NetAddress // actual address of the socket
 
@@ -125,6 +128,12 @@ type peer struct {
 	metricsTicker *time.Ticker
 	mlc           *metricsLabelCache
 
+	// features negotiated with this peer
+	features Features
+
+	// channels compressed with this peer, nil if none
+	compression *channelCompression
+
 	// When removal of a peer fails, we set this flag
 	removalAttemptFailed bool
 }
@@ -242,6 +251,13 @@ func (p *peer) NodeInfo() NodeInfo {
 	return p.nodeInfo
 }
 
+// Features returns the optional protocol features supported by both the peer
+// and us. Reactors use it to decide whether to speak a newer protocol with
+// the peer.
+func (p *peer) Features() Features {
+	return p.features
+}
+
 // SocketAddr returns the address of the socket.
 // For outbound peers, it's the address dialed (after DNS resolution).
 // For inbound peers, it's the address returned by the underlying connection
@@ -274,6 +290,9 @@ func (p *peer) send(chID byte, msg proto.Message, sendFunc func(byte, []byte) bo
 		return false
 	}
 	metricLabelValue := p.mlc.ValueToMetricLabel(msg)
+	_, span := telemetry.StartSpan(context.Background(), "p2p.send", telemetry.SpanKindProducer,
+		"peer_id", string(p.ID()), "channel_id", chID, "message_type", metricLabelValue)
+	defer span.End()
 	if w, ok := msg.(Wrapper); ok {
 		msg = w.Wrap()
 	}
@@ -282,14 +301,21 @@ func (p *peer) send(chID byte, msg proto.Message, sendFunc func(byte, []byte) bo
 		p.Logger.Error("marshaling message to send", "error", err)
 		return false
 	}
+	if p.compression.enabled(chID) {
+		msgBytes = p.compression.compress(msgBytes)
+	}
 	res := sendFunc(chID, msgBytes)
+	labels := []string{
+		"peer_id", string(p.ID()),
+		"chID", fmt.Sprintf("%#x", chID),
+	}
+	span.SetAttributes("bytes", len(msgBytes), "queued", res)
 	if res {
-		labels := []string{
-			"peer_id", string(p.ID()),
-			"chID", fmt.Sprintf("%#x", chID),
-		}
+		p.metrics.PeerSendMessagesTotal.With(labels...).Add(1)
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
 		p.metrics.MessageSendBytesTotal.With("message_type", metricLabelValue).Add(float64(len(msgBytes)))
+	} else {
+		p.metrics.PeerSendFailuresTotal.With(labels...).Add(1)
 	}
 	return res
 }
@@ -367,6 +393,18 @@ func PeerMetrics(metrics *Metrics) PeerOption {
 	}
 }
 
+func peerFeatures(f Features) PeerOption {
+	return func(p *peer) {
+		p.features = f
+	}
+}
+
+func peerCompression(c *channelCompression) PeerOption {
+	return func(p *peer) {
+		p.compression = c
+	}
+}
+
 func (p *peer) metricsReporter() {
 	for {
 		select {
@@ -375,9 +413,24 @@ func (p *peer) metricsReporter() {
 			var sendQueueSize float64
 			for _, chStatus := range status.Channels {
 				sendQueueSize += float64(chStatus.SendQueueSize)
+
+				labels := []string{
+					"peer_id", string(p.ID()),
+					"chID", fmt.Sprintf("%#x", chStatus.ID),
+				}
+				p.metrics.PeerChannelSendQueueSize.With(labels...).Set(float64(chStatus.SendQueueSize))
+				if chStatus.SendQueueCapacity > 0 {
+					p.metrics.PeerChannelSendQueueSaturation.With(labels...).Set(
+						float64(chStatus.SendQueueSize) / float64(chStatus.SendQueueCapacity))
+				}
+				p.metrics.PeerChannelSendRate.With(labels...).Set(float64(chStatus.SendRate))
+				p.metrics.PeerChannelReceiveRate.With(labels...).Set(float64(chStatus.RecvRate))
 			}
 
 			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+			if status.RTT > 0 {
+				p.metrics.PeerRTTSeconds.With("peer_id", string(p.ID())).Set(status.RTT.Seconds())
+			}
 		case <-p.Quit():
 			return
 		}
@@ -397,6 +450,11 @@ func createMConnection(
 	config cmtconn.MConnConfig,
 ) *cmtconn.MConnection {
 
+	recvCapByCh := make(map[byte]int, len(chDescs))
+	for _, desc := range chDescs {
+		recvCapByCh[desc.ID] = desc.FillDefaults().RecvMessageCapacity
+	}
+
 	onReceive := func(chID byte, msgBytes []byte) {
 		reactor := reactorsByCh[chID]
 		if reactor == nil {
@@ -406,7 +464,15 @@ func createMConnection(
 		}
 		mt := msgTypeByChID[chID]
 		msg := proto.Clone(mt)
-		err := proto.Unmarshal(msgBytes, msg)
+		payload := msgBytes
+		if p.compression.enabled(chID) {
+			var err error
+			payload, err = decompress(msgBytes, recvCapByCh[chID])
+			if err != nil {
+				panic(fmt.Errorf("decompressing message: %w", err))
+			}
+		}
+		err := proto.Unmarshal(payload, msg)
 		if err != nil {
 			panic(fmt.Errorf("unmarshaling message: %s into type: %s", err, reflect.TypeOf(mt)))
 		}
@@ -420,6 +486,7 @@ func createMConnection(
 				panic(fmt.Errorf("unwrapping message: %s", err))
 			}
 		}
+		p.metrics.PeerReceiveMessagesTotal.With(labels...).Add(1)
 		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
 		p.metrics.MessageReceiveBytesTotal.With("message_type", p.mlc.ValueToMetricLabel(msg)).Add(float64(len(msgBytes)))
 		reactor.Receive(Envelope{
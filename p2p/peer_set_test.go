@@ -22,6 +22,7 @@ func (mp *mockPeer) FlushStop()               { mp.Stop() } //nolint:errcheck //
 func (mp *mockPeer) TrySend(e Envelope) bool  { return true }
 func (mp *mockPeer) Send(e Envelope) bool     { return true }
 func (mp *mockPeer) NodeInfo() NodeInfo       { return DefaultNodeInfo{} }
+func (mp *mockPeer) Features() Features       { return 0 }
 func (mp *mockPeer) Status() ConnectionStatus { return ConnectionStatus{} }
 func (mp *mockPeer) ID() ID                   { return mp.id }
 func (mp *mockPeer) IsOutbound() bool         { return false }
@@ -67,6 +67,12 @@ type AddrBook interface {
 	// Add bad peers back to addrBook
 	ReinstateBadPeers()
 
+	// Record connection quality, used to prefer reliable addresses on dial
+	MarkConnected(p2p.ID)
+	MarkDisconnected(p2p.ID)
+	MarkHandshakeFailure(*p2p.NetAddress)
+	MarkLatency(p2p.ID, time.Duration)
+
 	IsGood(*p2p.NetAddress) bool
 	IsBanned(*p2p.NetAddress) bool
 
@@ -309,15 +315,24 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 			bucket = a.bucketsNew[a.rand.Intn(len(a.bucketsNew))]
 		}
 	}
-	// pick a random index and loop over the map to return that index
-	randIndex := a.rand.Intn(len(bucket))
+	// pick an address from the bucket, weighted by its reliability score
+	total := 0.0
+	for _, ka := range bucket {
+		total += ka.reliabilityScore()
+	}
+	target := a.rand.Float64() * total
+	var picked *knownAddress
 	for _, ka := range bucket {
-		if randIndex == 0 {
-			return ka.Addr
+		picked = ka
+		target -= ka.reliabilityScore()
+		if target < 0 {
+			break
 		}
-		randIndex--
 	}
-	return nil
+	if picked == nil {
+		return nil
+	}
+	return picked.Addr
 }
 
 // MarkGood implements AddrBook - it marks the peer as good and
@@ -388,6 +403,60 @@ func (a *addrBook) ReinstateBadPeers() {
 	}
 }
 
+// MarkConnected implements AddrBook - it records that we are now connected to
+// the peer.
+func (a *addrBook) MarkConnected(id p2p.ID) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[id]
+	if ka == nil {
+		return
+	}
+	ka.markConnected()
+}
+
+// MarkDisconnected implements AddrBook - it records that the connection to
+// the peer ended and accounts the connection time towards its uptime.
+func (a *addrBook) MarkDisconnected(id p2p.ID) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[id]
+	if ka == nil {
+		return
+	}
+	ka.markDisconnected()
+}
+
+// MarkHandshakeFailure implements AddrBook - it records that we reached the
+// address but failed to complete the handshake. Unlike MarkBad, the address
+// is kept in the book but becomes less likely to be picked.
+func (a *addrBook) MarkHandshakeFailure(addr *p2p.NetAddress) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[addr.ID]
+	if ka == nil {
+		return
+	}
+	ka.markAttempt()
+	ka.markHandshakeFailure()
+}
+
+// MarkLatency implements AddrBook - it records a latency sample (e.g. the
+// time taken to dial and handshake) for the peer.
+func (a *addrBook) MarkLatency(id p2p.ID, latency time.Duration) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[id]
+	if ka == nil {
+		return
+	}
+	ka.markLatency(latency)
+}
+
 // GetSelection implements AddrBook.
 // It randomly selects some addresses (old & new). Suitable for peer-exchange protocols.
 // Must never return a nil address.
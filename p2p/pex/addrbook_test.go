@@ -2,6 +2,7 @@ package pex
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
 	"net"
@@ -92,6 +93,126 @@ func TestAddrBookSaveLoad(t *testing.T) {
 	assert.Equal(t, 100, book.Size())
 }
 
+func TestAddrBookSaveLoadReliability(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	addrSrc := randNetAddressPairs(t, 1)[0]
+	require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	book.MarkConnected(addrSrc.addr.ID)
+	book.MarkDisconnected(addrSrc.addr.ID)
+	book.MarkHandshakeFailure(addrSrc.addr)
+	book.MarkLatency(addrSrc.addr.ID, 200*time.Millisecond)
+	book.Save()
+
+	book = NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	defer book.Stop() //nolint:errcheck // ignore for tests
+
+	ka := book.(*addrBook).addrLookup[addrSrc.addr.ID]
+	require.NotNil(t, ka)
+	assert.EqualValues(t, 1, ka.Reliability.Connections)
+	assert.EqualValues(t, 1, ka.Reliability.HandshakeFailures)
+	assert.Equal(t, 200*time.Millisecond, ka.Reliability.Latency)
+	assert.Positive(t, ka.Reliability.Uptime)
+}
+
+func TestAddrBookMigrateV1(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	addrSrc := randNetAddressPairs(t, 1)[0]
+	ka := newKnownAddress(addrSrc.addr, addrSrc.src)
+	ka.Buckets = []int{0}
+	ka.LastSuccess = time.Now()
+	// a version 1 file has neither the version nor the reliability fields.
+	kaBytes, err := json.Marshal(ka)
+	require.NoError(t, err)
+	legacyAddr := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(kaBytes, &legacyAddr))
+	delete(legacyAddr, "reliability")
+	legacyBytes, err := json.Marshal(map[string]interface{}{
+		"key":   "0123456789abcdef01234567",
+		"addrs": []interface{}{legacyAddr},
+	})
+	require.NoError(t, err)
+	legacy := string(legacyBytes)
+	require.NoError(t, os.WriteFile(fname, []byte(legacy), 0o600))
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	defer book.Stop() //nolint:errcheck // ignore for tests
+	assert.Equal(t, 1, book.Size())
+
+	backup := fname + ".v1.bak"
+	defer os.Remove(backup)
+	backupBytes, err := os.ReadFile(backup)
+	require.NoError(t, err)
+	assert.Equal(t, legacy, string(backupBytes))
+
+	migrated, err := os.ReadFile(fname)
+	require.NoError(t, err)
+	aJSON := &addrBookJSON{}
+	require.NoError(t, json.Unmarshal(migrated, aJSON))
+	assert.Equal(t, addrBookVersion, aJSON.Version)
+	require.Len(t, aJSON.Addrs, 1)
+	assert.EqualValues(t, 1, aJSON.Addrs[0].Reliability.Connections)
+}
+
+func TestKnownAddressReliabilityScore(t *testing.T) {
+	addrSrc := randNetAddressPairs(t, 2)
+
+	fresh := newKnownAddress(addrSrc[0].addr, addrSrc[0].src)
+	assert.Equal(t, 1.0, fresh.reliabilityScore())
+
+	reliable := newKnownAddress(addrSrc[1].addr, addrSrc[1].src)
+	reliable.Reliability.Uptime = 24 * time.Hour
+	assert.Greater(t, reliable.reliabilityScore(), fresh.reliabilityScore())
+
+	fresh.markHandshakeFailure()
+	assert.Less(t, fresh.reliabilityScore(), 1.0)
+
+	slow := newKnownAddress(addrSrc[0].addr, addrSrc[0].src)
+	slow.markLatency(latencyPenaltyScale)
+	assert.Equal(t, 0.5, slow.reliabilityScore())
+}
+
+func TestAddrBookPickAddressPrefersReliable(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true).(*addrBook)
+	book.SetLogger(log.TestingLogger())
+
+	randAddrs := randNetAddressPairs(t, 2)
+	for _, addrSrc := range randAddrs {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	}
+	good, bad := randAddrs[0].addr, randAddrs[1].addr
+	// put both addresses into the same bucket so only the weighting matters.
+	for _, ka := range book.addrLookup {
+		for _, idx := range ka.Buckets {
+			delete(book.bucketsNew[idx], ka.Addr.String())
+		}
+		ka.Buckets = []int{0}
+		book.bucketsNew[0][ka.Addr.String()] = ka
+	}
+	for i := 0; i < 20; i++ {
+		book.MarkHandshakeFailure(bad)
+	}
+
+	picks := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		picks[book.PickAddress(100).String()]++
+	}
+	assert.Greater(t, picks[good.String()], picks[bad.String()]*5)
+}
+
 func TestAddrBookLookup(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
@@ -0,0 +1,55 @@
+package pex
+
+import (
+	"net"
+	"strings"
+
+	"github.com/cometbft/cometbft/p2p"
+)
+
+// maxAddrsPerDNSSeed caps the number of addresses taken from a single DNS
+// seed, so that one misbehaving seed can't flood the address book.
+const maxAddrsPerDNSSeed = 64
+
+// lookupTXT is overridden in tests.
+var lookupTXT = net.LookupTXT
+
+// resolveDNSSeeds resolves the TXT records of the given DNS seeds. Each record
+// holds a comma separated list of peer addresses (id@host:port), which the
+// operator of the seed is free to rotate.
+//
+// Seeds which can't be resolved and malformed addresses are logged and
+// skipped.
+func (r *Reactor) resolveDNSSeeds(hosts []string) []*p2p.NetAddress {
+	var addrs []*p2p.NetAddress
+	for _, host := range hosts {
+		records, err := lookupTXT(host)
+		if err != nil {
+			r.Logger.Error("Failed to resolve DNS seed", "seed", host, "err", err)
+			continue
+		}
+
+		n := 0
+	RECORDS:
+		for _, record := range records {
+			for _, s := range strings.Split(record, ",") {
+				s = strings.TrimSpace(s)
+				if s == "" {
+					continue
+				}
+				addr, err := p2p.NewNetAddressString(s)
+				if err != nil {
+					r.Logger.Debug("Invalid address in DNS seed", "seed", host, "addr", s, "err", err)
+					continue
+				}
+				addrs = append(addrs, addr)
+				n++
+				if n >= maxAddrsPerDNSSeed {
+					break RECORDS
+				}
+			}
+		}
+		r.Logger.Debug("Resolved DNS seed", "seed", host, "addrs", n)
+	}
+	return addrs
+}
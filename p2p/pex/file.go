@@ -5,14 +5,21 @@ import (
 	"fmt"
 	"os"
 
+	cmtmath "github.com/cometbft/cometbft/libs/math"
 	"github.com/cometbft/cometbft/libs/tempfile"
 )
 
 /* Loading & Saving */
 
+// addrBookVersion is the current version of the on-disk address book format.
+// Version 1 files, which predate the version field, carry no reliability
+// stats and are migrated on load.
+const addrBookVersion = 2
+
 type addrBookJSON struct {
-	Key   string          `json:"key"`
-	Addrs []*knownAddress `json:"addrs"`
+	Version int             `json:"version"`
+	Key     string          `json:"key"`
+	Addrs   []*knownAddress `json:"addrs"`
 }
 
 func (a *addrBook) saveToFile(filePath string) {
@@ -26,8 +33,9 @@ func (a *addrBook) saveToFile(filePath string) {
 		addrs = append(addrs, ka)
 	}
 	aJSON := &addrBookJSON{
-		Key:   a.key,
-		Addrs: addrs,
+		Version: addrBookVersion,
+		Key:     a.key,
+		Addrs:   addrs,
 	}
 
 	jsonBytes, err := json.MarshalIndent(aJSON, "", "\t")
@@ -51,17 +59,19 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 	}
 
 	// Load addrBookJSON{}
-	r, err := os.Open(filePath)
+	jsonBytes, err := os.ReadFile(filePath)
 	if err != nil {
 		panic(fmt.Sprintf("Error opening file %s: %v", filePath, err))
 	}
-	defer r.Close()
 	aJSON := &addrBookJSON{}
-	dec := json.NewDecoder(r)
-	err = dec.Decode(aJSON)
+	err = json.Unmarshal(jsonBytes, aJSON)
 	if err != nil {
 		panic(fmt.Sprintf("Error reading file %s: %v", filePath, err))
 	}
+	if aJSON.Version > addrBookVersion {
+		panic(fmt.Sprintf("Unsupported AddrBook version %d in %s (max supported %d)",
+			aJSON.Version, filePath, addrBookVersion))
+	}
 
 	// Restore all the fields...
 	// Restore the key
@@ -79,5 +89,32 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 			a.nOld++
 		}
 	}
+
+	if aJSON.Version < addrBookVersion {
+		a.migrateFile(filePath, jsonBytes, aJSON)
+	}
 	return true
 }
+
+// migrateFile upgrades an address book loaded from an older format. The
+// original file is kept with a version suffix before the book is rewritten in
+// the current format.
+func (a *addrBook) migrateFile(filePath string, oldBytes []byte, aJSON *addrBookJSON) {
+	a.Logger.Info("Migrating AddrBook file", "file", filePath,
+		"from", cmtmath.MaxInt(aJSON.Version, 1), "to", addrBookVersion)
+
+	// Version 1 only recorded the last successful connection, which is the
+	// best approximation of a connection we have.
+	for _, ka := range aJSON.Addrs {
+		if !ka.LastSuccess.IsZero() && ka.Reliability.Connections == 0 {
+			ka.Reliability.Connections = 1
+		}
+	}
+
+	backupPath := fmt.Sprintf("%s.v%d.bak", filePath, cmtmath.MaxInt(aJSON.Version, 1))
+	if err := tempfile.WriteFileAtomic(backupPath, oldBytes, 0644); err != nil {
+		a.Logger.Error("Failed to back up AddrBook file before migration", "file", backupPath, "err", err)
+		return
+	}
+	a.saveToFile(filePath)
+}
@@ -1,6 +1,7 @@
 package pex
 
 import (
+	"math"
 	"time"
 
 	"github.com/cometbft/cometbft/p2p"
@@ -17,6 +18,20 @@ type knownAddress struct {
 	LastAttempt time.Time       `json:"last_attempt"`
 	LastSuccess time.Time       `json:"last_success"`
 	LastBanTime time.Time       `json:"last_ban_time"`
+	Reliability reliability     `json:"reliability"`
+}
+
+// reliability tracks the historical connection quality of an address. It is
+// persisted with the address book so that, after a restart, we still prefer
+// dialing peers which behaved well in the past.
+type reliability struct {
+	Connections       int32         `json:"connections"`
+	HandshakeFailures int32         `json:"handshake_failures"`
+	Uptime            time.Duration `json:"uptime"`
+	Latency           time.Duration `json:"latency"`
+
+	// set while the peer is connected, not persisted.
+	connectedSince time.Time
 }
 
 func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress) *knownAddress {
@@ -55,6 +70,57 @@ func (ka *knownAddress) markGood() {
 	ka.LastSuccess = now
 }
 
+func (ka *knownAddress) markConnected() {
+	ka.Reliability.Connections++
+	ka.Reliability.connectedSince = time.Now()
+}
+
+func (ka *knownAddress) markDisconnected() {
+	if ka.Reliability.connectedSince.IsZero() {
+		return
+	}
+	ka.Reliability.Uptime += time.Since(ka.Reliability.connectedSince)
+	ka.Reliability.connectedSince = time.Time{}
+}
+
+func (ka *knownAddress) markHandshakeFailure() {
+	ka.Reliability.HandshakeFailures++
+}
+
+// markLatency folds a new latency sample into an exponentially weighted
+// moving average.
+func (ka *knownAddress) markLatency(latency time.Duration) {
+	if ka.Reliability.Latency == 0 {
+		ka.Reliability.Latency = latency
+		return
+	}
+	ka.Reliability.Latency += (latency - ka.Reliability.Latency) / latencyEWMAFactor
+}
+
+// uptime returns the total time we have been connected to the address,
+// including the current connection if there is one.
+func (ka *knownAddress) uptime() time.Duration {
+	uptime := ka.Reliability.Uptime
+	if !ka.Reliability.connectedSince.IsZero() {
+		uptime += time.Since(ka.Reliability.connectedSince)
+	}
+	return uptime
+}
+
+// reliabilityScore returns a strictly positive weight used to bias dialing
+// towards historically reliable addresses. An address without any history
+// scores 1. Uptime increases the score logarithmically, while handshake
+// failures and high latency decrease it.
+func (ka *knownAddress) reliabilityScore() float64 {
+	r := ka.Reliability
+	score := 1 + math.Log1p(ka.uptime().Hours())
+	score /= 1 + float64(r.HandshakeFailures)
+	if r.Latency > 0 {
+		score /= 1 + r.Latency.Seconds()/latencyPenaltyScale.Seconds()
+	}
+	return score
+}
+
 func (ka *knownAddress) ban(banTime time.Duration) {
 	if ka.LastBanTime.Before(time.Now().Add(banTime)) {
 		ka.LastBanTime = time.Now().Add(banTime)
@@ -49,6 +49,13 @@ const (
 	// min addresses that must be returned by GetSelection. Useful for bootstrapping.
 	minGetSelection = 32
 
+	// weight given to the previous average when folding in a new latency
+	// sample (i.e. new = old + (sample - old) / latencyEWMAFactor).
+	latencyEWMAFactor = 5
+
+	// latency at which an address' reliability score is halved.
+	latencyPenaltyScale = 500 * time.Millisecond
+
 	// max addresses returned by GetSelection
 	// NOTE: this must match "maxMsgSize"
 	maxGetSelection = 250
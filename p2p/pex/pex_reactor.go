@@ -9,6 +9,7 @@ import (
 	"github.com/cometbft/cometbft/libs/cmap"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/libs/ratelimit"
 	"github.com/cometbft/cometbft/libs/service"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/conn"
@@ -41,6 +42,13 @@ const (
 	// check some peers every this
 	crawlPeerPeriod = 30 * time.Second
 
+	// maximum number of peers crawled concurrently
+	maxConcurrentCrawls = 32
+
+	// maximum number of peers from the same network group (e.g. /16 for IPv4)
+	// crawled per crawlPeerPeriod, so that we don't hammer a single network.
+	maxCrawlsPerGroup = 4
+
 	maxAttemptsToDial = 16 // ~ 35h in total (last attempt - 18h)
 
 	// if node connects to seed, it does not have any trusted peers.
@@ -87,11 +95,17 @@ type Reactor struct {
 	ensurePeersPeriod time.Duration // TODO: should go in the config
 
 	// maps to prevent abuse
-	requestsSent         *cmap.CMap // ID->struct{}: unanswered send requests
-	lastReceivedRequests *cmap.CMap // ID->time.Time: last time peer requested from us
+	requestsSent         *cmap.CMap         // ID->struct{}: unanswered send requests
+	lastReceivedRequests *cmap.CMap         // ID->time.Time: last time peer requested from us (seed mode)
+	requestLimiter       *ratelimit.Limiter // limits the rate of the requests of each peer
 
 	seedAddrs []*p2p.NetAddress
 
+	// addresses resolved from the DNS seeds, refreshed whenever we fall back
+	// to seeds
+	dnsSeedMtx   sync.Mutex
+	dnsSeedAddrs []*p2p.NetAddress
+
 	attemptsToDial sync.Map // address (string) -> {number of attempts (int), last time dialed (time.Time)}
 
 	// seed/crawled mode fields
@@ -120,6 +134,13 @@ type ReactorConfig struct {
 	// Seeds is a list of addresses reactor may use
 	// if it can't connect to peers in the addrbook.
 	Seeds []string
+
+	// DNSSeeds is a list of host names whose TXT records list seed
+	// addresses. They are used in addition to Seeds.
+	DNSSeeds []string
+
+	// Metrics of the rate limit of the PEX requests of the peers (optional)
+	RateLimitMetrics *ratelimit.Metrics
 }
 
 type _attemptsToDial struct {
@@ -137,10 +158,26 @@ func NewReactor(b AddrBook, config *ReactorConfig) *Reactor {
 		lastReceivedRequests: cmap.NewCMap(),
 		crawlPeerInfos:       make(map[p2p.ID]crawlPeerInfo),
 	}
+	r.requestLimiter = r.newRequestLimiter()
 	r.BaseReactor = *p2p.NewBaseReactor("PEX", r)
 	return r
 }
 
+// newRequestLimiter returns a limiter letting every peer request addresses
+// twice right away, as the first requests of both sides can cross, then once
+// every minReceiveRequestInterval.
+func (r *Reactor) newRequestLimiter() *ratelimit.Limiter {
+	var limit ratelimit.Limit
+	if interval := r.minReceiveRequestInterval(); interval > 0 {
+		limit = ratelimit.Limit{Rate: 1 / interval.Seconds(), Burst: 2}
+	}
+	metrics := r.config.RateLimitMetrics
+	if metrics == nil {
+		metrics = ratelimit.NopMetrics()
+	}
+	return ratelimit.NewLimiter(limit, ratelimit.WithMetrics(metrics, "pex"))
+}
+
 // OnStart implements BaseService
 func (r *Reactor) OnStart() error {
 	err := r.book.Start()
@@ -151,12 +188,20 @@ func (r *Reactor) OnStart() error {
 	numOnline, seedAddrs, err := r.checkSeeds()
 	if err != nil {
 		return err
-	} else if numOnline == 0 && r.book.Empty() {
-		return errors.New("address book is empty and couldn't resolve any seed nodes")
 	}
 
 	r.seedAddrs = seedAddrs
 
+	if len(r.config.DNSSeeds) > 0 {
+		if numOnline < 0 {
+			numOnline = 0
+		}
+		numOnline += r.refreshDNSSeeds()
+	}
+	if numOnline == 0 && r.book.Empty() {
+		return errors.New("address book is empty and couldn't resolve any seed nodes")
+	}
+
 	// Check if this node should run
 	// in seed/crawler mode
 	if r.config.SeedMode {
@@ -213,6 +258,7 @@ func (r *Reactor) AddPeer(p Peer) {
 		err = r.book.AddAddress(addr, src)
 		r.logErrAddrBook(err)
 	}
+	r.book.MarkConnected(p.ID())
 }
 
 // RemovePeer implements Reactor by resetting peer's requests info.
@@ -220,6 +266,8 @@ func (r *Reactor) RemovePeer(p Peer, reason interface{}) {
 	id := string(p.ID())
 	r.requestsSent.Delete(id)
 	r.lastReceivedRequests.Delete(id)
+	r.requestLimiter.Remove(id)
+	r.book.MarkDisconnected(p.ID())
 }
 
 func (r *Reactor) logErrAddrBook(err error) {
@@ -300,35 +348,13 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 
 // enforces a minimum amount of time between requests
 func (r *Reactor) receiveRequest(src Peer) error {
-	id := string(src.ID())
-	v := r.lastReceivedRequests.Get(id)
-	if v == nil {
-		// initialize with empty time
-		lastReceived := time.Time{}
-		r.lastReceivedRequests.Set(id, lastReceived)
-		return nil
-	}
-
-	lastReceived := v.(time.Time)
-	if lastReceived.Equal(time.Time{}) {
-		// first time gets a free pass. then we start tracking the time
-		lastReceived = time.Now()
-		r.lastReceivedRequests.Set(id, lastReceived)
-		return nil
-	}
-
-	now := time.Now()
-	minInterval := r.minReceiveRequestInterval()
-	if now.Sub(lastReceived) < minInterval {
+	if !r.requestLimiter.Allow(string(src.ID())) {
 		return fmt.Errorf(
-			"peer (%v) sent next PEX request too soon. lastReceived: %v, now: %v, minInterval: %v. Disconnecting",
+			"peer (%v) sent next PEX request too soon, minInterval: %v. Disconnecting",
 			src.ID(),
-			lastReceived,
-			now,
-			minInterval,
+			r.minReceiveRequestInterval(),
 		)
 	}
-	r.lastReceivedRequests.Set(id, now)
 	return nil
 }
 
@@ -363,7 +389,7 @@ func (r *Reactor) ReceiveAddrs(addrs []*p2p.NetAddress, src Peer) error {
 	}
 
 	srcIsSeed := false
-	for _, seedAddr := range r.seedAddrs {
+	for _, seedAddr := range r.seeds() {
 		if seedAddr.Equals(srcAddr) {
 			srcIsSeed = true
 			break
@@ -412,6 +438,7 @@ func (r *Reactor) SendAddrs(p Peer, netAddrs []*p2p.NetAddress) {
 // SetEnsurePeersPeriod sets period to ensure peers connected.
 func (r *Reactor) SetEnsurePeersPeriod(d time.Duration) {
 	r.ensurePeersPeriod = d
+	r.requestLimiter = r.newRequestLimiter()
 }
 
 // Ensures that sufficient peers are connected. (continuous)
@@ -561,6 +588,7 @@ func (r *Reactor) dialPeer(addr *p2p.NetAddress) error {
 		}
 	}
 
+	start := time.Now()
 	err := r.Switch.DialPeerWithAddress(addr)
 	if err != nil {
 		if _, ok := err.(p2p.ErrCurrentlyDialingOrExistingAddress); ok {
@@ -580,6 +608,7 @@ func (r *Reactor) dialPeer(addr *p2p.NetAddress) error {
 
 	// cleanup any history
 	r.attemptsToDial.Delete(addr.DialString())
+	r.book.MarkLatency(addr.ID, time.Since(start))
 	return nil
 }
 
@@ -616,13 +645,45 @@ func (r *Reactor) checkSeeds() (numOnline int, netAddrs []*p2p.NetAddress, err e
 	return numOnline, netAddrs, nil
 }
 
+// refreshDNSSeeds resolves the DNS seeds again, keeping the previous
+// addresses if none could be resolved. It returns the number of addresses.
+func (r *Reactor) refreshDNSSeeds() int {
+	if len(r.config.DNSSeeds) == 0 {
+		return 0
+	}
+	addrs := r.resolveDNSSeeds(r.config.DNSSeeds)
+
+	r.dnsSeedMtx.Lock()
+	defer r.dnsSeedMtx.Unlock()
+	if len(addrs) > 0 {
+		r.dnsSeedAddrs = addrs
+	}
+	return len(r.dnsSeedAddrs)
+}
+
+// seeds returns the configured seeds along with the ones resolved from DNS
+// seeds.
+func (r *Reactor) seeds() []*p2p.NetAddress {
+	r.dnsSeedMtx.Lock()
+	defer r.dnsSeedMtx.Unlock()
+	if len(r.dnsSeedAddrs) == 0 {
+		return r.seedAddrs
+	}
+	seeds := make([]*p2p.NetAddress, 0, len(r.seedAddrs)+len(r.dnsSeedAddrs))
+	seeds = append(seeds, r.seedAddrs...)
+	return append(seeds, r.dnsSeedAddrs...)
+}
+
 // randomly dial seeds until we connect to one or exhaust them
 func (r *Reactor) dialSeeds() {
-	perm := cmtrand.Perm(len(r.seedAddrs))
+	r.refreshDNSSeeds()
+	seeds := r.seeds()
+
+	perm := cmtrand.Perm(len(seeds))
 	// perm := r.Switch.rng.Perm(lSeeds)
 	for _, i := range perm {
 		// dial a random seed
-		seedAddr := r.seedAddrs[i]
+		seedAddr := seeds[i]
 		err := r.Switch.DialPeerWithAddress(seedAddr)
 
 		switch err.(type) {
@@ -632,7 +693,7 @@ func (r *Reactor) dialSeeds() {
 		r.Switch.Logger.Error("Error dialing seed", "err", err, "seed", seedAddr)
 	}
 	// do not write error message if there were no seeds specified in config
-	if len(r.seedAddrs) > 0 {
+	if len(seeds) > 0 {
 		r.Switch.Logger.Error("Couldn't connect to any seeds")
 	}
 }
@@ -654,7 +715,7 @@ func (r *Reactor) AttemptsToDial(addr *p2p.NetAddress) int {
 // from peers, except other seed nodes.
 func (r *Reactor) crawlPeersRoutine() {
 	// If we have any seed nodes, consult them first
-	if len(r.seedAddrs) > 0 {
+	if len(r.seeds()) > 0 {
 		r.dialSeeds()
 	} else {
 		// Do an initial crawl
@@ -692,9 +753,20 @@ type crawlPeerInfo struct {
 }
 
 // crawlPeers will crawl the network looking for new peer addresses.
+//
+// Up to maxConcurrentCrawls peers are crawled at once, and at most
+// maxCrawlsPerGroup peers of the same network group are crawled per call. The
+// remaining ones are left for the next round.
 func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 	now := time.Now()
 
+	var (
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, maxConcurrentCrawls)
+		groups = make(map[string]int)
+	)
+	defer wg.Wait()
+
 	for _, addr := range addrs {
 		peerInfo, ok := r.crawlPeerInfos[addr.ID]
 
@@ -703,27 +775,49 @@ func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 			continue
 		}
 
+		group := groupKeyFor(addr, false)
+		if groups[group] >= maxCrawlsPerGroup {
+			continue
+		}
+		groups[group]++
+
 		// Record crawling attempt.
 		r.crawlPeerInfos[addr.ID] = crawlPeerInfo{
 			Addr:        addr,
 			LastCrawled: now,
 		}
 
-		err := r.dialPeer(addr)
-		if err != nil {
-			switch err.(type) {
-			case errMaxAttemptsToDial, errTooEarlyToDial, p2p.ErrCurrentlyDialingOrExistingAddress:
-				r.Logger.Debug(err.Error(), "addr", addr)
-			default:
-				r.Logger.Debug(err.Error(), "addr", addr)
-			}
-			continue
+		select {
+		case sem <- struct{}{}:
+		case <-r.Quit():
+			return
 		}
+		wg.Add(1)
+		go func(addr *p2p.NetAddress) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			r.crawlPeer(addr)
+		}(addr)
+	}
+}
 
-		peer := r.Switch.Peers().Get(addr.ID)
-		if peer != nil {
-			r.RequestAddrs(peer)
+func (r *Reactor) crawlPeer(addr *p2p.NetAddress) {
+	err := r.dialPeer(addr)
+	if err != nil {
+		switch err.(type) {
+		case errMaxAttemptsToDial, errTooEarlyToDial, p2p.ErrCurrentlyDialingOrExistingAddress:
+			r.Logger.Debug(err.Error(), "addr", addr)
+		default:
+			r.Logger.Debug(err.Error(), "addr", addr)
 		}
+		return
+	}
+
+	peer := r.Switch.Peers().Get(addr.ID)
+	if peer != nil {
+		r.RequestAddrs(peer)
 	}
 }
 
@@ -756,9 +850,15 @@ func (r *Reactor) attemptDisconnects() {
 
 func markAddrInBookBasedOnErr(addr *p2p.NetAddress, book AddrBook, err error) {
 	// TODO: detect more "bad peer" scenarios
-	switch err.(type) {
+	switch e := err.(type) {
 	case p2p.ErrSwitchAuthenticationFailure:
 		book.MarkBad(addr, defaultBanTime)
+	case p2p.ErrRejected:
+		if e.IsAuthFailure() || e.IsIncompatible() || e.IsNodeInfoInvalid() {
+			book.MarkHandshakeFailure(addr)
+			return
+		}
+		book.MarkAttempt(addr)
 	default:
 		book.MarkAttempt(addr)
 	}
@@ -3,6 +3,7 @@ package pex
 import (
 	"encoding/hex"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/mock"
 	tmp2p "github.com/cometbft/cometbft/proto/tendermint/p2p"
@@ -153,21 +155,23 @@ func TestPEXReactorRequestMessageAbuse(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, book.HasAddress(peerAddr))
 
-	id := string(peer.ID())
-
 	// first time creates the entry
 	r.Receive(p2p.Envelope{ChannelID: PexChannel, Src: peer, Message: &tmp2p.PexRequest{}})
-	assert.True(t, r.lastReceivedRequests.Has(id))
+	assert.Equal(t, 1, r.requestLimiter.Len())
 	assert.True(t, sw.Peers().Has(peer.ID()))
 
-	// next time sets the last time value
+	// next time is still allowed, as the first requests of both sides can cross
 	r.Receive(p2p.Envelope{ChannelID: PexChannel, Src: peer, Message: &tmp2p.PexRequest{}})
-	assert.True(t, r.lastReceivedRequests.Has(id))
+	assert.Equal(t, 1, r.requestLimiter.Len())
 	assert.True(t, sw.Peers().Has(peer.ID()))
 
-	// third time is too many too soon - peer is removed
+	// another request inside the interval is rejected
+	assert.Error(t, r.receiveRequest(peer))
+
+	// and the peer sending it is stopped and removed
 	r.Receive(p2p.Envelope{ChannelID: PexChannel, Src: peer, Message: &tmp2p.PexRequest{}})
-	assert.False(t, r.lastReceivedRequests.Has(id))
+	assert.False(t, peer.IsRunning())
+	assert.Zero(t, r.requestLimiter.Len())
 	assert.False(t, sw.Peers().Has(peer.ID()))
 	assert.True(t, book.IsBanned(peerAddr))
 }
@@ -396,6 +400,66 @@ func TestPEXReactorDialsPeerUpToMaxAttemptsInSeedMode(t *testing.T) {
 	assert.False(t, book.HasAddress(addr))
 }
 
+func TestPEXReactorCrawlLimitsPerGroup(t *testing.T) {
+	pexR, book := createReactor(&ReactorConfig{SeedMode: true})
+	defer teardownReactor(book)
+
+	sw := createSwitchAndAddReactors(pexR)
+	sw.SetAddrBook(book)
+	// No need to start sw since crawlPeers is called manually here.
+
+	var addrs []*p2p.NetAddress
+	for i := 0; i < 2*maxCrawlsPerGroup; i++ {
+		// same /16, imitate maxAttemptsToDial reached so nothing is dialed
+		addr := p2p.NewNetAddressIPPort(net.IPv4(1, 2, byte(i), 1), 26656)
+		addr.ID = p2p.ID(hex.EncodeToString(cmtrand.Bytes(p2p.IDByteLength)))
+		pexR.attemptsToDial.Store(addr.DialString(), _attemptsToDial{maxAttemptsToDial + 1, time.Now()})
+		addrs = append(addrs, addr)
+	}
+	other := p2p.NewNetAddressIPPort(net.IPv4(5, 6, 7, 8), 26656)
+	other.ID = p2p.ID(hex.EncodeToString(cmtrand.Bytes(p2p.IDByteLength)))
+	pexR.attemptsToDial.Store(other.DialString(), _attemptsToDial{maxAttemptsToDial + 1, time.Now()})
+	addrs = append(addrs, other)
+
+	pexR.crawlPeers(addrs)
+	assert.Len(t, pexR.crawlPeerInfos, maxCrawlsPerGroup+1)
+	assert.Contains(t, pexR.crawlPeerInfos, other.ID)
+	for _, addr := range addrs[maxCrawlsPerGroup : 2*maxCrawlsPerGroup] {
+		assert.NotContains(t, pexR.crawlPeerInfos, addr.ID, "should be left for the next round")
+	}
+}
+
+func TestPEXReactorDNSSeeds(t *testing.T) {
+	// directory to store address books
+	dir, err := os.MkdirTemp("", "pex_reactor")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	seed := testCreateSeed(dir, 0, []*p2p.NetAddress{}, []*p2p.NetAddress{})
+	require.Nil(t, seed.Start())
+	defer seed.Stop() //nolint:errcheck // ignore for tests
+
+	defer func(orig func(string) ([]string, error)) { lookupTXT = orig }(lookupTXT)
+	lookupTXT = func(host string) ([]string, error) {
+		if host != "seeds.testnet" {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return []string{"invalid, " + seed.NetAddress().String()}, nil
+	}
+
+	// 1. the address book is empty and no DNS seed resolves
+	peer := testCreatePeerWithConfig(dir, 1, &ReactorConfig{DNSSeeds: []string{"unknown.testnet"}})
+	require.Error(t, peer.Start())
+	peer.Stop() //nolint:errcheck // ignore for tests
+
+	// 2. the peer connects to the seed resolved from DNS
+	peer = testCreatePeerWithConfig(dir, 2, &ReactorConfig{DNSSeeds: []string{"unknown.testnet", "seeds.testnet"}})
+	require.Nil(t, peer.Start())
+	defer peer.Stop() //nolint:errcheck // ignore for tests
+
+	assertPeersWithTimeout(t, []*p2p.Switch{peer}, 10*time.Millisecond, 3*time.Second, 1)
+}
+
 // connect a peer to a seed, wait a bit, then stop it.
 // this should give it time to request addrs and for the seed
 // to call FlushStop, and allows us to test calling Stop concurrently
@@ -0,0 +1,157 @@
+package p2p
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+// maxReconnectInterval caps the pause between two attempts to reconnect to a
+// peer, whatever the backoff.
+const maxReconnectInterval = 24 * time.Hour
+
+// ReconnectPolicy is how the switch reconnects to a persistent peer.
+type ReconnectPolicy struct {
+	// Maximum number of attempts, before leaving the peer to the PEX reactor,
+	// or 0 to keep trying forever.
+	MaxAttempts int
+	// How the pause between two attempts grows, one of the
+	// config.ReconnectBackoff* values.
+	Backoff string
+	// Base pause between two attempts.
+	Interval time.Duration
+	// Whether to connect to the peer ignoring the limits on the number of
+	// peers, as for the unconditional peers.
+	Unconditional bool
+}
+
+// DefaultReconnectPolicy returns the reconnect policy of the persistent peers
+// set by cfg, which apply to the peers without a policy of their own.
+func DefaultReconnectPolicy(cfg *config.P2PConfig) ReconnectPolicy {
+	return ReconnectPolicy{
+		MaxAttempts: cfg.PersistentPeersReconnectMaxAttempts,
+		Backoff:     cfg.PersistentPeersReconnectBackoff,
+		Interval:    cfg.PersistentPeersReconnectInterval,
+	}
+}
+
+// ValidateBasic performs basic validation.
+func (p ReconnectPolicy) ValidateBasic() error {
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("negative max_attempts %d", p.MaxAttempts)
+	}
+	switch p.Backoff {
+	case config.ReconnectBackoffHybrid, config.ReconnectBackoffConstant, config.ReconnectBackoffExponential:
+	default:
+		return fmt.Errorf("unknown backoff %q", p.Backoff)
+	}
+	if p.Interval < 0 {
+		return fmt.Errorf("negative interval %v", p.Interval)
+	}
+	return nil
+}
+
+// String returns the policy in the format parsed by ParseReconnectPolicy.
+func (p ReconnectPolicy) String() string {
+	return fmt.Sprintf("max_attempts=%d;backoff=%s;interval=%v;unconditional=%t",
+		p.MaxAttempts, p.Backoff, p.Interval, p.Unconditional)
+}
+
+// delay returns the pause before the given attempt, the first one (0) being
+// immediate.
+func (p ReconnectPolicy) delay(attempt int) time.Duration {
+	if attempt == 0 {
+		return 0
+	}
+	var d float64
+	switch p.Backoff {
+	case config.ReconnectBackoffConstant:
+		return p.Interval
+	case config.ReconnectBackoffExponential:
+		d = float64(p.Interval) * math.Pow(2, float64(attempt-1))
+	default:
+		if attempt < reconnectAttempts {
+			return p.Interval
+		}
+		d = float64(time.Second) * math.Pow(reconnectBackOffBaseSeconds, float64(attempt-reconnectAttempts+1))
+	}
+	if d > float64(maxReconnectInterval) {
+		return maxReconnectInterval
+	}
+	return time.Duration(d)
+}
+
+// ParseReconnectPolicy parses a policy given as <field>=<value> pairs
+// separated by semicolons, e.g. "max_attempts=0;backoff=constant;interval=1s",
+// the fields not given being those of def.
+func ParseReconnectPolicy(s string, def ReconnectPolicy) (ReconnectPolicy, error) {
+	p := def
+	for _, field := range strings.Split(s, ";") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return p, fmt.Errorf("field %q: expected <field>=<value>", field)
+		}
+		var err error
+		switch strings.TrimSpace(key) {
+		case "max_attempts":
+			p.MaxAttempts, err = strconv.Atoi(strings.TrimSpace(value))
+		case "backoff":
+			p.Backoff = strings.TrimSpace(value)
+		case "interval":
+			p.Interval, err = time.ParseDuration(strings.TrimSpace(value))
+		case "unconditional":
+			p.Unconditional, err = strconv.ParseBool(strings.TrimSpace(value))
+		default:
+			return p, fmt.Errorf("unknown field %q", key)
+		}
+		if err != nil {
+			return p, fmt.Errorf("field %q: %w", key, err)
+		}
+	}
+	return p, p.ValidateBasic()
+}
+
+// ParseReconnectPolicies parses a comma separated list of policies, as
+// <ID>:<policy> (see ParseReconnectPolicy).
+func ParseReconnectPolicies(s string, def ReconnectPolicy) (map[ID]ReconnectPolicy, error) {
+	policies := make(map[ID]ReconnectPolicy)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, policy, _ := strings.Cut(entry, ":")
+		if err := validateID(ID(id)); err != nil {
+			return nil, fmt.Errorf("reconnect policy %q: %w", entry, err)
+		}
+		p, err := ParseReconnectPolicy(policy, def)
+		if err != nil {
+			return nil, fmt.Errorf("reconnect policy of %s: %w", id, err)
+		}
+		policies[ID(id)] = p
+	}
+	return policies, nil
+}
+
+// reconnect is a routine reconnecting to a peer, which can be aborted.
+type reconnect struct {
+	abort     chan struct{}
+	abortOnce sync.Once
+}
+
+func newReconnect() *reconnect {
+	return &reconnect{abort: make(chan struct{})}
+}
+
+func (r *reconnect) stop() {
+	r.abortOnce.Do(func() { close(r.abort) })
+}
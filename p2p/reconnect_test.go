@@ -0,0 +1,75 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+func TestReconnectPolicyDelay(t *testing.T) {
+	policy := ReconnectPolicy{Backoff: config.ReconnectBackoffHybrid, Interval: 5 * time.Second}
+	assert.Zero(t, policy.delay(0))
+	assert.Equal(t, 5*time.Second, policy.delay(1))
+	assert.Equal(t, 5*time.Second, policy.delay(reconnectAttempts-1))
+	assert.Equal(t, 3*time.Second, policy.delay(reconnectAttempts))
+	assert.Equal(t, 9*time.Second, policy.delay(reconnectAttempts+1))
+	assert.Equal(t, maxReconnectInterval, policy.delay(1000))
+
+	policy.Backoff = config.ReconnectBackoffConstant
+	assert.Equal(t, 5*time.Second, policy.delay(1000))
+
+	policy.Backoff = config.ReconnectBackoffExponential
+	assert.Equal(t, 5*time.Second, policy.delay(1))
+	assert.Equal(t, 10*time.Second, policy.delay(2))
+	assert.Equal(t, 20*time.Second, policy.delay(3))
+	assert.Equal(t, maxReconnectInterval, policy.delay(1000))
+}
+
+func TestParseReconnectPolicies(t *testing.T) {
+	def := DefaultReconnectPolicy(config.DefaultP2PConfig())
+
+	policy, err := ParseReconnectPolicy("", def)
+	require.NoError(t, err)
+	assert.Equal(t, def, policy)
+
+	policy, err = ParseReconnectPolicy("max_attempts=0; backoff=constant;interval=1s;unconditional=true", def)
+	require.NoError(t, err)
+	assert.Equal(t, ReconnectPolicy{
+		MaxAttempts:   0,
+		Backoff:       config.ReconnectBackoffConstant,
+		Interval:      time.Second,
+		Unconditional: true,
+	}, policy)
+
+	parsed, err := ParseReconnectPolicy(policy.String(), def)
+	require.NoError(t, err)
+	assert.Equal(t, policy, parsed)
+
+	for _, s := range []string{
+		"max_attempts",
+		"max_attempts=-1",
+		"backoff=linear",
+		"interval=1",
+		"unconditional=yes",
+		"timeout=1s",
+	} {
+		_, err := ParseReconnectPolicy(s, def)
+		assert.Error(t, err, s)
+	}
+
+	id1, id2 := ID("d51fb70907db1c6c2d5237e78379b25cf1a37ab4"), ID("0491d373a8e0fcf1023aaf18c51d6a1d0d4f31bd")
+	policies, err := ParseReconnectPolicies(string(id1)+":interval=1s, "+string(id2), def)
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, time.Second, policies[id1].Interval)
+	assert.Equal(t, def, policies[id2])
+
+	_, err = ParseReconnectPolicies("notanid:interval=1s", def)
+	assert.Error(t, err)
+	_, err = ParseReconnectPolicies(string(id1)+":interval=-1s", def)
+	assert.Error(t, err)
+}
@@ -2,7 +2,7 @@ package p2p
 
 import (
 	"fmt"
-	"math"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +12,7 @@ import (
 	"github.com/cometbft/cometbft/libs/cmap"
 	"github.com/cometbft/cometbft/libs/rand"
 	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/p2p/conn"
 )
 
@@ -20,14 +21,12 @@ const (
 	// before dialing peers or reconnecting to help prevent DoS
 	dialRandomizerIntervalMilliseconds = 3000
 
-	// repeatedly try to reconnect for a few minutes
+	// with the hybrid backoff, repeatedly try to reconnect for a few minutes
 	// ie. 5 * 20 = 100s
 	reconnectAttempts = 20
-	reconnectInterval = 5 * time.Second
 
 	// then move into exponential backoff mode for ~1day
 	// ie. 3**10 = 16hrs
-	reconnectBackOffAttempts    = 10
 	reconnectBackOffBaseSeconds = 3
 )
 
@@ -81,9 +80,14 @@ type Switch struct {
 	nodeInfo      NodeInfo // our node info
 	nodeKey       *NodeKey // our node privkey
 	addrBook      AddrBook
+
+	peersMtx cmtsync.RWMutex
 	// peers addresses with whom we'll maintain constant connection
 	persistentPeersAddrs []*NetAddress
 	unconditionalPeerIDs map[ID]struct{}
+	// how to reconnect to the persistent peers, by default or by peer
+	reconnectPolicy   ReconnectPolicy
+	reconnectPolicies map[ID]ReconnectPolicy
 
 	transport Transport
 
@@ -126,6 +130,8 @@ func NewSwitch(
 		filterTimeout:        defaultFilterTimeout,
 		persistentPeersAddrs: make([]*NetAddress, 0),
 		unconditionalPeerIDs: make(map[ID]struct{}),
+		reconnectPolicy:      DefaultReconnectPolicy(cfg),
+		reconnectPolicies:    make(map[ID]ReconnectPolicy),
 		mlc:                  newMetricsLabelCache(),
 	}
 
@@ -312,8 +318,12 @@ func (sw *Switch) NumPeers() (outbound, inbound, dialing int) {
 }
 
 func (sw *Switch) IsPeerUnconditional(id ID) bool {
-	_, ok := sw.unconditionalPeerIDs[id]
-	return ok
+	sw.peersMtx.RLock()
+	defer sw.peersMtx.RUnlock()
+	if _, ok := sw.unconditionalPeerIDs[id]; ok {
+		return true
+	}
+	return sw.reconnectPolicies[id].Unconditional
 }
 
 // MaxNumOutboundPeers returns a maximum number of outbound peers.
@@ -384,8 +394,9 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	}
 }
 
-// reconnectToPeer tries to reconnect to the addr, first repeatedly
-// with a fixed interval, then with exponential backoff.
+// reconnectToPeer tries to reconnect to the addr, with the reconnect policy
+// of the peer (see SetReconnectPolicy), until the peer is no longer
+// persistent (see RemovePersistentPeers).
 // If no success after all that, it stops trying, and leaves it
 // to the PEX/Addrbook to find the peer with the addr again
 // NOTE: this will keep trying even if the handshake or auth fails.
@@ -396,40 +407,36 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 	if sw.reconnecting.Has(string(addr.ID)) {
 		return
 	}
-	sw.reconnecting.Set(string(addr.ID), addr)
+	r := newReconnect()
+	sw.reconnecting.Set(string(addr.ID), r)
 	defer sw.reconnecting.Delete(string(addr.ID))
 
 	start := time.Now()
 	sw.Logger.Info("Reconnecting to peer", "addr", addr)
-	for i := 0; i < reconnectAttempts; i++ {
-		if !sw.IsRunning() {
-			return
+	for i := 0; ; i++ {
+		// the policy may change in the meantime
+		policy := sw.ReconnectPolicyOf(addr.ID)
+		if policy.MaxAttempts > 0 && i >= policy.MaxAttempts {
+			break
 		}
-
-		err := sw.DialPeerWithAddress(addr)
-		if err == nil {
-			return // success
-		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
-			return
+		if i > 0 {
+			jitter := time.Duration(sw.rng.Int63n(dialRandomizerIntervalMilliseconds)) * time.Millisecond
+			timer := time.NewTimer(policy.delay(i) + jitter)
+			select {
+			case <-timer.C:
+			case <-r.abort:
+				timer.Stop()
+				sw.Logger.Info("Stopped reconnecting to peer, no longer persistent", "addr", addr)
+				return
+			case <-sw.Quit():
+				timer.Stop()
+				return
+			}
 		}
-
-		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
-		// sleep a set amount
-		sw.randomSleep(reconnectInterval)
-		continue
-	}
-
-	sw.Logger.Error("Failed to reconnect to peer. Beginning exponential backoff",
-		"addr", addr, "elapsed", time.Since(start))
-	for i := 0; i < reconnectBackOffAttempts; i++ {
 		if !sw.IsRunning() {
 			return
 		}
 
-		// sleep an exponentially increasing amount
-		sleepIntervalSeconds := math.Pow(reconnectBackOffBaseSeconds, float64(i))
-		sw.randomSleep(time.Duration(sleepIntervalSeconds) * time.Second)
-
 		err := sw.DialPeerWithAddress(addr)
 		if err == nil {
 			return // success
@@ -570,9 +577,9 @@ func (sw *Switch) IsDialingOrExistingAddress(addr *NetAddress) bool {
 		(!sw.config.AllowDuplicateIP && sw.peers.HasIP(addr.IP))
 }
 
-// AddPersistentPeers allows you to set persistent peers. It ignores
-// ErrNetAddressLookup. However, if there are other errors, first encounter is
-// returned.
+// AddPersistentPeers allows you to add persistent peers, replacing the
+// address of those already persistent. It ignores ErrNetAddressLookup.
+// However, if there are other errors, first encounter is returned.
 func (sw *Switch) AddPersistentPeers(addrs []string) error {
 	sw.Logger.Info("Adding persistent peers", "addrs", addrs)
 	netAddrs, errs := NewNetAddressStrings(addrs)
@@ -587,12 +594,124 @@ func (sw *Switch) AddPersistentPeers(addrs []string) error {
 		}
 		return err
 	}
-	sw.persistentPeersAddrs = netAddrs
+
+	sw.peersMtx.Lock()
+	defer sw.peersMtx.Unlock()
+	for _, na := range netAddrs {
+		sw.removePersistentPeer(na.ID)
+		sw.persistentPeersAddrs = append(sw.persistentPeersAddrs, na)
+	}
+	return nil
+}
+
+// RemovePersistentPeers makes the given peers, by ID or address, no longer
+// persistent, and stops reconnecting to them. The peers connected are not
+// disconnected.
+func (sw *Switch) RemovePersistentPeers(peers []string) error {
+	ids := make([]ID, len(peers))
+	for i, peer := range peers {
+		id, _, _ := strings.Cut(removeProtocolIfDefined(peer), "@")
+		if err := validateID(ID(id)); err != nil {
+			return fmt.Errorf("wrong ID #%d: %w", i, err)
+		}
+		ids[i] = ID(id)
+	}
+	sw.Logger.Info("Removing persistent peers", "ids", ids)
+
+	sw.peersMtx.Lock()
+	defer sw.peersMtx.Unlock()
+	for _, id := range ids {
+		sw.removePersistentPeer(id)
+		delete(sw.reconnectPolicies, id)
+		if r, ok := sw.reconnecting.Get(string(id)).(*reconnect); ok {
+			r.stop()
+		}
+	}
+	return nil
+}
+
+// removePersistentPeer removes the address of the peer from the persistent
+// ones. It must be called with peersMtx held.
+func (sw *Switch) removePersistentPeer(id ID) {
+	addrs := sw.persistentPeersAddrs[:0]
+	for _, pa := range sw.persistentPeersAddrs {
+		if pa.ID != id {
+			addrs = append(addrs, pa)
+		}
+	}
+	sw.persistentPeersAddrs = addrs
+}
+
+// PersistentPeers returns the addresses of the persistent peers.
+func (sw *Switch) PersistentPeers() []*NetAddress {
+	sw.peersMtx.RLock()
+	defer sw.peersMtx.RUnlock()
+	addrs := make([]*NetAddress, len(sw.persistentPeersAddrs))
+	copy(addrs, sw.persistentPeersAddrs)
+	return addrs
+}
+
+// SetReconnectPolicies sets the reconnect policy of the persistent peers, by
+// default and by peer, replacing those set before.
+func (sw *Switch) SetReconnectPolicies(def ReconnectPolicy, policies map[ID]ReconnectPolicy) error {
+	if err := def.ValidateBasic(); err != nil {
+		return fmt.Errorf("default reconnect policy: %w", err)
+	}
+	for id, policy := range policies {
+		if err := policy.ValidateBasic(); err != nil {
+			return fmt.Errorf("reconnect policy of %s: %w", id, err)
+		}
+	}
+
+	sw.peersMtx.Lock()
+	defer sw.peersMtx.Unlock()
+	sw.reconnectPolicy = def
+	sw.reconnectPolicies = make(map[ID]ReconnectPolicy, len(policies))
+	for id, policy := range policies {
+		sw.reconnectPolicies[id] = policy
+	}
+	return nil
+}
+
+// SetReconnectPolicy sets the reconnect policy of a peer, which applies from
+// the next attempt if the switch is reconnecting to it.
+func (sw *Switch) SetReconnectPolicy(id ID, policy ReconnectPolicy) error {
+	if err := validateID(id); err != nil {
+		return err
+	}
+	if err := policy.ValidateBasic(); err != nil {
+		return err
+	}
+
+	sw.peersMtx.Lock()
+	defer sw.peersMtx.Unlock()
+	sw.reconnectPolicies[id] = policy
 	return nil
 }
 
+// DefaultReconnectPolicy returns the reconnect policy of the persistent peers
+// without a policy of their own.
+func (sw *Switch) DefaultReconnectPolicy() ReconnectPolicy {
+	sw.peersMtx.RLock()
+	defer sw.peersMtx.RUnlock()
+	return sw.reconnectPolicy
+}
+
+// ReconnectPolicyOf returns the reconnect policy of a peer, its own or the
+// default one.
+func (sw *Switch) ReconnectPolicyOf(id ID) ReconnectPolicy {
+	sw.peersMtx.RLock()
+	defer sw.peersMtx.RUnlock()
+	if policy, ok := sw.reconnectPolicies[id]; ok {
+		return policy
+	}
+	return sw.reconnectPolicy
+}
+
 func (sw *Switch) AddUnconditionalPeerIDs(ids []string) error {
 	sw.Logger.Info("Adding unconditional peer ids", "ids", ids)
+	sw.peersMtx.Lock()
+	defer sw.peersMtx.Unlock()
 	for i, id := range ids {
 		err := validateID(ID(id))
 		if err != nil {
@@ -619,6 +738,8 @@ func (sw *Switch) AddPrivatePeerIDs(ids []string) error {
 }
 
 func (sw *Switch) IsPeerPersistent(na *NetAddress) bool {
+	sw.peersMtx.RLock()
+	defer sw.peersMtx.RUnlock()
 	for _, pa := range sw.persistentPeersAddrs {
 		if pa.Equals(na) {
 			return true
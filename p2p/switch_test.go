@@ -549,6 +549,50 @@ func TestSwitchReconnectsToInboundPersistentPeer(t *testing.T) {
 	assert.Equal(t, 1, sw.Peers().Size())
 }
 
+func TestSwitchRemovePersistentPeers(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// nobody listens on these addresses
+	addr1 := "d51fb70907db1c6c2d5237e78379b25cf1a37ab4@127.0.0.1:1"
+	addr2 := "0491d373a8e0fcf1023aaf18c51d6a1d0d4f31bd@127.0.0.1:2"
+	require.NoError(t, sw.AddPersistentPeers([]string{addr1}))
+	require.NoError(t, sw.AddPersistentPeers([]string{addr2}))
+	require.Len(t, sw.PersistentPeers(), 2)
+
+	na1, err := NewNetAddressString(addr1)
+	require.NoError(t, err)
+	policy := ReconnectPolicy{
+		Backoff:       config.ReconnectBackoffConstant,
+		Interval:      10 * time.Millisecond,
+		Unconditional: true,
+	}
+	require.NoError(t, sw.SetReconnectPolicy(na1.ID, policy))
+	assert.Equal(t, policy, sw.ReconnectPolicyOf(na1.ID))
+	assert.True(t, sw.IsPeerUnconditional(na1.ID))
+
+	go sw.reconnectToPeer(na1)
+	require.Eventually(t, func() bool { return sw.reconnecting.Has(string(na1.ID)) },
+		time.Second, 10*time.Millisecond)
+
+	require.NoError(t, sw.RemovePersistentPeers([]string{string(na1.ID)}))
+	require.Eventually(t, func() bool { return !sw.reconnecting.Has(string(na1.ID)) },
+		5*time.Second, 10*time.Millisecond)
+	assert.False(t, sw.IsPeerPersistent(na1))
+	assert.False(t, sw.IsPeerUnconditional(na1.ID))
+	assert.Equal(t, sw.DefaultReconnectPolicy(), sw.ReconnectPolicyOf(na1.ID))
+	require.Len(t, sw.PersistentPeers(), 1)
+	assert.Equal(t, addr2, sw.PersistentPeers()[0].String())
+
+	assert.Error(t, sw.RemovePersistentPeers([]string{"127.0.0.1:2"}))
+}
+
 func TestSwitchDialPeersAsync(t *testing.T) {
 	if testing.Short() {
 		return
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/libs/protoio"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/p2p/conn"
 	tmp2p "github.com/cometbft/cometbft/proto/tendermint/p2p"
 )
@@ -151,10 +153,16 @@ type MultiplexTransport struct {
 	dialTimeout      time.Duration
 	filterTimeout    time.Duration
 	handshakeTimeout time.Duration
-	nodeInfo         NodeInfo
 	nodeKey          NodeKey
 	resolver         IPResolver
 
+	nodeInfoMtx cmtsync.RWMutex
+	nodeInfo    NodeInfo // see SetListenAddr
+
+	// Mutual TLS with private peers, see MultiplexTransportPrivatePeerTLS.
+	tlsConfig  *tls.Config
+	tlsPeerIDs map[ID]struct{}
+
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
 	// with sane defaults.
@@ -190,6 +198,26 @@ func (mt *MultiplexTransport) NetAddress() NetAddress {
 	return mt.netAddr
 }
 
+// NodeInfo returns the node info sent to peers in the handshake.
+func (mt *MultiplexTransport) NodeInfo() NodeInfo {
+	mt.nodeInfoMtx.RLock()
+	defer mt.nodeInfoMtx.RUnlock()
+	return mt.nodeInfo
+}
+
+// SetListenAddr updates the listen address sent to peers in the handshake,
+// e.g. once the port is mapped on the gateway. It is safe to call while the
+// transport is running.
+// NOTE: NodeInfo must be of type DefaultNodeInfo else the address won't be updated
+func (mt *MultiplexTransport) SetListenAddr(addr string) {
+	mt.nodeInfoMtx.Lock()
+	defer mt.nodeInfoMtx.Unlock()
+	if ni, ok := mt.nodeInfo.(DefaultNodeInfo); ok {
+		ni.ListenAddr = addr
+		mt.nodeInfo = ni
+	}
+}
+
 // Accept implements Transport.
 func (mt *MultiplexTransport) Accept(cfg peerConfig) (Peer, error) {
 	select {
@@ -223,6 +251,15 @@ func (mt *MultiplexTransport) Dial(
 		return nil, err
 	}
 
+	if mt.requiresTLS(addr.ID) {
+		tc, err := mt.upgradeTLSClient(c, addr.ID)
+		if err != nil {
+			_ = mt.cleanup(c)
+			return nil, err
+		}
+		c = tc
+	}
+
 	secretConn, nodeInfo, err := mt.upgrade(c, &addr)
 	if err != nil {
 		return nil, err
@@ -270,6 +307,8 @@ func (mt *MultiplexTransport) Listen(addr NetAddress) error {
 // This is a bit messy at the moment but is cleaned up in the following version
 // when NodeInfo changes from an interface to a concrete type
 func (mt *MultiplexTransport) AddChannel(chID byte) {
+	mt.nodeInfoMtx.Lock()
+	defer mt.nodeInfoMtx.Unlock()
 	if ni, ok := mt.nodeInfo.(DefaultNodeInfo); ok {
 		if !ni.HasChannel(chID) {
 			ni.Channels = append(ni.Channels, chID)
@@ -326,6 +365,15 @@ func (mt *MultiplexTransport) acceptPeers() {
 			)
 
 			err := mt.filterConn(c)
+			if err == nil && mt.tlsConfig != nil {
+				var tc net.Conn
+				tc, err = mt.maybeUpgradeTLSServer(c)
+				if err != nil {
+					_ = mt.cleanup(c)
+				} else {
+					c = tc
+				}
+			}
 			if err == nil {
 				secretConn, nodeInfo, err = mt.upgrade(c, nil)
 				if err == nil {
@@ -439,7 +487,16 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
+	if err := mt.checkPeerTLS(c, connID); err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			id:            connID,
+			err:           err,
+			isAuthFailure: true,
+		}
+	}
+
+	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.NodeInfo())
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -471,7 +528,7 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	// Reject self.
-	if mt.nodeInfo.ID() == nodeInfo.ID() {
+	if mt.NodeInfo().ID() == nodeInfo.ID() {
 		return nil, nil, ErrRejected{
 			addr:   *NewNetAddress(nodeInfo.ID(), c.RemoteAddr()),
 			conn:   c,
@@ -480,7 +537,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
+	if err := mt.NodeInfo().CompatibleWith(nodeInfo); err != nil {
 		return nil, nil, ErrRejected{
 			conn:           c,
 			err:            err,
@@ -518,6 +575,8 @@ func (mt *MultiplexTransport) wrapPeer(
 		socketAddr,
 	)
 
+	features := mt.negotiateFeatures(ni)
+
 	p := newPeer(
 		peerConn,
 		mt.mConfig,
@@ -528,11 +587,43 @@ func (mt *MultiplexTransport) wrapPeer(
 		cfg.onPeerError,
 		cfg.mlc,
 		PeerMetrics(cfg.metrics),
+		peerFeatures(features),
+		peerCompression(mt.negotiateCompression(features, ni)),
 	)
 
 	return p
 }
 
+// negotiateFeatures returns the features supported by both us and the peer.
+func (mt *MultiplexTransport) negotiateFeatures(ni NodeInfo) Features {
+	ours, ok := mt.NodeInfo().(DefaultNodeInfo)
+	if !ok {
+		return 0
+	}
+	theirs, ok := ni.(DefaultNodeInfo)
+	if !ok {
+		return 0
+	}
+	return ours.Features.Intersect(theirs.Features)
+}
+
+// negotiateCompression returns the compression to use with a peer, based on
+// both sides' node info.
+func (mt *MultiplexTransport) negotiateCompression(features Features, ni NodeInfo) *channelCompression {
+	if !features.Has(FeatureCompression) {
+		return nil
+	}
+	ours, ok := mt.NodeInfo().(DefaultNodeInfo)
+	if !ok {
+		return nil
+	}
+	theirs, ok := ni.(DefaultNodeInfo)
+	if !ok {
+		return nil
+	}
+	return negotiateCompression(ours.Compression, theirs.Compression)
+}
+
 func handshake(
 	c net.Conn,
 	timeout time.Duration,
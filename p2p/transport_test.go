@@ -636,6 +636,20 @@ func TestTransportAddChannel(t *testing.T) {
 	}
 }
 
+func TestTransportSetListenAddr(t *testing.T) {
+	mt := newMultiplexTransport(
+		emptyNodeInfo(),
+		NodeKey{
+			PrivKey: ed25519.GenPrivKey(),
+		},
+	)
+
+	mt.SetListenAddr("203.0.113.7:26656")
+	if have, want := mt.NodeInfo().(DefaultNodeInfo).ListenAddr, "203.0.113.7:26656"; have != want {
+		t.Errorf("have %v, want %v", have, want)
+	}
+}
+
 // create listener
 func testSetupMultiplexTransport(t *testing.T) *MultiplexTransport {
 	var (
@@ -0,0 +1,152 @@
+package p2p
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// tlsRecordTypeHandshake is the first byte sent by a TLS client. It can't be
+// mistaken for the first byte of a secret connection handshake, which is the
+// length prefix of the ephemeral public key.
+const tlsRecordTypeHandshake = 0x16
+
+// MultiplexTransportPrivatePeerTLS requires connections with the given peers
+// to be wrapped in mutually-authenticated TLS before the secret connection is
+// established. The peer's certificate must be valid for tlsConfig and carry
+// its node ID as a DNS subject alternative name, which binds the certificate
+// to the key authenticated by the secret connection.
+//
+// Connections with other peers are unaffected: inbound connections are
+// accepted with or without TLS, and TLS is only used when dialing the given
+// peers.
+func MultiplexTransportPrivatePeerTLS(tlsConfig *tls.Config, ids []ID) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) {
+		mt.tlsConfig = tlsConfig
+		mt.tlsPeerIDs = make(map[ID]struct{}, len(ids))
+		for _, id := range ids {
+			mt.tlsPeerIDs[id] = struct{}{}
+		}
+	}
+}
+
+// LoadPrivatePeerTLSConfig builds a TLS configuration for private peers from
+// PEM encoded files. The CA file is used to verify both client and server
+// certificates.
+func LoadPrivatePeerTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TLS CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+func (mt *MultiplexTransport) requiresTLS(id ID) bool {
+	_, ok := mt.tlsPeerIDs[id]
+	return ok
+}
+
+// upgradeTLSClient wraps an outbound connection to id in TLS.
+func (mt *MultiplexTransport) upgradeTLSClient(c net.Conn, id ID) (net.Conn, error) {
+	cfg := mt.tlsConfig.Clone()
+	cfg.ServerName = string(id)
+	tc := tls.Client(c, cfg)
+	if err := tlsHandshake(tc, mt.handshakeTimeout); err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			id:            id,
+			err:           fmt.Errorf("tls handshake failed: %w", err),
+			isAuthFailure: true,
+		}
+	}
+	return tc, nil
+}
+
+// maybeUpgradeTLSServer wraps an inbound connection in TLS if the remote
+// starts a TLS handshake. Whether TLS was actually required is checked once
+// the remote ID is known, see checkPeerTLS.
+func (mt *MultiplexTransport) maybeUpgradeTLSServer(c net.Conn) (net.Conn, error) {
+	if err := c.SetReadDeadline(time.Now().Add(mt.handshakeTimeout)); err != nil {
+		return nil, err
+	}
+	pc := &peekedConn{Conn: c, r: bufio.NewReader(c)}
+	first, err := pc.r.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	if first[0] != tlsRecordTypeHandshake {
+		return pc, nil
+	}
+
+	tc := tls.Server(pc, mt.tlsConfig)
+	if err := tlsHandshake(tc, mt.handshakeTimeout); err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("tls handshake failed: %w", err),
+			isAuthFailure: true,
+		}
+	}
+	return tc, nil
+}
+
+// checkPeerTLS ensures that a connection with a peer requiring TLS was
+// established over TLS with a certificate issued for that peer's ID.
+func (mt *MultiplexTransport) checkPeerTLS(c net.Conn, id ID) error {
+	if !mt.requiresTLS(id) {
+		return nil
+	}
+	tc, ok := c.(*tls.Conn)
+	if !ok {
+		return errors.New("peer requires mutual TLS")
+	}
+	certs := tc.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return errors.New("peer did not present a TLS certificate")
+	}
+	if err := certs[0].VerifyHostname(string(id)); err != nil {
+		return fmt.Errorf("peer TLS certificate does not match its ID: %w", err)
+	}
+	return nil
+}
+
+func tlsHandshake(tc *tls.Conn, timeout time.Duration) error {
+	if err := tc.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	if err := tc.Handshake(); err != nil {
+		return err
+	}
+	return tc.SetDeadline(time.Time{})
+}
+
+// peekedConn is a net.Conn whose first bytes were peeked at.
+type peekedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
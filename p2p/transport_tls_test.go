@@ -0,0 +1,108 @@
+package p2p
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/internal/test"
+)
+
+// testTLSConfig returns a private peer TLS config with a certificate issued for id.
+func testTLSConfig(t *testing.T, ca *test.CA, id ID) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{ca.Issue(t, string(id))},
+		RootCAs:      ca.Pool,
+		ClientCAs:    ca.Pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}
+}
+
+func newTestTLSTransport(name string) (*MultiplexTransport, ID) {
+	pv := ed25519.GenPrivKey()
+	id := PubKeyToID(pv.PubKey())
+	return newMultiplexTransport(testNodeInfo(id, name), NodeKey{PrivKey: pv}), id
+}
+
+func TestTransportPrivatePeerTLS(t *testing.T) {
+	ca := test.NewCA(t)
+
+	testCases := []struct {
+		name      string
+		dialerTLS bool
+		certFor   func(dialerID ID) ID
+		expectErr bool
+	}{
+		{"mutual tls", true, func(id ID) ID { return id }, false},
+		{"no tls from private peer", false, nil, true},
+		{"certificate for another id", true, func(ID) ID { return ID("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef") }, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			listener, listenerID := newTestTLSTransport("listener")
+			dialer, dialerID := newTestTLSTransport("dialer")
+
+			MultiplexTransportPrivatePeerTLS(testTLSConfig(t, ca, listenerID), []ID{dialerID})(listener)
+			if tc.dialerTLS {
+				MultiplexTransportPrivatePeerTLS(testTLSConfig(t, ca, tc.certFor(dialerID)), []ID{listenerID})(dialer)
+			}
+
+			addr, err := NewNetAddressString(IDAddressString(listenerID, "127.0.0.1:0"))
+			require.NoError(t, err)
+			require.NoError(t, listener.Listen(*addr))
+			defer listener.Close()
+
+			laddr := NewNetAddress(listenerID, listener.listener.Addr())
+			errc := make(chan error, 1)
+			go func() {
+				_, err := dialer.Dial(*laddr, peerConfig{})
+				errc <- err
+			}()
+
+			p, err := listener.Accept(peerConfig{})
+			if tc.expectErr {
+				require.Error(t, err)
+				e, ok := err.(ErrRejected)
+				require.True(t, ok, "expected ErrRejected, got %v", err)
+				assert.True(t, e.IsAuthFailure())
+				<-errc
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, dialerID, p.ID())
+			require.NoError(t, <-errc)
+		})
+	}
+}
+
+func TestTransportPrivatePeerTLSOtherPeers(t *testing.T) {
+	ca := test.NewCA(t)
+	listener, listenerID := newTestTLSTransport("listener")
+	dialer, dialerID := newTestTLSTransport("dialer")
+
+	// TLS is only required from a different peer.
+	MultiplexTransportPrivatePeerTLS(testTLSConfig(t, ca, listenerID), []ID{"deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"})(listener)
+
+	addr, err := NewNetAddressString(IDAddressString(listenerID, "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, listener.Listen(*addr))
+	defer listener.Close()
+
+	laddr := NewNetAddress(listenerID, listener.listener.Addr())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := dialer.Dial(*laddr, peerConfig{})
+		errc <- err
+	}()
+
+	p, err := listener.Accept(peerConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, dialerID, p.ID())
+	require.NoError(t, <-errc)
+}
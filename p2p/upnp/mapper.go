@@ -0,0 +1,191 @@
+package upnp
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+const (
+	// DefaultLeaseDuration is the lifetime requested for port mappings. The
+	// mapping is refreshed at half of it.
+	DefaultLeaseDuration = 20 * time.Minute
+
+	mappingDescription = "CometBFT"
+
+	// retryInterval is the delay between attempts to map the port until the
+	// first one succeeds.
+	retryInterval = time.Minute
+)
+
+// PortMapper keeps a TCP port mapped on the local gateway, using UPnP or,
+// when that is unavailable, NAT-PMP.
+//
+// Once started, the port is mapped in the background, retrying until the
+// gateway answers, and the mapping is refreshed until the service is stopped,
+// at which point it is removed from the gateway. Discovering the gateway can
+// take several seconds, so Start does not wait for it; use SetOnMapped to
+// learn the external address.
+type PortMapper struct {
+	service.BaseService
+
+	port  int
+	lease time.Duration
+
+	// overridden in tests
+	discover      func() (NAT, error)
+	retryInterval time.Duration
+
+	onMapped func(externalAddr string)
+
+	mtx          sync.Mutex
+	nat          NAT
+	externalIP   net.IP
+	externalPort int
+}
+
+// NewPortMapper returns a PortMapper for the given local port.
+func NewPortMapper(port int, lease time.Duration, logger log.Logger) *PortMapper {
+	pm := &PortMapper{
+		port:          port,
+		lease:         lease,
+		discover:      DiscoverGateway,
+		retryInterval: retryInterval,
+	}
+	pm.BaseService = *service.NewBaseService(logger, "PortMapper", pm)
+	return pm
+}
+
+// DiscoverGateway discovers the local gateway via UPnP, falling back to
+// NAT-PMP.
+func DiscoverGateway() (NAT, error) {
+	nat, upnpErr := Discover()
+	if upnpErr == nil {
+		return nat, nil
+	}
+	nat, pmpErr := DiscoverNATPMP()
+	if pmpErr == nil {
+		return nat, nil
+	}
+	return nil, fmt.Errorf("no NAT gateway found (upnp: %v, natpmp: %v)", upnpErr, pmpErr)
+}
+
+// SetOnMapped sets a function called with the external address every time it
+// changes, starting with the first successful mapping. It must be called
+// before Start.
+func (pm *PortMapper) SetOnMapped(fn func(externalAddr string)) {
+	pm.onMapped = fn
+}
+
+// Map discovers the gateway, maps the port and resolves the external address.
+// It blocks until the gateway answers or the discovery times out; the service
+// calls it on its own once started.
+func (pm *PortMapper) Map() error {
+	nat, err := pm.discover()
+	if err != nil {
+		return err
+	}
+	pm.mtx.Lock()
+	pm.nat = nat
+	pm.mtx.Unlock()
+	return pm.refresh()
+}
+
+// ExternalAddress returns the external "host:port" at which the local port is
+// reachable, or an empty string if the port was not mapped.
+func (pm *PortMapper) ExternalAddress() string {
+	pm.mtx.Lock()
+	defer pm.mtx.Unlock()
+
+	if pm.externalIP == nil {
+		return ""
+	}
+	return net.JoinHostPort(pm.externalIP.String(), fmt.Sprint(pm.externalPort))
+}
+
+// OnStart implements service.Service by starting the mapping routine.
+func (pm *PortMapper) OnStart() error {
+	go pm.mapRoutine()
+	return nil
+}
+
+// OnStop implements service.Service by removing the mapping, if any.
+func (pm *PortMapper) OnStop() {
+	pm.mtx.Lock()
+	nat, externalPort := pm.nat, pm.externalPort
+	pm.mtx.Unlock()
+	if nat == nil || externalPort == 0 {
+		return
+	}
+
+	if err := nat.DeletePortMapping("tcp", externalPort, pm.port); err != nil {
+		pm.Logger.Error("Failed to delete port mapping", "port", pm.port, "err", err)
+	}
+}
+
+// mapRoutine maps the port, retrying until it succeeds, then refreshes the
+// mapping at half of the lease.
+func (pm *PortMapper) mapRoutine() {
+	for {
+		err := pm.Map()
+		if err == nil {
+			break
+		}
+		pm.Logger.Error("Failed to map port on gateway, retrying", "port", pm.port, "in", pm.retryInterval, "err", err)
+		select {
+		case <-time.After(pm.retryInterval):
+		case <-pm.Quit():
+			return
+		}
+	}
+
+	ticker := time.NewTicker(pm.lease / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := pm.refresh(); err != nil {
+				pm.Logger.Error("Failed to refresh port mapping", "port", pm.port, "err", err)
+			}
+		case <-pm.Quit():
+			return
+		}
+	}
+}
+
+func (pm *PortMapper) refresh() error {
+	pm.mtx.Lock()
+	nat, requested := pm.nat, pm.externalPort
+	pm.mtx.Unlock()
+	if requested == 0 {
+		requested = pm.port
+	}
+
+	externalPort, err := nat.AddPortMapping("tcp", requested, pm.port, mappingDescription, int(pm.lease.Seconds()))
+	if err != nil {
+		return fmt.Errorf("failed to map port %d: %w", pm.port, err)
+	}
+	externalIP, err := nat.GetExternalAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get external address: %w", err)
+	}
+
+	pm.mtx.Lock()
+	changed := !externalIP.Equal(pm.externalIP) || externalPort != pm.externalPort
+	pm.externalIP, pm.externalPort = externalIP, externalPort
+	pm.mtx.Unlock()
+
+	if changed {
+		externalAddr := pm.ExternalAddress()
+		pm.Logger.Info("Mapped port on gateway", "port", pm.port, "external", externalAddr)
+		if pm.onMapped != nil {
+			pm.onMapped(externalAddr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package upnp
+
+// Just enough NAT-PMP (RFC 6886) to be able to forward ports.
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	natpmpPort    = 5351
+	natpmpVersion = 0
+
+	natpmpOpExternalAddress = 0
+	natpmpOpMapUDP          = 1
+	natpmpOpMapTCP          = 2
+	natpmpOpResponse        = 128
+
+	// initial retransmission timeout, doubled on every attempt (RFC 6886 3.1).
+	natpmpInitialTimeout = 250 * time.Millisecond
+	natpmpAttempts       = 4
+)
+
+type natpmpNAT struct {
+	gateway *net.UDPAddr
+}
+
+// DiscoverNATPMP looks up the default gateway and checks that it answers
+// NAT-PMP requests.
+func DiscoverNATPMP() (nat NAT, err error) {
+	gw, err := defaultGateway()
+	if err != nil {
+		return nil, err
+	}
+	n := &natpmpNAT{gateway: &net.UDPAddr{IP: gw, Port: natpmpPort}}
+	if _, err := n.GetExternalAddress(); err != nil {
+		return nil, fmt.Errorf("natpmp port discovery failed: %w", err)
+	}
+	return n, nil
+}
+
+// GetExternalAddress returns the external IP reported by the gateway.
+func (n *natpmpNAT) GetExternalAddress() (addr net.IP, err error) {
+	res, err := n.request([]byte{natpmpVersion, natpmpOpExternalAddress}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(res[8], res[9], res[10], res[11]), nil
+}
+
+// AddPortMapping asks the gateway to map externalPort to internalPort for
+// timeout seconds. The gateway may choose a different external port, which is
+// returned.
+func (n *natpmpNAT) AddPortMapping(
+	protocol string,
+	externalPort,
+	internalPort int,
+	description string,
+	timeout int) (mappedExternalPort int, err error) {
+	res, err := n.mapPort(protocol, externalPort, internalPort, timeout)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(res[10:12])), nil
+}
+
+// DeletePortMapping removes the mapping by requesting a zero lifetime.
+func (n *natpmpNAT) DeletePortMapping(protocol string, externalPort, internalPort int) (err error) {
+	_, err = n.mapPort(protocol, 0, internalPort, 0)
+	return err
+}
+
+func (n *natpmpNAT) mapPort(protocol string, externalPort, internalPort, lifetime int) ([]byte, error) {
+	var op byte
+	switch strings.ToLower(protocol) {
+	case "udp":
+		op = natpmpOpMapUDP
+	case "tcp":
+		op = natpmpOpMapTCP
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", protocol)
+	}
+	msg := make([]byte, 12)
+	msg[0] = natpmpVersion
+	msg[1] = op
+	binary.BigEndian.PutUint16(msg[4:6], uint16(internalPort))
+	binary.BigEndian.PutUint16(msg[6:8], uint16(externalPort))
+	binary.BigEndian.PutUint32(msg[8:12], uint32(lifetime))
+	return n.request(msg, 16)
+}
+
+// request sends msg to the gateway, retransmitting with exponential backoff,
+// and returns the validated response.
+func (n *natpmpNAT) request(msg []byte, resSize int) ([]byte, error) {
+	conn, err := net.DialUDP("udp4", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res := make([]byte, 16)
+	timeout := natpmpInitialTimeout
+	for i := 0; i < natpmpAttempts; i++ {
+		if _, err = conn.Write(msg); err != nil {
+			return nil, err
+		}
+		if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+		var size int
+		size, err = conn.Read(res)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				timeout *= 2
+				continue
+			}
+			return nil, err
+		}
+		if size < resSize {
+			return nil, fmt.Errorf("natpmp: short response (%d bytes)", size)
+		}
+		if res[0] != natpmpVersion || res[1] != msg[1]|natpmpOpResponse {
+			return nil, fmt.Errorf("natpmp: unexpected response %X", res[:2])
+		}
+		if code := binary.BigEndian.Uint16(res[2:4]); code != 0 {
+			return nil, fmt.Errorf("natpmp: gateway returned result code %d", code)
+		}
+		return res[:resSize], nil
+	}
+	return nil, fmt.Errorf("natpmp: no response from gateway %v: %w", n.gateway, err)
+}
+
+// defaultGateway returns the IPv4 default gateway. On Linux it is read from
+// the routing table; elsewhere we assume the gateway is the first address of
+// our local /24.
+func defaultGateway() (net.IP, error) {
+	if gw, err := linuxDefaultGateway(); err == nil {
+		return gw, nil
+	}
+	ip, err := localIPv4()
+	if err != nil {
+		return nil, err
+	}
+	gw := make(net.IP, len(ip))
+	copy(gw, ip)
+	gw[3] = 1
+	return gw, nil
+}
+
+func linuxDefaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Iface Destination Gateway Flags ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		gw, err := hex.DecodeString(fields[2])
+		if err != nil || len(gw) != 4 {
+			continue
+		}
+		// the routing table stores addresses in host (little endian) order.
+		return net.IPv4(gw[3], gw[2], gw[1], gw[0]).To4(), nil
+	}
+	return nil, errors.New("no default route found")
+}
@@ -0,0 +1,121 @@
+package upnp
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// fakeGateway answers NAT-PMP requests on a local UDP port.
+func fakeGateway(t *testing.T, externalIP net.IP, portOffset uint16) *net.UDPAddr {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 16)
+		for {
+			n, from, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			if n < 2 {
+				continue
+			}
+			var res []byte
+			switch buf[1] {
+			case natpmpOpExternalAddress:
+				res = make([]byte, 12)
+				copy(res[8:], externalIP.To4())
+			case natpmpOpMapUDP, natpmpOpMapTCP:
+				res = make([]byte, 16)
+				copy(res[8:10], buf[4:6])
+				binary.BigEndian.PutUint16(res[10:12], binary.BigEndian.Uint16(buf[6:8])+portOffset)
+				copy(res[12:16], buf[8:12])
+			default:
+				continue
+			}
+			res[1] = buf[1] | natpmpOpResponse
+			_, _ = conn.WriteToUDP(res, from)
+		}
+	}()
+	return conn.LocalAddr().(*net.UDPAddr)
+}
+
+func TestNATPMP(t *testing.T) {
+	extIP := net.IPv4(203, 0, 113, 7)
+	nat := &natpmpNAT{gateway: fakeGateway(t, extIP, 1)}
+
+	addr, err := nat.GetExternalAddress()
+	require.NoError(t, err)
+	assert.True(t, extIP.Equal(addr))
+
+	port, err := nat.AddPortMapping("tcp", 26656, 26656, "test", 60)
+	require.NoError(t, err)
+	assert.Equal(t, 26657, port)
+
+	require.NoError(t, nat.DeletePortMapping("tcp", port, 26656))
+
+	_, err = nat.AddPortMapping("sctp", 26656, 26656, "test", 60)
+	assert.Error(t, err)
+}
+
+func TestNATPMPNoGateway(t *testing.T) {
+	// nothing listens on this port, so the request must time out.
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	gw := conn.LocalAddr().(*net.UDPAddr)
+	conn.Close()
+
+	nat := &natpmpNAT{gateway: gw}
+	_, err = nat.GetExternalAddress()
+	assert.Error(t, err)
+}
+
+func TestPortMapper(t *testing.T) {
+	extIP := net.IPv4(203, 0, 113, 7)
+	gw := fakeGateway(t, extIP, 0)
+
+	pm := NewPortMapper(26656, 2*time.Second, log.TestingLogger())
+	pm.retryInterval = 10 * time.Millisecond
+	// the gateway doesn't answer at first: Start must not fail nor wait for it.
+	attempts := 0
+	pm.discover = func() (NAT, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("no gateway")
+		}
+		return &natpmpNAT{gateway: gw}, nil
+	}
+	mapped := make(chan string, 1)
+	pm.SetOnMapped(func(externalAddr string) { mapped <- externalAddr })
+
+	require.NoError(t, pm.Start())
+	assert.Empty(t, pm.ExternalAddress())
+
+	select {
+	case addr := <-mapped:
+		assert.Equal(t, "203.0.113.7:26656", addr)
+	case <-time.After(5 * time.Second):
+		t.Fatal("port was not mapped")
+	}
+	assert.Equal(t, "203.0.113.7:26656", pm.ExternalAddress())
+
+	require.NoError(t, pm.Stop())
+}
+
+func TestPortMapperStopUnmapped(t *testing.T) {
+	pm := NewPortMapper(26656, 2*time.Second, log.TestingLogger())
+	pm.discover = func() (NAT, error) { return nil, errors.New("no gateway") }
+
+	require.NoError(t, pm.Start())
+	require.NoError(t, pm.Stop())
+	assert.Empty(t, pm.ExternalAddress())
+}
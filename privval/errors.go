@@ -22,6 +22,10 @@ var (
 	ErrWriteTimeout       = errors.New("endpoint write timed out")
 )
 
+// ErrDeadlineExceeded is returned for the requests to a remote signer given up
+// after their deadline.
+var ErrDeadlineExceeded = errors.New("request deadline exceeded")
+
 // RemoteSignerError allows (remote) validators to include meaningful error
 // descriptions in their reply.
 type RemoteSignerError struct {
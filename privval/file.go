@@ -17,7 +17,6 @@ import (
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmtos "github.com/cometbft/cometbft/libs/os"
 	"github.com/cometbft/cometbft/libs/protoio"
-	"github.com/cometbft/cometbft/libs/tempfile"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/types"
 	cmttime "github.com/cometbft/cometbft/types/time"
@@ -52,23 +51,31 @@ type FilePVKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
 	filePath string
+	// if not nil, the key is saved encrypted with it
+	encryption *keyEncryption
 }
 
 // Save persists the FilePVKey to its filePath.
 func (pvKey FilePVKey) Save() {
+	if err := pvKey.save(); err != nil {
+		panic(err)
+	}
+}
+
+func (pvKey FilePVKey) save() error {
 	outFile := pvKey.filePath
 	if outFile == "" {
-		panic("cannot save PrivValidator key: filePath not set")
+		return errors.New("cannot save PrivValidator key: filePath not set")
 	}
 
-	jsonBytes, err := cmtjson.MarshalIndent(pvKey, "", "  ")
-	if err != nil {
-		panic(err)
+	if pvKey.encryption == nil {
+		return writeKeyFile(outFile, pvKey)
 	}
-
-	if err := tempfile.WriteFileAtomic(outFile, jsonBytes, 0600); err != nil {
-		panic(err)
+	encKey, err := pvKey.encryption.encrypt(pvKey)
+	if err != nil {
+		return fmt.Errorf("error encrypting PrivValidator key: %w", err)
 	}
+	return writeKeyFile(outFile, encKey)
 }
 
 //-------------------------------------------------------------------------------
@@ -127,16 +134,7 @@ func (lss *FilePVLastSignState) CheckHRS(height int64, round int32, step int8) (
 
 // Save persists the FilePvLastSignState to its filePath.
 func (lss *FilePVLastSignState) Save() {
-	outFile := lss.filePath
-	if outFile == "" {
-		panic("cannot save FilePVLastSignState: filePath not set")
-	}
-	jsonBytes, err := cmtjson.MarshalIndent(lss, "", "  ")
-	if err != nil {
-		panic(err)
-	}
-	err = tempfile.WriteFileAtomic(outFile, jsonBytes, 0600)
-	if err != nil {
+	if err := NewFileWatermarkStore(lss.filePath).Save(*lss); err != nil {
 		panic(err)
 	}
 }
@@ -151,6 +149,9 @@ func (lss *FilePVLastSignState) Save() {
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	// if nil, the LastSignState is persisted to its filePath
+	store WatermarkStore
 }
 
 // NewFilePV generates a new validator from the given key and paths.
@@ -205,22 +206,52 @@ func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
 	return loadFilePV(keyFilePath, stateFilePath, false)
 }
 
-// If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
-	keyJSONBytes, err := os.ReadFile(keyFilePath)
+// LoadFilePVWithPassphrase loads a FilePV from the filePaths, its key being
+// encrypted with passphrase; the key is saved encrypted with it too.
+func LoadFilePVWithPassphrase(keyFilePath, stateFilePath string, passphrase []byte) (*FilePV, error) {
+	pvKey, err := loadFilePVKey(keyFilePath, passphrase)
 	if err != nil {
-		cmtos.Exit(err.Error())
+		return nil, err
 	}
-	pvKey := FilePVKey{}
-	err = cmtjson.Unmarshal(keyJSONBytes, &pvKey)
+	stateJSONBytes, err := os.ReadFile(stateFilePath)
 	if err != nil {
-		cmtos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
+		return nil, err
+	}
+	pvState := FilePVLastSignState{}
+	if err := cmtjson.Unmarshal(stateJSONBytes, &pvState); err != nil {
+		return nil, fmt.Errorf("error reading PrivValidator state from %v: %w", stateFilePath, err)
 	}
+	pvState.filePath = stateFilePath
+	return &FilePV{
+		Key:           pvKey,
+		LastSignState: pvState,
+	}, nil
+}
 
-	// overwrite pubkey and address for convenience
-	pvKey.PubKey = pvKey.PrivKey.PubKey()
-	pvKey.Address = pvKey.PubKey.Address()
-	pvKey.filePath = keyFilePath
+// LoadFilePVWithWatermarkStore loads a FilePV from the keyFilePath, persisting
+// its LastSignState to store, e.g. a SharedWatermarkStore.
+func LoadFilePVWithWatermarkStore(keyFilePath string, store WatermarkStore) (*FilePV, error) {
+	pvKey, err := loadFilePVKey(keyFilePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	pvState, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("error loading PrivValidator state: %w", err)
+	}
+	return &FilePV{
+		Key:           pvKey,
+		LastSignState: pvState,
+		store:         store,
+	}, nil
+}
+
+// If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
+	pvKey, err := loadFilePVKey(keyFilePath, nil)
+	if err != nil {
+		cmtos.Exit(err.Error())
+	}
 
 	pvState := FilePVLastSignState{}
 
@@ -243,6 +274,50 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	}
 }
 
+// loadFilePVKey loads the FilePVKey at keyFilePath, decrypting it with
+// passphrase if it is encrypted.
+func loadFilePVKey(keyFilePath string, passphrase []byte) (FilePVKey, error) {
+	pvKey := FilePVKey{}
+	keyJSONBytes, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return pvKey, err
+	}
+	if isEncryptedKey(keyJSONBytes) {
+		return loadEncryptedFilePVKey(keyFilePath, keyJSONBytes, passphrase)
+	}
+	err = cmtjson.Unmarshal(keyJSONBytes, &pvKey)
+	if err != nil {
+		return pvKey, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
+	}
+
+	// overwrite pubkey and address for convenience
+	pvKey.PubKey = pvKey.PrivKey.PubKey()
+	pvKey.Address = pvKey.PubKey.Address()
+	pvKey.filePath = keyFilePath
+	return pvKey, nil
+}
+
+func loadEncryptedFilePVKey(keyFilePath string, keyJSONBytes, passphrase []byte) (FilePVKey, error) {
+	if passphrase == nil {
+		return FilePVKey{}, fmt.Errorf("%v: %w", keyFilePath, ErrKeyEncrypted)
+	}
+	var encKey EncryptedFilePVKey
+	if err := cmtjson.Unmarshal(keyJSONBytes, &encKey); err != nil {
+		return FilePVKey{}, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
+	}
+	ke, err := deriveKeyEncryption(encKey.Encryption, passphrase)
+	if err != nil {
+		return FilePVKey{}, fmt.Errorf("error decrypting PrivValidator key from %v: %w", keyFilePath, err)
+	}
+	pvKey, err := ke.decrypt(encKey)
+	if err != nil {
+		return FilePVKey{}, fmt.Errorf("error decrypting PrivValidator key from %v: %w", keyFilePath, err)
+	}
+	pvKey.filePath = keyFilePath
+	pvKey.encryption = ke
+	return pvKey, nil
+}
+
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
 func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
@@ -283,9 +358,9 @@ func (pv *FilePV) GetPubKey() (crypto.PubKey, error) {
 
 // SignVote signs a canonical representation of the vote, along with the
 // chainID. Implements PrivValidator.
-func (pv *FilePV) SignVote(chainID string, vote *cmtproto.Vote) error {
-	if err := pv.signVote(chainID, vote); err != nil {
-		return fmt.Errorf("error signing vote: %v", err)
+func (pv *FilePV) SignVote(chainID string, encoding types.CanonicalEncoding, vote *cmtproto.Vote) error {
+	if err := pv.signVote(chainID, encoding, vote); err != nil {
+		return fmt.Errorf("error signing vote: %w", err)
 	}
 	return nil
 }
@@ -294,7 +369,7 @@ func (pv *FilePV) SignVote(chainID string, vote *cmtproto.Vote) error {
 // the chainID. Implements PrivValidator.
 func (pv *FilePV) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
 	if err := pv.signProposal(chainID, proposal); err != nil {
-		return fmt.Errorf("error signing proposal: %v", err)
+		return fmt.Errorf("error signing proposal: %w", err)
 	}
 	return nil
 }
@@ -302,7 +377,9 @@ func (pv *FilePV) SignProposal(chainID string, proposal *cmtproto.Proposal) erro
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
-	pv.LastSignState.Save()
+	if err := pv.watermarks().Save(pv.LastSignState); err != nil {
+		panic(err)
+	}
 }
 
 // Reset resets all fields in the FilePV.
@@ -333,7 +410,7 @@ func (pv *FilePV) String() string {
 // signVote checks if the vote is good to sign and sets the vote signature.
 // It may need to set the timestamp as well if the vote is otherwise the same as
 // a previously signed vote (ie. we crashed after signing but before the vote hit the WAL).
-func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
+func (pv *FilePV) signVote(chainID string, encoding types.CanonicalEncoding, vote *cmtproto.Vote) error {
 	height, round, step := vote.Height, vote.Round, voteToStep(vote)
 
 	lss := pv.LastSignState
@@ -343,7 +420,7 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
 		return err
 	}
 
-	signBytes := types.VoteSignBytes(chainID, vote)
+	signBytes := types.VoteSignBytes(chainID, encoding, vote)
 
 	// We might crash before writing to the wal,
 	// causing us to try to re-sign for the same HRS.
@@ -367,7 +444,9 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote) error {
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	if err := pv.saveSigned(height, round, step, signBytes, sig); err != nil {
+		return err
+	}
 	vote.Signature = sig
 	return nil
 }
@@ -409,21 +488,35 @@ func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) erro
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	if err := pv.saveSigned(height, round, step, signBytes, sig); err != nil {
+		return err
+	}
 	proposal.Signature = sig
 	return nil
 }
 
-// Persist height/round/step and signature
+// Persist height/round/step and signature, before the signature is returned.
 func (pv *FilePV) saveSigned(height int64, round int32, step int8,
-	signBytes []byte, sig []byte) {
+	signBytes []byte, sig []byte) error {
 
-	pv.LastSignState.Height = height
-	pv.LastSignState.Round = round
-	pv.LastSignState.Step = step
-	pv.LastSignState.Signature = sig
-	pv.LastSignState.SignBytes = signBytes
-	pv.LastSignState.Save()
+	lss := pv.LastSignState
+	lss.Height = height
+	lss.Round = round
+	lss.Step = step
+	lss.Signature = sig
+	lss.SignBytes = signBytes
+	if err := pv.watermarks().Save(lss); err != nil {
+		return fmt.Errorf("persisting the last sign state: %w", err)
+	}
+	pv.LastSignState = lss
+	return nil
+}
+
+func (pv *FilePV) watermarks() WatermarkStore {
+	if pv.store != nil {
+		return pv.store
+	}
+	return NewFileWatermarkStore(pv.LastSignState.filePath)
 }
 
 //-----------------------------------------------------------------------------------------
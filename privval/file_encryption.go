@@ -0,0 +1,262 @@
+package privval
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/cometbft/cometbft/crypto"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/tempfile"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	kdfArgon2id     = "argon2id"
+	cipherAES256GCM = "aes-256-gcm"
+
+	// The argon2id parameters recommended by RFC 9106 for memory constrained
+	// environments: the key is derived once, at startup.
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024 // KiB
+	argon2Threads = 4
+	argon2SaltLen = 16
+	aes256KeyLen  = 32
+)
+
+// ErrKeyEncrypted is returned when loading an encrypted FilePVKey without its
+// passphrase.
+var ErrKeyEncrypted = errors.New("the PrivValidator key is encrypted, its passphrase is required")
+
+// KeyEncryption describes how the private key of an EncryptedFilePVKey is
+// encrypted: with AES-256-GCM, under a key derived from the passphrase with
+// argon2id.
+type KeyEncryption struct {
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	Cipher  string `json:"cipher"`
+	Nonce   []byte `json:"nonce"`
+}
+
+// EncryptedFilePVKey is the form of a FilePVKey encrypted at rest. The address
+// and the public key are kept in the clear, e.g. for show-validator, and
+// authenticated along the encrypted private key.
+type EncryptedFilePVKey struct {
+	Address          types.Address `json:"address"`
+	PubKey           crypto.PubKey `json:"pub_key"`
+	Encryption       KeyEncryption `json:"encryption"`
+	EncryptedPrivKey []byte        `json:"encrypted_priv_key"`
+}
+
+// keyEncryption holds the parameters and the derived key with which a
+// FilePVKey is encrypted each time it is saved, so that the passphrase isn't
+// kept around.
+type keyEncryption struct {
+	params KeyEncryption
+	key    []byte
+}
+
+func newKeyEncryption(passphrase []byte) (*keyEncryption, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	params := KeyEncryption{
+		KDF:     kdfArgon2id,
+		Salt:    salt,
+		Time:    argon2Time,
+		Memory:  argon2Memory,
+		Threads: argon2Threads,
+		Cipher:  cipherAES256GCM,
+	}
+	return deriveKeyEncryption(params, passphrase)
+}
+
+func deriveKeyEncryption(params KeyEncryption, passphrase []byte) (*keyEncryption, error) {
+	if params.KDF != kdfArgon2id {
+		return nil, fmt.Errorf("unsupported key derivation function %q", params.KDF)
+	}
+	if params.Cipher != cipherAES256GCM {
+		return nil, fmt.Errorf("unsupported cipher %q", params.Cipher)
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	key := argon2.IDKey(passphrase, params.Salt, params.Time, params.Memory, params.Threads, aes256KeyLen)
+	return &keyEncryption{params: params, key: key}, nil
+}
+
+func (ke *keyEncryption) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(ke.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext, authenticating additionalData along, under a fresh
+// nonce, returned in the parameters.
+func (ke *keyEncryption) seal(plaintext, additionalData []byte) (KeyEncryption, []byte, error) {
+	aead, err := ke.aead()
+	if err != nil {
+		return KeyEncryption{}, nil, err
+	}
+	params := ke.params
+	params.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(params.Nonce); err != nil {
+		return KeyEncryption{}, nil, err
+	}
+	return params, aead.Seal(nil, params.Nonce, plaintext, additionalData), nil
+}
+
+// open decrypts ciphertext, sealed under nonce with additionalData.
+func (ke *keyEncryption) open(nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := ke.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	bz, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, errors.New("wrong passphrase, or corrupted key")
+	}
+	return bz, nil
+}
+
+// encrypt returns pvKey encrypted under a fresh nonce.
+func (ke *keyEncryption) encrypt(pvKey FilePVKey) (EncryptedFilePVKey, error) {
+	privKey, err := cmtjson.Marshal(pvKey.PrivKey)
+	if err != nil {
+		return EncryptedFilePVKey{}, err
+	}
+	params, encrypted, err := ke.seal(privKey, pvKey.Address)
+	if err != nil {
+		return EncryptedFilePVKey{}, err
+	}
+	return EncryptedFilePVKey{
+		Address:          pvKey.Address,
+		PubKey:           pvKey.PubKey,
+		Encryption:       params,
+		EncryptedPrivKey: encrypted,
+	}, nil
+}
+
+// decrypt returns the FilePVKey encrypted in encKey, checking that its public
+// key is the one in the clear.
+func (ke *keyEncryption) decrypt(encKey EncryptedFilePVKey) (FilePVKey, error) {
+	bz, err := ke.open(encKey.Encryption.Nonce, encKey.EncryptedPrivKey, encKey.Address)
+	if err != nil {
+		return FilePVKey{}, err
+	}
+	var privKey crypto.PrivKey
+	if err := cmtjson.Unmarshal(bz, &privKey); err != nil {
+		return FilePVKey{}, err
+	}
+	pvKey := FilePVKey{
+		Address: privKey.PubKey().Address(),
+		PubKey:  privKey.PubKey(),
+		PrivKey: privKey,
+	}
+	if !pvKey.PubKey.Equals(encKey.PubKey) {
+		return FilePVKey{}, errors.New("the public key doesn't match the private key")
+	}
+	return pvKey, nil
+}
+
+// isEncryptedKey reports whether the key file content keyJSONBytes is an
+// EncryptedFilePVKey.
+func isEncryptedKey(keyJSONBytes []byte) bool {
+	var probe struct {
+		Encryption json.RawMessage `json:"encryption"`
+	}
+	return json.Unmarshal(keyJSONBytes, &probe) == nil && len(probe.Encryption) > 0
+}
+
+// IsEncryptedFilePVKey reports whether the key file at keyFilePath is
+// encrypted; a missing file isn't.
+func IsEncryptedFilePVKey(keyFilePath string) (bool, error) {
+	keyJSONBytes, err := os.ReadFile(keyFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return isEncryptedKey(keyJSONBytes), nil
+}
+
+// LoadFilePVKeyPubKey returns the public key in the key file at keyFilePath,
+// whether it is encrypted or not.
+func LoadFilePVKeyPubKey(keyFilePath string) (crypto.PubKey, error) {
+	keyJSONBytes, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if !isEncryptedKey(keyJSONBytes) {
+		pvKey, err := loadFilePVKey(keyFilePath, nil)
+		if err != nil {
+			return nil, err
+		}
+		return pvKey.PubKey, nil
+	}
+	var encKey EncryptedFilePVKey
+	if err := cmtjson.Unmarshal(keyJSONBytes, &encKey); err != nil {
+		return nil, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
+	}
+	return encKey.PubKey, nil
+}
+
+// EncryptFilePVKey encrypts in place the plaintext key file at keyFilePath
+// with passphrase.
+func EncryptFilePVKey(keyFilePath string, passphrase []byte) error {
+	if encrypted, err := IsEncryptedFilePVKey(keyFilePath); err != nil {
+		return err
+	} else if encrypted {
+		return errors.New("the PrivValidator key is already encrypted")
+	}
+	pvKey, err := loadFilePVKey(keyFilePath, nil)
+	if err != nil {
+		return err
+	}
+	pvKey.encryption, err = newKeyEncryption(passphrase)
+	if err != nil {
+		return err
+	}
+	return pvKey.save()
+}
+
+// DecryptFilePVKey decrypts in place the key file at keyFilePath, encrypted
+// with passphrase.
+func DecryptFilePVKey(keyFilePath string, passphrase []byte) error {
+	if encrypted, err := IsEncryptedFilePVKey(keyFilePath); err != nil {
+		return err
+	} else if !encrypted {
+		return errors.New("the PrivValidator key is not encrypted")
+	}
+	pvKey, err := loadFilePVKey(keyFilePath, passphrase)
+	if err != nil {
+		return err
+	}
+	pvKey.encryption = nil
+	return pvKey.save()
+}
+
+// writeKeyFile writes v, the key file content, atomically to outFile.
+func writeKeyFile(outFile string, v interface{}) error {
+	jsonBytes, err := cmtjson.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(outFile, jsonBytes, 0600)
+}
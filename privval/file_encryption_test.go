@@ -0,0 +1,80 @@
+package privval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestEncryptFilePVKey(t *testing.T) {
+	dir := t.TempDir()
+	keyFile, stateFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")
+	privVal := NewFilePV(bn254.GenPrivKey(), keyFile, stateFile)
+	privVal.Save()
+	passphrase := []byte("correct horse battery staple")
+
+	require.NoError(t, EncryptFilePVKey(keyFile, passphrase))
+	encrypted, err := IsEncryptedFilePVKey(keyFile)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+	assert.Error(t, EncryptFilePVKey(keyFile, passphrase))
+
+	// the private key is no longer in the clear, the public key is
+	bz, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(bz), `"priv_key"`)
+	pubKey, err := LoadFilePVKeyPubKey(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, privVal.Key.PubKey, pubKey)
+
+	// the key can't be loaded without the passphrase
+	_, err = loadFilePVKey(keyFile, nil)
+	assert.ErrorIs(t, err, ErrKeyEncrypted)
+	_, err = LoadFilePVWithPassphrase(keyFile, stateFile, []byte("wrong"))
+	assert.Error(t, err)
+
+	loaded, err := LoadFilePVWithPassphrase(keyFile, stateFile, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+
+	// it signs, and stays encrypted when saved
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size)}
+	vote := newVote(loaded.Key.Address, 0, 1, 0, cmtproto.PrevoteType, blockID)
+	require.NoError(t, loaded.SignVote("mychainid", types.CanonicalEncodingProto, vote.ToProto()))
+	loaded.Save()
+	encrypted, err = IsEncryptedFilePVKey(keyFile)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+
+	require.NoError(t, DecryptFilePVKey(keyFile, passphrase))
+	loaded = LoadFilePV(keyFile, stateFile)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+	assert.EqualValues(t, 1, loaded.LastSignState.Height)
+}
+
+func TestReadPassphrase(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(file, []byte("from file\n"), 0o600))
+	t.Setenv(PassphraseEnv, "from env")
+
+	passphrase, err := ReadPassphrase(file, false)
+	require.NoError(t, err)
+	assert.Equal(t, "from file", string(passphrase))
+
+	passphrase, err = ReadPassphrase("", false)
+	require.NoError(t, err)
+	assert.Equal(t, "from env", string(passphrase))
+
+	t.Setenv(PassphraseEnv, "")
+	_, err = ReadPassphrase("", false)
+	assert.Error(t, err)
+}
@@ -57,7 +57,7 @@ func TestResetValidator(t *testing.T) {
 	randBytes := cmtrand.Bytes(tmhash.Size)
 	blockID := types.BlockID{Hash: randBytes, PartSetHeader: types.PartSetHeader{}}
 	vote := newVote(privVal.Key.Address, 0, height, round, voteType, blockID)
-	err = privVal.SignVote("mychainid", vote.ToProto())
+	err = privVal.SignVote("mychainid", types.CanonicalEncodingProto, vote.ToProto())
 	assert.NoError(t, err, "expected no error signing vote")
 
 	// priv val after signing is not same as empty
@@ -179,11 +179,11 @@ func TestSignVote(t *testing.T) {
 	// sign a vote for first time
 	vote := newVote(privVal.Key.Address, 0, height, round, voteType, block1)
 	v := vote.ToProto()
-	err = privVal.SignVote("mychainid", v)
+	err = privVal.SignVote("mychainid", types.CanonicalEncodingProto, v)
 	assert.NoError(err, "expected no error signing vote")
 
 	// try to sign the same vote again; should be fine
-	err = privVal.SignVote("mychainid", v)
+	err = privVal.SignVote("mychainid", types.CanonicalEncodingProto, v)
 	assert.NoError(err, "expected no error on signing same vote")
 
 	// now try some bad votes
@@ -196,14 +196,14 @@ func TestSignVote(t *testing.T) {
 
 	for _, c := range cases {
 		cpb := c.ToProto()
-		err = privVal.SignVote("mychainid", cpb)
+		err = privVal.SignVote("mychainid", types.CanonicalEncodingProto, cpb)
 		assert.Error(err, "expected error on signing conflicting vote")
 	}
 
 	// try signing a vote with a different time stamp
 	sig := vote.Signature
 	vote.Timestamp = vote.Timestamp.Add(time.Duration(1000))
-	err = privVal.SignVote("mychainid", v)
+	err = privVal.SignVote("mychainid", types.CanonicalEncodingProto, v)
 	assert.NoError(err)
 	assert.Equal(sig, vote.Signature)
 }
@@ -299,10 +299,10 @@ func TestDifferByTimestamp(t *testing.T) {
 		blockID := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{}}
 		vote := newVote(privVal.Key.Address, 0, height, round, voteType, blockID)
 		v := vote.ToProto()
-		err := privVal.SignVote("mychainid", v)
+		err := privVal.SignVote("mychainid", types.CanonicalEncodingProto, v)
 		assert.NoError(t, err, "expected no error signing vote")
 
-		signBytes := types.VoteSignBytes(chainID, v)
+		signBytes := types.VoteSignBytes(chainID, types.CanonicalEncodingProto, v)
 		sig := v.Signature
 		timeStamp := vote.Timestamp
 
@@ -310,11 +310,11 @@ func TestDifferByTimestamp(t *testing.T) {
 		v.Timestamp = v.Timestamp.Add(time.Millisecond)
 		var emptySig []byte
 		v.Signature = emptySig
-		err = privVal.SignVote("mychainid", v)
+		err = privVal.SignVote("mychainid", types.CanonicalEncodingProto, v)
 		assert.NoError(t, err, "expected no error on signing same vote")
 
 		assert.Equal(t, timeStamp, v.Timestamp)
-		assert.Equal(t, signBytes, types.VoteSignBytes(chainID, v))
+		assert.Equal(t, signBytes, types.VoteSignBytes(chainID, types.CanonicalEncodingProto, v))
 		assert.Equal(t, sig, v.Signature)
 	}
 }
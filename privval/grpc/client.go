@@ -0,0 +1,203 @@
+package privvalgrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/privval"
+	privvalproto "github.com/cometbft/cometbft/proto/tendermint/privval"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// waitForReady makes the requests wait, within their deadline, for the signer
+// to be reachable rather than failing at once, e.g. while it restarts.
+var waitForReady = grpc.WaitForReady(true)
+
+// SignerClient implements PrivValidator, with a remote signer serving the
+// PrivValidatorAPI over gRPC.
+//
+// Each request gets a deadline of timeout, which the signer uses to drop the
+// requests the client stopped waiting for.
+type SignerClient struct {
+	service.BaseService
+
+	addr    string
+	chainID string
+	timeout time.Duration
+	opts    []grpc.DialOption
+
+	conn   *grpc.ClientConn
+	client privvalproto.PrivValidatorAPIClient
+	health grpc_health_v1.HealthClient
+
+	mtx cmtsync.Mutex
+	// the hash-to-curve domain separation tag advertised by the signer, with
+	// which the returned nonces are checked
+	hashToCurveDST string
+}
+
+var _ types.PrivValidator = (*SignerClient)(nil)
+
+// NewSignerClient returns a client of the signer at addr, for chainID. The
+// options are passed through to grpc.Dial, e.g. the TLS credentials;
+// without any, an insecure connection is used.
+func NewSignerClient(
+	addr,
+	chainID string,
+	timeout time.Duration,
+	logger log.Logger,
+	opts ...grpc.DialOption,
+) *SignerClient {
+	if len(opts) == 0 {
+		//nolint: staticcheck // SA1019 Existing use of deprecated but supported dial option.
+		opts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+	sc := &SignerClient{
+		addr:    addr,
+		chainID: chainID,
+		timeout: timeout,
+		opts:    opts,
+	}
+	sc.BaseService = *service.NewBaseService(logger, "privvalgrpc.SignerClient", sc)
+	return sc
+}
+
+// OnStart implements service.Service. The connection is established lazily,
+// so that the node can start while a load balancer finds it a signer.
+func (sc *SignerClient) OnStart() error {
+	conn, err := grpc.Dial(sc.addr, sc.opts...)
+	if err != nil {
+		return fmt.Errorf("dialing the signer: %w", err)
+	}
+	sc.conn = conn
+	sc.client = privvalproto.NewPrivValidatorAPIClient(conn)
+	sc.health = grpc_health_v1.NewHealthClient(conn)
+	return nil
+}
+
+// OnStop implements service.Service.
+func (sc *SignerClient) OnStop() {
+	if err := sc.conn.Close(); err != nil {
+		sc.Logger.Error("Closing the connection to the signer", "err", err)
+	}
+}
+
+// CheckHealth returns an error unless the signer reports it is serving the
+// PrivValidatorAPI.
+func (sc *SignerClient) CheckHealth() error {
+	ctx, cancel := sc.context()
+	defer cancel()
+
+	res, err := sc.health.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: ServiceName}, waitForReady)
+	if err != nil {
+		return fmt.Errorf("health check: %w", err)
+	}
+	if res.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("the signer is not serving (%v)", res.Status)
+	}
+	return nil
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey retrieves the public key from the signer.
+func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
+	ctx, cancel := sc.context()
+	defer cancel()
+
+	resp, err := sc.client.GetPubKey(ctx, &privvalproto.PubKeyRequest{ChainId: sc.chainID}, waitForReady)
+	if err != nil {
+		return nil, fmt.Errorf("send: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, &privval.RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	pk, err := cryptoenc.PubKeyFromProto(resp.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := privval.CheckHashToCurveDST(pk, resp.HashToCurveDst); err != nil {
+		return nil, err
+	}
+
+	sc.mtx.Lock()
+	sc.hashToCurveDST = resp.HashToCurveDst
+	sc.mtx.Unlock()
+	return pk, nil
+}
+
+// SignVote requests the signer to sign a vote.
+func (sc *SignerClient) SignVote(chainID string, encoding types.CanonicalEncoding, vote *cmtproto.Vote) error {
+	ctx, cancel := sc.context()
+	defer cancel()
+
+	resp, err := sc.client.SignVote(ctx, &privvalproto.SignVoteRequest{
+		Vote: vote, ChainId: chainID, HashToCurveDst: bn254.HashToCurveDST, CanonicalEncoding: string(encoding),
+	}, waitForReady)
+	if err != nil {
+		return fmt.Errorf("send: %w", err)
+	}
+	if resp.Error != nil {
+		return &privval.RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	signBytes := types.VoteSignBytes(chainID, encoding, &resp.Vote)
+	err = privval.CheckHashToCurveNonce(sc.dst(), signBytes, resp.HashToCurveNonce)
+	if err != nil {
+		return err
+	}
+
+	*vote = resp.Vote
+	return nil
+}
+
+// SignProposal requests the signer to sign a proposal.
+func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	ctx, cancel := sc.context()
+	defer cancel()
+
+	resp, err := sc.client.SignProposal(ctx,
+		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID, HashToCurveDst: bn254.HashToCurveDST},
+		waitForReady)
+	if err != nil {
+		return fmt.Errorf("send: %w", err)
+	}
+	if resp.Error != nil {
+		return &privval.RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	err = privval.CheckHashToCurveNonce(
+		sc.dst(), types.ProposalSignBytes(chainID, &resp.Proposal), resp.HashToCurveNonce)
+	if err != nil {
+		return err
+	}
+
+	*proposal = resp.Proposal
+	return nil
+}
+
+func (sc *SignerClient) dst() string {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	return sc.hashToCurveDST
+}
+
+func (sc *SignerClient) context() (context.Context, context.CancelFunc) {
+	if sc.timeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), sc.timeout)
+}
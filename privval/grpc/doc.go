@@ -0,0 +1,20 @@
+/*
+Package privvalgrpc implements the gRPC remote signer protocol, the
+PrivValidatorAPI service.
+
+Unlike with the socket protocol, where the node listens for the signer to dial
+in, the signer serves the PrivValidatorAPI and the node dials it. The signer
+can thus sit behind standard gRPC load balancers and service meshes, which can
+use the health checks it serves. The connection is secured with mutual TLS.
+
+# SignerServer
+
+SignerServer serves the PrivValidatorAPI with a PrivValidator, e.g. a FilePV.
+It drops the sign requests past their deadline, which the node no longer waits
+for.
+
+# SignerClient
+
+SignerClient implements PrivValidator, dialing the signer.
+*/
+package privvalgrpc
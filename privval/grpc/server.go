@@ -0,0 +1,192 @@
+package privvalgrpc
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/cometbft/cometbft/crypto"
+	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/privval"
+	privvalproto "github.com/cometbft/cometbft/proto/tendermint/privval"
+	"github.com/cometbft/cometbft/types"
+)
+
+// ServiceName is the name of the PrivValidatorAPI service, as reported by the
+// health checks.
+const ServiceName = "tendermint.privval.PrivValidatorAPI"
+
+// minSignTime is the time left before its deadline under which a sign request
+// is dropped, as the client would likely stop waiting before the signature
+// reaches it.
+const minSignTime = 5 * time.Millisecond
+
+// SignerServer serves the PrivValidatorAPI with a PrivValidator, for a single
+// chain. The requests are handled one at a time.
+type SignerServer struct {
+	logger  log.Logger
+	chainID string
+
+	mtx     cmtsync.Mutex
+	privVal types.PrivValidator
+}
+
+var _ privvalproto.PrivValidatorAPIServer = (*SignerServer)(nil)
+
+// NewSignerServer returns a server of privVal for chainID.
+func NewSignerServer(chainID string, privVal types.PrivValidator, logger log.Logger) *SignerServer {
+	return &SignerServer{
+		logger:  logger,
+		chainID: chainID,
+		privVal: privVal,
+	}
+}
+
+// NewServer returns a gRPC server serving ss, and the standard health checks
+// for ServiceName. If tlsConfig is not nil, the server uses it, e.g. to
+// require the client certificates; see LoadTLSConfig. The other options are
+// passed through to grpc.NewServer.
+func NewServer(ss *SignerServer, tlsConfig *tls.Config, opts ...grpc.ServerOption) *grpc.Server {
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	s := grpc.NewServer(opts...)
+	privvalproto.RegisterPrivValidatorAPIServer(s, ss)
+
+	hs := health.NewServer()
+	hs.SetServingStatus(ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(s, hs)
+	return s
+}
+
+// GetPubKey implements PrivValidatorAPIServer.
+func (ss *SignerServer) GetPubKey(ctx context.Context, req *privvalproto.PubKeyRequest) (
+	*privvalproto.PubKeyResponse, error,
+) {
+	if req.ChainId != ss.chainID {
+		return &privvalproto.PubKeyResponse{Error: &privvalproto.RemoteSignerError{
+			Code: 0, Description: "unable to provide pubkey"}}, nil
+	}
+
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	pubKey, err := ss.privVal.GetPubKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting the pubkey: %v", err)
+	}
+	pk, err := cryptoenc.PubKeyToProto(pubKey)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encoding the pubkey: %v", err)
+	}
+	return &privvalproto.PubKeyResponse{PubKey: pk, HashToCurveDst: privval.HashToCurveDST(pubKey)}, nil
+}
+
+// SignVote implements PrivValidatorAPIServer.
+func (ss *SignerServer) SignVote(ctx context.Context, req *privvalproto.SignVoteRequest) (
+	*privvalproto.SignedVoteResponse, error,
+) {
+	if req.ChainId != ss.chainID {
+		return &privvalproto.SignedVoteResponse{Error: &privvalproto.RemoteSignerError{
+			Code: 0, Description: "unable to sign vote"}}, nil
+	}
+	if req.Vote == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing vote")
+	}
+
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	if err := checkDeadline(ctx); err != nil {
+		ss.logger.Info("Dropping a sign vote request past its deadline", "height", req.Vote.Height)
+		return nil, err
+	}
+	pubKey, err := ss.pubKey(req.HashToCurveDst)
+	if err != nil {
+		return &privvalproto.SignedVoteResponse{Error: &privvalproto.RemoteSignerError{
+			Code: 0, Description: err.Error()}}, nil
+	}
+	encoding, err := privval.VoteRequestEncoding(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	vote := req.Vote
+	if err := ss.privVal.SignVote(req.ChainId, encoding, vote); err != nil {
+		return &privvalproto.SignedVoteResponse{Error: &privvalproto.RemoteSignerError{
+			Code: 0, Description: err.Error()}}, nil
+	}
+	return &privvalproto.SignedVoteResponse{
+		Vote:             *vote,
+		HashToCurveNonce: privval.HashToCurveNonce(pubKey, types.VoteSignBytes(req.ChainId, encoding, vote)),
+	}, nil
+}
+
+// SignProposal implements PrivValidatorAPIServer.
+func (ss *SignerServer) SignProposal(ctx context.Context, req *privvalproto.SignProposalRequest) (
+	*privvalproto.SignedProposalResponse, error,
+) {
+	if req.ChainId != ss.chainID {
+		return &privvalproto.SignedProposalResponse{Error: &privvalproto.RemoteSignerError{
+			Code: 0, Description: "unable to sign proposal"}}, nil
+	}
+	if req.Proposal == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing proposal")
+	}
+
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	if err := checkDeadline(ctx); err != nil {
+		ss.logger.Info("Dropping a sign proposal request past its deadline", "height", req.Proposal.Height)
+		return nil, err
+	}
+	pubKey, err := ss.pubKey(req.HashToCurveDst)
+	if err != nil {
+		return &privvalproto.SignedProposalResponse{Error: &privvalproto.RemoteSignerError{
+			Code: 0, Description: err.Error()}}, nil
+	}
+	proposal := req.Proposal
+	if err := ss.privVal.SignProposal(req.ChainId, proposal); err != nil {
+		return &privvalproto.SignedProposalResponse{Error: &privvalproto.RemoteSignerError{
+			Code: 0, Description: err.Error()}}, nil
+	}
+	return &privvalproto.SignedProposalResponse{
+		Proposal:         *proposal,
+		HashToCurveNonce: privval.HashToCurveNonce(pubKey, types.ProposalSignBytes(req.ChainId, proposal)),
+	}, nil
+}
+
+// pubKey returns the public key of the signer, or an error if it doesn't hash
+// the messages to the curve with dst.
+func (ss *SignerServer) pubKey(dst string) (crypto.PubKey, error) {
+	pubKey, err := ss.privVal.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := privval.CheckHashToCurveDST(pubKey, dst); err != nil {
+		return nil, err
+	}
+	return pubKey, nil
+}
+
+// checkDeadline returns an error if the client stopped waiting for the
+// request, or is about to: signing it then would only update the state of the
+// signer, possibly preventing it from signing the retried request.
+func checkDeadline(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < minSignTime {
+		return status.Error(codes.DeadlineExceeded, "not enough time left to sign")
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package privvalgrpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/privval"
+	privvalproto "github.com/cometbft/cometbft/proto/tendermint/privval"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+const testChainID = "test-chain"
+
+// startSigner starts server on a local port, and returns its address.
+func startSigner(t *testing.T, server *grpc.Server) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(server.Stop)
+	return ln.Addr().String()
+}
+
+func startClient(t *testing.T, addr string, opts ...grpc.DialOption) *SignerClient {
+	t.Helper()
+	sc := NewSignerClient(addr, testChainID, time.Second, log.TestingLogger(), opts...)
+	require.NoError(t, sc.Start())
+	t.Cleanup(func() { _ = sc.Stop() })
+	return sc
+}
+
+func TestSignerClient(t *testing.T) {
+	privVal := types.NewMockPV()
+	addr := startSigner(t, NewServer(NewSignerServer(testChainID, privVal, log.TestingLogger()), nil))
+	sc := startClient(t, addr)
+
+	require.NoError(t, sc.CheckHealth())
+
+	pubKey, err := sc.GetPubKey()
+	require.NoError(t, err)
+	want, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, want, pubKey)
+
+	vote := &cmtproto.Vote{
+		Type:             cmtproto.PrecommitType,
+		Height:           1,
+		BlockID:          cmtproto.BlockID{Hash: tmhash.Sum([]byte("block"))},
+		Timestamp:        time.Now().UTC(),
+		ValidatorAddress: pubKey.Address(),
+	}
+	require.NoError(t, sc.SignVote(testChainID, types.CanonicalEncodingProto, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(testChainID, types.CanonicalEncodingProto, vote), vote.Signature))
+
+	proposal := &cmtproto.Proposal{
+		Type:      cmtproto.ProposalType,
+		Height:    1,
+		PolRound:  -1,
+		BlockID:   cmtproto.BlockID{Hash: tmhash.Sum([]byte("block"))},
+		Timestamp: time.Now().UTC(),
+	}
+	require.NoError(t, sc.SignProposal(testChainID, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(testChainID, proposal), proposal.Signature))
+
+	// the signer only signs for its chain
+	err = sc.SignVote("other-chain", types.CanonicalEncodingProto, vote)
+	assert.IsType(t, &privval.RemoteSignerError{}, err)
+}
+
+func TestSignerClientBn254(t *testing.T) {
+	privVal := types.NewMockPVWithParams(bn254.GenPrivKey(), false, false)
+	addr := startSigner(t, NewServer(NewSignerServer(testChainID, privVal, log.TestingLogger()), nil))
+	sc := startClient(t, addr)
+
+	pubKey, err := sc.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, bn254.HashToCurveDST, sc.dst())
+
+	vote := &cmtproto.Vote{
+		Type:             cmtproto.PrecommitType,
+		Height:           1,
+		BlockID:          cmtproto.BlockID{Hash: tmhash.Sum([]byte("block"))},
+		Timestamp:        time.Now().UTC(),
+		ValidatorAddress: pubKey.Address(),
+	}
+	require.NoError(t, sc.SignVote(testChainID, types.CanonicalEncodingProto, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(testChainID, types.CanonicalEncodingProto, vote), vote.Signature))
+
+	// a client hashing to the curve otherwise is refused
+	resp, err := sc.client.SignVote(context.Background(), &privvalproto.SignVoteRequest{
+		Vote: vote, ChainId: testChainID, HashToCurveDst: "OTHER_DST_",
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, resp.Error)
+}
+
+func TestSignerClientTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := test.NewCA(t)
+	caFile := writeCAFile(t, ca, dir)
+	signerCert, signerKey := issueFiles(t, ca, dir, "signer")
+	nodeCert, nodeKey := issueFiles(t, ca, dir, "node")
+
+	serverTLS, err := LoadTLSConfig(signerCert, signerKey, caFile)
+	require.NoError(t, err)
+	privVal := types.NewMockPV()
+	addr := startSigner(t, NewServer(NewSignerServer(testChainID, privVal, log.TestingLogger()), serverTLS))
+
+	clientTLS, err := LoadTLSConfig(nodeCert, nodeKey, caFile)
+	require.NoError(t, err)
+	sc := startClient(t, addr, WithTLS(clientTLS))
+	_, err = sc.GetPubKey()
+	require.NoError(t, err)
+
+	// a client without a certificate of the CA is refused
+	otherCA := test.NewCA(t)
+	otherCert, otherKey := issueFiles(t, otherCA, dir, "other")
+	otherTLS, err := LoadTLSConfig(otherCert, otherKey, caFile)
+	require.NoError(t, err)
+	sc = NewSignerClient(addr, testChainID, 200*time.Millisecond, log.TestingLogger(), WithTLS(otherTLS))
+	require.NoError(t, sc.Start())
+	defer sc.Stop() //nolint:errcheck // ignore for tests
+	_, err = sc.GetPubKey()
+	assert.Error(t, err)
+}
+
+func TestCheckDeadline(t *testing.T) {
+	assert.NoError(t, checkDeadline(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, checkDeadline(ctx))
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(checkDeadline(ctx)))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, codes.Canceled, status.Code(checkDeadline(ctx)))
+}
+
+// issueFiles writes a certificate issued by the CA for name, and its key, to
+// dir, and returns their paths.
+func issueFiles(t *testing.T, ca *test.CA, dir, name string) (string, string) {
+	cert := ca.Issue(t, name)
+	keyDER, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem")
+	writePEM(t, certFile, "CERTIFICATE", cert.Certificate[0])
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+// writeCAFile writes the certificate of the CA to dir, and returns its path.
+func writeCAFile(t *testing.T, ca *test.CA, dir string) string {
+	file := filepath.Join(dir, "ca.pem")
+	writePEM(t, file, "CERTIFICATE", ca.Cert.Raw)
+	return file
+}
+
+func writePEM(t *testing.T, file, typ string, der []byte) {
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+}
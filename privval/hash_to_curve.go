@@ -0,0 +1,56 @@
+package privval
+
+import (
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
+)
+
+// HashToCurveDST returns the domain separation tag of the hash-to-curve of
+// the messages signed with pubKey, as advertised by the remote signers and
+// sent along the sign requests. It is empty for the keys not hashing the
+// messages to a curve.
+func HashToCurveDST(pubKey crypto.PubKey) string {
+	if _, ok := pubKey.(bn254.PubKey); ok {
+		return bn254.HashToCurveDST
+	}
+	return ""
+}
+
+// CheckHashToCurveDST returns an error if the messages signed with pubKey are
+// not hashed to the curve with dst. An empty dst, as sent by the clients
+// predating it, is not checked.
+func CheckHashToCurveDST(pubKey crypto.PubKey, dst string) error {
+	if dst == "" {
+		return nil
+	}
+	if own := HashToCurveDST(pubKey); own != "" && own != dst {
+		return fmt.Errorf("unsupported hash-to-curve %q, the %s key hashes with %q", dst, pubKey.Type(), own)
+	}
+	return nil
+}
+
+// HashToCurveNonce returns the nonce with which signBytes are hashed to the
+// curve when signing them with pubKey, zero for the keys not hashing the
+// messages to a curve.
+func HashToCurveNonce(pubKey crypto.PubKey, signBytes []byte) uint32 {
+	if _, ok := pubKey.(bn254.PubKey); ok {
+		return bn254.HashToCurveNonce(signBytes)
+	}
+	return 0
+}
+
+// CheckHashToCurveNonce returns an error if the nonce returned by a remote
+// signer, advertising dst, differs from the one signBytes are hashed to the
+// curve with: the signer then hashes differently from the chain, and its
+// signatures would not verify. Signers advertising no dst are not checked.
+func CheckHashToCurveNonce(dst string, signBytes []byte, nonce uint32) error {
+	if dst == "" {
+		return nil
+	}
+	if want := bn254.HashToCurveNonce(signBytes); nonce != want {
+		return fmt.Errorf("the signer hashed to the curve with nonce %d, want %d", nonce, want)
+	}
+	return nil
+}
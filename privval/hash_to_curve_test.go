@@ -0,0 +1,63 @@
+package privval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	privvalproto "github.com/cometbft/cometbft/proto/tendermint/privval"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestHashToCurveRequestHandler(t *testing.T) {
+	const chainID = "test-chain"
+	privVal := types.NewMockPVWithParams(bn254.GenPrivKey(), false, false)
+	newVote := func() *cmtproto.Vote {
+		return &cmtproto.Vote{
+			Type:      cmtproto.PrecommitType,
+			Height:    1,
+			BlockID:   cmtproto.BlockID{Hash: tmhash.Sum([]byte("block"))},
+			Timestamp: time.Now().UTC(),
+		}
+	}
+
+	// the signer advertises its hash-to-curve
+	res, err := DefaultValidationRequestHandler(privVal, mustWrapMsg(&privvalproto.PubKeyRequest{ChainId: chainID}), chainID)
+	require.NoError(t, err)
+	assert.Equal(t, bn254.HashToCurveDST, res.GetPubKeyResponse().HashToCurveDst)
+
+	// and returns the nonce of the sign bytes
+	res, err = DefaultValidationRequestHandler(privVal, mustWrapMsg(&privvalproto.SignVoteRequest{
+		Vote: newVote(), ChainId: chainID, HashToCurveDst: bn254.HashToCurveDST,
+	}), chainID)
+	require.NoError(t, err)
+	resp := res.GetSignedVoteResponse()
+	require.Nil(t, resp.Error)
+	signBytes := types.VoteSignBytes(chainID, types.CanonicalEncodingProto, &resp.Vote)
+	assert.Equal(t, bn254.HashToCurveNonce(signBytes), resp.HashToCurveNonce)
+	assert.NoError(t, CheckHashToCurveNonce(bn254.HashToCurveDST, signBytes, resp.HashToCurveNonce))
+	assert.Error(t, CheckHashToCurveNonce(bn254.HashToCurveDST, signBytes, resp.HashToCurveNonce+1))
+
+	// it refuses to sign for another hash-to-curve
+	res, err = DefaultValidationRequestHandler(privVal, mustWrapMsg(&privvalproto.SignVoteRequest{
+		Vote: newVote(), ChainId: chainID, HashToCurveDst: "OTHER_DST_",
+	}), chainID)
+	assert.Error(t, err)
+	assert.NotNil(t, res.GetSignedVoteResponse().Error)
+
+	// the tag doesn't apply to the keys not hashing to a curve
+	edPrivVal := types.NewMockPVWithParams(ed25519.GenPrivKey(), false, false)
+	res, err = DefaultValidationRequestHandler(edPrivVal, mustWrapMsg(&privvalproto.SignVoteRequest{
+		Vote: newVote(), ChainId: chainID, HashToCurveDst: bn254.HashToCurveDST,
+	}), chainID)
+	require.NoError(t, err)
+	resp = res.GetSignedVoteResponse()
+	assert.Nil(t, resp.Error)
+	assert.Zero(t, resp.HashToCurveNonce)
+}
@@ -0,0 +1,155 @@
+package privval
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/armor"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+)
+
+const armorBlockType = "COMETBFT PRIVATE KEY"
+
+// The headers of an armored private key: its type, authenticated along the
+// key, and the KeyEncryption parameters.
+const (
+	armorHeaderType    = "type"
+	armorHeaderKDF     = "kdf"
+	armorHeaderSalt    = "salt"
+	armorHeaderTime    = "time"
+	armorHeaderMemory  = "memory"
+	armorHeaderThreads = "threads"
+	armorHeaderCipher  = "cipher"
+	armorHeaderNonce   = "nonce"
+)
+
+// ArmorPrivKey returns privKey encrypted with passphrase, as in an
+// EncryptedFilePVKey, in ASCII armor.
+func ArmorPrivKey(privKey crypto.PrivKey, passphrase []byte) (string, error) {
+	ke, err := newKeyEncryption(passphrase)
+	if err != nil {
+		return "", err
+	}
+	bz, err := cmtjson.Marshal(privKey)
+	if err != nil {
+		return "", err
+	}
+	params, encrypted, err := ke.seal(bz, []byte(privKey.Type()))
+	if err != nil {
+		return "", err
+	}
+	headers := map[string]string{
+		armorHeaderType:    privKey.Type(),
+		armorHeaderKDF:     params.KDF,
+		armorHeaderSalt:    hex.EncodeToString(params.Salt),
+		armorHeaderTime:    strconv.FormatUint(uint64(params.Time), 10),
+		armorHeaderMemory:  strconv.FormatUint(uint64(params.Memory), 10),
+		armorHeaderThreads: strconv.FormatUint(uint64(params.Threads), 10),
+		armorHeaderCipher:  params.Cipher,
+		armorHeaderNonce:   hex.EncodeToString(params.Nonce),
+	}
+	return armor.EncodeArmor(armorBlockType, headers, encrypted), nil
+}
+
+// UnarmorPrivKey returns the private key armored by ArmorPrivKey with
+// passphrase.
+func UnarmorPrivKey(armorStr string, passphrase []byte) (crypto.PrivKey, error) {
+	blockType, headers, encrypted, err := armor.DecodeArmor(armorStr)
+	if err != nil {
+		return nil, fmt.Errorf("decoding the armored key: %w", err)
+	}
+	if blockType != armorBlockType {
+		return nil, fmt.Errorf("unexpected armor block type %q, expected %q", blockType, armorBlockType)
+	}
+	params, err := armorKeyEncryption(headers)
+	if err != nil {
+		return nil, err
+	}
+	ke, err := deriveKeyEncryption(params, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	keyType := headers[armorHeaderType]
+	bz, err := ke.open(params.Nonce, encrypted, []byte(keyType))
+	if err != nil {
+		return nil, err
+	}
+	var privKey crypto.PrivKey
+	if err := cmtjson.Unmarshal(bz, &privKey); err != nil {
+		return nil, err
+	}
+	if privKey.Type() != keyType {
+		return nil, fmt.Errorf("the key is of type %s, not %s", privKey.Type(), keyType)
+	}
+	return privKey, nil
+}
+
+// armorKeyEncryption parses the KeyEncryption parameters in the headers of an
+// armored key.
+func armorKeyEncryption(headers map[string]string) (KeyEncryption, error) {
+	params := KeyEncryption{
+		KDF:    headers[armorHeaderKDF],
+		Cipher: headers[armorHeaderCipher],
+	}
+	var err error
+	if params.Salt, err = hex.DecodeString(headers[armorHeaderSalt]); err != nil {
+		return params, fmt.Errorf("invalid %s header: %w", armorHeaderSalt, err)
+	}
+	if params.Nonce, err = hex.DecodeString(headers[armorHeaderNonce]); err != nil {
+		return params, fmt.Errorf("invalid %s header: %w", armorHeaderNonce, err)
+	}
+	for _, h := range []struct {
+		name    string
+		bitSize int
+		set     func(uint64)
+	}{
+		{armorHeaderTime, 32, func(v uint64) { params.Time = uint32(v) }},
+		{armorHeaderMemory, 32, func(v uint64) { params.Memory = uint32(v) }},
+		{armorHeaderThreads, 8, func(v uint64) { params.Threads = uint8(v) }},
+	} {
+		v, err := strconv.ParseUint(headers[h.name], 10, h.bitSize)
+		if err != nil {
+			return params, fmt.Errorf("invalid %s header: %w", h.name, err)
+		}
+		h.set(v)
+	}
+	return params, nil
+}
+
+// ExportFilePVKey returns the private key in the key file at keyFilePath,
+// encrypted with keyPassphrase if it is, armored with passphrase.
+func ExportFilePVKey(keyFilePath string, keyPassphrase, passphrase []byte) (string, error) {
+	pvKey, err := loadFilePVKey(keyFilePath, keyPassphrase)
+	if err != nil {
+		return "", err
+	}
+	return ArmorPrivKey(pvKey.PrivKey, passphrase)
+}
+
+// ImportFilePVKey saves the private key armored with passphrase to the
+// plaintext key file at keyFilePath, which must not exist unless overwrite is
+// true. The LastSignState at stateFilePath is kept, or else created empty.
+func ImportFilePVKey(armorStr string, passphrase []byte, keyFilePath, stateFilePath string, overwrite bool) error {
+	if !overwrite && cmtos.FileExists(keyFilePath) {
+		return fmt.Errorf("the PrivValidator key file %s already exists", keyFilePath)
+	}
+	privKey, err := UnarmorPrivKey(armorStr, passphrase)
+	if err != nil {
+		return err
+	}
+	pv := NewFilePV(privKey, keyFilePath, stateFilePath)
+	if err := pv.Key.save(); err != nil {
+		return err
+	}
+	if _, err := os.Stat(stateFilePath); errors.Is(err, os.ErrNotExist) {
+		pv.LastSignState.Save()
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package privval
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
+	"github.com/cometbft/cometbft/crypto/sr25519"
+)
+
+func TestArmorPrivKey(t *testing.T) {
+	passphrase := []byte("correct horse battery staple")
+	for _, privKey := range []crypto.PrivKey{
+		ed25519.GenPrivKey(),
+		secp256k1.GenPrivKey(),
+		sr25519.GenPrivKey(),
+		bn254.GenPrivKey(),
+	} {
+		t.Run(privKey.Type(), func(t *testing.T) {
+			armored, err := ArmorPrivKey(privKey, passphrase)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(armored, "-----BEGIN "+armorBlockType+"-----"))
+
+			unarmored, err := UnarmorPrivKey(armored, passphrase)
+			require.NoError(t, err)
+			assert.Equal(t, privKey, unarmored)
+
+			_, err = UnarmorPrivKey(armored, []byte("wrong"))
+			assert.Error(t, err)
+
+			// the type is authenticated
+			tampered := strings.Replace(armored, "type: "+privKey.Type(), "type: other", 1)
+			_, err = UnarmorPrivKey(tampered, passphrase)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestExportImportFilePVKey(t *testing.T) {
+	dir := t.TempDir()
+	keyFile, stateFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")
+	privVal := NewFilePV(bn254.GenPrivKey(), keyFile, stateFile)
+	privVal.Save()
+	keyPassphrase, passphrase := []byte("key passphrase"), []byte("armor passphrase")
+	require.NoError(t, EncryptFilePVKey(keyFile, keyPassphrase))
+
+	_, err := ExportFilePVKey(keyFile, nil, passphrase)
+	assert.ErrorIs(t, err, ErrKeyEncrypted)
+	armored, err := ExportFilePVKey(keyFile, keyPassphrase, passphrase)
+	require.NoError(t, err)
+
+	// the key file isn't overwritten, unless asked to
+	assert.Error(t, ImportFilePVKey(armored, passphrase, keyFile, stateFile, false))
+	require.NoError(t, ImportFilePVKey(armored, passphrase, keyFile, stateFile, true))
+	loaded := LoadFilePV(keyFile, stateFile)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+
+	// the state file is created if missing
+	otherDir := t.TempDir()
+	otherKeyFile, otherStateFile := filepath.Join(otherDir, "key.json"), filepath.Join(otherDir, "state.json")
+	require.NoError(t, ImportFilePVKey(armored, passphrase, otherKeyFile, otherStateFile, false))
+	_, err = os.Stat(otherStateFile)
+	require.NoError(t, err)
+	loaded = LoadFilePV(otherKeyFile, otherStateFile)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+}
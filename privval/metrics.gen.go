@@ -0,0 +1,46 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package privval
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		RequestDurationSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Time taken by the remote signer to answer each request.",
+
+			Buckets: []float64{.001, .005, .01, .05, .1, .25, .5, 1, 2.5, 5},
+		}, append(labels, "method")).With(labelsAndValues...),
+		RequestErrors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "request_errors",
+			Help:      "Number of the requests which failed, including the retried ones.",
+		}, append(labels, "method")).With(labelsAndValues...),
+		DeadlinesExceeded: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "deadlines_exceeded",
+			Help:      "Number of the requests retried until they exceeded their deadline.",
+		}, append(labels, "method")).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		RequestDurationSeconds: discard.NewHistogram(),
+		RequestErrors:          discard.NewCounter(),
+		DeadlinesExceeded:      discard.NewCounter(),
+	}
+}
@@ -0,0 +1,25 @@
+package privval
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "privval"
+)
+
+//go:generate go run ../scripts/metricsgen -struct=Metrics
+
+// Metrics contains the metrics of the requests to a remote signer.
+type Metrics struct {
+	// Time taken by the remote signer to answer each request.
+	RequestDurationSeconds metrics.Histogram `metrics_bucketsizes:".001,.005,.01,.05,.1,.25,.5,1,2.5,5" metrics_labels:"method"`
+
+	// Number of the requests which failed, including the retried ones.
+	RequestErrors metrics.Counter `metrics_labels:"method"`
+
+	// Number of the requests retried until they exceeded their deadline.
+	DeadlinesExceeded metrics.Counter `metrics_labels:"method"`
+}
@@ -6,8 +6,20 @@ import (
 	"github.com/cosmos/gogoproto/proto"
 
 	privvalproto "github.com/cometbft/cometbft/proto/tendermint/privval"
+	"github.com/cometbft/cometbft/types"
 )
 
+// VoteRequestEncoding returns the canonical encoding the vote of req must be
+// signed with, the proto encoding if the request doesn't specify any (e.g.
+// from a node predating the field).
+func VoteRequestEncoding(req *privvalproto.SignVoteRequest) (types.CanonicalEncoding, error) {
+	encoding := types.CanonicalEncoding(req.CanonicalEncoding)
+	if encoding == "" {
+		return types.CanonicalEncodingProto, nil
+	}
+	return encoding, encoding.ValidateBasic()
+}
+
 // TODO: Add ChainIDRequest
 
 func mustWrapMsg(pb proto.Message) privvalproto.Message {
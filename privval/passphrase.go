@@ -0,0 +1,105 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// PassphraseEnv is the environment variable from which the passphrase of an
+// encrypted PrivValidator key is read, unless it is read from a file.
+const PassphraseEnv = "CMT_PRIV_VALIDATOR_KEY_PASSPHRASE"
+
+// ReadPassphrase returns the passphrase of an encrypted PrivValidator key,
+// read from passphraseFile if set, else from the PassphraseEnv environment
+// variable if set, else prompted for on the terminal, twice if confirm is
+// true.
+func ReadPassphrase(passphraseFile string, confirm bool) ([]byte, error) {
+	if passphraseFile == "" {
+		if passphrase, ok := os.LookupEnv(PassphraseEnv); ok {
+			return checkPassphrase([]byte(passphrase))
+		}
+	}
+	return readPassphrase(passphraseFile, "the PrivValidator key", confirm,
+		fmt.Sprintf("set priv_validator_key_passphrase_file, or the %s environment variable", PassphraseEnv))
+}
+
+// ReadArmorPassphrase returns the passphrase of an armored private key, read
+// from passphraseFile if set, else prompted for on the terminal, twice if
+// confirm is true.
+func ReadArmorPassphrase(passphraseFile string, confirm bool) ([]byte, error) {
+	return readPassphrase(passphraseFile, "the armored key", confirm, "set a passphrase file")
+}
+
+func readPassphrase(passphraseFile, what string, confirm bool, hint string) ([]byte, error) {
+	if passphraseFile != "" {
+		bz, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading the passphrase: %w", err)
+		}
+		return checkPassphrase(bytes.TrimRight(bz, "\r\n"))
+	}
+
+	if fi, err := os.Stdin.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return nil, fmt.Errorf("no passphrase for %s: %s", what, hint)
+	}
+	passphrase, err := prompt("Passphrase of " + what + ": ")
+	if err != nil {
+		return nil, err
+	}
+	if confirm {
+		again, err := prompt("Repeat the passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(passphrase, again) {
+			return nil, errors.New("the passphrases don't match")
+		}
+	}
+	return checkPassphrase(passphrase)
+}
+
+func checkPassphrase(passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	return passphrase, nil
+}
+
+// prompt reads a line from the terminal, without echoing it where supported.
+func prompt(msg string) ([]byte, error) {
+	fmt.Fprint(os.Stderr, msg)
+	defer fmt.Fprintln(os.Stderr)
+
+	line, err := readHidden(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("reading the passphrase: %w", err)
+	}
+	return line, nil
+}
+
+// readLine reads r up to the end of the line, one byte at a time so that
+// nothing past it is consumed.
+func readLine(r io.Reader) ([]byte, error) {
+	var (
+		line []byte
+		b    = make([]byte, 1)
+	)
+	for {
+		n, err := r.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				return bytes.TrimRight(line, "\r"), nil
+			}
+			line = append(line, b[0])
+		}
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			return line, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
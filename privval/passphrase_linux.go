@@ -0,0 +1,28 @@
+//go:build linux
+
+package privval
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// readHidden reads a line from the terminal f with its echo turned off.
+func readHidden(f *os.File) ([]byte, error) {
+	fd := int(f.Fd())
+	termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return readLine(f)
+	}
+	noEcho := *termios
+	noEcho.Lflag &^= unix.ECHO
+	noEcho.Lflag |= unix.ICANON | unix.ISIG
+	noEcho.Iflag |= unix.ICRNL
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, &noEcho); err != nil {
+		return nil, err
+	}
+	defer func() { _ = unix.IoctlSetTermios(fd, unix.TCSETS, termios) }()
+
+	return readLine(f)
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package privval
+
+import "os"
+
+// readHidden reads a line from the terminal f. The echo is left on.
+func readHidden(f *os.File) ([]byte, error) {
+	return readLine(f)
+}
@@ -9,18 +9,71 @@ import (
 	"github.com/cometbft/cometbft/types"
 )
 
+const (
+	// OnDeadlineSkip gives up on the requests exceeding their deadline: the
+	// validator misses the vote or the proposal it was signing.
+	OnDeadlineSkip = "skip"
+	// OnDeadlineBlock keeps retrying the requests exceeding their deadline,
+	// blocking consensus until the signer answers.
+	OnDeadlineBlock = "block"
+)
+
+// maxRetryBackoff caps the wait between two attempts of a request.
+const maxRetryBackoff = time.Second
+
 // RetrySignerClient wraps SignerClient adding retry for each operation (except
 // Ping) w/ a timeout.
 type RetrySignerClient struct {
 	next    *SignerClient
 	retries int
 	timeout time.Duration
+
+	deadline   time.Duration
+	onDeadline string
+	metrics    *Metrics
+}
+
+// RetrySignerClientOption sets an optional parameter on the RetrySignerClient.
+type RetrySignerClientOption func(*RetrySignerClient)
+
+// RetrySignerClientDeadline bounds the time spent retrying each request to
+// deadline, past which the request is given up, or only reported if
+// onDeadline is OnDeadlineBlock. By default, the requests are only bounded by
+// the number of retries.
+func RetrySignerClientDeadline(deadline time.Duration, onDeadline string) RetrySignerClientOption {
+	return func(sc *RetrySignerClient) {
+		sc.deadline = deadline
+		sc.onDeadline = onDeadline
+	}
+}
+
+// RetrySignerClientMetrics sets the metrics of the requests exceeding their
+// deadline.
+func RetrySignerClientMetrics(metrics *Metrics) RetrySignerClientOption {
+	return func(sc *RetrySignerClient) { sc.metrics = metrics }
 }
 
 // NewRetrySignerClient returns RetrySignerClient. If +retries+ is 0, the
-// client will be retrying each operation indefinitely.
-func NewRetrySignerClient(sc *SignerClient, retries int, timeout time.Duration) *RetrySignerClient {
-	return &RetrySignerClient{sc, retries, timeout}
+// client will be retrying each operation indefinitely. The wait between two
+// attempts starts at +timeout+, and doubles after each of them, with some
+// jitter, up to a second.
+func NewRetrySignerClient(
+	sc *SignerClient,
+	retries int,
+	timeout time.Duration,
+	options ...RetrySignerClientOption,
+) *RetrySignerClient {
+	rsc := &RetrySignerClient{
+		next:       sc,
+		retries:    retries,
+		timeout:    timeout,
+		onDeadline: OnDeadlineSkip,
+		metrics:    NopMetrics(),
+	}
+	for _, option := range options {
+		option(rsc)
+	}
+	return rsc
 }
 
 var _ types.PrivValidator = (*RetrySignerClient)(nil)
@@ -45,44 +98,48 @@ func (sc *RetrySignerClient) Ping() error {
 }
 
 func (sc *RetrySignerClient) GetPubKey() (crypto.PubKey, error) {
-	var (
-		pk  crypto.PubKey
-		err error
-	)
-	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+	var pk crypto.PubKey
+	err := sc.retry("get_pub_key", func() (err error) {
 		pk, err = sc.next.GetPubKey()
-		if err == nil {
-			return pk, nil
-		}
-		// If remote signer errors, we don't retry.
-		if _, ok := err.(*RemoteSignerError); ok {
-			return nil, err
-		}
-		time.Sleep(sc.timeout)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("exhausted all attempts to get pubkey: %w", err)
 	}
-	return nil, fmt.Errorf("exhausted all attempts to get pubkey: %w", err)
+	return pk, nil
 }
 
-func (sc *RetrySignerClient) SignVote(chainID string, vote *cmtproto.Vote) error {
-	var err error
-	for i := 0; i < sc.retries || sc.retries == 0; i++ {
-		err = sc.next.SignVote(chainID, vote)
-		if err == nil {
-			return nil
-		}
-		// If remote signer errors, we don't retry.
-		if _, ok := err.(*RemoteSignerError); ok {
-			return err
-		}
-		time.Sleep(sc.timeout)
+func (sc *RetrySignerClient) SignVote(chainID string, encoding types.CanonicalEncoding, vote *cmtproto.Vote) error {
+	err := sc.retry("sign_vote", func() error {
+		return sc.next.SignVote(chainID, encoding, vote)
+	})
+	if err != nil {
+		return fmt.Errorf("exhausted all attempts to sign vote: %w", err)
 	}
-	return fmt.Errorf("exhausted all attempts to sign vote: %w", err)
+	return nil
 }
 
 func (sc *RetrySignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
-	var err error
+	err := sc.retry("sign_proposal", func() error {
+		return sc.next.SignProposal(chainID, proposal)
+	})
+	if err != nil {
+		return fmt.Errorf("exhausted all attempts to sign proposal: %w", err)
+	}
+	return nil
+}
+
+// retry attempts request until it succeeds, the remote signer errors, or the
+// retries or the deadline are exhausted. The errors of the remote signer are
+// returned as is.
+func (sc *RetrySignerClient) retry(method string, request func() error) error {
+	var (
+		err      error
+		start    = time.Now()
+		reported bool
+	)
 	for i := 0; i < sc.retries || sc.retries == 0; i++ {
-		err = sc.next.SignProposal(chainID, proposal)
+		err = request()
 		if err == nil {
 			return nil
 		}
@@ -90,7 +147,23 @@ func (sc *RetrySignerClient) SignProposal(chainID string, proposal *cmtproto.Pro
 		if _, ok := err.(*RemoteSignerError); ok {
 			return err
 		}
-		time.Sleep(sc.timeout)
+
+		wait := backoff(i+1, sc.timeout, maxRetryBackoff)
+		if sc.deadline > 0 && !reported {
+			elapsed := time.Since(start)
+			if elapsed >= sc.deadline {
+				sc.metrics.DeadlinesExceeded.With("method", method).Add(1)
+				if sc.onDeadline != OnDeadlineBlock {
+					return fmt.Errorf("%w after %v: %w", ErrDeadlineExceeded, elapsed, err)
+				}
+				sc.next.endpoint.Logger.Error("Request to the remote signer exceeded its deadline, still retrying",
+					"method", method, "elapsed", elapsed, "err", err)
+				reported = true
+			} else if left := sc.deadline - elapsed; wait > left {
+				wait = left
+			}
+		}
+		time.Sleep(wait)
 	}
-	return fmt.Errorf("exhausted all attempts to sign proposal: %w", err)
+	return err
 }
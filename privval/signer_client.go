@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cometbft/cometbft/crypto/bn254"
+
 	"github.com/cometbft/cometbft/crypto"
 	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	privvalproto "github.com/cometbft/cometbft/proto/tendermint/privval"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/types"
@@ -16,20 +19,42 @@ import (
 type SignerClient struct {
 	endpoint *SignerListenerEndpoint
 	chainID  string
+	metrics  *Metrics
+
+	mtx cmtsync.Mutex
+	// the hash-to-curve domain separation tag advertised by the signer, with
+	// which the returned nonces are checked
+	hashToCurveDST string
 }
 
 var _ types.PrivValidator = (*SignerClient)(nil)
 
+// SignerClientOption sets an optional parameter on the SignerClient.
+type SignerClientOption func(*SignerClient)
+
+// SignerClientMetrics sets the metrics of the requests to the signer.
+func SignerClientMetrics(metrics *Metrics) SignerClientOption {
+	return func(sc *SignerClient) { sc.metrics = metrics }
+}
+
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
-func NewSignerClient(endpoint *SignerListenerEndpoint, chainID string) (*SignerClient, error) {
+func NewSignerClient(
+	endpoint *SignerListenerEndpoint,
+	chainID string,
+	options ...SignerClientOption,
+) (*SignerClient, error) {
 	if !endpoint.IsRunning() {
 		if err := endpoint.Start(); err != nil {
 			return nil, fmt.Errorf("failed to start listener endpoint: %w", err)
 		}
 	}
 
-	return &SignerClient{endpoint: endpoint, chainID: chainID}, nil
+	sc := &SignerClient{endpoint: endpoint, chainID: chainID, metrics: NopMetrics()}
+	for _, option := range options {
+		option(sc)
+	}
+	return sc, nil
 }
 
 // Close closes the underlying connection
@@ -68,7 +93,9 @@ func (sc *SignerClient) Ping() error {
 
 // GetPubKey retrieves a public key from a remote signer
 // returns an error if client is not able to provide the key
-func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
+func (sc *SignerClient) GetPubKey() (_ crypto.PubKey, err error) {
+	defer sc.observe("get_pub_key", time.Now(), &err)
+
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.PubKeyRequest{ChainId: sc.chainID}))
 	if err != nil {
 		return nil, fmt.Errorf("send: %w", err)
@@ -86,13 +113,24 @@ func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := CheckHashToCurveDST(pk, resp.HashToCurveDst); err != nil {
+		return nil, err
+	}
+
+	sc.mtx.Lock()
+	sc.hashToCurveDST = resp.HashToCurveDst
+	sc.mtx.Unlock()
 
 	return pk, nil
 }
 
 // SignVote requests a remote signer to sign a vote
-func (sc *SignerClient) SignVote(chainID string, vote *cmtproto.Vote) error {
-	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID}))
+func (sc *SignerClient) SignVote(chainID string, encoding types.CanonicalEncoding, vote *cmtproto.Vote) (err error) {
+	defer sc.observe("sign_vote", time.Now(), &err)
+
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.SignVoteRequest{
+		Vote: vote, ChainId: chainID, HashToCurveDst: bn254.HashToCurveDST, CanonicalEncoding: string(encoding),
+	}))
 	if err != nil {
 		return err
 	}
@@ -105,15 +143,22 @@ func (sc *SignerClient) SignVote(chainID string, vote *cmtproto.Vote) error {
 		return &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
 	}
 
+	signBytes := types.VoteSignBytes(chainID, encoding, &resp.Vote)
+	if err := CheckHashToCurveNonce(sc.dst(), signBytes, resp.HashToCurveNonce); err != nil {
+		return err
+	}
+
 	*vote = resp.Vote
 
 	return nil
 }
 
 // SignProposal requests a remote signer to sign a proposal
-func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) (err error) {
+	defer sc.observe("sign_proposal", time.Now(), &err)
+
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(
-		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID},
+		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID, HashToCurveDst: bn254.HashToCurveDST},
 	))
 	if err != nil {
 		return err
@@ -127,7 +172,27 @@ func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal
 		return &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
 	}
 
+	if err := CheckHashToCurveNonce(
+		sc.dst(), types.ProposalSignBytes(chainID, &resp.Proposal), resp.HashToCurveNonce,
+	); err != nil {
+		return err
+	}
+
 	*proposal = resp.Proposal
 
 	return nil
 }
+
+// observe records the duration and the outcome of a request started at start.
+func (sc *SignerClient) observe(method string, start time.Time, err *error) {
+	sc.metrics.RequestDurationSeconds.With("method", method).Observe(time.Since(start).Seconds())
+	if *err != nil {
+		sc.metrics.RequestErrors.With("method", method).Add(1)
+	}
+}
+
+func (sc *SignerClient) dst() string {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	return sc.hashToCurveDST
+}
@@ -191,13 +191,60 @@ func TestSignerVote(t *testing.T) {
 			}
 		})
 
-		require.NoError(t, tc.mockPV.SignVote(tc.chainID, want.ToProto()))
-		require.NoError(t, tc.signerClient.SignVote(tc.chainID, have.ToProto()))
+		require.NoError(t, tc.mockPV.SignVote(tc.chainID, types.CanonicalEncodingProto, want.ToProto()))
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, types.CanonicalEncodingProto, have.ToProto()))
 
 		assert.Equal(t, want.Signature, have.Signature)
 	}
 }
 
+func TestSignerVoteEncoding(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		newVote := func() *cmtproto.Vote {
+			return &cmtproto.Vote{
+				Type:      cmtproto.PrecommitType,
+				Height:    1,
+				BlockID:   cmtproto.BlockID{Hash: tmhash.Sum([]byte("block"))},
+				Timestamp: time.Now().UTC(),
+			}
+		}
+
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		// the signer signs with the encoding of the request
+		pubKey, err := tc.signerClient.GetPubKey()
+		require.NoError(t, err)
+		vote := newVote()
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, types.CanonicalEncodingFixedWidth, vote))
+		signBytes := types.VoteSignBytes(tc.chainID, types.CanonicalEncodingFixedWidth, vote)
+		assert.True(t, pubKey.VerifySignature(signBytes, vote.Signature))
+
+		// the requests without any are signed with the proto encoding
+		vote = newVote()
+		res, err := DefaultValidationRequestHandler(tc.mockPV,
+			mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: tc.chainID}), tc.chainID)
+		require.NoError(t, err)
+		resp := res.GetSignedVoteResponse()
+		require.Nil(t, resp.Error)
+		signBytes = types.VoteSignBytes(tc.chainID, types.CanonicalEncodingProto, &resp.Vote)
+		assert.True(t, pubKey.VerifySignature(signBytes, resp.Vote.Signature))
+
+		// and the unknown encodings are refused
+		err = tc.signerClient.SignVote(tc.chainID, types.CanonicalEncoding("amino"), newVote())
+		assert.Error(t, err)
+	}
+}
+
 func TestSignerVoteResetDeadline(t *testing.T) {
 	for _, tc := range getSignerTestCases(t) {
 		ts := time.Now()
@@ -237,8 +284,8 @@ func TestSignerVoteResetDeadline(t *testing.T) {
 
 		time.Sleep(testTimeoutReadWrite2o3)
 
-		require.NoError(t, tc.mockPV.SignVote(tc.chainID, want.ToProto()))
-		require.NoError(t, tc.signerClient.SignVote(tc.chainID, have.ToProto()))
+		require.NoError(t, tc.mockPV.SignVote(tc.chainID, types.CanonicalEncodingProto, want.ToProto()))
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, types.CanonicalEncodingProto, have.ToProto()))
 		assert.Equal(t, want.Signature, have.Signature)
 
 		// TODO(jleni): Clarify what is actually being tested
@@ -246,8 +293,8 @@ func TestSignerVoteResetDeadline(t *testing.T) {
 		// This would exceed the deadline if it was not extended by the previous message
 		time.Sleep(testTimeoutReadWrite2o3)
 
-		require.NoError(t, tc.mockPV.SignVote(tc.chainID, want.ToProto()))
-		require.NoError(t, tc.signerClient.SignVote(tc.chainID, have.ToProto()))
+		require.NoError(t, tc.mockPV.SignVote(tc.chainID, types.CanonicalEncodingProto, want.ToProto()))
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, types.CanonicalEncodingProto, have.ToProto()))
 		assert.Equal(t, want.Signature, have.Signature)
 	}
 }
@@ -298,8 +345,8 @@ func TestSignerVoteKeepAlive(t *testing.T) {
 		time.Sleep(testTimeoutReadWrite * 3)
 		tc.signerServer.Logger.Debug("TEST: Forced Wait DONE---------------------------------------------")
 
-		require.NoError(t, tc.mockPV.SignVote(tc.chainID, want.ToProto()))
-		require.NoError(t, tc.signerClient.SignVote(tc.chainID, have.ToProto()))
+		require.NoError(t, tc.mockPV.SignVote(tc.chainID, types.CanonicalEncodingProto, want.ToProto()))
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, types.CanonicalEncodingProto, have.ToProto()))
 
 		assert.Equal(t, want.Signature, have.Signature)
 	}
@@ -378,13 +425,13 @@ func TestSignerSignVoteErrors(t *testing.T) {
 			}
 		})
 
-		err := tc.signerClient.SignVote(tc.chainID, vote.ToProto())
+		err := tc.signerClient.SignVote(tc.chainID, types.CanonicalEncodingProto, vote.ToProto())
 		require.Equal(t, err.(*RemoteSignerError).Description, types.ErroringMockPVErr.Error())
 
-		err = tc.mockPV.SignVote(tc.chainID, vote.ToProto())
+		err = tc.mockPV.SignVote(tc.chainID, types.CanonicalEncodingProto, vote.ToProto())
 		require.Error(t, err)
 
-		err = tc.signerClient.SignVote(tc.chainID, vote.ToProto())
+		err = tc.signerClient.SignVote(tc.chainID, types.CanonicalEncodingProto, vote.ToProto())
 		require.Error(t, err)
 	}
 }
@@ -433,7 +480,48 @@ func TestSignerUnexpectedResponse(t *testing.T) {
 		ts := time.Now()
 		want := &types.Vote{Timestamp: ts, Type: cmtproto.PrecommitType}
 
-		e := tc.signerClient.SignVote(tc.chainID, want.ToProto())
+		e := tc.signerClient.SignVote(tc.chainID, types.CanonicalEncodingProto, want.ToProto())
 		assert.EqualError(t, e, "empty response")
 	}
 }
+
+func TestRetrySignerClientDeadline(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		const deadline = 200 * time.Millisecond
+		sc := NewRetrySignerClient(tc.signerClient, 0, 10*time.Millisecond,
+			RetrySignerClientDeadline(deadline, OnDeadlineSkip))
+
+		vote := &types.Vote{Timestamp: time.Now(), Type: cmtproto.PrecommitType}
+		require.NoError(t, sc.SignVote(tc.chainID, types.CanonicalEncodingProto, vote.ToProto()))
+
+		// with a signer failing to answer, the request is given up after its
+		// deadline
+		tc.signerServer.SetRequestHandler(brokenHandler)
+		start := time.Now()
+		err := sc.SignVote(tc.chainID, types.CanonicalEncodingProto, vote.ToProto())
+		assert.ErrorIs(t, err, ErrDeadlineExceeded)
+		assert.GreaterOrEqual(t, time.Since(start), deadline)
+
+		// or retried until the signer answers
+		RetrySignerClientDeadline(deadline, OnDeadlineBlock)(sc)
+		go func() {
+			time.Sleep(2 * deadline)
+			tc.signerServer.SetRequestHandler(DefaultValidationRequestHandler)
+		}()
+		start = time.Now()
+		require.NoError(t, sc.SignVote(tc.chainID, types.CanonicalEncodingProto, vote.ToProto()))
+		assert.GreaterOrEqual(t, time.Since(start), 2*deadline)
+	}
+}
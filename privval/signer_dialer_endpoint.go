@@ -10,6 +10,7 @@ import (
 const (
 	defaultMaxDialRetries        = 10
 	defaultRetryWaitMilliseconds = 100
+	defaultMaxRetryWaitSeconds   = 5
 )
 
 // SignerServiceEndpointOption sets an optional parameter on the SignerDialerEndpoint.
@@ -28,11 +29,18 @@ func SignerDialerEndpointConnRetries(retries int) SignerServiceEndpointOption {
 }
 
 // SignerDialerEndpointRetryWaitInterval sets the retry wait interval to a
-// custom value.
+// custom value. It is doubled after each failed attempt, up to the maximum
+// retry wait interval.
 func SignerDialerEndpointRetryWaitInterval(interval time.Duration) SignerServiceEndpointOption {
 	return func(ss *SignerDialerEndpoint) { ss.retryWait = interval }
 }
 
+// SignerDialerEndpointMaxRetryWaitInterval sets the maximum retry wait
+// interval to a custom value.
+func SignerDialerEndpointMaxRetryWaitInterval(interval time.Duration) SignerServiceEndpointOption {
+	return func(ss *SignerDialerEndpoint) { ss.maxRetryWait = interval }
+}
+
 // SignerDialerEndpoint dials using its dialer and responds to any signature
 // requests using its privVal.
 type SignerDialerEndpoint struct {
@@ -41,6 +49,7 @@ type SignerDialerEndpoint struct {
 	dialer SocketDialer
 
 	retryWait      time.Duration
+	maxRetryWait   time.Duration
 	maxConnRetries int
 }
 
@@ -56,6 +65,7 @@ func NewSignerDialerEndpoint(
 	sd := &SignerDialerEndpoint{
 		dialer:         dialer,
 		retryWait:      defaultRetryWaitMilliseconds * time.Millisecond,
+		maxRetryWait:   defaultMaxRetryWaitSeconds * time.Second,
 		maxConnRetries: defaultMaxDialRetries,
 	}
 
@@ -81,8 +91,8 @@ func (sd *SignerDialerEndpoint) ensureConnection() error {
 		if err != nil {
 			retries++
 			sd.Logger.Debug("SignerDialer: Reconnection failed", "retries", retries, "max", sd.maxConnRetries, "err", err)
-			// Wait between retries
-			time.Sleep(sd.retryWait)
+			// Wait between retries, backing off exponentially
+			time.Sleep(backoff(retries, sd.retryWait, sd.maxRetryWait))
 		} else {
 			sd.SetConnection(conn)
 			sd.Logger.Debug("SignerDialer: Connection Ready")
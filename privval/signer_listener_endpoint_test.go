@@ -66,6 +66,8 @@ func TestSignerRemoteRetryTCPOnly(t *testing.T) {
 	)
 	SignerDialerEndpointTimeoutReadWrite(time.Millisecond)(dialerEndpoint)
 	SignerDialerEndpointConnRetries(retries)(dialerEndpoint)
+	SignerDialerEndpointRetryWaitInterval(10 * time.Millisecond)(dialerEndpoint)
+	SignerDialerEndpointMaxRetryWaitInterval(100 * time.Millisecond)(dialerEndpoint)
 
 	chainID := cmtrand.Str(12)
 	mockPV := types.NewMockPV()
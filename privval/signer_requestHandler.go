@@ -44,7 +44,7 @@ func DefaultValidationRequestHandler(
 			res = mustWrapMsg(&privvalproto.PubKeyResponse{
 				PubKey: cryptoproto.PublicKey{}, Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
 		} else {
-			res = mustWrapMsg(&privvalproto.PubKeyResponse{PubKey: pk, Error: nil})
+			res = mustWrapMsg(&privvalproto.PubKeyResponse{PubKey: pk, Error: nil, HashToCurveDst: HashToCurveDST(pubKey)})
 		}
 
 	case *privvalproto.Message_SignVoteRequest:
@@ -57,12 +57,26 @@ func DefaultValidationRequestHandler(
 
 		vote := r.SignVoteRequest.Vote
 
-		err = privVal.SignVote(chainID, vote)
+		var (
+			pubKey   crypto.PubKey
+			encoding types.CanonicalEncoding
+		)
+		pubKey, err = privVal.GetPubKey()
+		if err == nil {
+			err = CheckHashToCurveDST(pubKey, r.SignVoteRequest.HashToCurveDst)
+		}
+		if err == nil {
+			encoding, err = VoteRequestEncoding(r.SignVoteRequest)
+		}
+		if err == nil {
+			err = privVal.SignVote(chainID, encoding, vote)
+		}
 		if err != nil {
 			res = mustWrapMsg(&privvalproto.SignedVoteResponse{
 				Vote: cmtproto.Vote{}, Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
 		} else {
-			res = mustWrapMsg(&privvalproto.SignedVoteResponse{Vote: *vote, Error: nil})
+			res = mustWrapMsg(&privvalproto.SignedVoteResponse{Vote: *vote, Error: nil,
+				HashToCurveNonce: HashToCurveNonce(pubKey, types.VoteSignBytes(chainID, encoding, vote))})
 		}
 
 	case *privvalproto.Message_SignProposalRequest:
@@ -76,12 +90,20 @@ func DefaultValidationRequestHandler(
 
 		proposal := r.SignProposalRequest.Proposal
 
-		err = privVal.SignProposal(chainID, proposal)
+		var pubKey crypto.PubKey
+		pubKey, err = privVal.GetPubKey()
+		if err == nil {
+			err = CheckHashToCurveDST(pubKey, r.SignProposalRequest.HashToCurveDst)
+		}
+		if err == nil {
+			err = privVal.SignProposal(chainID, proposal)
+		}
 		if err != nil {
 			res = mustWrapMsg(&privvalproto.SignedProposalResponse{
 				Proposal: cmtproto.Proposal{}, Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
 		} else {
-			res = mustWrapMsg(&privvalproto.SignedProposalResponse{Proposal: *proposal, Error: nil})
+			res = mustWrapMsg(&privvalproto.SignedProposalResponse{Proposal: *proposal, Error: nil,
+				HashToCurveNonce: HashToCurveNonce(pubKey, types.ProposalSignBytes(chainID, proposal))})
 		}
 	case *privvalproto.Message_PingRequest:
 		err, res = nil, mustWrapMsg(&privvalproto.PingResponse{})
@@ -0,0 +1,25 @@
+/*
+Package threshold implements a t-of-n threshold signer for bn254 validator
+keys.
+
+The validator key is split with SplitKey into n shares, any t of which sign
+in its stead, t being a majority of n. Each share is held by a cosigner, a
+PrivValidator such as a FilePV, usually on a machine of its own. The Signer
+requests the partial signatures of the cosigners and combines them into a
+signature of the validator key, so that no machine holds the key, and the
+validator keeps signing while a minority of the cosigners is down.
+
+The cosigners keep the last height, round and step they signed for, as a
+FilePV does. As any two quorums of cosigners share one, these high-watermarks
+prevent double signing even with several Signers, e.g. one per node of a
+validator with failover.
+
+A typical setup serves the shares with privvalgrpc.SignerServer, and the
+Signer, reaching them with privvalgrpc.SignerClient over mutual TLS, to the
+node with privvalgrpc.SignerServer too:
+
+	key, shares, err := threshold.SplitKey(privKey, 2, 3)
+	...
+	signer, err := threshold.NewSigner(key, cosigners, time.Second, logger)
+*/
+package threshold
@@ -0,0 +1,163 @@
+package threshold
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	gnark "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/tempfile"
+)
+
+// Key is the public part of a bn254 key split into shares, which the Signer
+// needs to check and combine the partial signatures.
+type Key struct {
+	// The public key of the validator, whose signatures the shares combine to.
+	PubKey crypto.PubKey `json:"pub_key"`
+	// The number of partial signatures combined into a signature.
+	Threshold int `json:"threshold"`
+	// The public keys of the shares, the i-th share having the index i+1.
+	SharePubKeys []crypto.PubKey `json:"share_pub_keys"`
+}
+
+// ValidateBasic performs basic validation.
+func (key Key) ValidateBasic() error {
+	if _, ok := key.PubKey.(bn254.PubKey); !ok {
+		return fmt.Errorf("expected a %s public key, got %T", bn254.KeyType, key.PubKey)
+	}
+	n := len(key.SharePubKeys)
+	// two quorums of cosigners must have one in common, which refuses to sign
+	// for both
+	if key.Threshold <= n/2 || key.Threshold > n {
+		return fmt.Errorf("threshold must be a majority of the %d shares, got %d", n, key.Threshold)
+	}
+	for i, pk := range key.SharePubKeys {
+		if _, ok := pk.(bn254.PubKey); !ok {
+			return fmt.Errorf("share #%d: expected a %s public key, got %T", i+1, bn254.KeyType, pk)
+		}
+	}
+	return nil
+}
+
+// Save writes the key to filePath.
+func (key Key) Save(filePath string) error {
+	bz, err := cmtjson.MarshalIndent(key, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(filePath, bz, 0o600)
+}
+
+// LoadKey reads a key written by Key.Save.
+func LoadKey(filePath string) (Key, error) {
+	var key Key
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return key, err
+	}
+	if err := cmtjson.Unmarshal(bz, &key); err != nil {
+		return key, fmt.Errorf("decoding the threshold key from %v: %w", filePath, err)
+	}
+	return key, key.ValidateBasic()
+}
+
+// SplitKey splits privKey into shares, any threshold of which can sign in its
+// stead, with Shamir's secret sharing. The i-th share has the index i+1, and
+// signs like a bn254 private key, so it can be held by any PrivValidator, e.g.
+// a FilePV.
+func SplitKey(privKey bn254.PrivKey, threshold, shares int) (Key, []bn254.PrivKey, error) {
+	if threshold <= shares/2 || threshold > shares {
+		return Key{}, nil, fmt.Errorf("threshold must be a majority of the %d shares, got %d", shares, threshold)
+	}
+
+	// f(x) = secret + a_1 x + ... + a_{t-1} x^{t-1}
+	coeffs := make([]fr.Element, threshold)
+	coeffs[0].SetBigInt(new(big.Int).SetBytes(privKey))
+	for i := 1; i < threshold; i++ {
+		if _, err := coeffs[i].SetRandom(); err != nil {
+			return Key{}, nil, err
+		}
+	}
+
+	key := Key{
+		PubKey:       privKey.PubKey(),
+		Threshold:    threshold,
+		SharePubKeys: make([]crypto.PubKey, shares),
+	}
+	privKeys := make([]bn254.PrivKey, shares)
+	for i := range privKeys {
+		var x, y fr.Element
+		x.SetUint64(uint64(i + 1))
+		for j := threshold - 1; j >= 0; j-- {
+			y.Mul(&y, &x).Add(&y, &coeffs[j])
+		}
+		bz := y.Bytes()
+		privKeys[i] = bn254.PrivKey(bz[:])
+		key.SharePubKeys[i] = privKeys[i].PubKey()
+	}
+	return key, privKeys, nil
+}
+
+// Combine combines the partial signatures sigs, of the shares of the given
+// indexes, into the signature of the key they were split from. There must be
+// as many as its threshold.
+func Combine(indexes []int, sigs [][]byte) ([]byte, error) {
+	if len(indexes) == 0 || len(indexes) != len(sigs) {
+		return nil, errors.New("expected as many indexes as signatures")
+	}
+
+	for _, index := range indexes {
+		if index <= 0 {
+			return nil, fmt.Errorf("invalid share index %d", index)
+		}
+	}
+
+	var combined gnark.G2Jac
+	for i, sig := range sigs {
+		var p gnark.G2Affine
+		if _, err := p.SetBytes(sig); err != nil {
+			return nil, fmt.Errorf("invalid partial signature of share #%d: %w", indexes[i], err)
+		}
+		lambda, err := lagrangeAtZero(indexes, i)
+		if err != nil {
+			return nil, err
+		}
+		var pj gnark.G2Jac
+		pj.FromAffine(&p)
+		pj.ScalarMultiplication(&pj, lambda.BigInt(new(big.Int)))
+		combined.AddAssign(&pj)
+	}
+
+	var res gnark.G2Affine
+	res.FromJacobian(&combined)
+	return res.Marshal(), nil
+}
+
+// lagrangeAtZero returns the Lagrange coefficient of the i-th index, to
+// interpolate the polynomial at zero.
+func lagrangeAtZero(indexes []int, i int) (fr.Element, error) {
+	var num, den, xi fr.Element
+	num.SetOne()
+	den.SetOne()
+	xi.SetUint64(uint64(indexes[i]))
+	for j, index := range indexes {
+		if j == i {
+			continue
+		}
+		if index == indexes[i] {
+			return fr.Element{}, fmt.Errorf("duplicate share index %d", index)
+		}
+		var xj, diff fr.Element
+		xj.SetUint64(uint64(index))
+		num.Mul(&num, &xj)
+		diff.Sub(&xj, &xi)
+		den.Mul(&den, &diff)
+	}
+	return *num.Div(&num, &den), nil
+}
@@ -0,0 +1,222 @@
+package threshold
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// The steps of a height and round, as in privval.
+const (
+	stepPropose   int8 = 1
+	stepPrevote   int8 = 2
+	stepPrecommit int8 = 3
+)
+
+func voteToStep(vote *cmtproto.Vote) int8 {
+	if vote.Type == cmtproto.PrecommitType {
+		return stepPrecommit
+	}
+	return stepPrevote
+}
+
+// Signer implements PrivValidator, coordinating the cosigners holding the
+// shares of a bn254 key split by SplitKey: it requests their partial
+// signatures, and combines a threshold of them into a signature of the key.
+//
+// The cosigners are PrivValidators holding a share each, the i-th cosigner
+// the share of index i+1. They sign like a FilePV, keeping the last height,
+// round and step they signed for and refusing to sign a regression or a
+// conflicting message. As the threshold is a majority of the shares, any two
+// quorums of cosigners have one in common, which refuses to sign for both:
+// their high-watermarks are shared by the quorums, and no two conflicting
+// messages can be signed, even by two Signers. The remote cosigners should be
+// reached over an authenticated transport, e.g. with privvalgrpc.SignerClient
+// over mutual TLS.
+type Signer struct {
+	key       Key
+	cosigners []types.PrivValidator
+	timeout   time.Duration
+	logger    log.Logger
+
+	// requests to the cosigners, which may outlive the signatures
+	inflight sync.WaitGroup
+
+	mtx cmtsync.Mutex
+	// high-watermark of the signatures combined by this signer, to refuse the
+	// regressions without reaching the cosigners
+	height int64
+	round  int32
+	step   int8
+}
+
+var _ types.PrivValidator = (*Signer)(nil)
+
+// NewSigner returns a Signer of key, with the given cosigners. The partial
+// signatures are awaited for timeout, or indefinitely if zero.
+func NewSigner(key Key, cosigners []types.PrivValidator, timeout time.Duration, logger log.Logger) (*Signer, error) {
+	if err := key.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if len(cosigners) != len(key.SharePubKeys) {
+		return nil, fmt.Errorf("expected %d cosigners, got %d", len(key.SharePubKeys), len(cosigners))
+	}
+	return &Signer{
+		key:       key,
+		cosigners: cosigners,
+		timeout:   timeout,
+		logger:    logger,
+	}, nil
+}
+
+// GetPubKey implements PrivValidator.
+func (s *Signer) GetPubKey() (crypto.PubKey, error) {
+	return s.key.PubKey, nil
+}
+
+// SignVote implements PrivValidator.
+func (s *Signer) SignVote(chainID string, encoding types.CanonicalEncoding, vote *cmtproto.Vote) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	step := voteToStep(vote)
+	if err := s.checkHRS(vote.Height, vote.Round, step); err != nil {
+		return err
+	}
+	// the requests may outlive this call, so they sign copies of the vote
+	unsigned := *vote
+	unsigned.Signature = nil
+	res, sig, err := s.sign(func(cosigner types.PrivValidator) partial {
+		v := unsigned
+		err := cosigner.SignVote(chainID, encoding, &v)
+		signBytes := types.VoteSignBytes(chainID, encoding, &v)
+		return partial{signBytes: signBytes, sig: v.Signature, timestamp: v.Timestamp, err: err}
+	})
+	if err != nil {
+		return err
+	}
+	s.height, s.round, s.step = vote.Height, vote.Round, step
+	// a cosigner which already signed for this height, round and step signs
+	// the same vote, with its timestamp
+	vote.Timestamp = res.timestamp
+	vote.Signature = sig
+	return nil
+}
+
+// SignProposal implements PrivValidator.
+func (s *Signer) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := s.checkHRS(proposal.Height, proposal.Round, stepPropose); err != nil {
+		return err
+	}
+	unsigned := *proposal
+	unsigned.Signature = nil
+	res, sig, err := s.sign(func(cosigner types.PrivValidator) partial {
+		p := unsigned
+		err := cosigner.SignProposal(chainID, &p)
+		return partial{signBytes: types.ProposalSignBytes(chainID, &p), sig: p.Signature, timestamp: p.Timestamp, err: err}
+	})
+	if err != nil {
+		return err
+	}
+	s.height, s.round, s.step = proposal.Height, proposal.Round, stepPropose
+	proposal.Timestamp = res.timestamp
+	proposal.Signature = sig
+	return nil
+}
+
+func (s *Signer) checkHRS(height int64, round int32, step int8) error {
+	if height < s.height ||
+		(height == s.height && round < s.round) ||
+		(height == s.height && round == s.round && step < s.step) {
+		return fmt.Errorf("regression: got %d/%d/%d, last signed %d/%d/%d",
+			height, round, step, s.height, s.round, s.step)
+	}
+	return nil
+}
+
+// partial is the partial signature of a cosigner.
+type partial struct {
+	index     int
+	signBytes []byte
+	sig       []byte
+	timestamp time.Time
+	err       error
+}
+
+// sign requests the partial signatures of all the cosigners with signFn, and
+// combines the first threshold of them signing the same message.
+func (s *Signer) sign(signFn func(types.PrivValidator) partial) (partial, []byte, error) {
+	results := make(chan partial, len(s.cosigners))
+	for i, cosigner := range s.cosigners {
+		s.inflight.Add(1)
+		go func(i int, cosigner types.PrivValidator) {
+			defer s.inflight.Done()
+			p := signFn(cosigner)
+			p.index = i
+			results <- p
+		}(i, cosigner)
+	}
+
+	var timeout <-chan time.Time
+	if s.timeout > 0 {
+		timer := time.NewTimer(s.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var (
+		// the partial signatures, by message signed
+		partials = make(map[string][]partial)
+		errs     []error
+	)
+	for received := 0; received < len(s.cosigners); received++ {
+		var p partial
+		select {
+		case p = <-results:
+		case <-timeout:
+			return partial{}, nil, fmt.Errorf("timed out waiting for the partial signatures: %w", errors.Join(errs...))
+		}
+
+		if p.err != nil {
+			errs = append(errs, fmt.Errorf("cosigner #%d: %w", p.index+1, p.err))
+			continue
+		}
+		if !s.key.SharePubKeys[p.index].VerifySignature(p.signBytes, p.sig) {
+			errs = append(errs, fmt.Errorf("cosigner #%d: invalid partial signature", p.index+1))
+			continue
+		}
+		signed := append(partials[string(p.signBytes)], p)
+		partials[string(p.signBytes)] = signed
+		if len(signed) < s.key.Threshold {
+			continue
+		}
+
+		indexes := make([]int, len(signed))
+		sigs := make([][]byte, len(signed))
+		for i, p := range signed {
+			indexes[i], sigs[i] = p.index+1, p.sig
+		}
+		sig, err := Combine(indexes, sigs)
+		if err != nil {
+			return partial{}, nil, err
+		}
+		if !s.key.PubKey.VerifySignature(p.signBytes, sig) {
+			return partial{}, nil, errors.New("the combined signature is invalid")
+		}
+		for _, err := range errs {
+			s.logger.Info("Cosigner failed to sign", "err", err)
+		}
+		return p, sig, nil
+	}
+	return partial{}, nil, fmt.Errorf("not enough partial signatures of the same message: %w", errors.Join(errs...))
+}
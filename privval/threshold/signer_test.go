@@ -0,0 +1,149 @@
+package threshold
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/privval"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+const chainID = "test-chain"
+
+func TestSplitKey(t *testing.T) {
+	privKey := bn254.GenPrivKey()
+	key, shares, err := SplitKey(privKey, 3, 5)
+	require.NoError(t, err)
+	require.NoError(t, key.ValidateBasic())
+	assert.Equal(t, privKey.PubKey(), key.PubKey)
+	require.Len(t, shares, 5)
+
+	msg := []byte("message")
+	sigs := make([][]byte, len(shares))
+	for i, share := range shares {
+		sigs[i], err = share.Sign(msg)
+		require.NoError(t, err)
+		assert.True(t, key.SharePubKeys[i].VerifySignature(msg, sigs[i]))
+	}
+
+	// any threshold of the partial signatures combine to a signature of the key
+	for _, indexes := range [][]int{{1, 2, 3}, {5, 3, 1}, {2, 4, 5}, {1, 2, 3, 4}} {
+		partials := make([][]byte, len(indexes))
+		for i, index := range indexes {
+			partials[i] = sigs[index-1]
+		}
+		sig, err := Combine(indexes, partials)
+		require.NoError(t, err)
+		assert.True(t, key.PubKey.VerifySignature(msg, sig), indexes)
+	}
+
+	// but fewer don't
+	sig, err := Combine([]int{1, 2}, sigs[:2])
+	require.NoError(t, err)
+	assert.False(t, key.PubKey.VerifySignature(msg, sig))
+
+	_, _, err = SplitKey(privKey, 2, 4)
+	assert.Error(t, err, "a threshold which isn't a majority must be refused")
+}
+
+// failingPV is a cosigner which is down.
+type failingPV struct {
+	types.PrivValidator
+}
+
+func (failingPV) SignVote(string, types.CanonicalEncoding, *cmtproto.Vote) error {
+	return errors.New("unreachable")
+}
+
+func newCosigners(t *testing.T, shares []bn254.PrivKey) []types.PrivValidator {
+	dir := t.TempDir()
+	cosigners := make([]types.PrivValidator, len(shares))
+	for i, share := range shares {
+		name := strconv.Itoa(i + 1)
+		cosigners[i] = privval.NewFilePV(share,
+			filepath.Join(dir, name+"_key.json"), filepath.Join(dir, name+"_state.json"))
+	}
+	return cosigners
+}
+
+func newSigner(t *testing.T, key Key, cosigners []types.PrivValidator) *Signer {
+	signer, err := NewSigner(key, cosigners, time.Second, log.TestingLogger())
+	require.NoError(t, err)
+	// let the cosigners answer before their files are removed
+	t.Cleanup(signer.inflight.Wait)
+	return signer
+}
+
+func testVote(pubKey crypto.PubKey, height int64, block string) *cmtproto.Vote {
+	return &cmtproto.Vote{
+		Type:             cmtproto.PrecommitType,
+		Height:           height,
+		BlockID:          cmtproto.BlockID{Hash: tmhash.Sum([]byte(block))},
+		Timestamp:        time.Now().UTC(),
+		ValidatorAddress: pubKey.Address(),
+	}
+}
+
+func TestSigner(t *testing.T) {
+	privKey := bn254.GenPrivKey()
+	key, shares, err := SplitKey(privKey, 2, 3)
+	require.NoError(t, err)
+	cosigners := newCosigners(t, shares)
+	// a minority of the cosigners is down
+	cosigners[1] = failingPV{cosigners[1]}
+
+	signer := newSigner(t, key, cosigners)
+	pubKey, err := signer.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, privKey.PubKey(), pubKey)
+
+	vote := testVote(pubKey, 1, "block")
+	require.NoError(t, signer.SignVote(chainID, types.CanonicalEncodingProto, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, types.CanonicalEncodingProto, vote), vote.Signature))
+
+	proposal := &cmtproto.Proposal{
+		Type:      cmtproto.ProposalType,
+		Height:    2,
+		PolRound:  -1,
+		BlockID:   cmtproto.BlockID{Hash: tmhash.Sum([]byte("block"))},
+		Timestamp: time.Now().UTC(),
+	}
+	require.NoError(t, signer.SignProposal(chainID, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+
+	// the regressions are refused
+	assert.Error(t, signer.SignVote(chainID, types.CanonicalEncodingProto, testVote(pubKey, 1, "block")))
+}
+
+func TestSignerDoubleSign(t *testing.T) {
+	key, shares, err := SplitKey(bn254.GenPrivKey(), 2, 3)
+	require.NoError(t, err)
+	cosigners := newCosigners(t, shares)
+
+	// two signers, e.g. of two nodes, reach the cosigners
+	first := newSigner(t, key, []types.PrivValidator{cosigners[0], cosigners[1], failingPV{cosigners[2]}})
+	second := newSigner(t, key, []types.PrivValidator{failingPV{cosigners[0]}, cosigners[1], cosigners[2]})
+
+	vote := testVote(key.PubKey, 1, "block")
+	require.NoError(t, first.SignVote(chainID, types.CanonicalEncodingProto, vote))
+
+	// the cosigner shared by both quorums refuses to sign a conflicting vote
+	assert.Error(t, second.SignVote(chainID, types.CanonicalEncodingProto, testVote(key.PubKey, 1, "other block")))
+
+	// but signs the same vote again, e.g. after a crash
+	again := *vote
+	again.Signature = nil
+	require.NoError(t, first.SignVote(chainID, types.CanonicalEncodingProto, &again))
+	assert.Equal(t, vote.Signature, again.Signature)
+}
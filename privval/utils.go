@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtnet "github.com/cometbft/cometbft/libs/net"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
 )
 
 // IsConnTimeout returns a boolean indicating whether the error is known to
@@ -60,3 +62,23 @@ func GetFreeLocalhostAddrPort() string {
 	}
 	return fmt.Sprintf("127.0.0.1:%d", port)
 }
+
+// backoff returns the time to wait before the retry following attempt
+// failures: base, doubled at each failure up to max, of which a random half
+// is taken off so that the retries of several clients don't line up.
+func backoff(attempt int, base, max time.Duration) time.Duration {
+	if max < base {
+		max = base
+	}
+	d := base
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	if half := int64(d / 2); half > 0 {
+		d -= time.Duration(cmtrand.Int63n(half + 1))
+	}
+	return d
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,3 +13,20 @@ func TestIsConnTimeoutForNonTimeoutErrors(t *testing.T) {
 	assert.False(t, IsConnTimeout(fmt.Errorf("max retries exceeded: %w", ErrDialRetryMax)))
 	assert.False(t, IsConnTimeout(errors.New("completely irrelevant error")))
 }
+
+func TestBackoff(t *testing.T) {
+	const base, max = 100 * time.Millisecond, time.Second
+	for attempt, want := range []time.Duration{base, base, 2 * base, 4 * base, 8 * base, max, max} {
+		if attempt == 0 {
+			continue
+		}
+		for i := 0; i < 10; i++ {
+			d := backoff(attempt, base, max)
+			assert.LessOrEqual(t, d, want)
+			assert.GreaterOrEqual(t, d, want/2)
+		}
+	}
+
+	// the base wait is never cut down by the maximum
+	assert.GreaterOrEqual(t, backoff(3, 2*max, max), max)
+}
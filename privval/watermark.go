@@ -0,0 +1,214 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/tempfile"
+)
+
+// ErrWatermarkAhead is returned when saving a last sign state behind, or
+// conflicting with, the one persisted, e.g. by another signer of the same key.
+var ErrWatermarkAhead = errors.New("the persisted watermark is ahead")
+
+// WatermarkStore persists the last sign state of a FilePV, its
+// high-watermark. The FilePV returns a signature only once its store saved it.
+type WatermarkStore interface {
+	// Load returns the persisted state, or an empty one if there is none.
+	Load() (FilePVLastSignState, error)
+	// Save durably persists state before returning. It fails with
+	// ErrWatermarkAhead if the persisted state is ahead of state.
+	Save(state FilePVLastSignState) error
+}
+
+// isAhead returns whether a is ahead of b, or conflicts with it: a signed
+// other bytes for the same height, round and step.
+func isAhead(a, b FilePVLastSignState) bool {
+	if a.Height != b.Height {
+		return a.Height > b.Height
+	}
+	if a.Round != b.Round {
+		return a.Round > b.Round
+	}
+	if a.Step != b.Step {
+		return a.Step > b.Step
+	}
+	return a.SignBytes != nil && !bytes.Equal(a.SignBytes, b.SignBytes)
+}
+
+//-------------------------------------------------------------------------------
+
+// FileWatermarkStore persists the last sign state to a JSON file, syncing it
+// and its directory to disk before returning.
+type FileWatermarkStore struct {
+	filePath string
+}
+
+var _ WatermarkStore = FileWatermarkStore{}
+
+// NewFileWatermarkStore returns a store of the last sign state at filePath.
+func NewFileWatermarkStore(filePath string) FileWatermarkStore {
+	return FileWatermarkStore{filePath: filePath}
+}
+
+// Load implements WatermarkStore.
+func (s FileWatermarkStore) Load() (FilePVLastSignState, error) {
+	state := FilePVLastSignState{filePath: s.filePath}
+	bz, err := os.ReadFile(s.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := cmtjson.Unmarshal(bz, &state); err != nil {
+		return state, fmt.Errorf("error reading PrivValidator state from %v: %w", s.filePath, err)
+	}
+	return state, nil
+}
+
+// Save implements WatermarkStore.
+func (s FileWatermarkStore) Save(state FilePVLastSignState) error {
+	if s.filePath == "" {
+		return errors.New("cannot save FilePVLastSignState: filePath not set")
+	}
+	bz, err := cmtjson.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	// the file is written synchronously, then renamed: sync the rename too
+	if err := tempfile.WriteFileAtomic(s.filePath, bz, 0o600); err != nil {
+		return err
+	}
+	dir, err := os.Open(filepath.Dir(s.filePath))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+//-------------------------------------------------------------------------------
+
+// WatermarkBackend is a strongly consistent key-value store shared by the
+// redundant signers of a key. Each value has a version, changing on every
+// write. CometBFT doesn't ship any implementation, nor a configuration for
+// one: the FilePV of the node always uses a FileWatermarkStore, and the
+// programs embedding the FilePV build a SharedWatermarkStore over a backend
+// of their own (see LoadFilePVWithWatermarkStore).
+type WatermarkBackend interface {
+	// Get returns the value at key and its version, or a zero version if
+	// there is none.
+	Get(ctx context.Context, key string) (value []byte, version uint64, err error)
+	// CompareAndSwap sets the value at key if its version is still version,
+	// and returns whether it did.
+	CompareAndSwap(ctx context.Context, key string, version uint64, value []byte) (bool, error)
+}
+
+// SharedWatermarkStore persists the last sign state to a WatermarkBackend
+// shared by the redundant signers of a key, and to a local store. A signer
+// can only advance the shared watermark from the version it read, like under
+// a lock, so two signers can't both sign for the same height, round and step:
+// the second one fails with ErrWatermarkAhead and returns no signature.
+type SharedWatermarkStore struct {
+	backend WatermarkBackend
+	key     string
+	local   WatermarkStore
+	timeout time.Duration
+}
+
+var _ WatermarkStore = (*SharedWatermarkStore)(nil)
+
+// NewSharedWatermarkStore returns a store of the last sign state at key in
+// backend, and in local. Each operation on the backend times out after
+// timeout, or never if zero.
+func NewSharedWatermarkStore(
+	backend WatermarkBackend,
+	key string,
+	local WatermarkStore,
+	timeout time.Duration,
+) *SharedWatermarkStore {
+	return &SharedWatermarkStore{
+		backend: backend,
+		key:     key,
+		local:   local,
+		timeout: timeout,
+	}
+}
+
+// Load implements WatermarkStore. It returns the state furthest ahead of the
+// shared and the local ones.
+func (s *SharedWatermarkStore) Load() (FilePVLastSignState, error) {
+	local, err := s.local.Load()
+	if err != nil {
+		return local, err
+	}
+	ctx, cancel := s.context()
+	defer cancel()
+	shared, _, err := s.get(ctx)
+	if err != nil {
+		return local, err
+	}
+	if isAhead(shared, local) {
+		shared.filePath = local.filePath
+		return shared, nil
+	}
+	return local, nil
+}
+
+// Save implements WatermarkStore.
+func (s *SharedWatermarkStore) Save(state FilePVLastSignState) error {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	for {
+		shared, version, err := s.get(ctx)
+		if err != nil {
+			return err
+		}
+		if isAhead(shared, state) {
+			return fmt.Errorf("%w: %d/%d/%d", ErrWatermarkAhead, shared.Height, shared.Round, shared.Step)
+		}
+		bz, err := cmtjson.Marshal(state)
+		if err != nil {
+			return err
+		}
+		ok, err := s.backend.CompareAndSwap(ctx, s.key, version, bz)
+		if err != nil {
+			return fmt.Errorf("saving the shared watermark: %w", err)
+		}
+		if ok {
+			break
+		}
+		// another signer saved its state meanwhile, check it again
+	}
+	return s.local.Save(state)
+}
+
+func (s *SharedWatermarkStore) get(ctx context.Context) (FilePVLastSignState, uint64, error) {
+	var state FilePVLastSignState
+	bz, version, err := s.backend.Get(ctx, s.key)
+	if err != nil {
+		return state, 0, fmt.Errorf("loading the shared watermark: %w", err)
+	}
+	if version == 0 {
+		return state, 0, nil
+	}
+	if err := cmtjson.Unmarshal(bz, &state); err != nil {
+		return state, 0, fmt.Errorf("decoding the shared watermark: %w", err)
+	}
+	return state, version, nil
+}
+
+func (s *SharedWatermarkStore) context() (context.Context, context.CancelFunc) {
+	if s.timeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), s.timeout)
+}
@@ -0,0 +1,103 @@
+package privval
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// memBackend is a WatermarkBackend in memory.
+type memBackend struct {
+	mtx      cmtsync.Mutex
+	values   map[string][]byte
+	versions map[string]uint64
+}
+
+func newMemBackend() *memBackend {
+	return &memBackend{values: map[string][]byte{}, versions: map[string]uint64{}}
+}
+
+func (b *memBackend) Get(_ context.Context, key string) ([]byte, uint64, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.values[key], b.versions[key], nil
+}
+
+func (b *memBackend) CompareAndSwap(_ context.Context, key string, version uint64, value []byte) (bool, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.versions[key] != version {
+		return false, nil
+	}
+	b.values[key] = value
+	b.versions[key]++
+	return true, nil
+}
+
+func TestFileWatermarkStore(t *testing.T) {
+	store := NewFileWatermarkStore(filepath.Join(t.TempDir(), "priv_validator_state.json"))
+
+	// a missing file is an empty state
+	state, err := store.Load()
+	require.NoError(t, err)
+	assert.Zero(t, state.Height)
+
+	state.Height, state.Round, state.Step = 10, 1, stepPrecommit
+	state.Signature, state.SignBytes = []byte("signature"), []byte("sign bytes")
+	require.NoError(t, store.Save(state))
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, state, loaded)
+}
+
+func TestSharedWatermarkStore(t *testing.T) {
+	backend := newMemBackend()
+	privKey := ed25519.GenPrivKey()
+
+	// two redundant signers of the same key, sharing their watermark
+	newSigner := func() *FilePV {
+		dir := t.TempDir()
+		keyFile := filepath.Join(dir, "priv_validator_key.json")
+		NewFilePV(privKey, keyFile, filepath.Join(dir, "unused.json")).Key.Save()
+
+		store := NewSharedWatermarkStore(backend, "validator",
+			NewFileWatermarkStore(filepath.Join(dir, "priv_validator_state.json")), 0)
+		pv, err := LoadFilePVWithWatermarkStore(keyFile, store)
+		require.NoError(t, err)
+		return pv
+	}
+	first, second := newSigner(), newSigner()
+
+	vote := func(height int64, block string) *cmtproto.Vote {
+		blockID := types.BlockID{Hash: tmhash.Sum([]byte(block))}
+		return newVote(privKey.PubKey().Address(), 0, height, 0, cmtproto.PrecommitType, blockID).ToProto()
+	}
+
+	require.NoError(t, first.SignVote("mychainid", types.CanonicalEncodingProto, vote(1, "block")))
+
+	// the second signer, unaware of the vote, can't sign a conflicting one
+	conflicting := vote(1, "other block")
+	err := second.SignVote("mychainid", types.CanonicalEncodingProto, conflicting)
+	assert.ErrorIs(t, err, ErrWatermarkAhead)
+	assert.Nil(t, conflicting.Signature)
+	assert.Zero(t, second.LastSignState.Height)
+
+	// but signs the next height, which the first one then can't go back on
+	require.NoError(t, second.SignVote("mychainid", types.CanonicalEncodingProto, vote(2, "block")))
+	err = first.SignProposal("mychainid", newProposal(1, 1, types.BlockID{}).ToProto())
+	assert.ErrorIs(t, err, ErrWatermarkAhead)
+
+	// a restarted signer starts from the shared watermark
+	restarted := newSigner()
+	assert.EqualValues(t, 2, restarted.LastSignState.Height)
+}
@@ -0,0 +1,2162 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/crypto/ics23.proto
+
+package crypto
+
+import (
+	fmt "fmt"
+	proto "github.com/cosmos/gogoproto/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type HashOp int32
+
+const (
+	HashOp_NO_HASH     HashOp = 0
+	HashOp_SHA256      HashOp = 1
+	HashOp_SHA512      HashOp = 2
+	HashOp_KECCAK256   HashOp = 3
+	HashOp_RIPEMD160   HashOp = 4
+	HashOp_BITCOIN     HashOp = 5
+	HashOp_SHA512_256  HashOp = 6
+	HashOp_BLAKE2B_512 HashOp = 7
+	HashOp_BLAKE2S_256 HashOp = 8
+	HashOp_BLAKE3      HashOp = 9
+)
+
+var HashOp_name = map[int32]string{
+	0: "NO_HASH",
+	1: "SHA256",
+	2: "SHA512",
+	3: "KECCAK256",
+	4: "RIPEMD160",
+	5: "BITCOIN",
+	6: "SHA512_256",
+	7: "BLAKE2B_512",
+	8: "BLAKE2S_256",
+	9: "BLAKE3",
+}
+
+var HashOp_value = map[string]int32{
+	"NO_HASH":     0,
+	"SHA256":      1,
+	"SHA512":      2,
+	"KECCAK256":   3,
+	"RIPEMD160":   4,
+	"BITCOIN":     5,
+	"SHA512_256":  6,
+	"BLAKE2B_512": 7,
+	"BLAKE2S_256": 8,
+	"BLAKE3":      9,
+}
+
+func (x HashOp) String() string {
+	return proto.EnumName(HashOp_name, int32(x))
+}
+
+func (HashOp) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ab286090991ccf5c, []int{0}
+}
+
+type LengthOp int32
+
+const (
+	LengthOp_NO_PREFIX        LengthOp = 0
+	LengthOp_VAR_PROTO        LengthOp = 1
+	LengthOp_VAR_RLP          LengthOp = 2
+	LengthOp_FIXED32_BIG      LengthOp = 3
+	LengthOp_FIXED32_LITTLE   LengthOp = 4
+	LengthOp_FIXED64_BIG      LengthOp = 5
+	LengthOp_FIXED64_LITTLE   LengthOp = 6
+	LengthOp_REQUIRE_32_BYTES LengthOp = 7
+	LengthOp_REQUIRE_64_BYTES LengthOp = 8
+)
+
+var LengthOp_name = map[int32]string{
+	0: "NO_PREFIX",
+	1: "VAR_PROTO",
+	2: "VAR_RLP",
+	3: "FIXED32_BIG",
+	4: "FIXED32_LITTLE",
+	5: "FIXED64_BIG",
+	6: "FIXED64_LITTLE",
+	7: "REQUIRE_32_BYTES",
+	8: "REQUIRE_64_BYTES",
+}
+
+var LengthOp_value = map[string]int32{
+	"NO_PREFIX":        0,
+	"VAR_PROTO":        1,
+	"VAR_RLP":          2,
+	"FIXED32_BIG":      3,
+	"FIXED32_LITTLE":   4,
+	"FIXED64_BIG":      5,
+	"FIXED64_LITTLE":   6,
+	"REQUIRE_32_BYTES": 7,
+	"REQUIRE_64_BYTES": 8,
+}
+
+func (x LengthOp) String() string {
+	return proto.EnumName(LengthOp_name, int32(x))
+}
+
+func (LengthOp) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ab286090991ccf5c, []int{1}
+}
+
+// ExistenceProof proves that the key maps to the value in the tree: the leaf
+// is hashed from them, then each step of the path from the leaf to the root.
+type ExistenceProof struct {
+	Key   []byte     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Leaf  *LeafOp    `protobuf:"bytes,3,opt,name=leaf,proto3" json:"leaf,omitempty"`
+	Path  []*InnerOp `protobuf:"bytes,4,rep,name=path,proto3" json:"path,omitempty"`
+}
+
+func (m *ExistenceProof) Reset()         { *m = ExistenceProof{} }
+func (m *ExistenceProof) String() string { return proto.CompactTextString(m) }
+func (*ExistenceProof) ProtoMessage()    {}
+func (*ExistenceProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab286090991ccf5c, []int{0}
+}
+func (m *ExistenceProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExistenceProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExistenceProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExistenceProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExistenceProof.Merge(m, src)
+}
+func (m *ExistenceProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExistenceProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExistenceProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExistenceProof proto.InternalMessageInfo
+
+func (m *ExistenceProof) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ExistenceProof) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ExistenceProof) GetLeaf() *LeafOp {
+	if m != nil {
+		return m.Leaf
+	}
+	return nil
+}
+
+func (m *ExistenceProof) GetPath() []*InnerOp {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+// CommitmentProof is the ICS-23 commitment proof, of which only the existence
+// proof (the first member of its oneof) is supported.
+type CommitmentProof struct {
+	Exist *ExistenceProof `protobuf:"bytes,1,opt,name=exist,proto3" json:"exist,omitempty"`
+}
+
+func (m *CommitmentProof) Reset()         { *m = CommitmentProof{} }
+func (m *CommitmentProof) String() string { return proto.CompactTextString(m) }
+func (*CommitmentProof) ProtoMessage()    {}
+func (*CommitmentProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab286090991ccf5c, []int{1}
+}
+func (m *CommitmentProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommitmentProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitmentProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CommitmentProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitmentProof.Merge(m, src)
+}
+func (m *CommitmentProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitmentProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitmentProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitmentProof proto.InternalMessageInfo
+
+func (m *CommitmentProof) GetExist() *ExistenceProof {
+	if m != nil {
+		return m.Exist
+	}
+	return nil
+}
+
+// LeafOp hashes the leaf as hash(prefix || length(prehash_key(key)) ||
+// length(prehash_value(value))).
+type LeafOp struct {
+	Hash         HashOp   `protobuf:"varint,1,opt,name=hash,proto3,enum=tendermint.crypto.HashOp" json:"hash,omitempty"`
+	PrehashKey   HashOp   `protobuf:"varint,2,opt,name=prehash_key,json=prehashKey,proto3,enum=tendermint.crypto.HashOp" json:"prehash_key,omitempty"`
+	PrehashValue HashOp   `protobuf:"varint,3,opt,name=prehash_value,json=prehashValue,proto3,enum=tendermint.crypto.HashOp" json:"prehash_value,omitempty"`
+	Length       LengthOp `protobuf:"varint,4,opt,name=length,proto3,enum=tendermint.crypto.LengthOp" json:"length,omitempty"`
+	Prefix       []byte   `protobuf:"bytes,5,opt,name=prefix,proto3" json:"prefix,omitempty"`
+}
+
+func (m *LeafOp) Reset()         { *m = LeafOp{} }
+func (m *LeafOp) String() string { return proto.CompactTextString(m) }
+func (*LeafOp) ProtoMessage()    {}
+func (*LeafOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab286090991ccf5c, []int{2}
+}
+func (m *LeafOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LeafOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeafOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LeafOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeafOp.Merge(m, src)
+}
+func (m *LeafOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *LeafOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeafOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeafOp proto.InternalMessageInfo
+
+func (m *LeafOp) GetHash() HashOp {
+	if m != nil {
+		return m.Hash
+	}
+	return HashOp_NO_HASH
+}
+
+func (m *LeafOp) GetPrehashKey() HashOp {
+	if m != nil {
+		return m.PrehashKey
+	}
+	return HashOp_NO_HASH
+}
+
+func (m *LeafOp) GetPrehashValue() HashOp {
+	if m != nil {
+		return m.PrehashValue
+	}
+	return HashOp_NO_HASH
+}
+
+func (m *LeafOp) GetLength() LengthOp {
+	if m != nil {
+		return m.Length
+	}
+	return LengthOp_NO_PREFIX
+}
+
+func (m *LeafOp) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+// InnerOp hashes a node as hash(prefix || child || suffix), the sibling being
+// in the prefix or in the suffix.
+type InnerOp struct {
+	Hash   HashOp `protobuf:"varint,1,opt,name=hash,proto3,enum=tendermint.crypto.HashOp" json:"hash,omitempty"`
+	Prefix []byte `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Suffix []byte `protobuf:"bytes,3,opt,name=suffix,proto3" json:"suffix,omitempty"`
+}
+
+func (m *InnerOp) Reset()         { *m = InnerOp{} }
+func (m *InnerOp) String() string { return proto.CompactTextString(m) }
+func (*InnerOp) ProtoMessage()    {}
+func (*InnerOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab286090991ccf5c, []int{3}
+}
+func (m *InnerOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InnerOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InnerOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InnerOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InnerOp.Merge(m, src)
+}
+func (m *InnerOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *InnerOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_InnerOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InnerOp proto.InternalMessageInfo
+
+func (m *InnerOp) GetHash() HashOp {
+	if m != nil {
+		return m.Hash
+	}
+	return HashOp_NO_HASH
+}
+
+func (m *InnerOp) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *InnerOp) GetSuffix() []byte {
+	if m != nil {
+		return m.Suffix
+	}
+	return nil
+}
+
+// ProofSpec is the format of the proofs of a tree, which verifiers check the
+// proofs against.
+type ProofSpec struct {
+	LeafSpec                   *LeafOp    `protobuf:"bytes,1,opt,name=leaf_spec,json=leafSpec,proto3" json:"leaf_spec,omitempty"`
+	InnerSpec                  *InnerSpec `protobuf:"bytes,2,opt,name=inner_spec,json=innerSpec,proto3" json:"inner_spec,omitempty"`
+	MaxDepth                   int32      `protobuf:"varint,3,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	MinDepth                   int32      `protobuf:"varint,4,opt,name=min_depth,json=minDepth,proto3" json:"min_depth,omitempty"`
+	PrehashKeyBeforeComparison bool       `protobuf:"varint,5,opt,name=prehash_key_before_comparison,json=prehashKeyBeforeComparison,proto3" json:"prehash_key_before_comparison,omitempty"`
+}
+
+func (m *ProofSpec) Reset()         { *m = ProofSpec{} }
+func (m *ProofSpec) String() string { return proto.CompactTextString(m) }
+func (*ProofSpec) ProtoMessage()    {}
+func (*ProofSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab286090991ccf5c, []int{4}
+}
+func (m *ProofSpec) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProofSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProofSpec.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProofSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProofSpec.Merge(m, src)
+}
+func (m *ProofSpec) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProofSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProofSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProofSpec proto.InternalMessageInfo
+
+func (m *ProofSpec) GetLeafSpec() *LeafOp {
+	if m != nil {
+		return m.LeafSpec
+	}
+	return nil
+}
+
+func (m *ProofSpec) GetInnerSpec() *InnerSpec {
+	if m != nil {
+		return m.InnerSpec
+	}
+	return nil
+}
+
+func (m *ProofSpec) GetMaxDepth() int32 {
+	if m != nil {
+		return m.MaxDepth
+	}
+	return 0
+}
+
+func (m *ProofSpec) GetMinDepth() int32 {
+	if m != nil {
+		return m.MinDepth
+	}
+	return 0
+}
+
+func (m *ProofSpec) GetPrehashKeyBeforeComparison() bool {
+	if m != nil {
+		return m.PrehashKeyBeforeComparison
+	}
+	return false
+}
+
+type InnerSpec struct {
+	ChildOrder      []int32 `protobuf:"varint,1,rep,packed,name=child_order,json=childOrder,proto3" json:"child_order,omitempty"`
+	ChildSize       int32   `protobuf:"varint,2,opt,name=child_size,json=childSize,proto3" json:"child_size,omitempty"`
+	MinPrefixLength int32   `protobuf:"varint,3,opt,name=min_prefix_length,json=minPrefixLength,proto3" json:"min_prefix_length,omitempty"`
+	MaxPrefixLength int32   `protobuf:"varint,4,opt,name=max_prefix_length,json=maxPrefixLength,proto3" json:"max_prefix_length,omitempty"`
+	EmptyChild      []byte  `protobuf:"bytes,5,opt,name=empty_child,json=emptyChild,proto3" json:"empty_child,omitempty"`
+	Hash            HashOp  `protobuf:"varint,6,opt,name=hash,proto3,enum=tendermint.crypto.HashOp" json:"hash,omitempty"`
+}
+
+func (m *InnerSpec) Reset()         { *m = InnerSpec{} }
+func (m *InnerSpec) String() string { return proto.CompactTextString(m) }
+func (*InnerSpec) ProtoMessage()    {}
+func (*InnerSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab286090991ccf5c, []int{5}
+}
+func (m *InnerSpec) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InnerSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InnerSpec.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InnerSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InnerSpec.Merge(m, src)
+}
+func (m *InnerSpec) XXX_Size() int {
+	return m.Size()
+}
+func (m *InnerSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_InnerSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InnerSpec proto.InternalMessageInfo
+
+func (m *InnerSpec) GetChildOrder() []int32 {
+	if m != nil {
+		return m.ChildOrder
+	}
+	return nil
+}
+
+func (m *InnerSpec) GetChildSize() int32 {
+	if m != nil {
+		return m.ChildSize
+	}
+	return 0
+}
+
+func (m *InnerSpec) GetMinPrefixLength() int32 {
+	if m != nil {
+		return m.MinPrefixLength
+	}
+	return 0
+}
+
+func (m *InnerSpec) GetMaxPrefixLength() int32 {
+	if m != nil {
+		return m.MaxPrefixLength
+	}
+	return 0
+}
+
+func (m *InnerSpec) GetEmptyChild() []byte {
+	if m != nil {
+		return m.EmptyChild
+	}
+	return nil
+}
+
+func (m *InnerSpec) GetHash() HashOp {
+	if m != nil {
+		return m.Hash
+	}
+	return HashOp_NO_HASH
+}
+
+func init() {
+	proto.RegisterEnum("tendermint.crypto.HashOp", HashOp_name, HashOp_value)
+	proto.RegisterEnum("tendermint.crypto.LengthOp", LengthOp_name, LengthOp_value)
+	proto.RegisterType((*ExistenceProof)(nil), "tendermint.crypto.ExistenceProof")
+	proto.RegisterType((*CommitmentProof)(nil), "tendermint.crypto.CommitmentProof")
+	proto.RegisterType((*LeafOp)(nil), "tendermint.crypto.LeafOp")
+	proto.RegisterType((*InnerOp)(nil), "tendermint.crypto.InnerOp")
+	proto.RegisterType((*ProofSpec)(nil), "tendermint.crypto.ProofSpec")
+	proto.RegisterType((*InnerSpec)(nil), "tendermint.crypto.InnerSpec")
+}
+
+func init() { proto.RegisterFile("tendermint/crypto/ics23.proto", fileDescriptor_ab286090991ccf5c) }
+
+var fileDescriptor_ab286090991ccf5c = []byte{
+	// 801 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0xcf, 0x6e, 0xdb, 0x46,
+	0x10, 0xc6, 0x45, 0x51, 0xa4, 0xc5, 0x61, 0x62, 0x33, 0x8b, 0xa0, 0x50, 0x93, 0x5a, 0x71, 0x75,
+	0x32, 0x0c, 0x54, 0x6e, 0x28, 0x47, 0x05, 0x5a, 0xa0, 0x80, 0x24, 0x33, 0x35, 0x6b, 0xd5, 0x54,
+	0x57, 0x6a, 0x90, 0xf6, 0xb2, 0xa0, 0xe9, 0x55, 0x48, 0xd4, 0xfc, 0x03, 0x92, 0x2e, 0xe4, 0x9c,
+	0xfa, 0x08, 0x3d, 0xb5, 0x0f, 0xd1, 0x17, 0xe9, 0x31, 0xc7, 0x1e, 0x0b, 0xfb, 0x11, 0x7a, 0x2e,
+	0x50, 0xcc, 0x2e, 0x25, 0x39, 0x8d, 0xdb, 0x34, 0xb7, 0x9d, 0x6f, 0x7e, 0x33, 0xfb, 0x69, 0x76,
+	0x44, 0xd8, 0x2e, 0x79, 0x72, 0xc6, 0xf3, 0x38, 0x4a, 0xca, 0xfd, 0x20, 0xbf, 0xcc, 0xca, 0x74,
+	0x3f, 0x0a, 0x0a, 0xbb, 0xd7, 0xcd, 0xf2, 0xb4, 0x4c, 0xc9, 0xbd, 0x75, 0xba, 0x2b, 0xd3, 0x9d,
+	0x5f, 0x14, 0xd8, 0x74, 0x16, 0x51, 0x51, 0xf2, 0x24, 0xe0, 0x93, 0x3c, 0x4d, 0xe7, 0xc4, 0x02,
+	0xf5, 0x7b, 0x7e, 0xd9, 0x52, 0x76, 0x94, 0xdd, 0x3b, 0x14, 0x8f, 0xe4, 0x3e, 0x68, 0x3f, 0xf8,
+	0xe7, 0x17, 0xbc, 0x55, 0x17, 0x9a, 0x0c, 0xc8, 0x47, 0xd0, 0x38, 0xe7, 0xfe, 0xbc, 0xa5, 0xee,
+	0x28, 0xbb, 0xa6, 0xfd, 0x7e, 0xf7, 0x8d, 0xe6, 0xdd, 0x31, 0xf7, 0xe7, 0x5e, 0x46, 0x05, 0x46,
+	0xba, 0xd0, 0xc8, 0xfc, 0x32, 0x6c, 0x35, 0x76, 0xd4, 0x5d, 0xd3, 0x7e, 0x70, 0x0b, 0xee, 0x26,
+	0x09, 0xcf, 0x91, 0x47, 0xae, 0xf3, 0x25, 0x6c, 0x8d, 0xd2, 0x38, 0x8e, 0xca, 0x98, 0x27, 0xa5,
+	0x74, 0xf6, 0x09, 0x68, 0x1c, 0xbd, 0x0a, 0x6f, 0xa6, 0xfd, 0xe1, 0x2d, 0x3d, 0x5e, 0xff, 0x2d,
+	0x54, 0xf2, 0x9d, 0x1f, 0xeb, 0xa0, 0x4b, 0x33, 0xe8, 0x3a, 0xf4, 0x8b, 0x50, 0xb4, 0xd8, 0xbc,
+	0xd5, 0xf5, 0x91, 0x5f, 0x84, 0xe8, 0x02, 0x31, 0xf2, 0x29, 0x98, 0x59, 0xce, 0xf1, 0xc8, 0x70,
+	0x28, 0xf5, 0xb7, 0x55, 0x41, 0x45, 0x1f, 0xf3, 0x4b, 0xf2, 0x39, 0xdc, 0x5d, 0xd6, 0xca, 0xf1,
+	0xa9, 0x6f, 0xab, 0xbe, 0x53, 0xf1, 0xcf, 0xc4, 0x80, 0x7b, 0xa0, 0x9f, 0xf3, 0xe4, 0x85, 0x98,
+	0x19, 0x16, 0x3e, 0xbc, 0x75, 0xc4, 0x08, 0x78, 0x19, 0xad, 0x50, 0xf2, 0x1e, 0xe8, 0x59, 0xce,
+	0xe7, 0xd1, 0xa2, 0xa5, 0x89, 0xc7, 0xaa, 0xa2, 0x4e, 0x08, 0x1b, 0xd5, 0x7c, 0xdf, 0x75, 0x04,
+	0xeb, 0x8e, 0xf5, 0x9b, 0x1d, 0x51, 0x2f, 0x2e, 0xe6, 0xa8, 0xab, 0x52, 0x97, 0x51, 0xe7, 0x2f,
+	0x05, 0x0c, 0x31, 0xfd, 0x69, 0xc6, 0x03, 0xd2, 0x07, 0x03, 0x9f, 0x9f, 0x15, 0x19, 0x0f, 0xaa,
+	0x77, 0xfb, 0x8f, 0x55, 0x69, 0x22, 0x2b, 0xea, 0x3e, 0x03, 0x88, 0xd0, 0xaf, 0x2c, 0xac, 0x8b,
+	0xc2, 0x0f, 0xfe, 0x6d, 0x69, 0xb0, 0x82, 0x1a, 0xd1, 0xf2, 0x48, 0x1e, 0x82, 0x11, 0xfb, 0x0b,
+	0x76, 0xc6, 0xb3, 0x32, 0x14, 0xee, 0x34, 0xda, 0x8c, 0xfd, 0xc5, 0x21, 0xc6, 0x22, 0x19, 0x25,
+	0x55, 0xb2, 0x51, 0x25, 0xa3, 0x44, 0x26, 0x07, 0xb0, 0x7d, 0xe3, 0xbd, 0xd9, 0x29, 0x9f, 0xa7,
+	0x39, 0x67, 0x41, 0x1a, 0x67, 0x7e, 0x1e, 0x15, 0x69, 0x22, 0xa6, 0xda, 0xa4, 0x0f, 0xd6, 0xcf,
+	0x3c, 0x14, 0xc8, 0x68, 0x45, 0x74, 0xfe, 0x54, 0xc0, 0x58, 0xb9, 0x22, 0x8f, 0xc0, 0x0c, 0xc2,
+	0xe8, 0xfc, 0x8c, 0xa5, 0xf9, 0x19, 0xcf, 0x5b, 0xca, 0x8e, 0xba, 0xab, 0x51, 0x10, 0x92, 0x87,
+	0x0a, 0xd9, 0x06, 0x19, 0xb1, 0x22, 0x7a, 0x29, 0xff, 0x61, 0x1a, 0x35, 0x84, 0x32, 0x8d, 0x5e,
+	0x72, 0xb2, 0x07, 0xf7, 0xd0, 0xad, 0x9c, 0x39, 0xab, 0xf6, 0x41, 0xfe, 0xa4, 0xad, 0x38, 0x4a,
+	0x26, 0x42, 0x97, 0x5b, 0x20, 0x58, 0x7f, 0xf1, 0x0f, 0xb6, 0x51, 0xb1, 0xfe, 0xe2, 0x35, 0xf6,
+	0x11, 0x98, 0x3c, 0xce, 0xca, 0x4b, 0x26, 0xae, 0xaa, 0x96, 0x05, 0x84, 0x34, 0x42, 0x65, 0xb5,
+	0x25, 0xfa, 0xff, 0xda, 0x92, 0xbd, 0x9f, 0x15, 0xd0, 0xa5, 0x40, 0x4c, 0xd8, 0x38, 0xf1, 0xd8,
+	0xd1, 0x60, 0x7a, 0x64, 0xd5, 0x08, 0x80, 0x3e, 0x3d, 0x1a, 0xd8, 0x4f, 0xfa, 0x96, 0x52, 0x9d,
+	0x9f, 0x3c, 0xb6, 0xad, 0x3a, 0xb9, 0x0b, 0xc6, 0xb1, 0x33, 0x1a, 0x0d, 0x8e, 0x31, 0xa5, 0x62,
+	0x48, 0xdd, 0x89, 0xf3, 0xd5, 0xe1, 0xe3, 0xfe, 0xc7, 0x56, 0x03, 0x5b, 0x0c, 0xdd, 0xd9, 0xc8,
+	0x73, 0x4f, 0x2c, 0x8d, 0x6c, 0x02, 0xc8, 0x32, 0x86, 0xac, 0x4e, 0xb6, 0xc0, 0x1c, 0x8e, 0x07,
+	0xc7, 0x8e, 0x3d, 0x64, 0xd8, 0x6b, 0x63, 0x2d, 0x4c, 0x05, 0xd1, 0xc4, 0x8b, 0x84, 0xd0, 0xb3,
+	0x8c, 0xbd, 0x5f, 0x15, 0x68, 0x2e, 0xff, 0x25, 0x78, 0xcd, 0x89, 0xc7, 0x26, 0xd4, 0x79, 0xea,
+	0x3e, 0xb7, 0x6a, 0x18, 0x3e, 0x1b, 0x50, 0x36, 0xa1, 0xde, 0xcc, 0xb3, 0x14, 0xbc, 0x15, 0x43,
+	0x3a, 0x9e, 0x58, 0x75, 0x6c, 0xfa, 0xd4, 0x7d, 0xee, 0x1c, 0xf6, 0x6c, 0x36, 0x74, 0xbf, 0xb0,
+	0x54, 0x42, 0x60, 0x73, 0x29, 0x8c, 0xdd, 0xd9, 0x6c, 0xec, 0x58, 0x8d, 0x15, 0xd4, 0x3f, 0x10,
+	0x90, 0xb6, 0x82, 0xfa, 0x07, 0x4b, 0x48, 0x27, 0xf7, 0xc1, 0xa2, 0xce, 0xd7, 0xdf, 0xb8, 0xd4,
+	0x61, 0xd8, 0xec, 0xdb, 0x99, 0x33, 0xb5, 0x36, 0x6e, 0xaa, 0xfd, 0x83, 0x4a, 0x6d, 0x0e, 0x4f,
+	0x7e, 0xbb, 0x6a, 0x2b, 0xaf, 0xae, 0xda, 0xca, 0x1f, 0x57, 0x6d, 0xe5, 0xa7, 0xeb, 0x76, 0xed,
+	0xd5, 0x75, 0xbb, 0xf6, 0xfb, 0x75, 0xbb, 0xf6, 0xdd, 0xc1, 0x8b, 0xa8, 0x0c, 0x2f, 0x4e, 0xbb,
+	0x41, 0x1a, 0xef, 0x07, 0x69, 0xcc, 0xcb, 0xd3, 0x79, 0xb9, 0x3e, 0x88, 0x0f, 0xfc, 0xfe, 0x1b,
+	0x9f, 0xff, 0x53, 0x5d, 0x24, 0x7a, 0x7f, 0x0f, 0x00, 0xd8, 0x54, 0xfb, 0x5b, 0x1a, 0x06, 0x00,
+	0x00,
+}
+
+func (m *ExistenceProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExistenceProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExistenceProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		for iNdEx := len(m.Path) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Path[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIcs23(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Leaf != nil {
+		{
+			size, err := m.Leaf.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIcs23(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIcs23(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIcs23(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommitmentProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitmentProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommitmentProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Exist != nil {
+		{
+			size, err := m.Exist.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIcs23(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LeafOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeafOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeafOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintIcs23(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Length != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.Length))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.PrehashValue != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.PrehashValue))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.PrehashKey != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.PrehashKey))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Hash != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.Hash))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InnerOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InnerOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InnerOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Suffix) > 0 {
+		i -= len(m.Suffix)
+		copy(dAtA[i:], m.Suffix)
+		i = encodeVarintIcs23(dAtA, i, uint64(len(m.Suffix)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintIcs23(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Hash != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.Hash))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProofSpec) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProofSpec) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProofSpec) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PrehashKeyBeforeComparison {
+		i--
+		if m.PrehashKeyBeforeComparison {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MinDepth != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.MinDepth))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxDepth != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.MaxDepth))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.InnerSpec != nil {
+		{
+			size, err := m.InnerSpec.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIcs23(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.LeafSpec != nil {
+		{
+			size, err := m.LeafSpec.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIcs23(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InnerSpec) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InnerSpec) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InnerSpec) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Hash != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.Hash))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.EmptyChild) > 0 {
+		i -= len(m.EmptyChild)
+		copy(dAtA[i:], m.EmptyChild)
+		i = encodeVarintIcs23(dAtA, i, uint64(len(m.EmptyChild)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.MaxPrefixLength != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.MaxPrefixLength))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MinPrefixLength != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.MinPrefixLength))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ChildSize != 0 {
+		i = encodeVarintIcs23(dAtA, i, uint64(m.ChildSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ChildOrder) > 0 {
+		dAtA6 := make([]byte, len(m.ChildOrder)*10)
+		var j5 int
+		for _, num1 := range m.ChildOrder {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j5++
+			}
+			dAtA6[j5] = uint8(num)
+			j5++
+		}
+		i -= j5
+		copy(dAtA[i:], dAtA6[:j5])
+		i = encodeVarintIcs23(dAtA, i, uint64(j5))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintIcs23(dAtA []byte, offset int, v uint64) int {
+	offset -= sovIcs23(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ExistenceProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	if m.Leaf != nil {
+		l = m.Leaf.Size()
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	if len(m.Path) > 0 {
+		for _, e := range m.Path {
+			l = e.Size()
+			n += 1 + l + sovIcs23(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CommitmentProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Exist != nil {
+		l = m.Exist.Size()
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	return n
+}
+
+func (m *LeafOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Hash != 0 {
+		n += 1 + sovIcs23(uint64(m.Hash))
+	}
+	if m.PrehashKey != 0 {
+		n += 1 + sovIcs23(uint64(m.PrehashKey))
+	}
+	if m.PrehashValue != 0 {
+		n += 1 + sovIcs23(uint64(m.PrehashValue))
+	}
+	if m.Length != 0 {
+		n += 1 + sovIcs23(uint64(m.Length))
+	}
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	return n
+}
+
+func (m *InnerOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Hash != 0 {
+		n += 1 + sovIcs23(uint64(m.Hash))
+	}
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	l = len(m.Suffix)
+	if l > 0 {
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	return n
+}
+
+func (m *ProofSpec) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LeafSpec != nil {
+		l = m.LeafSpec.Size()
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	if m.InnerSpec != nil {
+		l = m.InnerSpec.Size()
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	if m.MaxDepth != 0 {
+		n += 1 + sovIcs23(uint64(m.MaxDepth))
+	}
+	if m.MinDepth != 0 {
+		n += 1 + sovIcs23(uint64(m.MinDepth))
+	}
+	if m.PrehashKeyBeforeComparison {
+		n += 2
+	}
+	return n
+}
+
+func (m *InnerSpec) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ChildOrder) > 0 {
+		l = 0
+		for _, e := range m.ChildOrder {
+			l += sovIcs23(uint64(e))
+		}
+		n += 1 + sovIcs23(uint64(l)) + l
+	}
+	if m.ChildSize != 0 {
+		n += 1 + sovIcs23(uint64(m.ChildSize))
+	}
+	if m.MinPrefixLength != 0 {
+		n += 1 + sovIcs23(uint64(m.MinPrefixLength))
+	}
+	if m.MaxPrefixLength != 0 {
+		n += 1 + sovIcs23(uint64(m.MaxPrefixLength))
+	}
+	l = len(m.EmptyChild)
+	if l > 0 {
+		n += 1 + l + sovIcs23(uint64(l))
+	}
+	if m.Hash != 0 {
+		n += 1 + sovIcs23(uint64(m.Hash))
+	}
+	return n
+}
+
+func sovIcs23(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozIcs23(x uint64) (n int) {
+	return sovIcs23(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ExistenceProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIcs23
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExistenceProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExistenceProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leaf", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Leaf == nil {
+				m.Leaf = &LeafOp{}
+			}
+			if err := m.Leaf.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = append(m.Path, &InnerOp{})
+			if err := m.Path[len(m.Path)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIcs23(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitmentProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIcs23
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitmentProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitmentProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exist", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Exist == nil {
+				m.Exist = &ExistenceProof{}
+			}
+			if err := m.Exist.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIcs23(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeafOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIcs23
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeafOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeafOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			m.Hash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hash |= HashOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrehashKey", wireType)
+			}
+			m.PrehashKey = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrehashKey |= HashOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrehashValue", wireType)
+			}
+			m.PrehashValue = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrehashValue |= HashOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= LengthOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prefix == nil {
+				m.Prefix = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIcs23(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InnerOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIcs23
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InnerOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InnerOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			m.Hash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hash |= HashOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prefix == nil {
+				m.Prefix = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suffix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Suffix = append(m.Suffix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Suffix == nil {
+				m.Suffix = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIcs23(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProofSpec) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIcs23
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProofSpec: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProofSpec: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeafSpec", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeafSpec == nil {
+				m.LeafSpec = &LeafOp{}
+			}
+			if err := m.LeafSpec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InnerSpec", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InnerSpec == nil {
+				m.InnerSpec = &InnerSpec{}
+			}
+			if err := m.InnerSpec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDepth", wireType)
+			}
+			m.MaxDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxDepth |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinDepth", wireType)
+			}
+			m.MinDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinDepth |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrehashKeyBeforeComparison", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PrehashKeyBeforeComparison = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIcs23(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InnerSpec) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIcs23
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InnerSpec: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InnerSpec: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIcs23
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ChildOrder = append(m.ChildOrder, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIcs23
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIcs23
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIcs23
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ChildOrder) == 0 {
+					m.ChildOrder = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIcs23
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ChildOrder = append(m.ChildOrder, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChildOrder", wireType)
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChildSize", wireType)
+			}
+			m.ChildSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChildSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinPrefixLength", wireType)
+			}
+			m.MinPrefixLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinPrefixLength |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPrefixLength", wireType)
+			}
+			m.MaxPrefixLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPrefixLength |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmptyChild", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EmptyChild = append(m.EmptyChild[:0], dAtA[iNdEx:postIndex]...)
+			if m.EmptyChild == nil {
+				m.EmptyChild = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			m.Hash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hash |= HashOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIcs23(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIcs23
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipIcs23(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowIcs23
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowIcs23
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthIcs23
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupIcs23
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthIcs23
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthIcs23        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowIcs23          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupIcs23 = fmt.Errorf("proto: unexpected end of group")
+)
@@ -144,14 +144,17 @@ func (m *ProtocolVersion) GetApp() uint64 {
 }
 
 type DefaultNodeInfo struct {
-	ProtocolVersion ProtocolVersion      `protobuf:"bytes,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version"`
-	DefaultNodeID   string               `protobuf:"bytes,2,opt,name=default_node_id,json=defaultNodeId,proto3" json:"default_node_id,omitempty"`
-	ListenAddr      string               `protobuf:"bytes,3,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
-	Network         string               `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
-	Version         string               `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
-	Channels        []byte               `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
-	Moniker         string               `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
-	Other           DefaultNodeInfoOther `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	ProtocolVersion ProtocolVersion            `protobuf:"bytes,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version"`
+	DefaultNodeID   string                     `protobuf:"bytes,2,opt,name=default_node_id,json=defaultNodeId,proto3" json:"default_node_id,omitempty"`
+	ListenAddr      string                     `protobuf:"bytes,3,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
+	Network         string                     `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	Version         string                     `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Channels        []byte                     `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
+	Moniker         string                     `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
+	Other           DefaultNodeInfoOther       `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	Compression     DefaultNodeInfoCompression `protobuf:"bytes,9,opt,name=compression,proto3" json:"compression"`
+	// Bitmap of the optional protocol features supported by the node.
+	Features uint64 `protobuf:"varint,10,opt,name=features,proto3" json:"features,omitempty"`
 }
 
 func (m *DefaultNodeInfo) Reset()         { *m = DefaultNodeInfo{} }
@@ -243,6 +246,20 @@ func (m *DefaultNodeInfo) GetOther() DefaultNodeInfoOther {
 	return DefaultNodeInfoOther{}
 }
 
+func (m *DefaultNodeInfo) GetCompression() DefaultNodeInfoCompression {
+	if m != nil {
+		return m.Compression
+	}
+	return DefaultNodeInfoCompression{}
+}
+
+func (m *DefaultNodeInfo) GetFeatures() uint64 {
+	if m != nil {
+		return m.Features
+	}
+	return 0
+}
+
 type DefaultNodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -295,48 +312,107 @@ func (m *DefaultNodeInfoOther) GetRPCAddress() string {
 	return ""
 }
 
+// DefaultNodeInfoCompression advertises the channels a node is willing to
+// compress and the algorithms it supports, in order of preference.
+type DefaultNodeInfoCompression struct {
+	Channels   []byte   `protobuf:"bytes,1,opt,name=channels,proto3" json:"channels,omitempty"`
+	Algorithms []string `protobuf:"bytes,2,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
+}
+
+func (m *DefaultNodeInfoCompression) Reset()         { *m = DefaultNodeInfoCompression{} }
+func (m *DefaultNodeInfoCompression) String() string { return proto.CompactTextString(m) }
+func (*DefaultNodeInfoCompression) ProtoMessage()    {}
+func (*DefaultNodeInfoCompression) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8a29e659aeca578, []int{4}
+}
+func (m *DefaultNodeInfoCompression) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DefaultNodeInfoCompression) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DefaultNodeInfoCompression.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DefaultNodeInfoCompression) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DefaultNodeInfoCompression.Merge(m, src)
+}
+func (m *DefaultNodeInfoCompression) XXX_Size() int {
+	return m.Size()
+}
+func (m *DefaultNodeInfoCompression) XXX_DiscardUnknown() {
+	xxx_messageInfo_DefaultNodeInfoCompression.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DefaultNodeInfoCompression proto.InternalMessageInfo
+
+func (m *DefaultNodeInfoCompression) GetChannels() []byte {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *DefaultNodeInfoCompression) GetAlgorithms() []string {
+	if m != nil {
+		return m.Algorithms
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*NetAddress)(nil), "tendermint.p2p.NetAddress")
 	proto.RegisterType((*ProtocolVersion)(nil), "tendermint.p2p.ProtocolVersion")
 	proto.RegisterType((*DefaultNodeInfo)(nil), "tendermint.p2p.DefaultNodeInfo")
 	proto.RegisterType((*DefaultNodeInfoOther)(nil), "tendermint.p2p.DefaultNodeInfoOther")
+	proto.RegisterType((*DefaultNodeInfoCompression)(nil), "tendermint.p2p.DefaultNodeInfoCompression")
 }
 
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 483 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x3d, 0x8f, 0xda, 0x40,
-	0x10, 0xc5, 0xc6, 0x7c, 0xdc, 0x10, 0x8e, 0xcb, 0x0a, 0x45, 0x3e, 0x0a, 0x1b, 0xa1, 0x14, 0x54,
-	0xa0, 0x90, 0x2a, 0x5d, 0x42, 0x68, 0x50, 0xa4, 0x8b, 0xb5, 0x8a, 0x52, 0xa4, 0x41, 0xe0, 0x5d,
-	0x60, 0x85, 0xd9, 0x5d, 0xad, 0xf7, 0x12, 0xf2, 0x2f, 0xf2, 0xb3, 0xae, 0xbc, 0x32, 0x95, 0x15,
-	0x99, 0x32, 0x7f, 0x22, 0xf2, 0xae, 0x2f, 0xc7, 0xa1, 0xeb, 0xe6, 0xcd, 0x9b, 0x99, 0x37, 0xf3,
-	0x34, 0xd0, 0xd3, 0x94, 0x13, 0xaa, 0xf6, 0x8c, 0xeb, 0xb1, 0x9c, 0xc8, 0xb1, 0xfe, 0x29, 0x69,
-	0x3a, 0x92, 0x4a, 0x68, 0x81, 0x2e, 0x1f, 0xb9, 0x91, 0x9c, 0xc8, 0x5e, 0x77, 0x23, 0x36, 0xc2,
-	0x50, 0xe3, 0x22, 0xb2, 0x55, 0x83, 0x08, 0xe0, 0x86, 0xea, 0x0f, 0x84, 0x28, 0x9a, 0xa6, 0xe8,
-	0x15, 0xb8, 0x8c, 0xf8, 0x4e, 0xdf, 0x19, 0x5e, 0x4c, 0xeb, 0x79, 0x16, 0xba, 0xf3, 0x19, 0x76,
-	0x19, 0x31, 0x79, 0xe9, 0xbb, 0x27, 0xf9, 0x08, 0xbb, 0x4c, 0x22, 0x04, 0x9e, 0x14, 0x4a, 0xfb,
-	0xd5, 0xbe, 0x33, 0x6c, 0x63, 0x13, 0x0f, 0xbe, 0x40, 0x27, 0x2a, 0x46, 0xc7, 0x22, 0xf9, 0x4a,
-	0x55, 0xca, 0x04, 0x47, 0xd7, 0x50, 0x95, 0x13, 0x69, 0xe6, 0x7a, 0xd3, 0x46, 0x9e, 0x85, 0xd5,
-	0x68, 0x12, 0xe1, 0x22, 0x87, 0xba, 0x50, 0x5b, 0x25, 0x22, 0xde, 0x99, 0xe1, 0x1e, 0xb6, 0x00,
-	0x5d, 0x41, 0x75, 0x29, 0xa5, 0x19, 0xeb, 0xe1, 0x22, 0x1c, 0xfc, 0x75, 0xa1, 0x33, 0xa3, 0xeb,
-	0xe5, 0x6d, 0xa2, 0x6f, 0x04, 0xa1, 0x73, 0xbe, 0x16, 0x28, 0x82, 0x2b, 0x59, 0x2a, 0x2d, 0xbe,
-	0x5b, 0x29, 0xa3, 0xd1, 0x9a, 0x84, 0xa3, 0xa7, 0xc7, 0x8f, 0xce, 0x36, 0x9a, 0x7a, 0x77, 0x59,
-	0x58, 0xc1, 0x1d, 0x79, 0xb6, 0xe8, 0x3b, 0xe8, 0x10, 0x2b, 0xb2, 0xe0, 0x82, 0xd0, 0x05, 0x23,
-	0xe5, 0xd1, 0x2f, 0xf3, 0x2c, 0x6c, 0x9f, 0xea, 0xcf, 0x70, 0x9b, 0x9c, 0x40, 0x82, 0x42, 0x68,
-	0x25, 0x2c, 0xd5, 0x94, 0x2f, 0x96, 0x84, 0x28, 0xb3, 0xfa, 0x05, 0x06, 0x9b, 0x2a, 0xec, 0x45,
-	0x3e, 0x34, 0x38, 0xd5, 0x3f, 0x84, 0xda, 0xf9, 0x9e, 0x21, 0x1f, 0x60, 0xc1, 0x3c, 0xac, 0x5f,
-	0xb3, 0x4c, 0x09, 0x51, 0x0f, 0x9a, 0xf1, 0x76, 0xc9, 0x39, 0x4d, 0x52, 0xbf, 0xde, 0x77, 0x86,
-	0x2f, 0xf0, 0x7f, 0x5c, 0x74, 0xed, 0x05, 0x67, 0x3b, 0xaa, 0xfc, 0x86, 0xed, 0x2a, 0x21, 0x7a,
-	0x0f, 0x35, 0xa1, 0xb7, 0x54, 0xf9, 0x4d, 0x63, 0xc6, 0xeb, 0x73, 0x33, 0xce, 0x7c, 0xfc, 0x5c,
-	0xd4, 0x96, 0x8e, 0xd8, 0xc6, 0xc1, 0x0a, 0xba, 0xcf, 0x15, 0xa1, 0x6b, 0x68, 0xea, 0xc3, 0x82,
-	0x71, 0x42, 0x0f, 0xf6, 0x4b, 0x70, 0x43, 0x1f, 0xe6, 0x05, 0x44, 0x63, 0x68, 0x29, 0x19, 0x9b,
-	0xe3, 0x69, 0x9a, 0x96, 0xb6, 0x5d, 0xe6, 0x59, 0x08, 0x38, 0xfa, 0x58, 0xfe, 0x17, 0x06, 0x25,
-	0xe3, 0x32, 0x9e, 0x7e, 0xba, 0xcb, 0x03, 0xe7, 0x3e, 0x0f, 0x9c, 0x3f, 0x79, 0xe0, 0xfc, 0x3a,
-	0x06, 0x95, 0xfb, 0x63, 0x50, 0xf9, 0x7d, 0x0c, 0x2a, 0xdf, 0xde, 0x6c, 0x98, 0xde, 0xde, 0xae,
-	0x46, 0xb1, 0xd8, 0x8f, 0x63, 0xb1, 0xa7, 0x7a, 0xb5, 0xd6, 0x8f, 0x81, 0x7d, 0xe1, 0xa7, 0x8f,
-	0xbf, 0xaa, 0x9b, 0xec, 0xdb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xae, 0xdb, 0x56, 0x6d, 0x11,
-	0x03, 0x00, 0x00,
+	// 546 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x4f, 0x8f, 0xda, 0x3e,
+	0x10, 0x25, 0x24, 0xfc, 0x1b, 0x7e, 0x2c, 0xfb, 0xb3, 0x50, 0x95, 0xe5, 0x90, 0x20, 0xd4, 0x03,
+	0xea, 0x01, 0x54, 0x7a, 0xea, 0xad, 0x65, 0xb9, 0xa0, 0x4a, 0xdb, 0xc8, 0xaa, 0xaa, 0xaa, 0x17,
+	0x14, 0x62, 0x03, 0x11, 0x49, 0x6c, 0x39, 0xa6, 0xa5, 0xdf, 0xa2, 0x1f, 0x6b, 0x8f, 0x7b, 0xac,
+	0x54, 0x09, 0x55, 0xe1, 0x8b, 0x54, 0x76, 0xb2, 0xcb, 0x1f, 0xb5, 0xea, 0x6d, 0xde, 0x8c, 0xe7,
+	0xcd, 0xf3, 0xf3, 0x18, 0xba, 0x92, 0x26, 0x84, 0x8a, 0x38, 0x4c, 0xe4, 0x88, 0x8f, 0xf9, 0x48,
+	0x7e, 0xe3, 0x34, 0x1d, 0x72, 0xc1, 0x24, 0x43, 0x57, 0xc7, 0xda, 0x90, 0x8f, 0x79, 0xb7, 0xb3,
+	0x62, 0x2b, 0xa6, 0x4b, 0x23, 0x15, 0xe5, 0xa7, 0xfa, 0x1e, 0xc0, 0x1d, 0x95, 0x6f, 0x09, 0x11,
+	0x34, 0x4d, 0xd1, 0x33, 0x28, 0x87, 0xc4, 0x36, 0x7a, 0xc6, 0xa0, 0x31, 0xa9, 0x66, 0x7b, 0xb7,
+	0x3c, 0x9b, 0xe2, 0x72, 0x48, 0x74, 0x9e, 0xdb, 0xe5, 0x93, 0xbc, 0x87, 0xcb, 0x21, 0x47, 0x08,
+	0x2c, 0xce, 0x84, 0xb4, 0xcd, 0x9e, 0x31, 0x68, 0x61, 0x1d, 0xf7, 0x3f, 0x40, 0xdb, 0x53, 0xd4,
+	0x01, 0x8b, 0x3e, 0x52, 0x91, 0x86, 0x2c, 0x41, 0x37, 0x60, 0xf2, 0x31, 0xd7, 0xbc, 0xd6, 0xa4,
+	0x96, 0xed, 0x5d, 0xd3, 0x1b, 0x7b, 0x58, 0xe5, 0x50, 0x07, 0x2a, 0x8b, 0x88, 0x05, 0x1b, 0x4d,
+	0x6e, 0xe1, 0x1c, 0xa0, 0x6b, 0x30, 0x7d, 0xce, 0x35, 0xad, 0x85, 0x55, 0xd8, 0xff, 0x69, 0x42,
+	0x7b, 0x4a, 0x97, 0xfe, 0x36, 0x92, 0x77, 0x8c, 0xd0, 0x59, 0xb2, 0x64, 0xc8, 0x83, 0x6b, 0x5e,
+	0x4c, 0x9a, 0x7f, 0xc9, 0x47, 0xe9, 0x19, 0xcd, 0xb1, 0x3b, 0x3c, 0xbf, 0xfc, 0xf0, 0x42, 0xd1,
+	0xc4, 0xba, 0xdf, 0xbb, 0x25, 0xdc, 0xe6, 0x17, 0x42, 0x5f, 0x43, 0x9b, 0xe4, 0x43, 0xe6, 0x09,
+	0x23, 0x74, 0x1e, 0x92, 0xe2, 0xd2, 0xff, 0x67, 0x7b, 0xb7, 0x75, 0x3a, 0x7f, 0x8a, 0x5b, 0xe4,
+	0x04, 0x12, 0xe4, 0x42, 0x33, 0x0a, 0x53, 0x49, 0x93, 0xb9, 0x4f, 0x88, 0xd0, 0xd2, 0x1b, 0x18,
+	0xf2, 0x94, 0xb2, 0x17, 0xd9, 0x50, 0x4b, 0xa8, 0xfc, 0xca, 0xc4, 0xc6, 0xb6, 0x74, 0xf1, 0x11,
+	0xaa, 0xca, 0xa3, 0xfc, 0x4a, 0x5e, 0x29, 0x20, 0xea, 0x42, 0x3d, 0x58, 0xfb, 0x49, 0x42, 0xa3,
+	0xd4, 0xae, 0xf6, 0x8c, 0xc1, 0x7f, 0xf8, 0x09, 0xab, 0xae, 0x98, 0x25, 0xe1, 0x86, 0x0a, 0xbb,
+	0x96, 0x77, 0x15, 0x10, 0xbd, 0x81, 0x0a, 0x93, 0x6b, 0x2a, 0xec, 0xba, 0x36, 0xe3, 0xf9, 0xa5,
+	0x19, 0x17, 0x3e, 0xbe, 0x57, 0x67, 0x0b, 0x47, 0xf2, 0x46, 0x84, 0xa1, 0x19, 0xb0, 0x98, 0xab,
+	0x9d, 0x50, 0xaa, 0x1a, 0x9a, 0xe7, 0xc5, 0x3f, 0x78, 0x6e, 0x8f, 0x1d, 0x05, 0xdb, 0x29, 0x89,
+	0xba, 0xcb, 0x92, 0xfa, 0x72, 0x2b, 0x68, 0x6a, 0x83, 0x7e, 0xd8, 0x27, 0xdc, 0x5f, 0x40, 0xe7,
+	0x4f, 0xa2, 0xd0, 0x0d, 0xd4, 0xe5, 0x6e, 0x1e, 0x26, 0x84, 0xee, 0xf2, 0xad, 0xc4, 0x35, 0xb9,
+	0x9b, 0x29, 0x88, 0x46, 0xd0, 0x14, 0x3c, 0xd0, 0x66, 0xd3, 0x34, 0x2d, 0x9e, 0xe9, 0x2a, 0xdb,
+	0xbb, 0x80, 0xbd, 0xdb, 0x62, 0x9f, 0x31, 0x08, 0x1e, 0x14, 0x71, 0xff, 0x13, 0x74, 0xff, 0x2e,
+	0xf8, 0xcc, 0x69, 0xe3, 0xc2, 0x69, 0x07, 0xc0, 0x8f, 0x56, 0x4c, 0x84, 0x72, 0x1d, 0xab, 0x49,
+	0xa6, 0x7a, 0xd9, 0x63, 0x66, 0xf2, 0xee, 0x3e, 0x73, 0x8c, 0x87, 0xcc, 0x31, 0x7e, 0x65, 0x8e,
+	0xf1, 0xfd, 0xe0, 0x94, 0x1e, 0x0e, 0x4e, 0xe9, 0xc7, 0xc1, 0x29, 0x7d, 0x7e, 0xb9, 0x0a, 0xe5,
+	0x7a, 0xbb, 0x18, 0x06, 0x2c, 0x1e, 0x05, 0x2c, 0xa6, 0x72, 0xb1, 0x94, 0xc7, 0x20, 0xff, 0x8c,
+	0xe7, 0x5f, 0x78, 0x51, 0xd5, 0xd9, 0x57, 0xbf, 0x07, 0x00, 0xc4, 0x03, 0x19, 0xa7, 0xdb, 0x03,
+	0x00, 0x00,
 }
 
 func (m *NetAddress) Marshal() (dAtA []byte, err error) {
@@ -439,6 +515,21 @@ func (m *DefaultNodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Features != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Features))
+		i--
+		dAtA[i] = 0x50
+	}
+	{
+		size, err := m.Compression.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x4a
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -541,6 +632,45 @@ func (m *DefaultNodeInfoOther) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *DefaultNodeInfoCompression) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DefaultNodeInfoCompression) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DefaultNodeInfoCompression) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Algorithms) > 0 {
+		for iNdEx := len(m.Algorithms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Algorithms[iNdEx])
+			copy(dAtA[i:], m.Algorithms[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Algorithms[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Channels) > 0 {
+		i -= len(m.Channels)
+		copy(dAtA[i:], m.Channels)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Channels)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -624,6 +754,11 @@ func (m *DefaultNodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	l = m.Compression.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Features != 0 {
+		n += 1 + sovTypes(uint64(m.Features))
+	}
 	return n
 }
 
@@ -644,6 +779,25 @@ func (m *DefaultNodeInfoOther) Size() (n int) {
 	return n
 }
 
+func (m *DefaultNodeInfoCompression) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channels)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Algorithms) > 0 {
+		for _, s := range m.Algorithms {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1179,6 +1333,58 @@ func (m *DefaultNodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Compression.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			m.Features = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Features |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1314,6 +1520,122 @@ func (m *DefaultNodeInfoOther) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DefaultNodeInfoCompression) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DefaultNodeInfoCompression: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DefaultNodeInfoCompression: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channels", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channels = append(m.Channels[:0], dAtA[iNdEx:postIndex]...)
+			if m.Channels == nil {
+				m.Channels = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Algorithms", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Algorithms = append(m.Algorithms, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
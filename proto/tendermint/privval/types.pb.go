@@ -163,6 +163,9 @@ func (m *PubKeyRequest) GetChainId() string {
 type PubKeyResponse struct {
 	PubKey crypto.PublicKey   `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key"`
 	Error  *RemoteSignerError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// The domain separation tag of the hash-to-curve the signer uses, for bn254
+	// keys. Empty for the other keys, or the signers predating it.
+	HashToCurveDst string `protobuf:"bytes,3,opt,name=hash_to_curve_dst,json=hashToCurveDst,proto3" json:"hash_to_curve_dst,omitempty"`
 }
 
 func (m *PubKeyResponse) Reset()         { *m = PubKeyResponse{} }
@@ -212,10 +215,23 @@ func (m *PubKeyResponse) GetError() *RemoteSignerError {
 	return nil
 }
 
+func (m *PubKeyResponse) GetHashToCurveDst() string {
+	if m != nil {
+		return m.HashToCurveDst
+	}
+	return ""
+}
+
 // SignVoteRequest is a request to sign a vote
 type SignVoteRequest struct {
 	Vote    *types.Vote `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
 	ChainId string      `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// The domain separation tag of the hash-to-curve the signature is checked
+	// with, for bn254 keys. The signer refuses to sign with another one.
+	HashToCurveDst string `protobuf:"bytes,3,opt,name=hash_to_curve_dst,json=hashToCurveDst,proto3" json:"hash_to_curve_dst,omitempty"`
+	// The canonical encoding of the chain the vote is signed with, "proto"
+	// if empty.
+	CanonicalEncoding string `protobuf:"bytes,4,opt,name=canonical_encoding,json=canonicalEncoding,proto3" json:"canonical_encoding,omitempty"`
 }
 
 func (m *SignVoteRequest) Reset()         { *m = SignVoteRequest{} }
@@ -265,10 +281,27 @@ func (m *SignVoteRequest) GetChainId() string {
 	return ""
 }
 
+func (m *SignVoteRequest) GetHashToCurveDst() string {
+	if m != nil {
+		return m.HashToCurveDst
+	}
+	return ""
+}
+
+func (m *SignVoteRequest) GetCanonicalEncoding() string {
+	if m != nil {
+		return m.CanonicalEncoding
+	}
+	return ""
+}
+
 // SignedVoteResponse is a response containing a signed vote or an error
 type SignedVoteResponse struct {
 	Vote  types.Vote         `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote"`
 	Error *RemoteSignerError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// The nonce with which the sign bytes were hashed to the curve, for bn254
+	// keys: the point is recoverable from the sign bytes and the nonce.
+	HashToCurveNonce uint32 `protobuf:"varint,3,opt,name=hash_to_curve_nonce,json=hashToCurveNonce,proto3" json:"hash_to_curve_nonce,omitempty"`
 }
 
 func (m *SignedVoteResponse) Reset()         { *m = SignedVoteResponse{} }
@@ -318,10 +351,19 @@ func (m *SignedVoteResponse) GetError() *RemoteSignerError {
 	return nil
 }
 
+func (m *SignedVoteResponse) GetHashToCurveNonce() uint32 {
+	if m != nil {
+		return m.HashToCurveNonce
+	}
+	return 0
+}
+
 // SignProposalRequest is a request to sign a proposal
 type SignProposalRequest struct {
 	Proposal *types.Proposal `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
 	ChainId  string          `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// As in SignVoteRequest.
+	HashToCurveDst string `protobuf:"bytes,3,opt,name=hash_to_curve_dst,json=hashToCurveDst,proto3" json:"hash_to_curve_dst,omitempty"`
 }
 
 func (m *SignProposalRequest) Reset()         { *m = SignProposalRequest{} }
@@ -371,10 +413,19 @@ func (m *SignProposalRequest) GetChainId() string {
 	return ""
 }
 
+func (m *SignProposalRequest) GetHashToCurveDst() string {
+	if m != nil {
+		return m.HashToCurveDst
+	}
+	return ""
+}
+
 // SignedProposalResponse is response containing a signed proposal or an error
 type SignedProposalResponse struct {
 	Proposal types.Proposal     `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal"`
 	Error    *RemoteSignerError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// As in SignedVoteResponse.
+	HashToCurveNonce uint32 `protobuf:"varint,3,opt,name=hash_to_curve_nonce,json=hashToCurveNonce,proto3" json:"hash_to_curve_nonce,omitempty"`
 }
 
 func (m *SignedProposalResponse) Reset()         { *m = SignedProposalResponse{} }
@@ -424,6 +475,13 @@ func (m *SignedProposalResponse) GetError() *RemoteSignerError {
 	return nil
 }
 
+func (m *SignedProposalResponse) GetHashToCurveNonce() uint32 {
+	if m != nil {
+		return m.HashToCurveNonce
+	}
+	return 0
+}
+
 // PingRequest is a request to confirm that the connection is alive.
 type PingRequest struct {
 }
@@ -678,55 +736,60 @@ func init() {
 func init() { proto.RegisterFile("tendermint/privval/types.proto", fileDescriptor_cb4e437a5328cf9c) }
 
 var fileDescriptor_cb4e437a5328cf9c = []byte{
-	// 756 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4d, 0x4f, 0xe3, 0x46,
-	0x18, 0xb6, 0x21, 0x1f, 0xf0, 0x86, 0x84, 0x30, 0x50, 0x1a, 0x22, 0x6a, 0xd2, 0x54, 0x6d, 0x51,
-	0x0e, 0x49, 0x45, 0xd5, 0x5e, 0xe8, 0xa5, 0x80, 0xd5, 0x44, 0x11, 0x76, 0x3a, 0x09, 0x05, 0x21,
-	0x55, 0x56, 0x3e, 0x06, 0x63, 0x41, 0x3c, 0x5e, 0x8f, 0x83, 0x94, 0xf3, 0xde, 0xf6, 0xb4, 0xd2,
-	0xfe, 0x89, 0x3d, 0xef, 0xaf, 0xe0, 0xc8, 0x71, 0x4f, 0xab, 0x15, 0xfc, 0x91, 0x55, 0xc6, 0x13,
-	0xdb, 0xf9, 0x42, 0xbb, 0xe2, 0x36, 0xf3, 0xbe, 0xef, 0x3c, 0x1f, 0x33, 0x8f, 0x65, 0x50, 0x3c,
-	0x62, 0xf7, 0x88, 0xdb, 0xb7, 0x6c, 0xaf, 0xe2, 0xb8, 0xd6, 0xdd, 0x5d, 0xfb, 0xb6, 0xe2, 0x0d,
-	0x1d, 0xc2, 0xca, 0x8e, 0x4b, 0x3d, 0x8a, 0x50, 0xd8, 0x2f, 0x8b, 0x7e, 0x7e, 0x37, 0x72, 0xa6,
-	0xeb, 0x0e, 0x1d, 0x8f, 0x56, 0x6e, 0xc8, 0x50, 0x9c, 0x98, 0xe8, 0x72, 0xa4, 0x28, 0x5e, 0x7e,
-	0xcb, 0xa4, 0x26, 0xe5, 0xcb, 0xca, 0x68, 0xe5, 0x57, 0x8b, 0x35, 0xd8, 0xc0, 0xa4, 0x4f, 0x3d,
-	0xd2, 0xb4, 0x4c, 0x9b, 0xb8, 0xaa, 0xeb, 0x52, 0x17, 0x21, 0x88, 0x75, 0x69, 0x8f, 0xe4, 0xe4,
-	0x82, 0xbc, 0x1f, 0xc7, 0x7c, 0x8d, 0x0a, 0x90, 0xea, 0x11, 0xd6, 0x75, 0x2d, 0xc7, 0xb3, 0xa8,
-	0x9d, 0x5b, 0x2a, 0xc8, 0xfb, 0xab, 0x38, 0x5a, 0x2a, 0x96, 0x20, 0xdd, 0x18, 0x74, 0xea, 0x64,
-	0x88, 0xc9, 0xab, 0x01, 0x61, 0x1e, 0xda, 0x81, 0x95, 0xee, 0x75, 0xdb, 0xb2, 0x0d, 0xab, 0xc7,
-	0xa1, 0x56, 0x71, 0x92, 0xef, 0x6b, 0xbd, 0xe2, 0x1b, 0x19, 0x32, 0xe3, 0x61, 0xe6, 0x50, 0x9b,
-	0x11, 0x74, 0x08, 0x49, 0x67, 0xd0, 0x31, 0x6e, 0xc8, 0x90, 0x0f, 0xa7, 0x0e, 0x76, 0xcb, 0x91,
-	0x1b, 0xf0, 0xdd, 0x96, 0x1b, 0x83, 0xce, 0xad, 0xd5, 0xad, 0x93, 0xe1, 0x51, 0xec, 0xfe, 0xd3,
-	0x9e, 0x84, 0x13, 0x0e, 0x07, 0x41, 0x87, 0x10, 0x27, 0x23, 0xe9, 0x5c, 0x57, 0xea, 0xe0, 0xe7,
-	0xf2, 0xec, 0xe5, 0x95, 0x67, 0x7c, 0x62, 0xff, 0x4c, 0xf1, 0x02, 0xd6, 0x47, 0xd5, 0xff, 0xa8,
-	0x47, 0xc6, 0xd2, 0x4b, 0x10, 0xbb, 0xa3, 0x1e, 0x11, 0x4a, 0xb6, 0xa3, 0x70, 0xfe, 0x9d, 0xf2,
-	0x61, 0x3e, 0x33, 0x61, 0x73, 0x69, 0xd2, 0xe6, 0x6b, 0x19, 0x10, 0x27, 0xec, 0xf9, 0xe0, 0xc2,
-	0xea, 0x6f, 0x5f, 0x83, 0x2e, 0x1c, 0xfa, 0x1c, 0x2f, 0xf2, 0x77, 0x0d, 0x9b, 0xa3, 0x6a, 0xc3,
-	0xa5, 0x0e, 0x65, 0xed, 0xdb, 0xb1, 0xc7, 0x3f, 0x61, 0xc5, 0x11, 0x25, 0xa1, 0x24, 0x3f, 0xab,
-	0x24, 0x38, 0x14, 0xcc, 0x3e, 0xe7, 0xf7, 0x9d, 0x0c, 0xdb, 0xbe, 0xdf, 0x90, 0x4c, 0x78, 0xfe,
-	0xeb, 0x5b, 0xd8, 0x84, 0xf7, 0x90, 0xf3, 0x45, 0xfe, 0xd3, 0x90, 0x6a, 0x58, 0xb6, 0x29, 0x7c,
-	0x17, 0x33, 0xb0, 0xe6, 0x6f, 0x7d, 0x65, 0xc5, 0x0f, 0x71, 0x48, 0x9e, 0x12, 0xc6, 0xda, 0x26,
-	0x41, 0x75, 0x58, 0x17, 0x21, 0x34, 0x5c, 0x7f, 0x5c, 0x88, 0xfd, 0x71, 0x1e, 0xe3, 0x44, 0xdc,
-	0xab, 0x12, 0x4e, 0x3b, 0x13, 0xf9, 0xd7, 0x20, 0x1b, 0x82, 0xf9, 0x64, 0x42, 0x7f, 0xf1, 0x39,
-	0x34, 0x7f, 0xb2, 0x2a, 0xe1, 0x8c, 0x33, 0xf9, 0x85, 0xfc, 0x0b, 0x1b, 0xcc, 0x32, 0x6d, 0x63,
-	0x94, 0x88, 0x40, 0xde, 0x32, 0x07, 0xfc, 0x69, 0x1e, 0xe0, 0x54, 0xa8, 0xab, 0x12, 0x5e, 0x67,
-	0x53, 0x39, 0xbf, 0x84, 0x2d, 0xc6, 0xdf, 0x6b, 0x0c, 0x2a, 0x64, 0xc6, 0x38, 0xea, 0x2f, 0x8b,
-	0x50, 0x27, 0xf3, 0x5c, 0x95, 0x30, 0x62, 0xb3, 0x29, 0xff, 0x1f, 0xbe, 0xe3, 0x72, 0xc7, 0x8f,
-	0x18, 0x48, 0x8e, 0x73, 0xf0, 0x5f, 0x17, 0x81, 0x4f, 0xe5, 0xb4, 0x2a, 0xe1, 0x4d, 0x36, 0x27,
-	0xbe, 0x57, 0x90, 0x13, 0xd2, 0x23, 0x04, 0x42, 0x7e, 0x82, 0x33, 0x94, 0x16, 0xcb, 0x9f, 0x8e,
-	0x67, 0x55, 0xc2, 0xdb, 0x6c, 0x7e, 0x70, 0x4f, 0x60, 0xcd, 0xb1, 0x6c, 0x33, 0x50, 0x9f, 0xe4,
-	0xd8, 0x7b, 0x73, 0x5f, 0x30, 0x4c, 0x59, 0x55, 0xc2, 0x29, 0x27, 0xdc, 0xa2, 0x7f, 0x20, 0x2d,
-	0x50, 0x84, 0xc4, 0x15, 0x0e, 0x53, 0x58, 0x0c, 0x13, 0x08, 0x5b, 0x73, 0x22, 0xfb, 0xa3, 0x38,
-	0x2c, 0xb3, 0x41, 0xbf, 0xf4, 0x5e, 0x86, 0x04, 0x0f, 0x39, 0x43, 0x08, 0x32, 0x2a, 0xc6, 0x3a,
-	0x6e, 0x1a, 0x67, 0x5a, 0x5d, 0xd3, 0xcf, 0xb5, 0xac, 0x84, 0x14, 0xc8, 0x07, 0x35, 0xf5, 0xa2,
-	0xa1, 0x1e, 0xb7, 0xd4, 0x13, 0x03, 0xab, 0xcd, 0x86, 0xae, 0x35, 0xd5, 0xac, 0x8c, 0x72, 0xb0,
-	0x25, 0xfa, 0x9a, 0x6e, 0x1c, 0xeb, 0x9a, 0xa6, 0x1e, 0xb7, 0x6a, 0xba, 0x96, 0x5d, 0x42, 0x3f,
-	0xc0, 0x8e, 0xe8, 0x84, 0x65, 0xa3, 0x55, 0x3b, 0x55, 0xf5, 0xb3, 0x56, 0x76, 0x19, 0x7d, 0x0f,
-	0x9b, 0xa2, 0x8d, 0xd5, 0xbf, 0x4f, 0x82, 0x46, 0x2c, 0x82, 0x78, 0x8e, 0x6b, 0x2d, 0x35, 0xe8,
-	0xc4, 0x8f, 0xf4, 0xfb, 0x47, 0x45, 0x7e, 0x78, 0x54, 0xe4, 0xcf, 0x8f, 0x8a, 0xfc, 0xf6, 0x49,
-	0x91, 0x1e, 0x9e, 0x14, 0xe9, 0xe3, 0x93, 0x22, 0x5d, 0xfe, 0x61, 0x5a, 0xde, 0xf5, 0xa0, 0x53,
-	0xee, 0xd2, 0x7e, 0xa5, 0x4b, 0xfb, 0xc4, 0xeb, 0x5c, 0x79, 0xe1, 0xc2, 0xff, 0x57, 0xcd, 0xfe,
-	0x25, 0x3b, 0x09, 0xde, 0xf9, 0xfd, 0x4b, 0x00, 0x00, 0x00, 0xff, 0xff, 0xda, 0x9f, 0x99, 0x3e,
-	0x42, 0x07, 0x00, 0x00,
+	// 840 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x96, 0xdf, 0x8e, 0xdb, 0x44,
+	0x14, 0xc6, 0xed, 0xdd, 0x64, 0x77, 0x7b, 0xb2, 0xc9, 0x66, 0x67, 0x97, 0x25, 0x5d, 0x15, 0x77,
+	0x31, 0x02, 0xca, 0x4a, 0x4d, 0x50, 0x11, 0xdc, 0x94, 0x1b, 0x76, 0x63, 0x91, 0x28, 0xaa, 0x1d,
+	0x26, 0x29, 0x45, 0x95, 0x90, 0x95, 0xd8, 0x53, 0xc7, 0x6a, 0xe2, 0x31, 0x9e, 0x49, 0xa4, 0xbc,
+	0x05, 0x12, 0x2f, 0xc1, 0x75, 0x25, 0x5e, 0x80, 0xab, 0x5e, 0xf6, 0x92, 0x2b, 0x84, 0x76, 0x5f,
+	0x04, 0x79, 0x3c, 0xb1, 0x9d, 0x7f, 0x88, 0x0a, 0xd4, 0xbb, 0x99, 0x73, 0xce, 0x7c, 0xf3, 0x3b,
+	0x9f, 0xe7, 0x28, 0x01, 0x8d, 0x93, 0xc0, 0x25, 0xd1, 0xc4, 0x0f, 0x78, 0x23, 0x8c, 0xfc, 0xd9,
+	0x6c, 0x30, 0x6e, 0xf0, 0x79, 0x48, 0x58, 0x3d, 0x8c, 0x28, 0xa7, 0x08, 0x65, 0xf9, 0xba, 0xcc,
+	0x9f, 0xdf, 0xcb, 0x9d, 0x71, 0xa2, 0x79, 0xc8, 0x69, 0xe3, 0x25, 0x99, 0xcb, 0x13, 0x4b, 0x59,
+	0xa1, 0x94, 0xd7, 0x3b, 0x3f, 0xf5, 0xa8, 0x47, 0xc5, 0xb2, 0x11, 0xaf, 0x92, 0xa8, 0xde, 0x86,
+	0x63, 0x4c, 0x26, 0x94, 0x93, 0x9e, 0xef, 0x05, 0x24, 0x32, 0xa2, 0x88, 0x46, 0x08, 0x41, 0xc1,
+	0xa1, 0x2e, 0xa9, 0xa9, 0x17, 0xea, 0x83, 0x22, 0x16, 0x6b, 0x74, 0x01, 0x25, 0x97, 0x30, 0x27,
+	0xf2, 0x43, 0xee, 0xd3, 0xa0, 0xb6, 0x73, 0xa1, 0x3e, 0xb8, 0x83, 0xf3, 0x21, 0xfd, 0x12, 0xca,
+	0xdd, 0xe9, 0xb0, 0x43, 0xe6, 0x98, 0xfc, 0x34, 0x25, 0x8c, 0xa3, 0xbb, 0x70, 0xe0, 0x8c, 0x06,
+	0x7e, 0x60, 0xfb, 0xae, 0x90, 0xba, 0x83, 0xf7, 0xc5, 0xbe, 0xed, 0xea, 0xbf, 0xa9, 0x50, 0x59,
+	0x14, 0xb3, 0x90, 0x06, 0x8c, 0xa0, 0xc7, 0xb0, 0x1f, 0x4e, 0x87, 0xf6, 0x4b, 0x32, 0x17, 0xc5,
+	0xa5, 0x47, 0xf7, 0xea, 0x39, 0x07, 0x92, 0x6e, 0xeb, 0xdd, 0xe9, 0x70, 0xec, 0x3b, 0x1d, 0x32,
+	0xbf, 0x2a, 0xbc, 0xfe, 0xf3, 0xbe, 0x82, 0xf7, 0x42, 0x21, 0x82, 0x1e, 0x43, 0x91, 0xc4, 0xe8,
+	0x82, 0xab, 0xf4, 0xe8, 0xe3, 0xfa, 0xba, 0x79, 0xf5, 0xb5, 0x3e, 0x71, 0x72, 0x06, 0x7d, 0x06,
+	0xc7, 0xa3, 0x01, 0x1b, 0xd9, 0x9c, 0xda, 0xce, 0x34, 0x9a, 0x11, 0xdb, 0x65, 0xbc, 0xb6, 0x2b,
+	0x80, 0x2b, 0x71, 0xa2, 0x4f, 0xaf, 0xe3, 0x70, 0x93, 0x71, 0xfd, 0x95, 0x0a, 0x47, 0xb1, 0xc2,
+	0xf7, 0x94, 0x93, 0x45, 0x9b, 0x97, 0x50, 0x98, 0x51, 0x4e, 0x24, 0xf5, 0x59, 0xfe, 0xea, 0xc4,
+	0x7f, 0x51, 0x2c, 0x6a, 0x96, 0x2c, 0xd9, 0x59, 0xb2, 0xe4, 0x2d, 0x28, 0xd0, 0x43, 0x40, 0xce,
+	0x20, 0xa0, 0x81, 0xef, 0x0c, 0xc6, 0x36, 0x09, 0x1c, 0xea, 0xfa, 0x81, 0x57, 0x2b, 0x88, 0xda,
+	0xe3, 0x34, 0x63, 0xc8, 0x44, 0x0c, 0x8d, 0x44, 0xdb, 0x6e, 0x82, 0x2d, 0x0d, 0xff, 0xfc, 0xdf,
+	0x70, 0x4b, 0x9f, 0x13, 0xfa, 0xff, 0xe4, 0xf2, 0x43, 0x38, 0x59, 0xee, 0x2f, 0xa0, 0x81, 0x43,
+	0x44, 0x87, 0x65, 0x5c, 0xcd, 0x75, 0x68, 0xc6, 0x71, 0xfd, 0x17, 0x15, 0x4e, 0x62, 0x95, 0x6e,
+	0x44, 0x43, 0xca, 0x06, 0xe3, 0x85, 0xdb, 0x5f, 0xc1, 0x41, 0x28, 0x43, 0x92, 0xfc, 0x7c, 0x9d,
+	0x3c, 0x3d, 0x94, 0xd6, 0xfe, 0x3f, 0xce, 0xeb, 0xbf, 0xab, 0x70, 0x96, 0x58, 0x99, 0x71, 0x49,
+	0x3b, 0xbf, 0x7e, 0x1b, 0x30, 0x69, 0x6b, 0x86, 0xf7, 0x2e, 0xad, 0x2d, 0x43, 0xa9, 0xeb, 0x07,
+	0x9e, 0x74, 0x54, 0xaf, 0xc0, 0x61, 0xb2, 0x4d, 0x1a, 0xd1, 0x5f, 0x15, 0x61, 0xff, 0x09, 0x61,
+	0x6c, 0xe0, 0x11, 0xd4, 0x81, 0x23, 0x39, 0x94, 0x76, 0x94, 0x94, 0xcb, 0xde, 0x3e, 0xdc, 0x04,
+	0xb8, 0x34, 0xfe, 0x2d, 0x05, 0x97, 0xc3, 0x7c, 0x00, 0x99, 0x50, 0xcd, 0xc4, 0x92, 0xcb, 0x64,
+	0xbb, 0xfa, 0x3f, 0xa9, 0x25, 0x95, 0x2d, 0x05, 0x57, 0xc2, 0xa5, 0x08, 0xfa, 0x0e, 0x8e, 0x99,
+	0xef, 0x05, 0x76, 0xfc, 0x36, 0x53, 0xbc, 0x5d, 0x21, 0xf8, 0xd1, 0x26, 0xc1, 0x95, 0xc1, 0x6d,
+	0x29, 0xf8, 0x88, 0xad, 0xcc, 0xf2, 0x73, 0x38, 0x65, 0xe2, 0xf3, 0x2e, 0x44, 0x25, 0x66, 0x41,
+	0xa8, 0x7e, 0xb2, 0x4d, 0x75, 0x79, 0xb2, 0x5a, 0x0a, 0x46, 0x6c, 0x7d, 0xde, 0x7e, 0x84, 0xf7,
+	0x04, 0xee, 0xe2, 0x9b, 0xa7, 0xc8, 0x45, 0x21, 0xfe, 0xe9, 0x36, 0xf1, 0x95, 0x09, 0x68, 0x29,
+	0xf8, 0x84, 0xad, 0x87, 0xd1, 0x0b, 0xa8, 0x49, 0xf4, 0xdc, 0x05, 0x12, 0x7f, 0x4f, 0xdc, 0x70,
+	0xb9, 0x1d, 0x7f, 0xf5, 0x35, 0xb7, 0x14, 0x7c, 0xc6, 0x36, 0xbf, 0xf3, 0x26, 0x1c, 0x86, 0x7e,
+	0xe0, 0xa5, 0xf4, 0xfb, 0x42, 0xfb, 0xfe, 0xc6, 0x2f, 0x98, 0xbd, 0xb2, 0x96, 0x82, 0x4b, 0x61,
+	0xb6, 0x45, 0xdf, 0x42, 0x59, 0xaa, 0x48, 0xc4, 0x03, 0x21, 0x73, 0xb1, 0x5d, 0x26, 0x05, 0x3b,
+	0x0c, 0x73, 0xfb, 0xab, 0x22, 0xec, 0xb2, 0xe9, 0xe4, 0xf2, 0x57, 0x15, 0xf6, 0xc4, 0x4c, 0x30,
+	0x84, 0xa0, 0x62, 0x60, 0x6c, 0xe1, 0x9e, 0xfd, 0xd4, 0xec, 0x98, 0xd6, 0x33, 0xb3, 0xaa, 0x20,
+	0x0d, 0xce, 0xd3, 0x98, 0xf1, 0x43, 0xd7, 0xb8, 0xee, 0x1b, 0x4d, 0x1b, 0x1b, 0xbd, 0xae, 0x65,
+	0xf6, 0x8c, 0xaa, 0x8a, 0x6a, 0x70, 0x2a, 0xf3, 0xa6, 0x65, 0x5f, 0x5b, 0xa6, 0x69, 0x5c, 0xf7,
+	0xdb, 0x96, 0x59, 0xdd, 0x41, 0x1f, 0xc0, 0x5d, 0x99, 0xc9, 0xc2, 0x76, 0xbf, 0xfd, 0xc4, 0xb0,
+	0x9e, 0xf6, 0xab, 0xbb, 0xe8, 0x7d, 0x38, 0x91, 0x69, 0x6c, 0x7c, 0xd3, 0x4c, 0x13, 0x85, 0x9c,
+	0xe2, 0x33, 0xdc, 0xee, 0x1b, 0x69, 0xa6, 0x78, 0x65, 0xbd, 0xbe, 0xd1, 0xd4, 0x37, 0x37, 0x9a,
+	0xfa, 0xd7, 0x8d, 0xa6, 0xfe, 0x7c, 0xab, 0x29, 0x6f, 0x6e, 0x35, 0xe5, 0x8f, 0x5b, 0x4d, 0x79,
+	0xfe, 0xa5, 0xe7, 0xf3, 0xd1, 0x74, 0x58, 0x77, 0xe8, 0xa4, 0xe1, 0xd0, 0x09, 0xe1, 0xc3, 0x17,
+	0x3c, 0x5b, 0x24, 0xbf, 0xdd, 0xeb, 0xff, 0x1a, 0x86, 0x7b, 0x22, 0xf3, 0xc5, 0xdf, 0x03, 0x00,
+	0x6e, 0x43, 0xe0, 0xf0, 0x52, 0x08, 0x00, 0x00,
 }
 
 func (m *RemoteSignerError) Marshal() (dAtA []byte, err error) {
@@ -814,6 +877,13 @@ func (m *PubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.HashToCurveDst) > 0 {
+		i -= len(m.HashToCurveDst)
+		copy(dAtA[i:], m.HashToCurveDst)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.HashToCurveDst)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Error != nil {
 		{
 			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
@@ -859,6 +929,20 @@ func (m *SignVoteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.CanonicalEncoding) > 0 {
+		i -= len(m.CanonicalEncoding)
+		copy(dAtA[i:], m.CanonicalEncoding)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.CanonicalEncoding)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.HashToCurveDst) > 0 {
+		i -= len(m.HashToCurveDst)
+		copy(dAtA[i:], m.HashToCurveDst)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.HashToCurveDst)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.ChainId) > 0 {
 		i -= len(m.ChainId)
 		copy(dAtA[i:], m.ChainId)
@@ -901,6 +985,11 @@ func (m *SignedVoteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.HashToCurveNonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.HashToCurveNonce))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Error != nil {
 		{
 			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
@@ -946,6 +1035,13 @@ func (m *SignProposalRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.HashToCurveDst) > 0 {
+		i -= len(m.HashToCurveDst)
+		copy(dAtA[i:], m.HashToCurveDst)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.HashToCurveDst)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.ChainId) > 0 {
 		i -= len(m.ChainId)
 		copy(dAtA[i:], m.ChainId)
@@ -988,6 +1084,11 @@ func (m *SignedProposalResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.HashToCurveNonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.HashToCurveNonce))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Error != nil {
 		{
 			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
@@ -1311,6 +1412,10 @@ func (m *PubKeyResponse) Size() (n int) {
 		l = m.Error.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.HashToCurveDst)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1328,6 +1433,14 @@ func (m *SignVoteRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.HashToCurveDst)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.CanonicalEncoding)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1343,6 +1456,9 @@ func (m *SignedVoteResponse) Size() (n int) {
 		l = m.Error.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.HashToCurveNonce != 0 {
+		n += 1 + sovTypes(uint64(m.HashToCurveNonce))
+	}
 	return n
 }
 
@@ -1360,6 +1476,10 @@ func (m *SignProposalRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.HashToCurveDst)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1375,6 +1495,9 @@ func (m *SignedProposalResponse) Size() (n int) {
 		l = m.Error.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.HashToCurveNonce != 0 {
+		n += 1 + sovTypes(uint64(m.HashToCurveNonce))
+	}
 	return n
 }
 
@@ -1792,6 +1915,38 @@ func (m *PubKeyResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashToCurveDst", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HashToCurveDst = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1910,6 +2065,70 @@ func (m *SignVoteRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.ChainId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashToCurveDst", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HashToCurveDst = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CanonicalEncoding", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CanonicalEncoding = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2029,6 +2248,25 @@ func (m *SignedVoteResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashToCurveNonce", wireType)
+			}
+			m.HashToCurveNonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HashToCurveNonce |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2147,6 +2385,38 @@ func (m *SignProposalRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.ChainId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashToCurveDst", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HashToCurveDst = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2266,6 +2536,25 @@ func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashToCurveNonce", wireType)
+			}
+			m.HashToCurveNonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HashToCurveNonce |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
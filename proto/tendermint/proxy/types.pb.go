@@ -0,0 +1,557 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/proxy/types.proto
+
+package proxy
+
+import (
+	fmt "fmt"
+	types1 "github.com/cometbft/cometbft/abci/types"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	_ "github.com/cosmos/gogoproto/types"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	_ "github.com/golang/protobuf/ptypes/duration"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// RecordedCall is an ABCI call recorded by the proxy app connections, to be
+// replayed against an application.
+type RecordedCall struct {
+	// The connection the call was made on: consensus, mempool, query or snapshot.
+	Connection string           `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+	Time       time.Time        `protobuf:"bytes,2,opt,name=time,proto3,stdtime" json:"time"`
+	Duration   time.Duration    `protobuf:"bytes,3,opt,name=duration,proto3,stdduration" json:"duration"`
+	Request    *types1.Request  `protobuf:"bytes,4,opt,name=request,proto3" json:"request,omitempty"`
+	Response   *types1.Response `protobuf:"bytes,5,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (m *RecordedCall) Reset()         { *m = RecordedCall{} }
+func (m *RecordedCall) String() string { return proto.CompactTextString(m) }
+func (*RecordedCall) ProtoMessage()    {}
+func (*RecordedCall) Descriptor() ([]byte, []int) {
+	return fileDescriptor_205299f6820754ee, []int{0}
+}
+func (m *RecordedCall) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecordedCall) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecordedCall.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecordedCall) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordedCall.Merge(m, src)
+}
+func (m *RecordedCall) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecordedCall) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordedCall.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordedCall proto.InternalMessageInfo
+
+func (m *RecordedCall) GetConnection() string {
+	if m != nil {
+		return m.Connection
+	}
+	return ""
+}
+
+func (m *RecordedCall) GetTime() time.Time {
+	if m != nil {
+		return m.Time
+	}
+	return time.Time{}
+}
+
+func (m *RecordedCall) GetDuration() time.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *RecordedCall) GetRequest() *types1.Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *RecordedCall) GetResponse() *types1.Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*RecordedCall)(nil), "tendermint.proxy.RecordedCall")
+}
+
+func init() { proto.RegisterFile("tendermint/proxy/types.proto", fileDescriptor_205299f6820754ee) }
+
+var fileDescriptor_205299f6820754ee = []byte{
+	// 323 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0x3f, 0x4f, 0xf3, 0x30,
+	0x10, 0xc6, 0xe3, 0xbe, 0x7d, 0xa1, 0x18, 0x06, 0x14, 0x31, 0x84, 0x82, 0xdc, 0x8a, 0xa9, 0x93,
+	0x23, 0xb5, 0x42, 0x62, 0x43, 0x2a, 0xac, 0x2c, 0x11, 0x13, 0x5b, 0xfe, 0x5c, 0x43, 0xa4, 0xc6,
+	0x17, 0x1c, 0x47, 0xa2, 0xdf, 0xa2, 0x03, 0x03, 0x1f, 0xa9, 0x63, 0x47, 0x26, 0x40, 0xcd, 0x17,
+	0x41, 0xb1, 0x93, 0x36, 0x6a, 0xb7, 0xf3, 0x3d, 0xcf, 0xcf, 0xf7, 0xd8, 0x47, 0xaf, 0x15, 0x88,
+	0x08, 0x64, 0x9a, 0x08, 0xe5, 0x66, 0x12, 0xdf, 0x17, 0xae, 0x5a, 0x64, 0x90, 0xf3, 0x4c, 0xa2,
+	0x42, 0xfb, 0x7c, 0xa7, 0x72, 0xad, 0xf6, 0x2f, 0x62, 0x8c, 0x51, 0x8b, 0x6e, 0x55, 0x19, 0x5f,
+	0x7f, 0x10, 0x23, 0xc6, 0x73, 0x70, 0xf5, 0x29, 0x28, 0x66, 0xae, 0x4a, 0x52, 0xc8, 0x95, 0x9f,
+	0x66, 0xb5, 0x81, 0xed, 0x1b, 0xa2, 0x42, 0xfa, 0x2a, 0x41, 0x51, 0xeb, 0x57, 0xad, 0x18, 0x7e,
+	0x10, 0x26, 0xed, 0x14, 0x37, 0x1f, 0x1d, 0x7a, 0xe6, 0x41, 0x88, 0x32, 0x82, 0xe8, 0xc1, 0x9f,
+	0xcf, 0x6d, 0x46, 0x69, 0x88, 0x42, 0x40, 0x58, 0xdd, 0xe0, 0x90, 0x21, 0x19, 0x9d, 0x78, 0xad,
+	0x8e, 0x7d, 0x47, 0xbb, 0x55, 0x00, 0xa7, 0x33, 0x24, 0xa3, 0xd3, 0x71, 0x9f, 0x9b, 0xe1, 0xbc,
+	0x19, 0xce, 0x9f, 0x9b, 0x74, 0xd3, 0xde, 0xea, 0x7b, 0x60, 0x2d, 0x7f, 0x06, 0xc4, 0xd3, 0x84,
+	0x7d, 0x4f, 0x7b, 0x4d, 0x32, 0xe7, 0x9f, 0xa6, 0x2f, 0x0f, 0xe8, 0xc7, 0xda, 0x60, 0xe0, 0xcf,
+	0x0a, 0xde, 0x42, 0xf6, 0x98, 0x1e, 0x4b, 0x78, 0x2b, 0x20, 0x57, 0x4e, 0x57, 0xf3, 0x0e, 0x6f,
+	0xfd, 0x61, 0xf5, 0x34, 0xee, 0x19, 0xdd, 0x6b, 0x8c, 0xf6, 0x2d, 0xed, 0x49, 0xc8, 0x33, 0x14,
+	0x39, 0x38, 0xff, 0xeb, 0xa1, 0x87, 0x90, 0x31, 0x78, 0x5b, 0xeb, 0xf4, 0x69, 0xb5, 0x61, 0x64,
+	0xbd, 0x61, 0xe4, 0x77, 0xc3, 0xc8, 0xb2, 0x64, 0xd6, 0xba, 0x64, 0xd6, 0x57, 0xc9, 0xac, 0x97,
+	0x49, 0x9c, 0xa8, 0xd7, 0x22, 0xe0, 0x21, 0xa6, 0x6e, 0x88, 0x29, 0xa8, 0x60, 0xa6, 0x76, 0x85,
+	0xd9, 0xde, 0xfe, 0xde, 0x83, 0x23, 0xdd, 0x9f, 0xfc, 0x0d, 0x00, 0xe2, 0xdf, 0x68, 0x25, 0x12,
+	0x02, 0x00, 0x00,
+}
+
+func (m *RecordedCall) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecordedCall) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecordedCall) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Response != nil {
+		{
+			size, err := m.Response.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Request != nil {
+		{
+			size, err := m.Request.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	n3, err3 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Duration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Duration):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintTypes(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x1a
+	n4, err4 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintTypes(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x12
+	if len(m.Connection) > 0 {
+		i -= len(m.Connection)
+		copy(dAtA[i:], m.Connection)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Connection)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *RecordedCall) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Connection)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovTypes(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Duration)
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Request != nil {
+		l = m.Request.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Response != nil {
+		l = m.Response.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RecordedCall) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecordedCall: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecordedCall: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connection", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Connection = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Duration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Request == nil {
+				m.Request = &types1.Request{}
+			}
+			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Response == nil {
+				m.Response = &types1.Response{}
+			}
+			if err := m.Response.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTypes(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTypes
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupTypes
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthTypes
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthTypes        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTypes          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupTypes = fmt.Errorf("proto: unexpected end of group")
+)
@@ -24,7 +24,6 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Message struct {
 	// Types that are valid to be assigned to Sum:
-	//
 	//	*Message_SnapshotsRequest
 	//	*Message_SnapshotsResponse
 	//	*Message_ChunkRequest
@@ -250,6 +249,8 @@ type ChunkRequest struct {
 	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Format uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
 	Index  uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// the requester can decompress a zstd-compressed chunk
+	AcceptZstd bool `protobuf:"varint,4,opt,name=accept_zstd,json=acceptZstd,proto3" json:"accept_zstd,omitempty"`
 }
 
 func (m *ChunkRequest) Reset()         { *m = ChunkRequest{} }
@@ -306,12 +307,23 @@ func (m *ChunkRequest) GetIndex() uint32 {
 	return 0
 }
 
+func (m *ChunkRequest) GetAcceptZstd() bool {
+	if m != nil {
+		return m.AcceptZstd
+	}
+	return false
+}
+
 type ChunkResponse struct {
 	Height  uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Format  uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
 	Index   uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
 	Chunk   []byte `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
 	Missing bool   `protobuf:"varint,5,opt,name=missing,proto3" json:"missing,omitempty"`
+	// the chunk is compressed with zstd
+	Zstd bool `protobuf:"varint,6,opt,name=zstd,proto3" json:"zstd,omitempty"`
+	// SHA-256 checksum of the uncompressed chunk
+	Checksum []byte `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`
 }
 
 func (m *ChunkResponse) Reset()         { *m = ChunkResponse{} }
@@ -382,6 +394,20 @@ func (m *ChunkResponse) GetMissing() bool {
 	return false
 }
 
+func (m *ChunkResponse) GetZstd() bool {
+	if m != nil {
+		return m.Zstd
+	}
+	return false
+}
+
+func (m *ChunkResponse) GetChecksum() []byte {
+	if m != nil {
+		return m.Checksum
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Message)(nil), "tendermint.statesync.Message")
 	proto.RegisterType((*SnapshotsRequest)(nil), "tendermint.statesync.SnapshotsRequest")
@@ -393,32 +419,35 @@ func init() {
 func init() { proto.RegisterFile("tendermint/statesync/types.proto", fileDescriptor_a1c2869546ca7914) }
 
 var fileDescriptor_a1c2869546ca7914 = []byte{
-	// 397 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0x3f, 0x6b, 0xdb, 0x40,
-	0x1c, 0x95, 0xfc, 0x9f, 0x5f, 0xad, 0x62, 0x1f, 0xa6, 0x88, 0x0e, 0xc2, 0xa8, 0xd0, 0x76, 0x92,
-	0xa0, 0x1d, 0xba, 0xbb, 0x8b, 0x0b, 0xed, 0xd0, 0x6b, 0x03, 0x21, 0x4b, 0x38, 0xcb, 0x67, 0x49,
-	0x04, 0x9d, 0x14, 0xfd, 0x4e, 0x10, 0x7f, 0x80, 0x4c, 0x59, 0xf2, 0xb1, 0x32, 0x7a, 0x0c, 0x99,
-	0x82, 0xfd, 0x45, 0x82, 0x4e, 0xb2, 0xac, 0x38, 0x26, 0x21, 0x90, 0xed, 0xde, 0xd3, 0xd3, 0xbb,
-	0xf7, 0x1e, 0x1c, 0x8c, 0x25, 0x17, 0x73, 0x9e, 0x46, 0xa1, 0x90, 0x2e, 0x4a, 0x26, 0x39, 0x2e,
-	0x85, 0xe7, 0xca, 0x65, 0xc2, 0xd1, 0x49, 0xd2, 0x58, 0xc6, 0x64, 0xb4, 0x53, 0x38, 0x95, 0xc2,
-	0xbe, 0x6b, 0x40, 0xf7, 0x0f, 0x47, 0x64, 0x3e, 0x27, 0x47, 0x30, 0x44, 0xc1, 0x12, 0x0c, 0x62,
-	0x89, 0xa7, 0x29, 0x3f, 0xcf, 0x38, 0x4a, 0x53, 0x1f, 0xeb, 0x5f, 0xdf, 0x7d, 0xfb, 0xec, 0x1c,
-	0xfa, 0xdb, 0xf9, 0xb7, 0x95, 0xd3, 0x42, 0x3d, 0xd5, 0xe8, 0x00, 0xf7, 0x38, 0x72, 0x0c, 0xa4,
-	0x6e, 0x8b, 0x49, 0x2c, 0x90, 0x9b, 0x0d, 0xe5, 0xfb, 0xe5, 0x45, 0xdf, 0x42, 0x3e, 0xd5, 0xe8,
-	0x10, 0xf7, 0x49, 0xf2, 0x0b, 0x0c, 0x2f, 0xc8, 0xc4, 0x59, 0x15, 0xb6, 0xa9, 0x4c, 0xed, 0xc3,
-	0xa6, 0x3f, 0x73, 0xe9, 0x2e, 0x68, 0xdf, 0xab, 0x61, 0xf2, 0x1b, 0xde, 0x6f, 0xad, 0xca, 0x80,
-	0x2d, 0xe5, 0xf5, 0xe9, 0x59, 0xaf, 0x2a, 0x9c, 0xe1, 0xd5, 0x89, 0x49, 0x1b, 0x9a, 0x98, 0x45,
-	0x36, 0x81, 0xc1, 0xfe, 0x42, 0xf6, 0x95, 0x0e, 0xc3, 0x27, 0xf5, 0xc8, 0x07, 0xe8, 0x04, 0x3c,
-	0xf4, 0x83, 0x62, 0xef, 0x16, 0x2d, 0x51, 0xce, 0x2f, 0xe2, 0x34, 0x62, 0x52, 0xed, 0x65, 0xd0,
-	0x12, 0xe5, 0xbc, 0xba, 0x11, 0x55, 0x65, 0x83, 0x96, 0x88, 0x10, 0x68, 0x05, 0x0c, 0x03, 0x15,
-	0xbe, 0x4f, 0xd5, 0x99, 0x7c, 0x84, 0x5e, 0xc4, 0x25, 0x9b, 0x33, 0xc9, 0xcc, 0xb6, 0xe2, 0x2b,
-	0x6c, 0xff, 0x87, 0x7e, 0x7d, 0x96, 0x57, 0xe7, 0x18, 0x41, 0x3b, 0x14, 0x73, 0x7e, 0x51, 0xc6,
-	0x28, 0x80, 0x7d, 0xa9, 0x83, 0xf1, 0x68, 0xa1, 0xb7, 0xf1, 0xcd, 0x59, 0xd5, 0xb3, 0xac, 0x57,
-	0x00, 0x62, 0x42, 0x37, 0x0a, 0x11, 0x43, 0xe1, 0xab, 0x7a, 0x3d, 0xba, 0x85, 0x93, 0xbf, 0x37,
-	0x6b, 0x4b, 0x5f, 0xad, 0x2d, 0xfd, 0x7e, 0x6d, 0xe9, 0xd7, 0x1b, 0x4b, 0x5b, 0x6d, 0x2c, 0xed,
-	0x76, 0x63, 0x69, 0x27, 0x3f, 0xfc, 0x50, 0x06, 0xd9, 0xcc, 0xf1, 0xe2, 0xc8, 0xf5, 0xe2, 0x88,
-	0xcb, 0xd9, 0x42, 0xee, 0x0e, 0xea, 0xc1, 0xb8, 0x87, 0x5e, 0xd4, 0xac, 0xa3, 0xbe, 0x7d, 0x7f,
-	0x08, 0x00, 0x00, 0xff, 0xff, 0x04, 0xbe, 0xb0, 0x90, 0x70, 0x03, 0x00, 0x00,
+	// 436 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0x4d, 0x8b, 0xd4, 0x40,
+	0x10, 0x4d, 0x76, 0xe7, 0x8b, 0xda, 0x89, 0xec, 0x34, 0x83, 0x04, 0x0f, 0x71, 0x89, 0xa0, 0x9e,
+	0x32, 0xa0, 0x07, 0xef, 0xeb, 0x65, 0x05, 0x3d, 0xd8, 0x22, 0xc8, 0x5e, 0x96, 0x9e, 0x4e, 0xef,
+	0x24, 0x2c, 0xdd, 0x89, 0x53, 0x15, 0x70, 0xfd, 0x0b, 0x5e, 0xfc, 0x33, 0xfe, 0x07, 0x8f, 0x73,
+	0x14, 0x4f, 0x32, 0xf3, 0x47, 0x24, 0x95, 0xcc, 0x87, 0xe3, 0xa0, 0x08, 0xde, 0xea, 0xbd, 0xbc,
+	0xbc, 0x7e, 0xf5, 0xa0, 0xe0, 0x8c, 0x8c, 0x4b, 0xcd, 0xdc, 0xe6, 0x8e, 0x26, 0x48, 0x8a, 0x0c,
+	0xde, 0x3a, 0x3d, 0xa1, 0xdb, 0xd2, 0x60, 0x52, 0xce, 0x0b, 0x2a, 0xc4, 0x78, 0xab, 0x48, 0x36,
+	0x8a, 0xf8, 0xfb, 0x11, 0xf4, 0x5f, 0x19, 0x44, 0x35, 0x33, 0xe2, 0x2d, 0x8c, 0xd0, 0xa9, 0x12,
+	0xb3, 0x82, 0xf0, 0x6a, 0x6e, 0xde, 0x57, 0x06, 0x29, 0xf4, 0xcf, 0xfc, 0xc7, 0x27, 0x4f, 0x1e,
+	0x26, 0x87, 0xfe, 0x4e, 0xde, 0xac, 0xe5, 0xb2, 0x51, 0x5f, 0x78, 0xf2, 0x14, 0xf7, 0x38, 0xf1,
+	0x0e, 0xc4, 0xae, 0x2d, 0x96, 0x85, 0x43, 0x13, 0x1e, 0xb1, 0xef, 0xa3, 0xbf, 0xfa, 0x36, 0xf2,
+	0x0b, 0x4f, 0x8e, 0x70, 0x9f, 0x14, 0x2f, 0x20, 0xd0, 0x59, 0xe5, 0x6e, 0x36, 0x61, 0x8f, 0xd9,
+	0x34, 0x3e, 0x6c, 0xfa, 0xbc, 0x96, 0x6e, 0x83, 0x0e, 0xf5, 0x0e, 0x16, 0x2f, 0xe1, 0xce, 0xda,
+	0xaa, 0x0d, 0xd8, 0x61, 0xaf, 0x07, 0x7f, 0xf4, 0xda, 0x84, 0x0b, 0xf4, 0x2e, 0x71, 0xde, 0x85,
+	0x63, 0xac, 0x6c, 0x2c, 0xe0, 0x74, 0xbf, 0xa1, 0xf8, 0x93, 0x0f, 0xa3, 0xdf, 0xd6, 0x13, 0x77,
+	0xa1, 0x97, 0x99, 0x7c, 0x96, 0x35, 0x7d, 0x77, 0x64, 0x8b, 0x6a, 0xfe, 0xba, 0x98, 0x5b, 0x45,
+	0xdc, 0x57, 0x20, 0x5b, 0x54, 0xf3, 0xfc, 0x22, 0xf2, 0xca, 0x81, 0x6c, 0x91, 0x10, 0xd0, 0xc9,
+	0x14, 0x66, 0x1c, 0x7e, 0x28, 0x79, 0x16, 0xf7, 0x60, 0x60, 0x0d, 0xa9, 0x54, 0x91, 0x0a, 0xbb,
+	0xcc, 0x6f, 0x70, 0x5c, 0xc1, 0x70, 0xb7, 0x96, 0x7f, 0xce, 0x31, 0x86, 0x6e, 0xee, 0x52, 0xf3,
+	0xa1, 0x8d, 0xd1, 0x00, 0x71, 0x1f, 0x4e, 0x94, 0xd6, 0xa6, 0xa4, 0xab, 0x8f, 0x48, 0x29, 0x87,
+	0x19, 0x48, 0x68, 0xa8, 0x4b, 0xa4, 0x34, 0xfe, 0xe2, 0x43, 0xf0, 0x4b, 0x85, 0xff, 0xe9, 0xe1,
+	0x31, 0x74, 0xb9, 0x88, 0x76, 0xff, 0x06, 0x88, 0x10, 0xfa, 0x36, 0x47, 0xcc, 0xdd, 0x8c, 0xf7,
+	0x1f, 0xc8, 0x35, 0xac, 0xeb, 0xe2, 0x84, 0x3d, 0xa6, 0x79, 0xae, 0xeb, 0xd2, 0x99, 0xd1, 0x37,
+	0x58, 0xd9, 0xb0, 0xdf, 0xd4, 0xb5, 0xc6, 0xe7, 0xaf, 0xbf, 0x2e, 0x23, 0x7f, 0xb1, 0x8c, 0xfc,
+	0x1f, 0xcb, 0xc8, 0xff, 0xbc, 0x8a, 0xbc, 0xc5, 0x2a, 0xf2, 0xbe, 0xad, 0x22, 0xef, 0xf2, 0xd9,
+	0x2c, 0xa7, 0xac, 0x9a, 0x26, 0xba, 0xb0, 0x13, 0x5d, 0x58, 0x43, 0xd3, 0x6b, 0xda, 0x0e, 0x7c,
+	0x81, 0x93, 0x43, 0x27, 0x3a, 0xed, 0xf1, 0xb7, 0xa7, 0x3f, 0x07, 0x00, 0x00, 0xdf, 0x23, 0xc5,
+	0xc1, 0x03, 0x00, 0x00,
 }
 
 func (m *Message) Marshal() (dAtA []byte, err error) {
@@ -632,6 +661,16 @@ func (m *ChunkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AcceptZstd {
+		i--
+		if m.AcceptZstd {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.Index != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
 		i--
@@ -670,6 +709,23 @@ func (m *ChunkResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Checksum) > 0 {
+		i -= len(m.Checksum)
+		copy(dAtA[i:], m.Checksum)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Checksum)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Zstd {
+		i--
+		if m.Zstd {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	if m.Missing {
 		i--
 		if m.Missing {
@@ -826,6 +882,9 @@ func (m *ChunkRequest) Size() (n int) {
 	if m.Index != 0 {
 		n += 1 + sovTypes(uint64(m.Index))
 	}
+	if m.AcceptZstd {
+		n += 2
+	}
 	return n
 }
 
@@ -851,6 +910,13 @@ func (m *ChunkResponse) Size() (n int) {
 	if m.Missing {
 		n += 2
 	}
+	if m.Zstd {
+		n += 2
+	}
+	l = len(m.Checksum)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1361,6 +1427,26 @@ func (m *ChunkRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AcceptZstd", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AcceptZstd = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1522,6 +1608,60 @@ func (m *ChunkResponse) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Missing = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Zstd", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Zstd = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Checksum = append(m.Checksum[:0], dAtA[iNdEx:postIndex]...)
+			if m.Checksum == nil {
+				m.Checksum = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -31,6 +31,7 @@ type Evidence struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Evidence_DuplicateVoteEvidence
 	//	*Evidence_LightClientAttackEvidence
+	//	*Evidence_Bn254DuplicateVoteEvidence
 	Sum isEvidence_Sum `protobuf_oneof:"sum"`
 }
 
@@ -79,9 +80,13 @@ type Evidence_DuplicateVoteEvidence struct {
 type Evidence_LightClientAttackEvidence struct {
 	LightClientAttackEvidence *LightClientAttackEvidence `protobuf:"bytes,2,opt,name=light_client_attack_evidence,json=lightClientAttackEvidence,proto3,oneof" json:"light_client_attack_evidence,omitempty"`
 }
+type Evidence_Bn254DuplicateVoteEvidence struct {
+	Bn254DuplicateVoteEvidence *DuplicateVoteEvidence `protobuf:"bytes,3,opt,name=bn254_duplicate_vote_evidence,json=bn254DuplicateVoteEvidence,proto3,oneof" json:"bn254_duplicate_vote_evidence,omitempty"`
+}
 
-func (*Evidence_DuplicateVoteEvidence) isEvidence_Sum()     {}
-func (*Evidence_LightClientAttackEvidence) isEvidence_Sum() {}
+func (*Evidence_DuplicateVoteEvidence) isEvidence_Sum()      {}
+func (*Evidence_LightClientAttackEvidence) isEvidence_Sum()  {}
+func (*Evidence_Bn254DuplicateVoteEvidence) isEvidence_Sum() {}
 
 func (m *Evidence) GetSum() isEvidence_Sum {
 	if m != nil {
@@ -104,11 +109,19 @@ func (m *Evidence) GetLightClientAttackEvidence() *LightClientAttackEvidence {
 	return nil
 }
 
+func (m *Evidence) GetBn254DuplicateVoteEvidence() *DuplicateVoteEvidence {
+	if x, ok := m.GetSum().(*Evidence_Bn254DuplicateVoteEvidence); ok {
+		return x.Bn254DuplicateVoteEvidence
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Evidence) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*Evidence_DuplicateVoteEvidence)(nil),
 		(*Evidence_LightClientAttackEvidence)(nil),
+		(*Evidence_Bn254DuplicateVoteEvidence)(nil),
 	}
 }
 
@@ -196,6 +209,8 @@ type LightClientAttackEvidence struct {
 	ByzantineValidators []*Validator `protobuf:"bytes,3,rep,name=byzantine_validators,json=byzantineValidators,proto3" json:"byzantine_validators,omitempty"`
 	TotalVotingPower    int64        `protobuf:"varint,4,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
 	Timestamp           time.Time    `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	// the aggregated commit of the conflicting block, whose commit is then empty
+	ConflictingAggregatedCommit *AggregatedCommit `protobuf:"bytes,6,opt,name=conflicting_aggregated_commit,json=conflictingAggregatedCommit,proto3" json:"conflicting_aggregated_commit,omitempty"`
 }
 
 func (m *LightClientAttackEvidence) Reset()         { *m = LightClientAttackEvidence{} }
@@ -266,6 +281,13 @@ func (m *LightClientAttackEvidence) GetTimestamp() time.Time {
 	return time.Time{}
 }
 
+func (m *LightClientAttackEvidence) GetConflictingAggregatedCommit() *AggregatedCommit {
+	if m != nil {
+		return m.ConflictingAggregatedCommit
+	}
+	return nil
+}
+
 type EvidenceList struct {
 	Evidence []Evidence `protobuf:"bytes,1,rep,name=evidence,proto3" json:"evidence"`
 }
@@ -320,41 +342,44 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/evidence.proto", fileDescriptor_6825fabc78e0a168) }
 
 var fileDescriptor_6825fabc78e0a168 = []byte{
-	// 533 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xcf, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0xe3, 0x38, 0xa9, 0xc2, 0xb6, 0x40, 0x58, 0x5a, 0x48, 0x43, 0xe4, 0x44, 0xe1, 0xd0,
-	0x48, 0x80, 0x2d, 0xb5, 0x57, 0x2e, 0x35, 0x20, 0x15, 0x29, 0x20, 0x64, 0xa1, 0x1e, 0xb8, 0x58,
-	0xeb, 0xcd, 0xc6, 0x59, 0xd5, 0xde, 0x8d, 0xe2, 0x49, 0x50, 0x79, 0x8a, 0x3c, 0x56, 0x2f, 0x48,
-	0x3d, 0x72, 0x02, 0x94, 0xf0, 0x20, 0xc8, 0xeb, 0x3f, 0x89, 0xea, 0x98, 0x13, 0x97, 0xc8, 0x99,
-	0xf9, 0x7d, 0x3b, 0x33, 0x9f, 0x67, 0x8d, 0xba, 0xc0, 0xc4, 0x88, 0xcd, 0x42, 0x2e, 0xc0, 0x82,
-	0xeb, 0x29, 0x8b, 0x2c, 0xb6, 0xe0, 0x23, 0x26, 0x28, 0x33, 0xa7, 0x33, 0x09, 0x12, 0x37, 0x37,
-	0x80, 0xa9, 0x80, 0xf6, 0xa1, 0x2f, 0x7d, 0xa9, 0x92, 0x56, 0xfc, 0x94, 0x70, 0xed, 0xae, 0x2f,
-	0xa5, 0x1f, 0x30, 0x4b, 0xfd, 0xf3, 0xe6, 0x63, 0x0b, 0x78, 0xc8, 0x22, 0x20, 0xe1, 0x34, 0x05,
-	0x3a, 0x85, 0x4a, 0xea, 0x37, 0xcd, 0xf6, 0x0a, 0xd9, 0x05, 0x09, 0xf8, 0x88, 0x80, 0x9c, 0x25,
-	0x44, 0xff, 0x8f, 0x86, 0x1a, 0xef, 0xd2, 0xde, 0x30, 0x41, 0x4f, 0x47, 0xf3, 0x69, 0xc0, 0x29,
-	0x01, 0xe6, 0x2e, 0x24, 0x30, 0x37, 0x6b, 0xbb, 0xa5, 0xf5, 0xb4, 0xc1, 0xfe, 0xe9, 0x89, 0x79,
-	0xb7, 0x6f, 0xf3, 0x6d, 0x26, 0xb8, 0x94, 0xc0, 0xb2, 0x93, 0x2e, 0x2a, 0xce, 0xd1, 0x68, 0x57,
-	0x02, 0x0b, 0xd4, 0x09, 0xb8, 0x3f, 0x01, 0x97, 0x06, 0x9c, 0x09, 0x70, 0x09, 0x00, 0xa1, 0x57,
-	0x9b, 0x3a, 0x55, 0x55, 0xe7, 0x45, 0xb1, 0xce, 0x30, 0x56, 0xbd, 0x51, 0xa2, 0x73, 0xa5, 0xd9,
-	0xaa, 0x75, 0x1c, 0x94, 0x25, 0xed, 0x3a, 0xd2, 0xa3, 0x79, 0xd8, 0x5f, 0x56, 0xd1, 0xd1, 0xce,
-	0x4e, 0xf1, 0x2b, 0xb4, 0xa7, 0x26, 0x25, 0xe9, 0x88, 0x4f, 0x8a, 0xa5, 0x63, 0xde, 0xa9, 0xc7,
-	0xd4, 0x79, 0x8e, 0x7b, 0x69, 0xa7, 0xff, 0xc4, 0x6d, 0xfc, 0x12, 0x61, 0x90, 0x40, 0x82, 0xd8,
-	0x4d, 0x2e, 0x7c, 0x77, 0x2a, 0xbf, 0xb2, 0x59, 0x4b, 0xef, 0x69, 0x03, 0xdd, 0x69, 0xaa, 0xcc,
-	0xa5, 0x4a, 0x7c, 0x8a, 0xe3, 0xf8, 0x04, 0x3d, 0xcc, 0xdf, 0x4f, 0x8a, 0xd6, 0x14, 0xfa, 0x20,
-	0x0f, 0x27, 0xa0, 0x8d, 0xee, 0xe5, 0x8b, 0xd0, 0xaa, 0xab, 0x46, 0xda, 0x66, 0xb2, 0x2a, 0x66,
-	0xb6, 0x2a, 0xe6, 0xe7, 0x8c, 0xb0, 0x1b, 0x37, 0x3f, 0xbb, 0x95, 0xe5, 0xaf, 0xae, 0xe6, 0x6c,
-	0x64, 0xfd, 0xef, 0x55, 0x74, 0x5c, 0x6a, 0x2a, 0x7e, 0x8f, 0x1e, 0x51, 0x29, 0xc6, 0x01, 0xa7,
-	0xaa, 0x6f, 0x2f, 0x90, 0xf4, 0x2a, 0x75, 0xa8, 0x53, 0xf2, 0x72, 0xec, 0x98, 0x71, 0x9a, 0x5b,
-	0x32, 0x15, 0xc1, 0xcf, 0xd1, 0x7d, 0x2a, 0xc3, 0x50, 0x0a, 0x77, 0xc2, 0x62, 0x4e, 0x39, 0xa7,
-	0x3b, 0x07, 0x49, 0xf0, 0x42, 0xc5, 0xf0, 0x47, 0x74, 0xe8, 0x5d, 0x7f, 0x23, 0x02, 0xb8, 0x60,
-	0x6e, 0x3e, 0x6d, 0xd4, 0xd2, 0x7b, 0xfa, 0x60, 0xff, 0xf4, 0xd9, 0x0e, 0x97, 0x33, 0xc6, 0x79,
-	0x9c, 0x0b, 0xf3, 0x58, 0x54, 0x62, 0x7c, 0xad, 0xc4, 0xf8, 0xff, 0xe1, 0xe7, 0x10, 0x1d, 0x64,
-	0xee, 0x0d, 0x79, 0x04, 0xf8, 0x35, 0x6a, 0x6c, 0xdd, 0x1e, 0x5d, 0x1d, 0x59, 0x98, 0x22, 0xdf,
-	0xd3, 0x5a, 0x7c, 0xa4, 0x93, 0x2b, 0xec, 0x0f, 0x37, 0x2b, 0x43, 0xbb, 0x5d, 0x19, 0xda, 0xef,
-	0x95, 0xa1, 0x2d, 0xd7, 0x46, 0xe5, 0x76, 0x6d, 0x54, 0x7e, 0xac, 0x8d, 0xca, 0x97, 0x33, 0x9f,
-	0xc3, 0x64, 0xee, 0x99, 0x54, 0x86, 0x16, 0x95, 0x21, 0x03, 0x6f, 0x0c, 0x9b, 0x87, 0xe4, 0x0b,
-	0x72, 0xf7, 0xda, 0x7b, 0x7b, 0x2a, 0x7e, 0xf6, 0x37, 0x00, 0x00, 0xff, 0xff, 0xab, 0xbe, 0xb8,
-	0x21, 0x99, 0x04, 0x00, 0x00,
+	// 586 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xcf, 0x6e, 0xd3, 0x4e,
+	0x10, 0xc7, 0xe3, 0x38, 0x89, 0xf2, 0xdb, 0xf6, 0x07, 0x61, 0x69, 0x21, 0x4d, 0x53, 0x27, 0x0a,
+	0x87, 0x56, 0x02, 0x6c, 0x29, 0x85, 0x1b, 0x97, 0xb8, 0x20, 0x15, 0x29, 0x20, 0x64, 0xa1, 0x1e,
+	0xb8, 0x58, 0x6b, 0x7b, 0xe3, 0xac, 0x6a, 0x7b, 0xa3, 0x78, 0x12, 0x54, 0x9e, 0x22, 0x2f, 0xc3,
+	0x3b, 0xf4, 0x58, 0x71, 0xe2, 0x04, 0x28, 0x79, 0x11, 0xe4, 0xf5, 0x9f, 0x44, 0x71, 0x2c, 0x84,
+	0xc4, 0x25, 0x72, 0xe6, 0xfb, 0x99, 0x9d, 0x99, 0xaf, 0xc7, 0x8b, 0x3a, 0x40, 0x03, 0x87, 0x4e,
+	0x7d, 0x16, 0x80, 0x06, 0x37, 0x13, 0x1a, 0x6a, 0x74, 0xce, 0x1c, 0x1a, 0xd8, 0x54, 0x9d, 0x4c,
+	0x39, 0x70, 0xdc, 0x58, 0x03, 0xaa, 0x00, 0x5a, 0x07, 0x2e, 0x77, 0xb9, 0x10, 0xb5, 0xe8, 0x29,
+	0xe6, 0x5a, 0x1d, 0x97, 0x73, 0xd7, 0xa3, 0x9a, 0xf8, 0x67, 0xcd, 0x46, 0x1a, 0x30, 0x9f, 0x86,
+	0x40, 0xfc, 0x49, 0x02, 0xb4, 0x73, 0x95, 0xc4, 0x6f, 0xa2, 0x76, 0x73, 0xea, 0x9c, 0x78, 0xcc,
+	0x21, 0xc0, 0xa7, 0x31, 0xd1, 0xfb, 0x56, 0x46, 0xf5, 0x37, 0x49, 0x6f, 0x98, 0xa0, 0xc7, 0xce,
+	0x6c, 0xe2, 0x31, 0x9b, 0x00, 0x35, 0xe7, 0x1c, 0xa8, 0x99, 0xb6, 0xdd, 0x94, 0xba, 0xd2, 0xd9,
+	0x5e, 0xff, 0x54, 0xdd, 0xee, 0x5b, 0x7d, 0x9d, 0x26, 0x5c, 0x71, 0xa0, 0xe9, 0x49, 0x97, 0x25,
+	0xe3, 0xd0, 0xd9, 0x25, 0xe0, 0x00, 0xb5, 0x3d, 0xe6, 0x8e, 0xc1, 0xb4, 0x3d, 0x46, 0x03, 0x30,
+	0x09, 0x00, 0xb1, 0xaf, 0xd7, 0x75, 0xca, 0xa2, 0xce, 0xd3, 0x7c, 0x9d, 0x61, 0x94, 0x75, 0x21,
+	0x92, 0x06, 0x22, 0x67, 0xa3, 0xd6, 0x91, 0x57, 0x24, 0x62, 0x0f, 0x9d, 0x58, 0x41, 0xff, 0xe5,
+	0x0b, 0xb3, 0x68, 0x30, 0xf9, 0x6f, 0x07, 0x6b, 0x89, 0xf3, 0x76, 0xaa, 0x7a, 0x15, 0xc9, 0xe1,
+	0xcc, 0xef, 0x2d, 0xca, 0xe8, 0x70, 0x27, 0x80, 0x9f, 0xa3, 0x9a, 0x28, 0x4f, 0x12, 0x43, 0x1f,
+	0xe5, 0xeb, 0x46, 0xbc, 0x51, 0x8d, 0xa8, 0x41, 0x86, 0x5b, 0xcd, 0xf2, 0x9f, 0x71, 0x1d, 0x3f,
+	0x43, 0x18, 0x38, 0x10, 0x2f, 0x1a, 0x91, 0x05, 0xae, 0x39, 0xe1, 0x9f, 0xe9, 0x54, 0x4c, 0x28,
+	0x1b, 0x0d, 0xa1, 0x5c, 0x09, 0xe1, 0x43, 0x14, 0xc7, 0xa7, 0xe8, 0x7e, 0xb6, 0x0d, 0x09, 0x5a,
+	0x11, 0xe8, 0xbd, 0x2c, 0x1c, 0x83, 0x3a, 0xfa, 0x2f, 0x5b, 0xbb, 0x66, 0x55, 0x34, 0xd2, 0x52,
+	0xe3, 0xc5, 0x54, 0xd3, 0xc5, 0x54, 0x3f, 0xa6, 0x84, 0x5e, 0xbf, 0xfd, 0xd1, 0x29, 0x2d, 0x7e,
+	0x76, 0x24, 0x63, 0x9d, 0xd6, 0xfb, 0x2a, 0xa3, 0xa3, 0xc2, 0x57, 0x88, 0xdf, 0xa2, 0x07, 0x36,
+	0x0f, 0x46, 0x1e, 0xb3, 0x45, 0xdf, 0x96, 0xc7, 0xed, 0xeb, 0xc4, 0xa1, 0x76, 0xc1, 0x2a, 0xe8,
+	0x11, 0x63, 0x34, 0x36, 0xd2, 0x44, 0x04, 0x3f, 0x41, 0xff, 0xdb, 0xdc, 0xf7, 0x79, 0x60, 0x8e,
+	0x69, 0xc4, 0x09, 0xe7, 0x64, 0x63, 0x3f, 0x0e, 0x5e, 0x8a, 0x18, 0x7e, 0x8f, 0x0e, 0xac, 0x9b,
+	0x2f, 0x24, 0x00, 0x16, 0x50, 0x33, 0x9b, 0x36, 0x6c, 0xca, 0x5d, 0xf9, 0x6c, 0xaf, 0x7f, 0xbc,
+	0xc3, 0xe5, 0x94, 0x31, 0x1e, 0x66, 0x89, 0x59, 0x2c, 0x2c, 0x30, 0xbe, 0x52, 0x60, 0xfc, 0x3f,
+	0xf0, 0x13, 0x8f, 0xd0, 0xc9, 0xa6, 0x63, 0xc4, 0x75, 0xa7, 0xd4, 0x25, 0x40, 0x1d, 0x33, 0x1a,
+	0x94, 0x41, 0xb3, 0x26, 0xce, 0xed, 0xe5, 0x47, 0x19, 0x64, 0xe8, 0x85, 0x20, 0x8d, 0xe3, 0x8d,
+	0x83, 0xb6, 0xc5, 0xde, 0x10, 0xed, 0xa7, 0x6f, 0x69, 0xc8, 0x42, 0xc0, 0xaf, 0x50, 0x7d, 0xe3,
+	0x4e, 0x90, 0x45, 0xeb, 0xb9, 0x12, 0xd9, 0xf7, 0x50, 0x89, 0x5a, 0x37, 0xb2, 0x0c, 0xfd, 0xdd,
+	0xed, 0x52, 0x91, 0xee, 0x96, 0x8a, 0xf4, 0x6b, 0xa9, 0x48, 0x8b, 0x95, 0x52, 0xba, 0x5b, 0x29,
+	0xa5, 0xef, 0x2b, 0xa5, 0xf4, 0xe9, 0xdc, 0x65, 0x30, 0x9e, 0x59, 0xaa, 0xcd, 0x7d, 0xcd, 0xe6,
+	0x3e, 0x05, 0x6b, 0x04, 0xeb, 0x87, 0xf8, 0x5e, 0xdc, 0xbe, 0xcc, 0xac, 0x9a, 0x88, 0x9f, 0xff,
+	0x1e, 0x00, 0xe4, 0x2d, 0x06, 0xa1, 0x6f, 0x05, 0x00, 0x00,
 }
 
 func (m *Evidence) Marshal() (dAtA []byte, err error) {
@@ -431,6 +456,27 @@ func (m *Evidence_LightClientAttackEvidence) MarshalToSizedBuffer(dAtA []byte) (
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Evidence_Bn254DuplicateVoteEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Evidence_Bn254DuplicateVoteEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Bn254DuplicateVoteEvidence != nil {
+		{
+			size, err := m.Bn254DuplicateVoteEvidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvidence(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *DuplicateVoteEvidence) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -451,12 +497,12 @@ func (m *DuplicateVoteEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	n3, err3 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err3 != nil {
-		return 0, err3
+	n4, err4 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err4 != nil {
+		return 0, err4
 	}
-	i -= n3
-	i = encodeVarintEvidence(dAtA, i, uint64(n3))
+	i -= n4
+	i = encodeVarintEvidence(dAtA, i, uint64(n4))
 	i--
 	dAtA[i] = 0x2a
 	if m.ValidatorPower != 0 {
@@ -516,12 +562,24 @@ func (m *LightClientAttackEvidence) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
-	n6, err6 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err6 != nil {
-		return 0, err6
+	if m.ConflictingAggregatedCommit != nil {
+		{
+			size, err := m.ConflictingAggregatedCommit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvidence(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	n8, err8 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err8 != nil {
+		return 0, err8
 	}
-	i -= n6
-	i = encodeVarintEvidence(dAtA, i, uint64(n6))
+	i -= n8
+	i = encodeVarintEvidence(dAtA, i, uint64(n8))
 	i--
 	dAtA[i] = 0x2a
 	if m.TotalVotingPower != 0 {
@@ -647,6 +705,18 @@ func (m *Evidence_LightClientAttackEvidence) Size() (n int) {
 	}
 	return n
 }
+func (m *Evidence_Bn254DuplicateVoteEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Bn254DuplicateVoteEvidence != nil {
+		l = m.Bn254DuplicateVoteEvidence.Size()
+		n += 1 + l + sovEvidence(uint64(l))
+	}
+	return n
+}
 func (m *DuplicateVoteEvidence) Size() (n int) {
 	if m == nil {
 		return 0
@@ -696,6 +766,10 @@ func (m *LightClientAttackEvidence) Size() (n int) {
 	}
 	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
 	n += 1 + l + sovEvidence(uint64(l))
+	if m.ConflictingAggregatedCommit != nil {
+		l = m.ConflictingAggregatedCommit.Size()
+		n += 1 + l + sovEvidence(uint64(l))
+	}
 	return n
 }
 
@@ -819,6 +893,41 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Evidence_LightClientAttackEvidence{v}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bn254DuplicateVoteEvidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DuplicateVoteEvidence{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Evidence_Bn254DuplicateVoteEvidence{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvidence(dAtA[iNdEx:])
@@ -1203,6 +1312,42 @@ func (m *LightClientAttackEvidence) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConflictingAggregatedCommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConflictingAggregatedCommit == nil {
+				m.ConflictingAggregatedCommit = &AggregatedCommit{}
+			}
+			if err := m.ConflictingAggregatedCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvidence(dAtA[iNdEx:])
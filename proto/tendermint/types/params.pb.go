@@ -34,6 +34,7 @@ type ConsensusParams struct {
 	Evidence  *EvidenceParams  `protobuf:"bytes,2,opt,name=evidence,proto3" json:"evidence,omitempty"`
 	Validator *ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
 	Version   *VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Encoding  *EncodingParams  `protobuf:"bytes,5,opt,name=encoding,proto3" json:"encoding,omitempty"`
 }
 
 func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
@@ -97,6 +98,13 @@ func (m *ConsensusParams) GetVersion() *VersionParams {
 	return nil
 }
 
+func (m *ConsensusParams) GetEncoding() *EncodingParams {
+	if m != nil {
+		return m.Encoding
+	}
+	return nil
+}
+
 // BlockParams contains limits on the block size.
 type BlockParams struct {
 	// Max block size, in bytes.
@@ -171,6 +179,10 @@ type EvidenceParams struct {
 	// and should fall comfortably under the max block bytes.
 	// Default is 1048576 or 1MB
 	MaxBytes int64 `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// The types of evidence which can't be committed: "duplicate_vote",
+	// "bn254_duplicate_vote" or "light_client_attack".
+	// Default is none.
+	DisabledTypes []string `protobuf:"bytes,4,rep,name=disabled_types,json=disabledTypes,proto3" json:"disabled_types,omitempty"`
 }
 
 func (m *EvidenceParams) Reset()         { *m = EvidenceParams{} }
@@ -227,6 +239,13 @@ func (m *EvidenceParams) GetMaxBytes() int64 {
 	return 0
 }
 
+func (m *EvidenceParams) GetDisabledTypes() []string {
+	if m != nil {
+		return m.DisabledTypes
+	}
+	return nil
+}
+
 // ValidatorParams restrict the public key types validators can use.
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
@@ -318,6 +337,55 @@ func (m *VersionParams) GetApp() uint64 {
 	return 0
 }
 
+// EncodingParams select the canonical encoding of the votes, headers and
+// validator sets, which are signed or hashed in consensus. They are fixed by
+// the genesis, and cannot be updated.
+type EncodingParams struct {
+	// "proto" (the default) or "fixed-width", a circuit-friendly encoding made
+	// of fixed-width fields, only available to bn254 validators.
+	Canonical string `protobuf:"bytes,1,opt,name=canonical,proto3" json:"canonical,omitempty"`
+}
+
+func (m *EncodingParams) Reset()         { *m = EncodingParams{} }
+func (m *EncodingParams) String() string { return proto.CompactTextString(m) }
+func (*EncodingParams) ProtoMessage()    {}
+func (*EncodingParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e12598271a686f57, []int{5}
+}
+func (m *EncodingParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EncodingParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EncodingParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EncodingParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncodingParams.Merge(m, src)
+}
+func (m *EncodingParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *EncodingParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncodingParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncodingParams proto.InternalMessageInfo
+
+func (m *EncodingParams) GetCanonical() string {
+	if m != nil {
+		return m.Canonical
+	}
+	return ""
+}
+
 // HashedParams is a subset of ConsensusParams.
 //
 // It is hashed into the Header.ConsensusHash.
@@ -330,7 +398,7 @@ func (m *HashedParams) Reset()         { *m = HashedParams{} }
 func (m *HashedParams) String() string { return proto.CompactTextString(m) }
 func (*HashedParams) ProtoMessage()    {}
 func (*HashedParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e12598271a686f57, []int{5}
+	return fileDescriptor_e12598271a686f57, []int{6}
 }
 func (m *HashedParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -379,46 +447,50 @@ func init() {
 	proto.RegisterType((*EvidenceParams)(nil), "tendermint.types.EvidenceParams")
 	proto.RegisterType((*ValidatorParams)(nil), "tendermint.types.ValidatorParams")
 	proto.RegisterType((*VersionParams)(nil), "tendermint.types.VersionParams")
+	proto.RegisterType((*EncodingParams)(nil), "tendermint.types.EncodingParams")
 	proto.RegisterType((*HashedParams)(nil), "tendermint.types.HashedParams")
 }
 
 func init() { proto.RegisterFile("tendermint/types/params.proto", fileDescriptor_e12598271a686f57) }
 
 var fileDescriptor_e12598271a686f57 = []byte{
-	// 515 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x4f, 0x6f, 0xd3, 0x30,
-	0x18, 0xc6, 0xeb, 0xa5, 0x6c, 0xed, 0x5b, 0xba, 0x56, 0x16, 0x12, 0x61, 0x68, 0x49, 0xc9, 0x01,
-	0x4d, 0x9a, 0x94, 0x48, 0xf4, 0x04, 0x42, 0x9a, 0x28, 0xa0, 0xf1, 0x47, 0x43, 0x10, 0x21, 0x0e,
-	0xbb, 0x44, 0x4e, 0xe3, 0x65, 0xd1, 0xea, 0x38, 0x8a, 0x9d, 0xaa, 0xbd, 0xf1, 0x11, 0x38, 0x72,
-	0xdc, 0x11, 0xbe, 0x01, 0x1f, 0x61, 0xc7, 0x1d, 0x39, 0x01, 0x6a, 0x2f, 0x7c, 0x0c, 0x14, 0x27,
-	0x21, 0x6b, 0xb7, 0x9b, 0xe3, 0xe7, 0xf7, 0xd8, 0x79, 0x9e, 0x57, 0x86, 0x5d, 0x49, 0xe3, 0x80,
-	0xa6, 0x2c, 0x8a, 0xa5, 0x23, 0xe7, 0x09, 0x15, 0x4e, 0x42, 0x52, 0xc2, 0x84, 0x9d, 0xa4, 0x5c,
-	0x72, 0xdc, 0xaf, 0x65, 0x5b, 0xc9, 0x3b, 0x77, 0x42, 0x1e, 0x72, 0x25, 0x3a, 0xf9, 0xaa, 0xe0,
-	0x76, 0x8c, 0x90, 0xf3, 0x70, 0x42, 0x1d, 0xf5, 0xe5, 0x67, 0x27, 0x4e, 0x90, 0xa5, 0x44, 0x46,
-	0x3c, 0x2e, 0x74, 0xeb, 0xf3, 0x06, 0xf4, 0x9e, 0xf3, 0x58, 0xd0, 0x58, 0x64, 0xe2, 0xbd, 0xba,
-	0x01, 0x0f, 0xe1, 0x96, 0x3f, 0xe1, 0xe3, 0x33, 0x1d, 0x0d, 0xd0, 0x5e, 0xe7, 0xd1, 0xae, 0xbd,
-	0x7e, 0x97, 0x3d, 0xca, 0xe5, 0x82, 0x76, 0x0b, 0x16, 0x3f, 0x85, 0x16, 0x9d, 0x46, 0x01, 0x8d,
-	0xc7, 0x54, 0xdf, 0x50, 0xbe, 0xc1, 0x75, 0xdf, 0xcb, 0x92, 0x28, 0xad, 0xff, 0x1d, 0xf8, 0x00,
-	0xda, 0x53, 0x32, 0x89, 0x02, 0x22, 0x79, 0xaa, 0x6b, 0xca, 0xfe, 0xe0, 0xba, 0xfd, 0x53, 0x85,
-	0x94, 0xfe, 0xda, 0x83, 0x1f, 0xc3, 0xd6, 0x94, 0xa6, 0x22, 0xe2, 0xb1, 0xde, 0x54, 0x76, 0xf3,
-	0x06, 0x7b, 0x01, 0x94, 0xe6, 0x8a, 0xb7, 0x5e, 0x43, 0xe7, 0x4a, 0x1e, 0x7c, 0x1f, 0xda, 0x8c,
-	0xcc, 0x3c, 0x7f, 0x2e, 0xa9, 0x50, 0x0d, 0x68, 0x6e, 0x8b, 0x91, 0xd9, 0x28, 0xff, 0xc6, 0x77,
-	0x61, 0x2b, 0x17, 0x43, 0x22, 0x54, 0x48, 0xcd, 0xdd, 0x64, 0x64, 0x76, 0x48, 0xc4, 0x9b, 0x66,
-	0x4b, 0xeb, 0x37, 0xad, 0xef, 0x08, 0xb6, 0x57, 0x33, 0xe2, 0x7d, 0xc0, 0xb9, 0x83, 0x84, 0xd4,
-	0x8b, 0x33, 0xe6, 0xa9, 0xb2, 0xaa, 0x73, 0x7b, 0x8c, 0xcc, 0x9e, 0x85, 0xf4, 0x5d, 0xc6, 0xd4,
-	0x0f, 0x08, 0x7c, 0x04, 0xfd, 0x0a, 0xae, 0xe6, 0x54, 0x96, 0x79, 0xcf, 0x2e, 0x06, 0x69, 0x57,
-	0x83, 0xb4, 0x5f, 0x94, 0xc0, 0xa8, 0x75, 0xf1, 0xcb, 0x6c, 0x7c, 0xfd, 0x6d, 0x22, 0x77, 0xbb,
-	0x38, 0xaf, 0x52, 0x56, 0xa3, 0x68, 0xab, 0x51, 0xac, 0x03, 0xe8, 0xad, 0xf5, 0x89, 0x2d, 0xe8,
-	0x26, 0x99, 0xef, 0x9d, 0xd1, 0xb9, 0xa7, 0x1a, 0xd3, 0xd1, 0x40, 0xdb, 0x6b, 0xbb, 0x9d, 0x24,
-	0xf3, 0xdf, 0xd2, 0xf9, 0xc7, 0x7c, 0xeb, 0x49, 0xeb, 0xc7, 0xb9, 0x89, 0xfe, 0x9e, 0x9b, 0xc8,
-	0xda, 0x87, 0xee, 0x4a, 0xa3, 0xb8, 0x0f, 0x1a, 0x49, 0x12, 0x95, 0xad, 0xe9, 0xe6, 0xcb, 0x2b,
-	0xf0, 0x31, 0xdc, 0x7e, 0x45, 0xc4, 0x29, 0x0d, 0x4a, 0xf6, 0x21, 0xf4, 0x54, 0x15, 0xde, 0x7a,
-	0xd7, 0x5d, 0xb5, 0x7d, 0x54, 0x15, 0x6e, 0x41, 0xb7, 0xe6, 0xea, 0xda, 0x3b, 0x15, 0x75, 0x48,
-	0xc4, 0xe8, 0xc3, 0xb7, 0x85, 0x81, 0x2e, 0x16, 0x06, 0xba, 0x5c, 0x18, 0xe8, 0xcf, 0xc2, 0x40,
-	0x5f, 0x96, 0x46, 0xe3, 0x72, 0x69, 0x34, 0x7e, 0x2e, 0x8d, 0xc6, 0xf1, 0x30, 0x8c, 0xe4, 0x69,
-	0xe6, 0xdb, 0x63, 0xce, 0x9c, 0x31, 0x67, 0x54, 0xfa, 0x27, 0xb2, 0x5e, 0x14, 0x0f, 0x66, 0xfd,
-	0xad, 0xf9, 0x9b, 0x6a, 0x7f, 0xf8, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x3c, 0xfc, 0x4b, 0xf8, 0x86,
-	0x03, 0x00, 0x00,
+	// 569 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0x3f, 0x6f, 0xd3, 0x40,
+	0x18, 0xc6, 0x73, 0x75, 0xda, 0x26, 0x6f, 0xc8, 0x1f, 0x9d, 0x90, 0x30, 0x85, 0x3a, 0xc5, 0x12,
+	0xa8, 0x52, 0x25, 0x5b, 0x22, 0x0b, 0x20, 0xa4, 0x8a, 0x00, 0x2a, 0x7f, 0x54, 0x04, 0x16, 0x62,
+	0xe8, 0x62, 0x9d, 0xed, 0xab, 0x6b, 0x35, 0xbe, 0xb3, 0x7c, 0x76, 0x94, 0x7c, 0x0b, 0x46, 0xc6,
+	0x8e, 0x7c, 0x04, 0x26, 0xe6, 0x8e, 0x19, 0x99, 0x00, 0x25, 0x0b, 0x1f, 0x03, 0xf9, 0x6c, 0xd7,
+	0x49, 0x0a, 0xdb, 0xdd, 0xfb, 0x3e, 0x3f, 0xdf, 0x7b, 0xcf, 0x73, 0x09, 0xec, 0x26, 0x94, 0x79,
+	0x34, 0x0e, 0x03, 0x96, 0x98, 0xc9, 0x34, 0xa2, 0xc2, 0x8c, 0x48, 0x4c, 0x42, 0x61, 0x44, 0x31,
+	0x4f, 0x38, 0xee, 0x55, 0x6d, 0x43, 0xb6, 0x77, 0x6e, 0xfa, 0xdc, 0xe7, 0xb2, 0x69, 0x66, 0xab,
+	0x5c, 0xb7, 0xa3, 0xf9, 0x9c, 0xfb, 0x23, 0x6a, 0xca, 0x9d, 0x93, 0x9e, 0x9a, 0x5e, 0x1a, 0x93,
+	0x24, 0xe0, 0x2c, 0xef, 0xeb, 0xdf, 0x37, 0xa0, 0xfb, 0x9c, 0x33, 0x41, 0x99, 0x48, 0xc5, 0x7b,
+	0x79, 0x02, 0x1e, 0xc0, 0xa6, 0x33, 0xe2, 0xee, 0xb9, 0x8a, 0xf6, 0xd0, 0x7e, 0xeb, 0xe1, 0xae,
+	0xb1, 0x7e, 0x96, 0x31, 0xcc, 0xda, 0xb9, 0xda, 0xca, 0xb5, 0xf8, 0x29, 0x34, 0xe8, 0x38, 0xf0,
+	0x28, 0x73, 0xa9, 0xba, 0x21, 0xb9, 0xbd, 0xeb, 0xdc, 0xcb, 0x42, 0x51, 0xa0, 0x57, 0x04, 0x3e,
+	0x84, 0xe6, 0x98, 0x8c, 0x02, 0x8f, 0x24, 0x3c, 0x56, 0x15, 0x89, 0xdf, 0xbb, 0x8e, 0x7f, 0x2a,
+	0x25, 0x05, 0x5f, 0x31, 0xf8, 0x31, 0x6c, 0x8f, 0x69, 0x2c, 0x02, 0xce, 0xd4, 0xba, 0xc4, 0xfb,
+	0xff, 0xc0, 0x73, 0x41, 0x01, 0x97, 0x7a, 0x39, 0x39, 0x73, 0xb9, 0x17, 0x30, 0x5f, 0xdd, 0xfc,
+	0xef, 0xe4, 0x85, 0xe2, 0x6a, 0xf2, 0x62, 0xaf, 0xbf, 0x86, 0xd6, 0x92, 0x1b, 0xf8, 0x0e, 0x34,
+	0x43, 0x32, 0xb1, 0x9d, 0x69, 0x42, 0x85, 0xf4, 0x4f, 0xb1, 0x1a, 0x21, 0x99, 0x0c, 0xb3, 0x3d,
+	0xbe, 0x05, 0xdb, 0x59, 0xd3, 0x27, 0x42, 0x5a, 0xa4, 0x58, 0x5b, 0x21, 0x99, 0x1c, 0x11, 0xf1,
+	0xa6, 0xde, 0x50, 0x7a, 0x75, 0x7d, 0x86, 0xa0, 0xb3, 0xea, 0x10, 0x3e, 0x00, 0x9c, 0x11, 0xc4,
+	0xa7, 0x36, 0x4b, 0x43, 0x5b, 0x5a, 0x5d, 0x7e, 0xb7, 0x1b, 0x92, 0xc9, 0x33, 0x9f, 0xbe, 0x4b,
+	0x43, 0x39, 0x80, 0xc0, 0xc7, 0xd0, 0x2b, 0xc5, 0x65, 0xca, 0x45, 0x14, 0xb7, 0x8d, 0xfc, 0x19,
+	0x18, 0xe5, 0x33, 0x30, 0x5e, 0x14, 0x82, 0x61, 0xe3, 0xf2, 0x67, 0xbf, 0xf6, 0xe5, 0x57, 0x1f,
+	0x59, 0x9d, 0xfc, 0x7b, 0x65, 0x67, 0xf5, 0x2a, 0xca, 0xda, 0x55, 0xee, 0x43, 0xc7, 0x0b, 0x04,
+	0x71, 0x46, 0xd4, 0xb3, 0xa5, 0x43, 0x6a, 0x7d, 0x4f, 0xd9, 0x6f, 0x5a, 0xed, 0xb2, 0xfa, 0x31,
+	0x2b, 0xea, 0x87, 0xd0, 0x5d, 0x0b, 0x0d, 0xeb, 0xd0, 0x8e, 0x52, 0xc7, 0x3e, 0xa7, 0xd3, 0x02,
+	0x44, 0x12, 0x6c, 0x45, 0xa9, 0xf3, 0x96, 0x4e, 0x25, 0xf6, 0xa4, 0xf1, 0xed, 0xa2, 0x8f, 0xfe,
+	0x5c, 0xf4, 0x91, 0x7e, 0x00, 0xed, 0x95, 0xd8, 0x70, 0x0f, 0x14, 0x12, 0x45, 0xd2, 0x82, 0xba,
+	0x95, 0x2d, 0x97, 0xc4, 0x8f, 0xa0, 0xb3, 0x9a, 0x13, 0xbe, 0x0b, 0x4d, 0x97, 0x30, 0xce, 0x02,
+	0x97, 0x8c, 0x24, 0xd3, 0xb4, 0xaa, 0xc2, 0x12, 0x79, 0x02, 0x37, 0x5e, 0x11, 0x71, 0x46, 0xbd,
+	0x82, 0x7b, 0x00, 0x5d, 0xe9, 0xb5, 0xbd, 0x1e, 0x66, 0x5b, 0x96, 0x8f, 0x4b, 0x1b, 0x74, 0x68,
+	0x57, 0xba, 0x2a, 0xd7, 0x56, 0xa9, 0x3a, 0x22, 0x62, 0xf8, 0xe1, 0xeb, 0x5c, 0x43, 0x97, 0x73,
+	0x0d, 0xcd, 0xe6, 0x1a, 0xfa, 0x3d, 0xd7, 0xd0, 0xe7, 0x85, 0x56, 0x9b, 0x2d, 0xb4, 0xda, 0x8f,
+	0x85, 0x56, 0x3b, 0x19, 0xf8, 0x41, 0x72, 0x96, 0x3a, 0x86, 0xcb, 0x43, 0xd3, 0xe5, 0x21, 0x4d,
+	0x9c, 0xd3, 0xa4, 0x5a, 0xe4, 0xbf, 0xe7, 0xf5, 0xbf, 0x02, 0x67, 0x4b, 0xd6, 0x07, 0x7f, 0x07,
+	0x00, 0xa2, 0x01, 0xed, 0x11, 0x25, 0x04, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -452,6 +524,9 @@ func (this *ConsensusParams) Equal(that interface{}) bool {
 	if !this.Version.Equal(that1.Version) {
 		return false
 	}
+	if !this.Encoding.Equal(that1.Encoding) {
+		return false
+	}
 	return true
 }
 func (this *BlockParams) Equal(that interface{}) bool {
@@ -509,6 +584,14 @@ func (this *EvidenceParams) Equal(that interface{}) bool {
 	if this.MaxBytes != that1.MaxBytes {
 		return false
 	}
+	if len(this.DisabledTypes) != len(that1.DisabledTypes) {
+		return false
+	}
+	for i := range this.DisabledTypes {
+		if this.DisabledTypes[i] != that1.DisabledTypes[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *ValidatorParams) Equal(that interface{}) bool {
@@ -564,6 +647,30 @@ func (this *VersionParams) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *EncodingParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EncodingParams)
+	if !ok {
+		that2, ok := that.(EncodingParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Canonical != that1.Canonical {
+		return false
+	}
+	return true
+}
 func (this *HashedParams) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -611,6 +718,18 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Encoding != nil {
+		{
+			size, err := m.Encoding.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.Version != nil {
 		{
 			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
@@ -715,17 +834,26 @@ func (m *EvidenceParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DisabledTypes) > 0 {
+		for iNdEx := len(m.DisabledTypes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DisabledTypes[iNdEx])
+			copy(dAtA[i:], m.DisabledTypes[iNdEx])
+			i = encodeVarintParams(dAtA, i, uint64(len(m.DisabledTypes[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if m.MaxBytes != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.MaxBytes))
 		i--
 		dAtA[i] = 0x18
 	}
-	n5, err5 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MaxAgeDuration):])
-	if err5 != nil {
-		return 0, err5
+	n6, err6 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MaxAgeDuration):])
+	if err6 != nil {
+		return 0, err6
 	}
-	i -= n5
-	i = encodeVarintParams(dAtA, i, uint64(n5))
+	i -= n6
+	i = encodeVarintParams(dAtA, i, uint64(n6))
 	i--
 	dAtA[i] = 0x12
 	if m.MaxAgeNumBlocks != 0 {
@@ -796,6 +924,36 @@ func (m *VersionParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *EncodingParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EncodingParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EncodingParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Canonical) > 0 {
+		i -= len(m.Canonical)
+		copy(dAtA[i:], m.Canonical)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.Canonical)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *HashedParams) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -860,6 +1018,14 @@ func NewPopulatedVersionParams(r randyParams, easy bool) *VersionParams {
 	return this
 }
 
+func NewPopulatedEncodingParams(r randyParams, easy bool) *EncodingParams {
+	this := &EncodingParams{}
+	this.Canonical = string(randStringParams(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
 type randyParams interface {
 	Float32() float32
 	Float64() float64
@@ -954,6 +1120,10 @@ func (m *ConsensusParams) Size() (n int) {
 		l = m.Version.Size()
 		n += 1 + l + sovParams(uint64(l))
 	}
+	if m.Encoding != nil {
+		l = m.Encoding.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
 	return n
 }
 
@@ -986,6 +1156,12 @@ func (m *EvidenceParams) Size() (n int) {
 	if m.MaxBytes != 0 {
 		n += 1 + sovParams(uint64(m.MaxBytes))
 	}
+	if len(m.DisabledTypes) > 0 {
+		for _, s := range m.DisabledTypes {
+			l = len(s)
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1016,6 +1192,19 @@ func (m *VersionParams) Size() (n int) {
 	return n
 }
 
+func (m *EncodingParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Canonical)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	return n
+}
+
 func (m *HashedParams) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1210,6 +1399,42 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encoding", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Encoding == nil {
+				m.Encoding = &EncodingParams{}
+			}
+			if err := m.Encoding.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1419,6 +1644,38 @@ func (m *EvidenceParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisabledTypes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DisabledTypes = append(m.DisabledTypes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1591,6 +1848,88 @@ func (m *VersionParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *EncodingParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EncodingParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EncodingParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Canonical", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Canonical = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *HashedParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -703,6 +703,93 @@ func (m *CommitSig) GetSignature() []byte {
 	return nil
 }
 
+// AggregatedCommit is a Commit whose bn254 signatures for the block are
+// aggregated into a single signature.
+type AggregatedCommit struct {
+	Height  int64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round   int32   `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	BlockID BlockID `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id"`
+	// bitmap of the signers, in the order of the validator set
+	Signers    []byte      `protobuf:"bytes,4,opt,name=signers,proto3" json:"signers,omitempty"`
+	Timestamps []time.Time `protobuf:"bytes,5,rep,name=timestamps,proto3,stdtime" json:"timestamps"`
+	Signature  []byte      `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *AggregatedCommit) Reset()         { *m = AggregatedCommit{} }
+func (m *AggregatedCommit) String() string { return proto.CompactTextString(m) }
+func (*AggregatedCommit) ProtoMessage()    {}
+func (*AggregatedCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{8}
+}
+func (m *AggregatedCommit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AggregatedCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AggregatedCommit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AggregatedCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AggregatedCommit.Merge(m, src)
+}
+func (m *AggregatedCommit) XXX_Size() int {
+	return m.Size()
+}
+func (m *AggregatedCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_AggregatedCommit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AggregatedCommit proto.InternalMessageInfo
+
+func (m *AggregatedCommit) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *AggregatedCommit) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *AggregatedCommit) GetBlockID() BlockID {
+	if m != nil {
+		return m.BlockID
+	}
+	return BlockID{}
+}
+
+func (m *AggregatedCommit) GetSigners() []byte {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
+func (m *AggregatedCommit) GetTimestamps() []time.Time {
+	if m != nil {
+		return m.Timestamps
+	}
+	return nil
+}
+
+func (m *AggregatedCommit) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type Proposal struct {
 	Type      SignedMsgType `protobuf:"varint,1,opt,name=type,proto3,enum=tendermint.types.SignedMsgType" json:"type,omitempty"`
 	Height    int64         `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
@@ -717,7 +804,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{8}
+	return fileDescriptor_d3a6e55e2345de56, []int{9}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -804,7 +891,7 @@ func (m *SignedHeader) Reset()         { *m = SignedHeader{} }
 func (m *SignedHeader) String() string { return proto.CompactTextString(m) }
 func (*SignedHeader) ProtoMessage()    {}
 func (*SignedHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{9}
+	return fileDescriptor_d3a6e55e2345de56, []int{10}
 }
 func (m *SignedHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -856,7 +943,7 @@ func (m *LightBlock) Reset()         { *m = LightBlock{} }
 func (m *LightBlock) String() string { return proto.CompactTextString(m) }
 func (*LightBlock) ProtoMessage()    {}
 func (*LightBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{10}
+	return fileDescriptor_d3a6e55e2345de56, []int{11}
 }
 func (m *LightBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -910,7 +997,7 @@ func (m *BlockMeta) Reset()         { *m = BlockMeta{} }
 func (m *BlockMeta) String() string { return proto.CompactTextString(m) }
 func (*BlockMeta) ProtoMessage()    {}
 func (*BlockMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{11}
+	return fileDescriptor_d3a6e55e2345de56, []int{12}
 }
 func (m *BlockMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -978,7 +1065,7 @@ func (m *TxProof) Reset()         { *m = TxProof{} }
 func (m *TxProof) String() string { return proto.CompactTextString(m) }
 func (*TxProof) ProtoMessage()    {}
 func (*TxProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{12}
+	return fileDescriptor_d3a6e55e2345de56, []int{13}
 }
 func (m *TxProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1039,6 +1126,7 @@ func init() {
 	proto.RegisterType((*Vote)(nil), "tendermint.types.Vote")
 	proto.RegisterType((*Commit)(nil), "tendermint.types.Commit")
 	proto.RegisterType((*CommitSig)(nil), "tendermint.types.CommitSig")
+	proto.RegisterType((*AggregatedCommit)(nil), "tendermint.types.AggregatedCommit")
 	proto.RegisterType((*Proposal)(nil), "tendermint.types.Proposal")
 	proto.RegisterType((*SignedHeader)(nil), "tendermint.types.SignedHeader")
 	proto.RegisterType((*LightBlock)(nil), "tendermint.types.LightBlock")
@@ -1049,90 +1137,93 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1316 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xcf, 0xda, 0x9b, 0xd8, 0x7e, 0xb6, 0x13, 0x67, 0x95, 0xb6, 0xae, 0xdb, 0x38, 0x2b, 0x23,
-	0x20, 0x2d, 0x68, 0x53, 0x52, 0x84, 0xe0, 0xc0, 0xc1, 0x76, 0xd2, 0xd6, 0x6a, 0xec, 0x58, 0x6b,
-	0xb7, 0x08, 0x2e, 0xab, 0xb5, 0x77, 0x62, 0x2f, 0x5d, 0xef, 0xac, 0x76, 0xc6, 0x21, 0xe9, 0x27,
-	0x40, 0x39, 0xf5, 0xc4, 0x2d, 0x27, 0x38, 0x70, 0xe7, 0x0b, 0x20, 0x4e, 0x3d, 0xf6, 0x06, 0x17,
-	0x0a, 0x4a, 0x25, 0xc4, 0xc7, 0x40, 0xf3, 0xc7, 0xeb, 0x75, 0x9c, 0x40, 0x55, 0x55, 0x5c, 0xac,
-	0x9d, 0xf7, 0x7e, 0x6f, 0xe6, 0xbd, 0xdf, 0xfb, 0xcd, 0x1f, 0xc3, 0x4d, 0x8a, 0x7c, 0x07, 0x85,
-	0x23, 0xd7, 0xa7, 0x5b, 0xf4, 0x38, 0x40, 0x44, 0xfc, 0x1a, 0x41, 0x88, 0x29, 0xd6, 0x0a, 0x53,
-	0xaf, 0xc1, 0xed, 0xa5, 0xb5, 0x01, 0x1e, 0x60, 0xee, 0xdc, 0x62, 0x5f, 0x02, 0x57, 0xda, 0x18,
-	0x60, 0x3c, 0xf0, 0xd0, 0x16, 0x1f, 0xf5, 0xc6, 0x07, 0x5b, 0xd4, 0x1d, 0x21, 0x42, 0xed, 0x51,
-	0x20, 0x01, 0xeb, 0xb1, 0x65, 0xfa, 0xe1, 0x71, 0x40, 0x31, 0xc3, 0xe2, 0x03, 0xe9, 0x2e, 0xc7,
-	0xdc, 0x87, 0x28, 0x24, 0x2e, 0xf6, 0xe3, 0x79, 0x94, 0xf4, 0xb9, 0x2c, 0x0f, 0x6d, 0xcf, 0x75,
-	0x6c, 0x8a, 0x43, 0x81, 0xa8, 0x7c, 0x06, 0xf9, 0xb6, 0x1d, 0xd2, 0x0e, 0xa2, 0x0f, 0x90, 0xed,
-	0xa0, 0x50, 0x5b, 0x83, 0x45, 0x8a, 0xa9, 0xed, 0x15, 0x15, 0x5d, 0xd9, 0xcc, 0x9b, 0x62, 0xa0,
-	0x69, 0xa0, 0x0e, 0x6d, 0x32, 0x2c, 0x26, 0x74, 0x65, 0x33, 0x67, 0xf2, 0xef, 0xca, 0x10, 0x54,
-	0x16, 0xca, 0x22, 0x5c, 0xdf, 0x41, 0x47, 0x93, 0x08, 0x3e, 0x60, 0xd6, 0xde, 0x31, 0x45, 0x44,
-	0x86, 0x88, 0x81, 0xf6, 0x31, 0x2c, 0xf2, 0xfc, 0x8b, 0x49, 0x5d, 0xd9, 0xcc, 0x6e, 0x17, 0x8d,
-	0x18, 0x51, 0xa2, 0x3e, 0xa3, 0xcd, 0xfc, 0x35, 0xf5, 0xf9, 0xcb, 0x8d, 0x05, 0x53, 0x80, 0x2b,
-	0x1e, 0xa4, 0x6a, 0x1e, 0xee, 0x3f, 0x69, 0xec, 0x44, 0x89, 0x28, 0xd3, 0x44, 0xb4, 0x26, 0xac,
-	0x04, 0x76, 0x48, 0x2d, 0x82, 0xa8, 0x35, 0xe4, 0x55, 0xf0, 0x45, 0xb3, 0xdb, 0x1b, 0xc6, 0xf9,
-	0x3e, 0x18, 0x33, 0xc5, 0xca, 0x55, 0xf2, 0x41, 0xdc, 0x58, 0xf9, 0x4b, 0x85, 0x25, 0x49, 0xc6,
-	0xe7, 0x90, 0x92, 0xb4, 0xf2, 0x05, 0xb3, 0xdb, 0xeb, 0xf1, 0x19, 0xa5, 0xcb, 0xa8, 0x63, 0x9f,
-	0x20, 0x9f, 0x8c, 0x89, 0x9c, 0x6f, 0x12, 0xa3, 0xbd, 0x07, 0xe9, 0xfe, 0xd0, 0x76, 0x7d, 0xcb,
-	0x75, 0x78, 0x46, 0x99, 0x5a, 0xf6, 0xec, 0xe5, 0x46, 0xaa, 0xce, 0x6c, 0x8d, 0x1d, 0x33, 0xc5,
-	0x9d, 0x0d, 0x47, 0xbb, 0x0a, 0x4b, 0x43, 0xe4, 0x0e, 0x86, 0x94, 0xd3, 0x92, 0x34, 0xe5, 0x48,
-	0xfb, 0x14, 0x54, 0x26, 0x88, 0xa2, 0xca, 0xd7, 0x2e, 0x19, 0x42, 0x2d, 0xc6, 0x44, 0x2d, 0x46,
-	0x77, 0xa2, 0x96, 0x5a, 0x9a, 0x2d, 0xfc, 0xec, 0x8f, 0x0d, 0xc5, 0xe4, 0x11, 0x5a, 0x1d, 0xf2,
-	0x9e, 0x4d, 0xa8, 0xd5, 0x63, 0xb4, 0xb1, 0xe5, 0x17, 0xf9, 0x14, 0xd7, 0xe7, 0x09, 0x91, 0xc4,
-	0xca, 0xd4, 0xb3, 0x2c, 0x4a, 0x98, 0x1c, 0x6d, 0x13, 0x0a, 0x7c, 0x92, 0x3e, 0x1e, 0x8d, 0x5c,
-	0x6a, 0x71, 0xde, 0x97, 0x38, 0xef, 0xcb, 0xcc, 0x5e, 0xe7, 0xe6, 0x07, 0xac, 0x03, 0x37, 0x20,
-	0xe3, 0xd8, 0xd4, 0x16, 0x90, 0x14, 0x87, 0xa4, 0x99, 0x81, 0x3b, 0xdf, 0x87, 0x95, 0x48, 0x75,
-	0x44, 0x40, 0xd2, 0x62, 0x96, 0xa9, 0x99, 0x03, 0xef, 0xc0, 0x9a, 0x8f, 0x8e, 0xa8, 0x75, 0x1e,
-	0x9d, 0xe1, 0x68, 0x8d, 0xf9, 0x1e, 0xcf, 0x46, 0xbc, 0x0b, 0xcb, 0xfd, 0x09, 0xf9, 0x02, 0x0b,
-	0x1c, 0x9b, 0x8f, 0xac, 0x1c, 0x76, 0x1d, 0xd2, 0x76, 0x10, 0x08, 0x40, 0x96, 0x03, 0x52, 0x76,
-	0x10, 0x70, 0xd7, 0x6d, 0x58, 0xe5, 0x35, 0x86, 0x88, 0x8c, 0x3d, 0x2a, 0x27, 0xc9, 0x71, 0xcc,
-	0x0a, 0x73, 0x98, 0xc2, 0xce, 0xb1, 0xef, 0x40, 0x1e, 0x1d, 0xba, 0x0e, 0xf2, 0xfb, 0x48, 0xe0,
-	0xf2, 0x1c, 0x97, 0x9b, 0x18, 0x39, 0xe8, 0x16, 0x14, 0x82, 0x10, 0x07, 0x98, 0xa0, 0xd0, 0xb2,
-	0x1d, 0x27, 0x44, 0x84, 0x14, 0x97, 0xc5, 0x7c, 0x13, 0x7b, 0x55, 0x98, 0x2b, 0x45, 0x50, 0x77,
-	0x6c, 0x6a, 0x6b, 0x05, 0x48, 0xd2, 0x23, 0x52, 0x54, 0xf4, 0xe4, 0x66, 0xce, 0x64, 0x9f, 0x95,
-	0xbf, 0x13, 0xa0, 0x3e, 0xc6, 0x14, 0x69, 0x77, 0x41, 0x65, 0x6d, 0xe2, 0xea, 0x5b, 0xbe, 0x48,
-	0xcf, 0x1d, 0x77, 0xe0, 0x23, 0xa7, 0x49, 0x06, 0xdd, 0xe3, 0x00, 0x99, 0x1c, 0x1c, 0x93, 0x53,
-	0x62, 0x46, 0x4e, 0x6b, 0xb0, 0x18, 0xe2, 0xb1, 0xef, 0x70, 0x95, 0x2d, 0x9a, 0x62, 0xa0, 0xed,
-	0x42, 0x3a, 0x52, 0x89, 0xfa, 0x5f, 0x2a, 0x59, 0x61, 0x2a, 0x61, 0x1a, 0x96, 0x06, 0x33, 0xd5,
-	0x93, 0x62, 0xa9, 0x41, 0x26, 0x3a, 0xbc, 0xa4, 0xda, 0x5e, 0x4f, 0xb0, 0xd3, 0x30, 0xed, 0x03,
-	0x58, 0x8d, 0x7a, 0x1f, 0x91, 0x27, 0x14, 0x57, 0x88, 0x1c, 0x92, 0xbd, 0x19, 0x59, 0x59, 0xe2,
-	0x00, 0x4a, 0xf1, 0xba, 0xa6, 0xb2, 0x6a, 0xf0, 0x93, 0xe8, 0x26, 0x64, 0x88, 0x3b, 0xf0, 0x6d,
-	0x3a, 0x0e, 0x91, 0x54, 0xde, 0xd4, 0x50, 0xf9, 0x59, 0x81, 0x25, 0xa1, 0xe4, 0x18, 0x6f, 0xca,
-	0xc5, 0xbc, 0x25, 0x2e, 0xe3, 0x2d, 0xf9, 0xe6, 0xbc, 0x55, 0x01, 0xa2, 0x64, 0x48, 0x51, 0xd5,
-	0x93, 0x9b, 0xd9, 0xed, 0x1b, 0xf3, 0x13, 0x89, 0x14, 0x3b, 0xee, 0x40, 0x6e, 0xd4, 0x58, 0x50,
-	0xe5, 0x77, 0x05, 0x32, 0x91, 0x5f, 0xab, 0x42, 0x7e, 0x92, 0x97, 0x75, 0xe0, 0xd9, 0x03, 0xa9,
-	0x9d, 0xf5, 0x4b, 0x93, 0xbb, 0xe7, 0xd9, 0x03, 0x33, 0x2b, 0xf3, 0x61, 0x83, 0x8b, 0xfb, 0x90,
-	0xb8, 0xa4, 0x0f, 0x33, 0x8d, 0x4f, 0xbe, 0x59, 0xe3, 0x67, 0x5a, 0xa4, 0x9e, 0x6f, 0xd1, 0x4f,
-	0x09, 0x48, 0xb7, 0xf9, 0xde, 0xb1, 0xbd, 0xff, 0x63, 0x47, 0xdc, 0x80, 0x4c, 0x80, 0x3d, 0x4b,
-	0x78, 0x54, 0xee, 0x49, 0x07, 0xd8, 0x33, 0xe7, 0xda, 0xbe, 0xf8, 0x96, 0xb6, 0xcb, 0xd2, 0x5b,
-	0x60, 0x2d, 0x75, 0x9e, 0xb5, 0x10, 0x72, 0x82, 0x0a, 0x79, 0x97, 0xdd, 0x61, 0x1c, 0xf0, 0xcb,
-	0x51, 0x99, 0xbf, 0x7b, 0x45, 0xda, 0x02, 0x69, 0x4a, 0x1c, 0x8b, 0x10, 0x47, 0xbf, 0xbc, 0x4e,
-	0x8b, 0x97, 0xc9, 0xd2, 0x94, 0xb8, 0xca, 0x77, 0x0a, 0xc0, 0x1e, 0x63, 0x96, 0xd7, 0xcb, 0x6e,
-	0x21, 0xc2, 0x53, 0xb0, 0x66, 0x56, 0x2e, 0x5f, 0xd6, 0x34, 0xb9, 0x7e, 0x8e, 0xc4, 0xf3, 0xae,
-	0x43, 0x7e, 0x2a, 0x46, 0x82, 0x26, 0xc9, 0x5c, 0x30, 0x49, 0x74, 0x39, 0x74, 0x10, 0x35, 0x73,
-	0x87, 0xb1, 0x51, 0xe5, 0x17, 0x05, 0x32, 0x3c, 0xa7, 0x26, 0xa2, 0xf6, 0x4c, 0x0f, 0x95, 0x37,
-	0xef, 0xe1, 0x3a, 0x80, 0x98, 0x86, 0xb8, 0x4f, 0x91, 0x54, 0x56, 0x86, 0x5b, 0x3a, 0xee, 0x53,
-	0xa4, 0x7d, 0x12, 0x11, 0x9e, 0xfc, 0x77, 0xc2, 0xe5, 0x96, 0x9e, 0xd0, 0x7e, 0x0d, 0x52, 0xfe,
-	0x78, 0x64, 0xb1, 0x2b, 0x41, 0x15, 0x6a, 0xf5, 0xc7, 0xa3, 0xee, 0x11, 0xa9, 0x7c, 0x0d, 0xa9,
-	0xee, 0x11, 0x7f, 0x1e, 0x31, 0x89, 0x86, 0x18, 0xcb, 0x3b, 0x59, 0xbc, 0x85, 0xd2, 0xcc, 0xc0,
-	0xaf, 0x20, 0x0d, 0x54, 0x76, 0xf9, 0x4e, 0x1e, 0x6b, 0xec, 0x5b, 0x33, 0x5e, 0xf3, 0xe1, 0x25,
-	0x9f, 0x5c, 0xb7, 0x7f, 0x55, 0x20, 0x1b, 0x3b, 0x1f, 0xb4, 0x8f, 0xe0, 0x4a, 0x6d, 0x6f, 0xbf,
-	0xfe, 0xd0, 0x6a, 0xec, 0x58, 0xf7, 0xf6, 0xaa, 0xf7, 0xad, 0x47, 0xad, 0x87, 0xad, 0xfd, 0x2f,
-	0x5a, 0x85, 0x85, 0xd2, 0xd5, 0x93, 0x53, 0x5d, 0x8b, 0x61, 0x1f, 0xf9, 0x4f, 0x7c, 0xfc, 0x8d,
-	0xaf, 0x6d, 0xc1, 0xda, 0x6c, 0x48, 0xb5, 0xd6, 0xd9, 0x6d, 0x75, 0x0b, 0x4a, 0xe9, 0xca, 0xc9,
-	0xa9, 0xbe, 0x1a, 0x8b, 0xa8, 0xf6, 0x08, 0xf2, 0xe9, 0x7c, 0x40, 0x7d, 0xbf, 0xd9, 0x6c, 0x74,
-	0x0b, 0x89, 0xb9, 0x00, 0x79, 0x60, 0xdf, 0x82, 0xd5, 0xd9, 0x80, 0x56, 0x63, 0xaf, 0x90, 0x2c,
-	0x69, 0x27, 0xa7, 0xfa, 0x72, 0x0c, 0xdd, 0x72, 0xbd, 0x52, 0xfa, 0xdb, 0xef, 0xcb, 0x0b, 0x3f,
-	0xfe, 0x50, 0x56, 0x58, 0x65, 0xf9, 0x99, 0x33, 0x42, 0xfb, 0x10, 0xae, 0x75, 0x1a, 0xf7, 0x5b,
-	0xbb, 0x3b, 0x56, 0xb3, 0x73, 0xdf, 0xea, 0x7e, 0xd9, 0xde, 0x8d, 0x55, 0xb7, 0x72, 0x72, 0xaa,
-	0x67, 0x65, 0x49, 0x97, 0xa1, 0xdb, 0xe6, 0xee, 0xe3, 0xfd, 0xee, 0x6e, 0x41, 0x11, 0xe8, 0x76,
-	0x88, 0x0e, 0x31, 0x45, 0x1c, 0x7d, 0x07, 0xae, 0x5f, 0x80, 0x8e, 0x0a, 0x5b, 0x3d, 0x39, 0xd5,
-	0xf3, 0xed, 0x10, 0x89, 0xfd, 0xc3, 0x23, 0x0c, 0x28, 0xce, 0x47, 0xec, 0xb7, 0xf7, 0x3b, 0xd5,
-	0xbd, 0x82, 0x5e, 0x2a, 0x9c, 0x9c, 0xea, 0xb9, 0xc9, 0x61, 0xc8, 0xf0, 0xd3, 0xca, 0x6a, 0xcd,
-	0xe7, 0x67, 0x65, 0xe5, 0xc5, 0x59, 0x59, 0xf9, 0xf3, 0xac, 0xac, 0x3c, 0x7b, 0x55, 0x5e, 0x78,
-	0xf1, 0xaa, 0xbc, 0xf0, 0xdb, 0xab, 0xf2, 0xc2, 0x57, 0x77, 0x07, 0x2e, 0x1d, 0x8e, 0x7b, 0x46,
-	0x1f, 0x8f, 0xb6, 0xfa, 0x78, 0x84, 0x68, 0xef, 0x80, 0x4e, 0x3f, 0xc4, 0xdf, 0x92, 0xf3, 0x7f,
-	0x15, 0x7a, 0x4b, 0xdc, 0x7e, 0xf7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x9c, 0x41, 0x46, 0x23,
-	0xeb, 0x0c, 0x00, 0x00,
+	// 1361 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0xcf, 0x6f, 0xdb, 0xc6,
+	0x12, 0x36, 0x25, 0xea, 0xd7, 0x48, 0xb2, 0xe5, 0x85, 0x93, 0x30, 0x4a, 0x2c, 0x13, 0x7a, 0x78,
+	0xef, 0x39, 0x79, 0x0f, 0x72, 0xea, 0x14, 0x45, 0x7b, 0xe8, 0x41, 0x92, 0x9d, 0x44, 0x88, 0x25,
+	0x0b, 0x94, 0x92, 0xa2, 0xbd, 0x10, 0x94, 0xb8, 0xa6, 0xd8, 0x48, 0x24, 0x41, 0xae, 0x5c, 0x3b,
+	0xf7, 0x02, 0x85, 0x4f, 0x39, 0xf5, 0xe6, 0x53, 0x7b, 0xe8, 0xbd, 0xff, 0x40, 0xd1, 0x53, 0x8e,
+	0xb9, 0xb5, 0x97, 0xa6, 0x85, 0x03, 0x14, 0xfd, 0x33, 0x8a, 0xfd, 0x41, 0x8a, 0xb2, 0xec, 0x36,
+	0x0d, 0x82, 0xf6, 0x22, 0xec, 0xce, 0x7c, 0xb3, 0x3b, 0xf3, 0xcd, 0xc7, 0xdd, 0x15, 0xdc, 0x24,
+	0xd8, 0x31, 0xb1, 0x3f, 0xb1, 0x1d, 0xb2, 0x45, 0x8e, 0x3d, 0x1c, 0xf0, 0xdf, 0x9a, 0xe7, 0xbb,
+	0xc4, 0x45, 0xa5, 0x99, 0xb7, 0xc6, 0xec, 0xe5, 0x35, 0xcb, 0xb5, 0x5c, 0xe6, 0xdc, 0xa2, 0x23,
+	0x8e, 0x2b, 0x6f, 0x58, 0xae, 0x6b, 0x8d, 0xf1, 0x16, 0x9b, 0x0d, 0xa6, 0x07, 0x5b, 0xc4, 0x9e,
+	0xe0, 0x80, 0x18, 0x13, 0x4f, 0x00, 0xd6, 0x63, 0xdb, 0x0c, 0xfd, 0x63, 0x8f, 0xb8, 0x14, 0xeb,
+	0x1e, 0x08, 0x77, 0x25, 0xe6, 0x3e, 0xc4, 0x7e, 0x60, 0xbb, 0x4e, 0x3c, 0x8f, 0xb2, 0xba, 0x90,
+	0xe5, 0xa1, 0x31, 0xb6, 0x4d, 0x83, 0xb8, 0x3e, 0x47, 0x54, 0x3f, 0x80, 0x62, 0xd7, 0xf0, 0x49,
+	0x0f, 0x93, 0x07, 0xd8, 0x30, 0xb1, 0x8f, 0xd6, 0x20, 0x45, 0x5c, 0x62, 0x8c, 0x15, 0x49, 0x95,
+	0x36, 0x8b, 0x1a, 0x9f, 0x20, 0x04, 0xf2, 0xc8, 0x08, 0x46, 0x4a, 0x42, 0x95, 0x36, 0x0b, 0x1a,
+	0x1b, 0x57, 0x47, 0x20, 0xd3, 0x50, 0x1a, 0x61, 0x3b, 0x26, 0x3e, 0x0a, 0x23, 0xd8, 0x84, 0x5a,
+	0x07, 0xc7, 0x04, 0x07, 0x22, 0x84, 0x4f, 0xd0, 0xbb, 0x90, 0x62, 0xf9, 0x2b, 0x49, 0x55, 0xda,
+	0xcc, 0x6f, 0x2b, 0xb5, 0x18, 0x51, 0xbc, 0xbe, 0x5a, 0x97, 0xfa, 0x1b, 0xf2, 0xf3, 0x97, 0x1b,
+	0x4b, 0x1a, 0x07, 0x57, 0xc7, 0x90, 0x69, 0x8c, 0xdd, 0xe1, 0x93, 0xd6, 0x4e, 0x94, 0x88, 0x34,
+	0x4b, 0x04, 0xb5, 0x61, 0xc5, 0x33, 0x7c, 0xa2, 0x07, 0x98, 0xe8, 0x23, 0x56, 0x05, 0xdb, 0x34,
+	0xbf, 0xbd, 0x51, 0x3b, 0xdf, 0x87, 0xda, 0x5c, 0xb1, 0x62, 0x97, 0xa2, 0x17, 0x37, 0x56, 0x7f,
+	0x95, 0x21, 0x2d, 0xc8, 0xf8, 0x10, 0x32, 0x82, 0x56, 0xb6, 0x61, 0x7e, 0x7b, 0x3d, 0xbe, 0xa2,
+	0x70, 0xd5, 0x9a, 0xae, 0x13, 0x60, 0x27, 0x98, 0x06, 0x62, 0xbd, 0x30, 0x06, 0xfd, 0x07, 0xb2,
+	0xc3, 0x91, 0x61, 0x3b, 0xba, 0x6d, 0xb2, 0x8c, 0x72, 0x8d, 0xfc, 0xd9, 0xcb, 0x8d, 0x4c, 0x93,
+	0xda, 0x5a, 0x3b, 0x5a, 0x86, 0x39, 0x5b, 0x26, 0xba, 0x0a, 0xe9, 0x11, 0xb6, 0xad, 0x11, 0x61,
+	0xb4, 0x24, 0x35, 0x31, 0x43, 0xef, 0x83, 0x4c, 0x05, 0xa1, 0xc8, 0x6c, 0xef, 0x72, 0x8d, 0xab,
+	0xa5, 0x16, 0xaa, 0xa5, 0xd6, 0x0f, 0xd5, 0xd2, 0xc8, 0xd2, 0x8d, 0x9f, 0xfd, 0xbc, 0x21, 0x69,
+	0x2c, 0x02, 0x35, 0xa1, 0x38, 0x36, 0x02, 0xa2, 0x0f, 0x28, 0x6d, 0x74, 0xfb, 0x14, 0x5b, 0xe2,
+	0xfa, 0x22, 0x21, 0x82, 0x58, 0x91, 0x7a, 0x9e, 0x46, 0x71, 0x93, 0x89, 0x36, 0xa1, 0xc4, 0x16,
+	0x19, 0xba, 0x93, 0x89, 0x4d, 0x74, 0xc6, 0x7b, 0x9a, 0xf1, 0xbe, 0x4c, 0xed, 0x4d, 0x66, 0x7e,
+	0x40, 0x3b, 0x70, 0x03, 0x72, 0xa6, 0x41, 0x0c, 0x0e, 0xc9, 0x30, 0x48, 0x96, 0x1a, 0x98, 0xf3,
+	0xbf, 0xb0, 0x12, 0xa9, 0x2e, 0xe0, 0x90, 0x2c, 0x5f, 0x65, 0x66, 0x66, 0xc0, 0x3b, 0xb0, 0xe6,
+	0xe0, 0x23, 0xa2, 0x9f, 0x47, 0xe7, 0x18, 0x1a, 0x51, 0xdf, 0xe3, 0xf9, 0x88, 0x7f, 0xc3, 0xf2,
+	0x30, 0x24, 0x9f, 0x63, 0x81, 0x61, 0x8b, 0x91, 0x95, 0xc1, 0xae, 0x43, 0xd6, 0xf0, 0x3c, 0x0e,
+	0xc8, 0x33, 0x40, 0xc6, 0xf0, 0x3c, 0xe6, 0xba, 0x0d, 0xab, 0xac, 0x46, 0x1f, 0x07, 0xd3, 0x31,
+	0x11, 0x8b, 0x14, 0x18, 0x66, 0x85, 0x3a, 0x34, 0x6e, 0x67, 0xd8, 0x7f, 0x41, 0x11, 0x1f, 0xda,
+	0x26, 0x76, 0x86, 0x98, 0xe3, 0x8a, 0x0c, 0x57, 0x08, 0x8d, 0x0c, 0x74, 0x0b, 0x4a, 0x9e, 0xef,
+	0x7a, 0x6e, 0x80, 0x7d, 0xdd, 0x30, 0x4d, 0x1f, 0x07, 0x81, 0xb2, 0xcc, 0xd7, 0x0b, 0xed, 0x75,
+	0x6e, 0xae, 0x2a, 0x20, 0xef, 0x18, 0xc4, 0x40, 0x25, 0x48, 0x92, 0xa3, 0x40, 0x91, 0xd4, 0xe4,
+	0x66, 0x41, 0xa3, 0xc3, 0xea, 0x6f, 0x09, 0x90, 0x1f, 0xbb, 0x04, 0xa3, 0xbb, 0x20, 0xd3, 0x36,
+	0x31, 0xf5, 0x2d, 0x5f, 0xa4, 0xe7, 0x9e, 0x6d, 0x39, 0xd8, 0x6c, 0x07, 0x56, 0xff, 0xd8, 0xc3,
+	0x1a, 0x03, 0xc7, 0xe4, 0x94, 0x98, 0x93, 0xd3, 0x1a, 0xa4, 0x7c, 0x77, 0xea, 0x98, 0x4c, 0x65,
+	0x29, 0x8d, 0x4f, 0xd0, 0x2e, 0x64, 0x23, 0x95, 0xc8, 0x7f, 0xa6, 0x92, 0x15, 0xaa, 0x12, 0xaa,
+	0x61, 0x61, 0xd0, 0x32, 0x03, 0x21, 0x96, 0x06, 0xe4, 0xa2, 0xc3, 0x4b, 0x49, 0xfd, 0x05, 0xc1,
+	0xce, 0xc2, 0xd0, 0xff, 0x60, 0x35, 0xea, 0x7d, 0x44, 0x1e, 0x57, 0x5c, 0x29, 0x72, 0x08, 0xf6,
+	0xe6, 0x64, 0xa5, 0xf3, 0x03, 0x28, 0xc3, 0xea, 0x9a, 0xc9, 0xaa, 0x45, 0xad, 0xe8, 0x26, 0xe4,
+	0x02, 0xdb, 0x72, 0x0c, 0x32, 0xf5, 0xb1, 0x50, 0xde, 0xcc, 0x50, 0xfd, 0x4e, 0x82, 0x34, 0x57,
+	0x72, 0x8c, 0x37, 0xe9, 0x62, 0xde, 0x12, 0x97, 0xf1, 0x96, 0x7c, 0x73, 0xde, 0xea, 0x00, 0x51,
+	0x32, 0x81, 0x22, 0xab, 0xc9, 0xcd, 0xfc, 0xf6, 0x8d, 0xc5, 0x85, 0x78, 0x8a, 0x3d, 0xdb, 0x12,
+	0x1f, 0x6a, 0x2c, 0xa8, 0xfa, 0x93, 0x04, 0xb9, 0xc8, 0x8f, 0xea, 0x50, 0x0c, 0xf3, 0xd2, 0x0f,
+	0xc6, 0x86, 0x25, 0xb4, 0xb3, 0x7e, 0x69, 0x72, 0xf7, 0xc6, 0x86, 0xa5, 0xe5, 0x45, 0x3e, 0x74,
+	0x72, 0x71, 0x1f, 0x12, 0x97, 0xf4, 0x61, 0xae, 0xf1, 0xc9, 0x37, 0x6b, 0xfc, 0x5c, 0x8b, 0xe4,
+	0xf3, 0x2d, 0xfa, 0x3c, 0x01, 0xa5, 0xba, 0x65, 0xf9, 0xd8, 0x32, 0x08, 0x36, 0xff, 0xc9, 0x66,
+	0x29, 0x90, 0xa1, 0x69, 0x61, 0x3f, 0x10, 0x59, 0x86, 0x53, 0xb4, 0x03, 0x10, 0x95, 0x13, 0x28,
+	0x29, 0x35, 0xf9, 0xda, 0x34, 0xc4, 0xe2, 0xe6, 0x79, 0x48, 0x9f, 0xe7, 0xe1, 0xdb, 0x04, 0x64,
+	0xbb, 0xec, 0x0c, 0x31, 0xc6, 0x7f, 0xc7, 0xc9, 0x70, 0x03, 0x72, 0x9e, 0x3b, 0xd6, 0xb9, 0x47,
+	0x66, 0x9e, 0xac, 0xe7, 0x8e, 0xb5, 0x05, 0x46, 0x53, 0x6f, 0xe9, 0xd8, 0x48, 0xbf, 0x05, 0xf5,
+	0x64, 0xce, 0xb3, 0xe6, 0x43, 0x81, 0x53, 0x21, 0xee, 0xf4, 0x3b, 0x94, 0x03, 0x3a, 0x52, 0xa4,
+	0xc5, 0x37, 0x08, 0x4f, 0x9b, 0x23, 0xb5, 0xf4, 0x28, 0x8a, 0xe0, 0x57, 0xa0, 0x92, 0xb8, 0x2c,
+	0x82, 0x8b, 0x52, 0x13, 0xb8, 0xea, 0x97, 0x12, 0xc0, 0x1e, 0x65, 0x96, 0xd5, 0x4b, 0x6f, 0x63,
+	0xa6, 0x13, 0x53, 0x9f, 0xdb, 0xb9, 0x72, 0x59, 0xd3, 0xc4, 0xfe, 0x85, 0x20, 0x9e, 0x77, 0x13,
+	0x8a, 0xb3, 0x8f, 0x32, 0xc0, 0x61, 0x32, 0x17, 0x2c, 0x12, 0x5d, 0x92, 0x3d, 0x4c, 0xb4, 0xc2,
+	0x61, 0x6c, 0x56, 0xfd, 0x5e, 0x82, 0x1c, 0xcb, 0xa9, 0x8d, 0x89, 0x31, 0xd7, 0x43, 0xe9, 0xcd,
+	0x7b, 0xb8, 0x0e, 0xc0, 0x97, 0x09, 0xec, 0xa7, 0x58, 0x28, 0x2b, 0xc7, 0x2c, 0x3d, 0xfb, 0x29,
+	0x46, 0xef, 0x45, 0x84, 0x27, 0xff, 0x98, 0x70, 0x71, 0xb4, 0x85, 0xb4, 0x5f, 0x83, 0x8c, 0x33,
+	0x9d, 0xe8, 0xf4, 0x6a, 0x94, 0xb9, 0x5a, 0x9d, 0xe9, 0xa4, 0x7f, 0x14, 0x54, 0x3f, 0x85, 0x4c,
+	0xff, 0x88, 0x3d, 0x13, 0xa9, 0x44, 0x7d, 0xd7, 0x15, 0x6f, 0x13, 0xfe, 0x26, 0xcc, 0x52, 0x03,
+	0xbb, 0x8a, 0x11, 0xc8, 0xf4, 0x11, 0x12, 0x3e, 0x5a, 0xe9, 0x18, 0xd5, 0x5e, 0xf3, 0x01, 0x2a,
+	0x9e, 0x9e, 0xb7, 0x7f, 0x90, 0x20, 0x1f, 0x3b, 0x27, 0xd1, 0x3b, 0x70, 0xa5, 0xb1, 0xb7, 0xdf,
+	0x7c, 0xa8, 0xb7, 0x76, 0xf4, 0x7b, 0x7b, 0xf5, 0xfb, 0xfa, 0xa3, 0xce, 0xc3, 0xce, 0xfe, 0x47,
+	0x9d, 0xd2, 0x52, 0xf9, 0xea, 0xc9, 0xa9, 0x8a, 0x62, 0xd8, 0x47, 0xce, 0x13, 0xc7, 0xfd, 0xcc,
+	0x41, 0x5b, 0xb0, 0x36, 0x1f, 0x52, 0x6f, 0xf4, 0x76, 0x3b, 0xfd, 0x92, 0x54, 0xbe, 0x72, 0x72,
+	0xaa, 0xae, 0xc6, 0x22, 0xea, 0x83, 0x00, 0x3b, 0x64, 0x31, 0xa0, 0xb9, 0xdf, 0x6e, 0xb7, 0xfa,
+	0xa5, 0xc4, 0x42, 0x80, 0x38, 0x0b, 0x6f, 0xc1, 0xea, 0x7c, 0x40, 0xa7, 0xb5, 0x57, 0x4a, 0x96,
+	0xd1, 0xc9, 0xa9, 0xba, 0x1c, 0x43, 0x77, 0xec, 0x71, 0x39, 0xfb, 0xc5, 0x57, 0x95, 0xa5, 0x6f,
+	0xbe, 0xae, 0x48, 0xb4, 0xb2, 0xe2, 0xdc, 0x19, 0x81, 0xfe, 0x0f, 0xd7, 0x7a, 0xad, 0xfb, 0x9d,
+	0xdd, 0x1d, 0xbd, 0xdd, 0xbb, 0xaf, 0xf7, 0x3f, 0xee, 0xee, 0xc6, 0xaa, 0x5b, 0x39, 0x39, 0x55,
+	0xf3, 0xa2, 0xa4, 0xcb, 0xd0, 0x5d, 0x6d, 0xf7, 0xf1, 0x7e, 0x7f, 0xb7, 0x24, 0x71, 0x74, 0xd7,
+	0xc7, 0x87, 0x2e, 0xc1, 0x0c, 0x7d, 0x07, 0xae, 0x5f, 0x80, 0x8e, 0x0a, 0x5b, 0x3d, 0x39, 0x55,
+	0x8b, 0x5d, 0x1f, 0xf3, 0xef, 0x87, 0x45, 0xd4, 0x40, 0x59, 0x8c, 0xd8, 0xef, 0xee, 0xf7, 0xea,
+	0x7b, 0x25, 0xb5, 0x5c, 0x3a, 0x39, 0x55, 0x0b, 0xe1, 0x61, 0x48, 0xf1, 0xb3, 0xca, 0x1a, 0xed,
+	0xe7, 0x67, 0x15, 0xe9, 0xc5, 0x59, 0x45, 0xfa, 0xe5, 0xac, 0x22, 0x3d, 0x7b, 0x55, 0x59, 0x7a,
+	0xf1, 0xaa, 0xb2, 0xf4, 0xe3, 0xab, 0xca, 0xd2, 0x27, 0x77, 0x2d, 0x9b, 0x8c, 0xa6, 0x83, 0xda,
+	0xd0, 0x9d, 0x6c, 0x0d, 0xdd, 0x09, 0x26, 0x83, 0x03, 0x32, 0x1b, 0xf0, 0xbf, 0x67, 0xe7, 0xff,
+	0x32, 0x0d, 0xd2, 0xcc, 0x7e, 0xf7, 0xf7, 0x01, 0x00, 0x17, 0xb8, 0xf3, 0x18, 0xf3, 0x0d, 0x00,
+	0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1595,6 +1686,75 @@ func (m *CommitSig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *AggregatedCommit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AggregatedCommit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AggregatedCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Timestamps) > 0 {
+		for iNdEx := len(m.Timestamps) - 1; iNdEx >= 0; iNdEx-- {
+			n, err := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamps[iNdEx], dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamps[iNdEx]):])
+			if err != nil {
+				return 0, err
+			}
+			i -= n
+			i = encodeVarintTypes(dAtA, i, uint64(n))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Signers) > 0 {
+		i -= len(m.Signers)
+		copy(dAtA[i:], m.Signers)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signers)))
+		i--
+		dAtA[i] = 0x22
+	}
+	{
+		size, err := m.BlockID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Proposal) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1622,12 +1782,12 @@ func (m *Proposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x3a
 	}
-	n10, err10 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err10 != nil {
-		return 0, err10
+	n11, err11 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err11 != nil {
+		return 0, err11
 	}
-	i -= n10
-	i = encodeVarintTypes(dAtA, i, uint64(n10))
+	i -= n11
+	i = encodeVarintTypes(dAtA, i, uint64(n11))
 	i--
 	dAtA[i] = 0x32
 	{
@@ -2070,6 +2230,37 @@ func (m *CommitSig) Size() (n int) {
 	return n
 }
 
+func (m *AggregatedCommit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.BlockID.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.Signers)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Timestamps) > 0 {
+		for _, e := range m.Timestamps {
+			l = github_com_cosmos_gogoproto_types.SizeOfStdTime(e)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *Proposal) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3708,6 +3899,229 @@ func (m *CommitSig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *AggregatedCommit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AggregatedCommit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AggregatedCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signers = append(m.Signers[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signers == nil {
+				m.Signers = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timestamps = append(m.Timestamps, time.Time{})
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&(m.Timestamps[len(m.Timestamps)-1]), dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Proposal) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1,12 +1,14 @@
 package proxy
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"time"
 
-	"github.com/go-kit/kit/metrics"
-
 	abcicli "github.com/cometbft/cometbft/abci/client"
 	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/telemetry"
 )
 
 //go:generate ../scripts/mockery_generate.sh AppConnConsensus|AppConnMempool|AppConnQuery|AppConnSnapshot
@@ -21,10 +23,13 @@ type AppConnConsensus interface {
 	InitChainSync(types.RequestInitChain) (*types.ResponseInitChain, error)
 	PrepareProposalSync(types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error)
 	ProcessProposalSync(types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
+	FinalizeBlockSync(types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error)
+	CommitSync() (*types.ResponseCommit, error)
+
+	// Legacy block execution, superseded by FinalizeBlockSync.
 	BeginBlockSync(types.RequestBeginBlock) (*types.ResponseBeginBlock, error)
 	DeliverTxAsync(types.RequestDeliverTx) *abcicli.ReqRes
 	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
-	CommitSync() (*types.ResponseCommit, error)
 }
 
 type AppConnMempool interface {
@@ -61,14 +66,33 @@ type AppConnSnapshot interface {
 type appConnConsensus struct {
 	metrics *Metrics
 	appConn abcicli.Client
+	guard   *guard
+
+	// info queries the Info of the application, which tells whether its ABCI
+	// server handles FinalizeBlock. It is queried on the first block.
+	info          func(types.RequestInfo) (*types.ResponseInfo, error)
+	mtx           sync.Mutex
+	negotiated    bool
+	finalizeBlock bool
 }
 
 var _ AppConnConsensus = (*appConnConsensus)(nil)
 
 func NewAppConnConsensus(appConn abcicli.Client, metrics *Metrics) AppConnConsensus {
+	return newAppConnConsensus(appConn, metrics, nil, appConn.InfoSync)
+}
+
+func newAppConnConsensus(
+	appConn abcicli.Client,
+	metrics *Metrics,
+	g *guard,
+	info func(types.RequestInfo) (*types.ResponseInfo, error),
+) AppConnConsensus {
 	return &appConnConsensus{
 		metrics: metrics,
 		appConn: appConn,
+		guard:   g,
+		info:    info,
 	}
 }
 
@@ -81,38 +105,121 @@ func (app *appConnConsensus) Error() error {
 }
 
 func (app *appConnConsensus) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "init_chain", "type", "sync"))()
+	defer addTimeSample(app.metrics, "init_chain", "sync")()
+	defer app.guard.watch("init_chain")()
 	return app.appConn.InitChainSync(req)
 }
 
 func (app *appConnConsensus) PrepareProposalSync(
 	req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "prepare_proposal", "type", "sync"))()
+	defer addTimeSample(app.metrics, "prepare_proposal", "sync")()
+	defer app.guard.watch("prepare_proposal")()
 	return app.appConn.PrepareProposalSync(req)
 }
 
 func (app *appConnConsensus) ProcessProposalSync(req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "process_proposal", "type", "sync"))()
+	defer addTimeSample(app.metrics, "process_proposal", "sync")()
+	defer app.guard.watch("process_proposal")()
 	return app.appConn.ProcessProposalSync(req)
 }
 
+// FinalizeBlockSync executes a block with a FinalizeBlock call if the ABCI
+// server of the application handles it, else through BeginBlock, DeliverTx
+// and EndBlock calls, whose responses are combined.
+func (app *appConnConsensus) FinalizeBlockSync(req types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
+	ok, err := app.handlesFinalizeBlock()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return app.finalizeBlockLegacy(req)
+	}
+
+	defer addTimeSample(app.metrics, "finalize_block", "sync")()
+	defer app.guard.watch("finalize_block")()
+	return app.appConn.FinalizeBlockSync(req)
+}
+
+// handlesFinalizeBlock returns whether the ABCI server of the application
+// handles FinalizeBlock, as advertised in its Info.
+func (app *appConnConsensus) handlesFinalizeBlock() (bool, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	if !app.negotiated {
+		res, err := app.info(RequestInfo)
+		if err != nil {
+			return false, fmt.Errorf("querying the application info: %w", err)
+		}
+		app.negotiated, app.finalizeBlock = true, res.FinalizeBlock
+	}
+	return app.finalizeBlock, nil
+}
+
+// finalizeBlockLegacy executes a block through BeginBlock, DeliverTx for each
+// tx and EndBlock, as types.FinalizeBlock does on the server side.
+func (app *appConnConsensus) finalizeBlockLegacy(req types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
+	resBegin, err := app.BeginBlockSync(types.RequestBeginBlock{
+		Hash:                req.Hash,
+		Header:              req.Header,
+		LastCommitInfo:      req.LastCommitInfo,
+		ByzantineValidators: req.ByzantineValidators,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the DeliverTx responses come in order, and all of them before the
+	// EndBlock one
+	txResults := make([]*types.ResponseDeliverTx, 0, len(req.Txs))
+	app.SetResponseCallback(func(_ *types.Request, res *types.Response) {
+		if r, ok := res.Value.(*types.Response_DeliverTx); ok {
+			txResults = append(txResults, r.DeliverTx)
+		}
+	})
+	for _, tx := range req.Txs {
+		app.DeliverTxAsync(types.RequestDeliverTx{Tx: tx})
+		if err := app.Error(); err != nil {
+			return nil, err
+		}
+	}
+
+	resEnd, err := app.EndBlockSync(types.RequestEndBlock{Height: req.Header.Height})
+	if err != nil {
+		return nil, err
+	}
+	if len(txResults) != len(req.Txs) {
+		return nil, fmt.Errorf("expected %d DeliverTx responses, got %d", len(req.Txs), len(txResults))
+	}
+
+	return &types.ResponseFinalizeBlock{
+		BeginBlockEvents:      resBegin.Events,
+		TxResults:             txResults,
+		EndBlockEvents:        resEnd.Events,
+		ValidatorUpdates:      resEnd.ValidatorUpdates,
+		ConsensusParamUpdates: resEnd.ConsensusParamUpdates,
+	}, nil
+}
+
 func (app *appConnConsensus) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "begin_block", "type", "sync"))()
+	defer addTimeSample(app.metrics, "begin_block", "sync")()
+	defer app.guard.watch("begin_block")()
 	return app.appConn.BeginBlockSync(req)
 }
 
 func (app *appConnConsensus) DeliverTxAsync(req types.RequestDeliverTx) *abcicli.ReqRes {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "deliver_tx", "type", "async"))()
+	defer addTimeSample(app.metrics, "deliver_tx", "async")()
 	return app.appConn.DeliverTxAsync(req)
 }
 
 func (app *appConnConsensus) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "end_block", "type", "sync"))()
+	defer addTimeSample(app.metrics, "end_block", "sync")()
+	defer app.guard.watch("end_block")()
 	return app.appConn.EndBlockSync(req)
 }
 
 func (app *appConnConsensus) CommitSync() (*types.ResponseCommit, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "commit", "type", "sync"))()
+	defer addTimeSample(app.metrics, "commit", "sync")()
+	defer app.guard.watch("commit")()
 	return app.appConn.CommitSync()
 }
 
@@ -122,12 +229,18 @@ func (app *appConnConsensus) CommitSync() (*types.ResponseCommit, error) {
 type appConnMempool struct {
 	metrics *Metrics
 	appConn abcicli.Client
+	guard   *guard
 }
 
 func NewAppConnMempool(appConn abcicli.Client, metrics *Metrics) AppConnMempool {
+	return newAppConnMempool(appConn, metrics, nil)
+}
+
+func newAppConnMempool(appConn abcicli.Client, metrics *Metrics, g *guard) AppConnMempool {
 	return &appConnMempool{
 		metrics: metrics,
 		appConn: appConn,
+		guard:   g,
 	}
 }
 
@@ -140,23 +253,25 @@ func (app *appConnMempool) Error() error {
 }
 
 func (app *appConnMempool) FlushAsync() *abcicli.ReqRes {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "flush", "type", "async"))()
+	defer addTimeSample(app.metrics, "flush", "async")()
 	return app.appConn.FlushAsync()
 }
 
 func (app *appConnMempool) FlushSync() error {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "flush", "type", "sync"))()
+	defer addTimeSample(app.metrics, "flush", "sync")()
 	return app.appConn.FlushSync()
 }
 
 func (app *appConnMempool) CheckTxAsync(req types.RequestCheckTx) *abcicli.ReqRes {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "check_tx", "type", "async"))()
+	defer addTimeSample(app.metrics, "check_tx", "async")()
 	return app.appConn.CheckTxAsync(req)
 }
 
 func (app *appConnMempool) CheckTxSync(req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "check_tx", "type", "sync"))()
-	return app.appConn.CheckTxSync(req)
+	defer addTimeSample(app.metrics, "check_tx", "sync")()
+	return call(app.guard, "check_tx", func() (*types.ResponseCheckTx, error) {
+		return app.appConn.CheckTxSync(req)
+	})
 }
 
 //------------------------------------------------
@@ -165,12 +280,18 @@ func (app *appConnMempool) CheckTxSync(req types.RequestCheckTx) (*types.Respons
 type appConnQuery struct {
 	metrics *Metrics
 	appConn abcicli.Client
+	guard   *guard
 }
 
 func NewAppConnQuery(appConn abcicli.Client, metrics *Metrics) AppConnQuery {
+	return newAppConnQuery(appConn, metrics, nil)
+}
+
+func newAppConnQuery(appConn abcicli.Client, metrics *Metrics, g *guard) AppConnQuery {
 	return &appConnQuery{
 		metrics: metrics,
 		appConn: appConn,
+		guard:   g,
 	}
 }
 
@@ -179,18 +300,24 @@ func (app *appConnQuery) Error() error {
 }
 
 func (app *appConnQuery) EchoSync(msg string) (*types.ResponseEcho, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "echo", "type", "sync"))()
-	return app.appConn.EchoSync(msg)
+	defer addTimeSample(app.metrics, "echo", "sync")()
+	return call(app.guard, "echo", func() (*types.ResponseEcho, error) {
+		return app.appConn.EchoSync(msg)
+	})
 }
 
 func (app *appConnQuery) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "info", "type", "sync"))()
-	return app.appConn.InfoSync(req)
+	defer addTimeSample(app.metrics, "info", "sync")()
+	return retry(app.guard, "info", func() (*types.ResponseInfo, error) {
+		return app.appConn.InfoSync(req)
+	})
 }
 
 func (app *appConnQuery) QuerySync(reqQuery types.RequestQuery) (*types.ResponseQuery, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "query", "type", "sync"))()
-	return app.appConn.QuerySync(reqQuery)
+	defer addTimeSample(app.metrics, "query", "sync")()
+	return retry(app.guard, "query", func() (*types.ResponseQuery, error) {
+		return app.appConn.QuerySync(reqQuery)
+	})
 }
 
 //------------------------------------------------
@@ -199,12 +326,18 @@ func (app *appConnQuery) QuerySync(reqQuery types.RequestQuery) (*types.Response
 type appConnSnapshot struct {
 	metrics *Metrics
 	appConn abcicli.Client
+	guard   *guard
 }
 
 func NewAppConnSnapshot(appConn abcicli.Client, metrics *Metrics) AppConnSnapshot {
+	return newAppConnSnapshot(appConn, metrics, nil)
+}
+
+func newAppConnSnapshot(appConn abcicli.Client, metrics *Metrics, g *guard) AppConnSnapshot {
 	return &appConnSnapshot{
 		metrics: metrics,
 		appConn: appConn,
+		guard:   g,
 	}
 }
 
@@ -213,32 +346,47 @@ func (app *appConnSnapshot) Error() error {
 }
 
 func (app *appConnSnapshot) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "list_snapshots", "type", "sync"))()
-	return app.appConn.ListSnapshotsSync(req)
+	defer addTimeSample(app.metrics, "list_snapshots", "sync")()
+	return call(app.guard, "list_snapshots", func() (*types.ResponseListSnapshots, error) {
+		return app.appConn.ListSnapshotsSync(req)
+	})
 }
 
 func (app *appConnSnapshot) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "offer_snapshot", "type", "sync"))()
-	return app.appConn.OfferSnapshotSync(req)
+	defer addTimeSample(app.metrics, "offer_snapshot", "sync")()
+	return call(app.guard, "offer_snapshot", func() (*types.ResponseOfferSnapshot, error) {
+		return app.appConn.OfferSnapshotSync(req)
+	})
 }
 
 func (app *appConnSnapshot) LoadSnapshotChunkSync(
 	req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "load_snapshot_chunk", "type", "sync"))()
-	return app.appConn.LoadSnapshotChunkSync(req)
+	defer addTimeSample(app.metrics, "load_snapshot_chunk", "sync")()
+	return call(app.guard, "load_snapshot_chunk", func() (*types.ResponseLoadSnapshotChunk, error) {
+		return app.appConn.LoadSnapshotChunkSync(req)
+	})
 }
 
 func (app *appConnSnapshot) ApplySnapshotChunkSync(
 	req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
-	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "apply_snapshot_chunk", "type", "sync"))()
-	return app.appConn.ApplySnapshotChunkSync(req)
-}
-
-// addTimeSample returns a function that, when called, adds an observation to m.
-// The observation added to m is the number of seconds ellapsed since addTimeSample
-// was initially called. addTimeSample is meant to be called in a defer to calculate
-// the amount of time a function takes to complete.
-func addTimeSample(m metrics.Histogram) func() {
+	defer addTimeSample(app.metrics, "apply_snapshot_chunk", "sync")()
+	return call(app.guard, "apply_snapshot_chunk", func() (*types.ResponseApplySnapshotChunk, error) {
+		return app.appConn.ApplySnapshotChunkSync(req)
+	})
+}
+
+// addTimeSample returns a function that, when called, adds an observation to
+// the timing of method in m, and exports an "abci.<method>" span if the
+// traces are exported. The observation added to m is the number of seconds
+// ellapsed since addTimeSample was initially called. addTimeSample is meant to
+// be called in a defer to calculate the amount of time a function takes to
+// complete.
+func addTimeSample(m *Metrics, method, typ string) func() {
 	start := time.Now()
-	return func() { m.Observe(time.Since(start).Seconds()) }
+	_, span := telemetry.StartSpan(context.Background(), "abci."+method, telemetry.SpanKindClient,
+		"abci.method", method, "abci.type", typ)
+	return func() {
+		m.MethodTimingSeconds.With("method", method, "type", typ).Observe(time.Since(start).Seconds())
+		span.End()
+	}
 }
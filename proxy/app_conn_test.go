@@ -5,12 +5,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	abcicli "github.com/cometbft/cometbft/abci/client"
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	"github.com/cometbft/cometbft/abci/server"
 	"github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 )
 
 //----------------------------------------
@@ -162,4 +165,50 @@ func TestInfo(t *testing.T) {
 	if resInfo.Data != "{\"size\":0}" {
 		t.Error("Expected ResponseInfo with one element '{\"size\":0}' but got something else")
 	}
+	if !resInfo.FinalizeBlock {
+		t.Error("Expected the socket server to advertise FinalizeBlock")
+	}
+}
+
+type finalizeBlockApp struct {
+	*kvstore.Application
+	finalized int
+}
+
+func (app *finalizeBlockApp) FinalizeBlock(req types.RequestFinalizeBlock) types.ResponseFinalizeBlock {
+	app.finalized++
+	return types.FinalizeBlock(app.Application, req)
+}
+
+func TestFinalizeBlockNegotiation(t *testing.T) {
+	app := &finalizeBlockApp{Application: kvstore.NewApplication()}
+	cli := abcicli.NewLocalClient(nil, app)
+	req := types.RequestFinalizeBlock{
+		Header: cmtproto.Header{Height: 1},
+		Txs:    [][]byte{[]byte("a=1"), []byte("b=2")},
+	}
+
+	// the server handles FinalizeBlock
+	res, err := NewAppConnConsensus(cli, NopMetrics()).FinalizeBlockSync(req)
+	require.NoError(t, err)
+	require.Len(t, res.TxResults, 2)
+	require.Equal(t, 1, app.finalized)
+
+	// the server doesn't: the block is executed through BeginBlock, DeliverTx
+	// and EndBlock
+	legacyInfo := func(req types.RequestInfo) (*types.ResponseInfo, error) {
+		res, err := cli.InfoSync(req)
+		if err == nil {
+			res.FinalizeBlock = false
+		}
+		return res, err
+	}
+	req.Header.Height = 2
+	res, err = newAppConnConsensus(cli, NopMetrics(), nil, legacyInfo).FinalizeBlockSync(req)
+	require.NoError(t, err)
+	require.Len(t, res.TxResults, 2)
+	for _, txRes := range res.TxResults {
+		require.True(t, txRes.IsOK())
+	}
+	require.Equal(t, 1, app.finalized)
 }
@@ -72,20 +72,31 @@ type remoteClientCreator struct {
 	addr        string
 	transport   string
 	mustConnect bool
+
+	// shared by the gRPC clients, which each stream their requests over it
+	grpcConn *abcicli.GRPCConn
 }
 
 // NewRemoteClientCreator returns a ClientCreator for the given address (e.g.
 // "192.168.0.1") and transport (e.g. "tcp"). Set mustConnect to true if you
-// want the client to connect before reporting success.
+// want the client to connect before reporting success. The gRPC clients share a
+// single connection, over which they each open a stream.
 func NewRemoteClientCreator(addr, transport string, mustConnect bool) ClientCreator {
-	return &remoteClientCreator{
+	r := &remoteClientCreator{
 		addr:        addr,
 		transport:   transport,
 		mustConnect: mustConnect,
 	}
+	if transport == "grpc" {
+		r.grpcConn = abcicli.NewGRPCConn(addr)
+	}
+	return r
 }
 
 func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
+	if r.grpcConn != nil {
+		return abcicli.NewGRPCClientFromConn(r.grpcConn, r.mustConnect), nil
+	}
 	remoteApp, err := abcicli.NewClient(r.addr, r.transport, r.mustConnect)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to proxy: %w", err)
@@ -0,0 +1,211 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// What the node does when the application is unresponsive on the consensus
+// connection.
+const (
+	// UnresponsiveHalt stops the node, as when the application crashes.
+	UnresponsiveHalt = "halt"
+	// UnresponsiveDegrade keeps waiting for the application, the calls on the
+	// other connections failing fast meanwhile.
+	UnresponsiveDegrade = "degrade"
+)
+
+var (
+	// ErrTimeout is returned by the calls the application didn't answer within
+	// their deadline.
+	ErrTimeout = errors.New("the application did not respond in time")
+	// ErrCircuitOpen is returned, without calling the application, by the calls
+	// on a connection with too many consecutive timeouts.
+	ErrCircuitOpen = errors.New("the application is unresponsive, call refused")
+)
+
+// GuardConfig bounds the time the node waits for the application.
+//
+// The calls on the consensus connection can't be abandoned without the node
+// and the application diverging, so they are never failed: they are reported
+// once exceeding ConsensusTimeout, and the node halts or keeps waiting as set
+// by OnUnresponsive. The Async calls, answered through callbacks, are not
+// bounded.
+type GuardConfig struct {
+	// Deadline of the calls on the mempool, query and snapshot connections;
+	// zero waits indefinitely.
+	Timeout time.Duration
+	// Time after which a call on the consensus connection is reported as
+	// unresponsive; zero never reports it.
+	ConsensusTimeout time.Duration
+	// Number of times the Info and Query calls timing out are retried.
+	QueryRetries int
+	// Number of consecutive timeouts on a connection after which its calls are
+	// refused for BreakerCooldown, before one probes the application again;
+	// zero disables the circuit breaker.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// UnresponsiveHalt or UnresponsiveDegrade.
+	OnUnresponsive string
+}
+
+// guard bounds the calls on a connection, as set by a GuardConfig. A nil guard
+// doesn't bound them.
+type guard struct {
+	conn    string
+	cfg     GuardConfig
+	metrics *Metrics
+	logger  cmtlog.Logger
+	breaker *breaker
+	halt    func()
+}
+
+func newGuard(conn string, cfg GuardConfig, metrics *Metrics, logger cmtlog.Logger) *guard {
+	g := &guard{
+		conn:    conn,
+		cfg:     cfg,
+		metrics: metrics,
+		logger:  logger,
+		halt:    killProcess(logger),
+	}
+	if cfg.BreakerThreshold > 0 {
+		g.breaker = &breaker{threshold: cfg.BreakerThreshold, cooldown: cfg.BreakerCooldown}
+	}
+	return g
+}
+
+// call returns the result of fn, or ErrTimeout if it doesn't return within the
+// deadline; fn then keeps running in the background.
+func call[T any](g *guard, method string, fn func() (T, error)) (T, error) {
+	var zero T
+	if g == nil || g.cfg.Timeout == 0 {
+		return fn()
+	}
+	if !g.breaker.allow() {
+		g.metrics.RefusedCalls.With("method", method).Add(1)
+		return zero, fmt.Errorf("%s: %w", method, ErrCircuitOpen)
+	}
+
+	type result struct {
+		res T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res, err := fn()
+		done <- result{res, err}
+	}()
+	timer := time.NewTimer(g.cfg.Timeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-done:
+		g.breaker.succeeded()
+		return r.res, r.err
+	case <-timer.C:
+		g.metrics.Timeouts.With("method", method).Add(1)
+		if g.breaker.failed() {
+			g.logger.Error("Application unresponsive, refusing the calls",
+				"connection", g.conn, "cooldown", g.cfg.BreakerCooldown)
+		}
+		return zero, fmt.Errorf("%s: %w", method, ErrTimeout)
+	}
+}
+
+// retry calls fn, retrying it while it times out. fn must be idempotent.
+func retry[T any](g *guard, method string, fn func() (T, error)) (T, error) {
+	res, err := call(g, method, fn)
+	for i := 0; g != nil && i < g.cfg.QueryRetries && errors.Is(err, ErrTimeout); i++ {
+		res, err = call(g, method, fn)
+	}
+	return res, err
+}
+
+// watch reports the call to method, on the consensus connection, as
+// unresponsive if it doesn't return within the consensus timeout. The returned
+// function must be called once the call returns.
+func (g *guard) watch(method string) func() {
+	if g == nil || g.cfg.ConsensusTimeout == 0 {
+		return func() {}
+	}
+	timer := time.AfterFunc(g.cfg.ConsensusTimeout, func() {
+		g.metrics.Timeouts.With("method", method).Add(1)
+		if g.cfg.OnUnresponsive == UnresponsiveHalt {
+			g.logger.Error("Application unresponsive, halting", "method", method, "timeout", g.cfg.ConsensusTimeout)
+			g.halt()
+			return
+		}
+		g.logger.Error("Application unresponsive, still waiting", "method", method, "timeout", g.cfg.ConsensusTimeout)
+	})
+	return func() { timer.Stop() }
+}
+
+func killProcess(logger cmtlog.Logger) func() {
+	return func() {
+		if err := cmtos.Kill(); err != nil {
+			logger.Error("Failed to kill this process - please do so manually", "err", err)
+		}
+	}
+}
+
+// breaker refuses the calls on a connection after threshold consecutive
+// timeouts. Once cooldown has passed, a call probes the application: the
+// breaker closes if it succeeds, and stays open for another cooldown if not. A
+// nil breaker is always closed.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mtx       cmtsync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func (b *breaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	now := time.Now()
+	if now.Before(b.openUntil) {
+		return false
+	}
+	// let this call probe the application, the others waiting for its outcome
+	b.openUntil = now.Add(b.cooldown)
+	return true
+}
+
+func (b *breaker) succeeded() {
+	if b == nil {
+		return
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.failures = 0
+}
+
+// failed records a timeout, and returns whether it opened the breaker.
+func (b *breaker) failed() bool {
+	if b == nil {
+		return false
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.failures++
+	if b.failures < b.threshold {
+		return false
+	}
+	b.openUntil = time.Now().Add(b.cooldown)
+	return b.failures == b.threshold
+}
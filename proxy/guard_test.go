@@ -0,0 +1,118 @@
+package proxy
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func testGuard(cfg GuardConfig) *guard {
+	return newGuard("query", cfg, NopMetrics(), log.TestingLogger())
+}
+
+func TestGuardCallTimeout(t *testing.T) {
+	g := testGuard(GuardConfig{Timeout: 10 * time.Millisecond})
+
+	res, err := call(g, "info", func() (int, error) { return 1, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 1, res)
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	_, err = call(g, "info", func() (int, error) {
+		<-unblock
+		return 1, nil
+	})
+	assert.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestGuardRetry(t *testing.T) {
+	g := testGuard(GuardConfig{Timeout: 10 * time.Millisecond, QueryRetries: 2})
+
+	// the first two attempts time out, the third succeeds
+	var attempts int32
+	res, err := retry(g, "query", func() (int, error) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			time.Sleep(50 * time.Millisecond)
+		}
+		return 2, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, res)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&attempts))
+
+	// the client errors are not retried
+	attempts = 0
+	_, err = retry(g, "query", func() (int, error) {
+		atomic.AddInt32(&attempts, 1)
+		return 0, errors.New("connection closed")
+	})
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&attempts))
+}
+
+func TestGuardBreaker(t *testing.T) {
+	g := testGuard(GuardConfig{
+		Timeout:          10 * time.Millisecond,
+		BreakerThreshold: 2,
+		BreakerCooldown:  50 * time.Millisecond,
+	})
+	slow := func() (int, error) {
+		time.Sleep(30 * time.Millisecond)
+		return 0, nil
+	}
+	fast := func() (int, error) { return 3, nil }
+
+	for i := 0; i < 2; i++ {
+		_, err := call(g, "query", slow)
+		assert.ErrorIs(t, err, ErrTimeout)
+	}
+	// open: the calls are refused without reaching the application
+	_, err := call(g, "query", fast)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// after the cooldown, a failed probe opens the breaker again
+	time.Sleep(60 * time.Millisecond)
+	_, err = call(g, "query", slow)
+	assert.ErrorIs(t, err, ErrTimeout)
+	_, err = call(g, "query", fast)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// and a successful one closes it
+	time.Sleep(60 * time.Millisecond)
+	res, err := call(g, "query", fast)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res)
+	res, err = call(g, "query", fast)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res)
+}
+
+func TestGuardWatch(t *testing.T) {
+	for _, mode := range []string{UnresponsiveHalt, UnresponsiveDegrade} {
+		t.Run(mode, func(t *testing.T) {
+			g := newGuard("consensus", GuardConfig{
+				ConsensusTimeout: 10 * time.Millisecond,
+				OnUnresponsive:   mode,
+			}, NopMetrics(), log.TestingLogger())
+			var halted int32
+			g.halt = func() { atomic.StoreInt32(&halted, 1) }
+
+			// a call returning in time is not reported
+			g.watch("commit")()
+			time.Sleep(20 * time.Millisecond)
+			assert.Zero(t, atomic.LoadInt32(&halted))
+
+			done := g.watch("finalize_block")
+			time.Sleep(20 * time.Millisecond)
+			done()
+			assert.Equal(t, mode == UnresponsiveHalt, atomic.LoadInt32(&halted) == 1)
+		})
+	}
+}
@@ -22,11 +22,25 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 			Buckets: []float64{.0001, .0004, .002, .009, .02, .1, .65, 2, 6, 25},
 		}, append(labels, "method", "type")).With(labelsAndValues...),
+		Timeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "timeouts",
+			Help:      "Number of the ABCI calls which exceeded their deadline.",
+		}, append(labels, "method")).With(labelsAndValues...),
+		RefusedCalls: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "refused_calls",
+			Help:      "Number of the ABCI calls refused by the circuit breaker.",
+		}, append(labels, "method")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
 		MethodTimingSeconds: discard.NewHistogram(),
+		Timeouts:            discard.NewCounter(),
+		RefusedCalls:        discard.NewCounter(),
 	}
 }
@@ -16,4 +16,10 @@ const (
 type Metrics struct {
 	// Timing for each ABCI method.
 	MethodTimingSeconds metrics.Histogram `metrics_bucketsizes:".0001,.0004,.002,.009,.02,.1,.65,2,6,25" metrics_labels:"method, type"`
+
+	// Number of the ABCI calls which exceeded their deadline.
+	Timeouts metrics.Counter `metrics_labels:"method"`
+
+	// Number of the ABCI calls refused by the circuit breaker.
+	RefusedCalls metrics.Counter `metrics_labels:"method"`
 }
@@ -32,8 +32,23 @@ type AppConns interface {
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator ClientCreator, metrics *Metrics) AppConns {
-	return NewMultiAppConn(clientCreator, metrics)
+func NewAppConns(clientCreator ClientCreator, metrics *Metrics, options ...AppConnsOption) AppConns {
+	return NewMultiAppConn(clientCreator, metrics, options...)
+}
+
+// AppConnsOption sets an optional parameter on the AppConns.
+type AppConnsOption func(*multiAppConn)
+
+// WithGuard bounds the time the connections wait for the application, as set
+// by cfg. By default, they wait indefinitely.
+func WithGuard(cfg GuardConfig) AppConnsOption {
+	return func(app *multiAppConn) { app.guardConfig = &cfg }
+}
+
+// WithRecorder records the calls made on the connections with rec, which is
+// closed once they stop.
+func WithRecorder(rec *Recorder) AppConnsOption {
+	return func(app *multiAppConn) { app.recorder = rec }
 }
 
 // multiAppConn implements AppConns.
@@ -56,14 +71,19 @@ type multiAppConn struct {
 	snapshotConnClient  abcicli.Client
 
 	clientCreator ClientCreator
+	guardConfig   *GuardConfig
+	recorder      *Recorder
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator ClientCreator, metrics *Metrics) AppConns {
+func NewMultiAppConn(clientCreator ClientCreator, metrics *Metrics, options ...AppConnsOption) AppConns {
 	multiAppConn := &multiAppConn{
 		metrics:       metrics,
 		clientCreator: clientCreator,
 	}
+	for _, option := range options {
+		option(multiAppConn)
+	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
 	return multiAppConn
 }
@@ -90,7 +110,7 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.queryConnClient = c
-	app.queryConn = NewAppConnQuery(c, app.metrics)
+	app.queryConn = newAppConnQuery(c, app.metrics, app.guardFor(connQuery))
 
 	c, err = app.abciClientFor(connSnapshot)
 	if err != nil {
@@ -98,7 +118,7 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.snapshotConnClient = c
-	app.snapshotConn = NewAppConnSnapshot(c, app.metrics)
+	app.snapshotConn = newAppConnSnapshot(c, app.metrics, app.guardFor(connSnapshot))
 
 	c, err = app.abciClientFor(connMempool)
 	if err != nil {
@@ -106,7 +126,7 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.mempoolConnClient = c
-	app.mempoolConn = NewAppConnMempool(c, app.metrics)
+	app.mempoolConn = newAppConnMempool(c, app.metrics, app.guardFor(connMempool))
 
 	c, err = app.abciClientFor(connConsensus)
 	if err != nil {
@@ -114,7 +134,7 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.consensusConnClient = c
-	app.consensusConn = NewAppConnConsensus(c, app.metrics)
+	app.consensusConn = newAppConnConsensus(c, app.metrics, app.guardFor(connConsensus), app.queryConn.InfoSync)
 
 	// Kill CometBFT if the ABCI application crashes.
 	go app.killTMOnClientError()
@@ -124,6 +144,11 @@ func (app *multiAppConn) OnStart() error {
 
 func (app *multiAppConn) OnStop() {
 	app.stopAllClients()
+	if app.recorder != nil {
+		if err := app.recorder.Close(); err != nil {
+			app.Logger.Error("error while closing the ABCI recorder", "error", err)
+		}
+	}
 }
 
 func (app *multiAppConn) killTMOnClientError() {
@@ -180,6 +205,13 @@ func (app *multiAppConn) stopAllClients() {
 	}
 }
 
+func (app *multiAppConn) guardFor(conn string) *guard {
+	if app.guardConfig == nil {
+		return nil
+	}
+	return newGuard(conn, *app.guardConfig, app.metrics, app.Logger.With("connection", conn))
+}
+
 func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
 	c, err := app.clientCreator.NewABCIClient()
 	if err != nil {
@@ -189,5 +221,8 @@ func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
 	if err := c.Start(); err != nil {
 		return nil, fmt.Errorf("error starting ABCI client (%s connection): %w", conn, err)
 	}
+	if app.recorder != nil {
+		return newRecordingClient(conn, c, app.recorder), nil
+	}
 	return c, nil
 }
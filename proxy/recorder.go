@@ -0,0 +1,326 @@
+package proxy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/types"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	cmtproxy "github.com/cometbft/cometbft/proto/tendermint/proxy"
+)
+
+// Recorder writes the ABCI calls made on the proxy app connections to a file,
+// as length-delimited RecordedCall messages. The calls are written as their
+// responses are received, so that the trace can be replayed in order against
+// an application, e.g. with `cometbft abci-replay`, to reproduce the bugs of
+// non-deterministic applications. The Echo and Flush calls are not recorded.
+type Recorder struct {
+	mtx  cmtsync.Mutex
+	file *os.File
+	w    *bufio.Writer
+	err  error
+}
+
+// NewRecorder returns a recorder appending the calls to the file at path.
+func NewRecorder(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{file: f, w: bufio.NewWriter(f)}, nil
+}
+
+// Close closes the file of the recorder.
+func (r *Recorder) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := r.w.Flush(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+func (r *Recorder) record(conn string, start time.Time, req *types.Request, res *types.Response) {
+	call := &cmtproxy.RecordedCall{
+		Connection: conn,
+		Time:       start,
+		Duration:   time.Since(start),
+		Request:    req,
+		Response:   res,
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.err != nil {
+		return
+	}
+	// flush each call, so that the trace covers a crash of the node
+	if err := types.WriteMessage(call, r.w); err != nil {
+		r.err = fmt.Errorf("recording the ABCI call: %w", err)
+		return
+	}
+	if err := r.w.Flush(); err != nil {
+		r.err = fmt.Errorf("recording the ABCI call: %w", err)
+	}
+}
+
+// Error returns the error which stopped the recording, if any.
+func (r *Recorder) Error() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.err
+}
+
+// ReadRecordedCall reads the next call from a trace written by a Recorder. It
+// returns io.EOF at the end of the trace.
+func ReadRecordedCall(r io.Reader) (*cmtproxy.RecordedCall, error) {
+	call := &cmtproxy.RecordedCall{}
+	if err := types.ReadMessage(r, call); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+//----------------------------------------
+
+// recordingClient records the calls made on a connection.
+type recordingClient struct {
+	abcicli.Client
+
+	conn string
+	rec  *Recorder
+}
+
+var _ abcicli.Client = (*recordingClient)(nil)
+
+func newRecordingClient(conn string, client abcicli.Client, rec *Recorder) abcicli.Client {
+	return &recordingClient{Client: client, conn: conn, rec: rec}
+}
+
+// recordAsync records the call of reqres once it is answered. It returns a
+// ReqRes of its own, as the callback of reqres is taken.
+func (c *recordingClient) recordAsync(start time.Time, reqres *abcicli.ReqRes) *abcicli.ReqRes {
+	recorded := abcicli.NewReqRes(reqres.Request)
+	reqres.SetCallback(func(res *types.Response) {
+		c.rec.record(c.conn, start, reqres.Request, res)
+		recorded.Response = res
+		recorded.Done()
+		recorded.InvokeCallback()
+	})
+	return recorded
+}
+
+// recordSync calls fn with req, and records the call if it succeeds.
+func recordSync[Req, Res any](
+	c *recordingClient,
+	req Req,
+	fn func(Req) (*Res, error),
+	toRequest func(Req) *types.Request,
+	toResponse func(Res) *types.Response,
+) (*Res, error) {
+	start := time.Now()
+	res, err := fn(req)
+	if err == nil {
+		c.rec.record(c.conn, start, toRequest(req), toResponse(*res))
+	}
+	return res, err
+}
+
+func (c *recordingClient) InfoAsync(req types.RequestInfo) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.InfoAsync(req))
+}
+
+func (c *recordingClient) DeliverTxAsync(req types.RequestDeliverTx) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.DeliverTxAsync(req))
+}
+
+func (c *recordingClient) CheckTxAsync(req types.RequestCheckTx) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.CheckTxAsync(req))
+}
+
+func (c *recordingClient) QueryAsync(req types.RequestQuery) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.QueryAsync(req))
+}
+
+func (c *recordingClient) CommitAsync() *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.CommitAsync())
+}
+
+func (c *recordingClient) InitChainAsync(req types.RequestInitChain) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.InitChainAsync(req))
+}
+
+func (c *recordingClient) PrepareProposalAsync(req types.RequestPrepareProposal) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.PrepareProposalAsync(req))
+}
+
+func (c *recordingClient) BeginBlockAsync(req types.RequestBeginBlock) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.BeginBlockAsync(req))
+}
+
+func (c *recordingClient) EndBlockAsync(req types.RequestEndBlock) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.EndBlockAsync(req))
+}
+
+func (c *recordingClient) ListSnapshotsAsync(req types.RequestListSnapshots) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.ListSnapshotsAsync(req))
+}
+
+func (c *recordingClient) OfferSnapshotAsync(req types.RequestOfferSnapshot) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.OfferSnapshotAsync(req))
+}
+
+func (c *recordingClient) LoadSnapshotChunkAsync(req types.RequestLoadSnapshotChunk) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.LoadSnapshotChunkAsync(req))
+}
+
+func (c *recordingClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotChunk) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.ApplySnapshotChunkAsync(req))
+}
+
+func (c *recordingClient) ProcessProposalAsync(req types.RequestProcessProposal) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.ProcessProposalAsync(req))
+}
+
+func (c *recordingClient) FinalizeBlockAsync(req types.RequestFinalizeBlock) *abcicli.ReqRes {
+	return c.recordAsync(time.Now(), c.Client.FinalizeBlockAsync(req))
+}
+
+func (c *recordingClient) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
+	return recordSync(c, req, c.Client.InfoSync, types.ToRequestInfo, types.ToResponseInfo)
+}
+
+func (c *recordingClient) DeliverTxSync(req types.RequestDeliverTx) (*types.ResponseDeliverTx, error) {
+	return recordSync(c, req, c.Client.DeliverTxSync, types.ToRequestDeliverTx, types.ToResponseDeliverTx)
+}
+
+func (c *recordingClient) CheckTxSync(req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	return recordSync(c, req, c.Client.CheckTxSync, types.ToRequestCheckTx, types.ToResponseCheckTx)
+}
+
+func (c *recordingClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
+	return recordSync(c, req, c.Client.QuerySync, types.ToRequestQuery, types.ToResponseQuery)
+}
+
+func (c *recordingClient) CommitSync() (*types.ResponseCommit, error) {
+	start := time.Now()
+	res, err := c.Client.CommitSync()
+	if err == nil {
+		c.rec.record(c.conn, start, types.ToRequestCommit(), types.ToResponseCommit(*res))
+	}
+	return res, err
+}
+
+func (c *recordingClient) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
+	return recordSync(c, req, c.Client.InitChainSync, types.ToRequestInitChain, types.ToResponseInitChain)
+}
+
+func (c *recordingClient) PrepareProposalSync(req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	return recordSync(c, req, c.Client.PrepareProposalSync, types.ToRequestPrepareProposal,
+		types.ToResponsePrepareProposal)
+}
+
+func (c *recordingClient) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
+	return recordSync(c, req, c.Client.BeginBlockSync, types.ToRequestBeginBlock, types.ToResponseBeginBlock)
+}
+
+func (c *recordingClient) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+	return recordSync(c, req, c.Client.EndBlockSync, types.ToRequestEndBlock, types.ToResponseEndBlock)
+}
+
+func (c *recordingClient) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	return recordSync(c, req, c.Client.ListSnapshotsSync, types.ToRequestListSnapshots,
+		types.ToResponseListSnapshots)
+}
+
+func (c *recordingClient) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	return recordSync(c, req, c.Client.OfferSnapshotSync, types.ToRequestOfferSnapshot,
+		types.ToResponseOfferSnapshot)
+}
+
+func (c *recordingClient) LoadSnapshotChunkSync(
+	req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	return recordSync(c, req, c.Client.LoadSnapshotChunkSync, types.ToRequestLoadSnapshotChunk,
+		types.ToResponseLoadSnapshotChunk)
+}
+
+func (c *recordingClient) ApplySnapshotChunkSync(
+	req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	return recordSync(c, req, c.Client.ApplySnapshotChunkSync, types.ToRequestApplySnapshotChunk,
+		types.ToResponseApplySnapshotChunk)
+}
+
+func (c *recordingClient) ProcessProposalSync(req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	return recordSync(c, req, c.Client.ProcessProposalSync, types.ToRequestProcessProposal,
+		types.ToResponseProcessProposal)
+}
+
+func (c *recordingClient) FinalizeBlockSync(req types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
+	return recordSync(c, req, c.Client.FinalizeBlockSync, types.ToRequestFinalizeBlock,
+		types.ToResponseFinalizeBlock)
+}
+
+// Replay makes the recorded call on client, and returns the response of the
+// application.
+func Replay(client abcicli.Client, call *cmtproxy.RecordedCall) (*types.Response, error) {
+	var (
+		res *types.Response
+		err error
+	)
+	switch req := call.GetRequest().GetValue().(type) {
+	case *types.Request_Info:
+		res, err = replaySync(client.InfoSync, *req.Info, types.ToResponseInfo)
+	case *types.Request_DeliverTx:
+		res, err = replaySync(client.DeliverTxSync, *req.DeliverTx, types.ToResponseDeliverTx)
+	case *types.Request_CheckTx:
+		res, err = replaySync(client.CheckTxSync, *req.CheckTx, types.ToResponseCheckTx)
+	case *types.Request_Query:
+		res, err = replaySync(client.QuerySync, *req.Query, types.ToResponseQuery)
+	case *types.Request_Commit:
+		var commit *types.ResponseCommit
+		if commit, err = client.CommitSync(); err == nil {
+			res = types.ToResponseCommit(*commit)
+		}
+	case *types.Request_InitChain:
+		res, err = replaySync(client.InitChainSync, *req.InitChain, types.ToResponseInitChain)
+	case *types.Request_PrepareProposal:
+		res, err = replaySync(client.PrepareProposalSync, *req.PrepareProposal, types.ToResponsePrepareProposal)
+	case *types.Request_BeginBlock:
+		res, err = replaySync(client.BeginBlockSync, *req.BeginBlock, types.ToResponseBeginBlock)
+	case *types.Request_EndBlock:
+		res, err = replaySync(client.EndBlockSync, *req.EndBlock, types.ToResponseEndBlock)
+	case *types.Request_ListSnapshots:
+		res, err = replaySync(client.ListSnapshotsSync, *req.ListSnapshots, types.ToResponseListSnapshots)
+	case *types.Request_OfferSnapshot:
+		res, err = replaySync(client.OfferSnapshotSync, *req.OfferSnapshot, types.ToResponseOfferSnapshot)
+	case *types.Request_LoadSnapshotChunk:
+		res, err = replaySync(client.LoadSnapshotChunkSync, *req.LoadSnapshotChunk, types.ToResponseLoadSnapshotChunk)
+	case *types.Request_ApplySnapshotChunk:
+		res, err = replaySync(client.ApplySnapshotChunkSync, *req.ApplySnapshotChunk,
+			types.ToResponseApplySnapshotChunk)
+	case *types.Request_ProcessProposal:
+		res, err = replaySync(client.ProcessProposalSync, *req.ProcessProposal, types.ToResponseProcessProposal)
+	case *types.Request_FinalizeBlock:
+		res, err = replaySync(client.FinalizeBlockSync, *req.FinalizeBlock, types.ToResponseFinalizeBlock)
+	default:
+		return nil, fmt.Errorf("unexpected recorded request %T", req)
+	}
+	return res, err
+}
+
+func replaySync[Req, Res any](
+	fn func(Req) (*Res, error),
+	req Req,
+	toResponse func(Res) *types.Response,
+) (*types.Response, error) {
+	res, err := fn(req)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(*res), nil
+}
@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	"github.com/cometbft/cometbft/abci/types"
+	cmtproxy "github.com/cometbft/cometbft/proto/tendermint/proxy"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "abci.rec")
+	rec, err := NewRecorder(path)
+	require.NoError(t, err)
+
+	appConns := NewAppConns(NewLocalClientCreator(kvstore.NewApplication()), NopMetrics(), WithRecorder(rec))
+	require.NoError(t, appConns.Start())
+
+	appConns.Mempool().SetResponseCallback(func(*types.Request, *types.Response) {})
+	var checked *types.Response
+	reqRes := appConns.Mempool().CheckTxAsync(types.RequestCheckTx{Tx: []byte("a=1")})
+	reqRes.SetCallback(func(res *types.Response) { checked = res })
+	reqRes.Wait()
+	require.NotNil(t, checked)
+	assert.True(t, checked.GetCheckTx().IsOK())
+
+	_, err = appConns.Consensus().FinalizeBlockSync(types.RequestFinalizeBlock{
+		Header: cmtproto.Header{Height: 1},
+		Txs:    [][]byte{[]byte("a=1")},
+	})
+	require.NoError(t, err)
+	_, err = appConns.Consensus().CommitSync()
+	require.NoError(t, err)
+	_, err = appConns.Query().QuerySync(types.RequestQuery{Data: []byte("a")})
+	require.NoError(t, err)
+	// the echo calls are not recorded
+	_, err = appConns.Query().EchoSync("hello")
+	require.NoError(t, err)
+
+	require.NoError(t, appConns.Stop())
+	require.NoError(t, rec.Error())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var calls []*cmtproxy.RecordedCall
+	for r := bytes.NewReader(data); ; {
+		call, err := ReadRecordedCall(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		calls = append(calls, call)
+	}
+	require.Len(t, calls, 5)
+	assert.Equal(t, connMempool, calls[0].Connection)
+	assert.Equal(t, checked, calls[0].Response)
+	// the Info telling whether FinalizeBlock is handled, before the first block
+	assert.Equal(t, connQuery, calls[1].Connection)
+	assert.True(t, calls[1].Response.GetInfo().FinalizeBlock)
+	assert.Equal(t, connConsensus, calls[2].Connection)
+	assert.NotNil(t, calls[2].Request.GetFinalizeBlock())
+	assert.NotNil(t, calls[3].Request.GetCommit())
+	assert.Equal(t, connQuery, calls[4].Connection)
+	assert.Equal(t, []byte("1"), calls[4].Response.GetQuery().Value)
+
+	// replayed against a fresh application, the responses are the same
+	client := abcicli.NewLocalClient(nil, kvstore.NewApplication())
+	for _, call := range calls {
+		res, err := Replay(client, call)
+		require.NoError(t, err)
+		assert.Equal(t, call.Response, res)
+	}
+
+	// but not against one in another state
+	_, err = Replay(client, calls[2])
+	require.NoError(t, err)
+	res, err := Replay(client, calls[3])
+	require.NoError(t, err)
+	assert.NotEqual(t, calls[3].Response, res)
+}
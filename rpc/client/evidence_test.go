@@ -37,10 +37,10 @@ func newEvidence(t *testing.T, val *privval.FilePV,
 	v := vote.ToProto()
 	v2 := vote2.ToProto()
 
-	vote.Signature, err = val.Key.PrivKey.Sign(types.VoteSignBytes(chainID, v))
+	vote.Signature, err = val.Key.PrivKey.Sign(types.VoteSignBytes(chainID, types.CanonicalEncodingProto, v))
 	require.NoError(t, err)
 
-	vote2.Signature, err = val.Key.PrivKey.Sign(types.VoteSignBytes(chainID, v2))
+	vote2.Signature, err = val.Key.PrivKey.Sign(types.VoteSignBytes(chainID, types.CanonicalEncodingProto, v2))
 	require.NoError(t, err)
 
 	validator := types.NewValidator(val.Key.PubKey, 10)
@@ -372,6 +372,15 @@ func (c *baseRPCClient) Health(ctx context.Context) (*ctypes.ResultHealth, error
 	return result, nil
 }
 
+func (c *baseRPCClient) HealthDetailed(ctx context.Context) (*ctypes.ResultHealthDetailed, error) {
+	result := new(ctypes.ResultHealthDetailed)
+	_, err := c.caller.Call(ctx, "health/detailed", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BlockchainInfo(
 	ctx context.Context,
 	minHeight,
@@ -430,6 +439,18 @@ func (c *baseRPCClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.R
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockByTime(ctx context.Context, t time.Time) (*ctypes.ResultBlock, error) {
+	result := new(ctypes.ResultBlock)
+	params := map[string]interface{}{
+		"time": t,
+	}
+	_, err := c.caller.Call(ctx, "block_by_time", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BlockResults(
 	ctx context.Context,
 	height *int64,
@@ -446,6 +467,22 @@ func (c *baseRPCClient) BlockResults(
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockEvents(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultBlockEvents, error) {
+	result := new(ctypes.ResultBlockEvents)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "block_events", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
 	result := new(ctypes.ResultHeader)
 	params := make(map[string]interface{})
@@ -484,6 +521,19 @@ func (c *baseRPCClient) Commit(ctx context.Context, height *int64) (*ctypes.Resu
 	return result, nil
 }
 
+func (c *baseRPCClient) CommitAggregated(ctx context.Context, height *int64) (*ctypes.ResultCommitAggregated, error) {
+	result := new(ctypes.ResultCommitAggregated)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "commit_aggregated", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	result := new(ctypes.ResultTx)
 	params := map[string]interface{}{
@@ -497,6 +547,15 @@ func (c *baseRPCClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctype
 	return result, nil
 }
 
+func (c *baseRPCClient) TxProof(ctx context.Context, hash []byte) (*ctypes.ResultTxProof, error) {
+	result := new(ctypes.ResultTxProof)
+	_, err := c.caller.Call(ctx, "tx_proof", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) TxSearch(
 	ctx context.Context,
 	query string,
@@ -580,6 +639,35 @@ func (c *baseRPCClient) Validators(
 	return result, nil
 }
 
+func (c *baseRPCClient) ValidatorsCommitment(ctx context.Context, height *int64) (*ctypes.ResultValidatorsCommitment, error) {
+	result := new(ctypes.ResultValidatorsCommitment)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "validators_commitment", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ValidatorsDiff(ctx context.Context, from, to *int64) (*ctypes.ResultValidatorsDiff, error) {
+	result := new(ctypes.ResultValidatorsDiff)
+	params := make(map[string]interface{})
+	if from != nil {
+		params["from"] = from
+	}
+	if to != nil {
+		params["to"] = to
+	}
+	_, err := c.caller.Call(ctx, "validators_diff", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastEvidence(
 	ctx context.Context,
 	ev types.Evidence,
@@ -22,6 +22,7 @@ implementation.
 
 import (
 	"context"
+	"time"
 
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/libs/service"
@@ -66,12 +67,18 @@ type ABCIClient interface {
 type SignClient interface {
 	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
 	BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error)
+	BlockByTime(ctx context.Context, t time.Time) (*ctypes.ResultBlock, error)
 	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+	BlockEvents(ctx context.Context, height *int64) (*ctypes.ResultBlockEvents, error)
 	Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error)
 	HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*ctypes.ResultHeader, error)
 	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+	CommitAggregated(ctx context.Context, height *int64) (*ctypes.ResultCommitAggregated, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error)
+	ValidatorsCommitment(ctx context.Context, height *int64) (*ctypes.ResultValidatorsCommitment, error)
+	ValidatorsDiff(ctx context.Context, from, to *int64) (*ctypes.ResultValidatorsDiff, error)
 	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
+	TxProof(ctx context.Context, hash []byte) (*ctypes.ResultTxProof, error)
 
 	// TxSearch defines a method to search for a paginated set of transactions by
 	// DeliverTx event search criteria.
@@ -113,6 +120,7 @@ type NetworkClient interface {
 	ConsensusState(context.Context) (*ctypes.ResultConsensusState, error)
 	ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error)
 	Health(context.Context) (*ctypes.ResultHealth, error)
+	HealthDetailed(context.Context) (*ctypes.ResultHealthDetailed, error)
 }
 
 // EventsClient is reactive, you can subscribe to any message, given the proper
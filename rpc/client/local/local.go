@@ -129,6 +129,10 @@ func (c *Local) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	return c.env.Health(c.ctx)
 }
 
+func (c *Local) HealthDetailed(ctx context.Context) (*ctypes.ResultHealthDetailed, error) {
+	return c.env.HealthDetailed(c.ctx)
+}
+
 func (c *Local) DialSeeds(ctx context.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
 	return c.env.UnsafeDialSeeds(c.ctx, seeds)
 }
@@ -163,30 +167,54 @@ func (c *Local) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlo
 	return c.env.BlockByHash(c.ctx, hash)
 }
 
+func (c *Local) BlockByTime(ctx context.Context, t time.Time) (*ctypes.ResultBlock, error) {
+	return c.env.BlockByTime(c.ctx, t)
+}
+
 func (c *Local) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
 	return c.env.BlockResults(c.ctx, height)
 }
 
+func (c *Local) BlockEvents(ctx context.Context, height *int64) (*ctypes.ResultBlockEvents, error) {
+	return c.env.BlockEvents(c.ctx, height)
+}
+
 func (c *Local) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
-	return c.env.Header(c.ctx, height)
+	return c.env.Header(c.ctx, height, false)
 }
 
 func (c *Local) HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*ctypes.ResultHeader, error) {
-	return c.env.HeaderByHash(c.ctx, hash)
+	return c.env.HeaderByHash(c.ctx, hash, false)
 }
 
 func (c *Local) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
 	return c.env.Commit(c.ctx, height)
 }
 
+func (c *Local) CommitAggregated(ctx context.Context, height *int64) (*ctypes.ResultCommitAggregated, error) {
+	return c.env.CommitAggregated(c.ctx, height)
+}
+
 func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return c.env.Validators(c.ctx, height, page, perPage)
 }
 
+func (c *Local) ValidatorsCommitment(ctx context.Context, height *int64) (*ctypes.ResultValidatorsCommitment, error) {
+	return c.env.ValidatorsCommitment(c.ctx, height)
+}
+
+func (c *Local) ValidatorsDiff(ctx context.Context, from, to *int64) (*ctypes.ResultValidatorsDiff, error) {
+	return c.env.ValidatorsDiff(c.ctx, from, to)
+}
+
 func (c *Local) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	return c.env.Tx(c.ctx, hash, prove)
 }
 
+func (c *Local) TxProof(ctx context.Context, hash []byte) (*ctypes.ResultTxProof, error) {
+	return c.env.TxProof(c.ctx, hash)
+}
+
 func (c *Local) TxSearch(
 	_ context.Context,
 	query string,
@@ -195,7 +223,7 @@ func (c *Local) TxSearch(
 	perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
-	return c.env.TxSearch(c.ctx, query, prove, page, perPage, orderBy)
+	return c.env.TxSearch(c.ctx, query, prove, page, perPage, orderBy, "")
 }
 
 func (c *Local) BlockSearch(
@@ -204,7 +232,7 @@ func (c *Local) BlockSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultBlockSearch, error) {
-	return c.env.BlockSearch(c.ctx, query, page, perPage, orderBy)
+	return c.env.BlockSearch(c.ctx, query, page, perPage, orderBy, "")
 }
 
 func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
@@ -17,6 +17,7 @@ want to directly call a CometBFT node in process, you can use the
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/libs/service"
@@ -139,6 +140,10 @@ func (c Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	return c.env.Health(&rpctypes.Context{})
 }
 
+func (c Client) HealthDetailed(ctx context.Context) (*ctypes.ResultHealthDetailed, error) {
+	return c.env.HealthDetailed(&rpctypes.Context{})
+}
+
 func (c Client) DialSeeds(ctx context.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
 	return c.env.UnsafeDialSeeds(&rpctypes.Context{}, seeds)
 }
@@ -169,14 +174,30 @@ func (c Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlo
 	return c.env.BlockByHash(&rpctypes.Context{}, hash)
 }
 
+func (c Client) BlockByTime(ctx context.Context, t time.Time) (*ctypes.ResultBlock, error) {
+	return c.env.BlockByTime(&rpctypes.Context{}, t)
+}
+
 func (c Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
 	return c.env.Commit(&rpctypes.Context{}, height)
 }
 
+func (c Client) CommitAggregated(ctx context.Context, height *int64) (*ctypes.ResultCommitAggregated, error) {
+	return c.env.CommitAggregated(&rpctypes.Context{}, height)
+}
+
 func (c Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return c.env.Validators(&rpctypes.Context{}, height, page, perPage)
 }
 
+func (c Client) ValidatorsCommitment(ctx context.Context, height *int64) (*ctypes.ResultValidatorsCommitment, error) {
+	return c.env.ValidatorsCommitment(&rpctypes.Context{}, height)
+}
+
+func (c Client) ValidatorsDiff(ctx context.Context, from, to *int64) (*ctypes.ResultValidatorsDiff, error) {
+	return c.env.ValidatorsDiff(&rpctypes.Context{}, from, to)
+}
+
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
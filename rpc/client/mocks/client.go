@@ -14,6 +14,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/cometbft/cometbft/types"
 )
 
@@ -137,6 +139,52 @@ func (_m *Client) BlockByHash(ctx context.Context, hash []byte) (*coretypes.Resu
 	return r0, r1
 }
 
+// BlockByTime provides a mock function with given fields: ctx, t
+func (_m *Client) BlockByTime(ctx context.Context, t time.Time) (*coretypes.ResultBlock, error) {
+	ret := _m.Called(ctx, t)
+
+	var r0 *coretypes.ResultBlock
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *coretypes.ResultBlock); ok {
+		r0 = rf(ctx, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlockEvents provides a mock function with given fields: ctx, height
+func (_m *Client) BlockEvents(ctx context.Context, height *int64) (*coretypes.ResultBlockEvents, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultBlockEvents
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultBlockEvents); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlockEvents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockResults provides a mock function with given fields: ctx, height
 func (_m *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
 	ret := _m.Called(ctx, height)
@@ -344,6 +392,29 @@ func (_m *Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultC
 	return r0, r1
 }
 
+// CommitAggregated provides a mock function with given fields: ctx, height
+func (_m *Client) CommitAggregated(ctx context.Context, height *int64) (*coretypes.ResultCommitAggregated, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultCommitAggregated
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultCommitAggregated); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultCommitAggregated)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConsensusParams provides a mock function with given fields: ctx, height
 func (_m *Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	ret := _m.Called(ctx, height)
@@ -528,6 +599,29 @@ func (_m *Client) Health(_a0 context.Context) (*coretypes.ResultHealth, error) {
 	return r0, r1
 }
 
+// HealthDetailed provides a mock function with given fields: _a0
+func (_m *Client) HealthDetailed(_a0 context.Context) (*coretypes.ResultHealthDetailed, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultHealthDetailed
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultHealthDetailed); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultHealthDetailed)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsRunning provides a mock function with given fields:
 func (_m *Client) IsRunning() bool {
 	ret := _m.Called()
@@ -774,6 +868,29 @@ func (_m *Client) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.R
 	return r0, r1
 }
 
+// TxProof provides a mock function with given fields: ctx, hash
+func (_m *Client) TxProof(ctx context.Context, hash []byte) (*coretypes.ResultTxProof, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultTxProof
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultTxProof); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TxSearch provides a mock function with given fields: ctx, query, prove, page, perPage, orderBy
 func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *int, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
 	ret := _m.Called(ctx, query, prove, page, perPage, orderBy)
@@ -870,3 +987,49 @@ func (_m *Client) Validators(ctx context.Context, height *int64, page *int, perP
 
 	return r0, r1
 }
+
+// ValidatorsCommitment provides a mock function with given fields: ctx, height
+func (_m *Client) ValidatorsCommitment(ctx context.Context, height *int64) (*coretypes.ResultValidatorsCommitment, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultValidatorsCommitment
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultValidatorsCommitment); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidatorsCommitment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidatorsDiff provides a mock function with given fields: ctx, from, to
+func (_m *Client) ValidatorsDiff(ctx context.Context, from *int64, to *int64) (*coretypes.ResultValidatorsDiff, error) {
+	ret := _m.Called(ctx, from, to)
+
+	var r0 *coretypes.ResultValidatorsDiff
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, *int64) *coretypes.ResultValidatorsDiff); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidatorsDiff)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, *int64) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -15,10 +15,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	mempl "github.com/cometbft/cometbft/mempool"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
 	"github.com/cometbft/cometbft/rpc/client"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	rpclocal "github.com/cometbft/cometbft/rpc/client/local"
@@ -163,6 +165,16 @@ func TestHealth(t *testing.T) {
 	}
 }
 
+func TestHealthDetailed(t *testing.T) {
+	for i, c := range GetClients() {
+		nc, ok := c.(client.NetworkClient)
+		require.True(t, ok, "%d", i)
+		res, err := nc.HealthDetailed(context.Background())
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.NotEmpty(t, res.Checks)
+	}
+}
+
 func TestGenesisAndValidators(t *testing.T) {
 	for i, c := range GetClients() {
 
@@ -502,6 +514,64 @@ func TestTx(t *testing.T) {
 	}
 }
 
+func TestBlockByTime(t *testing.T) {
+	for i, c := range GetClients() {
+		t.Logf("client %d", i)
+
+		status, err := c.Status(context.Background())
+		require.NoError(t, err)
+		height := status.SyncInfo.LatestBlockHeight
+		require.Greater(t, height, int64(1))
+		block, err := c.Block(context.Background(), &height)
+		require.NoError(t, err)
+
+		res, err := c.BlockByTime(context.Background(), block.Block.Time)
+		require.NoError(t, err)
+		assert.Equal(t, block, res)
+		res, err = c.BlockByTime(context.Background(), block.Block.Time.Add(-time.Nanosecond))
+		require.NoError(t, err)
+		assert.Equal(t, block, res)
+
+		first, err := c.BlockByTime(context.Background(), time.Time{})
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, first.Block.Height)
+
+		_, err = c.BlockByTime(context.Background(), time.Now().Add(time.Hour))
+		assert.Error(t, err)
+	}
+}
+
+func TestTxProof(t *testing.T) {
+	_, _, tx := MakeTxKV()
+	bres, err := getHTTPClient().BroadcastTxCommit(context.Background(), tx)
+	require.NoError(t, err)
+
+	for i, c := range GetClients() {
+		t.Logf("client %d", i)
+
+		res, err := c.TxProof(context.Background(), bres.Hash)
+		require.NoError(t, err)
+		assert.EqualValues(t, bres.Hash, res.Hash)
+		assert.EqualValues(t, bres.Height, res.Height)
+		assert.Zero(t, res.Index)
+
+		block, err := c.Block(context.Background(), &res.Height)
+		require.NoError(t, err)
+		assert.EqualValues(t, block.Block.DataHash, res.RootHash)
+
+		var proof cmtcrypto.CommitmentProof
+		require.NoError(t, proof.Unmarshal(res.Proof))
+		require.NotNil(t, proof.Exist)
+		assert.EqualValues(t, bres.Hash, append(proof.Exist.Key, proof.Exist.Value...))
+		rootHash, err := merkle.ICS23Root(proof.Exist)
+		require.NoError(t, err)
+		assert.EqualValues(t, res.RootHash, rootHash)
+
+		_, err = c.TxProof(context.Background(), types.Tx("a different tx").Hash())
+		assert.Error(t, err)
+	}
+}
+
 func TestTxSearchWithTimeout(t *testing.T) {
 	// Get a client with a time-out of 10 secs.
 	timeoutClient := getHTTPClientWithTimeout(10)
@@ -736,3 +806,96 @@ func TestConcurrentJSONRPCBatching(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBroadcastTxNotify(t *testing.T) {
+	rpcAddr := rpctest.GetConfig().RPC.ListenAddress
+	ws, err := rpcclient.NewWS(rpcAddr, "/websocket")
+	require.NoError(t, err)
+	require.NoError(t, ws.Start())
+	t.Cleanup(func() {
+		if err := ws.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	_, _, tx := MakeTxKV()
+	require.NoError(t, ws.Call(ctx, "broadcast_tx_notify", map[string]interface{}{"tx": tx}))
+
+	// the response from CheckTx first, then the notification of the commit
+	// (with the same ID)
+	var (
+		checkTxRes ctypes.ResultBroadcastTx
+		commitRes  ctypes.ResultBroadcastTxCommit
+	)
+	for i, result := range []interface{}{&checkTxRes, &commitRes} {
+		select {
+		case resp := <-ws.ResponsesCh:
+			require.Nil(t, resp.Error, i)
+			require.NoError(t, cmtjson.Unmarshal(resp.Result, result), i)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for response #%d", i)
+		}
+	}
+	assert.EqualValues(t, abci.CodeTypeOK, checkTxRes.Code)
+	assert.EqualValues(t, types.Tx(tx).Hash(), checkTxRes.Hash)
+	assert.True(t, commitRes.DeliverTx.IsOK())
+	assert.EqualValues(t, checkTxRes.Hash, commitRes.Hash)
+	assert.NotZero(t, commitRes.Height)
+
+	// not available over HTTP
+	c, err := rpcclient.New(rpcAddr)
+	require.NoError(t, err)
+	_, _, tx = MakeTxKV()
+	_, err = c.Call(ctx, "broadcast_tx_notify", map[string]interface{}{"tx": tx}, new(ctypes.ResultBroadcastTx))
+	require.Error(t, err)
+}
+
+func TestSubscribeTx(t *testing.T) {
+	rpcAddr := rpctest.GetConfig().RPC.ListenAddress
+	ws, err := rpcclient.NewWS(rpcAddr, "/websocket")
+	require.NoError(t, err)
+	require.NoError(t, ws.Start())
+	t.Cleanup(func() {
+		if err := ws.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	_, _, tx := MakeTxKV()
+	query := fmt.Sprintf("tm.event = 'Tx' AND tx.hash = '%X'", types.Tx(tx).Hash())
+	require.NoError(t, ws.Call(ctx, "subscribe_tx", map[string]interface{}{"query": query}))
+	select {
+	case resp := <-ws.ResponsesCh:
+		require.Nil(t, resp.Error)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the subscription")
+	}
+
+	bres, err := getHTTPClient().BroadcastTxCommit(ctx, tx)
+	require.NoError(t, err)
+	require.True(t, bres.DeliverTx.IsOK())
+
+	// the committed tx comes with the proof of its inclusion in the block
+	var res ctypes.ResultEventTx
+	select {
+	case resp := <-ws.ResponsesCh:
+		require.Nil(t, resp.Error)
+		require.NoError(t, cmtjson.Unmarshal(resp.Result, &res))
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the tx")
+	}
+	assert.Equal(t, query, res.Query)
+	assert.EqualValues(t, bres.Hash, res.Hash)
+	assert.Equal(t, bres.Height, res.Height)
+	assert.True(t, res.TxResult.IsOK())
+	assert.EqualValues(t, tx, res.Tx)
+	require.NoError(t, res.Verify(types.CanonicalEncodingProto))
+
+	block, err := getHTTPClient().Block(ctx, &res.Height)
+	require.NoError(t, err)
+	assert.Equal(t, block.BlockID, res.BlockID)
+
+	// a proof of another tx doesn't verify
+	res.Tx = []byte("other")
+	assert.Error(t, res.Verify(types.CanonicalEncodingProto))
+}
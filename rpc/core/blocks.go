@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
+	"time"
 
 	"github.com/cometbft/cometbft/libs/bytes"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
@@ -85,10 +87,11 @@ func filterMinMax(base, height, min, max, limit int64) (int64, int64, error) {
 	return min, max, nil
 }
 
-// Header gets block header at a given height.
+// Header gets block header at a given height, and its commit if withCommit
+// is true.
 // If no height is provided, it will fetch the latest header.
 // More: https://docs.cometbft.com/main/rpc/#/Info/header
-func (env *Environment) Header(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultHeader, error) {
+func (env *Environment) Header(ctx *rpctypes.Context, heightPtr *int64, withCommit bool) (*ctypes.ResultHeader, error) {
 	height, err := env.getHeight(env.BlockStore.Height(), heightPtr)
 	if err != nil {
 		return nil, err
@@ -99,12 +102,12 @@ func (env *Environment) Header(ctx *rpctypes.Context, heightPtr *int64) (*ctypes
 		return &ctypes.ResultHeader{}, nil
 	}
 
-	return &ctypes.ResultHeader{Header: &blockMeta.Header}, nil
+	return env.newResultHeader(&blockMeta.Header, withCommit), nil
 }
 
-// HeaderByHash gets header by hash.
+// HeaderByHash gets header by hash, and its commit if withCommit is true.
 // More: https://docs.cometbft.com/main/rpc/#/Info/header_by_hash
-func (env *Environment) HeaderByHash(ctx *rpctypes.Context, hash bytes.HexBytes) (*ctypes.ResultHeader, error) {
+func (env *Environment) HeaderByHash(ctx *rpctypes.Context, hash bytes.HexBytes, withCommit bool) (*ctypes.ResultHeader, error) {
 	// N.B. The hash parameter is HexBytes so that the reflective parameter
 	// decoding logic in the HTTP service will correctly translate from JSON.
 	// See https://github.com/tendermint/tendermint/issues/6802 for context.
@@ -114,7 +117,25 @@ func (env *Environment) HeaderByHash(ctx *rpctypes.Context, hash bytes.HexBytes)
 		return &ctypes.ResultHeader{}, nil
 	}
 
-	return &ctypes.ResultHeader{Header: &blockMeta.Header}, nil
+	return env.newResultHeader(&blockMeta.Header, withCommit), nil
+}
+
+func (env *Environment) newResultHeader(header *types.Header, withCommit bool) *ctypes.ResultHeader {
+	res := &ctypes.ResultHeader{Header: header}
+	if withCommit {
+		res.Commit, res.CanonicalCommit = env.loadCommit(header.Height)
+	}
+	return res
+}
+
+// loadCommit loads the commit for the block at the given height: the
+// canonical commit, included in the next block, or the commit seen by this
+// node if the next block has not been committed yet.
+func (env *Environment) loadCommit(height int64) (commit *types.Commit, canonical bool) {
+	if height == env.BlockStore.Height() {
+		return env.BlockStore.LoadSeenCommit(height), false
+	}
+	return env.BlockStore.LoadBlockCommit(height), true
 }
 
 // Block gets block at a given height.
@@ -126,24 +147,54 @@ func (env *Environment) Block(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.
 		return nil, err
 	}
 
+	if res, ok := env.responseCache.Get("block", height); ok {
+		return res.(*ctypes.ResultBlock), nil
+	}
+
 	block := env.BlockStore.LoadBlock(height)
 	blockMeta := env.BlockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
 		return &ctypes.ResultBlock{BlockID: types.BlockID{}, Block: block}, nil
 	}
-	return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
+	res := &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}
+	if block != nil {
+		env.responseCache.Add("block", height, res, int64(block.Size()))
+	}
+	return res, nil
 }
 
 // BlockByHash gets block by hash.
 // More: https://docs.cometbft.com/main/rpc/#/Info/block_by_hash
 func (env *Environment) BlockByHash(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultBlock, error) {
+	if env.responseCache != nil {
+		// lookup the (much smaller) block meta to find the cached block
+		if blockMeta := env.BlockStore.LoadBlockMetaByHash(hash); blockMeta != nil {
+			if res, ok := env.responseCache.Get("block", blockMeta.Header.Height); ok {
+				return res.(*ctypes.ResultBlock), nil
+			}
+		}
+	}
+
 	block := env.BlockStore.LoadBlockByHash(hash)
 	if block == nil {
 		return &ctypes.ResultBlock{BlockID: types.BlockID{}, Block: nil}, nil
 	}
 	// If block is not nil, then blockMeta can't be nil.
 	blockMeta := env.BlockStore.LoadBlockMeta(block.Height)
-	return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
+	res := &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}
+	env.responseCache.Add("block", block.Height, res, int64(block.Size()))
+	return res, nil
+}
+
+// BlockByTime gets the first block whose time is at or after the given time.
+// It returns an error if there is no such block yet.
+// More: https://docs.cometbft.com/main/rpc/#/Info/block_by_time
+func (env *Environment) BlockByTime(ctx *rpctypes.Context, t time.Time) (*ctypes.ResultBlock, error) {
+	blockMeta := env.BlockStore.LoadBlockMetaByTime(t)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("no block at or after %v", t)
+	}
+	return env.Block(ctx, &blockMeta.Header.Height)
 }
 
 // Commit gets block commit at a given height.
@@ -155,22 +206,69 @@ func (env *Environment) Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes
 		return nil, err
 	}
 
+	if res, ok := env.responseCache.Get("commit", height); ok {
+		return res.(*ctypes.ResultCommit), nil
+	}
+
 	blockMeta := env.BlockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
 		return nil, nil
 	}
 	header := blockMeta.Header
 
-	// If the next block has not been committed yet,
-	// use a non-canonical commit
-	if height == env.BlockStore.Height() {
-		commit := env.BlockStore.LoadSeenCommit(height)
-		return ctypes.NewResultCommit(&header, commit, false), nil
+	commit, canonical := env.loadCommit(height)
+	res := ctypes.NewResultCommit(&header, commit, canonical)
+	// the commit seen for the latest block may be replaced by the canonical one
+	if canonical && commit != nil {
+		env.responseCache.Add("commit", height, res, int64(header.ToProto().Size()+commit.ToProto().Size()))
+	}
+	return res, nil
+}
+
+// CommitAggregated gets the commit at a given height, with the bn254
+// signatures for the block aggregated into a single signature, and the
+// validator set which signed it. If no height is provided, it will fetch the
+// commit for the latest block.
+//
+// The response is meant to be consumed as is by the light clients which can't
+// verify each signature, e.g. smart contracts and zk circuits.
+// It returns an error if the validators don't all use bn254 keys.
+// More: https://docs.cometbft.com/main/rpc/#/Info/commit_aggregated
+func (env *Environment) CommitAggregated(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommitAggregated, error) {
+	res, err := env.Commit(ctx, heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || res.Commit == nil {
+		return nil, errors.New("commit not found")
+	}
+	height := res.Header.Height
+
+	vals, err := env.StateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+
+	ac, err := res.Commit.Aggregate()
+	if err != nil {
+		return nil, err
+	}
+
+	var signedVotingPower int64
+	for idx, val := range vals.Validators {
+		if ac.HasSigner(idx) {
+			signedVotingPower += val.VotingPower
+		}
 	}
 
-	// Return the canonical commit (comes from the block at height+1)
-	commit := env.BlockStore.LoadBlockCommit(height)
-	return ctypes.NewResultCommit(&header, commit, true), nil
+	return &ctypes.ResultCommitAggregated{
+		Header:            res.Header,
+		Commit:            ac,
+		Validators:        vals.Validators,
+		SignedVotingPower: signedVotingPower,
+		TotalVotingPower:  vals.TotalVotingPower(),
+		CanonicalCommit:   res.CanonicalCommit,
+	}, nil
 }
 
 // BlockResults gets ABCIResults at a given height.
@@ -186,18 +284,54 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 		return nil, err
 	}
 
+	if res, ok := env.responseCache.Get("block_results", height); ok {
+		return res.(*ctypes.ResultBlockResults), nil
+	}
+
 	results, err := env.StateStore.LoadABCIResponses(height)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ctypes.ResultBlockResults{
+	res := &ctypes.ResultBlockResults{
 		Height:                height,
 		TxsResults:            results.DeliverTxs,
 		BeginBlockEvents:      results.BeginBlock.Events,
 		EndBlockEvents:        results.EndBlock.Events,
 		ValidatorUpdates:      results.EndBlock.ValidatorUpdates,
 		ConsensusParamUpdates: results.EndBlock.ConsensusParamUpdates,
+	}
+	env.responseCache.Add("block_results", height, res, int64(results.Size()))
+	return res, nil
+}
+
+// BlockEvents gets the BeginBlock and EndBlock events of a block, as indexed
+// by the block indexer, which keeps them after the ABCI responses are pruned.
+// If no height is provided, it will fetch the events of the latest block.
+// More: https://docs.cometbft.com/main/rpc/#/Info/block_events
+func (env *Environment) BlockEvents(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockEvents, error) {
+	// skip if block indexing is disabled
+	if _, ok := env.BlockIndexer.(*blockidxnull.BlockerIndexer); ok {
+		return nil, errors.New("block indexing is disabled")
+	}
+
+	height, err := env.getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := env.BlockIndexer.GetEvents(height)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		return nil, fmt.Errorf("the events of block %d are not indexed", height)
+	}
+
+	return &ctypes.ResultBlockEvents{
+		Height:           height,
+		BeginBlockEvents: events.BeginBlock,
+		EndBlockEvents:   events.EndBlock,
 	}, nil
 }
 
@@ -208,6 +342,7 @@ func (env *Environment) BlockSearch(
 	query string,
 	pagePtr, perPagePtr *int,
 	orderBy string,
+	cursor string,
 ) (*ctypes.ResultBlockSearch, error) {
 
 	// skip if block indexing is disabled
@@ -226,27 +361,41 @@ func (env *Environment) BlockSearch(
 	}
 
 	// sort results (must be done before pagination)
+	var less func(h1, h2 int64) bool
 	switch orderBy {
 	case "desc", "":
-		sort.Slice(results, func(i, j int) bool { return results[i] > results[j] })
+		less = func(h1, h2 int64) bool { return h1 > h2 }
 
 	case "asc":
-		sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+		less = func(h1, h2 int64) bool { return h1 < h2 }
 
 	default:
 		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
 	}
+	sort.Slice(results, func(i, j int) bool { return less(results[i], results[j]) })
 
 	// paginate results
 	totalCount := len(results)
 	perPage := env.validatePerPage(perPagePtr)
 
-	page, err := validatePage(pagePtr, perPage, totalCount)
-	if err != nil {
-		return nil, err
+	var skipCount int
+	if cursor != "" {
+		// resume right after the cursor, wherever it is now
+		if pagePtr != nil {
+			return nil, errors.New("page and cursor are mutually exclusive")
+		}
+		height, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor %q: %w", cursor, err)
+		}
+		skipCount = sort.Search(totalCount, func(i int) bool { return less(height, results[i]) })
+	} else {
+		page, err := validatePage(pagePtr, perPage, totalCount)
+		if err != nil {
+			return nil, err
+		}
+		skipCount = validateSkipCount(page, perPage)
 	}
-
-	skipCount := validateSkipCount(page, perPage)
 	pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
 
 	apiResults := make([]*ctypes.ResultBlock, 0, pageSize)
@@ -263,5 +412,10 @@ func (env *Environment) BlockSearch(
 		}
 	}
 
-	return &ctypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
+	var nextCursor string
+	if last := skipCount + pageSize - 1; pageSize > 0 && last < totalCount-1 {
+		nextCursor = strconv.FormatInt(results[last], 10)
+	}
+
+	return &ctypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount, NextCursor: nextCursor}, nil
 }
@@ -2,19 +2,27 @@ package core
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	dbm "github.com/cometbft/cometbft-db"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	sm "github.com/cometbft/cometbft/state"
+	indexermocks "github.com/cometbft/cometbft/state/indexer/mocks"
 	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
 )
 
 func TestBlockchainInfo(t *testing.T) {
@@ -80,9 +88,7 @@ func TestBlockResults(t *testing.T) {
 	}
 
 	env := &Environment{}
-	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
 	err := env.StateStore.SaveABCIResponses(100, results)
 	require.NoError(t, err)
 	mockstore := &mocks.BlockStore{}
@@ -118,3 +124,145 @@ func TestBlockResults(t *testing.T) {
 		}
 	}
 }
+
+func TestCommitAggregated(t *testing.T) {
+	const (
+		chainID = "test-chain"
+		height  = int64(100)
+	)
+
+	var (
+		vals     = make([]*types.Validator, 4)
+		privVals = make([]types.PrivValidator, 4)
+	)
+	for i := range privVals {
+		privVals[i] = types.NewMockPVWithParams(bn254.GenPrivKey(), false, false)
+		pubKey, err := privVals[i].GetPubKey()
+		require.NoError(t, err)
+		vals[i] = types.NewValidator(pubKey, 10)
+	}
+	sort.Sort(types.PrivValidatorsByAddress(privVals))
+	valSet := types.NewValidatorSet(vals)
+
+	blockID := types.BlockID{Hash: tmhash.Sum([]byte("block")), PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}}
+	voteSet := types.NewVoteSet(chainID, types.CanonicalEncodingProto, height, 0, cmtproto.PrecommitType, valSet)
+	commit, err := types.MakeCommit(blockID, height, 0, voteSet, privVals, cmttime.Now())
+	require.NoError(t, err)
+
+	env := &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	require.NoError(t, env.StateStore.Bootstrap(sm.State{
+		ChainID:         chainID,
+		InitialHeight:   1,
+		LastBlockHeight: height - 1,
+		Validators:      valSet,
+		NextValidators:  valSet,
+		LastValidators:  valSet,
+	}))
+
+	header := types.Header{ChainID: chainID, Height: height}
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Height").Return(height)
+	mockstore.On("Base").Return(int64(1))
+	mockstore.On("LoadBlockMeta", height).Return(&types.BlockMeta{BlockID: blockID, Header: header})
+	mockstore.On("LoadSeenCommit", height).Return(commit)
+	env.BlockStore = mockstore
+
+	res, err := env.CommitAggregated(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &header, res.Header)
+	assert.Equal(t, valSet.Validators, res.Validators)
+	assert.EqualValues(t, 40, res.SignedVotingPower)
+	assert.EqualValues(t, 40, res.TotalVotingPower)
+	assert.False(t, res.CanonicalCommit)
+	assert.Equal(t, 4, res.Commit.NumSigners())
+	assert.NoError(t, valSet.VerifyAggregatedCommit(chainID, types.CanonicalEncodingProto, blockID, height, res.Commit))
+
+	wrongHeight := height + 1
+	_, err = env.CommitAggregated(&rpctypes.Context{}, &wrongHeight)
+	assert.Error(t, err)
+}
+
+func TestBlockSearchCursor(t *testing.T) {
+	blockIndexer := &indexermocks.BlockIndexer{}
+	blockIndexer.On("Search", mock.Anything, mock.Anything).Return([]int64{3, 1, 4, 2, 5}, nil)
+	mockstore := &mocks.BlockStore{}
+	for h := int64(1); h <= 5; h++ {
+		mockstore.On("LoadBlock", h).Return(&types.Block{Header: types.Header{Height: h}})
+		mockstore.On("LoadBlockMeta", h).Return(&types.BlockMeta{Header: types.Header{Height: h}})
+	}
+	env := &Environment{BlockIndexer: blockIndexer, BlockStore: mockstore}
+
+	perPage := 2
+	search := func(orderBy, cursor string) (heights []int64, nextCursor string) {
+		res, err := env.BlockSearch(&rpctypes.Context{}, "block.height > 0", nil, &perPage, orderBy, cursor)
+		require.NoError(t, err)
+		assert.Equal(t, 5, res.TotalCount)
+		for _, b := range res.Blocks {
+			heights = append(heights, b.Block.Height)
+		}
+		return heights, res.NextCursor
+	}
+
+	heights, cursor := search("", "")
+	assert.Equal(t, []int64{5, 4}, heights)
+	assert.Equal(t, "4", cursor)
+	heights, cursor = search("", cursor)
+	assert.Equal(t, []int64{3, 2}, heights)
+	heights, cursor = search("", cursor)
+	assert.Equal(t, []int64{1}, heights)
+	assert.Empty(t, cursor)
+
+	heights, cursor = search("asc", "2")
+	assert.Equal(t, []int64{3, 4}, heights)
+	assert.Equal(t, "4", cursor)
+
+	_, err := env.BlockSearch(&rpctypes.Context{}, "block.height > 0", nil, &perPage, "asc", "x")
+	assert.Error(t, err)
+}
+
+func TestHeaderWithCommit(t *testing.T) {
+	const height = int64(10)
+
+	var (
+		header  = types.Header{ChainID: "test-chain", Height: height - 1}
+		blockID = types.BlockID{Hash: header.Hash(types.CanonicalEncodingProto)}
+		seen    = &types.Commit{Height: height, Round: 1}
+		commit  = &types.Commit{Height: height - 1, BlockID: blockID}
+	)
+	latest := types.Header{ChainID: "test-chain", Height: height}
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Height").Return(height)
+	mockstore.On("Base").Return(int64(1))
+	mockstore.On("LoadBlockMeta", height).Return(&types.BlockMeta{Header: latest})
+	mockstore.On("LoadBlockMeta", height-1).Return(&types.BlockMeta{BlockID: blockID, Header: header})
+	mockstore.On("LoadBlockMetaByHash", mock.Anything).Return(&types.BlockMeta{BlockID: blockID, Header: header})
+	mockstore.On("LoadSeenCommit", height).Return(seen)
+	mockstore.On("LoadBlockCommit", height-1).Return(commit)
+	env := &Environment{BlockStore: mockstore}
+
+	res, err := env.Header(&rpctypes.Context{}, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, &latest, res.Header)
+	assert.Nil(t, res.Commit)
+
+	// the next block has not been committed yet
+	res, err = env.Header(&rpctypes.Context{}, nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, &latest, res.Header)
+	assert.Equal(t, seen, res.Commit)
+	assert.False(t, res.CanonicalCommit)
+
+	h := height - 1
+	res, err = env.Header(&rpctypes.Context{}, &h, true)
+	require.NoError(t, err)
+	assert.Equal(t, &header, res.Header)
+	assert.Equal(t, commit, res.Commit)
+	assert.True(t, res.CanonicalCommit)
+
+	res, err = env.HeaderByHash(&rpctypes.Context{}, header.Hash(types.CanonicalEncodingProto), true)
+	require.NoError(t, err)
+	assert.Equal(t, &header, res.Header)
+	assert.Equal(t, commit, res.Commit)
+	assert.True(t, res.CanonicalCommit)
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"container/list"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// responseCacheKey identifies a cached response: the method which produced
+// it, for the given height.
+type responseCacheKey struct {
+	method string
+	height int64
+}
+
+type responseCacheEntry struct {
+	key   responseCacheKey
+	value interface{}
+	size  int64
+}
+
+// responseCache is a thread-safe LRU cache of the responses to the requests
+// for immutable data (e.g. blocks below the latest height), bounded by the
+// approximate size of the responses.
+//
+// The cached responses are shared by all callers, which must not modify them.
+type responseCache struct {
+	mtx     cmtsync.Mutex
+	maxSize int64
+	size    int64
+	entries map[responseCacheKey]*list.Element
+	list    *list.List // front: most recently used
+
+	metrics *Metrics
+}
+
+func newResponseCache(maxSize int64, metrics *Metrics) *responseCache {
+	return &responseCache{
+		maxSize: maxSize,
+		entries: make(map[responseCacheKey]*list.Element),
+		list:    list.New(),
+		metrics: metrics,
+	}
+}
+
+// Get returns the cached response of method at the given height, if any. It
+// is safe to call on a nil cache.
+func (c *responseCache) Get(method string, height int64) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.entries[responseCacheKey{method: method, height: height}]
+	if !ok {
+		c.metrics.ResponseCacheMisses.With("method", method).Add(1)
+		return nil, false
+	}
+	c.list.MoveToFront(e)
+	c.metrics.ResponseCacheHits.With("method", method).Add(1)
+	return e.Value.(*responseCacheEntry).value, true
+}
+
+// Add caches the response of method at the given height, whose approximate
+// size is given, evicting the least recently used responses if needed.
+// Responses larger than the cache itself are not cached. It is safe to call
+// on a nil cache.
+func (c *responseCache) Add(method string, height int64, value interface{}, size int64) {
+	if c == nil || size > c.maxSize {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := responseCacheKey{method: method, height: height}
+	if e, ok := c.entries[key]; ok {
+		c.list.MoveToFront(e)
+		return
+	}
+
+	for c.size+size > c.maxSize {
+		c.remove(c.list.Back())
+		c.metrics.ResponseCacheEvictions.Add(1)
+	}
+	c.entries[key] = c.list.PushFront(&responseCacheEntry{key: key, value: value, size: size})
+	c.size += size
+	c.metrics.ResponseCacheSizeBytes.Set(float64(c.size))
+}
+
+func (c *responseCache) remove(e *list.Element) {
+	entry := c.list.Remove(e).(*responseCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
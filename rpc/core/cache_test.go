@@ -0,0 +1,88 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestResponseCache(t *testing.T) {
+	c := newResponseCache(10, NopMetrics())
+
+	c.Add("block", 1, "1", 4)
+	c.Add("block", 2, "2", 4)
+	c.Add("commit", 1, "c1", 2)
+	assert.EqualValues(t, 10, c.size)
+
+	v, ok := c.Get("block", 1)
+	require.True(t, ok)
+	assert.Equal(t, "1", v)
+	_, ok = c.Get("block_results", 1)
+	assert.False(t, ok)
+
+	// evicts the least recently used responses
+	c.Add("block", 3, "3", 4)
+	_, ok = c.Get("block", 2)
+	assert.False(t, ok)
+	_, ok = c.Get("commit", 1)
+	assert.True(t, ok)
+	assert.EqualValues(t, 10, c.size)
+
+	c.Add("block", 4, "4", 6)
+	_, ok = c.Get("block", 1)
+	assert.False(t, ok)
+	_, ok = c.Get("block", 3)
+	assert.False(t, ok)
+	_, ok = c.Get("commit", 1)
+	assert.True(t, ok)
+	assert.EqualValues(t, 8, c.size)
+
+	// too large to be cached
+	c.Add("block", 5, "5", 11)
+	_, ok = c.Get("block", 5)
+	assert.False(t, ok)
+
+	// a nil cache caches nothing
+	var nilCache *responseCache
+	nilCache.Add("block", 1, "1", 1)
+	_, ok = nilCache.Get("block", 1)
+	assert.False(t, ok)
+}
+
+func TestBlockResponseCache(t *testing.T) {
+	const height = int64(10)
+
+	block := types.MakeBlock(height, nil, &types.Commit{}, nil)
+	blockMeta := &types.BlockMeta{BlockID: types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto)}, Header: block.Header}
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Height").Return(height)
+	mockstore.On("Base").Return(int64(1))
+	mockstore.On("LoadBlock", height).Return(block)
+	mockstore.On("LoadBlockMeta", height).Return(blockMeta)
+	mockstore.On("LoadBlockMetaByHash", []byte(block.Hash(types.CanonicalEncodingProto))).Return(blockMeta)
+
+	env := &Environment{BlockStore: mockstore}
+	env.Config.ResponseCacheSize = 1 << 20
+	env.InitResponseCache()
+
+	h := height
+	expected := &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}
+	for i := 0; i < 3; i++ {
+		res, err := env.Block(&rpctypes.Context{}, &h)
+		require.NoError(t, err)
+		assert.Equal(t, expected, res)
+	}
+	res, err := env.BlockByHash(&rpctypes.Context{}, block.Hash(types.CanonicalEncodingProto))
+	require.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	// the block was loaded from the store only once
+	mockstore.AssertNumberOfCalls(t, "LoadBlock", 1)
+	mockstore.AssertNotCalled(t, "LoadBlockByHash", []byte(block.Hash(types.CanonicalEncodingProto)))
+}
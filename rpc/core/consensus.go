@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+
 	cm "github.com/cometbft/cometbft/consensus"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
@@ -49,7 +51,110 @@ func (env *Environment) Validators(
 		Total:       totalCount}, nil
 }
 
-// DumpConsensusState dumps consensus state.
+// ValidatorsCommitment gets the circuit-friendly (MiMC) commitment to the
+// validator set at the given block height, i.e. the public input of the zk
+// light client circuits. See types.ValidatorSet.Commitment for its definition.
+//
+// If no height is provided, it will fetch the commitment to the latest
+// validator set. It returns an error if the validators don't all use bn254
+// keys.
+//
+// More: https://docs.cometbft.com/main/rpc/#/Info/validators_commitment
+func (env *Environment) ValidatorsCommitment(
+	ctx *rpctypes.Context,
+	heightPtr *int64) (*ctypes.ResultValidatorsCommitment, error) {
+
+	height, err := env.getHeight(env.latestUncommittedHeight(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := env.StateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := validators.Commitment()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultValidatorsCommitment{
+		BlockHeight:      height,
+		Scheme:           types.ValidatorSetCommitmentScheme,
+		Commitment:       commitment,
+		Count:            validators.Size(),
+		TotalVotingPower: validators.TotalVotingPower(),
+	}, nil
+}
+
+// ValidatorsDiff gets the difference between the validator sets at the from
+// and to block heights, i.e. the validators added or whose voting power
+// changed, and those removed, along with the hashes of both sets and, if the
+// validators use bn254 keys, their commitments. Relayers and provers use it to
+// prove epoch transitions without fetching the whole validator sets.
+//
+// If no to height is provided, it defaults to the latest height, and if no
+// from height is provided, to the height preceding to.
+//
+// More: https://docs.cometbft.com/main/rpc/#/Info/validators_diff
+func (env *Environment) ValidatorsDiff(
+	ctx *rpctypes.Context,
+	fromPtr, toPtr *int64) (*ctypes.ResultValidatorsDiff, error) {
+
+	latest := env.latestUncommittedHeight()
+	to, err := env.getHeight(latest, toPtr)
+	if err != nil {
+		return nil, err
+	}
+	if fromPtr == nil {
+		from := cmtmath.MaxInt64(to-1, 1)
+		fromPtr = &from
+	}
+	from, err := env.getHeight(latest, fromPtr)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("from height %d must be less than or equal to to height %d", from, to)
+	}
+
+	fromVals, err := env.StateStore.LoadValidators(from)
+	if err != nil {
+		return nil, err
+	}
+	toVals, err := env.StateStore.LoadValidators(to)
+	if err != nil {
+		return nil, err
+	}
+	// the canonical encoding of the hashes is fixed by the genesis
+	params, err := env.StateStore.LoadConsensusParams(from)
+	if err != nil {
+		return nil, err
+	}
+	encoding := params.Encoding.Canonical
+
+	diff := types.NewValidatorSetDiff(fromVals, toVals)
+	result := &ctypes.ResultValidatorsDiff{
+		FromHeight: from,
+		ToHeight:   to,
+		Updates:    diff.Updates,
+		Removals:   diff.Removals,
+		FromHash:   fromVals.Hash(encoding),
+		ToHash:     toVals.Hash(encoding),
+	}
+	// the commitments are only defined for bn254 validators
+	if commitment, err := fromVals.Commitment(); err == nil {
+		result.FromCommitment = commitment
+	}
+	if commitment, err := toVals.Commitment(); err == nil {
+		result.ToCommitment = commitment
+	}
+	return result, nil
+}
+
+// DumpConsensusState dumps consensus state. See the DumpConsensusState gRPC
+// method of NodeAPI for a structured version.
 // UNSTABLE
 // More: https://docs.cometbft.com/main/rpc/#/Info/dump_consensus_state
 func (env *Environment) DumpConsensusState(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState, error) {
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestValidatorsDiff(t *testing.T) {
+	const height = int64(10)
+
+	vals := make([]*types.Validator, 4)
+	for i := range vals {
+		vals[i] = types.NewValidator(bn254.GenPrivKey().PubKey(), 10)
+	}
+	valSet := types.NewValidatorSet(vals)
+
+	// the validator set changes at height + 2
+	nextValSet := valSet.Copy()
+	removed := vals[0].Copy()
+	removed.VotingPower = 0
+	added := types.NewValidator(bn254.GenPrivKey().PubKey(), 20)
+	require.NoError(t, nextValSet.UpdateWithChangeSet([]*types.Validator{removed, added}))
+
+	env := &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	state := sm.State{
+		ChainID:         "test-chain",
+		InitialHeight:   1,
+		LastBlockHeight: height - 1,
+		Validators:      valSet,
+		NextValidators:  valSet,
+		LastValidators:  valSet,
+
+		ConsensusParams:                  *types.DefaultConsensusParams(),
+		LastHeightConsensusParamsChanged: height,
+	}
+	require.NoError(t, env.StateStore.Bootstrap(state))
+	state.LastBlockHeight = height
+	state.NextValidators = nextValSet
+	state.LastHeightValidatorsChanged = height + 2
+	require.NoError(t, env.StateStore.Save(state))
+
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Height").Return(height + 2)
+	mockstore.On("Base").Return(int64(1))
+	env.BlockStore = mockstore
+	env.ConsensusReactor = syncingReactor(true)
+
+	from, to := height, height+2
+	res, err := env.ValidatorsDiff(&rpctypes.Context{}, &from, &to)
+	require.NoError(t, err)
+	assert.Equal(t, height, res.FromHeight)
+	assert.Equal(t, height+2, res.ToHeight)
+	require.Len(t, res.Updates, 1)
+	assert.Equal(t, added.Address, res.Updates[0].Address)
+	require.Len(t, res.Removals, 1)
+	assert.Equal(t, vals[0].Address, res.Removals[0].Address)
+	assert.EqualValues(t, valSet.Hash(types.CanonicalEncodingProto), res.FromHash)
+	assert.EqualValues(t, nextValSet.Hash(types.CanonicalEncodingProto), res.ToHash)
+	toCommitment, err := nextValSet.Commitment()
+	require.NoError(t, err)
+	assert.EqualValues(t, toCommitment, res.ToCommitment)
+
+	// the diff of the previous height is empty
+	to = height + 1
+	res, err = env.ValidatorsDiff(&rpctypes.Context{}, nil, &to)
+	require.NoError(t, err)
+	assert.Equal(t, height, res.FromHeight)
+	assert.Empty(t, res.Updates)
+	assert.Empty(t, res.Removals)
+
+	// from must not be after to
+	from = height + 2
+	_, err = env.ValidatorsDiff(&rpctypes.Context{}, &from, &to)
+	assert.Error(t, err)
+}
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"github.com/cometbft/cometbft/crypto/bn254"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// HashToCurve gets the point of G2 to which msg is hashed before it is signed
+// with a bn254 key, and the nonce with which it is, for external
+// implementations of the hashing to check theirs against.
+// More: https://docs.cometbft.com/main/rpc/#/Info/hash_to_curve
+func (env *Environment) HashToCurve(_ *rpctypes.Context, msg []byte) (*ctypes.ResultHashToCurve, error) {
+	point, nonce := bn254.HashToCurve(msg)
+	raw := point.RawBytes()
+	compressed := point.Bytes()
+	x0, x1 := point.X.A0.Bytes(), point.X.A1.Bytes()
+	y0, y1 := point.Y.A0.Bytes(), point.Y.A1.Bytes()
+	return &ctypes.ResultHashToCurve{
+		DST:             bn254.HashToCurveDST,
+		Nonce:           nonce,
+		Point:           raw[:],
+		PointCompressed: compressed[:],
+		CompressionMask: compressed[0] & (0b11 << 6),
+		X0:              x0[:],
+		X1:              x1[:],
+		Y0:              y0[:],
+		Y1:              y1[:],
+	}, nil
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"testing"
+
+	gnarkbn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestHashToCurve(t *testing.T) {
+	env := &Environment{}
+	for _, msg := range [][]byte{nil, []byte("hello"), []byte("cometbls")} {
+		res, err := env.HashToCurve(&rpctypes.Context{}, msg)
+		require.NoError(t, err)
+
+		point, nonce := bn254.HashToCurve(msg)
+		assert.Equal(t, bn254.HashToCurveDST, res.DST)
+		assert.Equal(t, nonce, res.Nonce)
+		assert.EqualValues(t, point.Marshal(), res.Point)
+
+		// the coordinates are laid out A1 first
+		require.Len(t, res.Point, gnarkbn254.SizeOfG2AffineUncompressed)
+		assert.EqualValues(t, res.X1, res.Point[0:32])
+		assert.EqualValues(t, res.X0, res.Point[32:64])
+		assert.EqualValues(t, res.Y1, res.Point[64:96])
+		assert.EqualValues(t, res.Y0, res.Point[96:128])
+
+		// the compressed point is X with the mask, and decompresses to the point
+		assert.Contains(t, []byte{0x80, 0xC0}, res.CompressionMask)
+		assert.Equal(t, res.CompressionMask, res.PointCompressed[0]&0xC0)
+		assert.EqualValues(t, res.X1[0], res.PointCompressed[0]&^0xC0)
+		assert.EqualValues(t, res.X1[1:], res.PointCompressed[1:32])
+		var decompressed gnarkbn254.G2Affine
+		_, err = decompressed.SetBytes(res.PointCompressed)
+		require.NoError(t, err)
+		assert.True(t, decompressed.Equal(&point))
+	}
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"errors"
+
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
@@ -10,3 +12,17 @@ func (env *Environment) UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.Resul
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeReloadConfig reloads the configuration file of the node, applying the
+// fields which can be at runtime, and reports the fields applied and the ones
+// requiring a restart.
+func (env *Environment) UnsafeReloadConfig(ctx *rpctypes.Context) (*ctypes.ResultReloadConfig, error) {
+	if env.ReloadConfig == nil {
+		return nil, errors.New("reloading the configuration is not supported")
+	}
+	applied, requireRestart, err := env.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultReloadConfig{Applied: applied, RequireRestart: requireRestart}, nil
+}
@@ -1,19 +1,21 @@
 package core
 
 import (
-	"encoding/base64"
 	"fmt"
 	"time"
 
 	cfg "github.com/cometbft/cometbft/config"
+	cstypes "github.com/cometbft/cometbft/consensus/types"
 	"github.com/cometbft/cometbft/crypto"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/proxy"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/state/indexer"
+	"github.com/cometbft/cometbft/state/invariant"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/types"
 )
@@ -39,6 +41,7 @@ type Consensus interface {
 	GetState() sm.State
 	GetValidators() (int64, []*types.Validator)
 	GetLastHeight() int64
+	GetRoundState() *cstypes.RoundState
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
 }
@@ -51,6 +54,12 @@ type transport interface {
 
 type peers interface {
 	AddPersistentPeers([]string) error
+	RemovePersistentPeers([]string) error
+	PersistentPeers() []*p2p.NetAddress
+	SetReconnectPolicy(p2p.ID, p2p.ReconnectPolicy) error
+	DefaultReconnectPolicy() p2p.ReconnectPolicy
+	ReconnectPolicyOf(p2p.ID) p2p.ReconnectPolicy
+	StopPeerGracefully(p2p.Peer)
 	AddUnconditionalPeerIDs([]string) error
 	AddPrivatePeerIDs([]string) error
 	DialPeersAsync([]string) error
@@ -61,6 +70,10 @@ type consensusReactor interface {
 	WaitSync() bool
 }
 
+type privValidator interface {
+	IsConnected() bool
+}
+
 // ----------------------------------------------
 // Environment contains objects and interfaces used by the RPC. It is expected
 // to be setup once during startup.
@@ -79,19 +92,33 @@ type Environment struct {
 	P2PTransport     transport
 
 	// objects
-	PubKey       crypto.PubKey
-	GenDoc       *types.GenesisDoc // cache the genesis structure
-	TxIndexer    txindex.TxIndexer
-	BlockIndexer indexer.BlockIndexer
-	EventBus     *types.EventBus // thread safe
-	Mempool      mempl.Mempool
-
-	Logger log.Logger
+	PubKey           crypto.PubKey
+	GenDoc           *types.GenesisDoc // cache the genesis structure
+	GenesisFile      string            // file GenDoc was loaded from, to read the genesis chunks from (optional)
+	TxIndexer        txindex.TxIndexer
+	BlockIndexer     indexer.BlockIndexer
+	IndexerProgress  *txindex.Progress  // nil if indexing is disabled
+	InvariantChecker *invariant.Checker // nil if invariant checking is disabled
+	EventBus         *types.EventBus    // thread safe
+	Mempool          mempl.Mempool
+	Services         *service.Manager   // reports the readiness of the services to /health (optional)
+	PrivValidator    privValidator      // remote signer, whose connection is reported to /health/detailed (optional)
+	MempoolConfig    *cfg.MempoolConfig // capacity of the mempool, reported to /health/detailed (optional)
+
+	// reloads the configuration file, returning the fields applied and the
+	// ones requiring a restart (optional)
+	ReloadConfig func() (applied, requireRestart []string, err error)
+
+	Logger  log.Logger
+	Metrics *Metrics
 
 	Config cfg.RPCConfig
 
-	// cache of chunked genesis data.
-	genChunks []string
+	// chunked genesis data.
+	genChunks *genesisChunks
+
+	// cache of the responses for immutable data, nil if disabled.
+	responseCache *responseCache
 }
 
 //----------------------------------------------
@@ -138,6 +165,12 @@ func (env *Environment) InitGenesisChunks() error {
 		return nil
 	}
 
+	var err error
+	if env.GenesisFile != "" {
+		env.genChunks, err = newGenesisFileChunks(env.GenesisFile, genesisChunkSize)
+		return err
+	}
+
 	if env.GenDoc == nil {
 		return nil
 	}
@@ -147,17 +180,25 @@ func (env *Environment) InitGenesisChunks() error {
 		return err
 	}
 
-	for i := 0; i < len(data); i += genesisChunkSize {
-		end := i + genesisChunkSize
-
-		if end > len(data) {
-			end = len(data)
-		}
+	env.genChunks, err = newGenesisBytesChunks(data, genesisChunkSize)
+	return err
+}
 
-		env.genChunks = append(env.genChunks, base64.StdEncoding.EncodeToString(data[i:end]))
+// InitResponseCache creates the cache of the responses for immutable data, if
+// enabled, and should be called on service startup.
+func (env *Environment) InitResponseCache() {
+	if env.responseCache != nil || env.Config.ResponseCacheSize <= 0 {
+		return
 	}
 
-	return nil
+	env.responseCache = newResponseCache(env.Config.ResponseCacheSize, env.metrics())
+}
+
+func (env *Environment) metrics() *Metrics {
+	if env.Metrics == nil {
+		return NopMetrics()
+	}
+	return env.Metrics
 }
 
 func validateSkipCount(page, perPage int) int {
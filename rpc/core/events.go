@@ -10,6 +10,7 @@ import (
 	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
 )
 
 const (
@@ -21,6 +22,77 @@ const (
 // Subscribe for events via WebSocket.
 // More: https://docs.cometbft.com/main/rpc/#/Websocket/subscribe
 func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+	sub, err := env.subscribe(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	go env.writeEvents(ctx, sub, func(msg cmtpubsub.Message) (interface{}, error) {
+		return &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}, nil
+	})
+
+	return &ctypes.ResultSubscribe{}, nil
+}
+
+// SubscribeTx subscribes, via WebSocket, to the transactions matching the
+// query, like Subscribe, but pushes each committed transaction along with the
+// proof of its inclusion in the block and the header of the block, so that
+// clients can verify it without a follow-up call to Tx. The events other
+// than Tx matching the query are ignored.
+//
+// The subscription is canceled with Unsubscribe and the same query.
+// More: https://docs.cometbft.com/main/rpc/#/Websocket/subscribe_tx
+func (env *Environment) SubscribeTx(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+	sub, err := env.subscribe(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// the transactions of a block are pushed in a row, so the block is
+	// loaded only once for all of them
+	var (
+		block     *types.Block
+		blockMeta *types.BlockMeta
+	)
+	go env.writeEvents(ctx, sub, func(msg cmtpubsub.Message) (interface{}, error) {
+		data, ok := msg.Data().(types.EventDataTx)
+		if !ok {
+			return nil, nil
+		}
+		if block == nil || block.Height != data.Height {
+			// the block is saved before its transactions are executed
+			block = env.BlockStore.LoadBlock(data.Height)
+			blockMeta = env.BlockStore.LoadBlockMeta(data.Height)
+			if block == nil || blockMeta == nil {
+				block = nil
+				return nil, fmt.Errorf("block at height %d not found", data.Height)
+			}
+		}
+		if int(data.Index) >= len(block.Data.Txs) {
+			return nil, fmt.Errorf("tx index %d out of range in block at height %d", data.Index, data.Height)
+		}
+
+		tx := types.Tx(data.Tx)
+		return &ctypes.ResultEventTx{
+			Query:    query,
+			Hash:     tx.Hash(),
+			Height:   data.Height,
+			Index:    data.Index,
+			TxResult: data.Result,
+			Tx:       tx,
+			Proof:    block.Data.Txs.Proof(int(data.Index)),
+			BlockID:  blockMeta.BlockID,
+			Header:   blockMeta.Header,
+			Events:   msg.Events(),
+		}, nil
+	})
+
+	return &ctypes.ResultSubscribe{}, nil
+}
+
+// subscribe subscribes the websocket client of ctx to the events matching the
+// query.
+func (env *Environment) subscribe(ctx *rpctypes.Context, query string) (types.Subscription, error) {
 	addr := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
@@ -41,64 +113,87 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
-	sub, err := env.EventBus.Subscribe(subCtx, addr, q, env.Config.SubscriptionBufferSize)
+	metrics := env.metrics()
+	policy, err := cmtpubsub.ParseOverflowPolicy(env.Config.SubscriptionOverflowPolicy)
 	if err != nil {
 		return nil, err
 	}
+	return env.EventBus.SubscribeWithPolicy(subCtx, addr, q, env.Config.SubscriptionBufferSize, policy, func() {
+		metrics.SubscriptionEventsDropped.With("reason", "buffer_full").Add(1)
+	})
+}
 
+// writeEvents writes the results of the events of the subscription to the
+// websocket client of ctx, with the ID of the subscribe request, until the
+// subscription is canceled. The events for which result returns nil, or an
+// error, are skipped.
+func (env *Environment) writeEvents(
+	ctx *rpctypes.Context,
+	sub types.Subscription,
+	result func(msg cmtpubsub.Message) (interface{}, error),
+) {
+	addr := ctx.RemoteAddr()
+	metrics := env.metrics()
 	closeIfSlow := env.Config.CloseOnSlowClient
 
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := ctx.JSONReq.ID
-	go func() {
-		for {
-			select {
-			case msg := <-sub.Out():
-				var (
-					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
-					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
-				)
-				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				defer cancel()
-				if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
-					env.Logger.Info("Can't write response (slow client)",
-						"to", addr, "subscriptionID", subscriptionID, "err", err)
+	for {
+		select {
+		case msg := <-sub.Out():
+			res, err := result(msg)
+			if err != nil {
+				env.Logger.Error("Can't make event result",
+					"to", addr, "subscriptionID", subscriptionID, "err", err)
+				continue
+			}
+			if res == nil {
+				continue
+			}
+			resp := rpctypes.NewRPCSuccessResponse(subscriptionID, res)
+			writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
+				env.Logger.Info("Can't write response (slow client)",
+					"to", addr, "subscriptionID", subscriptionID, "err", err)
+				metrics.SubscriptionEventsDropped.With("reason", "slow_client").Add(1)
 
-					if closeIfSlow {
-						var (
-							err  = errors.New("subscription was canceled (reason: slow client)")
-							resp = rpctypes.RPCServerError(subscriptionID, err)
-						)
-						if !ctx.WSConn.TryWriteRPCResponse(resp) {
-							env.Logger.Info("Can't write response (slow client)",
-								"to", addr, "subscriptionID", subscriptionID, "err", err)
-						}
-						return
-					}
-				}
-			case <-sub.Canceled():
-				if sub.Err() != cmtpubsub.ErrUnsubscribed {
-					var reason string
-					if sub.Err() == nil {
-						reason = "CometBFT exited"
-					} else {
-						reason = sub.Err().Error()
-					}
+				if closeIfSlow {
+					metrics.SubscriptionsCanceled.With("reason", "slow_client").Add(1)
 					var (
-						err  = fmt.Errorf("subscription was canceled (reason: %s)", reason)
+						err  = errors.New("subscription was canceled (reason: slow client)")
 						resp = rpctypes.RPCServerError(subscriptionID, err)
 					)
 					if !ctx.WSConn.TryWriteRPCResponse(resp) {
 						env.Logger.Info("Can't write response (slow client)",
 							"to", addr, "subscriptionID", subscriptionID, "err", err)
 					}
+					return
 				}
-				return
 			}
+		case <-sub.Canceled():
+			if sub.Err() == cmtpubsub.ErrOutOfCapacity {
+				metrics.SubscriptionsCanceled.With("reason", "buffer_full").Add(1)
+			}
+			if sub.Err() != cmtpubsub.ErrUnsubscribed {
+				var reason string
+				if sub.Err() == nil {
+					reason = "CometBFT exited"
+				} else {
+					reason = sub.Err().Error()
+				}
+				var (
+					err  = fmt.Errorf("subscription was canceled (reason: %s)", reason)
+					resp = rpctypes.RPCServerError(subscriptionID, err)
+				)
+				if !ctx.WSConn.TryWriteRPCResponse(resp) {
+					env.Logger.Info("Can't write response (slow client)",
+						"to", addr, "subscriptionID", subscriptionID, "err", err)
+				}
+			}
+			return
 		}
-	}()
-
-	return &ctypes.ResultSubscribe{}, nil
+	}
 }
 
 // Unsubscribe from events via WebSocket.
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// genesisReader reads the JSON-encoded genesis document.
+type genesisReader interface {
+	io.ReaderAt
+	io.Closer
+}
+
+type bytesGenesisReader struct {
+	*bytes.Reader
+}
+
+func (bytesGenesisReader) Close() error { return nil }
+
+// genesisChunks serves the genesis document in chunks of chunkSize bytes. The
+// chunks are read on demand, e.g. from the genesis file, so that the document
+// is never entirely loaded in memory, and are verified against the checksums
+// computed at startup, so that the chunk indexes stay stable.
+type genesisChunks struct {
+	open      func() (genesisReader, error)
+	chunkSize int
+	size      int64
+	// sha256 of each chunk, and of the whole document
+	checksums [][]byte
+	checksum  []byte
+}
+
+// newGenesisChunks reads through the genesis document once, to compute the
+// checksum of each chunk.
+func newGenesisChunks(open func() (genesisReader, error), chunkSize int) (*genesisChunks, error) {
+	r, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	gc := &genesisChunks{open: open, chunkSize: chunkSize}
+	var (
+		buf = make([]byte, chunkSize)
+		h   = sha256.New()
+	)
+	for {
+		n, err := r.ReadAt(buf, gc.size)
+		if n > 0 {
+			chunk := sha256.Sum256(buf[:n])
+			gc.checksums = append(gc.checksums, chunk[:])
+			h.Write(buf[:n])
+			gc.size += int64(n)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	gc.checksum = h.Sum(nil)
+	return gc, nil
+}
+
+// newGenesisFileChunks serves the chunks of the given genesis file.
+func newGenesisFileChunks(path string, chunkSize int) (*genesisChunks, error) {
+	return newGenesisChunks(func() (genesisReader, error) {
+		return os.Open(path)
+	}, chunkSize)
+}
+
+// newGenesisBytesChunks serves the chunks of the given genesis document.
+func newGenesisBytesChunks(data []byte, chunkSize int) (*genesisChunks, error) {
+	return newGenesisChunks(func() (genesisReader, error) {
+		return bytesGenesisReader{bytes.NewReader(data)}, nil
+	}, chunkSize)
+}
+
+// Total returns the number of chunks.
+func (gc *genesisChunks) Total() int {
+	return len(gc.checksums)
+}
+
+// Chunk reads the chunk at the given index. It returns an error if it doesn't
+// match its checksum, i.e. if the genesis file was modified.
+func (gc *genesisChunks) Chunk(id int) ([]byte, error) {
+	if id < 0 || id >= gc.Total() {
+		return nil, fmt.Errorf("there are %d chunks, %d is invalid", gc.Total()-1, id)
+	}
+
+	r, err := gc.open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	offset := int64(id) * int64(gc.chunkSize)
+	size := int64(gc.chunkSize)
+	if rest := gc.size - offset; rest < size {
+		size = rest
+	}
+	chunk := make([]byte, size)
+	if n, err := r.ReadAt(chunk, offset); n < len(chunk) {
+		return nil, fmt.Errorf("failed to read genesis chunk %d: %w", id, err)
+	}
+	if checksum := sha256.Sum256(chunk); !bytes.Equal(checksum[:], gc.checksums[id]) {
+		return nil, fmt.Errorf("genesis chunk %d doesn't match its checksum, the genesis file was modified", id)
+	}
+	return chunk, nil
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestGenesisChunked(t *testing.T) {
+	genesis := []byte(`{"chain_id":"test-chain","app_state":{"accounts":[1,2,3]}}`)
+	path := filepath.Join(t.TempDir(), "genesis.json")
+	require.NoError(t, os.WriteFile(path, genesis, 0o600))
+
+	chunks, err := newGenesisFileChunks(path, 16)
+	require.NoError(t, err)
+	env := &Environment{genChunks: chunks}
+
+	var data []byte
+	for i := 0; i < 4; i++ {
+		res, err := env.GenesisChunked(&rpctypes.Context{}, uint(i))
+		require.NoError(t, err)
+		assert.Equal(t, i, res.ChunkNumber)
+		assert.Equal(t, 4, res.TotalChunks)
+		chunk, err := base64.StdEncoding.DecodeString(res.Data)
+		require.NoError(t, err)
+		checksum := sha256.Sum256(chunk)
+		assert.EqualValues(t, checksum[:], res.Checksum)
+		genesisChecksum := sha256.Sum256(genesis)
+		assert.EqualValues(t, genesisChecksum[:], res.GenesisChecksum)
+		data = append(data, chunk...)
+	}
+	assert.Equal(t, genesis, data)
+
+	_, err = env.GenesisChunked(&rpctypes.Context{}, 4)
+	assert.Error(t, err)
+
+	_, err = env.Genesis(&rpctypes.Context{})
+	assert.ErrorContains(t, err, "genesis_chunked")
+
+	// the genesis file was modified
+	genesis[20] = 'X'
+	require.NoError(t, os.WriteFile(path, genesis, 0o600))
+	_, err = env.GenesisChunked(&rpctypes.Context{}, 1)
+	assert.ErrorContains(t, err, "checksum")
+	_, err = env.GenesisChunked(&rpctypes.Context{}, 0)
+	assert.NoError(t, err)
+}
@@ -1,13 +1,145 @@
 package core
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
 
 // Health gets node health. Returns empty result (200 OK) on success, no
-// response - in case of an error.
+// response - in case of an error, e.g. when some services of the node are not
+// ready.
 // More: https://docs.cometbft.com/main/rpc/#/Info/health
 func (env *Environment) Health(ctx *rpctypes.Context) (*ctypes.ResultHealth, error) {
+	if env.Services != nil {
+		if notReady := env.Services.NotReady(); len(notReady) > 0 {
+			return nil, fmt.Errorf("services not ready: %s", strings.Join(notReady, ", "))
+		}
+	}
 	return &ctypes.ResultHealth{}, nil
 }
+
+// HealthDetailed gets the status of each subsystem of the node, checked
+// against the health_* thresholds of the configuration, so that load
+// balancers and orchestrators can tell a node which is up from one which is
+// fit to serve. The node is healthy if all the checks are.
+// More: https://docs.cometbft.com/main/rpc/#/Info/health_detailed
+func (env *Environment) HealthDetailed(ctx *rpctypes.Context) (*ctypes.ResultHealthDetailed, error) {
+	var checks []ctypes.HealthCheck
+	if env.Services != nil {
+		check := ctypes.HealthCheck{Name: "services", Healthy: true, Message: "all services ready"}
+		if notReady := env.Services.NotReady(); len(notReady) > 0 {
+			check.Healthy = false
+			check.Message = "not ready: " + strings.Join(notReady, ", ")
+		}
+		checks = append(checks, check)
+	}
+	if env.ConsensusReactor != nil {
+		check := ctypes.HealthCheck{Name: "consensus", Healthy: true, Message: "in consensus"}
+		if env.ConsensusReactor.WaitSync() {
+			check.Healthy = false
+			check.Message = "catching up"
+		}
+		checks = append(checks, check)
+	}
+	checks = append(checks, env.checkBlockStore()...)
+	if minPeers := env.Config.HealthMinPeers; minPeers > 0 && env.P2PPeers != nil {
+		n := env.P2PPeers.Peers().Size()
+		checks = append(checks, ctypes.HealthCheck{
+			Name:    "peers",
+			Healthy: n >= minPeers,
+			Message: fmt.Sprintf("%d peers, min %d", n, minPeers),
+		})
+	}
+	if env.PrivValidator != nil {
+		check := ctypes.HealthCheck{Name: "privval", Healthy: true, Message: "signer connected"}
+		if !env.PrivValidator.IsConnected() {
+			check.Healthy = false
+			check.Message = "signer not connected"
+		}
+		checks = append(checks, check)
+	}
+	if check, ok := env.checkMempool(); ok {
+		checks = append(checks, check)
+	}
+	if env.InvariantChecker != nil {
+		check := ctypes.HealthCheck{Name: "invariants", Healthy: true, Message: "all invariants hold"}
+		var violated []string
+		for _, status := range env.InvariantChecker.Statuses() {
+			if status.Violated {
+				violated = append(violated, status.Name)
+			}
+		}
+		if len(violated) > 0 {
+			check.Healthy = false
+			check.Message = "violated: " + strings.Join(violated, ", ")
+		}
+		checks = append(checks, check)
+	}
+
+	result := &ctypes.ResultHealthDetailed{Healthy: true, Checks: checks}
+	for _, check := range checks {
+		if !check.Healthy {
+			result.Healthy = false
+		}
+	}
+	return result, nil
+}
+
+// checkBlockStore times a read of the last block from the block store, and
+// checks the age of the block.
+func (env *Environment) checkBlockStore() []ctypes.HealthCheck {
+	maxAge, maxLatency := env.Config.HealthMaxLastBlockAge, env.Config.HealthMaxDBLatency
+	if maxAge == 0 && maxLatency == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	meta := env.BlockStore.LoadBlockMeta(env.BlockStore.Height())
+	latency := time.Since(start)
+
+	var checks []ctypes.HealthCheck
+	if maxLatency > 0 {
+		checks = append(checks, ctypes.HealthCheck{
+			Name:    "db_latency",
+			Healthy: latency <= maxLatency,
+			Message: fmt.Sprintf("%v, max %v", latency, maxLatency),
+		})
+	}
+	if maxAge > 0 {
+		check := ctypes.HealthCheck{Name: "last_block_age", Message: "no block"}
+		if meta != nil {
+			age := time.Since(meta.Header.Time)
+			check.Healthy = age <= maxAge
+			check.Message = fmt.Sprintf("%v at height %d, max %v", age.Round(time.Millisecond), meta.Header.Height, maxAge)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkMempool checks the fraction of the capacity of the mempool in use, in
+// number of transactions or bytes, whichever is the highest.
+func (env *Environment) checkMempool() (ctypes.HealthCheck, bool) {
+	maxSaturation := env.Config.HealthMaxMempoolSaturation
+	if maxSaturation == 0 || env.Mempool == nil || env.MempoolConfig == nil {
+		return ctypes.HealthCheck{}, false
+	}
+	var saturation float64
+	if size := env.MempoolConfig.Size; size > 0 {
+		saturation = float64(env.Mempool.Size()) / float64(size)
+	}
+	if maxBytes := env.MempoolConfig.MaxTxsBytes; maxBytes > 0 {
+		if s := float64(env.Mempool.SizeBytes()) / float64(maxBytes); s > saturation {
+			saturation = s
+		}
+	}
+	return ctypes.HealthCheck{
+		Name:    "mempool",
+		Healthy: saturation <= maxSaturation,
+		Message: fmt.Sprintf("%.2f full, max %.2f", saturation, maxSaturation),
+	}, true
+}
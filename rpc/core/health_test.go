@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	mpmocks "github.com/cometbft/cometbft/mempool/mocks"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+type syncingReactor bool
+
+func (r syncingReactor) WaitSync() bool { return bool(r) }
+
+type signer bool
+
+func (s signer) IsConnected() bool { return bool(s) }
+
+func TestHealthDetailed(t *testing.T) {
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Height").Return(int64(10))
+	mockstore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{
+		Header: types.Header{Height: 10, Time: time.Now().Add(-time.Hour)},
+	})
+	mempool := &mpmocks.Mempool{}
+	mempool.On("Size").Return(10)
+	mempool.On("SizeBytes").Return(int64(950))
+
+	rpcConfig := cfg.DefaultRPCConfig()
+	rpcConfig.HealthMinPeers = 0
+	mempoolConfig := cfg.DefaultMempoolConfig()
+	mempoolConfig.Size = 100
+	mempoolConfig.MaxTxsBytes = 1000
+	env := &Environment{
+		BlockStore:       mockstore,
+		ConsensusReactor: syncingReactor(false),
+		PrivValidator:    signer(true),
+		Mempool:          mempool,
+		MempoolConfig:    mempoolConfig,
+		Config:           *rpcConfig,
+	}
+
+	res, err := env.HealthDetailed(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.False(t, res.Healthy)
+	healthy := make(map[string]bool)
+	for _, check := range res.Checks {
+		healthy[check.Name] = check.Healthy
+	}
+	// the last block is too old and the mempool too full, in bytes
+	assert.Equal(t, map[string]bool{
+		"consensus":      true,
+		"db_latency":     true,
+		"last_block_age": false,
+		"privval":        true,
+		"mempool":        false,
+	}, healthy)
+
+	env.Config.HealthMaxLastBlockAge = 2 * time.Hour
+	env.Config.HealthMaxMempoolSaturation = 0.95
+	res, err = env.HealthDetailed(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.True(t, res.Healthy)
+}
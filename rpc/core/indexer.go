@@ -0,0 +1,32 @@
+package core
+
+import (
+	"errors"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/txindex"
+)
+
+// IndexerStatus gets the ranges of heights indexed since the indexing
+// progress is tracked, and of the heights missing between them, e.g. because
+// the node was restarted with indexing temporarily disabled.
+// More: https://docs.cometbft.com/main/rpc/#/Info/indexer_status
+func (env *Environment) IndexerStatus(*rpctypes.Context) (*ctypes.ResultIndexerStatus, error) {
+	if env.IndexerProgress == nil {
+		return nil, errors.New("indexing is disabled")
+	}
+	return &ctypes.ResultIndexerStatus{
+		Indexed:    heightRanges(env.IndexerProgress.Indexed()),
+		Missing:    heightRanges(env.IndexerProgress.Missing()),
+		BaseHeight: env.BlockStore.Base(),
+	}, nil
+}
+
+func heightRanges(ranges []txindex.HeightRange) []ctypes.HeightRange {
+	res := make([]ctypes.HeightRange, len(ranges))
+	for i, r := range ranges {
+		res[i] = ctypes.HeightRange{From: r.From, To: r.To}
+	}
+	return res
+}
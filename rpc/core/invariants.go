@@ -0,0 +1,30 @@
+package core
+
+import (
+	"errors"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// Invariants gets the status of the invariants between the stores of the
+// node at the last check, i.e. whether the block store, the state, the
+// consensus WAL and the indexer are consistent, and since when they are not.
+// More: https://docs.cometbft.com/main/rpc/#/Info/invariants
+func (env *Environment) Invariants(*rpctypes.Context) (*ctypes.ResultInvariants, error) {
+	if env.InvariantChecker == nil {
+		return nil, errors.New("invariant checking is disabled")
+	}
+	statuses := env.InvariantChecker.Statuses()
+	res := &ctypes.ResultInvariants{Invariants: make([]ctypes.InvariantStatus, len(statuses))}
+	for i, s := range statuses {
+		res.Invariants[i] = ctypes.InvariantStatus{
+			Name:      s.Name,
+			Violated:  s.Violated,
+			Message:   s.Message,
+			Since:     s.Since,
+			CheckedAt: s.CheckedAt,
+		}
+	}
+	return res, nil
+}
@@ -62,89 +62,177 @@ func (env *Environment) BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ct
 // BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
 // More: https://docs.cometbft.com/main/rpc/#/Tx/broadcast_tx_commit
 func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	subscriber := ctx.RemoteAddr()
+	return env.BroadcastTxCommitNotify(ctx.Context(), ctx.RemoteAddr(), tx, nil)
+}
+
+// BroadcastTxCommitNotify is BroadcastTxCommit for the callers outside of the
+// JSON-RPC server (e.g. gRPC), subscribing on behalf of subscriber. If not
+// nil, onCheckTx is called with the response from CheckTx before waiting for
+// the transaction to be included in a block.
+func (env *Environment) BroadcastTxCommitNotify(
+	ctx context.Context,
+	subscriber string,
+	tx types.Tx,
+	onCheckTx func(*abci.ResponseCheckTx),
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	checkTxRes, deliverTxSub, unsubscribe, err := env.checkTxAndSubscribe(ctx, subscriber, tx)
+	if err != nil {
+		env.Logger.Error("Error on broadcastTxCommit", "err", err)
+		return nil, err
+	}
+	defer unsubscribe()
+
+	if onCheckTx != nil {
+		onCheckTx(checkTxRes)
+	}
+	if checkTxRes.Code != abci.CodeTypeOK {
+		return &ctypes.ResultBroadcastTxCommit{
+			CheckTx:   *checkTxRes,
+			DeliverTx: abci.ResponseDeliverTx{},
+			Hash:      tx.Hash(),
+		}, nil
+	}
+	return env.waitForDeliverTx(ctx, tx, checkTxRes, deliverTxSub)
+}
+
+// BroadcastTxNotify returns with the response from CheckTx, like
+// BroadcastTxSync, then notifies the client once the transaction is included
+// in a block, or when timeout_broadcast_tx_commit expires: the notification
+// has the same ID as the request, and the result of BroadcastTxCommit (or an
+// error). Only available over websocket; it doesn't hold a request open
+// across block production as BroadcastTxCommit does.
+// More: https://docs.cometbft.com/main/rpc/#/Websocket/broadcast_tx_notify
+func (env *Environment) BroadcastTxNotify(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	// N.B. over websocket, ctx.Context() is the context of the connection,
+	// which outlives the request.
+	addr := ctx.RemoteAddr()
+	checkTxRes, deliverTxSub, unsubscribe, err := env.checkTxAndSubscribe(ctx.Context(), addr, tx)
+	if err != nil {
+		env.Logger.Error("Error on broadcastTxNotify", "err", err)
+		return nil, err
+	}
+	res := &ctypes.ResultBroadcastTx{
+		Code:      checkTxRes.Code,
+		Data:      checkTxRes.Data,
+		Log:       checkTxRes.Log,
+		Codespace: checkTxRes.Codespace,
+		Hash:      tx.Hash(),
+	}
+	if checkTxRes.Code != abci.CodeTypeOK {
+		unsubscribe()
+		return res, nil
+	}
 
+	// Capture the current ID, since it can change in the future.
+	requestID := ctx.JSONReq.ID
+	go func() {
+		defer unsubscribe()
+
+		var resp rpctypes.RPCResponse
+		commitRes, err := env.waitForDeliverTx(ctx.Context(), tx, checkTxRes, deliverTxSub)
+		if err != nil {
+			resp = rpctypes.RPCServerError(requestID, err)
+		} else {
+			resp = rpctypes.NewRPCSuccessResponse(requestID, commitRes)
+		}
+		writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
+			env.Logger.Info("Can't write response (slow client)",
+				"to", addr, "requestID", requestID, "err", err)
+		}
+	}()
+
+	return res, nil
+}
+
+// checkTxAndSubscribe subscribes, on behalf of subscriber, to the transaction
+// being included in a block, then broadcasts it and waits for the response
+// from CheckTx. The caller must call unsubscribe once done with the
+// subscription.
+func (env *Environment) checkTxAndSubscribe(
+	ctx context.Context,
+	subscriber string,
+	tx types.Tx,
+) (checkTxRes *abci.ResponseCheckTx, deliverTxSub types.Subscription, unsubscribe func(), err error) {
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
-		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+		return nil, nil, nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
 	} else if env.EventBus.NumClientSubscriptions(subscriber) >= env.Config.MaxSubscriptionsPerClient {
-		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+		return nil, nil, nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
 	}
 
 	// Subscribe to tx being committed in block.
-	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
+	subCtx, cancel := context.WithTimeout(ctx, SubscribeTimeout)
 	defer cancel()
 	q := types.EventQueryTxFor(tx)
-	deliverTxSub, err := env.EventBus.Subscribe(subCtx, subscriber, q)
+	deliverTxSub, err = env.EventBus.Subscribe(subCtx, subscriber, q)
 	if err != nil {
-		err = fmt.Errorf("failed to subscribe to tx: %w", err)
-		env.Logger.Error("Error on broadcast_tx_commit", "err", err)
-		return nil, err
+		return nil, nil, nil, fmt.Errorf("failed to subscribe to tx: %w", err)
 	}
-	defer func() {
+	unsubscribe = func() {
 		if err := env.EventBus.Unsubscribe(context.Background(), subscriber, q); err != nil {
 			env.Logger.Error("Error unsubscribing from eventBus", "err", err)
 		}
-	}()
+	}
 
 	// Broadcast tx and wait for CheckTx result
 	checkTxResCh := make(chan *abci.Response, 1)
 	err = env.Mempool.CheckTx(tx, func(res *abci.Response) {
 		select {
-		case <-ctx.Context().Done():
+		case <-ctx.Done():
 		case checkTxResCh <- res:
 		}
 	}, mempl.TxInfo{})
 	if err != nil {
-		env.Logger.Error("Error on broadcastTxCommit", "err", err)
-		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
+		unsubscribe()
+		return nil, nil, nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
 	}
 	select {
-	case <-ctx.Context().Done():
-		return nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Context().Err())
+	case <-ctx.Done():
+		unsubscribe()
+		return nil, nil, nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Err())
 	case checkTxResMsg := <-checkTxResCh:
-		checkTxRes := checkTxResMsg.GetCheckTx()
-		if checkTxRes.Code != abci.CodeTypeOK {
-			return &ctypes.ResultBroadcastTxCommit{
-				CheckTx:   *checkTxRes,
-				DeliverTx: abci.ResponseDeliverTx{},
-				Hash:      tx.Hash(),
-			}, nil
-		}
+		return checkTxResMsg.GetCheckTx(), deliverTxSub, unsubscribe, nil
+	}
+}
 
-		// Wait for the tx to be included in a block or timeout.
-		select {
-		case msg := <-deliverTxSub.Out(): // The tx was included in a block.
-			deliverTxRes := msg.Data().(types.EventDataTx)
-			return &ctypes.ResultBroadcastTxCommit{
-				CheckTx:   *checkTxRes,
-				DeliverTx: deliverTxRes.Result,
-				Hash:      tx.Hash(),
-				Height:    deliverTxRes.Height,
-			}, nil
-		case <-deliverTxSub.Canceled():
-			var reason string
-			if deliverTxSub.Err() == nil {
-				reason = "CometBFT exited"
-			} else {
-				reason = deliverTxSub.Err().Error()
-			}
-			err = fmt.Errorf("deliverTxSub was canceled (reason: %s)", reason)
-			env.Logger.Error("Error on broadcastTxCommit", "err", err)
-			return &ctypes.ResultBroadcastTxCommit{
-				CheckTx:   *checkTxRes,
-				DeliverTx: abci.ResponseDeliverTx{},
-				Hash:      tx.Hash(),
-			}, err
-		case <-time.After(env.Config.TimeoutBroadcastTxCommit):
-			err = errors.New("timed out waiting for tx to be included in a block")
-			env.Logger.Error("Error on broadcastTxCommit", "err", err)
-			return &ctypes.ResultBroadcastTxCommit{
-				CheckTx:   *checkTxRes,
-				DeliverTx: abci.ResponseDeliverTx{},
-				Hash:      tx.Hash(),
-			}, err
+// waitForDeliverTx waits for the transaction to be included in a block, or
+// for timeout_broadcast_tx_commit to expire.
+func (env *Environment) waitForDeliverTx(
+	ctx context.Context,
+	tx types.Tx,
+	checkTxRes *abci.ResponseCheckTx,
+	deliverTxSub types.Subscription,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	var err error
+	select {
+	case msg := <-deliverTxSub.Out(): // The tx was included in a block.
+		deliverTxRes := msg.Data().(types.EventDataTx)
+		return &ctypes.ResultBroadcastTxCommit{
+			CheckTx:   *checkTxRes,
+			DeliverTx: deliverTxRes.Result,
+			Hash:      tx.Hash(),
+			Height:    deliverTxRes.Height,
+		}, nil
+	case <-deliverTxSub.Canceled():
+		var reason string
+		if deliverTxSub.Err() == nil {
+			reason = "CometBFT exited"
+		} else {
+			reason = deliverTxSub.Err().Error()
 		}
+		err = fmt.Errorf("deliverTxSub was canceled (reason: %s)", reason)
+	case <-ctx.Done():
+		err = fmt.Errorf("broadcast confirmation not received: %w", ctx.Err())
+	case <-time.After(env.Config.TimeoutBroadcastTxCommit):
+		err = errors.New("timed out waiting for tx to be included in a block")
 	}
+	env.Logger.Error("Error on broadcastTxCommit", "err", err)
+	return &ctypes.ResultBroadcastTxCommit{
+		CheckTx:   *checkTxRes,
+		DeliverTx: abci.ResponseDeliverTx{},
+		Hash:      tx.Hash(),
+	}, err
 }
 
 // UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
@@ -0,0 +1,65 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package core
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		ResponseCacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_hits",
+			Help:      "Number of responses served from the response cache.",
+		}, append(labels, "method")).With(labelsAndValues...),
+		ResponseCacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_misses",
+			Help:      "Number of responses which were not found in the response cache.",
+		}, append(labels, "method")).With(labelsAndValues...),
+		ResponseCacheEvictions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_evictions",
+			Help:      "Number of responses evicted from the response cache.",
+		}, labels).With(labelsAndValues...),
+		ResponseCacheSizeBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "response_cache_size_bytes",
+			Help:      "Approximate size, in bytes, of the responses in the response cache.",
+		}, labels).With(labelsAndValues...),
+		SubscriptionEventsDropped: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "subscription_events_dropped",
+			Help:      "Number of events dropped instead of being sent to a websocket subscriber, because its subscription buffer was full (buffer_full) or it didn't read them fast enough (slow_client).",
+		}, append(labels, "reason")).With(labelsAndValues...),
+		SubscriptionsCanceled: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "subscriptions_canceled",
+			Help:      "Number of websocket subscriptions canceled because the subscriber wasn't keeping up.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		ResponseCacheHits:         discard.NewCounter(),
+		ResponseCacheMisses:       discard.NewCounter(),
+		ResponseCacheEvictions:    discard.NewCounter(),
+		ResponseCacheSizeBytes:    discard.NewGauge(),
+		SubscriptionEventsDropped: discard.NewCounter(),
+		SubscriptionsCanceled:     discard.NewCounter(),
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc"
+)
+
+//go:generate go run ../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of responses served from the response cache.
+	ResponseCacheHits metrics.Counter `metrics_labels:"method"`
+	// Number of responses which were not found in the response cache.
+	ResponseCacheMisses metrics.Counter `metrics_labels:"method"`
+	// Number of responses evicted from the response cache.
+	ResponseCacheEvictions metrics.Counter
+	// Approximate size, in bytes, of the responses in the response cache.
+	ResponseCacheSizeBytes metrics.Gauge
+
+	// Number of events dropped instead of being sent to a websocket
+	// subscriber, because its subscription buffer was full (buffer_full) or
+	// it didn't read them fast enough (slow_client).
+	SubscriptionEventsDropped metrics.Counter `metrics_labels:"reason"`
+	// Number of websocket subscriptions canceled because the subscriber
+	// wasn't keeping up.
+	SubscriptionsCanceled metrics.Counter `metrics_labels:"reason"`
+}
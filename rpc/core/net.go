@@ -1,6 +1,7 @@
 package core
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"strings"
@@ -94,10 +95,97 @@ func (env *Environment) UnsafeDialPeers(
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeAddPersistentPeers makes the given peers (id@IP:PORT) persistent, or
+// updates their address, and dials them. If given, the policy (e.g.
+// "max_attempts=0;backoff=constant;interval=1s;unconditional=true") sets how
+// to reconnect to the peers, the fields not given being the default ones.
+func (env *Environment) UnsafeAddPersistentPeers(
+	ctx *rpctypes.Context,
+	peers []string,
+	policy string,
+) (*ctypes.ResultPersistentPeers, error) {
+	if len(peers) == 0 {
+		return nil, errors.New("no peers provided")
+	}
+	ids, err := getIDs(peers)
+	if err != nil {
+		return nil, err
+	}
+	var reconnectPolicy *p2p.ReconnectPolicy
+	if policy != "" {
+		p, err := p2p.ParseReconnectPolicy(policy, env.P2PPeers.DefaultReconnectPolicy())
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy: %w", err)
+		}
+		reconnectPolicy = &p
+	}
+
+	env.Logger.Info("AddPersistentPeers", "peers", peers, "policy", policy)
+
+	if err := env.P2PPeers.AddPersistentPeers(peers); err != nil {
+		return nil, err
+	}
+	if reconnectPolicy != nil {
+		for _, id := range ids {
+			if err := env.P2PPeers.SetReconnectPolicy(p2p.ID(id), *reconnectPolicy); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := env.P2PPeers.DialPeersAsync(peers); err != nil {
+		return nil, err
+	}
+	return env.persistentPeers(), nil
+}
+
+// UnsafeRemovePersistentPeers makes the given peers (id or id@IP:PORT) no
+// longer persistent, so that they are not redialed, and disconnects them if
+// disconnect is true.
+func (env *Environment) UnsafeRemovePersistentPeers(
+	ctx *rpctypes.Context,
+	peers []string,
+	disconnect bool,
+) (*ctypes.ResultPersistentPeers, error) {
+	if len(peers) == 0 {
+		return nil, errors.New("no peers provided")
+	}
+
+	env.Logger.Info("RemovePersistentPeers", "peers", peers, "disconnect", disconnect)
+
+	if err := env.P2PPeers.RemovePersistentPeers(peers); err != nil {
+		return nil, err
+	}
+	if disconnect {
+		for _, peer := range peers {
+			id, _, _ := strings.Cut(peer, "@")
+			if p := env.P2PPeers.Peers().Get(p2p.ID(id)); p != nil {
+				env.P2PPeers.StopPeerGracefully(p)
+			}
+		}
+	}
+	return env.persistentPeers(), nil
+}
+
+func (env *Environment) persistentPeers() *ctypes.ResultPersistentPeers {
+	addrs := env.P2PPeers.PersistentPeers()
+	peers := make([]ctypes.PersistentPeer, len(addrs))
+	for i, addr := range addrs {
+		policy := env.P2PPeers.ReconnectPolicyOf(addr.ID)
+		peers[i] = ctypes.PersistentPeer{
+			Address:       addr.String(),
+			MaxAttempts:   policy.MaxAttempts,
+			Backoff:       policy.Backoff,
+			Interval:      policy.Interval.String(),
+			Unconditional: policy.Unconditional,
+		}
+	}
+	return &ctypes.ResultPersistentPeers{PersistentPeers: peers}
+}
+
 // Genesis returns genesis file.
 // More: https://docs.cometbft.com/main/rpc/#/Info/genesis
 func (env *Environment) Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
-	if len(env.genChunks) > 1 {
+	if env.genChunks != nil && env.genChunks.Total() > 1 {
 		return nil, errors.New("genesis response is large, please use the genesis_chunked API instead")
 	}
 
@@ -109,20 +197,22 @@ func (env *Environment) GenesisChunked(ctx *rpctypes.Context, chunk uint) (*ctyp
 		return nil, fmt.Errorf("service configuration error, genesis chunks are not initialized")
 	}
 
-	if len(env.genChunks) == 0 {
+	if env.genChunks.Total() == 0 {
 		return nil, fmt.Errorf("service configuration error, there are no chunks")
 	}
 
 	id := int(chunk)
-
-	if id > len(env.genChunks)-1 {
-		return nil, fmt.Errorf("there are %d chunks, %d is invalid", len(env.genChunks)-1, id)
+	data, err := env.genChunks.Chunk(id)
+	if err != nil {
+		return nil, err
 	}
 
 	return &ctypes.ResultGenesisChunk{
-		TotalChunks: len(env.genChunks),
-		ChunkNumber: id,
-		Data:        env.genChunks[id],
+		TotalChunks:     env.genChunks.Total(),
+		ChunkNumber:     id,
+		Data:            base64.StdEncoding.EncodeToString(data),
+		Checksum:        env.genChunks.checksums[id],
+		GenesisChecksum: env.genChunks.checksum,
 	}, nil
 }
 
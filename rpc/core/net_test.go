@@ -87,3 +87,39 @@ func TestUnsafeDialPeers(t *testing.T) {
 		}
 	}
 }
+
+func TestUnsafeAddRemovePersistentPeers(t *testing.T) {
+	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1, "testing", "123.123.123",
+		func(n int, sw *p2p.Switch) *p2p.Switch { return sw })
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	env := &Environment{}
+	env.Logger = log.TestingLogger()
+	env.P2PPeers = sw
+
+	peer := "d51fb70907db1c6c2d5237e78379b25cf1a37ab4@127.0.0.1:41198"
+	_, err = env.UnsafeAddPersistentPeers(&rpctypes.Context{}, []string{}, "")
+	assert.Error(t, err)
+	_, err = env.UnsafeAddPersistentPeers(&rpctypes.Context{}, []string{"127.0.0.1:41198"}, "")
+	assert.Error(t, err)
+	_, err = env.UnsafeAddPersistentPeers(&rpctypes.Context{}, []string{peer}, "backoff=linear")
+	assert.Error(t, err)
+
+	res, err := env.UnsafeAddPersistentPeers(&rpctypes.Context{}, []string{peer}, "max_attempts=0;interval=1s")
+	require.NoError(t, err)
+	require.Len(t, res.PersistentPeers, 1)
+	assert.Equal(t, peer, res.PersistentPeers[0].Address)
+	assert.Equal(t, 0, res.PersistentPeers[0].MaxAttempts)
+	assert.Equal(t, cfg.ReconnectBackoffHybrid, res.PersistentPeers[0].Backoff)
+	assert.Equal(t, "1s", res.PersistentPeers[0].Interval)
+
+	res, err = env.UnsafeRemovePersistentPeers(&rpctypes.Context{}, []string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4"}, true)
+	require.NoError(t, err)
+	assert.Empty(t, res.PersistentPeers)
+}
@@ -13,41 +13,53 @@ func (env *Environment) GetRoutes() RoutesMap {
 	return RoutesMap{
 		// subscribe/unsubscribe are reserved for websocket events.
 		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query"),
+		"subscribe_tx":    rpc.NewWSRPCFunc(env.SubscribeTx, "query"),
 		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query"),
 		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, ""),
 
 		// info AP
-		"health":               rpc.NewRPCFunc(env.Health, ""),
-		"status":               rpc.NewRPCFunc(env.Status, ""),
-		"net_info":             rpc.NewRPCFunc(env.NetInfo, ""),
-		"blockchain":           rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
-		"genesis":              rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable()),
-		"genesis_chunked":      rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable()),
-		"block":                rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height")),
-		"block_by_hash":        rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable()),
-		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height", rpc.Cacheable("height")),
-		"commit":               rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height")),
-		"header":               rpc.NewRPCFunc(env.Header, "height", rpc.Cacheable("height")),
-		"header_by_hash":       rpc.NewRPCFunc(env.HeaderByHash, "hash", rpc.Cacheable()),
-		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx"),
-		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", rpc.Cacheable()),
-		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by"),
-		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
-		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", rpc.Cacheable("height")),
-		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, ""),
-		"consensus_state":      rpc.NewRPCFunc(env.GetConsensusState, ""),
-		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height")),
-		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit"),
-		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, ""),
+		"health":                rpc.NewRPCFunc(env.Health, "", rpc.ConcurrencySafe()),
+		"health/detailed":       rpc.NewRPCFunc(env.HealthDetailed, "", rpc.ConcurrencySafe()),
+		"status":                rpc.NewRPCFunc(env.Status, "", rpc.ConcurrencySafe()),
+		"net_info":              rpc.NewRPCFunc(env.NetInfo, "", rpc.ConcurrencySafe()),
+		"blockchain":            rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable(), rpc.ConcurrencySafe()),
+		"genesis":               rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable(), rpc.ConcurrencySafe()),
+		"genesis_chunked":       rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable(), rpc.ConcurrencySafe()),
+		"block":                 rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height"), rpc.ConcurrencySafe()),
+		"block_by_hash":         rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable(), rpc.ConcurrencySafe()),
+		"block_by_time":         rpc.NewRPCFunc(env.BlockByTime, "time", rpc.ConcurrencySafe()),
+		"block_results":         rpc.NewRPCFunc(env.BlockResults, "height", rpc.Cacheable("height"), rpc.ConcurrencySafe()),
+		"block_events":          rpc.NewRPCFunc(env.BlockEvents, "height", rpc.Cacheable("height"), rpc.ConcurrencySafe()),
+		"commit":                rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height"), rpc.ConcurrencySafe()),
+		"commit_aggregated":     rpc.NewRPCFunc(env.CommitAggregated, "height", rpc.Cacheable("height"), rpc.ConcurrencySafe()),
+		"header":                rpc.NewRPCFunc(env.Header, "height,with_commit", rpc.Cacheable("height"), rpc.ConcurrencySafe()),
+		"header_by_hash":        rpc.NewRPCFunc(env.HeaderByHash, "hash,with_commit", rpc.Cacheable(), rpc.ConcurrencySafe()),
+		"check_tx":              rpc.NewRPCFunc(env.CheckTx, "tx"),
+		"tx":                    rpc.NewRPCFunc(env.Tx, "hash,prove", rpc.Cacheable(), rpc.ConcurrencySafe()),
+		"tx_proof":              rpc.NewRPCFunc(env.TxProof, "hash", rpc.Cacheable(), rpc.ConcurrencySafe()),
+		"tx_search":             rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by,cursor", rpc.ConcurrencySafe()),
+		"block_search":          rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by,cursor", rpc.ConcurrencySafe()),
+		"indexer_status":        rpc.NewRPCFunc(env.IndexerStatus, "", rpc.ConcurrencySafe()),
+		"invariants":            rpc.NewRPCFunc(env.Invariants, "", rpc.ConcurrencySafe()),
+		"hash_to_curve":         rpc.NewRPCFunc(env.HashToCurve, "msg", rpc.Cacheable(), rpc.ConcurrencySafe()),
+		"validators":            rpc.NewRPCFunc(env.Validators, "height,page,per_page", rpc.Cacheable("height"), rpc.ConcurrencySafe()),
+		"validators_commitment": rpc.NewRPCFunc(env.ValidatorsCommitment, "height", rpc.Cacheable("height"), rpc.ConcurrencySafe()),
+		"validators_diff":       rpc.NewRPCFunc(env.ValidatorsDiff, "from,to", rpc.Cacheable("from", "to"), rpc.ConcurrencySafe()),
+		"dump_consensus_state":  rpc.NewRPCFunc(env.DumpConsensusState, "", rpc.ConcurrencySafe()),
+		"consensus_state":       rpc.NewRPCFunc(env.GetConsensusState, "", rpc.ConcurrencySafe()),
+		"consensus_params":      rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height"), rpc.ConcurrencySafe()),
+		"unconfirmed_txs":       rpc.NewRPCFunc(env.UnconfirmedTxs, "limit", rpc.ConcurrencySafe()),
+		"num_unconfirmed_txs":   rpc.NewRPCFunc(env.NumUnconfirmedTxs, "", rpc.ConcurrencySafe()),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx"),
 		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx"),
 		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx"),
+		"broadcast_tx_notify": rpc.NewWSRPCFunc(env.BroadcastTxNotify, "tx"), // websocket only
 
 		// abci API
-		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove"),
-		"abci_info":  rpc.NewRPCFunc(env.ABCIInfo, "", rpc.Cacheable()),
+		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove", rpc.ConcurrencySafe()),
+		"abci_info":  rpc.NewRPCFunc(env.ABCIInfo, "", rpc.Cacheable(), rpc.ConcurrencySafe()),
 
 		// evidence API
 		"broadcast_evidence": rpc.NewRPCFunc(env.BroadcastEvidence, "evidence"),
@@ -59,5 +71,8 @@ func (env *Environment) AddUnsafeRoutes(routes RoutesMap) {
 	// control API
 	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds")
 	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private")
+	routes["unsafe_add_persistent_peers"] = rpc.NewRPCFunc(env.UnsafeAddPersistentPeers, "peers,policy")
+	routes["unsafe_remove_persistent_peers"] = rpc.NewRPCFunc(env.UnsafeRemovePersistentPeers, "peers,disconnect")
 	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "")
+	routes["unsafe_reload_config"] = rpc.NewRPCFunc(env.UnsafeReloadConfig, "")
 }
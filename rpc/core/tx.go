@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
@@ -48,6 +50,47 @@ func (env *Environment) Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*cty
 	}, nil
 }
 
+// TxProof returns the proof of inclusion of a transaction in the data hash of
+// its block, as a protobuf-encoded ICS-23 commitment proof, whose spec is
+// merkle.ICS23ProofSpec, so that it can be checked by ICS-23 verifiers.
+// More: https://docs.cometbft.com/main/rpc/#/Info/tx_proof
+func (env *Environment) TxProof(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxProof, error) {
+	// if index is disabled, return error
+	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
+		return nil, fmt.Errorf("transaction indexing is disabled")
+	}
+
+	r, err := env.TxIndexer.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if r == nil {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+
+	block := env.BlockStore.LoadBlock(r.Height)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d not found", r.Height)
+	}
+	proof, err := block.Data.Txs.Proof(int(r.Index)).ICS23()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := proof.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultTxProof{
+		Hash:     hash,
+		Height:   r.Height,
+		Index:    r.Index,
+		RootHash: block.DataHash,
+		Proof:    bz,
+	}, nil
+}
+
 // TxSearch allows you to query for multiple transactions results. It returns a
 // list of transactions (maximum ?per_page entries) and the total count.
 // More: https://docs.cometbft.com/main/rpc/#/Info/tx_search
@@ -57,6 +100,7 @@ func (env *Environment) TxSearch(
 	prove bool,
 	pagePtr, perPagePtr *int,
 	orderBy string,
+	cursor string,
 ) (*ctypes.ResultTxSearch, error) {
 
 	// if index is disabled, return error
@@ -77,35 +121,53 @@ func (env *Environment) TxSearch(
 	}
 
 	// sort results (must be done before pagination)
+	var less func(h1 int64, i1 uint32, h2 int64, i2 uint32) bool
 	switch orderBy {
 	case "desc":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index > results[j].Index
+		less = func(h1 int64, i1 uint32, h2 int64, i2 uint32) bool {
+			if h1 == h2 {
+				return i1 > i2
 			}
-			return results[i].Height > results[j].Height
-		})
+			return h1 > h2
+		}
 	case "asc", "":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index < results[j].Index
+		less = func(h1 int64, i1 uint32, h2 int64, i2 uint32) bool {
+			if h1 == h2 {
+				return i1 < i2
 			}
-			return results[i].Height < results[j].Height
-		})
+			return h1 < h2
+		}
 	default:
 		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
 	}
+	sort.Slice(results, func(i, j int) bool {
+		return less(results[i].Height, results[i].Index, results[j].Height, results[j].Index)
+	})
 
 	// paginate results
 	totalCount := len(results)
 	perPage := env.validatePerPage(perPagePtr)
 
-	page, err := validatePage(pagePtr, perPage, totalCount)
-	if err != nil {
-		return nil, err
+	var skipCount int
+	if cursor != "" {
+		// resume right after the cursor, wherever it is now
+		if pagePtr != nil {
+			return nil, errors.New("page and cursor are mutually exclusive")
+		}
+		height, index, err := parseTxCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		skipCount = sort.Search(totalCount, func(i int) bool {
+			return less(height, index, results[i].Height, results[i].Index)
+		})
+	} else {
+		page, err := validatePage(pagePtr, perPage, totalCount)
+		if err != nil {
+			return nil, err
+		}
+		skipCount = validateSkipCount(page, perPage)
 	}
-
-	skipCount := validateSkipCount(page, perPage)
 	pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
 
 	apiResults := make([]*ctypes.ResultTx, 0, pageSize)
@@ -128,5 +190,28 @@ func (env *Environment) TxSearch(
 		})
 	}
 
-	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+	var nextCursor string
+	if last := skipCount + pageSize - 1; pageSize > 0 && last < totalCount-1 {
+		nextCursor = fmt.Sprintf("%d/%d", results[last].Height, results[last].Index)
+	}
+
+	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount, NextCursor: nextCursor}, nil
+}
+
+// parseTxCursor parses a tx_search cursor, i.e. the "height/index" of the
+// last tx of the previous page.
+func parseTxCursor(cursor string) (int64, uint32, error) {
+	heightStr, indexStr, ok := strings.Cut(cursor, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid cursor %q: expected height/index", cursor)
+	}
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid cursor %q: %w", cursor, err)
+	}
+	index, err := strconv.ParseUint(indexStr, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid cursor %q: %w", cursor, err)
+	}
+	return height, uint32(index), nil
 }
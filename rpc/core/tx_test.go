@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	txidxmocks "github.com/cometbft/cometbft/state/txindex/mocks"
+)
+
+func TestTxSearchCursor(t *testing.T) {
+	results := []*abci.TxResult{
+		{Height: 2, Index: 1}, {Height: 1, Index: 0}, {Height: 3, Index: 0},
+		{Height: 2, Index: 0}, {Height: 1, Index: 1},
+	}
+	txIndexer := &txidxmocks.TxIndexer{}
+	txIndexer.On("Search", mock.Anything, mock.Anything).Return(results, nil)
+	env := &Environment{TxIndexer: txIndexer}
+
+	perPage := 2
+	search := func(orderBy, cursor string) (heights []int64, nextCursor string) {
+		res, err := env.TxSearch(&rpctypes.Context{}, "tx.height > 0", false, nil, &perPage, orderBy, cursor)
+		require.NoError(t, err)
+		assert.Equal(t, len(results), res.TotalCount)
+		for _, tx := range res.Txs {
+			heights = append(heights, tx.Height*10+int64(tx.Index))
+		}
+		return heights, res.NextCursor
+	}
+
+	heights, cursor := search("asc", "")
+	assert.Equal(t, []int64{10, 11}, heights)
+	assert.Equal(t, "1/1", cursor)
+	heights, cursor = search("asc", cursor)
+	assert.Equal(t, []int64{20, 21}, heights)
+	heights, cursor = search("asc", cursor)
+	assert.Equal(t, []int64{30}, heights)
+	assert.Empty(t, cursor)
+
+	heights, cursor = search("desc", "")
+	assert.Equal(t, []int64{30, 21}, heights)
+	heights, _ = search("desc", cursor)
+	assert.Equal(t, []int64{20, 11}, heights)
+
+	// the cursor doesn't have to match a result
+	heights, _ = search("asc", "1/5")
+	assert.Equal(t, []int64{20, 21}, heights)
+	heights, cursor = search("asc", "9/0")
+	assert.Empty(t, heights)
+	assert.Empty(t, cursor)
+
+	page := 1
+	_, err := env.TxSearch(&rpctypes.Context{}, "tx.height > 0", false, &page, &perPage, "asc", "1/1")
+	assert.Error(t, err)
+	for _, cursor := range []string{"1", "a/1", "1/-1"} {
+		_, err = env.TxSearch(&rpctypes.Context{}, "tx.height > 0", false, nil, &perPage, "asc", cursor)
+		assert.Error(t, err, cursor)
+	}
+}
@@ -2,6 +2,8 @@ package coretypes
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -31,6 +33,10 @@ type ResultGenesisChunk struct {
 	ChunkNumber int    `json:"chunk"`
 	TotalChunks int    `json:"total"`
 	Data        string `json:"data"`
+	// SHA256 of the (decoded) data of the chunk, and of the whole genesis
+	// document, i.e. of the concatenation of the chunks.
+	Checksum        bytes.HexBytes `json:"checksum"`
+	GenesisChecksum bytes.HexBytes `json:"genesis_checksum"`
 }
 
 // Single block (with meta)
@@ -42,6 +48,11 @@ type ResultBlock struct {
 // ResultHeader represents the response for a Header RPC Client query
 type ResultHeader struct {
 	Header *types.Header `json:"header"`
+	// Commit is only set if requested. CanonicalCommit is then false if the
+	// commit is the one seen by the node, because the next block has not been
+	// committed yet.
+	Commit          *types.Commit `json:"commit,omitempty"`
+	CanonicalCommit bool          `json:"canonical,omitempty"`
 }
 
 // Commit and Header
@@ -50,6 +61,17 @@ type ResultCommit struct {
 	CanonicalCommit    bool `json:"canonical"`
 }
 
+// Commit aggregated into a single bn254 signature, along with the validator set
+// which signed it (in the order of the signers bitmap)
+type ResultCommitAggregated struct {
+	Header            *types.Header           `json:"header"`
+	Commit            *types.AggregatedCommit `json:"commit"`
+	Validators        []*types.Validator      `json:"validators"`
+	SignedVotingPower int64                   `json:"signed_voting_power"`
+	TotalVotingPower  int64                   `json:"total_voting_power"`
+	CanonicalCommit   bool                    `json:"canonical"`
+}
+
 // ABCI results from a block
 type ResultBlockResults struct {
 	Height                int64                     `json:"height"`
@@ -60,6 +82,28 @@ type ResultBlockResults struct {
 	ConsensusParamUpdates *cmtproto.ConsensusParams `json:"consensus_param_updates"`
 }
 
+// ResultBlockEvents are the indexed events of a block.
+type ResultBlockEvents struct {
+	Height           int64        `json:"height"`
+	BeginBlockEvents []abci.Event `json:"begin_block_events"`
+	EndBlockEvents   []abci.Event `json:"end_block_events"`
+}
+
+// ResultIndexerStatus are the heights indexed since the indexing progress is
+// tracked, and the heights missing between them, which are backfilled if
+// available, i.e. not below the base height of the block store.
+type ResultIndexerStatus struct {
+	Indexed    []HeightRange `json:"indexed"`
+	Missing    []HeightRange `json:"missing"`
+	BaseHeight int64         `json:"base_height"`
+}
+
+// HeightRange is an inclusive range of heights.
+type HeightRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
 // NewResultCommit is a helper to initialize the ResultCommit with
 // the embedded struct
 func NewResultCommit(header *types.Header, commit *types.Commit,
@@ -129,6 +173,20 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Persistent peers
+type ResultPersistentPeers struct {
+	PersistentPeers []PersistentPeer `json:"persistent_peers"`
+}
+
+// A persistent peer, with its reconnect policy
+type PersistentPeer struct {
+	Address       string `json:"address"`
+	MaxAttempts   int    `json:"max_attempts"`
+	Backoff       string `json:"backoff"`
+	Interval      string `json:"interval"`
+	Unconditional bool   `json:"unconditional"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
@@ -147,6 +205,27 @@ type ResultValidators struct {
 	Total int `json:"total"`
 }
 
+// Circuit-friendly commitment to the validator set at a given height
+type ResultValidatorsCommitment struct {
+	BlockHeight      int64          `json:"block_height"`
+	Scheme           string         `json:"scheme"`
+	Commitment       bytes.HexBytes `json:"commitment"`
+	Count            int            `json:"count"`
+	TotalVotingPower int64          `json:"total_voting_power"`
+}
+
+// Difference between the validator sets at two heights
+type ResultValidatorsDiff struct {
+	FromHeight     int64              `json:"from_height"`
+	ToHeight       int64              `json:"to_height"`
+	Updates        []*types.Validator `json:"updates"`
+	Removals       []*types.Validator `json:"removals"`
+	FromHash       bytes.HexBytes     `json:"from_hash"`
+	ToHash         bytes.HexBytes     `json:"to_hash"`
+	FromCommitment bytes.HexBytes     `json:"from_commitment,omitempty"`
+	ToCommitment   bytes.HexBytes     `json:"to_commitment,omitempty"`
+}
+
 // ConsensusParams for given height
 type ResultConsensusParams struct {
 	BlockHeight     int64                 `json:"block_height"`
@@ -204,16 +283,30 @@ type ResultTx struct {
 	Proof    types.TxProof          `json:"proof,omitempty"`
 }
 
+// ResultTxProof is the proof of inclusion of a transaction in the data hash
+// of its block, as a protobuf-encoded ICS-23 commitment proof.
+type ResultTxProof struct {
+	Hash     bytes.HexBytes `json:"hash"`
+	Height   int64          `json:"height"`
+	Index    uint32         `json:"index"`
+	RootHash bytes.HexBytes `json:"root_hash"`
+	Proof    bytes.HexBytes `json:"proof"`
+}
+
 // Result of searching for txs
 type ResultTxSearch struct {
 	Txs        []*ResultTx `json:"txs"`
 	TotalCount int         `json:"total_count"`
+	// NextCursor, if not empty, is the cursor to pass to get the next page.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ResultBlockSearch defines the RPC response type for a block search by events.
 type ResultBlockSearch struct {
 	Blocks     []*ResultBlock `json:"blocks"`
 	TotalCount int            `json:"total_count"`
+	// NextCursor, if not empty, is the cursor to pass to get the next page.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // List of mempool txs
@@ -248,9 +341,103 @@ type (
 	ResultHealth             struct{}
 )
 
+// HealthCheck is the status of a subsystem of the node.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	// what was measured, e.g. the age of the last block, and why the
+	// subsystem is unhealthy if so
+	Message string `json:"message"`
+}
+
+// Detailed node health
+type ResultHealthDetailed struct {
+	// true if all the checks are
+	Healthy bool          `json:"healthy"`
+	Checks  []HealthCheck `json:"checks"`
+}
+
+// InvariantStatus is the status of an invariant at the last check.
+type InvariantStatus struct {
+	Name     string `json:"name"`
+	Violated bool   `json:"violated"`
+	// the violation, if any
+	Message string `json:"message,omitempty"`
+	// when the invariant was first found violated, zero if it holds
+	Since     time.Time `json:"since"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Status of the invariants between the stores
+type ResultInvariants struct {
+	Invariants []InvariantStatus `json:"invariants"`
+}
+
+// Point of G2 to which a message is hashed before it is signed with a bn254
+// key. The coordinates are big-endian, and the encodings put the A1 component
+// of each coordinate before the A0 one.
+type ResultHashToCurve struct {
+	// domain separation tag of the hashing
+	DST string `json:"dst"`
+	// iteration of the try-and-increment which found the point
+	Nonce uint32 `json:"nonce"`
+	// X.A1 | X.A0 | Y.A1 | Y.A0, as in the signatures
+	Point bytes.HexBytes `json:"point"`
+	// X.A1 | X.A0, with the compression mask in the 2 most significant bits
+	PointCompressed bytes.HexBytes `json:"point_compressed"`
+	// 0x80 if Y is the lexicographically smallest of Y and -Y, 0xC0 if not
+	CompressionMask byte           `json:"compression_mask"`
+	X0              bytes.HexBytes `json:"x0"`
+	X1              bytes.HexBytes `json:"x1"`
+	Y0              bytes.HexBytes `json:"y0"`
+	Y1              bytes.HexBytes `json:"y1"`
+}
+
+// Reloaded configuration, by the keys of the fields in the configuration file
+type ResultReloadConfig struct {
+	Applied        []string `json:"applied"`
+	RequireRestart []string `json:"require_restart"`
+}
+
 // Event data from a subscription
 type ResultEvent struct {
 	Query  string              `json:"query"`
 	Data   types.TMEventData   `json:"data"`
 	Events map[string][]string `json:"events"`
 }
+
+// ResultEventTx is a committed transaction, along with the proof of its
+// inclusion in the block and the header of the block, as pushed to the
+// subscribe_tx subscriptions.
+type ResultEventTx struct {
+	Query    string                 `json:"query"`
+	Hash     bytes.HexBytes         `json:"hash"`
+	Height   int64                  `json:"height"`
+	Index    uint32                 `json:"index"`
+	TxResult abci.ResponseDeliverTx `json:"tx_result"`
+	Tx       types.Tx               `json:"tx"`
+	Proof    types.TxProof          `json:"proof"`
+	BlockID  types.BlockID          `json:"block_id"`
+	Header   types.Header           `json:"header"`
+	Events   map[string][]string    `json:"events"`
+}
+
+// Verify checks that the transaction is included in the block identified by
+// BlockID, i.e. that the header hashes to it with the canonical encoding of
+// the chain and that the proof is valid against its data hash. It doesn't
+// verify the header itself, e.g. with a light client.
+func (r *ResultEventTx) Verify(encoding types.CanonicalEncoding) error {
+	if hash := r.Header.Hash(encoding); string(hash) != string(r.BlockID.Hash) {
+		return fmt.Errorf("header hash %X doesn't match block ID %v", hash, r.BlockID)
+	}
+	if r.Header.Height != r.Height {
+		return fmt.Errorf("header height %d doesn't match tx height %d", r.Header.Height, r.Height)
+	}
+	if string(r.Proof.Data) != string(r.Tx) {
+		return errors.New("proof is for another tx")
+	}
+	if r.Proof.Proof.Index != int64(r.Index) {
+		return fmt.Errorf("proof index %d doesn't match tx index %d", r.Proof.Proof.Index, r.Index)
+	}
+	return r.Proof.Validate(r.Header.DataHash)
+}
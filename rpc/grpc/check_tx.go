@@ -0,0 +1,58 @@
+package coregrpc
+
+import (
+	"context"
+
+	mempl "github.com/cometbft/cometbft/mempool"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+// CheckTx runs the checks a transaction goes through before entering the
+// mempool, without adding it to the mempool, so that gateways can reject
+// invalid transactions before forwarding them to the validators:
+//
+//   - the size of the transaction, against max_tx_bytes and the maximum size
+//     of the data of a block;
+//   - unless req.Stateless, CheckTx of the application, and the gas wanted
+//     against the maximum gas of a block.
+//
+// Unlike in the mempool, transactions which were already seen aren't
+// rejected, and the application checks the transaction against the state of
+// its mempool connection, so the transaction may still be rejected later on.
+func (napi *nodeAPI) CheckTx(ctx context.Context, req *RequestCheckTx) (*ResponseCheckTx, error) {
+	env := napi.env
+	tx := types.Tx(req.Tx)
+	res := &ResponseCheckTx{Hash: tx.Hash()}
+
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if env.MempoolConfig != nil && len(tx) > env.MempoolConfig.MaxTxBytes {
+		res.Error = mempl.ErrTxTooLarge{Max: env.MempoolConfig.MaxTxBytes, Actual: len(tx)}.Error()
+		return res, nil
+	}
+	if err := sm.TxPreCheck(state)(tx); err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+	if req.Stateless {
+		return res, nil
+	}
+
+	checkRes, err := env.CheckTx(&rpctypes.Context{}, tx)
+	if err != nil {
+		return nil, err
+	}
+	res.CheckTx = &checkRes.ResponseCheckTx
+	if !checkRes.IsOK() {
+		return res, nil
+	}
+	if err := sm.TxPostCheck(state)(tx, res.CheckTx); err != nil {
+		res.Error = err.Error()
+	}
+	return res, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
 	cmtnet "github.com/cometbft/cometbft/libs/net"
 	"github.com/cometbft/cometbft/rpc/core"
@@ -15,12 +16,16 @@ type Config struct {
 	MaxOpenConnections int
 }
 
-// StartGRPCServer starts a new gRPC BroadcastAPIServer using the given
-// net.Listener.
+// StartGRPCServer starts a new gRPC server, serving BroadcastAPI, NodeAPI,
+// FirehoseAPI and server reflection, using the given net.Listener. Server options, e.g.
+// TLS credentials, are passed through to grpc.NewServer.
 // NOTE: This function blocks - you may want to call it in a go-routine.
-func StartGRPCServer(env *core.Environment, ln net.Listener) error {
-	grpcServer := grpc.NewServer()
+func StartGRPCServer(env *core.Environment, ln net.Listener, opts ...grpc.ServerOption) error {
+	grpcServer := grpc.NewServer(opts...)
 	RegisterBroadcastAPIServer(grpcServer, &broadcastAPI{env: env})
+	RegisterNodeAPIServer(grpcServer, &nodeAPI{env: env})
+	RegisterFirehoseAPIServer(grpcServer, &firehoseAPI{env: env})
+	reflection.Register(grpcServer)
 	return grpcServer.Serve(ln)
 }
 
@@ -35,6 +40,36 @@ func StartGRPCClient(protoAddr string) BroadcastAPIClient {
 	return NewBroadcastAPIClient(conn)
 }
 
+// StartGRPCNodeClient dials the gRPC server using protoAddr and returns a new
+// NodeAPIClient. Without options, an insecure connection is used.
+func StartGRPCNodeClient(protoAddr string, opts ...grpc.DialOption) NodeAPIClient {
+	if len(opts) == 0 {
+		//nolint: staticcheck // SA1019 Existing use of deprecated but supported dial option.
+		opts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+	opts = append(opts, grpc.WithContextDialer(dialerFunc))
+	conn, err := grpc.Dial(protoAddr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return NewNodeAPIClient(conn)
+}
+
+// StartGRPCFirehoseClient dials the gRPC server using protoAddr and returns a
+// new FirehoseAPIClient. Without options, an insecure connection is used.
+func StartGRPCFirehoseClient(protoAddr string, opts ...grpc.DialOption) FirehoseAPIClient {
+	if len(opts) == 0 {
+		//nolint: staticcheck // SA1019 Existing use of deprecated but supported dial option.
+		opts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+	opts = append(opts, grpc.WithContextDialer(dialerFunc))
+	conn, err := grpc.Dial(protoAddr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return NewFirehoseAPIClient(conn)
+}
+
 func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
 	return cmtnet.Connect(addr)
 }
@@ -0,0 +1,223 @@
+package coregrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/peer"
+
+	cm "github.com/cometbft/cometbft/consensus"
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	core "github.com/cometbft/cometbft/rpc/core"
+	"github.com/cometbft/cometbft/types"
+)
+
+// DumpConsensusState returns the round state of the node and of its peers,
+// as the dump_consensus_state JSON-RPC route does, but structured.
+func (napi *nodeAPI) DumpConsensusState(
+	ctx context.Context,
+	req *RequestDumpConsensusState,
+) (*ResponseDumpConsensusState, error) {
+	cs := napi.env.ConsensusState
+	rs, err := roundStateToProto(cs.GetRoundState(), cs.GetState().ConsensusParams.Encoding.Canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := napi.env.P2PPeers.Peers().List()
+	peerStates := make([]*PeerRoundState, 0, len(peers))
+	for _, p := range peers {
+		peerState, ok := p.Get(types.PeerStateKey).(*cm.PeerState)
+		if !ok { // peer does not have a state yet
+			continue
+		}
+		prs := peerRoundStateToProto(peerState.GetRoundState())
+		prs.NodeID = string(p.ID())
+		prs.NodeAddress = p.SocketAddr().String()
+		peerStates = append(peerStates, prs)
+	}
+
+	return &ResponseDumpConsensusState{RoundState: rs, Peers: peerStates}, nil
+}
+
+// StreamRoundState sends the current round state of the node, with an empty
+// event, then the round state after each new step, complete proposal and, if
+// req.IncludeVotes, vote, until the client goes away or the node shuts down.
+//
+// The changes are notified through the event bus, so the same subscription
+// limits as for Subscribe apply. The round state is read when the event is
+// received, so it may already reflect later changes.
+func (napi *nodeAPI) StreamRoundState(req *RequestStreamRoundState, stream NodeAPI_StreamRoundStateServer) error {
+	env := napi.env
+	ctx := stream.Context()
+
+	addr := "grpc"
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	q := eventTypesQuery{types.EventNewRoundStep, types.EventCompleteProposal}
+	if req.IncludeVotes {
+		q = append(q, types.EventVote)
+	}
+
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
+		return fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, core.SubscribeTimeout)
+	defer cancel()
+	sub, err := env.EventBus.Subscribe(subCtx, addr, q, env.Config.SubscriptionBufferSize)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := env.EventBus.Unsubscribe(context.Background(), addr, q); err != nil &&
+			err != cmtpubsub.ErrSubscriptionNotFound {
+			env.Logger.Error("Failed to unsubscribe gRPC client", "remote", addr, "query", q.String(), "err", err)
+		}
+	}()
+
+	send := func(event string) error {
+		cs := env.ConsensusState
+		rs, err := roundStateToProto(cs.GetRoundState(), cs.GetState().ConsensusParams.Encoding.Canonical)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&ResponseStreamRoundState{Event: event, RoundState: rs})
+	}
+	if err := send(""); err != nil {
+		return err
+	}
+	for {
+		select {
+		case msg := <-sub.Out():
+			var event string
+			if events := msg.Events()[types.EventTypeKey]; len(events) > 0 {
+				event = events[0]
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		case <-sub.Canceled():
+			if sub.Err() == nil {
+				return errors.New("subscription was canceled (reason: CometBFT exited)")
+			}
+			return fmt.Errorf("subscription was canceled (reason: %s)", sub.Err())
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// eventTypesQuery matches the events of any of the given types, which the
+// query language can't express.
+type eventTypesQuery []string
+
+var _ cmtpubsub.Query = eventTypesQuery(nil)
+
+func (q eventTypesQuery) Matches(events map[string][]string) (bool, error) {
+	for _, eventType := range events[types.EventTypeKey] {
+		for _, t := range q {
+			if eventType == t {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (q eventTypesQuery) String() string {
+	conds := make([]string, len(q))
+	for i, t := range q {
+		conds[i] = fmt.Sprintf("%s = '%s'", types.EventTypeKey, t)
+	}
+	return strings.Join(conds, " OR ")
+}
+
+// roundStateToProto converts the round state, hashing its blocks with the
+// given canonical encoding.
+func roundStateToProto(rs *cstypes.RoundState, encoding types.CanonicalEncoding) (*RoundState, error) {
+	validators, err := rs.Validators.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	var proposal *cmtproto.Proposal
+	if rs.Proposal != nil {
+		proposal = rs.Proposal.ToProto()
+	}
+
+	partsHeader := rs.ProposalBlockParts.Header()
+	pb := &RoundState{
+		Height:                    rs.Height,
+		Round:                     rs.Round,
+		Step:                      RoundStep(rs.Step),
+		StartTime:                 rs.StartTime,
+		CommitTime:                rs.CommitTime,
+		Validators:                validators,
+		Proposal:                  proposal,
+		ProposalBlockHash:         rs.ProposalBlock.Hash(encoding),
+		ProposalBlockPartsHeader:  partsHeader.ToProto(),
+		LockedRound:               rs.LockedRound,
+		LockedBlockHash:           rs.LockedBlock.Hash(encoding),
+		ValidRound:                rs.ValidRound,
+		ValidBlockHash:            rs.ValidBlock.Hash(encoding),
+		CommitRound:               rs.CommitRound,
+		LastCommit:                rs.LastCommit.BitArray().ToProto(),
+		TriggeredTimeoutPrecommit: rs.TriggeredTimeoutPrecommit,
+	}
+	if rs.ProposalBlockParts != nil {
+		pb.ProposalBlockParts = rs.ProposalBlockParts.BitArray().ToProto()
+	}
+	if rs.Votes != nil {
+		for round := int32(0); round <= rs.Votes.Round(); round++ {
+			pb.Votes = append(pb.Votes, &RoundVotes{
+				Round:           round,
+				Prevotes:        rs.Votes.Prevotes(round).BitArray().ToProto(),
+				Precommits:      rs.Votes.Precommits(round).BitArray().ToProto(),
+				PrevotesMaj23:   maj23ToProto(rs.Votes.Prevotes(round)),
+				PrecommitsMaj23: maj23ToProto(rs.Votes.Precommits(round)),
+			})
+		}
+	}
+	return pb, nil
+}
+
+// maj23ToProto returns the block ID which got +2/3 of the votes, if any.
+func maj23ToProto(voteSet *types.VoteSet) *cmtproto.BlockID {
+	if voteSet == nil {
+		return nil
+	}
+	blockID, ok := voteSet.TwoThirdsMajority()
+	if !ok {
+		return nil
+	}
+	pb := blockID.ToProto()
+	return &pb
+}
+
+func peerRoundStateToProto(prs *cstypes.PeerRoundState) *PeerRoundState {
+	return &PeerRoundState{
+		Height:                   prs.Height,
+		Round:                    prs.Round,
+		Step:                     RoundStep(prs.Step),
+		StartTime:                prs.StartTime,
+		Proposal:                 prs.Proposal,
+		ProposalBlockPartsHeader: prs.ProposalBlockPartSetHeader.ToProto(),
+		ProposalBlockParts:       prs.ProposalBlockParts.ToProto(),
+		ProposalPolRound:         prs.ProposalPOLRound,
+		ProposalPol:              prs.ProposalPOL.ToProto(),
+		Prevotes:                 prs.Prevotes.ToProto(),
+		Precommits:               prs.Precommits.ToProto(),
+		LastCommitRound:          prs.LastCommitRound,
+		LastCommit:               prs.LastCommit.ToProto(),
+		CatchupCommitRound:       prs.CatchupCommitRound,
+		CatchupCommit:            prs.CatchupCommit.ToProto(),
+	}
+}
@@ -0,0 +1,152 @@
+package coregrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/peer"
+
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	core "github.com/cometbft/cometbft/rpc/core"
+	"github.com/cometbft/cometbft/types"
+)
+
+// firehoseAPI implements FirehoseAPIServer.
+type firehoseAPI struct {
+	env *core.Environment
+}
+
+// StreamBlocks streams the committed blocks, with their results, starting
+// from req.StartHeight: it first catches up from the store, then follows the
+// head of the chain until the client goes away or the node shuts down.
+//
+// The new blocks are notified through the event bus, so the same
+// subscription limits as for Subscribe apply. A client which is slower than
+// the chain by more than the subscription buffer size is disconnected, and
+// must resume from the height of the last block it received.
+func (fapi *firehoseAPI) StreamBlocks(req *RequestStreamBlocks, stream FirehoseAPI_StreamBlocksServer) error {
+	env := fapi.env
+	ctx := stream.Context()
+
+	head, err := fapi.headHeight()
+	if err != nil {
+		return err
+	}
+	next := req.StartHeight
+	switch {
+	case next < 0:
+		return fmt.Errorf("start height must be non-negative, got %d", next)
+	case next == 0:
+		next = head + 1
+	case next < env.BlockStore.Base():
+		return fmt.Errorf("start height %d is not available, lowest height is %d", next, env.BlockStore.Base())
+	}
+
+	addr := "grpc"
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	var sub types.Subscription
+	for {
+		// catch up from the store
+		for ; next <= head; next++ {
+			res, err := fapi.loadBlock(next)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return nil
+			}
+		}
+
+		if sub == nil {
+			// Subscribe only once caught up, so that a long catch up doesn't
+			// overflow the subscription, then catch up with the blocks
+			// committed in the meantime.
+			sub, err = fapi.subscribe(ctx, addr)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := env.EventBus.Unsubscribe(context.Background(), addr, types.EventQueryNewBlock); err != nil &&
+					err != cmtpubsub.ErrSubscriptionNotFound {
+					env.Logger.Error("Failed to unsubscribe gRPC client", "remote", addr, "err", err)
+				}
+			}()
+		} else {
+			select {
+			case <-sub.Out():
+			case <-sub.Canceled():
+				if sub.Err() == nil {
+					return errors.New("subscription was canceled (reason: CometBFT exited)")
+				}
+				return fmt.Errorf("subscription was canceled (reason: %s)", sub.Err())
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		if head, err = fapi.headHeight(); err != nil {
+			return err
+		}
+	}
+}
+
+func (fapi *firehoseAPI) subscribe(ctx context.Context, addr string) (types.Subscription, error) {
+	env := fapi.env
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
+		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, core.SubscribeTimeout)
+	defer cancel()
+	return env.EventBus.Subscribe(subCtx, addr, types.EventQueryNewBlock, env.Config.SubscriptionBufferSize)
+}
+
+// headHeight returns the height of the last block whose results were saved.
+func (fapi *firehoseAPI) headHeight() (int64, error) {
+	state, err := fapi.env.StateStore.Load()
+	if err != nil {
+		return 0, err
+	}
+	return state.LastBlockHeight, nil
+}
+
+func (fapi *firehoseAPI) loadBlock(height int64) (*ResponseStreamBlocks, error) {
+	env := fapi.env
+	block := env.BlockStore.LoadBlock(height)
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if block == nil || blockMeta == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	pbBlock, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	blockID := blockMeta.BlockID.ToProto()
+
+	results, err := env.StateStore.LoadABCIResponses(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the results of block %d: %w", height, err)
+	}
+
+	return &ResponseStreamBlocks{
+		BlockID: &blockID,
+		Block:   pbBlock,
+		Results: &ResponseBlockResults{
+			Height:                height,
+			TxsResults:            results.DeliverTxs,
+			BeginBlockEvents:      eventPtrs(results.BeginBlock.Events),
+			EndBlockEvents:        eventPtrs(results.EndBlock.Events),
+			ValidatorUpdates:      validatorUpdatePtrs(results.EndBlock.ValidatorUpdates),
+			ConsensusParamUpdates: results.EndBlock.ConsensusParamUpdates,
+		},
+	}, nil
+}
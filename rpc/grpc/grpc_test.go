@@ -2,14 +2,18 @@ package coregrpc_test
 
 import (
 	"context"
+	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/abci/example/kvstore"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	core_grpc "github.com/cometbft/cometbft/rpc/grpc"
 	rpctest "github.com/cometbft/cometbft/rpc/test"
+	"github.com/cometbft/cometbft/types"
 )
 
 func TestMain(m *testing.M) {
@@ -33,3 +37,205 @@ func TestBroadcastTx(t *testing.T) {
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
 }
+
+func TestNodeAPIStatus(t *testing.T) {
+	res, err := rpctest.GetGRPCNodeClient().Status(context.Background(), &core_grpc.RequestStatus{})
+	require.NoError(t, err)
+	require.NotNil(t, res.NodeInfo)
+	require.NotNil(t, res.SyncInfo)
+	require.NotNil(t, res.ValidatorInfo.PubKey)
+	require.EqualValues(t, 10, res.ValidatorInfo.VotingPower)
+}
+
+func TestNodeAPIBroadcastTxAndBlock(t *testing.T) {
+	client := rpctest.GetGRPCNodeClient()
+	ctx := context.Background()
+
+	res, err := client.BroadcastTx(ctx, &core_grpc.RequestBroadcastTxWithMode{
+		Tx:   []byte("grpc=node_api"),
+		Mode: core_grpc.BROADCAST_MODE_COMMIT,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, res.CheckTx.Code)
+	require.EqualValues(t, 0, res.DeliverTx.Code)
+	require.NotZero(t, res.Height)
+
+	block, err := client.Block(ctx, &core_grpc.RequestBlock{Height: res.Height})
+	require.NoError(t, err)
+	require.EqualValues(t, res.Height, block.Block.Header.Height)
+	require.Len(t, block.Block.Data.Txs, 1)
+
+	results, err := client.BlockResults(ctx, &core_grpc.RequestBlockResults{Height: res.Height})
+	require.NoError(t, err)
+	require.Len(t, results.TxsResults, 1)
+
+	tx, err := client.Tx(ctx, &core_grpc.RequestTx{Hash: res.Hash, Prove: true})
+	require.NoError(t, err)
+	require.EqualValues(t, res.Height, tx.Height)
+	require.NotNil(t, tx.Proof)
+
+	vals, err := client.Validators(ctx, &core_grpc.RequestValidators{Height: res.Height})
+	require.NoError(t, err)
+	require.Len(t, vals.Validators, 1)
+	require.EqualValues(t, 1, vals.Total)
+
+	_, err = client.BroadcastTx(ctx, &core_grpc.RequestBroadcastTxWithMode{
+		Tx:   []byte("grpc=bad_mode"),
+		Mode: core_grpc.BroadcastMode(42),
+	})
+	require.Error(t, err)
+}
+
+func TestNodeAPISubscribe(t *testing.T) {
+	client := rpctest.GetGRPCNodeClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Subscribe(ctx, &core_grpc.RequestSubscribe{Query: "tm.event = 'NewBlock'"})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "tm.event = 'NewBlock'", res.Query)
+	require.NotEmpty(t, res.Data)
+	require.NotEmpty(t, res.Events)
+}
+
+func TestFirehoseAPIStreamBlocks(t *testing.T) {
+	client := rpctest.GetGRPCFirehoseClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// wait for a few blocks to be committed, so that the stream starts by
+	// catching up from the store, and then follows new blocks
+	status, err := rpctest.GetGRPCNodeClient().Status(ctx, &core_grpc.RequestStatus{})
+	require.NoError(t, err)
+	head := status.SyncInfo.LatestBlockHeight
+
+	stream, err := client.StreamBlocks(ctx, &core_grpc.RequestStreamBlocks{StartHeight: 1})
+	require.NoError(t, err)
+
+	for height := int64(1); height <= head+2; height++ {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, height, res.Block.Header.Height)
+		require.Equal(t, height, res.Results.Height)
+		require.NotNil(t, res.BlockID)
+	}
+
+	stream, err = client.StreamBlocks(ctx, &core_grpc.RequestStreamBlocks{StartHeight: -1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Error(t, err)
+}
+
+func TestNodeAPIBroadcastTxNotify(t *testing.T) {
+	client := rpctest.GetGRPCNodeClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.BroadcastTxNotify(ctx, &core_grpc.RequestBroadcastTx{Tx: []byte("grpc=notify")})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.EqualValues(t, 0, res.CheckTx.Code)
+	require.Nil(t, res.DeliverTx)
+	hash := res.Hash
+
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, hash, res.Hash)
+	require.EqualValues(t, 0, res.DeliverTx.Code)
+	require.NotZero(t, res.Height)
+
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestNodeAPIHashToCurve(t *testing.T) {
+	res, err := rpctest.GetGRPCNodeClient().HashToCurve(context.Background(),
+		&core_grpc.RequestHashToCurve{Msg: []byte("hello")})
+	require.NoError(t, err)
+
+	point, nonce := bn254.HashToCurve([]byte("hello"))
+	raw := point.RawBytes()
+	require.Equal(t, bn254.HashToCurveDST, res.Dst)
+	require.Equal(t, nonce, res.Nonce)
+	require.Equal(t, raw[:], res.Point)
+	require.EqualValues(t, res.PointCompressed[0]&0xC0, res.CompressionMask)
+}
+
+func TestNodeAPICheckTx(t *testing.T) {
+	ctx := context.Background()
+	client := rpctest.GetGRPCNodeClient()
+	tx := []byte("grpc=checktx")
+
+	res, err := client.CheckTx(ctx, &core_grpc.RequestCheckTx{Tx: tx})
+	require.NoError(t, err)
+	require.EqualValues(t, types.Tx(tx).Hash(), res.Hash)
+	require.NotNil(t, res.CheckTx)
+	require.True(t, res.CheckTx.IsOK())
+	require.Empty(t, res.Error)
+
+	// the tx was not added to the mempool (nor to its cache)
+	bres, err := client.BroadcastTx(ctx, &core_grpc.RequestBroadcastTxWithMode{
+		Tx:   tx,
+		Mode: core_grpc.BROADCAST_MODE_SYNC,
+	})
+	require.NoError(t, err)
+	require.True(t, bres.CheckTx.IsOK())
+
+	// rejected by the app, unless stateless
+	res, err = client.CheckTx(ctx, &core_grpc.RequestCheckTx{})
+	require.NoError(t, err)
+	require.NotNil(t, res.CheckTx)
+	require.False(t, res.CheckTx.IsOK())
+	res, err = client.CheckTx(ctx, &core_grpc.RequestCheckTx{Stateless: true})
+	require.NoError(t, err)
+	require.Nil(t, res.CheckTx)
+	require.Empty(t, res.Error)
+
+	// rejected by the node
+	tooLarge := make([]byte, rpctest.GetConfig().Mempool.MaxTxBytes+1)
+	res, err = client.CheckTx(ctx, &core_grpc.RequestCheckTx{Tx: tooLarge, Stateless: true})
+	require.NoError(t, err)
+	require.Nil(t, res.CheckTx)
+	require.NotEmpty(t, res.Error)
+}
+
+func TestNodeAPIDumpConsensusState(t *testing.T) {
+	res, err := rpctest.GetGRPCNodeClient().DumpConsensusState(context.Background(),
+		&core_grpc.RequestDumpConsensusState{})
+	require.NoError(t, err)
+	require.NotNil(t, res.RoundState)
+	require.NotZero(t, res.RoundState.Height)
+	require.NotEqual(t, core_grpc.ROUND_STEP_UNKNOWN, res.RoundState.Step)
+	require.Len(t, res.RoundState.Validators.Validators, 1)
+	require.NotEmpty(t, res.RoundState.Votes)
+	require.Empty(t, res.Peers)
+}
+
+func TestNodeAPIStreamRoundState(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := rpctest.GetGRPCNodeClient().StreamRoundState(ctx,
+		&core_grpc.RequestStreamRoundState{IncludeVotes: true})
+	require.NoError(t, err)
+
+	// the current round state comes first
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Empty(t, res.Event)
+	height := res.RoundState.Height
+
+	// then the round state after the next steps and votes
+	events := make(map[string]bool)
+	for !events[types.EventNewRoundStep] || !events[types.EventVote] {
+		res, err = stream.Recv()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, res.RoundState.Height, height)
+		events[res.Event] = true
+	}
+}
@@ -0,0 +1,343 @@
+package coregrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/grpc/peer"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	core "github.com/cometbft/cometbft/rpc/core"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// maxQueryLength is the maximum length of a subscription query.
+const maxQueryLength = 512
+
+// nodeAPI implements NodeAPIServer on top of the same environment as the
+// JSON-RPC routes.
+type nodeAPI struct {
+	env *core.Environment
+}
+
+func (napi *nodeAPI) Status(ctx context.Context, req *RequestStatus) (*ResponseStatus, error) {
+	res, err := napi.env.Status(&rpctypes.Context{})
+	if err != nil {
+		return nil, err
+	}
+
+	var pubKey *cmtcrypto.PublicKey
+	if res.ValidatorInfo.PubKey != nil {
+		pk, err := cryptoenc.PubKeyToProto(res.ValidatorInfo.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		pubKey = &pk
+	}
+
+	return &ResponseStatus{
+		NodeInfo: res.NodeInfo.ToProto(),
+		SyncInfo: &SyncInfo{
+			LatestBlockHash:     res.SyncInfo.LatestBlockHash,
+			LatestAppHash:       res.SyncInfo.LatestAppHash,
+			LatestBlockHeight:   res.SyncInfo.LatestBlockHeight,
+			LatestBlockTime:     res.SyncInfo.LatestBlockTime,
+			EarliestBlockHash:   res.SyncInfo.EarliestBlockHash,
+			EarliestAppHash:     res.SyncInfo.EarliestAppHash,
+			EarliestBlockHeight: res.SyncInfo.EarliestBlockHeight,
+			EarliestBlockTime:   res.SyncInfo.EarliestBlockTime,
+			CatchingUp:          res.SyncInfo.CatchingUp,
+		},
+		ValidatorInfo: &ValidatorInfo{
+			Address:     res.ValidatorInfo.Address,
+			PubKey:      pubKey,
+			VotingPower: res.ValidatorInfo.VotingPower,
+		},
+	}, nil
+}
+
+func (napi *nodeAPI) Block(ctx context.Context, req *RequestBlock) (*ResponseBlock, error) {
+	res, err := napi.env.Block(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, err
+	}
+	if res.Block == nil {
+		return nil, fmt.Errorf("block at height %d not found", req.Height)
+	}
+
+	block, err := res.Block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	blockID := res.BlockID.ToProto()
+	return &ResponseBlock{BlockID: &blockID, Block: block}, nil
+}
+
+func (napi *nodeAPI) BlockResults(ctx context.Context, req *RequestBlockResults) (*ResponseBlockResults, error) {
+	res, err := napi.env.BlockResults(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResponseBlockResults{
+		Height:                res.Height,
+		TxsResults:            res.TxsResults,
+		BeginBlockEvents:      eventPtrs(res.BeginBlockEvents),
+		EndBlockEvents:        eventPtrs(res.EndBlockEvents),
+		ValidatorUpdates:      validatorUpdatePtrs(res.ValidatorUpdates),
+		ConsensusParamUpdates: res.ConsensusParamUpdates,
+	}, nil
+}
+
+func (napi *nodeAPI) Tx(ctx context.Context, req *RequestTx) (*ResponseTx, error) {
+	res, err := napi.env.Tx(&rpctypes.Context{}, req.Hash, req.Prove)
+	if err != nil {
+		return nil, err
+	}
+
+	resTx := &ResponseTx{
+		Hash:     res.Hash,
+		Height:   res.Height,
+		Index:    res.Index,
+		TxResult: &res.TxResult,
+		Tx:       res.Tx,
+	}
+	if req.Prove {
+		proof := res.Proof.ToProto()
+		resTx.Proof = &proof
+	}
+	return resTx, nil
+}
+
+func (napi *nodeAPI) Validators(ctx context.Context, req *RequestValidators) (*ResponseValidators, error) {
+	var pagePtr, perPagePtr *int
+	if req.Page > 0 {
+		page := int(req.Page)
+		pagePtr = &page
+	}
+	if req.PerPage > 0 {
+		perPage := int(req.PerPage)
+		perPagePtr = &perPage
+	}
+
+	res, err := napi.env.Validators(&rpctypes.Context{}, heightPtr(req.Height), pagePtr, perPagePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	vals := make([]*cmtproto.Validator, len(res.Validators))
+	for i, v := range res.Validators {
+		if vals[i], err = v.ToProto(); err != nil {
+			return nil, err
+		}
+	}
+	return &ResponseValidators{
+		BlockHeight: res.BlockHeight,
+		Validators:  vals,
+		Count:       int32(res.Count),
+		Total:       int32(res.Total),
+	}, nil
+}
+
+func (napi *nodeAPI) HashToCurve(ctx context.Context, req *RequestHashToCurve) (*ResponseHashToCurve, error) {
+	res, err := napi.env.HashToCurve(&rpctypes.Context{}, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseHashToCurve{
+		Dst:             res.DST,
+		Nonce:           res.Nonce,
+		Point:           res.Point,
+		PointCompressed: res.PointCompressed,
+		CompressionMask: uint32(res.CompressionMask),
+		X0:              res.X0,
+		X1:              res.X1,
+		Y0:              res.Y0,
+		Y1:              res.Y1,
+	}, nil
+}
+
+func (napi *nodeAPI) BroadcastTx(
+	ctx context.Context,
+	req *RequestBroadcastTxWithMode,
+) (*ResponseBroadcastTxWithMode, error) {
+	switch req.Mode {
+	case BROADCAST_MODE_ASYNC:
+		res, err := napi.env.BroadcastTxAsync(&rpctypes.Context{}, req.Tx)
+		if err != nil {
+			return nil, err
+		}
+		return &ResponseBroadcastTxWithMode{Hash: res.Hash}, nil
+
+	case BROADCAST_MODE_SYNC:
+		res, err := napi.env.BroadcastTxSync(&rpctypes.Context{}, req.Tx)
+		if err != nil {
+			return nil, err
+		}
+		return &ResponseBroadcastTxWithMode{
+			Hash: res.Hash,
+			CheckTx: &abci.ResponseCheckTx{
+				Code:      res.Code,
+				Data:      res.Data,
+				Log:       res.Log,
+				Codespace: res.Codespace,
+			},
+		}, nil
+
+	case BROADCAST_MODE_COMMIT:
+		res, err := napi.env.BroadcastTxCommit(&rpctypes.Context{}, req.Tx)
+		if err != nil {
+			return nil, err
+		}
+		return &ResponseBroadcastTxWithMode{
+			Hash:      res.Hash,
+			CheckTx:   &res.CheckTx,
+			DeliverTx: &res.DeliverTx,
+			Height:    res.Height,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown broadcast mode %v", req.Mode)
+	}
+}
+
+// BroadcastTxNotify sends the response from CheckTx right away, then, if it
+// passed, the response from DeliverTx once the transaction is included in a
+// block. It fails if the transaction isn't included within
+// timeout_broadcast_tx_commit.
+func (napi *nodeAPI) BroadcastTxNotify(req *RequestBroadcastTx, stream NodeAPI_BroadcastTxNotifyServer) error {
+	ctx := stream.Context()
+
+	addr := "grpc"
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	var sendErr error
+	res, err := napi.env.BroadcastTxCommitNotify(ctx, addr, req.Tx, func(checkTx *abci.ResponseCheckTx) {
+		sendErr = stream.Send(&ResponseBroadcastTxWithMode{
+			Hash:    types.Tx(req.Tx).Hash(),
+			CheckTx: checkTx,
+		})
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return err
+	}
+	if res.CheckTx.Code != abci.CodeTypeOK {
+		return nil
+	}
+	return stream.Send(&ResponseBroadcastTxWithMode{
+		Hash:      res.Hash,
+		DeliverTx: &res.DeliverTx,
+		Height:    res.Height,
+	})
+}
+
+// Subscribe streams the events matching the query until the client goes away
+// or the node shuts down. The same subscription limits as for the JSON-RPC
+// websocket apply.
+func (napi *nodeAPI) Subscribe(req *RequestSubscribe, stream NodeAPI_SubscribeServer) error {
+	env := napi.env
+	ctx := stream.Context()
+
+	addr := "grpc"
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
+		return fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	} else if len(req.Query) > maxQueryLength {
+		return errors.New("maximum query length exceeded")
+	}
+
+	q, err := cmtquery.New(req.Query)
+	if err != nil {
+		return fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, core.SubscribeTimeout)
+	defer cancel()
+	sub, err := env.EventBus.Subscribe(subCtx, addr, q, env.Config.SubscriptionBufferSize)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := env.EventBus.Unsubscribe(context.Background(), addr, q); err != nil &&
+			err != cmtpubsub.ErrSubscriptionNotFound {
+			env.Logger.Error("Failed to unsubscribe gRPC client", "remote", addr, "query", req.Query, "err", err)
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			data, err := cmtjson.Marshal(msg.Data())
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(&ResponseSubscribe{
+				Query:  req.Query,
+				Data:   data,
+				Events: eventAttributes(msg.Events()),
+			}); err != nil {
+				return err
+			}
+		case <-sub.Canceled():
+			if sub.Err() == nil {
+				return errors.New("subscription was canceled (reason: CometBFT exited)")
+			}
+			return fmt.Errorf("subscription was canceled (reason: %s)", sub.Err())
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// heightPtr maps a zero height to nil, i.e. the latest height.
+func heightPtr(height int64) *int64 {
+	if height == 0 {
+		return nil
+	}
+	return &height
+}
+
+func eventPtrs(events []abci.Event) []*abci.Event {
+	ptrs := make([]*abci.Event, len(events))
+	for i := range events {
+		ptrs[i] = &events[i]
+	}
+	return ptrs
+}
+
+func validatorUpdatePtrs(updates []abci.ValidatorUpdate) []*abci.ValidatorUpdate {
+	ptrs := make([]*abci.ValidatorUpdate, len(updates))
+	for i := range updates {
+		ptrs[i] = &updates[i]
+	}
+	return ptrs
+}
+
+// eventAttributes converts the events map, sorting it by key.
+func eventAttributes(events map[string][]string) []*EventAttributes {
+	attrs := make([]*EventAttributes, 0, len(events))
+	for k, v := range events {
+		attrs = append(attrs, &EventAttributes{Key: k, Values: v})
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	return attrs
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+type contextKey int
+
+const (
+	clientIDContextKey contextKey = iota
+	rateLimiterContextKey
+)
+
+// ErrUnauthorized is returned when a client presents no or invalid credentials.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// Authenticator authenticates the clients of the RPC server, which present
+// either an API key or a JWT signed with HS256 in the Authorization header
+// ("Authorization: Bearer <token>").
+type Authenticator struct {
+	// sha256 of the API key -> client ID
+	apiKeys   map[[sha256.Size]byte]string
+	jwtSecret []byte
+	now       func() time.Time
+}
+
+// NewAuthenticator returns an authenticator accepting the given API keys, and
+// the JWTs signed with jwtSecret if it isn't empty.
+func NewAuthenticator(apiKeys []string, jwtSecret []byte) *Authenticator {
+	a := &Authenticator{
+		apiKeys:   make(map[[sha256.Size]byte]string, len(apiKeys)),
+		jwtSecret: jwtSecret,
+		now:       time.Now,
+	}
+	for _, key := range apiKeys {
+		h := sha256.Sum256([]byte(key))
+		// identify the client without leaking its key in the logs
+		a.apiKeys[h] = "apikey:" + hex.EncodeToString(h[:4])
+	}
+	return a
+}
+
+// Authenticate returns the ID of the client making the request: the subject of
+// its JWT, or an ID derived from its API key.
+func (a *Authenticator) Authenticate(r *http.Request) (string, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", ErrUnauthorized
+	}
+
+	h := sha256.Sum256([]byte(token))
+	for keyHash, clientID := range a.apiKeys {
+		if subtle.ConstantTimeCompare(h[:], keyHash[:]) == 1 {
+			return clientID, nil
+		}
+	}
+
+	if len(a.jwtSecret) > 0 && strings.Count(token, ".") == 2 {
+		sub, err := a.verifyJWT(token)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrUnauthorized, err)
+		}
+		return "jwt:" + sub, nil
+	}
+	return "", ErrUnauthorized
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Sub string `json:"sub"`
+	Exp *int64 `json:"exp"`
+	Nbf *int64 `json:"nbf"`
+}
+
+// verifyJWT verifies the signature and validity period of an HS256 JWT, and
+// returns its subject.
+func (a *Authenticator) verifyJWT(token string) (string, error) {
+	parts := strings.Split(token, ".")
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return "", fmt.Errorf("invalid JWT header: %w", err)
+	}
+	if header.Alg != "HS256" {
+		return "", fmt.Errorf("unsupported JWT algorithm %q", header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid JWT signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, a.jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return "", errors.New("invalid JWT signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return "", fmt.Errorf("invalid JWT claims: %w", err)
+	}
+	now := a.now().Unix()
+	if claims.Exp != nil && now >= *claims.Exp {
+		return "", errors.New("JWT expired")
+	}
+	if claims.Nbf != nil && now < *claims.Nbf {
+		return "", errors.New("JWT not valid yet")
+	}
+	if claims.Sub == "" {
+		return "", errors.New("JWT has no subject")
+	}
+	return claims.Sub, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	bz, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, v)
+}
+
+// AuthHandler rejects the requests which can't be authenticated, and attaches
+// the ID of the client to the others, which is then used for rate limiting.
+// The websocket endpoint is authenticated once, when the connection is
+// upgraded.
+func AuthHandler(handler http.Handler, auth *Authenticator, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, err := auth.Authenticate(r)
+		if err != nil {
+			logger.Debug("Rejected unauthenticated request", "remote", r.RemoteAddr, "err", err)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			res := types.RPCInvalidRequestError(nil, err)
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusUnauthorized, res); wErr != nil {
+				logger.Error("failed to write response", "res", res, "err", wErr)
+			}
+			return
+		}
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIDContextKey, clientID)))
+	})
+}
+
+// clientID returns the ID of the authenticated client making the request, or
+// its IP address if authentication is disabled.
+func clientID(r *http.Request) string {
+	if id, ok := r.Context().Value(clientIDContextKey).(string); ok {
+		return id
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
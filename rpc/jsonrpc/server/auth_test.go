@@ -0,0 +1,115 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func testJWT(secret []byte, header, claims string) string {
+	enc := base64.RawURLEncoding
+	payload := enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return payload + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthenticator(t *testing.T) {
+	secret := []byte("secret")
+	auth := NewAuthenticator([]string{"key1", "key2"}, secret)
+	auth.now = func() time.Time { return time.Unix(1000, 0) }
+
+	const hs256 = `{"alg":"HS256","typ":"JWT"}`
+	testCases := []struct {
+		name     string
+		header   string
+		clientID string
+	}{
+		{"no header", "", ""},
+		{"not a bearer token", "Basic a2V5MQ==", ""},
+		{"api key", "Bearer key1", "apikey:"},
+		{"unknown api key", "Bearer key3", ""},
+		{"jwt", "Bearer " + testJWT(secret, hs256, `{"sub":"alice","exp":2000,"nbf":500}`), "jwt:alice"},
+		{"expired jwt", "Bearer " + testJWT(secret, hs256, `{"sub":"alice","exp":1000}`), ""},
+		{"jwt not valid yet", "Bearer " + testJWT(secret, hs256, `{"sub":"alice","nbf":1001}`), ""},
+		{"jwt without subject", "Bearer " + testJWT(secret, hs256, `{}`), ""},
+		{"jwt with wrong secret", "Bearer " + testJWT([]byte("other"), hs256, `{"sub":"alice"}`), ""},
+		{"jwt with none alg", "Bearer " + testJWT(secret, `{"alg":"none"}`, `{"sub":"alice"}`), ""},
+		{"malformed jwt", "Bearer a.b.c", ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			clientID, err := auth.Authenticate(req)
+			if tc.clientID == "" {
+				assert.ErrorIs(t, err, ErrUnauthorized)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(clientID, tc.clientID), clientID)
+		})
+	}
+
+	// different API keys identify different clients
+	id1, err := auth.Authenticate(withBearer("key1"))
+	require.NoError(t, err)
+	id2, err := auth.Authenticate(withBearer("key2"))
+	require.NoError(t, err)
+	assert.NotEqual(t, id1, id2)
+	assert.NotContains(t, id1, "key1")
+}
+
+func withBearer(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc": "2.0", "method": "c", "id": "0", "params": ["a", "10"]}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestAuthHandler(t *testing.T) {
+	handler := AuthHandler(testMux(), NewAuthenticator([]string{"key"}, nil), log.TestingLogger())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/c?s=\"a\"&i=1", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, withBearer("key"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"result":"foo"`)
+
+	// the websocket endpoint is authenticated during the upgrade
+	wm := NewWebsocketManager(testFuncMap())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(AuthHandler(mux, NewAuthenticator([]string{"key"}, nil), log.TestingLogger()))
+	defer s.Close()
+
+	d := websocket.Dialer{}
+	_, dialResp, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, dialResp.StatusCode)
+	dialResp.Body.Close()
+
+	c, dialResp, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket",
+		http.Header{"Authorization": []string{"Bearer key"}})
+	require.NoError(t, err)
+	c.Close()
+	dialResp.Body.Close()
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content codings which can be negotiated with a client, in order of
+// preference.
+const (
+	EncodingZstd = "zstd"
+	EncodingGzip = "gzip"
+)
+
+var (
+	gzipWriterPool = sync.Pool{New: func() interface{} {
+		return gzip.NewWriter(nil)
+	}}
+	zstdWriterPool = sync.Pool{New: func() interface{} {
+		w, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return w
+	}}
+)
+
+// CompressionHandler compresses the responses with zstd or gzip, as
+// negotiated with the client through the Accept-Encoding header. Responses
+// smaller than minSize bytes are sent uncompressed, as compressing them isn't
+// worth the CPU. Websocket connections are never compressed.
+func CompressionHandler(handler http.Handler, minSize int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Header.Get("Upgrade") != "" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressResponseWriter{ResponseWriter: w, encoding: encoding, minSize: minSize}
+		defer cw.Close()
+		handler.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding returns the supported content coding with the highest
+// quality value in the Accept-Encoding header, or "" if the client accepts
+// none of them.
+func negotiateEncoding(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		q := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			var err error
+			if q, err = strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+				continue
+			}
+		}
+		qualities[coding] = q
+	}
+
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, encoding := range []string{EncodingZstd, EncodingGzip} {
+		q, ok := qualities[encoding]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// compressResponseWriter buffers the beginning of the response until it
+// reaches minSize bytes, and compresses it from then on. Smaller responses are
+// sent as is when the writer is closed.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status      int
+	buf         []byte
+	wroteHeader bool
+	w           io.WriteCloser
+}
+
+func (cw *compressResponseWriter) WriteHeader(status int) {
+	if cw.status == 0 {
+		cw.status = status
+	}
+}
+
+func (cw *compressResponseWriter) Write(p []byte) (int, error) {
+	if cw.w != nil {
+		return cw.w.Write(p)
+	}
+	if cw.wroteHeader {
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) < cw.minSize {
+		return len(p), nil
+	}
+
+	h := cw.ResponseWriter.Header()
+	h.Set("Content-Encoding", cw.encoding)
+	h.Del("Content-Length")
+	cw.writeHeader()
+	switch cw.encoding {
+	case EncodingZstd:
+		zw := zstdWriterPool.Get().(*zstd.Encoder)
+		zw.Reset(cw.ResponseWriter)
+		cw.w = zw
+	default:
+		gw := gzipWriterPool.Get().(*gzip.Writer)
+		gw.Reset(cw.ResponseWriter)
+		cw.w = gw
+	}
+	if _, err := cw.w.Write(cw.buf); err != nil {
+		return 0, err
+	}
+	cw.buf = nil
+	return len(p), nil
+}
+
+func (cw *compressResponseWriter) writeHeader() {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+	cw.wroteHeader = true
+}
+
+// Close flushes the response: it either writes the buffered bytes
+// uncompressed, or terminates the compressed stream.
+func (cw *compressResponseWriter) Close() error {
+	if cw.w == nil {
+		if cw.wroteHeader {
+			return nil
+		}
+		if cw.status == 0 && len(cw.buf) == 0 {
+			// nothing was written, leave it to net/http
+			return nil
+		}
+		cw.writeHeader()
+		_, err := cw.ResponseWriter.Write(cw.buf)
+		cw.buf = nil
+		return err
+	}
+
+	err := cw.w.Close()
+	switch w := cw.w.(type) {
+	case *zstd.Encoder:
+		w.Reset(nil)
+		zstdWriterPool.Put(w)
+	case *gzip.Writer:
+		w.Reset(nil)
+		gzipWriterPool.Put(w)
+	}
+	cw.w = nil
+	cw.wroteHeader = true
+	return err
+}
+
+// implements http.Hijacker
+func (cw *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return cw.ResponseWriter.(http.Hijacker).Hijack()
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	testCases := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"br", ""},
+		{"gzip", EncodingGzip},
+		{"gzip, deflate, br, zstd", EncodingZstd},
+		{"GZIP", EncodingGzip},
+		{"zstd;q=0.5, gzip", EncodingGzip},
+		{"zstd;q=0, gzip;q=0", ""},
+		{"*", EncodingZstd},
+		{"*;q=0.1, gzip;q=0.5", EncodingGzip},
+		{"zstd;q=invalid, gzip", EncodingGzip},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, negotiateEncoding(tc.acceptEncoding), tc.acceptEncoding)
+	}
+}
+
+func TestCompressionHandler(t *testing.T) {
+	small := []byte(`{"jsonrpc":"2.0","id":1,"result":{}}`)
+	large := bytes.Repeat([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`), 100)
+
+	handler := CompressionHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTeapot)
+		body := large
+		if r.URL.Path == "/small" {
+			body = small
+		}
+		// written in two chunks, so that the size threshold is crossed in the
+		// middle of the response
+		_, _ = w.Write(body[:len(body)/2])
+		_, _ = w.Write(body[len(body)/2:])
+	}), 1024)
+
+	serve := func(path, acceptEncoding string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	decoders := map[string]func(io.Reader) (io.Reader, error){
+		EncodingGzip: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		EncodingZstd: func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+	}
+	for encoding, decode := range decoders {
+		res := serve("/large", encoding)
+		assert.Equal(t, http.StatusTeapot, res.StatusCode)
+		assert.Equal(t, encoding, res.Header.Get("Content-Encoding"))
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		assert.Equal(t, "Accept-Encoding", res.Header.Get("Vary"))
+		r, err := decode(res.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, large, body, encoding)
+
+		// small responses aren't compressed
+		res = serve("/small", encoding)
+		assert.Equal(t, http.StatusTeapot, res.StatusCode)
+		assert.Empty(t, res.Header.Get("Content-Encoding"))
+		body, err = io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, small, body)
+	}
+
+	// the client doesn't accept any supported encoding
+	res := serve("/large", "br")
+	assert.Equal(t, http.StatusTeapot, res.StatusCode)
+	assert.Empty(t, res.Header.Get("Content-Encoding"))
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, large, body)
+}
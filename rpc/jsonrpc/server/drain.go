@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+// ErrShuttingDown is returned for the requests received while the server is
+// draining.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Drainer tracks the requests in flight, so that a server shutting down can
+// stop accepting requests and wait for the ones in flight to complete.
+type Drainer struct {
+	mtx      cmtsync.RWMutex
+	draining bool
+	inFlight sync.WaitGroup
+}
+
+// NewDrainer returns a drainer, which must wrap the handlers with Handler.
+func NewDrainer() *Drainer {
+	return &Drainer{}
+}
+
+// Handler rejects the requests received once draining started, with 503
+// Service Unavailable, and tracks the others. The websocket connections,
+// which last as long as the client wants, are not waited for.
+func (d *Drainer) Handler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.mtx.RLock()
+		if d.draining {
+			d.mtx.RUnlock()
+			w.Header().Set("Connection", "close")
+			res := types.RPCServerError(nil, ErrShuttingDown)
+			_ = WriteRPCResponseHTTPError(w, http.StatusServiceUnavailable, res)
+			return
+		}
+		websocket := r.Header.Get("Upgrade") != ""
+		if !websocket {
+			d.inFlight.Add(1)
+		}
+		d.mtx.RUnlock()
+
+		if !websocket {
+			defer d.inFlight.Done()
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Drain rejects the requests from now on, and waits for the ones in flight to
+// complete, or ctx to be done.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mtx.Lock()
+	d.draining = true
+	d.mtx.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainer(t *testing.T) {
+	d := NewDrainer()
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := d.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(started)
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/"))
+
+	slow := make(chan int)
+	go func() { slow <- serve("/slow") }()
+	<-started
+
+	// the request in flight is waited for
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, d.Drain(ctx), context.DeadlineExceeded)
+
+	// the new requests are rejected
+	assert.Equal(t, http.StatusServiceUnavailable, serve("/"))
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-slow)
+	require.NoError(t, d.Drain(context.Background()))
+}
@@ -3,11 +3,13 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
 	"sort"
+	"sync"
 
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
@@ -17,7 +19,12 @@ import (
 // HTTP + JSON handler
 
 // jsonrpc calls grab the given method's function info and runs reflect.Call
-func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.HandlerFunc {
+func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger, opts ...HandlerOption) http.HandlerFunc {
+	cfg := defaultHandlerConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		b, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -38,10 +45,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 		}
 
 		// first try to unmarshal the incoming request as an array of RPC requests
-		var (
-			requests  []types.RPCRequest
-			responses []types.RPCResponse
-		)
+		var requests []types.RPCRequest
 		if err := json.Unmarshal(b, &requests); err != nil {
 			// next, try to unmarshal as a single request
 			var request types.RPCRequest
@@ -53,65 +57,58 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				return
 			}
 			requests = []types.RPCRequest{request}
+		} else if len(requests) == 0 || (cfg.maxBatchSize > 0 && len(requests) > cfg.maxBatchSize) {
+			err := errors.New("empty batch")
+			if len(requests) > 0 {
+				err = fmt.Errorf("batch of %d requests exceeds the maximum of %d", len(requests), cfg.maxBatchSize)
+			}
+			res := types.RPCInvalidRequestError(nil, err)
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusBadRequest, res); wErr != nil {
+				logger.Error("failed to write response", "res", res, "err", wErr)
+			}
+			return
 		}
 
+		// The requests of a batch are executed in order, except that the
+		// consecutive requests to concurrency-safe functions are executed
+		// concurrently, up to batchConcurrency at a time: a request to any
+		// other function waits for them, e.g. so that the txs broadcast in a
+		// batch are checked in order. The responses are returned in the order
+		// of the requests.
+		var (
+			results = make([]*types.RPCResponse, len(requests))
+			cached  = make([]bool, len(requests))
+			sem     = make(chan struct{}, cfg.batchConcurrency)
+			wg      sync.WaitGroup
+		)
+		for i := range requests {
+			if len(requests) == 1 || !isConcurrencySafe(funcMap, &requests[i]) {
+				wg.Wait()
+				results[i], cached[i] = handleJSONRPCRequest(r, funcMap, &requests[i], logger)
+				continue
+			}
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(i int) {
+				defer func() { <-sem; wg.Done() }()
+				results[i], cached[i] = handleJSONRPCRequest(r, funcMap, &requests[i], logger)
+			}(i)
+		}
+		wg.Wait()
+
 		// Set the default response cache to true unless
 		// 1. Any RPC request error.
 		// 2. Any RPC request doesn't allow to be cached.
 		// 3. Any RPC request has the height argument and the value is 0 (the default).
 		cache := true
-		for _, request := range requests {
-			request := request
-
-			// A Notification is a Request object without an "id" member.
-			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
-			if request.ID == nil {
-				logger.Debug(
-					"HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
-					"req", request,
-				)
-				continue
-			}
-			if len(r.URL.Path) > 1 {
-				responses = append(
-					responses,
-					types.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path)),
-				)
-				cache = false
+		responses := make([]types.RPCResponse, 0, len(results))
+		for i, res := range results {
+			if res == nil {
+				// notification
 				continue
 			}
-			rpcFunc, ok := funcMap[request.Method]
-			if !ok || (rpcFunc.ws) {
-				responses = append(responses, types.RPCMethodNotFoundError(request.ID))
-				cache = false
-				continue
-			}
-			ctx := &types.Context{JSONReq: &request, HTTPReq: r}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
-				if err != nil {
-					responses = append(
-						responses,
-						types.RPCInvalidParamsError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					)
-					cache = false
-					continue
-				}
-				args = append(args, fnArgs...)
-			}
-
-			if cache && !rpcFunc.cacheableWithArgs(args) {
-				cache = false
-			}
-
-			returns := rpcFunc.f.Call(args)
-			result, err := unreflectResult(returns)
-			if err != nil {
-				responses = append(responses, types.RPCInternalError(request.ID, err))
-				continue
-			}
-			responses = append(responses, types.NewRPCSuccessResponse(request.ID, result))
+			responses = append(responses, *res)
+			cache = cache && cached[i]
 		}
 
 		if len(responses) > 0 {
@@ -128,6 +125,65 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 	}
 }
 
+// isConcurrencySafe returns whether request calls a concurrency-safe function.
+func isConcurrencySafe(funcMap map[string]*RPCFunc, request *types.RPCRequest) bool {
+	rpcFunc, ok := funcMap[request.Method]
+	return ok && rpcFunc.concurrencySafe
+}
+
+// handleJSONRPCRequest executes a single JSON-RPC request, and returns its
+// response (nil for a notification) and whether the response can be cached.
+func handleJSONRPCRequest(
+	r *http.Request,
+	funcMap map[string]*RPCFunc,
+	request *types.RPCRequest,
+	logger log.Logger,
+) (*types.RPCResponse, bool) {
+	respond := func(res types.RPCResponse, cacheable bool) (*types.RPCResponse, bool) {
+		return &res, cacheable
+	}
+
+	// A Notification is a Request object without an "id" member.
+	// The Server MUST NOT reply to a Notification, including those that are within a batch request.
+	if request.ID == nil {
+		logger.Debug(
+			"HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
+			"req", request,
+		)
+		return nil, true
+	}
+	if len(r.URL.Path) > 1 {
+		return respond(types.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path)), false)
+	}
+	rpcFunc, ok := funcMap[request.Method]
+	if !ok || (rpcFunc.ws) {
+		return respond(types.RPCMethodNotFoundError(request.ID), false)
+	}
+	if err := allowCall(r, request.Method); err != nil {
+		return respond(types.RPCServerError(request.ID, err), false)
+	}
+	ctx := &types.Context{JSONReq: request, HTTPReq: r}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+		if err != nil {
+			return respond(types.RPCInvalidParamsError(
+				request.ID, fmt.Errorf("error converting json params to arguments: %w", err),
+			), false)
+		}
+		args = append(args, fnArgs...)
+	}
+
+	cacheable := rpcFunc.cacheableWithArgs(args)
+
+	returns := rpcFunc.call(request.Method, ctx, args)
+	result, err := unreflectResult(returns)
+	if err != nil {
+		return respond(types.RPCInternalError(request.ID, err), cacheable)
+	}
+	return respond(types.NewRPCSuccessResponse(request.ID, result), cacheable)
+}
+
 func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Since the pattern "/" matches all paths not matched by other registered patterns,
@@ -3,11 +3,14 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,15 +19,18 @@ import (
 	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
 
-func testMux() *http.ServeMux {
-	funcMap := map[string]*RPCFunc{
+func testFuncMap() map[string]*RPCFunc {
+	return map[string]*RPCFunc{
 		"c":     NewRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
 		"block": NewRPCFunc(func(ctx *types.Context, h int) (string, error) { return "block", nil }, "height", Cacheable("height")),
 	}
+}
+
+func testMux() *http.ServeMux {
 	mux := http.NewServeMux()
 	buf := new(bytes.Buffer)
 	logger := log.NewTMLogger(buf)
-	RegisterRPCFuncs(mux, funcMap, logger)
+	RegisterRPCFuncs(mux, testFuncMap(), logger)
 
 	return mux
 }
@@ -217,6 +223,107 @@ func TestRPCNotificationInBatch(t *testing.T) {
 	}
 }
 
+func TestRPCBatch(t *testing.T) {
+	var (
+		mtx            sync.Mutex
+		running, peak  int
+		batchSize      = 10
+		maxConcurrency = 3
+	)
+	funcMap := map[string]*RPCFunc{
+		"echo": NewRPCFunc(func(ctx *types.Context, i int) (int, error) {
+			mtx.Lock()
+			running++
+			if running > peak {
+				peak = running
+			}
+			mtx.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mtx.Lock()
+			running--
+			mtx.Unlock()
+			return i, nil
+		}, "i", ConcurrencySafe()),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger(), MaxBatchSize(batchSize), BatchConcurrency(maxConcurrency))
+
+	call := func(payload string) (int, []byte) {
+		req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		defer res.Body.Close()
+		blob, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, blob
+	}
+
+	reqs := make([]string, batchSize)
+	for i := range reqs {
+		reqs[i] = fmt.Sprintf(`{"jsonrpc":"2.0","method":"echo","id":%d,"params":["%d"]}`, i, i)
+	}
+	code, blob := call("[" + strings.Join(reqs, ",") + "]")
+	require.True(t, statusOK(code), string(blob))
+
+	var responses []types.RPCResponse
+	require.NoError(t, json.Unmarshal(blob, &responses))
+	require.Len(t, responses, batchSize)
+	for i, res := range responses {
+		assert.Equal(t, types.JSONRPCIntID(i), res.ID)
+		assert.Nil(t, res.Error)
+		assert.Equal(t, fmt.Sprintf(`"%d"`, i), string(res.Result))
+	}
+	assert.Greater(t, peak, 1, "requests should run concurrently")
+	assert.LessOrEqual(t, peak, maxConcurrency)
+
+	// the requests to the other functions are executed in order, once the
+	// concurrent requests preceding them are done
+	var order []int
+	funcMap["append"] = NewRPCFunc(func(ctx *types.Context, i int) (int, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		assert.Zero(t, running, "append executed concurrently")
+		order = append(order, i)
+		return i, nil
+	}, "i")
+	mux = http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger(), MaxBatchSize(batchSize), BatchConcurrency(maxConcurrency))
+	mixed := make([]string, batchSize)
+	for i := range mixed {
+		method := "echo"
+		if i%3 == 0 {
+			method = "append"
+		}
+		mixed[i] = fmt.Sprintf(`{"jsonrpc":"2.0","method":"%s","id":%d,"params":["%d"]}`, method, i, i)
+	}
+	code, blob = call("[" + strings.Join(mixed, ",") + "]")
+	require.True(t, statusOK(code), string(blob))
+	require.NoError(t, json.Unmarshal(blob, &responses))
+	require.Len(t, responses, batchSize)
+	assert.Equal(t, []int{0, 3, 6, 9}, order)
+
+	// batch too large
+	code, blob = call("[" + strings.Join(append(reqs, reqs[0]), ",") + "]")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(blob), "exceeds the maximum")
+
+	// empty batch
+	code, blob = call("[]")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(blob), "empty batch")
+
+	// the batch size is unlimited by default
+	mux = http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+	reqs = make([]string, 2*defaultBatchConcurrency+1)
+	for i := range reqs {
+		reqs[i] = fmt.Sprintf(`{"jsonrpc":"2.0","method":"echo","id":%d,"params":["%d"]}`, i, i)
+	}
+	code, blob = call("[" + strings.Join(reqs, ",") + "]")
+	require.True(t, statusOK(code), string(blob))
+}
+
 func TestUnknownRPCPath(t *testing.T) {
 	mux := testMux()
 	req, _ := http.NewRequest("GET", "http://localhost/unknownrpcpath", nil)
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/ratelimit"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// RateLimit is the rate, in calls per second, and the burst of a token bucket.
+// A zero rate disables the limit.
+type RateLimit = ratelimit.Limit
+
+// RateLimiter limits the rate at which each client can call each RPC method,
+// using one token bucket per client and method. Expensive methods (e.g.
+// tx_search) can be given their own limit.
+type RateLimiter struct {
+	now     func() time.Time
+	metrics *ratelimit.Metrics
+
+	mtx              cmtsync.RWMutex
+	limiter          *ratelimit.Limiter
+	expensiveLimiter *ratelimit.Limiter
+	expensive        map[string]struct{}
+}
+
+// RateLimiterOption sets an optional parameter of a RateLimiter.
+type RateLimiterOption func(*RateLimiter)
+
+// RateLimiterWithMetrics counts the calls of the rate limiter, under the
+// limiter names "rpc" and "rpc_expensive".
+func RateLimiterWithMetrics(metrics *ratelimit.Metrics) RateLimiterOption {
+	return func(rl *RateLimiter) { rl.metrics = metrics }
+}
+
+// NewRateLimiter returns a rate limiter applying expensiveLimit to the
+// expensive methods, and limit to the others.
+func NewRateLimiter(
+	limit, expensiveLimit RateLimit,
+	expensiveMethods []string,
+	options ...RateLimiterOption,
+) *RateLimiter {
+	rl := &RateLimiter{
+		now:     time.Now,
+		metrics: ratelimit.NopMetrics(),
+	}
+	for _, option := range options {
+		option(rl)
+	}
+	rl.SetLimits(limit, expensiveLimit, expensiveMethods)
+	return rl
+}
+
+// SetLimits replaces the limits of the rate limiter, e.g. on a reload of the
+// configuration. The clients start over with full buckets.
+func (rl *RateLimiter) SetLimits(limit, expensiveLimit RateLimit, expensiveMethods []string) {
+	expensive := make(map[string]struct{}, len(expensiveMethods))
+	for _, method := range expensiveMethods {
+		expensive[method] = struct{}{}
+	}
+	limiter := ratelimit.NewLimiter(limit, ratelimit.WithMetrics(rl.metrics, "rpc"))
+	expensiveLimiter := ratelimit.NewLimiter(expensiveLimit, ratelimit.WithMetrics(rl.metrics, "rpc_expensive"))
+
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+	rl.limiter, rl.expensiveLimiter, rl.expensive = limiter, expensiveLimiter, expensive
+}
+
+// Allow returns an error if the client exceeded its rate limit for the method,
+// and takes a token from its bucket otherwise.
+func (rl *RateLimiter) Allow(clientID, method string) error {
+	rl.mtx.RLock()
+	limiter := rl.limiter
+	if _, ok := rl.expensive[method]; ok {
+		limiter = rl.expensiveLimiter
+	}
+	rl.mtx.RUnlock()
+	if !limiter.AllowN(rl.now(), clientID+"/"+method, 1) {
+		return fmt.Errorf("rate limit exceeded for %s (%v calls/s)", method, limiter.Limit().Rate)
+	}
+	return nil
+}
+
+// RateLimitHandler attaches the rate limiter to the requests, so that every
+// RPC call (over HTTP, JSON-RPC or websocket) made by a client is checked
+// against its limits. Clients are identified by the ID set by AuthHandler or,
+// if authentication is disabled, by their IP address.
+func RateLimitHandler(handler http.Handler, limiter *RateLimiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), rateLimiterContextKey, limiter)))
+	})
+}
+
+// allowCall checks the call to method against the rate limits of the client
+// making the request, if any.
+func allowCall(r *http.Request, method string) error {
+	limiter, ok := r.Context().Value(rateLimiterContextKey).(*RateLimiter)
+	if !ok {
+		return nil
+	}
+	return limiter.Allow(clientID(r), method)
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/ratelimit"
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	rl := NewRateLimiter(RateLimit{Rate: 1, Burst: 2}, RateLimit{Rate: 0.5}, []string{"tx_search"})
+	rl.now = func() time.Time { return now }
+
+	// the burst is available right away, then one call per second
+	require.NoError(t, rl.Allow("alice", "status"))
+	require.NoError(t, rl.Allow("alice", "status"))
+	require.Error(t, rl.Allow("alice", "status"))
+	// buckets are per client and method
+	require.NoError(t, rl.Allow("bob", "status"))
+	require.NoError(t, rl.Allow("alice", "block"))
+
+	now = now.Add(time.Second)
+	require.NoError(t, rl.Allow("alice", "status"))
+	require.Error(t, rl.Allow("alice", "status"))
+
+	// expensive methods have their own limit, with a burst of at least 1
+	require.NoError(t, rl.Allow("alice", "tx_search"))
+	require.Error(t, rl.Allow("alice", "tx_search"))
+	now = now.Add(time.Second)
+	require.Error(t, rl.Allow("alice", "tx_search"))
+	now = now.Add(time.Second)
+	require.NoError(t, rl.Allow("alice", "tx_search"))
+
+	// idle clients are eventually forgotten
+	assert.Equal(t, 3, rl.limiter.Len())
+	assert.Equal(t, 1, rl.expensiveLimiter.Len())
+	now = now.Add(ratelimit.SweepInterval)
+	require.NoError(t, rl.Allow("carol", "status"))
+	assert.Equal(t, 1, rl.limiter.Len())
+}
+
+func TestRateLimiterSetLimits(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{Rate: 0.001, Burst: 1}, RateLimit{}, nil)
+	require.NoError(t, rl.Allow("alice", "status"))
+	require.Error(t, rl.Allow("alice", "status"))
+
+	// status becomes expensive, and unlimited
+	rl.SetLimits(RateLimit{Rate: 0.001, Burst: 1}, RateLimit{}, []string{"status"})
+	for i := 0; i < 10; i++ {
+		require.NoError(t, rl.Allow("alice", "status"))
+	}
+	require.NoError(t, rl.Allow("alice", "block"))
+	require.Error(t, rl.Allow("alice", "block"))
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{}, RateLimit{Rate: 1}, []string{"tx_search"})
+	for i := 0; i < 100; i++ {
+		require.NoError(t, rl.Allow("alice", "status"))
+	}
+	assert.Zero(t, rl.limiter.Len())
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Rate: 0.001, Burst: 1}, RateLimit{}, nil)
+	handler := RateLimitHandler(testMux(), limiter)
+
+	// URI
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/c?s=\"a\"&i=1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/c?s=\"a\"&i=1", nil))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// JSON-RPC, which shares the bucket of the method
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, withBearer("unused"))
+	assert.Contains(t, rec.Body.String(), "rate limit exceeded for c")
+
+	// authenticated clients are limited independently of their IP address
+	authHandler := AuthHandler(handler, NewAuthenticator([]string{"key"}, nil), log.TestingLogger())
+	rec = httptest.NewRecorder()
+	authHandler.ServeHTTP(rec, withBearer("key"))
+	assert.Contains(t, rec.Body.String(), `"result":"foo"`)
+
+	// websocket
+	wm := NewWebsocketManager(testFuncMap())
+	wm.SetLogger(log.TestingLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(RateLimitHandler(mux, NewRateLimiter(RateLimit{Rate: 0.001, Burst: 1}, RateLimit{}, nil)))
+	defer s.Close()
+
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer c.Close()
+	dialResp.Body.Close()
+
+	req, err := types.MapToRequest(types.JSONRPCStringID("1"), "c", map[string]interface{}{"s": "a", "i": 10})
+	require.NoError(t, err)
+	var resp types.RPCResponse
+	require.NoError(t, c.WriteJSON(req))
+	require.NoError(t, c.ReadJSON(&resp))
+	require.Nil(t, resp.Error)
+
+	require.NoError(t, c.WriteJSON(req))
+	require.NoError(t, c.ReadJSON(&resp))
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Data, "rate limit exceeded")
+}
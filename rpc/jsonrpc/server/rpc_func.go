@@ -7,20 +7,61 @@ import (
 	"strings"
 
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/telemetry"
+	"github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
 
 // RegisterRPCFuncs adds a route for each function in the funcMap, as well as
 // general jsonrpc and websocket handlers for all functions. "result" is the
 // interface on which the result objects are registered, and is popualted with
 // every RPCResponse
-func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
+func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger, opts ...HandlerOption) {
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
-		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger))
+		mux.HandleFunc("/"+funcName, makeHTTPHandler(funcName, rpcFunc, logger))
 	}
 
 	// JSONRPC endpoints
-	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
+	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger, opts...)))
+}
+
+const (
+	defaultMaxBatchSize     = 0
+	defaultBatchConcurrency = 4
+)
+
+type handlerConfig struct {
+	maxBatchSize     int
+	batchConcurrency int
+}
+
+func defaultHandlerConfig() *handlerConfig {
+	return &handlerConfig{
+		maxBatchSize:     defaultMaxBatchSize,
+		batchConcurrency: defaultBatchConcurrency,
+	}
+}
+
+// HandlerOption sets an optional parameter of the JSON-RPC handler.
+type HandlerOption func(*handlerConfig)
+
+// MaxBatchSize sets the maximum number of requests in a JSON-RPC batch. Larger
+// batches are rejected. 0 means unlimited.
+func MaxBatchSize(n int) HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.maxBatchSize = n
+	}
+}
+
+// BatchConcurrency sets the maximum number of requests of a JSON-RPC batch
+// executed concurrently, among the requests to the functions marked with
+// ConcurrencySafe. It must be positive.
+func BatchConcurrency(n int) HandlerOption {
+	return func(cfg *handlerConfig) {
+		if n > 0 {
+			cfg.batchConcurrency = n
+		}
+	}
 }
 
 type Option func(*RPCFunc)
@@ -41,6 +82,16 @@ func Cacheable(noCacheDefArgs ...string) Option {
 	}
 }
 
+// ConcurrencySafe marks the function as safe to execute concurrently with the
+// other requests of a JSON-RPC batch, i.e. it does not mutate any state, so
+// that the order in which it is executed doesn't matter. The other functions
+// are executed in the order of the requests.
+func ConcurrencySafe() Option {
+	return func(r *RPCFunc) {
+		r.concurrencySafe = true
+	}
+}
+
 // Ws enables WebSocket communication.
 func Ws() Option {
 	return func(r *RPCFunc) {
@@ -50,13 +101,14 @@ func Ws() Option {
 
 // RPCFunc contains the introspected type information for a function
 type RPCFunc struct {
-	f              reflect.Value          // underlying rpc function
-	args           []reflect.Type         // type of each function arg
-	returns        []reflect.Type         // type of each return arg
-	argNames       []string               // name of each argument
-	cacheable      bool                   // enable cache control
-	ws             bool                   // enable websocket communication
-	noCacheDefArgs map[string]interface{} // a lookup table of args that, if not supplied or are set to default values, cause us to not cache
+	f               reflect.Value          // underlying rpc function
+	args            []reflect.Type         // type of each function arg
+	returns         []reflect.Type         // type of each return arg
+	argNames        []string               // name of each argument
+	cacheable       bool                   // enable cache control
+	ws              bool                   // enable websocket communication
+	concurrencySafe bool                   // can be executed concurrently in a batch
+	noCacheDefArgs  map[string]interface{} // a lookup table of args that, if not supplied or are set to default values, cause us to not cache
 }
 
 // NewRPCFunc wraps a function for introspection.
@@ -140,6 +192,25 @@ func funcReturnTypes(f interface{}) []reflect.Type {
 //-------------------------------------------------------------
 
 // NOTE: assume returns is result struct and error. If error is not nil, return it
+// call calls the function with args, within an "rpc.<method>" span whose
+// parent is given by the traceparent header of the HTTP request, if any.
+func (f *RPCFunc) call(method string, ctx *types.Context, args []reflect.Value) []reflect.Value {
+	if !telemetry.Enabled() {
+		return f.f.Call(args)
+	}
+	parent := ctx.Context()
+	if ctx.HTTPReq != nil {
+		parent = telemetry.ContextWithTraceParent(parent, ctx.HTTPReq.Header.Get("traceparent"))
+	}
+	_, span := telemetry.StartSpan(parent, "rpc."+method, telemetry.SpanKindServer, "rpc.method", method)
+	returns := f.f.Call(args)
+	if err, ok := returns[1].Interface().(error); ok {
+		span.SetError(err)
+	}
+	span.End()
+	return returns
+}
+
 func unreflectResult(returns []reflect.Value) (interface{}, error) {
 	errV := returns[1]
 	if errV.Interface() != nil {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"reflect"
 	"runtime/debug"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
 
@@ -35,6 +37,11 @@ type WebsocketManager struct {
 	funcMap       map[string]*RPCFunc
 	logger        log.Logger
 	wsConnOptions []func(*wsConnection)
+
+	// number of open connections per IP address
+	mtx                 cmtsync.Mutex
+	maxConnectionsPerIP int
+	connectionsPerIP    map[string]int
 }
 
 // NewWebsocketManager returns a new WebsocketManager that passes a map of
@@ -59,8 +66,9 @@ func NewWebsocketManager(
 				return true
 			},
 		},
-		logger:        log.NewNopLogger(),
-		wsConnOptions: wsConnOptions,
+		logger:           log.NewNopLogger(),
+		wsConnOptions:    wsConnOptions,
+		connectionsPerIP: make(map[string]int),
 	}
 }
 
@@ -69,9 +77,49 @@ func (wm *WebsocketManager) SetLogger(l log.Logger) {
 	wm.logger = l
 }
 
+// SetMaxConnectionsPerIP limits the number of simultaneous connections from a
+// single IP address. 0 means unlimited.
+func (wm *WebsocketManager) SetMaxConnectionsPerIP(n int) {
+	wm.maxConnectionsPerIP = n
+}
+
+// acquireConnection registers a new connection from the given IP address, and
+// returns false if the IP address has too many connections already.
+func (wm *WebsocketManager) acquireConnection(ip string) bool {
+	wm.mtx.Lock()
+	defer wm.mtx.Unlock()
+
+	if wm.maxConnectionsPerIP > 0 && wm.connectionsPerIP[ip] >= wm.maxConnectionsPerIP {
+		return false
+	}
+	wm.connectionsPerIP[ip]++
+	return true
+}
+
+func (wm *WebsocketManager) releaseConnection(ip string) {
+	wm.mtx.Lock()
+	defer wm.mtx.Unlock()
+
+	if wm.connectionsPerIP[ip]--; wm.connectionsPerIP[ip] <= 0 {
+		delete(wm.connectionsPerIP, ip)
+	}
+}
+
 // WebsocketHandler upgrades the request/response (via http.Hijack) and starts
 // the wsConnection.
 func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !wm.acquireConnection(ip) {
+		wm.logger.Info("Too many websocket connections", "remote", r.RemoteAddr,
+			"max_websocket_connections_per_ip", wm.maxConnectionsPerIP)
+		http.Error(w, "too many websocket connections from this IP address", http.StatusTooManyRequests)
+		return
+	}
+	defer wm.releaseConnection(ip)
+
 	wsConn, err := wm.Upgrade(w, r, nil)
 	if err != nil {
 		// TODO - return http error
@@ -86,6 +134,7 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	con.allowCall = func(method string) error { return allowCall(r, method) }
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -136,6 +185,9 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// checks the rate limits of the client before each call
+	allowCall func(method string) error
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -160,6 +212,7 @@ func newWSConnection(
 		readWait:          defaultWSReadWait,
 		pingPeriod:        defaultWSPingPeriod,
 		readRoutineQuit:   make(chan struct{}),
+		allowCall:         func(string) error { return nil },
 	}
 	for _, option := range options {
 		option(wsc)
@@ -362,6 +415,12 @@ func (wsc *wsConnection) readRoutine() {
 				}
 				continue
 			}
+			if err := wsc.allowCall(request.Method); err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCServerError(request.ID, err)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
 
 			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
@@ -378,7 +437,7 @@ func (wsc *wsConnection) readRoutine() {
 				args = append(args, fnArgs...)
 			}
 
-			returns := rpcFunc.f.Call(args)
+			returns := rpcFunc.call(request.Method, ctx, args)
 
 			// TODO: Need to encode args/returns to string if we want to log them
 			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
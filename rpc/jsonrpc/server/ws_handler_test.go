@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
@@ -42,12 +43,44 @@ func TestWebsocketManagerHandler(t *testing.T) {
 	dialResp.Body.Close()
 }
 
-func newWSServer() *httptest.Server {
+func TestWebsocketManagerMaxConnectionsPerIP(t *testing.T) {
+	s := newWSServer(func(wm *WebsocketManager) { wm.SetMaxConnectionsPerIP(1) })
+	defer s.Close()
+
+	url := "ws://" + s.Listener.Addr().String() + "/websocket"
+	d := websocket.Dialer{}
+	c, dialResp, err := d.Dial(url, nil)
+	require.NoError(t, err)
+	dialResp.Body.Close()
+
+	// a second connection from the same IP is rejected
+	_, dialResp, err = d.Dial(url, nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusTooManyRequests, dialResp.StatusCode)
+	dialResp.Body.Close()
+
+	// and accepted again once the first one is closed
+	require.NoError(t, c.Close())
+	require.Eventually(t, func() bool {
+		c, dialResp, err := d.Dial(url, nil)
+		if err != nil {
+			return false
+		}
+		dialResp.Body.Close()
+		c.Close()
+		return true
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func newWSServer(opts ...func(*WebsocketManager)) *httptest.Server {
 	funcMap := map[string]*RPCFunc{
 		"c": NewWSRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
 	}
 	wm := NewWebsocketManager(funcMap)
 	wm.SetLogger(log.TestingLogger())
+	for _, opt := range opts {
+		opt(wm)
+	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/websocket", wm.WebsocketHandler)
@@ -27,6 +27,8 @@ type BlockExecutor struct {
 
 	// use blockstore for the pruning functions.
 	blockStore BlockStore
+	// prunes in the background, if set.
+	pruner *Pruner
 
 	// execute the app against this
 	proxyApp proxy.AppConnConsensus
@@ -52,6 +54,14 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithPruner hands the retain heights of the application to the
+// pruner, which prunes in the background, instead of pruning on commit.
+func BlockExecutorWithPruner(pruner *Pruner) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.pruner = pruner
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -154,7 +164,7 @@ func (blockExec *BlockExecutor) ProcessProposal(
 	state State,
 ) (bool, error) {
 	resp, err := blockExec.proxyApp.ProcessProposalSync(abci.RequestProcessProposal{
-		Hash:               block.Header.Hash(),
+		Hash:               block.Header.Hash(state.ConsensusParams.Encoding.Canonical),
 		Height:             block.Header.Height,
 		Time:               block.Header.Time,
 		Txs:                block.Data.Txs.ToSliceOfBytes(),
@@ -202,6 +212,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	startTime := time.Now().UnixNano()
 	abciResponses, err := execBlockOnProxyApp(
 		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		state.ConsensusParams.Encoding.Canonical,
 	)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
@@ -263,7 +274,9 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	fail.Fail() // XXX
 
 	// Prune old heights, if requested by ABCI app.
-	if retainHeight > 0 {
+	if blockExec.pruner != nil {
+		blockExec.pruner.SetApplicationRetainHeight(retainHeight)
+	} else if retainHeight > 0 {
 		pruned, err := blockExec.pruneBlocks(retainHeight, state)
 		if err != nil {
 			blockExec.logger.Error("failed to prune blocks", "retain_height", retainHeight, "err", err)
@@ -331,7 +344,7 @@ func (blockExec *BlockExecutor) Commit(
 //---------------------------------------------------------
 // Helper functions for executing blocks and updating state
 
-// Executes block's transactions on proxyAppConn.
+// Executes block on proxyAppConn, in a single FinalizeBlock call.
 // Returns a list of transaction results and updates to the validator set
 func execBlockOnProxyApp(
 	logger log.Logger,
@@ -339,67 +352,50 @@ func execBlockOnProxyApp(
 	block *types.Block,
 	store Store,
 	initialHeight int64,
+	encoding types.CanonicalEncoding,
 ) (*cmtstate.ABCIResponses, error) {
-	var validTxs, invalidTxs = 0, 0
-
-	txIndex := 0
-	abciResponses := new(cmtstate.ABCIResponses)
-	dtxs := make([]*abci.ResponseDeliverTx, len(block.Txs))
-	abciResponses.DeliverTxs = dtxs
-
-	// Execute transactions and get hash.
-	proxyCb := func(req *abci.Request, res *abci.Response) {
-		if r, ok := res.Value.(*abci.Response_DeliverTx); ok {
-			// TODO: make use of res.Log
-			// TODO: make use of this info
-			// Blocks may include invalid txs.
-			txRes := r.DeliverTx
-			if txRes.Code == abci.CodeTypeOK {
-				validTxs++
-			} else {
-				logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
-				invalidTxs++
-			}
-
-			abciResponses.DeliverTxs[txIndex] = txRes
-			txIndex++
-		}
-	}
-	proxyAppConn.SetResponseCallback(proxyCb)
-
 	commitInfo := buildLastCommitInfo(block, store, initialHeight)
 
-	// Begin block
-	var err error
 	pbh := block.Header.ToProto()
 	if pbh == nil {
 		return nil, errors.New("nil header")
 	}
 
-	abciResponses.BeginBlock, err = proxyAppConn.BeginBlockSync(abci.RequestBeginBlock{
-		Hash:                block.Hash(),
+	// Execute the block, all its txs in a single call.
+	res, err := proxyAppConn.FinalizeBlockSync(abci.RequestFinalizeBlock{
+		Hash:                block.Hash(encoding),
 		Header:              *pbh,
 		LastCommitInfo:      commitInfo,
 		ByzantineValidators: block.Evidence.Evidence.ToABCI(),
+		Txs:                 block.Txs.ToSliceOfBytes(),
 	})
 	if err != nil {
-		logger.Error("error in proxyAppConn.BeginBlock", "err", err)
+		logger.Error("error in proxyAppConn.FinalizeBlock", "err", err)
 		return nil, err
 	}
+	if len(res.TxResults) != len(block.Txs) {
+		return nil, fmt.Errorf("expected %d tx results, got %d", len(block.Txs), len(res.TxResults))
+	}
 
-	// run txs of block
-	for _, tx := range block.Txs {
-		proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx})
-		if err := proxyAppConn.Error(); err != nil {
-			return nil, err
+	// Blocks may include invalid txs.
+	var validTxs, invalidTxs = 0, 0
+	for _, txRes := range res.TxResults {
+		if txRes.Code == abci.CodeTypeOK {
+			validTxs++
+		} else {
+			logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
+			invalidTxs++
 		}
 	}
 
-	// End block.
-	abciResponses.EndBlock, err = proxyAppConn.EndBlockSync(abci.RequestEndBlock{Height: block.Height})
-	if err != nil {
-		logger.Error("error in proxyAppConn.EndBlock", "err", err)
-		return nil, err
+	abciResponses := &cmtstate.ABCIResponses{
+		DeliverTxs: res.TxResults,
+		BeginBlock: &abci.ResponseBeginBlock{Events: res.BeginBlockEvents},
+		EndBlock: &abci.ResponseEndBlock{
+			ValidatorUpdates:      res.ValidatorUpdates,
+			ConsensusParamUpdates: res.ConsensusParamUpdates,
+			Events:                res.EndBlockEvents,
+		},
 	}
 
 	logger.Info("executed block", "height", block.Height, "num_valid_txs", validTxs, "num_invalid_txs", invalidTxs)
@@ -522,8 +518,12 @@ func updateState(
 	lastHeightParamsChanged := state.LastHeightConsensusParamsChanged
 	if abciResponses.EndBlock.ConsensusParamUpdates != nil {
 		// NOTE: must not mutate s.ConsensusParams
+		err := state.ConsensusParams.ValidateUpdate(abciResponses.EndBlock.ConsensusParamUpdates)
+		if err != nil {
+			return state, fmt.Errorf("error updating consensus params: %v", err)
+		}
 		nextParams = state.ConsensusParams.Update(abciResponses.EndBlock.ConsensusParamUpdates)
-		err := nextParams.ValidateBasic()
+		err = nextParams.ValidateBasic()
 		if err != nil {
 			return state, fmt.Errorf("error updating consensus params: %v", err)
 		}
@@ -624,8 +624,9 @@ func ExecCommitBlock(
 	logger log.Logger,
 	store Store,
 	initialHeight int64,
+	encoding types.CanonicalEncoding,
 ) ([]byte, error) {
-	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight)
+	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight, encoding)
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
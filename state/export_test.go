@@ -1,6 +1,8 @@
 package state
 
 import (
+	"time"
+
 	dbm "github.com/cometbft/cometbft-db"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -42,6 +44,12 @@ func ValidateValidatorUpdates(abciUpdates []abci.ValidatorUpdate, params types.V
 // SaveValidatorsInfo is an alias for the private saveValidatorsInfo method in
 // store.go, exported exclusively and explicitly for testing.
 func SaveValidatorsInfo(db dbm.DB, height, lastHeightChanged int64, valSet *types.ValidatorSet) error {
-	stateStore := dbStore{db, StoreOptions{DiscardABCIResponses: false}}
+	stateStore := dbStore{db, StoreOptions{}}
 	return stateStore.saveValidatorsInfo(height, lastHeightChanged, valSet)
 }
+
+// PruneAt is an alias for the prune method of the Pruner, exclusively and
+// explicitly for testing.
+func (p *Pruner) PruneAt(now time.Time) error {
+	return p.prune(now)
+}
@@ -0,0 +1,103 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/types"
+)
+
+// ExportGenesis returns the genesis document of a network continuing the
+// chain of genDoc after height, i.e. whose initial height is height + 1, with
+// the validator set and the consensus params of that height, and the app hash
+// the application had after committing height. The genesis time is the time of
+// the block at height.
+//
+// The validators keep the names and the proofs of possession of their bn254
+// keys they had in genDoc, if any. The proofs of possession of the validators
+// which joined later aren't stored, since the validator updates don't carry
+// them, so they are taken from proofs, by the address of the validator (see
+// crypto.Address.String). The export fails if one of the bn254 validators
+// has no proof of possession. The proposer priorities are reset, as in any
+// genesis.
+//
+// The app state is left empty: it is up to the application to export its state
+// at height, which it must be able to restore on InitChain.
+func ExportGenesis(
+	bs BlockStore,
+	ss Store,
+	genDoc *types.GenesisDoc,
+	height int64,
+	proofs map[string][]byte,
+) (*types.GenesisDoc, error) {
+	state, err := ss.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state found")
+	}
+	if height <= 0 || height > state.LastBlockHeight {
+		return nil, fmt.Errorf("can't export height %d, the state is at height %d", height, state.LastBlockHeight)
+	}
+	if height < bs.Base() {
+		return nil, fmt.Errorf("can't export height %d, below the base of the blockstore (%d)", height, bs.Base())
+	}
+
+	meta := bs.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	// the app hash after height is in the header of the next block, which isn't
+	// there yet at the last height
+	appHash := state.AppHash
+	if height < state.LastBlockHeight {
+		nextMeta := bs.LoadBlockMeta(height + 1)
+		if nextMeta == nil {
+			return nil, fmt.Errorf("block at height %d not found", height+1)
+		}
+		appHash = nextMeta.Header.AppHash
+	}
+
+	vals, err := ss.LoadValidators(height + 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the validators of height %d: %w", height+1, err)
+	}
+	params, err := ss.LoadConsensusParams(height + 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the consensus params of height %d: %w", height+1, err)
+	}
+
+	genVals := make(map[string]types.GenesisValidator, len(genDoc.Validators))
+	for _, val := range genDoc.Validators {
+		genVals[string(val.PubKey.Bytes())] = val
+	}
+	validators := make([]types.GenesisValidator, len(vals.Validators))
+	for i, val := range vals.Validators {
+		genVal := genVals[string(val.PubKey.Bytes())]
+		proof := genVal.ProofOfPossession
+		if len(proof) == 0 {
+			proof = proofs[val.Address.String()]
+		}
+		if _, ok := val.PubKey.(bn254.PubKey); ok && len(proof) == 0 {
+			return nil, fmt.Errorf("missing the proof of possession of the bn254 key of validator %v", val.Address)
+		}
+		validators[i] = types.GenesisValidator{
+			Address:           val.Address,
+			PubKey:            val.PubKey,
+			Power:             val.VotingPower,
+			Name:              genVal.Name,
+			ProofOfPossession: proof,
+		}
+	}
+
+	return &types.GenesisDoc{
+		GenesisTime:     meta.Header.Time,
+		ChainID:         genDoc.ChainID,
+		InitialHeight:   height + 1,
+		ConsensusParams: &params,
+		Validators:      validators,
+		AppHash:         appHash,
+	}, nil
+}
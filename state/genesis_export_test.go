@@ -0,0 +1,104 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestExportGenesis(t *testing.T) {
+	const height = int64(100)
+	stateStore := setupStateStore(t, height)
+	lastState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	blockTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{
+		Header: types.Header{Height: height, Time: blockTime},
+	})
+
+	// the first validator was in the original genesis
+	val := lastState.Validators.Validators[0]
+	genDoc := &types.GenesisDoc{
+		ChainID: "test-chain",
+		Validators: []types.GenesisValidator{{
+			PubKey:            val.PubKey,
+			Power:             1,
+			Name:              "genesis",
+			ProofOfPossession: []byte("proof"),
+		}},
+	}
+
+	exported, err := state.ExportGenesis(blockStore, stateStore, genDoc, height, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "test-chain", exported.ChainID)
+	assert.Equal(t, height+1, exported.InitialHeight)
+	assert.Equal(t, blockTime, exported.GenesisTime)
+	assert.EqualValues(t, lastState.AppHash, exported.AppHash)
+	assert.Equal(t, lastState.ConsensusParams, *exported.ConsensusParams)
+	require.Len(t, exported.Validators, lastState.Validators.Size())
+	for i, v := range lastState.Validators.Validators {
+		assert.Equal(t, v.Address, exported.Validators[i].Address)
+		assert.Equal(t, v.VotingPower, exported.Validators[i].Power)
+	}
+	assert.Equal(t, "genesis", exported.Validators[0].Name)
+	assert.EqualValues(t, "proof", exported.Validators[0].ProofOfPossession)
+	assert.Empty(t, exported.Validators[1].ProofOfPossession)
+
+	// the heights above the state can't be exported
+	_, err = state.ExportGenesis(blockStore, stateStore, genDoc, height+1, nil)
+	require.Error(t, err)
+	_, err = state.ExportGenesis(blockStore, stateStore, genDoc, 0, nil)
+	require.Error(t, err)
+}
+
+func TestExportGenesisProofOfPossession(t *testing.T) {
+	const height = int64(100)
+	lastState, err := setupStateStore(t, height).Load()
+	require.NoError(t, err)
+
+	// a bn254 validator joined after the genesis
+	privKey := bn254.GenPrivKey()
+	valSet := lastState.Validators.Copy()
+	require.NoError(t, valSet.UpdateWithChangeSet([]*types.Validator{types.NewValidator(privKey.PubKey(), 10)}))
+	lastState.Validators = valSet
+	stateStore := state.NewStore(dbm.NewMemDB(), state.StoreOptions{})
+	require.NoError(t, stateStore.Bootstrap(lastState))
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{
+		Header: types.Header{Height: height},
+	})
+	genDoc := &types.GenesisDoc{ChainID: "test-chain"}
+
+	// its proof of possession isn't stored, so it must be given
+	_, err = state.ExportGenesis(blockStore, stateStore, genDoc, height, nil)
+	require.Error(t, err)
+
+	proof, err := privKey.ProvePossession()
+	require.NoError(t, err)
+	address := privKey.PubKey().Address()
+	exported, err := state.ExportGenesis(blockStore, stateStore, genDoc, height,
+		map[string][]byte{address.String(): proof})
+	require.NoError(t, err)
+	for _, v := range exported.Validators {
+		if v.Address.String() == address.String() {
+			assert.EqualValues(t, proof, v.ProofOfPossession)
+		} else {
+			assert.Empty(t, v.ProofOfPossession)
+		}
+	}
+	require.NoError(t, exported.ValidateAndComplete())
+}
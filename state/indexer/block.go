@@ -3,6 +3,7 @@ package indexer
 import (
 	"context"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/pubsub/query"
 	"github.com/cometbft/cometbft/types"
 )
@@ -18,7 +19,24 @@ type BlockIndexer interface {
 	// Index indexes BeginBlock and EndBlock events for a given block by its height.
 	Index(types.EventDataNewBlockHeader) error
 
+	// GetEvents returns the BeginBlock and EndBlock events of the block at the
+	// given height, or nil if the height has not been indexed.
+	GetEvents(height int64) (*BlockEvents, error)
+
 	// Search performs a query for block heights that match a given BeginBlock
 	// and Endblock event search criteria.
 	Search(ctx context.Context, q *query.Query) ([]int64, error)
 }
+
+// HeightDeleter is implemented by the indexers which can delete what they
+// indexed at a height, the block and its txs, e.g. when the height is rolled
+// back.
+type HeightDeleter interface {
+	DeleteHeight(height int64) error
+}
+
+// BlockEvents are the BeginBlock and EndBlock events of a block.
+type BlockEvents struct {
+	BeginBlock []abci.Event
+	EndBlock   []abci.Event
+}
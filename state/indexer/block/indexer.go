@@ -11,6 +11,7 @@ import (
 	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
 	blockidxnull "github.com/cometbft/cometbft/state/indexer/block/null"
 	"github.com/cometbft/cometbft/state/indexer/sink/psql"
+	"github.com/cometbft/cometbft/state/indexer/sink/stream"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/state/txindex/kv"
 	"github.com/cometbft/cometbft/state/txindex/null"
@@ -39,6 +40,17 @@ func IndexerFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID
 		if err != nil {
 			return nil, nil, fmt.Errorf("creating psql indexer: %w", err)
 		}
+		if err := es.Migrate(); err != nil {
+			return nil, nil, fmt.Errorf("creating psql indexer: %w", err)
+		}
+		return es.TxIndexer(), es.BlockIndexer(), nil
+
+	case "kafka":
+		es, err := stream.NewEventSinkFromURL(cfg.TxIndex.Indexer, cfg.TxIndex.StreamURL, chainID,
+			cfg.TxIndex.StreamBlockTopic, cfg.TxIndex.StreamTxTopic)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating %s indexer: %w", cfg.TxIndex.Indexer, err)
+		}
 		return es.TxIndexer(), es.BlockIndexer(), nil
 
 	default:
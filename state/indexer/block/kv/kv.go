@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
-	"strings"
 
+	"github.com/cosmos/gogoproto/proto"
 	"github.com/google/orderedcode"
 
 	dbm "github.com/cometbft/cometbft-db"
@@ -45,12 +45,48 @@ func (idx *BlockerIndexer) Has(height int64) (bool, error) {
 	return idx.store.Has(key)
 }
 
+// GetEvents returns the BeginBlock and EndBlock events of the block at the
+// given height, or nil if the height has not been indexed.
+func (idx *BlockerIndexer) GetEvents(height int64) (*indexer.BlockEvents, error) {
+	beginBlock := new(abci.ResponseBeginBlock)
+	ok, err := idx.getEvents(height, "begin_block", beginBlock)
+	if err != nil || !ok {
+		return nil, err
+	}
+	endBlock := new(abci.ResponseEndBlock)
+	if _, err := idx.getEvents(height, "end_block", endBlock); err != nil {
+		return nil, err
+	}
+	return &indexer.BlockEvents{BeginBlock: beginBlock.Events, EndBlock: endBlock.Events}, nil
+}
+
+func (idx *BlockerIndexer) getEvents(height int64, typ string, msg proto.Message) (bool, error) {
+	key, err := eventsKey(height, typ)
+	if err != nil {
+		return false, fmt.Errorf("failed to create block events key: %w", err)
+	}
+	bz, err := idx.store.Get(key)
+	if err != nil || bz == nil {
+		return false, err
+	}
+	if err := proto.Unmarshal(bz, msg); err != nil {
+		return false, fmt.Errorf("failed to decode block events: %w", err)
+	}
+	return true, nil
+}
+
 // Index indexes BeginBlock and EndBlock events for a given block by its height.
 // The following is indexed:
 //
 // primary key: encode(block.height | height) => encode(height)
 // BeginBlock events: encode(eventType.eventAttr|eventValue|height|begin_block) => encode(height)
 // EndBlock events: encode(eventType.eventAttr|eventValue|height|end_block) => encode(height)
+//
+// The events themselves, including the attributes which are not indexed, are
+// stored as well, see GetEvents:
+//
+// encode(block_events|height|begin_block) => ResponseBeginBlock{events}
+// encode(block_events|height|end_block) => ResponseEndBlock{events}
 func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 	batch := idx.store.NewBatch()
 	defer batch.Close()
@@ -76,6 +112,65 @@ func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 		return fmt.Errorf("failed to index EndBlock events: %w", err)
 	}
 
+	// 4. store the events
+	if err := idx.setEvents(batch, height, "begin_block",
+		&abci.ResponseBeginBlock{Events: bh.ResultBeginBlock.Events}); err != nil {
+		return fmt.Errorf("failed to store BeginBlock events: %w", err)
+	}
+	if err := idx.setEvents(batch, height, "end_block",
+		&abci.ResponseEndBlock{Events: bh.ResultEndBlock.Events}); err != nil {
+		return fmt.Errorf("failed to store EndBlock events: %w", err)
+	}
+
+	return batch.WriteSync()
+}
+
+// DeleteHeight deletes the block indexed at height, and its events. The events
+// of the blocks indexed before they were stored (see GetEvents) are left
+// indexed.
+func (idx *BlockerIndexer) DeleteHeight(height int64) error {
+	events, err := idx.GetEvents(height)
+	if err != nil {
+		return err
+	}
+	if events == nil {
+		events = &indexer.BlockEvents{}
+	}
+
+	batch := idx.store.NewBatch()
+	defer batch.Close()
+
+	key, err := heightKey(height)
+	if err != nil {
+		return fmt.Errorf("failed to create block height index key: %w", err)
+	}
+	if err := batch.Delete(key); err != nil {
+		return err
+	}
+	for typ, events := range map[string][]abci.Event{"begin_block": events.BeginBlock, "end_block": events.EndBlock} {
+		for _, event := range events {
+			for _, attr := range event.Attributes {
+				if len(event.Type) == 0 || len(attr.Key) == 0 || !attr.GetIndex() {
+					continue
+				}
+				key, err := eventKey(fmt.Sprintf("%s.%s", event.Type, attr.Key), typ, attr.Value, height)
+				if err != nil {
+					return fmt.Errorf("failed to create block index key: %w", err)
+				}
+				if err := batch.Delete(key); err != nil {
+					return err
+				}
+			}
+		}
+		key, err := eventsKey(height, typ)
+		if err != nil {
+			return fmt.Errorf("failed to create block events key: %w", err)
+		}
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+
 	return batch.WriteSync()
 }
 
@@ -384,7 +479,7 @@ func (idx *BlockerIndexer) match(
 			return nil, err
 		}
 
-	case c.Op == syntax.TContains:
+	case indexer.IsValueMatchOperation(c.Op):
 		prefix, err := orderedcode.Append(nil, c.Tag)
 		if err != nil {
 			return nil, err
@@ -402,7 +497,7 @@ func (idx *BlockerIndexer) match(
 				continue
 			}
 
-			if strings.Contains(eventValue, c.Arg.Value()) {
+			if indexer.MatchesValue(c, eventValue) {
 				tmpHeights[string(it.Value())] = it.Value()
 			}
 
@@ -450,6 +545,18 @@ func (idx *BlockerIndexer) match(
 	return filteredHeights, nil
 }
 
+func (idx *BlockerIndexer) setEvents(batch dbm.Batch, height int64, typ string, msg proto.Message) error {
+	key, err := eventsKey(height, typ)
+	if err != nil {
+		return err
+	}
+	bz, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return batch.Set(key, bz)
+}
+
 func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ string, height int64) error {
 	heightBz := int64ToBytes(height)
 
@@ -122,6 +122,22 @@ func TestBlockIndexer(t *testing.T) {
 			q:       query.MustCompile(`block.height > 2 AND end_event.foo <= 8`),
 			results: []int64{4, 6, 8},
 		},
+		"begin_event.proposer STARTS_WITH 'FCAA'": {
+			q:       query.MustCompile(`begin_event.proposer STARTS_WITH 'FCAA'`),
+			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		"begin_event.proposer STARTS_WITH 'CAA'": {
+			q:       query.MustCompile(`begin_event.proposer STARTS_WITH 'CAA'`),
+			results: []int64{},
+		},
+		"begin_event.proposer IN ('FFFFFFF', 'FCAA001')": {
+			q:       query.MustCompile(`begin_event.proposer IN ('FFFFFFF', 'FCAA001')`),
+			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		"begin_event.proposer IN ('FFFFFFF')": {
+			q:       query.MustCompile(`begin_event.proposer IN ('FFFFFFF')`),
+			results: []int64{},
+		},
 		"begin_event.proposer CONTAINS 'FFFFFFF'": {
 			q:       query.MustCompile(`begin_event.proposer CONTAINS 'FFFFFFF'`),
 			results: []int64{},
@@ -141,3 +157,87 @@ func TestBlockIndexer(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockIndexerGetEvents(t *testing.T) {
+	indexer := blockidxkv.New(db.NewMemDB())
+
+	header := types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		ResultBeginBlock: abci.ResponseBeginBlock{
+			Events: []abci.Event{
+				{
+					Type: "rewards",
+					Attributes: []abci.EventAttribute{
+						{Key: "validator", Value: "FCAA001", Index: true},
+						{Key: "amount", Value: "10"},
+					},
+				},
+			},
+		},
+		ResultEndBlock: abci.ResponseEndBlock{
+			Events: []abci.Event{
+				{
+					Type:       "slash",
+					Attributes: []abci.EventAttribute{{Key: "validator", Value: "FCAA002", Index: true}},
+				},
+				{Type: "end_event"},
+			},
+		},
+	}
+	require.NoError(t, indexer.Index(header))
+
+	// the events are stored with their attributes which are not indexed
+	events, err := indexer.GetEvents(1)
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	require.Equal(t, header.ResultBeginBlock.Events, events.BeginBlock)
+	require.Equal(t, header.ResultEndBlock.Events, events.EndBlock)
+
+	events, err = indexer.GetEvents(2)
+	require.NoError(t, err)
+	require.Nil(t, events)
+
+	// the stored events don't match the searches
+	results, err := indexer.Search(context.Background(), query.MustCompile("slash.validator EXISTS"))
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, results)
+	results, err = indexer.Search(context.Background(), query.MustCompile("rewards.amount = 10"))
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestBlockIndexerDeleteHeight(t *testing.T) {
+	store := db.NewMemDB()
+	indexer := blockidxkv.New(store)
+
+	for height := int64(1); height <= 2; height++ {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{
+					{
+						Type:       "slash",
+						Attributes: []abci.EventAttribute{{Key: "validator", Value: "FCAA002", Index: true}},
+					},
+				},
+			},
+		}))
+	}
+
+	require.NoError(t, indexer.DeleteHeight(2))
+	has, err := indexer.Has(2)
+	require.NoError(t, err)
+	require.False(t, has)
+	events, err := indexer.GetEvents(2)
+	require.NoError(t, err)
+	require.Nil(t, events)
+	results, err := indexer.Search(context.Background(), query.MustCompile("slash.validator = 'FCAA002'"))
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, results)
+
+	// deleting a height not indexed is a no-op
+	require.NoError(t, indexer.DeleteHeight(3))
+	has, err = indexer.Has(1)
+	require.NoError(t, err)
+	require.True(t, has)
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/cometbft/cometbft/types"
 )
 
+const blockEventsKey = "block_events"
+
 func intInSlice(a int, list []int) bool {
 	for _, b := range list {
 		if b == a {
@@ -40,6 +42,17 @@ func heightKey(height int64) ([]byte, error) {
 	)
 }
 
+// eventsKey is the key of the events of a block. Unlike the composite keys of
+// the events, blockEventsKey has no dot, so the keys can't collide.
+func eventsKey(height int64, typ string) ([]byte, error) {
+	return orderedcode.Append(
+		nil,
+		blockEventsKey,
+		height,
+		typ,
+	)
+}
+
 func eventKey(compositeKey, typ, eventValue string, height int64) ([]byte, error) {
 	return orderedcode.Append(
 		nil,
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	indexer "github.com/cometbft/cometbft/state/indexer"
+
 	mock "github.com/stretchr/testify/mock"
 
 	query "github.com/cometbft/cometbft/libs/pubsub/query"
@@ -17,6 +19,32 @@ type BlockIndexer struct {
 	mock.Mock
 }
 
+// GetEvents provides a mock function with given fields: height
+func (_m *BlockIndexer) GetEvents(height int64) (*indexer.BlockEvents, error) {
+	ret := _m.Called(height)
+
+	var r0 *indexer.BlockEvents
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*indexer.BlockEvents, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *indexer.BlockEvents); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.BlockEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Has provides a mock function with given fields: height
 func (_m *BlockIndexer) Has(height int64) (bool, error) {
 	ret := _m.Called(height)
@@ -0,0 +1,39 @@
+package indexer
+
+import (
+	"strings"
+
+	"github.com/cometbft/cometbft/libs/pubsub/query/syntax"
+)
+
+// IsValueMatchOperation returns true if the operator is matched by scanning
+// the indexed values of the condition tag, see MatchesValue.
+func IsValueMatchOperation(op syntax.Token) bool {
+	switch op {
+	case syntax.TContains, syntax.TStartsWith, syntax.TIn:
+		return true
+	default:
+		return false
+	}
+}
+
+// MatchesValue reports whether an indexed value satisfies a CONTAINS,
+// STARTS_WITH or IN condition. Like for equality conditions, values are
+// compared with the text of the arguments.
+func MatchesValue(c syntax.Condition, value string) bool {
+	switch c.Op {
+	case syntax.TContains:
+		return strings.Contains(value, c.Arg.Value())
+	case syntax.TStartsWith:
+		return strings.HasPrefix(value, c.Arg.Value())
+	case syntax.TIn:
+		for _, arg := range c.Args {
+			if value == arg.Value() {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
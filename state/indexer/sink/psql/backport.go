@@ -19,6 +19,7 @@ import (
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/types"
 )
@@ -59,6 +60,12 @@ func (BackportTxIndexer) Search(context.Context, *query.Query) ([]*abci.TxResult
 	return nil, errors.New("the TxIndexer.Search method is not supported")
 }
 
+// DeleteHeight deletes the block at the given height, its txs and their
+// events. It is part of the indexer.HeightDeleter interface.
+func (b BackportTxIndexer) DeleteHeight(height int64) error {
+	return b.psql.DeleteHeight(height)
+}
+
 // BlockIndexer returns a bridge that implements the CometBFT v0.34 block
 // indexer interface, using the Postgres event sink as a backing store.
 func (es *EventSink) BlockIndexer() BackportBlockIndexer {
@@ -69,10 +76,16 @@ func (es *EventSink) BlockIndexer() BackportBlockIndexer {
 // delegating indexing operations to an underlying PostgreSQL event sink.
 type BackportBlockIndexer struct{ psql *EventSink }
 
-// Has is implemented to satisfy the BlockIndexer interface, but it is not
-// supported by the psql event sink and reports an error for all inputs.
-func (BackportBlockIndexer) Has(height int64) (bool, error) {
-	return false, errors.New("the BlockIndexer.Has method is not supported")
+// Has returns true if the given height has been indexed. It is part of the
+// BlockIndexer interface.
+func (b BackportBlockIndexer) Has(height int64) (bool, error) {
+	return b.psql.HasBlock(height)
+}
+
+// GetEvents returns the indexed BeginBlock and EndBlock events of the block at
+// the given height. It is part of the BlockIndexer interface.
+func (b BackportBlockIndexer) GetEvents(height int64) (*indexer.BlockEvents, error) {
+	return b.psql.GetBlockEvents(height)
 }
 
 // Index indexes block begin and end events for the specified block.  It is
@@ -81,8 +94,14 @@ func (b BackportBlockIndexer) Index(block types.EventDataNewBlockHeader) error {
 	return b.psql.IndexBlockEvents(block)
 }
 
-// Search is implemented to satisfy the BlockIndexer interface, but it is not
-// supported by the psql event sink and reports an error for all inputs.
-func (BackportBlockIndexer) Search(context.Context, *query.Query) ([]int64, error) {
-	return nil, errors.New("the BlockIndexer.Search method is not supported")
+// Search returns the heights of the blocks matching the query. It is part of
+// the BlockIndexer interface.
+func (b BackportBlockIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	return b.psql.SearchBlockEvents(ctx, q)
+}
+
+// DeleteHeight deletes the block at the given height, its txs and their
+// events. It is part of the indexer.HeightDeleter interface.
+func (b BackportBlockIndexer) DeleteHeight(height int64) error {
+	return b.psql.DeleteHeight(height)
 }
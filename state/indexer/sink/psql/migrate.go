@@ -0,0 +1,48 @@
+package psql
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+
+	"github.com/adlio/schema"
+)
+
+// migrationsTable is the table recording the applied migrations.
+const migrationsTable = "cometbft_schema_migrations"
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrations returns the migrations of the database schema, ordered by ID.
+func migrations() ([]*schema.Migration, error) {
+	ms, err := schema.FSMigrations(migrationsFS, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	schema.SortMigrations(ms)
+	return ms, nil
+}
+
+// Migrate applies the migrations of the database schema which have not been
+// applied to the database of the sink yet. The migrations are applied in a
+// single transaction, holding a lock, so that several nodes sharing a database
+// can migrate it concurrently.
+func (es *EventSink) Migrate() error {
+	return migrate(es.store)
+}
+
+func migrate(db *sql.DB) error {
+	ms, err := migrations()
+	if err != nil {
+		return fmt.Errorf("reading migrations: %w", err)
+	}
+	migrator := schema.NewMigrator(
+		schema.WithDialect(schema.Postgres),
+		schema.WithTableName(migrationsTable),
+	)
+	if err := migrator.Apply(db, ms); err != nil {
+		return fmt.Errorf("migrating the database schema: %w", err)
+	}
+	return nil
+}
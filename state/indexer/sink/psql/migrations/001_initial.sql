@@ -1,12 +1,13 @@
 /*
-  This file defines the database schema for the PostgresQL ("psql") event sink
-  implementation in CometBFT. The operator must create a database and install
-  this schema before using the database to index events.
+  This migration defines the initial database schema for the PostgreSQL
+  ("psql") event sink implementation in CometBFT. The statements are
+  idempotent, so that the databases where this schema was installed manually
+  are migrated as well.
  */
 
 -- The blocks table records metadata about each block.
 -- The block record does not include its events or transactions (see tx_results).
-CREATE TABLE blocks (
+CREATE TABLE IF NOT EXISTS blocks (
   rowid      BIGSERIAL PRIMARY KEY,
 
   height     BIGINT NOT NULL,
@@ -20,11 +21,11 @@ CREATE TABLE blocks (
 
 -- Index blocks by height and chain, since we need to resolve block IDs when
 -- indexing transaction records and transaction events.
-CREATE INDEX idx_blocks_height_chain ON blocks(height, chain_id);
+CREATE INDEX IF NOT EXISTS idx_blocks_height_chain ON blocks(height, chain_id);
 
 -- The tx_results table records metadata about transaction results.  Note that
 -- the events from a transaction are stored separately.
-CREATE TABLE tx_results (
+CREATE TABLE IF NOT EXISTS tx_results (
   rowid BIGSERIAL PRIMARY KEY,
 
   -- The block to which this transaction belongs.
@@ -43,7 +44,7 @@ CREATE TABLE tx_results (
 
 -- The events table records events. All events (both block and transaction) are
 -- associated with a block ID; transaction events also have a transaction ID.
-CREATE TABLE events (
+CREATE TABLE IF NOT EXISTS events (
   rowid BIGSERIAL PRIMARY KEY,
 
   -- The block and transaction this event belongs to.
@@ -56,7 +57,7 @@ CREATE TABLE events (
 );
 
 -- The attributes table records event attributes.
-CREATE TABLE attributes (
+CREATE TABLE IF NOT EXISTS attributes (
    event_id      BIGINT NOT NULL REFERENCES events(rowid),
    key           VARCHAR NOT NULL, -- bare key
    composite_key VARCHAR NOT NULL, -- composed type.key
@@ -67,18 +68,18 @@ CREATE TABLE attributes (
 
 -- A joined view of events and their attributes. Events that do not have any
 -- attributes are represented as a single row with empty key and value fields.
-CREATE VIEW event_attributes AS
+CREATE OR REPLACE VIEW event_attributes AS
   SELECT block_id, tx_id, type, key, composite_key, value
   FROM events LEFT JOIN attributes ON (events.rowid = attributes.event_id);
 
 -- A joined view of all block events (those having tx_id NULL).
-CREATE VIEW block_events AS
+CREATE OR REPLACE VIEW block_events AS
   SELECT blocks.rowid as block_id, height, chain_id, type, key, composite_key, value
   FROM blocks JOIN event_attributes ON (blocks.rowid = event_attributes.block_id)
   WHERE event_attributes.tx_id IS NULL;
 
 -- A joined view of all transaction events.
-CREATE VIEW tx_events AS
+CREATE OR REPLACE VIEW tx_events AS
   SELECT height, index, chain_id, type, key, composite_key, value, tx_results.created_at
   FROM blocks JOIN tx_results ON (blocks.rowid = tx_results.block_id)
   JOIN event_attributes ON (tx_results.rowid = event_attributes.tx_id)
@@ -0,0 +1,48 @@
+/*
+  This migration stores the results of the transactions as JSONB, with
+  generated columns for the commonly queried keys, and adds the indexes
+  missing from the initial schema. It requires PostgreSQL 12 or later.
+
+  The transactions indexed before this migration have a NULL result, and so
+  NULL generated columns: their result is only available in tx_result, or once
+  they are re-indexed into a new database.
+ */
+
+-- The JSON encoding of the ResponseDeliverTx message, see
+-- https://github.com/cometbft/cometbft/blob/main/proto/tendermint/abci/types.proto.
+-- The keys with a zero value are omitted.
+ALTER TABLE tx_results ADD COLUMN result JSONB NULL;
+
+ALTER TABLE tx_results
+  ADD COLUMN code BIGINT GENERATED ALWAYS AS (
+    CASE WHEN result IS NOT NULL THEN COALESCE((result->>'code')::BIGINT, 0) END
+  ) STORED,
+  ADD COLUMN codespace VARCHAR GENERATED ALWAYS AS (
+    CASE WHEN result IS NOT NULL THEN COALESCE(result->>'codespace', '') END
+  ) STORED,
+  ADD COLUMN gas_wanted BIGINT GENERATED ALWAYS AS (
+    CASE WHEN result IS NOT NULL THEN COALESCE((result->>'gas_wanted')::BIGINT, 0) END
+  ) STORED,
+  ADD COLUMN gas_used BIGINT GENERATED ALWAYS AS (
+    CASE WHEN result IS NOT NULL THEN COALESCE((result->>'gas_used')::BIGINT, 0) END
+  ) STORED;
+
+-- Look up transactions by hash, and failed transactions by code.
+CREATE INDEX idx_tx_results_hash ON tx_results(tx_hash);
+CREATE INDEX idx_tx_results_code ON tx_results(codespace, code) WHERE code <> 0;
+
+-- Look up the events of a block or transaction. The foreign keys are not
+-- indexed by PostgreSQL.
+CREATE INDEX idx_events_block_id ON events(block_id);
+CREATE INDEX idx_events_tx_id ON events(tx_id) WHERE tx_id IS NOT NULL;
+
+-- Look up the events by attribute, as the RPC searches do.
+CREATE INDEX idx_attributes_composite_key_value ON attributes(composite_key, value);
+
+-- Add the result columns to the joined view of the transaction events.
+CREATE OR REPLACE VIEW tx_events AS
+  SELECT height, index, chain_id, type, key, composite_key, value, tx_results.created_at,
+         tx_results.code, tx_results.codespace, tx_results.gas_wanted, tx_results.gas_used
+  FROM blocks JOIN tx_results ON (blocks.rowid = tx_results.block_id)
+  JOIN event_attributes ON (tx_results.rowid = event_attributes.tx_id)
+  WHERE event_attributes.tx_id IS NOT NULL;
@@ -4,6 +4,7 @@ package psql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -13,6 +14,8 @@ import (
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/libs/pubsub/query/syntax"
+	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -21,12 +24,17 @@ const (
 	tableTxResults  = "tx_results"
 	tableEvents     = "events"
 	tableAttributes = "attributes"
+	viewBlockEvents = "block_events"
 	driverName      = "postgres"
+
+	// blockEventsKey is the composite key of the meta-events marking the start
+	// of the BeginBlock and EndBlock events of a block.
+	blockEventsKey = "block.events"
 )
 
 // EventSink is an indexer backend providing the tx/block index services.  This
 // implementation stores records in a PostgreSQL database using the schema
-// defined in state/indexer/sink/psql/schema.sql.
+// defined by the migrations in state/indexer/sink/psql/migrations, see Migrate.
 type EventSink struct {
 	store   *sql.DB
 	chainID string
@@ -78,49 +86,141 @@ func queryWithID(tx *sql.Tx, query string, args ...interface{}) (uint32, error)
 	return id, nil
 }
 
-// insertEvents inserts a slice of events and any indexed attributes of those
-// events into the database associated with dbtx.
-//
-// If txID > 0, the event is attributed to the transaction with that
-// ID; otherwise it is recorded as a block event.
-func insertEvents(dbtx *sql.Tx, blockID, txID uint32, evts []abci.Event) error {
-	// Populate the transaction ID field iff one is defined (> 0).
-	var txIDArg interface{}
-	if txID > 0 {
-		txIDArg = txID
-	}
+// maxQueryParams is the maximum number of parameters of a PostgreSQL query.
+const maxQueryParams = 65535
 
-	// Add each event to the events table, and retrieve its row ID to use when
-	// adding any attributes the event provides.
-	for _, evt := range evts {
-		// Skip events with an empty type.
-		if evt.Type == "" {
-			continue
+// insertRows inserts rows into table with multi-row INSERT statements, each
+// having at most maxQueryParams parameters. The statements end with suffix,
+// e.g. a RETURNING clause, and if scan is not nil, it is called for every row
+// they return.
+func insertRows(
+	dbtx *sql.Tx,
+	table string,
+	columns []string,
+	rows [][]interface{},
+	suffix string,
+	scan func(*sql.Rows) error,
+) error {
+	batchSize := maxQueryParams / len(columns)
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
 		}
 
-		eid, err := queryWithID(dbtx, `
-INSERT INTO `+tableEvents+` (block_id, tx_id, type) VALUES ($1, $2, $3)
-  RETURNING rowid;
-`, blockID, txIDArg, evt.Type)
+		var sb strings.Builder
+		args := make([]interface{}, 0, (end-start)*len(columns))
+		fmt.Fprintf(&sb, "INSERT INTO %s (%s) VALUES", table, strings.Join(columns, ", "))
+		for i, row := range rows[start:end] {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(" (")
+			for j, v := range row {
+				if j > 0 {
+					sb.WriteString(", ")
+				}
+				args = append(args, v)
+				fmt.Fprintf(&sb, "$%d", len(args))
+			}
+			sb.WriteString(")")
+		}
+		sb.WriteString(" " + suffix + ";")
+
+		if scan == nil {
+			if _, err := dbtx.Exec(sb.String(), args...); err != nil {
+				return err
+			}
+			continue
+		}
+		res, err := dbtx.Query(sb.String(), args...)
 		if err != nil {
 			return err
 		}
+		for res.Next() {
+			if err := scan(res); err != nil {
+				res.Close()
+				return err
+			}
+		}
+		if err := res.Err(); err != nil {
+			return err
+		}
+		res.Close()
+	}
+	return nil
+}
+
+// eventGroup is a group of events attributed to a block and, if txID > 0, to
+// a transaction of the block.
+type eventGroup struct {
+	blockID uint32
+	txID    uint32
+	events  []abci.Event
+}
+
+// insertEvents inserts the events of groups and any indexed attributes of
+// those events into the database associated with dbtx. The events are
+// inserted in order, i.e. with increasing row IDs.
+func insertEvents(dbtx *sql.Tx, groups ...eventGroup) error {
+	var eventRows [][]interface{}
+	var evts []abci.Event
+	for _, g := range groups {
+		// Populate the transaction ID field iff one is defined (> 0).
+		var txIDArg interface{}
+		if g.txID > 0 {
+			txIDArg = g.txID
+		}
+		for _, evt := range g.events {
+			// Skip events with an empty type.
+			if evt.Type == "" {
+				continue
+			}
+			eventRows = append(eventRows, []interface{}{nil, g.blockID, txIDArg, evt.Type})
+			evts = append(evts, evt)
+		}
+	}
+	if len(eventRows) == 0 {
+		return nil
+	}
+
+	// Reserve the row IDs of the events up front, so that the events and their
+	// attributes are both inserted in one statement per batch.
+	res, err := dbtx.Query(`
+SELECT nextval(pg_get_serial_sequence('`+tableEvents+`', 'rowid')) FROM generate_series(1, $1);
+`, len(eventRows))
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	var attrRows [][]interface{}
+	for i := 0; res.Next(); i++ {
+		var eid int64
+		if err := res.Scan(&eid); err != nil {
+			return err
+		}
+		eventRows[i][0] = eid
 
 		// Add any attributes flagged for indexing.
-		for _, attr := range evt.Attributes {
+		for _, attr := range evts[i].Attributes {
 			if !attr.Index {
 				continue
 			}
-			compositeKey := evt.Type + "." + attr.Key
-			if _, err := dbtx.Exec(`
-INSERT INTO `+tableAttributes+` (event_id, key, composite_key, value)
-  VALUES ($1, $2, $3, $4);
-`, eid, attr.Key, compositeKey, attr.Value); err != nil {
-				return err
-			}
+			compositeKey := evts[i].Type + "." + attr.Key
+			attrRows = append(attrRows, []interface{}{eid, attr.Key, compositeKey, attr.Value})
 		}
 	}
-	return nil
+	if err := res.Err(); err != nil {
+		return err
+	}
+	res.Close()
+
+	if err := insertRows(dbtx, tableEvents,
+		[]string{"rowid", "block_id", "tx_id", "type"}, eventRows, "", nil); err != nil {
+		return err
+	}
+	return insertRows(dbtx, tableAttributes,
+		[]string{"event_id", "key", "composite_key", "value"}, attrRows, "", nil)
 }
 
 // makeIndexedEvent constructs an event from the specified composite key and
@@ -157,82 +257,283 @@ INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
 			return fmt.Errorf("indexing block header: %w", err)
 		}
 
-		// Insert the special block meta-event for height.
-		if err := insertEvents(dbtx, blockID, 0, []abci.Event{
+		// Insert the special block meta-event for height, and all the block
+		// events. Order is important here, as the events follow the meta-event
+		// marking their kind, see GetBlockEvents.
+		events := []abci.Event{
 			makeIndexedEvent(types.BlockHeightKey, fmt.Sprint(h.Header.Height)),
-		}); err != nil {
-			return fmt.Errorf("block meta-events: %w", err)
-		}
-		// Insert all the block events. Order is important here,
-		if err := insertEvents(dbtx, blockID, 0, h.ResultBeginBlock.Events); err != nil {
-			return fmt.Errorf("begin-block events: %w", err)
+			makeIndexedEvent(blockEventsKey, eventTypeBeginBlock),
 		}
-		if err := insertEvents(dbtx, blockID, 0, h.ResultEndBlock.Events); err != nil {
-			return fmt.Errorf("end-block events: %w", err)
+		events = append(events, h.ResultBeginBlock.Events...)
+		events = append(events, makeIndexedEvent(blockEventsKey, eventTypeEndBlock))
+		events = append(events, h.ResultEndBlock.Events...)
+		if err := insertEvents(dbtx, eventGroup{blockID: blockID, events: events}); err != nil {
+			return fmt.Errorf("indexing block events: %w", err)
 		}
 		return nil
 	})
 }
 
+// marshalResult returns the JSON encoding of res. PostgreSQL doesn't support
+// NUL characters in JSONB values, so those of the log and info, which are free
+// form, are replaced with U+FFFD.
+func marshalResult(res abci.ResponseDeliverTx) ([]byte, error) {
+	res.Log = strings.ReplaceAll(res.Log, "\x00", "\uFFFD")
+	res.Info = strings.ReplaceAll(res.Info, "\x00", "\uFFFD")
+	return json.Marshal(res)
+}
+
+// IndexTxEvents indexes the specified transaction results, part of the
+// indexer.EventSink interface. The results are indexed in a single
+// transaction, with batched inserts.
 func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	if len(txrs) == 0 {
+		return nil
+	}
 	ts := time.Now().UTC()
 
-	for _, txr := range txrs {
-		// Encode the result message in protobuf wire format for indexing.
-		resultData, err := proto.Marshal(txr)
-		if err != nil {
-			return fmt.Errorf("marshaling tx_result: %w", err)
-		}
-
-		// Index the hash of the underlying transaction as a hex string.
-		txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+	type txKey struct {
+		blockID uint32
+		index   uint32
+	}
+	txRows := make([][]interface{}, 0, len(txrs))
+	txResults := make(map[txKey]*abci.TxResult, len(txrs))
+	txHashes := make(map[txKey]string, len(txrs))
 
-		if err := runInTransaction(es.store, func(dbtx *sql.Tx) error {
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		blockIDs := make(map[int64]uint32)
+		for _, txr := range txrs {
 			// Find the block associated with this transaction. The block header
 			// must have been indexed prior to the transactions belonging to it.
-			blockID, err := queryWithID(dbtx, `
+			blockID, ok := blockIDs[txr.Height]
+			if !ok {
+				var err error
+				blockID, err = queryWithID(dbtx, `
 SELECT rowid FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2;
 `, txr.Height, es.chainID)
-			if err != nil {
-				return fmt.Errorf("finding block ID: %w", err)
+				if err != nil {
+					return fmt.Errorf("finding block ID: %w", err)
+				}
+				blockIDs[txr.Height] = blockID
 			}
 
-			// Insert a record for this tx_result and capture its ID for indexing events.
-			txID, err := queryWithID(dbtx, `
-INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
-  VALUES ($1, $2, $3, $4, $5)
-  ON CONFLICT DO NOTHING
-  RETURNING rowid;
-`, blockID, txr.Index, ts, txHash, resultData)
-			if err == sql.ErrNoRows {
-				return nil // we already saw this transaction; quietly succeed
-			} else if err != nil {
-				return fmt.Errorf("indexing tx_result: %w", err)
+			// Encode the result message in protobuf wire format, and the result
+			// of the transaction as JSON, for indexing.
+			resultData, err := proto.Marshal(txr)
+			if err != nil {
+				return fmt.Errorf("marshaling tx_result: %w", err)
+			}
+			resultJSON, err := marshalResult(txr.Result)
+			if err != nil {
+				return fmt.Errorf("marshaling result: %w", err)
 			}
 
-			// Insert the special transaction meta-events for hash and height.
-			if err := insertEvents(dbtx, blockID, txID, []abci.Event{
-				makeIndexedEvent(types.TxHashKey, txHash),
-				makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
+			// Index the hash of the underlying transaction as a hex string.
+			txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+
+			key := txKey{blockID: blockID, index: txr.Index}
+			txRows = append(txRows, []interface{}{blockID, txr.Index, ts, txHash, resultData, string(resultJSON)})
+			txResults[key] = txr
+			txHashes[key] = txHash
+		}
+
+		// Insert the records of the tx_results, and capture the IDs of those
+		// not already indexed for indexing their events.
+		var groups []eventGroup
+		if err := insertRows(dbtx, tableTxResults,
+			[]string{"block_id", "index", "created_at", "tx_hash", "tx_result", "result"}, txRows,
+			"ON CONFLICT DO NOTHING RETURNING rowid, block_id, index",
+			func(res *sql.Rows) error {
+				var txID uint32
+				var key txKey
+				if err := res.Scan(&txID, &key.blockID, &key.index); err != nil {
+					return err
+				}
+				txr := txResults[key]
+				// Insert the special transaction meta-events for hash and
+				// height, and any events packaged with the transaction.
+				events := []abci.Event{
+					makeIndexedEvent(types.TxHashKey, txHashes[key]),
+					makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
+				}
+				groups = append(groups, eventGroup{
+					blockID: key.blockID,
+					txID:    txID,
+					events:  append(events, txr.Result.Events...),
+				})
+				return nil
 			}); err != nil {
-				return fmt.Errorf("indexing transaction meta-events: %w", err)
-			}
-			// Index any events packaged with the transaction.
-			if err := insertEvents(dbtx, blockID, txID, txr.Result.Events); err != nil {
-				return fmt.Errorf("indexing transaction events: %w", err)
+			return fmt.Errorf("indexing tx_results: %w", err)
+		}
+
+		if err := insertEvents(dbtx, groups...); err != nil {
+			return fmt.Errorf("indexing transaction events: %w", err)
+		}
+		return nil
+	})
+}
+
+// SearchBlockEvents returns the heights of the blocks whose events match q,
+// part of the indexer.EventSink interface. Time and date arguments are not
+// supported.
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
+	sqlQuery := `SELECT height FROM ` + tableBlocks + ` WHERE chain_id = $1`
+	args := []interface{}{es.chainID}
+	for _, c := range q.Syntax() {
+		var (
+			cond string
+			err  error
+		)
+		cond, args, err = makeCondition(c, args)
+		if err != nil {
+			return nil, err
+		}
+		sqlQuery += "\n  AND rowid IN (SELECT block_id FROM " + viewBlockEvents + " WHERE " + cond + ")"
+	}
+
+	rows, err := es.store.QueryContext(ctx, sqlQuery+";", args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	defer rows.Close()
+
+	heights := make([]int64, 0)
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, err
+		}
+		heights = append(heights, height)
+	}
+	return heights, rows.Err()
+}
+
+// numericValue is the value of an attribute as a number, or NULL if it is not
+// a number. CASE guarantees that the value is only cast if it is a number.
+const numericValue = `(CASE WHEN value ~ '^-?[0-9]+(\.[0-9]+)?$' THEN value::numeric END)`
+
+// makeCondition returns the SQL condition on the block_events view matching
+// c, appending its arguments to args.
+func makeCondition(c syntax.Condition, args []interface{}) (string, []interface{}, error) {
+	param := func(v string) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	compare := func(op string, arg *syntax.Arg) (string, error) {
+		switch arg.Type {
+		case syntax.TNumber:
+			return numericValue + " " + op + " " + param(arg.Value()) + "::numeric", nil
+		case syntax.TString:
+			if op != "=" {
+				return "", fmt.Errorf("%s: string arguments can only be compared for equality", c)
 			}
-			return nil
+			return "value = " + param(arg.Value()), nil
+		default:
+			return "", fmt.Errorf("%s: %v arguments are not supported via the postgres event sink", c, arg.Type)
+		}
+	}
 
-		}); err != nil {
-			return err
+	cond := "composite_key = " + param(c.Tag)
+	switch c.Op {
+	case syntax.TExists:
+		return cond, args, nil
+	case syntax.TContains:
+		return cond + " AND strpos(value, " + param(c.Arg.Value()) + ") > 0", args, nil
+	case syntax.TStartsWith:
+		prefix := param(c.Arg.Value())
+		return cond + " AND left(value, length(" + prefix + ")) = " + prefix, args, nil
+	case syntax.TIn:
+		alternatives := make([]string, len(c.Args))
+		for i, arg := range c.Args {
+			alt, err := compare("=", arg)
+			if err != nil {
+				return "", nil, err
+			}
+			alternatives[i] = alt
 		}
+		return cond + " AND (" + strings.Join(alternatives, " OR ") + ")", args, nil
 	}
-	return nil
+
+	op, ok := map[syntax.Token]string{
+		syntax.TEq:  "=",
+		syntax.TLt:  "<",
+		syntax.TLeq: "<=",
+		syntax.TGt:  ">",
+		syntax.TGeq: ">=",
+	}[c.Op]
+	if !ok {
+		return "", nil, fmt.Errorf("%s: unsupported operator %v", c, c.Op)
+	}
+	comparison, err := compare(op, c.Arg)
+	if err != nil {
+		return "", nil, err
+	}
+	return cond + " AND " + comparison, args, nil
 }
 
-// SearchBlockEvents is not implemented by this sink, and reports an error for all queries.
-func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
-	return nil, errors.New("block search is not supported via the postgres event sink")
+// GetBlockEvents returns the BeginBlock and EndBlock events of the block at
+// the given height, or nil if the height has not been indexed. Only the
+// attributes which are indexed are stored, and the events of the blocks
+// indexed before the events were marked are not returned.
+func (es *EventSink) GetBlockEvents(height int64) (*indexer.BlockEvents, error) {
+	rows, err := es.store.Query(`
+SELECT events.rowid, events.type, attributes.key, attributes.composite_key, attributes.value
+  FROM `+tableBlocks+` JOIN `+tableEvents+` ON (`+tableBlocks+`.rowid = `+tableEvents+`.block_id)
+  LEFT JOIN `+tableAttributes+` ON (`+tableEvents+`.rowid = `+tableAttributes+`.event_id)
+  WHERE height = $1 AND chain_id = $2 AND tx_id IS NULL
+  ORDER BY events.rowid;
+`, height, es.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("loading block events: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		events  *indexer.BlockEvents
+		current *[]abci.Event
+		lastID  int64 = -1
+	)
+	for rows.Next() {
+		var (
+			id                       int64
+			typ                      string
+			key, compositeKey, value sql.NullString
+		)
+		if err := rows.Scan(&id, &typ, &key, &compositeKey, &value); err != nil {
+			return nil, err
+		}
+		switch compositeKey.String {
+		case types.BlockHeightKey:
+			continue
+		case blockEventsKey:
+			if events == nil {
+				events = &indexer.BlockEvents{}
+			}
+			switch value.String {
+			case eventTypeBeginBlock:
+				current = &events.BeginBlock
+			case eventTypeEndBlock:
+				current = &events.EndBlock
+			default:
+				current = nil
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if id != lastID {
+			*current = append(*current, abci.Event{Type: typ})
+			lastID = id
+		}
+		if key.Valid {
+			evt := &(*current)[len(*current)-1]
+			evt.Attributes = append(evt.Attributes, abci.EventAttribute{
+				Key: key.String, Value: value.String, Index: true,
+			})
+		}
+	}
+	return events, rows.Err()
 }
 
 // SearchTxEvents is not implemented by this sink, and reports an error for all queries.
@@ -245,9 +546,34 @@ func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
 	return nil, errors.New("getTxByHash is not supported via the postgres event sink")
 }
 
-// HasBlock is not implemented by this sink, and reports an error for all queries.
+// HasBlock returns whether the block at height h has been indexed.
 func (es *EventSink) HasBlock(h int64) (bool, error) {
-	return false, errors.New("hasBlock is not supported via the postgres event sink")
+	var found bool
+	if err := es.store.QueryRow(`
+SELECT EXISTS (SELECT 1 FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2);
+`, h, es.chainID).Scan(&found); err != nil {
+		return false, fmt.Errorf("looking up block: %w", err)
+	}
+	return found, nil
+}
+
+// DeleteHeight deletes the block at height h, its txs and their events.
+func (es *EventSink) DeleteHeight(h int64) error {
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		blocks := `SELECT rowid FROM ` + tableBlocks + ` WHERE height = $1 AND chain_id = $2`
+		for _, query := range []string{
+			`DELETE FROM ` + tableAttributes + ` WHERE event_id IN
+  (SELECT rowid FROM ` + tableEvents + ` WHERE block_id IN (` + blocks + `));`,
+			`DELETE FROM ` + tableEvents + ` WHERE block_id IN (` + blocks + `);`,
+			`DELETE FROM ` + tableTxResults + ` WHERE block_id IN (` + blocks + `);`,
+			`DELETE FROM ` + tableBlocks + ` WHERE height = $1 AND chain_id = $2;`,
+		} {
+			if _, err := dbtx.Exec(query, h, es.chainID); err != nil {
+				return fmt.Errorf("deleting block: %w", err)
+			}
+		}
+		return nil
+	})
 }
 
 // Stop closes the underlying PostgreSQL database.
@@ -11,7 +11,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/adlio/schema"
 	"github.com/cosmos/gogoproto/proto"
 	"github.com/ory/dockertest"
 	"github.com/ory/dockertest/docker"
@@ -19,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/pubsub/query"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/types"
 
@@ -43,8 +43,7 @@ const (
 	dbName   = "postgres"
 	chainID  = "test-chainID"
 
-	viewBlockEvents = "block_events"
-	viewTxEvents    = "tx_events"
+	viewTxEvents = "tx_events"
 )
 
 func TestMain(m *testing.M) {
@@ -105,14 +104,13 @@ func TestMain(m *testing.M) {
 		log.Fatalf("Flushing database: %v", err)
 	}
 
-	sm, err := readSchema()
-	if err != nil {
-		log.Fatalf("Reading schema: %v", err)
-	}
-	migrator := schema.NewMigrator()
-	if err := migrator.Apply(db, sm); err != nil {
+	if err := migrate(db); err != nil {
 		log.Fatalf("Applying schema: %v", err)
 	}
+	// the migrations are applied once
+	if err := migrate(db); err != nil {
+		log.Fatalf("Applying schema again: %v", err)
+	}
 
 	// Set up the hook for tests to get the shared database handle.
 	testDB = func() *sql.DB { return db }
@@ -145,13 +143,41 @@ func TestIndexing(t *testing.T) {
 		verifyBlock(t, 1)
 		verifyBlock(t, 2)
 
-		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(1) })
-		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(2) })
+		ok, err := indexer.HasBlock(1)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = indexer.HasBlock(2)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		for q, match := range map[string]bool{
+			"block.height = 1":                                     true,
+			"block.height > 1":                                     false,
+			"begin_event.proposer = 'FCAA001'":                     true,
+			"end_event.foo >= 100 AND end_event.foo < 101":         true,
+			"end_event.foo > 100":                                  false,
+			"thingy.whatzit CONTAINS '-'":                          true,
+			"begin_event.proposer STARTS_WITH 'FC'":                true,
+			"begin_event.proposer IN ('A', 'FCAA001')":             true,
+			"begin_event.proposer EXISTS AND thingy.whatzit = 'X'": false,
+		} {
+			heights, err := indexer.SearchBlockEvents(context.Background(), query.MustCompile(q))
+			require.NoError(t, err, q)
+			if match {
+				assert.Equal(t, []int64{1}, heights, q)
+			} else {
+				assert.Empty(t, heights, q)
+			}
+		}
 
-		verifyNotImplemented(t, "block search", func() (bool, error) {
-			v, err := indexer.SearchBlockEvents(context.Background(), nil)
-			return v != nil, err
-		})
+		events, err := indexer.GetBlockEvents(1)
+		require.NoError(t, err)
+		header := newTestBlockHeader()
+		assert.Equal(t, header.ResultBeginBlock.Events, events.BeginBlock)
+		assert.Equal(t, header.ResultEndBlock.Events, events.EndBlock)
+		events, err = indexer.GetBlockEvents(2)
+		require.NoError(t, err)
+		assert.Nil(t, events)
 
 		require.NoError(t, verifyTimeStamp(tableBlocks))
 
@@ -198,6 +224,50 @@ func TestIndexing(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("IndexTxEventsBatch", func(t *testing.T) {
+		indexer := &EventSink{store: testDB(), chainID: chainID}
+		require.NoError(t, indexer.IndexBlockEvents(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: 5},
+		}))
+
+		// the batch includes a duplicate, which is indexed once
+		var txrs []*abci.TxResult
+		for i := uint32(0); i < 3; i++ {
+			txrs = append(txrs, &abci.TxResult{
+				Height: 5,
+				Index:  i,
+				Tx:     types.Tx(fmt.Sprintf("batch %d", i)),
+				Result: abci.ResponseDeliverTx{
+					Code:      i,
+					Codespace: "bank",
+					Log:       "a\x00log",
+					GasUsed:   int64(10 * i),
+					Events:    []abci.Event{makeIndexedEvent("transfer.amount", fmt.Sprint(i))},
+				},
+			})
+		}
+		require.NoError(t, indexer.IndexTxEvents(append(txrs, txrs[1])))
+
+		for i, txr := range txrs {
+			var (
+				code, gasUsed int64
+				codespace     string
+				events        int
+			)
+			require.NoError(t, testDB().QueryRow(`
+SELECT code, codespace, gas_used FROM `+tableTxResults+` WHERE tx_hash = $1;
+`, fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())).Scan(&code, &codespace, &gasUsed))
+			assert.Equal(t, int64(i), code)
+			assert.Equal(t, "bank", codespace)
+			assert.Equal(t, int64(10*i), gasUsed)
+
+			require.NoError(t, testDB().QueryRow(`
+SELECT count(*) FROM `+viewTxEvents+` WHERE height = 5 AND index = $1 AND composite_key = 'transfer.amount';
+`, i).Scan(&events))
+			assert.Equal(t, 1, events)
+		}
+	})
+
 	t.Run("IndexerService", func(t *testing.T) {
 		indexer := &EventSink{store: testDB(), chainID: chainID}
 
@@ -273,23 +343,9 @@ func newTestBlockHeader() types.EventDataNewBlockHeader {
 	}
 }
 
-// readSchema loads the indexing database schema file
-func readSchema() ([]*schema.Migration, error) {
-	const filename = "schema.sql"
-	contents, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read sql file from '%s': %w", filename, err)
-	}
-
-	return []*schema.Migration{{
-		ID:     time.Now().Local().String() + " db schema",
-		Script: string(contents),
-	}}, nil
-}
-
 // resetDB drops all the data from the test database.
 func resetDatabase(db *sql.DB) error {
-	_, err := db.Exec(`DROP TABLE IF EXISTS blocks,tx_results,events,attributes CASCADE;`)
+	_, err := db.Exec(`DROP TABLE IF EXISTS blocks,tx_results,events,attributes,` + migrationsTable + ` CASCADE;`)
 	if err != nil {
 		return fmt.Errorf("dropping tables: %v", err)
 	}
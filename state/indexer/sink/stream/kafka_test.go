@@ -0,0 +1,71 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaPublisher(t *testing.T) {
+	type request struct {
+		topic   string
+		records []kafkaRecord
+	}
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "secret", password)
+		assert.Equal(t, kafkaContentType, r.Header.Get("Content-Type"))
+
+		var body struct {
+			Records []kafkaRecord `json:"records"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		topic := r.URL.Path[len("/topics/"):]
+		requests = append(requests, request{topic: topic, records: body.Records})
+
+		if topic == "failing" {
+			fmt.Fprint(w, `{"offsets":[{"partition":null,"offset":null,"error_code":50002,"error":"not leader"}]}`)
+			return
+		}
+		if topic == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error_code":40401,"message":"Topic not found."}`)
+			return
+		}
+		offsets := make([]map[string]int, len(body.Records))
+		for i := range offsets {
+			offsets[i] = map[string]int{"partition": 0, "offset": i}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"offsets": offsets}))
+	}))
+	defer srv.Close()
+
+	p, err := NewKafkaPublisher("http://user:secret@" + srv.Listener.Addr().String())
+	require.NoError(t, err)
+	defer p.Close()
+
+	require.NoError(t, p.Publish(context.Background(), []Message{
+		{Topic: "blocks", ID: "1", Key: "chain", Payload: []byte(`{"height":1}`)},
+		{Topic: "txs", ID: "1/0", Key: "chain", Payload: []byte(`{"index":0}`)},
+		{Topic: "txs", ID: "1/1", Key: "chain", Payload: []byte(`{"index":1}`)},
+	}))
+	require.Len(t, requests, 2)
+	assert.Equal(t, request{topic: "blocks", records: []kafkaRecord{
+		{Key: "chain", Value: json.RawMessage(`{"height":1}`)},
+	}}, requests[0])
+	assert.Equal(t, "txs", requests[1].topic)
+	assert.Len(t, requests[1].records, 2)
+
+	err = p.Publish(context.Background(), []Message{{Topic: "failing", ID: "2", Payload: []byte(`{}`)}})
+	assert.ErrorContains(t, err, "not leader")
+	err = p.Publish(context.Background(), []Message{{Topic: "missing", ID: "2", Payload: []byte(`{}`)}})
+	assert.ErrorContains(t, err, "Topic not found.")
+}
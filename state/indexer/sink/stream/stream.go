@@ -0,0 +1,367 @@
+// Package stream implements an event sink publishing the block and tx events
+// to a message broker, Kafka, so that they can be consumed without polling the
+// RPC.
+//
+// Every block and every tx is published as a JSON payload, versioned with
+// PayloadVersion. The messages are published with at-least-once delivery: a
+// message is only considered published once the broker acknowledged it, so
+// consumers may see duplicates. Every message has a unique ID (see BlockID and
+// TxID), which consumers can use to deduplicate the messages.
+//
+// As the indexing is done in-line with consensus, publishing a height is only
+// attempted a few times. If the broker is still unavailable, the height is left
+// unindexed, and the sink fails fast, without contacting the broker, for a
+// backoff growing with the consecutive failures. The heights left unindexed are
+// published again by the backfill of the indexer (see txindex.Backfiller),
+// which must be enabled.
+//
+// The sink is write-only: the searches are not supported.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/state/indexer"
+	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/types"
+)
+
+// PayloadVersion is the version of the schema of the payloads. It is bumped
+// on every incompatible change of BlockPayload or TxPayload.
+const PayloadVersion = 1
+
+const (
+	// DefaultBlockTopic is the default topic of the block payloads.
+	DefaultBlockTopic = "cometbft.blocks"
+	// DefaultTxTopic is the default topic of the tx payloads.
+	DefaultTxTopic = "cometbft.txs"
+
+	publishTimeout  = 5 * time.Second
+	publishAttempts = 3
+	// retryBackoff is the backoff between the attempts of a publish, doubled
+	// after every attempt.
+	retryBackoff = 100 * time.Millisecond
+	// initialBackoff and maxBackoff bound the time for which the sink fails
+	// fast after a publish failed, doubled after every consecutive failure.
+	initialBackoff = time.Second
+	maxBackoff     = time.Minute
+)
+
+// Message is a message to publish.
+type Message struct {
+	Topic string
+	// ID identifies the message, for deduplication.
+	ID string
+	// Key is the partitioning key of the message, if supported by the broker.
+	Key     string
+	Payload []byte
+}
+
+// Publisher publishes messages to a broker.
+type Publisher interface {
+	// Publish publishes the messages, in order, and returns once all of them
+	// have been acknowledged by the broker. On error, some of the messages may
+	// have been published.
+	Publish(ctx context.Context, msgs []Message) error
+	Close() error
+}
+
+// BlockPayload is the payload of the messages published for every block.
+type BlockPayload struct {
+	Version          int          `json:"version"`
+	ChainID          string       `json:"chain_id"`
+	Height           int64        `json:"height"`
+	Time             time.Time    `json:"time"`
+	NumTxs           int64        `json:"num_txs"`
+	BeginBlockEvents []abci.Event `json:"begin_block_events"`
+	EndBlockEvents   []abci.Event `json:"end_block_events"`
+}
+
+// TxPayload is the payload of the messages published for every tx.
+type TxPayload struct {
+	Version int                    `json:"version"`
+	ChainID string                 `json:"chain_id"`
+	Height  int64                  `json:"height"`
+	Index   uint32                 `json:"index"`
+	Hash    string                 `json:"hash"`
+	Tx      []byte                 `json:"tx"`
+	Result  abci.ResponseDeliverTx `json:"result"`
+}
+
+// BlockID returns the ID of the message of a block.
+func BlockID(chainID string, height int64) string {
+	return fmt.Sprintf("%s/%d", chainID, height)
+}
+
+// TxID returns the ID of the message of a tx.
+func TxID(chainID string, height int64, index uint32) string {
+	return fmt.Sprintf("%s/%d/%d", chainID, height, index)
+}
+
+// EventSink publishes the block and tx events with a Publisher.
+type EventSink struct {
+	publisher  Publisher
+	chainID    string
+	blockTopic string
+	txTopic    string
+
+	retryBackoff   time.Duration
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	mtx      sync.Mutex
+	failures int       // consecutive failed publishes
+	retryAt  time.Time // the sink fails fast until then
+
+	quit     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewEventSink returns an event sink publishing the events of the given chain
+// to blockTopic and txTopic.
+func NewEventSink(publisher Publisher, chainID, blockTopic, txTopic string) *EventSink {
+	return &EventSink{
+		publisher:  publisher,
+		chainID:    chainID,
+		blockTopic: blockTopic,
+		txTopic:    txTopic,
+
+		retryBackoff:   retryBackoff,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+
+		quit: make(chan struct{}),
+	}
+}
+
+// NewEventSinkFromURL returns an event sink publishing to Kafka, through a
+// Kafka REST proxy, if kind is "kafka".
+func NewEventSinkFromURL(kind, url, chainID, blockTopic, txTopic string) (*EventSink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("the %s url cannot be empty", kind)
+	}
+	if blockTopic == "" {
+		blockTopic = DefaultBlockTopic
+	}
+	if txTopic == "" {
+		txTopic = DefaultTxTopic
+	}
+
+	var (
+		publisher Publisher
+		err       error
+	)
+	switch kind {
+	case "kafka":
+		publisher, err = NewKafkaPublisher(url)
+	default:
+		return nil, fmt.Errorf("unsupported stream sink type: %s", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewEventSink(publisher, chainID, blockTopic, txTopic), nil
+}
+
+// IndexBlockEvents publishes the events of a block.
+func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	payload, err := json.Marshal(BlockPayload{
+		Version:          PayloadVersion,
+		ChainID:          es.chainID,
+		Height:           h.Header.Height,
+		Time:             h.Header.Time,
+		NumTxs:           h.NumTxs,
+		BeginBlockEvents: h.ResultBeginBlock.Events,
+		EndBlockEvents:   h.ResultEndBlock.Events,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding block payload: %w", err)
+	}
+	return es.publish([]Message{{
+		Topic:   es.blockTopic,
+		ID:      BlockID(es.chainID, h.Header.Height),
+		Key:     es.chainID,
+		Payload: payload,
+	}})
+}
+
+// IndexTxEvents publishes the results of txs.
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	if len(txrs) == 0 {
+		return nil
+	}
+	msgs := make([]Message, len(txrs))
+	for i, txr := range txrs {
+		payload, err := json.Marshal(TxPayload{
+			Version: PayloadVersion,
+			ChainID: es.chainID,
+			Height:  txr.Height,
+			Index:   txr.Index,
+			Hash:    fmt.Sprintf("%X", types.Tx(txr.Tx).Hash()),
+			Tx:      txr.Tx,
+			Result:  txr.Result,
+		})
+		if err != nil {
+			return fmt.Errorf("encoding tx payload: %w", err)
+		}
+		msgs[i] = Message{
+			Topic:   es.txTopic,
+			ID:      TxID(es.chainID, txr.Height, txr.Index),
+			Key:     es.chainID,
+			Payload: payload,
+		}
+	}
+	return es.publish(msgs)
+}
+
+// publish publishes the messages, in up to publishAttempts attempts. If they
+// all fail, the sink is unavailable for a backoff, during which publish fails
+// fast with errUnavailable, and then publishes in a single attempt until it
+// succeeds again. Either way, the height being published is not recorded as
+// indexed, and is published again by the backfill.
+func (es *EventSink) publish(msgs []Message) error {
+	es.mtx.Lock()
+	attempts, retryAt := publishAttempts, es.retryAt
+	if es.failures > 0 {
+		attempts = 1
+	}
+	es.mtx.Unlock()
+	if now := time.Now(); now.Before(retryAt) {
+		return fmt.Errorf("publishing %d messages: %w for %v", len(msgs), errUnavailable, retryAt.Sub(now))
+	}
+
+	var err error
+	backoff := es.retryBackoff
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-es.quit:
+				return fmt.Errorf("publishing %d messages: %w (last error: %v)", len(msgs), errStopped, err)
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		if err = es.tryPublish(msgs); err == nil {
+			break
+		}
+	}
+
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+	if err == nil {
+		es.failures, es.retryAt = 0, time.Time{}
+		return nil
+	}
+	es.failures++
+	backoff = es.maxBackoff
+	if es.failures <= 32 && es.initialBackoff<<(es.failures-1) < es.maxBackoff {
+		backoff = es.initialBackoff << (es.failures - 1)
+	}
+	es.retryAt = time.Now().Add(backoff)
+	return fmt.Errorf("publishing %d messages: %w", len(msgs), err)
+}
+
+// tryPublish makes a single attempt at publishing the messages, interrupted if
+// the sink is stopped.
+func (es *EventSink) tryPublish(msgs []Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-es.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return es.publisher.Publish(ctx, msgs)
+}
+
+// Stop interrupts the publishing in progress and closes the publisher.
+func (es *EventSink) Stop() error {
+	var err error
+	es.stopOnce.Do(func() {
+		close(es.quit)
+		err = es.publisher.Close()
+	})
+	return err
+}
+
+// TxIndexer returns the sink as a txindex.TxIndexer.
+func (es *EventSink) TxIndexer() TxIndexer {
+	return TxIndexer{es: es}
+}
+
+// BlockIndexer returns the sink as an indexer.BlockIndexer.
+func (es *EventSink) BlockIndexer() BlockIndexer {
+	return BlockIndexer{es: es}
+}
+
+var (
+	errNotSupported = errors.New("not supported by the stream event sink")
+	errStopped      = errors.New("stream event sink stopped")
+	errUnavailable  = errors.New("stream event sink unavailable")
+
+	_ txindex.TxIndexer    = TxIndexer{}
+	_ indexer.BlockIndexer = BlockIndexer{}
+)
+
+// TxIndexer implements the txindex.TxIndexer interface by publishing the txs
+// with an EventSink. Get and Search are not supported.
+type TxIndexer struct{ es *EventSink }
+
+// AddBatch publishes a batch of txs, as part of TxIndexer.
+func (t TxIndexer) AddBatch(batch *txindex.Batch) error {
+	return t.es.IndexTxEvents(batch.Ops)
+}
+
+// Index publishes a single tx, as part of TxIndexer.
+func (t TxIndexer) Index(txr *abci.TxResult) error {
+	return t.es.IndexTxEvents([]*abci.TxResult{txr})
+}
+
+// Stop stops the sink, interrupting the publishing in progress.
+func (t TxIndexer) Stop() error { return t.es.Stop() }
+
+// Get reports an error for all inputs.
+func (TxIndexer) Get([]byte) (*abci.TxResult, error) {
+	return nil, fmt.Errorf("TxIndexer.Get: %w", errNotSupported)
+}
+
+// Search reports an error for all inputs.
+func (TxIndexer) Search(context.Context, *query.Query) ([]*abci.TxResult, error) {
+	return nil, fmt.Errorf("TxIndexer.Search: %w", errNotSupported)
+}
+
+// BlockIndexer implements the indexer.BlockIndexer interface by publishing the
+// blocks with an EventSink. Only Index is supported.
+type BlockIndexer struct{ es *EventSink }
+
+// Stop stops the sink, interrupting the publishing in progress.
+func (b BlockIndexer) Stop() error { return b.es.Stop() }
+
+// Has reports an error for all inputs.
+func (BlockIndexer) Has(int64) (bool, error) {
+	return false, fmt.Errorf("BlockIndexer.Has: %w", errNotSupported)
+}
+
+// GetEvents reports an error for all inputs.
+func (BlockIndexer) GetEvents(int64) (*indexer.BlockEvents, error) {
+	return nil, fmt.Errorf("BlockIndexer.GetEvents: %w", errNotSupported)
+}
+
+// Index publishes the events of a block, as part of BlockIndexer.
+func (b BlockIndexer) Index(h types.EventDataNewBlockHeader) error {
+	return b.es.IndexBlockEvents(h)
+}
+
+// Search reports an error for all inputs.
+func (BlockIndexer) Search(context.Context, *query.Query) ([]int64, error) {
+	return nil, fmt.Errorf("BlockIndexer.Search: %w", errNotSupported)
+}
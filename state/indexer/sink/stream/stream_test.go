@@ -0,0 +1,163 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/types"
+)
+
+type testPublisher struct {
+	failures int
+	msgs     []Message
+}
+
+func (p *testPublisher) Publish(_ context.Context, msgs []Message) error {
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("unavailable")
+	}
+	p.msgs = append(p.msgs, msgs...)
+	return nil
+}
+
+func (p *testPublisher) Close() error { return nil }
+
+func TestEventSink(t *testing.T) {
+	publisher := &testPublisher{failures: 2}
+	es := NewEventSink(publisher, "test-chain", "blocks", "txs")
+	es.retryBackoff = time.Millisecond
+
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, es.BlockIndexer().Index(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 3, Time: ts},
+		NumTxs: 2,
+		ResultEndBlock: abci.ResponseEndBlock{Events: []abci.Event{
+			{Type: "slash", Attributes: []abci.EventAttribute{{Key: "validator", Value: "FCAA001"}}},
+		}},
+	}))
+
+	batch := txindex.NewBatch(2)
+	for i := uint32(0); i < 2; i++ {
+		require.NoError(t, batch.Add(&abci.TxResult{
+			Height: 3,
+			Index:  i,
+			Tx:     types.Tx{byte(i)},
+			Result: abci.ResponseDeliverTx{Code: i, GasUsed: 10},
+		}))
+	}
+	require.NoError(t, es.TxIndexer().AddBatch(batch))
+
+	// the failures are retried
+	require.Len(t, publisher.msgs, 3)
+	assert.Equal(t, Message{Topic: "blocks", ID: "test-chain/3", Key: "test-chain",
+		Payload: publisher.msgs[0].Payload}, publisher.msgs[0])
+	var block BlockPayload
+	require.NoError(t, json.Unmarshal(publisher.msgs[0].Payload, &block))
+	assert.Equal(t, BlockPayload{
+		Version:        PayloadVersion,
+		ChainID:        "test-chain",
+		Height:         3,
+		Time:           ts,
+		NumTxs:         2,
+		EndBlockEvents: []abci.Event{{Type: "slash", Attributes: []abci.EventAttribute{{Key: "validator", Value: "FCAA001"}}}},
+	}, block)
+
+	for i, msg := range publisher.msgs[1:] {
+		assert.Equal(t, "txs", msg.Topic)
+		assert.Equal(t, TxID("test-chain", 3, uint32(i)), msg.ID)
+		var tx TxPayload
+		require.NoError(t, json.Unmarshal(msg.Payload, &tx))
+		assert.Equal(t, PayloadVersion, tx.Version)
+		assert.Equal(t, uint32(i), tx.Index)
+		assert.Equal(t, uint32(i), tx.Result.Code)
+		assert.Equal(t, fmt.Sprintf("%X", types.Tx{byte(i)}.Hash()), tx.Hash)
+	}
+
+	// the searches are not supported
+	_, err := es.TxIndexer().Get([]byte{1})
+	assert.ErrorIs(t, err, errNotSupported)
+	_, err = es.BlockIndexer().Search(context.Background(), nil)
+	assert.ErrorIs(t, err, errNotSupported)
+
+	// the publish is only attempted publishAttempts times
+	es.initialBackoff, es.maxBackoff = 50*time.Millisecond, 100*time.Millisecond
+	publisher.failures = publishAttempts
+	err = es.TxIndexer().Index(&abci.TxResult{Height: 4})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, errUnavailable)
+	require.Len(t, publisher.msgs, 3)
+
+	// the sink then fails fast, without publishing, for the backoff
+	publisher.failures = 1
+	err = es.TxIndexer().Index(&abci.TxResult{Height: 4})
+	assert.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, 1, publisher.failures)
+
+	// and then publishes in a single attempt, failing for a longer backoff
+	time.Sleep(50 * time.Millisecond)
+	require.Error(t, es.TxIndexer().Index(&abci.TxResult{Height: 4}))
+	assert.Zero(t, publisher.failures)
+	assert.ErrorIs(t, es.TxIndexer().Index(&abci.TxResult{Height: 4}), errUnavailable)
+
+	// until it succeeds
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, es.TxIndexer().Index(&abci.TxResult{Height: 4}))
+	require.Len(t, publisher.msgs, 4)
+	assert.Equal(t, TxID("test-chain", 4, 0), publisher.msgs[3].ID)
+	publisher.failures = 1
+	es.retryBackoff = time.Millisecond
+	require.NoError(t, es.TxIndexer().Index(&abci.TxResult{Height: 5}))
+	require.Len(t, publisher.msgs, 5)
+}
+
+func TestEventSinkStop(t *testing.T) {
+	publisher := &testPublisher{failures: math.MaxInt}
+	es := NewEventSink(publisher, "test-chain", "blocks", "txs")
+	es.retryBackoff = time.Hour
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- es.TxIndexer().Index(&abci.TxResult{Height: 1})
+	}()
+
+	// the retries of the publish are interrupted by Stop
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, es.BlockIndexer().Stop())
+	require.NoError(t, es.TxIndexer().Stop())
+	select {
+	case err := <-errc:
+		assert.ErrorIs(t, err, errStopped)
+	case <-time.After(time.Second):
+		t.Fatal("publish not interrupted by Stop")
+	}
+	assert.Empty(t, publisher.msgs)
+}
+
+func TestNewEventSinkFromURL(t *testing.T) {
+	es, err := NewEventSinkFromURL("kafka", "http://localhost:8082", "test-chain", "", "")
+	require.NoError(t, err)
+	assert.IsType(t, &KafkaPublisher{}, es.publisher)
+	assert.Equal(t, DefaultBlockTopic, es.blockTopic)
+	assert.Equal(t, DefaultTxTopic, es.txTopic)
+
+	for _, tc := range []struct{ kind, url string }{
+		{"kafka", ""},
+		{"kafka", "localhost:8082"},
+		{"nats", "nats://localhost:4222"},
+		{"amqp", "amqp://localhost"},
+	} {
+		_, err := NewEventSinkFromURL(tc.kind, tc.url, "test-chain", "", "")
+		assert.Error(t, err, tc)
+	}
+}
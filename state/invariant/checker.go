@@ -0,0 +1,296 @@
+// Package invariant checks, in the background, invariants which hold between
+// the stores of the node, so that their corruption is detected before it
+// causes the node to halt.
+package invariant
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/consensus"
+	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/txindex"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// The invariants checked.
+const (
+	// The block store holds the last block of the state, or the next one,
+	// about to be applied.
+	BlockStoreHeight = "block_store_height"
+	// The validator sets of the state store are the ones of the header of the
+	// last block, which is the last block ID of the state.
+	ValidatorsHash = "validators_hash"
+	// The consensus WAL ends no further than the height after the last block
+	// of the state.
+	WALHeight = "wal_height"
+	// The indexed heights are contiguous, and no higher than the block store.
+	IndexerHeights = "indexer_heights"
+)
+
+// Status is the status of an invariant at the last check.
+type Status struct {
+	Name     string
+	Violated bool
+	// The violation, if any.
+	Message string
+	// When the invariant was first found violated, zero if it holds.
+	Since     time.Time
+	CheckedAt time.Time
+}
+
+// Option sets an optional parameter on the Checker.
+type Option func(*Checker)
+
+// WithWAL checks the consensus WAL returned by wal.
+func WithWAL(wal func() consensus.WAL) Option {
+	return func(c *Checker) { c.wal = wal }
+}
+
+// WithIndexerProgress checks the heights indexed, as tracked by progress.
+func WithIndexerProgress(progress *txindex.Progress) Option {
+	return func(c *Checker) { c.progress = progress }
+}
+
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) Option {
+	return func(c *Checker) { c.metrics = metrics }
+}
+
+// check checks an invariant, returning the violation if any.
+type check struct {
+	name string
+	fn   func() error
+}
+
+// Checker is a service checking the invariants at the given interval, which
+// reports the violations through its logger, its metrics and Statuses.
+type Checker struct {
+	service.BaseService
+
+	stateStore sm.Store
+	blockStore sm.BlockStore
+	wal        func() consensus.WAL
+	progress   *txindex.Progress
+	interval   time.Duration
+	metrics    *Metrics
+
+	mtx      cmtsync.Mutex
+	statuses []Status
+}
+
+// NewChecker returns a new Checker.
+func NewChecker(
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	interval time.Duration,
+	options ...Option,
+) *Checker {
+	c := &Checker{
+		stateStore: stateStore,
+		blockStore: blockStore,
+		interval:   interval,
+		metrics:    NopMetrics(),
+	}
+	c.BaseService = *service.NewBaseService(nil, "InvariantChecker", c)
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// OnStart implements service.Service.
+func (c *Checker) OnStart() error {
+	if c.interval <= 0 {
+		return errors.New("invariant check interval must be positive")
+	}
+	go c.checkRoutine()
+	return nil
+}
+
+func (c *Checker) checkRoutine() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.Check()
+		select {
+		case <-ticker.C:
+		case <-c.Quit():
+			return
+		}
+	}
+}
+
+// Statuses returns the status of the invariants at the last check, nil
+// before the first one.
+func (c *Checker) Statuses() []Status {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return append([]Status(nil), c.statuses...)
+}
+
+// Check checks the invariants, and returns their status.
+func (c *Checker) Check() []Status {
+	checks := []check{
+		{BlockStoreHeight, c.checkBlockStoreHeight},
+		{ValidatorsHash, c.checkValidatorsHash},
+	}
+	if c.wal != nil {
+		checks = append(checks, check{WALHeight, c.checkWALHeight})
+	}
+	if c.progress != nil {
+		checks = append(checks, check{IndexerHeights, c.checkIndexerHeights})
+	}
+
+	statuses := make([]Status, 0, len(checks))
+	errs := make([]error, 0, len(checks))
+	for _, check := range checks {
+		statuses = append(statuses, Status{Name: check.name, CheckedAt: cmttime.Now()})
+		errs = append(errs, check.fn())
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	previous := make(map[string]Status, len(c.statuses))
+	for _, status := range c.statuses {
+		previous[status.Name] = status
+	}
+	for i, err := range errs {
+		status := &statuses[i]
+		if err == nil {
+			if previous[status.Name].Violated {
+				c.Logger.Info("Invariant holds again", "invariant", status.Name)
+			}
+			c.metrics.Violated.With("invariant", status.Name).Set(0)
+			continue
+		}
+		status.Violated = true
+		status.Message = err.Error()
+		status.Since = status.CheckedAt
+		if prev := previous[status.Name]; prev.Violated {
+			status.Since = prev.Since
+		} else {
+			c.Logger.Error("Invariant violated", "invariant", status.Name, "err", err)
+		}
+		c.metrics.Violated.With("invariant", status.Name).Set(1)
+		c.metrics.Violations.With("invariant", status.Name).Add(1)
+	}
+	c.statuses = statuses
+	return append([]Status(nil), statuses...)
+}
+
+// checkBlockStoreHeight checks that the block store holds the last block of
+// the state, or the next one, which is saved before the state. The block
+// store is empty after state sync, until the next block is synced.
+func (c *Checker) checkBlockStoreHeight() error {
+	// the state is loaded first, as it is saved last
+	state, err := c.stateStore.Load()
+	if err != nil {
+		return fmt.Errorf("loading the state: %w", err)
+	}
+	height := c.blockStore.Height()
+	if height == 0 {
+		return nil
+	}
+	if height < state.LastBlockHeight || height > state.LastBlockHeight+1 {
+		return fmt.Errorf("block store at height %d, state at height %d", height, state.LastBlockHeight)
+	}
+	if base := c.blockStore.Base(); base > height {
+		return fmt.Errorf("block store base %d above its height %d", base, height)
+	}
+	return nil
+}
+
+// checkValidatorsHash checks that the header of the last block of the state
+// commits to the validator sets saved in the state store, and that its ID is
+// the last block ID of the state. The block is skipped if it was never in
+// the block store, as after state sync.
+func (c *Checker) checkValidatorsHash() error {
+	state, err := c.stateStore.Load()
+	if err != nil {
+		return fmt.Errorf("loading the state: %w", err)
+	}
+	height := state.LastBlockHeight
+	if height == 0 || height < c.blockStore.Base() || c.blockStore.Height() == 0 {
+		return nil
+	}
+	meta := c.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return fmt.Errorf("block %d of the state not found in the block store", height)
+	}
+	if !meta.BlockID.Equals(state.LastBlockID) {
+		return fmt.Errorf("block %d is %v in the block store, %v in the state", height, meta.BlockID, state.LastBlockID)
+	}
+	for _, vals := range []struct {
+		height int64
+		hash   []byte
+	}{
+		{height, meta.Header.ValidatorsHash},
+		{height + 1, meta.Header.NextValidatorsHash},
+	} {
+		valSet, err := c.stateStore.LoadValidators(vals.height)
+		if err != nil {
+			return fmt.Errorf("loading the validators of height %d: %w", vals.height, err)
+		}
+		if hash := valSet.Hash(state.ConsensusParams.Encoding.Canonical); !bytes.Equal(hash, vals.hash) {
+			return fmt.Errorf("validators of height %d have hash %X, %X in the header of block %d",
+				vals.height, hash, vals.hash, height)
+		}
+	}
+	return nil
+}
+
+// checkWALHeight checks that the WAL doesn't end a height further than the
+// one after the last block of the state, which is ended in the WAL before
+// the state is saved.
+func (c *Checker) checkWALHeight() error {
+	state, err := c.stateStore.Load()
+	if err != nil {
+		return fmt.Errorf("loading the state: %w", err)
+	}
+	wal := c.wal()
+	if err := wal.FlushAndSync(); err != nil {
+		return fmt.Errorf("flushing the WAL: %w", err)
+	}
+	height := state.LastBlockHeight + 2
+	rd, found, err := wal.SearchForEndHeight(height, &consensus.WALSearchOptions{IgnoreDataCorruptionErrors: true})
+	if err != nil {
+		return fmt.Errorf("searching the WAL: %w", err)
+	}
+	if rd != nil {
+		rd.Close()
+	}
+	if !found {
+		return nil
+	}
+	// the state may have moved on during the search
+	if state, err = c.stateStore.Load(); err != nil {
+		return fmt.Errorf("loading the state: %w", err)
+	}
+	if state.LastBlockHeight+1 < height {
+		return fmt.Errorf("WAL ends height %d, state at height %d", height, state.LastBlockHeight)
+	}
+	return nil
+}
+
+// checkIndexerHeights checks that the indexer has no gaps, e.g. left while
+// indexing was disabled and not backfilled, and that it is not ahead of the
+// block store, e.g. after a rollback.
+func (c *Checker) checkIndexerHeights() error {
+	indexed := c.progress.Indexed()
+	if missing := c.progress.Missing(); len(missing) > 0 {
+		return fmt.Errorf("heights %d to %d not indexed (%d gaps)", missing[0].From, missing[0].To, len(missing))
+	}
+	if len(indexed) == 0 {
+		return nil
+	}
+	if top, height := indexed[len(indexed)-1].To, c.blockStore.Height(); top > height {
+		return fmt.Errorf("indexed up to height %d, block store at height %d", top, height)
+	}
+	return nil
+}
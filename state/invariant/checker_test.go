@@ -0,0 +1,123 @@
+package invariant
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/consensus"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/libs/log"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/types"
+)
+
+// wal is a WAL ending the heights up to endHeight.
+type wal struct {
+	consensus.WAL
+	endHeight int64
+}
+
+func (w *wal) FlushAndSync() error { return nil }
+
+func (w *wal) SearchForEndHeight(height int64, _ *consensus.WALSearchOptions) (io.ReadCloser, bool, error) {
+	return nil, height <= w.endHeight, nil
+}
+
+func TestChecker(t *testing.T) {
+	vals, _ := types.RandValidatorSet(3, 10)
+	blockID := types.BlockID{Hash: tmhash.Sum([]byte("block")), PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}}
+	state := sm.State{LastBlockHeight: 10, LastBlockID: blockID}
+
+	stateStore := &mocks.Store{}
+	stateStore.On("Load").Return(func() sm.State { return state }, nil)
+	stateStore.On("LoadValidators", int64(10)).Return(vals, nil)
+	stateStore.On("LoadValidators", int64(11)).Return(vals, nil)
+
+	blockStore := &mocks.BlockStore{}
+	height := int64(11)
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(func() int64 { return height })
+	meta := &types.BlockMeta{
+		BlockID: blockID,
+		Header:  types.Header{Height: 10, ValidatorsHash: vals.Hash(types.CanonicalEncodingProto), NextValidatorsHash: vals.Hash(types.CanonicalEncodingProto)},
+	}
+	blockStore.On("LoadBlockMeta", int64(10)).Return(func(int64) *types.BlockMeta { return meta })
+
+	progress, err := txindex.NewProgress(dbm.NewMemDB(), "kv")
+	require.NoError(t, err)
+	for h := int64(1); h <= 10; h++ {
+		require.NoError(t, progress.MarkIndexed(h))
+	}
+
+	w := &wal{endHeight: 11}
+	metrics := NopMetrics()
+	c := NewChecker(stateStore, blockStore, 0,
+		WithWAL(func() consensus.WAL { return w }),
+		WithIndexerProgress(progress),
+		WithMetrics(metrics),
+	)
+	c.SetLogger(log.TestingLogger())
+
+	violated := func() map[string]string {
+		res := make(map[string]string)
+		for _, status := range c.Check() {
+			if status.Violated {
+				res[status.Name] = status.Message
+			}
+		}
+		return res
+	}
+
+	assert.Nil(t, c.Statuses())
+	assert.Empty(t, violated())
+	assert.Len(t, c.Statuses(), 4)
+
+	// the block store lost blocks
+	height = 9
+	assert.Contains(t, violated(), BlockStoreHeight)
+	since := c.Statuses()[0].Since
+	assert.False(t, since.IsZero())
+	assert.Contains(t, violated(), BlockStoreHeight)
+	assert.Equal(t, since, c.Statuses()[0].Since)
+	height = 11
+	assert.Empty(t, violated())
+	assert.True(t, c.Statuses()[0].Since.IsZero())
+
+	// the header doesn't commit to the validators
+	meta = &types.BlockMeta{
+		BlockID: blockID,
+		Header:  types.Header{Height: 10, ValidatorsHash: vals.Hash(types.CanonicalEncodingProto), NextValidatorsHash: tmhash.Sum([]byte("vals"))},
+	}
+	assert.Contains(t, violated(), ValidatorsHash)
+	meta = &types.BlockMeta{BlockID: types.BlockID{Hash: tmhash.Sum([]byte("other"))}, Header: meta.Header}
+	assert.Contains(t, violated(), ValidatorsHash)
+	meta = nil
+	assert.Contains(t, violated(), ValidatorsHash)
+	meta = &types.BlockMeta{
+		BlockID: blockID,
+		Header:  types.Header{Height: 10, ValidatorsHash: vals.Hash(types.CanonicalEncodingProto), NextValidatorsHash: vals.Hash(types.CanonicalEncodingProto)},
+	}
+
+	// the WAL is ahead of the state
+	w.endHeight = 12
+	assert.Contains(t, violated(), WALHeight)
+	w.endHeight = 11
+	assert.Empty(t, violated())
+
+	// the indexer has a gap, or is ahead of the block store
+	require.NoError(t, progress.MarkIndexed(12))
+	assert.Equal(t, map[string]string{
+		IndexerHeights: "heights 11 to 11 not indexed (1 gaps)",
+	}, violated())
+	require.NoError(t, progress.MarkIndexed(11))
+	assert.Equal(t, map[string]string{
+		IndexerHeights: "indexed up to height 12, block store at height 11",
+	}, violated())
+}
@@ -0,0 +1,37 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package invariant
+
+import (
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Violated: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "violated",
+			Help:      "Whether the invariant was violated at the last check (1) or not (0).",
+		}, append(labels, "invariant")).With(labelsAndValues...),
+		Violations: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "violations",
+			Help:      "Number of the checks which found the invariant violated.",
+		}, append(labels, "invariant")).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Violated:   discard.NewGauge(),
+		Violations: discard.NewCounter(),
+	}
+}
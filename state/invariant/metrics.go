@@ -0,0 +1,21 @@
+package invariant
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "invariant"
+)
+
+//go:generate go run ../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Whether the invariant was violated at the last check (1) or not (0).
+	Violated metrics.Gauge `metrics_labels:"invariant"`
+	// Number of the checks which found the invariant violated.
+	Violations metrics.Counter `metrics_labels:"invariant"`
+}
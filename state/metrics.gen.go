@@ -34,6 +34,24 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "validator_set_updates",
 			Help:      "ValidatorSetUpdates is the total number of times the application has udated the validator set since process start.",
 		}, labels).With(labelsAndValues...),
+		PrunedBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruned_blocks",
+			Help:      "PrunedBlocks is the total number of blocks pruned by the pruner since process start.",
+		}, labels).With(labelsAndValues...),
+		PruningRetainHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruning_retain_height",
+			Help:      "PruningRetainHeight is the height of the lowest block the pruner retains.",
+		}, labels).With(labelsAndValues...),
+		PruningBaseHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruning_base_height",
+			Help:      "PruningBaseHeight is the height of the lowest block left by the pruner.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -42,5 +60,8 @@ func NopMetrics() *Metrics {
 		BlockProcessingTime:   discard.NewHistogram(),
 		ConsensusParamUpdates: discard.NewCounter(),
 		ValidatorSetUpdates:   discard.NewCounter(),
+		PrunedBlocks:          discard.NewCounter(),
+		PruningRetainHeight:   discard.NewGauge(),
+		PruningBaseHeight:     discard.NewGauge(),
 	}
 }
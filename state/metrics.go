@@ -24,4 +24,16 @@ type Metrics struct {
 	// ValidatorSetUpdates is the total number of times the application has
 	// udated the validator set since process start.
 	ValidatorSetUpdates metrics.Counter
+
+	// PrunedBlocks is the total number of blocks pruned by the pruner since
+	// process start.
+	PrunedBlocks metrics.Counter
+
+	// PruningRetainHeight is the height of the lowest block the pruner
+	// retains.
+	PruningRetainHeight metrics.Gauge
+
+	// PruningBaseHeight is the height of the lowest block left by the
+	// pruner.
+	PruningBaseHeight metrics.Gauge
 }
@@ -6,6 +6,8 @@ import (
 	state "github.com/cometbft/cometbft/state"
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/cometbft/cometbft/types"
 )
 
@@ -166,6 +168,22 @@ func (_m *BlockStore) LoadBlockMetaByHash(hash []byte) *types.BlockMeta {
 	return r0
 }
 
+// LoadBlockMetaByTime provides a mock function with given fields: t
+func (_m *BlockStore) LoadBlockMetaByTime(t time.Time) *types.BlockMeta {
+	ret := _m.Called(t)
+
+	var r0 *types.BlockMeta
+	if rf, ok := ret.Get(0).(func(time.Time) *types.BlockMeta); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BlockMeta)
+		}
+	}
+
+	return r0
+}
+
 // LoadBlockPart provides a mock function with given fields: height, index
 func (_m *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
 	ret := _m.Called(height, index)
@@ -229,9 +247,9 @@ func (_m *BlockStore) PruneBlocks(height int64, _a1 state.State) (uint64, int64,
 	return r0, r1, r2
 }
 
-// SaveBlock provides a mock function with given fields: block, blockParts, seenCommit
-func (_m *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
-	_m.Called(block, blockParts, seenCommit)
+// SaveBlock provides a mock function with given fields: block, blockParts, seenCommit, encoding
+func (_m *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit, encoding types.CanonicalEncoding) {
+	_m.Called(block, blockParts, seenCommit, encoding)
 }
 
 // Size provides a mock function with given fields:
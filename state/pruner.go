@@ -0,0 +1,235 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// maxHeightsPerPrune bounds the number of heights pruned by a single run of
+// the Pruner, so that pruning is spread over time instead of being bursty.
+const maxHeightsPerPrune = 1000
+
+// PrunerRetention is the retention of the blocks, commits and ABCI responses
+// pruned by the Pruner. A block is retained if either of the windows retains
+// it. If both are zero, the Pruner only prunes up to the retain height of the
+// application.
+type PrunerRetention struct {
+	// Number of the latest blocks to retain.
+	Blocks int64
+	// Age of the oldest block to retain.
+	Duration time.Duration
+}
+
+// PrunerOption sets an optional parameter on the Pruner.
+type PrunerOption func(*Pruner)
+
+// PrunerWithMetrics sets the metrics.
+func PrunerWithMetrics(metrics *Metrics) PrunerOption {
+	return func(p *Pruner) { p.metrics = metrics }
+}
+
+// PrunerWithSnapshotHeight sets a function returning the height of the oldest
+// snapshot of the application (or 0 if none), which, and the blocks above,
+// are never pruned, so that they can be served to the nodes state syncing
+// from it.
+func PrunerWithSnapshotHeight(fn func() (int64, error)) PrunerOption {
+	return func(p *Pruner) { p.snapshotHeight = fn }
+}
+
+// Pruner is a service pruning the block and state stores in the background,
+// at the given interval, according to the retention windows of the node and
+// to the retain height of the application (see
+// BlockExecutorWithPruner). The data needed to prove evidence is kept, as
+// with the pruning driven by the application.
+type Pruner struct {
+	service.BaseService
+
+	stateStore Store
+	blockStore BlockStore
+	interval   time.Duration
+	metrics    *Metrics
+
+	snapshotHeight func() (int64, error)
+
+	mtx       sync.Mutex
+	retention PrunerRetention
+	// appRetainHeight is -1 until the application reported its retain height
+	// since the start, 0 if it doesn't retain any.
+	appRetainHeight int64
+}
+
+// NewPruner returns a new Pruner.
+func NewPruner(
+	stateStore Store,
+	blockStore BlockStore,
+	interval time.Duration,
+	retention PrunerRetention,
+	logger log.Logger,
+	options ...PrunerOption,
+) *Pruner {
+	p := &Pruner{
+		stateStore:      stateStore,
+		blockStore:      blockStore,
+		interval:        interval,
+		retention:       retention,
+		metrics:         NopMetrics(),
+		appRetainHeight: -1,
+	}
+	p.BaseService = *service.NewBaseService(logger, "Pruner", p)
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// OnStart implements service.Service.
+func (p *Pruner) OnStart() error {
+	if p.interval <= 0 {
+		return errors.New("pruning interval must be positive")
+	}
+	go p.pruneRoutine()
+	return nil
+}
+
+// SetRetention changes the retention windows of the node at runtime.
+func (p *Pruner) SetRetention(retention PrunerRetention) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.retention = retention
+}
+
+// SetApplicationRetainHeight sets the retain height returned by the
+// application on Commit. Above zero, the blocks below it are pruned (unless
+// retained by the node) and the ones above it are retained.
+func (p *Pruner) SetApplicationRetainHeight(height int64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if height > p.appRetainHeight {
+		p.appRetainHeight = height
+	}
+	if p.appRetainHeight < 0 {
+		p.appRetainHeight = 0
+	}
+}
+
+func (p *Pruner) pruneRoutine() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.Quit():
+			return
+		case <-ticker.C:
+			if err := p.prune(cmttime.Now()); err != nil {
+				p.Logger.Error("Failed to prune", "err", err)
+			}
+		}
+	}
+}
+
+// prune prunes up to maxHeightsPerPrune heights below the retain height.
+func (p *Pruner) prune(now time.Time) error {
+	retainHeight, err := p.retainHeight(now)
+	if err != nil {
+		return err
+	}
+	p.metrics.PruningRetainHeight.Set(float64(retainHeight))
+
+	base := p.blockStore.Base()
+	if retainHeight <= base {
+		return nil
+	}
+	if retainHeight-base > maxHeightsPerPrune {
+		retainHeight = base + maxHeightsPerPrune
+	}
+
+	state, err := p.stateStore.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	pruned, evidenceRetainHeight, err := p.blockStore.PruneBlocks(retainHeight, state)
+	if err != nil {
+		return fmt.Errorf("failed to prune block store: %w", err)
+	}
+	if err := p.stateStore.PruneStates(base, retainHeight, evidenceRetainHeight); err != nil {
+		return fmt.Errorf("failed to prune state store: %w", err)
+	}
+
+	p.metrics.PrunedBlocks.Add(float64(pruned))
+	p.metrics.PruningBaseHeight.Set(float64(retainHeight))
+	p.Logger.Debug("Pruned blocks", "pruned", pruned, "base", retainHeight)
+	return nil
+}
+
+// retainHeight returns the height of the lowest block to retain, or 0 if
+// nothing can be pruned.
+func (p *Pruner) retainHeight(now time.Time) (int64, error) {
+	p.mtx.Lock()
+	appRetainHeight, retention := p.appRetainHeight, p.retention
+	p.mtx.Unlock()
+	// Wait for the application to report its retain height, so as not to
+	// prune blocks it still needs after a restart.
+	if appRetainHeight < 0 {
+		return 0, nil
+	}
+
+	base, height := p.blockStore.Base(), p.blockStore.Height()
+	if height == 0 {
+		return 0, nil
+	}
+
+	retainHeight := int64(0)
+	if retention.Blocks > 0 || retention.Duration > 0 {
+		retainHeight = height
+		if retention.Blocks > 0 && height-retention.Blocks+1 < retainHeight {
+			retainHeight = height - retention.Blocks + 1
+		}
+		if retention.Duration > 0 {
+			if h := p.firstHeightAfter(base, height, now.Add(-retention.Duration)); h < retainHeight {
+				retainHeight = h
+			}
+		}
+		if appRetainHeight > 0 && appRetainHeight < retainHeight {
+			retainHeight = appRetainHeight
+		}
+	} else {
+		retainHeight = appRetainHeight
+	}
+
+	if p.snapshotHeight != nil && retainHeight > 0 {
+		snapshotHeight, err := p.snapshotHeight()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get the snapshot height: %w", err)
+		}
+		if snapshotHeight > 0 && snapshotHeight < retainHeight {
+			retainHeight = snapshotHeight
+		}
+	}
+
+	if retainHeight > height {
+		retainHeight = height
+	}
+	return retainHeight, nil
+}
+
+// firstHeightAfter returns the lowest height in [base, height] whose block
+// time is not before t, or height if there is none.
+func (p *Pruner) firstHeightAfter(base, height int64, t time.Time) int64 {
+	i := sort.Search(int(height-base+1), func(i int) bool {
+		meta := p.blockStore.LoadBlockMeta(base + int64(i))
+		// missing blocks (i.e. just pruned) are deemed old
+		return meta != nil && !meta.Header.Time.Before(t)
+	})
+	if h := base + int64(i); h < height {
+		return h
+	}
+	return height
+}
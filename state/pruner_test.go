@@ -0,0 +1,81 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestPruner(t *testing.T) {
+	var (
+		now   = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		state = sm.State{LastBlockHeight: 100}
+	)
+	// block h is 100-h minutes old
+	blockMeta := func(h int64) *types.BlockMeta {
+		return &types.BlockMeta{Header: types.Header{
+			Height: h,
+			Time:   now.Add(-time.Duration(100-h) * time.Minute),
+		}}
+	}
+
+	testCases := []struct {
+		name            string
+		retention       sm.PrunerRetention
+		appRetainHeight int64
+		snapshotHeight  int64
+		base            int64
+		retainHeight    int64 // 0 if nothing is pruned
+	}{
+		{"app retain height only", sm.PrunerRetention{}, 40, 0, 1, 40},
+		{"no retention", sm.PrunerRetention{}, 0, 0, 1, 0},
+		{"retain blocks", sm.PrunerRetention{Blocks: 10}, 0, 0, 1, 91},
+		{"retain duration", sm.PrunerRetention{Duration: 30 * time.Minute}, 0, 0, 1, 70},
+		{"retain the widest window", sm.PrunerRetention{Blocks: 10, Duration: 30 * time.Minute}, 0, 0, 1, 70},
+		{"honor the app retain height", sm.PrunerRetention{Blocks: 10}, 50, 0, 1, 50},
+		{"honor the snapshots", sm.PrunerRetention{Blocks: 10}, 0, 60, 1, 60},
+		{"already pruned", sm.PrunerRetention{Blocks: 10}, 0, 0, 91, 0},
+		{"retain the latest block", sm.PrunerRetention{Blocks: 1}, 0, 0, 1, 100},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			blockStore := &mocks.BlockStore{}
+			blockStore.On("Base").Return(tc.base)
+			blockStore.On("Height").Return(int64(100))
+			blockStore.On("LoadBlockMeta", mock.Anything).Return(func(h int64) *types.BlockMeta {
+				return blockMeta(h)
+			})
+			stateStore := &mocks.Store{}
+			stateStore.On("Load").Return(state, nil)
+			if tc.retainHeight > 0 {
+				blockStore.On("PruneBlocks", tc.retainHeight, state).Return(uint64(tc.retainHeight-tc.base), tc.retainHeight, nil)
+				stateStore.On("PruneStates", tc.base, tc.retainHeight, tc.retainHeight).Return(nil)
+			}
+
+			pruner := sm.NewPruner(stateStore, blockStore, time.Second, tc.retention, log.TestingLogger(),
+				sm.PrunerWithSnapshotHeight(func() (int64, error) { return tc.snapshotHeight, nil }))
+
+			// nothing is pruned until the application reports its retain height
+			require.NoError(t, pruner.PruneAt(now))
+			blockStore.AssertNotCalled(t, "PruneBlocks", mock.Anything, mock.Anything)
+
+			pruner.SetApplicationRetainHeight(tc.appRetainHeight)
+			require.NoError(t, pruner.PruneAt(now))
+			if tc.retainHeight > 0 {
+				blockStore.AssertCalled(t, "PruneBlocks", tc.retainHeight, state)
+				stateStore.AssertCalled(t, "PruneStates", tc.base, tc.retainHeight, tc.retainHeight)
+			} else {
+				blockStore.AssertNotCalled(t, "PruneBlocks", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -9,6 +9,54 @@ import (
 	"github.com/cometbft/cometbft/version"
 )
 
+// RollbackTo rolls the CometBFT state back to height, one height at a time (see
+// Rollback), and deletes the ABCIResponses of the heights above it. The blocks
+// above height + 1 are removed, as the blockstore can't be more than one height
+// above the state, and so is the block at height + 1 if removeBlock is true.
+// Note that this function does not affect application state.
+func RollbackTo(bs BlockStore, ss Store, height int64, removeBlock bool) (int64, []byte, error) {
+	invalidState, err := ss.Load()
+	if err != nil {
+		return -1, nil, err
+	}
+	if invalidState.IsEmpty() {
+		return -1, nil, errors.New("no state found")
+	}
+	if height >= invalidState.LastBlockHeight {
+		return -1, nil, fmt.Errorf("can't roll back to height %d, the state is at height %d",
+			height, invalidState.LastBlockHeight)
+	}
+	if height < bs.Base() {
+		return -1, nil, fmt.Errorf("can't roll back to height %d, below the base of the blockstore (%d)",
+			height, bs.Base())
+	}
+
+	// Discard the pending block, if any: see Rollback.
+	if bs.Height() == invalidState.LastBlockHeight+1 {
+		if err := ss.DeleteABCIResponses(bs.Height()); err != nil {
+			return -1, nil, fmt.Errorf("failed to delete the ABCI responses of height %d: %w", bs.Height(), err)
+		}
+		if err := bs.DeleteLatestBlock(); err != nil {
+			return -1, nil, fmt.Errorf("failed to remove final block from blockstore: %w", err)
+		}
+	}
+
+	var (
+		lastHeight = invalidState.LastBlockHeight
+		appHash    []byte
+	)
+	for lastHeight > height {
+		if err := ss.DeleteABCIResponses(lastHeight); err != nil {
+			return -1, nil, fmt.Errorf("failed to delete the ABCI responses of height %d: %w", lastHeight, err)
+		}
+		lastHeight, appHash, err = Rollback(bs, ss, removeBlock || lastHeight-1 > height)
+		if err != nil {
+			return -1, nil, err
+		}
+	}
+	return lastHeight, appHash, nil
+}
+
 // Rollback overwrites the current CometBFT state (height n) with the most
 // recent previous state (height n - 1).
 // Note that this function does not affect application state.
@@ -2,6 +2,7 @@ package state_test
 
 import (
 	"crypto/rand"
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
@@ -89,7 +91,7 @@ func TestRollback(t *testing.T) {
 func TestRollbackHard(t *testing.T) {
 	const height int64 = 100
 	blockStore := store.NewBlockStore(dbm.NewMemDB())
-	stateStore := state.NewStore(dbm.NewMemDB(), state.StoreOptions{DiscardABCIResponses: false})
+	stateStore := state.NewStore(dbm.NewMemDB(), state.StoreOptions{})
 
 	valSet, _ := types.RandValidatorSet(5, 10)
 
@@ -107,8 +109,8 @@ func TestRollbackHard(t *testing.T) {
 			LastBlockID:        makeBlockIDRandom(),
 			LastCommitHash:     crypto.CRandBytes(tmhash.Size),
 			DataHash:           crypto.CRandBytes(tmhash.Size),
-			ValidatorsHash:     valSet.Hash(),
-			NextValidatorsHash: valSet.CopyIncrementProposerPriority(1).Hash(),
+			ValidatorsHash:     valSet.Hash(types.CanonicalEncodingProto),
+			NextValidatorsHash: valSet.CopyIncrementProposerPriority(1).Hash(types.CanonicalEncodingProto),
 			ConsensusHash:      params.Hash(),
 			LastResultsHash:    crypto.CRandBytes(tmhash.Size),
 			EvidenceHash:       crypto.CRandBytes(tmhash.Size),
@@ -119,7 +121,7 @@ func TestRollbackHard(t *testing.T) {
 
 	partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
 	require.NoError(t, err)
-	blockStore.SaveBlock(block, partSet, &types.Commit{Height: block.Height})
+	blockStore.SaveBlock(block, partSet, &types.Commit{Height: block.Height}, types.CanonicalEncodingProto)
 
 	currState := state.State{
 		Version: cmtstate.Version{
@@ -146,11 +148,11 @@ func TestRollbackHard(t *testing.T) {
 			Time:               block.Time,
 			Height:             currState.LastBlockHeight + 1,
 			AppHash:            currState.AppHash,
-			LastBlockID:        types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()},
+			LastBlockID:        types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: partSet.Header()},
 			LastCommitHash:     crypto.CRandBytes(tmhash.Size),
 			DataHash:           crypto.CRandBytes(tmhash.Size),
-			ValidatorsHash:     valSet.CopyIncrementProposerPriority(1).Hash(),
-			NextValidatorsHash: valSet.CopyIncrementProposerPriority(2).Hash(),
+			ValidatorsHash:     valSet.CopyIncrementProposerPriority(1).Hash(types.CanonicalEncodingProto),
+			NextValidatorsHash: valSet.CopyIncrementProposerPriority(2).Hash(types.CanonicalEncodingProto),
 			ConsensusHash:      params.Hash(),
 			LastResultsHash:    currState.LastResultsHash,
 			EvidenceHash:       crypto.CRandBytes(tmhash.Size),
@@ -161,7 +163,7 @@ func TestRollbackHard(t *testing.T) {
 
 	nextPartSet, err := nextBlock.MakePartSet(types.BlockPartSizeBytes)
 	require.NoError(t, err)
-	blockStore.SaveBlock(nextBlock, nextPartSet, &types.Commit{Height: nextBlock.Height})
+	blockStore.SaveBlock(nextBlock, nextPartSet, &types.Commit{Height: nextBlock.Height}, types.CanonicalEncodingProto)
 
 	rollbackHeight, rollbackHash, err := state.Rollback(blockStore, stateStore, true)
 	require.NoError(t, err)
@@ -174,7 +176,7 @@ func TestRollbackHard(t *testing.T) {
 	require.Equal(t, currState, loadedState)
 
 	// resave the same block
-	blockStore.SaveBlock(nextBlock, nextPartSet, &types.Commit{Height: nextBlock.Height})
+	blockStore.SaveBlock(nextBlock, nextPartSet, &types.Commit{Height: nextBlock.Height}, types.CanonicalEncodingProto)
 
 	params.Version.App = 11
 
@@ -202,11 +204,108 @@ func TestRollbackHard(t *testing.T) {
 	require.Equal(t, rollbackHash, currState.AppHash)
 }
 
+func TestRollbackTo(t *testing.T) {
+	const height int64 = 100
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	stateStore := state.NewStore(dbm.NewMemDB(), state.StoreOptions{})
+
+	valSet, _ := types.RandValidatorSet(5, 10)
+	params := types.DefaultConsensusParams()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// blocks from 100 to 104, the last one pending, and the states from 100 to 103
+	var (
+		states      []state.State
+		lastBlockID = makeBlockIDRandom()
+	)
+	for h := height; h <= height+4; h++ {
+		block := &types.Block{
+			Header: types.Header{
+				Version:         cmtversion.Consensus{Block: version.BlockProtocol, App: 1},
+				ChainID:         "test-chain",
+				Time:            now.Add(time.Duration(h) * time.Second),
+				Height:          h,
+				AppHash:         tmhash.Sum([]byte(fmt.Sprint("app_hash", h-1))),
+				LastBlockID:     lastBlockID,
+				ValidatorsHash:  valSet.Hash(types.CanonicalEncodingProto),
+				ConsensusHash:   params.Hash(),
+				LastResultsHash: tmhash.Sum([]byte(fmt.Sprint("last_results_hash", h-1))),
+				ProposerAddress: valSet.Proposer.Address,
+			},
+			LastCommit: &types.Commit{Height: h - 1},
+		}
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, partSet, &types.Commit{Height: h}, types.CanonicalEncodingProto)
+		lastBlockID = types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: partSet.Header()}
+		require.NoError(t, stateStore.SaveABCIResponses(h, &cmtstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}))
+		if h == height+4 {
+			break
+		}
+
+		s := state.State{
+			Version: cmtstate.Version{
+				Consensus: block.Header.Version,
+				Software:  version.TMCoreSemVer,
+			},
+			ChainID:                          "test-chain",
+			InitialHeight:                    1,
+			LastBlockHeight:                  h,
+			LastBlockID:                      lastBlockID,
+			LastBlockTime:                    block.Time,
+			AppHash:                          tmhash.Sum([]byte(fmt.Sprint("app_hash", h))),
+			LastResultsHash:                  tmhash.Sum([]byte(fmt.Sprint("last_results_hash", h))),
+			LastValidators:                   valSet,
+			Validators:                       valSet,
+			NextValidators:                   valSet,
+			LastHeightValidatorsChanged:      height + 1,
+			ConsensusParams:                  *params,
+			LastHeightConsensusParamsChanged: height + 1,
+		}
+		if h == height {
+			require.NoError(t, stateStore.Bootstrap(s))
+		} else {
+			require.NoError(t, stateStore.Save(s))
+		}
+		states = append(states, s)
+	}
+
+	_, _, err := state.RollbackTo(blockStore, stateStore, height+3, false)
+	require.Error(t, err)
+
+	// roll back two heights, keeping the block above
+	rollbackHeight, rollbackHash, err := state.RollbackTo(blockStore, stateStore, height+1, false)
+	require.NoError(t, err)
+	require.Equal(t, height+1, rollbackHeight)
+	require.Equal(t, states[1].AppHash, rollbackHash)
+	require.Equal(t, height+2, blockStore.Height())
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, states[1].LastBlockID, loadedState.LastBlockID)
+	require.Equal(t, states[1].LastResultsHash, loadedState.LastResultsHash)
+
+	// the ABCI responses above the state are deleted
+	_, err = stateStore.LoadABCIResponses(height + 1)
+	require.NoError(t, err)
+	_, err = stateStore.LoadABCIResponses(height + 2)
+	require.Error(t, err)
+	_, err = stateStore.LoadLastABCIResponse(height + 1)
+	require.NoError(t, err)
+
+	// and one more, removing the block above
+	rollbackHeight, rollbackHash, err = state.RollbackTo(blockStore, stateStore, height, true)
+	require.NoError(t, err)
+	require.Equal(t, height, rollbackHeight)
+	require.Equal(t, states[0].AppHash, rollbackHash)
+	require.Equal(t, height, blockStore.Height())
+}
+
 func TestRollbackNoState(t *testing.T) {
 	stateStore := state.NewStore(dbm.NewMemDB(),
-		state.StoreOptions{
-			DiscardABCIResponses: false,
-		})
+		state.StoreOptions{})
 	blockStore := &mocks.BlockStore{}
 
 	_, _, err := state.Rollback(blockStore, stateStore, false)
@@ -239,7 +338,7 @@ func TestRollbackDifferentStateHeight(t *testing.T) {
 }
 
 func setupStateStore(t *testing.T, height int64) state.Store {
-	stateStore := state.NewStore(dbm.NewMemDB(), state.StoreOptions{DiscardABCIResponses: false})
+	stateStore := state.NewStore(dbm.NewMemDB(), state.StoreOptions{})
 	valSet, _ := types.RandValidatorSet(5, 10)
 
 	params := types.DefaultConsensusParams()
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"time"
+
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -24,12 +26,13 @@ type BlockStore interface {
 	LoadBlockMeta(height int64) *types.BlockMeta
 	LoadBlock(height int64) *types.Block
 
-	SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit)
+	SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit, encoding types.CanonicalEncoding)
 
 	PruneBlocks(height int64, state State) (uint64, int64, error)
 
 	LoadBlockByHash(hash []byte) *types.Block
 	LoadBlockMetaByHash(hash []byte) *types.BlockMeta
+	LoadBlockMetaByTime(t time.Time) *types.BlockMeta
 	LoadBlockPart(height int64, index int) *types.Part
 
 	LoadBlockCommit(height int64) *types.Commit
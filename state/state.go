@@ -251,10 +251,11 @@ func (state State) MakeBlock(
 	}
 
 	// Fill rest of header with state data.
+	encoding := state.ConsensusParams.Encoding.Canonical
 	block.Header.Populate(
 		state.Version.Consensus, state.ChainID,
 		timestamp, state.LastBlockID,
-		state.Validators.Hash(), state.NextValidators.Hash(),
+		state.Validators.Hash(encoding), state.NextValidators.Hash(encoding),
 		state.ConsensusParams.Hash(), state.AppHash, state.LastResultsHash,
 		proposerAddress,
 	)
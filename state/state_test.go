@@ -28,9 +28,7 @@ func setupTestCase(t *testing.T) (func(t *testing.T), dbm.DB, sm.State) {
 	config := test.ResetTestRoot("state_")
 	dbType := dbm.BackendType(config.DBBackend)
 	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	require.NoError(t, err)
 	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
 	assert.NoError(t, err, "expected no error on LoadStateFromDBOrGenesisFile")
@@ -77,9 +75,7 @@ func TestMakeGenesisStateNilValidators(t *testing.T) {
 func TestStateSaveLoad(t *testing.T) {
 	tearDown, stateDB, state := setupTestCase(t)
 	defer tearDown(t)
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	assert := assert.New(t)
 
 	state.LastBlockHeight++
@@ -98,9 +94,7 @@ func TestStateSaveLoad(t *testing.T) {
 func TestABCIResponsesSaveLoad1(t *testing.T) {
 	tearDown, stateDB, state := setupTestCase(t)
 	defer tearDown(t)
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	assert := assert.New(t)
 
 	state.LastBlockHeight++
@@ -133,9 +127,7 @@ func TestABCIResponsesSaveLoad2(t *testing.T) {
 	defer tearDown(t)
 	assert := assert.New(t)
 
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 
 	cases := [...]struct {
 		// Height is implied to equal index+2,
@@ -225,9 +217,7 @@ func TestValidatorSimpleSaveLoad(t *testing.T) {
 	defer tearDown(t)
 	assert := assert.New(t)
 
-	statestore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	statestore := sm.NewStore(stateDB, sm.StoreOptions{})
 
 	// Can't load anything for height 0.
 	_, err := statestore.LoadValidators(0)
@@ -236,12 +226,12 @@ func TestValidatorSimpleSaveLoad(t *testing.T) {
 	// Should be able to load for height 1.
 	v, err := statestore.LoadValidators(1)
 	assert.Nil(err, "expected no err at height 1")
-	assert.Equal(v.Hash(), state.Validators.Hash(), "expected validator hashes to match")
+	assert.Equal(v.Hash(types.CanonicalEncodingProto), state.Validators.Hash(types.CanonicalEncodingProto), "expected validator hashes to match")
 
 	// Should be able to load for height 2.
 	v, err = statestore.LoadValidators(2)
 	assert.Nil(err, "expected no err at height 2")
-	assert.Equal(v.Hash(), state.NextValidators.Hash(), "expected validator hashes to match")
+	assert.Equal(v.Hash(types.CanonicalEncodingProto), state.NextValidators.Hash(types.CanonicalEncodingProto), "expected validator hashes to match")
 
 	// Increment height, save; should be able to load for next & next next height.
 	state.LastBlockHeight++
@@ -252,17 +242,15 @@ func TestValidatorSimpleSaveLoad(t *testing.T) {
 	assert.Nil(err, "expected no err")
 	vp1, err := statestore.LoadValidators(nextHeight + 1)
 	assert.Nil(err, "expected no err")
-	assert.Equal(vp0.Hash(), state.Validators.Hash(), "expected validator hashes to match")
-	assert.Equal(vp1.Hash(), state.NextValidators.Hash(), "expected next validator hashes to match")
+	assert.Equal(vp0.Hash(types.CanonicalEncodingProto), state.Validators.Hash(types.CanonicalEncodingProto), "expected validator hashes to match")
+	assert.Equal(vp1.Hash(types.CanonicalEncodingProto), state.NextValidators.Hash(types.CanonicalEncodingProto), "expected next validator hashes to match")
 }
 
 // TestValidatorChangesSaveLoad tests saving and loading a validator set with changes.
 func TestOneValidatorChangesSaveLoad(t *testing.T) {
 	tearDown, stateDB, state := setupTestCase(t)
 	defer tearDown(t)
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 
 	// Change vals at these heights.
 	changeHeights := []int64{1, 2, 4, 5, 10, 15, 16, 17, 20}
@@ -458,7 +446,7 @@ func TestProposerPriorityDoesNotGetResetToZero(t *testing.T) {
 	block := makeBlock(state, state.LastBlockHeight+1, new(types.Commit))
 	bps, err := block.MakePartSet(testPartSize)
 	require.NoError(t, err)
-	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	blockID := types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: bps.Header()}
 	abciResponses := &cmtstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
 		EndBlock:   &abci.ResponseEndBlock{ValidatorUpdates: nil},
@@ -574,7 +562,7 @@ func TestProposerPriorityProposerAlternates(t *testing.T) {
 	block := makeBlock(state, state.LastBlockHeight+1, new(types.Commit))
 	bps, err := block.MakePartSet(testPartSize)
 	require.NoError(t, err)
-	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	blockID := types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: bps.Header()}
 	// no updates:
 	abciResponses := &cmtstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
@@ -763,7 +751,7 @@ func TestLargeGenesisValidator(t *testing.T) {
 		block := makeBlock(oldState, oldState.LastBlockHeight+1, new(types.Commit))
 		bps, err := block.MakePartSet(testPartSize)
 		require.NoError(t, err)
-		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+		blockID := types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: bps.Header()}
 
 		updatedState, err := sm.UpdateState(oldState, blockID, &block.Header, abciResponses, validatorUpdates)
 		require.NoError(t, err)
@@ -796,7 +784,7 @@ func TestLargeGenesisValidator(t *testing.T) {
 	bps, err := block.MakePartSet(testPartSize)
 	require.NoError(t, err)
 
-	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	blockID := types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: bps.Header()}
 	updatedState, err := sm.UpdateState(oldState, blockID, &block.Header, abciResponses, validatorUpdates)
 	require.NoError(t, err)
 
@@ -815,7 +803,7 @@ func TestLargeGenesisValidator(t *testing.T) {
 		bps, err = block.MakePartSet(testPartSize)
 		require.NoError(t, err)
 
-		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+		blockID := types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: bps.Header()}
 
 		updatedStateInner, err := sm.UpdateState(lastState, blockID, &block.Header, abciResponses, validatorUpdates)
 		require.NoError(t, err)
@@ -851,7 +839,7 @@ func TestLargeGenesisValidator(t *testing.T) {
 		bps, err := block.MakePartSet(testPartSize)
 		require.NoError(t, err)
 
-		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+		blockID := types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: bps.Header()}
 		state, err = sm.UpdateState(state, blockID, &block.Header, abciResponses, validatorUpdates)
 		require.NoError(t, err)
 	}
@@ -872,7 +860,7 @@ func TestLargeGenesisValidator(t *testing.T) {
 	bps, err = block.MakePartSet(testPartSize)
 	require.NoError(t, err)
 
-	blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	blockID = types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: bps.Header()}
 	validatorUpdates, err = types.PB2TM.ValidatorUpdates(abciResponses.EndBlock.ValidatorUpdates)
 	require.NoError(t, err)
 	updatedState, err = sm.UpdateState(state, blockID, &block.Header, abciResponses, validatorUpdates)
@@ -897,7 +885,7 @@ func TestLargeGenesisValidator(t *testing.T) {
 		bps, err := block.MakePartSet(testPartSize)
 		require.NoError(t, err)
 
-		blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+		blockID = types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: bps.Header()}
 		curState, err = sm.UpdateState(curState, blockID, &block.Header, abciResponses, validatorUpdates)
 		require.NoError(t, err)
 		if !bytes.Equal(curState.Validators.Proposer.Address, curState.NextValidators.Proposer.Address) {
@@ -926,7 +914,7 @@ func TestLargeGenesisValidator(t *testing.T) {
 		bps, err := block.MakePartSet(testPartSize)
 		require.NoError(t, err)
 
-		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+		blockID := types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: bps.Header()}
 
 		updatedState, err = sm.UpdateState(updatedState, blockID, &block.Header, abciResponses, validatorUpdates)
 		require.NoError(t, err)
@@ -944,9 +932,7 @@ func TestStoreLoadValidatorsIncrementsProposerPriority(t *testing.T) {
 	const valSetSize = 2
 	tearDown, stateDB, state := setupTestCase(t)
 	t.Cleanup(func() { tearDown(t) })
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	state.Validators = genValSet(valSetSize)
 	state.NextValidators = state.Validators.CopyIncrementProposerPriority(1)
 	err := stateStore.Save(state)
@@ -971,9 +957,7 @@ func TestManyValidatorChangesSaveLoad(t *testing.T) {
 	const valSetSize = 7
 	tearDown, stateDB, state := setupTestCase(t)
 	defer tearDown(t)
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	require.Equal(t, int64(0), state.LastBlockHeight)
 	state.Validators = genValSet(valSetSize)
 	state.NextValidators = state.Validators.CopyIncrementProposerPriority(1)
@@ -1037,9 +1021,7 @@ func TestConsensusParamsChangesSaveLoad(t *testing.T) {
 	tearDown, stateDB, state := setupTestCase(t)
 	defer tearDown(t)
 
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 
 	// Change vals at these heights.
 	changeHeights := []int64{1, 2, 4, 5, 10, 15, 16, 17, 20}
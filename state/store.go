@@ -3,6 +3,7 @@ package state
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/cosmos/gogoproto/proto"
 
@@ -34,8 +35,10 @@ func calcConsensusParamsKey(height int64) []byte {
 	return []byte(fmt.Sprintf("consensusParamsKey:%v", height))
 }
 
+const abciResponsesKeyPrefix = "abciResponsesKey:"
+
 func calcABCIResponsesKey(height int64) []byte {
-	return []byte(fmt.Sprintf("abciResponsesKey:%v", height))
+	return []byte(fmt.Sprintf("%s%v", abciResponsesKeyPrefix, height))
 }
 
 //----------------------
@@ -69,10 +72,17 @@ type Store interface {
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
 	SaveABCIResponses(int64, *cmtstate.ABCIResponses) error
+	// SaveValidators saves the validator set at a given height, which last
+	// changed at the given lastHeightChanged, e.g. when importing blocks
+	SaveValidators(height, lastHeightChanged int64, vals *types.ValidatorSet) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height
 	Bootstrap(State) error
 	// PruneStates takes the height from which to start pruning and which height stop at
 	PruneStates(int64, int64, int64) error
+	// PruneABCIResponses deletes the ABCIResponses below the given height, returning their number
+	PruneABCIResponses(int64) (int64, error)
+	// DeleteABCIResponses deletes the ABCIResponses of the given height, e.g. when rolling it back
+	DeleteABCIResponses(int64) error
 	// Close closes the connection with the database
 	Close() error
 }
@@ -85,11 +95,11 @@ type dbStore struct {
 }
 
 type StoreOptions struct {
-	// DiscardABCIResponses determines whether or not the store
-	// retains all ABCIResponses. If DiscardABCiResponses is enabled,
-	// the store will maintain only the response object from the latest
-	// height.
-	DiscardABCIResponses bool
+	// ABCIResponsesRetention is the number of the latest heights of which
+	// the store retains the ABCIResponses. The responses falling out of this
+	// window are deleted as new ones are saved. 0 retains all of them. In any
+	// case, the store maintains the response object from the latest height.
+	ABCIResponsesRetention int64
 }
 
 var _ Store = (*dbStore)(nil)
@@ -201,6 +211,13 @@ func (store dbStore) save(state State, key []byte) error {
 	return nil
 }
 
+// SaveValidators saves the validator set at the given height. The set itself
+// is only stored if it changed at that height (or at a checkpoint), otherwise
+// it is loaded from lastHeightChanged.
+func (store dbStore) SaveValidators(height, lastHeightChanged int64, vals *types.ValidatorSet) error {
+	return store.saveValidatorsInfo(height, lastHeightChanged, vals)
+}
+
 // BootstrapState saves a new state, used e.g. by state sync when starting from non-zero height.
 func (store dbStore) Bootstrap(state State) error {
 	height := state.LastBlockHeight + 1
@@ -377,18 +394,24 @@ func ABCIResponsesResultsHash(ar *cmtstate.ABCIResponses) []byte {
 }
 
 // LoadABCIResponses loads the ABCIResponses for the given height from the
-// database. If the node has DiscardABCIResponses set to true, ErrABCIResponsesNotPersisted
-// is persisted. If not found, ErrNoABCIResponsesForHeight is returned.
+// database. If the height is out of the retention window of the store (see
+// StoreOptions), ErrABCIResponsesNotPersisted is returned. If not found,
+// ErrNoABCIResponsesForHeight is returned.
 func (store dbStore) LoadABCIResponses(height int64) (*cmtstate.ABCIResponses, error) {
-	if store.DiscardABCIResponses {
-		return nil, ErrABCIResponsesNotPersisted
-	}
-
 	buf, err := store.db.Get(calcABCIResponsesKey(height))
 	if err != nil {
 		return nil, err
 	}
 	if len(buf) == 0 {
+		if store.ABCIResponsesRetention > 0 {
+			lastHeight, err := store.lastABCIResponseHeight()
+			if err != nil {
+				return nil, err
+			}
+			if height <= lastHeight-store.ABCIResponsesRetention {
+				return nil, ErrABCIResponsesNotPersisted
+			}
+		}
 		return nil, ErrNoABCIResponsesForHeight{height}
 	}
 
@@ -451,14 +474,17 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *cmtstate.ABC
 	}
 	abciResponses.DeliverTxs = dtxs
 
-	// If the flag is false then we save the ABCIResponse. This can be used for the /BlockResults
-	// query or to reindex an event using the command line.
-	if !store.DiscardABCIResponses {
-		bz, err := abciResponses.Marshal()
-		if err != nil {
-			return err
-		}
-		if err := store.db.Set(calcABCIResponsesKey(height), bz); err != nil {
+	// We save the ABCIResponse. This can be used for the /BlockResults query or to reindex an
+	// event using the command line. The one falling out of the retention window is deleted.
+	bz, err := abciResponses.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := store.db.Set(calcABCIResponsesKey(height), bz); err != nil {
+		return err
+	}
+	if store.ABCIResponsesRetention > 0 && height-store.ABCIResponsesRetention > 0 {
+		if err := store.db.Delete(calcABCIResponsesKey(height - store.ABCIResponsesRetention)); err != nil {
 			return err
 		}
 	}
@@ -469,7 +495,7 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *cmtstate.ABC
 		AbciResponses: abciResponses,
 		Height:        height,
 	}
-	bz, err := response.Marshal()
+	bz, err = response.Marshal()
 	if err != nil {
 		return err
 	}
@@ -477,6 +503,122 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *cmtstate.ABC
 	return store.db.SetSync(lastABCIResponseKey, bz)
 }
 
+// lastABCIResponseHeight returns the height of the last ABCIResponses saved,
+// or 0 if none.
+func (store dbStore) lastABCIResponseHeight() (int64, error) {
+	bz, err := store.db.Get(lastABCIResponseKey)
+	if err != nil || len(bz) == 0 {
+		return 0, err
+	}
+	info := new(cmtstate.ABCIResponsesInfo)
+	if err := info.Unmarshal(bz); err != nil {
+		return 0, err
+	}
+	return info.Height, nil
+}
+
+// DeleteABCIResponses deletes the ABCIResponses of height. If they are the last
+// ABCIResponses saved, the ones of height - 1, if any, become the last.
+func (store dbStore) DeleteABCIResponses(height int64) error {
+	lastHeight, err := store.lastABCIResponseHeight()
+	if err != nil {
+		return err
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Delete(calcABCIResponsesKey(height)); err != nil {
+		return err
+	}
+	if lastHeight == height {
+		bz, err := store.db.Get(calcABCIResponsesKey(height - 1))
+		if err != nil {
+			return err
+		}
+		if len(bz) == 0 {
+			if err := batch.Delete(lastABCIResponseKey); err != nil {
+				return err
+			}
+		} else {
+			abciResponses := new(cmtstate.ABCIResponses)
+			if err := abciResponses.Unmarshal(bz); err != nil {
+				return err
+			}
+			bz, err = (&cmtstate.ABCIResponsesInfo{AbciResponses: abciResponses, Height: height - 1}).Marshal()
+			if err != nil {
+				return err
+			}
+			if err := batch.Set(lastABCIResponseKey, bz); err != nil {
+				return err
+			}
+		}
+	}
+	return batch.WriteSync()
+}
+
+// PruneABCIResponses deletes the ABCIResponses below retainHeight, e.g. the
+// ones saved before the retention window of the store was set, and returns
+// their number. It scans all the ABCIResponses of the store.
+func (store dbStore) PruneABCIResponses(retainHeight int64) (int64, error) {
+	start, end := []byte(abciResponsesKeyPrefix), []byte(abciResponsesKeyPrefix)
+	end[len(end)-1]++
+	pruned := int64(0)
+	for {
+		// Collect a batch of keys to delete, then delete them, as the
+		// database can't be written while iterating.
+		keys, next, err := store.abciResponsesKeysBelow(start, end, retainHeight, 1000)
+		if err != nil {
+			return pruned, err
+		}
+		if len(keys) > 0 {
+			batch := store.db.NewBatch()
+			for _, key := range keys {
+				if err := batch.Delete(key); err != nil {
+					batch.Close()
+					return pruned, err
+				}
+			}
+			err := batch.WriteSync()
+			batch.Close()
+			if err != nil {
+				return pruned, err
+			}
+			pruned += int64(len(keys))
+		}
+		if next == nil {
+			return pruned, nil
+		}
+		start = next
+	}
+}
+
+// abciResponsesKeysBelow returns up to limit keys of ABCIResponses below
+// retainHeight in [start, end), and the key to resume from, or nil if done.
+func (store dbStore) abciResponsesKeysBelow(start, end []byte, retainHeight int64, limit int) ([][]byte, []byte, error) {
+	itr, err := store.db.Iterator(start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer itr.Close()
+
+	var keys [][]byte
+	for ; itr.Valid(); itr.Next() {
+		if len(keys) == limit {
+			return keys, itr.Key(), nil
+		}
+		height, err := strconv.ParseInt(string(itr.Key()[len(abciResponsesKeyPrefix):]), 10, 64)
+		if err != nil {
+			// not an ABCIResponses key
+			continue
+		}
+		if height < retainHeight {
+			keys = append(keys, itr.Key())
+		}
+	}
+	return keys, nil, itr.Error()
+}
+
 //-----------------------------------------------------------------------------
 
 // LoadValidators loads the ValidatorSet for a given height.
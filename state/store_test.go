@@ -22,9 +22,7 @@ import (
 
 func TestStoreLoadValidators(t *testing.T) {
 	stateDB := dbm.NewMemDB()
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	val, _ := types.RandValidator(true, 10)
 	vals := types.NewValidatorSet([]*types.Validator{val})
 
@@ -55,9 +53,7 @@ func BenchmarkLoadValidators(b *testing.B) {
 	dbType := dbm.BackendType(config.DBBackend)
 	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
 	require.NoError(b, err)
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
 	if err != nil {
 		b.Fatal(err)
@@ -120,9 +116,7 @@ func TestPruneStates(t *testing.T) {
 		tc := tc
 		t.Run(name, func(t *testing.T) {
 			db := dbm.NewMemDB()
-			stateStore := sm.NewStore(db, sm.StoreOptions{
-				DiscardABCIResponses: false,
-			})
+			stateStore := sm.NewStore(db, sm.StoreOptions{})
 			pk := ed25519.GenPrivKey().PubKey()
 
 			// Generate a bunch of state data. Validators change for heights ending with 3, and
@@ -250,9 +244,7 @@ func TestLastABCIResponses(t *testing.T) {
 	// create an empty state store.
 	t.Run("Not persisting responses", func(t *testing.T) {
 		stateDB := dbm.NewMemDB()
-		stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-			DiscardABCIResponses: false,
-		})
+		stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 		responses, err := stateStore.LoadABCIResponses(1)
 		require.Error(t, err)
 		require.Nil(t, responses)
@@ -266,7 +258,7 @@ func TestLastABCIResponses(t *testing.T) {
 		}
 		// create new db and state store and set discard abciresponses to false.
 		stateDB = dbm.NewMemDB()
-		stateStore = sm.NewStore(stateDB, sm.StoreOptions{DiscardABCIResponses: false})
+		stateStore = sm.NewStore(stateDB, sm.StoreOptions{})
 		height := int64(10)
 		// save the last abci response.
 		err = stateStore.SaveABCIResponses(height, response1)
@@ -285,31 +277,74 @@ func TestLastABCIResponses(t *testing.T) {
 		require.Equal(t, response1, responses)
 	})
 
-	t.Run("persisting responses", func(t *testing.T) {
+	t.Run("retaining responses", func(t *testing.T) {
 		stateDB := dbm.NewMemDB()
-		height := int64(10)
-		// stub the second abciresponse.
-		response2 := &cmtstate.ABCIResponses{
-			BeginBlock: &abci.ResponseBeginBlock{},
-			DeliverTxs: []*abci.ResponseDeliverTx{
-				{Code: 44, Data: []byte("Hello again"), Log: "????"},
-			},
-			EndBlock: &abci.ResponseEndBlock{},
-		}
-		// create a new statestore with the responses on.
+		// create a new statestore retaining the responses of the 2 latest heights.
 		stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-			DiscardABCIResponses: true,
+			ABCIResponsesRetention: 2,
 		})
-		// save an additional response.
-		err := stateStore.SaveABCIResponses(height+1, response2)
-		require.NoError(t, err)
-		// check to see if the response saved by calling the last response.
-		lastResponse2, err := stateStore.LoadLastABCIResponse(height + 1)
+		responses := make(map[int64]*cmtstate.ABCIResponses)
+		for height := int64(1); height <= 4; height++ {
+			responses[height] = &cmtstate.ABCIResponses{
+				BeginBlock: &abci.ResponseBeginBlock{},
+				DeliverTxs: []*abci.ResponseDeliverTx{
+					{Code: uint32(height), Data: []byte("Hello again"), Log: "????"},
+				},
+				EndBlock: &abci.ResponseEndBlock{},
+			}
+			err := stateStore.SaveABCIResponses(height, responses[height])
+			require.NoError(t, err)
+		}
+		// check to see if the last response saved.
+		lastResponse, err := stateStore.LoadLastABCIResponse(4)
 		require.NoError(t, err)
-		// check to see if the saved response height is the same as the loaded height.
-		assert.Equal(t, response2, lastResponse2)
-		// should error as we are no longer saving the response.
-		_, err = stateStore.LoadABCIResponses(height + 1)
-		assert.Equal(t, sm.ErrABCIResponsesNotPersisted, err)
+		assert.Equal(t, responses[4], lastResponse)
+		// the responses out of the window are no longer saved.
+		for height := int64(1); height <= 2; height++ {
+			_, err = stateStore.LoadABCIResponses(height)
+			assert.Equal(t, sm.ErrABCIResponsesNotPersisted, err)
+		}
+		for height := int64(3); height <= 4; height++ {
+			response, err := stateStore.LoadABCIResponses(height)
+			require.NoError(t, err)
+			assert.Equal(t, responses[height], response)
+		}
+		// heights which are yet to be saved are not found.
+		_, err = stateStore.LoadABCIResponses(5)
+		assert.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: 5}, err)
 	})
 }
+
+func TestPruneABCIResponses(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	// heights of more than one digit check that the heights are not compared as strings.
+	for height := int64(1); height <= 12; height++ {
+		err := stateStore.SaveABCIResponses(height, &cmtstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, stateDB.Set([]byte("validatorsKey:1"), []byte{1}))
+
+	pruned, err := stateStore.PruneABCIResponses(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, pruned)
+	for height := int64(1); height <= 12; height++ {
+		_, err := stateStore.LoadABCIResponses(height)
+		if height < 10 {
+			assert.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: height}, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+	// other keys are left as is
+	ok, err := stateDB.Has([]byte("validatorsKey:1"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	pruned, err = stateStore.PruneABCIResponses(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, pruned)
+}
@@ -0,0 +1,192 @@
+package txindex
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/indexer"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// maxHeightsPerBackfill bounds the number of heights indexed by a single
+	// run of the Backfiller, so that backfilling is spread over time.
+	maxHeightsPerBackfill = 100
+
+	backfillInterval = time.Second
+)
+
+// ErrHeightUnavailable is returned by IndexHeight if the block or the ABCI
+// responses of the height are not available, e.g. pruned.
+var ErrHeightUnavailable = errors.New("height unavailable")
+
+// IndexHeight indexes the block at height, and its txs, loading them and their
+// ABCI responses from blockStore and stateStore.
+func IndexHeight(
+	height int64,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	blockIdxr indexer.BlockIndexer,
+	txIdxr TxIndexer,
+) error {
+	b := blockStore.LoadBlock(height)
+	if b == nil {
+		return fmt.Errorf("not able to load block at height %d from the blockstore: %w", height, ErrHeightUnavailable)
+	}
+
+	r, err := stateStore.LoadABCIResponses(height)
+	if err != nil {
+		if errors.As(err, &sm.ErrNoABCIResponsesForHeight{}) || errors.Is(err, sm.ErrABCIResponsesNotPersisted) {
+			err = ErrHeightUnavailable
+		}
+		return fmt.Errorf("not able to load ABCI Response at height %d from the statestore: %w", height, err)
+	}
+
+	e := types.EventDataNewBlockHeader{
+		Header:           b.Header,
+		NumTxs:           int64(len(b.Txs)),
+		ResultBeginBlock: *r.BeginBlock,
+		ResultEndBlock:   *r.EndBlock,
+	}
+
+	// the block must be indexed before its txs, see psql.EventSink
+	if err := blockIdxr.Index(e); err != nil {
+		return fmt.Errorf("block event re-index at height %d failed: %w", height, err)
+	}
+
+	if e.NumTxs > 0 {
+		batch := NewBatch(e.NumTxs)
+		for i := range b.Data.Txs {
+			tr := abci.TxResult{
+				Height: b.Height,
+				Index:  uint32(i),
+				Tx:     b.Data.Txs[i],
+				Result: *(r.DeliverTxs[i]),
+			}
+			if err := batch.Add(&tr); err != nil {
+				return fmt.Errorf("adding tx to batch: %w", err)
+			}
+		}
+
+		if err := txIdxr.AddBatch(batch); err != nil {
+			return fmt.Errorf("tx event re-index at height %d failed: %w", height, err)
+		}
+	}
+	return nil
+}
+
+// Backfiller is a service indexing, in the background, the heights missing
+// from the progress of the indexers (see Progress.Missing), from the block and
+// state stores. The missing heights which are no longer available there, e.g.
+// pruned, are skipped.
+type Backfiller struct {
+	service.BaseService
+
+	progress   *Progress
+	blockStore sm.BlockStore
+	stateStore sm.Store
+	blockIdxr  indexer.BlockIndexer
+	txIdxr     TxIndexer
+
+	// unavailable are the missing heights found to be unavailable since the
+	// start.
+	unavailable []HeightRange
+}
+
+// NewBackfiller returns a new Backfiller.
+func NewBackfiller(
+	progress *Progress,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	blockIdxr indexer.BlockIndexer,
+	txIdxr TxIndexer,
+	logger log.Logger,
+) *Backfiller {
+	b := &Backfiller{
+		progress:   progress,
+		blockStore: blockStore,
+		stateStore: stateStore,
+		blockIdxr:  blockIdxr,
+		txIdxr:     txIdxr,
+	}
+	b.BaseService = *service.NewBaseService(logger, "Backfiller", b)
+	return b
+}
+
+// OnStart implements service.Service.
+func (b *Backfiller) OnStart() error {
+	go b.backfillRoutine()
+	return nil
+}
+
+func (b *Backfiller) backfillRoutine() {
+	ticker := time.NewTicker(backfillInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.Quit():
+			return
+		case <-ticker.C:
+			n, err := b.backfill()
+			if err != nil {
+				b.Logger.Error("Failed to backfill the index", "err", err)
+			}
+			if n > 0 {
+				b.Logger.Info("Backfilled the index", "heights", n)
+			}
+		}
+	}
+}
+
+// backfill indexes up to maxHeightsPerBackfill missing heights, and returns
+// the number of heights indexed.
+func (b *Backfiller) backfill() (int, error) {
+	n := 0
+	base := b.blockStore.Base()
+	for _, r := range b.progress.Missing() {
+		// the heights below the base are pruned
+		from := r.From
+		if from < base {
+			from = base
+		}
+		for height := from; height <= r.To; height++ {
+			if n == maxHeightsPerBackfill {
+				return n, nil
+			}
+			if skip, ok := findRange(b.unavailable, height); ok {
+				height = skip.To
+				continue
+			}
+
+			err := IndexHeight(height, b.blockStore, b.stateStore, b.blockIdxr, b.txIdxr)
+			if errors.Is(err, ErrHeightUnavailable) {
+				b.Logger.Debug("Can't backfill the index", "height", height, "err", err)
+				b.unavailable, _ = addHeight(b.unavailable, height)
+				continue
+			} else if err != nil {
+				return n, err
+			}
+			if err := b.progress.MarkIndexed(height); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+// findRange returns the range of ranges including height, if any.
+func findRange(ranges []HeightRange, height int64) (HeightRange, bool) {
+	for _, r := range ranges {
+		if r.From <= height && height <= r.To {
+			return r, true
+		}
+	}
+	return HeightRange{}, false
+}
@@ -0,0 +1,74 @@
+package txindex_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	sm "github.com/cometbft/cometbft/state"
+	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestBackfiller(t *testing.T) {
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+
+	progress, err := txindex.NewProgress(store, "kv")
+	require.NoError(t, err)
+	for _, height := range []int64{1, 2, 6} {
+		require.NoError(t, progress.MarkIndexed(height))
+	}
+
+	tx := types.Tx("foo")
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(2))
+	blockStore.On("LoadBlock", int64(3)).Return(&types.Block{
+		Header: types.Header{Height: 3},
+		Data:   types.Data{Txs: types.Txs{tx}},
+	})
+	blockStore.On("LoadBlock", int64(4)).Return(&types.Block{Header: types.Header{Height: 4}})
+	blockStore.On("LoadBlock", int64(5)).Return(nil)
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadABCIResponses", int64(3)).Return(&cmtstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{Code: 1}},
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}, nil)
+	stateStore.On("LoadABCIResponses", int64(4)).Return(nil, sm.ErrNoABCIResponsesForHeight{Height: 4})
+
+	backfiller := txindex.NewBackfiller(progress, blockStore, stateStore, blockIndexer, txIndexer,
+		log.TestingLogger())
+	require.NoError(t, backfiller.Start())
+	t.Cleanup(func() {
+		if err := backfiller.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	require.Eventually(t, func() bool {
+		return progress.Indexed()[0].To == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []txindex.HeightRange{{1, 3}, {6, 6}}, progress.Indexed())
+	// the unavailable heights are still missing
+	assert.Equal(t, []txindex.HeightRange{{4, 5}}, progress.Missing())
+
+	ok, err := blockIndexer.Has(3)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	res, err := txIndexer.Get(tx.Hash())
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, uint32(1), res.Result.Code)
+}
@@ -23,6 +23,16 @@ type IndexerService struct {
 	blockIdxr        indexer.BlockIndexer
 	eventBus         *types.EventBus
 	terminateOnError bool
+	progress         *Progress
+}
+
+// IndexerServiceOption sets an optional parameter on the IndexerService.
+type IndexerServiceOption func(*IndexerService)
+
+// IndexerServiceWithProgress sets the progress on which the indexed heights
+// are recorded.
+func IndexerServiceWithProgress(progress *Progress) IndexerServiceOption {
+	return func(is *IndexerService) { is.progress = progress }
 }
 
 // NewIndexerService returns a new service instance.
@@ -31,10 +41,14 @@ func NewIndexerService(
 	blockIdxr indexer.BlockIndexer,
 	eventBus *types.EventBus,
 	terminateOnError bool,
+	options ...IndexerServiceOption,
 ) *IndexerService {
 
 	is := &IndexerService{txIdxr: txIdxr, blockIdxr: blockIdxr, eventBus: eventBus, terminateOnError: terminateOnError}
 	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
+	for _, option := range options {
+		option(is)
+	}
 	return is
 }
 
@@ -67,12 +81,14 @@ func (is *IndexerService) OnStart() error {
 				eventDataHeader := msg.Data().(types.EventDataNewBlockHeader)
 				height := eventDataHeader.Header.Height
 				batch := NewBatch(eventDataHeader.NumTxs)
+				indexed := true
 
 				for i := int64(0); i < eventDataHeader.NumTxs; i++ {
 					msg2 := <-txsSub.Out()
 					txResult := msg2.Data().(types.EventDataTx).TxResult
 
 					if err = batch.Add(&txResult); err != nil {
+						indexed = false
 						is.Logger.Error(
 							"failed to add tx to batch",
 							"height", height,
@@ -90,6 +106,7 @@ func (is *IndexerService) OnStart() error {
 				}
 
 				if err := is.blockIdxr.Index(eventDataHeader); err != nil {
+					indexed = false
 					is.Logger.Error("failed to index block", "height", height, "err", err)
 					if is.terminateOnError {
 						if err := is.Stop(); err != nil {
@@ -102,6 +119,7 @@ func (is *IndexerService) OnStart() error {
 				}
 
 				if err = is.txIdxr.AddBatch(batch); err != nil {
+					indexed = false
 					is.Logger.Error("failed to index block txs", "height", height, "err", err)
 					if is.terminateOnError {
 						if err := is.Stop(); err != nil {
@@ -112,15 +130,31 @@ func (is *IndexerService) OnStart() error {
 				} else {
 					is.Logger.Debug("indexed transactions", "height", height, "num_txs", eventDataHeader.NumTxs)
 				}
+
+				// the heights not fully indexed are left to the Backfiller
+				if indexed && is.progress != nil {
+					if err := is.progress.MarkIndexed(height); err != nil {
+						is.Logger.Error("failed to record the indexed height", "height", height, "err", err)
+					}
+				}
 			}
 		}
 	}()
 	return nil
 }
 
-// OnStop implements service.Service by unsubscribing from all transactions.
+// OnStop implements service.Service by unsubscribing from all transactions,
+// and stopping the indexers which can be, e.g. the stream event sink waiting
+// to retry a publish.
 func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
 		_ = is.eventBus.UnsubscribeAll(context.Background(), subscriber)
 	}
+	for _, idxr := range []interface{}{is.txIdxr, is.blockIdxr} {
+		if s, ok := idxr.(interface{ Stop() error }); ok {
+			if err := s.Stop(); err != nil {
+				is.Logger.Error("failed to stop the indexer", "err", err)
+			}
+		}
+	}
 }
@@ -33,7 +33,11 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	txIndexer := kv.NewTxIndex(store)
 	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
 
-	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	progress, err := txindex.NewProgress(store, "kv")
+	require.NoError(t, err)
+
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false,
+		txindex.IndexerServiceWithProgress(progress))
 	service.SetLogger(log.TestingLogger())
 	err = service.Start()
 	require.NoError(t, err)
@@ -79,4 +83,6 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	res, err = txIndexer.Get(types.Tx("bar").Hash())
 	require.NoError(t, err)
 	require.Equal(t, txResult2, res)
+
+	require.Equal(t, []txindex.HeightRange{{From: 1, To: 1}}, progress.Indexed())
 }
@@ -152,6 +152,57 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 	return b.WriteSync()
 }
 
+// DeleteHeight deletes the transactions indexed at height, and their events.
+// The transactions indexed again at a later height are left indexed there.
+func (txi *TxIndex) DeleteHeight(height int64) error {
+	it, err := dbm.IteratePrefix(txi.store, startKey(types.TxHeightKey, height, height))
+	if err != nil {
+		return err
+	}
+	var heightKeys, hashes [][]byte
+	for ; it.Valid(); it.Next() {
+		heightKeys = append(heightKeys, append([]byte(nil), it.Key()...))
+		hashes = append(hashes, append([]byte(nil), it.Value()...))
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return err
+	}
+	it.Close()
+
+	b := txi.store.NewBatch()
+	defer b.Close()
+
+	for i, hash := range hashes {
+		if err := b.Delete(heightKeys[i]); err != nil {
+			return err
+		}
+		result, err := txi.Get(hash)
+		if err != nil {
+			return err
+		}
+		if result == nil || result.Height != height {
+			continue
+		}
+		for _, event := range result.Result.Events {
+			for _, attr := range event.Attributes {
+				if len(event.Type) == 0 || len(attr.Key) == 0 || !attr.GetIndex() {
+					continue
+				}
+				compositeTag := fmt.Sprintf("%s.%s", event.Type, attr.Key)
+				if err := b.Delete(keyForEvent(compositeTag, attr.Value, result)); err != nil {
+					return err
+				}
+			}
+		}
+		if err := b.Delete(hash); err != nil {
+			return err
+		}
+	}
+
+	return b.WriteSync()
+}
+
 func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Batch) error {
 	for _, event := range result.Result.Events {
 		// only index events with a non-empty type
@@ -294,7 +345,7 @@ RESULTS_LOOP:
 
 func lookForHash(conditions []syntax.Condition) (hash []byte, ok bool, err error) {
 	for _, c := range conditions {
-		if c.Tag == types.TxHashKey {
+		if c.Tag == types.TxHashKey && c.Arg != nil {
 			decoded, err := hex.DecodeString(c.Arg.Value())
 			return decoded, true, err
 		}
@@ -379,7 +430,7 @@ func (txi *TxIndex) match(
 			panic(err)
 		}
 
-	case c.Op == syntax.TContains:
+	case indexer.IsValueMatchOperation(c.Op):
 		// XXX: startKey does not apply here.
 		// For example, if startKey = "account.owner/an/" and search query = "account.owner CONTAINS an"
 		// we can't iterate with prefix "account.owner/an/" because we might miss keys like "account.owner/Ulan/"
@@ -394,7 +445,7 @@ func (txi *TxIndex) match(
 			if !isTagKey(it.Key()) {
 				continue
 			}
-			if strings.Contains(extractValueFromKey(it.Key()), c.Arg.Value()) {
+			if indexer.MatchesValue(c, extractValueFromKey(it.Key())) {
 				tmpHashes[string(it.Value())] = it.Value()
 			}
 
@@ -115,6 +115,14 @@ func TestTxSearch(t *testing.T) {
 		{"account.owner CONTAINS 'Vlad'", 0},
 		// search using the wrong key (of numeric type) using CONTAINS
 		{"account.number CONTAINS 'Iv'", 0},
+		// search using STARTS_WITH
+		{"account.owner STARTS_WITH 'Iv'", 1},
+		{"account.owner STARTS_WITH 'an'", 0},
+		// search using IN
+		{"account.owner IN ('Vlad', 'Ivan')", 1},
+		{"account.number IN (1, 2) AND account.owner IN ('Vlad')", 0},
+		// IN doesn't trigger a lookup by hash
+		{fmt.Sprintf("tx.hash IN ('%X')", hash), 0},
 		// search using EXISTS
 		{"account.number EXISTS", 1},
 		// search using EXISTS for non existing key
@@ -409,6 +417,35 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxIndexDeleteHeight(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	events := []abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}}},
+	}
+	txResult1 := txResultWithEvents(events)
+	txResult2 := txResultWithEvents(events)
+	txResult2.Height = 2
+	txResult2.Tx = types.Tx("BYE BYE WORLD")
+	require.NoError(t, indexer.Index(txResult1))
+	require.NoError(t, indexer.Index(txResult2))
+
+	require.NoError(t, indexer.DeleteHeight(2))
+	loaded, err := indexer.Get(types.Tx(txResult2.Tx).Hash())
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+	loaded, err = indexer.Get(types.Tx(txResult1.Tx).Hash())
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(txResult1, loaded))
+
+	for _, q := range []string{"account.number = 1", "tx.height >= 1"} {
+		results, err := indexer.Search(context.Background(), query.MustCompile(q))
+		require.NoError(t, err)
+		require.Len(t, results, 1, q)
+		assert.True(t, proto.Equal(txResult1, results[0]))
+	}
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -0,0 +1,140 @@
+package txindex
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// HeightRange is an inclusive range of heights.
+type HeightRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// Progress tracks the heights indexed by an indexer, as a sorted list of
+// disjoint, non-adjacent ranges persisted in a database. The heights missing
+// between the lowest and the highest indexed heights are the gaps left by the
+// indexer, e.g. when the node was restarted with indexing temporarily
+// disabled; the heights indexed before the tracking started are unknown, and
+// so not reported as missing.
+type Progress struct {
+	db  dbm.DB
+	key []byte
+
+	mtx    sync.Mutex
+	ranges []HeightRange
+}
+
+// NewProgress returns the progress of indexer, loaded from db.
+func NewProgress(db dbm.DB, indexer string) (*Progress, error) {
+	p := &Progress{db: db, key: []byte("indexerProgress:" + indexer)}
+	bz, err := db.Get(p.key)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) > 0 {
+		if err := json.Unmarshal(bz, &p.ranges); err != nil {
+			return nil, fmt.Errorf("decoding the indexer progress: %w", err)
+		}
+	}
+	return p, nil
+}
+
+// MarkIndexed records height as indexed.
+func (p *Progress) MarkIndexed(height int64) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	ranges, changed := addHeight(append([]HeightRange(nil), p.ranges...), height)
+	if !changed {
+		return nil
+	}
+	bz, err := json.Marshal(ranges)
+	if err != nil {
+		return err
+	}
+	if err := p.db.Set(p.key, bz); err != nil {
+		return err
+	}
+	p.ranges = ranges
+	return nil
+}
+
+// Truncate forgets the heights indexed above height, e.g. after they were
+// rolled back.
+func (p *Progress) Truncate(height int64) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var ranges []HeightRange
+	for _, r := range p.ranges {
+		if r.From > height {
+			break
+		}
+		if r.To > height {
+			r.To = height
+		}
+		ranges = append(ranges, r)
+	}
+	bz, err := json.Marshal(ranges)
+	if err != nil {
+		return err
+	}
+	if err := p.db.Set(p.key, bz); err != nil {
+		return err
+	}
+	p.ranges = ranges
+	return nil
+}
+
+// Indexed returns the ranges of indexed heights.
+func (p *Progress) Indexed() []HeightRange {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return append([]HeightRange(nil), p.ranges...)
+}
+
+// Missing returns the ranges of heights missing between the lowest and the
+// highest indexed heights.
+func (p *Progress) Missing() []HeightRange {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var missing []HeightRange
+	for i := 1; i < len(p.ranges); i++ {
+		missing = append(missing, HeightRange{From: p.ranges[i-1].To + 1, To: p.ranges[i].From - 1})
+	}
+	return missing
+}
+
+// addHeight returns ranges with height added, and whether it wasn't already
+// included. ranges may be modified.
+func addHeight(ranges []HeightRange, height int64) ([]HeightRange, bool) {
+	// the index of the first range ending at or after height - 1
+	i := 0
+	for i < len(ranges) && ranges[i].To < height-1 {
+		i++
+	}
+	switch {
+	case i == len(ranges) || ranges[i].From > height+1:
+		// not adjacent to any range
+		ranges = append(ranges, HeightRange{})
+		copy(ranges[i+1:], ranges[i:])
+		ranges[i] = HeightRange{From: height, To: height}
+	case ranges[i].From <= height && height <= ranges[i].To:
+		return ranges, false
+	case ranges[i].To == height-1:
+		ranges[i].To = height
+		// merge with the next range if now adjacent
+		if i+1 < len(ranges) && ranges[i+1].From == height+1 {
+			ranges[i].To = ranges[i+1].To
+			ranges = append(ranges[:i+1], ranges[i+2:]...)
+		}
+	default: // ranges[i].From == height+1
+		ranges[i].From = height
+	}
+	return ranges, true
+}
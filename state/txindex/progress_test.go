@@ -0,0 +1,49 @@
+package txindex_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/state/txindex"
+)
+
+func TestProgress(t *testing.T) {
+	store := db.NewMemDB()
+	progress, err := txindex.NewProgress(store, "kv")
+	require.NoError(t, err)
+	assert.Empty(t, progress.Indexed())
+	assert.Empty(t, progress.Missing())
+
+	for _, height := range []int64{5, 6, 7, 2, 10, 9, 6, 3, 12} {
+		require.NoError(t, progress.MarkIndexed(height))
+	}
+	assert.Equal(t, []txindex.HeightRange{{2, 3}, {5, 7}, {9, 10}, {12, 12}}, progress.Indexed())
+	assert.Equal(t, []txindex.HeightRange{{4, 4}, {8, 8}, {11, 11}}, progress.Missing())
+
+	// the adjacent ranges are merged
+	require.NoError(t, progress.MarkIndexed(4))
+	require.NoError(t, progress.MarkIndexed(11))
+	assert.Equal(t, []txindex.HeightRange{{2, 7}, {9, 12}}, progress.Indexed())
+
+	// the progress is persisted, per indexer
+	progress, err = txindex.NewProgress(store, "kv")
+	require.NoError(t, err)
+	assert.Equal(t, []txindex.HeightRange{{2, 7}, {9, 12}}, progress.Indexed())
+	assert.Equal(t, []txindex.HeightRange{{8, 8}}, progress.Missing())
+
+	// the heights above a rolled back height are forgotten
+	require.NoError(t, progress.Truncate(10))
+	assert.Equal(t, []txindex.HeightRange{{2, 7}, {9, 10}}, progress.Indexed())
+	require.NoError(t, progress.Truncate(8))
+	progress, err = txindex.NewProgress(store, "kv")
+	require.NoError(t, err)
+	assert.Equal(t, []txindex.HeightRange{{2, 7}}, progress.Indexed())
+
+	progress, err = txindex.NewProgress(store, "psql")
+	require.NoError(t, err)
+	assert.Empty(t, progress.Indexed())
+}
@@ -13,6 +13,8 @@ import (
 // Validate block
 
 func validateBlock(state State, block *types.Block) error {
+	encoding := state.ConsensusParams.Encoding.Canonical
+
 	// Validate internal consistency.
 	if err := block.ValidateBasic(); err != nil {
 		return err
@@ -69,15 +71,15 @@ func validateBlock(state State, block *types.Block) error {
 			block.LastResultsHash,
 		)
 	}
-	if !bytes.Equal(block.ValidatorsHash, state.Validators.Hash()) {
+	if !bytes.Equal(block.ValidatorsHash, state.Validators.Hash(encoding)) {
 		return fmt.Errorf("wrong Block.Header.ValidatorsHash.  Expected %X, got %v",
-			state.Validators.Hash(),
+			state.Validators.Hash(encoding),
 			block.ValidatorsHash,
 		)
 	}
-	if !bytes.Equal(block.NextValidatorsHash, state.NextValidators.Hash()) {
+	if !bytes.Equal(block.NextValidatorsHash, state.NextValidators.Hash(encoding)) {
 		return fmt.Errorf("wrong Block.Header.NextValidatorsHash.  Expected %X, got %v",
-			state.NextValidators.Hash(),
+			state.NextValidators.Hash(encoding),
 			block.NextValidatorsHash,
 		)
 	}
@@ -90,7 +92,7 @@ func validateBlock(state State, block *types.Block) error {
 	} else {
 		// LastCommit.Signatures length is checked in VerifyCommit.
 		if err := state.LastValidators.VerifyCommit(
-			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
+			state.ChainID, encoding, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
 			return err
 		}
 	}
@@ -146,5 +148,13 @@ func validateBlock(state State, block *types.Block) error {
 		return types.NewErrEvidenceOverflow(max, got)
 	}
 
+	// Check the evidence is of the enabled types.
+	for _, ev := range block.Evidence.Evidence {
+		if !state.ConsensusParams.Evidence.IsEnabled(ev) {
+			return types.NewErrInvalidEvidence(ev,
+				fmt.Errorf("%s evidence is disabled", types.EvidenceType(ev)))
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,197 @@
+package statesync
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/archive"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/proxy"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+// A snapshot archive is a tar archive, made of the manifest of the snapshot followed by its
+// chunks, in order. The SHA-256 checksum of each chunk is stored in the PAX records of its header.
+const (
+	archiveManifestName = "snapshot.json"
+	archiveChunkDir     = "chunks"
+)
+
+// ExportArchive writes the snapshot of the app at the given height and format to w, as a tar
+// archive. If height is 0, the latest snapshot is exported, and if format is 0, the snapshot with
+// the highest format at that height.
+func ExportArchive(conn proxy.AppConnSnapshot, height uint64, format uint32, w io.Writer) (*abci.Snapshot, error) {
+	resp, err := conn.ListSnapshotsSync(abci.RequestListSnapshots{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	snapshots := append([]*abci.Snapshot(nil), resp.Snapshots...)
+	sort.Slice(snapshots, func(i, j int) bool {
+		a, b := snapshots[i], snapshots[j]
+		return a.Height > b.Height || (a.Height == b.Height && a.Format > b.Format)
+	})
+	var snapshot *abci.Snapshot
+	for _, s := range snapshots {
+		if (height == 0 || s.Height == height) && (format == 0 || s.Format == format) {
+			snapshot = s
+			break
+		}
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("no snapshot found at height %v with format %v", height, format)
+	}
+	if snapshot.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+
+	tw := tar.NewWriter(w)
+	manifest, err := json.MarshalIndent(snapshotManifest{
+		Height:   snapshot.Height,
+		Format:   snapshot.Format,
+		Chunks:   snapshot.Chunks,
+		Hash:     snapshot.Hash,
+		Metadata: snapshot.Metadata,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.WriteFile(tw, archiveManifestName, manifest, nil); err != nil {
+		return nil, err
+	}
+
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		resp, err := conn.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  i,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load chunk %v: %w", i, err)
+		}
+		if resp.Chunk == nil {
+			return nil, fmt.Errorf("chunk %v is missing", i)
+		}
+		checksum := sha256.Sum256(resp.Chunk)
+		err = archive.WriteFile(tw, path.Join(archiveChunkDir, strconv.FormatUint(uint64(i), 10)), resp.Chunk,
+			map[string]string{archive.ChecksumRecord: hex.EncodeToString(checksum[:])})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// RestoreArchive restores the snapshot of the archive read from r into the app, the same way as a
+// snapshot fetched from peers: it is verified with the state provider, and the state and commit at
+// its height are returned, which the caller must use to bootstrap the node. The chunks are stored in
+// tempDir while restoring.
+func RestoreArchive(
+	cfg config.StateSyncConfig,
+	logger log.Logger,
+	conn proxy.AppConnSnapshot,
+	connQuery proxy.AppConnQuery,
+	stateProvider StateProvider,
+	tempDir string,
+	r io.Reader,
+) (sm.State, *types.Commit, error) {
+	snapshot, chunks, err := readArchive(r, tempDir)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+	defer chunks.Close()
+
+	// all the chunks are in the queue already, there are no peers to fetch them from
+	cfg.ChunkFetchers = 0
+	syncer := newSyncer(cfg, logger, conn, connQuery, stateProvider, tempDir)
+	return syncer.Sync(snapshot, chunks)
+}
+
+// readArchive reads a snapshot archive into a chunk queue, verifying the checksums of the chunks.
+func readArchive(r io.Reader, tempDir string) (*snapshot, *chunkQueue, error) {
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read snapshot archive: %w", err)
+	}
+	if hdr.Name != archiveManifestName {
+		return nil, nil, fmt.Errorf("invalid snapshot archive, expected %v, got %v", archiveManifestName, hdr.Name)
+	}
+	var manifest snapshotManifest
+	if err := json.NewDecoder(io.LimitReader(tr, int64(snapshotMsgSize))).Decode(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to read %v: %w", archiveManifestName, err)
+	}
+	snapshot := manifest.snapshot()
+	if snapshot.Height == 0 {
+		return nil, nil, errors.New("snapshot height cannot be 0")
+	}
+
+	chunks, err := newChunkQueue(snapshot, tempDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := readArchiveChunks(tr, snapshot, chunks); err != nil {
+		chunks.Close()
+		return nil, nil, err
+	}
+	return snapshot, chunks, nil
+}
+
+func readArchiveChunks(tr *tar.Reader, snapshot *snapshot, chunks *chunkQueue) error {
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read snapshot archive: %w", err)
+		}
+		dir, name := path.Split(hdr.Name)
+		if strings.TrimSuffix(dir, "/") != archiveChunkDir {
+			return fmt.Errorf("invalid snapshot archive, unexpected file %v", hdr.Name)
+		}
+		index, err := strconv.ParseUint(name, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid snapshot archive, unexpected file %v", hdr.Name)
+		}
+		if hdr.Size > int64(chunkMsgSize) {
+			return fmt.Errorf("chunk %v is too large: %v bytes", index, hdr.Size)
+		}
+		checksum, err := hex.DecodeString(hdr.PAXRecords[archive.ChecksumRecord])
+		if err != nil || len(checksum) != sha256.Size {
+			return fmt.Errorf("chunk %v has no valid checksum", index)
+		}
+		body := make([]byte, hdr.Size)
+		if _, err := io.ReadFull(tr, body); err != nil {
+			return fmt.Errorf("failed to read chunk %v: %w", index, err)
+		}
+		_, err = chunks.Add(&chunk{
+			Height:   snapshot.Height,
+			Format:   snapshot.Format,
+			Index:    uint32(index),
+			Chunk:    body,
+			Checksum: checksum,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if n := chunks.NumAdded(); n != int(snapshot.Chunks) {
+		return fmt.Errorf("snapshot archive has %v chunks, expected %v", n, snapshot.Chunks)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package statesync
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/archive"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/proxy"
+	proxymocks "github.com/cometbft/cometbft/proxy/mocks"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/statesync/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestExportRestoreArchive(t *testing.T) {
+	snapshots := []*abci.Snapshot{
+		{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}},
+		{Height: 2, Format: 1, Chunks: 3, Hash: []byte{2, 1}},
+		{Height: 2, Format: 2, Chunks: 3, Hash: []byte{2, 2}, Metadata: []byte("metadata")},
+	}
+	chunks := [][]byte{{2, 2, 0}, {}, {2, 2, 2}}
+
+	source := &proxymocks.AppConnSnapshot{}
+	source.On("ListSnapshotsSync", abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: snapshots,
+	}, nil)
+	for i, chunk := range chunks {
+		source.On("LoadSnapshotChunkSync", abci.RequestLoadSnapshotChunk{
+			Height: 2, Format: 2, Chunk: uint32(i),
+		}).Return(&abci.ResponseLoadSnapshotChunk{Chunk: chunk}, nil)
+	}
+
+	// the latest snapshot, with the highest format, is exported by default
+	var archive bytes.Buffer
+	exported, err := ExportArchive(source, 0, 0, &archive)
+	require.NoError(t, err)
+	assert.Equal(t, snapshots[2], exported)
+	source.AssertExpectations(t)
+
+	_, err = ExportArchive(source, 3, 0, io.Discard)
+	require.Error(t, err)
+
+	state := sm.State{LastBlockHeight: 2, AppHash: []byte("app_hash")}
+	state.Version.Consensus.App = testAppVersion
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(2)).Return(state.AppHash, nil)
+	stateProvider.On("State", mock.Anything, uint64(2)).Return(state, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(2)).Return(commit, nil)
+
+	target := &proxymocks.AppConnSnapshot{}
+	target.On("OfferSnapshotSync", abci.RequestOfferSnapshot{
+		Snapshot: snapshots[2],
+		AppHash:  state.AppHash,
+	}).Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	for i, chunk := range chunks {
+		target.On("ApplySnapshotChunkSync", abci.RequestApplySnapshotChunk{
+			Index: uint32(i), Chunk: chunk,
+		}).Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+	connQuery := &proxymocks.AppConnQuery{}
+	connQuery.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       testAppVersion,
+		LastBlockHeight:  2,
+		LastBlockAppHash: state.AppHash,
+	}, nil)
+
+	cfg := config.DefaultStateSyncConfig()
+	restoredState, restoredCommit, err := RestoreArchive(*cfg, log.NewNopLogger(), target, connQuery,
+		stateProvider, t.TempDir(), bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, state, restoredState)
+	assert.Equal(t, commit, restoredCommit)
+	target.AssertExpectations(t)
+	connQuery.AssertExpectations(t)
+}
+
+func TestReadArchive_Invalid(t *testing.T) {
+	source := &proxymocks.AppConnSnapshot{}
+	source.On("ListSnapshotsSync", abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}}},
+	}, nil)
+	source.On("LoadSnapshotChunkSync", mock.Anything).Return(&abci.ResponseLoadSnapshotChunk{
+		Chunk: []byte{1, 2, 3},
+	}, nil)
+	var exported bytes.Buffer
+	_, err := ExportArchive(source, 0, 0, &exported)
+	require.NoError(t, err)
+
+	// rewrite the archive, tampering with its files
+	rewrite := func(alter func(hdr *tar.Header, body []byte) []byte) io.Reader {
+		var out bytes.Buffer
+		tr := tar.NewReader(bytes.NewReader(exported.Bytes()))
+		tw := tar.NewWriter(&out)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			body, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			if body = alter(hdr, body); body == nil {
+				continue
+			}
+			hdr.Size = int64(len(body))
+			require.NoError(t, tw.WriteHeader(hdr))
+			_, err = tw.Write(body)
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return &out
+	}
+
+	testcases := map[string]func(hdr *tar.Header, body []byte) []byte{
+		"unaltered": func(hdr *tar.Header, body []byte) []byte { return body },
+		"corrupted chunk": func(hdr *tar.Header, body []byte) []byte {
+			if hdr.Name == "chunks/1" {
+				return []byte{3, 2, 1}
+			}
+			return body
+		},
+		"missing chunk": func(hdr *tar.Header, body []byte) []byte {
+			if hdr.Name == "chunks/0" {
+				return nil
+			}
+			return body
+		},
+		"missing checksum": func(hdr *tar.Header, body []byte) []byte {
+			delete(hdr.PAXRecords, archive.ChecksumRecord)
+			return body
+		},
+		"missing manifest": func(hdr *tar.Header, body []byte) []byte {
+			if hdr.Name == archiveManifestName {
+				return nil
+			}
+			return body
+		},
+	}
+	for name, alter := range testcases {
+		alter := alter
+		t.Run(name, func(t *testing.T) {
+			_, chunks, err := readArchive(rewrite(alter), t.TempDir())
+			if name == "unaltered" {
+				require.NoError(t, err)
+				require.NoError(t, chunks.Close())
+				return
+			}
+			require.Error(t, err)
+		})
+	}
+}
@@ -1,19 +1,36 @@
 package statesync
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/p2p"
 )
 
-// errDone is returned by chunkQueue.Next() when all chunks have been returned.
-var errDone = errors.New("chunk queue has completed")
+const (
+	// chunkQueueDirPrefix is the prefix of the temp dirs of the chunk queues.
+	chunkQueueDirPrefix = "tm-statesync-"
+	// chunkQueueManifestName is the file describing the snapshot in the temp dir of a chunk queue.
+	chunkQueueManifestName = "snapshot.json"
+)
+
+var (
+	// errDone is returned by chunkQueue.Next() when all chunks have been returned.
+	errDone = errors.New("chunk queue has completed")
+	// errChunkChecksum is returned by chunkQueue.Add() when a chunk doesn't match its checksum.
+	errChunkChecksum = errors.New("chunk doesn't match its checksum")
+)
 
 // chunk contains data for a chunk.
 type chunk struct {
@@ -22,6 +39,8 @@ type chunk struct {
 	Index  uint32
 	Chunk  []byte
 	Sender p2p.ID
+	// SHA-256 checksum of the chunk sent by the peer, if any
+	Checksum []byte
 }
 
 // chunkQueue manages chunks for a state sync process, ordering them if requested. It acts as an
@@ -40,15 +59,23 @@ type chunkQueue struct {
 
 // newChunkQueue creates a new chunk queue for a snapshot, using a temp dir for storage.
 // Callers must call Close() when done.
+//
+// The temp dir is specific to the snapshot, so that if the node is restarted in the middle of a
+// state sync, the chunks which were already fetched, and still match their checksum, are reused
+// rather than fetched again. The snapshot itself is described in the temp dir, so that it can be
+// found again with loadInterruptedSnapshots().
 func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
-	dir, err := os.MkdirTemp(tempDir, "tm-statesync")
-	if err != nil {
-		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %w", err)
-	}
 	if snapshot.Chunks == 0 {
 		return nil, errors.New("snapshot has no chunks")
 	}
-	return &chunkQueue{
+	dir := chunkQueueDir(snapshot, tempDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %w", err)
+	}
+	if err := writeChunkQueueManifest(dir, snapshot); err != nil {
+		return nil, err
+	}
+	q := &chunkQueue{
 		snapshot:       snapshot,
 		dir:            dir,
 		chunkFiles:     make(map[uint32]string, snapshot.Chunks),
@@ -56,10 +83,119 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
 		waiters:        make(map[uint32][]chan<- uint32),
-	}, nil
+	}
+	if err := q.loadExisting(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// chunkQueueDir returns the temp dir of the chunk queue of the snapshot.
+func chunkQueueDir(snapshot *snapshot, tempDir string) string {
+	if tempDir == "" {
+		tempDir = os.TempDir()
+	}
+	return filepath.Join(tempDir, fmt.Sprintf("%v%v-%v-%v-%X", chunkQueueDirPrefix,
+		snapshot.Height, snapshot.Format, snapshot.Chunks, snapshot.Hash))
 }
 
-// Add adds a chunk to the queue. It ignores chunks that already exist, returning false.
+// writeChunkQueueManifest describes the snapshot in the temp dir of its chunk queue, unless it was
+// already.
+func writeChunkQueueManifest(dir string, snapshot *snapshot) error {
+	path := filepath.Join(dir, chunkQueueManifestName)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	bz, err := json.Marshal(newSnapshotManifest(snapshot))
+	if err != nil {
+		return err
+	}
+	// written to a temp file first, so that an incomplete manifest is never loaded
+	if err := os.WriteFile(path+".tmp", bz, 0600); err != nil {
+		return fmt.Errorf("failed to save snapshot manifest: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to save snapshot manifest: %w", err)
+	}
+	return nil
+}
+
+// loadInterruptedSnapshots returns the snapshots which have a chunk queue in the temp dir, i.e.
+// which restoration was interrupted by a restart of the node, ordered by descending height and
+// format. The temp dirs which don't describe their snapshot are ignored.
+func loadInterruptedSnapshots(tempDir string) ([]*snapshot, error) {
+	if tempDir == "" {
+		tempDir = os.TempDir()
+	}
+	dirs, err := filepath.Glob(filepath.Join(tempDir, chunkQueueDirPrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]*snapshot, 0, len(dirs))
+	for _, dir := range dirs {
+		bz, err := os.ReadFile(filepath.Join(dir, chunkQueueManifestName))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to load snapshot manifest: %w", err)
+		}
+		var manifest snapshotManifest
+		if err := json.Unmarshal(bz, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot manifest %v: %w", dir, err)
+		}
+		snapshots = append(snapshots, manifest.snapshot())
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		a, b := snapshots[i], snapshots[j]
+		return a.Height > b.Height || (a.Height == b.Height && a.Format > b.Format)
+	})
+	return snapshots, nil
+}
+
+// loadExisting adds the chunks found in the temp dir to the queue, removing those which don't
+// match their checksum.
+func (q *chunkQueue) loadExisting() error {
+	for i := uint32(0); i < q.snapshot.Chunks; i++ {
+		path := q.chunkPath(i)
+		body, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to load chunk %v: %w", i, err)
+		}
+		checksum, err := os.ReadFile(path + ".sha256")
+		if err == nil {
+			checksum, err = hex.DecodeString(strings.TrimSpace(string(checksum)))
+		}
+		if sum := sha256.Sum256(body); err != nil || !bytes.Equal(sum[:], checksum) {
+			// an incomplete write, or a corrupted file
+			if err := q.removeChunkFiles(path); err != nil {
+				return fmt.Errorf("failed to remove chunk %v: %w", i, err)
+			}
+			continue
+		}
+		q.chunkFiles[i] = path
+		q.chunkAllocated[i] = true
+	}
+	return nil
+}
+
+func (q *chunkQueue) chunkPath(index uint32) string {
+	return filepath.Join(q.dir, strconv.FormatUint(uint64(index), 10))
+}
+
+// removeChunkFiles removes the chunk at the given path, and its checksum.
+func (q *chunkQueue) removeChunkFiles(path string) error {
+	for _, p := range []string{path, path + ".sha256"} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add adds a chunk to the queue. It ignores chunks that already exist, returning false. It returns
+// errChunkChecksum if the chunk has a checksum which it doesn't match.
 func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	if chunk == nil || chunk.Chunk == nil {
 		return false, errors.New("cannot add nil chunk")
@@ -81,12 +217,22 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	if q.chunkFiles[chunk.Index] != "" {
 		return false, nil
 	}
+	checksum := sha256.Sum256(chunk.Chunk)
+	if chunk.Checksum != nil && !bytes.Equal(checksum[:], chunk.Checksum) {
+		return false, fmt.Errorf("%w: chunk %v, expected %X, got %X",
+			errChunkChecksum, chunk.Index, chunk.Checksum, checksum)
+	}
 
-	path := filepath.Join(q.dir, strconv.FormatUint(uint64(chunk.Index), 10))
+	path := q.chunkPath(chunk.Index)
 	err := os.WriteFile(path, chunk.Chunk, 0600)
 	if err != nil {
 		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
 	}
+	// the checksum is written last, so that an incomplete chunk is never resumed
+	err = os.WriteFile(path+".sha256", []byte(hex.EncodeToString(checksum[:])), 0600)
+	if err != nil {
+		return false, fmt.Errorf("failed to save checksum of chunk %v to file %v: %w", chunk.Index, path, err)
+	}
 	q.chunkFiles[chunk.Index] = path
 	q.chunkSenders[chunk.Index] = chunk.Sender
 
@@ -159,7 +305,7 @@ func (q *chunkQueue) discard(index uint32) error {
 	if path == "" {
 		return nil
 	}
-	err := os.Remove(path)
+	err := q.removeChunkFiles(path)
 	if err != nil {
 		return fmt.Errorf("failed to remove chunk %v: %w", index, err)
 	}
@@ -285,6 +431,13 @@ func (q *chunkQueue) RetryAll() {
 	q.chunkReturned = make(map[uint32]bool)
 }
 
+// NumAdded returns the number of chunks in the queue.
+func (q *chunkQueue) NumAdded() int {
+	q.Lock()
+	defer q.Unlock()
+	return len(q.chunkFiles)
+}
+
 // Size returns the total number of chunks for the snapshot and queue, or 0 when closed.
 func (q *chunkQueue) Size() uint32 {
 	q.Lock()
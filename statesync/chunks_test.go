@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"crypto/sha256"
 	"os"
 	"testing"
 
@@ -53,6 +54,68 @@ func TestNewChunkQueue_TempDir(t *testing.T) {
 	assert.Len(t, files, 0)
 }
 
+func TestNewChunkQueue_Resume(t *testing.T) {
+	snapshot := &snapshot{
+		Height:   3,
+		Format:   1,
+		Chunks:   3,
+		Hash:     []byte{7},
+		Metadata: nil,
+	}
+	dir := t.TempDir()
+	queue, err := newChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	for i := uint32(0); i < 3; i++ {
+		added, err := queue.Add(&chunk{Height: 3, Format: 1, Index: i, Chunk: []byte{3, 1, byte(i)}})
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	// simulate a crash in the middle of writing the last chunk
+	require.NoError(t, os.WriteFile(queue.chunkPath(2), []byte{3, 1}, 0600))
+
+	// the chunks which are intact are reused by a new queue for the same snapshot
+	resumed, err := newChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, resumed.NumAdded())
+	assert.True(t, resumed.Has(0))
+	assert.True(t, resumed.Has(1))
+	assert.False(t, resumed.Has(2))
+
+	index, err := resumed.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, index)
+
+	c, err := resumed.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3, 1, 0}, c.Chunk)
+
+	// but not by a queue for a different snapshot
+	otherSnapshot := *snapshot
+	otherSnapshot.Hash = []byte{8}
+	other, err := newChunkQueue(&otherSnapshot, dir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, other.NumAdded())
+
+	require.NoError(t, resumed.Close())
+	require.NoError(t, other.Close())
+}
+
+func TestChunkQueue_AddChecksum(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	body := []byte{3, 1, 0}
+	checksum := sha256.Sum256(body)
+
+	_, err := queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: body, Checksum: []byte{1, 2, 3}})
+	require.ErrorIs(t, err, errChunkChecksum)
+	assert.False(t, queue.Has(0))
+
+	added, err := queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: body, Checksum: checksum[:]})
+	require.NoError(t, err)
+	assert.True(t, added)
+}
+
 func TestChunkQueue(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
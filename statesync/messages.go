@@ -1,10 +1,12 @@
 package statesync
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 
 	"github.com/cosmos/gogoproto/proto"
+	"github.com/klauspost/compress/zstd"
 
 	ssproto "github.com/cometbft/cometbft/proto/tendermint/statesync"
 )
@@ -16,6 +18,52 @@ const (
 	chunkMsgSize = int(16e6)
 )
 
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	// chunks can't be larger than chunkMsgSize uncompressed, as they couldn't be sent to peers
+	// which don't support compression otherwise
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(uint64(chunkMsgSize)))
+)
+
+// encodeChunk fills in the chunk response to a request, compressing the chunk if the requester
+// supports it and if it makes it smaller.
+func encodeChunk(req *ssproto.ChunkRequest, body []byte) *ssproto.ChunkResponse {
+	resp := &ssproto.ChunkResponse{
+		Height:  req.Height,
+		Format:  req.Format,
+		Index:   req.Index,
+		Chunk:   body,
+		Missing: body == nil,
+	}
+	if body == nil {
+		return resp
+	}
+	checksum := sha256.Sum256(body)
+	resp.Checksum = checksum[:]
+	if req.AcceptZstd {
+		if compressed := zstdEncoder.EncodeAll(body, nil); len(compressed) < len(body) {
+			resp.Chunk = compressed
+			resp.Zstd = true
+		}
+	}
+	return resp
+}
+
+// decodeChunk returns the chunk of a chunk response, decompressing it if needed.
+func decodeChunk(msg *ssproto.ChunkResponse) ([]byte, error) {
+	if !msg.Zstd {
+		return msg.Chunk, nil
+	}
+	body, err := zstdDecoder.DecodeAll(msg.Chunk, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress chunk %v: %w", msg.Index, err)
+	}
+	if body == nil {
+		body = []byte{}
+	}
+	return body, nil
+}
+
 // validateMsg validates a message.
 func validateMsg(pb proto.Message) error {
 	if pb == nil {
@@ -36,6 +84,12 @@ func validateMsg(pb proto.Message) error {
 		if !msg.Missing && msg.Chunk == nil {
 			return errors.New("chunk cannot be nil")
 		}
+		if msg.Missing && (msg.Zstd || len(msg.Checksum) > 0) {
+			return errors.New("missing chunk cannot be compressed or have a checksum")
+		}
+		if len(msg.Checksum) > 0 && len(msg.Checksum) != sha256.Size {
+			return fmt.Errorf("checksum must be %d bytes, got %d", sha256.Size, len(msg.Checksum))
+		}
 	case *ssproto.SnapshotsRequest:
 	case *ssproto.SnapshotsResponse:
 		if msg.Height == 0 {
@@ -1,6 +1,8 @@
 package statesync
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"testing"
 
@@ -52,6 +54,15 @@ func TestValidateMsg(t *testing.T) {
 		"ChunkResponse missing with body": {
 			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Missing: true, Chunk: []byte{1}},
 			false},
+		"ChunkResponse with checksum": {
+			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{1}, Checksum: make([]byte, 32)},
+			true},
+		"ChunkResponse with invalid checksum": {
+			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{1}, Checksum: []byte{1}},
+			false},
+		"ChunkResponse missing with checksum": {
+			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Missing: true, Checksum: make([]byte, 32)},
+			false},
 
 		"SnapshotsRequest valid": {&ssproto.SnapshotsRequest{}, true},
 
@@ -84,6 +95,43 @@ func TestValidateMsg(t *testing.T) {
 	}
 }
 
+func TestEncodeDecodeChunk(t *testing.T) {
+	compressible := bytes.Repeat([]byte("chunk"), 1000)
+	testcases := map[string]struct {
+		req          *ssproto.ChunkRequest
+		body         []byte
+		expectZstd   bool
+		expectLength int
+	}{
+		"uncompressed":   {&ssproto.ChunkRequest{Height: 1, Index: 1}, compressible, false, len(compressible)},
+		"compressed":     {&ssproto.ChunkRequest{Height: 1, Index: 1, AcceptZstd: true}, compressible, true, -1},
+		"incompressible": {&ssproto.ChunkRequest{Height: 1, Index: 1, AcceptZstd: true}, []byte{1, 2, 3}, false, 3},
+		"empty":          {&ssproto.ChunkRequest{Height: 1, Index: 1, AcceptZstd: true}, []byte{}, false, 0},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			resp := encodeChunk(tc.req, tc.body)
+			require.NoError(t, validateMsg(resp))
+			require.Equal(t, tc.expectZstd, resp.Zstd)
+			if tc.expectLength >= 0 {
+				require.Len(t, resp.Chunk, tc.expectLength)
+			} else {
+				require.Less(t, len(resp.Chunk), len(tc.body))
+			}
+			checksum := sha256.Sum256(tc.body)
+			require.Equal(t, checksum[:], resp.Checksum)
+
+			body, err := decodeChunk(resp)
+			require.NoError(t, err)
+			require.Equal(t, tc.body, body)
+		})
+	}
+
+	_, err := decodeChunk(&ssproto.ChunkResponse{Height: 1, Chunk: []byte{1, 2, 3}, Zstd: true})
+	require.Error(t, err)
+}
+
 //nolint:lll // ignore line length
 func TestStateSyncVectors(t *testing.T) {
 
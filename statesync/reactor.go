@@ -182,17 +182,12 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 					"chunk", msg.Index, "err", err)
 				return
 			}
+			chunkResp := encodeChunk(msg, resp.Chunk)
 			r.Logger.Debug("Sending chunk", "height", msg.Height, "format", msg.Format,
-				"chunk", msg.Index, "peer", e.Src.ID())
+				"chunk", msg.Index, "compressed", chunkResp.Zstd, "peer", e.Src.ID())
 			e.Src.Send(p2p.Envelope{
 				ChannelID: ChunkChannel,
-				Message: &ssproto.ChunkResponse{
-					Height:  msg.Height,
-					Format:  msg.Format,
-					Index:   msg.Index,
-					Chunk:   resp.Chunk,
-					Missing: resp.Chunk == nil,
-				},
+				Message:   chunkResp,
 			})
 
 		case *ssproto.ChunkResponse:
@@ -204,13 +199,26 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 			}
 			r.Logger.Debug("Received chunk, adding to sync", "height", msg.Height, "format", msg.Format,
 				"chunk", msg.Index, "peer", e.Src.ID())
-			_, err := r.syncer.AddChunk(&chunk{
-				Height: msg.Height,
-				Format: msg.Format,
-				Index:  msg.Index,
-				Chunk:  msg.Chunk,
-				Sender: e.Src.ID(),
+			body, err := decodeChunk(msg)
+			if err != nil {
+				r.Logger.Error("Invalid chunk", "peer", e.Src, "err", err)
+				r.Switch.StopPeerForError(e.Src, err)
+				return
+			}
+			_, err = r.syncer.AddChunk(&chunk{
+				Height:   msg.Height,
+				Format:   msg.Format,
+				Index:    msg.Index,
+				Chunk:    body,
+				Sender:   e.Src.ID(),
+				Checksum: msg.Checksum,
 			})
+			if errors.Is(err, errChunkChecksum) {
+				// the chunk was corrupted, it will be fetched again, from another peer
+				r.Logger.Error("Invalid chunk", "peer", e.Src, "err", err)
+				r.Switch.StopPeerForError(e.Src, err)
+				return
+			}
 			if err != nil {
 				r.Logger.Error("Failed to add chunk", "height", msg.Height, "format", msg.Format,
 					"chunk", msg.Index, "err", err)
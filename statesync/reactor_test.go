@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"crypto/sha256"
 	"testing"
 	"time"
 
@@ -26,11 +27,18 @@ func TestReactor_Receive_ChunkRequest(t *testing.T) {
 		"chunk is returned": {
 			&ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1},
 			[]byte{1, 2, 3},
-			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 2, 3}}},
+			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 2, 3},
+				Checksum: checksum([]byte{1, 2, 3})}},
+		"chunk is not compressed if it doesn't get smaller": {
+			&ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1, AcceptZstd: true},
+			[]byte{1, 2, 3},
+			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 2, 3},
+				Checksum: checksum([]byte{1, 2, 3})}},
 		"empty chunk is returned, as nil": {
 			&ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1},
 			[]byte{},
-			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: nil}},
+			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: nil,
+				Checksum: checksum([]byte{})}},
 		"nil (missing) chunk is returned as missing": {
 			&ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1},
 			nil,
@@ -183,3 +191,8 @@ func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
 		})
 	}
 }
+
+func checksum(body []byte) []byte {
+	sum := sha256.Sum256(body)
+	return sum[:]
+}
@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"sort"
 
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/p2p"
 )
@@ -38,6 +39,36 @@ func (s *snapshot) Key() snapshotKey {
 	return key
 }
 
+// snapshotManifest is the JSON encoding of a snapshot, stored in snapshot archives and along with
+// the chunks of the snapshot being restored.
+type snapshotManifest struct {
+	Height   uint64            `json:"height"`
+	Format   uint32            `json:"format"`
+	Chunks   uint32            `json:"chunks"`
+	Hash     cmtbytes.HexBytes `json:"hash"`
+	Metadata []byte            `json:"metadata"`
+}
+
+func newSnapshotManifest(s *snapshot) snapshotManifest {
+	return snapshotManifest{
+		Height:   s.Height,
+		Format:   s.Format,
+		Chunks:   s.Chunks,
+		Hash:     s.Hash,
+		Metadata: s.Metadata,
+	}
+}
+
+func (m snapshotManifest) snapshot() *snapshot {
+	return &snapshot{
+		Height:   m.Height,
+		Format:   m.Format,
+		Chunks:   m.Chunks,
+		Hash:     m.Hash,
+		Metadata: m.Metadata,
+	}
+}
+
 // snapshotPool discovers and aggregates snapshots across peers.
 type snapshotPool struct {
 	cmtsync.Mutex
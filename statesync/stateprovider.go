@@ -1,7 +1,9 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -45,9 +47,11 @@ type lightClientStateProvider struct {
 }
 
 // NewLightClientStateProvider creates a new StateProvider using a light client and RPC clients.
+// The encoding is the canonical encoding of the chain.
 func NewLightClientStateProvider(
 	ctx context.Context,
 	chainID string,
+	encoding types.CanonicalEncoding,
 	version cmtstate.Version,
 	initialHeight int64,
 	servers []string,
@@ -60,19 +64,31 @@ func NewLightClientStateProvider(
 
 	providers := make([]lightprovider.Provider, 0, len(servers))
 	providerRemotes := make(map[lightprovider.Provider]string)
+	clients := make(map[string]*rpchttp.HTTP, len(servers))
 	for _, server := range servers {
 		client, err := rpcClient(server)
 		if err != nil {
 			return nil, fmt.Errorf("failed to set up RPC client: %w", err)
 		}
-		provider := lighthttp.NewWithClient(chainID, client)
+		provider := lighthttp.NewWithClient(chainID, encoding, client)
 		providers = append(providers, provider)
 		// We store the RPC addresses keyed by provider, so we can find the address of the primary
 		// provider used by the light client and use it to fetch consensus parameters.
 		providerRemotes[provider] = server
+		clients[server] = client
 	}
 
-	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
+	if trustOptions.Height == 0 && len(trustOptions.Hash) == 0 {
+		var err error
+		trustOptions, err = discoverTrustOptions(ctx, clients, trustOptions.Period, encoding)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover the trusted height and hash: %w", err)
+		}
+		logger.Info("Trusting the latest block which all the RPC servers agree on",
+			"height", trustOptions.Height, "hash", log.NewLazySprintf("%X", trustOptions.Hash))
+	}
+
+	lc, err := light.NewClient(ctx, chainID, encoding, trustOptions, providers[0], providers[1:],
 		lightdb.New(dbm.NewMemDB(), ""), light.Logger(logger), light.MaxRetryAttempts(5))
 	if err != nil {
 		return nil, err
@@ -191,6 +207,53 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	return state, nil
 }
 
+// discoverTrustOptions returns the trust options for the latest block which all the RPC servers
+// have, provided that they all agree on its hash, so that the trusted height and hash don't have
+// to be obtained by hand. The RPC servers must then be trusted not to collude.
+func discoverTrustOptions(
+	ctx context.Context,
+	clients map[string]*rpchttp.HTTP,
+	trustPeriod time.Duration,
+	encoding types.CanonicalEncoding,
+) (light.TrustOptions, error) {
+	var height int64
+	for server, client := range clients {
+		status, err := client.Status(ctx)
+		if err != nil {
+			return light.TrustOptions{}, fmt.Errorf("failed to fetch the status of %v: %w", server, err)
+		}
+		if h := status.SyncInfo.LatestBlockHeight; height == 0 || h < height {
+			height = h
+		}
+	}
+	if height == 0 {
+		return light.TrustOptions{}, errors.New("the RPC servers have no blocks")
+	}
+
+	var hash []byte
+	for server, client := range clients {
+		commit, err := client.Commit(ctx, &height)
+		if err != nil {
+			return light.TrustOptions{}, fmt.Errorf("failed to fetch the commit at height %v from %v: %w",
+				height, server, err)
+		}
+		switch {
+		case commit.Header == nil:
+			return light.TrustOptions{}, fmt.Errorf("%v returned no header at height %v", server, height)
+		case hash == nil:
+			hash = commit.Hash(encoding)
+		case !bytes.Equal(hash, commit.Hash(encoding)):
+			return light.TrustOptions{}, fmt.Errorf("the RPC servers disagree on the hash of the block at height %v",
+				height)
+		}
+	}
+	return light.TrustOptions{
+		Period: trustPeriod,
+		Height: height,
+		Hash:   hash,
+	}, nil
+}
+
 // rpcClient sets up a new RPC client
 func rpcClient(server string) (*rpchttp.HTTP, error) {
 	if !strings.Contains(server, "://") {
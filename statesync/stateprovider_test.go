@@ -0,0 +1,67 @@
+package statesync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/libs/log"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// startRPCServer serves the status and commits of a chain of the given height, where the header
+// at height h is made by header(h).
+func startRPCServer(t *testing.T, latest int64, header func(height int64) *types.Header) *rpchttp.HTTP {
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		"status": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
+			return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: latest}}, nil
+		}, ""),
+		"commit": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommit, error) {
+			return ctypes.NewResultCommit(header(*height), &types.Commit{Height: *height}, true), nil
+		}, "height"),
+	}, log.NewNopLogger())
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client, err := rpcClient(server.URL)
+	require.NoError(t, err)
+	return client
+}
+
+func TestDiscoverTrustOptions(t *testing.T) {
+	header := func(height int64) *types.Header {
+		return &types.Header{ChainID: "chain", Height: height, ValidatorsHash: tmhash.Sum([]byte("vals"))}
+	}
+	forked := func(height int64) *types.Header {
+		return &types.Header{ChainID: "fork", Height: height, ValidatorsHash: tmhash.Sum([]byte("vals"))}
+	}
+	ctx := context.Background()
+
+	// the latest block which all the servers have is trusted
+	clients := map[string]*rpchttp.HTTP{
+		"a": startRPCServer(t, 10, header),
+		"b": startRPCServer(t, 8, header),
+		"c": startRPCServer(t, 12, header),
+	}
+	opts, err := discoverTrustOptions(ctx, clients, time.Hour, types.CanonicalEncodingProto)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, opts.Period)
+	assert.EqualValues(t, 8, opts.Height)
+	assert.EqualValues(t, header(8).Hash(types.CanonicalEncodingProto), opts.Hash)
+	require.NoError(t, opts.ValidateBasic())
+
+	// as long as they agree on its hash
+	clients["d"] = startRPCServer(t, 10, forked)
+	_, err = discoverTrustOptions(ctx, clients, time.Hour, types.CanonicalEncodingProto)
+	require.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -147,18 +148,27 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 		discoveryTime = 5 * minimumDiscoveryTime
 	}
 
-	if discoveryTime > 0 {
+	// The app may ask us to retry a snapshot restoration, in which case we need to reuse
+	// the snapshot and chunk queue from the previous loop iteration. This is also how the
+	// restoration of a snapshot interrupted by a restart is resumed.
+	snapshot, chunks := s.loadInterrupted()
+	if chunks != nil {
+		defer chunks.Close() // in case we forget to close it elsewhere
+	}
+	if discoveryTime > 0 && (chunks == nil || chunks.NumAdded() < int(snapshot.Chunks)) {
 		s.logger.Info("Discovering snapshots", "discoverTime", discoveryTime)
-		time.Sleep(discoveryTime)
+		s.discover(discoveryTime, snapshot)
+	}
+	if chunks != nil && chunks.NumAdded() < int(snapshot.Chunks) && len(s.snapshots.GetPeers(snapshot)) == 0 {
+		s.logger.Info("No peers have the interrupted snapshot, discarding it", "height", snapshot.Height,
+			"format", snapshot.Format, "hash", log.NewLazySprintf("%X", snapshot.Hash))
+		if err := chunks.Close(); err != nil {
+			s.logger.Error("Failed to clean up chunk queue", "err", err)
+		}
+		snapshot, chunks = nil, nil
 	}
 
-	// The app may ask us to retry a snapshot restoration, in which case we need to reuse
-	// the snapshot and chunk queue from the previous loop iteration.
-	var (
-		snapshot *snapshot
-		chunks   *chunkQueue
-		err      error
-	)
+	var err error
 	for {
 		// If not nil, we're going to retry restoration of the same snapshot.
 		if snapshot == nil {
@@ -236,6 +246,54 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 	}
 }
 
+// loadInterrupted returns the latest snapshot which restoration was interrupted by a restart of
+// the node, if any, and its chunk queue, with the chunks fetched before the restart. The chunk
+// queues of the other interrupted snapshots are discarded.
+func (s *syncer) loadInterrupted() (*snapshot, *chunkQueue) {
+	snapshots, err := loadInterruptedSnapshots(s.tempDir)
+	if err != nil {
+		s.logger.Error("Failed to load interrupted snapshot restorations", "err", err)
+		return nil, nil
+	}
+	var (
+		snapshot *snapshot
+		chunks   *chunkQueue
+	)
+	for _, candidate := range snapshots {
+		if chunks == nil {
+			chunks, err = newChunkQueue(candidate, s.tempDir)
+			if err != nil {
+				s.logger.Error("Failed to load chunk queue", "height", candidate.Height, "err", err)
+				chunks = nil
+			} else {
+				snapshot = candidate
+			}
+			continue
+		}
+		if err := os.RemoveAll(chunkQueueDir(candidate, s.tempDir)); err != nil {
+			s.logger.Error("Failed to clean up chunk queue", "height", candidate.Height, "err", err)
+		}
+	}
+	if snapshot != nil {
+		s.logger.Info("Resuming snapshot restoration", "height", snapshot.Height, "format", snapshot.Format,
+			"hash", log.NewLazySprintf("%X", snapshot.Hash), "chunks", chunks.NumAdded(), "total", snapshot.Chunks)
+	}
+	return snapshot, chunks
+}
+
+// discover waits for peers to advertise their snapshots, for the given discovery time, or until
+// the given snapshot, if any, is advertised.
+func (s *syncer) discover(discoveryTime time.Duration, snapshot *snapshot) {
+	if snapshot == nil {
+		time.Sleep(discoveryTime)
+		return
+	}
+	deadline := time.Now().Add(discoveryTime)
+	for time.Now().Before(deadline) && len(s.snapshots.GetPeers(snapshot)) == 0 {
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
 // Sync executes a sync for a specific snapshot, returning the latest state and block commit which
 // the caller must use to bootstrap the node.
 func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.Commit, error) {
@@ -474,9 +532,10 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 	peer.Send(p2p.Envelope{
 		ChannelID: ChunkChannel,
 		Message: &ssproto.ChunkRequest{
-			Height: snapshot.Height,
-			Format: snapshot.Format,
-			Index:  chunk,
+			Height:     snapshot.Height,
+			Format:     snapshot.Format,
+			Index:      chunk,
+			AcceptZstd: true,
 		},
 	})
 }
@@ -35,7 +35,7 @@ func setupOfferSyncer(t *testing.T) (*syncer, *proxymocks.AppConnSnapshot) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
 	cfg := config.DefaultStateSyncConfig()
-	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, t.TempDir())
 
 	return syncer, connSnapshot
 }
@@ -89,7 +89,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 	connQuery := &proxymocks.AppConnQuery{}
 
 	cfg := config.DefaultStateSyncConfig()
-	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, t.TempDir())
 
 	// Adding a chunk should error when no sync is in progress
 	_, err := syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1}})
@@ -231,6 +231,70 @@ func TestSyncer_SyncAny(t *testing.T) {
 	peerB.AssertExpectations(t)
 }
 
+func TestSyncer_SyncAny_resume(t *testing.T) {
+	state := sm.State{ChainID: "chain", LastBlockHeight: 2, AppHash: []byte("app_hash")}
+	state.Version.Consensus.App = testAppVersion
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+	s := &snapshot{Height: 2, Format: 1, Chunks: 2, Hash: []byte{2}, Metadata: []byte("metadata")}
+	older := &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}}
+	partial := &snapshot{Height: 3, Format: 1, Chunks: 2, Hash: []byte{3}}
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(2)).Return(state.AppHash, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(2)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(2)).Return(state, nil)
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connSnapshot.On("OfferSnapshotSync", abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{Height: 2, Format: 1, Chunks: 2, Hash: []byte{2}, Metadata: []byte("metadata")},
+		AppHash:  state.AppHash,
+	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	for i := uint32(0); i < 2; i++ {
+		connSnapshot.On("ApplySnapshotChunkSync", abci.RequestApplySnapshotChunk{
+			Index: i, Chunk: []byte{2, byte(i)},
+		}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+	connQuery := &proxymocks.AppConnQuery{}
+	connQuery.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       testAppVersion,
+		LastBlockHeight:  2,
+		LastBlockAppHash: state.AppHash,
+	}, nil)
+
+	// the restorations of the snapshots were interrupted by a restart, after all the chunks of the
+	// snapshot at height 2 were fetched
+	tempDir := t.TempDir()
+	for _, snapshot := range []*snapshot{s, older} {
+		queue, err := newChunkQueue(snapshot, tempDir)
+		require.NoError(t, err)
+		for i := uint32(0); i < snapshot.Chunks; i++ {
+			_, err := queue.Add(&chunk{Height: snapshot.Height, Format: 1, Index: i,
+				Chunk: []byte{byte(snapshot.Height), byte(i)}})
+			require.NoError(t, err)
+		}
+	}
+
+	// the snapshot is restored without any peers, and the older snapshot is discarded
+	cfg := config.DefaultStateSyncConfig()
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, tempDir)
+	newState, lastCommit, err := syncer.SyncAny(0, func() {})
+	require.NoError(t, err)
+	assert.Equal(t, state, newState)
+	assert.Equal(t, commit, lastCommit)
+	connSnapshot.AssertExpectations(t)
+	assert.NoDirExists(t, chunkQueueDir(s, tempDir))
+	assert.NoDirExists(t, chunkQueueDir(older, tempDir))
+
+	// an incomplete snapshot which no peer has is discarded
+	queue, err := newChunkQueue(partial, tempDir)
+	require.NoError(t, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 0}})
+	require.NoError(t, err)
+	syncer = newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, tempDir)
+	_, _, err = syncer.SyncAny(0, func() {})
+	assert.Equal(t, errNoSnapshots, err)
+	assert.NoDirExists(t, chunkQueueDir(partial, tempDir))
+}
+
 func TestSyncer_SyncAny_noSnapshots(t *testing.T) {
 	syncer, _ := setupOfferSyncer(t)
 	_, _, err := syncer.SyncAny(0, func() {})
@@ -437,6 +501,7 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 			body := []byte{1, 2, 3}
 			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 1}, "")
 			require.NoError(t, err)
+			defer chunks.Close()
 			_, err = chunks.Add(&chunk{Height: 1, Format: 1, Index: 0, Chunk: body})
 			require.NoError(t, err)
 
@@ -0,0 +1,366 @@
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/cometbft/cometbft/internal/archive"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+// A block archive is a tar archive, made of a manifest followed by a directory per height, in
+// ascending order, holding the block, its commit, the validator set at that height and, if
+// available, the ABCI responses of the block, encoded in protobuf. The SHA-256 checksum of each
+// file is stored in the PAX records of its header.
+const (
+	ArchiveVersion = 1
+
+	archiveManifestName      = "manifest.json"
+	archiveBlockName         = "block"
+	archiveCommitName        = "commit"
+	archiveValidatorsName    = "validators"
+	archiveABCIResponsesName = "abci_responses"
+
+	// maxArchiveFileSize bounds the size of the files read from an archive.
+	maxArchiveFileSize = 2 * types.MaxBlockSizeBytes
+)
+
+// ArchiveManifest describes the content of a block archive.
+type ArchiveManifest struct {
+	Version uint32 `json:"version"`
+	ChainID string `json:"chain_id"`
+	// From and To are the first and last heights of the archive, inclusive.
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// ExportArchive writes the blocks from height from to height to (inclusive), along with their
+// commits, validator sets and ABCI responses, to w, as a tar archive.
+//
+// The blocks and validator sets must be in the stores. ABCI responses which have been discarded
+// or pruned are skipped.
+func ExportArchive(blockStore *BlockStore, stateStore sm.Store, from, to int64, w io.Writer) (*ArchiveManifest, error) {
+	if to == 0 { // not the height of the block store, unlike for NewIterator
+		return nil, fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+	it, err := NewIterator(blockStore, stateStore, from, to)
+	if err != nil {
+		return nil, err
+	}
+	meta := blockStore.LoadBlockMeta(from)
+	if meta == nil {
+		return nil, fmt.Errorf("block %d not found", from)
+	}
+	manifest := &ArchiveManifest{
+		Version: ArchiveVersion,
+		ChainID: meta.Header.ChainID,
+		From:    from,
+		To:      to,
+	}
+	bz, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeArchiveFile(tw, archiveManifestName, bz); err != nil {
+		return nil, err
+	}
+	for it.Next() {
+		if err := exportHeight(tw, it, stateStore); err != nil {
+			return nil, err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func exportHeight(tw *tar.Writer, it *Iterator, stateStore sm.Store) error {
+	height := it.Height()
+	block := it.Block()
+	if block == nil {
+		return fmt.Errorf("block %d not found", height)
+	}
+	pbb, err := block.ToProto()
+	if err != nil {
+		return fmt.Errorf("failed to convert block %d to protobuf: %w", height, err)
+	}
+
+	commit := it.Commit()
+	if commit == nil {
+		return fmt.Errorf("commit %d not found", height)
+	}
+
+	vals, err := stateStore.LoadValidators(height)
+	if err != nil {
+		return fmt.Errorf("failed to load validators %d: %w", height, err)
+	}
+	pbv, err := vals.ToProto()
+	if err != nil {
+		return fmt.Errorf("failed to convert validators %d to protobuf: %w", height, err)
+	}
+
+	files := []archiveMsg{
+		{archiveBlockName, pbb},
+		{archiveCommitName, commit.ToProto()},
+		{archiveValidatorsName, pbv},
+	}
+	abciResponses, err := it.ABCIResponses()
+	if err != nil {
+		return err
+	}
+	if abciResponses != nil {
+		files = append(files, archiveMsg{archiveABCIResponsesName, abciResponses})
+	}
+
+	for _, file := range files {
+		bz, err := proto.Marshal(file.msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %d: %w", file.name, height, err)
+		}
+		if err := writeArchiveFile(tw, archivePath(height, file.name), bz); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveMsg is a file of an archive, encoded in protobuf.
+type archiveMsg struct {
+	name string
+	msg  proto.Message
+}
+
+func archivePath(height int64, name string) string {
+	return path.Join(strconv.FormatInt(height, 10), name)
+}
+
+// writeArchiveFile writes a file to the archive, with its checksum.
+func writeArchiveFile(tw *tar.Writer, name string, body []byte) error {
+	checksum := sha256.Sum256(body)
+	return archive.WriteFile(tw, name, body, map[string]string{archive.ChecksumRecord: hex.EncodeToString(checksum[:])})
+}
+
+// ImportArchive reads a block archive written by ExportArchive from r into the stores. The
+// archive must be of the given chain, whose canonical encoding the blocks and validator sets are
+// hashed with, and its blocks must follow the last block of the block store, if any.
+//
+// The blocks are checked against their commits and validator sets, and against each other, but
+// the signatures of the commits are not verified: the archive must come from a trusted source.
+func ImportArchive(blockStore *BlockStore, stateStore sm.Store, chainID string, encoding types.CanonicalEncoding,
+	r io.Reader) (*ArchiveManifest, error) {
+	ar := &archiveReader{tr: tar.NewReader(r)}
+	name, bz, err := ar.next()
+	if err != nil {
+		return nil, err
+	}
+	if name != archiveManifestName {
+		return nil, fmt.Errorf("invalid block archive, expected %v, got %v", archiveManifestName, name)
+	}
+	var manifest ArchiveManifest
+	if err := json.Unmarshal(bz, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to read %v: %w", archiveManifestName, err)
+	}
+	switch {
+	case manifest.Version != ArchiveVersion:
+		return nil, fmt.Errorf("unsupported block archive version %d", manifest.Version)
+	case manifest.ChainID != chainID:
+		return nil, fmt.Errorf("block archive is of chain %q, expected %q", manifest.ChainID, chainID)
+	case manifest.From <= 0 || manifest.To < manifest.From:
+		return nil, fmt.Errorf("invalid height range [%d, %d]", manifest.From, manifest.To)
+	}
+	if height := blockStore.Height(); height > 0 && manifest.From != height+1 {
+		return nil, fmt.Errorf("block archive starts at %d, but the block store ends at %d",
+			manifest.From, height)
+	}
+
+	var (
+		prevBlockID       types.BlockID
+		prevValsHash      []byte
+		lastHeightChanged int64
+	)
+	if height := blockStore.Height(); height > 0 {
+		meta := blockStore.LoadBlockMeta(height)
+		if meta == nil {
+			return nil, fmt.Errorf("block %d not found", height)
+		}
+		prevBlockID = meta.BlockID
+	}
+	for height := manifest.From; height <= manifest.To; height++ {
+		block, commit, vals, abciResponses, err := ar.readHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		valsHash := vals.Hash(encoding)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make part set of block %d: %w", height, err)
+		}
+		blockID := types.BlockID{Hash: block.Hash(encoding), PartSetHeader: parts.Header()}
+
+		switch {
+		case block.ChainID != chainID:
+			return nil, fmt.Errorf("block %d is of chain %q, expected %q", height, block.ChainID, chainID)
+		case !commit.BlockID.Equals(blockID):
+			return nil, fmt.Errorf("commit %d is for block %v, expected %v", height, commit.BlockID, blockID)
+		case commit.Height != height:
+			return nil, fmt.Errorf("commit %d has height %d", height, commit.Height)
+		case !bytes.Equal(valsHash, block.ValidatorsHash):
+			return nil, fmt.Errorf("validators %d don't match the validators hash of the block", height)
+		case !prevBlockID.IsZero() && !block.LastBlockID.Equals(prevBlockID):
+			return nil, fmt.Errorf("block %d doesn't follow block %v", height, prevBlockID)
+		}
+
+		if !bytes.Equal(valsHash, prevValsHash) {
+			lastHeightChanged = height
+		}
+		if err := stateStore.SaveValidators(height, lastHeightChanged, vals); err != nil {
+			return nil, fmt.Errorf("failed to save validators %d: %w", height, err)
+		}
+		if abciResponses != nil {
+			if err := stateStore.SaveABCIResponses(height, abciResponses); err != nil {
+				return nil, fmt.Errorf("failed to save ABCI responses %d: %w", height, err)
+			}
+		}
+		blockStore.SaveBlock(block, parts, commit, encoding)
+
+		prevBlockID, prevValsHash = blockID, valsHash
+	}
+
+	if name, _, err := ar.next(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid block archive, unexpected file %v", name)
+	}
+	return &manifest, nil
+}
+
+type archiveReader struct {
+	tr *tar.Reader
+	// pending is a file read ahead, if any.
+	pending *archiveFile
+}
+
+type archiveFile struct {
+	name string
+	body []byte
+}
+
+// next returns the next file of the archive, checking its checksum, or io.EOF.
+func (ar *archiveReader) next() (string, []byte, error) {
+	if f := ar.pending; f != nil {
+		ar.pending = nil
+		return f.name, f.body, nil
+	}
+	hdr, err := ar.tr.Next()
+	if errors.Is(err, io.EOF) {
+		return "", nil, io.EOF
+	} else if err != nil {
+		return "", nil, fmt.Errorf("failed to read block archive: %w", err)
+	}
+	if hdr.Size > maxArchiveFileSize {
+		return "", nil, fmt.Errorf("%v is too large: %v bytes", hdr.Name, hdr.Size)
+	}
+	checksum, err := hex.DecodeString(hdr.PAXRecords[archive.ChecksumRecord])
+	if err != nil || len(checksum) != sha256.Size {
+		return "", nil, fmt.Errorf("%v has no valid checksum", hdr.Name)
+	}
+	body := make([]byte, hdr.Size)
+	if _, err := io.ReadFull(ar.tr, body); err != nil {
+		return "", nil, fmt.Errorf("failed to read %v: %w", hdr.Name, err)
+	}
+	if sum := sha256.Sum256(body); !bytes.Equal(sum[:], checksum) {
+		return "", nil, fmt.Errorf("%v has an invalid checksum", hdr.Name)
+	}
+	return hdr.Name, body, nil
+}
+
+// readHeight reads the files of the given height. The ABCI responses are nil if the archive
+// doesn't have them.
+func (ar *archiveReader) readHeight(height int64) (
+	*types.Block, *types.Commit, *types.ValidatorSet, *cmtstate.ABCIResponses, error,
+) {
+	var (
+		pbb cmtproto.Block
+		pbc cmtproto.Commit
+		pbv cmtproto.ValidatorSet
+	)
+	for _, file := range []archiveMsg{
+		{archiveBlockName, &pbb},
+		{archiveCommitName, &pbc},
+		{archiveValidatorsName, &pbv},
+	} {
+		if err := ar.read(height, file.name, file.msg); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	var abciResponses *cmtstate.ABCIResponses
+	name, bz, err := ar.next()
+	switch {
+	case errors.Is(err, io.EOF):
+	case err != nil:
+		return nil, nil, nil, nil, err
+	case name == archivePath(height, archiveABCIResponsesName):
+		abciResponses = new(cmtstate.ABCIResponses)
+		if err := proto.Unmarshal(bz, abciResponses); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to unmarshal %v: %w", name, err)
+		}
+	default:
+		ar.pending = &archiveFile{name: name, body: bz}
+	}
+
+	block, err := types.BlockFromProto(&pbb)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("invalid block %d: %w", height, err)
+	}
+	if block.Height != height {
+		return nil, nil, nil, nil, fmt.Errorf("block %d has height %d", height, block.Height)
+	}
+	commit, err := types.CommitFromProto(&pbc)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("invalid commit %d: %w", height, err)
+	}
+	vals, err := types.ValidatorSetFromProto(&pbv)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("invalid validators %d: %w", height, err)
+	}
+	return block, commit, vals, abciResponses, nil
+}
+
+// read reads the next file, which must be the given file of the given height, into msg.
+func (ar *archiveReader) read(height int64, name string, msg proto.Message) error {
+	expected := archivePath(height, name)
+	fileName, bz, err := ar.next()
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid block archive, %v is missing", expected)
+	} else if err != nil {
+		return err
+	}
+	if fileName != expected {
+		return fmt.Errorf("invalid block archive, expected %v, got %v", expected, fileName)
+	}
+	if err := proto.Unmarshal(bz, msg); err != nil {
+		return fmt.Errorf("failed to unmarshal %v: %w", fileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/archive"
+	"github.com/cometbft/cometbft/internal/test"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// makeArchiveStores returns stores holding a chain of the given number of blocks, with the ABCI
+// responses of the even heights.
+func makeArchiveStores(t *testing.T, height int64) (*BlockStore, sm.Store, string) {
+	config := test.ResetTestRoot("store_archive_test")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	blockStore := NewBlockStore(dbm.NewMemDB())
+
+	lastCommit := new(types.Commit)
+	for h := int64(1); h <= height; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 2), lastCommit, nil, state.Validators.GetProposer().Address)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(types.CanonicalEncodingProto), PartSetHeader: parts.Header()}
+		commit := types.NewCommit(h, 0, blockID, []types.CommitSig{{
+			BlockIDFlag:      types.BlockIDFlagCommit,
+			ValidatorAddress: state.Validators.Validators[0].Address,
+			Timestamp:        cmttime.Now(),
+			Signature:        []byte("Signature"),
+		}})
+		blockStore.SaveBlock(block, parts, commit, types.CanonicalEncodingProto)
+		require.NoError(t, stateStore.SaveValidators(h, 1, state.Validators))
+		if h%2 == 0 {
+			require.NoError(t, stateStore.SaveABCIResponses(h, &cmtstate.ABCIResponses{
+				DeliverTxs: []*abci.ResponseDeliverTx{{Code: uint32(h)}},
+				EndBlock:   &abci.ResponseEndBlock{},
+				BeginBlock: &abci.ResponseBeginBlock{},
+			}))
+		}
+		state.LastBlockID = blockID
+		lastCommit = commit
+	}
+	return blockStore, stateStore, state.ChainID
+}
+
+func TestExportImportArchive(t *testing.T) {
+	blockStore, stateStore, chainID := makeArchiveStores(t, 5)
+
+	var archive bytes.Buffer
+	manifest, err := ExportArchive(blockStore, stateStore, 2, 4, &archive)
+	require.NoError(t, err)
+	assert.Equal(t, &ArchiveManifest{Version: ArchiveVersion, ChainID: chainID, From: 2, To: 4}, manifest)
+
+	_, err = ExportArchive(blockStore, stateStore, 4, 6, io.Discard)
+	require.Error(t, err)
+	_, err = ExportArchive(blockStore, stateStore, 3, 2, io.Discard)
+	require.Error(t, err)
+
+	targetBlockStore := NewBlockStore(dbm.NewMemDB())
+	targetStateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+
+	_, err = ImportArchive(targetBlockStore, targetStateStore, "other-chain", types.CanonicalEncodingProto, bytes.NewReader(archive.Bytes()))
+	require.Error(t, err)
+
+	imported, err := ImportArchive(targetBlockStore, targetStateStore, chainID, types.CanonicalEncodingProto, bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, manifest, imported)
+	assert.EqualValues(t, 2, targetBlockStore.Base())
+	assert.EqualValues(t, 4, targetBlockStore.Height())
+
+	for h := int64(2); h <= 4; h++ {
+		assert.Equal(t, blockStore.LoadBlock(h).Hash(types.CanonicalEncodingProto), targetBlockStore.LoadBlock(h).Hash(types.CanonicalEncodingProto))
+		assert.Equal(t, blockStore.LoadBlockMeta(h), targetBlockStore.LoadBlockMeta(h))
+		assert.Equal(t, blockStore.LoadSeenCommit(h), targetBlockStore.LoadSeenCommit(h))
+
+		vals, err := stateStore.LoadValidators(h)
+		require.NoError(t, err)
+		targetVals, err := targetStateStore.LoadValidators(h)
+		require.NoError(t, err)
+		assert.Equal(t, vals.Hash(types.CanonicalEncodingProto), targetVals.Hash(types.CanonicalEncodingProto))
+
+		abciResponses, err := stateStore.LoadABCIResponses(h)
+		targetABCIResponses, targetErr := targetStateStore.LoadABCIResponses(h)
+		if h%2 == 0 {
+			require.NoError(t, err)
+			require.NoError(t, targetErr)
+			assert.Equal(t, abciResponses, targetABCIResponses)
+		} else {
+			assert.Equal(t, err, targetErr)
+		}
+	}
+
+	// archives can only be imported after the last block
+	_, err = ImportArchive(targetBlockStore, targetStateStore, chainID, types.CanonicalEncodingProto, bytes.NewReader(archive.Bytes()))
+	require.Error(t, err)
+
+	archive.Reset()
+	_, err = ExportArchive(blockStore, stateStore, 5, 5, &archive)
+	require.NoError(t, err)
+	_, err = ImportArchive(targetBlockStore, targetStateStore, chainID, types.CanonicalEncodingProto, &archive)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, targetBlockStore.Height())
+}
+
+func TestImportArchiveInvalid(t *testing.T) {
+	blockStore, stateStore, chainID := makeArchiveStores(t, 3)
+	var archive bytes.Buffer
+	_, err := ExportArchive(blockStore, stateStore, 1, 3, &archive)
+	require.NoError(t, err)
+
+	// rewrite the archive, tampering with the file of the given name
+	tamper := func(name string, fn func(*tar.Header, []byte) []byte) io.Reader {
+		var buf bytes.Buffer
+		tr, tw := tar.NewReader(bytes.NewReader(archive.Bytes())), tar.NewWriter(&buf)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			body, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			if hdr.Name == name {
+				body = fn(hdr, body)
+				if body == nil {
+					continue
+				}
+				hdr.Size = int64(len(body))
+			}
+			require.NoError(t, tw.WriteHeader(hdr))
+			_, err = tw.Write(body)
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return &buf
+	}
+
+	testCases := map[string]io.Reader{
+		"corrupted block": tamper("2/block", func(_ *tar.Header, body []byte) []byte {
+			body[len(body)-1] ^= 0xff
+			return body
+		}),
+		"no checksum": tamper("2/commit", func(hdr *tar.Header, body []byte) []byte {
+			hdr.PAXRecords = nil
+			return body
+		}),
+		"missing validators": tamper("2/validators", func(*tar.Header, []byte) []byte {
+			return nil
+		}),
+		"mismatching commit": tamper("2/commit", func(hdr *tar.Header, _ []byte) []byte {
+			bz, _ := blockStore.LoadSeenCommit(3).ToProto().Marshal()
+			return rewriteChecksum(hdr, bz)
+		}),
+		"missing manifest": tamper(archiveManifestName, func(*tar.Header, []byte) []byte {
+			return nil
+		}),
+		"truncated": bytes.NewReader(archive.Bytes()[:archive.Len()/2]),
+	}
+	for name, r := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ImportArchive(NewBlockStore(dbm.NewMemDB()), sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{}),
+				chainID, types.CanonicalEncodingProto, r)
+			require.Error(t, err)
+		})
+	}
+}
+
+func rewriteChecksum(hdr *tar.Header, body []byte) []byte {
+	checksum := sha256.Sum256(body)
+	hdr.PAXRecords = map[string]string{archive.ChecksumRecord: hex.EncodeToString(checksum[:])}
+	return body
+}
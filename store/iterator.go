@@ -0,0 +1,139 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Iterator iterates over the blocks of a block store in a height range, in
+// ascending order, so that tools can read the data directory of a node without
+// going through the RPC. Only the block meta is loaded when moving to the next
+// height: the block, its commit and its ABCI responses are loaded and decoded
+// the first time they are accessed, so reading the headers only is cheap.
+//
+//	it, err := store.NewIterator(blockStore, stateStore, 1, 0)
+//	if err != nil {
+//		return err
+//	}
+//	for it.Next() {
+//		block := it.Block()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+//
+// An Iterator must not be used concurrently. It doesn't lock the stores, so
+// the blocks being pruned while iterating end the iteration with an error.
+type Iterator struct {
+	blockStore *BlockStore
+	stateStore sm.Store
+	height     int64
+	to         int64
+
+	meta   *types.BlockMeta
+	block  *types.Block
+	commit *types.Commit
+	err    error
+}
+
+// NewIterator returns an iterator over the blocks from height from to height
+// to, inclusive. A to of 0 means the height of the block store when the
+// iterator is created. The state store, which holds the ABCI responses, is
+// optional.
+//
+// It returns an error if the range isn't in the block store.
+func NewIterator(blockStore *BlockStore, stateStore sm.Store, from, to int64) (*Iterator, error) {
+	if to == 0 {
+		to = blockStore.Height()
+	}
+	if from <= 0 || to < from {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+	if base, height := blockStore.Base(), blockStore.Height(); from < base || to > height {
+		return nil, fmt.Errorf("height range [%d, %d] is not in the block store, which has [%d, %d]",
+			from, to, base, height)
+	}
+	return &Iterator{
+		blockStore: blockStore,
+		stateStore: stateStore,
+		height:     from - 1,
+		to:         to,
+	}, nil
+}
+
+// Next moves to the next height, returning false once the end of the range is
+// reached or on error, in which case Err returns it.
+func (it *Iterator) Next() bool {
+	if it.err != nil || it.height >= it.to {
+		return false
+	}
+	it.height++
+	it.block, it.commit = nil, nil
+	it.meta = it.blockStore.LoadBlockMeta(it.height)
+	if it.meta == nil {
+		it.err = fmt.Errorf("block %d not found", it.height)
+		return false
+	}
+	return true
+}
+
+// Err returns the error which ended the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Height returns the current height.
+func (it *Iterator) Height() int64 {
+	return it.height
+}
+
+// BlockMeta returns the meta of the current block, i.e. its ID, size, number
+// of transactions and header.
+func (it *Iterator) BlockMeta() *types.BlockMeta {
+	return it.meta
+}
+
+// Block returns the current block, or nil if it was pruned since Next was
+// called.
+func (it *Iterator) Block() *types.Block {
+	if it.block == nil {
+		it.block = it.blockStore.LoadBlock(it.height)
+	}
+	return it.block
+}
+
+// Commit returns the commit of the current block, which is the seen commit
+// for the last block of the store, or nil if it was pruned since Next was
+// called.
+func (it *Iterator) Commit() *types.Commit {
+	if it.commit == nil {
+		it.commit = it.blockStore.LoadBlockCommit(it.height)
+	}
+	if it.commit == nil {
+		it.commit = it.blockStore.LoadSeenCommit(it.height)
+	}
+	return it.commit
+}
+
+// ABCIResponses returns the ABCI responses of the current block, or nil if they
+// were not persisted or were pruned. It returns an error if the iterator has
+// no state store.
+func (it *Iterator) ABCIResponses() (*cmtstate.ABCIResponses, error) {
+	if it.stateStore == nil {
+		return nil, errors.New("no state store to load the ABCI responses from")
+	}
+	abciResponses, err := it.stateStore.LoadABCIResponses(it.height)
+	switch {
+	case err == nil:
+		return abciResponses, nil
+	case errors.Is(err, sm.ErrABCIResponsesNotPersisted) || errors.As(err, &sm.ErrNoABCIResponsesForHeight{}):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to load ABCI responses %d: %w", it.height, err)
+	}
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterator(t *testing.T) {
+	blockStore, stateStore, _ := makeArchiveStores(t, 5)
+
+	it, err := NewIterator(blockStore, stateStore, 2, 0)
+	require.NoError(t, err)
+	height := int64(2)
+	for it.Next() {
+		assert.Equal(t, height, it.Height())
+		assert.Equal(t, blockStore.LoadBlockMeta(height), it.BlockMeta())
+		assert.Equal(t, blockStore.LoadBlock(height), it.Block())
+		require.NotNil(t, it.Commit())
+		assert.Equal(t, height, it.Commit().Height)
+		assert.Equal(t, it.BlockMeta().BlockID, it.Commit().BlockID)
+
+		abciResponses, err := it.ABCIResponses()
+		require.NoError(t, err)
+		if height%2 == 0 {
+			require.NotNil(t, abciResponses)
+			assert.EqualValues(t, height, abciResponses.DeliverTxs[0].Code)
+		} else {
+			assert.Nil(t, abciResponses)
+		}
+		height++
+	}
+	require.NoError(t, it.Err())
+	assert.EqualValues(t, 6, height)
+	assert.False(t, it.Next())
+
+	// without a state store
+	it, err = NewIterator(blockStore, nil, 3, 3)
+	require.NoError(t, err)
+	require.True(t, it.Next())
+	_, err = it.ABCIResponses()
+	assert.Error(t, err)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestIteratorInvalid(t *testing.T) {
+	blockStore, stateStore, _ := makeArchiveStores(t, 5)
+
+	for _, r := range [][2]int64{{0, 3}, {3, 2}, {4, 6}} {
+		_, err := NewIterator(blockStore, stateStore, r[0], r[1])
+		assert.Error(t, err, "[%d, %d]", r[0], r[1])
+	}
+
+	// block deleted while iterating
+	it, err := NewIterator(blockStore, stateStore, 3, 5)
+	require.NoError(t, err)
+	require.True(t, it.Next())
+	require.NoError(t, blockStore.DeleteLatestBlock())
+	require.True(t, it.Next())
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+	assert.False(t, it.Next())
+}
@@ -0,0 +1,165 @@
+// Package migrate versions the on-disk schemas of the block and state stores,
+// and migrates the databases from one schema version to the next.
+//
+// The version of the schema of a database is stored in the database itself.
+// Databases written before the schemas were versioned are at version 0.
+//
+// Changing the layout of a store requires appending a Migration to its Schema,
+// which brings the databases of the previous version to the new one. The node
+// refuses to start on databases requiring a migration which changes their
+// data: it has to be run with "cometbft experimental-migrate-db" first.
+package migrate
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+var versionKey = []byte("schemaVersion")
+
+// ErrMigrationRequired is returned by Schema.Check when the database has to
+// be migrated with "cometbft experimental-migrate-db".
+var ErrMigrationRequired = errors.New("database migration required")
+
+// ProgressFunc reports the progress of a migration, as the number of items
+// migrated so far out of the total.
+type ProgressFunc func(done, total int64)
+
+// Migration migrates a database to a version of its schema, from the previous
+// version.
+type Migration struct {
+	// Version is the version of the schema after the migration.
+	Version     uint64
+	Description string
+	// Migrate migrates the data of the database, reporting its progress. It
+	// is nil if the data doesn't change, in which case the migration is
+	// applied automatically at startup.
+	//
+	// Migrate must be idempotent: the version is only recorded once it
+	// returns, so it is run again if interrupted.
+	Migrate func(db dbm.DB, progress ProgressFunc) error
+}
+
+// Schema is the versioned schema of a database.
+type Schema struct {
+	Name string
+	// Migrations to every version of the schema, in order, starting from
+	// version 1.
+	Migrations []Migration
+}
+
+// LatestVersion returns the version of the schema written by this binary.
+func (s Schema) LatestVersion() uint64 {
+	return uint64(len(s.Migrations))
+}
+
+// Version returns the version of the schema of the database.
+func Version(db dbm.DB) (uint64, error) {
+	bz, err := db.Get(versionKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("invalid schema version %X", bz)
+	}
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+func setVersion(db dbm.DB, version uint64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, version)
+	return db.SetSync(versionKey, bz)
+}
+
+// Pending returns the migrations to apply to the database, in order. It
+// returns an error if the database has a more recent version than the schema,
+// i.e. if it was written by a more recent binary.
+func (s Schema) Pending(db dbm.DB) ([]Migration, error) {
+	version, err := Version(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the schema version of the %s database: %w", s.Name, err)
+	}
+	if version > s.LatestVersion() {
+		return nil, fmt.Errorf("the %s database has schema version %d, more recent than version %d, "+
+			"it was written by a more recent version of CometBFT", s.Name, version, s.LatestVersion())
+	}
+	return s.Migrations[version:], nil
+}
+
+// Check checks that the database is at the latest version of the schema,
+// applying the pending migrations which don't change the data, if any. It
+// returns ErrMigrationRequired if other migrations are pending.
+func (s Schema) Check(db dbm.DB) error {
+	pending, err := s.Pending(db)
+	if err != nil {
+		return err
+	}
+	for _, m := range pending {
+		if m.Migrate != nil {
+			return fmt.Errorf("%w: the %s database has to be migrated to schema version %d (%s), "+
+				"run \"cometbft experimental-migrate-db\"", ErrMigrationRequired, s.Name, m.Version, m.Description)
+		}
+	}
+	for _, m := range pending {
+		if err := setVersion(db, m.Version); err != nil {
+			return fmt.Errorf("failed to set the schema version of the %s database: %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// Options are the options of Schema.Migrate.
+type Options struct {
+	// DryRun only logs the pending migrations, without applying them.
+	DryRun bool
+	// Progress, if set, is called with the progress of every migration.
+	Progress func(m Migration, done, total int64)
+}
+
+// Migrate applies the pending migrations to the database, in order, and
+// returns the versions of the schema before and after.
+func (s Schema) Migrate(db dbm.DB, logger log.Logger, opts Options) (from, to uint64, err error) {
+	pending, err := s.Pending(db)
+	if err != nil {
+		return 0, 0, err
+	}
+	from, _ = Version(db)
+	if len(pending) == 0 {
+		logger.Info("Database is up to date", "db", s.Name, "version", from)
+		return from, from, nil
+	}
+
+	to = from
+	for _, m := range pending {
+		logger.Info("Migrating database", "db", s.Name, "version", m.Version,
+			"description", m.Description, "dryRun", opts.DryRun)
+		if opts.DryRun {
+			to = m.Version
+			continue
+		}
+		if m.Migrate != nil {
+			progress := func(done, total int64) {
+				if opts.Progress != nil {
+					opts.Progress(m, done, total)
+				}
+			}
+			if err := m.Migrate(db, progress); err != nil {
+				return from, to, fmt.Errorf("failed to migrate the %s database to schema version %d: %w",
+					s.Name, m.Version, err)
+			}
+		}
+		if err := setVersion(db, m.Version); err != nil {
+			return from, to, fmt.Errorf("failed to set the schema version of the %s database: %w", s.Name, err)
+		}
+		to = m.Version
+	}
+	return from, to, nil
+}
@@ -0,0 +1,134 @@
+package migrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestSchemas(t *testing.T) {
+	for name, schema := range Schemas() {
+		assert.Equal(t, name, schema.Name)
+		for i, m := range schema.Migrations {
+			assert.EqualValues(t, i+1, m.Version, "%s migrations are out of order", name)
+			assert.NotEmpty(t, m.Description)
+		}
+	}
+}
+
+// testSchema renames the key "a" to "b" at version 2.
+func testSchema() Schema {
+	return Schema{
+		Name: "test",
+		Migrations: []Migration{
+			{Version: 1, Description: "initial"},
+			{Version: 2, Description: "rename a to b", Migrate: func(db dbm.DB, progress ProgressFunc) error {
+				bz, err := db.Get([]byte("a"))
+				if err != nil || bz == nil {
+					return err
+				}
+				batch := db.NewBatch()
+				defer batch.Close()
+				if err := batch.Set([]byte("b"), bz); err != nil {
+					return err
+				}
+				if err := batch.Delete([]byte("a")); err != nil {
+					return err
+				}
+				progress(1, 1)
+				return batch.WriteSync()
+			}},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	db := dbm.NewMemDB()
+	schema := testSchema()
+	schema.Migrations = schema.Migrations[:1]
+
+	// migrations which don't change the data are applied
+	require.NoError(t, schema.Check(db))
+	version, err := Version(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, version)
+
+	// others are not
+	schema = testSchema()
+	err = schema.Check(db)
+	assert.True(t, errors.Is(err, ErrMigrationRequired), err)
+	version, err = Version(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, version)
+
+	// nor are downgrades
+	schema.Migrations = nil
+	err = schema.Check(db)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrMigrationRequired))
+}
+
+func TestMigrate(t *testing.T) {
+	db := dbm.NewMemDB()
+	require.NoError(t, db.Set([]byte("a"), []byte{1}))
+	schema := testSchema()
+
+	from, to, err := schema.Migrate(db, log.TestingLogger(), Options{DryRun: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, from)
+	assert.EqualValues(t, 2, to)
+	version, err := Version(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, version)
+	ok, err := db.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	var progress []int64
+	from, to, err = schema.Migrate(db, log.TestingLogger(), Options{
+		Progress: func(m Migration, done, total int64) {
+			assert.EqualValues(t, 2, m.Version)
+			progress = append(progress, done, total)
+		},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, from)
+	assert.EqualValues(t, 2, to)
+	assert.Equal(t, []int64{1, 1}, progress)
+	version, err = Version(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, version)
+	bz, err := db.Get([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, bz)
+	require.NoError(t, schema.Check(db))
+
+	// nothing left to do
+	from, to, err = schema.Migrate(db, log.TestingLogger(), Options{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, from)
+	assert.EqualValues(t, 2, to)
+}
+
+func TestMigrateFailure(t *testing.T) {
+	db := dbm.NewMemDB()
+	schema := testSchema()
+	schema.Migrations[1].Migrate = func(dbm.DB, ProgressFunc) error {
+		return errors.New("failure")
+	}
+
+	// the version is left at the last successful migration
+	from, to, err := schema.Migrate(db, log.TestingLogger(), Options{})
+	require.Error(t, err)
+	assert.EqualValues(t, 0, from)
+	assert.EqualValues(t, 1, to)
+	version, err := Version(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, version)
+}
@@ -0,0 +1,32 @@
+package migrate
+
+// BlockStoreSchema is the schema of the block store (see store.BlockStore).
+var BlockStoreSchema = Schema{
+	Name: "blockstore",
+	Migrations: []Migration{
+		{
+			Version:     1,
+			Description: "record the schema version",
+		},
+	},
+}
+
+// StateStoreSchema is the schema of the state store (see state.Store).
+var StateStoreSchema = Schema{
+	Name: "state",
+	Migrations: []Migration{
+		{
+			Version:     1,
+			Description: "record the schema version",
+		},
+	},
+}
+
+// Schemas returns the schemas of the databases of the node, by name of the
+// database.
+func Schemas() map[string]Schema {
+	return map[string]Schema{
+		BlockStoreSchema.Name: BlockStoreSchema,
+		StateStoreSchema.Name: StateStoreSchema,
+	}
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/cosmos/gogoproto/proto"
 
@@ -23,6 +24,7 @@ There are three types of information stored:
   - BlockMeta:   Meta information about each block
   - Block part:  Parts of each block, aggregated w/ PartSet
   - Commit:      The commit part of each block, for gossiping precommit votes
+  - Block time:  The height of each block, indexed by time
 
 Currently the precommit signatures are duplicated in the Block parts as
 well as the Commit.  In the future this may change, perhaps by moving
@@ -44,6 +46,9 @@ type BlockStore struct {
 	mtx    cmtsync.RWMutex
 	base   int64
 	height int64
+	// timeIndexBase is the first height indexed by time, the blocks saved before
+	// the index was introduced not being indexed, or 0 if none is.
+	timeIndexBase int64
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
@@ -51,9 +56,10 @@ type BlockStore struct {
 func NewBlockStore(db dbm.DB) *BlockStore {
 	bs := LoadBlockStoreState(db)
 	return &BlockStore{
-		base:   bs.Base,
-		height: bs.Height,
-		db:     db,
+		base:          bs.Base,
+		height:        bs.Height,
+		timeIndexBase: loadTimeIndexBase(db),
+		db:            db,
 	}
 }
 
@@ -216,6 +222,71 @@ func (bs *BlockStore) LoadBlockMetaByHash(hash []byte) *types.BlockMeta {
 	return bs.LoadBlockMeta(height)
 }
 
+// LoadBlockMetaByTime returns the BlockMeta of the first block whose time is at
+// or after t. If there is no such block, it returns nil.
+//
+// Since the block times increase with the height, the index of the block times
+// gives it right away, unless the block is at or before the first indexed
+// block, in which case the blocks saved before the index was introduced are
+// binary searched.
+func (bs *BlockStore) LoadBlockMetaByTime(t time.Time) *types.BlockMeta {
+	bs.mtx.RLock()
+	base, height, indexBase := bs.base, bs.height, bs.timeIndexBase
+	bs.mtx.RUnlock()
+	if base == 0 {
+		return nil
+	}
+
+	// the first indexed block at or after t
+	var indexed int64
+	iter, err := bs.db.Iterator(calcBlockTimeKeyPrefix(t), blockTimeKeyEnd)
+	if err != nil {
+		panic(err)
+	}
+	for ; iter.Valid(); iter.Next() {
+		h, err := strconv.ParseInt(string(iter.Value()), 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("failed to extract height from %s: %v", iter.Value(), err))
+		}
+		if h >= base { // skip the blocks being pruned
+			indexed = h
+			break
+		}
+	}
+	if err := iter.Close(); err != nil {
+		panic(err)
+	}
+
+	switch {
+	case indexed > indexBase && indexed > base:
+		// the previous block is indexed too, and is before t
+		return bs.LoadBlockMeta(indexed)
+	case indexed == 0 && indexBase > 0 && indexBase <= height:
+		// the last block is indexed, and is before t
+		return nil
+	case indexed == 0:
+		indexed = height + 1
+	}
+
+	lo, hi := base, indexed
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		meta := bs.LoadBlockMeta(mid)
+		if meta == nil {
+			return nil
+		}
+		if meta.Header.Time.Before(t) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo > height {
+		return nil
+	}
+	return bs.LoadBlockMeta(lo)
+}
+
 // LoadBlockCommit returns the Commit for the given height.
 // This commit consists of the +2/3 and other Precommit-votes for block at `height`,
 // and it comes from the block.LastCommit for `height+1`.
@@ -324,6 +395,9 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 		if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
 			return 0, -1, err
 		}
+		if err := batch.Delete(calcBlockTimeKey(meta.Header.Time, h)); err != nil {
+			return 0, -1, err
+		}
 		// if height is beyond the evidence point we dont delete the commit data
 		if h < evidencePoint {
 			if err := batch.Delete(calcBlockCommitKey(h)); err != nil {
@@ -365,13 +439,16 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 //	If all the nodes restart after committing a block,
 //	we need this to reload the precommits to catch-up nodes to the
 //	most recent height.  Otherwise they'd stall at H-1.
-func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
+//
+// encoding: The canonical encoding of the chain, the block is hashed with.
+func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit,
+	encoding types.CanonicalEncoding) {
 	if block == nil {
 		panic("BlockStore can only save a non-nil block")
 	}
 
 	height := block.Height
-	hash := block.Hash()
+	hash := block.Hash(encoding)
 
 	if g, w := height, bs.Height()+1; bs.Base() > 0 && g != w {
 		panic(fmt.Sprintf("BlockStore can only save contiguous blocks. Wanted %v, got %v", w, g))
@@ -390,7 +467,7 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	}
 
 	// Save block meta
-	blockMeta := types.NewBlockMeta(block, blockParts)
+	blockMeta := types.NewBlockMeta(block, blockParts, encoding)
 	pbm := blockMeta.ToProto()
 	if pbm == nil {
 		panic("nil blockmeta")
@@ -402,6 +479,20 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	if err := bs.db.Set(calcBlockHashKey(hash), []byte(fmt.Sprintf("%d", height))); err != nil {
 		panic(err)
 	}
+	if err := bs.db.Set(calcBlockTimeKey(block.Time, height), []byte(fmt.Sprintf("%d", height))); err != nil {
+		panic(err)
+	}
+	bs.mtx.RLock()
+	indexBase := bs.timeIndexBase
+	bs.mtx.RUnlock()
+	if indexBase == 0 {
+		if err := bs.db.Set(timeIndexBaseKey, []byte(fmt.Sprintf("%d", height))); err != nil {
+			panic(err)
+		}
+		bs.mtx.Lock()
+		bs.timeIndexBase = height
+		bs.mtx.Unlock()
+	}
 
 	// Save block commit (duplicate and separate from the Block)
 	pbc := block.LastCommit.ToProto()
@@ -487,9 +578,25 @@ func calcBlockHashKey(hash []byte) []byte {
 	return []byte(fmt.Sprintf("BH:%x", hash))
 }
 
+// The block times are encoded as their Unix time in nanoseconds, with the sign
+// bit flipped, in fixed-width hex, so that the keys are ordered by time.
+func calcBlockTimeKeyPrefix(t time.Time) []byte {
+	return []byte(fmt.Sprintf("BT:%016x", uint64(t.UnixNano())^(1<<63)))
+}
+
+func calcBlockTimeKey(t time.Time, height int64) []byte {
+	return append(calcBlockTimeKeyPrefix(t), fmt.Sprintf(":%016x", height)...)
+}
+
+// blockTimeKeyEnd is the end of the range of the block time keys.
+var blockTimeKeyEnd = []byte("BT;")
+
 //-----------------------------------------------------------------------------
 
-var blockStoreKey = []byte("blockStore")
+var (
+	blockStoreKey    = []byte("blockStore")
+	timeIndexBaseKey = []byte("blockTimeIndexBase")
+)
 
 // SaveBlockStoreState persists the blockStore state to the database.
 func SaveBlockStoreState(bsj *cmtstore.BlockStoreState, db dbm.DB) {
@@ -529,6 +636,21 @@ func LoadBlockStoreState(db dbm.DB) cmtstore.BlockStoreState {
 	return bsj
 }
 
+func loadTimeIndexBase(db dbm.DB) int64 {
+	bz, err := db.Get(timeIndexBaseKey)
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return 0
+	}
+	height, err := strconv.ParseInt(string(bz), 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("failed to extract height from %s: %v", bz, err))
+	}
+	return height
+}
+
 // mustEncode proto encodes a proto.message and panics if fails
 func mustEncode(pb proto.Message) []byte {
 	bz, err := proto.Marshal(pb)
@@ -556,6 +678,9 @@ func (bs *BlockStore) DeleteLatestBlock() error {
 		if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
 			return err
 		}
+		if err := batch.Delete(calcBlockTimeKey(meta.Header.Time, targetHeight)); err != nil {
+			return err
+		}
 		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
 			if err := batch.Delete(calcBlockPartKey(targetHeight, p)); err != nil {
 				return err
@@ -575,6 +700,13 @@ func (bs *BlockStore) DeleteLatestBlock() error {
 
 	bs.mtx.Lock()
 	bs.height = targetHeight - 1
+	if bs.timeIndexBase == targetHeight {
+		bs.timeIndexBase = 0
+		if err := batch.Delete(timeIndexBaseKey); err != nil {
+			bs.mtx.Unlock()
+			return err
+		}
+	}
 	bs.mtx.Unlock()
 	bs.saveState()
 
@@ -50,9 +50,7 @@ func makeStateAndBlockStore(logger log.Logger) (sm.State, *BlockStore, cleanupFu
 	// stateDB := dbm.NewDebugDB("stateDB", dbm.NewMemDB())
 	blockDB := dbm.NewMemDB()
 	stateDB := dbm.NewMemDB()
-	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
 	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
 	if err != nil {
 		panic(fmt.Errorf("error constructing state from genesis file: %w", err))
@@ -174,7 +172,7 @@ func TestBlockStoreSaveLoadBlock(t *testing.T) {
 	validPartSet, err := block.MakePartSet(2)
 	require.NoError(t, err)
 	seenCommit := makeTestCommit(10, cmttime.Now())
-	bs.SaveBlock(block, partSet, seenCommit)
+	bs.SaveBlock(block, partSet, seenCommit, types.CanonicalEncodingProto)
 	require.EqualValues(t, 1, bs.Base(), "expecting the new height to be changed")
 	require.EqualValues(t, block.Header.Height, bs.Height(), "expecting the new height to be changed")
 
@@ -296,7 +294,7 @@ func TestBlockStoreSaveLoadBlock(t *testing.T) {
 		bs, db := freshBlockStore()
 		// SaveBlock
 		res, err, panicErr := doFn(func() (interface{}, error) {
-			bs.SaveBlock(tuple.block, tuple.parts, tuple.seenCommit)
+			bs.SaveBlock(tuple.block, tuple.parts, tuple.seenCommit, types.CanonicalEncodingProto)
 			if tuple.block == nil {
 				return nil, nil
 			}
@@ -371,9 +369,7 @@ func TestBlockStoreSaveLoadBlock(t *testing.T) {
 func TestLoadBaseMeta(t *testing.T) {
 	config := test.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
-	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
 	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
 	require.NoError(t, err)
 	bs := NewBlockStore(dbm.NewMemDB())
@@ -383,7 +379,7 @@ func TestLoadBaseMeta(t *testing.T) {
 		partSet, err := block.MakePartSet(2)
 		require.NoError(t, err)
 		seenCommit := makeTestCommit(h, cmttime.Now())
-		bs.SaveBlock(block, partSet, seenCommit)
+		bs.SaveBlock(block, partSet, seenCommit, types.CanonicalEncodingProto)
 	}
 
 	_, _, err = bs.PruneBlocks(4, state)
@@ -433,9 +429,7 @@ func TestLoadBlockPart(t *testing.T) {
 func TestPruneBlocks(t *testing.T) {
 	config := test.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
-	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
 	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
 	require.NoError(t, err)
 	db := dbm.NewMemDB()
@@ -457,7 +451,7 @@ func TestPruneBlocks(t *testing.T) {
 		partSet, err := block.MakePartSet(2)
 		require.NoError(t, err)
 		seenCommit := makeTestCommit(h, cmttime.Now())
-		bs.SaveBlock(block, partSet, seenCommit)
+		bs.SaveBlock(block, partSet, seenCommit, types.CanonicalEncodingProto)
 	}
 
 	assert.EqualValues(t, 1, bs.Base())
@@ -474,6 +468,7 @@ func TestPruneBlocks(t *testing.T) {
 	pruned, evidenceRetainHeight, err := bs.PruneBlocks(1200, state)
 	require.NoError(t, err)
 	assert.EqualValues(t, 1199, pruned)
+	assert.EqualValues(t, 1200, bs.LoadBlockMetaByTime(time.Time{}).Header.Height)
 	assert.EqualValues(t, 1200, bs.Base())
 	assert.EqualValues(t, 1500, bs.Height())
 	assert.EqualValues(t, 301, bs.Size())
@@ -575,9 +570,7 @@ func TestLoadBlockMeta(t *testing.T) {
 func TestLoadBlockMetaByHash(t *testing.T) {
 	config := test.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
-	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
-		DiscardABCIResponses: false,
-	})
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
 	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
 	require.NoError(t, err)
 	bs := NewBlockStore(dbm.NewMemDB())
@@ -586,14 +579,64 @@ func TestLoadBlockMetaByHash(t *testing.T) {
 	partSet, err := b1.MakePartSet(2)
 	require.NoError(t, err)
 	seenCommit := makeTestCommit(1, cmttime.Now())
-	bs.SaveBlock(b1, partSet, seenCommit)
+	bs.SaveBlock(b1, partSet, seenCommit, types.CanonicalEncodingProto)
 
-	baseBlock := bs.LoadBlockMetaByHash(b1.Hash())
+	baseBlock := bs.LoadBlockMetaByHash(b1.Hash(types.CanonicalEncodingProto))
 	assert.EqualValues(t, b1.Header.Height, baseBlock.Header.Height)
 	assert.EqualValues(t, b1.Header.LastBlockID, baseBlock.Header.LastBlockID)
 	assert.EqualValues(t, b1.Header.ChainID, baseBlock.Header.ChainID)
 }
 
+func TestLoadBlockMetaByTime(t *testing.T) {
+	state, _, cleanup := makeStateAndBlockStore(log.NewNopLogger())
+	defer cleanup()
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db)
+	require.Nil(t, bs.LoadBlockMetaByTime(time.Now()))
+
+	genesisTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	blockTime := func(height int64) time.Time { return genesisTime.Add(time.Duration(height) * 10 * time.Second) }
+	for h := int64(1); h <= 10; h++ {
+		block := state.MakeBlock(h, nil, new(types.Commit), nil, state.Validators.GetProposer().Address)
+		block.Time = blockTime(h)
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		bs.SaveBlock(block, partSet, makeTestCommit(h, cmttime.Now()), types.CanonicalEncodingProto)
+	}
+
+	check := func(bs *BlockStore) {
+		t.Helper()
+		for h := int64(1); h <= 10; h++ {
+			for _, tm := range []time.Time{blockTime(h), blockTime(h).Add(-time.Second), blockTime(h - 1).Add(time.Nanosecond)} {
+				meta := bs.LoadBlockMetaByTime(tm)
+				if assert.NotNil(t, meta, "%v", tm) {
+					assert.Equal(t, h, meta.Header.Height, "%v", tm)
+				}
+			}
+		}
+		assert.EqualValues(t, 1, bs.LoadBlockMetaByTime(time.Time{}).Header.Height)
+		assert.Nil(t, bs.LoadBlockMetaByTime(blockTime(10).Add(time.Nanosecond)))
+	}
+	check(bs)
+
+	// the blocks saved before the index was introduced are not indexed
+	for h := int64(1); h <= 5; h++ {
+		require.NoError(t, db.Delete(calcBlockTimeKey(blockTime(h), h)))
+	}
+	require.NoError(t, db.Set(timeIndexBaseKey, []byte("6")))
+	check(NewBlockStore(db))
+	require.NoError(t, db.Delete(timeIndexBaseKey))
+	for h := int64(6); h <= 10; h++ {
+		require.NoError(t, db.Delete(calcBlockTimeKey(blockTime(h), h)))
+	}
+	check(NewBlockStore(db))
+
+	// the index follows the deletion of the latest block
+	bs = NewBlockStore(db)
+	require.NoError(t, bs.DeleteLatestBlock())
+	assert.Nil(t, bs.LoadBlockMetaByTime(blockTime(10)))
+}
+
 func TestBlockFetchAtHeight(t *testing.T) {
 	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
 	defer cleanup()
@@ -603,7 +646,7 @@ func TestBlockFetchAtHeight(t *testing.T) {
 	partSet, err := block.MakePartSet(2)
 	require.NoError(t, err)
 	seenCommit := makeTestCommit(10, cmttime.Now())
-	bs.SaveBlock(block, partSet, seenCommit)
+	bs.SaveBlock(block, partSet, seenCommit, types.CanonicalEncodingProto)
 	require.Equal(t, bs.Height(), block.Header.Height, "expecting the new height to be changed")
 
 	blockAtHeight := bs.LoadBlock(bs.Height())
@@ -614,7 +657,7 @@ func TestBlockFetchAtHeight(t *testing.T) {
 	bz1 := mustEncode(b1)
 	bz2 := mustEncode(b2)
 	require.Equal(t, bz1, bz2)
-	require.Equal(t, block.Hash(), blockAtHeight.Hash(),
+	require.Equal(t, block.Hash(types.CanonicalEncodingProto), blockAtHeight.Hash(types.CanonicalEncodingProto),
 		"expecting a successful load of the last saved block")
 
 	blockAtHeightPlus1 := bs.LoadBlock(bs.Height() + 1)
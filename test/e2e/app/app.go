@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cometbft/cometbft/abci/example/code"
@@ -46,8 +47,9 @@ type Config struct {
 	// SnapshotInterval and EvidenceAgeHeight.
 	RetainBlocks uint64 `toml:"retain_blocks"`
 
-	// KeyType sets the curve that will be used by validators.
-	// Options are ed25519 & secp256k1
+	// KeyType sets the curve that will be used by validators, unless the key
+	// of a validator update is prefixed with another one, as "<type>:<key>".
+	// Options are ed25519, secp256k1 & bn254
 	KeyType string `toml:"key_type"`
 
 	// PersistInterval specifies the height interval at which the application
@@ -319,12 +321,17 @@ func (app *Application) validatorUpdates(height uint64) (abci.ValidatorUpdates,
 
 	valUpdates := abci.ValidatorUpdates{}
 	for keyString, power := range updates {
+		// keys of another type than the one of the node are prefixed with it
+		keyType := app.cfg.KeyType
+		if t, k, ok := strings.Cut(keyString, ":"); ok {
+			keyType, keyString = t, k
+		}
 
 		keyBytes, err := base64.StdEncoding.DecodeString(keyString)
 		if err != nil {
 			return nil, fmt.Errorf("invalid base64 pubkey value %q: %w", keyString, err)
 		}
-		valUpdates = append(valUpdates, abci.UpdateValidator(keyBytes, int64(power), app.cfg.KeyType))
+		valUpdates = append(valUpdates, abci.UpdateValidator(keyBytes, int64(power), keyType))
 	}
 	return valUpdates, nil
 }
@@ -48,6 +48,11 @@ var (
 		4 * int(e2e.EvidenceAgeHeight),
 	}
 	evidence          = uniformChoice{0, 1, 10}
+	keyTypes          = uniformChoice{"ed25519", "bn254", "mixed"} // mixed: random per validator
+	nodeKeyTypes      = uniformChoice{"ed25519", "secp256k1", "bn254"}
+	nodeLatencies     = uniformChoice{time.Duration(0), time.Duration(0), 50 * time.Millisecond, 200 * time.Millisecond}
+	nodePacketLosses  = uniformChoice{0.0, 0.0, 0.0, 1.0, 5.0}
+	partitions        = uniformChoice{false, true}
 	abciDelays        = uniformChoice{"none", "small", "large"}
 	nodePerturbations = probSetChoice{
 		"disconnect": 0.1,
@@ -129,6 +134,13 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}, upgradeVersion st
 		UpgradeVersion:   upgradeVersion,
 	}
 
+	keyType := keyTypes.Choose(r).(string)
+	switch keyType {
+	case "ed25519":
+	case "bn254", "mixed":
+		manifest.KeyType = "bn254"
+	}
+
 	switch abciDelays.Choose(r).(string) {
 	case "none":
 	case "small":
@@ -176,6 +188,9 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}, upgradeVersion st
 		name := fmt.Sprintf("validator%02d", i)
 		manifest.Nodes[name] = generateNode(
 			r, e2e.ModeValidator, startAt, manifest.InitialHeight, i <= 2)
+		if keyType == "mixed" {
+			manifest.Nodes[name].KeyType = nodeKeyTypes.Choose(r).(string)
+		}
 
 		if startAt == 0 {
 			(*manifest.Validators)[name] = int64(30 + r.Intn(71))
@@ -186,6 +201,20 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}, upgradeVersion st
 		}
 	}
 
+	// Partition the validators in two halves, neither of which can commit
+	// blocks on its own, for a while.
+	if numValidators >= 4 && partitions.Choose(r).(bool) {
+		var groups [2][]string
+		for i := 1; i <= numValidators; i++ {
+			groups[i%2] = append(groups[i%2], fmt.Sprintf("validator%02d", i))
+		}
+		manifest.Partitions = append(manifest.Partitions, e2e.ManifestPartition{
+			Height:   manifest.InitialHeight + 20,
+			Duration: 20 * time.Second,
+			Groups:   [][]string{groups[0], groups[1]},
+		})
+	}
+
 	// Move validators to InitChain if specified.
 	switch opt["validators"].(string) {
 	case "genesis":
@@ -281,6 +310,11 @@ func generateNode(
 		SnapshotInterval: uint64(nodeSnapshotIntervals.Choose(r).(int)),
 		RetainBlocks:     uint64(nodeRetainBlocks.Choose(r).(int)),
 		Perturb:          nodePerturbations.Choose(r),
+		Latency:          nodeLatencies.Choose(r).(time.Duration),
+		PacketLoss:       nodePacketLosses.Choose(r).(float64),
+	}
+	if node.Latency > 0 {
+		node.LatencyJitter = node.Latency / 5
 	}
 
 	// If this node is forced to be an archive node, retain all blocks and
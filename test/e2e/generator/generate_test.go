@@ -1,12 +1,30 @@
 package main
 
 import (
+	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
 )
 
+// Tests that the generated testnets are valid.
+func TestGenerator(t *testing.T) {
+	manifests, err := Generate(&generateConfig{randSource: rand.New(rand.NewSource(randomSeed))}) //nolint:gosec
+	require.NoError(t, err)
+	for i, m := range manifests {
+		t.Run(fmt.Sprintf("gen-%04d", i), func(t *testing.T) {
+			ifd, err := e2e.NewDockerInfrastructureData(m)
+			require.NoError(t, err)
+			_, err = e2e.LoadTestnet(m, fmt.Sprintf("gen-%04d.toml", i), ifd)
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestVersionFinder(t *testing.T) {
 	testCases := []struct {
 		baseVer        string
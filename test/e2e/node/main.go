@@ -29,6 +29,7 @@ import (
 	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
 	"github.com/cometbft/cometbft/test/e2e/app"
 	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
+	"github.com/cometbft/cometbft/types"
 )
 
 var logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
@@ -161,9 +162,12 @@ func startLightClient(cfg *Config) error {
 
 	providers := rpcEndpoints(cmtcfg.P2P.PersistentPeers)
 
+	// the light nodes have no genesis, but the testnets use the default
+	// canonical encoding
 	c, err := light.NewHTTPClient(
 		context.Background(),
 		cfg.ChainID,
+		types.CanonicalEncodingProto,
 		light.TrustOptions{
 			Period: cmtcfg.StateSync.TrustPeriod,
 			Height: cmtcfg.StateSync.TrustHeight,
@@ -258,7 +262,7 @@ func setupNode() (*config.Config, log.Logger, *p2p.NodeKey, error) {
 	}
 
 	if cmtcfg.LogFormat == config.LogFormatJSON {
-		logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
+		logger = log.NewJSONLogger(log.NewSyncWriter(os.Stdout))
 	}
 
 	nodeLogger, err := cmtflags.ParseLogLevel(cmtcfg.LogLevel, logger, config.DefaultLogLevel)
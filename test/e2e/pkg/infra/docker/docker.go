@@ -62,6 +62,10 @@ services:
     entrypoint: /usr/bin/entrypoint-builtin
 {{- end }}
     init: true
+{{- if $.HasNetworkFaults }}
+    cap_add:
+    - NET_ADMIN
+{{- end }}
     ports:
     - 26656
     - {{ if .ProxyPort }}{{ .ProxyPort }}:{{ end }}26657
@@ -86,6 +90,10 @@ services:
     entrypoint: /usr/bin/entrypoint-builtin
 {{- end }}
     init: true
+{{- if $.HasNetworkFaults }}
+    cap_add:
+    - NET_ADMIN
+{{- end }}
     ports:
     - 26656
     - {{ if .ProxyPort }}{{ .ProxyPort }}:{{ end }}26657
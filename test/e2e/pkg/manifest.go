@@ -49,7 +49,8 @@ type Manifest struct {
 	Nodes map[string]*ManifestNode `toml:"node"`
 
 	// KeyType sets the curve that will be used by validators.
-	// Options are ed25519 & secp256k1
+	// Options are ed25519, secp256k1 & bn254. Defaults to ed25519. Nodes can
+	// override it, to run networks of mixed key types.
 	KeyType string `toml:"key_type"`
 
 	// Evidence indicates the amount of evidence that will be injected into the
@@ -86,6 +87,29 @@ type Manifest struct {
 	// Enable or disable Prometheus metrics on all nodes.
 	// Defaults to false (disabled).
 	Prometheus bool `toml:"prometheus"`
+
+	// Partitions lists network partitions to inject, once the testnet has
+	// been started and the nodes perturbed:
+	//
+	// [[partition]]
+	// height = 20
+	// duration = "30s"
+	// groups = [["validator01", "validator02"], ["validator03", "validator04"]]
+	Partitions []ManifestPartition `toml:"partition"`
+}
+
+// ManifestPartition represents a network partition in a testnet manifest.
+type ManifestPartition struct {
+	// Height is the height the network must reach before it is partitioned.
+	Height int64 `toml:"height"`
+
+	// Duration is how long the network stays partitioned before it is healed.
+	Duration time.Duration `toml:"duration"`
+
+	// Groups are the node names of each side of the partition: the nodes of
+	// a group can't reach the nodes of the other groups. The nodes in no
+	// group can reach all the nodes.
+	Groups [][]string `toml:"groups"`
 }
 
 // ManifestNode represents a node in a testnet manifest.
@@ -158,6 +182,19 @@ type ManifestNode struct {
 	// restart:    restarts the node, shutting it down with SIGTERM
 	Perturb []string `toml:"perturb"`
 
+	// KeyType sets the curve of the validator key of the node, overriding the
+	// one of the testnet.
+	KeyType string `toml:"key_type"`
+
+	// Latency is the delay added to the packets sent by the node, and
+	// LatencyJitter its random variation. Defaults to none.
+	Latency       time.Duration `toml:"latency"`
+	LatencyJitter time.Duration `toml:"latency_jitter"`
+
+	// PacketLoss is the percentage (0-100) of the packets sent by the node
+	// which are dropped. Defaults to 0.
+	PacketLoss float64 `toml:"packet_loss"`
+
 	// SendNoLoad determines if the e2e test should send load to this node.
 	// It defaults to false so unless the configured, the node will
 	// receive load.
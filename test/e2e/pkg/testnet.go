@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/secp256k1"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
@@ -81,6 +82,15 @@ type Testnet struct {
 	CheckTxDelay         time.Duration
 	UpgradeVersion       string
 	Prometheus           bool
+	Partitions           []*Partition
+}
+
+// Partition represents a network partition of a testnet, in which the nodes
+// of each group can't reach the nodes of the other groups.
+type Partition struct {
+	Height   int64
+	Duration time.Duration
+	Groups   [][]*Node
 }
 
 // Node represents a CometBFT node in a testnet.
@@ -108,6 +118,9 @@ type Node struct {
 	SendNoLoad          bool
 	Prometheus          bool
 	PrometheusProxyPort uint32
+	Latency             time.Duration
+	LatencyJitter       time.Duration
+	PacketLoss          float64
 }
 
 // LoadTestnet loads a testnet from a manifest file, using the filename to
@@ -185,12 +198,19 @@ func LoadTestnet(manifest Manifest, fname string, ifd InfrastructureData) (*Test
 		if v == "" {
 			v = localVersion
 		}
+		keyType := manifest.KeyType
+		if nodeManifest.KeyType != "" {
+			keyType = nodeManifest.KeyType
+		}
+		if !isKeyType(keyType) {
+			return nil, fmt.Errorf("unknown key type %q for node %q", keyType, name)
+		}
 
 		node := &Node{
 			Name:             name,
 			Version:          v,
 			Testnet:          testnet,
-			PrivvalKey:       keyGen.Generate(manifest.KeyType),
+			PrivvalKey:       keyGen.Generate(keyType),
 			NodeKey:          keyGen.Generate("ed25519"),
 			IP:               ind.IPAddress,
 			ProxyPort:        proxyPortGen.Next(),
@@ -207,6 +227,9 @@ func LoadTestnet(manifest Manifest, fname string, ifd InfrastructureData) (*Test
 			Perturbations:    []Perturbation{},
 			SendNoLoad:       nodeManifest.SendNoLoad,
 			Prometheus:       testnet.Prometheus,
+			Latency:          nodeManifest.Latency,
+			LatencyJitter:    nodeManifest.LatencyJitter,
+			PacketLoss:       nodeManifest.PacketLoss,
 		}
 		if node.StartAt == testnet.InitialHeight {
 			node.StartAt = 0 // normalize to 0 for initial nodes, since code expects this
@@ -305,6 +328,23 @@ func LoadTestnet(manifest Manifest, fname string, ifd InfrastructureData) (*Test
 		testnet.ValidatorUpdates[int64(height)] = valUpdate
 	}
 
+	// Set up partitions.
+	for i, p := range manifest.Partitions {
+		partition := &Partition{Height: p.Height, Duration: p.Duration}
+		for _, names := range p.Groups {
+			group := []*Node{}
+			for _, name := range names {
+				node := testnet.LookupNode(name)
+				if node == nil {
+					return nil, fmt.Errorf("unknown node %q in partition %d", name, i)
+				}
+				group = append(group, node)
+			}
+			partition.Groups = append(partition.Groups, group)
+		}
+		testnet.Partitions = append(testnet.Partitions, partition)
+	}
+
 	return testnet, testnet.Validate()
 }
 
@@ -324,6 +364,34 @@ func (t Testnet) Validate() error {
 			return fmt.Errorf("invalid node %q: %w", node.Name, err)
 		}
 	}
+	for i, partition := range t.Partitions {
+		if err := partition.Validate(); err != nil {
+			return fmt.Errorf("invalid partition %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate validates a partition.
+func (p Partition) Validate() error {
+	if p.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if len(p.Groups) < 2 {
+		return errors.New("at least two groups are required")
+	}
+	seen := map[string]bool{}
+	for _, group := range p.Groups {
+		if len(group) == 0 {
+			return errors.New("empty group")
+		}
+		for _, node := range group {
+			if seen[node.Name] {
+				return fmt.Errorf("node %q in several groups", node.Name)
+			}
+			seen[node.Name] = true
+		}
+	}
 	return nil
 }
 
@@ -401,6 +469,15 @@ func (n Node) Validate(testnet Testnet) error {
 	if n.SnapshotInterval > 0 && n.RetainBlocks > 0 && n.RetainBlocks < n.SnapshotInterval {
 		return errors.New("snapshot_interval must be less than er equal to retain_blocks")
 	}
+	if n.Latency < 0 || n.LatencyJitter < 0 {
+		return errors.New("latency and latency_jitter can't be negative")
+	}
+	if n.LatencyJitter > 0 && n.Latency == 0 {
+		return errors.New("latency_jitter requires latency")
+	}
+	if n.PacketLoss < 0 || n.PacketLoss > 100 {
+		return fmt.Errorf("packet_loss %v must be a percentage", n.PacketLoss)
+	}
 
 	var upgradeFound bool
 	for _, perturbation := range n.Perturbations {
@@ -457,14 +534,41 @@ func (t Testnet) IPv6() bool {
 	return t.IP.IP.To4() == nil
 }
 
-// HasPerturbations returns whether the network has any perturbations.
+// HasPerturbations returns whether the network has any perturbations,
+// partitions included.
 func (t Testnet) HasPerturbations() bool {
 	for _, node := range t.Nodes {
 		if len(node.Perturbations) > 0 {
 			return true
 		}
 	}
-	return false
+	return len(t.Partitions) > 0
+}
+
+// HasNetworkFaults returns whether the network has any latency, packet loss
+// or partitions, which require the nodes to be able to administer their
+// network.
+func (t Testnet) HasNetworkFaults() bool {
+	for _, node := range t.Nodes {
+		if node.HasNetem() {
+			return true
+		}
+	}
+	return len(t.Partitions) > 0
+}
+
+// KeyTypes returns the sorted key types of the validator keys of the nodes.
+func (t Testnet) KeyTypes() []string {
+	keyTypes := []string{}
+	seen := map[string]bool{}
+	for _, node := range t.Nodes {
+		if keyType := node.PrivvalKey.Type(); !seen[keyType] {
+			seen[keyType] = true
+			keyTypes = append(keyTypes, keyType)
+		}
+	}
+	sort.Strings(keyTypes)
+	return keyTypes
 }
 
 // Address returns a P2P endpoint address for the node.
@@ -501,6 +605,22 @@ func (n Node) Stateless() bool {
 	return n.Mode == ModeLight || n.Mode == ModeSeed
 }
 
+// HasNetem returns true if latency or packet loss is added to the packets
+// sent by the node.
+func (n Node) HasNetem() bool {
+	return n.Latency > 0 || n.PacketLoss > 0
+}
+
+// isKeyType returns true if keys of keyType can be generated.
+func isKeyType(keyType string) bool {
+	switch keyType {
+	case "", "ed25519", "secp256k1", "bn254":
+		return true
+	default:
+		return false
+	}
+}
+
 // keyGenerator generates pseudorandom Ed25519 keys based on a seed.
 type keyGenerator struct {
 	random *rand.Rand
@@ -524,6 +644,8 @@ func (g *keyGenerator) Generate(keyType string) crypto.PrivKey {
 		return secp256k1.GenPrivKeySecp256k1(seed)
 	case "", "ed25519":
 		return ed25519.GenPrivKeyFromSecret(seed)
+	case "bn254":
+		return bn254.GenPrivKeyFromSecret(seed)
 	default:
 		panic("KeyType not supported") // should not make it this far
 	}
@@ -25,6 +25,10 @@ import (
 // 1 in 4 evidence is light client evidence, the rest is duplicate vote evidence
 const lightClientEvidenceRatio = 4
 
+// the testnets use the default canonical encoding, with which the evidence is
+// signed and hashed
+const canonicalEncoding = types.CanonicalEncodingProto
+
 // InjectEvidence takes a running testnet and generates an amount of valid
 // evidence and broadcasts it to a random node through the rpc endpoint `/broadcast_evidence`.
 // Evidence is random and can be a mixture of LightClientAttackEvidence and
@@ -162,11 +166,11 @@ func generateLightClientAttackEvidence(
 		return nil, err
 	}
 
-	header.ValidatorsHash = conflictingVals.Hash()
+	header.ValidatorsHash = conflictingVals.Hash(canonicalEncoding)
 
 	// create a commit for the forged header
-	blockID := makeBlockID(header.Hash(), 1000, []byte("partshash"))
-	voteSet := types.NewVoteSet(chainID, forgedHeight, 0, cmtproto.SignedMsgType(2), conflictingVals)
+	blockID := makeBlockID(header.Hash(canonicalEncoding), 1000, []byte("partshash"))
+	voteSet := types.NewVoteSet(chainID, canonicalEncoding, forgedHeight, 0, cmtproto.SignedMsgType(2), conflictingVals)
 	commit, err := test.MakeCommitFromVoteSet(blockID, voteSet, pv, forgedTime)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
+)
+
+// containerName returns the name of the container running a node, which is
+// the alternate one once the node was upgraded, and whether it was.
+func containerName(node *e2e.Node) (string, bool, error) {
+	out, err := execComposeOutput(node.Testnet.Dir, "ps", "-q", node.Name)
+	if err != nil {
+		return "", false, err
+	}
+	if len(out) == 0 {
+		return node.Name + "_u", true, nil
+	}
+	return node.Name, false, nil
+}
+
+// applyNetem adds the latency and packet loss of a node to the packets sent
+// by its container, replacing any previously added. It must be applied again
+// whenever the container is restarted or reconnected.
+func applyNetem(node *e2e.Node, container string) error {
+	if !node.HasNetem() {
+		return nil
+	}
+	args := []string{"exec", container, "tc", "qdisc", "replace", "dev", "eth0", "root", "netem"}
+	if node.Latency > 0 {
+		args = append(args, "delay", fmt.Sprintf("%dus", node.Latency.Microseconds()))
+		if node.LatencyJitter > 0 {
+			args = append(args, fmt.Sprintf("%dus", node.LatencyJitter.Microseconds()))
+		}
+	}
+	if node.PacketLoss > 0 {
+		args = append(args, "loss", fmt.Sprintf("%v%%", node.PacketLoss))
+	}
+	logger.Info("netem", "msg", log.NewLazySprintf("Adding %v latency (±%v) and %v%% packet loss to node %v",
+		node.Latency, node.LatencyJitter, node.PacketLoss, node.Name))
+	return execDocker(args...)
+}
+
+// Partition partitions a testnet once it reached the height of the
+// partition, and heals it after its duration.
+func Partition(testnet *e2e.Testnet, partition *e2e.Partition) error {
+	if _, _, err := waitForHeight(testnet, partition.Height); err != nil {
+		return err
+	}
+	groups := make([]string, len(partition.Groups))
+	for i, group := range partition.Groups {
+		names := make([]string, len(group))
+		for j, node := range group {
+			names[j] = node.Name
+		}
+		groups[i] = "[" + strings.Join(names, " ") + "]"
+	}
+	logger.Info("partition", "msg", log.NewLazySprintf("Partitioning the network into %v for %v...",
+		strings.Join(groups, " "), partition.Duration))
+	if err := routePartition(partition, "add"); err != nil {
+		return err
+	}
+	time.Sleep(partition.Duration)
+	logger.Info("partition", "msg", log.NewLazySprintf("Healing the partition into %v", strings.Join(groups, " ")))
+	return routePartition(partition, "del")
+}
+
+// routePartition adds or deletes (op) blackhole routes from the nodes of each
+// group of a partition to the nodes of the other groups.
+func routePartition(partition *e2e.Partition, op string) error {
+	for i, group := range partition.Groups {
+		for _, node := range group {
+			container, _, err := containerName(node)
+			if err != nil {
+				return err
+			}
+			for j, other := range partition.Groups {
+				if i == j {
+					continue
+				}
+				for _, peer := range other {
+					if err := execDocker("exec", container, "ip", "route", op, "blackhole", peer.IP.String()); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -9,7 +9,7 @@ import (
 	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
 )
 
-// Perturbs a running testnet.
+// Perturbs a running testnet, then partitions it.
 func Perturb(testnet *e2e.Testnet) error {
 	for _, node := range testnet.Nodes {
 		for _, perturbation := range node.Perturbations {
@@ -20,6 +20,12 @@ func Perturb(testnet *e2e.Testnet) error {
 			time.Sleep(3 * time.Second) // give network some time to recover between each
 		}
 	}
+	for _, partition := range testnet.Partitions {
+		if err := Partition(testnet, partition); err != nil {
+			return err
+		}
+		time.Sleep(3 * time.Second)
+	}
 	return nil
 }
 
@@ -27,15 +33,11 @@ func Perturb(testnet *e2e.Testnet) error {
 // after recovering.
 func PerturbNode(node *e2e.Node, perturbation e2e.Perturbation) (*rpctypes.ResultStatus, error) {
 	testnet := node.Testnet
-	out, err := execComposeOutput(testnet.Dir, "ps", "-q", node.Name)
+	name, upgraded, err := containerName(node)
 	if err != nil {
 		return nil, err
 	}
-	name := node.Name
-	upgraded := false
-	if len(out) == 0 {
-		name = name + "_u"
-		upgraded = true
+	if upgraded {
 		logger.Info("perturb node", "msg",
 			log.NewLazySprintf("Node %v already upgraded, operating on alternate container %v",
 				node.Name, name))
@@ -98,7 +100,8 @@ func PerturbNode(node *e2e.Node, perturbation e2e.Perturbation) (*rpctypes.Resul
 			return nil, err
 		}
 		time.Sleep(10 * time.Second)
-		if err := execCompose(testnet.Dir, "up", "-d", name+"_u"); err != nil {
+		name += "_u"
+		if err := execCompose(testnet.Dir, "up", "-d", name); err != nil {
 			return nil, err
 		}
 
@@ -106,6 +109,10 @@ func PerturbNode(node *e2e.Node, perturbation e2e.Perturbation) (*rpctypes.Resul
 		return nil, fmt.Errorf("unexpected perturbation %q", perturbation)
 	}
 
+	// the network of the container was reset
+	if err := applyNetem(node, name); err != nil {
+		return nil, err
+	}
 	status, err := waitForNode(node, 0, 20*time.Second)
 	if err != nil {
 		return nil, err
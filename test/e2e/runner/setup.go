@@ -16,6 +16,7 @@ import (
 	"github.com/BurntSushi/toml"
 
 	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/p2p"
@@ -129,13 +130,22 @@ func MakeGenesis(testnet *e2e.Testnet) (types.GenesisDoc, error) {
 	}
 	// set the app version to 1
 	genesis.ConsensusParams.Version.App = 1
+	genesis.ConsensusParams.Validator.PubKeyTypes = testnet.KeyTypes()
 	for validator, power := range testnet.Validators {
-		genesis.Validators = append(genesis.Validators, types.GenesisValidator{
+		genVal := types.GenesisValidator{
 			Name:    validator.Name,
 			Address: validator.PrivvalKey.PubKey().Address(),
 			PubKey:  validator.PrivvalKey.PubKey(),
 			Power:   power,
-		})
+		}
+		if privKey, ok := validator.PrivvalKey.(bn254.PrivKey); ok {
+			proof, err := privKey.ProvePossession()
+			if err != nil {
+				return types.GenesisDoc{}, err
+			}
+			genVal.ProofOfPossession = proof
+		}
+		genesis.Validators = append(genesis.Validators, genVal)
 	}
 	// The validator set will be sorted internally by CometBFT ranked by power,
 	// but we sort it here as well so that all genesis files are identical.
@@ -190,6 +200,7 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 
 	switch node.Mode {
 	case e2e.ModeValidator:
+		cfg.Mode = config.ModeValidator
 		switch node.PrivvalProtocol {
 		case e2e.ProtocolFile:
 			cfg.PrivValidatorKey = PrivvalKeyFile
@@ -202,10 +213,11 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 			return nil, fmt.Errorf("invalid privval protocol setting %q", node.PrivvalProtocol)
 		}
 	case e2e.ModeSeed:
-		cfg.P2P.SeedMode = true
+		cfg.Mode = config.ModeSeed
 		cfg.P2P.PexReactor = true
 	case e2e.ModeFull, e2e.ModeLight:
-		// Don't need to do anything, since we're using a dummy privval key by default.
+		// The dummy privval key is never used to sign.
+		cfg.Mode = config.ModeFull
 	default:
 		return nil, fmt.Errorf("unexpected mode %q", node.Mode)
 	}
@@ -297,8 +309,13 @@ func MakeAppConfig(node *e2e.Node) ([]byte, error) {
 		validatorUpdates := map[string]map[string]int64{}
 		for height, validators := range node.Testnet.ValidatorUpdates {
 			updateVals := map[string]int64{}
-			for node, power := range validators {
-				updateVals[base64.StdEncoding.EncodeToString(node.PrivvalKey.PubKey().Bytes())] = power
+			for validator, power := range validators {
+				// the key type is given if it differs from the one of the node
+				key := base64.StdEncoding.EncodeToString(validator.PrivvalKey.PubKey().Bytes())
+				if keyType := validator.PrivvalKey.Type(); keyType != node.PrivvalKey.Type() {
+					key = keyType + ":" + key
+				}
+				updateVals[key] = power
 			}
 			validatorUpdates[fmt.Sprintf("%v", height)] = updateVals
 		}
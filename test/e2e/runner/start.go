@@ -46,6 +46,9 @@ func Start(testnet *e2e.Testnet) error {
 		if err := execCompose(testnet.Dir, "up", "-d", node.Name); err != nil {
 			return err
 		}
+		if err := applyNetem(node, node.Name); err != nil {
+			return err
+		}
 		if _, err := waitForNode(node, 0, 15*time.Second); err != nil {
 			return err
 		}
@@ -104,6 +107,9 @@ func Start(testnet *e2e.Testnet) error {
 		if err := execCompose(testnet.Dir, "up", "-d", node.Name); err != nil {
 			return err
 		}
+		if err := applyNetem(node, node.Name); err != nil {
+			return err
+		}
 		status, err := waitForNode(node, node.StartAt, 3*time.Minute)
 		if err != nil {
 			return err
@@ -6,7 +6,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/crypto/bn254"
 	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
+	"github.com/cometbft/cometbft/types"
 )
 
 // Tests that block headers are identical across nodes where present.
@@ -93,3 +95,48 @@ func TestBlock_Range(t *testing.T) {
 		}
 	})
 }
+
+// Tests that the commits of bn254 validators aggregate into a single signature
+// verifying against the validator set. Commits of mixed key types don't
+// aggregate.
+func TestBlock_AggregatedCommit(t *testing.T) {
+	blocks := fetchBlockChain(t)
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		keyTypes := node.Testnet.KeyTypes()
+		if node.Mode == e2e.ModeSeed || len(keyTypes) != 1 || keyTypes[0] != bn254.KeyType {
+			return
+		}
+
+		client, err := node.Client()
+		require.NoError(t, err)
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+
+		first := status.SyncInfo.EarliestBlockHeight
+		last := status.SyncInfo.LatestBlockHeight - 1 // the last commit may not be canonical yet
+		if node.RetainBlocks > 0 {
+			first++ // avoid race conditions with block pruning
+		}
+
+		for _, block := range blocks {
+			height := block.Header.Height
+			if height < first {
+				continue
+			}
+			if height > last {
+				break
+			}
+			res, err := client.CommitAggregated(ctx, &height)
+			require.NoError(t, err)
+			require.Equal(t, block.Hash(types.CanonicalEncodingProto), res.Header.Hash(types.CanonicalEncodingProto),
+				"header mismatch for height %d", height)
+
+			valSet, err := types.ValidatorSetFromExistingValidators(res.Validators)
+			require.NoError(t, err)
+			require.Equal(t, block.ValidatorsHash, valSet.Hash(types.CanonicalEncodingProto),
+				"validator set mismatch for height %d", height)
+			require.NoError(t, valSet.VerifyAggregatedCommit(node.Testnet.Name, types.CanonicalEncodingProto, res.Commit.BlockID, height, res.Commit),
+				"invalid aggregated commit for height %d", height)
+		}
+	})
+}
@@ -0,0 +1,320 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+// AggregatedCommit is a Commit whose bn254 signatures for the block are
+// aggregated into a single signature, in a form fit for light clients which
+// can't afford to verify each signature (smart contracts, zk circuits).
+//
+// Only the signatures for the block (BlockIDFlagCommit) are aggregated; nil
+// and absent votes are dropped.
+type AggregatedCommit struct {
+	Height  int64   `json:"height"`
+	Round   int32   `json:"round"`
+	BlockID BlockID `json:"block_id"`
+	// Signers is a bitmap of the validators, in the order of the validator
+	// set, whose signatures are aggregated: validator i signed iff bit i%8
+	// (least significant first) of byte i/8 is set.
+	Signers cmtbytes.HexBytes `json:"signers"`
+	// Timestamps are the timestamps of the votes of the signers, in the same
+	// order. They are part of the signed message, which hence differs from one
+	// validator to the other.
+	Timestamps []time.Time `json:"timestamps"`
+	// Signature is the uncompressed G2 point sum of the signatures.
+	Signature cmtbytes.HexBytes `json:"signature"`
+}
+
+// Aggregate aggregates the signatures for the block of the commit. It returns
+// an error if there are none, or if any of them isn't a bn254 signature.
+func (commit *Commit) Aggregate() (*AggregatedCommit, error) {
+	ac := &AggregatedCommit{
+		Height:  commit.Height,
+		Round:   commit.Round,
+		BlockID: commit.BlockID,
+		Signers: make([]byte, (len(commit.Signatures)+7)/8),
+	}
+
+	var sigs [][]byte
+	for idx, commitSig := range commit.Signatures {
+		if !commitSig.ForBlock() {
+			continue
+		}
+		ac.Signers[idx/8] |= 1 << (idx % 8)
+		ac.Timestamps = append(ac.Timestamps, commitSig.Timestamp)
+		sigs = append(sigs, commitSig.Signature)
+	}
+
+	sig, err := bn254.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate commit signatures: %w", err)
+	}
+	ac.Signature = sig
+	return ac, nil
+}
+
+// HasSigner returns true if the signature of the validator at valIdx is part
+// of the aggregated signature.
+func (ac *AggregatedCommit) HasSigner(valIdx int) bool {
+	if valIdx < 0 || valIdx/8 >= len(ac.Signers) {
+		return false
+	}
+	return ac.Signers[valIdx/8]&(1<<(valIdx%8)) != 0
+}
+
+// NumSigners returns the number of signers.
+func (ac *AggregatedCommit) NumSigners() int {
+	n := 0
+	for i := 0; i < len(ac.Signers)*8; i++ {
+		if ac.HasSigner(i) {
+			n++
+		}
+	}
+	return n
+}
+
+// VoteSignBytes returns the bytes signed by the validator whose vote was cast
+// at the given time.
+func (ac *AggregatedCommit) VoteSignBytes(chainID string, encoding CanonicalEncoding, timestamp time.Time) []byte {
+	v := &cmtproto.Vote{
+		Type:      cmtproto.PrecommitType,
+		Height:    ac.Height,
+		Round:     ac.Round,
+		BlockID:   ac.BlockID.ToProto(),
+		Timestamp: timestamp,
+	}
+	return VoteSignBytes(chainID, encoding, v)
+}
+
+// ValidateBasic performs basic validation.
+func (ac *AggregatedCommit) ValidateBasic() error {
+	if ac.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if ac.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if err := ac.BlockID.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong BlockID: %v", err)
+	}
+	if n := ac.NumSigners(); n == 0 {
+		return errors.New("no signers")
+	} else if n != len(ac.Timestamps) {
+		return fmt.Errorf("expected %d timestamps (one per signer), got %d", n, len(ac.Timestamps))
+	}
+	if len(ac.Signature) == 0 {
+		return errors.New("no signature")
+	}
+	return nil
+}
+
+// ToProto converts AggregatedCommit to protobuf.
+func (ac *AggregatedCommit) ToProto() *cmtproto.AggregatedCommit {
+	if ac == nil {
+		return nil
+	}
+
+	return &cmtproto.AggregatedCommit{
+		Height:     ac.Height,
+		Round:      ac.Round,
+		BlockID:    ac.BlockID.ToProto(),
+		Signers:    ac.Signers,
+		Timestamps: ac.Timestamps,
+		Signature:  ac.Signature,
+	}
+}
+
+// AggregatedCommitFromProto converts protobuf to AggregatedCommit.
+// It returns an error if the aggregated commit is invalid.
+func AggregatedCommitFromProto(acp *cmtproto.AggregatedCommit) (*AggregatedCommit, error) {
+	if acp == nil {
+		return nil, errors.New("nil AggregatedCommit")
+	}
+
+	bi, err := BlockIDFromProto(&acp.BlockID)
+	if err != nil {
+		return nil, err
+	}
+
+	ac := &AggregatedCommit{
+		Height:     acp.Height,
+		Round:      acp.Round,
+		BlockID:    *bi,
+		Signers:    acp.Signers,
+		Timestamps: acp.Timestamps,
+		Signature:  acp.Signature,
+	}
+	return ac, ac.ValidateBasic()
+}
+
+// AggregatedSignedHeader is a header along with the aggregated commit that
+// proves it.
+type AggregatedSignedHeader struct {
+	*Header `json:"header"`
+
+	Commit *AggregatedCommit `json:"commit"`
+}
+
+// ValidateBasic does basic consistency checks and makes sure the header
+// and aggregated commit are consistent.
+//
+// NOTE: This does not actually check the aggregated signature.
+func (ash AggregatedSignedHeader) ValidateBasic(chainID string, encoding CanonicalEncoding) error {
+	if err := ash.validateBasic(chainID); err != nil {
+		return err
+	}
+	if hhash, chash := ash.Header.Hash(encoding), ash.Commit.BlockID.Hash; !bytes.Equal(hhash, chash) {
+		return fmt.Errorf("commit signs block %X, header is block %X", chash, hhash)
+	}
+	return nil
+}
+
+// validateBasic is ValidateBasic, without checking that the header hashes to
+// the block ID of the commit.
+func (ash AggregatedSignedHeader) validateBasic(chainID string) error {
+	if ash.Header == nil {
+		return errors.New("missing header")
+	}
+	if ash.Commit == nil {
+		return errors.New("missing commit")
+	}
+
+	if err := ash.Header.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	}
+	if err := ash.Commit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+
+	if ash.ChainID != chainID {
+		return fmt.Errorf("header belongs to another chain %q, not %q", ash.ChainID, chainID)
+	}
+
+	// Make sure the header is consistent with the commit.
+	if ash.Commit.Height != ash.Height {
+		return fmt.Errorf("header and commit height mismatch: %d vs %d", ash.Height, ash.Commit.Height)
+	}
+	return nil
+}
+
+// VerifyAggregatedCommit verifies +2/3 of the set had signed the given
+// aggregated commit. The signers are looked up by index, i.e. vals must be
+// the validator set which signed the commit.
+func VerifyAggregatedCommit(chainID string, encoding CanonicalEncoding, vals *ValidatorSet, blockID BlockID,
+	height int64, ac *AggregatedCommit) error {
+	if vals == nil {
+		return errors.New("nil validator set")
+	}
+	if ac == nil {
+		return errors.New("nil aggregated commit")
+	}
+	if err := ac.ValidateBasic(); err != nil {
+		return err
+	}
+	if expected := (vals.Size() + 7) / 8; len(ac.Signers) != expected {
+		return fmt.Errorf("invalid signers bitmap: expected %d bytes for %d validators, got %d",
+			expected, vals.Size(), len(ac.Signers))
+	}
+	for i := vals.Size(); i < len(ac.Signers)*8; i++ {
+		if ac.HasSigner(i) {
+			return fmt.Errorf("invalid signers bitmap: signer #%d out of the validator set", i)
+		}
+	}
+
+	// Validate Height and BlockID.
+	if height != ac.Height {
+		return NewErrInvalidCommitHeight(height, ac.Height)
+	}
+	if !blockID.Equals(ac.BlockID) {
+		return fmt.Errorf("invalid commit -- wrong block ID: want %v, got %v",
+			blockID, ac.BlockID)
+	}
+
+	var (
+		pubKeys            = make([]*bn254.DecodedPubKey, 0, len(ac.Timestamps))
+		msgs               = make([][]byte, 0, len(ac.Timestamps))
+		talliedVotingPower int64
+	)
+	for idx, val := range vals.Validators {
+		if !ac.HasSigner(idx) {
+			continue
+		}
+		if val.PubKey.Type() != bn254.KeyType {
+			return fmt.Errorf("validator #%d has a %s key, expected %s", idx, val.PubKey.Type(), bn254.KeyType)
+		}
+		pubKey, ok := bn254.Decoded(val.VerifyingPubKey())
+		if !ok {
+			return fmt.Errorf("validator #%d has an invalid key", idx)
+		}
+		pubKeys = append(pubKeys, pubKey)
+		msgs = append(msgs, ac.VoteSignBytes(chainID, encoding, ac.Timestamps[len(msgs)]))
+		talliedVotingPower += val.VotingPower
+	}
+
+	if !bn254.VerifyAggregateSignatureDecoded(pubKeys, msgs, ac.Signature) {
+		return fmt.Errorf("wrong aggregated signature: %X", ac.Signature)
+	}
+
+	// Note that total voting power is capped to 1/8th of max int64 so this
+	// operation should never overflow
+	if got, needed := talliedVotingPower, vals.TotalVotingPower()*2/3; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+	return nil
+}
+
+// VerifyAggregatedCommitLightTrusting verifies that trustLevel of the
+// validator set signed the given aggregated commit, which was signed by
+// signers, e.g. the validator set at a later height: only the signers which
+// are part of vals are counted, with their voting power in vals.
+//
+// NOTE: the aggregated signature isn't verified, since it can only be
+// verified as a whole: VerifyAggregatedCommit must be called as well, with
+// signers.
+func VerifyAggregatedCommitLightTrusting(vals, signers *ValidatorSet, ac *AggregatedCommit,
+	trustLevel cmtmath.Fraction) error {
+	if vals == nil || signers == nil {
+		return errors.New("nil validator set")
+	}
+	if ac == nil {
+		return errors.New("nil aggregated commit")
+	}
+	if trustLevel.Denominator == 0 {
+		return errors.New("trustLevel has zero Denominator")
+	}
+
+	var talliedVotingPower int64
+	for idx, signer := range signers.Validators {
+		if !ac.HasSigner(idx) {
+			continue
+		}
+		_, val := vals.GetByAddress(signer.Address)
+		if val == nil {
+			continue
+		}
+		if !val.PubKey.Equals(signer.PubKey) {
+			return fmt.Errorf("validator %X has a different key in the signers", val.Address)
+		}
+		talliedVotingPower += val.VotingPower
+	}
+
+	// Safely calculate voting power needed.
+	totalVotingPowerMulByNumerator, overflow := safeMul(vals.TotalVotingPower(), int64(trustLevel.Numerator))
+	if overflow {
+		return errors.New("int64 overflow while calculating voting power needed. please provide smaller trustLevel numerator")
+	}
+	votingPowerNeeded := totalVotingPowerMulByNumerator / int64(trustLevel.Denominator)
+	if talliedVotingPower <= votingPowerNeeded {
+		return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+	}
+	return nil
+}
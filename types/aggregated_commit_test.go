@@ -0,0 +1,136 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+// makeBn254Commit makes a commit where the validators in absent didn't vote,
+// and those in nilVotes voted nil.
+func makeBn254Commit(t *testing.T, chainID string, height int64, blockID BlockID,
+	privVals []PrivValidator, absent, nilVotes map[int]bool) *Commit {
+	sigs := make([]CommitSig, len(privVals))
+	for i, pv := range privVals {
+		if absent[i] {
+			sigs[i] = NewCommitSigAbsent()
+			continue
+		}
+		pubKey, err := pv.GetPubKey()
+		require.NoError(t, err)
+		vote := &Vote{
+			ValidatorAddress: pubKey.Address(),
+			ValidatorIndex:   int32(i),
+			Height:           height,
+			Round:            1,
+			Type:             cmtproto.PrecommitType,
+			BlockID:          blockID,
+			Timestamp:        time.Now().Add(time.Duration(i) * time.Millisecond),
+		}
+		if nilVotes[i] {
+			vote.BlockID = BlockID{}
+		}
+		v := vote.ToProto()
+		require.NoError(t, pv.SignVote(chainID, CanonicalEncodingProto, v))
+		vote.Signature = v.Signature
+		sigs[i] = vote.CommitSig()
+	}
+	return NewCommit(height, 1, blockID, sigs)
+}
+
+func TestAggregatedCommit(t *testing.T) {
+	var (
+		chainID = "Lalande21185"
+		height  = int64(100)
+		blockID = makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	)
+	valSet, privVals := RandBn254ValidatorSet(10, 10)
+
+	commit := makeBn254Commit(t, chainID, height, blockID, privVals,
+		map[int]bool{2: true}, map[int]bool{9: true})
+	require.NoError(t, valSet.VerifyCommit(chainID, CanonicalEncodingProto, blockID, height, commit))
+
+	ac, err := commit.Aggregate()
+	require.NoError(t, err)
+	assert.Equal(t, height, ac.Height)
+	assert.EqualValues(t, 1, ac.Round)
+	assert.Equal(t, blockID, ac.BlockID)
+	assert.Equal(t, []byte{0xff &^ (1 << 2), 0x01}, []byte(ac.Signers))
+	assert.Equal(t, 8, ac.NumSigners())
+	assert.Len(t, ac.Timestamps, 8)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i != 2 && i != 9, ac.HasSigner(i), i)
+	}
+	assert.False(t, ac.HasSigner(16))
+	assert.False(t, ac.HasSigner(-1))
+
+	require.NoError(t, valSet.VerifyAggregatedCommit(chainID, CanonicalEncodingProto, blockID, height, ac))
+
+	err = valSet.VerifyAggregatedCommit("EpsilonEridani", CanonicalEncodingProto, blockID, height, ac)
+	assert.ErrorContains(t, err, "wrong aggregated signature")
+	err = valSet.VerifyAggregatedCommit(chainID, CanonicalEncodingProto, makeBlockIDRandom(), height, ac)
+	assert.ErrorContains(t, err, "wrong block ID")
+	err = valSet.VerifyAggregatedCommit(chainID, CanonicalEncodingProto, blockID, height-1, ac)
+	assert.Error(t, err)
+
+	// claiming a signer which didn't sign
+	forged := *ac
+	forged.Signers = []byte{0xff, 0x01}
+	forged.Timestamps = append([]time.Time{time.Now()}, ac.Timestamps...)
+	err = valSet.VerifyAggregatedCommit(chainID, CanonicalEncodingProto, blockID, height, &forged)
+	assert.ErrorContains(t, err, "wrong aggregated signature")
+
+	// tampering with a timestamp
+	forged = *ac
+	forged.Timestamps = append([]time.Time{}, ac.Timestamps...)
+	forged.Timestamps[0] = forged.Timestamps[0].Add(time.Second)
+	err = valSet.VerifyAggregatedCommit(chainID, CanonicalEncodingProto, blockID, height, &forged)
+	assert.ErrorContains(t, err, "wrong aggregated signature")
+
+	// signer out of the validator set
+	forged = *ac
+	forged.Signers = []byte{ac.Signers[0], 0x81}
+	forged.Timestamps = append(append([]time.Time{}, ac.Timestamps...), time.Now())
+	err = valSet.VerifyAggregatedCommit(chainID, CanonicalEncodingProto, blockID, height, &forged)
+	assert.ErrorContains(t, err, "out of the validator set")
+
+	// missing timestamp
+	forged = *ac
+	forged.Timestamps = ac.Timestamps[1:]
+	err = valSet.VerifyAggregatedCommit(chainID, CanonicalEncodingProto, blockID, height, &forged)
+	assert.ErrorContains(t, err, "timestamps")
+}
+
+func TestAggregatedCommitNotEnoughVotingPower(t *testing.T) {
+	var (
+		chainID = "Lalande21185"
+		height  = int64(100)
+		blockID = makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	)
+	valSet, privVals := RandBn254ValidatorSet(3, 10)
+
+	commit := makeBn254Commit(t, chainID, height, blockID, privVals,
+		map[int]bool{0: true}, nil)
+	ac, err := commit.Aggregate()
+	require.NoError(t, err)
+
+	err = valSet.VerifyAggregatedCommit(chainID, CanonicalEncodingProto, blockID, height, ac)
+	assert.Equal(t, ErrNotEnoughVotingPowerSigned{Got: 20, Needed: 20}, err)
+}
+
+func TestAggregateCommitErrors(t *testing.T) {
+	// no signature for the block
+	_, err := NewCommit(1, 0, makeBlockIDRandom(), []CommitSig{NewCommitSigAbsent()}).Aggregate()
+	assert.Error(t, err)
+
+	// ed25519 signatures can't be aggregated
+	voteSet, _, vals := randVoteSet(1, 0, cmtproto.PrecommitType, 2, 10)
+	commit, err := MakeCommit(makeBlockIDRandom(), 1, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	_, err = commit.Aggregate()
+	assert.Error(t, err)
+}
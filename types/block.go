@@ -118,9 +118,9 @@ func (b *Block) fillHeader() {
 	}
 }
 
-// Hash computes and returns the block hash.
+// Hash computes and returns the block hash, with the given canonical encoding.
 // If the block is incomplete, block hash is nil for safety.
-func (b *Block) Hash() cmtbytes.HexBytes {
+func (b *Block) Hash(encoding CanonicalEncoding) cmtbytes.HexBytes {
 	if b == nil {
 		return nil
 	}
@@ -131,7 +131,7 @@ func (b *Block) Hash() cmtbytes.HexBytes {
 		return nil
 	}
 	b.fillHeader()
-	return b.Header.Hash()
+	return b.Header.Hash(encoding)
 }
 
 // MakePartSet returns a PartSet containing parts of a serialized block.
@@ -155,16 +155,17 @@ func (b *Block) MakePartSet(partSize uint32) (*PartSet, error) {
 	return NewPartSetFromData(bz, partSize), nil
 }
 
-// HashesTo is a convenience function that checks if a block hashes to the given argument.
+// HashesTo is a convenience function that checks if a block hashes to the given argument,
+// with the given canonical encoding.
 // Returns false if the block is nil or the hash is empty.
-func (b *Block) HashesTo(hash []byte) bool {
+func (b *Block) HashesTo(hash []byte, encoding CanonicalEncoding) bool {
 	if len(hash) == 0 {
 		return false
 	}
 	if b == nil {
 		return false
 	}
-	return bytes.Equal(b.Hash(), hash)
+	return bytes.Equal(b.Hash(encoding), hash)
 }
 
 // Size returns size of the block in bytes.
@@ -190,7 +191,9 @@ func (b *Block) String() string {
 // Data
 // Evidence
 // LastCommit
-// Hash
+//
+// The hash of the block depends on the canonical encoding, so it isn't part of
+// the string.
 func (b *Block) StringIndented(indent string) string {
 	if b == nil {
 		return "nil-Block"
@@ -200,12 +203,12 @@ func (b *Block) StringIndented(indent string) string {
 %s  %v
 %s  %v
 %s  %v
-%s}#%v`,
+%s}`,
 		indent, b.Header.StringIndented(indent+"  "),
 		indent, b.Data.StringIndented(indent+"  "),
 		indent, b.Evidence.StringIndented(indent+"  "),
 		indent, b.LastCommit.StringIndented(indent+"  "),
-		indent, b.Hash())
+		indent)
 }
 
 // StringShort returns a shortened string representation of the block.
@@ -213,7 +216,7 @@ func (b *Block) StringShort() string {
 	if b == nil {
 		return "nil-Block"
 	}
-	return fmt.Sprintf("Block#%X", b.Hash())
+	return fmt.Sprintf("Block@%d", b.Height)
 }
 
 // ToProto converts Block to protobuf
@@ -437,10 +440,20 @@ func (h Header) ValidateBasic() error {
 // Returns nil if ValidatorHash is missing,
 // since a Header is not valid unless there is
 // a ValidatorsHash (corresponding to the validator set).
-func (h *Header) Hash() cmtbytes.HexBytes {
+//
+// With the fixed-width canonical encoding, it is the SHA-256 hash of
+// FixedWidthBytes instead.
+func (h *Header) Hash(encoding CanonicalEncoding) cmtbytes.HexBytes {
 	if h == nil || len(h.ValidatorsHash) == 0 {
 		return nil
 	}
+	if encoding == CanonicalEncodingFixedWidth {
+		bz, err := h.FixedWidthBytes()
+		if err != nil {
+			return nil
+		}
+		return tmhash.Sum(bz)
+	}
 	hbz, err := h.Version.Marshal()
 	if err != nil {
 		return nil
@@ -474,7 +487,8 @@ func (h *Header) Hash() cmtbytes.HexBytes {
 	})
 }
 
-// StringIndented returns an indented string representation of the header.
+// StringIndented returns an indented string representation of the header,
+// without its hash, which depends on the canonical encoding.
 func (h *Header) StringIndented(indent string) string {
 	if h == nil {
 		return "nil-Header"
@@ -494,7 +508,7 @@ func (h *Header) StringIndented(indent string) string {
 %s  Results:        %v
 %s  Evidence:       %v
 %s  Proposer:       %v
-%s}#%v`,
+%s}`,
 		indent, h.Version,
 		indent, h.ChainID,
 		indent, h.Height,
@@ -509,7 +523,7 @@ func (h *Header) StringIndented(indent string) string {
 		indent, h.LastResultsHash,
 		indent, h.EvidenceHash,
 		indent, h.ProposerAddress,
-		indent, h.Hash(),
+		indent,
 	)
 }
 
@@ -587,9 +601,9 @@ const (
 const (
 	// Max size of commit without any commitSigs -> 82 for BlockID, 8 for Height, 4 for Round.
 	MaxCommitOverheadBytes int64 = 94
-	// Commit sig size is made up of 64 bytes for the signature, 20 bytes for the address,
-	// 1 byte for the flag and 14 bytes for the timestamp
-	MaxCommitSigBytes int64 = 109
+	// Commit sig size is made up of 128 bytes for the signature (bn254), 20 bytes for the
+	// address, 1 byte for the flag and 14 bytes for the timestamp
+	MaxCommitSigBytes int64 = 174
 )
 
 // CommitSig is a part of the Vote included in a Commit.
@@ -611,8 +625,8 @@ func NewCommitSigForBlock(signature []byte, valAddr Address, ts time.Time) Commi
 }
 
 func MaxCommitBytes(valCount int) int64 {
-	// From the repeated commit sig field
-	var protoEncodingOverhead int64 = 2
+	// From the repeated commit sig field: 1 byte for the tag, 2 for the length
+	var protoEncodingOverhead int64 = 3
 	return MaxCommitOverheadBytes + ((MaxCommitSigBytes + protoEncodingOverhead) * int64(valCount))
 }
 
@@ -765,8 +779,8 @@ func NewCommit(height int64, round int32, blockID BlockID, commitSigs []CommitSi
 // CommitToVoteSet constructs a VoteSet from the Commit and validator set.
 // Panics if signatures from the commit can't be added to the voteset.
 // Inverse of VoteSet.MakeCommit().
-func CommitToVoteSet(chainID string, commit *Commit, vals *ValidatorSet) *VoteSet {
-	voteSet := NewVoteSet(chainID, commit.Height, commit.Round, cmtproto.PrecommitType, vals)
+func CommitToVoteSet(chainID string, encoding CanonicalEncoding, commit *Commit, vals *ValidatorSet) *VoteSet {
+	voteSet := NewVoteSet(chainID, encoding, commit.Height, commit.Round, cmtproto.PrecommitType, vals)
 	for idx, commitSig := range commit.Signatures {
 		if commitSig.Absent() {
 			continue // OK, some precommits can be missing.
@@ -805,9 +819,9 @@ func (commit *Commit) GetVote(valIdx int32) *Vote {
 // Panics if valIdx >= commit.Size().
 //
 // See VoteSignBytes
-func (commit *Commit) VoteSignBytes(chainID string, valIdx int32) []byte {
+func (commit *Commit) VoteSignBytes(chainID string, encoding CanonicalEncoding, valIdx int32) []byte {
 	v := commit.GetVote(valIdx).ToProto()
-	return VoteSignBytes(chainID, v)
+	return VoteSignBytes(chainID, encoding, v)
 }
 
 // Type returns the vote type of the commit, which is always VoteTypePrecommit
@@ -16,10 +16,11 @@ type BlockMeta struct {
 	NumTxs    int     `json:"num_txs"`
 }
 
-// NewBlockMeta returns a new BlockMeta.
-func NewBlockMeta(block *Block, blockParts *PartSet) *BlockMeta {
+// NewBlockMeta returns a new BlockMeta, the block being hashed with the given
+// canonical encoding.
+func NewBlockMeta(block *Block, blockParts *PartSet, encoding CanonicalEncoding) *BlockMeta {
 	return &BlockMeta{
-		BlockID:   BlockID{block.Hash(), blockParts.Header()},
+		BlockID:   BlockID{block.Hash(encoding), blockParts.Header()},
 		BlockSize: block.Size(),
 		Header:    block.Header,
 		NumTxs:    len(block.Data.Txs),
@@ -62,17 +63,18 @@ func BlockMetaFromProto(pb *cmtproto.BlockMeta) (*BlockMeta, error) {
 	bm.Header = h
 	bm.NumTxs = int(pb.NumTxs)
 
-	return bm, bm.ValidateBasic()
+	return bm, bm.BlockID.ValidateBasic()
 }
 
-// ValidateBasic performs basic validation.
-func (bm *BlockMeta) ValidateBasic() error {
+// ValidateBasic performs basic validation, the header being hashed with the
+// given canonical encoding.
+func (bm *BlockMeta) ValidateBasic(encoding CanonicalEncoding) error {
 	if err := bm.BlockID.ValidateBasic(); err != nil {
 		return err
 	}
-	if !bytes.Equal(bm.BlockID.Hash, bm.Header.Hash()) {
+	if hash := bm.Header.Hash(encoding); !bytes.Equal(bm.BlockID.Hash, hash) {
 		return fmt.Errorf("expected BlockID#Hash and Header#Hash to be the same, got %X != %X",
-			bm.BlockID.Hash, bm.Header.Hash())
+			bm.BlockID.Hash, hash)
 	}
 	return nil
 }
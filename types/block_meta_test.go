@@ -11,7 +11,7 @@ import (
 
 func TestBlockMeta_ToProto(t *testing.T) {
 	h := makeRandHeader()
-	bi := BlockID{Hash: h.Hash(), PartSetHeader: PartSetHeader{Total: 123, Hash: cmtrand.Bytes(tmhash.Size)}}
+	bi := BlockID{Hash: h.Hash(CanonicalEncodingProto), PartSetHeader: PartSetHeader{Total: 123, Hash: cmtrand.Bytes(tmhash.Size)}}
 
 	bm := &BlockMeta{
 		BlockID:   bi,
@@ -48,7 +48,7 @@ func TestBlockMeta_ToProto(t *testing.T) {
 
 func TestBlockMeta_ValidateBasic(t *testing.T) {
 	h := makeRandHeader()
-	bi := BlockID{Hash: h.Hash(), PartSetHeader: PartSetHeader{Total: 123, Hash: cmtrand.Bytes(tmhash.Size)}}
+	bi := BlockID{Hash: h.Hash(CanonicalEncodingProto), PartSetHeader: PartSetHeader{Total: 123, Hash: cmtrand.Bytes(tmhash.Size)}}
 	bi2 := BlockID{Hash: cmtrand.Bytes(tmhash.Size),
 		PartSetHeader: PartSetHeader{Total: 123, Hash: cmtrand.Bytes(tmhash.Size)}}
 	bi3 := BlockID{Hash: []byte("incorrect hash"),
@@ -87,7 +87,7 @@ func TestBlockMeta_ValidateBasic(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.bm.ValidateBasic(); (err != nil) != tt.wantErr {
+			if err := tt.bm.ValidateBasic(CanonicalEncodingProto); (err != nil) != tt.wantErr {
 				t.Errorf("BlockMeta.ValidateBasic() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
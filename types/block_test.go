@@ -107,8 +107,8 @@ func TestBlockValidateBasic(t *testing.T) {
 }
 
 func TestBlockHash(t *testing.T) {
-	assert.Nil(t, (*Block)(nil).Hash())
-	assert.Nil(t, MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, nil).Hash())
+	assert.Nil(t, (*Block)(nil).Hash(CanonicalEncodingProto))
+	assert.Nil(t, MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, nil).Hash(CanonicalEncodingProto))
 }
 
 func TestBlockMakePartSet(t *testing.T) {
@@ -146,7 +146,7 @@ func TestBlockMakePartSetWithEvidence(t *testing.T) {
 }
 
 func TestBlockHashesTo(t *testing.T) {
-	assert.False(t, (*Block)(nil).HashesTo(nil))
+	assert.False(t, (*Block)(nil).HashesTo(nil, CanonicalEncodingProto))
 
 	lastID := makeBlockIDRandom()
 	h := int64(3)
@@ -159,10 +159,10 @@ func TestBlockHashesTo(t *testing.T) {
 	evList := []Evidence{ev}
 
 	block := MakeBlock(h, []Tx{Tx("Hello World")}, commit, evList)
-	block.ValidatorsHash = valSet.Hash()
-	assert.False(t, block.HashesTo([]byte{}))
-	assert.False(t, block.HashesTo([]byte("something else")))
-	assert.True(t, block.HashesTo(block.Hash()))
+	block.ValidatorsHash = valSet.Hash(CanonicalEncodingProto)
+	assert.False(t, block.HashesTo([]byte{}, CanonicalEncodingProto))
+	assert.False(t, block.HashesTo([]byte("something else"), CanonicalEncodingProto))
+	assert.True(t, block.HashesTo(block.Hash(CanonicalEncodingProto), CanonicalEncodingProto))
 }
 
 func TestBlockSize(t *testing.T) {
@@ -217,8 +217,8 @@ var emptyBytes = []byte{0xe3, 0xb0, 0xc4, 0x42, 0x98, 0xfc, 0x1c, 0x14, 0x9a, 0x
 	0x78, 0x52, 0xb8, 0x55}
 
 func TestNilHeaderHashDoesntCrash(t *testing.T) {
-	assert.Equal(t, nilBytes, []byte((*Header)(nil).Hash()))
-	assert.Equal(t, nilBytes, []byte((new(Header)).Hash()))
+	assert.Equal(t, nilBytes, []byte((*Header)(nil).Hash(CanonicalEncodingProto)))
+	assert.Equal(t, nilBytes, []byte((new(Header)).Hash(CanonicalEncodingProto)))
 }
 
 func TestNilDataHashDoesntCrash(t *testing.T) {
@@ -356,7 +356,7 @@ func TestHeaderHash(t *testing.T) {
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.desc, func(t *testing.T) {
-			assert.Equal(t, tc.expectHash, tc.header.Hash())
+			assert.Equal(t, tc.expectHash, tc.header.Hash(CanonicalEncodingProto))
 
 			// We also make sure that all fields are hashed in struct order, and that all
 			// fields in the test struct are non-zero.
@@ -391,7 +391,7 @@ func TestHeaderHash(t *testing.T) {
 					}
 				}
 				assert.Equal(t,
-					bytes.HexBytes(merkle.HashFromByteSlices(byteSlices)), tc.header.Hash())
+					bytes.HexBytes(merkle.HashFromByteSlices(byteSlices)), tc.header.Hash(CanonicalEncodingProto))
 			}
 		})
 	}
@@ -463,11 +463,11 @@ func TestBlockMaxDataBytes(t *testing.T) {
 	}{
 		0: {-10, 1, 0, true, 0},
 		1: {10, 1, 0, true, 0},
-		2: {841, 1, 0, true, 0},
-		3: {842, 1, 0, false, 0},
-		4: {843, 1, 0, false, 1},
-		5: {954, 2, 0, false, 1},
-		6: {1053, 2, 100, false, 0},
+		2: {907, 1, 0, true, 0},
+		3: {908, 1, 0, false, 0},
+		4: {909, 1, 0, false, 1},
+		5: {1086, 2, 0, false, 1},
+		6: {1185, 2, 100, false, 0},
 	}
 
 	for i, tc := range testCases {
@@ -494,9 +494,9 @@ func TestBlockMaxDataBytesNoEvidence(t *testing.T) {
 	}{
 		0: {-10, 1, true, 0},
 		1: {10, 1, true, 0},
-		2: {841, 1, true, 0},
-		3: {842, 1, false, 0},
-		4: {843, 1, false, 1},
+		2: {907, 1, true, 0},
+		3: {908, 1, false, 0},
+		4: {909, 1, false, 1},
 	}
 
 	for i, tc := range testCases {
@@ -523,7 +523,7 @@ func TestCommitToVoteSet(t *testing.T) {
 	assert.NoError(t, err)
 
 	chainID := voteSet.ChainID()
-	voteSet2 := CommitToVoteSet(chainID, commit, valSet)
+	voteSet2 := CommitToVoteSet(chainID, CanonicalEncodingProto, commit, valSet)
 
 	for i := int32(0); int(i) < len(vals); i++ {
 		vote1 := voteSet.GetByIndex(i)
@@ -589,7 +589,7 @@ func TestCommitToVoteSetWithVotesForNilBlock(t *testing.T) {
 		if tc.valid {
 			commit := voteSet.MakeCommit() // panics without > 2/3 valid votes
 			assert.NotNil(t, commit)
-			err := valSet.VerifyCommit(voteSet.ChainID(), blockID, height-1, commit)
+			err := valSet.VerifyCommit(voteSet.ChainID(), CanonicalEncodingProto, blockID, height-1, commit)
 			assert.Nil(t, err)
 		} else {
 			assert.Panics(t, func() { voteSet.MakeCommit() })
@@ -0,0 +1,194 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+// CanonicalEncoding is the canonical encoding of the votes, headers and
+// validator sets, which are signed or hashed in consensus. It is selected by
+// the Encoding consensus params, fixed by the genesis of the chain, and is
+// passed along with the chain ID to whatever signs, hashes or verifies them.
+type CanonicalEncoding string
+
+const (
+	// CanonicalEncodingProto is the (default) protobuf encoding.
+	CanonicalEncodingProto CanonicalEncoding = "proto"
+	// CanonicalEncodingFixedWidth is a circuit-friendly encoding, made of
+	// fixed-width, big-endian fields, without any varint, so that it can be
+	// parsed and hashed cheaply in a zk circuit. It is only available to bn254
+	// validators.
+	CanonicalEncodingFixedWidth CanonicalEncoding = "fixed-width"
+)
+
+// Sizes of the fields of the fixed-width encoding.
+const (
+	fixedWidthChainIDSize   = 1 + MaxChainIDLen // length-prefixed, zero-padded
+	fixedWidthTimeSize      = 8 + 4             // seconds and nanoseconds
+	fixedWidthBlockIDSize   = tmhash.Size + 4 + tmhash.Size
+	fixedWidthVoteSize      = 1 + 8 + 8 + fixedWidthBlockIDSize + fixedWidthChainIDSize + fixedWidthTimeSize
+	fixedWidthHeaderSize    = 8 + 8 + fixedWidthChainIDSize + 8 + fixedWidthTimeSize + fixedWidthBlockIDSize + 8*tmhash.Size + crypto.AddressSize //nolint:lll
+	fixedWidthValidatorSize = bn254.PubKeySize + 8
+)
+
+// ValidateBasic returns an error if the encoding is unknown.
+func (encoding CanonicalEncoding) ValidateBasic() error {
+	switch encoding {
+	case CanonicalEncodingProto, CanonicalEncodingFixedWidth:
+		return nil
+	default:
+		return fmt.Errorf("unknown canonical encoding %q", string(encoding))
+	}
+}
+
+// FixedWidthVoteSignBytes returns the fixed-width encoding of the canonicalized
+// Vote, for signing, i.e. the concatenation of
+//
+//	type            uint8
+//	height          int64
+//	round           int64
+//	block_id        [32]byte hash, uint32 part set total, [32]byte part set hash
+//	chain_id        uint8 length, [50]byte zero-padded
+//	timestamp       int64 seconds, uint32 nanoseconds
+//
+// all of them big-endian, nil hashes being zeros. The timestamp, which is the
+// only field differing between the votes of a commit, comes last, so that a
+// circuit can hash the common prefix only once. Panics if the chain ID is too
+// long.
+func FixedWidthVoteSignBytes(chainID string, vote *cmtproto.Vote) []byte {
+	bz := make([]byte, 0, fixedWidthVoteSize)
+	bz = append(bz, byte(vote.Type))
+	bz = binary.BigEndian.AppendUint64(bz, uint64(vote.Height))
+	bz = binary.BigEndian.AppendUint64(bz, uint64(vote.Round))
+	bz, err := appendFixedWidthBlockID(bz, vote.BlockID)
+	if err != nil {
+		panic(err)
+	}
+	if bz, err = appendFixedWidthChainID(bz, chainID); err != nil {
+		panic(err)
+	}
+	return appendFixedWidthTime(bz, vote.Timestamp)
+}
+
+// FixedWidthBytes returns the fixed-width encoding of the canonicalized block
+// ID, as found in the sign bytes of the votes and in the headers, i.e. the
+// concatenation of
+//
+//	hash                [32]byte
+//	part_set_header     uint32 total, [32]byte hash
+//
+// big-endian, the nil block ID being all zeros. It returns an error if any of
+// the hashes is longer than 32 bytes.
+func (blockID BlockID) FixedWidthBytes() ([]byte, error) {
+	return appendFixedWidthBlockID(make([]byte, 0, fixedWidthBlockIDSize), blockID.ToProto())
+}
+
+// FixedWidthBytes returns the fixed-width encoding of the header, i.e. the
+// concatenation of its fields, in order, as
+//
+//	version             uint64 block, uint64 app
+//	chain_id            uint8 length, [50]byte zero-padded
+//	height              int64
+//	time                int64 seconds, uint32 nanoseconds
+//	last_block_id       [32]byte hash, uint32 part set total, [32]byte part set hash
+//	last_commit_hash, data_hash, validators_hash, next_validators_hash,
+//	consensus_hash, app_hash, last_results_hash, evidence_hash
+//	                    [32]byte
+//	proposer_address    [20]byte
+//
+// all of them big-endian, empty hashes being zeros. The app hash, whose size
+// is chosen by the application, is encoded as its SHA-256 hash unless it is
+// empty or 32 bytes long.
+func (h *Header) FixedWidthBytes() ([]byte, error) {
+	bz := make([]byte, 0, fixedWidthHeaderSize)
+	bz = binary.BigEndian.AppendUint64(bz, h.Version.Block)
+	bz = binary.BigEndian.AppendUint64(bz, h.Version.App)
+	bz, err := appendFixedWidthChainID(bz, h.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	bz = binary.BigEndian.AppendUint64(bz, uint64(h.Height))
+	bz = appendFixedWidthTime(bz, h.Time)
+	if bz, err = appendFixedWidthBlockID(bz, h.LastBlockID.ToProto()); err != nil {
+		return nil, fmt.Errorf("last block ID: %w", err)
+	}
+
+	appHash := h.AppHash
+	if len(appHash) != 0 && len(appHash) != tmhash.Size {
+		appHash = tmhash.Sum(appHash)
+	}
+	fields := []struct {
+		name string
+		bz   []byte
+		size int
+	}{
+		{"last commit hash", h.LastCommitHash, tmhash.Size},
+		{"data hash", h.DataHash, tmhash.Size},
+		{"validators hash", h.ValidatorsHash, tmhash.Size},
+		{"next validators hash", h.NextValidatorsHash, tmhash.Size},
+		{"consensus hash", h.ConsensusHash, tmhash.Size},
+		{"app hash", appHash, tmhash.Size},
+		{"last results hash", h.LastResultsHash, tmhash.Size},
+		{"evidence hash", h.EvidenceHash, tmhash.Size},
+		{"proposer address", h.ProposerAddress, crypto.AddressSize},
+	}
+	for _, f := range fields {
+		if bz, err = appendFixedWidth(bz, f.bz, f.size); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+	}
+	return bz, nil
+}
+
+// FixedWidthBytes returns the fixed-width encoding of the validator set, i.e.
+// the concatenation of the compressed bn254 public key and of the (big-endian,
+// uint64) voting power of each validator, in the order of the set.
+//
+// It returns an error if any of the validators doesn't have a bn254 key.
+func (vals *ValidatorSet) FixedWidthBytes() ([]byte, error) {
+	bz := make([]byte, 0, len(vals.Validators)*fixedWidthValidatorSize)
+	for i, val := range vals.Validators {
+		pubKey, ok := val.PubKey.(bn254.PubKey)
+		if !ok {
+			return nil, fmt.Errorf("validator #%d has a %s key, expected %s", i, val.PubKey.Type(), bn254.KeyType)
+		}
+		bz = append(bz, pubKey.Bytes()...)
+		bz = binary.BigEndian.AppendUint64(bz, uint64(val.VotingPower))
+	}
+	return bz, nil
+}
+
+func appendFixedWidth(dst, bz []byte, size int) ([]byte, error) {
+	if len(bz) > size {
+		return nil, fmt.Errorf("expected at most %d bytes, got %d", size, len(bz))
+	}
+	dst = append(dst, bz...)
+	return append(dst, make([]byte, size-len(bz))...), nil
+}
+
+func appendFixedWidthChainID(dst []byte, chainID string) ([]byte, error) {
+	if len(chainID) > MaxChainIDLen {
+		return nil, fmt.Errorf("chainID is too long; got: %d, max: %d", len(chainID), MaxChainIDLen)
+	}
+	return appendFixedWidth(append(dst, byte(len(chainID))), []byte(chainID), MaxChainIDLen)
+}
+
+func appendFixedWidthBlockID(dst []byte, bid cmtproto.BlockID) ([]byte, error) {
+	dst, err := appendFixedWidth(dst, bid.Hash, tmhash.Size)
+	if err != nil {
+		return nil, err
+	}
+	dst = binary.BigEndian.AppendUint32(dst, bid.PartSetHeader.Total)
+	return appendFixedWidth(dst, bid.PartSetHeader.Hash, tmhash.Size)
+}
+
+func appendFixedWidthTime(dst []byte, t time.Time) []byte {
+	dst = binary.BigEndian.AppendUint64(dst, uint64(t.Unix()))
+	return binary.BigEndian.AppendUint32(dst, uint32(t.Nanosecond()))
+}
@@ -0,0 +1,318 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+)
+
+func TestCanonicalEncodingValidateBasic(t *testing.T) {
+	assert.NoError(t, CanonicalEncodingProto.ValidateBasic())
+	assert.NoError(t, CanonicalEncodingFixedWidth.ValidateBasic())
+	assert.Error(t, CanonicalEncoding("amino").ValidateBasic())
+	assert.Error(t, CanonicalEncoding("").ValidateBasic())
+}
+
+func TestFixedWidthVoteSignBytes(t *testing.T) {
+	vote := examplePrecommit()
+	bz := FixedWidthVoteSignBytes("test_chain_id", vote.ToProto())
+	require.Len(t, bz, fixedWidthVoteSize)
+
+	assert.Equal(t, byte(2), bz[0])
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39}, bz[1:9]) // height 12345
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 2}, bz[9:17])      // round 2
+	assert.Equal(t, []byte(vote.BlockID.Hash), bz[17:49])          // block hash
+	assert.Equal(t, []byte{0, 0x0f, 0x42, 0x40}, bz[49:53])        // part set total
+	assert.Equal(t, []byte(vote.BlockID.PartSetHeader.Hash), bz[53:85])
+	assert.Equal(t, byte(len("test_chain_id")), bz[85])
+	assert.Equal(t, "test_chain_id", strings.TrimRight(string(bz[86:136]), "\x00"))
+	assert.Equal(t, []byte{0, 0, 0, 0, 0x5a, 0x40, 0x69, 0xb1}, bz[136:144]) // seconds
+	assert.Equal(t, []byte{0x0d, 0xf2, 0x8e, 0x80}, bz[144:148])             // 234ms
+
+	// the votes of a commit only differ by their suffix
+	other := examplePrecommit()
+	other.Timestamp = other.Timestamp.Add(time.Second)
+	otherBz := FixedWidthVoteSignBytes("test_chain_id", other.ToProto())
+	assert.Equal(t, bz[:fixedWidthVoteSize-fixedWidthTimeSize], otherBz[:fixedWidthVoteSize-fixedWidthTimeSize])
+	assert.NotEqual(t, bz, otherBz)
+
+	// nil votes have a zero block ID
+	nilVote := examplePrecommit()
+	nilVote.BlockID = BlockID{}
+	nilBz := FixedWidthVoteSignBytes("test_chain_id", nilVote.ToProto())
+	require.Len(t, nilBz, fixedWidthVoteSize)
+	assert.Equal(t, make([]byte, fixedWidthBlockIDSize), nilBz[17:85])
+
+	assert.Panics(t, func() {
+		FixedWidthVoteSignBytes(strings.Repeat("a", MaxChainIDLen+1), vote.ToProto())
+	})
+}
+
+func TestVoteSignBytesFixedWidth(t *testing.T) {
+	privVal := NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	vote := examplePrecommit()
+	vote.ValidatorAddress = pubKey.Address()
+
+	protoSignBytes := VoteSignBytes("test_chain_id", CanonicalEncodingProto, vote.ToProto())
+	signBytes := VoteSignBytes("test_chain_id", CanonicalEncodingFixedWidth, vote.ToProto())
+	assert.Equal(t, FixedWidthVoteSignBytes("test_chain_id", vote.ToProto()), signBytes)
+	assert.NotEqual(t, protoSignBytes, signBytes)
+
+	v := vote.ToProto()
+	require.NoError(t, privVal.SignVote("test_chain_id", CanonicalEncodingFixedWidth, v))
+	vote.Signature = v.Signature
+	require.NoError(t, vote.Verify("test_chain_id", CanonicalEncodingFixedWidth, pubKey))
+	assert.True(t, pubKey.VerifySignature(signBytes, vote.Signature))
+	// the signature doesn't verify with another encoding
+	assert.Error(t, vote.Verify("test_chain_id", CanonicalEncodingProto, pubKey))
+}
+
+func TestHeaderFixedWidthBytes(t *testing.T) {
+	h := &Header{
+		Version:            cmtversion.Consensus{Block: 1, App: 2},
+		ChainID:            "chainId",
+		Height:             3,
+		Time:               time.Date(2019, 10, 13, 16, 14, 44, 0, time.UTC),
+		LastBlockID:        makeBlockID(make([]byte, tmhash.Size), 6, make([]byte, tmhash.Size)),
+		LastCommitHash:     tmhash.Sum([]byte("last_commit_hash")),
+		DataHash:           tmhash.Sum([]byte("data_hash")),
+		ValidatorsHash:     tmhash.Sum([]byte("validators_hash")),
+		NextValidatorsHash: tmhash.Sum([]byte("next_validators_hash")),
+		ConsensusHash:      tmhash.Sum([]byte("consensus_hash")),
+		AppHash:            tmhash.Sum([]byte("app_hash")),
+		LastResultsHash:    tmhash.Sum([]byte("last_results_hash")),
+		EvidenceHash:       tmhash.Sum([]byte("evidence_hash")),
+		ProposerAddress:    crypto.AddressHash([]byte("proposer_address")),
+	}
+	bz, err := h.FixedWidthBytes()
+	require.NoError(t, err)
+	require.Len(t, bz, fixedWidthHeaderSize)
+	assert.True(t, bytes.HasSuffix(bz, h.ProposerAddress))
+
+	hash := h.Hash(CanonicalEncodingFixedWidth)
+	assert.Equal(t, tmhash.Sum(bz), []byte(hash))
+	assert.NotEqual(t, h.Hash(CanonicalEncodingProto), hash)
+
+	// the app hashes which aren't 32 bytes long are hashed
+	h.AppHash = []byte("short_app_hash")
+	shortBz, err := h.FixedWidthBytes()
+	require.NoError(t, err)
+	require.Len(t, shortBz, fixedWidthHeaderSize)
+	assert.NotEqual(t, bz, shortBz)
+	appHashOffset := fixedWidthHeaderSize - crypto.AddressSize - 3*tmhash.Size
+	assert.Equal(t, tmhash.Sum(h.AppHash), shortBz[appHashOffset:appHashOffset+tmhash.Size])
+
+	// and the empty ones are zeros
+	h.AppHash = nil
+	emptyBz, err := h.FixedWidthBytes()
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, tmhash.Size), emptyBz[appHashOffset:appHashOffset+tmhash.Size])
+
+	h.EvidenceHash = make([]byte, tmhash.Size+1)
+	_, err = h.FixedWidthBytes()
+	require.Error(t, err)
+	assert.Nil(t, h.Hash(CanonicalEncodingFixedWidth))
+}
+
+func TestValidatorSetFixedWidthBytes(t *testing.T) {
+	vals, _ := RandBn254ValidatorSet(3, 10)
+	bz, err := vals.FixedWidthBytes()
+	require.NoError(t, err)
+	require.Len(t, bz, 3*fixedWidthValidatorSize)
+	for i, val := range vals.Validators {
+		offset := i * fixedWidthValidatorSize
+		assert.Equal(t, val.PubKey.Bytes(), bz[offset:offset+len(val.PubKey.Bytes())])
+		assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 10}, bz[offset+fixedWidthValidatorSize-8:offset+fixedWidthValidatorSize])
+	}
+
+	hash := vals.Hash(CanonicalEncodingFixedWidth)
+	assert.Equal(t, tmhash.Sum(bz), hash)
+	assert.NotEqual(t, vals.Hash(CanonicalEncodingProto), hash)
+
+	mixed := NewValidatorSet(append(vals.Copy().Validators, NewValidator(ed25519.GenPrivKey().PubKey(), 10)))
+	_, err = mixed.FixedWidthBytes()
+	require.Error(t, err)
+	assert.Nil(t, mixed.Hash(CanonicalEncodingFixedWidth))
+}
+
+// fixedWidthVector returns the concatenation of the hex encoded fields.
+func fixedWidthVector(fields ...string) []byte {
+	bz, err := hex.DecodeString(strings.Join(fields, ""))
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func TestFixedWidthVoteSignBytesTestVectors(t *testing.T) {
+	var (
+		zeroBlockID   = strings.Repeat("00", fixedWidthBlockIDSize)
+		emptyChainID  = "00" + strings.Repeat("00", MaxChainIDLen)
+		testChainID   = "0d" + hex.EncodeToString([]byte("test_chain_id")) + strings.Repeat("00", MaxChainIDLen-13)
+		zeroTimestamp = "fffffff1886e0900" + "00000000" // 0001-01-01T00:00:00Z
+		stamp         = time.Date(2017, 12, 25, 3, 0, 1, 234000000, time.UTC)
+		blockID       = BlockID{
+			Hash:          bytes.Repeat([]byte{0x01}, tmhash.Size),
+			PartSetHeader: PartSetHeader{Total: math.MaxUint32, Hash: bytes.Repeat([]byte{0x02}, tmhash.Size)},
+		}
+	)
+
+	tests := []struct {
+		chainID string
+		vote    *Vote
+		want    []byte
+	}{
+		0: {
+			"", &Vote{},
+			fixedWidthVector(
+				"00",               // type
+				"0000000000000000", // height
+				"0000000000000000", // round
+				zeroBlockID,
+				emptyChainID,
+				zeroTimestamp,
+			),
+		},
+		1: {
+			"", &Vote{Height: 1, Round: 1, Type: cmtproto.PrecommitType},
+			fixedWidthVector("02", "0000000000000001", "0000000000000001", zeroBlockID, emptyChainID, zeroTimestamp),
+		},
+		2: {
+			"", &Vote{Height: 1, Round: 1, Type: cmtproto.PrevoteType},
+			fixedWidthVector("01", "0000000000000001", "0000000000000001", zeroBlockID, emptyChainID, zeroTimestamp),
+		},
+		// containing a chain ID and a timestamp
+		3: {
+			"test_chain_id", &Vote{Height: 1, Round: 1, Type: cmtproto.PrecommitType, Timestamp: stamp},
+			fixedWidthVector(
+				"02",
+				"0000000000000001",
+				"0000000000000001",
+				zeroBlockID,
+				testChainID,
+				"000000005a4069b1", // seconds
+				"0df28e80",         // nanoseconds
+			),
+		},
+		// containing a block ID
+		4: {
+			"test_chain_id", &Vote{Height: 1, Round: 1, Type: cmtproto.PrecommitType, BlockID: blockID, Timestamp: stamp},
+			fixedWidthVector(
+				"02",
+				"0000000000000001",
+				"0000000000000001",
+				strings.Repeat("01", tmhash.Size), // hash
+				"ffffffff",                        // part set total
+				strings.Repeat("02", tmhash.Size), // part set hash
+				testChainID,
+				"000000005a4069b1",
+				"0df28e80",
+			),
+		},
+		// containing a block ID without part set header
+		5: {
+			"", &Vote{Type: cmtproto.PrevoteType, BlockID: BlockID{Hash: blockID.Hash}},
+			fixedWidthVector(
+				"01",
+				"0000000000000000",
+				"0000000000000000",
+				strings.Repeat("01", tmhash.Size),
+				"00000000",
+				strings.Repeat("00", tmhash.Size),
+				emptyChainID,
+				zeroTimestamp,
+			),
+		},
+		// with the largest height and round
+		6: {
+			"", &Vote{Height: math.MaxInt64, Round: math.MaxInt32, Type: cmtproto.PrecommitType},
+			fixedWidthVector("02", "7fffffffffffffff", "000000007fffffff", zeroBlockID, emptyChainID, zeroTimestamp),
+		},
+		// with the longest chain ID
+		7: {
+			strings.Repeat("a", MaxChainIDLen), &Vote{Type: cmtproto.PrecommitType},
+			fixedWidthVector(
+				"02",
+				"0000000000000000",
+				"0000000000000000",
+				zeroBlockID,
+				"32"+strings.Repeat("61", MaxChainIDLen),
+				zeroTimestamp,
+			),
+		},
+		// with a timestamp before 1970
+		8: {
+			"", &Vote{Type: cmtproto.PrecommitType, Timestamp: time.Unix(-1, 500).UTC()},
+			fixedWidthVector(
+				"02",
+				"0000000000000000",
+				"0000000000000000",
+				zeroBlockID,
+				emptyChainID,
+				"ffffffffffffffff", // -1 second
+				"000001f4",         // 500 nanoseconds
+			),
+		},
+	}
+	for i, tc := range tests {
+		v := tc.vote.ToProto()
+		got := FixedWidthVoteSignBytes(tc.chainID, v)
+		assert.Len(t, got, fixedWidthVoteSize, "test case #%v", i)
+		assert.Equal(t, tc.want, got, "test case #%v: got unexpected sign bytes for Vote.", i)
+	}
+}
+
+func TestBlockIDFixedWidthBytesTestVectors(t *testing.T) {
+	tests := []struct {
+		blockID BlockID
+		want    []byte
+		wantErr bool
+	}{
+		0: {BlockID{}, fixedWidthVector(strings.Repeat("00", fixedWidthBlockIDSize)), false},
+		1: {
+			BlockID{
+				Hash:          bytes.Repeat([]byte{0xab}, tmhash.Size),
+				PartSetHeader: PartSetHeader{Total: 1, Hash: bytes.Repeat([]byte{0xcd}, tmhash.Size)},
+			},
+			fixedWidthVector(strings.Repeat("ab", tmhash.Size), "00000001", strings.Repeat("cd", tmhash.Size)),
+			false,
+		},
+		// the hashes shorter than 32 bytes are zero-padded
+		2: {
+			BlockID{Hash: []byte{0xab}, PartSetHeader: PartSetHeader{Total: 0x01020304}},
+			fixedWidthVector("ab", strings.Repeat("00", tmhash.Size-1), "01020304", strings.Repeat("00", tmhash.Size)),
+			false,
+		},
+		3: {BlockID{Hash: make([]byte, tmhash.Size+1)}, nil, true},
+		4: {BlockID{PartSetHeader: PartSetHeader{Hash: make([]byte, tmhash.Size+1)}}, nil, true},
+	}
+	for i, tc := range tests {
+		got, err := tc.blockID.FixedWidthBytes()
+		if tc.wantErr {
+			assert.Error(t, err, "test case #%v", i)
+			continue
+		}
+		require.NoError(t, err, "test case #%v", i)
+		assert.Equal(t, tc.want, got, "test case #%v", i)
+	}
+
+	// the block IDs of the votes are encoded the same way
+	vote := examplePrecommit()
+	bz, err := vote.BlockID.FixedWidthBytes()
+	require.NoError(t, err)
+	assert.Equal(t, bz, FixedWidthVoteSignBytes("test_chain_id", vote.ToProto())[17:17+fixedWidthBlockIDSize])
+}
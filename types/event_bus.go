@@ -43,7 +43,13 @@ func NewEventBus() *EventBus {
 // NewEventBusWithBufferCapacity returns a new event bus with the given buffer capacity.
 func NewEventBusWithBufferCapacity(cap int) *EventBus {
 	// capacity could be exposed later if needed
-	pubsub := cmtpubsub.NewServer(cmtpubsub.BufferCapacity(cap))
+	return NewEventBusWithOptions(cmtpubsub.BufferCapacity(cap))
+}
+
+// NewEventBusWithOptions returns a new event bus, whose pubsub server is
+// created with the given options.
+func NewEventBusWithOptions(options ...cmtpubsub.Option) *EventBus {
+	pubsub := cmtpubsub.NewServer(options...)
 	b := &EventBus{pubsub: pubsub}
 	b.BaseService = *service.NewBaseService(nil, "EventBus", b)
 	return b
@@ -81,6 +87,33 @@ func (b *EventBus) Subscribe(
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeDropping is Subscribe, except that the events are dropped, rather
+// than the subscription canceled, when the subscriber isn't pulling them fast
+// enough. See pubsub.Server.SubscribeDropping.
+func (b *EventBus) SubscribeDropping(
+	ctx context.Context,
+	subscriber string,
+	query cmtpubsub.Query,
+	outCapacity int,
+	onDrop func(),
+) (Subscription, error) {
+	return b.pubsub.SubscribeDropping(ctx, subscriber, query, outCapacity, onDrop)
+}
+
+// SubscribeWithPolicy is Subscribe, except that policy decides what happens
+// to the events when the subscriber isn't pulling them fast enough. See
+// pubsub.Server.SubscribeWithPolicy.
+func (b *EventBus) SubscribeWithPolicy(
+	ctx context.Context,
+	subscriber string,
+	query cmtpubsub.Query,
+	outCapacity int,
+	policy cmtpubsub.OverflowPolicy,
+	onDrop func(),
+) (Subscription, error) {
+	return b.pubsub.SubscribeWithPolicy(ctx, subscriber, query, outCapacity, policy, onDrop)
+}
+
 // This method can be used for a local consensus explorer and synchronous
 // testing. Do not use for for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(
@@ -227,6 +260,10 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+func (b *EventBus) PublishEventShutdown(data EventDataShutdown) error {
+	return b.Publish(EventShutdown, data)
+}
+
 // -----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -306,3 +343,7 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventShutdown(data EventDataShutdown) error {
+	return nil
+}
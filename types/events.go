@@ -36,6 +36,11 @@ const (
 	EventUnlock           = "Unlock"
 	EventValidBlock       = "ValidBlock"
 	EventVote             = "Vote"
+
+	// Node level events.
+	// Shutdown is the last event published by a node draining on shutdown,
+	// before the subscriptions are canceled.
+	EventShutdown = "Shutdown"
 )
 
 // ENCODING / DECODING
@@ -56,6 +61,7 @@ func init() {
 	cmtjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	cmtjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
 	cmtjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
+	cmtjson.RegisterType(EventDataShutdown{}, "tendermint/event/Shutdown")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -125,6 +131,10 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+type EventDataShutdown struct {
+	Reason string `json:"reason"`
+}
+
 // PUBSUB
 
 const (
@@ -152,6 +162,7 @@ var (
 	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
 	EventQueryPolka               = QueryForEvent(EventPolka)
 	EventQueryRelock              = QueryForEvent(EventRelock)
+	EventQueryShutdown            = QueryForEvent(EventShutdown)
 	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
 	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
 	EventQueryTx                  = QueryForEvent(EventTx)
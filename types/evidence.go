@@ -10,6 +10,7 @@ import (
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
@@ -185,6 +186,65 @@ func DuplicateVoteEvidenceFromProto(pb *cmtproto.DuplicateVoteEvidence) (*Duplic
 	return dve, dve.ValidateBasic()
 }
 
+// Bn254DuplicateVoteEvidence is the DuplicateVoteEvidence of a bn254
+// validator. The commits of bn254 validators are aggregated, from which the
+// individual votes can't be recovered: their equivocations are only provable
+// from the conflicting votes captured during consensus, before aggregation.
+// Both signatures are verified with a single pairing check.
+//
+// It has the bytes, and so the hash, of the DuplicateVoteEvidence of the same
+// votes, so that the same equivocation can't be committed twice.
+type Bn254DuplicateVoteEvidence struct {
+	DuplicateVoteEvidence
+}
+
+var _ Evidence = &Bn254DuplicateVoteEvidence{}
+
+// NewBn254DuplicateVoteEvidence creates Bn254DuplicateVoteEvidence as
+// NewDuplicateVoteEvidence does. An error is returned if the voter is not a
+// bn254 validator.
+func NewBn254DuplicateVoteEvidence(vote1, vote2 *Vote, blockTime time.Time, valSet *ValidatorSet,
+) (*Bn254DuplicateVoteEvidence, error) {
+	dve, err := NewDuplicateVoteEvidence(vote1, vote2, blockTime, valSet)
+	if err != nil {
+		return nil, err
+	}
+	if _, val := valSet.GetByAddress(vote1.ValidatorAddress); val.PubKey.Type() != bn254.KeyType {
+		return nil, fmt.Errorf("validator %s is not a bn254 validator", vote1.ValidatorAddress)
+	}
+	return &Bn254DuplicateVoteEvidence{*dve}, nil
+}
+
+// String returns a string representation of the evidence.
+func (bdve *Bn254DuplicateVoteEvidence) String() string {
+	return fmt.Sprintf("Bn254DuplicateVoteEvidence{VoteA: %v, VoteB: %v}", bdve.VoteA, bdve.VoteB)
+}
+
+// ValidateBasic performs basic validation.
+func (bdve *Bn254DuplicateVoteEvidence) ValidateBasic() error {
+	if bdve == nil {
+		return errors.New("empty bn254 duplicate vote evidence")
+	}
+	if err := bdve.DuplicateVoteEvidence.ValidateBasic(); err != nil {
+		return err
+	}
+	if len(bdve.VoteA.Signature) != bn254.SignatureSize || len(bdve.VoteB.Signature) != bn254.SignatureSize {
+		return errors.New("the votes must have bn254 signatures")
+	}
+	return nil
+}
+
+// Bn254DuplicateVoteEvidenceFromProto decodes protobuf into
+// Bn254DuplicateVoteEvidence.
+func Bn254DuplicateVoteEvidenceFromProto(pb *cmtproto.DuplicateVoteEvidence) (*Bn254DuplicateVoteEvidence, error) {
+	dve, err := DuplicateVoteEvidenceFromProto(pb)
+	if err != nil {
+		return nil, err
+	}
+	bdve := &Bn254DuplicateVoteEvidence{*dve}
+	return bdve, bdve.ValidateBasic()
+}
+
 //------------------------------------ LIGHT EVIDENCE --------------------------------------
 
 // LightClientAttackEvidence is a generalized evidence that captures all forms of known attacks on
@@ -195,6 +255,10 @@ func DuplicateVoteEvidenceFromProto(pb *cmtproto.DuplicateVoteEvidence) (*Duplic
 type LightClientAttackEvidence struct {
 	ConflictingBlock *LightBlock
 	CommonHeight     int64
+	// ConflictingAggregatedCommit is the commit of the conflicting block if
+	// it is aggregated, as given to light clients which only verify
+	// aggregated commits, in which case the conflicting block has no commit.
+	ConflictingAggregatedCommit *AggregatedCommit
 
 	// abci specific information
 	ByzantineValidators []*Validator // validators in the validator set that misbehaved in creating the conflicting block
@@ -240,8 +304,9 @@ func (l *LightClientAttackEvidence) GetByzantineValidators(commonVals *Validator
 	var validators []*Validator
 	// First check if the header is invalid. This means that it is a lunatic attack and therefore we take the
 	// validators who are in the commonVals and voted for the lunatic header
+	conflictingSigs := l.conflictingCommitSigs()
 	if l.ConflictingHeaderIsInvalid(trusted.Header) {
-		for _, commitSig := range l.ConflictingBlock.Commit.Signatures {
+		for _, commitSig := range conflictingSigs {
 			if !commitSig.ForBlock() {
 				continue
 			}
@@ -255,13 +320,13 @@ func (l *LightClientAttackEvidence) GetByzantineValidators(commonVals *Validator
 		}
 		sort.Sort(ValidatorsByVotingPower(validators))
 		return validators
-	} else if trusted.Commit.Round == l.ConflictingBlock.Commit.Round {
+	} else if trusted.Commit.Round == l.conflictingCommitRound() {
 		// This is an equivocation attack as both commits are in the same round. We then find the validators
 		// from the conflicting light block validator set that voted in both headers.
 		// Validator hashes are the same therefore the indexing order of validators are the same and thus we
 		// only need a single loop to find the validators that voted twice.
-		for i := 0; i < len(l.ConflictingBlock.Commit.Signatures); i++ {
-			sigA := l.ConflictingBlock.Commit.Signatures[i]
+		for i := 0; i < len(conflictingSigs); i++ {
+			sigA := conflictingSigs[i]
 			if sigA.Absent() {
 				continue
 			}
@@ -283,6 +348,35 @@ func (l *LightClientAttackEvidence) GetByzantineValidators(commonVals *Validator
 	return validators
 }
 
+// conflictingCommitSigs returns the signatures of the commit of the
+// conflicting block. The aggregated commits only tell which validators signed
+// for the block, from their bitmap: only those are returned, without their
+// signatures.
+func (l *LightClientAttackEvidence) conflictingCommitSigs() []CommitSig {
+	ac := l.ConflictingAggregatedCommit
+	if ac == nil {
+		return l.ConflictingBlock.Commit.Signatures
+	}
+	sigs := make([]CommitSig, len(l.ConflictingBlock.ValidatorSet.Validators))
+	for idx, val := range l.ConflictingBlock.ValidatorSet.Validators {
+		if ac.HasSigner(idx) {
+			sigs[idx] = CommitSig{BlockIDFlag: BlockIDFlagCommit, ValidatorAddress: val.Address}
+		} else {
+			sigs[idx] = NewCommitSigAbsent()
+		}
+	}
+	return sigs
+}
+
+// conflictingCommitRound returns the round of the commit of the conflicting
+// block.
+func (l *LightClientAttackEvidence) conflictingCommitRound() int32 {
+	if l.ConflictingAggregatedCommit != nil {
+		return l.ConflictingAggregatedCommit.Round
+	}
+	return l.ConflictingBlock.Commit.Round
+}
+
 // ConflictingHeaderIsInvalid takes a trusted header and matches it againt a conflicting header
 // to determine whether the conflicting header was the product of a valid state transition
 // or not. If it is then all the deterministic fields of the header should be the same.
@@ -296,7 +390,8 @@ func (l *LightClientAttackEvidence) ConflictingHeaderIsInvalid(trustedHeader *He
 
 }
 
-// Hash returns the hash of the header and the commonHeight. This is designed to cause hash collisions
+// Hash returns the hash of the header and the commonHeight. The hash of the header is the one its
+// commit signs, which ValidateHashes checks. This is designed to cause hash collisions
 // with evidence that have the same conflicting header and common height but different permutations
 // of validator commit signatures. The reason for this is that we don't want to allow several
 // permutations of the same evidence to be committed on chain. Ideally we commit the header with the
@@ -308,11 +403,23 @@ func (l *LightClientAttackEvidence) Hash() []byte {
 	buf := make([]byte, binary.MaxVarintLen64)
 	n := binary.PutVarint(buf, l.CommonHeight)
 	bz := make([]byte, tmhash.Size+n)
-	copy(bz[:tmhash.Size-1], l.ConflictingBlock.Hash().Bytes())
+	copy(bz[:tmhash.Size-1], l.conflictingBlockHash())
 	copy(bz[tmhash.Size:], buf)
 	return tmhash.Sum(bz)
 }
 
+// conflictingBlockHash returns the hash of the conflicting block signed by its commit.
+func (l *LightClientAttackEvidence) conflictingBlockHash() []byte {
+	switch {
+	case l.ConflictingAggregatedCommit != nil:
+		return l.ConflictingAggregatedCommit.BlockID.Hash
+	case l.ConflictingBlock != nil && l.ConflictingBlock.SignedHeader != nil && l.ConflictingBlock.Commit != nil:
+		return l.ConflictingBlock.Commit.BlockID.Hash
+	default:
+		return nil
+	}
+}
+
 // Height returns the last height at which the primary provider and witness provider had the same header.
 // We use this as the height of the infraction rather than the actual conflicting header because we know
 // that the malicious validators were bonded at this height which is important for evidence expiry
@@ -338,6 +445,7 @@ func (l *LightClientAttackEvidence) Time() time.Time {
 }
 
 // ValidateBasic performs basic validation such that the evidence is consistent and can now be used for verification.
+// The hashes of the conflicting block, which depend on the canonical encoding, are checked by ValidateHashes.
 func (l *LightClientAttackEvidence) ValidateBasic() error {
 	if l.ConflictingBlock == nil {
 		return errors.New("conflicting block is nil")
@@ -364,13 +472,58 @@ func (l *LightClientAttackEvidence) ValidateBasic() error {
 			l.CommonHeight, l.ConflictingBlock.Height)
 	}
 
-	if err := l.ConflictingBlock.ValidateBasic(l.ConflictingBlock.ChainID); err != nil {
+	if l.ConflictingAggregatedCommit != nil {
+		if err := l.validateConflictingAggregatedBlock(); err != nil {
+			return fmt.Errorf("invalid conflicting aggregated light block: %w", err)
+		}
+		return nil
+	}
+
+	if err := l.ConflictingBlock.validateBasic(l.ConflictingBlock.ChainID); err != nil {
 		return fmt.Errorf("invalid conflicting light block: %w", err)
 	}
 
 	return nil
 }
 
+// ValidateHashes checks that the header of the conflicting block hashes, with the given canonical encoding, to
+// the block ID of its commit, and that its validator set hashes to its validators hash.
+//
+// CONTRACT: must run ValidateBasic() on the evidence first.
+func (l *LightClientAttackEvidence) ValidateHashes(encoding CanonicalEncoding) error {
+	lb := l.ConflictingBlock
+	if hhash, chash := lb.Header.Hash(encoding), l.conflictingBlockHash(); !bytes.Equal(hhash, chash) {
+		return fmt.Errorf("commit signs block %X, conflicting header is block %X", chash, hhash)
+	}
+	if valSetHash := lb.ValidatorSet.Hash(encoding); !bytes.Equal(lb.ValidatorsHash, valSetHash) {
+		return fmt.Errorf("expected validator hash of header to match validator set hash (%X != %X)",
+			lb.ValidatorsHash, valSetHash,
+		)
+	}
+	return nil
+}
+
+// validateConflictingAggregatedBlock is LightBlock.validateBasic for the
+// conflicting block with an aggregated commit.
+func (l *LightClientAttackEvidence) validateConflictingAggregatedBlock() error {
+	lb := l.ConflictingBlock
+	if lb.Commit != nil {
+		return errors.New("both a commit and an aggregated commit")
+	}
+	if lb.ValidatorSet == nil {
+		return errors.New("missing validator set")
+	}
+
+	ash := AggregatedSignedHeader{Header: lb.Header, Commit: l.ConflictingAggregatedCommit}
+	if err := ash.validateBasic(lb.ChainID); err != nil {
+		return fmt.Errorf("invalid aggregated signed header: %w", err)
+	}
+	if err := lb.ValidatorSet.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid validator set: %w", err)
+	}
+	return nil
+}
+
 // ToProto encodes LightClientAttackEvidence to protobuf
 func (l *LightClientAttackEvidence) ToProto() (*cmtproto.LightClientAttackEvidence, error) {
 	conflictingBlock, err := l.ConflictingBlock.ToProto()
@@ -388,11 +541,12 @@ func (l *LightClientAttackEvidence) ToProto() (*cmtproto.LightClientAttackEviden
 	}
 
 	return &cmtproto.LightClientAttackEvidence{
-		ConflictingBlock:    conflictingBlock,
-		CommonHeight:        l.CommonHeight,
-		ByzantineValidators: byzVals,
-		TotalVotingPower:    l.TotalVotingPower,
-		Timestamp:           l.Timestamp,
+		ConflictingBlock:            conflictingBlock,
+		CommonHeight:                l.CommonHeight,
+		ByzantineValidators:         byzVals,
+		TotalVotingPower:            l.TotalVotingPower,
+		Timestamp:                   l.Timestamp,
+		ConflictingAggregatedCommit: l.ConflictingAggregatedCommit.ToProto(),
 	}, nil
 }
 
@@ -416,12 +570,21 @@ func LightClientAttackEvidenceFromProto(lpb *cmtproto.LightClientAttackEvidence)
 		byzVals[idx] = val
 	}
 
+	var conflictingAggregatedCommit *AggregatedCommit
+	if lpb.ConflictingAggregatedCommit != nil {
+		conflictingAggregatedCommit, err = AggregatedCommitFromProto(lpb.ConflictingAggregatedCommit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	l := &LightClientAttackEvidence{
-		ConflictingBlock:    conflictingBlock,
-		CommonHeight:        lpb.CommonHeight,
-		ByzantineValidators: byzVals,
-		TotalVotingPower:    lpb.TotalVotingPower,
-		Timestamp:           lpb.Timestamp,
+		ConflictingBlock:            conflictingBlock,
+		CommonHeight:                lpb.CommonHeight,
+		ByzantineValidators:         byzVals,
+		TotalVotingPower:            lpb.TotalVotingPower,
+		Timestamp:                   lpb.Timestamp,
+		ConflictingAggregatedCommit: conflictingAggregatedCommit,
 	}
 
 	return l, l.ValidateBasic()
@@ -429,6 +592,37 @@ func LightClientAttackEvidenceFromProto(lpb *cmtproto.LightClientAttackEvidence)
 
 //------------------------------------------------------------------------------------------
 
+// The types of evidence, as named in EvidenceParams.
+const (
+	EvidenceTypeDuplicateVote      = "duplicate_vote"
+	EvidenceTypeBn254DuplicateVote = "bn254_duplicate_vote"
+	EvidenceTypeLightClientAttack  = "light_client_attack"
+)
+
+// EvidenceType returns the type of ev, one of the EvidenceType* constants, or
+// an empty string for the evidence of an unknown type.
+func EvidenceType(ev Evidence) string {
+	switch ev.(type) {
+	case *DuplicateVoteEvidence:
+		return EvidenceTypeDuplicateVote
+	case *Bn254DuplicateVoteEvidence:
+		return EvidenceTypeBn254DuplicateVote
+	case *LightClientAttackEvidence:
+		return EvidenceTypeLightClientAttack
+	default:
+		return ""
+	}
+}
+
+func isEvidenceType(evType string) bool {
+	switch evType {
+	case EvidenceTypeDuplicateVote, EvidenceTypeBn254DuplicateVote, EvidenceTypeLightClientAttack:
+		return true
+	default:
+		return false
+	}
+}
+
 // EvidenceList is a list of Evidence. Evidences is not a word.
 type EvidenceList []Evidence
 
@@ -492,6 +686,14 @@ func EvidenceToProto(evidence Evidence) (*cmtproto.Evidence, error) {
 			},
 		}, nil
 
+	case *Bn254DuplicateVoteEvidence:
+		pbev := evi.ToProto()
+		return &cmtproto.Evidence{
+			Sum: &cmtproto.Evidence_Bn254DuplicateVoteEvidence{
+				Bn254DuplicateVoteEvidence: pbev,
+			},
+		}, nil
+
 	case *LightClientAttackEvidence:
 		pbev, err := evi.ToProto()
 		if err != nil {
@@ -520,6 +722,8 @@ func EvidenceFromProto(evidence *cmtproto.Evidence) (Evidence, error) {
 		return DuplicateVoteEvidenceFromProto(evi.DuplicateVoteEvidence)
 	case *cmtproto.Evidence_LightClientAttackEvidence:
 		return LightClientAttackEvidenceFromProto(evi.LightClientAttackEvidence)
+	case *cmtproto.Evidence_Bn254DuplicateVoteEvidence:
+		return Bn254DuplicateVoteEvidenceFromProto(evi.Bn254DuplicateVoteEvidence)
 	default:
 		return nil, errors.New("evidence is not recognized")
 	}
@@ -528,6 +732,7 @@ func EvidenceFromProto(evidence *cmtproto.Evidence) (Evidence, error) {
 func init() {
 	cmtjson.RegisterType(&DuplicateVoteEvidence{}, "tendermint/DuplicateVoteEvidence")
 	cmtjson.RegisterType(&LightClientAttackEvidence{}, "tendermint/LightClientAttackEvidence")
+	cmtjson.RegisterType(&Bn254DuplicateVoteEvidence{}, "tendermint/Bn254DuplicateVoteEvidence")
 }
 
 //-------------------------------------------- ERRORS --------------------------------------
@@ -574,7 +779,8 @@ func NewMockDuplicateVoteEvidence(height int64, time time.Time, chainID string)
 	return NewMockDuplicateVoteEvidenceWithValidator(height, time, val, chainID)
 }
 
-// assumes voting power to be 10 and validator to be the only one in the set
+// assumes voting power to be 10 and validator to be the only one in the set, and the votes to be signed with
+// the proto encoding
 func NewMockDuplicateVoteEvidenceWithValidator(height int64, time time.Time,
 	pv PrivValidator, chainID string) (*DuplicateVoteEvidence, error) {
 	pubKey, err := pv.GetPubKey()
@@ -584,14 +790,14 @@ func NewMockDuplicateVoteEvidenceWithValidator(height int64, time time.Time,
 	val := NewValidator(pubKey, 10)
 	voteA := makeMockVote(height, 0, 0, pubKey.Address(), randBlockID(), time)
 	vA := voteA.ToProto()
-	err = pv.SignVote(chainID, vA)
+	err = pv.SignVote(chainID, CanonicalEncodingProto, vA)
 	if err != nil {
 		return nil, err
 	}
 	voteA.Signature = vA.Signature
 	voteB := makeMockVote(height, 0, 0, pubKey.Address(), randBlockID(), time)
 	vB := voteB.ToProto()
-	err = pv.SignVote(chainID, vB)
+	err = pv.SignVote(chainID, CanonicalEncodingProto, vB)
 	if err != nil {
 		return nil, err
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -91,6 +92,44 @@ func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 	}
 }
 
+func TestBn254DuplicateVoteEvidence(t *testing.T) {
+	val := NewMockPVWithParams(bn254.GenPrivKey(), false, false)
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	const chainID = "mychain"
+	vote1 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultVoteTime)
+	vote2 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultVoteTime)
+	valSet := NewValidatorSet([]*Validator{val.ExtractIntoValidator(10)})
+
+	ev, err := NewBn254DuplicateVoteEvidence(vote1, vote2, defaultVoteTime, valSet)
+	require.NoError(t, err)
+	require.NoError(t, ev.ValidateBasic())
+
+	// the same votes make the same evidence, whatever its type
+	dve, err := NewDuplicateVoteEvidence(vote1, vote2, defaultVoteTime, valSet)
+	require.NoError(t, err)
+	assert.Equal(t, dve.Hash(), ev.Hash())
+
+	pb, err := EvidenceToProto(ev)
+	require.NoError(t, err)
+	assert.NotNil(t, pb.GetBn254DuplicateVoteEvidence())
+	evi, err := EvidenceFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, ev, evi)
+
+	// the votes must be signed with bn254
+	ev.VoteA.Signature = crypto.CRandBytes(64)
+	assert.Error(t, ev.ValidateBasic())
+
+	// the validator must have a bn254 key
+	ed25519Val := NewMockPV()
+	vote1 = makeVote(t, ed25519Val, chainID, 0, 10, 2, 1, blockID, defaultVoteTime)
+	vote2 = makeVote(t, ed25519Val, chainID, 0, 10, 2, 1, blockID2, defaultVoteTime)
+	valSet = NewValidatorSet([]*Validator{ed25519Val.ExtractIntoValidator(10)})
+	_, err = NewBn254DuplicateVoteEvidence(vote1, vote2, defaultVoteTime, valSet)
+	assert.Error(t, err)
+}
+
 func TestLightClientAttackEvidenceBasic(t *testing.T) {
 	height := int64(5)
 	commonHeight := height - 1
@@ -124,7 +163,9 @@ func TestLightClientAttackEvidenceBasic(t *testing.T) {
 		testName         string
 		malleateEvidence func(*LightClientAttackEvidence)
 	}{
-		{"Different header", func(ev *LightClientAttackEvidence) { ev.ConflictingBlock.Header = makeHeaderRandom() }},
+		{"Different block", func(ev *LightClientAttackEvidence) {
+			ev.ConflictingBlock.Commit = &Commit{BlockID: makeBlockIDRandom()}
+		}},
 		{"Different common height", func(ev *LightClientAttackEvidence) {
 			ev.CommonHeight = height + 1
 		}},
@@ -157,8 +198,8 @@ func TestLightClientAttackEvidenceValidation(t *testing.T) {
 	voteSet, valSet, privVals := randVoteSet(height, 1, cmtproto.PrecommitType, nValidators, 1)
 	header := makeHeaderRandom()
 	header.Height = height
-	header.ValidatorsHash = valSet.Hash()
-	blockID := makeBlockID(header.Hash(), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	header.ValidatorsHash = valSet.Hash(CanonicalEncodingProto)
+	blockID := makeBlockID(header.Hash(CanonicalEncodingProto), math.MaxInt32, tmhash.Sum([]byte("partshash")))
 	commit, err := MakeCommit(blockID, height, 1, voteSet, privVals, time.Now())
 	require.NoError(t, err)
 	lcae := &LightClientAttackEvidence{
@@ -225,6 +266,67 @@ func TestLightClientAttackEvidenceValidation(t *testing.T) {
 
 }
 
+func TestLightClientAttackEvidenceAggregated(t *testing.T) {
+	height := int64(5)
+	valSet, privVals := RandBn254ValidatorSet(6, 10)
+	header := makeHeaderRandom()
+	header.Height = height
+	header.ValidatorsHash = valSet.Hash(CanonicalEncodingProto)
+	blockID := makeBlockID(header.Hash(CanonicalEncodingProto), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	// validator 0 is absent, and 1 voted nil
+	commit := makeBn254Commit(t, header.ChainID, height, blockID, privVals, map[int]bool{0: true}, map[int]bool{1: true})
+	ac, err := commit.Aggregate()
+	require.NoError(t, err)
+	lcae := &LightClientAttackEvidence{
+		ConflictingBlock: &LightBlock{
+			SignedHeader: &SignedHeader{Header: header},
+			ValidatorSet: valSet,
+		},
+		ConflictingAggregatedCommit: ac,
+		CommonHeight:                height - 1,
+		TotalVotingPower:            valSet.TotalVotingPower(),
+		Timestamp:                   header.Time,
+	}
+	require.NoError(t, lcae.ValidateBasic())
+
+	pb, err := EvidenceToProto(lcae)
+	require.NoError(t, err)
+	evi, err := EvidenceFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, lcae.Bytes(), evi.Bytes())
+
+	// lunatic attack: the validators which signed the conflicting block
+	trustedHeader := *header
+	trustedHeader.AppHash = crypto.CRandBytes(tmhash.Size)
+	byzVals := lcae.GetByzantineValidators(valSet, &SignedHeader{Header: &trustedHeader})
+	assert.ElementsMatch(t, valSet.Validators[2:], byzVals)
+
+	// equivocation: the validators which signed both blocks
+	trustedHeader = *header
+	trustedHeader.Time = header.Time.Add(time.Second)
+	trustedBlockID := makeBlockID(trustedHeader.Hash(CanonicalEncodingProto), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	trustedCommit := makeBn254Commit(t, header.ChainID, height, trustedBlockID, privVals, map[int]bool{5: true}, nil)
+	byzVals = lcae.GetByzantineValidators(valSet, &SignedHeader{Header: &trustedHeader, Commit: trustedCommit})
+	assert.ElementsMatch(t, valSet.Validators[2:5], byzVals)
+
+	// the conflicting block has either a commit or an aggregated commit
+	lcae.ConflictingBlock.Commit = commit
+	assert.Error(t, lcae.ValidateBasic())
+	lcae.ConflictingBlock.Commit = nil
+
+	// the aggregated commit must be for the conflicting block
+	lcae.ConflictingAggregatedCommit = &AggregatedCommit{
+		Height:     height,
+		Round:      ac.Round,
+		BlockID:    trustedBlockID,
+		Signers:    ac.Signers,
+		Timestamps: ac.Timestamps,
+		Signature:  ac.Signature,
+	}
+	require.NoError(t, lcae.ValidateBasic())
+	assert.Error(t, lcae.ValidateHashes(CanonicalEncodingProto))
+}
+
 func TestMockEvidenceValidateBasic(t *testing.T) {
 	goodEvidence, err := NewMockDuplicateVoteEvidence(int64(1), time.Now(), "mock-chain-id")
 	require.NoError(t, err)
@@ -247,7 +349,7 @@ func makeVote(
 	}
 
 	vpb := v.ToProto()
-	err = val.SignVote(chainID, vpb)
+	err = val.SignVote(chainID, CanonicalEncodingProto, vpb)
 	require.NoError(t, err)
 
 	v.Signature = vpb.Signature
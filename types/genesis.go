@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmtos "github.com/cometbft/cometbft/libs/os"
@@ -32,6 +33,9 @@ type GenesisValidator struct {
 	PubKey  crypto.PubKey `json:"pub_key"`
 	Power   int64         `json:"power"`
 	Name    string        `json:"name"`
+	// ProofOfPossession is the proof of possession of a bn254 key, see
+	// bn254.PrivKey.ProvePossession. It is optional, but verified if present.
+	ProofOfPossession cmtbytes.HexBytes `json:"proof_of_possession,omitempty"`
 }
 
 // GenesisDoc defines the initial conditions for a CometBFT blockchain, in particular its validator set.
@@ -61,7 +65,7 @@ func (genDoc *GenesisDoc) ValidatorHash() []byte {
 		vals[i] = NewValidator(v.PubKey, v.Power)
 	}
 	vset := NewValidatorSet(vals)
-	return vset.Hash()
+	return vset.Hash(genDoc.ConsensusParams.Encoding.Canonical)
 }
 
 // ValidateAndComplete checks that all necessary fields are present
@@ -82,7 +86,11 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 
 	if genDoc.ConsensusParams == nil {
 		genDoc.ConsensusParams = DefaultConsensusParams()
-	} else if err := genDoc.ConsensusParams.ValidateBasic(); err != nil {
+	} else if genDoc.ConsensusParams.Encoding.Canonical == "" {
+		// the genesis files which predate the encoding params have none
+		genDoc.ConsensusParams.Encoding = DefaultEncodingParams()
+	}
+	if err := genDoc.ConsensusParams.ValidateBasic(); err != nil {
 		return err
 	}
 
@@ -96,6 +104,16 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		if len(v.Address) == 0 {
 			genDoc.Validators[i].Address = v.PubKey.Address()
 		}
+		if len(v.ProofOfPossession) > 0 {
+			pk, ok := v.PubKey.(bn254.PubKey)
+			if !ok {
+				return fmt.Errorf("proof of possession of the %s key of validator %v in the genesis file, "+
+					"only bn254 keys have one", v.PubKey.Type(), v)
+			}
+			if !pk.VerifyPossession(v.ProofOfPossession) {
+				return fmt.Errorf("invalid proof of possession for validator %v in the genesis file", v)
+			}
+		}
 	}
 
 	if genDoc.GenesisTime.IsZero() {
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmttime "github.com/cometbft/cometbft/types/time"
@@ -80,7 +81,7 @@ func TestGenesisGood(t *testing.T) {
 	// create a base gendoc from struct
 	baseGenDoc := &GenesisDoc{
 		ChainID:    "abc",
-		Validators: []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+		Validators: []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval", nil}},
 	}
 	genDocBytes, err = cmtjson.Marshal(baseGenDoc)
 	assert.NoError(t, err, "error marshaling genDoc")
@@ -120,6 +121,32 @@ func TestGenesisGood(t *testing.T) {
 	}
 }
 
+func TestGenesisProofOfPossession(t *testing.T) {
+	privKey := bn254.GenPrivKey()
+	proof, err := privKey.ProvePossession()
+	require.NoError(t, err)
+
+	genDoc := &GenesisDoc{
+		ChainID: "abc",
+		Validators: []GenesisValidator{{
+			PubKey:            privKey.PubKey(),
+			Power:             10,
+			ProofOfPossession: proof,
+		}},
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+
+	// the proof must be of the key
+	genDoc.Validators[0].PubKey = bn254.GenPrivKey().PubKey()
+	genDoc.Validators[0].Address = nil
+	require.Error(t, genDoc.ValidateAndComplete())
+
+	// and only bn254 keys have one
+	genDoc.Validators[0].PubKey = ed25519.GenPrivKey().PubKey()
+	genDoc.Validators[0].Address = nil
+	require.Error(t, genDoc.ValidateAndComplete())
+}
+
 func TestGenesisSaveAs(t *testing.T) {
 	tmpfile, err := os.CreateTemp("", "genesis")
 	require.NoError(t, err)
@@ -157,7 +184,7 @@ func randomGenesisDoc() *GenesisDoc {
 		GenesisTime:     cmttime.Now(),
 		ChainID:         "abc",
 		InitialHeight:   1000,
-		Validators:      []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+		Validators:      []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval", nil}},
 		ConsensusParams: DefaultConsensusParams(),
 		AppHash:         []byte{1, 2, 3},
 	}
@@ -0,0 +1,349 @@
+package types
+
+import (
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+)
+
+// The JSON fast paths of the types encoded on most RPC requests, equivalent to
+// their encoding by reflection.
+func init() {
+	cmtjson.RegisterFastPath(PartSetHeader{}, encodePartSetHeaderJSON, decodePartSetHeaderJSON)
+	cmtjson.RegisterFastPath(BlockID{}, encodeBlockIDJSON, decodeBlockIDJSON)
+	cmtjson.RegisterFastPath(Header{}, encodeHeaderJSON, decodeHeaderJSON)
+	cmtjson.RegisterFastPath(Data{}, encodeDataJSON, decodeDataJSON)
+	cmtjson.RegisterFastPath(CommitSig{}, encodeCommitSigJSON, decodeCommitSigJSON)
+	cmtjson.RegisterFastPath(Commit{}, encodeCommitJSON, decodeCommitJSON)
+	cmtjson.RegisterFastPath(Block{}, encodeBlockJSON, decodeBlockJSON)
+	cmtjson.RegisterFastPath(Vote{}, encodeVoteJSON, decodeVoteJSON)
+	cmtjson.RegisterFastPath(Validator{}, encodeValidatorJSON, decodeValidatorJSON)
+	cmtjson.RegisterFastPath(ValidatorSet{}, encodeValidatorSetJSON, decodeValidatorSetJSON)
+}
+
+func encodePartSetHeaderJSON(w *cmtjson.Writer, v interface{}) {
+	psh := v.(*PartSetHeader)
+	w.BeginObject()
+	w.Field("total")
+	w.Uint32(psh.Total)
+	w.Field("hash")
+	w.HexBytes(psh.Hash)
+	w.EndObject()
+}
+
+func decodePartSetHeaderJSON(bz []byte, v interface{}) error {
+	psh := v.(*PartSetHeader)
+	o := cmtjson.DecodeObject(bz)
+	o.Uint32("total", &psh.Total)
+	o.HexBytes("hash", &psh.Hash)
+	return o.Err()
+}
+
+func encodeBlockIDJSON(w *cmtjson.Writer, v interface{}) {
+	blockID := v.(*BlockID)
+	w.BeginObject()
+	w.Field("hash")
+	w.HexBytes(blockID.Hash)
+	w.Field("parts")
+	encodePartSetHeaderJSON(w, &blockID.PartSetHeader)
+	w.EndObject()
+}
+
+func decodeBlockIDJSON(bz []byte, v interface{}) error {
+	blockID := v.(*BlockID)
+	o := cmtjson.DecodeObject(bz)
+	o.HexBytes("hash", &blockID.Hash)
+	o.Value("parts", &blockID.PartSetHeader)
+	return o.Err()
+}
+
+func encodeHeaderJSON(w *cmtjson.Writer, v interface{}) {
+	h := v.(*Header)
+	w.BeginObject()
+	w.Field("version")
+	w.BeginObject()
+	if h.Version.Block != 0 {
+		w.Field("block")
+		w.Uint64(h.Version.Block)
+	}
+	if h.Version.App != 0 {
+		w.Field("app")
+		w.Uint64(h.Version.App)
+	}
+	w.EndObject()
+	w.Field("chain_id")
+	w.String(h.ChainID)
+	w.Field("height")
+	w.Int64(h.Height)
+	w.Field("time")
+	w.Time(h.Time)
+	w.Field("last_block_id")
+	encodeBlockIDJSON(w, &h.LastBlockID)
+	w.Field("last_commit_hash")
+	w.HexBytes(h.LastCommitHash)
+	w.Field("data_hash")
+	w.HexBytes(h.DataHash)
+	w.Field("validators_hash")
+	w.HexBytes(h.ValidatorsHash)
+	w.Field("next_validators_hash")
+	w.HexBytes(h.NextValidatorsHash)
+	w.Field("consensus_hash")
+	w.HexBytes(h.ConsensusHash)
+	w.Field("app_hash")
+	w.HexBytes(h.AppHash)
+	w.Field("last_results_hash")
+	w.HexBytes(h.LastResultsHash)
+	w.Field("evidence_hash")
+	w.HexBytes(h.EvidenceHash)
+	w.Field("proposer_address")
+	w.HexBytes(h.ProposerAddress)
+	w.EndObject()
+}
+
+func decodeHeaderJSON(bz []byte, v interface{}) error {
+	h := v.(*Header)
+	o := cmtjson.DecodeObject(bz)
+	o.Value("version", &h.Version)
+	o.String("chain_id", &h.ChainID)
+	o.Int64("height", &h.Height)
+	o.Time("time", &h.Time)
+	o.Value("last_block_id", &h.LastBlockID)
+	o.HexBytes("last_commit_hash", &h.LastCommitHash)
+	o.HexBytes("data_hash", &h.DataHash)
+	o.HexBytes("validators_hash", &h.ValidatorsHash)
+	o.HexBytes("next_validators_hash", &h.NextValidatorsHash)
+	o.HexBytes("consensus_hash", &h.ConsensusHash)
+	o.HexBytes("app_hash", &h.AppHash)
+	o.HexBytes("last_results_hash", &h.LastResultsHash)
+	o.HexBytes("evidence_hash", &h.EvidenceHash)
+	o.HexBytes("proposer_address", &h.ProposerAddress)
+	return o.Err()
+}
+
+func encodeDataJSON(w *cmtjson.Writer, v interface{}) {
+	data := v.(*Data)
+	w.BeginObject()
+	w.Field("txs")
+	if data.Txs == nil {
+		w.Null()
+	} else {
+		w.BeginArray()
+		for _, tx := range data.Txs {
+			w.Elem()
+			w.Bytes(tx)
+		}
+		w.EndArray()
+	}
+	w.EndObject()
+}
+
+func decodeDataJSON(bz []byte, v interface{}) error {
+	data := v.(*Data)
+	o := cmtjson.DecodeObject(bz)
+	data.Txs = nil
+	if elems := o.Array("txs"); elems != nil {
+		data.Txs = make(Txs, len(elems))
+		for i, elem := range elems {
+			tx, err := cmtjson.DecodeBytes(elem)
+			if err != nil {
+				return err
+			}
+			data.Txs[i] = tx
+		}
+	}
+	return o.Err()
+}
+
+func encodeCommitSigJSON(w *cmtjson.Writer, v interface{}) {
+	cs := v.(*CommitSig)
+	w.BeginObject()
+	w.Field("block_id_flag")
+	w.Uint32(uint32(cs.BlockIDFlag))
+	w.Field("validator_address")
+	w.HexBytes(cs.ValidatorAddress)
+	w.Field("timestamp")
+	w.Time(cs.Timestamp)
+	w.Field("signature")
+	w.Bytes(cs.Signature)
+	w.EndObject()
+}
+
+func decodeCommitSigJSON(bz []byte, v interface{}) error {
+	cs := v.(*CommitSig)
+	o := cmtjson.DecodeObject(bz)
+	o.Uint8("block_id_flag", (*uint8)(&cs.BlockIDFlag))
+	o.HexBytes("validator_address", &cs.ValidatorAddress)
+	o.Time("timestamp", &cs.Timestamp)
+	o.Bytes("signature", &cs.Signature)
+	return o.Err()
+}
+
+func encodeCommitJSON(w *cmtjson.Writer, v interface{}) {
+	commit := v.(*Commit)
+	w.BeginObject()
+	w.Field("height")
+	w.Int64(commit.Height)
+	w.Field("round")
+	w.Int32(commit.Round)
+	w.Field("block_id")
+	encodeBlockIDJSON(w, &commit.BlockID)
+	w.Field("signatures")
+	if commit.Signatures == nil {
+		w.Null()
+	} else {
+		w.BeginArray()
+		for i := range commit.Signatures {
+			w.Elem()
+			encodeCommitSigJSON(w, &commit.Signatures[i])
+		}
+		w.EndArray()
+	}
+	w.EndObject()
+}
+
+func decodeCommitJSON(bz []byte, v interface{}) error {
+	commit := v.(*Commit)
+	o := cmtjson.DecodeObject(bz)
+	o.Int64("height", &commit.Height)
+	o.Int32("round", &commit.Round)
+	o.Value("block_id", &commit.BlockID)
+	commit.Signatures = nil
+	if elems := o.Array("signatures"); elems != nil {
+		commit.Signatures = make([]CommitSig, len(elems))
+		for i, elem := range elems {
+			if err := cmtjson.DecodeValue(elem, &commit.Signatures[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return o.Err()
+}
+
+func encodeBlockJSON(w *cmtjson.Writer, v interface{}) {
+	block := v.(*Block)
+	w.BeginObject()
+	w.Field("header")
+	encodeHeaderJSON(w, &block.Header)
+	w.Field("data")
+	encodeDataJSON(w, &block.Data)
+	w.Field("evidence")
+	w.Value(&block.Evidence)
+	w.Field("last_commit")
+	if block.LastCommit == nil {
+		w.Null()
+	} else {
+		encodeCommitJSON(w, block.LastCommit)
+	}
+	w.EndObject()
+}
+
+func decodeBlockJSON(bz []byte, v interface{}) error {
+	block := v.(*Block)
+	o := cmtjson.DecodeObject(bz)
+	o.Value("header", &block.Header)
+	o.Value("data", &block.Data)
+	o.Value("evidence", &block.Evidence)
+	o.Value("last_commit", &block.LastCommit)
+	return o.Err()
+}
+
+func encodeVoteJSON(w *cmtjson.Writer, v interface{}) {
+	vote := v.(*Vote)
+	w.BeginObject()
+	w.Field("type")
+	w.Int32(int32(vote.Type))
+	w.Field("height")
+	w.Int64(vote.Height)
+	w.Field("round")
+	w.Int32(vote.Round)
+	w.Field("block_id")
+	encodeBlockIDJSON(w, &vote.BlockID)
+	w.Field("timestamp")
+	w.Time(vote.Timestamp)
+	w.Field("validator_address")
+	w.HexBytes(vote.ValidatorAddress)
+	w.Field("validator_index")
+	w.Int32(vote.ValidatorIndex)
+	w.Field("signature")
+	w.Bytes(vote.Signature)
+	w.EndObject()
+}
+
+func decodeVoteJSON(bz []byte, v interface{}) error {
+	vote := v.(*Vote)
+	o := cmtjson.DecodeObject(bz)
+	o.Int32("type", (*int32)(&vote.Type))
+	o.Int64("height", &vote.Height)
+	o.Int32("round", &vote.Round)
+	o.Value("block_id", &vote.BlockID)
+	o.Time("timestamp", &vote.Timestamp)
+	o.HexBytes("validator_address", &vote.ValidatorAddress)
+	o.Int32("validator_index", &vote.ValidatorIndex)
+	o.Bytes("signature", &vote.Signature)
+	return o.Err()
+}
+
+func encodeValidatorJSON(w *cmtjson.Writer, v interface{}) {
+	val := v.(*Validator)
+	w.BeginObject()
+	w.Field("address")
+	w.HexBytes(val.Address)
+	w.Field("pub_key")
+	w.Value(&val.PubKey)
+	w.Field("voting_power")
+	w.Int64(val.VotingPower)
+	w.Field("proposer_priority")
+	w.Int64(val.ProposerPriority)
+	w.EndObject()
+}
+
+func decodeValidatorJSON(bz []byte, v interface{}) error {
+	val := v.(*Validator)
+	o := cmtjson.DecodeObject(bz)
+	o.HexBytes("address", &val.Address)
+	o.Value("pub_key", &val.PubKey)
+	o.Int64("voting_power", &val.VotingPower)
+	o.Int64("proposer_priority", &val.ProposerPriority)
+	return o.Err()
+}
+
+func encodeValidatorSetJSON(w *cmtjson.Writer, v interface{}) {
+	vals := v.(*ValidatorSet)
+	w.BeginObject()
+	w.Field("validators")
+	if vals.Validators == nil {
+		w.Null()
+	} else {
+		w.BeginArray()
+		for _, val := range vals.Validators {
+			w.Elem()
+			if val == nil {
+				w.Null()
+			} else {
+				encodeValidatorJSON(w, val)
+			}
+		}
+		w.EndArray()
+	}
+	w.Field("proposer")
+	if vals.Proposer == nil {
+		w.Null()
+	} else {
+		encodeValidatorJSON(w, vals.Proposer)
+	}
+	w.EndObject()
+}
+
+func decodeValidatorSetJSON(bz []byte, v interface{}) error {
+	vals := v.(*ValidatorSet)
+	o := cmtjson.DecodeObject(bz)
+	vals.Validators = nil
+	if elems := o.Array("validators"); elems != nil {
+		vals.Validators = make([]*Validator, len(elems))
+		for i, elem := range elems {
+			if err := cmtjson.DecodeValue(elem, &vals.Validators[i]); err != nil {
+				return err
+			}
+		}
+	}
+	o.Value("proposer", &vals.Proposer)
+	return o.Err()
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+)
+
+// The types without fast paths, encoded by reflection.
+type (
+	reflectBlockID      BlockID
+	reflectHeader       Header
+	reflectData         Data
+	reflectCommit       Commit
+	reflectBlock        Block
+	reflectVote         Vote
+	reflectValidator    Validator
+	reflectValidatorSet ValidatorSet
+)
+
+// checkFastPath checks that v and r, the same value without fast paths, are
+// encoded and decoded alike.
+func checkFastPath(t *testing.T, v, r, vDecoded, rDecoded interface{}) {
+	t.Helper()
+	bz, err := cmtjson.Marshal(v)
+	require.NoError(t, err)
+	rbz, err := cmtjson.Marshal(r)
+	require.NoError(t, err)
+	require.Equal(t, string(rbz), string(bz))
+
+	require.NoError(t, cmtjson.Unmarshal(bz, vDecoded))
+	require.NoError(t, cmtjson.Unmarshal(bz, rDecoded))
+	bz, err = cmtjson.Marshal(vDecoded)
+	require.NoError(t, err)
+	rbz, err = cmtjson.Marshal(rDecoded)
+	require.NoError(t, err)
+	assert.Equal(t, string(rbz), string(bz))
+}
+
+func TestJSONFastPaths(t *testing.T) {
+	now := time.Date(2023, 6, 8, 16, 21, 28, 123, time.UTC)
+	blockID := makeBlockIDRandom()
+	commit := randCommit(now)
+	ev, err := NewMockDuplicateVoteEvidence(2, now, "test-chain")
+	require.NoError(t, err)
+	block := MakeBlock(3, []Tx{Tx("foo"), Tx("")}, commit, []Evidence{ev})
+	block.Header.Version = cmtversion.Consensus{Block: 11}
+	block.Header.Time = now
+	block.ChainID = "test-<chain>"
+	vote := examplePrevote()
+	vals, _ := RandValidatorSet(3, 10)
+	val := NewValidator(bn254.GenPrivKey().PubKey(), 7)
+
+	checkFastPath(t, &blockID, (*reflectBlockID)(&blockID), &BlockID{}, &reflectBlockID{})
+	checkFastPath(t, &block.Header, (*reflectHeader)(&block.Header), &Header{}, &reflectHeader{})
+	zoned := block.Header
+	zoned.Time = now.In(time.FixedZone("UTC+2", 2*60*60))
+	checkFastPath(t, &zoned, (*reflectHeader)(&zoned), &Header{}, &reflectHeader{})
+	checkFastPath(t, &block.Data, (*reflectData)(&block.Data), &Data{}, &reflectData{})
+	checkFastPath(t, &Data{}, &reflectData{}, &Data{}, &reflectData{})
+	checkFastPath(t, commit, (*reflectCommit)(commit), &Commit{}, &reflectCommit{})
+	checkFastPath(t, block, (*reflectBlock)(block), &Block{}, &reflectBlock{})
+	checkFastPath(t, vote, (*reflectVote)(vote), &Vote{}, &reflectVote{})
+	checkFastPath(t, val, (*reflectValidator)(val), &Validator{}, &reflectValidator{})
+	checkFastPath(t, &Validator{}, &reflectValidator{}, &Validator{}, &reflectValidator{})
+	checkFastPath(t, vals, (*reflectValidatorSet)(vals), &ValidatorSet{}, &reflectValidatorSet{})
+	checkFastPath(t, &ValidatorSet{}, &reflectValidatorSet{}, &ValidatorSet{}, &reflectValidatorSet{})
+
+	// the fast paths decode as reflection does
+	var decoded Block
+	bz, err := cmtjson.Marshal(block)
+	require.NoError(t, err)
+	require.NoError(t, cmtjson.Unmarshal(bz, &decoded))
+	assert.Equal(t, block.Header.Hash(CanonicalEncodingProto), decoded.Header.Hash(CanonicalEncodingProto))
+	assert.Equal(t, block.Evidence.Evidence, decoded.Evidence.Evidence)
+	assert.Equal(t, block.LastCommit.Signatures, decoded.LastCommit.Signatures)
+	assert.Nil(t, decoded.Txs[1])
+
+	_, err = cmtjson.Marshal(&Header{ChainID: cmtrand.Str(10), Time: time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.Error(t, err)
+	assert.Error(t, cmtjson.Unmarshal([]byte(`{"height":3}`), &Header{}))
+	assert.Error(t, cmtjson.Unmarshal([]byte(`{"time":"2023-06-08T16:21:28+02:00"}`), &Header{}))
+}
+
+func BenchmarkJSONBlock(b *testing.B) {
+	txs := make([]Tx, 100)
+	for i := range txs {
+		txs[i] = cmtrand.Bytes(200)
+	}
+	block := MakeBlock(3, txs, randCommit(time.Now()), nil)
+	bz, err := cmtjson.Marshal(block)
+	require.NoError(b, err)
+
+	b.Run("marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := cmtjson.Marshal(block); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var decoded Block
+			if err := cmtjson.Unmarshal(bz, &decoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
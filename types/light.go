@@ -15,10 +15,31 @@ type LightBlock struct {
 	ValidatorSet  *ValidatorSet `json:"validator_set"`
 }
 
-// ValidateBasic checks that the data is correct and consistent
+// ValidateBasic checks that the data is correct and consistent, the header and
+// the validator set being hashed with the given canonical encoding.
 //
 // This does no verification of the signatures
-func (lb LightBlock) ValidateBasic(chainID string) error {
+func (lb LightBlock) ValidateBasic(chainID string, encoding CanonicalEncoding) error {
+	if err := lb.validateBasic(chainID); err != nil {
+		return err
+	}
+	if err := lb.SignedHeader.ValidateBasic(chainID, encoding); err != nil {
+		return fmt.Errorf("invalid signed header: %w", err)
+	}
+
+	// make sure the validator set is consistent with the header
+	if valSetHash := lb.ValidatorSet.Hash(encoding); !bytes.Equal(lb.SignedHeader.ValidatorsHash, valSetHash) {
+		return fmt.Errorf("expected validator hash of header to match validator set hash (%X != %X)",
+			lb.SignedHeader.ValidatorsHash, valSetHash,
+		)
+	}
+
+	return nil
+}
+
+// validateBasic is ValidateBasic, without checking the hashes of the header
+// and of the validator set.
+func (lb LightBlock) validateBasic(chainID string) error {
 	if lb.SignedHeader == nil {
 		return errors.New("missing signed header")
 	}
@@ -26,20 +47,12 @@ func (lb LightBlock) ValidateBasic(chainID string) error {
 		return errors.New("missing validator set")
 	}
 
-	if err := lb.SignedHeader.ValidateBasic(chainID); err != nil {
+	if err := lb.SignedHeader.validateBasic(chainID); err != nil {
 		return fmt.Errorf("invalid signed header: %w", err)
 	}
 	if err := lb.ValidatorSet.ValidateBasic(); err != nil {
 		return fmt.Errorf("invalid validator set: %w", err)
 	}
-
-	// make sure the validator set is consistent with the header
-	if valSetHash := lb.ValidatorSet.Hash(); !bytes.Equal(lb.SignedHeader.ValidatorsHash, valSetHash) {
-		return fmt.Errorf("expected validator hash of header to match validator set hash (%X != %X)",
-			lb.SignedHeader.ValidatorsHash, valSetHash,
-		)
-	}
-
 	return nil
 }
 
@@ -121,12 +134,25 @@ type SignedHeader struct {
 }
 
 // ValidateBasic does basic consistency checks and makes sure the header
-// and commit are consistent.
+// and commit are consistent, the header being hashed with the given canonical
+// encoding.
 //
 // NOTE: This does not actually check the cryptographic signatures.  Make sure
 // to use a Verifier to validate the signatures actually provide a
 // significantly strong proof for this header's validity.
-func (sh SignedHeader) ValidateBasic(chainID string) error {
+func (sh SignedHeader) ValidateBasic(chainID string, encoding CanonicalEncoding) error {
+	if err := sh.validateBasic(chainID); err != nil {
+		return err
+	}
+	if hhash, chash := sh.Header.Hash(encoding), sh.Commit.BlockID.Hash; !bytes.Equal(hhash, chash) {
+		return fmt.Errorf("commit signs block %X, header is block %X", chash, hhash)
+	}
+	return nil
+}
+
+// validateBasic is ValidateBasic, without checking that the header hashes to
+// the block ID of the commit.
+func (sh SignedHeader) validateBasic(chainID string) error {
 	if sh.Header == nil {
 		return errors.New("missing header")
 	}
@@ -149,10 +175,6 @@ func (sh SignedHeader) ValidateBasic(chainID string) error {
 	if sh.Commit.Height != sh.Height {
 		return fmt.Errorf("header and commit height mismatch: %d vs %d", sh.Height, sh.Commit.Height)
 	}
-	if hhash, chash := sh.Header.Hash(), sh.Commit.BlockID.Hash; !bytes.Equal(hhash, chash) {
-		return fmt.Errorf("commit signs block %X, header is block %X", chash, hhash)
-	}
-
 	return nil
 }
 
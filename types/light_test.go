@@ -18,12 +18,12 @@ func TestLightBlockValidateBasic(t *testing.T) {
 	vals, _ := RandValidatorSet(5, 1)
 	header.Height = commit.Height
 	header.LastBlockID = commit.BlockID
-	header.ValidatorsHash = vals.Hash()
+	header.ValidatorsHash = vals.Hash(CanonicalEncodingProto)
 	header.Version.Block = version.BlockProtocol
 	vals2, _ := RandValidatorSet(3, 1)
 	vals3 := vals.Copy()
 	vals3.Proposer = &Validator{}
-	commit.BlockID.Hash = header.Hash()
+	commit.BlockID.Hash = header.Hash(CanonicalEncodingProto)
 
 	sh := &SignedHeader{
 		Header: &header,
@@ -47,7 +47,7 @@ func TestLightBlockValidateBasic(t *testing.T) {
 			SignedHeader: tc.sh,
 			ValidatorSet: tc.vals,
 		}
-		err := lightBlock.ValidateBasic(header.ChainID)
+		err := lightBlock.ValidateBasic(header.ChainID, CanonicalEncodingProto)
 		if tc.expectErr {
 			assert.Error(t, err, tc.name)
 		} else {
@@ -64,10 +64,10 @@ func TestLightBlockProtobuf(t *testing.T) {
 	header.Height = commit.Height
 	header.LastBlockID = commit.BlockID
 	header.Version.Block = version.BlockProtocol
-	header.ValidatorsHash = vals.Hash()
+	header.ValidatorsHash = vals.Hash(CanonicalEncodingProto)
 	vals3 := vals.Copy()
 	vals3.Proposer = &Validator{}
-	commit.BlockID.Hash = header.Hash()
+	commit.BlockID.Hash = header.Hash(CanonicalEncodingProto)
 
 	sh := &SignedHeader{
 		Header: &header,
@@ -132,7 +132,7 @@ func TestSignedHeaderValidateBasic(t *testing.T) {
 	}
 
 	validSignedHeader := SignedHeader{Header: &h, Commit: commit}
-	validSignedHeader.Commit.BlockID.Hash = validSignedHeader.Hash()
+	validSignedHeader.Commit.BlockID.Hash = validSignedHeader.Hash(CanonicalEncodingProto)
 	invalidSignedHeader := SignedHeader{}
 
 	testCases := []struct {
@@ -153,7 +153,7 @@ func TestSignedHeaderValidateBasic(t *testing.T) {
 				Header: tc.shHeader,
 				Commit: tc.shCommit,
 			}
-			err := sh.ValidateBasic(validSignedHeader.Header.ChainID)
+			err := sh.ValidateBasic(validSignedHeader.Header.ChainID, CanonicalEncodingProto)
 			assert.Equalf(
 				t,
 				tc.expectErr,
@@ -40,6 +40,7 @@ type ConsensusParams struct {
 	Evidence  EvidenceParams  `json:"evidence"`
 	Validator ValidatorParams `json:"validator"`
 	Version   VersionParams   `json:"version"`
+	Encoding  EncodingParams  `json:"encoding"`
 }
 
 // BlockParams define limits on the block size and gas plus minimum time
@@ -54,6 +55,20 @@ type EvidenceParams struct {
 	MaxAgeNumBlocks int64         `json:"max_age_num_blocks"` // only accept new evidence more recent than this
 	MaxAgeDuration  time.Duration `json:"max_age_duration"`
 	MaxBytes        int64         `json:"max_bytes"`
+	// DisabledTypes are the types of evidence, see EvidenceType, which can't
+	// be committed.
+	DisabledTypes []string `json:"disabled_types"`
+}
+
+// IsEnabled returns true if ev is of a type which can be committed.
+func (params EvidenceParams) IsEnabled(ev Evidence) bool {
+	evType := EvidenceType(ev)
+	for _, disabled := range params.DisabledTypes {
+		if disabled == evType {
+			return false
+		}
+	}
+	return true
 }
 
 // ValidatorParams restrict the public key types validators can use.
@@ -66,6 +81,12 @@ type VersionParams struct {
 	App uint64 `json:"app"`
 }
 
+// EncodingParams select the canonical encoding of the votes, headers and
+// validator sets. They are fixed by the genesis.
+type EncodingParams struct {
+	Canonical CanonicalEncoding `json:"canonical"`
+}
+
 // DefaultConsensusParams returns a default ConsensusParams.
 func DefaultConsensusParams() *ConsensusParams {
 	return &ConsensusParams{
@@ -73,6 +94,7 @@ func DefaultConsensusParams() *ConsensusParams {
 		Evidence:  DefaultEvidenceParams(),
 		Validator: DefaultValidatorParams(),
 		Version:   DefaultVersionParams(),
+		Encoding:  DefaultEncodingParams(),
 	}
 }
 
@@ -107,6 +129,14 @@ func DefaultVersionParams() VersionParams {
 	}
 }
 
+// DefaultEncodingParams returns a default EncodingParams, which select the
+// protobuf encoding.
+func DefaultEncodingParams() EncodingParams {
+	return EncodingParams{
+		Canonical: CanonicalEncodingProto,
+	}
+}
+
 func IsValidPubkeyType(params ValidatorParams, pubkeyType string) bool {
 	for i := 0; i < len(params.PubKeyTypes); i++ {
 		if params.PubKeyTypes[i] == pubkeyType {
@@ -153,6 +183,13 @@ func (params ConsensusParams) ValidateBasic() error {
 			params.Evidence.MaxBytes)
 	}
 
+	for i, evType := range params.Evidence.DisabledTypes {
+		if !isEvidenceType(evType) {
+			return fmt.Errorf("params.Evidence.DisabledTypes[%d], %s, is an unknown evidence type",
+				i, evType)
+		}
+	}
+
 	if len(params.Validator.PubKeyTypes) == 0 {
 		return errors.New("len(Validator.PubKeyTypes) must be greater than 0")
 	}
@@ -166,6 +203,31 @@ func (params ConsensusParams) ValidateBasic() error {
 		}
 	}
 
+	if err := params.Encoding.Canonical.ValidateBasic(); err != nil {
+		return fmt.Errorf("encoding.Canonical: %w", err)
+	}
+	if params.Encoding.Canonical == CanonicalEncodingFixedWidth {
+		for i, keyType := range params.Validator.PubKeyTypes {
+			if keyType != ABCIPubKeyTypeBn254 {
+				return fmt.Errorf("params.Validator.PubKeyTypes[%d], %s, is not available with the %s encoding",
+					i, keyType, CanonicalEncodingFixedWidth)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateUpdate validates the updates of the params, which cannot change the
+// encoding.
+func (params ConsensusParams) ValidateUpdate(params2 *cmtproto.ConsensusParams) error {
+	if params2 == nil || params2.Encoding == nil {
+		return nil
+	}
+	if CanonicalEncoding(params2.Encoding.Canonical) != params.Encoding.Canonical {
+		return fmt.Errorf("encoding.Canonical cannot be updated from %s to %s",
+			params.Encoding.Canonical, params2.Encoding.Canonical)
+	}
 	return nil
 }
 
@@ -211,6 +273,7 @@ func (params ConsensusParams) Update(params2 *cmtproto.ConsensusParams) Consensu
 		res.Evidence.MaxAgeNumBlocks = params2.Evidence.MaxAgeNumBlocks
 		res.Evidence.MaxAgeDuration = params2.Evidence.MaxAgeDuration
 		res.Evidence.MaxBytes = params2.Evidence.MaxBytes
+		res.Evidence.DisabledTypes = append([]string(nil), params2.Evidence.DisabledTypes...)
 	}
 	if params2.Validator != nil {
 		// Copy params2.Validator.PubkeyTypes, and set result's value to the copy.
@@ -220,6 +283,9 @@ func (params ConsensusParams) Update(params2 *cmtproto.ConsensusParams) Consensu
 	if params2.Version != nil {
 		res.Version.App = params2.Version.App
 	}
+	if params2.Encoding != nil {
+		res.Encoding.Canonical = CanonicalEncoding(params2.Encoding.Canonical)
+	}
 	return res
 }
 
@@ -233,6 +299,7 @@ func (params *ConsensusParams) ToProto() cmtproto.ConsensusParams {
 			MaxAgeNumBlocks: params.Evidence.MaxAgeNumBlocks,
 			MaxAgeDuration:  params.Evidence.MaxAgeDuration,
 			MaxBytes:        params.Evidence.MaxBytes,
+			DisabledTypes:   params.Evidence.DisabledTypes,
 		},
 		Validator: &cmtproto.ValidatorParams{
 			PubKeyTypes: params.Validator.PubKeyTypes,
@@ -240,11 +307,14 @@ func (params *ConsensusParams) ToProto() cmtproto.ConsensusParams {
 		Version: &cmtproto.VersionParams{
 			App: params.Version.App,
 		},
+		Encoding: &cmtproto.EncodingParams{
+			Canonical: string(params.Encoding.Canonical),
+		},
 	}
 }
 
 func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams {
-	return ConsensusParams{
+	c := ConsensusParams{
 		Block: BlockParams{
 			MaxBytes: pbParams.Block.MaxBytes,
 			MaxGas:   pbParams.Block.MaxGas,
@@ -253,6 +323,7 @@ func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams
 			MaxAgeNumBlocks: pbParams.Evidence.MaxAgeNumBlocks,
 			MaxAgeDuration:  pbParams.Evidence.MaxAgeDuration,
 			MaxBytes:        pbParams.Evidence.MaxBytes,
+			DisabledTypes:   pbParams.Evidence.DisabledTypes,
 		},
 		Validator: ValidatorParams{
 			PubKeyTypes: pbParams.Validator.PubKeyTypes,
@@ -260,5 +331,11 @@ func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams
 		Version: VersionParams{
 			App: pbParams.Version.App,
 		},
+		// the params stored before the encoding was introduced have none
+		Encoding: DefaultEncodingParams(),
+	}
+	if pbParams.Encoding != nil {
+		c.Encoding.Canonical = CanonicalEncoding(pbParams.Encoding.Canonical)
 	}
+	return c
 }
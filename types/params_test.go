@@ -14,6 +14,7 @@ import (
 var (
 	valEd25519   = []string{ABCIPubKeyTypeEd25519}
 	valSecp256k1 = []string{ABCIPubKeyTypeSecp256k1}
+	valBn254     = []string{ABCIPubKeyTypeBn254}
 )
 
 func TestConsensusParamsValidation(t *testing.T) {
@@ -38,6 +39,14 @@ func TestConsensusParamsValidation(t *testing.T) {
 		11: {makeParams(1, 0, 2, 0, []string{}), false},
 		// test invalid pubkey type provided
 		12: {makeParams(1, 0, 2, 0, []string{"potatoes make good pubkeys"}), false},
+		// test encoding params
+		13: {withEncoding(makeParams(1, 0, 2, 0, valBn254), CanonicalEncodingFixedWidth), true},
+		14: {withEncoding(makeParams(1, 0, 2, 0, valEd25519), CanonicalEncodingFixedWidth), false},
+		15: {withEncoding(makeParams(1, 0, 2, 0, valEd25519), "amino"), false},
+		16: {withEncoding(makeParams(1, 0, 2, 0, valEd25519), ""), false},
+		// test disabled evidence types
+		17: {withDisabledEvidence(makeParams(1, 0, 2, 0, valEd25519), EvidenceTypeLightClientAttack), true},
+		18: {withDisabledEvidence(makeParams(1, 0, 2, 0, valEd25519), "amnesia"), false},
 	}
 	for i, tc := range testCases {
 		if tc.valid {
@@ -67,9 +76,20 @@ func makeParams(
 		Validator: ValidatorParams{
 			PubKeyTypes: pubkeyTypes,
 		},
+		Encoding: DefaultEncodingParams(),
 	}
 }
 
+func withEncoding(params ConsensusParams, encoding CanonicalEncoding) ConsensusParams {
+	params.Encoding.Canonical = encoding
+	return params
+}
+
+func withDisabledEvidence(params ConsensusParams, evTypes ...string) ConsensusParams {
+	params.Evidence.DisabledTypes = evTypes
+	return params
+}
+
 func TestConsensusParamsHash(t *testing.T) {
 	params := []ConsensusParams{
 		makeParams(4, 2, 3, 1, valEd25519),
@@ -128,6 +148,18 @@ func TestConsensusParamsUpdate(t *testing.T) {
 			},
 			makeParams(100, 200, 300, 50, valSecp256k1),
 		},
+		// disabling evidence types
+		{
+			makeParams(1, 2, 3, 0, valEd25519),
+			&cmtproto.ConsensusParams{
+				Evidence: &cmtproto.EvidenceParams{
+					MaxAgeNumBlocks: 3,
+					MaxAgeDuration:  time.Duration(3),
+					DisabledTypes:   []string{EvidenceTypeDuplicateVote},
+				},
+			},
+			withDisabledEvidence(makeParams(1, 2, 3, 0, valEd25519), EvidenceTypeDuplicateVote),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -146,6 +178,21 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 	assert.EqualValues(t, 1, updated.Version.App)
 }
 
+func TestConsensusParamsValidateUpdate(t *testing.T) {
+	params := makeParams(1, 2, 3, 0, valEd25519)
+
+	assert.NoError(t, params.ValidateUpdate(nil))
+	assert.NoError(t, params.ValidateUpdate(&cmtproto.ConsensusParams{
+		Block: &cmtproto.BlockParams{MaxBytes: 100, MaxGas: 200},
+	}))
+	assert.NoError(t, params.ValidateUpdate(&cmtproto.ConsensusParams{
+		Encoding: &cmtproto.EncodingParams{Canonical: string(CanonicalEncodingProto)},
+	}))
+	assert.Error(t, params.ValidateUpdate(&cmtproto.ConsensusParams{
+		Encoding: &cmtproto.EncodingParams{Canonical: string(CanonicalEncodingFixedWidth)},
+	}))
+}
+
 func TestProto(t *testing.T) {
 	params := []ConsensusParams{
 		makeParams(4, 2, 3, 1, valEd25519),
@@ -156,6 +203,8 @@ func TestProto(t *testing.T) {
 		makeParams(9, 5, 4, 1, valEd25519),
 		makeParams(7, 8, 9, 1, valEd25519),
 		makeParams(4, 6, 5, 1, valEd25519),
+		withEncoding(makeParams(4, 6, 5, 1, valBn254), CanonicalEncodingFixedWidth),
+		withDisabledEvidence(makeParams(4, 6, 5, 1, valEd25519), EvidenceTypeLightClientAttack),
 	}
 
 	for i := range params {
@@ -166,4 +215,23 @@ func TestProto(t *testing.T) {
 		assert.Equal(t, params[i], oriParams)
 
 	}
+
+	// the params stored before the encoding params have none
+	old := makeParams(4, 6, 5, 1, valEd25519)
+	pbParams := old.ToProto()
+	pbParams.Encoding = nil
+	assert.Equal(t, DefaultEncodingParams(), ConsensusParamsFromProto(pbParams).Encoding)
+}
+
+func TestEvidenceParamsIsEnabled(t *testing.T) {
+	params := DefaultEvidenceParams()
+	dve := &DuplicateVoteEvidence{}
+	lcae := &LightClientAttackEvidence{}
+	assert.True(t, params.IsEnabled(dve))
+	assert.True(t, params.IsEnabled(lcae))
+
+	params.DisabledTypes = []string{EvidenceTypeLightClientAttack}
+	assert.True(t, params.IsEnabled(dve))
+	assert.True(t, params.IsEnabled(&Bn254DuplicateVoteEvidence{}))
+	assert.False(t, params.IsEnabled(lcae))
 }
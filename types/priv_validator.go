@@ -15,7 +15,8 @@ import (
 type PrivValidator interface {
 	GetPubKey() (crypto.PubKey, error)
 
-	SignVote(chainID string, vote *cmtproto.Vote) error
+	// SignVote signs the vote, encoded with the canonical encoding of the chain.
+	SignVote(chainID string, encoding CanonicalEncoding, vote *cmtproto.Vote) error
 	SignProposal(chainID string, proposal *cmtproto.Proposal) error
 }
 
@@ -70,13 +71,13 @@ func (pv MockPV) GetPubKey() (crypto.PubKey, error) {
 }
 
 // Implements PrivValidator.
-func (pv MockPV) SignVote(chainID string, vote *cmtproto.Vote) error {
+func (pv MockPV) SignVote(chainID string, encoding CanonicalEncoding, vote *cmtproto.Vote) error {
 	useChainID := chainID
 	if pv.breakVoteSigning {
 		useChainID = "incorrect-chain-id"
 	}
 
-	signBytes := VoteSignBytes(useChainID, vote)
+	signBytes := VoteSignBytes(useChainID, encoding, vote)
 	sig, err := pv.PrivKey.Sign(signBytes)
 	if err != nil {
 		return err
@@ -129,7 +130,7 @@ type ErroringMockPV struct {
 var ErroringMockPVErr = errors.New("erroringMockPV always returns an error")
 
 // Implements PrivValidator.
-func (pv *ErroringMockPV) SignVote(chainID string, vote *cmtproto.Vote) error {
+func (pv *ErroringMockPV) SignVote(chainID string, encoding CanonicalEncoding, vote *cmtproto.Vote) error {
 	return ErroringMockPVErr
 }
 
@@ -39,7 +39,7 @@ func MakeCommit(blockID BlockID, height int64, round int32,
 
 func signAddVote(privVal PrivValidator, vote *Vote, voteSet *VoteSet) (signed bool, err error) {
 	v := vote.ToProto()
-	err = privVal.SignVote(voteSet.ChainID(), v)
+	err = privVal.SignVote(voteSet.ChainID(), voteSet.Encoding(), v)
 	if err != nil {
 		return false, err
 	}
@@ -47,6 +47,7 @@ func signAddVote(privVal PrivValidator, vote *Vote, voteSet *VoteSet) (signed bo
 	return voteSet.AddVote(vote)
 }
 
+// MakeVote returns a precommit signed by privVal, with the proto encoding.
 func MakeVote(
 	height int64,
 	blockID BlockID,
@@ -72,7 +73,7 @@ func MakeVote(
 	}
 	v := vote.ToProto()
 
-	if err := privVal.SignVote(chainID, v); err != nil {
+	if err := privVal.SignVote(chainID, CanonicalEncodingProto, v); err != nil {
 		return nil, err
 	}
 
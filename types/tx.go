@@ -9,6 +9,7 @@ import (
 	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 )
 
@@ -155,6 +156,18 @@ func (tp TxProof) Validate(dataHash []byte) error {
 	return nil
 }
 
+// ICS23 returns the proof as an ICS-23 commitment proof, in the format of
+// merkle.ICS23ProofSpec. Since the leaves of the tree are the hashes of the
+// transactions, the key of the proof is the first byte of the hash of the
+// transaction, and its value the rest of the hash.
+func (tp TxProof) ICS23() (*cmtcrypto.CommitmentProof, error) {
+	exist, err := tp.Proof.ICS23ExistenceProof(tp.Leaf())
+	if err != nil {
+		return nil, err
+	}
+	return &cmtcrypto.CommitmentProof{Exist: exist}, nil
+}
+
 func (tp TxProof) ToProto() cmtproto.TxProof {
 
 	pbProof := tp.Proof.ToProto()
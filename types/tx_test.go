@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/crypto/merkle"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	ctest "github.com/cometbft/cometbft/libs/test"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -94,6 +95,21 @@ func TestValidTxProof(t *testing.T) {
 	}
 }
 
+func TestTxProofICS23(t *testing.T) {
+	txs := makeTxs(7, 81)
+	root := txs.Hash()
+	for i, tx := range txs {
+		proof, err := txs.Proof(i).ICS23()
+		require.NoError(t, err)
+		require.NotNil(t, proof.Exist)
+		assert.Equal(t, tx.Hash(), append(proof.Exist.Key, proof.Exist.Value...))
+
+		proofRoot, err := merkle.ICS23Root(proof.Exist)
+		require.NoError(t, err)
+		assert.EqualValues(t, root, proofRoot)
+	}
+}
+
 func TestTxProofUnchangable(t *testing.T) {
 	// run the other test a bunch...
 	for i := 0; i < 40; i++ {
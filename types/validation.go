@@ -22,7 +22,7 @@ func shouldBatchVerify(vals *ValidatorSet, commit *Commit) bool {
 // application that depends on the LastCommitInfo sent in BeginBlock, which
 // includes which validators signed. For instance, Gaia incentivizes proposers
 // with a bonus for including more than +2/3 of the signatures.
-func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
+func VerifyCommit(chainID string, encoding CanonicalEncoding, vals *ValidatorSet, blockID BlockID,
 	height int64, commit *Commit) error {
 	// run a basic validation of the arguments
 	if err := verifyBasicValsAndCommit(vals, commit, height, blockID); err != nil {
@@ -41,12 +41,12 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
-		return verifyCommitBatch(chainID, vals, commit,
+		return verifyCommitBatch(chainID, encoding, vals, commit,
 			votingPowerNeeded, ignore, count, true, true)
 	}
 
 	// if verification failed or is not supported then fallback to single verification
-	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+	return verifyCommitSingle(chainID, encoding, vals, commit, votingPowerNeeded,
 		ignore, count, true, true)
 }
 
@@ -56,7 +56,7 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 //
 // This method is primarily used by the light client and does not check all the
 // signatures.
-func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
+func VerifyCommitLight(chainID string, encoding CanonicalEncoding, vals *ValidatorSet, blockID BlockID,
 	height int64, commit *Commit) error {
 	// run a basic validation of the arguments
 	if err := verifyBasicValsAndCommit(vals, commit, height, blockID); err != nil {
@@ -74,12 +74,12 @@ func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
 
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
-		return verifyCommitBatch(chainID, vals, commit,
+		return verifyCommitBatch(chainID, encoding, vals, commit,
 			votingPowerNeeded, ignore, count, false, true)
 	}
 
 	// if verification failed or is not supported then fallback to single verification
-	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+	return verifyCommitSingle(chainID, encoding, vals, commit, votingPowerNeeded,
 		ignore, count, false, true)
 }
 
@@ -91,7 +91,13 @@ func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
 //
 // This method is primarily used by the light client and does not check all the
 // signatures.
-func VerifyCommitLightTrusting(chainID string, vals *ValidatorSet, commit *Commit, trustLevel cmtmath.Fraction) error {
+func VerifyCommitLightTrusting(
+	chainID string,
+	encoding CanonicalEncoding,
+	vals *ValidatorSet,
+	commit *Commit,
+	trustLevel cmtmath.Fraction,
+) error {
 	// sanity checks
 	if vals == nil {
 		return errors.New("nil validator set")
@@ -120,12 +126,12 @@ func VerifyCommitLightTrusting(chainID string, vals *ValidatorSet, commit *Commi
 	// correspond with the validator set that signed the block we need to look
 	// up by address rather than index.
 	if shouldBatchVerify(vals, commit) {
-		return verifyCommitBatch(chainID, vals, commit,
+		return verifyCommitBatch(chainID, encoding, vals, commit,
 			votingPowerNeeded, ignore, count, false, false)
 	}
 
 	// attempt with single verification
-	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+	return verifyCommitSingle(chainID, encoding, vals, commit, votingPowerNeeded,
 		ignore, count, false, false)
 }
 
@@ -151,6 +157,7 @@ func ValidateHash(h []byte) error {
 // usable via `shouldVerifyBatch(vals, commit)`.
 func verifyCommitBatch(
 	chainID string,
+	encoding CanonicalEncoding,
 	vals *ValidatorSet,
 	commit *Commit,
 	votingPowerNeeded int64,
@@ -203,10 +210,10 @@ func verifyCommitBatch(
 		}
 
 		// Validate signature.
-		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
+		voteSignBytes := commit.VoteSignBytes(chainID, encoding, int32(idx))
 
 		// add the key, sig and message to the verifier
-		if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
+		if err := bv.Add(val.VerifyingPubKey(), voteSignBytes, commitSig.Signature); err != nil {
 			return err
 		}
 		batchSigIdxs = append(batchSigIdxs, idx)
@@ -264,6 +271,7 @@ func verifyCommitBatch(
 // CONTRACT: both commit and validator set should have passed validate basic
 func verifyCommitSingle(
 	chainID string,
+	encoding CanonicalEncoding,
 	vals *ValidatorSet,
 	commit *Commit,
 	votingPowerNeeded int64,
@@ -306,9 +314,9 @@ func verifyCommitSingle(
 			seenVals[valIdx] = idx
 		}
 
-		voteSignBytes = commit.VoteSignBytes(chainID, int32(idx))
+		voteSignBytes = commit.VoteSignBytes(chainID, encoding, int32(idx))
 
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
+		if !val.VerifyingPubKey().VerifySignature(voteSignBytes, commitSig.Signature) {
 			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
 		}
 
@@ -87,7 +87,7 @@ func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 
 				v := vote.ToProto()
 
-				require.NoError(t, vals[vi%len(vals)].SignVote(tc.chainID, v))
+				require.NoError(t, vals[vi%len(vals)].SignVote(tc.chainID, CanonicalEncodingProto, v))
 				vote.Signature = v.Signature
 
 				sigs[vi] = vote.CommitSig()
@@ -96,7 +96,7 @@ func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 			}
 			commit := NewCommit(tc.height, round, tc.blockID, sigs)
 
-			err := valSet.VerifyCommit(chainID, blockID, height, commit)
+			err := valSet.VerifyCommit(chainID, CanonicalEncodingProto, blockID, height, commit)
 			if tc.expErr {
 				if assert.Error(t, err, "VerifyCommit") {
 					assert.Contains(t, err.Error(), tc.description, "VerifyCommit")
@@ -105,7 +105,7 @@ func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 				assert.NoError(t, err, "VerifyCommit")
 			}
 
-			err = valSet.VerifyCommitLight(chainID, blockID, height, commit)
+			err = valSet.VerifyCommitLight(chainID, CanonicalEncodingProto, blockID, height, commit)
 			if tc.expErr {
 				if assert.Error(t, err, "VerifyCommitLight") {
 					assert.Contains(t, err.Error(), tc.description, "VerifyCommitLight")
@@ -118,7 +118,7 @@ func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 			if totalVotes != tc.valSize || !tc.blockID.Equals(blockID) || tc.height != height {
 				tc.expErr = false
 			}
-			err = valSet.VerifyCommitLightTrusting(chainID, commit, trustLevel)
+			err = valSet.VerifyCommitLightTrusting(chainID, CanonicalEncodingProto, commit, trustLevel)
 			if tc.expErr {
 				if assert.Error(t, err, "VerifyCommitLightTrusting") {
 					assert.Contains(t, err.Error(), tc.description, "VerifyCommitLightTrusting")
@@ -140,17 +140,17 @@ func TestValidatorSet_VerifyCommit_CheckAllSignatures(t *testing.T) {
 	voteSet, valSet, vals := randVoteSet(h, 0, cmtproto.PrecommitType, 4, 10)
 	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
 	require.NoError(t, err)
-	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommit(chainID, CanonicalEncodingProto, blockID, h, commit))
 
 	// malleate 4th signature
 	vote := voteSet.GetByIndex(3)
 	v := vote.ToProto()
-	err = vals[3].SignVote("CentaurusA", v)
+	err = vals[3].SignVote("CentaurusA", CanonicalEncodingProto, v)
 	require.NoError(t, err)
 	vote.Signature = v.Signature
 	commit.Signatures[3] = vote.CommitSig()
 
-	err = valSet.VerifyCommit(chainID, blockID, h, commit)
+	err = valSet.VerifyCommit(chainID, CanonicalEncodingProto, blockID, h, commit)
 	if assert.Error(t, err) {
 		assert.Contains(t, err.Error(), "wrong signature (#3)")
 	}
@@ -166,17 +166,17 @@ func TestValidatorSet_VerifyCommitLight_ReturnsAsSoonAsMajorityOfVotingPowerSign
 	voteSet, valSet, vals := randVoteSet(h, 0, cmtproto.PrecommitType, 4, 10)
 	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
 	require.NoError(t, err)
-	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommit(chainID, CanonicalEncodingProto, blockID, h, commit))
 
 	// malleate 4th signature (3 signatures are enough for 2/3+)
 	vote := voteSet.GetByIndex(3)
 	v := vote.ToProto()
-	err = vals[3].SignVote("CentaurusA", v)
+	err = vals[3].SignVote("CentaurusA", CanonicalEncodingProto, v)
 	require.NoError(t, err)
 	vote.Signature = v.Signature
 	commit.Signatures[3] = vote.CommitSig()
 
-	err = valSet.VerifyCommitLight(chainID, blockID, h, commit)
+	err = valSet.VerifyCommitLight(chainID, CanonicalEncodingProto, blockID, h, commit)
 	assert.NoError(t, err)
 }
 
@@ -190,17 +190,17 @@ func TestValidatorSet_VerifyCommitLightTrusting_ReturnsAsSoonAsTrustLevelOfVotin
 	voteSet, valSet, vals := randVoteSet(h, 0, cmtproto.PrecommitType, 4, 10)
 	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
 	require.NoError(t, err)
-	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommit(chainID, CanonicalEncodingProto, blockID, h, commit))
 
 	// malleate 3rd signature (2 signatures are enough for 1/3+ trust level)
 	vote := voteSet.GetByIndex(2)
 	v := vote.ToProto()
-	err = vals[2].SignVote("CentaurusA", v)
+	err = vals[2].SignVote("CentaurusA", CanonicalEncodingProto, v)
 	require.NoError(t, err)
 	vote.Signature = v.Signature
 	commit.Signatures[2] = vote.CommitSig()
 
-	err = valSet.VerifyCommitLightTrusting(chainID, commit, cmtmath.Fraction{Numerator: 1, Denominator: 3})
+	err = valSet.VerifyCommitLightTrusting(chainID, CanonicalEncodingProto, commit, cmtmath.Fraction{Numerator: 1, Denominator: 3})
 	assert.NoError(t, err)
 }
 
@@ -235,7 +235,7 @@ func TestValidatorSet_VerifyCommitLightTrusting(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		err = tc.valSet.VerifyCommitLightTrusting("test_chain_id", commit,
+		err = tc.valSet.VerifyCommitLightTrusting("test_chain_id", CanonicalEncodingProto, commit,
 			cmtmath.Fraction{Numerator: 1, Denominator: 3})
 		if tc.err {
 			assert.Error(t, err)
@@ -253,7 +253,7 @@ func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	err = valSet.VerifyCommitLightTrusting("test_chain_id", commit,
+	err = valSet.VerifyCommitLightTrusting("test_chain_id", CanonicalEncodingProto, commit,
 		cmtmath.Fraction{Numerator: 25, Denominator: 55})
 	if assert.Error(t, err) {
 		assert.Contains(t, err.Error(), "int64 overflow")
@@ -21,6 +21,9 @@ type Validator struct {
 	VotingPower int64         `json:"voting_power"`
 
 	ProposerPriority int64 `json:"proposer_priority"`
+
+	// decoded is PubKey decoded, shared by the copies of the validator.
+	decoded crypto.PubKey
 }
 
 // NewValidator returns a new validator with the given pubkey and voting power.
@@ -30,9 +33,39 @@ func NewValidator(pubKey crypto.PubKey, votingPower int64) *Validator {
 		PubKey:           pubKey,
 		VotingPower:      votingPower,
 		ProposerPriority: 0,
+		decoded:          decodePubKey(pubKey),
 	}
 }
 
+// decodePubKey returns the public key decoded, or nil if it can't be.
+func decodePubKey(pubKey crypto.PubKey) crypto.PubKey {
+	decoder, ok := pubKey.(crypto.PubKeyDecoder)
+	if !ok {
+		return nil
+	}
+	decoded, err := decoder.Decode()
+	if err != nil {
+		// the signatures will be rejected by the key itself
+		return nil
+	}
+	return decoded
+}
+
+// VerifyingPubKey returns the public key to verify the signatures of the
+// validator with: PubKey decoded (e.g. with its curve point decompressed)
+// when the validator was created, if its type supports it, so that verifying
+// the signatures of the validator doesn't decode it again.
+//
+// The decoded key is dropped if PubKey changes. PubKey is returned as is if
+// it can't be decoded, or if the validator wasn't created with NewValidator
+// or ValidatorFromProto.
+func (v *Validator) VerifyingPubKey() crypto.PubKey {
+	if v.decoded == nil || !v.decoded.Equals(v.PubKey) {
+		return v.PubKey
+	}
+	return v.decoded
+}
+
 // ValidateBasic performs basic validation.
 func (v *Validator) ValidateBasic() error {
 	if v == nil {
@@ -169,6 +202,7 @@ func ValidatorFromProto(vp *cmtproto.Validator) (*Validator, error) {
 	v.PubKey = pk
 	v.VotingPower = vp.GetVotingPower()
 	v.ProposerPriority = vp.GetProposerPriority()
+	v.decoded = decodePubKey(pk)
 
 	return v, nil
 }
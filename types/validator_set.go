@@ -9,7 +9,9 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 )
@@ -344,7 +346,18 @@ func (vals *ValidatorSet) findProposer() *Validator {
 
 // Hash returns the Merkle root hash build using validators (as leaves) in the
 // set.
-func (vals *ValidatorSet) Hash() []byte {
+//
+// With the fixed-width canonical encoding, it is the SHA-256 hash of
+// FixedWidthBytes instead, or nil if any of the validators doesn't have a bn254
+// key.
+func (vals *ValidatorSet) Hash(encoding CanonicalEncoding) []byte {
+	if encoding == CanonicalEncodingFixedWidth {
+		bz, err := vals.FixedWidthBytes()
+		if err != nil {
+			return nil
+		}
+		return tmhash.Sum(bz)
+	}
 	bzs := make([][]byte, len(vals.Validators))
 	for i, val := range vals.Validators {
 		bzs[i] = val.Bytes()
@@ -659,23 +672,39 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 
 // VerifyCommit verifies +2/3 of the set had signed the given commit and all
 // other signatures are valid
-func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
+func (vals *ValidatorSet) VerifyCommit(chainID string, encoding CanonicalEncoding, blockID BlockID,
 	height int64, commit *Commit) error {
-	return VerifyCommit(chainID, vals, blockID, height, commit)
+	return VerifyCommit(chainID, encoding, vals, blockID, height, commit)
+}
+
+// VerifyAggregatedCommit verifies +2/3 of the set had signed the given
+// aggregated commit.
+func (vals *ValidatorSet) VerifyAggregatedCommit(chainID string, encoding CanonicalEncoding, blockID BlockID,
+	height int64, ac *AggregatedCommit) error {
+	return VerifyAggregatedCommit(chainID, encoding, vals, blockID, height, ac)
 }
 
 // LIGHT CLIENT VERIFICATION METHODS
 
 // VerifyCommitLight verifies +2/3 of the set had signed the given commit.
-func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
+func (vals *ValidatorSet) VerifyCommitLight(chainID string, encoding CanonicalEncoding, blockID BlockID,
 	height int64, commit *Commit) error {
-	return VerifyCommitLight(chainID, vals, blockID, height, commit)
+	return VerifyCommitLight(chainID, encoding, vals, blockID, height, commit)
 }
 
 // VerifyCommitLightTrusting verifies that trustLevel of the validator set signed
 // this commit.
-func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Commit, trustLevel cmtmath.Fraction) error {
-	return VerifyCommitLightTrusting(chainID, vals, commit, trustLevel)
+func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, encoding CanonicalEncoding, commit *Commit,
+	trustLevel cmtmath.Fraction) error {
+	return VerifyCommitLightTrusting(chainID, encoding, vals, commit, trustLevel)
+}
+
+// VerifyAggregatedCommitLightTrusting verifies that trustLevel of the
+// validator set signed the aggregated commit of signers, without verifying
+// its signature.
+func (vals *ValidatorSet) VerifyAggregatedCommitLightTrusting(signers *ValidatorSet, ac *AggregatedCommit,
+	trustLevel cmtmath.Fraction) error {
+	return VerifyAggregatedCommitLightTrusting(vals, signers, ac, trustLevel)
 }
 
 // findPreviousProposer reverses the compare proposer priority function to find the validator
@@ -894,6 +923,27 @@ func RandValidatorSet(numValidators int, votingPower int64) (*ValidatorSet, []Pr
 	return NewValidatorSet(valz), privValidators
 }
 
+// RandBn254ValidatorSet is like RandValidatorSet, but with bn254 keys, so
+// that the commits of the set are aggregated.
+//
+// EXPOSED FOR TESTING.
+func RandBn254ValidatorSet(numValidators int, votingPower int64) (*ValidatorSet, []PrivValidator) {
+	var (
+		valz           = make([]*Validator, numValidators)
+		privValidators = make([]PrivValidator, numValidators)
+	)
+
+	for i := 0; i < numValidators; i++ {
+		privValidator := NewMockPVWithParams(bn254.GenPrivKey(), false, false)
+		valz[i] = privValidator.ExtractIntoValidator(votingPower)
+		privValidators[i] = privValidator
+	}
+
+	sort.Sort(PrivValidatorsByAddress(privValidators))
+
+	return NewValidatorSet(valz), privValidators
+}
+
 // safe addition/subtraction/multiplication
 
 func safeAdd(a, b int64) (int64, bool) {
@@ -0,0 +1,210 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+)
+
+// ValidatorSetCommitmentScheme is the scheme of ValidatorSet.Commitment: the
+// MiMC hash over the scalar field of bn254 (Miyaguchi-Preneel, 91 rounds,
+// gnark's constants).
+const ValidatorSetCommitmentScheme = "mimc-bn254"
+
+// fieldElementSize is the size of a (big-endian) field element, and
+// limbSize the size of the limbs the coordinates of the public keys are split
+// into, so that they fit in the scalar field.
+const (
+	fieldElementSize = mimc.BlockSize
+	limbSize         = fieldElementSize / 2
+)
+
+// Commitment returns a circuit-friendly commitment to the validator set, i.e.
+// the MiMC hash of the field elements
+//
+//	X_hi(pk_0), X_lo(pk_0), Y_hi(pk_0), Y_lo(pk_0), power_0,
+//	...
+//	X_hi(pk_n-1), X_lo(pk_n-1), Y_hi(pk_n-1), Y_lo(pk_n-1), power_n-1,
+//	total_power
+//
+// where X and Y are the affine coordinates of the public key of each
+// validator, in the order of the set, split into their 128-bit high and low
+// limbs. Unlike Hash, it can be computed cheaply in a zk circuit.
+//
+// It returns an error if any of the validators doesn't have a bn254 key.
+func (vals *ValidatorSet) Commitment() ([]byte, error) {
+	elems := make([]byte, 0, (5*len(vals.Validators)+1)*fieldElementSize)
+	for i, val := range vals.Validators {
+		var err error
+		if elems, err = appendValidatorFieldElements(elems, i, val); err != nil {
+			return nil, err
+		}
+	}
+	elems = appendUint64FieldElement(elems, uint64(vals.TotalVotingPower()))
+
+	h := mimc.NewMiMC()
+	if _, err := h.Write(elems); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// IncrementalCommitment is the commitment to a validator set (see
+// ValidatorSet.Commitment), which is updated from the diffs applied to the
+// set rather than rehashed from scratch.
+//
+// Since MiMC is a chain over the field elements, it keeps the state of the
+// chain after each validator, and only rehashes the validators from the first
+// one whose position in the set changed. Changes to the validators with the
+// least voting power, which come last, are thus the cheapest.
+type IncrementalCommitment struct {
+	vals *ValidatorSet
+	// states[i] is the state of the chain after the first i validators.
+	states []fr.Element
+}
+
+// NewIncrementalCommitment returns the commitment to a copy of vals. It
+// returns an error if any of the validators doesn't have a bn254 key.
+func NewIncrementalCommitment(vals *ValidatorSet) (*IncrementalCommitment, error) {
+	c := &IncrementalCommitment{vals: vals.Copy()}
+	states, err := absorbValidators(make([]fr.Element, 1, len(vals.Validators)+1), c.vals.Validators)
+	if err != nil {
+		return nil, err
+	}
+	c.states = states
+	return c, nil
+}
+
+// ValidatorSet returns the validator set committed to. It must not be
+// modified.
+func (c *IncrementalCommitment) ValidatorSet() *ValidatorSet {
+	return c.vals
+}
+
+// ApplyDiff applies the diff to the validator set (see ValidatorSet.ApplyDiff)
+// and updates the commitment. If an error is returned, the commitment is
+// unchanged.
+func (c *IncrementalCommitment) ApplyDiff(diff *ValidatorSetDiff) error {
+	vals := c.vals.Copy()
+	if err := vals.ApplyDiff(diff); err != nil {
+		return err
+	}
+
+	i := 0
+	for i < len(vals.Validators) && i < len(c.vals.Validators) &&
+		bytes.Equal(vals.Validators[i].Address, c.vals.Validators[i].Address) &&
+		vals.Validators[i].VotingPower == c.vals.Validators[i].VotingPower {
+		i++
+	}
+	states := make([]fr.Element, i+1, len(vals.Validators)+1)
+	copy(states, c.states[:i+1])
+	states, err := absorbValidators(states, vals.Validators[i:])
+	if err != nil {
+		return err
+	}
+
+	c.vals, c.states = vals, states
+	return nil
+}
+
+// Commitment returns the commitment to the validator set, equal to
+// ValidatorSet.Commitment.
+func (c *IncrementalCommitment) Commitment() []byte {
+	total := appendUint64FieldElement(nil, uint64(c.vals.TotalVotingPower()))
+	h, err := mimcAbsorb(c.states[len(c.states)-1], total)
+	if err != nil {
+		panic(err) // the total voting power is always a valid field element
+	}
+	bz := h.Bytes()
+	return bz[:]
+}
+
+// absorbValidators extends the states of the chain with the field elements of
+// vals, which follow the validators already absorbed.
+func absorbValidators(states []fr.Element, vals []*Validator) ([]fr.Element, error) {
+	offset := len(states) - 1
+	elems := make([]byte, 0, 5*fieldElementSize)
+	for i, val := range vals {
+		var err error
+		if elems, err = appendValidatorFieldElements(elems[:0], offset+i, val); err != nil {
+			return nil, err
+		}
+		h, err := mimcAbsorb(states[len(states)-1], elems)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, h)
+	}
+	return states, nil
+}
+
+var (
+	mimcConstantsOnce sync.Once
+	mimcConstants     []fr.Element
+)
+
+// mimcAbsorb returns the state of the MiMC chain h after absorbing the field
+// elements, as the Miyaguchi-Preneel construction of mimc does, i.e.
+// h = E_h(x) + h + x for each element x.
+func mimcAbsorb(h fr.Element, elems []byte) (fr.Element, error) {
+	mimcConstantsOnce.Do(func() {
+		constants := mimc.GetConstants()
+		mimcConstants = make([]fr.Element, len(constants))
+		for i := range constants {
+			mimcConstants[i].SetBigInt(&constants[i])
+		}
+	})
+
+	for i := 0; i < len(elems); i += fieldElementSize {
+		x, err := fr.BigEndian.Element((*[fieldElementSize]byte)(elems[i : i+fieldElementSize]))
+		if err != nil {
+			return h, err
+		}
+		// m = (m+h+c)^5 for each round constant c, then E_h(x) = m+h
+		m := x
+		for j := range mimcConstants {
+			var tmp fr.Element
+			tmp.Add(&m, &h).Add(&tmp, &mimcConstants[j])
+			m.Square(&tmp).Square(&m).Mul(&m, &tmp)
+		}
+		m.Add(&m, &h)
+		h.Add(&h, &m).Add(&h, &x)
+	}
+	return h, nil
+}
+
+// appendValidatorFieldElements appends the field elements of the i-th
+// validator of a set.
+func appendValidatorFieldElements(elems []byte, i int, val *Validator) ([]byte, error) {
+	pubKey, ok := val.PubKey.(bn254.PubKey)
+	if !ok {
+		return nil, fmt.Errorf("validator #%d has a %s key, expected %s", i, val.PubKey.Type(), bn254.KeyType)
+	}
+	x, y, err := pubKey.Coordinates()
+	if err != nil {
+		return nil, fmt.Errorf("validator #%d has an invalid key: %w", i, err)
+	}
+	elems = appendLimbs(elems, x[:])
+	elems = appendLimbs(elems, y[:])
+	return appendUint64FieldElement(elems, uint64(val.VotingPower)), nil
+}
+
+// appendLimbs appends the high and low limbs of a 256-bit big-endian number,
+// as field elements.
+func appendLimbs(elems, bz []byte) []byte {
+	var pad [fieldElementSize - limbSize]byte
+	elems = append(append(elems, pad[:]...), bz[:limbSize]...)
+	return append(append(elems, pad[:]...), bz[limbSize:]...)
+}
+
+func appendUint64FieldElement(elems []byte, v uint64) []byte {
+	var elem [fieldElementSize]byte
+	binary.BigEndian.PutUint64(elem[fieldElementSize-8:], v)
+	return append(elems, elem[:]...)
+}
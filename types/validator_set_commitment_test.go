@@ -0,0 +1,95 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+func TestValidatorSetCommitment(t *testing.T) {
+	valSet, _ := RandBn254ValidatorSet(2, 10)
+
+	commitment, err := valSet.Commitment()
+	require.NoError(t, err)
+	assert.Len(t, commitment, mimc.BlockSize)
+
+	// recompute it from the field elements
+	var elems []byte
+	appendElem := func(v *big.Int) {
+		elems = append(elems, v.FillBytes(make([]byte, mimc.BlockSize))...)
+	}
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	for _, val := range valSet.Validators {
+		x, y, err := val.PubKey.(bn254.PubKey).Coordinates()
+		require.NoError(t, err)
+		for _, c := range [][]byte{x[:], y[:]} {
+			n := new(big.Int).SetBytes(c)
+			appendElem(new(big.Int).Rsh(n, 128))
+			appendElem(new(big.Int).And(n, mask))
+		}
+		appendElem(big.NewInt(val.VotingPower))
+	}
+	appendElem(big.NewInt(valSet.TotalVotingPower()))
+	expected, err := mimc.Sum(elems)
+	require.NoError(t, err)
+	assert.Equal(t, expected, commitment)
+
+	// the commitment binds the voting powers
+	valSet = valSet.Copy()
+	valSet.Validators[0].VotingPower++
+	other, err := valSet.Commitment()
+	require.NoError(t, err)
+	assert.NotEqual(t, commitment, other)
+
+	// only bn254 keys are supported
+	edValSet, _ := RandValidatorSet(1, 10)
+	_, err = edValSet.Commitment()
+	assert.Error(t, err)
+}
+
+func TestIncrementalCommitment(t *testing.T) {
+	valSet, _ := RandBn254ValidatorSet(6, 10)
+
+	c, err := NewIncrementalCommitment(valSet)
+	require.NoError(t, err)
+	expected, err := valSet.Commitment()
+	require.NoError(t, err)
+	assert.Equal(t, expected, c.Commitment())
+
+	// the lowest power validator changes, then new validators join and
+	// others leave
+	last := valSet.Validators[5].Copy()
+	last.VotingPower = 5
+	first := valSet.Validators[0].Copy()
+	first.VotingPower = 0
+	changes := [][]*Validator{
+		{last},
+		{NewValidator(bn254.GenPrivKey().PubKey(), 50), NewValidator(bn254.GenPrivKey().PubKey(), 7), first},
+	}
+	for _, change := range changes {
+		next := c.ValidatorSet().Copy()
+		require.NoError(t, next.UpdateWithChangeSet(change))
+
+		require.NoError(t, c.ApplyDiff(NewValidatorSetDiff(c.ValidatorSet(), next)))
+		expected, err := next.Commitment()
+		require.NoError(t, err)
+		assert.Equal(t, expected, c.Commitment())
+		assert.Equal(t, next.Hash(CanonicalEncodingProto), c.ValidatorSet().Hash(CanonicalEncodingProto))
+	}
+
+	// a failed diff leaves the commitment unchanged
+	before := c.Commitment()
+	edVal := NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	assert.Error(t, c.ApplyDiff(&ValidatorSetDiff{Updates: []*Validator{edVal}}))
+	assert.Equal(t, before, c.Commitment())
+
+	edValSet, _ := RandValidatorSet(1, 10)
+	_, err = NewIncrementalCommitment(edValSet)
+	assert.Error(t, err)
+}
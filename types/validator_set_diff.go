@@ -0,0 +1,74 @@
+package types
+
+import (
+	"sort"
+)
+
+// ValidatorSetDiff is the difference between two validator sets, i.e. the
+// validators added to or whose voting power changed in the second set, and
+// the validators removed from the first one, both sorted by address.
+//
+// It is what relayers and provers need to prove a transition between two
+// epochs, without shipping the whole validator sets.
+type ValidatorSetDiff struct {
+	Updates  []*Validator `json:"updates"`
+	Removals []*Validator `json:"removals"`
+}
+
+// NewValidatorSetDiff returns the difference between the validator sets from
+// and to. The removals are the validators of from, with their former voting
+// power.
+func NewValidatorSetDiff(from, to *ValidatorSet) *ValidatorSetDiff {
+	fromVals := make(map[string]*Validator, len(from.Validators))
+	for _, val := range from.Validators {
+		fromVals[string(val.Address)] = val
+	}
+
+	diff := &ValidatorSetDiff{
+		Updates:  make([]*Validator, 0),
+		Removals: make([]*Validator, 0),
+	}
+	for _, val := range to.Validators {
+		prev, ok := fromVals[string(val.Address)]
+		if !ok || prev.VotingPower != val.VotingPower {
+			diff.Updates = append(diff.Updates, val.Copy())
+		}
+		delete(fromVals, string(val.Address))
+	}
+	for _, val := range fromVals {
+		diff.Removals = append(diff.Removals, val.Copy())
+	}
+
+	sort.Sort(ValidatorsByAddress(diff.Updates))
+	sort.Sort(ValidatorsByAddress(diff.Removals))
+	return diff
+}
+
+// IsEmpty returns true if the validator sets are the same.
+func (diff *ValidatorSetDiff) IsEmpty() bool {
+	return len(diff.Updates) == 0 && len(diff.Removals) == 0
+}
+
+// ChangeSet returns the diff as the changes expected by UpdateWithChangeSet,
+// i.e. the updates and the removals with a voting power of 0.
+func (diff *ValidatorSetDiff) ChangeSet() []*Validator {
+	changes := make([]*Validator, 0, len(diff.Updates)+len(diff.Removals))
+	for _, val := range diff.Updates {
+		changes = append(changes, val.Copy())
+	}
+	for _, val := range diff.Removals {
+		removal := val.Copy()
+		removal.VotingPower = 0
+		changes = append(changes, removal)
+	}
+	return changes
+}
+
+// ApplyDiff applies the diff to the validator set, with UpdateWithChangeSet.
+//
+// The resulting set has the same validators, voting powers, Hash and
+// Commitment as the set the diff was computed against, but the proposer
+// priorities, which depend on the history of the set, may differ.
+func (vals *ValidatorSet) ApplyDiff(diff *ValidatorSetDiff) error {
+	return vals.UpdateWithChangeSet(diff.ChangeSet())
+}
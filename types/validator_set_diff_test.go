@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+)
+
+func TestValidatorSetDiff(t *testing.T) {
+	from, _ := RandBn254ValidatorSet(5, 10)
+
+	to := from.Copy()
+	added := NewValidator(bn254.GenPrivKey().PubKey(), 30)
+	changed := from.Validators[1].Copy()
+	changed.VotingPower = 25
+	removed := from.Validators[3].Copy()
+	removed.VotingPower = 0
+	require.NoError(t, to.UpdateWithChangeSet([]*Validator{added, changed, removed}))
+
+	diff := NewValidatorSetDiff(from, to)
+	require.Len(t, diff.Updates, 2)
+	require.Len(t, diff.Removals, 1)
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, from.Validators[3].Address, diff.Removals[0].Address)
+	assert.EqualValues(t, 10, diff.Removals[0].VotingPower)
+	for _, val := range diff.Updates {
+		_, expected := to.GetByAddress(val.Address)
+		require.NotNil(t, expected)
+		assert.Equal(t, expected.VotingPower, val.VotingPower)
+	}
+
+	applied := from.Copy()
+	require.NoError(t, applied.ApplyDiff(diff))
+	assert.Equal(t, to.Hash(CanonicalEncodingProto), applied.Hash(CanonicalEncodingProto))
+	assert.Equal(t, to.TotalVotingPower(), applied.TotalVotingPower())
+	require.Equal(t, to.Size(), applied.Size())
+	for i, val := range to.Validators {
+		assert.Equal(t, val.Address, applied.Validators[i].Address)
+	}
+
+	assert.True(t, NewValidatorSetDiff(to, applied).IsEmpty())
+	// applying an empty diff is a no-op
+	require.NoError(t, applied.ApplyDiff(NewValidatorSetDiff(to, applied)))
+	assert.Equal(t, to.Hash(CanonicalEncodingProto), applied.Hash(CanonicalEncodingProto))
+
+	// removing an unknown validator fails
+	unknown := &ValidatorSetDiff{Removals: []*Validator{added}}
+	assert.Error(t, from.Copy().ApplyDiff(unknown))
+}
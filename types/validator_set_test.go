@@ -47,7 +47,7 @@ func TestValidatorSetBasic(t *testing.T) {
 	assert.Nil(t, vset.GetProposer())
 	assert.Equal(t, []byte{0xe3, 0xb0, 0xc4, 0x42, 0x98, 0xfc, 0x1c, 0x14, 0x9a, 0xfb, 0xf4,
 		0xc8, 0x99, 0x6f, 0xb9, 0x24, 0x27, 0xae, 0x41, 0xe4, 0x64, 0x9b, 0x93, 0x4c, 0xa4, 0x95,
-		0x99, 0x1b, 0x78, 0x52, 0xb8, 0x55}, vset.Hash())
+		0x99, 0x1b, 0x78, 0x52, 0xb8, 0x55}, vset.Hash(CanonicalEncodingProto))
 	// add
 	val = randValidator(vset.TotalVotingPower())
 	assert.NoError(t, vset.UpdateWithChangeSet([]*Validator{val}))
@@ -59,7 +59,7 @@ func TestValidatorSetBasic(t *testing.T) {
 	assert.Equal(t, []byte(val.Address), addr)
 	assert.Equal(t, 1, vset.Size())
 	assert.Equal(t, val.VotingPower, vset.TotalVotingPower())
-	assert.NotNil(t, vset.Hash())
+	assert.NotNil(t, vset.Hash(CanonicalEncodingProto))
 	assert.NotPanics(t, func() { vset.IncrementProposerPriority(1) })
 	assert.Equal(t, val.Address, vset.GetProposer().Address)
 
@@ -137,13 +137,13 @@ func TestValidatorSetValidateBasic(t *testing.T) {
 
 func TestCopy(t *testing.T) {
 	vset := randValidatorSet(10)
-	vsetHash := vset.Hash()
+	vsetHash := vset.Hash(CanonicalEncodingProto)
 	if len(vsetHash) == 0 {
 		t.Fatalf("ValidatorSet had unexpected zero hash")
 	}
 
 	vsetCopy := vset.Copy()
-	vsetCopyHash := vsetCopy.Hash()
+	vsetCopyHash := vsetCopy.Hash(CanonicalEncodingProto)
 
 	if !bytes.Equal(vsetHash, vsetCopyHash) {
 		t.Fatalf("ValidatorSet copy had wrong hash. Orig: %X, Copy: %X", vsetHash, vsetCopyHash)
@@ -5,6 +5,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/sr25519"
 )
 
 func TestValidatorProtoBuf(t *testing.T) {
@@ -97,3 +102,37 @@ func TestValidatorValidateBasic(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatorVerifyingPubKey(t *testing.T) {
+	for _, privKey := range []crypto.PrivKey{ed25519.GenPrivKey(), bn254.GenPrivKey()} {
+		msg := []byte("msg")
+		sig, err := privKey.Sign(msg)
+		require.NoError(t, err)
+
+		val := NewValidator(privKey.PubKey(), 10)
+		verifying := val.VerifyingPubKey()
+		assert.NotEqual(t, privKey.PubKey(), verifying, privKey.Type())
+		assert.True(t, verifying.Equals(val.PubKey))
+		assert.True(t, verifying.VerifySignature(msg, sig))
+
+		// the copies share the decoded key
+		assert.Same(t, verifying, val.Copy().VerifyingPubKey())
+		protoVal, err := val.ToProto()
+		require.NoError(t, err)
+		fromProto, err := ValidatorFromProto(protoVal)
+		require.NoError(t, err)
+		assert.True(t, fromProto.VerifyingPubKey().VerifySignature(msg, sig))
+
+		// it is dropped when the key changes
+		other := val.Copy()
+		other.PubKey = ed25519.GenPrivKey().PubKey()
+		assert.Equal(t, other.PubKey, other.VerifyingPubKey())
+		assert.False(t, other.VerifyingPubKey().VerifySignature(msg, sig))
+	}
+
+	// the keys which can't be decoded are returned as is
+	pubKey := sr25519.GenPrivKey().PubKey()
+	assert.Equal(t, pubKey, NewValidator(pubKey, 10).VerifyingPubKey())
+	val := &Validator{PubKey: ed25519.GenPrivKey().PubKey()}
+	assert.Equal(t, val.PubKey, val.VerifyingPubKey())
+}
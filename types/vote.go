@@ -89,8 +89,14 @@ func (vote *Vote) CommitSig() CommitSig {
 // for backwards-compatibility with the Amino encoding, due to e.g. hardware
 // devices that rely on this encoding.
 //
+// With the fixed-width canonical encoding, it returns FixedWidthVoteSignBytes
+// instead.
+//
 // See CanonicalizeVote
-func VoteSignBytes(chainID string, vote *cmtproto.Vote) []byte {
+func VoteSignBytes(chainID string, encoding CanonicalEncoding, vote *cmtproto.Vote) []byte {
+	if encoding == CanonicalEncodingFixedWidth {
+		return FixedWidthVoteSignBytes(chainID, vote)
+	}
 	pb := CanonicalizeVote(chainID, vote)
 	bz, err := protoio.MarshalDelimited(&pb)
 	if err != nil {
@@ -144,12 +150,12 @@ func (vote *Vote) String() string {
 	)
 }
 
-func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
+func (vote *Vote) Verify(chainID string, encoding CanonicalEncoding, pubKey crypto.PubKey) error {
 	if !bytes.Equal(pubKey.Address(), vote.ValidatorAddress) {
 		return ErrVoteInvalidValidatorAddress
 	}
 	v := vote.ToProto()
-	if !pubKey.VerifySignature(VoteSignBytes(chainID, v), vote.Signature) {
+	if !pubKey.VerifySignature(VoteSignBytes(chainID, encoding, v), vote.Signature) {
 		return ErrVoteInvalidSignature
 	}
 	return nil
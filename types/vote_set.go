@@ -60,6 +60,7 @@ NOTE: Assumes that the sum total of voting power does not exceed MaxUInt64.
 */
 type VoteSet struct {
 	chainID       string
+	encoding      CanonicalEncoding
 	height        int64
 	round         int32
 	signedMsgType cmtproto.SignedMsgType
@@ -74,14 +75,16 @@ type VoteSet struct {
 	peerMaj23s    map[P2PID]BlockID      // Maj23 for each peer
 }
 
-// Constructs a new VoteSet struct used to accumulate votes for given height/round.
-func NewVoteSet(chainID string, height int64, round int32,
+// Constructs a new VoteSet struct used to accumulate votes for given height/round,
+// whose signatures are verified with the given canonical encoding.
+func NewVoteSet(chainID string, encoding CanonicalEncoding, height int64, round int32,
 	signedMsgType cmtproto.SignedMsgType, valSet *ValidatorSet) *VoteSet {
 	if height == 0 {
 		panic("Cannot make VoteSet for height == 0, doesn't make sense.")
 	}
 	return &VoteSet{
 		chainID:       chainID,
+		encoding:      encoding,
 		height:        height,
 		round:         round,
 		signedMsgType: signedMsgType,
@@ -99,6 +102,11 @@ func (voteSet *VoteSet) ChainID() string {
 	return voteSet.chainID
 }
 
+// Encoding returns the canonical encoding of the votes.
+func (voteSet *VoteSet) Encoding() CanonicalEncoding {
+	return voteSet.encoding
+}
+
 // Implements VoteSetReader.
 func (voteSet *VoteSet) GetHeight() int64 {
 	if voteSet == nil {
@@ -202,7 +210,7 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	}
 
 	// Check signature.
-	if err := vote.Verify(voteSet.chainID, val.PubKey); err != nil {
+	if err := vote.Verify(voteSet.chainID, voteSet.encoding, val.VerifyingPubKey()); err != nil {
 		return false, fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
 	}
 
@@ -49,7 +49,7 @@ func exampleVote(t byte) *Vote {
 func TestVoteSignable(t *testing.T) {
 	vote := examplePrecommit()
 	v := vote.ToProto()
-	signBytes := VoteSignBytes("test_chain_id", v)
+	signBytes := VoteSignBytes("test_chain_id", CanonicalEncodingProto, v)
 	pb := CanonicalizeVote("test_chain_id", v)
 	expected, err := protoio.MarshalDelimited(&pb)
 	require.NoError(t, err)
@@ -130,7 +130,7 @@ func TestVoteSignBytesTestVectors(t *testing.T) {
 	}
 	for i, tc := range tests {
 		v := tc.vote.ToProto()
-		got := VoteSignBytes(tc.chainID, v)
+		got := VoteSignBytes(tc.chainID, CanonicalEncodingProto, v)
 		assert.Equal(t, len(tc.want), len(got), "test case #%v: got unexpected sign bytes length for Vote.", i)
 		assert.Equal(t, tc.want, got, "test case #%v: got unexpected sign bytes for Vote.", i)
 	}
@@ -153,14 +153,14 @@ func TestVoteVerifySignature(t *testing.T) {
 
 	vote := examplePrecommit()
 	v := vote.ToProto()
-	signBytes := VoteSignBytes("test_chain_id", v)
+	signBytes := VoteSignBytes("test_chain_id", CanonicalEncodingProto, v)
 
 	// sign it
-	err = privVal.SignVote("test_chain_id", v)
+	err = privVal.SignVote("test_chain_id", CanonicalEncodingProto, v)
 	require.NoError(t, err)
 
 	// verify the same vote
-	valid := pubkey.VerifySignature(VoteSignBytes("test_chain_id", v), v.Signature)
+	valid := pubkey.VerifySignature(VoteSignBytes("test_chain_id", CanonicalEncodingProto, v), v.Signature)
 	require.True(t, valid)
 
 	// serialize, deserialize and verify again....
@@ -171,7 +171,7 @@ func TestVoteVerifySignature(t *testing.T) {
 	require.NoError(t, err)
 
 	// verify the transmitted vote
-	newSignBytes := VoteSignBytes("test_chain_id", precommit)
+	newSignBytes := VoteSignBytes("test_chain_id", CanonicalEncodingProto, precommit)
 	require.Equal(t, string(signBytes), string(newSignBytes))
 	valid = pubkey.VerifySignature(newSignBytes, precommit.Signature)
 	require.True(t, valid)
@@ -206,12 +206,12 @@ func TestVoteVerify(t *testing.T) {
 	vote := examplePrevote()
 	vote.ValidatorAddress = pubkey.Address()
 
-	err = vote.Verify("test_chain_id", ed25519.GenPrivKey().PubKey())
+	err = vote.Verify("test_chain_id", CanonicalEncodingProto, ed25519.GenPrivKey().PubKey())
 	if assert.Error(t, err) {
 		assert.Equal(t, ErrVoteInvalidValidatorAddress, err)
 	}
 
-	err = vote.Verify("test_chain_id", pubkey)
+	err = vote.Verify("test_chain_id", CanonicalEncodingProto, pubkey)
 	if assert.Error(t, err) {
 		assert.Equal(t, ErrVoteInvalidSignature, err)
 	}
@@ -255,7 +255,7 @@ func TestVoteValidateBasic(t *testing.T) {
 		t.Run(tc.testName, func(t *testing.T) {
 			vote := examplePrecommit()
 			v := vote.ToProto()
-			err := privVal.SignVote("test_chain_id", v)
+			err := privVal.SignVote("test_chain_id", CanonicalEncodingProto, v)
 			vote.Signature = v.Signature
 			require.NoError(t, err)
 			tc.malleateVote(vote)
@@ -268,7 +268,7 @@ func TestVoteProtobuf(t *testing.T) {
 	privVal := NewMockPV()
 	vote := examplePrecommit()
 	v := vote.ToProto()
-	err := privVal.SignVote("test_chain_id", v)
+	err := privVal.SignVote("test_chain_id", CanonicalEncodingProto, v)
 	vote.Signature = v.Signature
 	require.NoError(t, err)
 